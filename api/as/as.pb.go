@@ -56,12 +56,15 @@ func (RXWindow) EnumDescriptor() ([]byte, []int) {
 type ErrorType int32
 
 const (
-	ErrorType_GENERIC                ErrorType = 0
-	ErrorType_OTAA                   ErrorType = 1
-	ErrorType_DATA_UP_FCNT           ErrorType = 2
-	ErrorType_DATA_UP_MIC            ErrorType = 3
-	ErrorType_DEVICE_QUEUE_ITEM_SIZE ErrorType = 4
-	ErrorType_DEVICE_QUEUE_ITEM_FCNT ErrorType = 5
+	ErrorType_GENERIC                    ErrorType = 0
+	ErrorType_OTAA                       ErrorType = 1
+	ErrorType_DATA_UP_FCNT               ErrorType = 2
+	ErrorType_DATA_UP_MIC                ErrorType = 3
+	ErrorType_DEVICE_QUEUE_ITEM_SIZE     ErrorType = 4
+	ErrorType_DEVICE_QUEUE_ITEM_FCNT     ErrorType = 5
+	ErrorType_DEVICE_QUEUE_ITEM_EXPIRED  ErrorType = 6
+	ErrorType_DEVICE_QUEUE_ITEM_OVERFLOW ErrorType = 7
+	ErrorType_DEVICE_QUEUE_ITEM_VETOED   ErrorType = 8
 )
 
 var ErrorType_name = map[int32]string{
@@ -71,15 +74,21 @@ var ErrorType_name = map[int32]string{
 	3: "DATA_UP_MIC",
 	4: "DEVICE_QUEUE_ITEM_SIZE",
 	5: "DEVICE_QUEUE_ITEM_FCNT",
+	6: "DEVICE_QUEUE_ITEM_EXPIRED",
+	7: "DEVICE_QUEUE_ITEM_OVERFLOW",
+	8: "DEVICE_QUEUE_ITEM_VETOED",
 }
 
 var ErrorType_value = map[string]int32{
-	"GENERIC":                0,
-	"OTAA":                   1,
-	"DATA_UP_FCNT":           2,
-	"DATA_UP_MIC":            3,
-	"DEVICE_QUEUE_ITEM_SIZE": 4,
-	"DEVICE_QUEUE_ITEM_FCNT": 5,
+	"GENERIC":                    0,
+	"OTAA":                       1,
+	"DATA_UP_FCNT":               2,
+	"DATA_UP_MIC":                3,
+	"DEVICE_QUEUE_ITEM_SIZE":     4,
+	"DEVICE_QUEUE_ITEM_FCNT":     5,
+	"DEVICE_QUEUE_ITEM_EXPIRED":  6,
+	"DEVICE_QUEUE_ITEM_OVERFLOW": 7,
+	"DEVICE_QUEUE_ITEM_VETOED":   8,
 }
 
 func (x ErrorType) String() string {
@@ -90,6 +99,33 @@ func (ErrorType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_426943aecdb4a493, []int{1}
 }
 
+type UplinkAttestationAlgorithm int32
+
+const (
+	// HMAC-SHA256 (shared key).
+	UplinkAttestationAlgorithm_HMAC_SHA256 UplinkAttestationAlgorithm = 0
+	// Ed25519 (network-server public key).
+	UplinkAttestationAlgorithm_ED25519 UplinkAttestationAlgorithm = 1
+)
+
+var UplinkAttestationAlgorithm_name = map[int32]string{
+	0: "HMAC_SHA256",
+	1: "ED25519",
+}
+
+var UplinkAttestationAlgorithm_value = map[string]int32{
+	"HMAC_SHA256": 0,
+	"ED25519":     1,
+}
+
+func (x UplinkAttestationAlgorithm) String() string {
+	return proto.EnumName(UplinkAttestationAlgorithm_name, int32(x))
+}
+
+func (UplinkAttestationAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{2}
+}
+
 type DeviceActivationContext struct {
 	// Assigned Device Address.
 	DevAddr []byte `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
@@ -163,9 +199,14 @@ type HandleUplinkDataRequest struct {
 	// This field is only set on the first uplink frame when the security
 	// context has changed (e.g. a new OTAA (re)activation).
 	DeviceActivationContext *DeviceActivationContext `protobuf:"bytes,10,opt,name=device_activation_context,json=deviceActivationContext,proto3" json:"device_activation_context,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}                 `json:"-"`
-	XXX_unrecognized        []byte                   `json:"-"`
-	XXX_sizecache           int32                    `json:"-"`
+	// Uplink attestation.
+	//
+	// This field is only set when uplink attestation has been configured
+	// on the network-server.
+	Attestation          *UplinkAttestation `protobuf:"bytes,11,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *HandleUplinkDataRequest) Reset()         { *m = HandleUplinkDataRequest{} }
@@ -263,6 +304,206 @@ func (m *HandleUplinkDataRequest) GetDeviceActivationContext() *DeviceActivation
 	return nil
 }
 
+func (m *HandleUplinkDataRequest) GetAttestation() *UplinkAttestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+type UplinkAttestationPayload struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Frame-counter.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// PHYPayload as received by the gateway(s).
+	PhyPayload []byte `protobuf:"bytes,3,opt,name=phy_payload,json=phyPayload,proto3" json:"phy_payload,omitempty"`
+	// TX meta-data.
+	TxInfo *gw.UplinkTXInfo `protobuf:"bytes,4,opt,name=tx_info,json=txInfo,proto3" json:"tx_info,omitempty"`
+	// RX meta-data.
+	RxInfo []*gw.UplinkRXInfo `protobuf:"bytes,5,rep,name=rx_info,json=rxInfo,proto3" json:"rx_info,omitempty"`
+	// Timestamp at which the network-server handled the uplink.
+	NsTime               *timestamp.Timestamp `protobuf:"bytes,6,opt,name=ns_time,json=nsTime,proto3" json:"ns_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UplinkAttestationPayload) Reset()         { *m = UplinkAttestationPayload{} }
+func (m *UplinkAttestationPayload) String() string { return proto.CompactTextString(m) }
+func (*UplinkAttestationPayload) ProtoMessage()    {}
+func (*UplinkAttestationPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{2}
+}
+
+func (m *UplinkAttestationPayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UplinkAttestationPayload.Unmarshal(m, b)
+}
+func (m *UplinkAttestationPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UplinkAttestationPayload.Marshal(b, m, deterministic)
+}
+func (m *UplinkAttestationPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UplinkAttestationPayload.Merge(m, src)
+}
+func (m *UplinkAttestationPayload) XXX_Size() int {
+	return xxx_messageInfo_UplinkAttestationPayload.Size(m)
+}
+func (m *UplinkAttestationPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_UplinkAttestationPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UplinkAttestationPayload proto.InternalMessageInfo
+
+func (m *UplinkAttestationPayload) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *UplinkAttestationPayload) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *UplinkAttestationPayload) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
+func (m *UplinkAttestationPayload) GetTxInfo() *gw.UplinkTXInfo {
+	if m != nil {
+		return m.TxInfo
+	}
+	return nil
+}
+
+func (m *UplinkAttestationPayload) GetRxInfo() []*gw.UplinkRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+func (m *UplinkAttestationPayload) GetNsTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.NsTime
+	}
+	return nil
+}
+
+type UplinkAttestation struct {
+	// Encoded UplinkAttestationPayload message.
+	// The signature is calculated over these exact bytes.
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Signature algorithm.
+	Algorithm UplinkAttestationAlgorithm `protobuf:"varint,2,opt,name=algorithm,proto3,enum=as.UplinkAttestationAlgorithm" json:"algorithm,omitempty"`
+	// ID of the network-server key used for the signature.
+	KeyId string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Signature.
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UplinkAttestation) Reset()         { *m = UplinkAttestation{} }
+func (m *UplinkAttestation) String() string { return proto.CompactTextString(m) }
+func (*UplinkAttestation) ProtoMessage()    {}
+func (*UplinkAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{3}
+}
+
+func (m *UplinkAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UplinkAttestation.Unmarshal(m, b)
+}
+func (m *UplinkAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UplinkAttestation.Marshal(b, m, deterministic)
+}
+func (m *UplinkAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UplinkAttestation.Merge(m, src)
+}
+func (m *UplinkAttestation) XXX_Size() int {
+	return xxx_messageInfo_UplinkAttestation.Size(m)
+}
+func (m *UplinkAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_UplinkAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UplinkAttestation proto.InternalMessageInfo
+
+func (m *UplinkAttestation) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *UplinkAttestation) GetAlgorithm() UplinkAttestationAlgorithm {
+	if m != nil {
+		return m.Algorithm
+	}
+	return UplinkAttestationAlgorithm_HMAC_SHA256
+}
+
+func (m *UplinkAttestation) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *UplinkAttestation) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type HandleUplinkDataBatchRequest struct {
+	// Uplink data items.
+	Items                []*HandleUplinkDataRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *HandleUplinkDataBatchRequest) Reset()         { *m = HandleUplinkDataBatchRequest{} }
+func (m *HandleUplinkDataBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleUplinkDataBatchRequest) ProtoMessage()    {}
+func (*HandleUplinkDataBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_426943aecdb4a493, []int{4}
+}
+
+func (m *HandleUplinkDataBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleUplinkDataBatchRequest.Unmarshal(m, b)
+}
+func (m *HandleUplinkDataBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleUplinkDataBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleUplinkDataBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleUplinkDataBatchRequest.Merge(m, src)
+}
+func (m *HandleUplinkDataBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleUplinkDataBatchRequest.Size(m)
+}
+func (m *HandleUplinkDataBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleUplinkDataBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleUplinkDataBatchRequest proto.InternalMessageInfo
+
+func (m *HandleUplinkDataBatchRequest) GetItems() []*HandleUplinkDataRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
 type HandleProprietaryUplinkRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
@@ -281,7 +522,7 @@ func (m *HandleProprietaryUplinkRequest) Reset()         { *m = HandleProprietar
 func (m *HandleProprietaryUplinkRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleProprietaryUplinkRequest) ProtoMessage()    {}
 func (*HandleProprietaryUplinkRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{2}
+	return fileDescriptor_426943aecdb4a493, []int{5}
 }
 
 func (m *HandleProprietaryUplinkRequest) XXX_Unmarshal(b []byte) error {
@@ -348,7 +589,7 @@ func (m *HandleErrorRequest) Reset()         { *m = HandleErrorRequest{} }
 func (m *HandleErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleErrorRequest) ProtoMessage()    {}
 func (*HandleErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{3}
+	return fileDescriptor_426943aecdb4a493, []int{6}
 }
 
 func (m *HandleErrorRequest) XXX_Unmarshal(b []byte) error {
@@ -403,17 +644,25 @@ type HandleDownlinkACKRequest struct {
 	// Downlink frame-counter.
 	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
 	// Frame was acknowledged?
-	Acknowledged         bool     `protobuf:"varint,3,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Acknowledged bool `protobuf:"varint,3,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	// Number of transmissions of the frame.
+	TxCount uint32 `protobuf:"varint,4,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	// Timestamp on which the frame was enqueued.
+	EnqueuedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=enqueued_at,json=enqueuedAt,proto3" json:"enqueued_at,omitempty"`
+	// Timestamp of the (last) transmission of the frame.
+	TransmittedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=transmitted_at,json=transmittedAt,proto3" json:"transmitted_at,omitempty"`
+	// Timestamp on which the acknowledgement was received.
+	AcknowledgedAt       *timestamp.Timestamp `protobuf:"bytes,7,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *HandleDownlinkACKRequest) Reset()         { *m = HandleDownlinkACKRequest{} }
 func (m *HandleDownlinkACKRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleDownlinkACKRequest) ProtoMessage()    {}
 func (*HandleDownlinkACKRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{4}
+	return fileDescriptor_426943aecdb4a493, []int{7}
 }
 
 func (m *HandleDownlinkACKRequest) XXX_Unmarshal(b []byte) error {
@@ -455,6 +704,34 @@ func (m *HandleDownlinkACKRequest) GetAcknowledged() bool {
 	return false
 }
 
+func (m *HandleDownlinkACKRequest) GetTxCount() uint32 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *HandleDownlinkACKRequest) GetEnqueuedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EnqueuedAt
+	}
+	return nil
+}
+
+func (m *HandleDownlinkACKRequest) GetTransmittedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.TransmittedAt
+	}
+	return nil
+}
+
+func (m *HandleDownlinkACKRequest) GetAcknowledgedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.AcknowledgedAt
+	}
+	return nil
+}
+
 type SetDeviceStatusRequest struct {
 	// Device EUI (8 bytes).
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -481,7 +758,7 @@ func (m *SetDeviceStatusRequest) Reset()         { *m = SetDeviceStatusRequest{}
 func (m *SetDeviceStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceStatusRequest) ProtoMessage()    {}
 func (*SetDeviceStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{5}
+	return fileDescriptor_426943aecdb4a493, []int{8}
 }
 
 func (m *SetDeviceStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -558,7 +835,7 @@ func (m *SetDeviceLocationRequest) Reset()         { *m = SetDeviceLocationReque
 func (m *SetDeviceLocationRequest) String() string { return proto.CompactTextString(m) }
 func (*SetDeviceLocationRequest) ProtoMessage()    {}
 func (*SetDeviceLocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{6}
+	return fileDescriptor_426943aecdb4a493, []int{9}
 }
 
 func (m *SetDeviceLocationRequest) XXX_Unmarshal(b []byte) error {
@@ -619,7 +896,7 @@ func (m *HandleGatewayStatsRequest) Reset()         { *m = HandleGatewayStatsReq
 func (m *HandleGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleGatewayStatsRequest) ProtoMessage()    {}
 func (*HandleGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_426943aecdb4a493, []int{7}
+	return fileDescriptor_426943aecdb4a493, []int{10}
 }
 
 func (m *HandleGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
@@ -699,8 +976,12 @@ func (m *HandleGatewayStatsRequest) GetTxPacketsEmitted() uint32 {
 func init() {
 	proto.RegisterEnum("as.RXWindow", RXWindow_name, RXWindow_value)
 	proto.RegisterEnum("as.ErrorType", ErrorType_name, ErrorType_value)
+	proto.RegisterEnum("as.UplinkAttestationAlgorithm", UplinkAttestationAlgorithm_name, UplinkAttestationAlgorithm_value)
 	proto.RegisterType((*DeviceActivationContext)(nil), "as.DeviceActivationContext")
 	proto.RegisterType((*HandleUplinkDataRequest)(nil), "as.HandleUplinkDataRequest")
+	proto.RegisterType((*UplinkAttestationPayload)(nil), "as.UplinkAttestationPayload")
+	proto.RegisterType((*UplinkAttestation)(nil), "as.UplinkAttestation")
+	proto.RegisterType((*HandleUplinkDataBatchRequest)(nil), "as.HandleUplinkDataBatchRequest")
 	proto.RegisterType((*HandleProprietaryUplinkRequest)(nil), "as.HandleProprietaryUplinkRequest")
 	proto.RegisterType((*HandleErrorRequest)(nil), "as.HandleErrorRequest")
 	proto.RegisterType((*HandleDownlinkACKRequest)(nil), "as.HandleDownlinkACKRequest")
@@ -712,72 +993,93 @@ func init() {
 func init() { proto.RegisterFile("as.proto", fileDescriptor_426943aecdb4a493) }
 
 var fileDescriptor_426943aecdb4a493 = []byte{
-	// 1036 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5d, 0x6f, 0x23, 0x35,
-	0x14, 0x6d, 0xbe, 0xd3, 0xdb, 0x76, 0x3b, 0x75, 0xd9, 0x64, 0x1a, 0x76, 0xa1, 0x84, 0x97, 0xb2,
-	0x5a, 0xa5, 0xa2, 0xfb, 0xc6, 0x0b, 0x8a, 0xd2, 0xa1, 0x44, 0xdd, 0xee, 0x86, 0x49, 0x4a, 0x2b,
-	0x5e, 0x2c, 0x77, 0xe6, 0x26, 0x1a, 0x32, 0x19, 0x0f, 0x8e, 0xf3, 0x25, 0xc4, 0x0f, 0xe2, 0x91,
-	0x3f, 0xc4, 0xcf, 0x40, 0x3c, 0x22, 0x7b, 0x9c, 0x8f, 0x6e, 0xd2, 0x94, 0x97, 0xc4, 0xbe, 0xe7,
-	0xcc, 0xbd, 0xd7, 0xc7, 0xf6, 0x31, 0x14, 0xd9, 0xb0, 0x16, 0x0b, 0x2e, 0x39, 0x49, 0xb3, 0x61,
-	0xe5, 0x08, 0x07, 0xb1, 0x9c, 0x9d, 0xeb, 0xdf, 0x24, 0x5c, 0x39, 0x91, 0xc1, 0x00, 0x87, 0x92,
-	0x0d, 0xe2, 0xf3, 0xc5, 0xc8, 0x40, 0x65, 0x16, 0x07, 0xe7, 0x1e, 0x1f, 0x0c, 0x78, 0x64, 0xfe,
-	0x0c, 0x70, 0xa8, 0x80, 0xde, 0xe4, 0xbc, 0x37, 0x49, 0x02, 0x55, 0x84, 0xf2, 0x25, 0x8e, 0x03,
-	0x0f, 0xeb, 0x9e, 0x0c, 0xc6, 0x4c, 0x06, 0x3c, 0x6a, 0xf0, 0x48, 0xe2, 0x54, 0x92, 0x13, 0x28,
-	0xfa, 0x38, 0xa6, 0xcc, 0xf7, 0x85, 0x9d, 0x3a, 0x4d, 0x9d, 0xed, 0xbb, 0x05, 0x1f, 0xc7, 0x75,
-	0xdf, 0x17, 0xe4, 0x1c, 0x76, 0x59, 0x1c, 0xd3, 0x21, 0xed, 0xe3, 0xcc, 0x4e, 0x9f, 0xa6, 0xce,
-	0xf6, 0x2e, 0x8e, 0x6b, 0xa6, 0xd0, 0x35, 0xce, 0x9c, 0x68, 0x8c, 0x21, 0x8f, 0xd1, 0x2d, 0xb0,
-	0x38, 0x6e, 0x5f, 0xe3, 0xac, 0xfa, 0x77, 0x1a, 0xca, 0x3f, 0xb2, 0xc8, 0x0f, 0xf1, 0x36, 0x0e,
-	0x83, 0xa8, 0x7f, 0xc9, 0x24, 0x73, 0xf1, 0xb7, 0x11, 0x0e, 0x25, 0x29, 0x83, 0xca, 0x4b, 0x71,
-	0x14, 0x98, 0x32, 0x79, 0x1f, 0xc7, 0xce, 0x28, 0x50, 0x0d, 0xfc, 0xca, 0x83, 0x48, 0x23, 0xe9,
-	0xa4, 0x01, 0x35, 0x57, 0xd0, 0x31, 0xe4, 0xba, 0xd4, 0x8b, 0xa4, 0x9d, 0x39, 0x4d, 0x9d, 0x1d,
-	0xb8, 0xd9, 0x6e, 0x23, 0x92, 0xe4, 0x25, 0xe4, 0xbb, 0x34, 0xe6, 0x42, 0xda, 0x59, 0x1d, 0xcd,
-	0x75, 0x5b, 0x5c, 0x48, 0x62, 0x41, 0x86, 0xf9, 0xc2, 0xce, 0x9d, 0xa6, 0xce, 0x8a, 0xae, 0x1a,
-	0x92, 0x17, 0x90, 0xf6, 0x85, 0x9d, 0xd7, 0xa4, 0xb4, 0x2f, 0xc8, 0x37, 0x50, 0x90, 0x53, 0x1a,
-	0x44, 0x5d, 0x6e, 0x17, 0xf4, 0x62, 0xac, 0x5a, 0x6f, 0x52, 0x4b, 0x3a, 0xed, 0xdc, 0x37, 0xa3,
-	0x2e, 0x77, 0xf3, 0x72, 0xaa, 0xfe, 0x15, 0x55, 0x18, 0x6a, 0xf1, 0x34, 0xf3, 0x98, 0xea, 0x1a,
-	0xaa, 0x48, 0xa8, 0x04, 0xb2, 0x3e, 0x93, 0xcc, 0xde, 0xd5, 0xad, 0xeb, 0x31, 0xb9, 0x83, 0x13,
-	0x5f, 0xcb, 0x4d, 0xd9, 0x42, 0x6f, 0xea, 0x25, 0x82, 0xdb, 0xa0, 0x6b, 0x7f, 0x5e, 0x63, 0xc3,
-	0xda, 0x13, 0x7b, 0xe2, 0x96, 0xfd, 0xcd, 0x40, 0xf5, 0xcf, 0x14, 0x7c, 0x91, 0x08, 0xdc, 0x12,
-	0x3c, 0x16, 0x01, 0x4a, 0x26, 0x66, 0xa6, 0x2d, 0xa3, 0xf3, 0x97, 0xb0, 0x37, 0x60, 0x1e, 0x8d,
-	0xd9, 0x2c, 0xe4, 0xcc, 0x37, 0x5a, 0xc3, 0x80, 0x79, 0xad, 0x24, 0xa2, 0x84, 0x1a, 0x04, 0x9e,
-	0x91, 0x5a, 0x0d, 0x57, 0x85, 0xc9, 0xfc, 0x7f, 0x61, 0xb2, 0xdb, 0x85, 0xa9, 0xfe, 0x0e, 0x24,
-	0x69, 0xd5, 0x11, 0x82, 0x8b, 0x67, 0x8f, 0xc1, 0x57, 0x90, 0x95, 0xb3, 0x18, 0x75, 0x07, 0x2f,
-	0x2e, 0x0e, 0x94, 0x3c, 0xfa, 0xc3, 0xce, 0x2c, 0x46, 0x57, 0x43, 0xe4, 0x33, 0xc8, 0xa1, 0x0a,
-	0xe9, 0x8d, 0xdf, 0x75, 0x93, 0xc9, 0xf2, 0x90, 0xe4, 0x96, 0x87, 0xa4, 0x1a, 0x82, 0x9d, 0x14,
-	0xbf, 0xe4, 0x93, 0x48, 0x35, 0x57, 0x6f, 0x5c, 0x3f, 0xdb, 0xc2, 0x22, 0x53, 0x7a, 0xe5, 0xb8,
-	0x55, 0x61, 0x9f, 0x79, 0xfd, 0x88, 0x4f, 0x42, 0xf4, 0x7b, 0xe8, 0xeb, 0xfe, 0x8a, 0xee, 0xa3,
-	0x58, 0xf5, 0xdf, 0x14, 0x94, 0xda, 0x28, 0x93, 0xed, 0x6c, 0x4b, 0x26, 0x47, 0xc3, 0x67, 0x8b,
-	0xd9, 0x50, 0x78, 0x60, 0x52, 0xa2, 0x98, 0x99, 0x72, 0xf3, 0x29, 0x29, 0x41, 0x7e, 0xc0, 0x44,
-	0x2f, 0x88, 0x74, 0xad, 0x9c, 0x6b, 0x66, 0xe4, 0x02, 0x5e, 0xe2, 0x54, 0xa2, 0x88, 0x58, 0x48,
-	0x63, 0x3e, 0x41, 0x41, 0x87, 0x7c, 0x24, 0x3c, 0xd4, 0x72, 0x14, 0xdd, 0xe3, 0x39, 0xd8, 0x52,
-	0x58, 0x5b, 0x43, 0xe4, 0x3b, 0x38, 0x31, 0x69, 0x69, 0x88, 0x63, 0x0c, 0xe9, 0x28, 0x62, 0x63,
-	0x16, 0x84, 0xec, 0x21, 0x44, 0x73, 0x57, 0xca, 0x86, 0xf0, 0x5e, 0xe1, 0xb7, 0x4b, 0x98, 0x7c,
-	0x0d, 0x07, 0x8f, 0xbe, 0xd5, 0x57, 0x29, 0xed, 0xee, 0xaf, 0xf2, 0xab, 0x0c, 0xec, 0xc5, 0xca,
-	0xdf, 0x73, 0x4f, 0x9f, 0xd6, 0x67, 0xd7, 0xfe, 0x16, 0x8a, 0xa1, 0xe1, 0x1a, 0x5f, 0xb1, 0xe6,
-	0xbe, 0xb2, 0xc8, 0xb1, 0x60, 0x54, 0xff, 0x49, 0xc3, 0x49, 0xb2, 0x99, 0x57, 0x4c, 0xe2, 0x84,
-	0xcd, 0x94, 0xc2, 0x0b, 0x81, 0x5f, 0x03, 0xf4, 0x92, 0x30, 0x0d, 0xe6, 0xc7, 0x7d, 0xd7, 0x44,
-	0x9a, 0xbe, 0x72, 0x97, 0xa1, 0xa2, 0x2b, 0xd0, 0xb8, 0x8b, 0x9e, 0x37, 0x7d, 0x52, 0x83, 0xac,
-	0x72, 0x54, 0x73, 0xe6, 0x2b, 0xb5, 0x1e, 0xe7, 0xbd, 0x10, 0x13, 0xc7, 0x7c, 0x18, 0x75, 0x6b,
-	0x9d, 0xb9, 0xdd, 0xba, 0x9a, 0xf7, 0xa8, 0xeb, 0xec, 0x73, 0x5d, 0x93, 0x1a, 0x1c, 0x8b, 0x29,
-	0x8d, 0x99, 0xd7, 0x47, 0x39, 0xa4, 0x02, 0x3d, 0x0c, 0xc6, 0xe8, 0x9b, 0x43, 0x7a, 0x24, 0xa6,
-	0xad, 0x04, 0x71, 0x0d, 0x40, 0xde, 0x41, 0x69, 0x03, 0x9f, 0xf2, 0xbe, 0x71, 0xb0, 0xe3, 0xb5,
-	0x4f, 0x3e, 0xf6, 0x55, 0x11, 0xb9, 0xa1, 0x48, 0x21, 0x29, 0x22, 0xd7, 0x8a, 0xbc, 0x05, 0xb2,
-	0xc2, 0xc7, 0x41, 0x20, 0x25, 0xfa, 0x76, 0x51, 0xd3, 0xad, 0x05, 0xdd, 0x49, 0xe2, 0x6f, 0x5e,
-	0x41, 0xd1, 0xbd, 0xbf, 0x0b, 0x22, 0x9f, 0x4f, 0x48, 0x01, 0x32, 0xee, 0xfd, 0xb7, 0xd6, 0x4e,
-	0x32, 0xb8, 0xb0, 0x52, 0x6f, 0xfe, 0x80, 0xdd, 0xc5, 0x05, 0x25, 0x7b, 0x50, 0xb8, 0x72, 0x3e,
-	0x38, 0x6e, 0xb3, 0x61, 0xed, 0x90, 0x22, 0x64, 0x3f, 0x76, 0xea, 0x75, 0x2b, 0x45, 0x2c, 0xd8,
-	0xbf, 0xac, 0x77, 0xea, 0xf4, 0xb6, 0x45, 0x7f, 0x68, 0x7c, 0xe8, 0x58, 0x69, 0x72, 0x08, 0x7b,
-	0xf3, 0xc8, 0x4d, 0xb3, 0x61, 0x65, 0x48, 0x05, 0x4a, 0x97, 0xce, 0xcf, 0xcd, 0x86, 0x43, 0x7f,
-	0xba, 0x75, 0x6e, 0x1d, 0xda, 0xec, 0x38, 0x37, 0xb4, 0xdd, 0xfc, 0xc5, 0xb1, 0xb2, 0x9b, 0x31,
-	0x9d, 0x28, 0x77, 0xf1, 0x57, 0x16, 0xec, 0x7a, 0x1c, 0x87, 0x41, 0xa2, 0x77, 0x1b, 0xc5, 0x18,
-	0x85, 0xfa, 0x0d, 0x3c, 0x24, 0x4d, 0xb0, 0x3e, 0x7d, 0x87, 0x88, 0x76, 0xdc, 0x27, 0x5e, 0xa7,
-	0x4a, 0x69, 0x6d, 0xf7, 0x1d, 0xf5, 0x06, 0x57, 0x77, 0xc8, 0xdd, 0xfc, 0x49, 0x5b, 0x73, 0x5c,
-	0x52, 0x5d, 0x66, 0x7c, 0xca, 0x8e, 0xb7, 0x24, 0xfe, 0x1e, 0xf6, 0x56, 0xfc, 0x91, 0x94, 0x96,
-	0xc9, 0x56, 0x0d, 0x73, 0x4b, 0x82, 0x6b, 0x38, 0x5a, 0xf3, 0x38, 0xf2, 0x6a, 0x99, 0x66, 0xdd,
-	0xfa, 0xb6, 0x24, 0xbb, 0x99, 0xbb, 0xf5, 0xea, 0x1d, 0x23, 0xaf, 0x97, 0xd9, 0x36, 0xdc, 0xbd,
-	0x2d, 0xe9, 0xae, 0xe0, 0xf0, 0x13, 0x43, 0x24, 0x15, 0x95, 0x6b, 0xb3, 0x4b, 0x6e, 0x5f, 0xe4,
-	0x9a, 0xbf, 0x24, 0x8b, 0x7c, 0xca, 0x76, 0x9e, 0x4e, 0xf6, 0x90, 0xd7, 0x91, 0x77, 0xff, 0x05,
-	0x00, 0x00, 0xff, 0xff, 0xc7, 0x3c, 0xcf, 0x85, 0x75, 0x09, 0x00, 0x00,
+	// 1368 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x4b, 0x6f, 0xdb, 0xc6,
+	0x16, 0xb6, 0xde, 0xd2, 0x91, 0x1f, 0xf4, 0xf8, 0xda, 0xa6, 0x75, 0x9d, 0xc4, 0x57, 0x77, 0xe3,
+	0x1b, 0x04, 0x32, 0xa2, 0x20, 0xb7, 0x68, 0x5a, 0xa0, 0x60, 0x25, 0x26, 0x11, 0x1c, 0xc7, 0x0e,
+	0x25, 0xc7, 0x46, 0x37, 0x83, 0x31, 0x39, 0x92, 0x59, 0x49, 0x24, 0x33, 0x1c, 0xbd, 0xd0, 0x7d,
+	0xff, 0x42, 0xb7, 0xdd, 0xf6, 0xbf, 0xf4, 0x17, 0xf4, 0x77, 0x14, 0x45, 0x97, 0xc5, 0xcc, 0x50,
+	0x0f, 0x47, 0xaf, 0x6c, 0x2c, 0xce, 0x39, 0xdf, 0x7c, 0x73, 0xe6, 0x9b, 0xf3, 0x30, 0x64, 0x49,
+	0x58, 0x0a, 0x98, 0xcf, 0x7d, 0x14, 0x27, 0x61, 0x61, 0x97, 0x76, 0x03, 0x3e, 0x3a, 0x93, 0x7f,
+	0x95, 0xb9, 0x70, 0xc4, 0xdd, 0x2e, 0x0d, 0x39, 0xe9, 0x06, 0x67, 0x93, 0xaf, 0xc8, 0x75, 0x48,
+	0x02, 0xf7, 0xcc, 0xf6, 0xbb, 0x5d, 0xdf, 0x8b, 0x7e, 0x22, 0xc7, 0x8e, 0x70, 0xb4, 0x06, 0x67,
+	0xad, 0x81, 0x32, 0x14, 0x29, 0x1c, 0x56, 0x69, 0xdf, 0xb5, 0xa9, 0x61, 0x73, 0xb7, 0x4f, 0xb8,
+	0xeb, 0x7b, 0x15, 0xdf, 0xe3, 0x74, 0xc8, 0xd1, 0x11, 0x64, 0x1d, 0xda, 0xc7, 0xc4, 0x71, 0x98,
+	0x1e, 0x3b, 0x89, 0x9d, 0x6e, 0x5a, 0x19, 0x87, 0xf6, 0x0d, 0xc7, 0x61, 0xe8, 0x0c, 0x72, 0x24,
+	0x08, 0x70, 0x88, 0xdb, 0x74, 0xa4, 0xc7, 0x4f, 0x62, 0xa7, 0xf9, 0xf2, 0x5e, 0x29, 0x3a, 0xe8,
+	0x9c, 0x8e, 0x4c, 0xaf, 0x4f, 0x3b, 0x7e, 0x40, 0xad, 0x0c, 0x09, 0x82, 0xfa, 0x39, 0x1d, 0x15,
+	0x7f, 0x49, 0xc0, 0xe1, 0x5b, 0xe2, 0x39, 0x1d, 0x7a, 0x1d, 0x74, 0x5c, 0xaf, 0x5d, 0x25, 0x9c,
+	0x58, 0xf4, 0x53, 0x8f, 0x86, 0x1c, 0x1d, 0x82, 0xe0, 0xc5, 0xb4, 0xe7, 0x46, 0xc7, 0xa4, 0x1d,
+	0xda, 0x37, 0x7b, 0xae, 0x08, 0xe0, 0x47, 0xdf, 0xf5, 0xa4, 0x27, 0xae, 0x02, 0x10, 0x6b, 0xe1,
+	0xda, 0x83, 0x54, 0x13, 0xdb, 0x1e, 0xd7, 0x13, 0x27, 0xb1, 0xd3, 0x2d, 0x2b, 0xd9, 0xac, 0x78,
+	0x1c, 0xed, 0x43, 0xba, 0x89, 0x03, 0x9f, 0x71, 0x3d, 0x29, 0xad, 0xa9, 0xe6, 0x95, 0xcf, 0x38,
+	0xd2, 0x20, 0x41, 0x1c, 0xa6, 0xa7, 0x4e, 0x62, 0xa7, 0x59, 0x4b, 0x7c, 0xa2, 0x6d, 0x88, 0x3b,
+	0x4c, 0x4f, 0x4b, 0x50, 0xdc, 0x61, 0xe8, 0x7f, 0x90, 0xe1, 0x43, 0xec, 0x7a, 0x4d, 0x5f, 0xcf,
+	0xc8, 0xcb, 0x68, 0xa5, 0xd6, 0xa0, 0xa4, 0x22, 0x6d, 0xdc, 0xd6, 0xbc, 0xa6, 0x6f, 0xa5, 0xf9,
+	0x50, 0xfc, 0x0a, 0x28, 0x8b, 0xa0, 0xd9, 0x93, 0xc4, 0x43, 0xa8, 0x15, 0x41, 0x99, 0x82, 0x22,
+	0x48, 0x3a, 0x84, 0x13, 0x3d, 0x27, 0x43, 0x97, 0xdf, 0xe8, 0x06, 0x8e, 0x1c, 0x29, 0x37, 0x26,
+	0x13, 0xbd, 0xb1, 0xad, 0x04, 0xd7, 0x41, 0x9e, 0xfd, 0xef, 0x12, 0x09, 0x4b, 0x4b, 0xde, 0xc4,
+	0x3a, 0x74, 0x96, 0x3c, 0xd6, 0x57, 0x90, 0x27, 0x9c, 0x8b, 0x24, 0x10, 0x56, 0x3d, 0x2f, 0xa9,
+	0xf6, 0x05, 0x95, 0x8a, 0xcd, 0x98, 0x3a, 0xad, 0x59, 0x64, 0xf1, 0xcf, 0x18, 0xe8, 0x73, 0x90,
+	0x2b, 0x32, 0xea, 0xf8, 0xc4, 0x59, 0xfe, 0x34, 0x13, 0xfd, 0xe3, 0x33, 0xfa, 0x3f, 0x81, 0x7c,
+	0x70, 0x3f, 0xc2, 0x81, 0xda, 0x2c, 0x9f, 0x66, 0xd3, 0x82, 0xe0, 0x7e, 0x34, 0xa6, 0x9b, 0xd1,
+	0x39, 0xf9, 0xe5, 0x3a, 0xa7, 0xd6, 0xe8, 0xfc, 0x02, 0x32, 0x5e, 0x88, 0x45, 0x09, 0xc8, 0x27,
+	0xcd, 0x97, 0x0b, 0xa5, 0x96, 0xef, 0xb7, 0x3a, 0x54, 0xa5, 0xf8, 0x5d, 0xaf, 0x59, 0x6a, 0x8c,
+	0xeb, 0xc3, 0x4a, 0x7b, 0xa1, 0x58, 0x14, 0x7f, 0x8d, 0xc1, 0xee, 0xdc, 0xb5, 0x91, 0x0e, 0x99,
+	0x71, 0xf4, 0x51, 0xc6, 0x47, 0x4b, 0xf4, 0x2d, 0xe4, 0x48, 0xa7, 0xe5, 0x33, 0x97, 0xdf, 0x77,
+	0xe5, 0xa5, 0xb7, 0xcb, 0x8f, 0x17, 0xaa, 0x6b, 0x8c, 0x51, 0xd6, 0x74, 0x83, 0xc8, 0xcc, 0x36,
+	0x1d, 0x61, 0x57, 0x89, 0x92, 0xb3, 0x52, 0x6d, 0x3a, 0xaa, 0x39, 0xe8, 0x18, 0x72, 0xa1, 0xdb,
+	0xf2, 0x08, 0xef, 0x31, 0x2a, 0x15, 0xd9, 0xb4, 0xa6, 0x86, 0xe2, 0x07, 0x38, 0xfe, 0xbc, 0x64,
+	0xbe, 0x27, 0xdc, 0xbe, 0x1f, 0xd7, 0xcd, 0x73, 0x48, 0xb9, 0x9c, 0x76, 0x43, 0x3d, 0x76, 0x92,
+	0x18, 0xe7, 0xcd, 0x92, 0x1a, 0xb3, 0x14, 0xb2, 0xf8, 0x5b, 0x0c, 0x1e, 0x2b, 0xc8, 0x15, 0xf3,
+	0x03, 0xe6, 0x52, 0x4e, 0xd8, 0x28, 0x12, 0x35, 0x62, 0x7d, 0x02, 0xf9, 0x2e, 0xb1, 0xf1, 0x43,
+	0x19, 0xa0, 0x4b, 0xec, 0xf1, 0x23, 0x6a, 0x90, 0xe8, 0xba, 0x76, 0x54, 0x90, 0xe2, 0x73, 0xf6,
+	0x59, 0x13, 0x5f, 0xfe, 0xac, 0xc9, 0xd5, 0xcf, 0x5a, 0xfc, 0x09, 0x90, 0x0a, 0xd5, 0x64, 0xcc,
+	0x67, 0x6b, 0x9b, 0xc5, 0x7f, 0x20, 0xc9, 0x47, 0x01, 0x95, 0x11, 0x6c, 0x97, 0xb7, 0x84, 0x18,
+	0x72, 0x63, 0x63, 0x14, 0x50, 0x4b, 0xba, 0xd0, 0xbf, 0x20, 0x45, 0x85, 0x49, 0x4a, 0x9d, 0xb3,
+	0xd4, 0x62, 0x9a, 0xca, 0xa9, 0x69, 0x2a, 0x17, 0x7f, 0x8f, 0x83, 0xae, 0x4e, 0xaf, 0xfa, 0x03,
+	0x4f, 0x3e, 0x71, 0xe5, 0x7c, 0x6d, 0x0c, 0x0b, 0xab, 0xa2, 0x08, 0x9b, 0xc4, 0x6e, 0x7b, 0xfe,
+	0xa0, 0x43, 0x9d, 0x16, 0x55, 0x19, 0x90, 0xb5, 0x1e, 0xd8, 0x44, 0xa7, 0xe3, 0x43, 0x6c, 0xfb,
+	0x3d, 0x6f, 0xdc, 0xbb, 0x32, 0x7c, 0x58, 0x11, 0x4b, 0xf4, 0x0d, 0xe4, 0xa9, 0xf7, 0xa9, 0x47,
+	0x7b, 0xd4, 0xc1, 0x44, 0x05, 0xb9, 0x3a, 0xc3, 0x61, 0x0c, 0x37, 0x38, 0x32, 0x60, 0x9b, 0x33,
+	0xe2, 0x85, 0x5d, 0x97, 0x73, 0xb5, 0x7f, 0x7d, 0x85, 0x6c, 0xcd, 0xec, 0x30, 0x38, 0xaa, 0xc0,
+	0xce, 0x6c, 0xa8, 0x82, 0x23, 0xb3, 0x96, 0x63, 0x7b, 0x76, 0x8b, 0xc1, 0x8b, 0x7f, 0xc7, 0xe0,
+	0xa0, 0x4e, 0xb9, 0xea, 0x6a, 0x75, 0x4e, 0x78, 0x2f, 0x5c, 0x2b, 0xa6, 0x0e, 0x99, 0x3b, 0xc2,
+	0x39, 0x65, 0xa3, 0x48, 0xce, 0xf1, 0x12, 0x1d, 0x40, 0xba, 0x4b, 0x58, 0xcb, 0xf5, 0xa4, 0x96,
+	0x29, 0x2b, 0x5a, 0xa1, 0x32, 0xec, 0xd3, 0x21, 0xa7, 0xcc, 0x23, 0x1d, 0x1c, 0xf8, 0x03, 0xca,
+	0x70, 0xe8, 0xf7, 0x98, 0xad, 0x4a, 0x2b, 0x6b, 0xed, 0x8d, 0x9d, 0x57, 0xc2, 0x57, 0x97, 0x2e,
+	0xf4, 0x0a, 0x8e, 0x22, 0x5a, 0xdc, 0xa1, 0x7d, 0xda, 0xc1, 0x3d, 0x8f, 0xf4, 0x89, 0xdb, 0x21,
+	0x77, 0x1d, 0x1a, 0x8d, 0x8c, 0xc3, 0x08, 0xf0, 0x4e, 0xf8, 0xaf, 0xa7, 0x6e, 0xf4, 0x5f, 0xd8,
+	0x7a, 0xb0, 0x57, 0x8a, 0x1b, 0xb7, 0x36, 0x67, 0xf1, 0x45, 0x02, 0xfa, 0xe4, 0xe6, 0xef, 0x7c,
+	0x5b, 0x75, 0xe0, 0x75, 0x77, 0x7f, 0x06, 0xd9, 0x4e, 0x84, 0x8d, 0xc6, 0xab, 0x36, 0x1e, 0xaf,
+	0x13, 0x8e, 0x09, 0xa2, 0xf8, 0x57, 0x1c, 0x8e, 0x54, 0xb2, 0xbe, 0x21, 0x9c, 0x0e, 0xc8, 0x48,
+	0x28, 0x3c, 0x11, 0xf8, 0x11, 0x40, 0x4b, 0x99, 0x45, 0xff, 0x51, 0xe7, 0xe4, 0x22, 0x4b, 0x4d,
+	0xa6, 0x9e, 0xe8, 0x5c, 0xa1, 0x70, 0x46, 0x43, 0x56, 0xae, 0x6b, 0x0e, 0x2a, 0x41, 0x52, 0x76,
+	0xd5, 0xc4, 0xda, 0xf7, 0x96, 0xb8, 0x07, 0x51, 0x27, 0xd7, 0x45, 0x8d, 0x4a, 0xb0, 0xc7, 0x86,
+	0x38, 0x20, 0x76, 0x9b, 0xf2, 0x10, 0x33, 0x6a, 0x53, 0xb7, 0x4f, 0x9d, 0xa8, 0x0a, 0x77, 0xd9,
+	0xf0, 0x4a, 0x79, 0xac, 0xc8, 0x81, 0x5e, 0xc0, 0xc1, 0x02, 0x3c, 0xf6, 0xdb, 0xd1, 0x20, 0xdf,
+	0x9b, 0xdb, 0x72, 0xd9, 0x16, 0x87, 0xf0, 0x05, 0x87, 0x64, 0xd4, 0x21, 0x7c, 0xee, 0x90, 0x67,
+	0x80, 0x66, 0xf0, 0x54, 0x55, 0x81, 0x9e, 0x95, 0x70, 0x6d, 0x02, 0x37, 0x95, 0xfd, 0xe9, 0x31,
+	0x64, 0xad, 0xdb, 0x1b, 0xd7, 0x73, 0xfc, 0x01, 0xca, 0x40, 0xc2, 0xba, 0x7d, 0xae, 0x6d, 0xa8,
+	0x8f, 0xb2, 0x16, 0x7b, 0xfa, 0x47, 0x0c, 0x72, 0x93, 0x16, 0x84, 0xf2, 0x90, 0x79, 0x63, 0xbe,
+	0x37, 0xad, 0x5a, 0x45, 0xdb, 0x40, 0x59, 0x48, 0x5e, 0x36, 0x0c, 0x43, 0x8b, 0x21, 0x0d, 0x36,
+	0xab, 0x46, 0xc3, 0xc0, 0xd7, 0x57, 0xf8, 0x75, 0xe5, 0x7d, 0x43, 0x8b, 0xa3, 0x1d, 0xc8, 0x8f,
+	0x2d, 0x17, 0xb5, 0x8a, 0x96, 0x40, 0x05, 0x38, 0xa8, 0x9a, 0x1f, 0x6b, 0x15, 0x13, 0x7f, 0xb8,
+	0x36, 0xaf, 0x4d, 0x5c, 0x6b, 0x98, 0x17, 0xb8, 0x5e, 0xfb, 0xc1, 0xd4, 0x92, 0x8b, 0x7d, 0x92,
+	0x28, 0x85, 0x1e, 0xc1, 0xd1, 0xbc, 0xcf, 0xbc, 0xbd, 0xaa, 0x59, 0x66, 0x55, 0x4b, 0xa3, 0xc7,
+	0x50, 0x98, 0x77, 0x5f, 0x7e, 0x34, 0xad, 0xd7, 0xef, 0x2e, 0x6f, 0xb4, 0x0c, 0x3a, 0x06, 0x7d,
+	0xde, 0xff, 0xd1, 0x6c, 0x5c, 0x9a, 0x55, 0x2d, 0xfb, 0xf4, 0x15, 0x14, 0x96, 0x8f, 0x3e, 0x71,
+	0x87, 0xb7, 0x17, 0x46, 0x05, 0xd7, 0xdf, 0x1a, 0xe5, 0x97, 0xff, 0xd7, 0x36, 0xc4, 0xed, 0xcd,
+	0x6a, 0xf9, 0xe5, 0xcb, 0xe7, 0x5f, 0x6b, 0xb1, 0xf2, 0xcf, 0x29, 0xd0, 0x8d, 0x20, 0xe8, 0xb8,
+	0x2a, 0x13, 0xea, 0x94, 0xf5, 0x29, 0x13, 0x7f, 0x5d, 0x9b, 0xa2, 0x1a, 0x68, 0x9f, 0x0f, 0x31,
+	0xb4, 0x6a, 0xb4, 0x15, 0x0e, 0xe6, 0xf2, 0xd2, 0x14, 0xff, 0x24, 0x17, 0x37, 0x50, 0x1d, 0xf6,
+	0x17, 0x0e, 0x50, 0x74, 0xb2, 0x88, 0x6f, 0x76, 0xb6, 0xae, 0x20, 0xbd, 0x81, 0xc3, 0x25, 0x13,
+	0x14, 0x15, 0xa7, 0xb4, 0xcb, 0xc6, 0xeb, 0x0a, 0xe2, 0xef, 0x20, 0x3f, 0x33, 0xef, 0xd0, 0xc1,
+	0x94, 0x6c, 0x76, 0x00, 0xae, 0x20, 0x38, 0x87, 0xdd, 0xb9, 0x91, 0x85, 0x8e, 0xa7, 0x34, 0xf3,
+	0x93, 0x6c, 0x05, 0xd9, 0x05, 0xa0, 0xf9, 0x96, 0x82, 0x1e, 0x4d, 0xd9, 0x16, 0xb4, 0x9a, 0x15,
+	0x74, 0x6f, 0x60, 0xe7, 0xb3, 0xfe, 0x8f, 0x0a, 0x82, 0x6b, 0xf1, 0x50, 0x58, 0x7d, 0xc9, 0xb9,
+	0x76, 0xaa, 0x2e, 0xb9, 0xac, 0xcb, 0x2e, 0x27, 0xbb, 0x4b, 0x4b, 0xcb, 0x8b, 0x7f, 0x06, 0x00,
+	0x89, 0x47, 0x4a, 0xe2, 0x6b, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -794,6 +1096,9 @@ const _ = grpc.SupportPackageIsVersion4
 type ApplicationServerServiceClient interface {
 	// HandleUplinkData handles uplink data received from an end-device.
 	HandleUplinkData(ctx context.Context, in *HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleUplinkDataBatch handles a batch of uplink data received from
+	// end-devices. The items must be handled in the given order.
+	HandleUplinkDataBatch(ctx context.Context, in *HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// HandleProprietaryUplink handles proprietary uplink payloads.
 	HandleProprietaryUplink(ctx context.Context, in *HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// HandleError handles an error message.
@@ -825,6 +1130,15 @@ func (c *applicationServerServiceClient) HandleUplinkData(ctx context.Context, i
 	return out, nil
 }
 
+func (c *applicationServerServiceClient) HandleUplinkDataBatch(ctx context.Context, in *HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleUplinkDataBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServerServiceClient) HandleProprietaryUplink(ctx context.Context, in *HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/as.ApplicationServerService/HandleProprietaryUplink", in, out, opts...)
@@ -883,6 +1197,9 @@ func (c *applicationServerServiceClient) SetDeviceLocation(ctx context.Context,
 type ApplicationServerServiceServer interface {
 	// HandleUplinkData handles uplink data received from an end-device.
 	HandleUplinkData(context.Context, *HandleUplinkDataRequest) (*empty.Empty, error)
+	// HandleUplinkDataBatch handles a batch of uplink data received from
+	// end-devices. The items must be handled in the given order.
+	HandleUplinkDataBatch(context.Context, *HandleUplinkDataBatchRequest) (*empty.Empty, error)
 	// HandleProprietaryUplink handles proprietary uplink payloads.
 	HandleProprietaryUplink(context.Context, *HandleProprietaryUplinkRequest) (*empty.Empty, error)
 	// HandleError handles an error message.
@@ -904,6 +1221,9 @@ type UnimplementedApplicationServerServiceServer struct {
 func (*UnimplementedApplicationServerServiceServer) HandleUplinkData(ctx context.Context, req *HandleUplinkDataRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleUplinkData not implemented")
 }
+func (*UnimplementedApplicationServerServiceServer) HandleUplinkDataBatch(ctx context.Context, req *HandleUplinkDataBatchRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleUplinkDataBatch not implemented")
+}
 func (*UnimplementedApplicationServerServiceServer) HandleProprietaryUplink(ctx context.Context, req *HandleProprietaryUplinkRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleProprietaryUplink not implemented")
 }
@@ -945,6 +1265,24 @@ func _ApplicationServerService_HandleUplinkData_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationServerService_HandleUplinkDataBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleUplinkDataBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServiceServer).HandleUplinkDataBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServerService/HandleUplinkDataBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServiceServer).HandleUplinkDataBatch(ctx, req.(*HandleUplinkDataBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationServerService_HandleProprietaryUplink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HandleProprietaryUplinkRequest)
 	if err := dec(in); err != nil {
@@ -1061,6 +1399,10 @@ var _ApplicationServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleUplinkData",
 			Handler:    _ApplicationServerService_HandleUplinkData_Handler,
 		},
+		{
+			MethodName: "HandleUplinkDataBatch",
+			Handler:    _ApplicationServerService_HandleUplinkDataBatch_Handler,
+		},
 		{
 			MethodName: "HandleProprietaryUplink",
 			Handler:    _ApplicationServerService_HandleProprietaryUplink_Handler,
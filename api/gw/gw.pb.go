@@ -85,6 +85,33 @@ func (FineTimestampType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9ee4117efac0d846, []int{1}
 }
 
+type ConnState_State int32
+
+const (
+	// The gateway is offline.
+	ConnState_OFFLINE ConnState_State = 0
+	// The gateway is online.
+	ConnState_ONLINE ConnState_State = 1
+)
+
+var ConnState_State_name = map[int32]string{
+	0: "OFFLINE",
+	1: "ONLINE",
+}
+
+var ConnState_State_value = map[string]int32{
+	"OFFLINE": 0,
+	"ONLINE":  1,
+}
+
+func (x ConnState_State) String() string {
+	return proto.EnumName(ConnState_State_name, int32(x))
+}
+
+func (ConnState_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{23, 0}
+}
+
 type UplinkTXInfo struct {
 	// Frequency (Hz).
 	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
@@ -421,10 +448,17 @@ type GatewayStats struct {
 	MetaData map[string]string `protobuf:"bytes,10,rep,name=meta_data,json=metaData,proto3" json:"meta_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Stats ID (UUID).
 	// Unique identifier for the gateway stats.
-	StatsId              []byte   `protobuf:"bytes,11,opt,name=stats_id,json=statsID,proto3" json:"stats_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	StatsId []byte `protobuf:"bytes,11,opt,name=stats_id,json=statsID,proto3" json:"stats_id,omitempty"`
+	// Number of downlink packets emitted per frequency (Hz).
+	TxPacketsPerFrequency map[uint32]uint32 `protobuf:"bytes,12,rep,name=tx_packets_per_frequency,json=txPacketsPerFrequency,proto3" json:"tx_packets_per_frequency,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of radio packets received per frequency (Hz).
+	RxPacketsPerFrequency map[uint32]uint32 `protobuf:"bytes,13,rep,name=rx_packets_per_frequency,json=rxPacketsPerFrequency,proto3" json:"rx_packets_per_frequency,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of downlink packets per TX acknowledgement status (e.g. OK,
+	// TOO_LATE, COLLISION_PACKET).
+	TxPacketsPerStatus   map[string]uint32 `protobuf:"bytes,16,rep,name=tx_packets_per_status,json=txPacketsPerStatus,proto3" json:"tx_packets_per_status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
@@ -529,6 +563,27 @@ func (m *GatewayStats) GetStatsId() []byte {
 	return nil
 }
 
+func (m *GatewayStats) GetTxPacketsPerFrequency() map[uint32]uint32 {
+	if m != nil {
+		return m.TxPacketsPerFrequency
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetRxPacketsPerFrequency() map[uint32]uint32 {
+	if m != nil {
+		return m.RxPacketsPerFrequency
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetTxPacketsPerStatus() map[string]uint32 {
+	if m != nil {
+		return m.TxPacketsPerStatus
+	}
+	return nil
+}
+
 type UplinkRXInfo struct {
 	// Gateway ID.
 	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayID,proto3" json:"gateway_id,omitempty"`
@@ -1310,10 +1365,16 @@ type GatewayConfiguration struct {
 	// Configuration version.
 	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 	// Channels.
-	Channels             []*ChannelConfiguration `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Channels []*ChannelConfiguration `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+	// Listen-before-talk configuration.
+	// When not set, listen-before-talk is disabled.
+	Lbt *LBTConfiguration `protobuf:"bytes,4,opt,name=lbt,proto3" json:"lbt,omitempty"`
+	// Class-B beacon configuration.
+	// When not set, the gateway uses its default beacon configuration.
+	Beacon               *BeaconConfiguration `protobuf:"bytes,5,opt,name=beacon,proto3" json:"beacon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *GatewayConfiguration) Reset()         { *m = GatewayConfiguration{} }
@@ -1362,6 +1423,136 @@ func (m *GatewayConfiguration) GetChannels() []*ChannelConfiguration {
 	return nil
 }
 
+func (m *GatewayConfiguration) GetLbt() *LBTConfiguration {
+	if m != nil {
+		return m.Lbt
+	}
+	return nil
+}
+
+func (m *GatewayConfiguration) GetBeacon() *BeaconConfiguration {
+	if m != nil {
+		return m.Beacon
+	}
+	return nil
+}
+
+type LBTConfiguration struct {
+	// RSSI target (dBm).
+	RssiTarget int32 `protobuf:"varint,1,opt,name=rssi_target,json=rssiTarget,proto3" json:"rssi_target,omitempty"`
+	// Scan time (us).
+	ScanTime uint32 `protobuf:"varint,2,opt,name=scan_time,json=scanTime,proto3" json:"scan_time,omitempty"`
+	// Frequencies (Hz) on which listen-before-talk must be performed.
+	Frequencies          []uint32 `protobuf:"varint,3,rep,packed,name=frequencies,proto3" json:"frequencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LBTConfiguration) Reset()         { *m = LBTConfiguration{} }
+func (m *LBTConfiguration) String() string { return proto.CompactTextString(m) }
+func (*LBTConfiguration) ProtoMessage()    {}
+func (*LBTConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{16}
+}
+
+func (m *LBTConfiguration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LBTConfiguration.Unmarshal(m, b)
+}
+func (m *LBTConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LBTConfiguration.Marshal(b, m, deterministic)
+}
+func (m *LBTConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LBTConfiguration.Merge(m, src)
+}
+func (m *LBTConfiguration) XXX_Size() int {
+	return xxx_messageInfo_LBTConfiguration.Size(m)
+}
+func (m *LBTConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_LBTConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LBTConfiguration proto.InternalMessageInfo
+
+func (m *LBTConfiguration) GetRssiTarget() int32 {
+	if m != nil {
+		return m.RssiTarget
+	}
+	return 0
+}
+
+func (m *LBTConfiguration) GetScanTime() uint32 {
+	if m != nil {
+		return m.ScanTime
+	}
+	return 0
+}
+
+func (m *LBTConfiguration) GetFrequencies() []uint32 {
+	if m != nil {
+		return m.Frequencies
+	}
+	return nil
+}
+
+type BeaconConfiguration struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Bandwidth (kHz).
+	Bandwidth uint32 `protobuf:"varint,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Spreading-factor.
+	SpreadingFactor      uint32   `protobuf:"varint,3,opt,name=spreading_factor,json=spreadingFactor,proto3" json:"spreading_factor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BeaconConfiguration) Reset()         { *m = BeaconConfiguration{} }
+func (m *BeaconConfiguration) String() string { return proto.CompactTextString(m) }
+func (*BeaconConfiguration) ProtoMessage()    {}
+func (*BeaconConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{17}
+}
+
+func (m *BeaconConfiguration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeaconConfiguration.Unmarshal(m, b)
+}
+func (m *BeaconConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeaconConfiguration.Marshal(b, m, deterministic)
+}
+func (m *BeaconConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeaconConfiguration.Merge(m, src)
+}
+func (m *BeaconConfiguration) XXX_Size() int {
+	return xxx_messageInfo_BeaconConfiguration.Size(m)
+}
+func (m *BeaconConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeaconConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeaconConfiguration proto.InternalMessageInfo
+
+func (m *BeaconConfiguration) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *BeaconConfiguration) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *BeaconConfiguration) GetSpreadingFactor() uint32 {
+	if m != nil {
+		return m.SpreadingFactor
+	}
+	return 0
+}
+
 type ChannelConfiguration struct {
 	// Frequency (Hz).
 	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
@@ -1384,7 +1575,7 @@ func (m *ChannelConfiguration) Reset()         { *m = ChannelConfiguration{} }
 func (m *ChannelConfiguration) String() string { return proto.CompactTextString(m) }
 func (*ChannelConfiguration) ProtoMessage()    {}
 func (*ChannelConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ee4117efac0d846, []int{16}
+	return fileDescriptor_9ee4117efac0d846, []int{18}
 }
 
 func (m *ChannelConfiguration) XXX_Unmarshal(b []byte) error {
@@ -1492,7 +1683,7 @@ func (m *LoRaModulationConfig) Reset()         { *m = LoRaModulationConfig{} }
 func (m *LoRaModulationConfig) String() string { return proto.CompactTextString(m) }
 func (*LoRaModulationConfig) ProtoMessage()    {}
 func (*LoRaModulationConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ee4117efac0d846, []int{17}
+	return fileDescriptor_9ee4117efac0d846, []int{19}
 }
 
 func (m *LoRaModulationConfig) XXX_Unmarshal(b []byte) error {
@@ -1541,7 +1732,7 @@ func (m *FSKModulationConfig) Reset()         { *m = FSKModulationConfig{} }
 func (m *FSKModulationConfig) String() string { return proto.CompactTextString(m) }
 func (*FSKModulationConfig) ProtoMessage()    {}
 func (*FSKModulationConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ee4117efac0d846, []int{18}
+	return fileDescriptor_9ee4117efac0d846, []int{20}
 }
 
 func (m *FSKModulationConfig) XXX_Unmarshal(b []byte) error {
@@ -1599,7 +1790,7 @@ func (m *GatewayCommandExecRequest) Reset()         { *m = GatewayCommandExecReq
 func (m *GatewayCommandExecRequest) String() string { return proto.CompactTextString(m) }
 func (*GatewayCommandExecRequest) ProtoMessage()    {}
 func (*GatewayCommandExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ee4117efac0d846, []int{19}
+	return fileDescriptor_9ee4117efac0d846, []int{21}
 }
 
 func (m *GatewayCommandExecRequest) XXX_Unmarshal(b []byte) error {
@@ -1675,7 +1866,7 @@ func (m *GatewayCommandExecResponse) Reset()         { *m = GatewayCommandExecRe
 func (m *GatewayCommandExecResponse) String() string { return proto.CompactTextString(m) }
 func (*GatewayCommandExecResponse) ProtoMessage()    {}
 func (*GatewayCommandExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9ee4117efac0d846, []int{20}
+	return fileDescriptor_9ee4117efac0d846, []int{22}
 }
 
 func (m *GatewayCommandExecResponse) XXX_Unmarshal(b []byte) error {
@@ -1731,9 +1922,195 @@ func (m *GatewayCommandExecResponse) GetError() string {
 	return ""
 }
 
+type ConnState struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayID,proto3" json:"gateway_id,omitempty"`
+	// Connection state.
+	State                ConnState_State `protobuf:"varint,2,opt,name=state,proto3,enum=gw.ConnState_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ConnState) Reset()         { *m = ConnState{} }
+func (m *ConnState) String() string { return proto.CompactTextString(m) }
+func (*ConnState) ProtoMessage()    {}
+func (*ConnState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{23}
+}
+
+func (m *ConnState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnState.Unmarshal(m, b)
+}
+func (m *ConnState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnState.Marshal(b, m, deterministic)
+}
+func (m *ConnState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnState.Merge(m, src)
+}
+func (m *ConnState) XXX_Size() int {
+	return xxx_messageInfo_ConnState.Size(m)
+}
+func (m *ConnState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnState proto.InternalMessageInfo
+
+func (m *ConnState) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *ConnState) GetState() ConnState_State {
+	if m != nil {
+		return m.State
+	}
+	return ConnState_OFFLINE
+}
+
+type NoiseFloorReport struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayID,proto3" json:"gateway_id,omitempty"`
+	// Gateway time of the scan.
+	Time *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Noise-floor per channel.
+	Channels             []*ChannelNoiseFloor `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *NoiseFloorReport) Reset()         { *m = NoiseFloorReport{} }
+func (m *NoiseFloorReport) String() string { return proto.CompactTextString(m) }
+func (*NoiseFloorReport) ProtoMessage()    {}
+func (*NoiseFloorReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{24}
+}
+
+func (m *NoiseFloorReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoiseFloorReport.Unmarshal(m, b)
+}
+func (m *NoiseFloorReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoiseFloorReport.Marshal(b, m, deterministic)
+}
+func (m *NoiseFloorReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoiseFloorReport.Merge(m, src)
+}
+func (m *NoiseFloorReport) XXX_Size() int {
+	return xxx_messageInfo_NoiseFloorReport.Size(m)
+}
+func (m *NoiseFloorReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoiseFloorReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoiseFloorReport proto.InternalMessageInfo
+
+func (m *NoiseFloorReport) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *NoiseFloorReport) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *NoiseFloorReport) GetChannels() []*ChannelNoiseFloor {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ChannelNoiseFloor struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Bandwidth (Hz).
+	Bandwidth uint32 `protobuf:"varint,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Noise-floor (dBm).
+	NoiseFloor float64 `protobuf:"fixed64,3,opt,name=noise_floor,json=noiseFloor,proto3" json:"noise_floor,omitempty"`
+	// Channel utilization, the fraction of the scan time the channel was
+	// busy (0 - 1).
+	ChannelUtilization float64 `protobuf:"fixed64,4,opt,name=channel_utilization,json=channelUtilization,proto3" json:"channel_utilization,omitempty"`
+	// RSSI histogram of the spectral-scan (dBm => number of samples).
+	// This is only set when the gateway supports spectral-scan.
+	RssiHistogram        map[int32]uint32 `protobuf:"bytes,5,rep,name=rssi_histogram,json=rssiHistogram,proto3" json:"rssi_histogram,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ChannelNoiseFloor) Reset()         { *m = ChannelNoiseFloor{} }
+func (m *ChannelNoiseFloor) String() string { return proto.CompactTextString(m) }
+func (*ChannelNoiseFloor) ProtoMessage()    {}
+func (*ChannelNoiseFloor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ee4117efac0d846, []int{25}
+}
+
+func (m *ChannelNoiseFloor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelNoiseFloor.Unmarshal(m, b)
+}
+func (m *ChannelNoiseFloor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelNoiseFloor.Marshal(b, m, deterministic)
+}
+func (m *ChannelNoiseFloor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelNoiseFloor.Merge(m, src)
+}
+func (m *ChannelNoiseFloor) XXX_Size() int {
+	return xxx_messageInfo_ChannelNoiseFloor.Size(m)
+}
+func (m *ChannelNoiseFloor) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelNoiseFloor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelNoiseFloor proto.InternalMessageInfo
+
+func (m *ChannelNoiseFloor) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *ChannelNoiseFloor) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *ChannelNoiseFloor) GetNoiseFloor() float64 {
+	if m != nil {
+		return m.NoiseFloor
+	}
+	return 0
+}
+
+func (m *ChannelNoiseFloor) GetChannelUtilization() float64 {
+	if m != nil {
+		return m.ChannelUtilization
+	}
+	return 0
+}
+
+func (m *ChannelNoiseFloor) GetRssiHistogram() map[int32]uint32 {
+	if m != nil {
+		return m.RssiHistogram
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("gw.DownlinkTiming", DownlinkTiming_name, DownlinkTiming_value)
 	proto.RegisterEnum("gw.FineTimestampType", FineTimestampType_name, FineTimestampType_value)
+	proto.RegisterEnum("gw.ConnState_State", ConnState_State_name, ConnState_State_value)
 	proto.RegisterType((*UplinkTXInfo)(nil), "gw.UplinkTXInfo")
 	proto.RegisterType((*LoRaModulationInfo)(nil), "gw.LoRaModulationInfo")
 	proto.RegisterType((*FSKModulationInfo)(nil), "gw.FSKModulationInfo")
@@ -1741,6 +2118,9 @@ func init() {
 	proto.RegisterType((*PlainFineTimestamp)(nil), "gw.PlainFineTimestamp")
 	proto.RegisterType((*GatewayStats)(nil), "gw.GatewayStats")
 	proto.RegisterMapType((map[string]string)(nil), "gw.GatewayStats.MetaDataEntry")
+	proto.RegisterMapType((map[uint32]uint32)(nil), "gw.GatewayStats.RxPacketsPerFrequencyEntry")
+	proto.RegisterMapType((map[uint32]uint32)(nil), "gw.GatewayStats.TxPacketsPerFrequencyEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "gw.GatewayStats.TxPacketsPerStatusEntry")
 	proto.RegisterType((*UplinkRXInfo)(nil), "gw.UplinkRXInfo")
 	proto.RegisterType((*DownlinkTXInfo)(nil), "gw.DownlinkTXInfo")
 	proto.RegisterType((*ImmediatelyTimingInfo)(nil), "gw.ImmediatelyTimingInfo")
@@ -1751,122 +2131,151 @@ func init() {
 	proto.RegisterType((*DownlinkFrame)(nil), "gw.DownlinkFrame")
 	proto.RegisterType((*DownlinkTXAck)(nil), "gw.DownlinkTXAck")
 	proto.RegisterType((*GatewayConfiguration)(nil), "gw.GatewayConfiguration")
+	proto.RegisterType((*LBTConfiguration)(nil), "gw.LBTConfiguration")
+	proto.RegisterType((*BeaconConfiguration)(nil), "gw.BeaconConfiguration")
 	proto.RegisterType((*ChannelConfiguration)(nil), "gw.ChannelConfiguration")
 	proto.RegisterType((*LoRaModulationConfig)(nil), "gw.LoRaModulationConfig")
 	proto.RegisterType((*FSKModulationConfig)(nil), "gw.FSKModulationConfig")
 	proto.RegisterType((*GatewayCommandExecRequest)(nil), "gw.GatewayCommandExecRequest")
 	proto.RegisterMapType((map[string]string)(nil), "gw.GatewayCommandExecRequest.EnvironmentEntry")
 	proto.RegisterType((*GatewayCommandExecResponse)(nil), "gw.GatewayCommandExecResponse")
+	proto.RegisterType((*ConnState)(nil), "gw.ConnState")
+	proto.RegisterType((*NoiseFloorReport)(nil), "gw.NoiseFloorReport")
+	proto.RegisterType((*ChannelNoiseFloor)(nil), "gw.ChannelNoiseFloor")
+	proto.RegisterMapType((map[int32]uint32)(nil), "gw.ChannelNoiseFloor.RssiHistogramEntry")
 }
 
 func init() { proto.RegisterFile("gw.proto", fileDescriptor_9ee4117efac0d846) }
 
 var fileDescriptor_9ee4117efac0d846 = []byte{
-	// 1685 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x6f, 0x22, 0xc9,
-	0x11, 0xf7, 0x60, 0x63, 0xa0, 0x30, 0x36, 0xb4, 0xb1, 0x3d, 0xeb, 0xfc, 0x73, 0x46, 0x8a, 0xb4,
-	0xbb, 0x77, 0x8b, 0x25, 0x5f, 0xa2, 0x44, 0x59, 0x29, 0x92, 0x6d, 0xd8, 0x5d, 0xd6, 0x7f, 0x16,
-	0x35, 0xce, 0xe9, 0x36, 0x2f, 0x93, 0xf6, 0x4c, 0x83, 0x47, 0x40, 0xcf, 0x64, 0xa6, 0x31, 0x90,
-	0xbc, 0x26, 0x4a, 0x3e, 0xc0, 0x3d, 0xdd, 0x4b, 0x9e, 0xf3, 0x98, 0x2f, 0x93, 0xcf, 0x13, 0xf5,
-	0x9f, 0x19, 0x66, 0x80, 0x3b, 0xd6, 0xc9, 0xdd, 0x13, 0x53, 0xd5, 0xd5, 0x55, 0xd5, 0x55, 0xd5,
-	0xbf, 0xaa, 0x06, 0x8a, 0xfd, 0x49, 0x23, 0x08, 0x7d, 0xee, 0xa3, 0x5c, 0x7f, 0x72, 0x7c, 0x44,
-	0x02, 0xef, 0xd4, 0xf1, 0x47, 0x23, 0x9f, 0xe9, 0x1f, 0xb5, 0x78, 0xfc, 0x8c, 0x7b, 0x23, 0x1a,
-	0x71, 0x32, 0x0a, 0x4e, 0x93, 0x2f, 0xbd, 0x74, 0xe4, 0x8e, 0x43, 0xc2, 0x3d, 0x9f, 0x9d, 0xc6,
-	0x1f, 0x6a, 0xc1, 0xfa, 0x47, 0x0e, 0x76, 0x7e, 0x1f, 0x0c, 0x3d, 0x36, 0xb8, 0xfb, 0xaa, 0xcd,
-	0x7a, 0x3e, 0xfa, 0x31, 0x94, 0x7a, 0x21, 0xfd, 0xd3, 0x98, 0x32, 0x67, 0x66, 0x1a, 0x27, 0xc6,
-	0xf3, 0x0a, 0x9e, 0x33, 0xd0, 0x19, 0xc0, 0xc8, 0x77, 0xc7, 0x43, 0xa9, 0xc2, 0xcc, 0x9d, 0x18,
-	0xcf, 0x77, 0xcf, 0x50, 0x43, 0x7b, 0x71, 0x93, 0xac, 0xe0, 0x94, 0x14, 0x7a, 0x0f, 0xf5, 0xa1,
-	0x1f, 0x12, 0x7b, 0xce, 0xb2, 0x3d, 0xd6, 0xf3, 0xcd, 0xcd, 0x13, 0xe3, 0x79, 0xf9, 0xec, 0xb0,
-	0xd1, 0x9f, 0x34, 0xae, 0x7d, 0x4c, 0xe6, 0xbb, 0x85, 0x1f, 0xef, 0x36, 0x30, 0x1a, 0x2e, 0x71,
-	0xd1, 0x5b, 0xd8, 0xef, 0x45, 0x83, 0x25, 0x55, 0x5b, 0x52, 0xd5, 0x81, 0x50, 0xf5, 0xa6, 0x7b,
-	0xb5, 0xa4, 0xa9, 0xd6, 0x8b, 0x06, 0x59, 0xe6, 0x45, 0x0d, 0xf6, 0x16, 0x94, 0x58, 0xff, 0x36,
-	0x00, 0x2d, 0x3b, 0x22, 0x02, 0x72, 0x4f, 0x98, 0x3b, 0xf1, 0x5c, 0xfe, 0x10, 0x07, 0x24, 0x61,
-	0xa0, 0x17, 0x50, 0x8d, 0x82, 0x90, 0x12, 0xd7, 0x63, 0x7d, 0xbb, 0x47, 0x1c, 0xee, 0x87, 0x32,
-	0x2c, 0x15, 0xbc, 0x97, 0xf0, 0xdf, 0x48, 0x36, 0xfa, 0x11, 0x94, 0x1c, 0xdf, 0xa5, 0x76, 0x48,
-	0x38, 0x95, 0x87, 0x2f, 0xe1, 0xa2, 0x60, 0x60, 0xc2, 0x29, 0xfa, 0x15, 0x1c, 0x06, 0xfe, 0x90,
-	0x84, 0xde, 0x9f, 0x63, 0x8f, 0x1e, 0x69, 0x18, 0x89, 0x20, 0x8b, 0xb3, 0x15, 0xf1, 0x41, 0x7a,
-	0xb5, 0x1d, 0x2f, 0x5a, 0x57, 0x50, 0x5b, 0x3a, 0xf0, 0x1a, 0x8f, 0x4d, 0x28, 0xdc, 0x7b, 0x5c,
-	0x3a, 0xa1, 0x1c, 0x8d, 0x49, 0x6b, 0x0a, 0x87, 0x2d, 0xe6, 0x84, 0xb3, 0x80, 0x53, 0xf7, 0x8d,
-	0xc7, 0xe8, 0x5d, 0x5c, 0x44, 0xc8, 0x82, 0x0a, 0xa1, 0x91, 0x3d, 0xa0, 0x33, 0xdb, 0x63, 0x2e,
-	0x9d, 0x6a, 0xad, 0x65, 0x42, 0xa3, 0x2b, 0x3a, 0x6b, 0x0b, 0x16, 0xfa, 0x39, 0xec, 0xd0, 0x78,
-	0xb7, 0xcd, 0x22, 0xa9, 0x7c, 0x07, 0x97, 0x13, 0xde, 0x6d, 0x17, 0x1d, 0x41, 0xa1, 0x17, 0xf4,
-	0x89, 0xed, 0xb9, 0xf2, 0xfc, 0x3b, 0x78, 0x5b, 0x90, 0xed, 0xa6, 0xd5, 0x04, 0xd4, 0x19, 0x12,
-	0x8f, 0x65, 0xad, 0x36, 0x60, 0x4b, 0xd4, 0xb1, 0x34, 0x56, 0x3e, 0x3b, 0x6e, 0xf4, 0x7d, 0xbf,
-	0x3f, 0xa4, 0xaa, 0x70, 0xef, 0xc7, 0xbd, 0x46, 0x22, 0x89, 0xa5, 0x9c, 0xf5, 0xcd, 0x16, 0xec,
-	0xbc, 0x25, 0x9c, 0x4e, 0xc8, 0xac, 0xcb, 0x09, 0x8f, 0xd0, 0x4f, 0x00, 0xfa, 0x8a, 0x16, 0x26,
-	0x0d, 0x69, 0xb2, 0xa4, 0x39, 0xed, 0x26, 0xda, 0x85, 0x9c, 0x17, 0x98, 0x25, 0x99, 0x89, 0x9c,
-	0x37, 0xb7, 0x97, 0xfb, 0x34, 0x7b, 0xe8, 0x73, 0x28, 0x0e, 0x7d, 0x47, 0x5d, 0x05, 0x55, 0xcc,
-	0xd5, 0xf8, 0x2a, 0x5c, 0x6b, 0x3e, 0x4e, 0x24, 0xd0, 0x2f, 0x60, 0xd7, 0xf1, 0x59, 0xcf, 0xeb,
-	0xdb, 0xe9, 0xcc, 0x96, 0x70, 0x45, 0x71, 0xbf, 0x54, 0x4c, 0xd4, 0x80, 0xfd, 0x70, 0x6a, 0x07,
-	0xc4, 0x19, 0x50, 0x1e, 0xd9, 0x21, 0x75, 0xa8, 0xf7, 0x48, 0x5d, 0x33, 0x2f, 0x03, 0x5e, 0x0b,
-	0xa7, 0x1d, 0xb5, 0x82, 0xf5, 0x02, 0xfa, 0x02, 0x0e, 0x57, 0xc8, 0xdb, 0xfe, 0xc0, 0xdc, 0x96,
-	0x5b, 0xf6, 0x97, 0xb6, 0x7c, 0xb8, 0x12, 0x46, 0xf8, 0x0a, 0x23, 0x05, 0x65, 0x84, 0x2f, 0x19,
-	0xf9, 0x1c, 0x50, 0x4a, 0x9e, 0x8e, 0x3c, 0xce, 0xa9, 0x6b, 0x16, 0xa5, 0x78, 0x35, 0x11, 0x6f,
-	0x29, 0x3e, 0x7a, 0x0d, 0xa5, 0x11, 0xe5, 0xc4, 0x76, 0x09, 0x27, 0x26, 0x9c, 0x6c, 0x3e, 0x2f,
-	0x9f, 0xfd, 0x54, 0x5c, 0xcd, 0x74, 0x6e, 0x1a, 0x37, 0x94, 0x93, 0x26, 0xe1, 0xa4, 0xc5, 0x78,
-	0x38, 0xc3, 0xc5, 0x91, 0x26, 0xd1, 0x33, 0x28, 0x46, 0x42, 0x40, 0x64, 0xac, 0x2c, 0x33, 0x56,
-	0x90, 0x74, 0xbb, 0x79, 0xfc, 0x1a, 0x2a, 0x99, 0x5d, 0xa8, 0x0a, 0x9b, 0x03, 0xaa, 0x50, 0xaa,
-	0x84, 0xc5, 0x27, 0xaa, 0x43, 0xfe, 0x91, 0x0c, 0xc7, 0x2a, 0x87, 0x25, 0xac, 0x88, 0xdf, 0xe6,
-	0x7e, 0x63, 0x58, 0xff, 0xcc, 0xc7, 0x40, 0x87, 0x15, 0xd0, 0xad, 0x29, 0x8e, 0xa7, 0x16, 0xc3,
-	0x7b, 0xa8, 0x8b, 0x5f, 0x3b, 0xf2, 0x98, 0x43, 0xed, 0x7e, 0x10, 0xd9, 0x34, 0xf0, 0x9d, 0x07,
-	0x5d, 0x18, 0xcf, 0x96, 0xf6, 0x37, 0x35, 0x0e, 0xe3, 0x9a, 0xd8, 0xd6, 0x15, 0xbb, 0xde, 0x76,
-	0xba, 0x2d, 0xb1, 0x07, 0x21, 0xd8, 0x0a, 0xa3, 0xc8, 0x93, 0x49, 0xcf, 0x63, 0xf9, 0x2d, 0xe2,
-	0x22, 0x51, 0x34, 0x62, 0xa1, 0xcc, 0xac, 0x81, 0x0b, 0x02, 0x1f, 0xbb, 0xb7, 0x58, 0xdc, 0x68,
-	0xe7, 0x81, 0x30, 0x46, 0x87, 0x3a, 0x83, 0x31, 0x29, 0x36, 0x85, 0x3d, 0xdb, 0x79, 0x20, 0x1e,
-	0xd3, 0xd9, 0x2a, 0x84, 0xbd, 0x4b, 0x41, 0x8a, 0x48, 0xdd, 0xfb, 0x24, 0x74, 0x65, 0xfd, 0x57,
-	0xb0, 0x22, 0x84, 0x2a, 0xc2, 0x38, 0x65, 0x4c, 0x24, 0x4e, 0xca, 0x6b, 0x32, 0x53, 0xec, 0xe5,
-	0xb5, 0xc5, 0xde, 0x82, 0xfd, 0x9e, 0xc7, 0xa8, 0x9d, 0xf4, 0x21, 0x9b, 0xcf, 0x02, 0x6a, 0xee,
-	0xc8, 0x86, 0xa1, 0x70, 0x3a, 0x7d, 0xd5, 0xef, 0x66, 0x01, 0xc5, 0xb5, 0xde, 0x22, 0x0b, 0x7d,
-	0x09, 0xe6, 0x1c, 0x53, 0xb2, 0x0a, 0xcd, 0x4a, 0x9c, 0x98, 0x49, 0x63, 0x35, 0x6a, 0xbd, 0xdb,
-	0xc0, 0x87, 0x74, 0x35, 0x9e, 0xbd, 0x87, 0x7a, 0x20, 0xf0, 0x66, 0x51, 0xe7, 0xee, 0xbc, 0x25,
-	0x2d, 0xe3, 0x91, 0x68, 0x49, 0xc1, 0x32, 0x4a, 0x89, 0xe8, 0xfb, 0x8c, 0xd3, 0x29, 0x37, 0xf7,
-	0x54, 0xbd, 0x6a, 0x52, 0x00, 0xfe, 0x58, 0x56, 0x9c, 0x28, 0xb0, 0xaa, 0x5c, 0x2b, 0x2a, 0x46,
-	0xbb, 0x79, 0x51, 0x85, 0xdd, 0xac, 0x71, 0xeb, 0x5f, 0x79, 0xd8, 0x6d, 0xfa, 0x13, 0x96, 0x6a,
-	0xc6, 0x6b, 0x6a, 0x34, 0xd3, 0xab, 0xf3, 0x8b, 0xbd, 0xba, 0x0e, 0xf9, 0xc0, 0x9f, 0x50, 0x55,
-	0x2e, 0x79, 0xac, 0x88, 0x85, 0x0e, 0x5e, 0xf8, 0xbf, 0x3a, 0x78, 0xf1, 0xfb, 0xeb, 0xe0, 0xa5,
-	0xa7, 0x76, 0xf0, 0x79, 0x01, 0xc3, 0xb7, 0x14, 0x70, 0x39, 0x5b, 0xc0, 0x2f, 0x61, 0x9b, 0x7b,
-	0x23, 0x8f, 0xf5, 0x75, 0x15, 0x22, 0x61, 0x2b, 0x89, 0xb7, 0x5c, 0xc1, 0x5a, 0x02, 0x75, 0xe1,
-	0xc8, 0x1b, 0x8d, 0xa8, 0xeb, 0x11, 0x4e, 0x87, 0x33, 0x5b, 0x71, 0x95, 0xa3, 0x95, 0xf8, 0x3e,
-	0x4f, 0x1a, 0xed, 0xb9, 0x88, 0xda, 0x2f, 0x9d, 0x35, 0xf0, 0x81, 0xb7, 0x6a, 0x01, 0x9d, 0x43,
-	0xcd, 0xa5, 0x43, 0x92, 0x55, 0xa7, 0x2a, 0x6e, 0x5f, 0xfa, 0x22, 0x16, 0x33, 0x8a, 0xf6, 0xdc,
-	0x2c, 0x0b, 0x5d, 0xc1, 0x41, 0x82, 0x2c, 0x19, 0x35, 0x7b, 0xf3, 0x4c, 0xc4, 0x28, 0x92, 0xd1,
-	0x84, 0xfa, 0x41, 0xb4, 0xc0, 0x4d, 0x17, 0x6e, 0x35, 0x53, 0xb8, 0x2b, 0x86, 0xa3, 0x8b, 0x0a,
-	0x94, 0x53, 0xf6, 0xac, 0x23, 0x38, 0x58, 0x79, 0x7a, 0xeb, 0x02, 0xf6, 0x16, 0xce, 0x81, 0x4e,
-	0x21, 0x2f, 0xcf, 0xa1, 0xfb, 0xf8, 0x77, 0x40, 0xa1, 0x92, 0xb3, 0xfe, 0x08, 0x68, 0xf9, 0x10,
-	0xdf, 0x0a, 0xb0, 0xc6, 0xd3, 0x01, 0xd6, 0xfa, 0xab, 0x01, 0x65, 0xd5, 0x0c, 0xde, 0x84, 0x64,
-	0x44, 0xd1, 0xcf, 0xa0, 0x1c, 0x3c, 0xcc, 0xec, 0x80, 0xcc, 0x86, 0x3e, 0x89, 0x2f, 0x1a, 0x04,
-	0x0f, 0xb3, 0x8e, 0xe2, 0xa0, 0x17, 0x50, 0xe0, 0x53, 0x15, 0xea, 0x9c, 0x06, 0xbf, 0xfe, 0xa4,
-	0x91, 0x1e, 0x9c, 0xf1, 0x36, 0x9f, 0x4a, 0x3f, 0x5f, 0x40, 0x21, 0x9c, 0xa6, 0x27, 0xdc, 0x94,
-	0x28, 0xd6, 0xa2, 0xa1, 0x14, 0xb5, 0xfe, 0x6e, 0xc0, 0x6e, 0xca, 0x8d, 0x2e, 0xe5, 0x3f, 0x9c,
-	0x27, 0x9b, 0xdf, 0xe9, 0xc9, 0xd7, 0x06, 0x54, 0xe2, 0xbb, 0xf0, 0x89, 0x21, 0xf9, 0x6c, 0xd1,
-	0x91, 0xec, 0x85, 0xca, 0xba, 0x52, 0x87, 0x3c, 0xf7, 0x07, 0x54, 0xcd, 0x49, 0x15, 0xac, 0x08,
-	0x61, 0xc3, 0xd5, 0xf2, 0x02, 0xdf, 0xb6, 0x94, 0x8d, 0x98, 0xd5, 0x6e, 0x5a, 0x7f, 0x99, 0x7b,
-	0x75, 0xf7, 0xd5, 0xb9, 0x33, 0x58, 0x07, 0x88, 0x89, 0x99, 0x5c, 0xda, 0x4c, 0x1d, 0xf2, 0x34,
-	0x0c, 0xfd, 0x50, 0x0f, 0xdd, 0x8a, 0x58, 0x6f, 0xfc, 0x6f, 0x06, 0xd4, 0xf5, 0xc8, 0x72, 0x29,
-	0x47, 0x34, 0x5d, 0x50, 0xeb, 0x9c, 0x30, 0xa1, 0x10, 0x4f, 0x78, 0x6a, 0x0a, 0x89, 0x49, 0xf4,
-	0x4b, 0x28, 0xea, 0xce, 0x1c, 0xe9, 0x8c, 0x98, 0x22, 0x66, 0x97, 0x8a, 0x97, 0x31, 0x82, 0x13,
-	0x49, 0xeb, 0x3f, 0x39, 0xa8, 0xaf, 0x12, 0xf9, 0x01, 0x9e, 0x6a, 0x1d, 0x38, 0x5c, 0x04, 0x7a,
-	0x35, 0x9d, 0xea, 0x52, 0x36, 0x97, 0xa1, 0x5e, 0xb9, 0xf4, 0x6e, 0x03, 0xd7, 0x87, 0x2b, 0xf8,
-	0xe8, 0x06, 0x0e, 0x16, 0xe0, 0x5e, 0x2b, 0x54, 0x4f, 0xb6, 0xa3, 0x25, 0xc0, 0x4f, 0xf4, 0xed,
-	0x67, 0x20, 0x5f, 0xab, 0x4b, 0x40, 0x3f, 0x9f, 0x06, 0xfd, 0x13, 0x28, 0xbb, 0x54, 0x9b, 0xf0,
-	0x43, 0x3d, 0xf8, 0xa6, 0x59, 0x17, 0xfb, 0x50, 0x5b, 0x72, 0xc1, 0x22, 0x50, 0x5f, 0x75, 0x96,
-	0x35, 0xef, 0xa7, 0xcf, 0xa0, 0xb6, 0xf8, 0xe2, 0x13, 0x8f, 0x9d, 0x4d, 0x31, 0x0a, 0x2f, 0x3c,
-	0xf9, 0x22, 0xeb, 0x06, 0xf6, 0x57, 0x9c, 0xee, 0x7f, 0x7e, 0xa1, 0x7d, 0x9d, 0x83, 0x67, 0x49,
-	0x49, 0x8e, 0x46, 0x84, 0xb9, 0xad, 0x29, 0x75, 0xb0, 0x48, 0x79, 0xc4, 0x3f, 0xa1, 0x2e, 0x1d,
-	0xb5, 0x29, 0xae, 0x4b, 0x4d, 0xa2, 0x43, 0xd8, 0x16, 0x7a, 0xda, 0xc9, 0xb3, 0x8c, 0x0a, 0x4a,
-	0x5e, 0xa7, 0x88, 0xbb, 0x1e, 0xd3, 0x97, 0x43, 0x11, 0xa8, 0x03, 0x65, 0xca, 0x1e, 0xbd, 0xd0,
-	0x67, 0x23, 0xca, 0xb8, 0x99, 0x97, 0x85, 0xdc, 0x48, 0x0d, 0xf8, 0xcb, 0xae, 0x35, 0x5a, 0xf3,
-	0x0d, 0x6a, 0xe0, 0x4f, 0xab, 0x38, 0xfe, 0x1d, 0x54, 0x17, 0x05, 0x9e, 0x34, 0xdb, 0x7f, 0x63,
-	0xc0, 0xf1, 0x2a, 0xdb, 0x51, 0xe0, 0xb3, 0x88, 0xae, 0x8b, 0xcb, 0x11, 0x14, 0xc4, 0x79, 0xc5,
-	0x5a, 0x2e, 0x73, 0xfc, 0x43, 0xd8, 0x8e, 0xb8, 0xeb, 0x8f, 0x79, 0x1c, 0x16, 0x45, 0x69, 0x3e,
-	0x0d, 0x43, 0x1d, 0x17, 0x4d, 0xcd, 0x71, 0x26, 0x9f, 0xc2, 0x99, 0x97, 0xaf, 0x53, 0x53, 0x9d,
-	0x9a, 0x2e, 0xf6, 0xa0, 0xdc, 0xbe, 0xb9, 0x69, 0x35, 0xdb, 0xe7, 0x77, 0xad, 0xeb, 0x8f, 0xd5,
-	0x0d, 0x54, 0x82, 0x7c, 0xb3, 0x75, 0x7d, 0xfe, 0xb1, 0x6a, 0xa0, 0x0a, 0x94, 0xde, 0x76, 0xba,
-	0x76, 0xab, 0xf3, 0xe1, 0xf2, 0x5d, 0x35, 0xf7, 0xf2, 0xd7, 0x50, 0x5b, 0x1a, 0x94, 0x51, 0x11,
-	0xb6, 0x6e, 0x3f, 0xdc, 0xb6, 0xaa, 0x1b, 0x42, 0xba, 0x75, 0x7b, 0x89, 0x3f, 0x76, 0xee, 0x5a,
-	0xcd, 0xaa, 0x21, 0xf4, 0x74, 0xae, 0xcf, 0xdb, 0xb7, 0xd5, 0xdc, 0xc5, 0xe9, 0x1f, 0x5e, 0xf5,
-	0x3d, 0xfe, 0x30, 0xbe, 0x17, 0x37, 0xfe, 0x74, 0x34, 0x75, 0x5e, 0xf5, 0xfc, 0x31, 0x73, 0xd5,
-	0x7f, 0x40, 0xc3, 0x60, 0x42, 0xd8, 0xab, 0x88, 0x86, 0x8f, 0x34, 0x3c, 0x25, 0x81, 0x77, 0xda,
-	0x9f, 0xdc, 0x6f, 0xcb, 0xc6, 0xf9, 0xc5, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x1a, 0xc1, 0x8a,
-	0x72, 0x6c, 0x12, 0x00, 0x00,
+	// 2054 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x18, 0x4d, 0x6f, 0x23, 0x49,
+	0x75, 0xda, 0x89, 0x63, 0xfb, 0x39, 0x4e, 0xec, 0x8a, 0x93, 0xf4, 0x84, 0x8f, 0x0d, 0x2d, 0x81,
+	0xe6, 0x63, 0xc7, 0x11, 0x59, 0x10, 0x88, 0x91, 0x10, 0x93, 0xd8, 0x99, 0x78, 0x27, 0x1f, 0x56,
+	0x25, 0xbb, 0xda, 0x81, 0x43, 0x53, 0x71, 0x97, 0x9d, 0x56, 0xec, 0xea, 0xa6, 0xba, 0x1c, 0xdb,
+	0xc0, 0x11, 0x09, 0x7e, 0xc0, 0x9e, 0x38, 0xc0, 0x99, 0x23, 0x12, 0xbf, 0x85, 0x23, 0xbf, 0x05,
+	0x55, 0x55, 0x77, 0xbb, 0xdb, 0xed, 0x8c, 0x67, 0x76, 0x67, 0x2f, 0x76, 0xbf, 0x8f, 0x7a, 0x5f,
+	0xf5, 0xea, 0xbd, 0x57, 0x05, 0xc5, 0xfe, 0xb8, 0xe1, 0x73, 0x4f, 0x78, 0x28, 0xd7, 0x1f, 0xef,
+	0xed, 0x12, 0xdf, 0x3d, 0xe8, 0x7a, 0xc3, 0xa1, 0xc7, 0xc2, 0x3f, 0x4d, 0xdc, 0x7b, 0x2c, 0xdc,
+	0x21, 0x0d, 0x04, 0x19, 0xfa, 0x07, 0xf1, 0x57, 0x48, 0xda, 0x75, 0x46, 0x9c, 0x08, 0xd7, 0x63,
+	0x07, 0xd1, 0x87, 0x26, 0x58, 0x7f, 0xcb, 0xc1, 0xfa, 0x17, 0xfe, 0xc0, 0x65, 0x77, 0xd7, 0x5f,
+	0xb5, 0x59, 0xcf, 0x43, 0xdf, 0x87, 0x52, 0x8f, 0xd3, 0x3f, 0x8c, 0x28, 0xeb, 0x4e, 0x4d, 0x63,
+	0xdf, 0x78, 0x52, 0xc1, 0x33, 0x04, 0x3a, 0x04, 0x18, 0x7a, 0xce, 0x68, 0xa0, 0x44, 0x98, 0xb9,
+	0x7d, 0xe3, 0xc9, 0xc6, 0x21, 0x6a, 0x84, 0x56, 0x9c, 0xc7, 0x14, 0x9c, 0xe0, 0x42, 0x9f, 0x43,
+	0x7d, 0xe0, 0x71, 0x62, 0xcf, 0x50, 0xb6, 0xcb, 0x7a, 0x9e, 0xb9, 0xb2, 0x6f, 0x3c, 0x29, 0x1f,
+	0xee, 0x34, 0xfa, 0xe3, 0xc6, 0x99, 0x87, 0xc9, 0x6c, 0xb5, 0xb4, 0xe3, 0xf4, 0x11, 0x46, 0x83,
+	0x0c, 0x16, 0xbd, 0x86, 0xad, 0x5e, 0x70, 0x97, 0x11, 0xb5, 0xaa, 0x44, 0x6d, 0x4b, 0x51, 0x27,
+	0x57, 0x6f, 0x32, 0x92, 0x6a, 0xbd, 0xe0, 0x2e, 0x8d, 0x3c, 0xaa, 0xc1, 0xe6, 0x9c, 0x10, 0xeb,
+	0xdf, 0x06, 0xa0, 0xac, 0x21, 0x32, 0x20, 0x37, 0x84, 0x39, 0x63, 0xd7, 0x11, 0xb7, 0x51, 0x40,
+	0x62, 0x04, 0x7a, 0x0a, 0xd5, 0xc0, 0xe7, 0x94, 0x38, 0x2e, 0xeb, 0xdb, 0x3d, 0xd2, 0x15, 0x1e,
+	0x57, 0x61, 0xa9, 0xe0, 0xcd, 0x18, 0x7f, 0xa2, 0xd0, 0xe8, 0x7b, 0x50, 0xea, 0x7a, 0x0e, 0xb5,
+	0x39, 0x11, 0x54, 0x39, 0x5f, 0xc2, 0x45, 0x89, 0xc0, 0x44, 0x50, 0xf4, 0x73, 0xd8, 0xf1, 0xbd,
+	0x01, 0xe1, 0xee, 0x1f, 0x23, 0x8b, 0xee, 0x29, 0x0f, 0x64, 0x90, 0xa5, 0x6f, 0x45, 0xbc, 0x9d,
+	0xa4, 0xb6, 0x23, 0xa2, 0xf5, 0x06, 0x6a, 0x19, 0x87, 0x97, 0x58, 0x6c, 0x42, 0xe1, 0xc6, 0x15,
+	0xca, 0x08, 0x6d, 0x68, 0x04, 0x5a, 0x13, 0xd8, 0x69, 0xb1, 0x2e, 0x9f, 0xfa, 0x82, 0x3a, 0x27,
+	0x2e, 0xa3, 0xd7, 0x51, 0x12, 0x21, 0x0b, 0x2a, 0x84, 0x06, 0xf6, 0x1d, 0x9d, 0xda, 0x2e, 0x73,
+	0xe8, 0x24, 0x94, 0x5a, 0x26, 0x34, 0x78, 0x43, 0xa7, 0x6d, 0x89, 0x42, 0x3f, 0x82, 0x75, 0x1a,
+	0xad, 0xb6, 0x59, 0xa0, 0x84, 0xaf, 0xe3, 0x72, 0x8c, 0xbb, 0xb8, 0x42, 0xbb, 0x50, 0xe8, 0xf9,
+	0x7d, 0x62, 0xbb, 0x8e, 0xf2, 0x7f, 0x1d, 0xaf, 0x49, 0xb0, 0xdd, 0xb4, 0x9a, 0x80, 0x3a, 0x03,
+	0xe2, 0xb2, 0xb4, 0xd6, 0x06, 0xac, 0xca, 0x3c, 0x56, 0xca, 0xca, 0x87, 0x7b, 0x8d, 0xbe, 0xe7,
+	0xf5, 0x07, 0x54, 0x27, 0xee, 0xcd, 0xa8, 0xd7, 0x88, 0x39, 0xb1, 0xe2, 0xb3, 0xfe, 0x51, 0x84,
+	0xf5, 0xd7, 0x44, 0xd0, 0x31, 0x99, 0x5e, 0x09, 0x22, 0x02, 0xf4, 0x03, 0x80, 0xbe, 0x86, 0xa5,
+	0x4a, 0x43, 0xa9, 0x2c, 0x85, 0x98, 0x76, 0x13, 0x6d, 0x40, 0xce, 0xf5, 0xcd, 0x92, 0xda, 0x89,
+	0x9c, 0x3b, 0xd3, 0x97, 0x7b, 0x3f, 0x7d, 0xe8, 0x53, 0x28, 0x0e, 0xbc, 0xae, 0x3e, 0x0a, 0x3a,
+	0x99, 0xab, 0xd1, 0x51, 0x38, 0x0b, 0xf1, 0x38, 0xe6, 0x40, 0x3f, 0x86, 0x8d, 0xae, 0xc7, 0x7a,
+	0x6e, 0xdf, 0x4e, 0xee, 0x6c, 0x09, 0x57, 0x34, 0xf6, 0x4b, 0x8d, 0x44, 0x0d, 0xd8, 0xe2, 0x13,
+	0xdb, 0x27, 0xdd, 0x3b, 0x2a, 0x02, 0x9b, 0xd3, 0x2e, 0x75, 0xef, 0xa9, 0x63, 0xe6, 0x55, 0xc0,
+	0x6b, 0x7c, 0xd2, 0xd1, 0x14, 0x1c, 0x12, 0xd0, 0x67, 0xb0, 0xb3, 0x80, 0xdf, 0xf6, 0xee, 0xcc,
+	0x35, 0xb5, 0x64, 0x2b, 0xb3, 0xe4, 0xf2, 0x8d, 0x54, 0x22, 0x16, 0x28, 0x29, 0x68, 0x25, 0x22,
+	0xa3, 0xe4, 0x53, 0x40, 0x09, 0x7e, 0x3a, 0x74, 0x85, 0xa0, 0x8e, 0x59, 0x54, 0xec, 0xd5, 0x98,
+	0xbd, 0xa5, 0xf1, 0xe8, 0x25, 0x94, 0x86, 0x54, 0x10, 0xdb, 0x21, 0x82, 0x98, 0xb0, 0xbf, 0xf2,
+	0xa4, 0x7c, 0xf8, 0x43, 0x79, 0x34, 0x93, 0x7b, 0xd3, 0x38, 0xa7, 0x82, 0x34, 0x89, 0x20, 0x2d,
+	0x26, 0xf8, 0x14, 0x17, 0x87, 0x21, 0x88, 0x1e, 0x43, 0x31, 0x90, 0x0c, 0x72, 0xc7, 0xca, 0x6a,
+	0xc7, 0x0a, 0x0a, 0x6e, 0x37, 0x91, 0x03, 0x66, 0xc2, 0x0a, 0x9f, 0x72, 0x7b, 0x56, 0xa9, 0xd6,
+	0x95, 0x9a, 0xe7, 0x19, 0x35, 0xd7, 0x91, 0x71, 0x1d, 0xca, 0x4f, 0x22, 0x6e, 0xad, 0x73, 0x5b,
+	0x2c, 0xa2, 0x49, 0x2d, 0xfc, 0x21, 0x2d, 0x95, 0x07, 0xb4, 0xe0, 0x77, 0x68, 0xe1, 0x0b, 0xb5,
+	0xfc, 0x0e, 0xb6, 0xe7, 0x7c, 0x91, 0x5e, 0x8e, 0x02, 0xb3, 0xaa, 0x54, 0x3c, 0x79, 0xa7, 0x23,
+	0x57, 0x8a, 0x55, 0xcb, 0x47, 0x22, 0x43, 0xd8, 0x7b, 0x09, 0x95, 0x54, 0x78, 0x51, 0x15, 0x56,
+	0xee, 0xa8, 0x2e, 0xe7, 0x25, 0x2c, 0x3f, 0x51, 0x1d, 0xf2, 0xf7, 0x64, 0x30, 0xd2, 0xc9, 0x5e,
+	0xc2, 0x1a, 0xf8, 0x55, 0xee, 0x97, 0xc6, 0xde, 0x29, 0xec, 0x3d, 0x1c, 0xb4, 0xa4, 0xa4, 0xca,
+	0x02, 0x49, 0x95, 0x39, 0x49, 0xf8, 0xe3, 0x48, 0x6a, 0xc1, 0xee, 0x03, 0xfe, 0x2f, 0x73, 0x2d,
+	0x29, 0xc6, 0xfa, 0x67, 0x3e, 0x6a, 0x76, 0x58, 0x37, 0xbb, 0x25, 0x05, 0xe2, 0x43, 0x0b, 0xc2,
+	0xe7, 0x50, 0x97, 0xff, 0x76, 0xe0, 0xb2, 0x2e, 0xb5, 0xfb, 0x7e, 0x60, 0x53, 0xdf, 0xeb, 0xde,
+	0x86, 0xc5, 0xe1, 0x71, 0x66, 0x7d, 0x33, 0xec, 0xc5, 0xb8, 0x26, 0x97, 0x5d, 0xc9, 0x55, 0xaf,
+	0x3b, 0x57, 0x2d, 0xb9, 0x06, 0x21, 0x58, 0xe5, 0x41, 0xe0, 0xaa, 0x83, 0x9f, 0xc7, 0xea, 0x5b,
+	0x9e, 0x0d, 0xd5, 0x49, 0x03, 0xc6, 0xd5, 0xe9, 0x36, 0x70, 0x41, 0xf6, 0xc8, 0xab, 0x0b, 0x2c,
+	0xab, 0x7a, 0xf7, 0x96, 0x30, 0x46, 0x07, 0xe1, 0x29, 0x8e, 0x40, 0xb9, 0x88, 0xf7, 0xec, 0xee,
+	0x2d, 0x71, 0x59, 0x78, 0x62, 0x0b, 0xbc, 0x77, 0x2c, 0x41, 0x19, 0xa9, 0x1b, 0x8f, 0x70, 0x47,
+	0xd5, 0xc0, 0x0a, 0xd6, 0x80, 0x14, 0x45, 0x98, 0xa0, 0x8c, 0xc9, 0xc3, 0xab, 0xf8, 0x43, 0x30,
+	0x55, 0xf0, 0xca, 0x4b, 0x0b, 0x5e, 0x0b, 0xb6, 0x7a, 0x2e, 0xa3, 0x76, 0x3c, 0x8b, 0xd8, 0x62,
+	0xea, 0x53, 0x73, 0x5d, 0x0d, 0x0d, 0xba, 0x57, 0x27, 0xcb, 0xfd, 0xf5, 0xd4, 0xa7, 0xb8, 0xd6,
+	0x9b, 0x47, 0xa1, 0x2f, 0xc1, 0x9c, 0xf5, 0x95, 0xb4, 0x40, 0xb3, 0x12, 0x6d, 0xcc, 0xb8, 0xb1,
+	0xb8, 0x73, 0x9d, 0x3e, 0xc2, 0x3b, 0x74, 0x21, 0x45, 0x6e, 0x96, 0x2f, 0x7b, 0xce, 0xbc, 0xcc,
+	0x8d, 0xd9, 0x58, 0x92, 0xed, 0x49, 0x72, 0x2c, 0xf1, 0x33, 0x58, 0x15, 0x7d, 0x8f, 0x09, 0x3a,
+	0x11, 0xe6, 0xa6, 0xae, 0x59, 0x21, 0x28, 0x9b, 0xfe, 0x48, 0x65, 0x9c, 0x4c, 0xb0, 0xaa, 0xa2,
+	0x15, 0x35, 0xa2, 0xdd, 0x3c, 0xaa, 0xc2, 0x46, 0x5a, 0xb9, 0xf5, 0xaf, 0x3c, 0x6c, 0x34, 0xbd,
+	0x31, 0x4b, 0x0c, 0x64, 0x4b, 0x72, 0x34, 0x35, 0xaf, 0xe5, 0xe7, 0xe7, 0xb5, 0x3a, 0xe4, 0x7d,
+	0x6f, 0x4c, 0x75, 0xba, 0xe4, 0xb1, 0x06, 0xe6, 0xa6, 0xb8, 0xc2, 0xb7, 0x9a, 0xe2, 0x8a, 0x1f,
+	0x6f, 0x8a, 0x2b, 0x7d, 0xe8, 0x14, 0x37, 0x4b, 0x60, 0x78, 0x20, 0x81, 0xcb, 0xe9, 0x04, 0x7e,
+	0x06, 0x6b, 0xc2, 0x1d, 0xba, 0xac, 0x1f, 0x66, 0x21, 0x92, 0xba, 0xe2, 0x78, 0x2b, 0x0a, 0x0e,
+	0x39, 0xd0, 0x15, 0xec, 0xba, 0xc3, 0x21, 0x75, 0x5c, 0x22, 0xe8, 0x60, 0x6a, 0x6b, 0xac, 0x36,
+	0xb4, 0x12, 0x9d, 0xe7, 0x71, 0xa3, 0x3d, 0x63, 0xd1, 0xeb, 0x95, 0xb1, 0x06, 0xde, 0x76, 0x17,
+	0x11, 0xd0, 0x2b, 0xa8, 0x39, 0x74, 0x40, 0xd2, 0xe2, 0x74, 0xc6, 0x6d, 0x29, 0x5b, 0x24, 0x31,
+	0x25, 0x68, 0xd3, 0x49, 0xa3, 0xd0, 0x1b, 0xd8, 0x8e, 0x2b, 0x4b, 0x4a, 0xcc, 0xe6, 0x6c, 0x27,
+	0xa2, 0x2a, 0x92, 0x92, 0x84, 0xfa, 0x7e, 0x30, 0x87, 0x4d, 0x26, 0x6e, 0x35, 0x95, 0xb8, 0x0b,
+	0x06, 0xe4, 0xa3, 0x0a, 0x94, 0x13, 0xfa, 0xac, 0x5d, 0xd8, 0x5e, 0xe8, 0xbd, 0x75, 0x04, 0x9b,
+	0x73, 0x7e, 0xa0, 0x03, 0xc8, 0x2b, 0x3f, 0x4c, 0x63, 0x59, 0x29, 0xd4, 0x7c, 0xd6, 0xef, 0x01,
+	0x65, 0x9d, 0x78, 0xb0, 0xc0, 0x1a, 0x1f, 0x5e, 0x60, 0xad, 0xbf, 0x18, 0x50, 0xd6, 0xcd, 0xe0,
+	0x84, 0x93, 0x21, 0x45, 0x9f, 0x40, 0xd9, 0xbf, 0x9d, 0xda, 0x3e, 0x99, 0x0e, 0x3c, 0x12, 0x1d,
+	0x34, 0xf0, 0x6f, 0xa7, 0x1d, 0x8d, 0x41, 0x4f, 0xa1, 0x20, 0x26, 0x3a, 0xd4, 0xb9, 0xb0, 0xf8,
+	0xf5, 0xc7, 0x8d, 0xe4, 0xe5, 0x09, 0xaf, 0x89, 0x89, 0xb2, 0xf3, 0x29, 0x14, 0xf8, 0x24, 0x79,
+	0xcb, 0x49, 0xb0, 0xe2, 0x90, 0x95, 0x2b, 0x56, 0xeb, 0xaf, 0x06, 0x6c, 0x24, 0xcc, 0xb8, 0xa2,
+	0xe2, 0xbb, 0xb3, 0x64, 0xe5, 0x9d, 0x96, 0x7c, 0x6d, 0x40, 0x25, 0x3a, 0x0b, 0xef, 0x19, 0x92,
+	0xe7, 0xf3, 0x86, 0xa4, 0x0f, 0x54, 0xda, 0x94, 0x3a, 0xe4, 0x85, 0x77, 0x47, 0xf5, 0xac, 0x5c,
+	0xc1, 0x1a, 0x90, 0x3a, 0x9c, 0x90, 0x5f, 0xd6, 0xb7, 0x55, 0xad, 0x23, 0x42, 0xb5, 0x9b, 0xd6,
+	0x9f, 0x66, 0x56, 0x5d, 0x7f, 0xf5, 0xaa, 0x7b, 0xb7, 0xac, 0x20, 0xc6, 0x6a, 0x72, 0x49, 0x35,
+	0x75, 0xc8, 0x53, 0xce, 0x3d, 0x1e, 0x5e, 0xbc, 0x34, 0xb0, 0x5c, 0xf9, 0xff, 0x0c, 0xa8, 0x87,
+	0x63, 0xd8, 0xb1, 0x1a, 0xd3, 0xc3, 0x84, 0x5a, 0x66, 0x84, 0x09, 0x85, 0x68, 0xca, 0xd7, 0x03,
+	0x56, 0x04, 0xa2, 0x9f, 0x41, 0x31, 0xec, 0xcc, 0x41, 0xb8, 0x23, 0xa6, 0x8c, 0xd9, 0xb1, 0xc6,
+	0xa5, 0x94, 0xe0, 0x98, 0x13, 0xfd, 0x04, 0x56, 0x06, 0x37, 0x22, 0xbc, 0xe7, 0xd6, 0x55, 0xb1,
+	0x3d, 0xba, 0x4e, 0x33, 0x4b, 0x06, 0x74, 0x00, 0x6b, 0x37, 0x94, 0x74, 0x3d, 0xa6, 0x5a, 0x41,
+	0xf9, 0x70, 0x57, 0xb2, 0x1e, 0x29, 0x4c, 0x9a, 0x3b, 0x64, 0xb3, 0x38, 0x54, 0xe7, 0x25, 0xc9,
+	0xa8, 0xc8, 0x71, 0xc3, 0x16, 0x84, 0xf7, 0xa9, 0x50, 0xce, 0xe5, 0x31, 0x48, 0xd4, 0xb5, 0xc2,
+	0xc8, 0xa6, 0x16, 0x74, 0x09, 0xb3, 0xe3, 0xe1, 0xa8, 0x82, 0x8b, 0x12, 0x21, 0x1b, 0x22, 0xda,
+	0x87, 0x72, 0xd4, 0x7f, 0x5c, 0xaa, 0x7d, 0xac, 0xe0, 0x24, 0xca, 0xfa, 0x33, 0x6c, 0x2d, 0x30,
+	0x69, 0xc9, 0xcb, 0x43, 0xea, 0x52, 0x9b, 0x7b, 0x9f, 0x6b, 0xf8, 0xca, 0xc2, 0x6b, 0xb8, 0xf5,
+	0xdf, 0x1c, 0xd4, 0x17, 0x45, 0xfb, 0x3b, 0x78, 0xf9, 0xe8, 0xc0, 0xce, 0x7c, 0xcf, 0xd4, 0x97,
+	0xbd, 0xb0, 0x2a, 0x98, 0xd9, 0xae, 0xa9, 0x4d, 0x3a, 0x7d, 0x84, 0xeb, 0x83, 0x05, 0x78, 0x74,
+	0x0e, 0xdb, 0x73, 0x9d, 0x33, 0x14, 0xb8, 0x3a, 0xdb, 0xee, 0x54, 0xef, 0x8c, 0xe5, 0x6d, 0xa5,
+	0xba, 0x67, 0x28, 0x2e, 0xee, 0x9f, 0xf9, 0x64, 0xff, 0xdc, 0x87, 0xb2, 0x43, 0x43, 0x15, 0x1e,
+	0x0f, 0xef, 0x91, 0x49, 0xd4, 0xd1, 0x16, 0xd4, 0x32, 0x26, 0x58, 0x04, 0xea, 0x8b, 0x7c, 0x59,
+	0xf2, 0x1c, 0xf1, 0x1c, 0x6a, 0xf3, 0x3b, 0x27, 0xdf, 0x0e, 0x64, 0xd2, 0x54, 0xe7, 0xb6, 0x2e,
+	0xb0, 0xce, 0x61, 0x6b, 0x81, 0x77, 0xdf, 0xf8, 0xc1, 0xe3, 0xeb, 0x1c, 0x3c, 0x8e, 0x4f, 0xf7,
+	0x70, 0x48, 0x98, 0xd3, 0x9a, 0xd0, 0x2e, 0x96, 0x5b, 0x1e, 0x88, 0xf7, 0x38, 0xe2, 0x5d, 0xbd,
+	0x28, 0x3a, 0xe2, 0x21, 0x88, 0x76, 0x60, 0x4d, 0xca, 0x69, 0xc7, 0xaf, 0x1c, 0x54, 0x42, 0xaa,
+	0x32, 0x05, 0xc2, 0x71, 0x59, 0x58, 0x67, 0x34, 0x80, 0x3a, 0x50, 0xa6, 0xec, 0xde, 0xe5, 0x1e,
+	0x1b, 0x52, 0x26, 0xcc, 0xbc, 0xaa, 0x09, 0x8d, 0xc4, 0xfd, 0x2f, 0x6b, 0x5a, 0xa3, 0x35, 0x5b,
+	0xa0, 0x6f, 0x81, 0x49, 0x11, 0x7b, 0xbf, 0x86, 0xea, 0x3c, 0xc3, 0x87, 0xdc, 0x00, 0xad, 0xbf,
+	0x1b, 0xb0, 0xb7, 0x48, 0x77, 0xe0, 0x7b, 0x2c, 0xa0, 0xcb, 0xe2, 0xb2, 0x0b, 0x05, 0xe9, 0xaf,
+	0xa4, 0xe5, 0x52, 0xee, 0xef, 0xc0, 0x5a, 0x20, 0x1c, 0x6f, 0x24, 0xa2, 0xb0, 0x68, 0x28, 0xc4,
+	0x53, 0xce, 0xc3, 0xb8, 0x84, 0xd0, 0xac, 0x64, 0xe7, 0x13, 0x25, 0xdb, 0x1a, 0x43, 0xe9, 0xd8,
+	0x63, 0x4c, 0x5e, 0x01, 0x97, 0x9a, 0xf2, 0x54, 0x06, 0x3c, 0xda, 0xf7, 0x0d, 0x3d, 0x61, 0xc5,
+	0x8b, 0x1b, 0xea, 0x17, 0x6b, 0x0e, 0x6b, 0x1f, 0xf2, 0x5a, 0x64, 0x19, 0x0a, 0x97, 0x27, 0x27,
+	0x67, 0xed, 0x8b, 0x56, 0xf5, 0x11, 0x02, 0x58, 0xbb, 0xbc, 0x50, 0xdf, 0x86, 0x6c, 0x8f, 0xd5,
+	0x0b, 0xcf, 0x0d, 0xe8, 0xc9, 0xc0, 0xf3, 0x38, 0xa6, 0xbe, 0xc7, 0xc5, 0xc7, 0xbe, 0x40, 0xfe,
+	0x34, 0xd3, 0x1c, 0xb6, 0x13, 0xcd, 0x21, 0xa1, 0x3d, 0x66, 0xb3, 0xfe, 0x93, 0x83, 0x5a, 0x86,
+	0xfe, 0xad, 0x6a, 0xe9, 0x27, 0x50, 0x66, 0x52, 0x92, 0xdd, 0x93, 0xa2, 0xd4, 0x66, 0x19, 0x18,
+	0xd8, 0x4c, 0xf8, 0x01, 0x6c, 0x85, 0xea, 0xed, 0x91, 0x70, 0x07, 0xe1, 0xa3, 0xa4, 0xda, 0x3d,
+	0x03, 0xa3, 0x90, 0xf4, 0xc5, 0x8c, 0x82, 0x2e, 0x61, 0x43, 0x35, 0x94, 0x5b, 0x37, 0x10, 0x5e,
+	0x9f, 0x93, 0xa1, 0x99, 0x9f, 0xbd, 0x72, 0x64, 0x8c, 0x6f, 0xe0, 0x20, 0x70, 0x4f, 0x23, 0x56,
+	0x9d, 0xdf, 0x15, 0x9e, 0xc4, 0xed, 0xfd, 0x06, 0x50, 0x96, 0x29, 0x99, 0xe3, 0xb5, 0x25, 0x4f,
+	0x01, 0xcf, 0x5e, 0x26, 0xee, 0x59, 0x7a, 0xde, 0xdf, 0x84, 0x72, 0xfb, 0xfc, 0xbc, 0xd5, 0x6c,
+	0xbf, 0xba, 0x6e, 0x9d, 0xbd, 0xad, 0x3e, 0x42, 0x25, 0xc8, 0x37, 0x5b, 0x67, 0xaf, 0xde, 0x56,
+	0x0d, 0x54, 0x81, 0xd2, 0xeb, 0xce, 0x95, 0xdd, 0xea, 0x5c, 0x1e, 0x9f, 0x56, 0x73, 0xcf, 0x7e,
+	0x01, 0xb5, 0xcc, 0xd5, 0x15, 0x15, 0x61, 0xf5, 0xe2, 0x52, 0x65, 0x4d, 0x05, 0x4a, 0xad, 0x8b,
+	0x63, 0xfc, 0xb6, 0x73, 0xdd, 0x6a, 0x56, 0x0d, 0x29, 0xa7, 0x73, 0xf6, 0xaa, 0x7d, 0x51, 0xcd,
+	0x1d, 0x1d, 0xfc, 0xf6, 0x45, 0xdf, 0x15, 0xb7, 0xa3, 0x1b, 0xd9, 0x38, 0x0e, 0x86, 0x93, 0xee,
+	0x8b, 0x9e, 0x37, 0x62, 0x8e, 0x7e, 0x99, 0x1f, 0xf8, 0x63, 0xc2, 0x5e, 0x04, 0x94, 0xdf, 0x53,
+	0x7e, 0x20, 0xdf, 0xf8, 0xfb, 0xe3, 0x9b, 0x35, 0x95, 0x2a, 0x9f, 0xfd, 0x7f, 0x00, 0xb7, 0x4e,
+	0xa1, 0x59, 0x02, 0x18, 0x00, 0x00,
 }
@@ -142,34 +142,214 @@ func (m *HandleUplinkMACCommandRequest) GetCommands() [][]byte {
 	return nil
 }
 
+type MACCommandBlock struct {
+	// Command identifier (specified by the LoRaWAN specs).
+	Cid uint32 `protobuf:"varint,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// MAC-command payload(s).
+	Commands             [][]byte `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MACCommandBlock) Reset()         { *m = MACCommandBlock{} }
+func (m *MACCommandBlock) String() string { return proto.CompactTextString(m) }
+func (*MACCommandBlock) ProtoMessage()    {}
+func (*MACCommandBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3fd7d898ee948e33, []int{2}
+}
+
+func (m *MACCommandBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MACCommandBlock.Unmarshal(m, b)
+}
+func (m *MACCommandBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MACCommandBlock.Marshal(b, m, deterministic)
+}
+func (m *MACCommandBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MACCommandBlock.Merge(m, src)
+}
+func (m *MACCommandBlock) XXX_Size() int {
+	return xxx_messageInfo_MACCommandBlock.Size(m)
+}
+func (m *MACCommandBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_MACCommandBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MACCommandBlock proto.InternalMessageInfo
+
+func (m *MACCommandBlock) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *MACCommandBlock) GetCommands() [][]byte {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+type HandleDownlinkMACCommandsRequest struct {
+	// Request ID.
+	// This ID must be used by the network-controller in the response.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// The max. size (in bytes) available for mac-commands.
+	MaxSize uint32 `protobuf:"varint,3,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// MAC-command blocks which LoRa Server is about to send.
+	MacCommandBlocks     []*MACCommandBlock `protobuf:"bytes,4,rep,name=mac_command_blocks,json=macCommandBlocks,proto3" json:"mac_command_blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HandleDownlinkMACCommandsRequest) Reset()         { *m = HandleDownlinkMACCommandsRequest{} }
+func (m *HandleDownlinkMACCommandsRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleDownlinkMACCommandsRequest) ProtoMessage()    {}
+func (*HandleDownlinkMACCommandsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3fd7d898ee948e33, []int{3}
+}
+
+func (m *HandleDownlinkMACCommandsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleDownlinkMACCommandsRequest.Unmarshal(m, b)
+}
+func (m *HandleDownlinkMACCommandsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleDownlinkMACCommandsRequest.Marshal(b, m, deterministic)
+}
+func (m *HandleDownlinkMACCommandsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleDownlinkMACCommandsRequest.Merge(m, src)
+}
+func (m *HandleDownlinkMACCommandsRequest) XXX_Size() int {
+	return xxx_messageInfo_HandleDownlinkMACCommandsRequest.Size(m)
+}
+func (m *HandleDownlinkMACCommandsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleDownlinkMACCommandsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleDownlinkMACCommandsRequest proto.InternalMessageInfo
+
+func (m *HandleDownlinkMACCommandsRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *HandleDownlinkMACCommandsRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *HandleDownlinkMACCommandsRequest) GetMaxSize() uint32 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *HandleDownlinkMACCommandsRequest) GetMacCommandBlocks() []*MACCommandBlock {
+	if m != nil {
+		return m.MacCommandBlocks
+	}
+	return nil
+}
+
+type HandleDownlinkMACCommandsResponse struct {
+	// Request ID.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// MAC-command blocks to send.
+	// These replace the mac-command blocks of the request. Omitting a block
+	// from the request prevents it from being sent.
+	MacCommandBlocks     []*MACCommandBlock `protobuf:"bytes,2,rep,name=mac_command_blocks,json=macCommandBlocks,proto3" json:"mac_command_blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HandleDownlinkMACCommandsResponse) Reset()         { *m = HandleDownlinkMACCommandsResponse{} }
+func (m *HandleDownlinkMACCommandsResponse) String() string { return proto.CompactTextString(m) }
+func (*HandleDownlinkMACCommandsResponse) ProtoMessage()    {}
+func (*HandleDownlinkMACCommandsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3fd7d898ee948e33, []int{4}
+}
+
+func (m *HandleDownlinkMACCommandsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandleDownlinkMACCommandsResponse.Unmarshal(m, b)
+}
+func (m *HandleDownlinkMACCommandsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandleDownlinkMACCommandsResponse.Marshal(b, m, deterministic)
+}
+func (m *HandleDownlinkMACCommandsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandleDownlinkMACCommandsResponse.Merge(m, src)
+}
+func (m *HandleDownlinkMACCommandsResponse) XXX_Size() int {
+	return xxx_messageInfo_HandleDownlinkMACCommandsResponse.Size(m)
+}
+func (m *HandleDownlinkMACCommandsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandleDownlinkMACCommandsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandleDownlinkMACCommandsResponse proto.InternalMessageInfo
+
+func (m *HandleDownlinkMACCommandsResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *HandleDownlinkMACCommandsResponse) GetMacCommandBlocks() []*MACCommandBlock {
+	if m != nil {
+		return m.MacCommandBlocks
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*HandleUplinkMetaDataRequest)(nil), "nc.HandleUplinkMetaDataRequest")
 	proto.RegisterType((*HandleUplinkMACCommandRequest)(nil), "nc.HandleUplinkMACCommandRequest")
+	proto.RegisterType((*MACCommandBlock)(nil), "nc.MACCommandBlock")
+	proto.RegisterType((*HandleDownlinkMACCommandsRequest)(nil), "nc.HandleDownlinkMACCommandsRequest")
+	proto.RegisterType((*HandleDownlinkMACCommandsResponse)(nil), "nc.HandleDownlinkMACCommandsResponse")
 }
 
 func init() { proto.RegisterFile("nc.proto", fileDescriptor_3fd7d898ee948e33) }
 
 var fileDescriptor_3fd7d898ee948e33 = []byte{
-	// 298 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xdf, 0x4a, 0xc3, 0x30,
-	0x18, 0xc5, 0xed, 0x0a, 0xdd, 0x88, 0x13, 0x67, 0x90, 0x59, 0x2a, 0x62, 0xdd, 0xd5, 0xbc, 0x49,
-	0x61, 0x3e, 0x81, 0xd4, 0x81, 0x5e, 0x28, 0x18, 0x1d, 0x78, 0x37, 0xb2, 0xf4, 0x6b, 0x09, 0x6b,
-	0x93, 0x9a, 0xa5, 0xed, 0x7c, 0x07, 0x1f, 0xcb, 0x07, 0x93, 0xb6, 0x43, 0x98, 0x4e, 0xbd, 0xc9,
-	0x9f, 0xc3, 0xef, 0x3b, 0x9c, 0xef, 0xa0, 0x9e, 0xe4, 0x24, 0xd7, 0xca, 0x28, 0xdc, 0x91, 0xdc,
-	0x3b, 0x82, 0x2c, 0x37, 0x6f, 0x41, 0x73, 0xb6, 0xb2, 0x77, 0xc8, 0x72, 0x11, 0x24, 0x55, 0x90,
-	0x54, 0xad, 0x30, 0x7a, 0xb7, 0xd0, 0xe9, 0x2d, 0x93, 0x51, 0x0a, 0xb3, 0x3c, 0x15, 0x72, 0x79,
-	0x0f, 0x86, 0xdd, 0x30, 0xc3, 0x28, 0xbc, 0x16, 0xb0, 0x32, 0xf8, 0x04, 0x75, 0x23, 0x28, 0xe7,
-	0x50, 0x08, 0xd7, 0xf2, 0xad, 0x71, 0x9f, 0x3a, 0x11, 0x94, 0xd3, 0x42, 0xe0, 0x4b, 0xd4, 0x35,
-	0xeb, 0xb9, 0x90, 0xb1, 0x72, 0x3b, 0xbe, 0x35, 0xde, 0x9f, 0x0c, 0x48, 0x52, 0x91, 0xd6, 0xe4,
-	0xf9, 0xe5, 0x4e, 0xc6, 0x8a, 0x3a, 0x66, 0x5d, 0xdf, 0x35, 0xaa, 0x37, 0xa8, 0xed, 0xdb, 0xdb,
-	0x28, 0xdd, 0xa0, 0xba, 0x41, 0x47, 0x31, 0x3a, 0xdb, 0x4a, 0x73, 0x1d, 0x86, 0x2a, 0xcb, 0x98,
-	0x8c, 0xfe, 0xcd, 0x33, 0x40, 0x36, 0x17, 0x51, 0x93, 0xe5, 0x80, 0xd6, 0x4f, 0xec, 0xa1, 0x1e,
-	0x6f, 0x87, 0x57, 0xae, 0xe3, 0xdb, 0xe3, 0x3e, 0xfd, 0xfa, 0x4f, 0x3e, 0x2c, 0xe4, 0x3e, 0x80,
-	0xa9, 0x94, 0x5e, 0x86, 0x4a, 0x1a, 0xad, 0xd2, 0x14, 0xf4, 0x13, 0xe8, 0x52, 0x70, 0xc0, 0x8f,
-	0xe8, 0x78, 0x57, 0x25, 0xf8, 0x9c, 0x48, 0x4e, 0xfe, 0x28, 0xcb, 0x1b, 0x92, 0x44, 0xa9, 0x24,
-	0x85, 0xb6, 0xdb, 0x45, 0x11, 0x93, 0x69, 0xdd, 0xfd, 0x68, 0x0f, 0xcf, 0xd0, 0x70, 0xf7, 0x5e,
-	0xf8, 0xe2, 0x87, 0xe9, 0xf7, 0x9d, 0x7f, 0xb7, 0x5d, 0x38, 0x8d, 0x72, 0xf5, 0x19, 0x00, 0x00,
-	0xff, 0xff, 0x4c, 0xd0, 0x40, 0xae, 0xf8, 0x01, 0x00, 0x00,
+	// 439 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xd1, 0x6e, 0xd3, 0x30,
+	0x14, 0x86, 0x17, 0x67, 0x6a, 0xab, 0xc3, 0x60, 0xc5, 0xa0, 0x91, 0x05, 0x21, 0xb2, 0x08, 0xa4,
+	0x72, 0x93, 0xa2, 0xf1, 0x00, 0x68, 0x74, 0x93, 0xe0, 0x02, 0x24, 0x3c, 0x26, 0x71, 0x17, 0xb9,
+	0xce, 0x69, 0x64, 0x35, 0xb1, 0x43, 0xec, 0x36, 0x65, 0xcf, 0xc0, 0x8b, 0x70, 0xcf, 0x03, 0xa2,
+	0x24, 0x1d, 0x5b, 0xbb, 0xae, 0x13, 0x37, 0x6d, 0x72, 0xf4, 0xe7, 0x3b, 0x5f, 0x9c, 0x1f, 0x7a,
+	0x4a, 0x44, 0x45, 0xa9, 0xad, 0xa6, 0x44, 0x09, 0xff, 0x31, 0xe6, 0x85, 0xfd, 0x39, 0x6c, 0x7e,
+	0xdb, 0xb1, 0xbf, 0xcf, 0x0b, 0x39, 0x4c, 0xab, 0x61, 0x5a, 0xb5, 0x83, 0xf0, 0x97, 0x03, 0xcf,
+	0x3f, 0x72, 0x95, 0x64, 0x78, 0x51, 0x64, 0x52, 0x4d, 0x3f, 0xa3, 0xe5, 0xa7, 0xdc, 0x72, 0x86,
+	0x3f, 0x66, 0x68, 0x2c, 0x7d, 0x06, 0xdd, 0x04, 0xe7, 0x31, 0xce, 0xa4, 0xe7, 0x04, 0xce, 0x60,
+	0x8f, 0x75, 0x12, 0x9c, 0x9f, 0xcd, 0x24, 0x7d, 0x03, 0x5d, 0xbb, 0x88, 0xa5, 0x9a, 0x68, 0x8f,
+	0x04, 0xce, 0xe0, 0xc1, 0x71, 0x3f, 0x4a, 0xab, 0xa8, 0x85, 0x7c, 0xfb, 0xfe, 0x49, 0x4d, 0x34,
+	0xeb, 0xd8, 0x45, 0xfd, 0x5f, 0x47, 0xcb, 0x65, 0xd4, 0x0d, 0xdc, 0xd5, 0x28, 0x5b, 0x46, 0xcb,
+	0x26, 0x1a, 0x4e, 0xe0, 0xc5, 0x8a, 0xcd, 0xc9, 0x68, 0xa4, 0xf3, 0x9c, 0xab, 0xe4, 0x5e, 0x9f,
+	0x3e, 0xb8, 0x42, 0x26, 0x8d, 0xcb, 0x43, 0x56, 0x5f, 0x52, 0x1f, 0x7a, 0xa2, 0x7d, 0xd8, 0x78,
+	0x9d, 0xc0, 0x1d, 0xec, 0xb1, 0x7f, 0xf7, 0xe1, 0x7b, 0xd8, 0xbf, 0x66, 0x7f, 0xc8, 0xb4, 0x98,
+	0x5e, 0x01, 0x9c, 0xcd, 0x00, 0xb2, 0x06, 0xf8, 0xed, 0x40, 0xd0, 0x9a, 0x9e, 0xea, 0x4a, 0xad,
+	0xba, 0x9a, 0x2b, 0xd9, 0x47, 0x40, 0x96, 0xc4, 0x5d, 0x46, 0x64, 0x72, 0x53, 0x9e, 0xac, 0xc8,
+	0x1f, 0x42, 0x2f, 0xe7, 0x8b, 0xd8, 0xc8, 0x4b, 0xf4, 0xdc, 0x46, 0xa0, 0x9b, 0xf3, 0xc5, 0xb9,
+	0xbc, 0x44, 0x7a, 0x02, 0x34, 0xe7, 0x22, 0x5e, 0x2e, 0x8e, 0xc7, 0xb5, 0xab, 0xf1, 0x76, 0x9b,
+	0x73, 0x7c, 0x12, 0x29, 0x11, 0xad, 0xbd, 0x07, 0xeb, 0xe7, 0x5c, 0xdc, 0x1c, 0x98, 0x70, 0x0e,
+	0x47, 0x5b, 0x54, 0x4d, 0xa1, 0x95, 0xc1, 0x5b, 0xae, 0x9b, 0xf7, 0x92, 0xff, 0xd8, 0x7b, 0xfc,
+	0x87, 0x80, 0xf7, 0x05, 0x6d, 0xa5, 0xcb, 0xe9, 0x48, 0x2b, 0x5b, 0xea, 0x2c, 0xc3, 0xf2, 0x1c,
+	0xcb, 0xb9, 0x14, 0x48, 0xbf, 0xc2, 0xd3, 0x4d, 0xbd, 0xa3, 0x2f, 0x6b, 0xf6, 0x96, 0x46, 0xfa,
+	0x07, 0x51, 0xaa, 0x75, 0x9a, 0x61, 0x5b, 0xe0, 0xf1, 0x6c, 0x12, 0x9d, 0xd5, 0x05, 0x0f, 0x77,
+	0xe8, 0x05, 0x1c, 0x6c, 0x2e, 0x0f, 0x3d, 0xba, 0x05, 0x5d, 0x2f, 0xd6, 0x16, 0xac, 0x82, 0xc3,
+	0x3b, 0x8f, 0x8f, 0xbe, 0xba, 0x26, 0xdf, 0x5d, 0x04, 0xff, 0xf5, 0x3d, 0xa9, 0xf6, 0x1b, 0x84,
+	0x3b, 0x03, 0xe7, 0xad, 0x33, 0xee, 0x34, 0x06, 0xef, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff, 0x89,
+	0x80, 0x6d, 0xfa, 0xcd, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -191,6 +371,12 @@ type NetworkControllerServiceClient interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleUplinkMACCommand(ctx context.Context, in *HandleUplinkMACCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HandleDownlinkMACCommands opens a bi-directional stream over which the
+	// network-server sends the mac-commands it is about to send to a device.
+	// For each request, the network-controller must respond with the
+	// mac-commands that must be sent instead. This makes it possible to veto
+	// or modify the mac-commands and to inject additional mac-commands.
+	HandleDownlinkMACCommands(ctx context.Context, opts ...grpc.CallOption) (NetworkControllerService_HandleDownlinkMACCommandsClient, error)
 }
 
 type networkControllerServiceClient struct {
@@ -219,6 +405,37 @@ func (c *networkControllerServiceClient) HandleUplinkMACCommand(ctx context.Cont
 	return out, nil
 }
 
+func (c *networkControllerServiceClient) HandleDownlinkMACCommands(ctx context.Context, opts ...grpc.CallOption) (NetworkControllerService_HandleDownlinkMACCommandsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NetworkControllerService_serviceDesc.Streams[0], "/nc.NetworkControllerService/HandleDownlinkMACCommands", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkControllerServiceHandleDownlinkMACCommandsClient{stream}
+	return x, nil
+}
+
+type NetworkControllerService_HandleDownlinkMACCommandsClient interface {
+	Send(*HandleDownlinkMACCommandsRequest) error
+	Recv() (*HandleDownlinkMACCommandsResponse, error)
+	grpc.ClientStream
+}
+
+type networkControllerServiceHandleDownlinkMACCommandsClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkControllerServiceHandleDownlinkMACCommandsClient) Send(m *HandleDownlinkMACCommandsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *networkControllerServiceHandleDownlinkMACCommandsClient) Recv() (*HandleDownlinkMACCommandsResponse, error) {
+	m := new(HandleDownlinkMACCommandsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // NetworkControllerServiceServer is the server API for NetworkControllerService service.
 type NetworkControllerServiceServer interface {
 	// HandleUplinkMetaData handles uplink meta-rata.
@@ -228,6 +445,12 @@ type NetworkControllerServiceServer interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleUplinkMACCommand(context.Context, *HandleUplinkMACCommandRequest) (*empty.Empty, error)
+	// HandleDownlinkMACCommands opens a bi-directional stream over which the
+	// network-server sends the mac-commands it is about to send to a device.
+	// For each request, the network-controller must respond with the
+	// mac-commands that must be sent instead. This makes it possible to veto
+	// or modify the mac-commands and to inject additional mac-commands.
+	HandleDownlinkMACCommands(NetworkControllerService_HandleDownlinkMACCommandsServer) error
 }
 
 // UnimplementedNetworkControllerServiceServer can be embedded to have forward compatible implementations.
@@ -240,6 +463,9 @@ func (*UnimplementedNetworkControllerServiceServer) HandleUplinkMetaData(ctx con
 func (*UnimplementedNetworkControllerServiceServer) HandleUplinkMACCommand(ctx context.Context, req *HandleUplinkMACCommandRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleUplinkMACCommand not implemented")
 }
+func (*UnimplementedNetworkControllerServiceServer) HandleDownlinkMACCommands(srv NetworkControllerService_HandleDownlinkMACCommandsServer) error {
+	return status.Errorf(codes.Unimplemented, "method HandleDownlinkMACCommands not implemented")
+}
 
 func RegisterNetworkControllerServiceServer(s *grpc.Server, srv NetworkControllerServiceServer) {
 	s.RegisterService(&_NetworkControllerService_serviceDesc, srv)
@@ -281,6 +507,32 @@ func _NetworkControllerService_HandleUplinkMACCommand_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkControllerService_HandleDownlinkMACCommands_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NetworkControllerServiceServer).HandleDownlinkMACCommands(&networkControllerServiceHandleDownlinkMACCommandsServer{stream})
+}
+
+type NetworkControllerService_HandleDownlinkMACCommandsServer interface {
+	Send(*HandleDownlinkMACCommandsResponse) error
+	Recv() (*HandleDownlinkMACCommandsRequest, error)
+	grpc.ServerStream
+}
+
+type networkControllerServiceHandleDownlinkMACCommandsServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkControllerServiceHandleDownlinkMACCommandsServer) Send(m *HandleDownlinkMACCommandsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *networkControllerServiceHandleDownlinkMACCommandsServer) Recv() (*HandleDownlinkMACCommandsRequest, error) {
+	m := new(HandleDownlinkMACCommandsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _NetworkControllerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nc.NetworkControllerService",
 	HandlerType: (*NetworkControllerServiceServer)(nil),
@@ -294,6 +546,13 @@ var _NetworkControllerService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _NetworkControllerService_HandleUplinkMACCommand_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "HandleDownlinkMACCommands",
+			Handler:       _NetworkControllerService_HandleDownlinkMACCommands_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "nc.proto",
 }
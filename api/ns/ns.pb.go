@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	common "github.com/mxc-foundation/lpwan-server/api/common"
@@ -28,6 +29,103 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type SecuritySeverity int32
+
+const (
+	// Low
+	SecuritySeverity_LOW SecuritySeverity = 0
+	// Medium
+	SecuritySeverity_MEDIUM SecuritySeverity = 1
+	// High
+	SecuritySeverity_HIGH SecuritySeverity = 2
+	// Critical
+	SecuritySeverity_CRITICAL SecuritySeverity = 3
+)
+
+var SecuritySeverity_name = map[int32]string{
+	0: "LOW",
+	1: "MEDIUM",
+	2: "HIGH",
+	3: "CRITICAL",
+}
+
+var SecuritySeverity_value = map[string]int32{
+	"LOW":      0,
+	"MEDIUM":   1,
+	"HIGH":     2,
+	"CRITICAL": 3,
+}
+
+func (x SecuritySeverity) String() string {
+	return proto.EnumName(SecuritySeverity_name, int32(x))
+}
+
+func (SecuritySeverity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{0}
+}
+
+type GatewayContributionType int32
+
+const (
+	// Uplink frame received
+	GatewayContributionType_UPLINK GatewayContributionType = 0
+	// Downlink frame transmitted
+	GatewayContributionType_DOWNLINK GatewayContributionType = 1
+)
+
+var GatewayContributionType_name = map[int32]string{
+	0: "UPLINK",
+	1: "DOWNLINK",
+}
+
+var GatewayContributionType_value = map[string]int32{
+	"UPLINK":   0,
+	"DOWNLINK": 1,
+}
+
+func (x GatewayContributionType) String() string {
+	return proto.EnumName(GatewayContributionType_name, int32(x))
+}
+
+func (GatewayContributionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{1}
+}
+
+type GatewayConfigurationState int32
+
+const (
+	// No configuration has been sent to the gateway yet.
+	GatewayConfigurationState_CONFIGURATION_UNKNOWN GatewayConfigurationState = 0
+	// The configuration will be sent within the next reconfiguration window.
+	GatewayConfigurationState_CONFIGURATION_SCHEDULED GatewayConfigurationState = 1
+	// The configuration has been sent to the gateway.
+	GatewayConfigurationState_CONFIGURATION_SENT GatewayConfigurationState = 2
+	// The gateway reported the configuration version.
+	GatewayConfigurationState_CONFIGURATION_APPLIED GatewayConfigurationState = 3
+)
+
+var GatewayConfigurationState_name = map[int32]string{
+	0: "CONFIGURATION_UNKNOWN",
+	1: "CONFIGURATION_SCHEDULED",
+	2: "CONFIGURATION_SENT",
+	3: "CONFIGURATION_APPLIED",
+}
+
+var GatewayConfigurationState_value = map[string]int32{
+	"CONFIGURATION_UNKNOWN":   0,
+	"CONFIGURATION_SCHEDULED": 1,
+	"CONFIGURATION_SENT":      2,
+	"CONFIGURATION_APPLIED":   3,
+}
+
+func (x GatewayConfigurationState) String() string {
+	return proto.EnumName(GatewayConfigurationState_name, int32(x))
+}
+
+func (GatewayConfigurationState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{2}
+}
+
 type RXWindow int32
 
 const (
@@ -52,7 +150,39 @@ func (x RXWindow) String() string {
 }
 
 func (RXWindow) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{0}
+	return fileDescriptor_3b280de855f92a4a, []int{3}
+}
+
+type DeviceClassHealthStatus int32
+
+const (
+	// No Class-B / Class-C confirmed downlink has been acknowledged (yet).
+	DeviceClassHealthStatus_CLASS_HEALTH_UNKNOWN DeviceClassHealthStatus = 0
+	// The device acknowledges its Class-B / Class-C confirmed downlinks.
+	DeviceClassHealthStatus_CLASS_HEALTH_OK DeviceClassHealthStatus = 1
+	// The last Class-B / Class-C confirmed downlinks have not been
+	// acknowledged, the configured device-class is probably incorrect.
+	DeviceClassHealthStatus_CLASS_HEALTH_MISCLASSIFIED DeviceClassHealthStatus = 2
+)
+
+var DeviceClassHealthStatus_name = map[int32]string{
+	0: "CLASS_HEALTH_UNKNOWN",
+	1: "CLASS_HEALTH_OK",
+	2: "CLASS_HEALTH_MISCLASSIFIED",
+}
+
+var DeviceClassHealthStatus_value = map[string]int32{
+	"CLASS_HEALTH_UNKNOWN":       0,
+	"CLASS_HEALTH_OK":            1,
+	"CLASS_HEALTH_MISCLASSIFIED": 2,
+}
+
+func (x DeviceClassHealthStatus) String() string {
+	return proto.EnumName(DeviceClassHealthStatus_name, int32(x))
+}
+
+func (DeviceClassHealthStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{4}
 }
 
 type AggregationInterval int32
@@ -95,7 +225,85 @@ func (x AggregationInterval) String() string {
 }
 
 func (AggregationInterval) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{1}
+	return fileDescriptor_3b280de855f92a4a, []int{5}
+}
+
+type MType int32
+
+const (
+	// Join-request.
+	MType_JOIN_REQUEST MType = 0
+	// Join-accept.
+	MType_JOIN_ACCEPT MType = 1
+	// Unconfirmed data-up.
+	MType_UNCONFIRMED_DATA_UP MType = 2
+	// Unconfirmed data-down.
+	MType_UNCONFIRMED_DATA_DOWN MType = 3
+	// Confirmed data-up.
+	MType_CONFIRMED_DATA_UP MType = 4
+	// Confirmed data-down.
+	MType_CONFIRMED_DATA_DOWN MType = 5
+	// Rejoin-request.
+	MType_REJOIN_REQUEST MType = 6
+	// Proprietary.
+	MType_PROPRIETARY MType = 7
+)
+
+var MType_name = map[int32]string{
+	0: "JOIN_REQUEST",
+	1: "JOIN_ACCEPT",
+	2: "UNCONFIRMED_DATA_UP",
+	3: "UNCONFIRMED_DATA_DOWN",
+	4: "CONFIRMED_DATA_UP",
+	5: "CONFIRMED_DATA_DOWN",
+	6: "REJOIN_REQUEST",
+	7: "PROPRIETARY",
+}
+
+var MType_value = map[string]int32{
+	"JOIN_REQUEST":          0,
+	"JOIN_ACCEPT":           1,
+	"UNCONFIRMED_DATA_UP":   2,
+	"UNCONFIRMED_DATA_DOWN": 3,
+	"CONFIRMED_DATA_UP":     4,
+	"CONFIRMED_DATA_DOWN":   5,
+	"REJOIN_REQUEST":        6,
+	"PROPRIETARY":           7,
+}
+
+func (x MType) String() string {
+	return proto.EnumName(MType_name, int32(x))
+}
+
+func (MType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{6}
+}
+
+type FrameLogCaptureFormat int32
+
+const (
+	// JSON document containing the capture meta-data and frames.
+	FrameLogCaptureFormat_JSON FrameLogCaptureFormat = 0
+	// PCAPNG using the LoRaTap link-type (Wireshark).
+	FrameLogCaptureFormat_PCAPNG FrameLogCaptureFormat = 1
+)
+
+var FrameLogCaptureFormat_name = map[int32]string{
+	0: "JSON",
+	1: "PCAPNG",
+}
+
+var FrameLogCaptureFormat_value = map[string]int32{
+	"JSON":   0,
+	"PCAPNG": 1,
+}
+
+func (x FrameLogCaptureFormat) String() string {
+	return proto.EnumName(FrameLogCaptureFormat_name, int32(x))
+}
+
+func (FrameLogCaptureFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{7}
 }
 
 type MulticastGroupType int32
@@ -122,7 +330,75 @@ func (x MulticastGroupType) String() string {
 }
 
 func (MulticastGroupType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{2}
+	return fileDescriptor_3b280de855f92a4a, []int{8}
+}
+
+type MulticastTXState int32
+
+const (
+	// The frame has been sent to the gateway, but the gateway did not (yet)
+	// acknowledge the transmission.
+	MulticastTXState_PENDING MulticastTXState = 0
+	// The gateway acknowledged the transmission.
+	MulticastTXState_ACKED MulticastTXState = 1
+	// The gateway reported an error for the last retry.
+	MulticastTXState_FAILED MulticastTXState = 2
+)
+
+var MulticastTXState_name = map[int32]string{
+	0: "PENDING",
+	1: "ACKED",
+	2: "FAILED",
+}
+
+var MulticastTXState_value = map[string]int32{
+	"PENDING": 0,
+	"ACKED":   1,
+	"FAILED":  2,
+}
+
+func (x MulticastTXState) String() string {
+	return proto.EnumName(MulticastTXState_name, int32(x))
+}
+
+func (MulticastTXState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{9}
+}
+
+type MulticastSetupState int32
+
+const (
+	// The McGroupSetupReq has been enqueued.
+	MulticastSetupState_SETUP_GROUP_PENDING MulticastSetupState = 0
+	// The McGroupSetupAns has been received and the session request
+	// (McClassCSessionReq or McClassBSessionReq) has been enqueued.
+	MulticastSetupState_SETUP_SESSION_PENDING MulticastSetupState = 1
+	// The session answer has been received.
+	MulticastSetupState_SETUP_COMPLETED MulticastSetupState = 2
+	// The setup failed (see error).
+	MulticastSetupState_SETUP_FAILED MulticastSetupState = 3
+)
+
+var MulticastSetupState_name = map[int32]string{
+	0: "SETUP_GROUP_PENDING",
+	1: "SETUP_SESSION_PENDING",
+	2: "SETUP_COMPLETED",
+	3: "SETUP_FAILED",
+}
+
+var MulticastSetupState_value = map[string]int32{
+	"SETUP_GROUP_PENDING":   0,
+	"SETUP_SESSION_PENDING": 1,
+	"SETUP_COMPLETED":       2,
+	"SETUP_FAILED":          3,
+}
+
+func (x MulticastSetupState) String() string {
+	return proto.EnumName(MulticastSetupState_name, int32(x))
+}
+
+func (MulticastSetupState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{10}
 }
 
 type CreateServiceProfileRequest struct {
@@ -915,10 +1191,14 @@ type Device struct {
 	// When using geolocation, this altitude will be used as a reference
 	// (when supported by the geolocation-server) to increase geolocation
 	// accuracy.
-	ReferenceAltitude    float64  `protobuf:"fixed64,6,opt,name=reference_altitude,json=referenceAltitude,proto3" json:"reference_altitude,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ReferenceAltitude float64 `protobuf:"fixed64,6,opt,name=reference_altitude,json=referenceAltitude,proto3" json:"reference_altitude,omitempty"`
+	// Certification test mode.
+	// When enabled, the LoRaWAN Certification Protocol (FPort 224) is handled
+	// by the network-server. This is intended for device certification only.
+	CertificationTestMode bool     `protobuf:"varint,7,opt,name=certification_test_mode,json=certificationTestMode,proto3" json:"certification_test_mode,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *Device) Reset()         { *m = Device{} }
@@ -988,6 +1268,13 @@ func (m *Device) GetReferenceAltitude() float64 {
 	return 0
 }
 
+func (m *Device) GetCertificationTestMode() bool {
+	if m != nil {
+		return m.CertificationTestMode
+	}
+	return false
+}
+
 type CreateDeviceRequest struct {
 	// Device object to create.
 	Device               *Device  `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
@@ -1166,6 +1453,66 @@ func (m *UpdateDeviceRequest) GetDevice() *Device {
 	return nil
 }
 
+type TransferDeviceRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Service-profile ID to move the device to.
+	// When not set, the service-profile is not changed.
+	ServiceProfileId []byte `protobuf:"bytes,2,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID to move the device to.
+	// When not set, the routing-profile is not changed.
+	RoutingProfileId     []byte   `protobuf:"bytes,3,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferDeviceRequest) Reset()         { *m = TransferDeviceRequest{} }
+func (m *TransferDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferDeviceRequest) ProtoMessage()    {}
+func (*TransferDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{23}
+}
+
+func (m *TransferDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferDeviceRequest.Unmarshal(m, b)
+}
+func (m *TransferDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferDeviceRequest.Marshal(b, m, deterministic)
+}
+func (m *TransferDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferDeviceRequest.Merge(m, src)
+}
+func (m *TransferDeviceRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferDeviceRequest.Size(m)
+}
+func (m *TransferDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferDeviceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferDeviceRequest proto.InternalMessageInfo
+
+func (m *TransferDeviceRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *TransferDeviceRequest) GetServiceProfileId() []byte {
+	if m != nil {
+		return m.ServiceProfileId
+	}
+	return nil
+}
+
+func (m *TransferDeviceRequest) GetRoutingProfileId() []byte {
+	if m != nil {
+		return m.RoutingProfileId
+	}
+	return nil
+}
+
 type DeleteDeviceRequest struct {
 	// DevEUI.
 	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -1178,7 +1525,7 @@ func (m *DeleteDeviceRequest) Reset()         { *m = DeleteDeviceRequest{} }
 func (m *DeleteDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteDeviceRequest) ProtoMessage()    {}
 func (*DeleteDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{23}
+	return fileDescriptor_3b280de855f92a4a, []int{24}
 }
 
 func (m *DeleteDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1234,7 +1581,7 @@ func (m *DeviceActivation) Reset()         { *m = DeviceActivation{} }
 func (m *DeviceActivation) String() string { return proto.CompactTextString(m) }
 func (*DeviceActivation) ProtoMessage()    {}
 func (*DeviceActivation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{24}
+	return fileDescriptor_3b280de855f92a4a, []int{25}
 }
 
 func (m *DeviceActivation) XXX_Unmarshal(b []byte) error {
@@ -1330,7 +1677,7 @@ func (m *ActivateDeviceRequest) Reset()         { *m = ActivateDeviceRequest{} }
 func (m *ActivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*ActivateDeviceRequest) ProtoMessage()    {}
 func (*ActivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{25}
+	return fileDescriptor_3b280de855f92a4a, []int{26}
 }
 
 func (m *ActivateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1370,7 +1717,7 @@ func (m *DeactivateDeviceRequest) Reset()         { *m = DeactivateDeviceRequest
 func (m *DeactivateDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*DeactivateDeviceRequest) ProtoMessage()    {}
 func (*DeactivateDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{26}
+	return fileDescriptor_3b280de855f92a4a, []int{27}
 }
 
 func (m *DeactivateDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -1410,7 +1757,7 @@ func (m *GetDeviceActivationRequest) Reset()         { *m = GetDeviceActivationR
 func (m *GetDeviceActivationRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationRequest) ProtoMessage()    {}
 func (*GetDeviceActivationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{27}
+	return fileDescriptor_3b280de855f92a4a, []int{28}
 }
 
 func (m *GetDeviceActivationRequest) XXX_Unmarshal(b []byte) error {
@@ -1450,7 +1797,7 @@ func (m *GetDeviceActivationResponse) Reset()         { *m = GetDeviceActivation
 func (m *GetDeviceActivationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceActivationResponse) ProtoMessage()    {}
 func (*GetDeviceActivationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{28}
+	return fileDescriptor_3b280de855f92a4a, []int{29}
 }
 
 func (m *GetDeviceActivationResponse) XXX_Unmarshal(b []byte) error {
@@ -1478,130 +1825,392 @@ func (m *GetDeviceActivationResponse) GetDeviceActivation() *DeviceActivation {
 	return nil
 }
 
-type GetRandomDevAddrResponse struct {
-	// Random device address (DevAddr).
-	// Note that this includes the NetID prefix of the network-server.
-	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+type GetDeviceClassHealthRequest struct {
+	// Device EUI (8 bytes).
+	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrResponse{} }
-func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
-func (*GetRandomDevAddrResponse) ProtoMessage()    {}
-func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{29}
+func (m *GetDeviceClassHealthRequest) Reset()         { *m = GetDeviceClassHealthRequest{} }
+func (m *GetDeviceClassHealthRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceClassHealthRequest) ProtoMessage()    {}
+func (*GetDeviceClassHealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{30}
 }
 
-func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
+func (m *GetDeviceClassHealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceClassHealthRequest.Unmarshal(m, b)
 }
-func (m *GetRandomDevAddrResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Marshal(b, m, deterministic)
+func (m *GetDeviceClassHealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceClassHealthRequest.Marshal(b, m, deterministic)
 }
-func (m *GetRandomDevAddrResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRandomDevAddrResponse.Merge(m, src)
+func (m *GetDeviceClassHealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceClassHealthRequest.Merge(m, src)
 }
-func (m *GetRandomDevAddrResponse) XXX_Size() int {
-	return xxx_messageInfo_GetRandomDevAddrResponse.Size(m)
+func (m *GetDeviceClassHealthRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceClassHealthRequest.Size(m)
 }
-func (m *GetRandomDevAddrResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRandomDevAddrResponse.DiscardUnknown(m)
+func (m *GetDeviceClassHealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceClassHealthRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRandomDevAddrResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDeviceClassHealthRequest proto.InternalMessageInfo
 
-func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+func (m *GetDeviceClassHealthRequest) GetDevEui() []byte {
 	if m != nil {
-		return m.DevAddr
+		return m.DevEui
 	}
 	return nil
 }
 
-type CreateMACCommandQueueItemRequest struct {
-	// DevEUI EUI (8 bytes).
-	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
-	// Command identifier (specified by the LoRaWAN specs).
-	Cid uint32 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
-	// MAC-command(s). In case multiple payloads are defined, then they
-	// are always sent within a single frame.
-	Commands             [][]byte `protobuf:"bytes,5,rep,name=commands,proto3" json:"commands,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetDeviceClassHealthResponse struct {
+	// Configured device-class (A, B or C).
+	DeviceClass string `protobuf:"bytes,1,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	// Health status.
+	Status DeviceClassHealthStatus `protobuf:"varint,2,opt,name=status,proto3,enum=ns.DeviceClassHealthStatus" json:"status,omitempty"`
+	// Number of Class-B / Class-C downlinks sent.
+	Downlinks uint32 `protobuf:"varint,3,opt,name=downlinks,proto3" json:"downlinks,omitempty"`
+	// Number of Class-B / Class-C confirmed downlinks sent.
+	ConfirmedDownlinks uint32 `protobuf:"varint,4,opt,name=confirmed_downlinks,json=confirmedDownlinks,proto3" json:"confirmed_downlinks,omitempty"`
+	// Number of acknowledged Class-B / Class-C confirmed downlinks.
+	Acknowledged uint32 `protobuf:"varint,5,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	// Number of Class-B / Class-C confirmed downlinks which timed out.
+	Timeouts uint32 `protobuf:"varint,6,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	// Number of consecutive Class-B / Class-C confirmed downlinks which
+	// timed out.
+	ConsecutiveTimeouts uint32 `protobuf:"varint,7,opt,name=consecutive_timeouts,json=consecutiveTimeouts,proto3" json:"consecutive_timeouts,omitempty"`
+	// Last acknowledgement timestamp.
+	LastAcknowledgedAt   *timestamp.Timestamp `protobuf:"bytes,8,opt,name=last_acknowledged_at,json=lastAcknowledgedAt,proto3" json:"last_acknowledged_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACCommandQueueItemRequest{} }
-func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
-func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{30}
+func (m *GetDeviceClassHealthResponse) Reset()         { *m = GetDeviceClassHealthResponse{} }
+func (m *GetDeviceClassHealthResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceClassHealthResponse) ProtoMessage()    {}
+func (*GetDeviceClassHealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{31}
 }
 
-func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Unmarshal(m, b)
+func (m *GetDeviceClassHealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDeviceClassHealthResponse.Unmarshal(m, b)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Marshal(b, m, deterministic)
+func (m *GetDeviceClassHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDeviceClassHealthResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMACCommandQueueItemRequest.Merge(m, src)
+func (m *GetDeviceClassHealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDeviceClassHealthResponse.Merge(m, src)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Size(m)
+func (m *GetDeviceClassHealthResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDeviceClassHealthResponse.Size(m)
 }
-func (m *CreateMACCommandQueueItemRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMACCommandQueueItemRequest.DiscardUnknown(m)
+func (m *GetDeviceClassHealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDeviceClassHealthResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateMACCommandQueueItemRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetDeviceClassHealthResponse proto.InternalMessageInfo
 
-func (m *CreateMACCommandQueueItemRequest) GetDevEui() []byte {
+func (m *GetDeviceClassHealthResponse) GetDeviceClass() string {
 	if m != nil {
-		return m.DevEui
+		return m.DeviceClass
 	}
-	return nil
+	return ""
 }
 
-func (m *CreateMACCommandQueueItemRequest) GetCid() uint32 {
+func (m *GetDeviceClassHealthResponse) GetStatus() DeviceClassHealthStatus {
 	if m != nil {
-		return m.Cid
+		return m.Status
+	}
+	return DeviceClassHealthStatus_CLASS_HEALTH_UNKNOWN
+}
+
+func (m *GetDeviceClassHealthResponse) GetDownlinks() uint32 {
+	if m != nil {
+		return m.Downlinks
 	}
 	return 0
 }
 
-func (m *CreateMACCommandQueueItemRequest) GetCommands() [][]byte {
+func (m *GetDeviceClassHealthResponse) GetConfirmedDownlinks() uint32 {
 	if m != nil {
-		return m.Commands
+		return m.ConfirmedDownlinks
 	}
-	return nil
+	return 0
 }
 
-type SendProprietaryPayloadRequest struct {
-	// MACPayload of the proprietary LoRaWAN frame.
-	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
-	// MIC of the proprietary LoRaWAN frame (must be 4 bytes).
-	Mic []byte `protobuf:"bytes,2,opt,name=mic,proto3" json:"mic,omitempty"`
-	// Gateway MAC address(es) to use for transmitting the LoRaWAN frame.
-	GatewayMacs [][]byte `protobuf:"bytes,3,rep,name=gateway_macs,json=gatewayMacs,proto3" json:"gateway_macs,omitempty"`
-	// Set to true for sending as a gateway, or false for sending as a node.
-	// In the latter case the frame will be received by other gateways.
-	PolarizationInversion bool `protobuf:"varint,4,opt,name=polarization_inversion,json=polarizationInversion,proto3" json:"polarization_inversion,omitempty"`
-	// Frequency (Hz) to use for the transmission.
-	Frequency uint32 `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	// Data-rate to use for the transmission.
-	Dr                   uint32   `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GetDeviceClassHealthResponse) GetAcknowledged() uint32 {
+	if m != nil {
+		return m.Acknowledged
+	}
+	return 0
 }
 
-func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPayloadRequest{} }
+func (m *GetDeviceClassHealthResponse) GetTimeouts() uint32 {
+	if m != nil {
+		return m.Timeouts
+	}
+	return 0
+}
+
+func (m *GetDeviceClassHealthResponse) GetConsecutiveTimeouts() uint32 {
+	if m != nil {
+		return m.ConsecutiveTimeouts
+	}
+	return 0
+}
+
+func (m *GetDeviceClassHealthResponse) GetLastAcknowledgedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastAcknowledgedAt
+	}
+	return nil
+}
+
+type ProvisionABPDeviceRequest struct {
+	// Device object to create.
+	Device *Device `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	// Device-activation (the dev_eui is ignored).
+	// When the dev_addr is not set, a free DevAddr is allocated.
+	DeviceActivation *DeviceActivation `protobuf:"bytes,2,opt,name=device_activation,json=deviceActivation,proto3" json:"device_activation,omitempty"`
+	// AppSKey (optional).
+	// When set, the AppSKey is forwarded to the application-server together
+	// with the first uplink.
+	AppSKey *common.KeyEnvelope `protobuf:"bytes,3,opt,name=app_s_key,json=appSKey,proto3" json:"app_s_key,omitempty"`
+	// Enabled uplink channels (optional).
+	// When not set, the channels defined by the LoRaWAN Regional Parameters
+	// are enabled.
+	EnabledUplinkChannels []uint32 `protobuf:"varint,4,rep,packed,name=enabled_uplink_channels,json=enabledUplinkChannels,proto3" json:"enabled_uplink_channels,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *ProvisionABPDeviceRequest) Reset()         { *m = ProvisionABPDeviceRequest{} }
+func (m *ProvisionABPDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*ProvisionABPDeviceRequest) ProtoMessage()    {}
+func (*ProvisionABPDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{32}
+}
+
+func (m *ProvisionABPDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProvisionABPDeviceRequest.Unmarshal(m, b)
+}
+func (m *ProvisionABPDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProvisionABPDeviceRequest.Marshal(b, m, deterministic)
+}
+func (m *ProvisionABPDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvisionABPDeviceRequest.Merge(m, src)
+}
+func (m *ProvisionABPDeviceRequest) XXX_Size() int {
+	return xxx_messageInfo_ProvisionABPDeviceRequest.Size(m)
+}
+func (m *ProvisionABPDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvisionABPDeviceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProvisionABPDeviceRequest proto.InternalMessageInfo
+
+func (m *ProvisionABPDeviceRequest) GetDevice() *Device {
+	if m != nil {
+		return m.Device
+	}
+	return nil
+}
+
+func (m *ProvisionABPDeviceRequest) GetDeviceActivation() *DeviceActivation {
+	if m != nil {
+		return m.DeviceActivation
+	}
+	return nil
+}
+
+func (m *ProvisionABPDeviceRequest) GetAppSKey() *common.KeyEnvelope {
+	if m != nil {
+		return m.AppSKey
+	}
+	return nil
+}
+
+func (m *ProvisionABPDeviceRequest) GetEnabledUplinkChannels() []uint32 {
+	if m != nil {
+		return m.EnabledUplinkChannels
+	}
+	return nil
+}
+
+type ProvisionABPDeviceResponse struct {
+	// Device address (DevAddr) of the activation.
+	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProvisionABPDeviceResponse) Reset()         { *m = ProvisionABPDeviceResponse{} }
+func (m *ProvisionABPDeviceResponse) String() string { return proto.CompactTextString(m) }
+func (*ProvisionABPDeviceResponse) ProtoMessage()    {}
+func (*ProvisionABPDeviceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{33}
+}
+
+func (m *ProvisionABPDeviceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProvisionABPDeviceResponse.Unmarshal(m, b)
+}
+func (m *ProvisionABPDeviceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProvisionABPDeviceResponse.Marshal(b, m, deterministic)
+}
+func (m *ProvisionABPDeviceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProvisionABPDeviceResponse.Merge(m, src)
+}
+func (m *ProvisionABPDeviceResponse) XXX_Size() int {
+	return xxx_messageInfo_ProvisionABPDeviceResponse.Size(m)
+}
+func (m *ProvisionABPDeviceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProvisionABPDeviceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProvisionABPDeviceResponse proto.InternalMessageInfo
+
+func (m *ProvisionABPDeviceResponse) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+type GetRandomDevAddrResponse struct {
+	// Random device address (DevAddr).
+	// Note that this includes the NetID prefix of the network-server.
+	DevAddr              []byte   `protobuf:"bytes,1,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRandomDevAddrResponse) Reset()         { *m = GetRandomDevAddrResponse{} }
+func (m *GetRandomDevAddrResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRandomDevAddrResponse) ProtoMessage()    {}
+func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{34}
+}
+
+func (m *GetRandomDevAddrResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Unmarshal(m, b)
+}
+func (m *GetRandomDevAddrResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRandomDevAddrResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRandomDevAddrResponse.Merge(m, src)
+}
+func (m *GetRandomDevAddrResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRandomDevAddrResponse.Size(m)
+}
+func (m *GetRandomDevAddrResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRandomDevAddrResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRandomDevAddrResponse proto.InternalMessageInfo
+
+func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+type CreateMACCommandQueueItemRequest struct {
+	// DevEUI EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Command identifier (specified by the LoRaWAN specs).
+	Cid uint32 `protobuf:"varint,4,opt,name=cid,proto3" json:"cid,omitempty"`
+	// MAC-command(s). In case multiple payloads are defined, then they
+	// are always sent within a single frame.
+	Commands             [][]byte `protobuf:"bytes,5,rep,name=commands,proto3" json:"commands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateMACCommandQueueItemRequest) Reset()         { *m = CreateMACCommandQueueItemRequest{} }
+func (m *CreateMACCommandQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMACCommandQueueItemRequest) ProtoMessage()    {}
+func (*CreateMACCommandQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{35}
+}
+
+func (m *CreateMACCommandQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Unmarshal(m, b)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMACCommandQueueItemRequest.Merge(m, src)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMACCommandQueueItemRequest.Size(m)
+}
+func (m *CreateMACCommandQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMACCommandQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMACCommandQueueItemRequest proto.InternalMessageInfo
+
+func (m *CreateMACCommandQueueItemRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *CreateMACCommandQueueItemRequest) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *CreateMACCommandQueueItemRequest) GetCommands() [][]byte {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+type SendProprietaryPayloadRequest struct {
+	// MACPayload of the proprietary LoRaWAN frame.
+	MacPayload []byte `protobuf:"bytes,1,opt,name=mac_payload,json=macPayload,proto3" json:"mac_payload,omitempty"`
+	// MIC of the proprietary LoRaWAN frame (must be 4 bytes).
+	Mic []byte `protobuf:"bytes,2,opt,name=mic,proto3" json:"mic,omitempty"`
+	// Gateway MAC address(es) to use for transmitting the LoRaWAN frame.
+	GatewayMacs [][]byte `protobuf:"bytes,3,rep,name=gateway_macs,json=gatewayMacs,proto3" json:"gateway_macs,omitempty"`
+	// Set to true for sending as a gateway, or false for sending as a node.
+	// In the latter case the frame will be received by other gateways.
+	PolarizationInversion bool `protobuf:"varint,4,opt,name=polarization_inversion,json=polarizationInversion,proto3" json:"polarization_inversion,omitempty"`
+	// Frequency (Hz) to use for the transmission.
+	Frequency uint32 `protobuf:"varint,5,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Data-rate to use for the transmission.
+	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Time since GPS epoch at which the gateways must transmit the frame
+	// (optional).
+	// When set, all gateways transmit the frame at the same time, which
+	// requires the gateways to be GPS synchronized. When not set, the frame
+	// is transmitted immediately.
+	TimeSinceGpsEpoch    *duration.Duration `protobuf:"bytes,7,opt,name=time_since_gps_epoch,json=timeSinceGpsEpoch,proto3" json:"time_since_gps_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SendProprietaryPayloadRequest) Reset()         { *m = SendProprietaryPayloadRequest{} }
 func (m *SendProprietaryPayloadRequest) String() string { return proto.CompactTextString(m) }
 func (*SendProprietaryPayloadRequest) ProtoMessage()    {}
 func (*SendProprietaryPayloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{31}
+	return fileDescriptor_3b280de855f92a4a, []int{36}
 }
 
 func (m *SendProprietaryPayloadRequest) XXX_Unmarshal(b []byte) error {
@@ -1664,6 +2273,13 @@ func (m *SendProprietaryPayloadRequest) GetDr() uint32 {
 	return 0
 }
 
+func (m *SendProprietaryPayloadRequest) GetTimeSinceGpsEpoch() *duration.Duration {
+	if m != nil {
+		return m.TimeSinceGpsEpoch
+	}
+	return nil
+}
+
 type Gateway struct {
 	// Gateway ID (8 bytes EUI64).
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1679,7 +2295,16 @@ type Gateway struct {
 	// Routing Profile ID.
 	// The routing-profile ID defines to which application-server statistical
 	// data for this gateway is forwarded.
-	RoutingProfileId     []byte   `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	RoutingProfileId []byte `protobuf:"bytes,5,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Sub-band (1 - 8) on which the gateway is listening (optional).
+	// This is only used for regions consisting of sub-bands (e.g. US915 and
+	// AU915). The channel-mask of the devices is generated based on the
+	// sub-bands of the gateways receiving the device.
+	SubBand uint32 `protobuf:"varint,6,opt,name=sub_band,json=subBand,proto3" json:"sub_band,omitempty"`
+	// Antenna gain (dBi).
+	AntennaGain float32 `protobuf:"fixed32,7,opt,name=antenna_gain,json=antennaGain,proto3" json:"antenna_gain,omitempty"`
+	// Cable loss (dB).
+	CableLoss            float32  `protobuf:"fixed32,8,opt,name=cable_loss,json=cableLoss,proto3" json:"cable_loss,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1689,7 +2314,7 @@ func (m *Gateway) Reset()         { *m = Gateway{} }
 func (m *Gateway) String() string { return proto.CompactTextString(m) }
 func (*Gateway) ProtoMessage()    {}
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{32}
+	return fileDescriptor_3b280de855f92a4a, []int{37}
 }
 
 func (m *Gateway) XXX_Unmarshal(b []byte) error {
@@ -1745,21 +2370,48 @@ func (m *Gateway) GetRoutingProfileId() []byte {
 	return nil
 }
 
+func (m *Gateway) GetSubBand() uint32 {
+	if m != nil {
+		return m.SubBand
+	}
+	return 0
+}
+
+func (m *Gateway) GetAntennaGain() float32 {
+	if m != nil {
+		return m.AntennaGain
+	}
+	return 0
+}
+
+func (m *Gateway) GetCableLoss() float32 {
+	if m != nil {
+		return m.CableLoss
+	}
+	return 0
+}
+
 type GatewayBoard struct {
 	// FPGA ID of the gateway (8 bytes) (optional).
 	FpgaId []byte `protobuf:"bytes,1,opt,name=fpga_id,json=fpgaId,proto3" json:"fpga_id,omitempty"`
 	// Fine-timestamp AES decryption key (16 bytes) (optional).
-	FineTimestampKey     []byte   `protobuf:"bytes,2,opt,name=fine_timestamp_key,json=fineTimestampKey,proto3" json:"fine_timestamp_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FineTimestampKey []byte `protobuf:"bytes,2,opt,name=fine_timestamp_key,json=fineTimestampKey,proto3" json:"fine_timestamp_key,omitempty"`
+	// Antennas connected to the board (optional).
+	// The index of the antenna in this list must match the antenna index
+	// reported by the gateway. When set, the gain and cable loss of the
+	// antenna receiving the uplink are used (instead of the gateway antenna
+	// gain and cable loss) to calculate the downlink TX power.
+	Antennas             []*GatewayAntenna `protobuf:"bytes,3,rep,name=antennas,proto3" json:"antennas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *GatewayBoard) Reset()         { *m = GatewayBoard{} }
 func (m *GatewayBoard) String() string { return proto.CompactTextString(m) }
 func (*GatewayBoard) ProtoMessage()    {}
 func (*GatewayBoard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{33}
+	return fileDescriptor_3b280de855f92a4a, []int{38}
 }
 
 func (m *GatewayBoard) XXX_Unmarshal(b []byte) error {
@@ -1794,50 +2446,125 @@ func (m *GatewayBoard) GetFineTimestampKey() []byte {
 	return nil
 }
 
-type CreateGatewayRequest struct {
-	// Gateway object to create.
-	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+func (m *GatewayBoard) GetAntennas() []*GatewayAntenna {
+	if m != nil {
+		return m.Antennas
+	}
+	return nil
+}
+
+type GatewayAntenna struct {
+	// Antenna gain (dBi).
+	Gain float32 `protobuf:"fixed32,1,opt,name=gain,proto3" json:"gain,omitempty"`
+	// Cable loss (dB).
+	CableLoss float32 `protobuf:"fixed32,2,opt,name=cable_loss,json=cableLoss,proto3" json:"cable_loss,omitempty"`
+	// Azimuth (degrees, clockwise from north).
+	Azimuth float32 `protobuf:"fixed32,3,opt,name=azimuth,proto3" json:"azimuth,omitempty"`
+	// Horizontal beamwidth (degrees).
+	// A beamwidth of 0 defines an omni-directional antenna.
+	Beamwidth            float32  `protobuf:"fixed32,4,opt,name=beamwidth,proto3" json:"beamwidth,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
-func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateGatewayRequest) ProtoMessage()    {}
-func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{34}
+func (m *GatewayAntenna) Reset()         { *m = GatewayAntenna{} }
+func (m *GatewayAntenna) String() string { return proto.CompactTextString(m) }
+func (*GatewayAntenna) ProtoMessage()    {}
+func (*GatewayAntenna) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{39}
 }
 
-func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateGatewayRequest.Unmarshal(m, b)
+func (m *GatewayAntenna) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayAntenna.Unmarshal(m, b)
 }
-func (m *CreateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateGatewayRequest.Marshal(b, m, deterministic)
+func (m *GatewayAntenna) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayAntenna.Marshal(b, m, deterministic)
 }
-func (m *CreateGatewayRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateGatewayRequest.Merge(m, src)
+func (m *GatewayAntenna) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayAntenna.Merge(m, src)
 }
-func (m *CreateGatewayRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateGatewayRequest.Size(m)
+func (m *GatewayAntenna) XXX_Size() int {
+	return xxx_messageInfo_GatewayAntenna.Size(m)
 }
-func (m *CreateGatewayRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateGatewayRequest.DiscardUnknown(m)
+func (m *GatewayAntenna) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayAntenna.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateGatewayRequest proto.InternalMessageInfo
+var xxx_messageInfo_GatewayAntenna proto.InternalMessageInfo
 
-func (m *CreateGatewayRequest) GetGateway() *Gateway {
+func (m *GatewayAntenna) GetGain() float32 {
 	if m != nil {
-		return m.Gateway
+		return m.Gain
 	}
-	return nil
+	return 0
 }
 
-type GetGatewayRequest struct {
-	// Gateway ID.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+func (m *GatewayAntenna) GetCableLoss() float32 {
+	if m != nil {
+		return m.CableLoss
+	}
+	return 0
+}
+
+func (m *GatewayAntenna) GetAzimuth() float32 {
+	if m != nil {
+		return m.Azimuth
+	}
+	return 0
+}
+
+func (m *GatewayAntenna) GetBeamwidth() float32 {
+	if m != nil {
+		return m.Beamwidth
+	}
+	return 0
+}
+
+type CreateGatewayRequest struct {
+	// Gateway object to create.
+	Gateway              *Gateway `protobuf:"bytes,1,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGatewayRequest) Reset()         { *m = CreateGatewayRequest{} }
+func (m *CreateGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayRequest) ProtoMessage()    {}
+func (*CreateGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{40}
+}
+
+func (m *CreateGatewayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGatewayRequest.Unmarshal(m, b)
+}
+func (m *CreateGatewayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGatewayRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateGatewayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGatewayRequest.Merge(m, src)
+}
+func (m *CreateGatewayRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGatewayRequest.Size(m)
+}
+func (m *CreateGatewayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGatewayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGatewayRequest proto.InternalMessageInfo
+
+func (m *CreateGatewayRequest) GetGateway() *Gateway {
+	if m != nil {
+		return m.Gateway
+	}
+	return nil
+}
+
+type GetGatewayRequest struct {
+	// Gateway ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
@@ -1846,7 +2573,7 @@ func (m *GetGatewayRequest) Reset()         { *m = GetGatewayRequest{} }
 func (m *GetGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayRequest) ProtoMessage()    {}
 func (*GetGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{35}
+	return fileDescriptor_3b280de855f92a4a, []int{41}
 }
 
 func (m *GetGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -1894,7 +2621,7 @@ func (m *GetGatewayResponse) Reset()         { *m = GetGatewayResponse{} }
 func (m *GetGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayResponse) ProtoMessage()    {}
 func (*GetGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{36}
+	return fileDescriptor_3b280de855f92a4a, []int{42}
 }
 
 func (m *GetGatewayResponse) XXX_Unmarshal(b []byte) error {
@@ -1962,7 +2689,7 @@ func (m *UpdateGatewayRequest) Reset()         { *m = UpdateGatewayRequest{} }
 func (m *UpdateGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGatewayRequest) ProtoMessage()    {}
 func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{37}
+	return fileDescriptor_3b280de855f92a4a, []int{43}
 }
 
 func (m *UpdateGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2002,7 +2729,7 @@ func (m *DeleteGatewayRequest) Reset()         { *m = DeleteGatewayRequest{} }
 func (m *DeleteGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteGatewayRequest) ProtoMessage()    {}
 func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{38}
+	return fileDescriptor_3b280de855f92a4a, []int{44}
 }
 
 func (m *DeleteGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2050,7 +2777,7 @@ func (m *GatewayStats) Reset()         { *m = GatewayStats{} }
 func (m *GatewayStats) String() string { return proto.CompactTextString(m) }
 func (*GatewayStats) ProtoMessage()    {}
 func (*GatewayStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{39}
+	return fileDescriptor_3b280de855f92a4a, []int{45}
 }
 
 func (m *GatewayStats) XXX_Unmarshal(b []byte) error {
@@ -2124,7 +2851,7 @@ func (m *GetGatewayStatsRequest) Reset()         { *m = GetGatewayStatsRequest{}
 func (m *GetGatewayStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsRequest) ProtoMessage()    {}
 func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{40}
+	return fileDescriptor_3b280de855f92a4a, []int{46}
 }
 
 func (m *GetGatewayStatsRequest) XXX_Unmarshal(b []byte) error {
@@ -2184,7 +2911,7 @@ func (m *GetGatewayStatsResponse) Reset()         { *m = GetGatewayStatsResponse
 func (m *GetGatewayStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGatewayStatsResponse) ProtoMessage()    {}
 func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{41}
+	return fileDescriptor_3b280de855f92a4a, []int{47}
 }
 
 func (m *GetGatewayStatsResponse) XXX_Unmarshal(b []byte) error {
@@ -2230,7 +2957,18 @@ type DeviceQueueItem struct {
 	// is a gap between the activation and the delivery of the AppSKey to the
 	// application-server, there is a possibility that the application-server
 	// tries to enqueue payloads encrypted with the old session-key.
-	DevAddr              []byte   `protobuf:"bytes,6,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	DevAddr []byte `protobuf:"bytes,6,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Time to live (optional).
+	// When set, the item is removed from the queue when it has not been
+	// transmitted within the given duration and the application-server
+	// is notified using a DEVICE_QUEUE_ITEM_EXPIRED error.
+	Ttl *duration.Duration `protobuf:"bytes,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Timestamp on which the item expires (set by LoRa Server, only
+	// returned by GetDeviceQueueItemsForDevEUI).
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Priority of the item (higher value = higher priority).
+	// This is used by the DROP_LOWEST_PRIORITY device-queue overflow policy.
+	Priority             uint32   `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2240,7 +2978,7 @@ func (m *DeviceQueueItem) Reset()         { *m = DeviceQueueItem{} }
 func (m *DeviceQueueItem) String() string { return proto.CompactTextString(m) }
 func (*DeviceQueueItem) ProtoMessage()    {}
 func (*DeviceQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{42}
+	return fileDescriptor_3b280de855f92a4a, []int{48}
 }
 
 func (m *DeviceQueueItem) XXX_Unmarshal(b []byte) error {
@@ -2303,6 +3041,27 @@ func (m *DeviceQueueItem) GetDevAddr() []byte {
 	return nil
 }
 
+func (m *DeviceQueueItem) GetTtl() *duration.Duration {
+	if m != nil {
+		return m.Ttl
+	}
+	return nil
+}
+
+func (m *DeviceQueueItem) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *DeviceQueueItem) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 type CreateDeviceQueueItemRequest struct {
 	Item                 *DeviceQueueItem `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -2314,7 +3073,7 @@ func (m *CreateDeviceQueueItemRequest) Reset()         { *m = CreateDeviceQueueI
 func (m *CreateDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateDeviceQueueItemRequest) ProtoMessage()    {}
 func (*CreateDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{43}
+	return fileDescriptor_3b280de855f92a4a, []int{49}
 }
 
 func (m *CreateDeviceQueueItemRequest) XXX_Unmarshal(b []byte) error {
@@ -2354,7 +3113,7 @@ func (m *FlushDeviceQueueForDevEUIRequest) Reset()         { *m = FlushDeviceQue
 func (m *FlushDeviceQueueForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*FlushDeviceQueueForDevEUIRequest) ProtoMessage()    {}
 func (*FlushDeviceQueueForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{44}
+	return fileDescriptor_3b280de855f92a4a, []int{50}
 }
 
 func (m *FlushDeviceQueueForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2394,7 +3153,7 @@ func (m *GetDeviceQueueItemsForDevEUIRequest) Reset()         { *m = GetDeviceQu
 func (m *GetDeviceQueueItemsForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIRequest) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{45}
+	return fileDescriptor_3b280de855f92a4a, []int{51}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2433,7 +3192,7 @@ func (m *GetDeviceQueueItemsForDevEUIResponse) Reset()         { *m = GetDeviceQ
 func (m *GetDeviceQueueItemsForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetDeviceQueueItemsForDevEUIResponse) ProtoMessage()    {}
 func (*GetDeviceQueueItemsForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{46}
+	return fileDescriptor_3b280de855f92a4a, []int{52}
 }
 
 func (m *GetDeviceQueueItemsForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2473,7 +3232,7 @@ func (m *GetNextDownlinkFCntForDevEUIRequest) Reset()         { *m = GetNextDown
 func (m *GetNextDownlinkFCntForDevEUIRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIRequest) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{47}
+	return fileDescriptor_3b280de855f92a4a, []int{53}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIRequest) XXX_Unmarshal(b []byte) error {
@@ -2513,7 +3272,7 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) Reset()         { *m = GetNextDow
 func (m *GetNextDownlinkFCntForDevEUIResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNextDownlinkFCntForDevEUIResponse) ProtoMessage()    {}
 func (*GetNextDownlinkFCntForDevEUIResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{48}
+	return fileDescriptor_3b280de855f92a4a, []int{54}
 }
 
 func (m *GetNextDownlinkFCntForDevEUIResponse) XXX_Unmarshal(b []byte) error {
@@ -2541,19 +3300,111 @@ func (m *GetNextDownlinkFCntForDevEUIResponse) GetFCnt() uint32 {
 	return 0
 }
 
-type StreamFrameLogsForGatewayRequest struct {
-	// MAC address of the gateway.
-	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+type FrameLogFilter struct {
+	// Only forward frames of the given message-types.
+	// When empty, all message-types are forwarded.
+	MTypes []MType `protobuf:"varint,1,rep,packed,name=m_types,json=mTypes,proto3,enum=ns.MType" json:"m_types,omitempty"`
+	// Only forward data frames with an FPort >= f_port_min.
+	FPortMin uint32 `protobuf:"varint,2,opt,name=f_port_min,json=fPortMin,proto3" json:"f_port_min,omitempty"`
+	// Only forward data frames with an FPort <= f_port_max.
+	// When both f_port_min and f_port_max are 0, this filter is disabled.
+	FPortMax uint32 `protobuf:"varint,3,opt,name=f_port_max,json=fPortMax,proto3" json:"f_port_max,omitempty"`
+	// Only forward uplink frames received with an RSSI >= min_rssi by at
+	// least one gateway (0 = disabled). Downlink frames are not filtered.
+	MinRssi int32 `protobuf:"varint,4,opt,name=min_rssi,json=minRssi,proto3" json:"min_rssi,omitempty"`
+	// Only forward frames received or transmitted by one of the given
+	// gateway IDs. When empty, frames of all gateways are forwarded.
+	GatewayIds [][]byte `protobuf:"bytes,5,rep,name=gateway_ids,json=gatewayIds,proto3" json:"gateway_ids,omitempty"`
+	// Only forward data frames containing mac-commands (either as FOpts or
+	// as FRMPayload with FPort 0).
+	MacCommandsOnly      bool     `protobuf:"varint,6,opt,name=mac_commands_only,json=macCommandsOnly,proto3" json:"mac_commands_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *FrameLogFilter) Reset()         { *m = FrameLogFilter{} }
+func (m *FrameLogFilter) String() string { return proto.CompactTextString(m) }
+func (*FrameLogFilter) ProtoMessage()    {}
+func (*FrameLogFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{55}
+}
+
+func (m *FrameLogFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FrameLogFilter.Unmarshal(m, b)
+}
+func (m *FrameLogFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FrameLogFilter.Marshal(b, m, deterministic)
+}
+func (m *FrameLogFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrameLogFilter.Merge(m, src)
+}
+func (m *FrameLogFilter) XXX_Size() int {
+	return xxx_messageInfo_FrameLogFilter.Size(m)
+}
+func (m *FrameLogFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrameLogFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FrameLogFilter proto.InternalMessageInfo
+
+func (m *FrameLogFilter) GetMTypes() []MType {
+	if m != nil {
+		return m.MTypes
+	}
+	return nil
+}
+
+func (m *FrameLogFilter) GetFPortMin() uint32 {
+	if m != nil {
+		return m.FPortMin
+	}
+	return 0
+}
+
+func (m *FrameLogFilter) GetFPortMax() uint32 {
+	if m != nil {
+		return m.FPortMax
+	}
+	return 0
+}
+
+func (m *FrameLogFilter) GetMinRssi() int32 {
+	if m != nil {
+		return m.MinRssi
+	}
+	return 0
+}
+
+func (m *FrameLogFilter) GetGatewayIds() [][]byte {
+	if m != nil {
+		return m.GatewayIds
+	}
+	return nil
+}
+
+func (m *FrameLogFilter) GetMacCommandsOnly() bool {
+	if m != nil {
+		return m.MacCommandsOnly
+	}
+	return false
+}
+
+type StreamFrameLogsForGatewayRequest struct {
+	// MAC address of the gateway.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Filter (optional).
+	Filter               *FrameLogFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
 func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLogsForGatewayRequest{} }
 func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{49}
+	return fileDescriptor_3b280de855f92a4a, []int{56}
 }
 
 func (m *StreamFrameLogsForGatewayRequest) XXX_Unmarshal(b []byte) error {
@@ -2581,6 +3432,13 @@ func (m *StreamFrameLogsForGatewayRequest) GetGatewayId() []byte {
 	return nil
 }
 
+func (m *StreamFrameLogsForGatewayRequest) GetFilter() *FrameLogFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 type StreamFrameLogsForGatewayResponse struct {
 	// Types that are valid to be assigned to Frame:
 	//	*StreamFrameLogsForGatewayResponse_UplinkFrameSet
@@ -2595,7 +3453,7 @@ func (m *StreamFrameLogsForGatewayResponse) Reset()         { *m = StreamFrameLo
 func (m *StreamFrameLogsForGatewayResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForGatewayResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForGatewayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{50}
+	return fileDescriptor_3b280de855f92a4a, []int{57}
 }
 
 func (m *StreamFrameLogsForGatewayResponse) XXX_Unmarshal(b []byte) error {
@@ -2628,7 +3486,8 @@ type StreamFrameLogsForGatewayResponse_DownlinkFrame struct {
 	DownlinkFrame *gw.DownlinkFrame `protobuf:"bytes,2,opt,name=downlink_frame,json=downlinkFrame,proto3,oneof"`
 }
 
-func (*StreamFrameLogsForGatewayResponse_UplinkFrameSet) isStreamFrameLogsForGatewayResponse_Frame() {}
+func (*StreamFrameLogsForGatewayResponse_UplinkFrameSet) isStreamFrameLogsForGatewayResponse_Frame() {
+}
 
 func (*StreamFrameLogsForGatewayResponse_DownlinkFrame) isStreamFrameLogsForGatewayResponse_Frame() {}
 
@@ -2663,17 +3522,19 @@ func (*StreamFrameLogsForGatewayResponse) XXX_OneofWrappers() []interface{} {
 
 type StreamFrameLogsForDeviceRequest struct {
 	// DevEUI of the device.
-	DevEui               []byte   `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Filter (optional).
+	Filter               *FrameLogFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *StreamFrameLogsForDeviceRequest) Reset()         { *m = StreamFrameLogsForDeviceRequest{} }
 func (m *StreamFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceRequest) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{51}
+	return fileDescriptor_3b280de855f92a4a, []int{58}
 }
 
 func (m *StreamFrameLogsForDeviceRequest) XXX_Unmarshal(b []byte) error {
@@ -2701,6 +3562,13 @@ func (m *StreamFrameLogsForDeviceRequest) GetDevEui() []byte {
 	return nil
 }
 
+func (m *StreamFrameLogsForDeviceRequest) GetFilter() *FrameLogFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 type StreamFrameLogsForDeviceResponse struct {
 	// Types that are valid to be assigned to Frame:
 	//	*StreamFrameLogsForDeviceResponse_UplinkFrameSet
@@ -2715,7 +3583,7 @@ func (m *StreamFrameLogsForDeviceResponse) Reset()         { *m = StreamFrameLog
 func (m *StreamFrameLogsForDeviceResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamFrameLogsForDeviceResponse) ProtoMessage()    {}
 func (*StreamFrameLogsForDeviceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{52}
+	return fileDescriptor_3b280de855f92a4a, []int{59}
 }
 
 func (m *StreamFrameLogsForDeviceResponse) XXX_Unmarshal(b []byte) error {
@@ -2781,696 +3649,750 @@ func (*StreamFrameLogsForDeviceResponse) XXX_OneofWrappers() []interface{} {
 	}
 }
 
-type GetVersionResponse struct {
-	// LoRa Server version.
-	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	// Region configured for this network-server.
-	Region               common.Region `protobuf:"varint,2,opt,name=region,proto3,enum=common.Region" json:"region,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type CreateFrameLogCaptureRequest struct {
+	// DevEUI of the device to capture.
+	// Either dev_eui or gateway_id must be set.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// ID of the gateway to capture.
+	GatewayId []byte `protobuf:"bytes,2,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Duration of the capture session.
+	Duration *duration.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Max. number of frames to capture (0 = the configured max.).
+	MaxFrames uint32 `protobuf:"varint,4,opt,name=max_frames,json=maxFrames,proto3" json:"max_frames,omitempty"`
+	// Filter (optional).
+	Filter               *FrameLogFilter `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
-func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
-func (*GetVersionResponse) ProtoMessage()    {}
-func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{53}
+func (m *CreateFrameLogCaptureRequest) Reset()         { *m = CreateFrameLogCaptureRequest{} }
+func (m *CreateFrameLogCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFrameLogCaptureRequest) ProtoMessage()    {}
+func (*CreateFrameLogCaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{60}
 }
 
-func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
+func (m *CreateFrameLogCaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFrameLogCaptureRequest.Unmarshal(m, b)
 }
-func (m *GetVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetVersionResponse.Marshal(b, m, deterministic)
+func (m *CreateFrameLogCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFrameLogCaptureRequest.Marshal(b, m, deterministic)
 }
-func (m *GetVersionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetVersionResponse.Merge(m, src)
+func (m *CreateFrameLogCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFrameLogCaptureRequest.Merge(m, src)
 }
-func (m *GetVersionResponse) XXX_Size() int {
-	return xxx_messageInfo_GetVersionResponse.Size(m)
+func (m *CreateFrameLogCaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFrameLogCaptureRequest.Size(m)
 }
-func (m *GetVersionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetVersionResponse.DiscardUnknown(m)
+func (m *CreateFrameLogCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFrameLogCaptureRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetVersionResponse proto.InternalMessageInfo
+var xxx_messageInfo_CreateFrameLogCaptureRequest proto.InternalMessageInfo
 
-func (m *GetVersionResponse) GetVersion() string {
+func (m *CreateFrameLogCaptureRequest) GetDevEui() []byte {
 	if m != nil {
-		return m.Version
+		return m.DevEui
 	}
-	return ""
+	return nil
 }
 
-func (m *GetVersionResponse) GetRegion() common.Region {
+func (m *CreateFrameLogCaptureRequest) GetGatewayId() []byte {
 	if m != nil {
-		return m.Region
+		return m.GatewayId
 	}
-	return common.Region_EU868
+	return nil
 }
 
-type GatewayProfile struct {
-	// ID of the gateway-profile.
-	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Default channels (channels specified by the LoRaWAN Regional Parameters
-	// specification) enabled for this configuration.
-	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels,proto3" json:"channels,omitempty"`
-	// Extra channels added to the channel-configuration (in case the LoRaWAN
-	// region supports adding custom channels).
-	ExtraChannels        []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+func (m *CreateFrameLogCaptureRequest) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
 }
 
-func (m *GatewayProfile) Reset()         { *m = GatewayProfile{} }
-func (m *GatewayProfile) String() string { return proto.CompactTextString(m) }
-func (*GatewayProfile) ProtoMessage()    {}
-func (*GatewayProfile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{54}
+func (m *CreateFrameLogCaptureRequest) GetMaxFrames() uint32 {
+	if m != nil {
+		return m.MaxFrames
+	}
+	return 0
 }
 
-func (m *GatewayProfile) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GatewayProfile.Unmarshal(m, b)
-}
-func (m *GatewayProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GatewayProfile.Marshal(b, m, deterministic)
+func (m *CreateFrameLogCaptureRequest) GetFilter() *FrameLogFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
 }
-func (m *GatewayProfile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GatewayProfile.Merge(m, src)
+
+type CreateFrameLogCaptureResponse struct {
+	// ID of the capture session.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Time at which the capture session ends.
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,2,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
-func (m *GatewayProfile) XXX_Size() int {
-	return xxx_messageInfo_GatewayProfile.Size(m)
+
+func (m *CreateFrameLogCaptureResponse) Reset()         { *m = CreateFrameLogCaptureResponse{} }
+func (m *CreateFrameLogCaptureResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFrameLogCaptureResponse) ProtoMessage()    {}
+func (*CreateFrameLogCaptureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{61}
 }
-func (m *GatewayProfile) XXX_DiscardUnknown() {
-	xxx_messageInfo_GatewayProfile.DiscardUnknown(m)
+
+func (m *CreateFrameLogCaptureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFrameLogCaptureResponse.Unmarshal(m, b)
+}
+func (m *CreateFrameLogCaptureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFrameLogCaptureResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFrameLogCaptureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFrameLogCaptureResponse.Merge(m, src)
+}
+func (m *CreateFrameLogCaptureResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFrameLogCaptureResponse.Size(m)
+}
+func (m *CreateFrameLogCaptureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFrameLogCaptureResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GatewayProfile proto.InternalMessageInfo
+var xxx_messageInfo_CreateFrameLogCaptureResponse proto.InternalMessageInfo
 
-func (m *GatewayProfile) GetId() []byte {
+func (m *CreateFrameLogCaptureResponse) GetId() []byte {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-func (m *GatewayProfile) GetChannels() []uint32 {
+func (m *CreateFrameLogCaptureResponse) GetEndsAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Channels
+		return m.EndsAt
 	}
 	return nil
 }
 
-func (m *GatewayProfile) GetExtraChannels() []*GatewayProfileExtraChannel {
+type GetFrameLogCaptureRequest struct {
+	// ID of the capture session.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Format of the returned data.
+	Format FrameLogCaptureFormat `protobuf:"varint,2,opt,name=format,proto3,enum=ns.FrameLogCaptureFormat" json:"format,omitempty"`
+	// Include the session-keys of the captured device, so that the frames
+	// can be decrypted (PCAPNG only). Note that the AppSKey is only included
+	// when it is not wrapped by a KEK.
+	IncludeSessionKeys   bool     `protobuf:"varint,3,opt,name=include_session_keys,json=includeSessionKeys,proto3" json:"include_session_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFrameLogCaptureRequest) Reset()         { *m = GetFrameLogCaptureRequest{} }
+func (m *GetFrameLogCaptureRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFrameLogCaptureRequest) ProtoMessage()    {}
+func (*GetFrameLogCaptureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{62}
+}
+
+func (m *GetFrameLogCaptureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrameLogCaptureRequest.Unmarshal(m, b)
+}
+func (m *GetFrameLogCaptureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrameLogCaptureRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFrameLogCaptureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrameLogCaptureRequest.Merge(m, src)
+}
+func (m *GetFrameLogCaptureRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFrameLogCaptureRequest.Size(m)
+}
+func (m *GetFrameLogCaptureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrameLogCaptureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFrameLogCaptureRequest proto.InternalMessageInfo
+
+func (m *GetFrameLogCaptureRequest) GetId() []byte {
 	if m != nil {
-		return m.ExtraChannels
+		return m.Id
 	}
 	return nil
 }
 
-type GatewayProfileExtraChannel struct {
-	// Modulation.
-	Modulation common.Modulation `protobuf:"varint,1,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
-	// Frequency.
-	Frequency uint32 `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	// Bandwidth.
-	Bandwidth uint32 `protobuf:"varint,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
-	// Bitrate (in case of FSK modulation).
-	Bitrate uint32 `protobuf:"varint,4,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
-	// Spreading factors (in case of LoRa modulation).
-	SpreadingFactors     []uint32 `protobuf:"varint,5,rep,packed,name=spreading_factors,json=spreadingFactors,proto3" json:"spreading_factors,omitempty"`
+func (m *GetFrameLogCaptureRequest) GetFormat() FrameLogCaptureFormat {
+	if m != nil {
+		return m.Format
+	}
+	return FrameLogCaptureFormat_JSON
+}
+
+func (m *GetFrameLogCaptureRequest) GetIncludeSessionKeys() bool {
+	if m != nil {
+		return m.IncludeSessionKeys
+	}
+	return false
+}
+
+type GetFrameLogCaptureResponse struct {
+	// The capture session has ended.
+	Completed bool `protobuf:"varint,1,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Number of frames captured so far.
+	FrameCount uint32 `protobuf:"varint,2,opt,name=frame_count,json=frameCount,proto3" json:"frame_count,omitempty"`
+	// Time at which the capture session was created.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Time at which the capture session ends.
+	EndsAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// Captured frames, encoded in the requested format.
+	Data                 []byte   `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GatewayProfileExtraChannel) Reset()         { *m = GatewayProfileExtraChannel{} }
-func (m *GatewayProfileExtraChannel) String() string { return proto.CompactTextString(m) }
-func (*GatewayProfileExtraChannel) ProtoMessage()    {}
-func (*GatewayProfileExtraChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{55}
+func (m *GetFrameLogCaptureResponse) Reset()         { *m = GetFrameLogCaptureResponse{} }
+func (m *GetFrameLogCaptureResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFrameLogCaptureResponse) ProtoMessage()    {}
+func (*GetFrameLogCaptureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{63}
 }
 
-func (m *GatewayProfileExtraChannel) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GatewayProfileExtraChannel.Unmarshal(m, b)
+func (m *GetFrameLogCaptureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrameLogCaptureResponse.Unmarshal(m, b)
 }
-func (m *GatewayProfileExtraChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GatewayProfileExtraChannel.Marshal(b, m, deterministic)
+func (m *GetFrameLogCaptureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrameLogCaptureResponse.Marshal(b, m, deterministic)
 }
-func (m *GatewayProfileExtraChannel) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GatewayProfileExtraChannel.Merge(m, src)
+func (m *GetFrameLogCaptureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrameLogCaptureResponse.Merge(m, src)
 }
-func (m *GatewayProfileExtraChannel) XXX_Size() int {
-	return xxx_messageInfo_GatewayProfileExtraChannel.Size(m)
+func (m *GetFrameLogCaptureResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFrameLogCaptureResponse.Size(m)
 }
-func (m *GatewayProfileExtraChannel) XXX_DiscardUnknown() {
-	xxx_messageInfo_GatewayProfileExtraChannel.DiscardUnknown(m)
+func (m *GetFrameLogCaptureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrameLogCaptureResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GatewayProfileExtraChannel proto.InternalMessageInfo
+var xxx_messageInfo_GetFrameLogCaptureResponse proto.InternalMessageInfo
 
-func (m *GatewayProfileExtraChannel) GetModulation() common.Modulation {
+func (m *GetFrameLogCaptureResponse) GetCompleted() bool {
 	if m != nil {
-		return m.Modulation
+		return m.Completed
 	}
-	return common.Modulation_LORA
+	return false
 }
 
-func (m *GatewayProfileExtraChannel) GetFrequency() uint32 {
+func (m *GetFrameLogCaptureResponse) GetFrameCount() uint32 {
 	if m != nil {
-		return m.Frequency
+		return m.FrameCount
 	}
 	return 0
 }
 
-func (m *GatewayProfileExtraChannel) GetBandwidth() uint32 {
+func (m *GetFrameLogCaptureResponse) GetCreatedAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Bandwidth
+		return m.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-func (m *GatewayProfileExtraChannel) GetBitrate() uint32 {
+func (m *GetFrameLogCaptureResponse) GetEndsAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Bitrate
+		return m.EndsAt
 	}
-	return 0
+	return nil
 }
 
-func (m *GatewayProfileExtraChannel) GetSpreadingFactors() []uint32 {
+func (m *GetFrameLogCaptureResponse) GetData() []byte {
 	if m != nil {
-		return m.SpreadingFactors
+		return m.Data
 	}
 	return nil
 }
 
-type CreateGatewayProfileRequest struct {
-	// Gateway-profile object to create.
-	GatewayProfile       *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GetVersionResponse struct {
+	// LoRa Server version.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Region configured for this network-server.
+	Region               common.Region `protobuf:"varint,2,opt,name=region,proto3,enum=common.Region" json:"region,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *CreateGatewayProfileRequest) Reset()         { *m = CreateGatewayProfileRequest{} }
-func (m *CreateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateGatewayProfileRequest) ProtoMessage()    {}
-func (*CreateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{56}
+func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
+func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetVersionResponse) ProtoMessage()    {}
+func (*GetVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{64}
 }
 
-func (m *CreateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateGatewayProfileRequest.Unmarshal(m, b)
+func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
 }
-func (m *CreateGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateGatewayProfileRequest.Marshal(b, m, deterministic)
+func (m *GetVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVersionResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateGatewayProfileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateGatewayProfileRequest.Merge(m, src)
+func (m *GetVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVersionResponse.Merge(m, src)
 }
-func (m *CreateGatewayProfileRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateGatewayProfileRequest.Size(m)
+func (m *GetVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetVersionResponse.Size(m)
 }
-func (m *CreateGatewayProfileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateGatewayProfileRequest.DiscardUnknown(m)
+func (m *GetVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVersionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateGatewayProfileRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetVersionResponse proto.InternalMessageInfo
 
-func (m *CreateGatewayProfileRequest) GetGatewayProfile() *GatewayProfile {
+func (m *GetVersionResponse) GetVersion() string {
 	if m != nil {
-		return m.GatewayProfile
+		return m.Version
 	}
-	return nil
+	return ""
 }
 
-type CreateGatewayProfileResponse struct {
-	// ID of the create configuration object.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GetVersionResponse) GetRegion() common.Region {
+	if m != nil {
+		return m.Region
+	}
+	return common.Region_EU868
 }
 
-func (m *CreateGatewayProfileResponse) Reset()         { *m = CreateGatewayProfileResponse{} }
-func (m *CreateGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateGatewayProfileResponse) ProtoMessage()    {}
-func (*CreateGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{57}
+type GatewayProfile struct {
+	// ID of the gateway-profile.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Default channels (channels specified by the LoRaWAN Regional Parameters
+	// specification) enabled for this configuration.
+	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	// Extra channels added to the channel-configuration (in case the LoRaWAN
+	// region supports adding custom channels).
+	ExtraChannels []*GatewayProfileExtraChannel `protobuf:"bytes,3,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
+	// Region of the gateways using this profile.
+	// This must match one of the regions configured in the network-server
+	// configuration. When empty, the default band is used.
+	Region string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	// Listen-before-talk configuration.
+	// When not set, listen-before-talk is disabled.
+	Lbt *GatewayProfileLBT `protobuf:"bytes,5,opt,name=lbt,proto3" json:"lbt,omitempty"`
+	// Frequency-plan assigned to this gateway-profile.
+	// When set, the channels of the frequency-plan are used instead of the
+	// channels and extra-channels of this gateway-profile.
+	FrequencyPlanId []byte `protobuf:"bytes,6,opt,name=frequency_plan_id,json=frequencyPlanId,proto3" json:"frequency_plan_id,omitempty"`
+	// Frequency-plan version to use.
+	// When 0, the latest version of the frequency-plan is used. Set this to
+	// a previous version to roll back.
+	FrequencyPlanVersion uint32 `protobuf:"varint,7,opt,name=frequency_plan_version,json=frequencyPlanVersion,proto3" json:"frequency_plan_version,omitempty"`
+	// Channel-group of the gateways using this profile.
+	// This must match one of the channel-groups configured for the region
+	// in the network-server configuration. When empty, the channels of the
+	// region are used.
+	ChannelGroup string `protobuf:"bytes,8,opt,name=channel_group,json=channelGroup,proto3" json:"channel_group,omitempty"`
+	// Reconfiguration window.
+	// When set, configuration changes are only sent to the gateways within
+	// this window. When not set, configuration changes are sent immediately.
+	ReconfigurationWindow *GatewayProfileReconfigurationWindow `protobuf:"bytes,9,opt,name=reconfiguration_window,json=reconfigurationWindow,proto3" json:"reconfiguration_window,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                             `json:"-"`
+	XXX_unrecognized      []byte                               `json:"-"`
+	XXX_sizecache         int32                                `json:"-"`
 }
 
-func (m *CreateGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateGatewayProfileResponse.Unmarshal(m, b)
+func (m *GatewayProfile) Reset()         { *m = GatewayProfile{} }
+func (m *GatewayProfile) String() string { return proto.CompactTextString(m) }
+func (*GatewayProfile) ProtoMessage()    {}
+func (*GatewayProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{65}
 }
-func (m *CreateGatewayProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateGatewayProfileResponse.Marshal(b, m, deterministic)
+
+func (m *GatewayProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayProfile.Unmarshal(m, b)
 }
-func (m *CreateGatewayProfileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateGatewayProfileResponse.Merge(m, src)
+func (m *GatewayProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayProfile.Marshal(b, m, deterministic)
 }
-func (m *CreateGatewayProfileResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateGatewayProfileResponse.Size(m)
+func (m *GatewayProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayProfile.Merge(m, src)
 }
-func (m *CreateGatewayProfileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateGatewayProfileResponse.DiscardUnknown(m)
+func (m *GatewayProfile) XXX_Size() int {
+	return xxx_messageInfo_GatewayProfile.Size(m)
+}
+func (m *GatewayProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayProfile.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateGatewayProfileResponse proto.InternalMessageInfo
+var xxx_messageInfo_GatewayProfile proto.InternalMessageInfo
 
-func (m *CreateGatewayProfileResponse) GetId() []byte {
+func (m *GatewayProfile) GetId() []byte {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-type GetGatewayProfileRequest struct {
-	// Gateway-profile ID.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *GatewayProfile) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
 }
 
-func (m *GetGatewayProfileRequest) Reset()         { *m = GetGatewayProfileRequest{} }
-func (m *GetGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayProfileRequest) ProtoMessage()    {}
-func (*GetGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{58}
+func (m *GatewayProfile) GetExtraChannels() []*GatewayProfileExtraChannel {
+	if m != nil {
+		return m.ExtraChannels
+	}
+	return nil
 }
 
-func (m *GetGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayProfileRequest.Unmarshal(m, b)
-}
-func (m *GetGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayProfileRequest.Marshal(b, m, deterministic)
+func (m *GatewayProfile) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
 }
-func (m *GetGatewayProfileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayProfileRequest.Merge(m, src)
+
+func (m *GatewayProfile) GetLbt() *GatewayProfileLBT {
+	if m != nil {
+		return m.Lbt
+	}
+	return nil
 }
-func (m *GetGatewayProfileRequest) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayProfileRequest.Size(m)
+
+func (m *GatewayProfile) GetFrequencyPlanId() []byte {
+	if m != nil {
+		return m.FrequencyPlanId
+	}
+	return nil
 }
-func (m *GetGatewayProfileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayProfileRequest.DiscardUnknown(m)
+
+func (m *GatewayProfile) GetFrequencyPlanVersion() uint32 {
+	if m != nil {
+		return m.FrequencyPlanVersion
+	}
+	return 0
 }
 
-var xxx_messageInfo_GetGatewayProfileRequest proto.InternalMessageInfo
+func (m *GatewayProfile) GetChannelGroup() string {
+	if m != nil {
+		return m.ChannelGroup
+	}
+	return ""
+}
 
-func (m *GetGatewayProfileRequest) GetId() []byte {
+func (m *GatewayProfile) GetReconfigurationWindow() *GatewayProfileReconfigurationWindow {
 	if m != nil {
-		return m.Id
+		return m.ReconfigurationWindow
 	}
 	return nil
 }
 
-type GetGatewayProfileResponse struct {
-	// Gateway-profile object.
-	GatewayProfile *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
-	// Created at timestamp.
-	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// Last update timestamp.
-	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type GatewayProfileReconfigurationWindow struct {
+	// Start of the window (minutes after midnight, UTC).
+	StartMinute uint32 `protobuf:"varint,1,opt,name=start_minute,json=startMinute,proto3" json:"start_minute,omitempty"`
+	// Duration of the window (minutes).
+	DurationMinutes      uint32   `protobuf:"varint,2,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetGatewayProfileResponse) Reset()         { *m = GetGatewayProfileResponse{} }
-func (m *GetGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
-func (*GetGatewayProfileResponse) ProtoMessage()    {}
-func (*GetGatewayProfileResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{59}
+func (m *GatewayProfileReconfigurationWindow) Reset()         { *m = GatewayProfileReconfigurationWindow{} }
+func (m *GatewayProfileReconfigurationWindow) String() string { return proto.CompactTextString(m) }
+func (*GatewayProfileReconfigurationWindow) ProtoMessage()    {}
+func (*GatewayProfileReconfigurationWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{66}
 }
 
-func (m *GetGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetGatewayProfileResponse.Unmarshal(m, b)
+func (m *GatewayProfileReconfigurationWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayProfileReconfigurationWindow.Unmarshal(m, b)
 }
-func (m *GetGatewayProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetGatewayProfileResponse.Marshal(b, m, deterministic)
+func (m *GatewayProfileReconfigurationWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayProfileReconfigurationWindow.Marshal(b, m, deterministic)
 }
-func (m *GetGatewayProfileResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetGatewayProfileResponse.Merge(m, src)
+func (m *GatewayProfileReconfigurationWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayProfileReconfigurationWindow.Merge(m, src)
 }
-func (m *GetGatewayProfileResponse) XXX_Size() int {
-	return xxx_messageInfo_GetGatewayProfileResponse.Size(m)
+func (m *GatewayProfileReconfigurationWindow) XXX_Size() int {
+	return xxx_messageInfo_GatewayProfileReconfigurationWindow.Size(m)
 }
-func (m *GetGatewayProfileResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetGatewayProfileResponse.DiscardUnknown(m)
+func (m *GatewayProfileReconfigurationWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayProfileReconfigurationWindow.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetGatewayProfileResponse proto.InternalMessageInfo
-
-func (m *GetGatewayProfileResponse) GetGatewayProfile() *GatewayProfile {
-	if m != nil {
-		return m.GatewayProfile
-	}
-	return nil
-}
+var xxx_messageInfo_GatewayProfileReconfigurationWindow proto.InternalMessageInfo
 
-func (m *GetGatewayProfileResponse) GetCreatedAt() *timestamp.Timestamp {
+func (m *GatewayProfileReconfigurationWindow) GetStartMinute() uint32 {
 	if m != nil {
-		return m.CreatedAt
+		return m.StartMinute
 	}
-	return nil
+	return 0
 }
 
-func (m *GetGatewayProfileResponse) GetUpdatedAt() *timestamp.Timestamp {
+func (m *GatewayProfileReconfigurationWindow) GetDurationMinutes() uint32 {
 	if m != nil {
-		return m.UpdatedAt
+		return m.DurationMinutes
 	}
-	return nil
+	return 0
 }
 
-type UpdateGatewayProfileRequest struct {
-	// Gateway-profile object to update.
-	GatewayProfile       *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GatewayProfileLBT struct {
+	// RSSI target (dBm).
+	// The channel is considered busy when the RSSI is above this target.
+	RssiTarget int32 `protobuf:"varint,1,opt,name=rssi_target,json=rssiTarget,proto3" json:"rssi_target,omitempty"`
+	// Scan time (us).
+	ScanTime uint32 `protobuf:"varint,2,opt,name=scan_time,json=scanTime,proto3" json:"scan_time,omitempty"`
+	// Frequencies (Hz) on which listen-before-talk must be performed.
+	Frequencies          []uint32 `protobuf:"varint,3,rep,packed,name=frequencies,proto3" json:"frequencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateGatewayProfileRequest) Reset()         { *m = UpdateGatewayProfileRequest{} }
-func (m *UpdateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateGatewayProfileRequest) ProtoMessage()    {}
-func (*UpdateGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{60}
+func (m *GatewayProfileLBT) Reset()         { *m = GatewayProfileLBT{} }
+func (m *GatewayProfileLBT) String() string { return proto.CompactTextString(m) }
+func (*GatewayProfileLBT) ProtoMessage()    {}
+func (*GatewayProfileLBT) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{67}
 }
 
-func (m *UpdateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateGatewayProfileRequest.Unmarshal(m, b)
+func (m *GatewayProfileLBT) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayProfileLBT.Unmarshal(m, b)
 }
-func (m *UpdateGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateGatewayProfileRequest.Marshal(b, m, deterministic)
+func (m *GatewayProfileLBT) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayProfileLBT.Marshal(b, m, deterministic)
 }
-func (m *UpdateGatewayProfileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateGatewayProfileRequest.Merge(m, src)
+func (m *GatewayProfileLBT) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayProfileLBT.Merge(m, src)
 }
-func (m *UpdateGatewayProfileRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateGatewayProfileRequest.Size(m)
+func (m *GatewayProfileLBT) XXX_Size() int {
+	return xxx_messageInfo_GatewayProfileLBT.Size(m)
 }
-func (m *UpdateGatewayProfileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateGatewayProfileRequest.DiscardUnknown(m)
+func (m *GatewayProfileLBT) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayProfileLBT.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateGatewayProfileRequest proto.InternalMessageInfo
+var xxx_messageInfo_GatewayProfileLBT proto.InternalMessageInfo
 
-func (m *UpdateGatewayProfileRequest) GetGatewayProfile() *GatewayProfile {
+func (m *GatewayProfileLBT) GetRssiTarget() int32 {
 	if m != nil {
-		return m.GatewayProfile
+		return m.RssiTarget
 	}
-	return nil
-}
-
-type DeleteGatewayProfileRequest struct {
-	// Gateway-profile ID.
-	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *DeleteGatewayProfileRequest) Reset()         { *m = DeleteGatewayProfileRequest{} }
-func (m *DeleteGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteGatewayProfileRequest) ProtoMessage()    {}
-func (*DeleteGatewayProfileRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{61}
+	return 0
 }
 
-func (m *DeleteGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteGatewayProfileRequest.Unmarshal(m, b)
-}
-func (m *DeleteGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteGatewayProfileRequest.Marshal(b, m, deterministic)
-}
-func (m *DeleteGatewayProfileRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteGatewayProfileRequest.Merge(m, src)
-}
-func (m *DeleteGatewayProfileRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteGatewayProfileRequest.Size(m)
-}
-func (m *DeleteGatewayProfileRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteGatewayProfileRequest.DiscardUnknown(m)
+func (m *GatewayProfileLBT) GetScanTime() uint32 {
+	if m != nil {
+		return m.ScanTime
+	}
+	return 0
 }
 
-var xxx_messageInfo_DeleteGatewayProfileRequest proto.InternalMessageInfo
-
-func (m *DeleteGatewayProfileRequest) GetId() []byte {
+func (m *GatewayProfileLBT) GetFrequencies() []uint32 {
 	if m != nil {
-		return m.Id
+		return m.Frequencies
 	}
 	return nil
 }
 
-type MulticastGroup struct {
-	// Multicast-group ID.
-	// Note: this can be set on create. When left blank, a random ID will
-	// be generated.
-	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Multicast Address for this group.
-	McAddr []byte `protobuf:"bytes,2,opt,name=mc_addr,json=mcAddr,proto3" json:"mc_addr,omitempty"`
-	// Multicast network session key.
-	McNwkSKey []byte `protobuf:"bytes,3,opt,name=mc_nwk_s_key,json=mcNwkSKey,proto3" json:"mc_nwk_s_key,omitempty"`
-	// Frame-counter (downlink).
-	FCnt uint32 `protobuf:"varint,4,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
-	// Multicast-group type (Class-B or Class-C).
-	GroupType MulticastGroupType `protobuf:"varint,5,opt,name=group_type,json=groupType,proto3,enum=ns.MulticastGroupType" json:"group_type,omitempty"`
-	// Data-rate.
-	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
-	// Frequency (Hz).
-	Frequency uint32 `protobuf:"varint,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
-	// Ping-slot period.
-	// Mandatory for Class-B multicast groups.
-	PingSlotPeriod uint32 `protobuf:"varint,8,opt,name=ping_slot_period,json=pingSlotPeriod,proto3" json:"ping_slot_period,omitempty"`
-	// Service-profile ID.
-	ServiceProfileId []byte `protobuf:"bytes,9,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
-	// Routing-profile ID.
-	RoutingProfileId     []byte   `protobuf:"bytes,10,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+type GatewayProfileExtraChannel struct {
+	// Modulation.
+	Modulation common.Modulation `protobuf:"varint,1,opt,name=modulation,proto3,enum=common.Modulation" json:"modulation,omitempty"`
+	// Frequency.
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Bandwidth.
+	Bandwidth uint32 `protobuf:"varint,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Bitrate (in case of FSK modulation).
+	Bitrate uint32 `protobuf:"varint,4,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
+	// Spreading factors (in case of LoRa modulation).
+	SpreadingFactors     []uint32 `protobuf:"varint,5,rep,packed,name=spreading_factors,json=spreadingFactors,proto3" json:"spreading_factors,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MulticastGroup) Reset()         { *m = MulticastGroup{} }
-func (m *MulticastGroup) String() string { return proto.CompactTextString(m) }
-func (*MulticastGroup) ProtoMessage()    {}
-func (*MulticastGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{62}
+func (m *GatewayProfileExtraChannel) Reset()         { *m = GatewayProfileExtraChannel{} }
+func (m *GatewayProfileExtraChannel) String() string { return proto.CompactTextString(m) }
+func (*GatewayProfileExtraChannel) ProtoMessage()    {}
+func (*GatewayProfileExtraChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{68}
 }
 
-func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MulticastGroup.Unmarshal(m, b)
-}
-func (m *MulticastGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MulticastGroup.Marshal(b, m, deterministic)
-}
-func (m *MulticastGroup) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MulticastGroup.Merge(m, src)
-}
-func (m *MulticastGroup) XXX_Size() int {
-	return xxx_messageInfo_MulticastGroup.Size(m)
-}
-func (m *MulticastGroup) XXX_DiscardUnknown() {
-	xxx_messageInfo_MulticastGroup.DiscardUnknown(m)
+func (m *GatewayProfileExtraChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayProfileExtraChannel.Unmarshal(m, b)
 }
-
-var xxx_messageInfo_MulticastGroup proto.InternalMessageInfo
-
-func (m *MulticastGroup) GetId() []byte {
-	if m != nil {
-		return m.Id
-	}
-	return nil
+func (m *GatewayProfileExtraChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayProfileExtraChannel.Marshal(b, m, deterministic)
 }
-
-func (m *MulticastGroup) GetMcAddr() []byte {
-	if m != nil {
-		return m.McAddr
-	}
-	return nil
+func (m *GatewayProfileExtraChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayProfileExtraChannel.Merge(m, src)
 }
-
-func (m *MulticastGroup) GetMcNwkSKey() []byte {
-	if m != nil {
-		return m.McNwkSKey
-	}
-	return nil
+func (m *GatewayProfileExtraChannel) XXX_Size() int {
+	return xxx_messageInfo_GatewayProfileExtraChannel.Size(m)
 }
-
-func (m *MulticastGroup) GetFCnt() uint32 {
-	if m != nil {
-		return m.FCnt
-	}
-	return 0
+func (m *GatewayProfileExtraChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayProfileExtraChannel.DiscardUnknown(m)
 }
 
-func (m *MulticastGroup) GetGroupType() MulticastGroupType {
-	if m != nil {
-		return m.GroupType
-	}
-	return MulticastGroupType_CLASS_C
-}
+var xxx_messageInfo_GatewayProfileExtraChannel proto.InternalMessageInfo
 
-func (m *MulticastGroup) GetDr() uint32 {
+func (m *GatewayProfileExtraChannel) GetModulation() common.Modulation {
 	if m != nil {
-		return m.Dr
+		return m.Modulation
 	}
-	return 0
+	return common.Modulation_LORA
 }
 
-func (m *MulticastGroup) GetFrequency() uint32 {
+func (m *GatewayProfileExtraChannel) GetFrequency() uint32 {
 	if m != nil {
 		return m.Frequency
 	}
 	return 0
 }
 
-func (m *MulticastGroup) GetPingSlotPeriod() uint32 {
+func (m *GatewayProfileExtraChannel) GetBandwidth() uint32 {
 	if m != nil {
-		return m.PingSlotPeriod
+		return m.Bandwidth
 	}
 	return 0
 }
 
-func (m *MulticastGroup) GetServiceProfileId() []byte {
+func (m *GatewayProfileExtraChannel) GetBitrate() uint32 {
 	if m != nil {
-		return m.ServiceProfileId
+		return m.Bitrate
 	}
-	return nil
+	return 0
 }
 
-func (m *MulticastGroup) GetRoutingProfileId() []byte {
+func (m *GatewayProfileExtraChannel) GetSpreadingFactors() []uint32 {
 	if m != nil {
-		return m.RoutingProfileId
+		return m.SpreadingFactors
 	}
 	return nil
 }
 
-type CreateMulticastGroupRequest struct {
-	// Multicast-group to create.
-	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+type CreateGatewayProfileRequest struct {
+	// Gateway-profile object to create.
+	GatewayProfile       *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *CreateMulticastGroupRequest) Reset()         { *m = CreateMulticastGroupRequest{} }
-func (m *CreateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateMulticastGroupRequest) ProtoMessage()    {}
-func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{63}
+func (m *CreateGatewayProfileRequest) Reset()         { *m = CreateGatewayProfileRequest{} }
+func (m *CreateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayProfileRequest) ProtoMessage()    {}
+func (*CreateGatewayProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{69}
 }
 
-func (m *CreateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMulticastGroupRequest.Unmarshal(m, b)
+func (m *CreateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGatewayProfileRequest.Unmarshal(m, b)
 }
-func (m *CreateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *CreateGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGatewayProfileRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMulticastGroupRequest.Merge(m, src)
+func (m *CreateGatewayProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGatewayProfileRequest.Merge(m, src)
 }
-func (m *CreateMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateMulticastGroupRequest.Size(m)
+func (m *CreateGatewayProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGatewayProfileRequest.Size(m)
 }
-func (m *CreateMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMulticastGroupRequest.DiscardUnknown(m)
+func (m *CreateGatewayProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGatewayProfileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateGatewayProfileRequest proto.InternalMessageInfo
 
-func (m *CreateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+func (m *CreateGatewayProfileRequest) GetGatewayProfile() *GatewayProfile {
 	if m != nil {
-		return m.MulticastGroup
+		return m.GatewayProfile
 	}
 	return nil
 }
 
-type CreateMulticastGroupResponse struct {
-	// Multicast-group ID.
+type CreateGatewayProfileResponse struct {
+	// ID of the create configuration object.
 	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateMulticastGroupResponse) Reset()         { *m = CreateMulticastGroupResponse{} }
-func (m *CreateMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateMulticastGroupResponse) ProtoMessage()    {}
-func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{64}
+func (m *CreateGatewayProfileResponse) Reset()         { *m = CreateGatewayProfileResponse{} }
+func (m *CreateGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayProfileResponse) ProtoMessage()    {}
+func (*CreateGatewayProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{70}
 }
 
-func (m *CreateMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateMulticastGroupResponse.Unmarshal(m, b)
+func (m *CreateGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGatewayProfileResponse.Unmarshal(m, b)
 }
-func (m *CreateMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateMulticastGroupResponse.Marshal(b, m, deterministic)
+func (m *CreateGatewayProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGatewayProfileResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateMulticastGroupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateMulticastGroupResponse.Merge(m, src)
+func (m *CreateGatewayProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGatewayProfileResponse.Merge(m, src)
 }
-func (m *CreateMulticastGroupResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateMulticastGroupResponse.Size(m)
+func (m *CreateGatewayProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateGatewayProfileResponse.Size(m)
 }
-func (m *CreateMulticastGroupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateMulticastGroupResponse.DiscardUnknown(m)
+func (m *CreateGatewayProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGatewayProfileResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateMulticastGroupResponse proto.InternalMessageInfo
+var xxx_messageInfo_CreateGatewayProfileResponse proto.InternalMessageInfo
 
-func (m *CreateMulticastGroupResponse) GetId() []byte {
+func (m *CreateGatewayProfileResponse) GetId() []byte {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-type GetMulticastGroupRequest struct {
-	// Multicast-group ID.
+type GetGatewayProfileRequest struct {
+	// Gateway-profile ID.
 	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMulticastGroupRequest) Reset()         { *m = GetMulticastGroupRequest{} }
-func (m *GetMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*GetMulticastGroupRequest) ProtoMessage()    {}
-func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{65}
+func (m *GetGatewayProfileRequest) Reset()         { *m = GetGatewayProfileRequest{} }
+func (m *GetGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayProfileRequest) ProtoMessage()    {}
+func (*GetGatewayProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{71}
 }
 
-func (m *GetMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMulticastGroupRequest.Unmarshal(m, b)
+func (m *GetGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayProfileRequest.Unmarshal(m, b)
 }
-func (m *GetMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *GetGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayProfileRequest.Marshal(b, m, deterministic)
 }
-func (m *GetMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMulticastGroupRequest.Merge(m, src)
+func (m *GetGatewayProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayProfileRequest.Merge(m, src)
 }
-func (m *GetMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_GetMulticastGroupRequest.Size(m)
+func (m *GetGatewayProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayProfileRequest.Size(m)
 }
-func (m *GetMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMulticastGroupRequest.DiscardUnknown(m)
+func (m *GetGatewayProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayProfileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayProfileRequest proto.InternalMessageInfo
 
-func (m *GetMulticastGroupRequest) GetId() []byte {
+func (m *GetGatewayProfileRequest) GetId() []byte {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-type GetMulticastGroupResponse struct {
-	// Multicast-group.
-	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+type GetGatewayProfileResponse struct {
+	// Gateway-profile object.
+	GatewayProfile *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
 	// Created at timestamp.
 	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	// Last update timestamp.
@@ -3480,2428 +4402,9729 @@ type GetMulticastGroupResponse struct {
 	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetMulticastGroupResponse) Reset()         { *m = GetMulticastGroupResponse{} }
-func (m *GetMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
-func (*GetMulticastGroupResponse) ProtoMessage()    {}
-func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{66}
+func (m *GetGatewayProfileResponse) Reset()         { *m = GetGatewayProfileResponse{} }
+func (m *GetGatewayProfileResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayProfileResponse) ProtoMessage()    {}
+func (*GetGatewayProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{72}
 }
 
-func (m *GetMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMulticastGroupResponse.Unmarshal(m, b)
+func (m *GetGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayProfileResponse.Unmarshal(m, b)
 }
-func (m *GetMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMulticastGroupResponse.Marshal(b, m, deterministic)
+func (m *GetGatewayProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayProfileResponse.Marshal(b, m, deterministic)
 }
-func (m *GetMulticastGroupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMulticastGroupResponse.Merge(m, src)
+func (m *GetGatewayProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayProfileResponse.Merge(m, src)
 }
-func (m *GetMulticastGroupResponse) XXX_Size() int {
-	return xxx_messageInfo_GetMulticastGroupResponse.Size(m)
+func (m *GetGatewayProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayProfileResponse.Size(m)
 }
-func (m *GetMulticastGroupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMulticastGroupResponse.DiscardUnknown(m)
+func (m *GetGatewayProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayProfileResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMulticastGroupResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetGatewayProfileResponse proto.InternalMessageInfo
 
-func (m *GetMulticastGroupResponse) GetMulticastGroup() *MulticastGroup {
+func (m *GetGatewayProfileResponse) GetGatewayProfile() *GatewayProfile {
 	if m != nil {
-		return m.MulticastGroup
+		return m.GatewayProfile
 	}
 	return nil
 }
 
-func (m *GetMulticastGroupResponse) GetCreatedAt() *timestamp.Timestamp {
+func (m *GetGatewayProfileResponse) GetCreatedAt() *timestamp.Timestamp {
 	if m != nil {
 		return m.CreatedAt
 	}
 	return nil
 }
 
-func (m *GetMulticastGroupResponse) GetUpdatedAt() *timestamp.Timestamp {
+func (m *GetGatewayProfileResponse) GetUpdatedAt() *timestamp.Timestamp {
 	if m != nil {
 		return m.UpdatedAt
 	}
 	return nil
 }
 
-type UpdateMulticastGroupRequest struct {
-	// Multicast-group to update.
-	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+type UpdateGatewayProfileRequest struct {
+	// Gateway-profile object to update.
+	GatewayProfile       *GatewayProfile `protobuf:"bytes,1,opt,name=gateway_profile,json=gatewayProfile,proto3" json:"gateway_profile,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *UpdateMulticastGroupRequest) Reset()         { *m = UpdateMulticastGroupRequest{} }
-func (m *UpdateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateMulticastGroupRequest) ProtoMessage()    {}
-func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{67}
+func (m *UpdateGatewayProfileRequest) Reset()         { *m = UpdateGatewayProfileRequest{} }
+func (m *UpdateGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateGatewayProfileRequest) ProtoMessage()    {}
+func (*UpdateGatewayProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{73}
 }
 
-func (m *UpdateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateMulticastGroupRequest.Unmarshal(m, b)
+func (m *UpdateGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateGatewayProfileRequest.Unmarshal(m, b)
 }
-func (m *UpdateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *UpdateGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateGatewayProfileRequest.Marshal(b, m, deterministic)
 }
-func (m *UpdateMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateMulticastGroupRequest.Merge(m, src)
+func (m *UpdateGatewayProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGatewayProfileRequest.Merge(m, src)
 }
-func (m *UpdateMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_UpdateMulticastGroupRequest.Size(m)
+func (m *UpdateGatewayProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateGatewayProfileRequest.Size(m)
 }
-func (m *UpdateMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateMulticastGroupRequest.DiscardUnknown(m)
+func (m *UpdateGatewayProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGatewayProfileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateGatewayProfileRequest proto.InternalMessageInfo
 
-func (m *UpdateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+func (m *UpdateGatewayProfileRequest) GetGatewayProfile() *GatewayProfile {
 	if m != nil {
-		return m.MulticastGroup
+		return m.GatewayProfile
 	}
 	return nil
 }
 
-type DeleteMulticastGroupRequest struct {
-	// Multicast-group ID.
+type DeleteGatewayProfileRequest struct {
+	// Gateway-profile ID.
 	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteMulticastGroupRequest) Reset()         { *m = DeleteMulticastGroupRequest{} }
-func (m *DeleteMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteMulticastGroupRequest) ProtoMessage()    {}
-func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{68}
+func (m *DeleteGatewayProfileRequest) Reset()         { *m = DeleteGatewayProfileRequest{} }
+func (m *DeleteGatewayProfileRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewayProfileRequest) ProtoMessage()    {}
+func (*DeleteGatewayProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{74}
 }
 
-func (m *DeleteMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteMulticastGroupRequest.Unmarshal(m, b)
+func (m *DeleteGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteGatewayProfileRequest.Unmarshal(m, b)
 }
-func (m *DeleteMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *DeleteGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteGatewayProfileRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteMulticastGroupRequest.Merge(m, src)
+func (m *DeleteGatewayProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteGatewayProfileRequest.Merge(m, src)
 }
-func (m *DeleteMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteMulticastGroupRequest.Size(m)
+func (m *DeleteGatewayProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteGatewayProfileRequest.Size(m)
 }
-func (m *DeleteMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteMulticastGroupRequest.DiscardUnknown(m)
+func (m *DeleteGatewayProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteGatewayProfileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteGatewayProfileRequest proto.InternalMessageInfo
 
-func (m *DeleteMulticastGroupRequest) GetId() []byte {
+func (m *DeleteGatewayProfileRequest) GetId() []byte {
 	if m != nil {
 		return m.Id
 	}
 	return nil
 }
 
-type AddDeviceToMulticastGroupRequest struct {
-	// Device EUI.
-	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
-	// Multicast-group ID.
-	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+type FrequencyPlan struct {
+	// ID of the frequency-plan.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the frequency-plan.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Default channels (channels specified by the LoRaWAN Regional Parameters
+	// specification) enabled for this frequency-plan.
+	Channels []uint32 `protobuf:"varint,3,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	// Extra channels added to the channel-configuration.
+	ExtraChannels []*GatewayProfileExtraChannel `protobuf:"bytes,4,rep,name=extra_channels,json=extraChannels,proto3" json:"extra_channels,omitempty"`
+	// RX2 frequency (Hz).
+	// When 0, the network-server RX2 settings are used.
+	Rx2Frequency uint32 `protobuf:"varint,5,opt,name=rx2_frequency,json=rx2Frequency,proto3" json:"rx2_frequency,omitempty"`
+	// RX2 data-rate.
+	Rx2Dr uint32 `protobuf:"varint,6,opt,name=rx2_dr,json=rx2Dr,proto3" json:"rx2_dr,omitempty"`
+	// Class-B beacon frequency (Hz).
+	// When 0, no beacon configuration is sent to the gateways.
+	BeaconFrequency uint32 `protobuf:"varint,7,opt,name=beacon_frequency,json=beaconFrequency,proto3" json:"beacon_frequency,omitempty"`
+	// Class-B beacon data-rate.
+	BeaconDr uint32 `protobuf:"varint,8,opt,name=beacon_dr,json=beaconDr,proto3" json:"beacon_dr,omitempty"`
+	// Class-B ping-slot frequency (Hz).
+	// When 0, the network-server Class-B settings are used.
+	PingSlotFrequency uint32 `protobuf:"varint,9,opt,name=ping_slot_frequency,json=pingSlotFrequency,proto3" json:"ping_slot_frequency,omitempty"`
+	// Class-B ping-slot data-rate.
+	PingSlotDr           uint32   `protobuf:"varint,10,opt,name=ping_slot_dr,json=pingSlotDr,proto3" json:"ping_slot_dr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMulticastGroupRequest{} }
-func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
-func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{69}
+func (m *FrequencyPlan) Reset()         { *m = FrequencyPlan{} }
+func (m *FrequencyPlan) String() string { return proto.CompactTextString(m) }
+func (*FrequencyPlan) ProtoMessage()    {}
+func (*FrequencyPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{75}
 }
 
-func (m *AddDeviceToMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Unmarshal(m, b)
+func (m *FrequencyPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FrequencyPlan.Unmarshal(m, b)
 }
-func (m *AddDeviceToMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *FrequencyPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FrequencyPlan.Marshal(b, m, deterministic)
 }
-func (m *AddDeviceToMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddDeviceToMulticastGroupRequest.Merge(m, src)
+func (m *FrequencyPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrequencyPlan.Merge(m, src)
 }
-func (m *AddDeviceToMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Size(m)
+func (m *FrequencyPlan) XXX_Size() int {
+	return xxx_messageInfo_FrequencyPlan.Size(m)
 }
-func (m *AddDeviceToMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddDeviceToMulticastGroupRequest.DiscardUnknown(m)
+func (m *FrequencyPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrequencyPlan.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddDeviceToMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_FrequencyPlan proto.InternalMessageInfo
 
-func (m *AddDeviceToMulticastGroupRequest) GetDevEui() []byte {
+func (m *FrequencyPlan) GetId() []byte {
 	if m != nil {
-		return m.DevEui
+		return m.Id
 	}
 	return nil
 }
 
-func (m *AddDeviceToMulticastGroupRequest) GetMulticastGroupId() []byte {
+func (m *FrequencyPlan) GetName() string {
 	if m != nil {
-		return m.MulticastGroupId
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FrequencyPlan) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
 	}
 	return nil
 }
 
-type RemoveDeviceFromMulticastGroupRequest struct {
-	// Device EUI.
-	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
-	// Multicast-group ID.
-	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *FrequencyPlan) GetExtraChannels() []*GatewayProfileExtraChannel {
+	if m != nil {
+		return m.ExtraChannels
+	}
+	return nil
 }
 
-func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDeviceFromMulticastGroupRequest{} }
-func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
-func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
-func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{70}
+func (m *FrequencyPlan) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
 }
 
-func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Unmarshal(m, b)
+func (m *FrequencyPlan) GetRx2Dr() uint32 {
+	if m != nil {
+		return m.Rx2Dr
+	}
+	return 0
 }
-func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Marshal(b, m, deterministic)
+
+func (m *FrequencyPlan) GetBeaconFrequency() uint32 {
+	if m != nil {
+		return m.BeaconFrequency
+	}
+	return 0
 }
-func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Merge(m, src)
+
+func (m *FrequencyPlan) GetBeaconDr() uint32 {
+	if m != nil {
+		return m.BeaconDr
+	}
+	return 0
 }
-func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Size(m)
+
+func (m *FrequencyPlan) GetPingSlotFrequency() uint32 {
+	if m != nil {
+		return m.PingSlotFrequency
+	}
+	return 0
 }
-func (m *RemoveDeviceFromMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.DiscardUnknown(m)
+
+func (m *FrequencyPlan) GetPingSlotDr() uint32 {
+	if m != nil {
+		return m.PingSlotDr
+	}
+	return 0
 }
 
-var xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest proto.InternalMessageInfo
+type CreateFrequencyPlanRequest struct {
+	// Frequency-plan object to create.
+	FrequencyPlan        *FrequencyPlan `protobuf:"bytes,1,opt,name=frequency_plan,json=frequencyPlan,proto3" json:"frequency_plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
 
-func (m *RemoveDeviceFromMulticastGroupRequest) GetDevEui() []byte {
+func (m *CreateFrequencyPlanRequest) Reset()         { *m = CreateFrequencyPlanRequest{} }
+func (m *CreateFrequencyPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFrequencyPlanRequest) ProtoMessage()    {}
+func (*CreateFrequencyPlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{76}
+}
+
+func (m *CreateFrequencyPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFrequencyPlanRequest.Unmarshal(m, b)
+}
+func (m *CreateFrequencyPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFrequencyPlanRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFrequencyPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFrequencyPlanRequest.Merge(m, src)
+}
+func (m *CreateFrequencyPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFrequencyPlanRequest.Size(m)
+}
+func (m *CreateFrequencyPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFrequencyPlanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFrequencyPlanRequest proto.InternalMessageInfo
+
+func (m *CreateFrequencyPlanRequest) GetFrequencyPlan() *FrequencyPlan {
 	if m != nil {
-		return m.DevEui
+		return m.FrequencyPlan
 	}
 	return nil
 }
 
-func (m *RemoveDeviceFromMulticastGroupRequest) GetMulticastGroupId() []byte {
+type CreateFrequencyPlanResponse struct {
+	// ID of the created frequency-plan.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateFrequencyPlanResponse) Reset()         { *m = CreateFrequencyPlanResponse{} }
+func (m *CreateFrequencyPlanResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFrequencyPlanResponse) ProtoMessage()    {}
+func (*CreateFrequencyPlanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{77}
+}
+
+func (m *CreateFrequencyPlanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFrequencyPlanResponse.Unmarshal(m, b)
+}
+func (m *CreateFrequencyPlanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFrequencyPlanResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFrequencyPlanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFrequencyPlanResponse.Merge(m, src)
+}
+func (m *CreateFrequencyPlanResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFrequencyPlanResponse.Size(m)
+}
+func (m *CreateFrequencyPlanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFrequencyPlanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFrequencyPlanResponse proto.InternalMessageInfo
+
+func (m *CreateFrequencyPlanResponse) GetId() []byte {
 	if m != nil {
-		return m.MulticastGroupId
+		return m.Id
 	}
 	return nil
 }
 
-type MulticastQueueItem struct {
-	// Multicast-group id.
-	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
-	// Frame-counter of payload.
-	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
-	// Frame-port of payload.
-	FPort uint32 `protobuf:"varint,3,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
-	// Encrypted FRMPayload bytes.
-	FrmPayload           []byte   `protobuf:"bytes,4,opt,name=frm_payload,json=frmPayload,proto3" json:"frm_payload,omitempty"`
+type GetFrequencyPlanRequest struct {
+	// Frequency-plan ID.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version to return.
+	// When 0, the latest version is returned.
+	Version              uint32   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MulticastQueueItem) Reset()         { *m = MulticastQueueItem{} }
-func (m *MulticastQueueItem) String() string { return proto.CompactTextString(m) }
-func (*MulticastQueueItem) ProtoMessage()    {}
-func (*MulticastQueueItem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{71}
+func (m *GetFrequencyPlanRequest) Reset()         { *m = GetFrequencyPlanRequest{} }
+func (m *GetFrequencyPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFrequencyPlanRequest) ProtoMessage()    {}
+func (*GetFrequencyPlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{78}
 }
 
-func (m *MulticastQueueItem) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MulticastQueueItem.Unmarshal(m, b)
+func (m *GetFrequencyPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequencyPlanRequest.Unmarshal(m, b)
 }
-func (m *MulticastQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MulticastQueueItem.Marshal(b, m, deterministic)
+func (m *GetFrequencyPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequencyPlanRequest.Marshal(b, m, deterministic)
 }
-func (m *MulticastQueueItem) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MulticastQueueItem.Merge(m, src)
+func (m *GetFrequencyPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequencyPlanRequest.Merge(m, src)
 }
-func (m *MulticastQueueItem) XXX_Size() int {
-	return xxx_messageInfo_MulticastQueueItem.Size(m)
+func (m *GetFrequencyPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFrequencyPlanRequest.Size(m)
 }
-func (m *MulticastQueueItem) XXX_DiscardUnknown() {
-	xxx_messageInfo_MulticastQueueItem.DiscardUnknown(m)
+func (m *GetFrequencyPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequencyPlanRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MulticastQueueItem proto.InternalMessageInfo
+var xxx_messageInfo_GetFrequencyPlanRequest proto.InternalMessageInfo
 
-func (m *MulticastQueueItem) GetMulticastGroupId() []byte {
+func (m *GetFrequencyPlanRequest) GetId() []byte {
 	if m != nil {
-		return m.MulticastGroupId
+		return m.Id
 	}
 	return nil
 }
 
-func (m *MulticastQueueItem) GetFCnt() uint32 {
+func (m *GetFrequencyPlanRequest) GetVersion() uint32 {
 	if m != nil {
-		return m.FCnt
+		return m.Version
 	}
 	return 0
 }
 
-func (m *MulticastQueueItem) GetFPort() uint32 {
+type GetFrequencyPlanResponse struct {
+	// Frequency-plan object.
+	FrequencyPlan *FrequencyPlan `protobuf:"bytes,1,opt,name=frequency_plan,json=frequencyPlan,proto3" json:"frequency_plan,omitempty"`
+	// Version of the returned frequency-plan.
+	Version uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetFrequencyPlanResponse) Reset()         { *m = GetFrequencyPlanResponse{} }
+func (m *GetFrequencyPlanResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFrequencyPlanResponse) ProtoMessage()    {}
+func (*GetFrequencyPlanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{79}
+}
+
+func (m *GetFrequencyPlanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequencyPlanResponse.Unmarshal(m, b)
+}
+func (m *GetFrequencyPlanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequencyPlanResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFrequencyPlanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequencyPlanResponse.Merge(m, src)
+}
+func (m *GetFrequencyPlanResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFrequencyPlanResponse.Size(m)
+}
+func (m *GetFrequencyPlanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequencyPlanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFrequencyPlanResponse proto.InternalMessageInfo
+
+func (m *GetFrequencyPlanResponse) GetFrequencyPlan() *FrequencyPlan {
 	if m != nil {
-		return m.FPort
+		return m.FrequencyPlan
+	}
+	return nil
+}
+
+func (m *GetFrequencyPlanResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
 	}
 	return 0
 }
 
-func (m *MulticastQueueItem) GetFrmPayload() []byte {
+func (m *GetFrequencyPlanResponse) GetCreatedAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.FrmPayload
+		return m.CreatedAt
 	}
 	return nil
 }
 
-type EnqueueMulticastQueueItemRequest struct {
-	MulticastQueueItem   *MulticastQueueItem `protobuf:"bytes,1,opt,name=multicast_queue_item,json=multicastQueueItem,proto3" json:"multicast_queue_item,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *GetFrequencyPlanResponse) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
 }
 
-func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMulticastQueueItemRequest{} }
-func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
-func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
-func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{72}
+type UpdateFrequencyPlanRequest struct {
+	// Frequency-plan object to update.
+	FrequencyPlan        *FrequencyPlan `protobuf:"bytes,1,opt,name=frequency_plan,json=frequencyPlan,proto3" json:"frequency_plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *EnqueueMulticastQueueItemRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Unmarshal(m, b)
+func (m *UpdateFrequencyPlanRequest) Reset()         { *m = UpdateFrequencyPlanRequest{} }
+func (m *UpdateFrequencyPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateFrequencyPlanRequest) ProtoMessage()    {}
+func (*UpdateFrequencyPlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{80}
 }
-func (m *EnqueueMulticastQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Marshal(b, m, deterministic)
+
+func (m *UpdateFrequencyPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFrequencyPlanRequest.Unmarshal(m, b)
 }
-func (m *EnqueueMulticastQueueItemRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnqueueMulticastQueueItemRequest.Merge(m, src)
+func (m *UpdateFrequencyPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFrequencyPlanRequest.Marshal(b, m, deterministic)
 }
-func (m *EnqueueMulticastQueueItemRequest) XXX_Size() int {
-	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Size(m)
+func (m *UpdateFrequencyPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFrequencyPlanRequest.Merge(m, src)
 }
-func (m *EnqueueMulticastQueueItemRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnqueueMulticastQueueItemRequest.DiscardUnknown(m)
+func (m *UpdateFrequencyPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateFrequencyPlanRequest.Size(m)
+}
+func (m *UpdateFrequencyPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFrequencyPlanRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EnqueueMulticastQueueItemRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateFrequencyPlanRequest proto.InternalMessageInfo
 
-func (m *EnqueueMulticastQueueItemRequest) GetMulticastQueueItem() *MulticastQueueItem {
+func (m *UpdateFrequencyPlanRequest) GetFrequencyPlan() *FrequencyPlan {
 	if m != nil {
-		return m.MulticastQueueItem
+		return m.FrequencyPlan
 	}
 	return nil
 }
 
-type FlushMulticastQueueForMulticastGroupRequest struct {
-	// Multicast-group id.
-	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+type UpdateFrequencyPlanResponse struct {
+	// Version created by this update.
+	Version              uint32   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FlushMulticastQueueForMulticastGroupRequest) Reset() {
-	*m = FlushMulticastQueueForMulticastGroupRequest{}
-}
-func (m *FlushMulticastQueueForMulticastGroupRequest) String() string {
-	return proto.CompactTextString(m)
-}
-func (*FlushMulticastQueueForMulticastGroupRequest) ProtoMessage() {}
-func (*FlushMulticastQueueForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{73}
+func (m *UpdateFrequencyPlanResponse) Reset()         { *m = UpdateFrequencyPlanResponse{} }
+func (m *UpdateFrequencyPlanResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateFrequencyPlanResponse) ProtoMessage()    {}
+func (*UpdateFrequencyPlanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{81}
 }
 
-func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Unmarshal(m, b)
+func (m *UpdateFrequencyPlanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFrequencyPlanResponse.Unmarshal(m, b)
 }
-func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *UpdateFrequencyPlanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFrequencyPlanResponse.Marshal(b, m, deterministic)
 }
-func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Merge(m, src)
+func (m *UpdateFrequencyPlanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFrequencyPlanResponse.Merge(m, src)
 }
-func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Size(m)
+func (m *UpdateFrequencyPlanResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateFrequencyPlanResponse.Size(m)
 }
-func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.DiscardUnknown(m)
+func (m *UpdateFrequencyPlanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFrequencyPlanResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateFrequencyPlanResponse proto.InternalMessageInfo
 
-func (m *FlushMulticastQueueForMulticastGroupRequest) GetMulticastGroupId() []byte {
+func (m *UpdateFrequencyPlanResponse) GetVersion() uint32 {
 	if m != nil {
-		return m.MulticastGroupId
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-type GetMulticastQueueItemsForMulticastGroupRequest struct {
-	// Multicast-group id.
-	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+type DeleteFrequencyPlanRequest struct {
+	// Frequency-plan ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) Reset() {
-	*m = GetMulticastQueueItemsForMulticastGroupRequest{}
-}
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) String() string {
-	return proto.CompactTextString(m)
-}
-func (*GetMulticastQueueItemsForMulticastGroupRequest) ProtoMessage() {}
-func (*GetMulticastQueueItemsForMulticastGroupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{74}
+func (m *DeleteFrequencyPlanRequest) Reset()         { *m = DeleteFrequencyPlanRequest{} }
+func (m *DeleteFrequencyPlanRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFrequencyPlanRequest) ProtoMessage()    {}
+func (*DeleteFrequencyPlanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{82}
 }
 
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Unmarshal(m, b)
+func (m *DeleteFrequencyPlanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFrequencyPlanRequest.Unmarshal(m, b)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Marshal(b, m, deterministic)
+func (m *DeleteFrequencyPlanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFrequencyPlanRequest.Marshal(b, m, deterministic)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Merge(m, src)
+func (m *DeleteFrequencyPlanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFrequencyPlanRequest.Merge(m, src)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Size() int {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Size(m)
+func (m *DeleteFrequencyPlanRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFrequencyPlanRequest.Size(m)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.DiscardUnknown(m)
+func (m *DeleteFrequencyPlanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFrequencyPlanRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteFrequencyPlanRequest proto.InternalMessageInfo
 
-func (m *GetMulticastQueueItemsForMulticastGroupRequest) GetMulticastGroupId() []byte {
+func (m *DeleteFrequencyPlanRequest) GetId() []byte {
 	if m != nil {
-		return m.MulticastGroupId
+		return m.Id
 	}
 	return nil
 }
 
-type GetMulticastQueueItemsForMulticastGroupResponse struct {
-	MulticastQueueItems  []*MulticastQueueItem `protobuf:"bytes,1,rep,name=multicast_queue_items,json=multicastQueueItems,proto3" json:"multicast_queue_items,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+type GetFrequencyPlanVersionsRequest struct {
+	// Frequency-plan ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) Reset() {
-	*m = GetMulticastQueueItemsForMulticastGroupResponse{}
-}
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) String() string {
-	return proto.CompactTextString(m)
-}
-func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
-func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b280de855f92a4a, []int{75}
+func (m *GetFrequencyPlanVersionsRequest) Reset()         { *m = GetFrequencyPlanVersionsRequest{} }
+func (m *GetFrequencyPlanVersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFrequencyPlanVersionsRequest) ProtoMessage()    {}
+func (*GetFrequencyPlanVersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{83}
 }
 
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Unmarshal(m, b)
+func (m *GetFrequencyPlanVersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequencyPlanVersionsRequest.Unmarshal(m, b)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Marshal(b, m, deterministic)
+func (m *GetFrequencyPlanVersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequencyPlanVersionsRequest.Marshal(b, m, deterministic)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Merge(m, src)
+func (m *GetFrequencyPlanVersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequencyPlanVersionsRequest.Merge(m, src)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Size() int {
-	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Size(m)
+func (m *GetFrequencyPlanVersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFrequencyPlanVersionsRequest.Size(m)
 }
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.DiscardUnknown(m)
+func (m *GetFrequencyPlanVersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequencyPlanVersionsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetFrequencyPlanVersionsRequest proto.InternalMessageInfo
 
-func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems() []*MulticastQueueItem {
+func (m *GetFrequencyPlanVersionsRequest) GetId() []byte {
 	if m != nil {
-		return m.MulticastQueueItems
+		return m.Id
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
-	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
-	proto.RegisterEnum("ns.MulticastGroupType", MulticastGroupType_name, MulticastGroupType_value)
-	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
-	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
-	proto.RegisterType((*GetServiceProfileRequest)(nil), "ns.GetServiceProfileRequest")
-	proto.RegisterType((*GetServiceProfileResponse)(nil), "ns.GetServiceProfileResponse")
-	proto.RegisterType((*UpdateServiceProfileRequest)(nil), "ns.UpdateServiceProfileRequest")
-	proto.RegisterType((*DeleteServiceProfileRequest)(nil), "ns.DeleteServiceProfileRequest")
-	proto.RegisterType((*CreateRoutingProfileRequest)(nil), "ns.CreateRoutingProfileRequest")
-	proto.RegisterType((*CreateRoutingProfileResponse)(nil), "ns.CreateRoutingProfileResponse")
-	proto.RegisterType((*GetRoutingProfileRequest)(nil), "ns.GetRoutingProfileRequest")
-	proto.RegisterType((*GetRoutingProfileResponse)(nil), "ns.GetRoutingProfileResponse")
-	proto.RegisterType((*UpdateRoutingProfileRequest)(nil), "ns.UpdateRoutingProfileRequest")
-	proto.RegisterType((*DeleteRoutingProfileRequest)(nil), "ns.DeleteRoutingProfileRequest")
-	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
-	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
-	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
-	proto.RegisterType((*GetDeviceProfileResponse)(nil), "ns.GetDeviceProfileResponse")
-	proto.RegisterType((*UpdateDeviceProfileRequest)(nil), "ns.UpdateDeviceProfileRequest")
-	proto.RegisterType((*DeleteDeviceProfileRequest)(nil), "ns.DeleteDeviceProfileRequest")
-	proto.RegisterType((*Device)(nil), "ns.Device")
-	proto.RegisterType((*CreateDeviceRequest)(nil), "ns.CreateDeviceRequest")
-	proto.RegisterType((*GetDeviceRequest)(nil), "ns.GetDeviceRequest")
-	proto.RegisterType((*GetDeviceResponse)(nil), "ns.GetDeviceResponse")
-	proto.RegisterType((*UpdateDeviceRequest)(nil), "ns.UpdateDeviceRequest")
-	proto.RegisterType((*DeleteDeviceRequest)(nil), "ns.DeleteDeviceRequest")
-	proto.RegisterType((*DeviceActivation)(nil), "ns.DeviceActivation")
-	proto.RegisterType((*ActivateDeviceRequest)(nil), "ns.ActivateDeviceRequest")
-	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
-	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
-	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
-	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
-	proto.RegisterType((*CreateMACCommandQueueItemRequest)(nil), "ns.CreateMACCommandQueueItemRequest")
-	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
-	proto.RegisterType((*Gateway)(nil), "ns.Gateway")
-	proto.RegisterType((*GatewayBoard)(nil), "ns.GatewayBoard")
-	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
-	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
-	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
-	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
-	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
-	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
-	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
-	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
-	proto.RegisterType((*DeviceQueueItem)(nil), "ns.DeviceQueueItem")
-	proto.RegisterType((*CreateDeviceQueueItemRequest)(nil), "ns.CreateDeviceQueueItemRequest")
-	proto.RegisterType((*FlushDeviceQueueForDevEUIRequest)(nil), "ns.FlushDeviceQueueForDevEUIRequest")
-	proto.RegisterType((*GetDeviceQueueItemsForDevEUIRequest)(nil), "ns.GetDeviceQueueItemsForDevEUIRequest")
-	proto.RegisterType((*GetDeviceQueueItemsForDevEUIResponse)(nil), "ns.GetDeviceQueueItemsForDevEUIResponse")
-	proto.RegisterType((*GetNextDownlinkFCntForDevEUIRequest)(nil), "ns.GetNextDownlinkFCntForDevEUIRequest")
-	proto.RegisterType((*GetNextDownlinkFCntForDevEUIResponse)(nil), "ns.GetNextDownlinkFCntForDevEUIResponse")
-	proto.RegisterType((*StreamFrameLogsForGatewayRequest)(nil), "ns.StreamFrameLogsForGatewayRequest")
-	proto.RegisterType((*StreamFrameLogsForGatewayResponse)(nil), "ns.StreamFrameLogsForGatewayResponse")
-	proto.RegisterType((*StreamFrameLogsForDeviceRequest)(nil), "ns.StreamFrameLogsForDeviceRequest")
-	proto.RegisterType((*StreamFrameLogsForDeviceResponse)(nil), "ns.StreamFrameLogsForDeviceResponse")
-	proto.RegisterType((*GetVersionResponse)(nil), "ns.GetVersionResponse")
-	proto.RegisterType((*GatewayProfile)(nil), "ns.GatewayProfile")
-	proto.RegisterType((*GatewayProfileExtraChannel)(nil), "ns.GatewayProfileExtraChannel")
-	proto.RegisterType((*CreateGatewayProfileRequest)(nil), "ns.CreateGatewayProfileRequest")
-	proto.RegisterType((*CreateGatewayProfileResponse)(nil), "ns.CreateGatewayProfileResponse")
-	proto.RegisterType((*GetGatewayProfileRequest)(nil), "ns.GetGatewayProfileRequest")
-	proto.RegisterType((*GetGatewayProfileResponse)(nil), "ns.GetGatewayProfileResponse")
-	proto.RegisterType((*UpdateGatewayProfileRequest)(nil), "ns.UpdateGatewayProfileRequest")
-	proto.RegisterType((*DeleteGatewayProfileRequest)(nil), "ns.DeleteGatewayProfileRequest")
-	proto.RegisterType((*MulticastGroup)(nil), "ns.MulticastGroup")
-	proto.RegisterType((*CreateMulticastGroupRequest)(nil), "ns.CreateMulticastGroupRequest")
-	proto.RegisterType((*CreateMulticastGroupResponse)(nil), "ns.CreateMulticastGroupResponse")
-	proto.RegisterType((*GetMulticastGroupRequest)(nil), "ns.GetMulticastGroupRequest")
-	proto.RegisterType((*GetMulticastGroupResponse)(nil), "ns.GetMulticastGroupResponse")
-	proto.RegisterType((*UpdateMulticastGroupRequest)(nil), "ns.UpdateMulticastGroupRequest")
-	proto.RegisterType((*DeleteMulticastGroupRequest)(nil), "ns.DeleteMulticastGroupRequest")
-	proto.RegisterType((*AddDeviceToMulticastGroupRequest)(nil), "ns.AddDeviceToMulticastGroupRequest")
-	proto.RegisterType((*RemoveDeviceFromMulticastGroupRequest)(nil), "ns.RemoveDeviceFromMulticastGroupRequest")
-	proto.RegisterType((*MulticastQueueItem)(nil), "ns.MulticastQueueItem")
-	proto.RegisterType((*EnqueueMulticastQueueItemRequest)(nil), "ns.EnqueueMulticastQueueItemRequest")
-	proto.RegisterType((*FlushMulticastQueueForMulticastGroupRequest)(nil), "ns.FlushMulticastQueueForMulticastGroupRequest")
-	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupRequest)(nil), "ns.GetMulticastQueueItemsForMulticastGroupRequest")
-	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupResponse)(nil), "ns.GetMulticastQueueItemsForMulticastGroupResponse")
+type GetFrequencyPlanVersionsResponse struct {
+	// Versions, newest first.
+	Versions             []*FrequencyPlanVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func init() { proto.RegisterFile("ns.proto", fileDescriptor_3b280de855f92a4a) }
-
-var fileDescriptor_3b280de855f92a4a = []byte{
-	// 3002 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0xcd, 0x72, 0xdb, 0xc8,
-	0xf1, 0x17, 0x29, 0x91, 0x92, 0x5a, 0x22, 0x05, 0x8d, 0x6c, 0x8b, 0xa6, 0xe5, 0x95, 0x8c, 0xf5,
-	0xae, 0xb5, 0x5e, 0xaf, 0xfc, 0xff, 0x6b, 0xcb, 0x55, 0xfb, 0x91, 0x75, 0x8a, 0x4b, 0x51, 0xb6,
-	0xd6, 0xb6, 0x6c, 0x83, 0x96, 0xf7, 0xab, 0x2a, 0x08, 0x0c, 0x0c, 0x69, 0x94, 0x08, 0x80, 0x0b,
-	0x0c, 0x25, 0x2b, 0x55, 0x39, 0xa4, 0x72, 0xcc, 0x21, 0x97, 0xbc, 0x43, 0x72, 0x49, 0x25, 0xe7,
-	0x3c, 0x42, 0xaa, 0x92, 0x4b, 0x6e, 0xfb, 0x0c, 0x39, 0xe5, 0x09, 0x52, 0x83, 0x19, 0x0c, 0x3e,
-	0x38, 0x00, 0xe9, 0xb5, 0x5d, 0xce, 0xc5, 0x16, 0xa6, 0x7b, 0x7e, 0xd3, 0xdd, 0xd3, 0x33, 0xdd,
-	0xd3, 0x4d, 0x58, 0x70, 0x83, 0x9d, 0xa1, 0xef, 0x11, 0x0f, 0x95, 0xdd, 0xa0, 0x79, 0x91, 0xd8,
-	0x0e, 0x0e, 0x88, 0xe1, 0x0c, 0x6f, 0x8a, 0xbf, 0x18, 0xb9, 0xb9, 0x8a, 0x9d, 0x21, 0x39, 0xbb,
-	0x19, 0xfe, 0xcb, 0x87, 0xd6, 0x8d, 0xa1, 0x7d, 0xd3, 0xf4, 0x1c, 0xc7, 0x73, 0xf9, 0x7f, 0x9c,
-	0xb0, 0x42, 0x09, 0xfd, 0xd3, 0x9b, 0xfd, 0x53, 0x3e, 0x50, 0x1f, 0xfa, 0x5e, 0xcf, 0x1e, 0x60,
-	0xbe, 0x96, 0xfa, 0x1d, 0x5c, 0x6a, 0xfb, 0xd8, 0x20, 0xb8, 0x8b, 0xfd, 0x13, 0xdb, 0xc4, 0x8f,
-	0x18, 0x59, 0xc3, 0x3f, 0x8c, 0x70, 0x40, 0xd0, 0xe7, 0xb0, 0x12, 0x30, 0x82, 0xce, 0x27, 0x36,
-	0x4a, 0x5b, 0xa5, 0xed, 0xa5, 0x5d, 0xb4, 0xe3, 0x06, 0x3b, 0x99, 0x39, 0xf5, 0x20, 0xf5, 0xad,
-	0xee, 0xc0, 0x86, 0x1c, 0x3b, 0x18, 0x7a, 0x6e, 0x80, 0x51, 0x1d, 0xca, 0xb6, 0x15, 0xe2, 0x2d,
-	0x6b, 0x65, 0xdb, 0x52, 0xaf, 0x43, 0xe3, 0x0e, 0x26, 0x72, 0x41, 0xb2, 0xbc, 0xff, 0x2c, 0xc1,
-	0x45, 0x09, 0x33, 0x47, 0x7e, 0x15, 0xb1, 0xd1, 0xa7, 0x00, 0x66, 0x28, 0xb6, 0xa5, 0x1b, 0xa4,
-	0x51, 0x0e, 0xe7, 0x35, 0x77, 0xfa, 0x9e, 0xd7, 0x1f, 0x60, 0x66, 0xb5, 0x67, 0xa3, 0xde, 0xce,
-	0x93, 0x68, 0x57, 0xb4, 0x45, 0xce, 0xdd, 0x22, 0x74, 0xea, 0x68, 0x68, 0x45, 0x53, 0x67, 0x27,
-	0x4f, 0xe5, 0xdc, 0x2d, 0x42, 0x37, 0xe2, 0x28, 0xfc, 0x78, 0x03, 0x1b, 0xf1, 0x11, 0x5c, 0xda,
-	0xc3, 0x03, 0x9c, 0x87, 0x9d, 0xb5, 0xad, 0xf0, 0x09, 0xcd, 0x1b, 0x11, 0xdb, 0xed, 0x8f, 0x8b,
-	0xe2, 0x33, 0x82, 0x4c, 0x94, 0xcc, 0x9c, 0xba, 0x9f, 0xfa, 0x8e, 0x7d, 0x22, 0x8b, 0x5d, 0xe8,
-	0x13, 0x72, 0x41, 0x72, 0x7c, 0x22, 0x07, 0xf9, 0x55, 0xc4, 0x7e, 0xdb, 0x3e, 0xf1, 0x06, 0x36,
-	0x42, 0xf8, 0xc4, 0x74, 0xb6, 0x7d, 0x0a, 0x4d, 0xb6, 0x6f, 0x7b, 0x58, 0xe2, 0x41, 0x9f, 0x40,
-	0xdd, 0xc2, 0x12, 0xe7, 0x5c, 0xa5, 0x82, 0xa4, 0x67, 0xd4, 0x2c, 0x9c, 0x71, 0x4d, 0x29, 0x6e,
-	0x8e, 0x3b, 0x7c, 0x00, 0xeb, 0x77, 0x30, 0x91, 0xca, 0x90, 0x65, 0xfd, 0x7b, 0x29, 0x74, 0x1d,
-	0x39, 0xee, 0x4f, 0x16, 0xf8, 0x2d, 0x79, 0xc2, 0x53, 0x68, 0x32, 0x4f, 0x78, 0xcd, 0xe6, 0xbf,
-	0x01, 0x4d, 0xe6, 0x05, 0x53, 0x99, 0xf4, 0x37, 0x65, 0xa8, 0x32, 0x46, 0xb4, 0x0e, 0xf3, 0x16,
-	0x3e, 0xd1, 0xf1, 0xc8, 0xe6, 0xf4, 0xaa, 0x85, 0x4f, 0x3a, 0x23, 0x1b, 0x5d, 0x87, 0xd5, 0xb4,
-	0x2c, 0xba, 0x6d, 0x85, 0x66, 0x5a, 0xd6, 0x56, 0x52, 0x6b, 0x1f, 0x58, 0xe8, 0x06, 0xa0, 0xcc,
-	0xa5, 0x46, 0x99, 0x67, 0x43, 0x66, 0x25, 0x7d, 0x87, 0x31, 0xee, 0x8c, 0xbb, 0x53, 0xee, 0x39,
-	0xc6, 0x9d, 0xf6, 0xee, 0x03, 0x0b, 0x5d, 0x03, 0x25, 0x38, 0xb6, 0x87, 0x7a, 0x4f, 0x37, 0x5d,
-	0xa2, 0x9b, 0xcf, 0xb1, 0x79, 0xdc, 0xa8, 0x6c, 0x95, 0xb6, 0x17, 0xb4, 0x1a, 0x1d, 0xdf, 0x6f,
-	0xbb, 0xa4, 0x4d, 0x07, 0xd1, 0x47, 0x80, 0x7c, 0xdc, 0xc3, 0x3e, 0x76, 0x4d, 0xac, 0x1b, 0x03,
-	0x62, 0x93, 0x91, 0x85, 0x1b, 0xd5, 0xad, 0xd2, 0x76, 0x49, 0x5b, 0x15, 0x94, 0x16, 0x27, 0xa8,
-	0x9f, 0xc2, 0x5a, 0xd2, 0x61, 0x23, 0x53, 0xa9, 0x50, 0x65, 0xda, 0x71, 0xd3, 0x43, 0x6c, 0x7a,
-	0x8d, 0x53, 0xd4, 0x0f, 0x41, 0x11, 0x0e, 0x19, 0xcd, 0xcb, 0xb3, 0xa3, 0xfa, 0xe7, 0x12, 0xac,
-	0x26, 0xb8, 0xb9, 0xdf, 0x4e, 0xb1, 0xcc, 0x5b, 0xf2, 0xd0, 0x4f, 0x61, 0x2d, 0xe9, 0xa1, 0x2f,
-	0x63, 0x97, 0x1d, 0x58, 0x4b, 0x3a, 0xe1, 0x44, 0xd3, 0xfc, 0xad, 0x0c, 0x0a, 0x63, 0x6d, 0x99,
-	0xc4, 0x3e, 0x31, 0x88, 0xed, 0xb9, 0xf9, 0x0e, 0x79, 0x11, 0x16, 0x28, 0xc1, 0xb0, 0x2c, 0x9f,
-	0xfb, 0x21, 0x65, 0x6c, 0x59, 0x96, 0x8f, 0xae, 0xc2, 0x4a, 0xa0, 0xbb, 0xa7, 0xc7, 0x7a, 0xa0,
-	0xdb, 0x2e, 0xd1, 0x8f, 0xf1, 0x19, 0x77, 0xbe, 0xa5, 0xe0, 0xf0, 0xf4, 0xb8, 0x7b, 0xe0, 0x92,
-	0x7b, 0xf8, 0x8c, 0x72, 0xf5, 0x32, 0x5c, 0xcc, 0xe9, 0x96, 0x7a, 0x09, 0xae, 0x2b, 0x50, 0x63,
-	0x3c, 0xd8, 0x35, 0x43, 0x9e, 0x4a, 0xc8, 0x03, 0xee, 0xe9, 0x71, 0xb7, 0xe3, 0x9a, 0x94, 0xa5,
-	0x01, 0x0b, 0xcc, 0x1b, 0x47, 0xc3, 0xd0, 0xbf, 0x6a, 0x5a, 0xb5, 0xd7, 0x76, 0xc9, 0xd1, 0x10,
-	0x6d, 0xc2, 0xb2, 0xcb, 0x3d, 0xd5, 0xf2, 0x4e, 0xdd, 0xc6, 0x7c, 0x48, 0x5d, 0x74, 0xa9, 0x97,
-	0xee, 0x79, 0xa7, 0x2e, 0x65, 0x30, 0x92, 0x0c, 0x0b, 0x8c, 0xc1, 0x10, 0x0c, 0x32, 0x77, 0x5f,
-	0x94, 0xb8, 0xbb, 0xfa, 0x1d, 0x9c, 0xe7, 0x56, 0xcb, 0x98, 0xbb, 0x25, 0x0e, 0xae, 0x21, 0xac,
-	0xca, 0x37, 0xed, 0x5c, 0xbc, 0x69, 0xb1, 0xc5, 0x35, 0xc5, 0xca, 0x8c, 0xa8, 0xbb, 0xb0, 0xbe,
-	0x87, 0x0d, 0x29, 0x7a, 0xee, 0x66, 0xde, 0x82, 0xa6, 0x70, 0xf3, 0x04, 0xf8, 0xa4, 0x69, 0xbf,
-	0x84, 0x4b, 0xd2, 0x69, 0xfc, 0x9c, 0xbc, 0x06, 0x65, 0x6e, 0xb1, 0xcc, 0xc3, 0x70, 0x2d, 0xcf,
-	0xd9, 0x63, 0x0e, 0x23, 0xe0, 0x93, 0x3e, 0x55, 0x4a, 0xf9, 0x94, 0x6a, 0xc3, 0x16, 0xbb, 0x1f,
-	0x1e, 0xb4, 0xda, 0x6d, 0xcf, 0x71, 0x0c, 0xd7, 0x7a, 0x3c, 0xc2, 0x23, 0x7c, 0x40, 0xb0, 0x33,
-	0x49, 0x2b, 0xa4, 0xc0, 0xac, 0xc9, 0xef, 0xb4, 0x9a, 0x46, 0xff, 0x44, 0x4d, 0x58, 0x30, 0x19,
-	0x4a, 0xd0, 0xa8, 0x6c, 0xcd, 0x6e, 0x2f, 0x6b, 0xe2, 0x5b, 0xfd, 0xb1, 0x04, 0x97, 0xbb, 0xd8,
-	0xb5, 0x1e, 0xf9, 0xde, 0xd0, 0xb7, 0x31, 0x31, 0xfc, 0xb3, 0x47, 0xc6, 0xd9, 0xc0, 0x33, 0xac,
-	0x68, 0xa1, 0x4d, 0x58, 0x72, 0x0c, 0x53, 0x1f, 0xb2, 0x51, 0xbe, 0x18, 0x38, 0x86, 0xc9, 0xf9,
-	0xe8, 0x82, 0x8e, 0x6d, 0xf2, 0x73, 0x41, 0xff, 0x44, 0x57, 0x60, 0xb9, 0x6f, 0x10, 0x7c, 0x6a,
-	0x9c, 0xe9, 0x8e, 0x61, 0x06, 0x8d, 0xd9, 0x70, 0xd1, 0x25, 0x3e, 0xf6, 0xc0, 0x30, 0x03, 0x74,
-	0x0b, 0x2e, 0x0c, 0xbd, 0x81, 0xe1, 0xdb, 0xbf, 0x0a, 0x2d, 0xa5, 0xdb, 0xee, 0x09, 0xf6, 0x03,
-	0x6a, 0xe1, 0xb9, 0xd0, 0xe3, 0xce, 0x27, 0xa9, 0x07, 0x11, 0x11, 0x6d, 0xc0, 0x62, 0xcf, 0xa7,
-	0x82, 0xb9, 0x26, 0x3b, 0x1d, 0x35, 0x2d, 0x1e, 0xa0, 0xb1, 0xc6, 0xf2, 0xf9, 0xb1, 0x28, 0x5b,
-	0xbe, 0xfa, 0x8f, 0x12, 0xcc, 0xdf, 0x61, 0x8b, 0x66, 0xe3, 0x10, 0xba, 0x01, 0x0b, 0x03, 0xcf,
-	0x64, 0x9b, 0xca, 0xee, 0x37, 0x65, 0x87, 0x3f, 0x7b, 0xee, 0xf3, 0x71, 0x4d, 0x70, 0xd0, 0xb8,
-	0x11, 0x69, 0x34, 0x1e, 0x65, 0x38, 0x25, 0x8e, 0x1b, 0xdb, 0x50, 0x7d, 0xe6, 0x19, 0xbe, 0x15,
-	0x34, 0xe6, 0xb6, 0x66, 0x43, 0x64, 0x37, 0xd8, 0xe1, 0x82, 0x7c, 0x49, 0x09, 0x1a, 0xa7, 0xe7,
-	0xc4, 0xa3, 0x8a, 0x3c, 0x1e, 0xa9, 0x47, 0xb0, 0x9c, 0x44, 0xa1, 0x3e, 0xd0, 0x1b, 0xf6, 0x0d,
-	0x5d, 0x28, 0x56, 0xa5, 0x9f, 0x2c, 0xcc, 0xf5, 0x6c, 0x17, 0xeb, 0xe2, 0xd9, 0x17, 0xde, 0x26,
-	0x6c, 0x87, 0x14, 0x4a, 0x11, 0xd7, 0xef, 0x3d, 0x7c, 0xa6, 0x7e, 0x01, 0xe7, 0x98, 0xbb, 0x71,
-	0xf0, 0x68, 0xe7, 0xdf, 0x83, 0x79, 0xae, 0x1a, 0x77, 0xfb, 0xa5, 0x84, 0x1e, 0x5a, 0x44, 0x53,
-	0xdf, 0x0d, 0x83, 0x4c, 0x66, 0x6e, 0x36, 0xec, 0xff, 0xa5, 0x0c, 0x28, 0xc9, 0xc5, 0x0f, 0xc1,
-	0x74, 0x4b, 0xbc, 0x9d, 0x70, 0x84, 0x6e, 0x43, 0xad, 0x67, 0xfb, 0x01, 0xd1, 0x03, 0x8c, 0x5d,
-	0x3a, 0x7b, 0x6e, 0xe2, 0xec, 0xa5, 0x70, 0x42, 0x17, 0x63, 0xb7, 0x45, 0xd0, 0xcf, 0x60, 0x79,
-	0x60, 0x24, 0xa6, 0x57, 0x26, 0x4e, 0x07, 0xca, 0xcf, 0x66, 0xd3, 0x5d, 0x61, 0xc1, 0xf0, 0xa7,
-	0xed, 0xca, 0xfb, 0x70, 0x8e, 0x05, 0xc4, 0x09, 0x1b, 0xf3, 0xbb, 0xb2, 0x70, 0xaa, 0x2e, 0x31,
-	0x48, 0x80, 0x3e, 0x81, 0x45, 0xe1, 0x36, 0x7c, 0x85, 0x42, 0x7b, 0x09, 0x66, 0xb4, 0x03, 0x6b,
-	0xfe, 0x0b, 0x7d, 0x68, 0x98, 0xc7, 0x98, 0x04, 0xba, 0x8f, 0x4d, 0x6c, 0x9f, 0x60, 0x96, 0xb8,
-	0x55, 0xb4, 0x55, 0xff, 0xc5, 0x23, 0x46, 0xd1, 0x38, 0x01, 0x7d, 0x0c, 0x17, 0x24, 0xfc, 0xba,
-	0x77, 0x1c, 0x6e, 0x53, 0x45, 0x5b, 0x1b, 0x9b, 0xf2, 0xf0, 0x98, 0x2e, 0x42, 0x24, 0x8b, 0xcc,
-	0xb1, 0x45, 0xc8, 0xd8, 0x22, 0x37, 0x00, 0x25, 0xf8, 0xb1, 0x63, 0x13, 0x82, 0xd9, 0x09, 0xab,
-	0x68, 0x8a, 0x60, 0xef, 0xb0, 0x71, 0xf5, 0x3f, 0x25, 0xb8, 0x10, 0xbb, 0x69, 0x68, 0x90, 0xc8,
-	0x70, 0x97, 0x01, 0xa2, 0x2b, 0x40, 0x18, 0x70, 0x91, 0x8f, 0x1c, 0x50, 0x65, 0x16, 0x6c, 0x97,
-	0x60, 0xff, 0xc4, 0x18, 0x84, 0x1a, 0xd7, 0x77, 0xd7, 0xe9, 0xbe, 0xb4, 0xfa, 0x7d, 0x1f, 0xf7,
-	0xf9, 0x2d, 0xc6, 0xc8, 0x9a, 0x60, 0x44, 0x6d, 0x58, 0x09, 0x88, 0xe1, 0x93, 0xf8, 0xa0, 0x4e,
-	0xe1, 0xa1, 0xf5, 0x70, 0x8a, 0xf8, 0x46, 0x3f, 0x87, 0x1a, 0x76, 0xad, 0x04, 0xc4, 0x64, 0x37,
-	0x5d, 0xc6, 0xae, 0x25, 0xbe, 0xd4, 0x76, 0xf8, 0x20, 0x4a, 0xeb, 0xcc, 0xcf, 0xe7, 0x36, 0x54,
-	0x7d, 0x1c, 0x8c, 0x06, 0xa4, 0x51, 0x1a, 0xbb, 0xc9, 0x18, 0x27, 0xa7, 0xab, 0x7f, 0x2d, 0xc1,
-	0x0a, 0x8b, 0x88, 0x22, 0x54, 0xe5, 0xc7, 0xa8, 0x4d, 0x58, 0xea, 0xf9, 0x8e, 0x88, 0x29, 0xec,
-	0x62, 0x82, 0x9e, 0xef, 0x44, 0x31, 0x65, 0x0d, 0x2a, 0x61, 0x16, 0x12, 0x9a, 0xa3, 0xa6, 0xcd,
-	0xd1, 0x1c, 0x07, 0x9d, 0x87, 0x6a, 0x4f, 0x1f, 0x7a, 0x3e, 0xe1, 0xc1, 0xad, 0xd2, 0x7b, 0xe4,
-	0xf9, 0x84, 0xc6, 0x04, 0xd3, 0x73, 0x7b, 0xb6, 0xef, 0xf0, 0x8d, 0x5d, 0xd0, 0xe2, 0x81, 0x54,
-	0x98, 0xad, 0xa6, 0xc3, 0xec, 0x9d, 0xa8, 0x8e, 0x90, 0x91, 0x3b, 0xda, 0xf1, 0x6b, 0x30, 0x67,
-	0x13, 0xec, 0xf0, 0x43, 0xb0, 0x16, 0xc7, 0xfc, 0x98, 0x33, 0x64, 0x50, 0x3f, 0x87, 0xad, 0xfd,
-	0xc1, 0x28, 0x78, 0x9e, 0xa0, 0xee, 0x7b, 0xfe, 0x1e, 0x3e, 0xe9, 0x1c, 0x1d, 0x4c, 0xcc, 0x42,
-	0x6e, 0xc3, 0xbb, 0x22, 0x0b, 0x11, 0xc0, 0xc1, 0xf4, 0xf3, 0x1f, 0xc3, 0xd5, 0xe2, 0xf9, 0x7c,
-	0x2b, 0x3f, 0x80, 0x0a, 0x15, 0x36, 0xe0, 0x3b, 0x29, 0x55, 0x87, 0x71, 0x70, 0x91, 0x0e, 0xf1,
-	0x8b, 0x30, 0x2f, 0x1c, 0xd8, 0xee, 0x31, 0xcd, 0xfd, 0xa6, 0x17, 0xe9, 0xf3, 0x50, 0xa4, 0x82,
-	0xf9, 0x5c, 0x24, 0xb1, 0xcb, 0xa5, 0x78, 0x97, 0xd5, 0x16, 0x6c, 0x75, 0x89, 0x8f, 0x0d, 0x67,
-	0xdf, 0x37, 0x1c, 0x7c, 0xdf, 0xeb, 0x53, 0x5d, 0x32, 0x97, 0x58, 0xf1, 0x59, 0x54, 0xff, 0x54,
-	0x82, 0x2b, 0x05, 0x18, 0x7c, 0xf5, 0xdb, 0xa0, 0x8c, 0x86, 0x54, 0x38, 0xbd, 0x47, 0xb9, 0xf4,
-	0x00, 0x13, 0x51, 0xfb, 0xe8, 0x9f, 0xee, 0x1c, 0x85, 0xb4, 0x10, 0xa0, 0x8b, 0xc9, 0xdd, 0x19,
-	0xad, 0x3e, 0x4a, 0x8d, 0xa0, 0xcf, 0xa0, 0x6e, 0x71, 0xf5, 0x18, 0x02, 0x0f, 0x4c, 0xab, 0x74,
-	0xb6, 0x50, 0x9c, 0x12, 0xee, 0xce, 0x68, 0x35, 0x2b, 0x39, 0xf0, 0xe5, 0x3c, 0x54, 0xc2, 0x29,
-	0xea, 0x67, 0xb0, 0x39, 0x2e, 0xe9, 0x94, 0x69, 0xef, 0x1f, 0x4b, 0x32, 0x53, 0x65, 0x5e, 0x7b,
-	0xff, 0x13, 0x5a, 0x3e, 0x0d, 0x83, 0xff, 0x53, 0x96, 0xc4, 0x09, 0xd1, 0x1a, 0x30, 0x1f, 0x25,
-	0x7d, 0x54, 0xa2, 0x45, 0x2d, 0xfa, 0x44, 0xef, 0xd3, 0x6b, 0xa7, 0x1f, 0xa5, 0x66, 0xf5, 0xdd,
-	0x7a, 0x94, 0x9a, 0x69, 0xe1, 0xa8, 0xc6, 0xa9, 0xea, 0x6f, 0x4b, 0x50, 0xbf, 0x93, 0xca, 0xbe,
-	0xc6, 0xf2, 0x3c, 0x9a, 0xfc, 0x3e, 0x37, 0x5c, 0x17, 0x0f, 0x82, 0x46, 0x79, 0x6b, 0x76, 0xbb,
-	0xa6, 0x89, 0x6f, 0xd4, 0x81, 0x3a, 0x7e, 0x41, 0x7c, 0x43, 0x17, 0x1c, 0xb3, 0xe1, 0xd9, 0x78,
-	0x27, 0x71, 0xcb, 0x71, 0xdc, 0x0e, 0xe5, 0x6b, 0x33, 0x36, 0xad, 0x86, 0x13, 0x5f, 0x81, 0xfa,
-	0xaf, 0x12, 0x34, 0xf3, 0xb9, 0xd1, 0x2e, 0x80, 0xe3, 0x59, 0xa3, 0x41, 0xfc, 0x80, 0xa8, 0xef,
-	0xa2, 0x48, 0xa1, 0x07, 0x82, 0xa2, 0x25, 0xb8, 0xd2, 0x79, 0x6e, 0x39, 0x9b, 0xe7, 0x6e, 0xc0,
-	0xe2, 0x33, 0xc3, 0xb5, 0x4e, 0x6d, 0x8b, 0x3c, 0xe7, 0x37, 0x64, 0x3c, 0x40, 0xcd, 0xfa, 0xcc,
-	0x26, 0xbe, 0x41, 0x30, 0xbf, 0x27, 0xa3, 0x4f, 0xf4, 0x21, 0xac, 0x06, 0x43, 0x1f, 0x1b, 0x16,
-	0xcd, 0x37, 0x7b, 0x86, 0x49, 0x3c, 0x9f, 0xbd, 0x08, 0x6a, 0x9a, 0x22, 0x08, 0xfb, 0x6c, 0x3c,
-	0xae, 0xe0, 0xa6, 0x55, 0x4b, 0x14, 0x0e, 0x33, 0x19, 0x71, 0xb2, 0x70, 0x98, 0x99, 0x53, 0x4f,
-	0xa7, 0xc8, 0x71, 0x05, 0x37, 0x8b, 0x5d, 0x58, 0xc1, 0x95, 0x0b, 0x92, 0x53, 0xc1, 0xcd, 0x41,
-	0x7e, 0x15, 0xb1, 0xdf, 0x76, 0x05, 0xf7, 0x0d, 0x6c, 0x84, 0xa8, 0xe0, 0x4e, 0x67, 0xdb, 0x1f,
-	0xcb, 0x50, 0x7f, 0x30, 0x1a, 0x10, 0xdb, 0x34, 0x02, 0x72, 0xc7, 0xf7, 0x46, 0xc3, 0xb1, 0xf3,
-	0xb6, 0x0e, 0xf3, 0x8e, 0x99, 0xac, 0x94, 0x54, 0x1d, 0x33, 0x2c, 0x94, 0x6c, 0xc2, 0xb2, 0x63,
-	0xf2, 0x1a, 0x48, 0x5c, 0x25, 0x59, 0x74, 0xcc, 0xc3, 0xd3, 0xe3, 0xee, 0x3d, 0x7c, 0x16, 0x47,
-	0x83, 0xb9, 0x44, 0xcc, 0xbf, 0x05, 0xd0, 0xa7, 0xeb, 0xe8, 0xe4, 0x6c, 0x88, 0xc3, 0xe8, 0x5e,
-	0xdf, 0xbd, 0x40, 0x15, 0x4b, 0x8b, 0xf1, 0xe4, 0x6c, 0x88, 0xb5, 0xc5, 0x7e, 0xf4, 0x67, 0xf6,
-	0x25, 0x98, 0x3e, 0x4f, 0xf3, 0xd9, 0xf3, 0xb4, 0x0d, 0xca, 0x90, 0x1e, 0x89, 0x60, 0xe0, 0x11,
-	0x7d, 0x88, 0x7d, 0xdb, 0xb3, 0x78, 0x75, 0xa4, 0x4e, 0xc7, 0xbb, 0x03, 0x8f, 0x3c, 0x0a, 0x47,
-	0x73, 0xaa, 0x8d, 0x8b, 0x2f, 0x55, 0x6d, 0x84, 0x9c, 0xd7, 0x9d, 0x38, 0x70, 0x69, 0xd5, 0x12,
-	0xfb, 0xec, 0x44, 0x04, 0x3d, 0xd4, 0x34, 0xb9, 0xcf, 0x99, 0x39, 0x75, 0x27, 0xf5, 0x1d, 0x1f,
-	0xb8, 0x2c, 0x76, 0xe1, 0x81, 0x93, 0x0b, 0x92, 0x73, 0xe0, 0x72, 0x90, 0x5f, 0x45, 0xec, 0xb7,
-	0x7d, 0xe0, 0xde, 0xc0, 0x46, 0x88, 0x03, 0x37, 0x9d, 0x6d, 0x6d, 0xd8, 0x6a, 0x59, 0x16, 0x0b,
-	0xe9, 0x4f, 0x3c, 0xf9, 0x9c, 0xdc, 0x2c, 0xfb, 0x06, 0xa0, 0x8c, 0xa0, 0x71, 0x1d, 0x5d, 0x49,
-	0xcb, 0x75, 0x60, 0xa9, 0x2e, 0xbc, 0xa7, 0x61, 0xc7, 0x3b, 0xe1, 0xd9, 0xf0, 0xbe, 0xef, 0x39,
-	0x6f, 0x74, 0xbd, 0xdf, 0x97, 0x00, 0x89, 0x05, 0xe2, 0x37, 0x83, 0x1c, 0xa4, 0x24, 0x07, 0x89,
-	0xef, 0x8c, 0xb2, 0xf4, 0x9d, 0x30, 0x9b, 0x7c, 0x27, 0x64, 0x1e, 0x1d, 0x73, 0xd9, 0x47, 0x87,
-	0x3a, 0x80, 0xad, 0x8e, 0xfb, 0x03, 0x95, 0x64, 0x5c, 0xae, 0x48, 0xf9, 0xbb, 0x70, 0x2e, 0x16,
-	0x2f, 0xe4, 0xd5, 0x13, 0x6f, 0x84, 0xf4, 0xcd, 0x14, 0x4f, 0x8e, 0x55, 0x12, 0x63, 0xea, 0xf7,
-	0xf0, 0x61, 0xf8, 0x68, 0x48, 0xb3, 0xef, 0x7b, 0xbe, 0xdc, 0xea, 0x2f, 0x65, 0x17, 0xf5, 0x17,
-	0xb0, 0x93, 0x3c, 0x92, 0xa9, 0x77, 0xc1, 0xeb, 0xc0, 0xff, 0x35, 0xdc, 0x9c, 0x1a, 0x9f, 0x5f,
-	0x04, 0x5f, 0xc1, 0x79, 0x99, 0xe5, 0xa2, 0xf7, 0x48, 0x9e, 0xe9, 0xd6, 0xc6, 0x4d, 0x17, 0x5c,
-	0xdf, 0x80, 0x05, 0xed, 0x9b, 0xaf, 0x6d, 0xd7, 0xf2, 0x4e, 0xd1, 0x3c, 0xcc, 0x6a, 0xdf, 0xfc,
-	0xbf, 0x32, 0xc3, 0xfe, 0xd8, 0x55, 0x4a, 0xd7, 0x07, 0xb0, 0x26, 0x79, 0x76, 0x23, 0x80, 0x6a,
-	0xb7, 0xd3, 0x7e, 0x78, 0xb8, 0xa7, 0xcc, 0xd0, 0xbf, 0x1f, 0x1c, 0x1c, 0x1e, 0x3d, 0xe9, 0x28,
-	0x25, 0xb4, 0x00, 0x73, 0x77, 0x1f, 0x1e, 0x69, 0x4a, 0x99, 0x22, 0xec, 0xb5, 0xbe, 0x55, 0x66,
-	0xe9, 0xd0, 0xd7, 0x9d, 0xce, 0x3d, 0x65, 0x0e, 0x2d, 0x42, 0xe5, 0xc1, 0xc3, 0xc3, 0x27, 0x77,
-	0x95, 0x0a, 0x5a, 0x82, 0xf9, 0xc7, 0x47, 0x2d, 0xed, 0x49, 0x47, 0x53, 0xaa, 0x94, 0xe3, 0xdb,
-	0x4e, 0x4b, 0x53, 0xe6, 0xaf, 0xef, 0x24, 0xdc, 0x58, 0xc4, 0x22, 0xca, 0xdc, 0xbe, 0xdf, 0xea,
-	0x76, 0xf5, 0xb6, 0x32, 0x13, 0x7f, 0x7c, 0xa9, 0x94, 0x76, 0xff, 0xbd, 0x09, 0xe7, 0x0e, 0x31,
-	0x39, 0xf5, 0xfc, 0xe3, 0x2e, 0xf6, 0x4f, 0xb0, 0xcf, 0x1b, 0xea, 0xe8, 0xfb, 0xa8, 0x0c, 0x97,
-	0xee, 0xb0, 0xa3, 0x4d, 0x6a, 0x99, 0x82, 0x1f, 0x58, 0x34, 0xb7, 0xf2, 0x19, 0x98, 0xed, 0xd5,
-	0x19, 0xa4, 0x85, 0x45, 0xba, 0x0c, 0xf2, 0x46, 0x98, 0x21, 0xe4, 0xfc, 0x5c, 0xa2, 0x79, 0x39,
-	0x87, 0x2a, 0x30, 0x1f, 0x47, 0x15, 0x2a, 0x99, 0xc0, 0x05, 0x3f, 0x44, 0x68, 0x5e, 0x18, 0xbb,
-	0x87, 0x3b, 0xce, 0x90, 0x9c, 0x31, 0x48, 0xd9, 0xaf, 0x0c, 0x18, 0x64, 0xc1, 0xef, 0x0f, 0x0a,
-	0x20, 0x85, 0x59, 0xd3, 0x4d, 0xea, 0xa4, 0x59, 0xa5, 0xed, 0xeb, 0xa4, 0x59, 0xe5, 0x3f, 0x07,
-	0x10, 0x66, 0xcd, 0x20, 0x47, 0x66, 0x95, 0xc3, 0x5e, 0xce, 0xa1, 0x8e, 0x9b, 0x55, 0x26, 0x70,
-	0x41, 0x2f, 0x7f, 0x1a, 0xb3, 0xca, 0x20, 0x0b, 0x5a, 0xf8, 0x05, 0x90, 0xdf, 0xa4, 0x7b, 0x98,
-	0x11, 0xe2, 0x3b, 0xb1, 0xd1, 0x64, 0xed, 0xe0, 0xe6, 0x66, 0x2e, 0x5d, 0xe8, 0xff, 0x30, 0xd1,
-	0xe2, 0x8c, 0x60, 0x2f, 0x71, 0xa3, 0x49, 0x31, 0x37, 0xe4, 0xc4, 0x04, 0xe0, 0x9a, 0xa4, 0xf1,
-	0xcd, 0x44, 0xcd, 0xef, 0x88, 0x17, 0xe8, 0xfe, 0x30, 0xdd, 0x6c, 0x4c, 0x01, 0xe6, 0xb7, 0xc2,
-	0x0b, 0x00, 0x5b, 0xb0, 0x9c, 0xb4, 0x09, 0x5a, 0xcf, 0x5a, 0x69, 0x32, 0xc4, 0x67, 0xb0, 0x28,
-	0x4c, 0x80, 0xce, 0xa5, 0x2c, 0x12, 0x4d, 0x3e, 0x9f, 0x19, 0x15, 0x06, 0x6a, 0xc1, 0x72, 0xd2,
-	0x0e, 0x6c, 0x79, 0x49, 0x27, 0xb6, 0x58, 0x83, 0xa4, 0xe6, 0x0c, 0x42, 0xd2, 0x91, 0x2d, 0x80,
-	0xe8, 0x40, 0x3d, 0xdd, 0x55, 0x44, 0x17, 0xc3, 0x0a, 0xaa, 0xac, 0x17, 0x58, 0x00, 0x73, 0x00,
-	0x4a, 0xb6, 0x81, 0xc8, 0xdc, 0x27, 0xa7, 0xad, 0x58, 0xec, 0xe3, 0x92, 0x06, 0x21, 0xdb, 0xe7,
-	0xfc, 0x86, 0x23, 0xf3, 0xf1, 0x82, 0xce, 0xa2, 0x3a, 0x83, 0xba, 0x70, 0x5e, 0x5a, 0x7a, 0x44,
-	0x5b, 0xd9, 0x9d, 0xcf, 0x66, 0x20, 0x85, 0x37, 0xdd, 0xc5, 0xdc, 0x32, 0x24, 0xba, 0x4a, 0x81,
-	0x27, 0x55, 0x29, 0x0b, 0xc0, 0x03, 0xd8, 0x28, 0x2a, 0x33, 0xa2, 0x6b, 0x29, 0xa5, 0xf3, 0x0b,
-	0x99, 0xcd, 0xed, 0xc9, 0x8c, 0xc2, 0x4c, 0x6c, 0xd1, 0xdc, 0x42, 0xa2, 0x58, 0x74, 0x52, 0xa9,
-	0x52, 0x2c, 0x3a, 0xb1, 0x26, 0xa9, 0xce, 0xa0, 0xaf, 0xc2, 0xfb, 0x27, 0xd5, 0xb4, 0x45, 0x39,
-	0x76, 0x11, 0x57, 0x8f, 0xb4, 0xc5, 0xcb, 0xb6, 0x24, 0xb7, 0x93, 0xcb, 0xb6, 0x64, 0x52, 0xa3,
-	0xb7, 0x60, 0x4b, 0x8e, 0xe0, 0x82, 0xbc, 0x75, 0x8b, 0xae, 0xb0, 0x1f, 0xf4, 0x15, 0xb4, 0x75,
-	0x0b, 0x60, 0xdb, 0x50, 0x4b, 0x15, 0x67, 0x50, 0x23, 0x96, 0x33, 0x5d, 0x87, 0x2d, 0x00, 0xf9,
-	0x02, 0x20, 0x2e, 0xc2, 0xa0, 0xe8, 0xe6, 0x19, 0x9b, 0x9e, 0x19, 0x16, 0x76, 0x6b, 0x43, 0x2d,
-	0x55, 0xf3, 0x60, 0x32, 0xc8, 0xfa, 0x61, 0xc5, 0x8a, 0xa4, 0x8a, 0x1b, 0x0c, 0x44, 0xd6, 0x15,
-	0x9b, 0x26, 0x7d, 0xc8, 0xd4, 0x19, 0x37, 0xc7, 0x8c, 0x92, 0x9f, 0x3e, 0xc8, 0x6b, 0x51, 0x22,
-	0x7d, 0xc8, 0x20, 0x6f, 0xa4, 0xad, 0x92, 0x93, 0x3e, 0xe4, 0x62, 0x3e, 0xce, 0xf4, 0x0d, 0x25,
-	0xe9, 0x83, 0x1c, 0x79, 0x8a, 0xf4, 0x41, 0x06, 0x59, 0x50, 0x3f, 0x2a, 0x80, 0xbc, 0x0f, 0x2b,
-	0x99, 0x9e, 0x13, 0x6a, 0xa6, 0x35, 0x4b, 0x36, 0xdf, 0x9a, 0x97, 0xa4, 0x34, 0xa1, 0xf3, 0x00,
-	0x2e, 0xe6, 0xd6, 0xfb, 0xd9, 0x31, 0x9b, 0xd4, 0x52, 0x68, 0xbe, 0x37, 0x81, 0x2b, 0x5a, 0xeb,
-	0xff, 0x4a, 0xc8, 0x86, 0x46, 0x5e, 0xd9, 0x1d, 0xbd, 0x2b, 0x87, 0x49, 0x47, 0x9c, 0xab, 0xc5,
-	0x4c, 0x89, 0xa5, 0x84, 0xf7, 0x65, 0xaa, 0x6e, 0x09, 0xef, 0x93, 0x3e, 0xe7, 0x92, 0xde, 0x27,
-	0x7f, 0x8f, 0x09, 0xef, 0xcb, 0x20, 0x47, 0xde, 0x27, 0x87, 0xbd, 0x9c, 0x43, 0x1d, 0xf7, 0x3e,
-	0x99, 0xc0, 0x05, 0x55, 0x95, 0x69, 0xbc, 0x4f, 0x06, 0x59, 0x50, 0x4c, 0x29, 0x8e, 0x94, 0xb9,
-	0x65, 0x15, 0xe6, 0x2f, 0x93, 0xaa, 0x2e, 0x05, 0xe0, 0x18, 0xde, 0x29, 0x2e, 0xa4, 0xa0, 0x0f,
-	0xc2, 0xdf, 0xd6, 0x4e, 0x53, 0x6c, 0x29, 0xd6, 0x21, 0xb7, 0x5a, 0xc1, 0x74, 0x98, 0x54, 0xcc,
-	0x28, 0x00, 0xff, 0x01, 0xae, 0x4e, 0x53, 0x9c, 0x40, 0x37, 0x45, 0x56, 0x31, 0x5d, 0x19, 0xa3,
-	0x60, 0xc9, 0x3f, 0x94, 0xe0, 0xda, 0x94, 0x35, 0x05, 0xb4, 0x9b, 0x75, 0xc3, 0xc9, 0x05, 0x8e,
-	0xe6, 0xc7, 0x2f, 0x35, 0x47, 0x38, 0xf4, 0xed, 0x30, 0x90, 0xf1, 0xd6, 0x55, 0x6e, 0x1e, 0x10,
-	0x45, 0xb2, 0x4c, 0x8b, 0x4b, 0x9d, 0x79, 0x56, 0x0d, 0x39, 0x3f, 0xfe, 0x6f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x07, 0x81, 0xc3, 0xae, 0x9b, 0x31, 0x00, 0x00,
+func (m *GetFrequencyPlanVersionsResponse) Reset()         { *m = GetFrequencyPlanVersionsResponse{} }
+func (m *GetFrequencyPlanVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFrequencyPlanVersionsResponse) ProtoMessage()    {}
+func (*GetFrequencyPlanVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{84}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// NetworkServerServiceClient is the client API for NetworkServerService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NetworkServerServiceClient interface {
-	// CreateServiceProfile creates the given service-profile.
-	CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error)
-	// GetServiceProfile returns the service-profile matching the given id.
-	GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error)
-	// UpdateServiceProfile updates the given service-profile.
-	UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteServiceProfile deletes the service-profile matching the given id.
-	DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// CreateRoutingProfile creates the given routing-profile.
-	CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error)
-	// GetRoutingProfile returns the routing-profile matching the given id.
-	GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error)
-	// UpdateRoutingProfile updates the given routing-profile.
-	UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteRoutingProfile deletes the routing-profile matching the given id.
-	DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// CreateDeviceProfile creates the given device-profile.
-	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
-	// GetDeviceProfile returns the device-profile matching the given id.
-	GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error)
-	// UpdateDeviceProfile updates the given device-profile.
-	UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteDeviceProfile deletes the device-profile matching the given id.
-	DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// CreateDevice creates the given device.
-	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetDevice returns the device matching the given DevEUI.
-	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
-	// UpdateDevice updates the given device.
-	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteDevice deletes the device matching the given DevEUI.
-	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// ActivateDevice activates a device (ABP).
-	ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeactivateDevice de-activates a device.
-	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetDeviceActivation returns the device activation details.
-	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
-	// CreateDeviceQueueItem creates the given device-queue item.
-	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
-	FlushDeviceQueueForDevEUI(ctx context.Context, in *FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
-	GetDeviceQueueItemsForDevEUI(ctx context.Context, in *GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsForDevEUIResponse, error)
-	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
-	// This also takes device-queue items for the given DevEUI into consideration.
-	GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
-	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
-	CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
-	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// CreateGatewayProfile creates the given gateway-profile.
-	CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error)
-	// GetGatewayProfile returns the gateway-profile given an id.
-	GetGatewayProfile(ctx context.Context, in *GetGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayProfileResponse, error)
-	// UpdateGatewayProfile updates the given gateway-profile.
-	UpdateGatewayProfile(ctx context.Context, in *UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteGatewayProfile deletes the gateway-profile matching a given id.
-	DeleteGatewayProfile(ctx context.Context, in *DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
-	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
-	StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error)
-	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
-	StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForDeviceClient, error)
-	// CreateMulticastGroup creates the given multicast-group.
-	CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error)
-	// GetMulticastGroup returns the multicast-group given an id.
-	GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error)
-	// UpdateMulticastGroup updates the given multicast-group.
-	UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// DeleteMulticastGroup deletes a multicast-group given an id.
-	DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
-	AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
-	RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// EnqueueMulticastQueueItem enqueues the given multicast queue-item and
-	// increments the frame-counter after enqueueing.
-	EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
-	FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
-	GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
-	// GetVersion returns the LoRa Server version.
-	GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error)
+func (m *GetFrequencyPlanVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFrequencyPlanVersionsResponse.Unmarshal(m, b)
 }
-
-type networkServerServiceClient struct {
-	cc *grpc.ClientConn
+func (m *GetFrequencyPlanVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFrequencyPlanVersionsResponse.Marshal(b, m, deterministic)
 }
-
-func NewNetworkServerServiceClient(cc *grpc.ClientConn) NetworkServerServiceClient {
-	return &networkServerServiceClient{cc}
+func (m *GetFrequencyPlanVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFrequencyPlanVersionsResponse.Merge(m, src)
+}
+func (m *GetFrequencyPlanVersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFrequencyPlanVersionsResponse.Size(m)
+}
+func (m *GetFrequencyPlanVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFrequencyPlanVersionsResponse.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error) {
-	out := new(CreateServiceProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateServiceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetFrequencyPlanVersionsResponse proto.InternalMessageInfo
+
+func (m *GetFrequencyPlanVersionsResponse) GetVersions() []*FrequencyPlanVersion {
+	if m != nil {
+		return m.Versions
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error) {
-	out := new(GetServiceProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetServiceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type FrequencyPlanVersion struct {
+	// Version.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Created at timestamp.
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (c *networkServerServiceClient) UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateServiceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *FrequencyPlanVersion) Reset()         { *m = FrequencyPlanVersion{} }
+func (m *FrequencyPlanVersion) String() string { return proto.CompactTextString(m) }
+func (*FrequencyPlanVersion) ProtoMessage()    {}
+func (*FrequencyPlanVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{85}
 }
 
-func (c *networkServerServiceClient) DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteServiceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *FrequencyPlanVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FrequencyPlanVersion.Unmarshal(m, b)
+}
+func (m *FrequencyPlanVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FrequencyPlanVersion.Marshal(b, m, deterministic)
+}
+func (m *FrequencyPlanVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrequencyPlanVersion.Merge(m, src)
+}
+func (m *FrequencyPlanVersion) XXX_Size() int {
+	return xxx_messageInfo_FrequencyPlanVersion.Size(m)
+}
+func (m *FrequencyPlanVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrequencyPlanVersion.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error) {
-	out := new(CreateRoutingProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateRoutingProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FrequencyPlanVersion proto.InternalMessageInfo
+
+func (m *FrequencyPlanVersion) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error) {
-	out := new(GetRoutingProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRoutingProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *FrequencyPlanVersion) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateRoutingProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type MulticastGroup struct {
+	// Multicast-group ID.
+	// Note: this can be set on create. When left blank, a random ID will
+	// be generated.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Multicast Address for this group.
+	McAddr []byte `protobuf:"bytes,2,opt,name=mc_addr,json=mcAddr,proto3" json:"mc_addr,omitempty"`
+	// Multicast network session key.
+	McNwkSKey []byte `protobuf:"bytes,3,opt,name=mc_nwk_s_key,json=mcNwkSKey,proto3" json:"mc_nwk_s_key,omitempty"`
+	// Frame-counter (downlink).
+	FCnt uint32 `protobuf:"varint,4,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Multicast-group type (Class-B or Class-C).
+	GroupType MulticastGroupType `protobuf:"varint,5,opt,name=group_type,json=groupType,proto3,enum=ns.MulticastGroupType" json:"group_type,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,6,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Ping-slot period.
+	// Mandatory for Class-B multicast groups.
+	PingSlotPeriod uint32 `protobuf:"varint,8,opt,name=ping_slot_period,json=pingSlotPeriod,proto3" json:"ping_slot_period,omitempty"`
+	// Service-profile ID.
+	ServiceProfileId []byte `protobuf:"bytes,9,opt,name=service_profile_id,json=serviceProfileId,proto3" json:"service_profile_id,omitempty"`
+	// Routing-profile ID.
+	RoutingProfileId []byte `protobuf:"bytes,10,opt,name=routing_profile_id,json=routingProfileId,proto3" json:"routing_profile_id,omitempty"`
+	// Max. number of frames per minute, per gateway.
+	// Frames exceeding this rate are deferred. When set to 0, the rate is
+	// not limited.
+	MaxFramesPerMinute uint32 `protobuf:"varint,11,opt,name=max_frames_per_minute,json=maxFramesPerMinute,proto3" json:"max_frames_per_minute,omitempty"`
+	// Max. airtime (percentage) per gateway, e.g. 10 for 10%, calculated
+	// over a window of one hour.
+	// Frames exceeding this airtime are deferred. When set to 0, the airtime
+	// is not limited.
+	MaxAirtimePercentage float64  `protobuf:"fixed64,12,opt,name=max_airtime_percentage,json=maxAirtimePercentage,proto3" json:"max_airtime_percentage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *networkServerServiceClient) DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteRoutingProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *MulticastGroup) Reset()         { *m = MulticastGroup{} }
+func (m *MulticastGroup) String() string { return proto.CompactTextString(m) }
+func (*MulticastGroup) ProtoMessage()    {}
+func (*MulticastGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{86}
 }
 
-func (c *networkServerServiceClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
-	out := new(CreateDeviceProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGroup.Unmarshal(m, b)
 }
-
-func (c *networkServerServiceClient) GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error) {
-	out := new(GetDeviceProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *MulticastGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGroup.Marshal(b, m, deterministic)
 }
-
-func (c *networkServerServiceClient) UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDeviceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *MulticastGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGroup.Merge(m, src)
+}
+func (m *MulticastGroup) XXX_Size() int {
+	return xxx_messageInfo_MulticastGroup.Size(m)
+}
+func (m *MulticastGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGroup.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDeviceProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_MulticastGroup proto.InternalMessageInfo
+
+func (m *MulticastGroup) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetMcAddr() []byte {
+	if m != nil {
+		return m.McAddr
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
-	out := new(GetDeviceResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetMcNwkSKey() []byte {
+	if m != nil {
+		return m.McNwkSKey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetGroupType() MulticastGroupType {
+	if m != nil {
+		return m.GroupType
 	}
-	return out, nil
+	return MulticastGroupType_CLASS_C
 }
 
-func (c *networkServerServiceClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ActivateDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeactivateDevice", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
-	out := new(GetDeviceActivationResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceActivation", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetPingSlotPeriod() uint32 {
+	if m != nil {
+		return m.PingSlotPeriod
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceQueueItem", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetServiceProfileId() []byte {
+	if m != nil {
+		return m.ServiceProfileId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) FlushDeviceQueueForDevEUI(ctx context.Context, in *FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushDeviceQueueForDevEUI", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetRoutingProfileId() []byte {
+	if m != nil {
+		return m.RoutingProfileId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) GetDeviceQueueItemsForDevEUI(ctx context.Context, in *GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsForDevEUIResponse, error) {
-	out := new(GetDeviceQueueItemsForDevEUIResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceQueueItemsForDevEUI", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetMaxFramesPerMinute() uint32 {
+	if m != nil {
+		return m.MaxFramesPerMinute
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error) {
-	out := new(GetNextDownlinkFCntForDevEUIResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetNextDownlinkFCntForDevEUI", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *MulticastGroup) GetMaxAirtimePercentage() float64 {
+	if m != nil {
+		return m.MaxAirtimePercentage
 	}
-	return out, nil
+	return 0
 }
 
-func (c *networkServerServiceClient) GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
-	out := new(GetRandomDevAddrResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRandomDevAddr", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CreateMulticastGroupRequest struct {
+	// Multicast-group to create.
+	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (c *networkServerServiceClient) CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMACCommandQueueItem", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupRequest) Reset()         { *m = CreateMulticastGroupRequest{} }
+func (m *CreateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupRequest) ProtoMessage()    {}
+func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{87}
 }
 
-func (c *networkServerServiceClient) SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/SendProprietaryPayload", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *CreateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMulticastGroupRequest.Merge(m, src)
+}
+func (m *CreateMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMulticastGroupRequest.Size(m)
+}
+func (m *CreateMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMulticastGroupRequest.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateGateway", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CreateMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *CreateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
-	out := new(GetGatewayResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGateway", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CreateMulticastGroupResponse struct {
+	// Multicast-group ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *networkServerServiceClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateGateway", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) Reset()         { *m = CreateMulticastGroupResponse{} }
+func (m *CreateMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupResponse) ProtoMessage()    {}
+func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{88}
 }
 
-func (c *networkServerServiceClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteGateway", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Unmarshal(m, b)
 }
-
-func (c *networkServerServiceClient) CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error) {
-	out := new(CreateGatewayProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateGatewayProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Marshal(b, m, deterministic)
 }
-
-func (c *networkServerServiceClient) GetGatewayProfile(ctx context.Context, in *GetGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayProfileResponse, error) {
-	out := new(GetGatewayProfileResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMulticastGroupResponse.Merge(m, src)
 }
-
-func (c *networkServerServiceClient) UpdateGatewayProfile(ctx context.Context, in *UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateGatewayProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateMulticastGroupResponse.Size(m)
 }
-
-func (c *networkServerServiceClient) DeleteGatewayProfile(ctx context.Context, in *DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteGatewayProfile", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *CreateMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMulticastGroupResponse.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
-	out := new(GetGatewayStatsResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayStats", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+var xxx_messageInfo_CreateMulticastGroupResponse proto.InternalMessageInfo
 
-func (c *networkServerServiceClient) StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[0], "/ns.NetworkServerService/StreamFrameLogsForGateway", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &networkServerServiceStreamFrameLogsForGatewayClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *CreateMulticastGroupResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	return x, nil
+	return nil
 }
 
-type NetworkServerService_StreamFrameLogsForGatewayClient interface {
-	Recv() (*StreamFrameLogsForGatewayResponse, error)
-	grpc.ClientStream
+type GetMulticastGroupRequest struct {
+	// Multicast-group ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type networkServerServiceStreamFrameLogsForGatewayClient struct {
-	grpc.ClientStream
+func (m *GetMulticastGroupRequest) Reset()         { *m = GetMulticastGroupRequest{} }
+func (m *GetMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupRequest) ProtoMessage()    {}
+func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{89}
 }
 
-func (x *networkServerServiceStreamFrameLogsForGatewayClient) Recv() (*StreamFrameLogsForGatewayResponse, error) {
-	m := new(StreamFrameLogsForGatewayResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupRequest.Unmarshal(m, b)
 }
-
-func (c *networkServerServiceClient) StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForDeviceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[1], "/ns.NetworkServerService/StreamFrameLogsForDevice", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &networkServerServiceStreamFrameLogsForDeviceClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *GetMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupRequest.Marshal(b, m, deterministic)
 }
-
-type NetworkServerService_StreamFrameLogsForDeviceClient interface {
-	Recv() (*StreamFrameLogsForDeviceResponse, error)
-	grpc.ClientStream
+func (m *GetMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupRequest.Merge(m, src)
 }
-
-type networkServerServiceStreamFrameLogsForDeviceClient struct {
-	grpc.ClientStream
+func (m *GetMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupRequest.Size(m)
 }
-
-func (x *networkServerServiceStreamFrameLogsForDeviceClient) Recv() (*StreamFrameLogsForDeviceResponse, error) {
-	m := new(StreamFrameLogsForDeviceResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *GetMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupRequest.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error) {
-	out := new(CreateMulticastGroupResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+var xxx_messageInfo_GetMulticastGroupRequest proto.InternalMessageInfo
 
-func (c *networkServerServiceClient) GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error) {
-	out := new(GetMulticastGroupResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetMulticastGroupRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetMulticastGroupResponse struct {
+	// Multicast-group.
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (c *networkServerServiceClient) DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetMulticastGroupResponse) Reset()         { *m = GetMulticastGroupResponse{} }
+func (m *GetMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupResponse) ProtoMessage()    {}
+func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{90}
 }
 
-func (c *networkServerServiceClient) AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/AddDeviceToMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupResponse.Merge(m, src)
+}
+func (m *GetMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupResponse.Size(m)
+}
+func (m *GetMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupResponse.DiscardUnknown(m)
 }
 
-func (c *networkServerServiceClient) RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *GetMulticastGroupResponse) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueMulticastQueueItem", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetMulticastGroupResponse) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetMulticastGroupResponse) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
 	}
-	return out, nil
+	return nil
 }
 
-func (c *networkServerServiceClient) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error) {
-	out := new(GetMulticastQueueItemsForMulticastGroupResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type UpdateMulticastGroupRequest struct {
+	// Multicast-group to update.
+	MulticastGroup       *MulticastGroup `protobuf:"bytes,1,opt,name=multicast_group,json=multicastGroup,proto3" json:"multicast_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (c *networkServerServiceClient) GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error) {
-	out := new(GetVersionResponse)
-	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *UpdateMulticastGroupRequest) Reset()         { *m = UpdateMulticastGroupRequest{} }
+func (m *UpdateMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupRequest) ProtoMessage()    {}
+func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{91}
 }
 
-// NetworkServerServiceServer is the server API for NetworkServerService service.
-type NetworkServerServiceServer interface {
-	// CreateServiceProfile creates the given service-profile.
-	CreateServiceProfile(context.Context, *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error)
-	// GetServiceProfile returns the service-profile matching the given id.
-	GetServiceProfile(context.Context, *GetServiceProfileRequest) (*GetServiceProfileResponse, error)
-	// UpdateServiceProfile updates the given service-profile.
-	UpdateServiceProfile(context.Context, *UpdateServiceProfileRequest) (*empty.Empty, error)
-	// DeleteServiceProfile deletes the service-profile matching the given id.
-	DeleteServiceProfile(context.Context, *DeleteServiceProfileRequest) (*empty.Empty, error)
-	// CreateRoutingProfile creates the given routing-profile.
-	CreateRoutingProfile(context.Context, *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error)
-	// GetRoutingProfile returns the routing-profile matching the given id.
-	GetRoutingProfile(context.Context, *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error)
-	// UpdateRoutingProfile updates the given routing-profile.
-	UpdateRoutingProfile(context.Context, *UpdateRoutingProfileRequest) (*empty.Empty, error)
-	// DeleteRoutingProfile deletes the routing-profile matching the given id.
-	DeleteRoutingProfile(context.Context, *DeleteRoutingProfileRequest) (*empty.Empty, error)
-	// CreateDeviceProfile creates the given device-profile.
-	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
-	// GetDeviceProfile returns the device-profile matching the given id.
-	GetDeviceProfile(context.Context, *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error)
-	// UpdateDeviceProfile updates the given device-profile.
-	UpdateDeviceProfile(context.Context, *UpdateDeviceProfileRequest) (*empty.Empty, error)
-	// DeleteDeviceProfile deletes the device-profile matching the given id.
-	DeleteDeviceProfile(context.Context, *DeleteDeviceProfileRequest) (*empty.Empty, error)
-	// CreateDevice creates the given device.
-	CreateDevice(context.Context, *CreateDeviceRequest) (*empty.Empty, error)
-	// GetDevice returns the device matching the given DevEUI.
-	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
-	// UpdateDevice updates the given device.
-	UpdateDevice(context.Context, *UpdateDeviceRequest) (*empty.Empty, error)
-	// DeleteDevice deletes the device matching the given DevEUI.
-	DeleteDevice(context.Context, *DeleteDeviceRequest) (*empty.Empty, error)
-	// ActivateDevice activates a device (ABP).
-	ActivateDevice(context.Context, *ActivateDeviceRequest) (*empty.Empty, error)
-	// DeactivateDevice de-activates a device.
-	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*empty.Empty, error)
-	// GetDeviceActivation returns the device activation details.
-	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
-	// CreateDeviceQueueItem creates the given device-queue item.
-	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
-	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
-	FlushDeviceQueueForDevEUI(context.Context, *FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error)
-	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
-	GetDeviceQueueItemsForDevEUI(context.Context, *GetDeviceQueueItemsForDevEUIRequest) (*GetDeviceQueueItemsForDevEUIResponse, error)
-	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
-	// This also takes device-queue items for the given DevEUI into consideration.
-	GetNextDownlinkFCntForDevEUI(context.Context, *GetNextDownlinkFCntForDevEUIRequest) (*GetNextDownlinkFCntForDevEUIResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(context.Context, *empty.Empty) (*GetRandomDevAddrResponse, error)
-	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
-	CreateMACCommandQueueItem(context.Context, *CreateMACCommandQueueItemRequest) (*empty.Empty, error)
-	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
-	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*empty.Empty, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(context.Context, *CreateGatewayRequest) (*empty.Empty, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(context.Context, *UpdateGatewayRequest) (*empty.Empty, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(context.Context, *DeleteGatewayRequest) (*empty.Empty, error)
-	// CreateGatewayProfile creates the given gateway-profile.
-	CreateGatewayProfile(context.Context, *CreateGatewayProfileRequest) (*CreateGatewayProfileResponse, error)
-	// GetGatewayProfile returns the gateway-profile given an id.
-	GetGatewayProfile(context.Context, *GetGatewayProfileRequest) (*GetGatewayProfileResponse, error)
-	// UpdateGatewayProfile updates the given gateway-profile.
-	UpdateGatewayProfile(context.Context, *UpdateGatewayProfileRequest) (*empty.Empty, error)
-	// DeleteGatewayProfile deletes the gateway-profile matching a given id.
-	DeleteGatewayProfile(context.Context, *DeleteGatewayProfileRequest) (*empty.Empty, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
-	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
-	StreamFrameLogsForGateway(*StreamFrameLogsForGatewayRequest, NetworkServerService_StreamFrameLogsForGatewayServer) error
-	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
-	StreamFrameLogsForDevice(*StreamFrameLogsForDeviceRequest, NetworkServerService_StreamFrameLogsForDeviceServer) error
-	// CreateMulticastGroup creates the given multicast-group.
-	CreateMulticastGroup(context.Context, *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error)
-	// GetMulticastGroup returns the multicast-group given an id.
-	GetMulticastGroup(context.Context, *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error)
-	// UpdateMulticastGroup updates the given multicast-group.
-	UpdateMulticastGroup(context.Context, *UpdateMulticastGroupRequest) (*empty.Empty, error)
-	// DeleteMulticastGroup deletes a multicast-group given an id.
-	DeleteMulticastGroup(context.Context, *DeleteMulticastGroupRequest) (*empty.Empty, error)
-	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
-	AddDeviceToMulticastGroup(context.Context, *AddDeviceToMulticastGroupRequest) (*empty.Empty, error)
-	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
-	RemoveDeviceFromMulticastGroup(context.Context, *RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error)
-	// EnqueueMulticastQueueItem enqueues the given multicast queue-item and
-	// increments the frame-counter after enqueueing.
-	EnqueueMulticastQueueItem(context.Context, *EnqueueMulticastQueueItemRequest) (*empty.Empty, error)
-	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
-	FlushMulticastQueueForMulticastGroup(context.Context, *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error)
-	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
-	GetMulticastQueueItemsForMulticastGroup(context.Context, *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
-	// GetVersion returns the LoRa Server version.
-	GetVersion(context.Context, *empty.Empty) (*GetVersionResponse, error)
+func (m *UpdateMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Unmarshal(m, b)
 }
-
-// UnimplementedNetworkServerServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedNetworkServerServiceServer struct {
+func (m *UpdateMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Marshal(b, m, deterministic)
 }
-
-func (*UnimplementedNetworkServerServiceServer) CreateServiceProfile(ctx context.Context, req *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceProfile not implemented")
+func (m *UpdateMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMulticastGroupRequest.Merge(m, src)
 }
-func (*UnimplementedNetworkServerServiceServer) GetServiceProfile(ctx context.Context, req *GetServiceProfileRequest) (*GetServiceProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetServiceProfile not implemented")
+func (m *UpdateMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateMulticastGroupRequest.Size(m)
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateServiceProfile(ctx context.Context, req *UpdateServiceProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceProfile not implemented")
+func (m *UpdateMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMulticastGroupRequest.DiscardUnknown(m)
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteServiceProfile(ctx context.Context, req *DeleteServiceProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteServiceProfile not implemented")
+
+var xxx_messageInfo_UpdateMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *UpdateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) CreateRoutingProfile(ctx context.Context, req *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoutingProfile not implemented")
+
+type DeleteMulticastGroupRequest struct {
+	// Multicast-group ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedNetworkServerServiceServer) GetRoutingProfile(ctx context.Context, req *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingProfile not implemented")
+
+func (m *DeleteMulticastGroupRequest) Reset()         { *m = DeleteMulticastGroupRequest{} }
+func (m *DeleteMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupRequest) ProtoMessage()    {}
+func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{92}
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateRoutingProfile(ctx context.Context, req *UpdateRoutingProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoutingProfile not implemented")
+
+func (m *DeleteMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Unmarshal(m, b)
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteRoutingProfile(ctx context.Context, req *DeleteRoutingProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoutingProfile not implemented")
+func (m *DeleteMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Marshal(b, m, deterministic)
 }
-func (*UnimplementedNetworkServerServiceServer) CreateDeviceProfile(ctx context.Context, req *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceProfile not implemented")
+func (m *DeleteMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMulticastGroupRequest.Merge(m, src)
 }
-func (*UnimplementedNetworkServerServiceServer) GetDeviceProfile(ctx context.Context, req *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceProfile not implemented")
+func (m *DeleteMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMulticastGroupRequest.Size(m)
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateDeviceProfile(ctx context.Context, req *UpdateDeviceProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceProfile not implemented")
+func (m *DeleteMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMulticastGroupRequest.DiscardUnknown(m)
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteDeviceProfile(ctx context.Context, req *DeleteDeviceProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceProfile not implemented")
+
+var xxx_messageInfo_DeleteMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *DeleteMulticastGroupRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) CreateDevice(ctx context.Context, req *CreateDeviceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+
+type AddDeviceToMulticastGroupRequest struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedNetworkServerServiceServer) GetDevice(ctx context.Context, req *GetDeviceRequest) (*GetDeviceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+
+func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMulticastGroupRequest{} }
+func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
+func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{93}
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateDevice(ctx context.Context, req *UpdateDeviceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+
+func (m *AddDeviceToMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Unmarshal(m, b)
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteDevice(ctx context.Context, req *DeleteDeviceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+func (m *AddDeviceToMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Marshal(b, m, deterministic)
 }
-func (*UnimplementedNetworkServerServiceServer) ActivateDevice(ctx context.Context, req *ActivateDeviceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ActivateDevice not implemented")
+func (m *AddDeviceToMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddDeviceToMulticastGroupRequest.Merge(m, src)
 }
-func (*UnimplementedNetworkServerServiceServer) DeactivateDevice(ctx context.Context, req *DeactivateDeviceRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeactivateDevice not implemented")
+func (m *AddDeviceToMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_AddDeviceToMulticastGroupRequest.Size(m)
 }
-func (*UnimplementedNetworkServerServiceServer) GetDeviceActivation(ctx context.Context, req *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceActivation not implemented")
+func (m *AddDeviceToMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddDeviceToMulticastGroupRequest.DiscardUnknown(m)
 }
-func (*UnimplementedNetworkServerServiceServer) CreateDeviceQueueItem(ctx context.Context, req *CreateDeviceQueueItemRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceQueueItem not implemented")
+
+var xxx_messageInfo_AddDeviceToMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *AddDeviceToMulticastGroupRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) FlushDeviceQueueForDevEUI(ctx context.Context, req *FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FlushDeviceQueueForDevEUI not implemented")
+
+func (m *AddDeviceToMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *GetDeviceQueueItemsForDevEUIRequest) (*GetDeviceQueueItemsForDevEUIResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceQueueItemsForDevEUI not implemented")
+
+type RemoveDeviceFromMulticastGroupRequest struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Multicast-group ID.
+	MulticastGroupId     []byte   `protobuf:"bytes,2,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedNetworkServerServiceServer) GetNextDownlinkFCntForDevEUI(ctx context.Context, req *GetNextDownlinkFCntForDevEUIRequest) (*GetNextDownlinkFCntForDevEUIResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNextDownlinkFCntForDevEUI not implemented")
+
+func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDeviceFromMulticastGroupRequest{} }
+func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
+func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{94}
 }
-func (*UnimplementedNetworkServerServiceServer) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*GetRandomDevAddrResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRandomDevAddr not implemented")
+
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Unmarshal(m, b)
 }
-func (*UnimplementedNetworkServerServiceServer) CreateMACCommandQueueItem(ctx context.Context, req *CreateMACCommandQueueItemRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateMACCommandQueueItem not implemented")
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Marshal(b, m, deterministic)
 }
-func (*UnimplementedNetworkServerServiceServer) SendProprietaryPayload(ctx context.Context, req *SendProprietaryPayloadRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SendProprietaryPayload not implemented")
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Merge(m, src)
 }
-func (*UnimplementedNetworkServerServiceServer) CreateGateway(ctx context.Context, req *CreateGatewayRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateGateway not implemented")
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.Size(m)
 }
-func (*UnimplementedNetworkServerServiceServer) GetGateway(ctx context.Context, req *GetGatewayRequest) (*GetGatewayResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGateway not implemented")
+func (m *RemoveDeviceFromMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest.DiscardUnknown(m)
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateGateway(ctx context.Context, req *UpdateGatewayRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateGateway not implemented")
+
+var xxx_messageInfo_RemoveDeviceFromMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteGateway(ctx context.Context, req *DeleteGatewayRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteGateway not implemented")
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) CreateGatewayProfile(ctx context.Context, req *CreateGatewayProfileRequest) (*CreateGatewayProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateGatewayProfile not implemented")
+
+type MulticastQueueItem struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// Frame-counter of payload.
+	FCnt uint32 `protobuf:"varint,2,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Frame-port of payload.
+	FPort uint32 `protobuf:"varint,3,opt,name=f_port,json=fPort,proto3" json:"f_port,omitempty"`
+	// Encrypted FRMPayload bytes.
+	FrmPayload []byte `protobuf:"bytes,4,opt,name=frm_payload,json=frmPayload,proto3" json:"frm_payload,omitempty"`
+	// Geofence (optional).
+	// When set, only the gateways within the geofence are used for
+	// transmitting the payload.
+	Geofence             *MulticastGeofence `protobuf:"bytes,5,opt,name=geofence,proto3" json:"geofence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
-func (*UnimplementedNetworkServerServiceServer) GetGatewayProfile(ctx context.Context, req *GetGatewayProfileRequest) (*GetGatewayProfileResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayProfile not implemented")
+
+func (m *MulticastQueueItem) Reset()         { *m = MulticastQueueItem{} }
+func (m *MulticastQueueItem) String() string { return proto.CompactTextString(m) }
+func (*MulticastQueueItem) ProtoMessage()    {}
+func (*MulticastQueueItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{95}
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateGatewayProfile(ctx context.Context, req *UpdateGatewayProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateGatewayProfile not implemented")
+
+func (m *MulticastQueueItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastQueueItem.Unmarshal(m, b)
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteGatewayProfile(ctx context.Context, req *DeleteGatewayProfileRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteGatewayProfile not implemented")
+func (m *MulticastQueueItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastQueueItem.Marshal(b, m, deterministic)
 }
-func (*UnimplementedNetworkServerServiceServer) GetGatewayStats(ctx context.Context, req *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayStats not implemented")
+func (m *MulticastQueueItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastQueueItem.Merge(m, src)
 }
-func (*UnimplementedNetworkServerServiceServer) StreamFrameLogsForGateway(req *StreamFrameLogsForGatewayRequest, srv NetworkServerService_StreamFrameLogsForGatewayServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamFrameLogsForGateway not implemented")
+func (m *MulticastQueueItem) XXX_Size() int {
+	return xxx_messageInfo_MulticastQueueItem.Size(m)
 }
-func (*UnimplementedNetworkServerServiceServer) StreamFrameLogsForDevice(req *StreamFrameLogsForDeviceRequest, srv NetworkServerService_StreamFrameLogsForDeviceServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamFrameLogsForDevice not implemented")
+func (m *MulticastQueueItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastQueueItem.DiscardUnknown(m)
 }
-func (*UnimplementedNetworkServerServiceServer) CreateMulticastGroup(ctx context.Context, req *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateMulticastGroup not implemented")
+
+var xxx_messageInfo_MulticastQueueItem proto.InternalMessageInfo
+
+func (m *MulticastQueueItem) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) GetMulticastGroup(ctx context.Context, req *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastGroup not implemented")
+
+func (m *MulticastQueueItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
 }
-func (*UnimplementedNetworkServerServiceServer) UpdateMulticastGroup(ctx context.Context, req *UpdateMulticastGroupRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateMulticastGroup not implemented")
+
+func (m *MulticastQueueItem) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
 }
-func (*UnimplementedNetworkServerServiceServer) DeleteMulticastGroup(ctx context.Context, req *DeleteMulticastGroupRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteMulticastGroup not implemented")
+
+func (m *MulticastQueueItem) GetFrmPayload() []byte {
+	if m != nil {
+		return m.FrmPayload
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) AddDeviceToMulticastGroup(ctx context.Context, req *AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddDeviceToMulticastGroup not implemented")
+
+func (m *MulticastQueueItem) GetGeofence() *MulticastGeofence {
+	if m != nil {
+		return m.Geofence
+	}
+	return nil
 }
-func (*UnimplementedNetworkServerServiceServer) RemoveDeviceFromMulticastGroup(ctx context.Context, req *RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveDeviceFromMulticastGroup not implemented")
+
+type MulticastGeofence struct {
+	// Center of the geofence (latitude and longitude).
+	// Must be set together with the radius.
+	Center *common.Location `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	// Radius (meters).
+	Radius float64 `protobuf:"fixed64,2,opt,name=radius,proto3" json:"radius,omitempty"`
+	// Polygon (latitude and longitude), as an alternative to the center
+	// and radius. A polygon must have at least 3 points.
+	Polygon              []*common.Location `protobuf:"bytes,3,rep,name=polygon,proto3" json:"polygon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
-func (*UnimplementedNetworkServerServiceServer) EnqueueMulticastQueueItem(ctx context.Context, req *EnqueueMulticastQueueItemRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnqueueMulticastQueueItem not implemented")
+
+func (m *MulticastGeofence) Reset()         { *m = MulticastGeofence{} }
+func (m *MulticastGeofence) String() string { return proto.CompactTextString(m) }
+func (*MulticastGeofence) ProtoMessage()    {}
+func (*MulticastGeofence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{96}
 }
-func (*UnimplementedNetworkServerServiceServer) FlushMulticastQueueForMulticastGroup(ctx context.Context, req *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FlushMulticastQueueForMulticastGroup not implemented")
+
+func (m *MulticastGeofence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGeofence.Unmarshal(m, b)
+}
+func (m *MulticastGeofence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGeofence.Marshal(b, m, deterministic)
+}
+func (m *MulticastGeofence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGeofence.Merge(m, src)
+}
+func (m *MulticastGeofence) XXX_Size() int {
+	return xxx_messageInfo_MulticastGeofence.Size(m)
+}
+func (m *MulticastGeofence) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGeofence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastGeofence proto.InternalMessageInfo
+
+func (m *MulticastGeofence) GetCenter() *common.Location {
+	if m != nil {
+		return m.Center
+	}
+	return nil
+}
+
+func (m *MulticastGeofence) GetRadius() float64 {
+	if m != nil {
+		return m.Radius
+	}
+	return 0
+}
+
+func (m *MulticastGeofence) GetPolygon() []*common.Location {
+	if m != nil {
+		return m.Polygon
+	}
+	return nil
+}
+
+type EnqueueMulticastQueueItemRequest struct {
+	MulticastQueueItem   *MulticastQueueItem `protobuf:"bytes,1,opt,name=multicast_queue_item,json=multicastQueueItem,proto3" json:"multicast_queue_item,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMulticastQueueItemRequest{} }
+func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{97}
+}
+
+func (m *EnqueueMulticastQueueItemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Unmarshal(m, b)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Marshal(b, m, deterministic)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueMulticastQueueItemRequest.Merge(m, src)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueMulticastQueueItemRequest.Size(m)
+}
+func (m *EnqueueMulticastQueueItemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueMulticastQueueItemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueMulticastQueueItemRequest proto.InternalMessageInfo
+
+func (m *EnqueueMulticastQueueItemRequest) GetMulticastQueueItem() *MulticastQueueItem {
+	if m != nil {
+		return m.MulticastQueueItem
+	}
+	return nil
+}
+
+type FlushMulticastQueueForMulticastGroupRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlushMulticastQueueForMulticastGroupRequest) Reset() {
+	*m = FlushMulticastQueueForMulticastGroupRequest{}
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*FlushMulticastQueueForMulticastGroupRequest) ProtoMessage() {}
+func (*FlushMulticastQueueForMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{98}
+}
+
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Merge(m, src)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.Size(m)
+}
+func (m *FlushMulticastQueueForMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlushMulticastQueueForMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *FlushMulticastQueueForMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastQueueItemsForMulticastGroupRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) Reset() {
+	*m = GetMulticastQueueItemsForMulticastGroupRequest{}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemsForMulticastGroupRequest) ProtoMessage() {}
+func (*GetMulticastQueueItemsForMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{99}
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Merge(m, src)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.Size(m)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupRequest proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemsForMulticastGroupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type GetMulticastQueueItemsForMulticastGroupResponse struct {
+	MulticastQueueItems  []*MulticastQueueItem `protobuf:"bytes,1,rep,name=multicast_queue_items,json=multicastQueueItems,proto3" json:"multicast_queue_items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) Reset() {
+	*m = GetMulticastQueueItemsForMulticastGroupResponse{}
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) ProtoMessage() {}
+func (*GetMulticastQueueItemsForMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{100}
+}
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Merge(m, src)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.Size(m)
+}
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastQueueItemsForMulticastGroupResponse proto.InternalMessageInfo
+
+func (m *GetMulticastQueueItemsForMulticastGroupResponse) GetMulticastQueueItems() []*MulticastQueueItem {
+	if m != nil {
+		return m.MulticastQueueItems
+	}
+	return nil
+}
+
+type GetMulticastGroupCoverageRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastGroupCoverageRequest) Reset()         { *m = GetMulticastGroupCoverageRequest{} }
+func (m *GetMulticastGroupCoverageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupCoverageRequest) ProtoMessage()    {}
+func (*GetMulticastGroupCoverageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{101}
+}
+
+func (m *GetMulticastGroupCoverageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupCoverageRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupCoverageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupCoverageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupCoverageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupCoverageRequest.Merge(m, src)
+}
+func (m *GetMulticastGroupCoverageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupCoverageRequest.Size(m)
+}
+func (m *GetMulticastGroupCoverageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupCoverageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupCoverageRequest proto.InternalMessageInfo
+
+func (m *GetMulticastGroupCoverageRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type MulticastGatewayCoverage struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// RSSI of the last uplink received by the gateway.
+	Rssi int32 `protobuf:"varint,2,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	// LoRa SNR of the last uplink received by the gateway.
+	LoraSnr float64 `protobuf:"fixed64,3,opt,name=lora_snr,json=loraSnr,proto3" json:"lora_snr,omitempty"`
+	// The LoRa SNR meets the required SNR (including the installation margin)
+	// for the data-rate of the last uplink.
+	SnrMarginMet         bool     `protobuf:"varint,4,opt,name=snr_margin_met,json=snrMarginMet,proto3" json:"snr_margin_met,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MulticastGatewayCoverage) Reset()         { *m = MulticastGatewayCoverage{} }
+func (m *MulticastGatewayCoverage) String() string { return proto.CompactTextString(m) }
+func (*MulticastGatewayCoverage) ProtoMessage()    {}
+func (*MulticastGatewayCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{102}
+}
+
+func (m *MulticastGatewayCoverage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGatewayCoverage.Unmarshal(m, b)
+}
+func (m *MulticastGatewayCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGatewayCoverage.Marshal(b, m, deterministic)
+}
+func (m *MulticastGatewayCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGatewayCoverage.Merge(m, src)
+}
+func (m *MulticastGatewayCoverage) XXX_Size() int {
+	return xxx_messageInfo_MulticastGatewayCoverage.Size(m)
+}
+func (m *MulticastGatewayCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGatewayCoverage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastGatewayCoverage proto.InternalMessageInfo
+
+func (m *MulticastGatewayCoverage) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *MulticastGatewayCoverage) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *MulticastGatewayCoverage) GetLoraSnr() float64 {
+	if m != nil {
+		return m.LoraSnr
+	}
+	return 0
+}
+
+func (m *MulticastGatewayCoverage) GetSnrMarginMet() bool {
+	if m != nil {
+		return m.SnrMarginMet
+	}
+	return false
+}
+
+type MulticastDeviceCoverage struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device is covered by at least one gateway of the gateway-set.
+	Covered bool `protobuf:"varint,2,opt,name=covered,proto3" json:"covered,omitempty"`
+	// Gateways of the gateway-set which can reach the device.
+	Gateways             []*MulticastGatewayCoverage `protobuf:"bytes,3,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *MulticastDeviceCoverage) Reset()         { *m = MulticastDeviceCoverage{} }
+func (m *MulticastDeviceCoverage) String() string { return proto.CompactTextString(m) }
+func (*MulticastDeviceCoverage) ProtoMessage()    {}
+func (*MulticastDeviceCoverage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{103}
+}
+
+func (m *MulticastDeviceCoverage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastDeviceCoverage.Unmarshal(m, b)
+}
+func (m *MulticastDeviceCoverage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastDeviceCoverage.Marshal(b, m, deterministic)
+}
+func (m *MulticastDeviceCoverage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastDeviceCoverage.Merge(m, src)
+}
+func (m *MulticastDeviceCoverage) XXX_Size() int {
+	return xxx_messageInfo_MulticastDeviceCoverage.Size(m)
+}
+func (m *MulticastDeviceCoverage) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastDeviceCoverage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastDeviceCoverage proto.InternalMessageInfo
+
+func (m *MulticastDeviceCoverage) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *MulticastDeviceCoverage) GetCovered() bool {
+	if m != nil {
+		return m.Covered
+	}
+	return false
+}
+
+func (m *MulticastDeviceCoverage) GetGateways() []*MulticastGatewayCoverage {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+type GetMulticastGroupCoverageResponse struct {
+	// Gateway-set used for the multicast-group.
+	GatewayIds [][]byte `protobuf:"bytes,1,rep,name=gateway_ids,json=gatewayIds,proto3" json:"gateway_ids,omitempty"`
+	// Per device coverage.
+	Devices []*MulticastDeviceCoverage `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Number of devices without coverage.
+	UncoveredCount       uint32   `protobuf:"varint,3,opt,name=uncovered_count,json=uncoveredCount,proto3" json:"uncovered_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastGroupCoverageResponse) Reset()         { *m = GetMulticastGroupCoverageResponse{} }
+func (m *GetMulticastGroupCoverageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastGroupCoverageResponse) ProtoMessage()    {}
+func (*GetMulticastGroupCoverageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{104}
+}
+
+func (m *GetMulticastGroupCoverageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastGroupCoverageResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastGroupCoverageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastGroupCoverageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastGroupCoverageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastGroupCoverageResponse.Merge(m, src)
+}
+func (m *GetMulticastGroupCoverageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastGroupCoverageResponse.Size(m)
+}
+func (m *GetMulticastGroupCoverageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastGroupCoverageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastGroupCoverageResponse proto.InternalMessageInfo
+
+func (m *GetMulticastGroupCoverageResponse) GetGatewayIds() [][]byte {
+	if m != nil {
+		return m.GatewayIds
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupCoverageResponse) GetDevices() []*MulticastDeviceCoverage {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupCoverageResponse) GetUncoveredCount() uint32 {
+	if m != nil {
+		return m.UncoveredCount
+	}
+	return 0
+}
+
+type EstimateMulticastSessionRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// FRMPayload size (bytes) of each fragment.
+	PayloadSize uint32 `protobuf:"varint,2,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	// Number of fragments.
+	FragmentCount uint32 `protobuf:"varint,3,opt,name=fragment_count,json=fragmentCount,proto3" json:"fragment_count,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,4,opt,name=dr,proto3" json:"dr,omitempty"`
+	// Max. duty-cycle (percentage) per gateway, e.g. 1 for 1%.
+	// When not set, 1% is used.
+	MaxDutyCycle         float64  `protobuf:"fixed64,5,opt,name=max_duty_cycle,json=maxDutyCycle,proto3" json:"max_duty_cycle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateMulticastSessionRequest) Reset()         { *m = EstimateMulticastSessionRequest{} }
+func (m *EstimateMulticastSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateMulticastSessionRequest) ProtoMessage()    {}
+func (*EstimateMulticastSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{105}
+}
+
+func (m *EstimateMulticastSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateMulticastSessionRequest.Unmarshal(m, b)
+}
+func (m *EstimateMulticastSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateMulticastSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *EstimateMulticastSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateMulticastSessionRequest.Merge(m, src)
+}
+func (m *EstimateMulticastSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimateMulticastSessionRequest.Size(m)
+}
+func (m *EstimateMulticastSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateMulticastSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateMulticastSessionRequest proto.InternalMessageInfo
+
+func (m *EstimateMulticastSessionRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *EstimateMulticastSessionRequest) GetPayloadSize() uint32 {
+	if m != nil {
+		return m.PayloadSize
+	}
+	return 0
+}
+
+func (m *EstimateMulticastSessionRequest) GetFragmentCount() uint32 {
+	if m != nil {
+		return m.FragmentCount
+	}
+	return 0
+}
+
+func (m *EstimateMulticastSessionRequest) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *EstimateMulticastSessionRequest) GetMaxDutyCycle() float64 {
+	if m != nil {
+		return m.MaxDutyCycle
+	}
+	return 0
+}
+
+type MulticastGatewayAirtime struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Total airtime of the session for this gateway.
+	Airtime *duration.Duration `protobuf:"bytes,2,opt,name=airtime,proto3" json:"airtime,omitempty"`
+	// Duty-cycle (percentage) of this gateway, over the duration of the
+	// session (with a minimum of one hour).
+	DutyCycle            float64  `protobuf:"fixed64,3,opt,name=duty_cycle,json=dutyCycle,proto3" json:"duty_cycle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MulticastGatewayAirtime) Reset()         { *m = MulticastGatewayAirtime{} }
+func (m *MulticastGatewayAirtime) String() string { return proto.CompactTextString(m) }
+func (*MulticastGatewayAirtime) ProtoMessage()    {}
+func (*MulticastGatewayAirtime) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{106}
+}
+
+func (m *MulticastGatewayAirtime) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGatewayAirtime.Unmarshal(m, b)
+}
+func (m *MulticastGatewayAirtime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGatewayAirtime.Marshal(b, m, deterministic)
+}
+func (m *MulticastGatewayAirtime) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGatewayAirtime.Merge(m, src)
+}
+func (m *MulticastGatewayAirtime) XXX_Size() int {
+	return xxx_messageInfo_MulticastGatewayAirtime.Size(m)
+}
+func (m *MulticastGatewayAirtime) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGatewayAirtime.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastGatewayAirtime proto.InternalMessageInfo
+
+func (m *MulticastGatewayAirtime) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *MulticastGatewayAirtime) GetAirtime() *duration.Duration {
+	if m != nil {
+		return m.Airtime
+	}
+	return nil
+}
+
+func (m *MulticastGatewayAirtime) GetDutyCycle() float64 {
+	if m != nil {
+		return m.DutyCycle
+	}
+	return 0
+}
+
+type EstimateMulticastSessionResponse struct {
+	// Airtime of a single fragment.
+	FrameAirtime *duration.Duration `protobuf:"bytes,1,opt,name=frame_airtime,json=frameAirtime,proto3" json:"frame_airtime,omitempty"`
+	// Airtime per gateway of the gateway-set.
+	Gateways []*MulticastGatewayAirtime `protobuf:"bytes,2,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	// Expected duration of the session.
+	Duration *duration.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Minimum duration of the session to stay within the max. duty-cycle.
+	MinDuration *duration.Duration `protobuf:"bytes,4,opt,name=min_duration,json=minDuration,proto3" json:"min_duration,omitempty"`
+	// The session stays within the max. duty-cycle for all gateways.
+	DutyCycleFeasible    bool     `protobuf:"varint,5,opt,name=duty_cycle_feasible,json=dutyCycleFeasible,proto3" json:"duty_cycle_feasible,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateMulticastSessionResponse) Reset()         { *m = EstimateMulticastSessionResponse{} }
+func (m *EstimateMulticastSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateMulticastSessionResponse) ProtoMessage()    {}
+func (*EstimateMulticastSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{107}
+}
+
+func (m *EstimateMulticastSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateMulticastSessionResponse.Unmarshal(m, b)
+}
+func (m *EstimateMulticastSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateMulticastSessionResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimateMulticastSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateMulticastSessionResponse.Merge(m, src)
+}
+func (m *EstimateMulticastSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateMulticastSessionResponse.Size(m)
+}
+func (m *EstimateMulticastSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateMulticastSessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateMulticastSessionResponse proto.InternalMessageInfo
+
+func (m *EstimateMulticastSessionResponse) GetFrameAirtime() *duration.Duration {
+	if m != nil {
+		return m.FrameAirtime
+	}
+	return nil
+}
+
+func (m *EstimateMulticastSessionResponse) GetGateways() []*MulticastGatewayAirtime {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+func (m *EstimateMulticastSessionResponse) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *EstimateMulticastSessionResponse) GetMinDuration() *duration.Duration {
+	if m != nil {
+		return m.MinDuration
+	}
+	return nil
+}
+
+func (m *EstimateMulticastSessionResponse) GetDutyCycleFeasible() bool {
+	if m != nil {
+		return m.DutyCycleFeasible
+	}
+	return false
+}
+
+type GetMulticastTXStatusRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastTXStatusRequest) Reset()         { *m = GetMulticastTXStatusRequest{} }
+func (m *GetMulticastTXStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastTXStatusRequest) ProtoMessage()    {}
+func (*GetMulticastTXStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{108}
+}
+
+func (m *GetMulticastTXStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastTXStatusRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastTXStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastTXStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastTXStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastTXStatusRequest.Merge(m, src)
+}
+func (m *GetMulticastTXStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastTXStatusRequest.Size(m)
+}
+func (m *GetMulticastTXStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastTXStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastTXStatusRequest proto.InternalMessageInfo
+
+func (m *GetMulticastTXStatusRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type MulticastGatewayTXStatus struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Transmission state.
+	State MulticastTXState `protobuf:"varint,2,opt,name=state,proto3,enum=ns.MulticastTXState" json:"state,omitempty"`
+	// Last error reported by the gateway (if any).
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Number of retries.
+	Retries              uint32   `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MulticastGatewayTXStatus) Reset()         { *m = MulticastGatewayTXStatus{} }
+func (m *MulticastGatewayTXStatus) String() string { return proto.CompactTextString(m) }
+func (*MulticastGatewayTXStatus) ProtoMessage()    {}
+func (*MulticastGatewayTXStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{109}
+}
+
+func (m *MulticastGatewayTXStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastGatewayTXStatus.Unmarshal(m, b)
+}
+func (m *MulticastGatewayTXStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastGatewayTXStatus.Marshal(b, m, deterministic)
+}
+func (m *MulticastGatewayTXStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastGatewayTXStatus.Merge(m, src)
+}
+func (m *MulticastGatewayTXStatus) XXX_Size() int {
+	return xxx_messageInfo_MulticastGatewayTXStatus.Size(m)
+}
+func (m *MulticastGatewayTXStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastGatewayTXStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastGatewayTXStatus proto.InternalMessageInfo
+
+func (m *MulticastGatewayTXStatus) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *MulticastGatewayTXStatus) GetState() MulticastTXState {
+	if m != nil {
+		return m.State
+	}
+	return MulticastTXState_PENDING
+}
+
+func (m *MulticastGatewayTXStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *MulticastGatewayTXStatus) GetRetries() uint32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+type MulticastFrameTXStatus struct {
+	// Frame-counter of the frame.
+	FCnt uint32 `protobuf:"varint,1,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// Transmission status per gateway.
+	Gateways             []*MulticastGatewayTXStatus `protobuf:"bytes,2,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *MulticastFrameTXStatus) Reset()         { *m = MulticastFrameTXStatus{} }
+func (m *MulticastFrameTXStatus) String() string { return proto.CompactTextString(m) }
+func (*MulticastFrameTXStatus) ProtoMessage()    {}
+func (*MulticastFrameTXStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{110}
+}
+
+func (m *MulticastFrameTXStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastFrameTXStatus.Unmarshal(m, b)
+}
+func (m *MulticastFrameTXStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastFrameTXStatus.Marshal(b, m, deterministic)
+}
+func (m *MulticastFrameTXStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastFrameTXStatus.Merge(m, src)
+}
+func (m *MulticastFrameTXStatus) XXX_Size() int {
+	return xxx_messageInfo_MulticastFrameTXStatus.Size(m)
+}
+func (m *MulticastFrameTXStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastFrameTXStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastFrameTXStatus proto.InternalMessageInfo
+
+func (m *MulticastFrameTXStatus) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *MulticastFrameTXStatus) GetGateways() []*MulticastGatewayTXStatus {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+type GetMulticastTXStatusResponse struct {
+	// Transmission status per frame, ordered by frame-counter.
+	Frames               []*MulticastFrameTXStatus `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetMulticastTXStatusResponse) Reset()         { *m = GetMulticastTXStatusResponse{} }
+func (m *GetMulticastTXStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastTXStatusResponse) ProtoMessage()    {}
+func (*GetMulticastTXStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{111}
+}
+
+func (m *GetMulticastTXStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastTXStatusResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastTXStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastTXStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastTXStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastTXStatusResponse.Merge(m, src)
+}
+func (m *GetMulticastTXStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastTXStatusResponse.Size(m)
+}
+func (m *GetMulticastTXStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastTXStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastTXStatusResponse proto.InternalMessageInfo
+
+func (m *GetMulticastTXStatusResponse) GetFrames() []*MulticastFrameTXStatus {
+	if m != nil {
+		return m.Frames
+	}
+	return nil
+}
+
+type StartMulticastSetupRequest struct {
+	// Multicast-group id.
+	MulticastGroupId []byte `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	// McGroupID (0 - 3), the multicast-group index used by the devices.
+	McGroupId uint32 `protobuf:"varint,2,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// McKey encrypted with the McKEKey of the devices (16 bytes).
+	// As the McKEKey is derived from the root-key of the device, this must
+	// be provided by the application-server.
+	McKeyEncrypted []byte `protobuf:"bytes,3,opt,name=mc_key_encrypted,json=mcKeyEncrypted,proto3" json:"mc_key_encrypted,omitempty"`
+	// Max. multicast frame-counter (when 0, the max. uint32 value is used).
+	// The min. multicast frame-counter is set to the frame-counter of the
+	// multicast-group.
+	MaxMcFCnt uint32 `protobuf:"varint,4,opt,name=max_mc_f_cnt,json=maxMcFCnt,proto3" json:"max_mc_f_cnt,omitempty"`
+	// Start of the multicast session. For Class-B multicast-groups, this is
+	// rounded up to the next beacon-period.
+	SessionTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	// Max. duration of the multicast session (2^session_time_out seconds,
+	// 0 - 15).
+	SessionTimeOut       uint32   `protobuf:"varint,6,opt,name=session_time_out,json=sessionTimeOut,proto3" json:"session_time_out,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartMulticastSetupRequest) Reset()         { *m = StartMulticastSetupRequest{} }
+func (m *StartMulticastSetupRequest) String() string { return proto.CompactTextString(m) }
+func (*StartMulticastSetupRequest) ProtoMessage()    {}
+func (*StartMulticastSetupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{112}
+}
+
+func (m *StartMulticastSetupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartMulticastSetupRequest.Unmarshal(m, b)
+}
+func (m *StartMulticastSetupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartMulticastSetupRequest.Marshal(b, m, deterministic)
+}
+func (m *StartMulticastSetupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartMulticastSetupRequest.Merge(m, src)
+}
+func (m *StartMulticastSetupRequest) XXX_Size() int {
+	return xxx_messageInfo_StartMulticastSetupRequest.Size(m)
+}
+func (m *StartMulticastSetupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartMulticastSetupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartMulticastSetupRequest proto.InternalMessageInfo
+
+func (m *StartMulticastSetupRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+func (m *StartMulticastSetupRequest) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *StartMulticastSetupRequest) GetMcKeyEncrypted() []byte {
+	if m != nil {
+		return m.McKeyEncrypted
+	}
+	return nil
+}
+
+func (m *StartMulticastSetupRequest) GetMaxMcFCnt() uint32 {
+	if m != nil {
+		return m.MaxMcFCnt
+	}
+	return 0
+}
+
+func (m *StartMulticastSetupRequest) GetSessionTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SessionTime
+	}
+	return nil
+}
+
+func (m *StartMulticastSetupRequest) GetSessionTimeOut() uint32 {
+	if m != nil {
+		return m.SessionTimeOut
+	}
+	return 0
+}
+
+type GetMulticastSetupStatusRequest struct {
+	// Multicast-group id.
+	MulticastGroupId     []byte   `protobuf:"bytes,1,opt,name=multicast_group_id,json=multicastGroupId,proto3" json:"multicast_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMulticastSetupStatusRequest) Reset()         { *m = GetMulticastSetupStatusRequest{} }
+func (m *GetMulticastSetupStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastSetupStatusRequest) ProtoMessage()    {}
+func (*GetMulticastSetupStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{113}
+}
+
+func (m *GetMulticastSetupStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastSetupStatusRequest.Unmarshal(m, b)
+}
+func (m *GetMulticastSetupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastSetupStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastSetupStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastSetupStatusRequest.Merge(m, src)
+}
+func (m *GetMulticastSetupStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastSetupStatusRequest.Size(m)
+}
+func (m *GetMulticastSetupStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastSetupStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastSetupStatusRequest proto.InternalMessageInfo
+
+func (m *GetMulticastSetupStatusRequest) GetMulticastGroupId() []byte {
+	if m != nil {
+		return m.MulticastGroupId
+	}
+	return nil
+}
+
+type MulticastDeviceSetupStatus struct {
+	// Device EUI.
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Setup state.
+	State MulticastSetupState `protobuf:"varint,2,opt,name=state,proto3,enum=ns.MulticastSetupState" json:"state,omitempty"`
+	// Error (when failed).
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Last updated timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MulticastDeviceSetupStatus) Reset()         { *m = MulticastDeviceSetupStatus{} }
+func (m *MulticastDeviceSetupStatus) String() string { return proto.CompactTextString(m) }
+func (*MulticastDeviceSetupStatus) ProtoMessage()    {}
+func (*MulticastDeviceSetupStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{114}
+}
+
+func (m *MulticastDeviceSetupStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MulticastDeviceSetupStatus.Unmarshal(m, b)
+}
+func (m *MulticastDeviceSetupStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MulticastDeviceSetupStatus.Marshal(b, m, deterministic)
+}
+func (m *MulticastDeviceSetupStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MulticastDeviceSetupStatus.Merge(m, src)
+}
+func (m *MulticastDeviceSetupStatus) XXX_Size() int {
+	return xxx_messageInfo_MulticastDeviceSetupStatus.Size(m)
+}
+func (m *MulticastDeviceSetupStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_MulticastDeviceSetupStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MulticastDeviceSetupStatus proto.InternalMessageInfo
+
+func (m *MulticastDeviceSetupStatus) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *MulticastDeviceSetupStatus) GetState() MulticastSetupState {
+	if m != nil {
+		return m.State
+	}
+	return MulticastSetupState_SETUP_GROUP_PENDING
+}
+
+func (m *MulticastDeviceSetupStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *MulticastDeviceSetupStatus) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetMulticastSetupStatusResponse struct {
+	// McGroupID.
+	McGroupId uint32 `protobuf:"varint,1,opt,name=mc_group_id,json=mcGroupId,proto3" json:"mc_group_id,omitempty"`
+	// Start of the multicast session.
+	SessionTime *timestamp.Timestamp `protobuf:"bytes,2,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	// Setup status per device.
+	Devices              []*MulticastDeviceSetupStatus `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetMulticastSetupStatusResponse) Reset()         { *m = GetMulticastSetupStatusResponse{} }
+func (m *GetMulticastSetupStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMulticastSetupStatusResponse) ProtoMessage()    {}
+func (*GetMulticastSetupStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{115}
+}
+
+func (m *GetMulticastSetupStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMulticastSetupStatusResponse.Unmarshal(m, b)
+}
+func (m *GetMulticastSetupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMulticastSetupStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetMulticastSetupStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMulticastSetupStatusResponse.Merge(m, src)
+}
+func (m *GetMulticastSetupStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMulticastSetupStatusResponse.Size(m)
+}
+func (m *GetMulticastSetupStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMulticastSetupStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMulticastSetupStatusResponse proto.InternalMessageInfo
+
+func (m *GetMulticastSetupStatusResponse) GetMcGroupId() uint32 {
+	if m != nil {
+		return m.McGroupId
+	}
+	return 0
+}
+
+func (m *GetMulticastSetupStatusResponse) GetSessionTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.SessionTime
+	}
+	return nil
+}
+
+func (m *GetMulticastSetupStatusResponse) GetDevices() []*MulticastDeviceSetupStatus {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type SecurityEvent struct {
+	// Timestamp of the event.
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Event type (mic_failure_burst, dev_nonce_replay, join_flood,
+	// api_auth_failure).
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Severity.
+	Severity SecuritySeverity `protobuf:"varint,3,opt,name=severity,proto3,enum=ns.SecuritySeverity" json:"severity,omitempty"`
+	// Device EUI (when known).
+	DevEui []byte `protobuf:"bytes,4,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Device address (when known).
+	DevAddr []byte `protobuf:"bytes,5,opt,name=dev_addr,json=devAddr,proto3" json:"dev_addr,omitempty"`
+	// Source of the event (gateway ID(s) or remote address).
+	Source string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	// Event message.
+	Message              string   `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SecurityEvent) Reset()         { *m = SecurityEvent{} }
+func (m *SecurityEvent) String() string { return proto.CompactTextString(m) }
+func (*SecurityEvent) ProtoMessage()    {}
+func (*SecurityEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{116}
+}
+
+func (m *SecurityEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecurityEvent.Unmarshal(m, b)
+}
+func (m *SecurityEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecurityEvent.Marshal(b, m, deterministic)
+}
+func (m *SecurityEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecurityEvent.Merge(m, src)
+}
+func (m *SecurityEvent) XXX_Size() int {
+	return xxx_messageInfo_SecurityEvent.Size(m)
+}
+func (m *SecurityEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecurityEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecurityEvent proto.InternalMessageInfo
+
+func (m *SecurityEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *SecurityEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SecurityEvent) GetSeverity() SecuritySeverity {
+	if m != nil {
+		return m.Severity
+	}
+	return SecuritySeverity_LOW
+}
+
+func (m *SecurityEvent) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *SecurityEvent) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+func (m *SecurityEvent) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *SecurityEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetSecurityEventsRequest struct {
+	// Max number of events to return (0 = all stored events).
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Minimum severity of the events to return.
+	MinSeverity SecuritySeverity `protobuf:"varint,2,opt,name=min_severity,json=minSeverity,proto3,enum=ns.SecuritySeverity" json:"min_severity,omitempty"`
+	// Only return the events of the given device EUI (optional).
+	DevEui               []byte   `protobuf:"bytes,3,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSecurityEventsRequest) Reset()         { *m = GetSecurityEventsRequest{} }
+func (m *GetSecurityEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSecurityEventsRequest) ProtoMessage()    {}
+func (*GetSecurityEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{117}
+}
+
+func (m *GetSecurityEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecurityEventsRequest.Unmarshal(m, b)
+}
+func (m *GetSecurityEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecurityEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSecurityEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecurityEventsRequest.Merge(m, src)
+}
+func (m *GetSecurityEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSecurityEventsRequest.Size(m)
+}
+func (m *GetSecurityEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecurityEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecurityEventsRequest proto.InternalMessageInfo
+
+func (m *GetSecurityEventsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetSecurityEventsRequest) GetMinSeverity() SecuritySeverity {
+	if m != nil {
+		return m.MinSeverity
+	}
+	return SecuritySeverity_LOW
+}
+
+func (m *GetSecurityEventsRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+type GetSecurityEventsResponse struct {
+	// Security events, the most recent event first.
+	Events               []*SecurityEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetSecurityEventsResponse) Reset()         { *m = GetSecurityEventsResponse{} }
+func (m *GetSecurityEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSecurityEventsResponse) ProtoMessage()    {}
+func (*GetSecurityEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{118}
+}
+
+func (m *GetSecurityEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSecurityEventsResponse.Unmarshal(m, b)
+}
+func (m *GetSecurityEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSecurityEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSecurityEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSecurityEventsResponse.Merge(m, src)
+}
+func (m *GetSecurityEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSecurityEventsResponse.Size(m)
+}
+func (m *GetSecurityEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSecurityEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSecurityEventsResponse proto.InternalMessageInfo
+
+func (m *GetSecurityEventsResponse) GetEvents() []*SecurityEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type GatewayContributions struct {
+	// Timestamp of the (aggregated) measurement.
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Unique frames for which the gateway was the first receiver.
+	FirstReceiverCount uint32 `protobuf:"varint,2,opt,name=first_receiver_count,json=firstReceiverCount,proto3" json:"first_receiver_count,omitempty"`
+	// Unique frames for which the gateway was the only receiver.
+	OnlyReceiverCount uint32 `protobuf:"varint,3,opt,name=only_receiver_count,json=onlyReceiverCount,proto3" json:"only_receiver_count,omitempty"`
+	// Unique frames received by the gateway (de-duplicated).
+	UplinkCount uint32 `protobuf:"varint,4,opt,name=uplink_count,json=uplinkCount,proto3" json:"uplink_count,omitempty"`
+	// Downlink frames transmitted by the gateway.
+	DownlinkCount        uint32   `protobuf:"varint,5,opt,name=downlink_count,json=downlinkCount,proto3" json:"downlink_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayContributions) Reset()         { *m = GatewayContributions{} }
+func (m *GatewayContributions) String() string { return proto.CompactTextString(m) }
+func (*GatewayContributions) ProtoMessage()    {}
+func (*GatewayContributions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{119}
+}
+
+func (m *GatewayContributions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayContributions.Unmarshal(m, b)
+}
+func (m *GatewayContributions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayContributions.Marshal(b, m, deterministic)
+}
+func (m *GatewayContributions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayContributions.Merge(m, src)
+}
+func (m *GatewayContributions) XXX_Size() int {
+	return xxx_messageInfo_GatewayContributions.Size(m)
+}
+func (m *GatewayContributions) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayContributions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayContributions proto.InternalMessageInfo
+
+func (m *GatewayContributions) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *GatewayContributions) GetFirstReceiverCount() uint32 {
+	if m != nil {
+		return m.FirstReceiverCount
+	}
+	return 0
+}
+
+func (m *GatewayContributions) GetOnlyReceiverCount() uint32 {
+	if m != nil {
+		return m.OnlyReceiverCount
+	}
+	return 0
+}
+
+func (m *GatewayContributions) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+func (m *GatewayContributions) GetDownlinkCount() uint32 {
+	if m != nil {
+		return m.DownlinkCount
+	}
+	return 0
+}
+
+type GetGatewayContributionsRequest struct {
+	// MAC address of the gateway.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Aggregation interval.
+	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,proto3,enum=ns.AggregationInterval" json:"interval,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetGatewayContributionsRequest) Reset()         { *m = GetGatewayContributionsRequest{} }
+func (m *GetGatewayContributionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayContributionsRequest) ProtoMessage()    {}
+func (*GetGatewayContributionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{120}
+}
+
+func (m *GetGatewayContributionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayContributionsRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayContributionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayContributionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayContributionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayContributionsRequest.Merge(m, src)
+}
+func (m *GetGatewayContributionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayContributionsRequest.Size(m)
+}
+func (m *GetGatewayContributionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayContributionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayContributionsRequest proto.InternalMessageInfo
+
+func (m *GetGatewayContributionsRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GetGatewayContributionsRequest) GetInterval() AggregationInterval {
+	if m != nil {
+		return m.Interval
+	}
+	return AggregationInterval_SECOND
+}
+
+func (m *GetGatewayContributionsRequest) GetStartTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return nil
+}
+
+func (m *GetGatewayContributionsRequest) GetEndTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return nil
+}
+
+type GetGatewayContributionsResponse struct {
+	Result               []*GatewayContributions `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetGatewayContributionsResponse) Reset()         { *m = GetGatewayContributionsResponse{} }
+func (m *GetGatewayContributionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayContributionsResponse) ProtoMessage()    {}
+func (*GetGatewayContributionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{121}
+}
+
+func (m *GetGatewayContributionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayContributionsResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayContributionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayContributionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayContributionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayContributionsResponse.Merge(m, src)
+}
+func (m *GetGatewayContributionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayContributionsResponse.Size(m)
+}
+func (m *GetGatewayContributionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayContributionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayContributionsResponse proto.InternalMessageInfo
+
+func (m *GetGatewayContributionsResponse) GetResult() []*GatewayContributions {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type StreamGatewayContributionsRequest struct {
+	// MAC address of the gateway (optional, when empty the events of all
+	// gateways are returned).
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamGatewayContributionsRequest) Reset()         { *m = StreamGatewayContributionsRequest{} }
+func (m *StreamGatewayContributionsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamGatewayContributionsRequest) ProtoMessage()    {}
+func (*StreamGatewayContributionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{122}
+}
+
+func (m *StreamGatewayContributionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamGatewayContributionsRequest.Unmarshal(m, b)
+}
+func (m *StreamGatewayContributionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamGatewayContributionsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamGatewayContributionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamGatewayContributionsRequest.Merge(m, src)
+}
+func (m *StreamGatewayContributionsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamGatewayContributionsRequest.Size(m)
+}
+func (m *StreamGatewayContributionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamGatewayContributionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamGatewayContributionsRequest proto.InternalMessageInfo
+
+func (m *StreamGatewayContributionsRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GatewayContributionEvent struct {
+	// Timestamp of the event.
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// MAC address of the gateway.
+	GatewayId []byte `protobuf:"bytes,2,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Contribution type.
+	Type GatewayContributionType `protobuf:"varint,3,opt,name=type,proto3,enum=ns.GatewayContributionType" json:"type,omitempty"`
+	// The gateway was the first receiver of the uplink frame.
+	FirstReceiver bool `protobuf:"varint,4,opt,name=first_receiver,json=firstReceiver,proto3" json:"first_receiver,omitempty"`
+	// The gateway was the only receiver of the uplink frame.
+	OnlyReceiver         bool     `protobuf:"varint,5,opt,name=only_receiver,json=onlyReceiver,proto3" json:"only_receiver,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayContributionEvent) Reset()         { *m = GatewayContributionEvent{} }
+func (m *GatewayContributionEvent) String() string { return proto.CompactTextString(m) }
+func (*GatewayContributionEvent) ProtoMessage()    {}
+func (*GatewayContributionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{123}
+}
+
+func (m *GatewayContributionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayContributionEvent.Unmarshal(m, b)
+}
+func (m *GatewayContributionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayContributionEvent.Marshal(b, m, deterministic)
+}
+func (m *GatewayContributionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayContributionEvent.Merge(m, src)
+}
+func (m *GatewayContributionEvent) XXX_Size() int {
+	return xxx_messageInfo_GatewayContributionEvent.Size(m)
+}
+func (m *GatewayContributionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayContributionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayContributionEvent proto.InternalMessageInfo
+
+func (m *GatewayContributionEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *GatewayContributionEvent) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayContributionEvent) GetType() GatewayContributionType {
+	if m != nil {
+		return m.Type
+	}
+	return GatewayContributionType_UPLINK
+}
+
+func (m *GatewayContributionEvent) GetFirstReceiver() bool {
+	if m != nil {
+		return m.FirstReceiver
+	}
+	return false
+}
+
+func (m *GatewayContributionEvent) GetOnlyReceiver() bool {
+	if m != nil {
+		return m.OnlyReceiver
+	}
+	return false
+}
+
+type RoamingAgreement struct {
+	// ID of the roaming agreement.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// NetID of the partner network.
+	NetId []byte `protobuf:"bytes,2,opt,name=net_id,json=netId,proto3" json:"net_id,omitempty"`
+	// Backend Interfaces endpoint of the partner network.
+	Server string `protobuf:"bytes,3,opt,name=server,proto3" json:"server,omitempty"`
+	// Passive Roaming allowed.
+	PassiveRoaming bool `protobuf:"varint,4,opt,name=passive_roaming,json=passiveRoaming,proto3" json:"passive_roaming,omitempty"`
+	// Handover Roaming allowed.
+	HandoverRoaming bool `protobuf:"varint,5,opt,name=handover_roaming,json=handoverRoaming,proto3" json:"handover_roaming,omitempty"`
+	// Lifetime of a passive-roaming session.
+	PassiveRoamingLifetime *duration.Duration `protobuf:"bytes,6,opt,name=passive_roaming_lifetime,json=passiveRoamingLifetime,proto3" json:"passive_roaming_lifetime,omitempty"`
+	// Reference to the (commercial) agreement, used for billing.
+	BillingReference string `protobuf:"bytes,7,opt,name=billing_reference,json=billingReference,proto3" json:"billing_reference,omitempty"`
+	// Stateless passive roaming.
+	// When set, no passive-roaming session is kept and each uplink is
+	// forwarded to the partner network using a PRStartReq.
+	PassiveRoamingStateless bool     `protobuf:"varint,8,opt,name=passive_roaming_stateless,json=passiveRoamingStateless,proto3" json:"passive_roaming_stateless,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *RoamingAgreement) Reset()         { *m = RoamingAgreement{} }
+func (m *RoamingAgreement) String() string { return proto.CompactTextString(m) }
+func (*RoamingAgreement) ProtoMessage()    {}
+func (*RoamingAgreement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{124}
+}
+
+func (m *RoamingAgreement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoamingAgreement.Unmarshal(m, b)
+}
+func (m *RoamingAgreement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoamingAgreement.Marshal(b, m, deterministic)
+}
+func (m *RoamingAgreement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoamingAgreement.Merge(m, src)
+}
+func (m *RoamingAgreement) XXX_Size() int {
+	return xxx_messageInfo_RoamingAgreement.Size(m)
+}
+func (m *RoamingAgreement) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoamingAgreement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoamingAgreement proto.InternalMessageInfo
+
+func (m *RoamingAgreement) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *RoamingAgreement) GetNetId() []byte {
+	if m != nil {
+		return m.NetId
+	}
+	return nil
+}
+
+func (m *RoamingAgreement) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *RoamingAgreement) GetPassiveRoaming() bool {
+	if m != nil {
+		return m.PassiveRoaming
+	}
+	return false
+}
+
+func (m *RoamingAgreement) GetHandoverRoaming() bool {
+	if m != nil {
+		return m.HandoverRoaming
+	}
+	return false
+}
+
+func (m *RoamingAgreement) GetPassiveRoamingLifetime() *duration.Duration {
+	if m != nil {
+		return m.PassiveRoamingLifetime
+	}
+	return nil
+}
+
+func (m *RoamingAgreement) GetBillingReference() string {
+	if m != nil {
+		return m.BillingReference
+	}
+	return ""
+}
+
+func (m *RoamingAgreement) GetPassiveRoamingStateless() bool {
+	if m != nil {
+		return m.PassiveRoamingStateless
+	}
+	return false
+}
+
+type CreateRoamingAgreementRequest struct {
+	// Roaming agreement object to create.
+	RoamingAgreement     *RoamingAgreement `protobuf:"bytes,1,opt,name=roaming_agreement,json=roamingAgreement,proto3" json:"roaming_agreement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CreateRoamingAgreementRequest) Reset()         { *m = CreateRoamingAgreementRequest{} }
+func (m *CreateRoamingAgreementRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRoamingAgreementRequest) ProtoMessage()    {}
+func (*CreateRoamingAgreementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{125}
+}
+
+func (m *CreateRoamingAgreementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRoamingAgreementRequest.Unmarshal(m, b)
+}
+func (m *CreateRoamingAgreementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRoamingAgreementRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRoamingAgreementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRoamingAgreementRequest.Merge(m, src)
+}
+func (m *CreateRoamingAgreementRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRoamingAgreementRequest.Size(m)
+}
+func (m *CreateRoamingAgreementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRoamingAgreementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRoamingAgreementRequest proto.InternalMessageInfo
+
+func (m *CreateRoamingAgreementRequest) GetRoamingAgreement() *RoamingAgreement {
+	if m != nil {
+		return m.RoamingAgreement
+	}
+	return nil
+}
+
+type CreateRoamingAgreementResponse struct {
+	// ID of the created roaming agreement.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRoamingAgreementResponse) Reset()         { *m = CreateRoamingAgreementResponse{} }
+func (m *CreateRoamingAgreementResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateRoamingAgreementResponse) ProtoMessage()    {}
+func (*CreateRoamingAgreementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{126}
+}
+
+func (m *CreateRoamingAgreementResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRoamingAgreementResponse.Unmarshal(m, b)
+}
+func (m *CreateRoamingAgreementResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRoamingAgreementResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateRoamingAgreementResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRoamingAgreementResponse.Merge(m, src)
+}
+func (m *CreateRoamingAgreementResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateRoamingAgreementResponse.Size(m)
+}
+func (m *CreateRoamingAgreementResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRoamingAgreementResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRoamingAgreementResponse proto.InternalMessageInfo
+
+func (m *CreateRoamingAgreementResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetRoamingAgreementRequest struct {
+	// Roaming agreement ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRoamingAgreementRequest) Reset()         { *m = GetRoamingAgreementRequest{} }
+func (m *GetRoamingAgreementRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRoamingAgreementRequest) ProtoMessage()    {}
+func (*GetRoamingAgreementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{127}
+}
+
+func (m *GetRoamingAgreementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoamingAgreementRequest.Unmarshal(m, b)
+}
+func (m *GetRoamingAgreementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoamingAgreementRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRoamingAgreementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoamingAgreementRequest.Merge(m, src)
+}
+func (m *GetRoamingAgreementRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRoamingAgreementRequest.Size(m)
+}
+func (m *GetRoamingAgreementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoamingAgreementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoamingAgreementRequest proto.InternalMessageInfo
+
+func (m *GetRoamingAgreementRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetRoamingAgreementResponse struct {
+	// Roaming agreement object.
+	RoamingAgreement *RoamingAgreement `protobuf:"bytes,1,opt,name=roaming_agreement,json=roamingAgreement,proto3" json:"roaming_agreement,omitempty"`
+	// Created at timestamp.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update timestamp.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetRoamingAgreementResponse) Reset()         { *m = GetRoamingAgreementResponse{} }
+func (m *GetRoamingAgreementResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRoamingAgreementResponse) ProtoMessage()    {}
+func (*GetRoamingAgreementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{128}
+}
+
+func (m *GetRoamingAgreementResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRoamingAgreementResponse.Unmarshal(m, b)
+}
+func (m *GetRoamingAgreementResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRoamingAgreementResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRoamingAgreementResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRoamingAgreementResponse.Merge(m, src)
+}
+func (m *GetRoamingAgreementResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRoamingAgreementResponse.Size(m)
+}
+func (m *GetRoamingAgreementResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRoamingAgreementResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRoamingAgreementResponse proto.InternalMessageInfo
+
+func (m *GetRoamingAgreementResponse) GetRoamingAgreement() *RoamingAgreement {
+	if m != nil {
+		return m.RoamingAgreement
+	}
+	return nil
+}
+
+func (m *GetRoamingAgreementResponse) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *GetRoamingAgreementResponse) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateRoamingAgreementRequest struct {
+	// Roaming agreement object to update.
+	RoamingAgreement     *RoamingAgreement `protobuf:"bytes,1,opt,name=roaming_agreement,json=roamingAgreement,proto3" json:"roaming_agreement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateRoamingAgreementRequest) Reset()         { *m = UpdateRoamingAgreementRequest{} }
+func (m *UpdateRoamingAgreementRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRoamingAgreementRequest) ProtoMessage()    {}
+func (*UpdateRoamingAgreementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{129}
+}
+
+func (m *UpdateRoamingAgreementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRoamingAgreementRequest.Unmarshal(m, b)
+}
+func (m *UpdateRoamingAgreementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRoamingAgreementRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRoamingAgreementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRoamingAgreementRequest.Merge(m, src)
+}
+func (m *UpdateRoamingAgreementRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRoamingAgreementRequest.Size(m)
+}
+func (m *UpdateRoamingAgreementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRoamingAgreementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRoamingAgreementRequest proto.InternalMessageInfo
+
+func (m *UpdateRoamingAgreementRequest) GetRoamingAgreement() *RoamingAgreement {
+	if m != nil {
+		return m.RoamingAgreement
+	}
+	return nil
+}
+
+type DeleteRoamingAgreementRequest struct {
+	// Roaming agreement ID.
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRoamingAgreementRequest) Reset()         { *m = DeleteRoamingAgreementRequest{} }
+func (m *DeleteRoamingAgreementRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRoamingAgreementRequest) ProtoMessage()    {}
+func (*DeleteRoamingAgreementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{130}
+}
+
+func (m *DeleteRoamingAgreementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRoamingAgreementRequest.Unmarshal(m, b)
+}
+func (m *DeleteRoamingAgreementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRoamingAgreementRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRoamingAgreementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRoamingAgreementRequest.Merge(m, src)
+}
+func (m *DeleteRoamingAgreementRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRoamingAgreementRequest.Size(m)
+}
+func (m *DeleteRoamingAgreementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRoamingAgreementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRoamingAgreementRequest proto.InternalMessageInfo
+
+func (m *DeleteRoamingAgreementRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type ListRoamingAgreementsRequest struct {
+	// Max number of items to return.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset               uint32   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoamingAgreementsRequest) Reset()         { *m = ListRoamingAgreementsRequest{} }
+func (m *ListRoamingAgreementsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRoamingAgreementsRequest) ProtoMessage()    {}
+func (*ListRoamingAgreementsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{131}
+}
+
+func (m *ListRoamingAgreementsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoamingAgreementsRequest.Unmarshal(m, b)
+}
+func (m *ListRoamingAgreementsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoamingAgreementsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRoamingAgreementsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoamingAgreementsRequest.Merge(m, src)
+}
+func (m *ListRoamingAgreementsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRoamingAgreementsRequest.Size(m)
+}
+func (m *ListRoamingAgreementsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoamingAgreementsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoamingAgreementsRequest proto.InternalMessageInfo
+
+func (m *ListRoamingAgreementsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListRoamingAgreementsRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListRoamingAgreementsResponse struct {
+	// Total number of roaming agreements.
+	TotalCount uint32 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Roaming agreements.
+	Result               []*RoamingAgreement `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListRoamingAgreementsResponse) Reset()         { *m = ListRoamingAgreementsResponse{} }
+func (m *ListRoamingAgreementsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRoamingAgreementsResponse) ProtoMessage()    {}
+func (*ListRoamingAgreementsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{132}
+}
+
+func (m *ListRoamingAgreementsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoamingAgreementsResponse.Unmarshal(m, b)
+}
+func (m *ListRoamingAgreementsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoamingAgreementsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRoamingAgreementsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoamingAgreementsResponse.Merge(m, src)
+}
+func (m *ListRoamingAgreementsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRoamingAgreementsResponse.Size(m)
+}
+func (m *ListRoamingAgreementsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoamingAgreementsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoamingAgreementsResponse proto.InternalMessageInfo
+
+func (m *ListRoamingAgreementsResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListRoamingAgreementsResponse) GetResult() []*RoamingAgreement {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type EnqueueCertificationCommandRequest struct {
+	// Device EUI (8 bytes).
+	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Certification command(s) (unencrypted).
+	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EnqueueCertificationCommandRequest) Reset()         { *m = EnqueueCertificationCommandRequest{} }
+func (m *EnqueueCertificationCommandRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueCertificationCommandRequest) ProtoMessage()    {}
+func (*EnqueueCertificationCommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{133}
+}
+
+func (m *EnqueueCertificationCommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnqueueCertificationCommandRequest.Unmarshal(m, b)
+}
+func (m *EnqueueCertificationCommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnqueueCertificationCommandRequest.Marshal(b, m, deterministic)
+}
+func (m *EnqueueCertificationCommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnqueueCertificationCommandRequest.Merge(m, src)
+}
+func (m *EnqueueCertificationCommandRequest) XXX_Size() int {
+	return xxx_messageInfo_EnqueueCertificationCommandRequest.Size(m)
+}
+func (m *EnqueueCertificationCommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnqueueCertificationCommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnqueueCertificationCommandRequest proto.InternalMessageInfo
+
+func (m *EnqueueCertificationCommandRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *EnqueueCertificationCommandRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type RedisKeyClassMemoryUsage struct {
+	// Key class (sessions, deduplication, framelog, queues, metrics or other).
+	KeyClass string `protobuf:"bytes,1,opt,name=key_class,json=keyClass,proto3" json:"key_class,omitempty"`
+	// Number of keys.
+	Keys int64 `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	// Estimated memory usage (bytes).
+	Bytes int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Configured cap (bytes, 0 when no cap is configured).
+	CapBytes             int64    `protobuf:"varint,4,opt,name=cap_bytes,json=capBytes,proto3" json:"cap_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedisKeyClassMemoryUsage) Reset()         { *m = RedisKeyClassMemoryUsage{} }
+func (m *RedisKeyClassMemoryUsage) String() string { return proto.CompactTextString(m) }
+func (*RedisKeyClassMemoryUsage) ProtoMessage()    {}
+func (*RedisKeyClassMemoryUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{134}
+}
+
+func (m *RedisKeyClassMemoryUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedisKeyClassMemoryUsage.Unmarshal(m, b)
+}
+func (m *RedisKeyClassMemoryUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedisKeyClassMemoryUsage.Marshal(b, m, deterministic)
+}
+func (m *RedisKeyClassMemoryUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedisKeyClassMemoryUsage.Merge(m, src)
+}
+func (m *RedisKeyClassMemoryUsage) XXX_Size() int {
+	return xxx_messageInfo_RedisKeyClassMemoryUsage.Size(m)
+}
+func (m *RedisKeyClassMemoryUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedisKeyClassMemoryUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedisKeyClassMemoryUsage proto.InternalMessageInfo
+
+func (m *RedisKeyClassMemoryUsage) GetKeyClass() string {
+	if m != nil {
+		return m.KeyClass
+	}
+	return ""
+}
+
+func (m *RedisKeyClassMemoryUsage) GetKeys() int64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *RedisKeyClassMemoryUsage) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *RedisKeyClassMemoryUsage) GetCapBytes() int64 {
+	if m != nil {
+		return m.CapBytes
+	}
+	return 0
+}
+
+type GetRedisMemoryUsageResponse struct {
+	// Time of the sample (not set when no sample has been taken yet).
+	SampledAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=sampled_at,json=sampledAt,proto3" json:"sampled_at,omitempty"`
+	// Total memory used by Redis (bytes), as reported by Redis.
+	UsedMemory int64 `protobuf:"varint,2,opt,name=used_memory,json=usedMemory,proto3" json:"used_memory,omitempty"`
+	// Memory usage per key class.
+	KeyClasses           []*RedisKeyClassMemoryUsage `protobuf:"bytes,3,rep,name=key_classes,json=keyClasses,proto3" json:"key_classes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetRedisMemoryUsageResponse) Reset()         { *m = GetRedisMemoryUsageResponse{} }
+func (m *GetRedisMemoryUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRedisMemoryUsageResponse) ProtoMessage()    {}
+func (*GetRedisMemoryUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{135}
+}
+
+func (m *GetRedisMemoryUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRedisMemoryUsageResponse.Unmarshal(m, b)
+}
+func (m *GetRedisMemoryUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRedisMemoryUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRedisMemoryUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRedisMemoryUsageResponse.Merge(m, src)
+}
+func (m *GetRedisMemoryUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRedisMemoryUsageResponse.Size(m)
+}
+func (m *GetRedisMemoryUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRedisMemoryUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRedisMemoryUsageResponse proto.InternalMessageInfo
+
+func (m *GetRedisMemoryUsageResponse) GetSampledAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SampledAt
+	}
+	return nil
+}
+
+func (m *GetRedisMemoryUsageResponse) GetUsedMemory() int64 {
+	if m != nil {
+		return m.UsedMemory
+	}
+	return 0
+}
+
+func (m *GetRedisMemoryUsageResponse) GetKeyClasses() []*RedisKeyClassMemoryUsage {
+	if m != nil {
+		return m.KeyClasses
+	}
+	return nil
+}
+
+type GatewayConfigurationStatus struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Configuration version.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Rollout state.
+	State GatewayConfigurationState `protobuf:"varint,3,opt,name=state,proto3,enum=ns.GatewayConfigurationState" json:"state,omitempty"`
+	// Last update timestamp.
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Start of the reconfiguration window in which the configuration will be
+	// sent (only set when scheduled).
+	ScheduledAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	// Timestamp at which the configuration was sent.
+	SentAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	// Timestamp at which the gateway reported the configuration version.
+	AppliedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GatewayConfigurationStatus) Reset()         { *m = GatewayConfigurationStatus{} }
+func (m *GatewayConfigurationStatus) String() string { return proto.CompactTextString(m) }
+func (*GatewayConfigurationStatus) ProtoMessage()    {}
+func (*GatewayConfigurationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{136}
+}
+
+func (m *GatewayConfigurationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayConfigurationStatus.Unmarshal(m, b)
+}
+func (m *GatewayConfigurationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayConfigurationStatus.Marshal(b, m, deterministic)
+}
+func (m *GatewayConfigurationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayConfigurationStatus.Merge(m, src)
+}
+func (m *GatewayConfigurationStatus) XXX_Size() int {
+	return xxx_messageInfo_GatewayConfigurationStatus.Size(m)
+}
+func (m *GatewayConfigurationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayConfigurationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayConfigurationStatus proto.InternalMessageInfo
+
+func (m *GatewayConfigurationStatus) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayConfigurationStatus) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GatewayConfigurationStatus) GetState() GatewayConfigurationState {
+	if m != nil {
+		return m.State
+	}
+	return GatewayConfigurationState_CONFIGURATION_UNKNOWN
+}
+
+func (m *GatewayConfigurationStatus) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *GatewayConfigurationStatus) GetScheduledAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *GatewayConfigurationStatus) GetSentAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SentAt
+	}
+	return nil
+}
+
+func (m *GatewayConfigurationStatus) GetAppliedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.AppliedAt
+	}
+	return nil
+}
+
+type GetGatewayConfigurationStatusRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayConfigurationStatusRequest) Reset()         { *m = GetGatewayConfigurationStatusRequest{} }
+func (m *GetGatewayConfigurationStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayConfigurationStatusRequest) ProtoMessage()    {}
+func (*GetGatewayConfigurationStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{137}
+}
+
+func (m *GetGatewayConfigurationStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConfigurationStatusRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayConfigurationStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConfigurationStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConfigurationStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConfigurationStatusRequest.Merge(m, src)
+}
+func (m *GetGatewayConfigurationStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConfigurationStatusRequest.Size(m)
+}
+func (m *GetGatewayConfigurationStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConfigurationStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConfigurationStatusRequest proto.InternalMessageInfo
+
+func (m *GetGatewayConfigurationStatusRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayConfigurationStatusResponse struct {
+	// Configuration rollout status.
+	Status               *GatewayConfigurationStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetGatewayConfigurationStatusResponse) Reset()         { *m = GetGatewayConfigurationStatusResponse{} }
+func (m *GetGatewayConfigurationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayConfigurationStatusResponse) ProtoMessage()    {}
+func (*GetGatewayConfigurationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{138}
+}
+
+func (m *GetGatewayConfigurationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConfigurationStatusResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayConfigurationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConfigurationStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConfigurationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConfigurationStatusResponse.Merge(m, src)
+}
+func (m *GetGatewayConfigurationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConfigurationStatusResponse.Size(m)
+}
+func (m *GetGatewayConfigurationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConfigurationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConfigurationStatusResponse proto.InternalMessageInfo
+
+func (m *GetGatewayConfigurationStatusResponse) GetStatus() *GatewayConfigurationStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type GetGatewayConfigurationStatusForGatewayProfileRequest struct {
+	// Gateway-profile ID.
+	GatewayProfileId     []byte   `protobuf:"bytes,1,opt,name=gateway_profile_id,json=gatewayProfileId,proto3" json:"gateway_profile_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) Reset() {
+	*m = GetGatewayConfigurationStatusForGatewayProfileRequest{}
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetGatewayConfigurationStatusForGatewayProfileRequest) ProtoMessage() {}
+func (*GetGatewayConfigurationStatusForGatewayProfileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{139}
+}
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest.Merge(m, src)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest.Size(m)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileRequest proto.InternalMessageInfo
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileRequest) GetGatewayProfileId() []byte {
+	if m != nil {
+		return m.GatewayProfileId
+	}
+	return nil
+}
+
+type GetGatewayConfigurationStatusForGatewayProfileResponse struct {
+	// Configuration rollout status per gateway.
+	Result               []*GatewayConfigurationStatus `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) Reset() {
+	*m = GetGatewayConfigurationStatusForGatewayProfileResponse{}
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) String() string {
+	return proto.CompactTextString(m)
+}
+func (*GetGatewayConfigurationStatusForGatewayProfileResponse) ProtoMessage() {}
+func (*GetGatewayConfigurationStatusForGatewayProfileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{140}
+}
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse.Merge(m, src)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse.Size(m)
+}
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConfigurationStatusForGatewayProfileResponse proto.InternalMessageInfo
+
+func (m *GetGatewayConfigurationStatusForGatewayProfileResponse) GetResult() []*GatewayConfigurationStatus {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DecodePHYPayloadRequest struct {
+	// PHYPayload.
+	PhyPayload []byte `protobuf:"bytes,1,opt,name=phy_payload,json=phyPayload,proto3" json:"phy_payload,omitempty"`
+	// DevEUI of the device-session to use (optional).
+	// When not set, the device-session is looked up by DevAddr, FCnt and MIC.
+	DevEui []byte `protobuf:"bytes,2,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// Decrypt the application FRMPayload.
+	// This is only performed when the AppSKey is known to the network-server
+	// and the service-profile of the device does not redact the FRMPayload.
+	DecryptFrmPayload bool `protobuf:"varint,3,opt,name=decrypt_frm_payload,json=decryptFrmPayload,proto3" json:"decrypt_frm_payload,omitempty"`
+	// Uplink TX data-rate and channel index.
+	// These are used for the LoRaWAN 1.1 uplink MIC validation.
+	TxDr                 uint32   `protobuf:"varint,4,opt,name=tx_dr,json=txDr,proto3" json:"tx_dr,omitempty"`
+	TxCh                 uint32   `protobuf:"varint,5,opt,name=tx_ch,json=txCh,proto3" json:"tx_ch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecodePHYPayloadRequest) Reset()         { *m = DecodePHYPayloadRequest{} }
+func (m *DecodePHYPayloadRequest) String() string { return proto.CompactTextString(m) }
+func (*DecodePHYPayloadRequest) ProtoMessage()    {}
+func (*DecodePHYPayloadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{141}
+}
+
+func (m *DecodePHYPayloadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecodePHYPayloadRequest.Unmarshal(m, b)
+}
+func (m *DecodePHYPayloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecodePHYPayloadRequest.Marshal(b, m, deterministic)
+}
+func (m *DecodePHYPayloadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecodePHYPayloadRequest.Merge(m, src)
+}
+func (m *DecodePHYPayloadRequest) XXX_Size() int {
+	return xxx_messageInfo_DecodePHYPayloadRequest.Size(m)
+}
+func (m *DecodePHYPayloadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecodePHYPayloadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecodePHYPayloadRequest proto.InternalMessageInfo
+
+func (m *DecodePHYPayloadRequest) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
+func (m *DecodePHYPayloadRequest) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DecodePHYPayloadRequest) GetDecryptFrmPayload() bool {
+	if m != nil {
+		return m.DecryptFrmPayload
+	}
+	return false
+}
+
+func (m *DecodePHYPayloadRequest) GetTxDr() uint32 {
+	if m != nil {
+		return m.TxDr
+	}
+	return 0
+}
+
+func (m *DecodePHYPayloadRequest) GetTxCh() uint32 {
+	if m != nil {
+		return m.TxCh
+	}
+	return 0
+}
+
+type DecodePHYPayloadResponse struct {
+	// Message-type.
+	MType MType `protobuf:"varint,1,opt,name=m_type,json=mType,proto3,enum=ns.MType" json:"m_type,omitempty"`
+	// Decoded PHYPayload (JSON).
+	PhyPayloadJson string `protobuf:"bytes,2,opt,name=phy_payload_json,json=phyPayloadJson,proto3" json:"phy_payload_json,omitempty"`
+	// DevEUI of the device-session used for the MIC validation and
+	// decryption (not set when no device-session matches).
+	DevEui []byte `protobuf:"bytes,3,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
+	// The MIC is valid for the device-session.
+	MicValid bool `protobuf:"varint,4,opt,name=mic_valid,json=micValid,proto3" json:"mic_valid,omitempty"`
+	// Full frame-counter (data frames only).
+	FCnt uint32 `protobuf:"varint,5,opt,name=f_cnt,json=fCnt,proto3" json:"f_cnt,omitempty"`
+	// The mac-commands (FOpts and FRMPayload with FPort 0) are decrypted.
+	MacCommandsDecrypted bool `protobuf:"varint,6,opt,name=mac_commands_decrypted,json=macCommandsDecrypted,proto3" json:"mac_commands_decrypted,omitempty"`
+	// The application FRMPayload is decrypted.
+	FrmPayloadDecrypted  bool     `protobuf:"varint,7,opt,name=frm_payload_decrypted,json=frmPayloadDecrypted,proto3" json:"frm_payload_decrypted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecodePHYPayloadResponse) Reset()         { *m = DecodePHYPayloadResponse{} }
+func (m *DecodePHYPayloadResponse) String() string { return proto.CompactTextString(m) }
+func (*DecodePHYPayloadResponse) ProtoMessage()    {}
+func (*DecodePHYPayloadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{142}
+}
+
+func (m *DecodePHYPayloadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecodePHYPayloadResponse.Unmarshal(m, b)
+}
+func (m *DecodePHYPayloadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecodePHYPayloadResponse.Marshal(b, m, deterministic)
+}
+func (m *DecodePHYPayloadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecodePHYPayloadResponse.Merge(m, src)
+}
+func (m *DecodePHYPayloadResponse) XXX_Size() int {
+	return xxx_messageInfo_DecodePHYPayloadResponse.Size(m)
+}
+func (m *DecodePHYPayloadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecodePHYPayloadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecodePHYPayloadResponse proto.InternalMessageInfo
+
+func (m *DecodePHYPayloadResponse) GetMType() MType {
+	if m != nil {
+		return m.MType
+	}
+	return MType_JOIN_REQUEST
+}
+
+func (m *DecodePHYPayloadResponse) GetPhyPayloadJson() string {
+	if m != nil {
+		return m.PhyPayloadJson
+	}
+	return ""
+}
+
+func (m *DecodePHYPayloadResponse) GetDevEui() []byte {
+	if m != nil {
+		return m.DevEui
+	}
+	return nil
+}
+
+func (m *DecodePHYPayloadResponse) GetMicValid() bool {
+	if m != nil {
+		return m.MicValid
+	}
+	return false
+}
+
+func (m *DecodePHYPayloadResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DecodePHYPayloadResponse) GetMacCommandsDecrypted() bool {
+	if m != nil {
+		return m.MacCommandsDecrypted
+	}
+	return false
+}
+
+func (m *DecodePHYPayloadResponse) GetFrmPayloadDecrypted() bool {
+	if m != nil {
+		return m.FrmPayloadDecrypted
+	}
+	return false
+}
+
+type GetSchedulerBacklogRequest struct {
+	// Gateway ID (optional).
+	// When set, only the backlog related to the given gateway is returned.
+	// For the device-queue, these are the items of the devices of which the
+	// last uplink was received by the gateway.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchedulerBacklogRequest) Reset()         { *m = GetSchedulerBacklogRequest{} }
+func (m *GetSchedulerBacklogRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchedulerBacklogRequest) ProtoMessage()    {}
+func (*GetSchedulerBacklogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{143}
+}
+
+func (m *GetSchedulerBacklogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchedulerBacklogRequest.Unmarshal(m, b)
+}
+func (m *GetSchedulerBacklogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchedulerBacklogRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSchedulerBacklogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchedulerBacklogRequest.Merge(m, src)
+}
+func (m *GetSchedulerBacklogRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSchedulerBacklogRequest.Size(m)
+}
+func (m *GetSchedulerBacklogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchedulerBacklogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchedulerBacklogRequest proto.InternalMessageInfo
+
+func (m *GetSchedulerBacklogRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type BeaconPeriodBacklog struct {
+	// Start of the beacon period (time since GPS epoch).
+	TimeSinceGpsEpoch *duration.Duration `protobuf:"bytes,1,opt,name=time_since_gps_epoch,json=timeSinceGpsEpoch,proto3" json:"time_since_gps_epoch,omitempty"`
+	// Number of Class-B device-queue items to emit within the beacon period.
+	Items                uint32   `protobuf:"varint,2,opt,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BeaconPeriodBacklog) Reset()         { *m = BeaconPeriodBacklog{} }
+func (m *BeaconPeriodBacklog) String() string { return proto.CompactTextString(m) }
+func (*BeaconPeriodBacklog) ProtoMessage()    {}
+func (*BeaconPeriodBacklog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{144}
+}
+
+func (m *BeaconPeriodBacklog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BeaconPeriodBacklog.Unmarshal(m, b)
+}
+func (m *BeaconPeriodBacklog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BeaconPeriodBacklog.Marshal(b, m, deterministic)
+}
+func (m *BeaconPeriodBacklog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BeaconPeriodBacklog.Merge(m, src)
+}
+func (m *BeaconPeriodBacklog) XXX_Size() int {
+	return xxx_messageInfo_BeaconPeriodBacklog.Size(m)
+}
+func (m *BeaconPeriodBacklog) XXX_DiscardUnknown() {
+	xxx_messageInfo_BeaconPeriodBacklog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BeaconPeriodBacklog proto.InternalMessageInfo
+
+func (m *BeaconPeriodBacklog) GetTimeSinceGpsEpoch() *duration.Duration {
+	if m != nil {
+		return m.TimeSinceGpsEpoch
+	}
+	return nil
+}
+
+func (m *BeaconPeriodBacklog) GetItems() uint32 {
+	if m != nil {
+		return m.Items
+	}
+	return 0
+}
+
+type GetSchedulerBacklogResponse struct {
+	// Number of Class-C device-queue items awaiting transmission.
+	ClassCItems uint32 `protobuf:"varint,1,opt,name=class_c_items,json=classCItems,proto3" json:"class_c_items,omitempty"`
+	// Number of Class-C devices with device-queue items awaiting
+	// transmission.
+	ClassCDevices uint32 `protobuf:"varint,2,opt,name=class_c_devices,json=classCDevices,proto3" json:"class_c_devices,omitempty"`
+	// Class-B device-queue items awaiting transmission, per beacon period.
+	ClassBBeaconPeriods []*BeaconPeriodBacklog `protobuf:"bytes,3,rep,name=class_b_beacon_periods,json=classBBeaconPeriods,proto3" json:"class_b_beacon_periods,omitempty"`
+	// Number of multicast-queue items of which the schedule timestamp has
+	// passed.
+	MulticastDueItems uint32 `protobuf:"varint,4,opt,name=multicast_due_items,json=multicastDueItems,proto3" json:"multicast_due_items,omitempty"`
+	// Number of multicast-queue items scheduled in the future.
+	MulticastScheduledItems uint32 `protobuf:"varint,5,opt,name=multicast_scheduled_items,json=multicastScheduledItems,proto3" json:"multicast_scheduled_items,omitempty"`
+	// Number of downlink frames sent to the gateway(s), awaiting a TX
+	// acknowledgement.
+	TxAckPending         uint32   `protobuf:"varint,6,opt,name=tx_ack_pending,json=txAckPending,proto3" json:"tx_ack_pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchedulerBacklogResponse) Reset()         { *m = GetSchedulerBacklogResponse{} }
+func (m *GetSchedulerBacklogResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSchedulerBacklogResponse) ProtoMessage()    {}
+func (*GetSchedulerBacklogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{145}
+}
+
+func (m *GetSchedulerBacklogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchedulerBacklogResponse.Unmarshal(m, b)
+}
+func (m *GetSchedulerBacklogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchedulerBacklogResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSchedulerBacklogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchedulerBacklogResponse.Merge(m, src)
+}
+func (m *GetSchedulerBacklogResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSchedulerBacklogResponse.Size(m)
+}
+func (m *GetSchedulerBacklogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchedulerBacklogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchedulerBacklogResponse proto.InternalMessageInfo
+
+func (m *GetSchedulerBacklogResponse) GetClassCItems() uint32 {
+	if m != nil {
+		return m.ClassCItems
+	}
+	return 0
+}
+
+func (m *GetSchedulerBacklogResponse) GetClassCDevices() uint32 {
+	if m != nil {
+		return m.ClassCDevices
+	}
+	return 0
+}
+
+func (m *GetSchedulerBacklogResponse) GetClassBBeaconPeriods() []*BeaconPeriodBacklog {
+	if m != nil {
+		return m.ClassBBeaconPeriods
+	}
+	return nil
+}
+
+func (m *GetSchedulerBacklogResponse) GetMulticastDueItems() uint32 {
+	if m != nil {
+		return m.MulticastDueItems
+	}
+	return 0
+}
+
+func (m *GetSchedulerBacklogResponse) GetMulticastScheduledItems() uint32 {
+	if m != nil {
+		return m.MulticastScheduledItems
+	}
+	return 0
+}
+
+func (m *GetSchedulerBacklogResponse) GetTxAckPending() uint32 {
+	if m != nil {
+		return m.TxAckPending
+	}
+	return 0
+}
+
+type StartGatewayDrainRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartGatewayDrainRequest) Reset()         { *m = StartGatewayDrainRequest{} }
+func (m *StartGatewayDrainRequest) String() string { return proto.CompactTextString(m) }
+func (*StartGatewayDrainRequest) ProtoMessage()    {}
+func (*StartGatewayDrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{146}
+}
+
+func (m *StartGatewayDrainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartGatewayDrainRequest.Unmarshal(m, b)
+}
+func (m *StartGatewayDrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartGatewayDrainRequest.Marshal(b, m, deterministic)
+}
+func (m *StartGatewayDrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartGatewayDrainRequest.Merge(m, src)
+}
+func (m *StartGatewayDrainRequest) XXX_Size() int {
+	return xxx_messageInfo_StartGatewayDrainRequest.Size(m)
+}
+func (m *StartGatewayDrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartGatewayDrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartGatewayDrainRequest proto.InternalMessageInfo
+
+func (m *StartGatewayDrainRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type StopGatewayDrainRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopGatewayDrainRequest) Reset()         { *m = StopGatewayDrainRequest{} }
+func (m *StopGatewayDrainRequest) String() string { return proto.CompactTextString(m) }
+func (*StopGatewayDrainRequest) ProtoMessage()    {}
+func (*StopGatewayDrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{147}
+}
+
+func (m *StopGatewayDrainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopGatewayDrainRequest.Unmarshal(m, b)
+}
+func (m *StopGatewayDrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopGatewayDrainRequest.Marshal(b, m, deterministic)
+}
+func (m *StopGatewayDrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopGatewayDrainRequest.Merge(m, src)
+}
+func (m *StopGatewayDrainRequest) XXX_Size() int {
+	return xxx_messageInfo_StopGatewayDrainRequest.Size(m)
+}
+func (m *StopGatewayDrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopGatewayDrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopGatewayDrainRequest proto.InternalMessageInfo
+
+func (m *StopGatewayDrainRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayDrainStatusRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayDrainStatusRequest) Reset()         { *m = GetGatewayDrainStatusRequest{} }
+func (m *GetGatewayDrainStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDrainStatusRequest) ProtoMessage()    {}
+func (*GetGatewayDrainStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{148}
+}
+
+func (m *GetGatewayDrainStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDrainStatusRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayDrainStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDrainStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayDrainStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDrainStatusRequest.Merge(m, src)
+}
+func (m *GetGatewayDrainStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDrainStatusRequest.Size(m)
+}
+func (m *GetGatewayDrainStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDrainStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDrainStatusRequest proto.InternalMessageInfo
+
+func (m *GetGatewayDrainStatusRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayDrainStatusResponse struct {
+	// The gateway is in drain mode.
+	Draining bool `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	// Timestamp at which the drain mode was started.
+	StartedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Number of downlink frames sent to the gateway, awaiting a TX
+	// acknowledgement.
+	TxAckPending uint32 `protobuf:"varint,3,opt,name=tx_ack_pending,json=txAckPending,proto3" json:"tx_ack_pending,omitempty"`
+	// Number of multicast-queue items scheduled for the gateway.
+	MulticastQueueItems uint32 `protobuf:"varint,4,opt,name=multicast_queue_items,json=multicastQueueItems,proto3" json:"multicast_queue_items,omitempty"`
+	// The gateway is draining and has no outstanding downlinks.
+	SafeToPowerOff       bool     `protobuf:"varint,5,opt,name=safe_to_power_off,json=safeToPowerOff,proto3" json:"safe_to_power_off,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayDrainStatusResponse) Reset()         { *m = GetGatewayDrainStatusResponse{} }
+func (m *GetGatewayDrainStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDrainStatusResponse) ProtoMessage()    {}
+func (*GetGatewayDrainStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{149}
+}
+
+func (m *GetGatewayDrainStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDrainStatusResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayDrainStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDrainStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayDrainStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDrainStatusResponse.Merge(m, src)
+}
+func (m *GetGatewayDrainStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDrainStatusResponse.Size(m)
+}
+func (m *GetGatewayDrainStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDrainStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDrainStatusResponse proto.InternalMessageInfo
+
+func (m *GetGatewayDrainStatusResponse) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *GetGatewayDrainStatusResponse) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *GetGatewayDrainStatusResponse) GetTxAckPending() uint32 {
+	if m != nil {
+		return m.TxAckPending
+	}
+	return 0
+}
+
+func (m *GetGatewayDrainStatusResponse) GetMulticastQueueItems() uint32 {
+	if m != nil {
+		return m.MulticastQueueItems
+	}
+	return 0
+}
+
+func (m *GetGatewayDrainStatusResponse) GetSafeToPowerOff() bool {
+	if m != nil {
+		return m.SafeToPowerOff
+	}
+	return false
+}
+
+type GatewayInventory struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Gateway model.
+	Model string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// Gateway firmware version.
+	FirmwareVersion string `protobuf:"bytes,3,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	// Gateway bridge (packet-forwarder / station) version.
+	BridgeVersion string `protobuf:"bytes,4,opt,name=bridge_version,json=bridgeVersion,proto3" json:"bridge_version,omitempty"`
+	// Concentrator (HAL) version.
+	ConcentratorVersion string `protobuf:"bytes,5,opt,name=concentrator_version,json=concentratorVersion,proto3" json:"concentrator_version,omitempty"`
+	// Last time the inventory changed.
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The gateway is not used for GPS time-synchronized (Class-B) downlinks
+	// (scheduling constraint of the model).
+	NoGpsTiming bool `protobuf:"varint,7,opt,name=no_gps_timing,json=noGpsTiming,proto3" json:"no_gps_timing,omitempty"`
+	// Max. number of downlinks awaiting a TX acknowledgement, 0 = no limit
+	// (scheduling constraint of the model).
+	MaxDownlinkQueueDepth uint32   `protobuf:"varint,8,opt,name=max_downlink_queue_depth,json=maxDownlinkQueueDepth,proto3" json:"max_downlink_queue_depth,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *GatewayInventory) Reset()         { *m = GatewayInventory{} }
+func (m *GatewayInventory) String() string { return proto.CompactTextString(m) }
+func (*GatewayInventory) ProtoMessage()    {}
+func (*GatewayInventory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{150}
+}
+
+func (m *GatewayInventory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayInventory.Unmarshal(m, b)
+}
+func (m *GatewayInventory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayInventory.Marshal(b, m, deterministic)
+}
+func (m *GatewayInventory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayInventory.Merge(m, src)
+}
+func (m *GatewayInventory) XXX_Size() int {
+	return xxx_messageInfo_GatewayInventory.Size(m)
+}
+func (m *GatewayInventory) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayInventory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayInventory proto.InternalMessageInfo
+
+func (m *GatewayInventory) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayInventory) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *GatewayInventory) GetFirmwareVersion() string {
+	if m != nil {
+		return m.FirmwareVersion
+	}
+	return ""
+}
+
+func (m *GatewayInventory) GetBridgeVersion() string {
+	if m != nil {
+		return m.BridgeVersion
+	}
+	return ""
+}
+
+func (m *GatewayInventory) GetConcentratorVersion() string {
+	if m != nil {
+		return m.ConcentratorVersion
+	}
+	return ""
+}
+
+func (m *GatewayInventory) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *GatewayInventory) GetNoGpsTiming() bool {
+	if m != nil {
+		return m.NoGpsTiming
+	}
+	return false
+}
+
+func (m *GatewayInventory) GetMaxDownlinkQueueDepth() uint32 {
+	if m != nil {
+		return m.MaxDownlinkQueueDepth
+	}
+	return 0
+}
+
+type GetGatewayInventoryRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayInventoryRequest) Reset()         { *m = GetGatewayInventoryRequest{} }
+func (m *GetGatewayInventoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayInventoryRequest) ProtoMessage()    {}
+func (*GetGatewayInventoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{151}
+}
+
+func (m *GetGatewayInventoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayInventoryRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayInventoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayInventoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayInventoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayInventoryRequest.Merge(m, src)
+}
+func (m *GetGatewayInventoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayInventoryRequest.Size(m)
+}
+func (m *GetGatewayInventoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayInventoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayInventoryRequest proto.InternalMessageInfo
+
+func (m *GetGatewayInventoryRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayInventoryResponse struct {
+	// Gateway inventory.
+	Inventory            *GatewayInventory `protobuf:"bytes,1,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetGatewayInventoryResponse) Reset()         { *m = GetGatewayInventoryResponse{} }
+func (m *GetGatewayInventoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayInventoryResponse) ProtoMessage()    {}
+func (*GetGatewayInventoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{152}
+}
+
+func (m *GetGatewayInventoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayInventoryResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayInventoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayInventoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayInventoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayInventoryResponse.Merge(m, src)
+}
+func (m *GetGatewayInventoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayInventoryResponse.Size(m)
+}
+func (m *GetGatewayInventoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayInventoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayInventoryResponse proto.InternalMessageInfo
+
+func (m *GetGatewayInventoryResponse) GetInventory() *GatewayInventory {
+	if m != nil {
+		return m.Inventory
+	}
+	return nil
+}
+
+type ListGatewayInventoryRequest struct {
+	// Max number of items to return.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only return the gateways of the given model (optional).
+	Model                string   `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGatewayInventoryRequest) Reset()         { *m = ListGatewayInventoryRequest{} }
+func (m *ListGatewayInventoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayInventoryRequest) ProtoMessage()    {}
+func (*ListGatewayInventoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{153}
+}
+
+func (m *ListGatewayInventoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayInventoryRequest.Unmarshal(m, b)
+}
+func (m *ListGatewayInventoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayInventoryRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayInventoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayInventoryRequest.Merge(m, src)
+}
+func (m *ListGatewayInventoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayInventoryRequest.Size(m)
+}
+func (m *ListGatewayInventoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayInventoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayInventoryRequest proto.InternalMessageInfo
+
+func (m *ListGatewayInventoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewayInventoryRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListGatewayInventoryRequest) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+type ListGatewayInventoryResponse struct {
+	// Total number of gateway inventories.
+	TotalCount uint32 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Gateway inventories.
+	Result               []*GatewayInventory `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListGatewayInventoryResponse) Reset()         { *m = ListGatewayInventoryResponse{} }
+func (m *ListGatewayInventoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayInventoryResponse) ProtoMessage()    {}
+func (*ListGatewayInventoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{154}
+}
+
+func (m *ListGatewayInventoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayInventoryResponse.Unmarshal(m, b)
+}
+func (m *ListGatewayInventoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayInventoryResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayInventoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayInventoryResponse.Merge(m, src)
+}
+func (m *ListGatewayInventoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayInventoryResponse.Size(m)
+}
+func (m *ListGatewayInventoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayInventoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayInventoryResponse proto.InternalMessageInfo
+
+func (m *ListGatewayInventoryResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewayInventoryResponse) GetResult() []*GatewayInventory {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GatewayConnectionState struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// The gateway is online.
+	Online bool `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	// Last time the connection state changed.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GatewayConnectionState) Reset()         { *m = GatewayConnectionState{} }
+func (m *GatewayConnectionState) String() string { return proto.CompactTextString(m) }
+func (*GatewayConnectionState) ProtoMessage()    {}
+func (*GatewayConnectionState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{155}
+}
+
+func (m *GatewayConnectionState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayConnectionState.Unmarshal(m, b)
+}
+func (m *GatewayConnectionState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayConnectionState.Marshal(b, m, deterministic)
+}
+func (m *GatewayConnectionState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayConnectionState.Merge(m, src)
+}
+func (m *GatewayConnectionState) XXX_Size() int {
+	return xxx_messageInfo_GatewayConnectionState.Size(m)
+}
+func (m *GatewayConnectionState) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayConnectionState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayConnectionState proto.InternalMessageInfo
+
+func (m *GatewayConnectionState) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayConnectionState) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *GatewayConnectionState) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetGatewayConnectionStateRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayConnectionStateRequest) Reset()         { *m = GetGatewayConnectionStateRequest{} }
+func (m *GetGatewayConnectionStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayConnectionStateRequest) ProtoMessage()    {}
+func (*GetGatewayConnectionStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{156}
+}
+
+func (m *GetGatewayConnectionStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConnectionStateRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayConnectionStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConnectionStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConnectionStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConnectionStateRequest.Merge(m, src)
+}
+func (m *GetGatewayConnectionStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConnectionStateRequest.Size(m)
+}
+func (m *GetGatewayConnectionStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConnectionStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConnectionStateRequest proto.InternalMessageInfo
+
+func (m *GetGatewayConnectionStateRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayConnectionStateResponse struct {
+	// Gateway connection state.
+	State                *GatewayConnectionState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetGatewayConnectionStateResponse) Reset()         { *m = GetGatewayConnectionStateResponse{} }
+func (m *GetGatewayConnectionStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayConnectionStateResponse) ProtoMessage()    {}
+func (*GetGatewayConnectionStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{157}
+}
+
+func (m *GetGatewayConnectionStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayConnectionStateResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayConnectionStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayConnectionStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayConnectionStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayConnectionStateResponse.Merge(m, src)
+}
+func (m *GetGatewayConnectionStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayConnectionStateResponse.Size(m)
+}
+func (m *GetGatewayConnectionStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayConnectionStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayConnectionStateResponse proto.InternalMessageInfo
+
+func (m *GetGatewayConnectionStateResponse) GetState() *GatewayConnectionState {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type ListGatewayConnectionStatesRequest struct {
+	// Max number of items to return.
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only return the offline gateways.
+	OfflineOnly          bool     `protobuf:"varint,3,opt,name=offline_only,json=offlineOnly,proto3" json:"offline_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGatewayConnectionStatesRequest) Reset()         { *m = ListGatewayConnectionStatesRequest{} }
+func (m *ListGatewayConnectionStatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayConnectionStatesRequest) ProtoMessage()    {}
+func (*ListGatewayConnectionStatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{158}
+}
+
+func (m *ListGatewayConnectionStatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayConnectionStatesRequest.Unmarshal(m, b)
+}
+func (m *ListGatewayConnectionStatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayConnectionStatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayConnectionStatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayConnectionStatesRequest.Merge(m, src)
+}
+func (m *ListGatewayConnectionStatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayConnectionStatesRequest.Size(m)
+}
+func (m *ListGatewayConnectionStatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayConnectionStatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayConnectionStatesRequest proto.InternalMessageInfo
+
+func (m *ListGatewayConnectionStatesRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewayConnectionStatesRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListGatewayConnectionStatesRequest) GetOfflineOnly() bool {
+	if m != nil {
+		return m.OfflineOnly
+	}
+	return false
+}
+
+type ListGatewayConnectionStatesResponse struct {
+	// Total number of gateway connection states.
+	TotalCount uint32 `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Gateway connection states.
+	Result               []*GatewayConnectionState `protobuf:"bytes,2,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ListGatewayConnectionStatesResponse) Reset()         { *m = ListGatewayConnectionStatesResponse{} }
+func (m *ListGatewayConnectionStatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayConnectionStatesResponse) ProtoMessage()    {}
+func (*ListGatewayConnectionStatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{159}
+}
+
+func (m *ListGatewayConnectionStatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayConnectionStatesResponse.Unmarshal(m, b)
+}
+func (m *ListGatewayConnectionStatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayConnectionStatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayConnectionStatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayConnectionStatesResponse.Merge(m, src)
+}
+func (m *ListGatewayConnectionStatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayConnectionStatesResponse.Size(m)
+}
+func (m *ListGatewayConnectionStatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayConnectionStatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayConnectionStatesResponse proto.InternalMessageInfo
+
+func (m *ListGatewayConnectionStatesResponse) GetTotalCount() uint32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewayConnectionStatesResponse) GetResult() []*GatewayConnectionState {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ExecGatewayCommandRequest struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Command to execute.
+	// This command must be pre-configured in the LoRa Gateway Bridge configuration.
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	// Standard input.
+	Stdin []byte `protobuf:"bytes,3,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// Environment variables.
+	Environment map[string]string `protobuf:"bytes,4,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Max duration to wait for the command execution response (optional).
+	// When not set, the response must be retrieved using
+	// GetGatewayCommandExecResponse.
+	Timeout              *duration.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ExecGatewayCommandRequest) Reset()         { *m = ExecGatewayCommandRequest{} }
+func (m *ExecGatewayCommandRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecGatewayCommandRequest) ProtoMessage()    {}
+func (*ExecGatewayCommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{160}
+}
+
+func (m *ExecGatewayCommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Unmarshal(m, b)
+}
+func (m *ExecGatewayCommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecGatewayCommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecGatewayCommandRequest.Merge(m, src)
+}
+func (m *ExecGatewayCommandRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecGatewayCommandRequest.Size(m)
+}
+func (m *ExecGatewayCommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecGatewayCommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecGatewayCommandRequest proto.InternalMessageInfo
+
+func (m *ExecGatewayCommandRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *ExecGatewayCommandRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandRequest) GetEnvironment() map[string]string {
+	if m != nil {
+		return m.Environment
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandRequest) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+type ExecGatewayCommandResponse struct {
+	// Execution request ID (UUID).
+	ExecId []byte `protobuf:"bytes,1,opt,name=exec_id,json=execId,proto3" json:"exec_id,omitempty"`
+	// Command execution response.
+	// This is only set when the response was received within the timeout.
+	Response             *gw.GatewayCommandExecResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ExecGatewayCommandResponse) Reset()         { *m = ExecGatewayCommandResponse{} }
+func (m *ExecGatewayCommandResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecGatewayCommandResponse) ProtoMessage()    {}
+func (*ExecGatewayCommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{161}
+}
+
+func (m *ExecGatewayCommandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Unmarshal(m, b)
+}
+func (m *ExecGatewayCommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecGatewayCommandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecGatewayCommandResponse.Merge(m, src)
+}
+func (m *ExecGatewayCommandResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecGatewayCommandResponse.Size(m)
+}
+func (m *ExecGatewayCommandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecGatewayCommandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecGatewayCommandResponse proto.InternalMessageInfo
+
+func (m *ExecGatewayCommandResponse) GetExecId() []byte {
+	if m != nil {
+		return m.ExecId
+	}
+	return nil
+}
+
+func (m *ExecGatewayCommandResponse) GetResponse() *gw.GatewayCommandExecResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type GetGatewayCommandExecResponseRequest struct {
+	// Execution request ID (UUID).
+	ExecId []byte `protobuf:"bytes,1,opt,name=exec_id,json=execId,proto3" json:"exec_id,omitempty"`
+	// Max duration to wait for the command execution response (optional).
+	Timeout              *duration.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetGatewayCommandExecResponseRequest) Reset()         { *m = GetGatewayCommandExecResponseRequest{} }
+func (m *GetGatewayCommandExecResponseRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayCommandExecResponseRequest) ProtoMessage()    {}
+func (*GetGatewayCommandExecResponseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{162}
+}
+
+func (m *GetGatewayCommandExecResponseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayCommandExecResponseRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayCommandExecResponseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayCommandExecResponseRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayCommandExecResponseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayCommandExecResponseRequest.Merge(m, src)
+}
+func (m *GetGatewayCommandExecResponseRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayCommandExecResponseRequest.Size(m)
+}
+func (m *GetGatewayCommandExecResponseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayCommandExecResponseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayCommandExecResponseRequest proto.InternalMessageInfo
+
+func (m *GetGatewayCommandExecResponseRequest) GetExecId() []byte {
+	if m != nil {
+		return m.ExecId
+	}
+	return nil
+}
+
+func (m *GetGatewayCommandExecResponseRequest) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+type GetGatewayCommandExecResponseResponse struct {
+	// Command execution response.
+	Response             *gw.GatewayCommandExecResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *GetGatewayCommandExecResponseResponse) Reset()         { *m = GetGatewayCommandExecResponseResponse{} }
+func (m *GetGatewayCommandExecResponseResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayCommandExecResponseResponse) ProtoMessage()    {}
+func (*GetGatewayCommandExecResponseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{163}
+}
+
+func (m *GetGatewayCommandExecResponseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayCommandExecResponseResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayCommandExecResponseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayCommandExecResponseResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayCommandExecResponseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayCommandExecResponseResponse.Merge(m, src)
+}
+func (m *GetGatewayCommandExecResponseResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayCommandExecResponseResponse.Size(m)
+}
+func (m *GetGatewayCommandExecResponseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayCommandExecResponseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayCommandExecResponseResponse proto.InternalMessageInfo
+
+func (m *GetGatewayCommandExecResponseResponse) GetResponse() *gw.GatewayCommandExecResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type DownlinkTXPending struct {
+	// Downlink token.
+	Token uint32 `protobuf:"varint,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Downlink ID (UUID).
+	DownlinkId []byte `protobuf:"bytes,2,opt,name=downlink_id,json=downlinkId,proto3" json:"downlink_id,omitempty"`
+	// Time when the downlink was sent to the gateway.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// TX meta-data.
+	TxInfo               *gw.DownlinkTXInfo `protobuf:"bytes,4,opt,name=tx_info,json=txInfo,proto3" json:"tx_info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DownlinkTXPending) Reset()         { *m = DownlinkTXPending{} }
+func (m *DownlinkTXPending) String() string { return proto.CompactTextString(m) }
+func (*DownlinkTXPending) ProtoMessage()    {}
+func (*DownlinkTXPending) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{164}
+}
+
+func (m *DownlinkTXPending) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownlinkTXPending.Unmarshal(m, b)
+}
+func (m *DownlinkTXPending) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownlinkTXPending.Marshal(b, m, deterministic)
+}
+func (m *DownlinkTXPending) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownlinkTXPending.Merge(m, src)
+}
+func (m *DownlinkTXPending) XXX_Size() int {
+	return xxx_messageInfo_DownlinkTXPending.Size(m)
+}
+func (m *DownlinkTXPending) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownlinkTXPending.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownlinkTXPending proto.InternalMessageInfo
+
+func (m *DownlinkTXPending) GetToken() uint32 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *DownlinkTXPending) GetDownlinkId() []byte {
+	if m != nil {
+		return m.DownlinkId
+	}
+	return nil
+}
+
+func (m *DownlinkTXPending) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *DownlinkTXPending) GetTxInfo() *gw.DownlinkTXInfo {
+	if m != nil {
+		return m.TxInfo
+	}
+	return nil
+}
+
+type GetGatewayDownlinkTXStateRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayDownlinkTXStateRequest) Reset()         { *m = GetGatewayDownlinkTXStateRequest{} }
+func (m *GetGatewayDownlinkTXStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDownlinkTXStateRequest) ProtoMessage()    {}
+func (*GetGatewayDownlinkTXStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{165}
+}
+
+func (m *GetGatewayDownlinkTXStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayDownlinkTXStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayDownlinkTXStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDownlinkTXStateRequest.Merge(m, src)
+}
+func (m *GetGatewayDownlinkTXStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateRequest.Size(m)
+}
+func (m *GetGatewayDownlinkTXStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDownlinkTXStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDownlinkTXStateRequest proto.InternalMessageInfo
+
+func (m *GetGatewayDownlinkTXStateRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayDownlinkTXStateResponse struct {
+	// Number of downlinks pending a TX acknowledgement.
+	QueueDepth uint32 `protobuf:"varint,1,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	// Downlinks pending a TX acknowledgement, ordered by created at.
+	Pending              []*DownlinkTXPending `protobuf:"bytes,2,rep,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetGatewayDownlinkTXStateResponse) Reset()         { *m = GetGatewayDownlinkTXStateResponse{} }
+func (m *GetGatewayDownlinkTXStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayDownlinkTXStateResponse) ProtoMessage()    {}
+func (*GetGatewayDownlinkTXStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{166}
+}
+
+func (m *GetGatewayDownlinkTXStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayDownlinkTXStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayDownlinkTXStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayDownlinkTXStateResponse.Merge(m, src)
+}
+func (m *GetGatewayDownlinkTXStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayDownlinkTXStateResponse.Size(m)
+}
+func (m *GetGatewayDownlinkTXStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayDownlinkTXStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayDownlinkTXStateResponse proto.InternalMessageInfo
+
+func (m *GetGatewayDownlinkTXStateResponse) GetQueueDepth() uint32 {
+	if m != nil {
+		return m.QueueDepth
+	}
+	return 0
+}
+
+func (m *GetGatewayDownlinkTXStateResponse) GetPending() []*DownlinkTXPending {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
+type GatewayDownlinkQueueDepth struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Number of downlinks pending a TX acknowledgement.
+	QueueDepth           uint32   `protobuf:"varint,2,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GatewayDownlinkQueueDepth) Reset()         { *m = GatewayDownlinkQueueDepth{} }
+func (m *GatewayDownlinkQueueDepth) String() string { return proto.CompactTextString(m) }
+func (*GatewayDownlinkQueueDepth) ProtoMessage()    {}
+func (*GatewayDownlinkQueueDepth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{167}
+}
+
+func (m *GatewayDownlinkQueueDepth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayDownlinkQueueDepth.Unmarshal(m, b)
+}
+func (m *GatewayDownlinkQueueDepth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayDownlinkQueueDepth.Marshal(b, m, deterministic)
+}
+func (m *GatewayDownlinkQueueDepth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayDownlinkQueueDepth.Merge(m, src)
+}
+func (m *GatewayDownlinkQueueDepth) XXX_Size() int {
+	return xxx_messageInfo_GatewayDownlinkQueueDepth.Size(m)
+}
+func (m *GatewayDownlinkQueueDepth) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayDownlinkQueueDepth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayDownlinkQueueDepth proto.InternalMessageInfo
+
+func (m *GatewayDownlinkQueueDepth) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GatewayDownlinkQueueDepth) GetQueueDepth() uint32 {
+	if m != nil {
+		return m.QueueDepth
+	}
+	return 0
+}
+
+type ListGatewayDownlinkQueueDepthsRequest struct {
+	// Max number of items to return (optional).
+	Limit                uint32   `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGatewayDownlinkQueueDepthsRequest) Reset()         { *m = ListGatewayDownlinkQueueDepthsRequest{} }
+func (m *ListGatewayDownlinkQueueDepthsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayDownlinkQueueDepthsRequest) ProtoMessage()    {}
+func (*ListGatewayDownlinkQueueDepthsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{168}
+}
+
+func (m *ListGatewayDownlinkQueueDepthsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest.Unmarshal(m, b)
+}
+func (m *ListGatewayDownlinkQueueDepthsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayDownlinkQueueDepthsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest.Merge(m, src)
+}
+func (m *ListGatewayDownlinkQueueDepthsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest.Size(m)
+}
+func (m *ListGatewayDownlinkQueueDepthsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayDownlinkQueueDepthsRequest proto.InternalMessageInfo
+
+func (m *ListGatewayDownlinkQueueDepthsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListGatewayDownlinkQueueDepthsResponse struct {
+	// Gateway downlink queue depths.
+	Result               []*GatewayDownlinkQueueDepth `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ListGatewayDownlinkQueueDepthsResponse) Reset() {
+	*m = ListGatewayDownlinkQueueDepthsResponse{}
+}
+func (m *ListGatewayDownlinkQueueDepthsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayDownlinkQueueDepthsResponse) ProtoMessage()    {}
+func (*ListGatewayDownlinkQueueDepthsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{169}
+}
+
+func (m *ListGatewayDownlinkQueueDepthsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse.Unmarshal(m, b)
+}
+func (m *ListGatewayDownlinkQueueDepthsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGatewayDownlinkQueueDepthsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse.Merge(m, src)
+}
+func (m *ListGatewayDownlinkQueueDepthsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse.Size(m)
+}
+func (m *ListGatewayDownlinkQueueDepthsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGatewayDownlinkQueueDepthsResponse proto.InternalMessageInfo
+
+func (m *ListGatewayDownlinkQueueDepthsResponse) GetResult() []*GatewayDownlinkQueueDepth {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GatewayStatsAggregate struct {
+	// Start of the aggregation interval.
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Number of radio packets received.
+	RxPacketsReceived uint32 `protobuf:"varint,2,opt,name=rx_packets_received,json=rxPacketsReceived,proto3" json:"rx_packets_received,omitempty"`
+	// Number of radio packets received with valid PHY CRC.
+	RxPacketsReceivedOk uint32 `protobuf:"varint,3,opt,name=rx_packets_received_ok,json=rxPacketsReceivedOk,proto3" json:"rx_packets_received_ok,omitempty"`
+	// Number of downlink packets received for transmission.
+	TxPacketsReceived uint32 `protobuf:"varint,4,opt,name=tx_packets_received,json=txPacketsReceived,proto3" json:"tx_packets_received,omitempty"`
+	// Number of downlink packets emitted.
+	TxPacketsEmitted uint32 `protobuf:"varint,5,opt,name=tx_packets_emitted,json=txPacketsEmitted,proto3" json:"tx_packets_emitted,omitempty"`
+	// Number of radio packets received per frequency (Hz).
+	RxPacketsPerFrequency map[uint32]uint32 `protobuf:"bytes,6,rep,name=rx_packets_per_frequency,json=rxPacketsPerFrequency,proto3" json:"rx_packets_per_frequency,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of downlink packets emitted per frequency (Hz).
+	TxPacketsPerFrequency map[uint32]uint32 `protobuf:"bytes,7,rep,name=tx_packets_per_frequency,json=txPacketsPerFrequency,proto3" json:"tx_packets_per_frequency,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of downlink packets per TX acknowledgement status.
+	TxPacketsPerStatus   map[string]uint32 `protobuf:"bytes,8,rep,name=tx_packets_per_status,json=txPacketsPerStatus,proto3" json:"tx_packets_per_status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GatewayStatsAggregate) Reset()         { *m = GatewayStatsAggregate{} }
+func (m *GatewayStatsAggregate) String() string { return proto.CompactTextString(m) }
+func (*GatewayStatsAggregate) ProtoMessage()    {}
+func (*GatewayStatsAggregate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{170}
+}
+
+func (m *GatewayStatsAggregate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayStatsAggregate.Unmarshal(m, b)
+}
+func (m *GatewayStatsAggregate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayStatsAggregate.Marshal(b, m, deterministic)
+}
+func (m *GatewayStatsAggregate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayStatsAggregate.Merge(m, src)
+}
+func (m *GatewayStatsAggregate) XXX_Size() int {
+	return xxx_messageInfo_GatewayStatsAggregate.Size(m)
+}
+func (m *GatewayStatsAggregate) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayStatsAggregate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayStatsAggregate proto.InternalMessageInfo
+
+func (m *GatewayStatsAggregate) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *GatewayStatsAggregate) GetRxPacketsReceived() uint32 {
+	if m != nil {
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStatsAggregate) GetRxPacketsReceivedOk() uint32 {
+	if m != nil {
+		return m.RxPacketsReceivedOk
+	}
+	return 0
+}
+
+func (m *GatewayStatsAggregate) GetTxPacketsReceived() uint32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStatsAggregate) GetTxPacketsEmitted() uint32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+func (m *GatewayStatsAggregate) GetRxPacketsPerFrequency() map[uint32]uint32 {
+	if m != nil {
+		return m.RxPacketsPerFrequency
+	}
+	return nil
+}
+
+func (m *GatewayStatsAggregate) GetTxPacketsPerFrequency() map[uint32]uint32 {
+	if m != nil {
+		return m.TxPacketsPerFrequency
+	}
+	return nil
+}
+
+func (m *GatewayStatsAggregate) GetTxPacketsPerStatus() map[string]uint32 {
+	if m != nil {
+		return m.TxPacketsPerStatus
+	}
+	return nil
+}
+
+type GetGatewayStatsAggregatesRequest struct {
+	// Gateway ID.
+	GatewayId []byte `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// Aggregation interval (must match one of the configured intervals).
+	Interval *duration.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_timestamp,json=startTimestamp,proto3" json:"start_timestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_timestamp,json=endTimestamp,proto3" json:"end_timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetGatewayStatsAggregatesRequest) Reset()         { *m = GetGatewayStatsAggregatesRequest{} }
+func (m *GetGatewayStatsAggregatesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayStatsAggregatesRequest) ProtoMessage()    {}
+func (*GetGatewayStatsAggregatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{171}
+}
+
+func (m *GetGatewayStatsAggregatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayStatsAggregatesRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayStatsAggregatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayStatsAggregatesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayStatsAggregatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayStatsAggregatesRequest.Merge(m, src)
+}
+func (m *GetGatewayStatsAggregatesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayStatsAggregatesRequest.Size(m)
+}
+func (m *GetGatewayStatsAggregatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayStatsAggregatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayStatsAggregatesRequest proto.InternalMessageInfo
+
+func (m *GetGatewayStatsAggregatesRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsAggregatesRequest) GetInterval() *duration.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsAggregatesRequest) GetStartTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsAggregatesRequest) GetEndTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return nil
+}
+
+type GetGatewayStatsAggregatesResponse struct {
+	// Aggregated gateway stats, ordered by timestamp.
+	Result               []*GatewayStatsAggregate `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetGatewayStatsAggregatesResponse) Reset()         { *m = GetGatewayStatsAggregatesResponse{} }
+func (m *GetGatewayStatsAggregatesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayStatsAggregatesResponse) ProtoMessage()    {}
+func (*GetGatewayStatsAggregatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{172}
+}
+
+func (m *GetGatewayStatsAggregatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayStatsAggregatesResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayStatsAggregatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayStatsAggregatesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayStatsAggregatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayStatsAggregatesResponse.Merge(m, src)
+}
+func (m *GetGatewayStatsAggregatesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayStatsAggregatesResponse.Size(m)
+}
+func (m *GetGatewayStatsAggregatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayStatsAggregatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayStatsAggregatesResponse proto.InternalMessageInfo
+
+func (m *GetGatewayStatsAggregatesResponse) GetResult() []*GatewayStatsAggregate {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GatewayChannelNoiseFloor struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Bandwidth (Hz).
+	Bandwidth uint32 `protobuf:"varint,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// Noise-floor (dBm).
+	NoiseFloor float64 `protobuf:"fixed64,3,opt,name=noise_floor,json=noiseFloor,proto3" json:"noise_floor,omitempty"`
+	// Channel utilization (0 - 1).
+	ChannelUtilization float64 `protobuf:"fixed64,4,opt,name=channel_utilization,json=channelUtilization,proto3" json:"channel_utilization,omitempty"`
+	// RSSI histogram of the spectral-scan (dBm => number of samples).
+	RssiHistogram map[int32]uint32 `protobuf:"bytes,5,rep,name=rssi_histogram,json=rssiHistogram,proto3" json:"rssi_histogram,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Time of the report.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GatewayChannelNoiseFloor) Reset()         { *m = GatewayChannelNoiseFloor{} }
+func (m *GatewayChannelNoiseFloor) String() string { return proto.CompactTextString(m) }
+func (*GatewayChannelNoiseFloor) ProtoMessage()    {}
+func (*GatewayChannelNoiseFloor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{173}
+}
+
+func (m *GatewayChannelNoiseFloor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewayChannelNoiseFloor.Unmarshal(m, b)
+}
+func (m *GatewayChannelNoiseFloor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewayChannelNoiseFloor.Marshal(b, m, deterministic)
+}
+func (m *GatewayChannelNoiseFloor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewayChannelNoiseFloor.Merge(m, src)
+}
+func (m *GatewayChannelNoiseFloor) XXX_Size() int {
+	return xxx_messageInfo_GatewayChannelNoiseFloor.Size(m)
+}
+func (m *GatewayChannelNoiseFloor) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewayChannelNoiseFloor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewayChannelNoiseFloor proto.InternalMessageInfo
+
+func (m *GatewayChannelNoiseFloor) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *GatewayChannelNoiseFloor) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *GatewayChannelNoiseFloor) GetNoiseFloor() float64 {
+	if m != nil {
+		return m.NoiseFloor
+	}
+	return 0
+}
+
+func (m *GatewayChannelNoiseFloor) GetChannelUtilization() float64 {
+	if m != nil {
+		return m.ChannelUtilization
+	}
+	return 0
+}
+
+func (m *GatewayChannelNoiseFloor) GetRssiHistogram() map[int32]uint32 {
+	if m != nil {
+		return m.RssiHistogram
+	}
+	return nil
+}
+
+func (m *GatewayChannelNoiseFloor) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type GetGatewayNoiseFloorRequest struct {
+	// Gateway ID.
+	GatewayId            []byte   `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGatewayNoiseFloorRequest) Reset()         { *m = GetGatewayNoiseFloorRequest{} }
+func (m *GetGatewayNoiseFloorRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayNoiseFloorRequest) ProtoMessage()    {}
+func (*GetGatewayNoiseFloorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{174}
+}
+
+func (m *GetGatewayNoiseFloorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayNoiseFloorRequest.Unmarshal(m, b)
+}
+func (m *GetGatewayNoiseFloorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayNoiseFloorRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayNoiseFloorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayNoiseFloorRequest.Merge(m, src)
+}
+func (m *GetGatewayNoiseFloorRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayNoiseFloorRequest.Size(m)
+}
+func (m *GetGatewayNoiseFloorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayNoiseFloorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayNoiseFloorRequest proto.InternalMessageInfo
+
+func (m *GetGatewayNoiseFloorRequest) GetGatewayId() []byte {
+	if m != nil {
+		return m.GatewayId
+	}
+	return nil
+}
+
+type GetGatewayNoiseFloorResponse struct {
+	// Noise-floor per channel, ordered by frequency.
+	Result               []*GatewayChannelNoiseFloor `protobuf:"bytes,1,rep,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetGatewayNoiseFloorResponse) Reset()         { *m = GetGatewayNoiseFloorResponse{} }
+func (m *GetGatewayNoiseFloorResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayNoiseFloorResponse) ProtoMessage()    {}
+func (*GetGatewayNoiseFloorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b280de855f92a4a, []int{175}
+}
+
+func (m *GetGatewayNoiseFloorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGatewayNoiseFloorResponse.Unmarshal(m, b)
+}
+func (m *GetGatewayNoiseFloorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGatewayNoiseFloorResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGatewayNoiseFloorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGatewayNoiseFloorResponse.Merge(m, src)
+}
+func (m *GetGatewayNoiseFloorResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGatewayNoiseFloorResponse.Size(m)
+}
+func (m *GetGatewayNoiseFloorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGatewayNoiseFloorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGatewayNoiseFloorResponse proto.InternalMessageInfo
+
+func (m *GetGatewayNoiseFloorResponse) GetResult() []*GatewayChannelNoiseFloor {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("ns.SecuritySeverity", SecuritySeverity_name, SecuritySeverity_value)
+	proto.RegisterEnum("ns.GatewayContributionType", GatewayContributionType_name, GatewayContributionType_value)
+	proto.RegisterEnum("ns.GatewayConfigurationState", GatewayConfigurationState_name, GatewayConfigurationState_value)
+	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("ns.DeviceClassHealthStatus", DeviceClassHealthStatus_name, DeviceClassHealthStatus_value)
+	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+	proto.RegisterEnum("ns.MType", MType_name, MType_value)
+	proto.RegisterEnum("ns.FrameLogCaptureFormat", FrameLogCaptureFormat_name, FrameLogCaptureFormat_value)
+	proto.RegisterEnum("ns.MulticastGroupType", MulticastGroupType_name, MulticastGroupType_value)
+	proto.RegisterEnum("ns.MulticastTXState", MulticastTXState_name, MulticastTXState_value)
+	proto.RegisterEnum("ns.MulticastSetupState", MulticastSetupState_name, MulticastSetupState_value)
+	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
+	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
+	proto.RegisterType((*GetServiceProfileRequest)(nil), "ns.GetServiceProfileRequest")
+	proto.RegisterType((*GetServiceProfileResponse)(nil), "ns.GetServiceProfileResponse")
+	proto.RegisterType((*UpdateServiceProfileRequest)(nil), "ns.UpdateServiceProfileRequest")
+	proto.RegisterType((*DeleteServiceProfileRequest)(nil), "ns.DeleteServiceProfileRequest")
+	proto.RegisterType((*CreateRoutingProfileRequest)(nil), "ns.CreateRoutingProfileRequest")
+	proto.RegisterType((*CreateRoutingProfileResponse)(nil), "ns.CreateRoutingProfileResponse")
+	proto.RegisterType((*GetRoutingProfileRequest)(nil), "ns.GetRoutingProfileRequest")
+	proto.RegisterType((*GetRoutingProfileResponse)(nil), "ns.GetRoutingProfileResponse")
+	proto.RegisterType((*UpdateRoutingProfileRequest)(nil), "ns.UpdateRoutingProfileRequest")
+	proto.RegisterType((*DeleteRoutingProfileRequest)(nil), "ns.DeleteRoutingProfileRequest")
+	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
+	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
+	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
+	proto.RegisterType((*GetDeviceProfileResponse)(nil), "ns.GetDeviceProfileResponse")
+	proto.RegisterType((*UpdateDeviceProfileRequest)(nil), "ns.UpdateDeviceProfileRequest")
+	proto.RegisterType((*DeleteDeviceProfileRequest)(nil), "ns.DeleteDeviceProfileRequest")
+	proto.RegisterType((*Device)(nil), "ns.Device")
+	proto.RegisterType((*CreateDeviceRequest)(nil), "ns.CreateDeviceRequest")
+	proto.RegisterType((*GetDeviceRequest)(nil), "ns.GetDeviceRequest")
+	proto.RegisterType((*GetDeviceResponse)(nil), "ns.GetDeviceResponse")
+	proto.RegisterType((*UpdateDeviceRequest)(nil), "ns.UpdateDeviceRequest")
+	proto.RegisterType((*TransferDeviceRequest)(nil), "ns.TransferDeviceRequest")
+	proto.RegisterType((*DeleteDeviceRequest)(nil), "ns.DeleteDeviceRequest")
+	proto.RegisterType((*DeviceActivation)(nil), "ns.DeviceActivation")
+	proto.RegisterType((*ActivateDeviceRequest)(nil), "ns.ActivateDeviceRequest")
+	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
+	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
+	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*GetDeviceClassHealthRequest)(nil), "ns.GetDeviceClassHealthRequest")
+	proto.RegisterType((*GetDeviceClassHealthResponse)(nil), "ns.GetDeviceClassHealthResponse")
+	proto.RegisterType((*ProvisionABPDeviceRequest)(nil), "ns.ProvisionABPDeviceRequest")
+	proto.RegisterType((*ProvisionABPDeviceResponse)(nil), "ns.ProvisionABPDeviceResponse")
+	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
+	proto.RegisterType((*CreateMACCommandQueueItemRequest)(nil), "ns.CreateMACCommandQueueItemRequest")
+	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
+	proto.RegisterType((*Gateway)(nil), "ns.Gateway")
+	proto.RegisterType((*GatewayBoard)(nil), "ns.GatewayBoard")
+	proto.RegisterType((*GatewayAntenna)(nil), "ns.GatewayAntenna")
+	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
+	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
+	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
+	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
+	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
+	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
+	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
+	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
+	proto.RegisterType((*DeviceQueueItem)(nil), "ns.DeviceQueueItem")
+	proto.RegisterType((*CreateDeviceQueueItemRequest)(nil), "ns.CreateDeviceQueueItemRequest")
+	proto.RegisterType((*FlushDeviceQueueForDevEUIRequest)(nil), "ns.FlushDeviceQueueForDevEUIRequest")
+	proto.RegisterType((*GetDeviceQueueItemsForDevEUIRequest)(nil), "ns.GetDeviceQueueItemsForDevEUIRequest")
+	proto.RegisterType((*GetDeviceQueueItemsForDevEUIResponse)(nil), "ns.GetDeviceQueueItemsForDevEUIResponse")
+	proto.RegisterType((*GetNextDownlinkFCntForDevEUIRequest)(nil), "ns.GetNextDownlinkFCntForDevEUIRequest")
+	proto.RegisterType((*GetNextDownlinkFCntForDevEUIResponse)(nil), "ns.GetNextDownlinkFCntForDevEUIResponse")
+	proto.RegisterType((*FrameLogFilter)(nil), "ns.FrameLogFilter")
+	proto.RegisterType((*StreamFrameLogsForGatewayRequest)(nil), "ns.StreamFrameLogsForGatewayRequest")
+	proto.RegisterType((*StreamFrameLogsForGatewayResponse)(nil), "ns.StreamFrameLogsForGatewayResponse")
+	proto.RegisterType((*StreamFrameLogsForDeviceRequest)(nil), "ns.StreamFrameLogsForDeviceRequest")
+	proto.RegisterType((*StreamFrameLogsForDeviceResponse)(nil), "ns.StreamFrameLogsForDeviceResponse")
+	proto.RegisterType((*CreateFrameLogCaptureRequest)(nil), "ns.CreateFrameLogCaptureRequest")
+	proto.RegisterType((*CreateFrameLogCaptureResponse)(nil), "ns.CreateFrameLogCaptureResponse")
+	proto.RegisterType((*GetFrameLogCaptureRequest)(nil), "ns.GetFrameLogCaptureRequest")
+	proto.RegisterType((*GetFrameLogCaptureResponse)(nil), "ns.GetFrameLogCaptureResponse")
+	proto.RegisterType((*GetVersionResponse)(nil), "ns.GetVersionResponse")
+	proto.RegisterType((*GatewayProfile)(nil), "ns.GatewayProfile")
+	proto.RegisterType((*GatewayProfileReconfigurationWindow)(nil), "ns.GatewayProfileReconfigurationWindow")
+	proto.RegisterType((*GatewayProfileLBT)(nil), "ns.GatewayProfileLBT")
+	proto.RegisterType((*GatewayProfileExtraChannel)(nil), "ns.GatewayProfileExtraChannel")
+	proto.RegisterType((*CreateGatewayProfileRequest)(nil), "ns.CreateGatewayProfileRequest")
+	proto.RegisterType((*CreateGatewayProfileResponse)(nil), "ns.CreateGatewayProfileResponse")
+	proto.RegisterType((*GetGatewayProfileRequest)(nil), "ns.GetGatewayProfileRequest")
+	proto.RegisterType((*GetGatewayProfileResponse)(nil), "ns.GetGatewayProfileResponse")
+	proto.RegisterType((*UpdateGatewayProfileRequest)(nil), "ns.UpdateGatewayProfileRequest")
+	proto.RegisterType((*DeleteGatewayProfileRequest)(nil), "ns.DeleteGatewayProfileRequest")
+	proto.RegisterType((*FrequencyPlan)(nil), "ns.FrequencyPlan")
+	proto.RegisterType((*CreateFrequencyPlanRequest)(nil), "ns.CreateFrequencyPlanRequest")
+	proto.RegisterType((*CreateFrequencyPlanResponse)(nil), "ns.CreateFrequencyPlanResponse")
+	proto.RegisterType((*GetFrequencyPlanRequest)(nil), "ns.GetFrequencyPlanRequest")
+	proto.RegisterType((*GetFrequencyPlanResponse)(nil), "ns.GetFrequencyPlanResponse")
+	proto.RegisterType((*UpdateFrequencyPlanRequest)(nil), "ns.UpdateFrequencyPlanRequest")
+	proto.RegisterType((*UpdateFrequencyPlanResponse)(nil), "ns.UpdateFrequencyPlanResponse")
+	proto.RegisterType((*DeleteFrequencyPlanRequest)(nil), "ns.DeleteFrequencyPlanRequest")
+	proto.RegisterType((*GetFrequencyPlanVersionsRequest)(nil), "ns.GetFrequencyPlanVersionsRequest")
+	proto.RegisterType((*GetFrequencyPlanVersionsResponse)(nil), "ns.GetFrequencyPlanVersionsResponse")
+	proto.RegisterType((*FrequencyPlanVersion)(nil), "ns.FrequencyPlanVersion")
+	proto.RegisterType((*MulticastGroup)(nil), "ns.MulticastGroup")
+	proto.RegisterType((*CreateMulticastGroupRequest)(nil), "ns.CreateMulticastGroupRequest")
+	proto.RegisterType((*CreateMulticastGroupResponse)(nil), "ns.CreateMulticastGroupResponse")
+	proto.RegisterType((*GetMulticastGroupRequest)(nil), "ns.GetMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastGroupResponse)(nil), "ns.GetMulticastGroupResponse")
+	proto.RegisterType((*UpdateMulticastGroupRequest)(nil), "ns.UpdateMulticastGroupRequest")
+	proto.RegisterType((*DeleteMulticastGroupRequest)(nil), "ns.DeleteMulticastGroupRequest")
+	proto.RegisterType((*AddDeviceToMulticastGroupRequest)(nil), "ns.AddDeviceToMulticastGroupRequest")
+	proto.RegisterType((*RemoveDeviceFromMulticastGroupRequest)(nil), "ns.RemoveDeviceFromMulticastGroupRequest")
+	proto.RegisterType((*MulticastQueueItem)(nil), "ns.MulticastQueueItem")
+	proto.RegisterType((*MulticastGeofence)(nil), "ns.MulticastGeofence")
+	proto.RegisterType((*EnqueueMulticastQueueItemRequest)(nil), "ns.EnqueueMulticastQueueItemRequest")
+	proto.RegisterType((*FlushMulticastQueueForMulticastGroupRequest)(nil), "ns.FlushMulticastQueueForMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupRequest)(nil), "ns.GetMulticastQueueItemsForMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastQueueItemsForMulticastGroupResponse)(nil), "ns.GetMulticastQueueItemsForMulticastGroupResponse")
+	proto.RegisterType((*GetMulticastGroupCoverageRequest)(nil), "ns.GetMulticastGroupCoverageRequest")
+	proto.RegisterType((*MulticastGatewayCoverage)(nil), "ns.MulticastGatewayCoverage")
+	proto.RegisterType((*MulticastDeviceCoverage)(nil), "ns.MulticastDeviceCoverage")
+	proto.RegisterType((*GetMulticastGroupCoverageResponse)(nil), "ns.GetMulticastGroupCoverageResponse")
+	proto.RegisterType((*EstimateMulticastSessionRequest)(nil), "ns.EstimateMulticastSessionRequest")
+	proto.RegisterType((*MulticastGatewayAirtime)(nil), "ns.MulticastGatewayAirtime")
+	proto.RegisterType((*EstimateMulticastSessionResponse)(nil), "ns.EstimateMulticastSessionResponse")
+	proto.RegisterType((*GetMulticastTXStatusRequest)(nil), "ns.GetMulticastTXStatusRequest")
+	proto.RegisterType((*MulticastGatewayTXStatus)(nil), "ns.MulticastGatewayTXStatus")
+	proto.RegisterType((*MulticastFrameTXStatus)(nil), "ns.MulticastFrameTXStatus")
+	proto.RegisterType((*GetMulticastTXStatusResponse)(nil), "ns.GetMulticastTXStatusResponse")
+	proto.RegisterType((*StartMulticastSetupRequest)(nil), "ns.StartMulticastSetupRequest")
+	proto.RegisterType((*GetMulticastSetupStatusRequest)(nil), "ns.GetMulticastSetupStatusRequest")
+	proto.RegisterType((*MulticastDeviceSetupStatus)(nil), "ns.MulticastDeviceSetupStatus")
+	proto.RegisterType((*GetMulticastSetupStatusResponse)(nil), "ns.GetMulticastSetupStatusResponse")
+	proto.RegisterType((*SecurityEvent)(nil), "ns.SecurityEvent")
+	proto.RegisterType((*GetSecurityEventsRequest)(nil), "ns.GetSecurityEventsRequest")
+	proto.RegisterType((*GetSecurityEventsResponse)(nil), "ns.GetSecurityEventsResponse")
+	proto.RegisterType((*GatewayContributions)(nil), "ns.GatewayContributions")
+	proto.RegisterType((*GetGatewayContributionsRequest)(nil), "ns.GetGatewayContributionsRequest")
+	proto.RegisterType((*GetGatewayContributionsResponse)(nil), "ns.GetGatewayContributionsResponse")
+	proto.RegisterType((*StreamGatewayContributionsRequest)(nil), "ns.StreamGatewayContributionsRequest")
+	proto.RegisterType((*GatewayContributionEvent)(nil), "ns.GatewayContributionEvent")
+	proto.RegisterType((*RoamingAgreement)(nil), "ns.RoamingAgreement")
+	proto.RegisterType((*CreateRoamingAgreementRequest)(nil), "ns.CreateRoamingAgreementRequest")
+	proto.RegisterType((*CreateRoamingAgreementResponse)(nil), "ns.CreateRoamingAgreementResponse")
+	proto.RegisterType((*GetRoamingAgreementRequest)(nil), "ns.GetRoamingAgreementRequest")
+	proto.RegisterType((*GetRoamingAgreementResponse)(nil), "ns.GetRoamingAgreementResponse")
+	proto.RegisterType((*UpdateRoamingAgreementRequest)(nil), "ns.UpdateRoamingAgreementRequest")
+	proto.RegisterType((*DeleteRoamingAgreementRequest)(nil), "ns.DeleteRoamingAgreementRequest")
+	proto.RegisterType((*ListRoamingAgreementsRequest)(nil), "ns.ListRoamingAgreementsRequest")
+	proto.RegisterType((*ListRoamingAgreementsResponse)(nil), "ns.ListRoamingAgreementsResponse")
+	proto.RegisterType((*EnqueueCertificationCommandRequest)(nil), "ns.EnqueueCertificationCommandRequest")
+	proto.RegisterType((*RedisKeyClassMemoryUsage)(nil), "ns.RedisKeyClassMemoryUsage")
+	proto.RegisterType((*GetRedisMemoryUsageResponse)(nil), "ns.GetRedisMemoryUsageResponse")
+	proto.RegisterType((*GatewayConfigurationStatus)(nil), "ns.GatewayConfigurationStatus")
+	proto.RegisterType((*GetGatewayConfigurationStatusRequest)(nil), "ns.GetGatewayConfigurationStatusRequest")
+	proto.RegisterType((*GetGatewayConfigurationStatusResponse)(nil), "ns.GetGatewayConfigurationStatusResponse")
+	proto.RegisterType((*GetGatewayConfigurationStatusForGatewayProfileRequest)(nil), "ns.GetGatewayConfigurationStatusForGatewayProfileRequest")
+	proto.RegisterType((*GetGatewayConfigurationStatusForGatewayProfileResponse)(nil), "ns.GetGatewayConfigurationStatusForGatewayProfileResponse")
+	proto.RegisterType((*DecodePHYPayloadRequest)(nil), "ns.DecodePHYPayloadRequest")
+	proto.RegisterType((*DecodePHYPayloadResponse)(nil), "ns.DecodePHYPayloadResponse")
+	proto.RegisterType((*GetSchedulerBacklogRequest)(nil), "ns.GetSchedulerBacklogRequest")
+	proto.RegisterType((*BeaconPeriodBacklog)(nil), "ns.BeaconPeriodBacklog")
+	proto.RegisterType((*GetSchedulerBacklogResponse)(nil), "ns.GetSchedulerBacklogResponse")
+	proto.RegisterType((*StartGatewayDrainRequest)(nil), "ns.StartGatewayDrainRequest")
+	proto.RegisterType((*StopGatewayDrainRequest)(nil), "ns.StopGatewayDrainRequest")
+	proto.RegisterType((*GetGatewayDrainStatusRequest)(nil), "ns.GetGatewayDrainStatusRequest")
+	proto.RegisterType((*GetGatewayDrainStatusResponse)(nil), "ns.GetGatewayDrainStatusResponse")
+	proto.RegisterType((*GatewayInventory)(nil), "ns.GatewayInventory")
+	proto.RegisterType((*GetGatewayInventoryRequest)(nil), "ns.GetGatewayInventoryRequest")
+	proto.RegisterType((*GetGatewayInventoryResponse)(nil), "ns.GetGatewayInventoryResponse")
+	proto.RegisterType((*ListGatewayInventoryRequest)(nil), "ns.ListGatewayInventoryRequest")
+	proto.RegisterType((*ListGatewayInventoryResponse)(nil), "ns.ListGatewayInventoryResponse")
+	proto.RegisterType((*GatewayConnectionState)(nil), "ns.GatewayConnectionState")
+	proto.RegisterType((*GetGatewayConnectionStateRequest)(nil), "ns.GetGatewayConnectionStateRequest")
+	proto.RegisterType((*GetGatewayConnectionStateResponse)(nil), "ns.GetGatewayConnectionStateResponse")
+	proto.RegisterType((*ListGatewayConnectionStatesRequest)(nil), "ns.ListGatewayConnectionStatesRequest")
+	proto.RegisterType((*ListGatewayConnectionStatesResponse)(nil), "ns.ListGatewayConnectionStatesResponse")
+	proto.RegisterType((*ExecGatewayCommandRequest)(nil), "ns.ExecGatewayCommandRequest")
+	proto.RegisterMapType((map[string]string)(nil), "ns.ExecGatewayCommandRequest.EnvironmentEntry")
+	proto.RegisterType((*ExecGatewayCommandResponse)(nil), "ns.ExecGatewayCommandResponse")
+	proto.RegisterType((*GetGatewayCommandExecResponseRequest)(nil), "ns.GetGatewayCommandExecResponseRequest")
+	proto.RegisterType((*GetGatewayCommandExecResponseResponse)(nil), "ns.GetGatewayCommandExecResponseResponse")
+	proto.RegisterType((*DownlinkTXPending)(nil), "ns.DownlinkTXPending")
+	proto.RegisterType((*GetGatewayDownlinkTXStateRequest)(nil), "ns.GetGatewayDownlinkTXStateRequest")
+	proto.RegisterType((*GetGatewayDownlinkTXStateResponse)(nil), "ns.GetGatewayDownlinkTXStateResponse")
+	proto.RegisterType((*GatewayDownlinkQueueDepth)(nil), "ns.GatewayDownlinkQueueDepth")
+	proto.RegisterType((*ListGatewayDownlinkQueueDepthsRequest)(nil), "ns.ListGatewayDownlinkQueueDepthsRequest")
+	proto.RegisterType((*ListGatewayDownlinkQueueDepthsResponse)(nil), "ns.ListGatewayDownlinkQueueDepthsResponse")
+	proto.RegisterType((*GatewayStatsAggregate)(nil), "ns.GatewayStatsAggregate")
+	proto.RegisterMapType((map[uint32]uint32)(nil), "ns.GatewayStatsAggregate.RxPacketsPerFrequencyEntry")
+	proto.RegisterMapType((map[uint32]uint32)(nil), "ns.GatewayStatsAggregate.TxPacketsPerFrequencyEntry")
+	proto.RegisterMapType((map[string]uint32)(nil), "ns.GatewayStatsAggregate.TxPacketsPerStatusEntry")
+	proto.RegisterType((*GetGatewayStatsAggregatesRequest)(nil), "ns.GetGatewayStatsAggregatesRequest")
+	proto.RegisterType((*GetGatewayStatsAggregatesResponse)(nil), "ns.GetGatewayStatsAggregatesResponse")
+	proto.RegisterType((*GatewayChannelNoiseFloor)(nil), "ns.GatewayChannelNoiseFloor")
+	proto.RegisterMapType((map[int32]uint32)(nil), "ns.GatewayChannelNoiseFloor.RssiHistogramEntry")
+	proto.RegisterType((*GetGatewayNoiseFloorRequest)(nil), "ns.GetGatewayNoiseFloorRequest")
+	proto.RegisterType((*GetGatewayNoiseFloorResponse)(nil), "ns.GetGatewayNoiseFloorResponse")
+}
+
+func init() { proto.RegisterFile("ns.proto", fileDescriptor_3b280de855f92a4a) }
+
+var fileDescriptor_3b280de855f92a4a = []byte{
+	// 8326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x7d, 0x4b, 0x6c, 0x1c, 0xc9,
+	0x92, 0x98, 0xba, 0x49, 0x36, 0x9b, 0x41, 0x76, 0xab, 0x99, 0xfc, 0xb5, 0x5a, 0x1f, 0x52, 0x25,
+	0xe9, 0x8d, 0x46, 0x33, 0x8f, 0x9a, 0xe1, 0x3c, 0xcd, 0xef, 0xbd, 0x99, 0xdd, 0x16, 0xd9, 0x92,
+	0x38, 0xe2, 0x6f, 0xaa, 0xc9, 0x99, 0x79, 0xfb, 0x80, 0x57, 0x2e, 0x56, 0x65, 0xb7, 0x6a, 0xd5,
+	0x5d, 0xd5, 0x53, 0x55, 0x4d, 0x91, 0x63, 0xd8, 0x80, 0x0d, 0xf8, 0x01, 0xb6, 0x17, 0x86, 0x0f,
+	0xf6, 0xc9, 0x80, 0x4f, 0x86, 0xbf, 0x58, 0x18, 0xf6, 0xda, 0x80, 0xbd, 0x27, 0xc3, 0x3e, 0xd9,
+	0x07, 0xfb, 0x60, 0xc0, 0xd8, 0x9b, 0x0f, 0x5e, 0xf8, 0x62, 0x9f, 0x0c, 0x9f, 0xfc, 0x83, 0x91,
+	0xdf, 0xca, 0xaa, 0xae, 0xaa, 0x6e, 0x4a, 0x33, 0x98, 0xc5, 0x5e, 0x24, 0x56, 0x66, 0x64, 0x64,
+	0x64, 0x64, 0x64, 0x64, 0x64, 0x64, 0x64, 0x34, 0x94, 0xdd, 0x60, 0x73, 0xe0, 0x7b, 0xa1, 0x87,
+	0x8a, 0x6e, 0xd0, 0xb8, 0x16, 0x3a, 0x7d, 0x1c, 0x84, 0x66, 0x7f, 0xf0, 0x50, 0xfe, 0xc5, 0xaa,
+	0x1b, 0x8b, 0xb8, 0x3f, 0x08, 0x2f, 0x1e, 0xd2, 0x7f, 0x79, 0xd1, 0x9a, 0x3d, 0xf4, 0xcd, 0xd0,
+	0xf1, 0xdc, 0x87, 0xe2, 0x0f, 0x51, 0x61, 0x0e, 0x9c, 0x87, 0x96, 0xd7, 0xef, 0x7b, 0x2e, 0xff,
+	0x8f, 0x57, 0x5c, 0x25, 0x15, 0xdd, 0x57, 0x0f, 0xbb, 0xaf, 0x78, 0x41, 0x75, 0xe0, 0x7b, 0x1d,
+	0xa7, 0x87, 0x39, 0x11, 0xda, 0xef, 0xc0, 0xf5, 0x6d, 0x1f, 0x9b, 0x21, 0x6e, 0x63, 0xff, 0xcc,
+	0xb1, 0xf0, 0x11, 0xab, 0xd6, 0xf1, 0xb7, 0x43, 0x1c, 0x84, 0xe8, 0xe7, 0x70, 0x35, 0x60, 0x15,
+	0x06, 0x6f, 0x58, 0x2f, 0x6c, 0x14, 0xee, 0xcf, 0x6f, 0xa1, 0x4d, 0x37, 0xd8, 0x4c, 0xb4, 0xa9,
+	0x06, 0xb1, 0x6f, 0x6d, 0x13, 0x6e, 0xa4, 0xe3, 0x0e, 0x06, 0x9e, 0x1b, 0x60, 0x54, 0x85, 0xa2,
+	0x63, 0x53, 0x7c, 0x0b, 0x7a, 0xd1, 0xb1, 0xb5, 0x07, 0x50, 0x7f, 0x8a, 0xc3, 0x74, 0x42, 0x92,
+	0xb0, 0xff, 0xbe, 0x00, 0xd7, 0x52, 0x80, 0x39, 0xe6, 0x37, 0x21, 0x1b, 0x7d, 0x02, 0x60, 0x51,
+	0xb2, 0x6d, 0xc3, 0x0c, 0xeb, 0x45, 0xda, 0xae, 0xb1, 0xd9, 0xf5, 0xbc, 0x6e, 0x0f, 0x33, 0xae,
+	0x9d, 0x0e, 0x3b, 0x9b, 0xc7, 0x62, 0xba, 0xf4, 0x39, 0x0e, 0xdd, 0x0c, 0x49, 0xd3, 0xe1, 0xc0,
+	0x16, 0x4d, 0xa7, 0xc6, 0x37, 0xe5, 0xd0, 0xcd, 0x90, 0x4c, 0xc4, 0x09, 0xfd, 0xf8, 0x01, 0x26,
+	0xe2, 0xa7, 0x70, 0x7d, 0x07, 0xf7, 0x70, 0x88, 0x27, 0xe3, 0xad, 0x94, 0x09, 0xdd, 0x1b, 0x86,
+	0x8e, 0xdb, 0x1d, 0x25, 0xc5, 0x67, 0x15, 0x69, 0xa4, 0x24, 0xda, 0x54, 0xfd, 0xd8, 0x77, 0x24,
+	0x13, 0x49, 0xdc, 0xb9, 0x32, 0x91, 0x4e, 0x48, 0x86, 0x4c, 0x64, 0x60, 0x7e, 0x13, 0xb2, 0x7f,
+	0x6c, 0x99, 0xf8, 0x01, 0x26, 0x42, 0xca, 0xc4, 0x64, 0xbc, 0xfd, 0x0a, 0x1a, 0x6c, 0xde, 0x76,
+	0x70, 0x8a, 0x04, 0x7d, 0x0c, 0x55, 0x1b, 0xa7, 0x08, 0xe7, 0x22, 0x21, 0x24, 0xde, 0xa2, 0x62,
+	0xe3, 0x84, 0x68, 0xa6, 0xe2, 0xcd, 0x10, 0x87, 0xb7, 0x61, 0xed, 0x29, 0x0e, 0x53, 0x69, 0x48,
+	0x82, 0xfe, 0xbb, 0x02, 0xd4, 0x47, 0x61, 0x39, 0xde, 0xd7, 0x26, 0xf8, 0x47, 0x92, 0x84, 0xaf,
+	0xa0, 0xc1, 0x24, 0xe1, 0x7b, 0x66, 0xff, 0xbb, 0xd0, 0x60, 0x52, 0x30, 0x11, 0x4b, 0xff, 0x65,
+	0x11, 0x4a, 0x0c, 0x10, 0xad, 0xc1, 0xac, 0x8d, 0xcf, 0x0c, 0x3c, 0x74, 0x78, 0x7d, 0xc9, 0xc6,
+	0x67, 0xad, 0xa1, 0x83, 0x1e, 0xc0, 0x62, 0x9c, 0x16, 0xc3, 0xb1, 0x29, 0x9b, 0x16, 0xf4, 0xab,
+	0xb1, 0xbe, 0x77, 0x6d, 0xf4, 0x2e, 0xa0, 0x84, 0x52, 0x23, 0xc0, 0x53, 0x14, 0xb8, 0x16, 0xd7,
+	0x61, 0x0c, 0x3a, 0x21, 0xee, 0x04, 0x7a, 0x9a, 0x41, 0xc7, 0xa5, 0x7b, 0xd7, 0x46, 0x6f, 0x41,
+	0x2d, 0x78, 0xe9, 0x0c, 0x8c, 0x8e, 0x61, 0xb9, 0xa1, 0x61, 0xbd, 0xc0, 0xd6, 0xcb, 0xfa, 0xcc,
+	0x46, 0xe1, 0x7e, 0x59, 0xaf, 0x90, 0xf2, 0x27, 0xdb, 0x6e, 0xb8, 0x4d, 0x0a, 0xd1, 0x4f, 0x01,
+	0xf9, 0xb8, 0x83, 0x7d, 0xec, 0x5a, 0xd8, 0x30, 0x7b, 0xa1, 0x13, 0x0e, 0x6d, 0x5c, 0x2f, 0x6d,
+	0x14, 0xee, 0x17, 0xf4, 0x45, 0x59, 0xd3, 0xe4, 0x15, 0xe8, 0x43, 0x58, 0xb3, 0xb0, 0x1f, 0x3a,
+	0x1d, 0xc7, 0xa2, 0x3b, 0xb0, 0x11, 0xe2, 0x20, 0x34, 0xfa, 0x9e, 0x8d, 0xeb, 0xb3, 0x14, 0xfd,
+	0x4a, 0xac, 0xfa, 0x18, 0x07, 0xe1, 0xbe, 0x67, 0x63, 0xed, 0x13, 0x58, 0x52, 0x05, 0x5d, 0xb0,
+	0x58, 0x83, 0x12, 0xe3, 0x0a, 0x9f, 0x32, 0x88, 0xa6, 0x4c, 0xe7, 0x35, 0xda, 0x3b, 0x50, 0x93,
+	0x82, 0x2c, 0xda, 0x65, 0xf1, 0x5f, 0xfb, 0xfd, 0x02, 0x2c, 0x2a, 0xd0, 0x5c, 0xde, 0x27, 0xe8,
+	0xe6, 0x47, 0x92, 0xec, 0x4f, 0x60, 0x49, 0x95, 0xec, 0xcb, 0xf0, 0xe5, 0xf7, 0x0a, 0xb0, 0x72,
+	0xec, 0x9b, 0x6e, 0xd0, 0xc1, 0xfe, 0x64, 0xdc, 0xc9, 0x90, 0xb8, 0xe2, 0xa5, 0x24, 0x6e, 0x2a,
+	0x5d, 0xe2, 0xb4, 0x4d, 0x58, 0x52, 0xd7, 0xd2, 0xd8, 0x99, 0xfa, 0xc3, 0x22, 0xd4, 0x18, 0x68,
+	0xd3, 0x0a, 0x9d, 0x33, 0x2a, 0x2e, 0xd9, 0x94, 0x5f, 0x83, 0x32, 0xa9, 0x30, 0x6d, 0xdb, 0xe7,
+	0xf4, 0x12, 0xc0, 0xa6, 0x6d, 0xfb, 0xe8, 0x2e, 0x5c, 0x0d, 0x0c, 0xf7, 0xd5, 0x4b, 0x23, 0x30,
+	0x1c, 0x37, 0x34, 0x5e, 0xe2, 0x0b, 0x4e, 0xe3, 0x7c, 0x70, 0xf0, 0xea, 0x65, 0x7b, 0xd7, 0x0d,
+	0x9f, 0xe3, 0x0b, 0x02, 0xd5, 0x49, 0x40, 0xb1, 0xb5, 0x33, 0xdf, 0x51, 0xa0, 0x6e, 0x43, 0x85,
+	0xc1, 0x60, 0xd7, 0xa2, 0x30, 0x33, 0x14, 0x06, 0xdc, 0x57, 0x2f, 0xdb, 0x2d, 0xd7, 0x22, 0x20,
+	0x75, 0x28, 0xb3, 0x45, 0x35, 0x1c, 0xd0, 0x65, 0x52, 0xd1, 0x4b, 0x9d, 0x6d, 0x37, 0x3c, 0x19,
+	0xa0, 0x75, 0x58, 0x70, 0xf9, 0x82, 0xb3, 0xbd, 0x57, 0x2e, 0x5d, 0x10, 0x15, 0x7d, 0xce, 0x25,
+	0x8b, 0x6d, 0xc7, 0x7b, 0xe5, 0x12, 0x00, 0x53, 0x05, 0x28, 0x33, 0x00, 0x53, 0x02, 0xa4, 0xad,
+	0xda, 0xb9, 0x94, 0x55, 0xab, 0xfd, 0x0e, 0xac, 0x70, 0xae, 0x25, 0xd8, 0xdd, 0x94, 0xfa, 0xc7,
+	0x94, 0x5c, 0xe5, 0x32, 0xb4, 0x1c, 0xc9, 0x50, 0xc4, 0x71, 0xbd, 0x66, 0x27, 0x4a, 0xb4, 0x2d,
+	0x58, 0xdb, 0xc1, 0x66, 0x2a, 0xf6, 0xcc, 0xc9, 0x7c, 0x04, 0x0d, 0xb9, 0xea, 0x14, 0xe4, 0xe3,
+	0x9a, 0xfd, 0x19, 0xb8, 0x9e, 0xda, 0x8c, 0x2f, 0xdb, 0xef, 0x61, 0x30, 0x1f, 0x2a, 0x3d, 0x6c,
+	0xf7, 0xcc, 0x20, 0x78, 0x86, 0xcd, 0x5e, 0xf8, 0x62, 0x2c, 0x65, 0xbf, 0x99, 0x82, 0x1b, 0xe9,
+	0x0d, 0x39, 0x6d, 0xb7, 0x61, 0x81, 0xd3, 0x66, 0x91, 0x5a, 0xda, 0x7c, 0x4e, 0x9f, 0xb7, 0xa3,
+	0x06, 0xe8, 0x03, 0x28, 0x05, 0xa1, 0x19, 0x0e, 0x03, 0x2a, 0xb1, 0xd5, 0xad, 0xeb, 0x11, 0xcd,
+	0x0a, 0xc6, 0x36, 0x05, 0xd1, 0x39, 0x28, 0xba, 0x01, 0x73, 0x44, 0x36, 0x7a, 0x8e, 0xfb, 0x32,
+	0xa0, 0x72, 0x5c, 0xd1, 0xa3, 0x02, 0xf4, 0x10, 0x96, 0x2c, 0xcf, 0xed, 0x38, 0x7e, 0x1f, 0xdb,
+	0x46, 0x04, 0x37, 0x4d, 0xe1, 0x90, 0xac, 0xda, 0x91, 0x0d, 0x34, 0x58, 0x30, 0xad, 0x97, 0xae,
+	0xf7, 0xaa, 0x87, 0xed, 0x2e, 0xb6, 0xa9, 0x3c, 0x57, 0xf4, 0x58, 0x19, 0x6a, 0x40, 0x99, 0x9c,
+	0xbe, 0xbc, 0x61, 0x18, 0x70, 0x89, 0x96, 0xdf, 0xe8, 0x7d, 0x58, 0xb6, 0xc8, 0x78, 0xad, 0x61,
+	0xe8, 0x9c, 0x61, 0x43, 0xc2, 0x31, 0xd9, 0x5e, 0x52, 0xea, 0x8e, 0x45, 0x93, 0x3d, 0x58, 0xee,
+	0x99, 0x41, 0x68, 0xa8, 0x7d, 0x10, 0xbd, 0x58, 0x1e, 0xab, 0x17, 0x11, 0x69, 0xd7, 0x54, 0x9a,
+	0x35, 0x43, 0xed, 0xbf, 0x15, 0xe0, 0xda, 0x91, 0xef, 0x9d, 0x39, 0x81, 0xe3, 0xb9, 0xcd, 0xc7,
+	0x47, 0x97, 0xd6, 0x93, 0xe9, 0x52, 0x54, 0xbc, 0x8c, 0x14, 0xa1, 0x87, 0x30, 0x67, 0x0e, 0x06,
+	0x46, 0x20, 0x95, 0xcb, 0xfc, 0xd6, 0xd2, 0x26, 0x3f, 0x69, 0x3e, 0xc7, 0x17, 0x2d, 0xf7, 0x0c,
+	0xf7, 0xbc, 0x01, 0xd6, 0x67, 0xcd, 0xc1, 0xa0, 0x4d, 0x94, 0xc4, 0x87, 0xb0, 0x86, 0x5d, 0xf3,
+	0xb4, 0x87, 0x6d, 0x63, 0x38, 0x20, 0x33, 0x61, 0x58, 0x2f, 0x4c, 0xd7, 0xc5, 0x3d, 0x32, 0x57,
+	0x53, 0xf7, 0x2b, 0xfa, 0x0a, 0xaf, 0x3e, 0xa1, 0xb5, 0xdb, 0xbc, 0x52, 0xfb, 0x08, 0x1a, 0x69,
+	0x83, 0xe5, 0x32, 0xa7, 0x2a, 0xc1, 0x42, 0x4c, 0x09, 0x6a, 0x8f, 0xd8, 0x41, 0xc1, 0x74, 0x6d,
+	0xaf, 0xbf, 0xc3, 0xca, 0x26, 0x69, 0xe6, 0xc0, 0x06, 0xdb, 0x96, 0xf7, 0x9b, 0xdb, 0xdb, 0x5e,
+	0xbf, 0x6f, 0xba, 0xf6, 0x97, 0x43, 0x3c, 0xc4, 0xbb, 0x21, 0xee, 0x8f, 0xdd, 0x4d, 0x6a, 0x30,
+	0x65, 0x71, 0x13, 0xa4, 0xa2, 0x93, 0x3f, 0x89, 0x24, 0x59, 0x0c, 0x4b, 0x50, 0x9f, 0xd9, 0x98,
+	0xba, 0xbf, 0xa0, 0xcb, 0x6f, 0xed, 0xef, 0x16, 0xe1, 0x66, 0x1b, 0xbb, 0xf6, 0x91, 0xef, 0x0d,
+	0x7c, 0x07, 0x87, 0xa6, 0x7f, 0x71, 0x64, 0x5e, 0xf4, 0x3c, 0xd3, 0x16, 0x1d, 0xad, 0xc3, 0x7c,
+	0xdf, 0xb4, 0x8c, 0x01, 0x2b, 0xe5, 0x9d, 0x41, 0xdf, 0xb4, 0x38, 0x1c, 0xe9, 0xb0, 0xef, 0x58,
+	0x5c, 0xff, 0x93, 0x3f, 0xc9, 0x2a, 0xec, 0x9a, 0x21, 0x7e, 0x65, 0x5e, 0x18, 0x7d, 0xd3, 0x22,
+	0x0b, 0x86, 0x74, 0x3a, 0xcf, 0xcb, 0xf6, 0x4d, 0x2b, 0x40, 0x8f, 0x60, 0x75, 0xe0, 0xf5, 0x4c,
+	0xdf, 0xf9, 0x8e, 0x19, 0x2c, 0x8e, 0x7b, 0x86, 0x7d, 0xc2, 0x5f, 0x4a, 0x78, 0x59, 0x5f, 0x51,
+	0x6b, 0x77, 0x45, 0x25, 0x59, 0x87, 0x1d, 0x9f, 0x10, 0xe6, 0x5a, 0x17, 0x7c, 0xd5, 0x44, 0x05,
+	0xc4, 0x34, 0xb4, 0x7d, 0xbe, 0x58, 0x8a, 0xb6, 0x8f, 0xbe, 0x80, 0x65, 0xb2, 0x34, 0x8c, 0xc0,
+	0x21, 0x66, 0x54, 0x77, 0x10, 0x18, 0x78, 0xe0, 0x59, 0x2f, 0xe8, 0x32, 0x99, 0xdf, 0xba, 0x36,
+	0x22, 0xf3, 0x3b, 0xdc, 0x81, 0xa1, 0x2f, 0x92, 0x66, 0x6d, 0xd2, 0xea, 0xe9, 0x20, 0x68, 0x91,
+	0x36, 0xda, 0x3f, 0x2c, 0xc2, 0xec, 0x53, 0x36, 0x80, 0xa4, 0x09, 0x8a, 0xde, 0x85, 0x72, 0xcf,
+	0xb3, 0x54, 0x11, 0xae, 0x09, 0x39, 0xdc, 0xe3, 0xe5, 0xba, 0x84, 0x20, 0x1b, 0xb8, 0xe0, 0xce,
+	0xe8, 0x06, 0xce, 0x6b, 0xa2, 0xed, 0xfe, 0x3e, 0x94, 0x4e, 0x3d, 0xd3, 0xb7, 0x99, 0x88, 0x12,
+	0xcc, 0x6e, 0xb0, 0xc9, 0x09, 0x79, 0x4c, 0x2a, 0x74, 0x5e, 0x9f, 0x61, 0x18, 0xcc, 0x64, 0x98,
+	0xa2, 0xd7, 0xa0, 0x1c, 0x0c, 0x4f, 0x8d, 0x53, 0xd3, 0xb5, 0x39, 0xc7, 0x66, 0x83, 0xe1, 0xe9,
+	0x63, 0xd3, 0xb5, 0xc9, 0xf4, 0x99, 0x6e, 0x88, 0x5d, 0xd7, 0x34, 0xba, 0xa6, 0xc3, 0x76, 0xcc,
+	0xa2, 0x3e, 0xcf, 0xcb, 0x9e, 0x9a, 0x8e, 0x8b, 0x6e, 0x02, 0x58, 0x64, 0xa5, 0x18, 0x3d, 0x2f,
+	0x08, 0xa8, 0x0e, 0x29, 0xea, 0x73, 0xb4, 0x64, 0xcf, 0x0b, 0x02, 0xed, 0x2f, 0x15, 0x60, 0x41,
+	0xa5, 0x91, 0x48, 0x6b, 0x67, 0xd0, 0x35, 0x0d, 0xc9, 0xb6, 0x12, 0xf9, 0x64, 0xd6, 0x4c, 0xc7,
+	0x71, 0x99, 0x0a, 0xa3, 0xea, 0x86, 0x2e, 0x66, 0x6e, 0xfb, 0x90, 0x1a, 0xa9, 0x87, 0xc8, 0x02,
+	0xde, 0x84, 0x32, 0xa7, 0x82, 0x09, 0x15, 0x3f, 0x55, 0xf2, 0xae, 0x9a, 0xac, 0x4a, 0x97, 0x30,
+	0xda, 0x9f, 0x85, 0x6a, 0xbc, 0x0e, 0x21, 0x98, 0xa6, 0x63, 0x2a, 0x50, 0x92, 0xa7, 0xbb, 0xa3,
+	0x83, 0x29, 0x26, 0x06, 0x83, 0xea, 0x30, 0x6b, 0x7e, 0xe7, 0xf4, 0x87, 0xe1, 0x0b, 0x3a, 0x49,
+	0x45, 0x5d, 0x7c, 0x12, 0x69, 0x3c, 0xc5, 0x66, 0xff, 0x95, 0x63, 0x87, 0x2f, 0xa8, 0xdc, 0x16,
+	0xf5, 0xa8, 0x40, 0xfb, 0x0c, 0x96, 0xd9, 0x2a, 0xe6, 0x24, 0x88, 0x05, 0x75, 0x0f, 0x66, 0xf9,
+	0x2c, 0x73, 0xf5, 0x38, 0xaf, 0x8c, 0x41, 0x17, 0x75, 0xda, 0x1d, 0x6a, 0x32, 0x27, 0xda, 0x26,
+	0x0f, 0x3f, 0xff, 0xb8, 0x08, 0x48, 0x85, 0xe2, 0xba, 0x65, 0xb2, 0x2e, 0x7e, 0x1c, 0xe3, 0x1a,
+	0x7d, 0x0e, 0x95, 0x8e, 0xe3, 0x07, 0xa1, 0x11, 0x60, 0xec, 0x92, 0xd6, 0xd3, 0x63, 0x5b, 0xcf,
+	0xd3, 0x06, 0x6d, 0x8c, 0xdd, 0x66, 0x88, 0x7e, 0x01, 0x0b, 0x3d, 0x53, 0x69, 0x3e, 0x33, 0xb6,
+	0x39, 0xf4, 0x4c, 0xd1, 0x9a, 0xcc, 0x0a, 0x33, 0xed, 0x5f, 0x6f, 0x56, 0x7e, 0x02, 0xcb, 0xcc,
+	0x9e, 0x1e, 0x33, 0x31, 0x7f, 0xb5, 0x28, 0x57, 0x00, 0x31, 0x25, 0x02, 0xf4, 0x31, 0xcc, 0x49,
+	0x19, 0xaf, 0x17, 0xc6, 0x92, 0x1c, 0x01, 0xa3, 0x4d, 0x58, 0xf2, 0xcf, 0x8d, 0x81, 0x69, 0xbd,
+	0xc4, 0x61, 0x60, 0xf8, 0xd8, 0xc2, 0xce, 0x19, 0x66, 0xe7, 0x83, 0x19, 0x7d, 0xd1, 0x3f, 0x3f,
+	0x62, 0x35, 0x3a, 0xaf, 0x40, 0x1f, 0xc0, 0x6a, 0x0a, 0xbc, 0xe1, 0xbd, 0xa4, 0xd3, 0x34, 0xa3,
+	0x2f, 0x8d, 0x34, 0x39, 0x7c, 0x49, 0x3a, 0x09, 0x53, 0x3a, 0x99, 0x66, 0x9d, 0x84, 0x23, 0x9d,
+	0xbc, 0x0b, 0x48, 0x81, 0xc7, 0x7d, 0x27, 0x0c, 0xb9, 0x1d, 0x33, 0xa3, 0xd7, 0x24, 0x78, 0x8b,
+	0x95, 0x6b, 0xff, 0xa3, 0x00, 0xab, 0x91, 0x98, 0x52, 0x86, 0x08, 0xc6, 0xdd, 0x04, 0x10, 0xda,
+	0x50, 0x32, 0x70, 0x8e, 0x97, 0xec, 0x92, 0xc1, 0x94, 0x1d, 0x37, 0xc4, 0xfe, 0x99, 0xd9, 0xe3,
+	0xf6, 0xda, 0x1a, 0x99, 0x97, 0x66, 0xb7, 0xeb, 0xe3, 0x2e, 0xdf, 0x1c, 0x58, 0xb5, 0x2e, 0x01,
+	0xd1, 0x36, 0x5c, 0x0d, 0x42, 0xd3, 0x0f, 0x23, 0xad, 0x32, 0x81, 0x84, 0x56, 0x69, 0x13, 0xf9,
+	0x8d, 0x7e, 0x0b, 0x2a, 0xd8, 0xb5, 0x15, 0x14, 0xe3, 0xc5, 0x74, 0x01, 0xbb, 0xb6, 0xfc, 0xd2,
+	0xb6, 0x61, 0x6d, 0x64, 0xcc, 0x7c, 0x7d, 0xde, 0x87, 0x92, 0x8f, 0x83, 0x61, 0x2f, 0xac, 0x17,
+	0x46, 0x94, 0x3a, 0x83, 0xe4, 0xf5, 0xda, 0x3f, 0x2b, 0xc2, 0x55, 0x66, 0x6f, 0x48, 0x0b, 0x20,
+	0x7b, 0xeb, 0x5f, 0x87, 0xf9, 0x8e, 0xdf, 0x97, 0x5b, 0x35, 0xd3, 0xa2, 0xd0, 0xf1, 0xfb, 0x62,
+	0xab, 0x5e, 0x82, 0x19, 0x7a, 0x88, 0xe1, 0x26, 0xec, 0x34, 0x39, 0x22, 0xa1, 0x15, 0x28, 0x75,
+	0x8c, 0x81, 0xe7, 0x87, 0xdc, 0x66, 0x98, 0xe9, 0x1c, 0x79, 0x7e, 0x48, 0x94, 0x9b, 0xb4, 0x5c,
+	0xb9, 0x93, 0x22, 0x2a, 0x88, 0x59, 0x2f, 0xa5, 0xf8, 0xc9, 0xef, 0x1d, 0x98, 0x0a, 0xc3, 0xde,
+	0xf8, 0x4d, 0x96, 0x40, 0x11, 0x3d, 0x82, 0xcf, 0x07, 0x8e, 0x8f, 0x83, 0xc9, 0x8c, 0xd1, 0x39,
+	0x0e, 0xdd, 0x0c, 0x89, 0x59, 0x33, 0xf0, 0x1d, 0xcf, 0x77, 0xc2, 0x0b, 0x7a, 0x1c, 0xab, 0xe8,
+	0xf2, 0x5b, 0x7b, 0x2a, 0x3c, 0xba, 0x09, 0xde, 0x09, 0xa9, 0x7b, 0x0b, 0xa6, 0x9d, 0x10, 0xf7,
+	0xf9, 0x42, 0x5c, 0x8a, 0x0c, 0xce, 0x08, 0x92, 0x02, 0x68, 0x3f, 0x87, 0x8d, 0x27, 0xbd, 0x61,
+	0xf0, 0x42, 0xa9, 0x7d, 0xe2, 0x91, 0x83, 0x7d, 0xeb, 0x64, 0x77, 0xec, 0x71, 0xe5, 0x73, 0xb8,
+	0x23, 0x4f, 0x2b, 0x12, 0x71, 0x30, 0x79, 0xfb, 0x2f, 0xe1, 0x6e, 0x7e, 0x7b, 0x2e, 0x4e, 0x6f,
+	0xc3, 0x0c, 0x21, 0x36, 0xe0, 0xd2, 0x94, 0x3a, 0x1c, 0x06, 0xc1, 0x49, 0x3a, 0xc0, 0xe7, 0xa1,
+	0x38, 0x8d, 0x90, 0xe3, 0xeb, 0xe4, 0x24, 0xfd, 0x1c, 0xee, 0xe6, 0xb7, 0xe7, 0x24, 0x49, 0x49,
+	0x2b, 0x44, 0x92, 0xa6, 0xfd, 0x51, 0x01, 0xaa, 0x4f, 0x7c, 0xb3, 0x8f, 0xf7, 0xbc, 0xee, 0x13,
+	0xa7, 0x17, 0x62, 0x1f, 0x69, 0x30, 0xdb, 0x37, 0xc2, 0x8b, 0x01, 0x66, 0xc4, 0x57, 0xb7, 0xe6,
+	0x08, 0xf1, 0xfb, 0xc7, 0x17, 0x03, 0xac, 0x97, 0xfa, 0xe4, 0x3f, 0x72, 0xf8, 0x02, 0x26, 0xa0,
+	0x46, 0xdf, 0x61, 0x06, 0x56, 0x45, 0x2f, 0x53, 0x21, 0xdd, 0x77, 0x5c, 0xb5, 0xd6, 0x3c, 0xaf,
+	0x4f, 0xa9, 0xb5, 0xe6, 0x39, 0x91, 0xd3, 0xbe, 0xe3, 0x1a, 0x7e, 0x10, 0x38, 0x5c, 0x99, 0xcd,
+	0xf6, 0x1d, 0x57, 0x0f, 0x02, 0xba, 0x5a, 0x22, 0xcd, 0x23, 0x2c, 0x63, 0x90, 0xaa, 0x27, 0x20,
+	0x5e, 0x43, 0x62, 0xf9, 0x0a, 0x5b, 0xd9, 0xf0, 0xdc, 0xde, 0x05, 0x15, 0xf6, 0xb2, 0x7e, 0xb5,
+	0x6f, 0x5a, 0xdc, 0x32, 0x0f, 0x0e, 0xdd, 0xde, 0x85, 0xd6, 0x87, 0x8d, 0x76, 0xe8, 0x63, 0xb3,
+	0x2f, 0xc6, 0x47, 0xa6, 0x29, 0xb1, 0x47, 0x8c, 0x51, 0x75, 0x0f, 0xa0, 0xd4, 0xa1, 0x4c, 0xe1,
+	0x3b, 0x31, 0x35, 0x6d, 0xe2, 0xec, 0xd2, 0x39, 0x84, 0xf6, 0xf7, 0x0b, 0x70, 0x3b, 0xa7, 0x3f,
+	0x3e, 0x09, 0x9f, 0x43, 0x8d, 0x9f, 0x73, 0x3a, 0x04, 0xca, 0x08, 0x70, 0x28, 0x9d, 0xf1, 0xdd,
+	0x57, 0x9b, 0xec, 0x94, 0x43, 0x11, 0xb4, 0x71, 0xf8, 0xec, 0x8a, 0x5e, 0x1d, 0xc6, 0x4a, 0xd0,
+	0xa7, 0x50, 0x15, 0xa7, 0x59, 0x86, 0x81, 0x53, 0xb6, 0x48, 0x5a, 0xcb, 0xf9, 0x27, 0x15, 0xcf,
+	0xae, 0xe8, 0x15, 0x5b, 0x2d, 0x78, 0x3c, 0x0b, 0x33, 0xb4, 0x89, 0xd6, 0x81, 0xf5, 0x51, 0x4a,
+	0x27, 0xf4, 0x8c, 0x5d, 0x86, 0x25, 0x7f, 0xaf, 0x00, 0x1b, 0xd9, 0x1d, 0xfd, 0x49, 0xe2, 0xc8,
+	0x1f, 0x15, 0x84, 0x76, 0x12, 0x94, 0x6e, 0x9b, 0x83, 0x70, 0xe8, 0x8f, 0xe7, 0x47, 0x5c, 0x82,
+	0x8a, 0x49, 0x09, 0x7a, 0x04, 0x65, 0x71, 0x07, 0x5b, 0x9f, 0x1a, 0xa7, 0x7e, 0x25, 0x28, 0xc1,
+	0xda, 0x37, 0xcf, 0xd9, 0x78, 0x84, 0xd7, 0x62, 0xae, 0x6f, 0x9e, 0x53, 0xea, 0x02, 0x65, 0x12,
+	0x66, 0xc6, 0x4e, 0x82, 0x0d, 0x37, 0x33, 0x46, 0x96, 0x7e, 0x77, 0x82, 0x3e, 0x80, 0x59, 0x4c,
+	0xd6, 0xd6, 0x44, 0xf6, 0x67, 0x89, 0x80, 0x36, 0x43, 0xed, 0xaf, 0xb3, 0x3b, 0xb5, 0x0c, 0xee,
+	0x25, 0xbb, 0x78, 0x1f, 0x4a, 0x1d, 0xcf, 0xef, 0xf3, 0x1e, 0xaa, 0x5b, 0xd7, 0x54, 0xfa, 0x79,
+	0xdb, 0x27, 0x14, 0x40, 0xe7, 0x80, 0xe8, 0x3d, 0x58, 0x76, 0x5c, 0xab, 0x37, 0xb4, 0x89, 0x84,
+	0x04, 0xe4, 0xe4, 0x49, 0x8e, 0x25, 0xcc, 0xf3, 0x53, 0xd6, 0x11, 0xaf, 0x6b, 0xb3, 0xaa, 0xe7,
+	0xf8, 0x22, 0xd0, 0xfe, 0x73, 0x81, 0xfa, 0xda, 0xb2, 0x86, 0x4d, 0x37, 0xd3, 0xfe, 0xa0, 0x87,
+	0x43, 0xcc, 0x48, 0x2b, 0xeb, 0x51, 0x01, 0xdb, 0xb7, 0x89, 0x38, 0x5a, 0xde, 0xd0, 0x0d, 0xb9,
+	0x86, 0x03, 0x5a, 0xb4, 0x4d, 0x4a, 0x12, 0x86, 0xfa, 0xd4, 0x65, 0x0c, 0x75, 0x85, 0xc1, 0xd3,
+	0x93, 0x32, 0x98, 0x9c, 0x92, 0x6c, 0x33, 0x34, 0xf9, 0xe1, 0x91, 0xfe, 0xad, 0x7d, 0x45, 0x4f,
+	0x1a, 0x5f, 0xb1, 0x83, 0xb8, 0x1c, 0x58, 0x1d, 0x66, 0xc5, 0xc1, 0x9d, 0xf9, 0xda, 0xc4, 0x27,
+	0xfa, 0x09, 0xb1, 0x71, 0xba, 0xe2, 0x48, 0x5c, 0xdd, 0xaa, 0x8a, 0x23, 0xb1, 0x4e, 0x4b, 0x75,
+	0x5e, 0xab, 0xfd, 0xa3, 0x29, 0x79, 0x48, 0x13, 0xd7, 0x59, 0xc9, 0x19, 0x24, 0x0e, 0x0c, 0xe1,
+	0xa8, 0x29, 0x52, 0x47, 0x8d, 0xfc, 0x46, 0x2d, 0xa8, 0xe2, 0xf3, 0xd0, 0x37, 0x23, 0x57, 0x0e,
+	0x3b, 0x18, 0xde, 0x52, 0x4c, 0x2a, 0x8e, 0xb7, 0x45, 0xe0, 0xb8, 0x53, 0x47, 0xaf, 0x60, 0xe5,
+	0x2b, 0x40, 0xab, 0x92, 0xda, 0x69, 0x3a, 0x0c, 0xfe, 0x85, 0xde, 0x82, 0xa9, 0xde, 0xa9, 0x38,
+	0x63, 0xac, 0x8c, 0xe2, 0xdc, 0x7b, 0x7c, 0xac, 0x13, 0x08, 0xb2, 0x59, 0x48, 0x47, 0x84, 0x31,
+	0xe8, 0x99, 0x2e, 0x59, 0xa1, 0xcc, 0x32, 0xba, 0x2a, 0x2b, 0x8e, 0x7a, 0xa6, 0xbb, 0x6b, 0xa3,
+	0x9f, 0xc1, 0x6a, 0x02, 0x56, 0xf0, 0x90, 0x39, 0xf0, 0x96, 0x63, 0x0d, 0x38, 0xcb, 0xd1, 0x1d,
+	0xa8, 0xf0, 0x31, 0x1a, 0x5d, 0xdf, 0x1b, 0x0e, 0xa8, 0xb5, 0x34, 0xa7, 0x2f, 0xf0, 0xc2, 0xa7,
+	0xa4, 0x0c, 0xfd, 0x1a, 0x56, 0x7d, 0x4c, 0xcd, 0xb4, 0x2e, 0x5f, 0xde, 0xc6, 0x2b, 0xc7, 0xb5,
+	0xbd, 0x57, 0xd4, 0x44, 0x9a, 0xdf, 0x7a, 0x6b, 0x74, 0x08, 0x7a, 0x1c, 0xfe, 0x6b, 0x0a, 0xae,
+	0xaf, 0xf8, 0x69, 0xc5, 0x5a, 0x00, 0x77, 0x26, 0x68, 0x4d, 0x5c, 0x08, 0xcc, 0x02, 0xef, 0x3b,
+	0xee, 0x30, 0xc4, 0xdc, 0x0a, 0x98, 0xa7, 0x65, 0xfb, 0xb4, 0x08, 0xbd, 0x0d, 0x35, 0xa1, 0x81,
+	0x38, 0x54, 0xc0, 0x25, 0xff, 0xaa, 0x28, 0x67, 0x90, 0x81, 0x16, 0xc0, 0xe2, 0x08, 0xd7, 0xc9,
+	0xa2, 0x21, 0xbb, 0xba, 0x11, 0x9a, 0x7e, 0x97, 0x6b, 0xf1, 0x19, 0x1d, 0x48, 0xd1, 0x31, 0x2d,
+	0x41, 0xd7, 0x61, 0x2e, 0xb0, 0x4c, 0x97, 0x5a, 0xf0, 0xc2, 0x6a, 0x20, 0x05, 0x44, 0xdc, 0xd1,
+	0x06, 0xcc, 0x0b, 0x26, 0x3b, 0x98, 0xc9, 0x4c, 0x45, 0x57, 0x8b, 0xb4, 0xff, 0x48, 0x56, 0x74,
+	0xa6, 0xfc, 0xa0, 0x2d, 0x80, 0xbe, 0x67, 0x0f, 0x7b, 0x91, 0xfb, 0xbb, 0xba, 0x85, 0x84, 0x88,
+	0xef, 0xcb, 0x1a, 0x5d, 0x81, 0x8a, 0x7b, 0xaf, 0x8a, 0x49, 0xef, 0x15, 0xf1, 0x26, 0x98, 0xae,
+	0xcd, 0xbc, 0x09, 0xdc, 0xc7, 0x2c, 0x0b, 0xc8, 0x42, 0x3b, 0x75, 0x42, 0xdf, 0x0c, 0x31, 0xd7,
+	0xd0, 0xe2, 0x13, 0xbd, 0x03, 0x8b, 0xc1, 0xc0, 0xc7, 0xa6, 0x4d, 0x3c, 0x3f, 0x1d, 0xd3, 0x0a,
+	0x3d, 0x9f, 0x59, 0x33, 0x15, 0xbd, 0x26, 0x2b, 0x9e, 0xb0, 0xf2, 0x28, 0x8c, 0x22, 0x39, 0x8b,
+	0xf2, 0xf6, 0x3e, 0xe1, 0x9b, 0x52, 0x6f, 0xef, 0x13, 0x6d, 0xaa, 0x71, 0x67, 0x55, 0x14, 0x46,
+	0x91, 0xc4, 0x9d, 0x1b, 0x46, 0x91, 0x4e, 0x48, 0x46, 0x18, 0x45, 0x06, 0xe6, 0x37, 0x21, 0xfb,
+	0xc7, 0x0e, 0xa3, 0xf8, 0x01, 0x26, 0x42, 0x86, 0x51, 0x4c, 0xc6, 0xdb, 0xff, 0x5e, 0x84, 0xca,
+	0x13, 0x55, 0xe3, 0x24, 0x21, 0xc8, 0x7e, 0xe0, 0x0a, 0x63, 0x67, 0x4e, 0xa7, 0x7f, 0xc7, 0x94,
+	0xf2, 0xd4, 0x58, 0xa5, 0x3c, 0xfd, 0x3a, 0x4a, 0xf9, 0x0e, 0x54, 0xfc, 0xf3, 0x2d, 0x23, 0xe9,
+	0xf1, 0x5d, 0xf0, 0xcf, 0xb7, 0x24, 0xbd, 0xe4, 0xf8, 0x4a, 0x80, 0xa4, 0xe3, 0x77, 0xc6, 0x3f,
+	0xdf, 0xda, 0xf1, 0x89, 0x7a, 0x39, 0xc5, 0xa6, 0xe5, 0xb9, 0x4a, 0x73, 0xa6, 0x5d, 0xaf, 0xb2,
+	0xf2, 0x08, 0xc3, 0x75, 0x98, 0xe3, 0xa0, 0xb6, 0xcf, 0x6f, 0xff, 0xca, 0xac, 0x60, 0xc7, 0x27,
+	0x8e, 0x91, 0x01, 0x59, 0x58, 0x41, 0xcf, 0x0b, 0x15, 0x54, 0xec, 0xc0, 0xb9, 0x48, 0xaa, 0xda,
+	0x3d, 0x2f, 0x8c, 0x90, 0x6d, 0xc0, 0x42, 0x04, 0x6f, 0xfb, 0x75, 0xa0, 0x80, 0x20, 0x00, 0x77,
+	0xfc, 0x28, 0x6a, 0x25, 0xc6, 0x73, 0x25, 0x6c, 0x22, 0xbe, 0x37, 0xa8, 0x61, 0x13, 0xf1, 0x16,
+	0x95, 0xd8, 0x36, 0x11, 0x45, 0xad, 0x24, 0xf0, 0x66, 0xac, 0x3e, 0xe6, 0x9e, 0x48, 0xa5, 0x21,
+	0x39, 0xfd, 0xca, 0x26, 0xcf, 0xb4, 0x96, 0xf8, 0xd4, 0xfe, 0x98, 0xc5, 0xb3, 0xa4, 0xf7, 0xf8,
+	0xda, 0x43, 0xc9, 0xee, 0xf0, 0x4d, 0x2c, 0xa1, 0xf8, 0x62, 0x9d, 0x7e, 0xad, 0x48, 0x97, 0xef,
+	0x79, 0xca, 0x3e, 0x12, 0x4a, 0x20, 0x9d, 0x81, 0x09, 0xe3, 0x4a, 0xe1, 0xbb, 0x0c, 0x91, 0x99,
+	0x64, 0xfe, 0xb4, 0xf7, 0x61, 0x3d, 0x39, 0x49, 0xdc, 0xa8, 0x08, 0xb2, 0x9a, 0x7c, 0x03, 0x1b,
+	0xd9, 0x4d, 0x38, 0x79, 0x3f, 0x83, 0x32, 0xa7, 0x47, 0x78, 0x1e, 0xea, 0x23, 0x23, 0xe6, 0x8d,
+	0x74, 0x09, 0xa9, 0xbd, 0x84, 0xe5, 0x34, 0x88, 0xec, 0xc1, 0xbe, 0x81, 0x82, 0xd6, 0xfe, 0xcd,
+	0x14, 0x54, 0xf7, 0x87, 0xbd, 0xd0, 0xb1, 0xcc, 0x20, 0x64, 0x16, 0x52, 0x52, 0xb8, 0xd7, 0x60,
+	0xb6, 0x6f, 0xa9, 0x21, 0x0c, 0xa5, 0xbe, 0x45, 0xfd, 0x58, 0xeb, 0xb0, 0xd0, 0xb7, 0x78, 0x70,
+	0x42, 0x14, 0xbe, 0x30, 0xd7, 0xb7, 0x48, 0x64, 0x02, 0xb9, 0x8d, 0x90, 0x3e, 0x8e, 0x69, 0xc5,
+	0x9b, 0xf6, 0x08, 0x80, 0x5a, 0x67, 0xd4, 0xa9, 0x41, 0x15, 0x56, 0x75, 0x6b, 0x95, 0xfa, 0x34,
+	0x62, 0x64, 0x50, 0x07, 0xc7, 0x5c, 0x57, 0xfc, 0x39, 0x72, 0x75, 0x15, 0x33, 0x15, 0x66, 0x93,
+	0xa6, 0xc2, 0x7d, 0xa8, 0x45, 0x4a, 0x66, 0x80, 0x7d, 0xc7, 0xb3, 0xb9, 0xe2, 0xaa, 0x0a, 0x45,
+	0x73, 0x44, 0x4b, 0x33, 0x62, 0x4b, 0xe6, 0x2e, 0x15, 0x5b, 0x02, 0x19, 0x57, 0x48, 0xef, 0xc3,
+	0x4a, 0x74, 0x6e, 0x24, 0x64, 0x08, 0x6b, 0x6f, 0x9e, 0x92, 0x82, 0xe4, 0x11, 0xf2, 0x08, 0xfb,
+	0xdc, 0xe8, 0xfb, 0x19, 0xac, 0x92, 0x26, 0xa6, 0xe3, 0xd3, 0x8b, 0xb9, 0x01, 0xf6, 0x2d, 0xec,
+	0x86, 0x66, 0x17, 0xd7, 0x17, 0x68, 0x6c, 0xd3, 0x72, 0xdf, 0x3c, 0x6f, 0xb2, 0xca, 0x23, 0x59,
+	0x17, 0x19, 0x2d, 0x71, 0x1e, 0x2a, 0x7b, 0x65, 0x5f, 0x54, 0x70, 0xd3, 0x58, 0xd9, 0x2b, 0x13,
+	0x6d, 0xaa, 0xfd, 0xd8, 0x77, 0x64, 0xb4, 0x24, 0x71, 0xe7, 0x1a, 0x2d, 0xe9, 0x84, 0x64, 0x18,
+	0x2d, 0x19, 0x98, 0xdf, 0x84, 0xec, 0x1f, 0xdb, 0x68, 0xf9, 0x01, 0x26, 0x42, 0x1a, 0x2d, 0x93,
+	0xf1, 0xd6, 0x81, 0x8d, 0xa6, 0x6d, 0x33, 0xf7, 0xce, 0xb1, 0x97, 0xde, 0x26, 0x2f, 0xe2, 0x2a,
+	0x41, 0xa8, 0x12, 0x71, 0x15, 0xa7, 0x6b, 0xd7, 0xd6, 0x5c, 0xb8, 0xa7, 0xe3, 0xbe, 0x77, 0xc6,
+	0x9d, 0xc9, 0x4f, 0x7c, 0xaf, 0xff, 0x83, 0xf6, 0xf7, 0xaf, 0x0b, 0x80, 0x64, 0x07, 0x91, 0xdb,
+	0x3f, 0x1d, 0x49, 0x21, 0x1d, 0x49, 0xa4, 0x9c, 0x8a, 0xa9, 0xae, 0xfe, 0x29, 0xd5, 0xd5, 0x9f,
+	0xb8, 0x37, 0x98, 0x1e, 0xb9, 0x37, 0x78, 0x1f, 0xca, 0x5d, 0xec, 0x75, 0xb0, 0x6b, 0x61, 0xf5,
+	0x28, 0x1c, 0x71, 0x81, 0x57, 0xea, 0x12, 0x4c, 0xfb, 0x0b, 0x05, 0x58, 0x1c, 0xa9, 0x27, 0x17,
+	0x1f, 0x64, 0x51, 0x63, 0xbf, 0x5e, 0xc8, 0xb8, 0x27, 0xe7, 0xf5, 0xf4, 0x40, 0x6e, 0xda, 0x0e,
+	0x0f, 0xd3, 0x29, 0xe8, 0xfc, 0x0b, 0x3d, 0x80, 0xd9, 0x81, 0xd7, 0xbb, 0xe8, 0x52, 0x17, 0xd7,
+	0x54, 0x2a, 0x0a, 0x01, 0xa0, 0xf5, 0x60, 0xa3, 0xe5, 0x7e, 0x4b, 0x18, 0x38, 0xca, 0x4e, 0x31,
+	0x67, 0xcf, 0x60, 0x39, 0xe2, 0x2a, 0x85, 0x35, 0x94, 0x9b, 0x81, 0xb8, 0xe6, 0x8e, 0x1a, 0xa3,
+	0xfe, 0x48, 0x99, 0xf6, 0x2b, 0x78, 0x87, 0x5e, 0x15, 0xc4, 0xc1, 0x9f, 0x78, 0x7e, 0xba, 0xb0,
+	0x5c, 0x6a, 0x3a, 0xb5, 0x5f, 0xc3, 0xa6, 0xaa, 0x49, 0x62, 0xb7, 0x01, 0xdf, 0x07, 0xfe, 0x3f,
+	0x07, 0x0f, 0x27, 0xc6, 0xcf, 0xf5, 0xd7, 0x17, 0xb0, 0x92, 0xc6, 0x39, 0x61, 0x0b, 0x64, 0xb1,
+	0x6e, 0x69, 0x94, 0x75, 0x81, 0x76, 0x44, 0xcd, 0x8d, 0x78, 0x47, 0xdb, 0xde, 0x19, 0xf6, 0xcd,
+	0x2e, 0x7e, 0xbd, 0x01, 0xfd, 0xb5, 0x02, 0xd4, 0x23, 0x7c, 0xec, 0xc8, 0x21, 0x30, 0x8e, 0xf3,
+	0xc4, 0x23, 0x98, 0xa6, 0x17, 0x06, 0xec, 0x8a, 0x95, 0xfe, 0x4d, 0x2e, 0x12, 0x7a, 0x9e, 0x6f,
+	0x1a, 0x81, 0xeb, 0xd3, 0xc5, 0x53, 0xd0, 0x67, 0xc9, 0x77, 0xdb, 0x25, 0xa1, 0x8e, 0xd5, 0xc0,
+	0xf5, 0x8d, 0xbe, 0xe9, 0x77, 0x1d, 0xd7, 0xe8, 0xe3, 0x90, 0xc7, 0xb0, 0x2c, 0x04, 0xae, 0xbf,
+	0x4f, 0x0b, 0xf7, 0x71, 0xa8, 0xfd, 0xa6, 0x00, 0x6b, 0x92, 0x20, 0x1e, 0x6f, 0x26, 0xe8, 0xc9,
+	0x54, 0x1c, 0x75, 0x98, 0xb5, 0x08, 0x10, 0xbf, 0xef, 0x2d, 0xeb, 0xe2, 0x13, 0x7d, 0x0c, 0x65,
+	0x4e, 0xb0, 0xf0, 0x78, 0xdd, 0x88, 0x2f, 0xc9, 0xf8, 0x90, 0x75, 0x09, 0xad, 0xfd, 0x9d, 0x02,
+	0xdc, 0xce, 0x61, 0x36, 0x9f, 0xdd, 0xc4, 0xed, 0x48, 0x61, 0xe4, 0x76, 0xe4, 0x11, 0xa5, 0xd9,
+	0xb1, 0x30, 0xf3, 0xc9, 0xcd, 0xb3, 0x40, 0xba, 0x8c, 0x11, 0xea, 0x02, 0x16, 0xbd, 0x05, 0x57,
+	0x87, 0x2e, 0x1f, 0x04, 0xf7, 0x77, 0x32, 0x5d, 0x54, 0x95, 0xc5, 0xd4, 0xe7, 0xa9, 0xfd, 0x87,
+	0x02, 0xac, 0xb7, 0x82, 0xd0, 0xe9, 0xab, 0xdb, 0x0d, 0x77, 0xb9, 0xbe, 0x96, 0x48, 0x10, 0xa7,
+	0x14, 0x57, 0x71, 0x46, 0xe0, 0x7c, 0x27, 0x7c, 0x42, 0xf3, 0xbc, 0xac, 0xed, 0x7c, 0x47, 0x02,
+	0x27, 0xaa, 0x1d, 0xdf, 0xec, 0xf6, 0x31, 0x09, 0xf4, 0x54, 0x88, 0xab, 0x88, 0x52, 0x4a, 0x1b,
+	0xb7, 0xd6, 0xa6, 0xa5, 0xb5, 0x76, 0x17, 0xaa, 0xc4, 0xac, 0xb1, 0x87, 0xe1, 0x85, 0x61, 0x5d,
+	0x58, 0x3d, 0xa6, 0x25, 0x0b, 0xfa, 0x42, 0xdf, 0x3c, 0xdf, 0x19, 0x86, 0x17, 0xdb, 0xa4, 0x4c,
+	0xfb, 0x3d, 0x55, 0x02, 0x44, 0x5c, 0x0a, 0x33, 0x76, 0xc6, 0x5f, 0x83, 0xcf, 0x72, 0x9b, 0xa9,
+	0x5e, 0x1c, 0xe7, 0xd8, 0x9f, 0x35, 0x23, 0x9c, 0x0a, 0x45, 0x4c, 0x68, 0xe7, 0x6c, 0x49, 0xce,
+	0xbf, 0x2a, 0xc2, 0x46, 0x36, 0x83, 0xe5, 0x85, 0x49, 0x85, 0xb9, 0xa6, 0x45, 0xf7, 0x85, 0x71,
+	0xdd, 0x2f, 0x50, 0x78, 0x31, 0xae, 0x8f, 0x14, 0x31, 0x4d, 0x13, 0x93, 0x38, 0x1b, 0x22, 0x29,
+	0x7d, 0xdd, 0xbb, 0x8c, 0x5f, 0xc0, 0x02, 0xb9, 0xef, 0x93, 0x4d, 0xa7, 0xc7, 0x35, 0x9d, 0xef,
+	0x3b, 0xae, 0xf8, 0x20, 0x87, 0xfd, 0x88, 0x63, 0x46, 0x07, 0x9b, 0x81, 0x73, 0xca, 0x27, 0xb3,
+	0xac, 0x2f, 0x4a, 0xd6, 0x3d, 0xe1, 0x15, 0xda, 0x73, 0x1a, 0xc7, 0x2a, 0x07, 0x73, 0xfc, 0x0d,
+	0x0f, 0x1b, 0x7d, 0x2d, 0x8d, 0xf5, 0x37, 0x53, 0x34, 0x96, 0xc0, 0x38, 0xfe, 0xee, 0x70, 0x26,
+	0x08, 0xcd, 0x10, 0x73, 0x5f, 0xfb, 0x72, 0x8c, 0xc7, 0x0c, 0x09, 0xd6, 0x19, 0x08, 0x5a, 0x86,
+	0x19, 0xec, 0xfb, 0x1e, 0x53, 0x63, 0x73, 0x3a, 0xfb, 0x20, 0x9a, 0xc6, 0xc7, 0xa1, 0xef, 0xc8,
+	0x1b, 0x20, 0xf1, 0xa9, 0x75, 0x61, 0x55, 0xa2, 0xa2, 0xf6, 0xbc, 0x24, 0x2a, 0xed, 0x92, 0x17,
+	0x7d, 0x3c, 0x32, 0xe3, 0xa9, 0x8a, 0x49, 0xf2, 0x2a, 0x52, 0x4c, 0x3a, 0x0d, 0xee, 0x4d, 0xe1,
+	0x26, 0x97, 0xc5, 0x2d, 0x28, 0xf1, 0x3b, 0x2a, 0xb6, 0xc3, 0x34, 0x62, 0x78, 0x63, 0xa4, 0xe9,
+	0x1c, 0x52, 0xfb, 0xdb, 0x45, 0x68, 0xb4, 0xa9, 0xd7, 0x39, 0x92, 0xf0, 0xf0, 0x35, 0x37, 0x49,
+	0x74, 0x0b, 0xe6, 0xfb, 0x56, 0xdc, 0x7e, 0x23, 0x37, 0x65, 0x96, 0xa8, 0xbf, 0x0f, 0xb5, 0x3e,
+	0x0d, 0x50, 0x27, 0x81, 0xea, 0xfe, 0xc5, 0x80, 0x5c, 0xf6, 0xb0, 0x53, 0x63, 0xb5, 0x6f, 0xd1,
+	0x88, 0x54, 0x5e, 0x4a, 0xcf, 0x96, 0xe6, 0xb9, 0xd1, 0xb7, 0x0c, 0xf5, 0x04, 0x49, 0x2e, 0xdd,
+	0xf6, 0x2d, 0x72, 0xa1, 0x8e, 0x3e, 0x83, 0x05, 0x71, 0xf3, 0x44, 0x97, 0xdd, 0xf8, 0x20, 0xa7,
+	0x79, 0x0e, 0x4f, 0x4a, 0x08, 0x25, 0x6a, 0x73, 0xc3, 0x1b, 0x86, 0xfc, 0x70, 0x59, 0x55, 0xc0,
+	0x0e, 0x87, 0xa1, 0x76, 0x00, 0xb7, 0x9e, 0xe2, 0x04, 0x77, 0xde, 0x44, 0x8a, 0xff, 0x79, 0x01,
+	0x1a, 0x89, 0x4d, 0x40, 0xc1, 0x99, 0xbd, 0xd3, 0xfd, 0x34, 0x2e, 0xc1, 0x6b, 0xb1, 0xb9, 0x95,
+	0x18, 0xc6, 0x08, 0xf1, 0x1b, 0xb8, 0x78, 0xfe, 0xb0, 0x40, 0x9d, 0x24, 0xe9, 0x8c, 0xe0, 0x02,
+	0x98, 0x98, 0xff, 0x42, 0x72, 0xfe, 0x93, 0x93, 0x56, 0xbc, 0xdc, 0xa4, 0x7d, 0x1c, 0xed, 0xa8,
+	0xca, 0x1d, 0x56, 0x36, 0x33, 0xe5, 0xa6, 0x4a, 0xc2, 0xb1, 0x2b, 0x6d, 0x6c, 0x0d, 0x49, 0xec,
+	0x4b, 0xeb, 0x0c, 0xbb, 0x21, 0xda, 0x84, 0x69, 0x45, 0x5d, 0xe7, 0x91, 0x40, 0xe1, 0x88, 0xc9,
+	0x43, 0x1d, 0x16, 0xdc, 0xc3, 0x4b, 0xfe, 0x46, 0xef, 0x41, 0x39, 0xc0, 0x67, 0x98, 0x20, 0xad,
+	0x4f, 0x45, 0x7a, 0x45, 0x74, 0xd4, 0xe6, 0x75, 0xba, 0x84, 0x52, 0x67, 0x77, 0x3a, 0xf3, 0xa1,
+	0xc8, 0x4c, 0x3c, 0x5c, 0x68, 0x15, 0x4a, 0x81, 0x37, 0xf4, 0x2d, 0xf6, 0xbc, 0x69, 0x4e, 0xe7,
+	0x5f, 0x44, 0x21, 0xf5, 0x71, 0x10, 0x10, 0xdf, 0xc0, 0x2c, 0xad, 0x10, 0x9f, 0xda, 0x5f, 0x2c,
+	0xf0, 0x37, 0xb9, 0xca, 0x80, 0xa5, 0xb4, 0x2e, 0xc3, 0x4c, 0xcf, 0xe9, 0x3b, 0x42, 0x27, 0xb1,
+	0x0f, 0xf4, 0x11, 0xdb, 0x16, 0xe4, 0x70, 0x8a, 0x39, 0xc3, 0x21, 0x3b, 0x42, 0x3b, 0x65, 0x44,
+	0x53, 0xb1, 0x40, 0x98, 0x27, 0xfc, 0xa9, 0x6f, 0x9c, 0x06, 0x19, 0x90, 0x53, 0xc2, 0xb4, 0x84,
+	0x6b, 0xaa, 0x45, 0xb5, 0x23, 0x0a, 0xab, 0x73, 0x00, 0xed, 0xff, 0x14, 0x60, 0x59, 0xda, 0x6a,
+	0x6e, 0xe8, 0x3b, 0xa7, 0x43, 0xb2, 0x15, 0xbd, 0x49, 0xc0, 0xe0, 0x7b, 0xb0, 0xcc, 0x02, 0x2c,
+	0x79, 0x18, 0x9f, 0x1f, 0xbb, 0x57, 0x46, 0xb4, 0x8e, 0x07, 0xf2, 0xf9, 0xcc, 0x9e, 0xd9, 0x84,
+	0x25, 0x12, 0xdc, 0x92, 0x6c, 0xc0, 0x6c, 0x9f, 0x45, 0x52, 0x15, 0x87, 0xbf, 0x0d, 0x0b, 0x22,
+	0x80, 0x9e, 0x02, 0x32, 0xf5, 0x35, 0xcf, 0xca, 0x18, 0xc8, 0x3d, 0x25, 0x52, 0x82, 0x01, 0x31,
+	0xe7, 0xbd, 0x0c, 0x8a, 0x60, 0x56, 0xde, 0xff, 0x2a, 0x50, 0xfd, 0x93, 0xc6, 0x81, 0x3f, 0xfd,
+	0x11, 0x82, 0x6d, 0x58, 0xcf, 0x1c, 0x3b, 0x97, 0xa4, 0xf7, 0x12, 0x91, 0x82, 0x75, 0xe5, 0x06,
+	0x25, 0xde, 0x82, 0xc3, 0x69, 0x8f, 0x45, 0x64, 0xd0, 0xeb, 0xf3, 0x54, 0xfb, 0xaf, 0x64, 0x85,
+	0x8d, 0x36, 0x7f, 0x3d, 0xd5, 0x32, 0x26, 0x68, 0xe5, 0x21, 0xd7, 0x3c, 0x53, 0xd1, 0x6b, 0x9c,
+	0x94, 0xae, 0xa9, 0xbf, 0x94, 0x02, 0x52, 0x1b, 0x3d, 0x26, 0xde, 0xfc, 0xb8, 0x55, 0x89, 0x09,
+	0x36, 0xb9, 0x3c, 0x8a, 0xc9, 0x34, 0xb7, 0xe2, 0x16, 0x54, 0x69, 0xd6, 0xfe, 0x4b, 0x11, 0x6a,
+	0xba, 0x67, 0xf6, 0x1d, 0xb7, 0xdb, 0xec, 0xfa, 0x18, 0xf7, 0x31, 0xb3, 0xee, 0x63, 0x1e, 0xe2,
+	0x15, 0x28, 0xb9, 0x38, 0x8c, 0x88, 0x9f, 0x71, 0x71, 0xb8, 0x6b, 0x53, 0xc5, 0x85, 0x7d, 0x82,
+	0x79, 0x8a, 0x2b, 0x2e, 0xfa, 0x45, 0x4e, 0x38, 0x03, 0x33, 0x08, 0xc8, 0xc3, 0x1c, 0x9f, 0xa1,
+	0xe6, 0x04, 0x56, 0x79, 0x31, 0xef, 0x90, 0x5c, 0x51, 0xbd, 0x20, 0x4f, 0x43, 0xc8, 0x82, 0x13,
+	0x90, 0x8c, 0xc8, 0xab, 0xa2, 0x5c, 0x80, 0xb6, 0xa1, 0x9e, 0xc0, 0x69, 0xf4, 0x9c, 0x0e, 0xa6,
+	0xf3, 0x50, 0x1a, 0x67, 0xe2, 0xae, 0xc6, 0xfb, 0xdd, 0xe3, 0x0d, 0xc9, 0xc5, 0xf1, 0xa9, 0xd3,
+	0xeb, 0x11, 0x64, 0xf2, 0x49, 0x29, 0xd7, 0xb5, 0x35, 0x5e, 0xa1, 0x8b, 0x72, 0xf4, 0x29, 0x5c,
+	0x4b, 0x52, 0x40, 0x77, 0xe2, 0x1e, 0xe6, 0x0f, 0x00, 0xca, 0xfa, 0x5a, 0xbc, 0x9f, 0xb6, 0xa8,
+	0xd6, 0x4e, 0x45, 0x54, 0x50, 0x92, 0xd5, 0xca, 0xfb, 0x38, 0x81, 0xd4, 0x14, 0x75, 0xea, 0x93,
+	0xb2, 0x91, 0x76, 0x35, 0x3f, 0x51, 0xa2, 0xbd, 0x07, 0xb7, 0xb2, 0xfa, 0xc8, 0xf0, 0xe4, 0xbe,
+	0x4b, 0x23, 0x76, 0xb2, 0x48, 0x4a, 0x42, 0xff, 0xa7, 0x02, 0x5c, 0x4f, 0x05, 0x8f, 0x5e, 0xc5,
+	0xbd, 0xe1, 0x10, 0x7e, 0x24, 0x9f, 0xee, 0x29, 0xdc, 0x14, 0xef, 0xf9, 0x7f, 0xb0, 0xc9, 0x79,
+	0x08, 0x37, 0xc5, 0xbb, 0xfe, 0xc9, 0xb8, 0xbd, 0x07, 0x37, 0xf6, 0x9c, 0x60, 0x84, 0xdb, 0x63,
+	0x76, 0xf9, 0x55, 0x28, 0x79, 0x9d, 0x4e, 0x80, 0xc5, 0x56, 0xc7, 0xbf, 0x34, 0x17, 0x6e, 0x66,
+	0x60, 0x8b, 0x9c, 0x1d, 0xa1, 0x17, 0x9a, 0x3d, 0xbe, 0x53, 0x31, 0xa4, 0x40, 0x8b, 0xd8, 0x6e,
+	0xf6, 0xae, 0x54, 0xc3, 0xec, 0x48, 0x93, 0x3e, 0x70, 0xa1, 0x82, 0xbf, 0x06, 0x8d, 0xfb, 0x1d,
+	0xb7, 0xd5, 0x67, 0xd7, 0x3c, 0x60, 0x74, 0xac, 0xb7, 0xb8, 0x0e, 0xb3, 0xf1, 0x10, 0x6e, 0xf1,
+	0xa9, 0xfd, 0x79, 0xa8, 0xeb, 0xd8, 0x76, 0x82, 0xe7, 0xf8, 0x82, 0xbe, 0x55, 0xdc, 0xc7, 0x7d,
+	0xcf, 0xbf, 0x38, 0x21, 0x56, 0x11, 0xb9, 0xc5, 0x26, 0x27, 0x0f, 0xf5, 0xdd, 0x63, 0xf9, 0x25,
+	0x87, 0x23, 0xe6, 0x1d, 0x0d, 0x60, 0x23, 0xf8, 0xa6, 0x74, 0xfa, 0x37, 0xe1, 0xe1, 0xe9, 0x45,
+	0x88, 0x59, 0x54, 0xdb, 0x94, 0xce, 0x3e, 0x08, 0x1a, 0xcb, 0x1c, 0x18, 0xac, 0x66, 0x9a, 0xd6,
+	0x94, 0x2d, 0x73, 0xf0, 0x98, 0x7c, 0x6b, 0xff, 0x82, 0x2f, 0x02, 0x42, 0x83, 0xd2, 0xb7, 0xe4,
+	0xe3, 0x27, 0x00, 0x81, 0x49, 0xa2, 0xda, 0xa8, 0x18, 0x4e, 0x60, 0xb4, 0x70, 0xe8, 0x26, 0xf5,
+	0x41, 0x0f, 0x03, 0x6c, 0x1b, 0x7d, 0x8a, 0x96, 0x13, 0x0a, 0xa4, 0x88, 0x75, 0x84, 0x3e, 0x83,
+	0x79, 0x39, 0x3e, 0x1c, 0xf3, 0x79, 0x65, 0xb1, 0x44, 0x07, 0x31, 0x7e, 0x1c, 0x68, 0xff, 0xb3,
+	0x28, 0xc3, 0x79, 0xb6, 0xd5, 0x80, 0xa5, 0xc9, 0xce, 0xd7, 0x89, 0x0b, 0x69, 0x25, 0xcc, 0xed,
+	0x03, 0x71, 0x6e, 0x61, 0xfb, 0xd7, 0xcd, 0xf8, 0xfe, 0x15, 0xef, 0x47, 0x9e, 0x5e, 0x5e, 0xff,
+	0x9c, 0x42, 0xcf, 0x18, 0xd6, 0x0b, 0x6c, 0x0f, 0x39, 0x93, 0x27, 0x39, 0x18, 0x0a, 0x78, 0x16,
+	0x0e, 0x18, 0x60, 0x37, 0x24, 0x2d, 0x4b, 0x63, 0x5b, 0x96, 0x08, 0x28, 0xd3, 0x2e, 0xe6, 0x60,
+	0xd0, 0x73, 0x58, 0x8f, 0xb3, 0xe3, 0xc9, 0xe5, 0xd0, 0xcd, 0x50, 0x6b, 0xd1, 0x78, 0xf1, 0x6c,
+	0xc6, 0x4f, 0x68, 0x90, 0x18, 0x70, 0x6f, 0x0c, 0x1a, 0x2e, 0x81, 0x1f, 0xca, 0xd7, 0xbd, 0x4c,
+	0xfa, 0x6e, 0xe5, 0xcd, 0x47, 0xf4, 0xc0, 0x57, 0xc3, 0xf0, 0x28, 0xb7, 0x83, 0x28, 0xba, 0x3a,
+	0x11, 0x4c, 0x93, 0xfe, 0x9a, 0xaf, 0x90, 0xfe, 0x9a, 0x4f, 0x1b, 0xc0, 0x87, 0x97, 0xed, 0x26,
+	0x1a, 0x58, 0xcc, 0x10, 0x1c, 0x3b, 0x30, 0xae, 0x8b, 0x7e, 0xbf, 0x40, 0x1e, 0x8e, 0x5b, 0x9e,
+	0x8d, 0x8f, 0x9e, 0xfd, 0x72, 0xf4, 0x69, 0xe7, 0xe0, 0xc5, 0x45, 0xf2, 0x69, 0xe7, 0xe0, 0x85,
+	0x78, 0x02, 0xaa, 0xaa, 0xa8, 0x62, 0x4c, 0x45, 0x11, 0x47, 0x19, 0xa6, 0xce, 0x0c, 0x43, 0xbd,
+	0x39, 0x9a, 0xe2, 0x8e, 0x32, 0x56, 0xf5, 0x24, 0xf6, 0xf0, 0x24, 0x3c, 0x37, 0xa4, 0xcf, 0x74,
+	0x3a, 0x3c, 0xdf, 0xf1, 0x79, 0xa1, 0xf5, 0x82, 0x9f, 0x0c, 0xa6, 0xc3, 0xf3, 0xed, 0x17, 0xda,
+	0xdf, 0x2a, 0x42, 0x7d, 0x94, 0x5e, 0xce, 0x84, 0x0d, 0x28, 0xb1, 0xd7, 0x02, 0x3c, 0xe0, 0x4e,
+	0x79, 0x2c, 0x30, 0x43, 0x1f, 0x0b, 0xd0, 0x9b, 0xf1, 0x68, 0x48, 0xc6, 0xef, 0x06, 0x72, 0xc5,
+	0x56, 0xa3, 0x71, 0x7d, 0x11, 0xc4, 0x93, 0x1a, 0xc4, 0x4e, 0x76, 0x44, 0x03, 0xf6, 0x1d, 0xcb,
+	0x38, 0x33, 0x7b, 0xfc, 0x19, 0x6d, 0x59, 0x2f, 0xf7, 0x1d, 0xeb, 0x2b, 0xf2, 0x1d, 0xb9, 0xbc,
+	0x66, 0x14, 0x97, 0x17, 0xbd, 0xd5, 0x56, 0x1e, 0x0a, 0xf0, 0xf1, 0x63, 0x9b, 0xbf, 0x16, 0x58,
+	0x56, 0x5e, 0x0b, 0xec, 0x88, 0x3a, 0xb4, 0x05, 0x2b, 0x0a, 0xef, 0x94, 0x46, 0x2c, 0x65, 0xc7,
+	0x52, 0x74, 0xff, 0x26, 0xdb, 0x68, 0x3f, 0xa7, 0x36, 0x4b, 0x9b, 0x2f, 0x68, 0xff, 0xb1, 0x69,
+	0xbd, 0xec, 0x79, 0xdd, 0x09, 0x17, 0xd1, 0x2b, 0x58, 0x7a, 0x4c, 0xc3, 0x9a, 0x58, 0x6c, 0x00,
+	0x6f, 0x9c, 0xf9, 0x4a, 0xb6, 0x70, 0xf9, 0x57, 0xb2, 0x64, 0x4f, 0x61, 0x77, 0x40, 0x6c, 0x03,
+	0x66, 0x1f, 0xda, 0xbf, 0x2d, 0xc2, 0xf5, 0x54, 0xb2, 0x65, 0x22, 0x90, 0x0a, 0x55, 0xeb, 0x86,
+	0x25, 0x6f, 0x90, 0xe8, 0x79, 0x92, 0x16, 0x6e, 0xd3, 0x1b, 0x22, 0xf4, 0x13, 0xb8, 0x2a, 0x60,
+	0xa2, 0x6b, 0x07, 0x7a, 0xa0, 0x64, 0x50, 0xcc, 0x3b, 0x42, 0xde, 0xb9, 0xaf, 0x32, 0xb8, 0x53,
+	0x83, 0x07, 0x75, 0xb1, 0xf8, 0x08, 0xb1, 0x63, 0xd0, 0xc3, 0x61, 0x0a, 0x1b, 0xf4, 0x25, 0xda,
+	0xec, 0xb1, 0x5a, 0x15, 0x10, 0x39, 0x8f, 0x7c, 0x5f, 0xb6, 0xbc, 0xe1, 0x62, 0x52, 0xbc, 0x28,
+	0xab, 0x76, 0xf8, 0x3d, 0x16, 0xb1, 0x92, 0x23, 0xf8, 0x48, 0x4f, 0xb3, 0x56, 0x4c, 0x64, 0xd6,
+	0x24, 0x80, 0xe0, 0x87, 0xcd, 0xda, 0xde, 0x85, 0x6a, 0x78, 0x4e, 0xde, 0xe7, 0x1b, 0x03, 0xec,
+	0x92, 0x98, 0x4d, 0xee, 0xb1, 0x5b, 0x08, 0xcf, 0x9b, 0xd6, 0xcb, 0x23, 0x56, 0xa6, 0x7d, 0x02,
+	0x75, 0xea, 0xcf, 0xe4, 0x4b, 0x7f, 0xc7, 0x37, 0x1d, 0x77, 0xc2, 0xf9, 0xff, 0x18, 0xd6, 0xda,
+	0xa1, 0x37, 0x78, 0x8d, 0x96, 0x9f, 0x51, 0xcf, 0xac, 0xda, 0xf0, 0x52, 0xda, 0xfb, 0x7f, 0x17,
+	0xe0, 0x66, 0x46, 0x7b, 0x2e, 0x01, 0x0d, 0x28, 0xdb, 0xa4, 0x98, 0x8c, 0x9a, 0x85, 0xc7, 0xcb,
+	0x6f, 0x6a, 0x54, 0x90, 0x11, 0x4f, 0x6c, 0x16, 0x73, 0xe8, 0x66, 0x98, 0xc2, 0xd2, 0xa9, 0x51,
+	0x96, 0x92, 0x85, 0x98, 0x7e, 0x91, 0xc9, 0xa6, 0x39, 0xed, 0xc2, 0x12, 0xbd, 0x0d, 0x8b, 0x81,
+	0xd9, 0xc1, 0x46, 0xe8, 0x19, 0x03, 0xef, 0x15, 0xf6, 0x0d, 0xaf, 0xd3, 0xe1, 0x87, 0xb7, 0x2a,
+	0xa9, 0x38, 0xf6, 0x8e, 0x48, 0xf1, 0x61, 0xa7, 0xa3, 0xfd, 0x71, 0x11, 0x6a, 0x7c, 0xe8, 0xe4,
+	0x21, 0xbb, 0x1b, 0x12, 0x6b, 0x66, 0x8c, 0xbd, 0xb1, 0x0c, 0x33, 0x7d, 0xcf, 0xc6, 0x3d, 0xae,
+	0xbb, 0xd8, 0x07, 0x39, 0x30, 0x92, 0xe7, 0x77, 0xaf, 0x4c, 0x1f, 0xcb, 0x88, 0x71, 0x76, 0xf6,
+	0xbc, 0x2a, 0xca, 0x45, 0x34, 0xd5, 0x3d, 0xa8, 0x9e, 0xfa, 0x8e, 0xdd, 0x8d, 0x00, 0x59, 0x5c,
+	0x7b, 0x85, 0x95, 0x0a, 0x30, 0x96, 0x48, 0xc2, 0xc2, 0x6e, 0xe8, 0x9b, 0xa1, 0xe7, 0x4b, 0xe0,
+	0x19, 0x0a, 0xbc, 0xa4, 0xd6, 0x7d, 0x15, 0x45, 0x63, 0x29, 0xb6, 0x4b, 0xe9, 0x32, 0xb6, 0x8b,
+	0x06, 0x15, 0xd7, 0xa3, 0x1a, 0x26, 0x74, 0xe8, 0x69, 0x97, 0x69, 0xba, 0x79, 0xd7, 0x7b, 0x3a,
+	0x08, 0x8e, 0x69, 0x11, 0xfa, 0x08, 0xea, 0xf4, 0x2a, 0x4d, 0xf8, 0x8e, 0xd8, 0x7c, 0xd8, 0x78,
+	0x10, 0xbe, 0xe0, 0x21, 0x4e, 0x24, 0xe8, 0x48, 0x3c, 0xb5, 0xa1, 0x33, 0xb2, 0x43, 0x2a, 0xb9,
+	0x6a, 0x4c, 0x32, 0x7a, 0x42, 0x09, 0xfd, 0x12, 0xae, 0xa7, 0x36, 0x96, 0x37, 0x0f, 0x73, 0x8e,
+	0x28, 0x54, 0x8f, 0x3e, 0x23, 0x0d, 0x22, 0x30, 0xcd, 0x84, 0xeb, 0xe4, 0xd0, 0x91, 0x45, 0xd0,
+	0xa5, 0x4e, 0x30, 0x91, 0x3c, 0x4c, 0x29, 0xf2, 0xa0, 0xf5, 0xe1, 0x46, 0x7a, 0x17, 0x6f, 0x74,
+	0xac, 0x19, 0x41, 0x27, 0x4c, 0x89, 0xbf, 0x42, 0x5e, 0xf1, 0x4a, 0x8b, 0xc3, 0xc5, 0x96, 0xb4,
+	0x6b, 0xc7, 0x89, 0x33, 0x19, 0x16, 0x99, 0x2f, 0xcc, 0x6f, 0xb1, 0xf9, 0xd7, 0x9b, 0x1c, 0x5b,
+	0x9b, 0x34, 0x62, 0x20, 0x9d, 0x9c, 0x09, 0x27, 0xfd, 0x04, 0x6e, 0xe7, 0xa0, 0x90, 0x0e, 0x38,
+	0x6e, 0xdf, 0x8b, 0xd3, 0x4c, 0xcc, 0xec, 0x8a, 0x35, 0x61, 0x80, 0xda, 0x10, 0x34, 0x65, 0x56,
+	0x12, 0x40, 0xaf, 0x77, 0x82, 0x25, 0x0e, 0x57, 0xaf, 0xd3, 0x21, 0x3c, 0x63, 0xaf, 0x10, 0x99,
+	0xa1, 0x35, 0xcf, 0xcb, 0xe8, 0x0b, 0xc4, 0xef, 0xe0, 0x4e, 0x6e, 0xb7, 0x93, 0xca, 0xc4, 0x56,
+	0x42, 0x26, 0xf2, 0x46, 0x2c, 0x24, 0xe3, 0x9f, 0x14, 0xe1, 0x5a, 0xeb, 0x1c, 0x5b, 0x12, 0x2c,
+	0x76, 0xd0, 0x1d, 0x7f, 0xb6, 0xe2, 0x96, 0x93, 0x38, 0x5b, 0xf1, 0x4f, 0xc2, 0xa3, 0x20, 0xb4,
+	0x1d, 0x97, 0x1b, 0x68, 0xec, 0x03, 0x1d, 0xc1, 0x3c, 0x76, 0xcf, 0x1c, 0xdf, 0x73, 0xa9, 0x27,
+	0x82, 0x45, 0x96, 0x6f, 0x12, 0x2a, 0x33, 0x49, 0xd8, 0x6c, 0x45, 0x0d, 0x5a, 0x6e, 0xe8, 0x5f,
+	0xe8, 0x2a, 0x0a, 0x72, 0x28, 0xe2, 0x29, 0x74, 0xea, 0x33, 0xe3, 0x8c, 0x1e, 0x01, 0xd9, 0xf8,
+	0x1c, 0x6a, 0x49, 0xac, 0x24, 0x15, 0x0a, 0x89, 0x14, 0x65, 0xa7, 0x6f, 0xf2, 0x27, 0x19, 0xc2,
+	0x99, 0xd9, 0x1b, 0x8a, 0x8b, 0x15, 0xf6, 0xf1, 0x69, 0xf1, 0xe3, 0x82, 0xf6, 0x2d, 0x34, 0xd2,
+	0xe8, 0xe5, 0xd3, 0xb4, 0x06, 0xb3, 0xf8, 0x1c, 0x5b, 0x4a, 0xc2, 0x0c, 0xf2, 0xb9, 0x6b, 0xa3,
+	0x4f, 0xa1, 0xec, 0x73, 0x20, 0xbe, 0x17, 0xde, 0x22, 0x6f, 0x0f, 0xe3, 0x68, 0x08, 0x62, 0x81,
+	0x4a, 0x97, 0xf0, 0x5a, 0x18, 0x3f, 0x8c, 0x8d, 0x82, 0x46, 0x9e, 0x89, 0xf4, 0xce, 0x15, 0x46,
+	0x15, 0x27, 0x65, 0x94, 0x66, 0xc1, 0xbd, 0x31, 0xbd, 0xf2, 0x31, 0xab, 0x43, 0x2b, 0x5c, 0x72,
+	0x68, 0xff, 0xb4, 0x00, 0x8b, 0x62, 0x53, 0x38, 0xfe, 0x46, 0xec, 0xec, 0xcb, 0x30, 0x13, 0x7a,
+	0x2f, 0xb1, 0x88, 0x28, 0x66, 0x1f, 0x64, 0x09, 0xc8, 0xed, 0x45, 0x3a, 0x75, 0x41, 0x14, 0xed,
+	0xda, 0x6f, 0x12, 0x64, 0xfe, 0x0e, 0xcc, 0x86, 0xe7, 0x86, 0xe3, 0x76, 0xbc, 0xfa, 0x74, 0xf4,
+	0xae, 0x34, 0xa2, 0x6c, 0xd7, 0xed, 0x78, 0x7a, 0x29, 0x3c, 0x27, 0xff, 0xc7, 0x75, 0x58, 0x04,
+	0x73, 0x19, 0x1d, 0x36, 0x84, 0xdb, 0x39, 0x28, 0xa2, 0x35, 0xaf, 0x6e, 0xa3, 0x7c, 0xcd, 0x7f,
+	0x2b, 0xf7, 0x4e, 0xf4, 0x10, 0x66, 0x85, 0x81, 0xc4, 0x16, 0x3d, 0x0d, 0xef, 0x1b, 0xe1, 0xa7,
+	0x2e, 0xa0, 0xb4, 0x5f, 0xc1, 0xb5, 0x44, 0x9f, 0xd1, 0x4e, 0x3c, 0x6e, 0xbd, 0x27, 0xa8, 0x29,
+	0x26, 0xa9, 0xd1, 0x3e, 0x83, 0x7b, 0x8a, 0x26, 0x1b, 0xed, 0x20, 0x5f, 0x87, 0x6a, 0x06, 0xfc,
+	0x64, 0x5c, 0x73, 0xce, 0x97, 0x47, 0x89, 0x33, 0xb5, 0xea, 0xbc, 0x19, 0x6d, 0x27, 0xb5, 0xdd,
+	0x1f, 0x94, 0x60, 0x45, 0x4d, 0xd6, 0x20, 0xae, 0x9a, 0xf0, 0x0f, 0x93, 0xe4, 0xa3, 0x72, 0xf9,
+	0x24, 0x1f, 0x95, 0x4b, 0x27, 0xf9, 0xa8, 0x5c, 0x2e, 0xc9, 0x47, 0x65, 0x34, 0xc9, 0x07, 0xea,
+	0x43, 0x5d, 0x21, 0x89, 0x84, 0x83, 0x47, 0x11, 0xec, 0x25, 0xca, 0xdf, 0x9f, 0x25, 0xd3, 0x5c,
+	0x48, 0xce, 0x6d, 0xea, 0x02, 0xdb, 0x11, 0xf6, 0xe5, 0xf3, 0x00, 0xa6, 0xaa, 0x57, 0xfc, 0xb4,
+	0x3a, 0xd2, 0x5d, 0x98, 0xd5, 0xdd, 0xec, 0xb8, 0xee, 0x8e, 0x73, 0xba, 0x0b, 0x53, 0xbb, 0xb3,
+	0x61, 0x25, 0xd1, 0x1d, 0xf7, 0x33, 0x95, 0x69, 0x5f, 0xef, 0x4f, 0xd6, 0x17, 0x3b, 0xf6, 0xb0,
+	0x8e, 0x50, 0x38, 0x52, 0xd1, 0x78, 0x06, 0x8d, 0x6c, 0x4e, 0xa8, 0xdb, 0x4b, 0x25, 0x65, 0x7b,
+	0xa9, 0x28, 0xdb, 0x0b, 0xc1, 0x74, 0xfc, 0xfd, 0x60, 0x6a, 0xc1, 0x5a, 0xc6, 0x10, 0xc6, 0xed,
+	0x77, 0x2a, 0x1a, 0xed, 0xff, 0x15, 0x54, 0x6d, 0x17, 0xe7, 0xd1, 0xa4, 0x77, 0xbd, 0x8f, 0x12,
+	0x77, 0xbd, 0xf9, 0x41, 0x61, 0x7f, 0xc2, 0x6e, 0x7b, 0xbf, 0x82, 0xdb, 0x39, 0xe3, 0xe7, 0x2a,
+	0xe9, 0xfd, 0x84, 0x4a, 0xba, 0x96, 0x29, 0x57, 0x52, 0x1d, 0xfd, 0xdf, 0x62, 0x74, 0x59, 0xcb,
+	0x5e, 0xce, 0x1d, 0x78, 0x4e, 0x80, 0x9f, 0xf4, 0x3c, 0x2f, 0xf1, 0x8e, 0xa4, 0x90, 0xfb, 0xe4,
+	0xb4, 0x98, 0x7c, 0x72, 0xba, 0x0e, 0xf3, 0x2e, 0xc1, 0x64, 0x74, 0x08, 0x2a, 0x1e, 0x40, 0x08,
+	0x6e, 0x84, 0x9c, 0xe4, 0x3d, 0x64, 0x3d, 0x1a, 0xc3, 0xd0, 0xe9, 0xf1, 0x6c, 0x6d, 0x94, 0x31,
+	0x05, 0x1d, 0xf1, 0xaa, 0x93, 0xa8, 0x06, 0x7d, 0x05, 0x55, 0xfa, 0x66, 0xf7, 0x85, 0x13, 0x84,
+	0x5e, 0xd7, 0x37, 0xfb, 0xf4, 0x9d, 0xea, 0xfc, 0xd6, 0x43, 0xd5, 0xc6, 0x4c, 0x8e, 0x61, 0x53,
+	0x0f, 0x02, 0xe7, 0x99, 0x68, 0xc1, 0xd6, 0x4e, 0xc5, 0x57, 0xcb, 0xde, 0xe0, 0x4c, 0xda, 0xf8,
+	0x6d, 0x40, 0xa3, 0xf8, 0x55, 0xc1, 0x5e, 0x1c, 0x27, 0xd8, 0xbf, 0x50, 0xcf, 0x8e, 0x11, 0xd9,
+	0x13, 0x6e, 0xe0, 0xc7, 0x70, 0x23, 0xbd, 0xb5, 0x7c, 0x64, 0x15, 0x17, 0x88, 0x1b, 0x79, 0xac,
+	0x12, 0x32, 0xf1, 0xe0, 0xb7, 0xa0, 0x96, 0x8c, 0x6b, 0x41, 0xb3, 0x30, 0xb5, 0x77, 0xf8, 0x75,
+	0xed, 0x0a, 0x02, 0x28, 0xed, 0xb7, 0x76, 0x76, 0x4f, 0xf6, 0x6b, 0x05, 0x54, 0x86, 0xe9, 0x67,
+	0xbb, 0x4f, 0x9f, 0xd5, 0x8a, 0x68, 0x01, 0xca, 0xdb, 0xfa, 0xee, 0xf1, 0xee, 0x76, 0x73, 0xaf,
+	0x36, 0xf5, 0xe0, 0x03, 0x58, 0xcb, 0xb8, 0x85, 0x27, 0xcd, 0x4f, 0x8e, 0xf6, 0x76, 0x0f, 0x9e,
+	0xd7, 0xae, 0x90, 0x46, 0x3b, 0x87, 0x5f, 0x1f, 0xd0, 0xaf, 0xc2, 0x83, 0xdf, 0x14, 0xe0, 0x5a,
+	0x96, 0x4b, 0x9a, 0x24, 0x3c, 0x5c, 0xd9, 0x3e, 0x3c, 0x78, 0xb2, 0xfb, 0xf4, 0x44, 0x6f, 0x1e,
+	0xef, 0x1e, 0x1e, 0x18, 0x27, 0x07, 0xcf, 0x0f, 0x0e, 0xbf, 0x3e, 0xa8, 0x5d, 0x41, 0xd7, 0x61,
+	0x2d, 0x5e, 0xd5, 0xde, 0x7e, 0xd6, 0xda, 0x39, 0xd9, 0x6b, 0xed, 0xd4, 0x0a, 0x68, 0x15, 0x50,
+	0xa2, 0xb2, 0x75, 0x70, 0x5c, 0x2b, 0x8e, 0xe2, 0x6b, 0x1e, 0x1d, 0xed, 0xed, 0xb6, 0x76, 0x6a,
+	0x53, 0x0f, 0x6e, 0x40, 0x59, 0xff, 0x86, 0x3f, 0x45, 0x9f, 0x85, 0x29, 0xfd, 0x9b, 0xf7, 0x6b,
+	0x57, 0xd8, 0x1f, 0x5b, 0xb5, 0xc2, 0x83, 0x17, 0xb0, 0xc6, 0xbc, 0x85, 0x23, 0xf9, 0x3e, 0x51,
+	0x1d, 0x96, 0xb7, 0xf7, 0x9a, 0xed, 0xb6, 0xf1, 0xac, 0xd5, 0xdc, 0x3b, 0x7e, 0xa6, 0x90, 0xb8,
+	0x04, 0x57, 0x63, 0x35, 0x87, 0xcf, 0x6b, 0x05, 0x74, 0x0b, 0x1a, 0xb1, 0xc2, 0xfd, 0xdd, 0x36,
+	0xfd, 0xde, 0x7d, 0x42, 0xe8, 0x28, 0x3e, 0xe8, 0xc1, 0x52, 0x4a, 0x1c, 0x0a, 0xe1, 0x60, 0xbb,
+	0xb5, 0x7d, 0x78, 0xb0, 0xc3, 0x27, 0x63, 0xf7, 0xe0, 0xe4, 0xb8, 0xc5, 0x27, 0xe3, 0xf0, 0x44,
+	0xaf, 0x15, 0x09, 0xad, 0x3b, 0xcd, 0x5f, 0xd6, 0xa6, 0x48, 0xd1, 0xd7, 0xad, 0xd6, 0xf3, 0xda,
+	0x34, 0x9a, 0x83, 0x99, 0xfd, 0xc3, 0x83, 0xe3, 0x67, 0xb5, 0x19, 0x34, 0x0f, 0xb3, 0x5f, 0x9e,
+	0x34, 0xf5, 0xe3, 0x96, 0x5e, 0x2b, 0x11, 0x88, 0x5f, 0xb6, 0x9a, 0x7a, 0x6d, 0xf6, 0xc1, 0x1f,
+	0x14, 0x60, 0x86, 0x3a, 0xc3, 0x51, 0x0d, 0x16, 0xbe, 0x38, 0xdc, 0x3d, 0x30, 0xf4, 0xd6, 0x97,
+	0x27, 0xad, 0xf6, 0x71, 0xed, 0x0a, 0xba, 0x0a, 0xf3, 0xb4, 0xa4, 0xb9, 0xbd, 0xdd, 0x3a, 0x3a,
+	0xae, 0x15, 0xd0, 0x1a, 0x2c, 0x9d, 0x1c, 0x50, 0xfe, 0xe9, 0xfb, 0xad, 0x1d, 0x63, 0xa7, 0x79,
+	0xdc, 0x34, 0x4e, 0x8e, 0x18, 0x5b, 0x47, 0x2a, 0xc8, 0x1c, 0xd7, 0xa6, 0xd0, 0x0a, 0x2c, 0x8e,
+	0xb6, 0x98, 0x26, 0xa8, 0xd2, 0xe0, 0x67, 0x10, 0x82, 0xaa, 0xde, 0x8a, 0x11, 0x52, 0x22, 0x84,
+	0x1c, 0xe9, 0x87, 0x47, 0xfa, 0x6e, 0xeb, 0xb8, 0xa9, 0xff, 0xb2, 0x36, 0xfb, 0xe0, 0xa7, 0xb0,
+	0x92, 0x9a, 0x8c, 0x83, 0x0c, 0xec, 0x8b, 0xf6, 0xe1, 0x01, 0xe3, 0xd1, 0xd1, 0x76, 0xf3, 0xe8,
+	0xe0, 0x69, 0xad, 0xf0, 0x60, 0x53, 0x79, 0x1b, 0x23, 0x5f, 0xd2, 0x11, 0x8e, 0xb0, 0x89, 0xd8,
+	0xae, 0x5d, 0x89, 0x3e, 0x1e, 0xd7, 0x0a, 0x0f, 0x3e, 0x84, 0x5a, 0x32, 0x10, 0x96, 0x00, 0x1c,
+	0xb5, 0x0e, 0x76, 0x76, 0x0f, 0x9e, 0xd6, 0xae, 0x10, 0xbe, 0x36, 0xb7, 0x9f, 0x53, 0x49, 0x03,
+	0x28, 0x3d, 0x69, 0xee, 0xee, 0xd1, 0xa9, 0x1b, 0xc0, 0x52, 0x4a, 0xf8, 0x21, 0x19, 0x6b, 0xbb,
+	0x75, 0x7c, 0x72, 0x64, 0x3c, 0xd5, 0x0f, 0x4f, 0x8e, 0x8c, 0x08, 0xcd, 0x35, 0x58, 0x61, 0x15,
+	0xed, 0x56, 0xbb, 0x4d, 0xa4, 0x51, 0x54, 0x15, 0x88, 0xe8, 0xb0, 0xaa, 0xed, 0xc3, 0xfd, 0xa3,
+	0xbd, 0xd6, 0x31, 0xc1, 0x4f, 0xa6, 0x88, 0x15, 0xf2, 0x1e, 0xa7, 0xb6, 0xfe, 0xf2, 0x6f, 0xc3,
+	0xf2, 0x01, 0x0e, 0x5f, 0x79, 0xfe, 0xcb, 0x36, 0x8d, 0x24, 0xe1, 0x3f, 0x8c, 0x80, 0x7e, 0x25,
+	0x12, 0x09, 0xc6, 0x7f, 0x29, 0x01, 0xad, 0x13, 0x55, 0x90, 0xf3, 0x43, 0x19, 0x8d, 0x8d, 0x6c,
+	0x00, 0x7e, 0x6c, 0xba, 0x82, 0x74, 0x9a, 0x66, 0x30, 0x81, 0x99, 0x29, 0x99, 0x8c, 0x9f, 0xbd,
+	0x68, 0xdc, 0xcc, 0xa8, 0x95, 0x38, 0xbf, 0x14, 0x39, 0xf6, 0xd2, 0x08, 0xce, 0xf9, 0x41, 0x89,
+	0xc6, 0xea, 0x88, 0xce, 0x6e, 0x91, 0x5f, 0x1a, 0x61, 0x28, 0xd3, 0x7e, 0x2d, 0x82, 0xa1, 0xcc,
+	0xf9, 0x1d, 0x89, 0x1c, 0x94, 0x92, 0xad, 0xf1, 0x1f, 0x1b, 0x50, 0xd9, 0x9a, 0xfa, 0x33, 0x04,
+	0x8d, 0x8d, 0x6c, 0x80, 0x04, 0x5b, 0x13, 0x98, 0x05, 0x5b, 0xd3, 0xd1, 0xde, 0xcc, 0xa8, 0x1d,
+	0x65, 0x6b, 0x1a, 0xc1, 0x39, 0xbf, 0xc9, 0x30, 0x09, 0x5b, 0xd3, 0x50, 0xe6, 0xfc, 0x14, 0x43,
+	0x0e, 0xca, 0x6f, 0xe2, 0x39, 0xe5, 0x05, 0xc6, 0x5b, 0x11, 0xd3, 0xd2, 0xd2, 0xfa, 0x37, 0xd6,
+	0x33, 0xeb, 0xe5, 0xf8, 0x0f, 0x95, 0x94, 0xf3, 0x02, 0xed, 0x75, 0xce, 0xb4, 0x54, 0x9c, 0x37,
+	0xd2, 0x2b, 0x15, 0x84, 0x4b, 0x29, 0x3f, 0x60, 0xc0, 0x48, 0xcd, 0xfe, 0x65, 0x83, 0x9c, 0xb1,
+	0x1f, 0xc6, 0xb3, 0xad, 0xc7, 0x10, 0x66, 0xff, 0xa4, 0x41, 0x0e, 0xc2, 0x26, 0x2c, 0xa8, 0x3c,
+	0x41, 0x6b, 0x49, 0x2e, 0x8d, 0x47, 0xf1, 0x29, 0xcc, 0x49, 0x16, 0xa0, 0xe5, 0x18, 0x47, 0x44,
+	0xe3, 0x95, 0x44, 0xa9, 0x64, 0x50, 0x13, 0x16, 0x54, 0x3e, 0xb0, 0xee, 0x53, 0x32, 0xe3, 0xe7,
+	0x74, 0xdf, 0x82, 0x6a, 0x3c, 0x1d, 0x3e, 0xa2, 0x26, 0x6d, 0x6a, 0x8a, 0xfc, 0x7c, 0x46, 0xa8,
+	0x0c, 0x64, 0x94, 0xa4, 0x64, 0xb6, 0xcf, 0xa7, 0x24, 0x9e, 0x9d, 0x9d, 0x51, 0x92, 0x9a, 0xb1,
+	0x3d, 0x07, 0xcd, 0x2e, 0x49, 0x90, 0x1f, 0x4f, 0xc4, 0x8e, 0x78, 0x0e, 0x71, 0xf3, 0x92, 0xa8,
+	0xbe, 0x81, 0xa5, 0x94, 0x44, 0xeb, 0x4c, 0x5c, 0xb2, 0x13, 0xb7, 0x37, 0xd6, 0x33, 0xeb, 0xe5,
+	0xc4, 0xfd, 0x0a, 0x96, 0xd3, 0xf2, 0xa4, 0xa3, 0x78, 0xd3, 0xd1, 0xd4, 0xeb, 0x8d, 0x8d, 0x6c,
+	0x00, 0x89, 0xfc, 0x04, 0xd0, 0x68, 0x3a, 0x6c, 0x44, 0xd5, 0x57, 0x66, 0x4e, 0xf0, 0xc6, 0xad,
+	0xac, 0x6a, 0x89, 0xb6, 0x0d, 0x2b, 0xa9, 0x39, 0x1b, 0xd1, 0x46, 0x52, 0xe8, 0x93, 0x8f, 0x38,
+	0x73, 0x95, 0xfc, 0xb5, 0xcc, 0xfc, 0x8d, 0xe8, 0x2e, 0x41, 0x3c, 0x2e, 0xbd, 0x63, 0x0e, 0xf2,
+	0x40, 0xc9, 0x46, 0x9f, 0x92, 0x9f, 0x11, 0xbd, 0x15, 0x63, 0x66, 0x76, 0x06, 0xc8, 0xc6, 0xfd,
+	0xf1, 0x80, 0x92, 0x4d, 0xac, 0xd3, 0xcc, 0x0c, 0x8c, 0xb2, 0xd3, 0x71, 0x39, 0x1e, 0x1b, 0xf7,
+	0xc7, 0x03, 0xca, 0x4e, 0xbf, 0x80, 0x5a, 0x32, 0x91, 0x39, 0xca, 0xe0, 0x8b, 0xd4, 0xba, 0xa9,
+	0x69, 0xcf, 0xd9, 0x94, 0x64, 0x66, 0x37, 0x67, 0x53, 0x32, 0x2e, 0xf9, 0x79, 0xce, 0x94, 0x9c,
+	0xc0, 0x6a, 0x7a, 0x3a, 0x73, 0x74, 0x9b, 0xc5, 0xe0, 0xe7, 0xa4, 0x3a, 0xcf, 0x41, 0xbb, 0x0d,
+	0x95, 0x58, 0x6a, 0x23, 0x54, 0x8f, 0xe8, 0x8c, 0x67, 0x79, 0xcc, 0x41, 0xf2, 0x19, 0x40, 0x74,
+	0xd4, 0x43, 0x42, 0xe9, 0x8e, 0x34, 0x4f, 0x14, 0x4b, 0xbe, 0x6d, 0x43, 0x25, 0x96, 0x31, 0x88,
+	0xd1, 0x90, 0x96, 0xcc, 0x38, 0x7f, 0x20, 0xb1, 0xd4, 0x40, 0x0c, 0x49, 0x5a, 0x4a, 0xe3, 0x49,
+	0x2c, 0xa7, 0x44, 0xde, 0xb6, 0xf5, 0x11, 0xa6, 0x64, 0x5b, 0x4e, 0xe9, 0x61, 0x4e, 0xd2, 0x72,
+	0x4a, 0x60, 0xbe, 0x11, 0xe7, 0x4a, 0x86, 0xe5, 0x94, 0x89, 0xf3, 0xcb, 0x44, 0xd2, 0xe7, 0x14,
+	0xcb, 0x29, 0x1d, 0xf3, 0x04, 0x96, 0x53, 0x1a, 0xca, 0x9c, 0xec, 0x4b, 0x93, 0x58, 0x4e, 0xf1,
+	0x64, 0x4c, 0x8a, 0xe5, 0x94, 0x96, 0xed, 0xa5, 0xb1, 0x9e, 0x59, 0x9f, 0xb0, 0x9c, 0xe2, 0x68,
+	0x85, 0xe5, 0x94, 0x8a, 0xf3, 0x46, 0x7a, 0xa5, 0x44, 0xf8, 0x8d, 0xb0, 0x9c, 0x52, 0x48, 0xcd,
+	0xce, 0x94, 0xd3, 0x58, 0xcf, 0xac, 0x57, 0x6d, 0xb2, 0x94, 0xcc, 0x36, 0xaa, 0x09, 0x95, 0x8a,
+	0x39, 0x9b, 0xab, 0xdd, 0xd1, 0x0c, 0x45, 0x22, 0x93, 0x0d, 0xba, 0x93, 0x36, 0xcc, 0x44, 0x6a,
+	0x9c, 0xc6, 0xdd, 0x7c, 0x20, 0x49, 0xf9, 0x1e, 0x5c, 0x4d, 0xf8, 0xf7, 0x50, 0x23, 0x2e, 0x98,
+	0x6a, 0xe2, 0xeb, 0xc6, 0xf5, 0xd4, 0x3a, 0x89, 0xad, 0x07, 0xd7, 0x32, 0x13, 0xbc, 0x32, 0x2d,
+	0x39, 0x2e, 0xdf, 0x6c, 0xe3, 0xde, 0x18, 0x28, 0xd1, 0xd7, 0x7b, 0x05, 0xe4, 0x40, 0x7d, 0x14,
+	0x90, 0x6f, 0xec, 0x77, 0xd2, 0xd1, 0xc4, 0xb7, 0xf7, 0xbb, 0xf9, 0x40, 0x4a, 0x57, 0xbf, 0x16,
+	0xdb, 0x7c, 0xe2, 0xd4, 0xaf, 0x6e, 0xf3, 0xe9, 0x99, 0x3d, 0x1b, 0xb7, 0x73, 0x20, 0x54, 0xeb,
+	0x64, 0x34, 0x11, 0x27, 0xba, 0x29, 0x27, 0x31, 0x15, 0xf3, 0xad, 0xac, 0x6a, 0xd5, 0xa2, 0x4a,
+	0xcb, 0x13, 0xa3, 0xea, 0xbc, 0xd4, 0x3c, 0x0c, 0x8d, 0x8d, 0x6c, 0x80, 0x84, 0xce, 0x4b, 0x60,
+	0x16, 0x6b, 0x30, 0x1d, 0xed, 0xcd, 0x8c, 0xda, 0x51, 0x9d, 0x97, 0x46, 0x70, 0x4e, 0x16, 0x97,
+	0x49, 0x74, 0x5e, 0x1a, 0xca, 0x9c, 0xe4, 0x2d, 0xf9, 0xf6, 0x59, 0x66, 0x1a, 0x17, 0x26, 0xe6,
+	0xe3, 0xb2, 0xbc, 0xe4, 0x20, 0xc7, 0x70, 0x2b, 0x3f, 0x71, 0x0b, 0x7a, 0x9b, 0xc5, 0x8f, 0x4f,
+	0x90, 0xdc, 0x25, 0x7f, 0x0c, 0x99, 0x69, 0x46, 0xd8, 0x18, 0xc6, 0x65, 0x21, 0xc9, 0x41, 0xfe,
+	0x2d, 0xdc, 0x9d, 0x24, 0xab, 0x08, 0x7a, 0x28, 0x6d, 0xd9, 0xc9, 0xf2, 0x8f, 0xe4, 0x74, 0xf9,
+	0x37, 0x0a, 0xf0, 0xd6, 0x84, 0xc9, 0x40, 0xd0, 0x56, 0x52, 0x0c, 0xc7, 0x67, 0x26, 0x69, 0x7c,
+	0x70, 0xa9, 0x36, 0x52, 0xa0, 0x7f, 0x37, 0x25, 0x99, 0x92, 0xcc, 0xa0, 0x71, 0x37, 0x75, 0x39,
+	0x24, 0x52, 0x88, 0x34, 0xee, 0x8d, 0x81, 0x92, 0x7d, 0x75, 0xa1, 0x9e, 0x95, 0x1a, 0x81, 0xe9,
+	0xc3, 0x31, 0x99, 0x29, 0x1a, 0x77, 0xf3, 0x81, 0x12, 0x07, 0xb5, 0x91, 0x37, 0xef, 0xf2, 0xa0,
+	0x96, 0x95, 0x5b, 0xa0, 0xb1, 0x91, 0x0d, 0xa0, 0xee, 0xa5, 0x29, 0x6f, 0xdf, 0xd9, 0x5e, 0x9a,
+	0xfd, 0x28, 0x3e, 0x47, 0x32, 0x6c, 0x9a, 0x33, 0x30, 0xed, 0x8d, 0x34, 0xd2, 0x92, 0xf4, 0x8c,
+	0xbe, 0x24, 0x6f, 0xdc, 0xc9, 0x85, 0x91, 0x64, 0x1b, 0x70, 0x3d, 0xe7, 0xf9, 0x0c, 0xfa, 0x89,
+	0xb2, 0xa2, 0x72, 0xde, 0xd7, 0xe4, 0x0c, 0xc3, 0x84, 0xd5, 0xf4, 0xb7, 0x62, 0xe8, 0xb6, 0xea,
+	0xda, 0x4b, 0x7d, 0xaa, 0xd4, 0xd0, 0xf2, 0x40, 0x54, 0x03, 0x29, 0xe5, 0xb5, 0x98, 0x3c, 0xda,
+	0x67, 0x21, 0x5f, 0xcf, 0xac, 0x57, 0xf6, 0xb7, 0xd5, 0xf4, 0xf7, 0x5a, 0x8c, 0xf8, 0xdc, 0xb7,
+	0x5c, 0xf9, 0x07, 0xa7, 0xf4, 0x27, 0x5a, 0x0c, 0x6d, 0xee, 0xf3, 0xad, 0x1c, 0xb4, 0xbf, 0x86,
+	0x95, 0xd4, 0xa7, 0x57, 0x6c, 0xb7, 0xcf, 0x7b, 0xe3, 0xd5, 0xb8, 0x9d, 0x03, 0x21, 0xb9, 0xf1,
+	0x39, 0x3d, 0x53, 0x89, 0xd8, 0xd4, 0xac, 0x23, 0xa9, 0x38, 0x54, 0x25, 0xb2, 0x57, 0x6b, 0x57,
+	0xd0, 0x53, 0x58, 0xd2, 0x31, 0x39, 0x03, 0xc6, 0x2e, 0xac, 0x72, 0x10, 0x65, 0x0d, 0x54, 0xf8,
+	0xd1, 0xd5, 0xf7, 0xe0, 0x8a, 0x1f, 0x3d, 0xe5, 0xa9, 0x7a, 0xe3, 0x66, 0x46, 0xad, 0x24, 0xce,
+	0x56, 0x7f, 0x41, 0x24, 0xfe, 0x3a, 0x5c, 0x8b, 0x5b, 0x8f, 0x69, 0x8f, 0x7c, 0x1b, 0x77, 0x72,
+	0x61, 0x64, 0x2f, 0x18, 0x1a, 0xcc, 0x70, 0x4b, 0xed, 0x48, 0x31, 0x22, 0xf3, 0xfa, 0xba, 0x91,
+	0xf1, 0x6e, 0x97, 0x8e, 0x89, 0xda, 0x7d, 0x47, 0x6c, 0x45, 0x24, 0x9e, 0x8e, 0x65, 0x72, 0x5a,
+	0xae, 0x84, 0x8c, 0xb7, 0x66, 0xda, 0x15, 0x74, 0xa6, 0x46, 0x95, 0xa7, 0x3d, 0xea, 0xba, 0x3f,
+	0xc2, 0x80, 0x8c, 0xe7, 0x47, 0x8d, 0xb7, 0x27, 0x80, 0x94, 0xfd, 0xfe, 0x83, 0x02, 0x6c, 0x5e,
+	0xee, 0x15, 0x0f, 0xfa, 0x64, 0x2c, 0xfe, 0xac, 0x07, 0x46, 0x8d, 0x4f, 0x5f, 0xa7, 0xa9, 0x7a,
+	0xf2, 0x4b, 0xbe, 0xa6, 0x11, 0xde, 0xca, 0xd4, 0x37, 0x41, 0x8d, 0x1b, 0xe9, 0x95, 0x09, 0xc5,
+	0x96, 0x7c, 0xca, 0x21, 0x15, 0x5b, 0xc6, 0xd3, 0x94, 0xc6, 0x7a, 0x66, 0xbd, 0xc4, 0xfc, 0x1c,
+	0x16, 0x47, 0x5e, 0x36, 0xb0, 0x15, 0x94, 0xf5, 0xe0, 0x21, 0xdf, 0x4b, 0x9b, 0x7c, 0xeb, 0xc0,
+	0xc6, 0x9d, 0xf1, 0x02, 0x22, 0x5f, 0x85, 0xa5, 0x3e, 0x5e, 0x40, 0x1b, 0xf1, 0x99, 0x19, 0x7d,
+	0x17, 0xd1, 0xb8, 0x9d, 0x03, 0x91, 0xe0, 0xe8, 0xc8, 0x0b, 0x81, 0x5b, 0xf1, 0xb6, 0xc9, 0x00,
+	0xf2, 0xc6, 0x7a, 0x66, 0xbd, 0x6a, 0x5c, 0xa4, 0xc5, 0x87, 0x33, 0xe3, 0x22, 0x27, 0x38, 0xbd,
+	0xb1, 0x91, 0x0d, 0x90, 0x30, 0xc7, 0x32, 0xe2, 0xc1, 0xef, 0x8e, 0x08, 0x6d, 0x4a, 0x7c, 0x76,
+	0xe3, 0xde, 0x18, 0x28, 0xd9, 0xd7, 0x20, 0x16, 0x4b, 0x9f, 0x80, 0x0b, 0x98, 0x45, 0x30, 0x3e,
+	0xe6, 0xba, 0xf1, 0xd6, 0x58, 0x38, 0xf5, 0x14, 0x39, 0x1a, 0x9d, 0xcb, 0x4e, 0x91, 0x99, 0x51,
+	0xc6, 0x8d, 0x5b, 0x59, 0xd5, 0x59, 0x2a, 0x6b, 0x24, 0xa2, 0x75, 0x54, 0x65, 0x65, 0x05, 0xe9,
+	0x36, 0xde, 0x9e, 0x00, 0x32, 0x7d, 0xb2, 0x12, 0x61, 0xa2, 0xc9, 0xc9, 0x4a, 0x0f, 0x44, 0x6d,
+	0xdc, 0x1b, 0x03, 0x25, 0xfb, 0xba, 0x80, 0x5b, 0xf9, 0xf1, 0x97, 0xec, 0xd4, 0x35, 0x51, 0x88,
+	0x67, 0xe3, 0xc1, 0x24, 0xa0, 0xe9, 0xc3, 0x4c, 0x84, 0x58, 0x25, 0x87, 0x99, 0x1e, 0x81, 0xd6,
+	0xb8, 0x37, 0x06, 0x2a, 0x61, 0xb9, 0x8f, 0x04, 0xee, 0xa0, 0xc4, 0xba, 0x1c, 0x09, 0x08, 0x6a,
+	0x6c, 0x64, 0x03, 0x08, 0xe4, 0xa7, 0x25, 0xaa, 0x85, 0x3e, 0xf8, 0xff, 0x03, 0x00, 0x0a, 0x89,
+	0x10, 0x3a, 0x99, 0x83, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NetworkServerServiceClient is the client API for NetworkServerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NetworkServerServiceClient interface {
+	// CreateServiceProfile creates the given service-profile.
+	CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error)
+	// GetServiceProfile returns the service-profile matching the given id.
+	GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error)
+	// UpdateServiceProfile updates the given service-profile.
+	UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteServiceProfile deletes the service-profile matching the given id.
+	DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateRoutingProfile creates the given routing-profile.
+	CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error)
+	// GetRoutingProfile returns the routing-profile matching the given id.
+	GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error)
+	// UpdateRoutingProfile updates the given routing-profile.
+	UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteRoutingProfile deletes the routing-profile matching the given id.
+	DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile matching the given id.
+	GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteDeviceProfile deletes the device-profile matching the given id.
+	DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateDevice creates the given device.
+	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetDevice returns the device matching the given DevEUI.
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
+	// UpdateDevice updates the given device.
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// TransferDevice moves the given device to an other service-profile and /
+	// or routing-profile, preserving its device-session and device-queue.
+	TransferDevice(ctx context.Context, in *TransferDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteDevice deletes the device matching the given DevEUI.
+	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ActivateDevice activates a device (ABP).
+	ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeactivateDevice de-activates a device.
+	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetDeviceActivation returns the device activation details.
+	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// GetDeviceClassHealth returns the Class-B / Class-C downlink statistics
+	// of the device and whether the device behaves according to its
+	// configured device-class.
+	GetDeviceClassHealth(ctx context.Context, in *GetDeviceClassHealthRequest, opts ...grpc.CallOption) (*GetDeviceClassHealthResponse, error)
+	// ProvisionABPDevice creates the given device and activates it (ABP) in
+	// a single call. When no DevAddr is given, a free DevAddr is allocated.
+	ProvisionABPDevice(ctx context.Context, in *ProvisionABPDeviceRequest, opts ...grpc.CallOption) (*ProvisionABPDeviceResponse, error)
+	// CreateDeviceQueueItem creates the given device-queue item.
+	CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
+	FlushDeviceQueueForDevEUI(ctx context.Context, in *FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
+	GetDeviceQueueItemsForDevEUI(ctx context.Context, in *GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsForDevEUIResponse, error)
+	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
+	// This also takes device-queue items for the given DevEUI into consideration.
+	GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
+	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
+	CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
+	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateGatewayProfile creates the given gateway-profile.
+	CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error)
+	// GetGatewayProfile returns the gateway-profile given an id.
+	GetGatewayProfile(ctx context.Context, in *GetGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayProfileResponse, error)
+	// UpdateGatewayProfile updates the given gateway-profile.
+	UpdateGatewayProfile(ctx context.Context, in *UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteGatewayProfile deletes the gateway-profile matching a given id.
+	DeleteGatewayProfile(ctx context.Context, in *DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateFrequencyPlan creates the given frequency-plan.
+	CreateFrequencyPlan(ctx context.Context, in *CreateFrequencyPlanRequest, opts ...grpc.CallOption) (*CreateFrequencyPlanResponse, error)
+	// GetFrequencyPlan returns the frequency-plan given an id and optional version.
+	GetFrequencyPlan(ctx context.Context, in *GetFrequencyPlanRequest, opts ...grpc.CallOption) (*GetFrequencyPlanResponse, error)
+	// UpdateFrequencyPlan updates the given frequency-plan.
+	// Each update creates a new frequency-plan version.
+	UpdateFrequencyPlan(ctx context.Context, in *UpdateFrequencyPlanRequest, opts ...grpc.CallOption) (*UpdateFrequencyPlanResponse, error)
+	// DeleteFrequencyPlan deletes the frequency-plan matching a given id.
+	DeleteFrequencyPlan(ctx context.Context, in *DeleteFrequencyPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetFrequencyPlanVersions returns the versions of the given frequency-plan.
+	GetFrequencyPlanVersions(ctx context.Context, in *GetFrequencyPlanVersionsRequest, opts ...grpc.CallOption) (*GetFrequencyPlanVersionsResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
+	StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error)
+	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
+	StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForDeviceClient, error)
+	// CreateFrameLogCapture starts a bounded frame-log capture session for
+	// the given device or gateway.
+	CreateFrameLogCapture(ctx context.Context, in *CreateFrameLogCaptureRequest, opts ...grpc.CallOption) (*CreateFrameLogCaptureResponse, error)
+	// GetFrameLogCapture returns the status of the given capture session and
+	// the frames captured so far, encoded in the requested format.
+	GetFrameLogCapture(ctx context.Context, in *GetFrameLogCaptureRequest, opts ...grpc.CallOption) (*GetFrameLogCaptureResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group given an id.
+	GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteMulticastGroup deletes a multicast-group given an id.
+	DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+	AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+	RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// EnqueueMulticastQueueItem enqueues the given multicast queue-item and
+	// increments the frame-counter after enqueueing.
+	EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
+	FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
+	GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
+	// GetMulticastGroupCoverage returns for each device of the multicast-group
+	// the gateways of the multicast gateway-set which can reach the device.
+	GetMulticastGroupCoverage(ctx context.Context, in *GetMulticastGroupCoverageRequest, opts ...grpc.CallOption) (*GetMulticastGroupCoverageResponse, error)
+	// EstimateMulticastSession estimates the airtime per gateway, the
+	// duration and the duty-cycle feasibility of a multicast session, without
+	// enqueueing any frames.
+	EstimateMulticastSession(ctx context.Context, in *EstimateMulticastSessionRequest, opts ...grpc.CallOption) (*EstimateMulticastSessionResponse, error)
+	// GetMulticastTXStatus returns per frame of the multicast-group the
+	// transmission status for each gateway of the gateway-set.
+	GetMulticastTXStatus(ctx context.Context, in *GetMulticastTXStatusRequest, opts ...grpc.CallOption) (*GetMulticastTXStatusResponse, error)
+	// StartMulticastSetup starts the remote multicast setup of the devices
+	// of the multicast-group, using the LoRaWAN Remote Multicast Setup
+	// package. This replaces an existing setup of the multicast-group.
+	StartMulticastSetup(ctx context.Context, in *StartMulticastSetupRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetMulticastSetupStatus returns the remote multicast setup status of
+	// each device of the multicast-group.
+	GetMulticastSetupStatus(ctx context.Context, in *GetMulticastSetupStatusRequest, opts ...grpc.CallOption) (*GetMulticastSetupStatusResponse, error)
+	// EnqueueCertificationCommand enqueues the given LoRaWAN Certification
+	// Protocol command(s) for a device in certification test mode.
+	EnqueueCertificationCommand(ctx context.Context, in *EnqueueCertificationCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// CreateRoamingAgreement creates the given roaming agreement.
+	CreateRoamingAgreement(ctx context.Context, in *CreateRoamingAgreementRequest, opts ...grpc.CallOption) (*CreateRoamingAgreementResponse, error)
+	// GetRoamingAgreement returns the roaming agreement matching the given id.
+	GetRoamingAgreement(ctx context.Context, in *GetRoamingAgreementRequest, opts ...grpc.CallOption) (*GetRoamingAgreementResponse, error)
+	// UpdateRoamingAgreement updates the given roaming agreement.
+	UpdateRoamingAgreement(ctx context.Context, in *UpdateRoamingAgreementRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteRoamingAgreement deletes the roaming agreement matching the given id.
+	DeleteRoamingAgreement(ctx context.Context, in *DeleteRoamingAgreementRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ListRoamingAgreements returns the roaming agreements, ordered by NetID.
+	ListRoamingAgreements(ctx context.Context, in *ListRoamingAgreementsRequest, opts ...grpc.CallOption) (*ListRoamingAgreementsResponse, error)
+	// GetVersion returns the LoRa Server version.
+	GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// ReloadConfiguration reloads the settings of the configuration file
+	// which can be changed without restarting LoRa Server (e.g. the log-level).
+	ReloadConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetSecurityEvents returns the most recent security events.
+	GetSecurityEvents(ctx context.Context, in *GetSecurityEventsRequest, opts ...grpc.CallOption) (*GetSecurityEventsResponse, error)
+	// GetGatewayContributions returns the contribution metrics of a gateway
+	// per aggregation interval.
+	GetGatewayContributions(ctx context.Context, in *GetGatewayContributionsRequest, opts ...grpc.CallOption) (*GetGatewayContributionsResponse, error)
+	// StreamGatewayContributions returns a stream of gateway contribution events.
+	StreamGatewayContributions(ctx context.Context, in *StreamGatewayContributionsRequest, opts ...grpc.CallOption) (NetworkServerService_StreamGatewayContributionsClient, error)
+	// GetRedisMemoryUsage returns the last sample of the Redis memory usage
+	// per key class.
+	GetRedisMemoryUsage(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRedisMemoryUsageResponse, error)
+	// GetGatewayConfigurationStatus returns the configuration rollout status
+	// of the given gateway.
+	GetGatewayConfigurationStatus(ctx context.Context, in *GetGatewayConfigurationStatusRequest, opts ...grpc.CallOption) (*GetGatewayConfigurationStatusResponse, error)
+	// GetGatewayConfigurationStatusForGatewayProfile returns the configuration
+	// rollout status of the gateways using the given gateway-profile.
+	GetGatewayConfigurationStatusForGatewayProfile(ctx context.Context, in *GetGatewayConfigurationStatusForGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayConfigurationStatusForGatewayProfileResponse, error)
+	// DecodePHYPayload decodes the given PHYPayload (for debugging purposes).
+	// Data frames are matched against the device-sessions to validate the
+	// MIC and to decrypt the mac-commands and (when authorized) FRMPayload.
+	DecodePHYPayload(ctx context.Context, in *DecodePHYPayloadRequest, opts ...grpc.CallOption) (*DecodePHYPayloadResponse, error)
+	// GetSchedulerBacklog returns the downlink scheduler backlog, either
+	// global or for a single gateway.
+	GetSchedulerBacklog(ctx context.Context, in *GetSchedulerBacklogRequest, opts ...grpc.CallOption) (*GetSchedulerBacklogResponse, error)
+	// StartGatewayDrain puts the gateway in maintenance drain mode. A
+	// draining gateway is no longer selected for new downlinks (including
+	// multicast), while the outstanding downlinks are still transmitted.
+	StartGatewayDrain(ctx context.Context, in *StartGatewayDrainRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// StopGatewayDrain takes the gateway out of maintenance drain mode.
+	StopGatewayDrain(ctx context.Context, in *StopGatewayDrainRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// GetGatewayDrainStatus returns the drain status of the gateway, including
+	// whether it is safe to power off the gateway.
+	GetGatewayDrainStatus(ctx context.Context, in *GetGatewayDrainStatusRequest, opts ...grpc.CallOption) (*GetGatewayDrainStatusResponse, error)
+	// GetGatewayInventory returns the inventory (model and versions) of the
+	// gateway, as reported by the gateway stats.
+	GetGatewayInventory(ctx context.Context, in *GetGatewayInventoryRequest, opts ...grpc.CallOption) (*GetGatewayInventoryResponse, error)
+	// ListGatewayInventory returns the gateway inventories, ordered by
+	// gateway ID.
+	ListGatewayInventory(ctx context.Context, in *ListGatewayInventoryRequest, opts ...grpc.CallOption) (*ListGatewayInventoryResponse, error)
+	// GetGatewayConnectionState returns the connection state (online /
+	// offline) of the gateway, as reported by the gateway backend.
+	GetGatewayConnectionState(ctx context.Context, in *GetGatewayConnectionStateRequest, opts ...grpc.CallOption) (*GetGatewayConnectionStateResponse, error)
+	// ListGatewayConnectionStates returns the gateway connection states,
+	// ordered by gateway ID.
+	ListGatewayConnectionStates(ctx context.Context, in *ListGatewayConnectionStatesRequest, opts ...grpc.CallOption) (*ListGatewayConnectionStatesResponse, error)
+	// ExecGatewayCommand relays the command execution request (e.g. reboot)
+	// through the gateway backend to the gateway. When a timeout is given,
+	// it waits for the command execution response.
+	ExecGatewayCommand(ctx context.Context, in *ExecGatewayCommandRequest, opts ...grpc.CallOption) (*ExecGatewayCommandResponse, error)
+	// GetGatewayCommandExecResponse returns the command execution response
+	// for the given execution ID.
+	GetGatewayCommandExecResponse(ctx context.Context, in *GetGatewayCommandExecResponseRequest, opts ...grpc.CallOption) (*GetGatewayCommandExecResponseResponse, error)
+	// GetGatewayDownlinkTXState returns the downlinks sent to the gateway
+	// which are pending a TX acknowledgement (in flight).
+	GetGatewayDownlinkTXState(ctx context.Context, in *GetGatewayDownlinkTXStateRequest, opts ...grpc.CallOption) (*GetGatewayDownlinkTXStateResponse, error)
+	// ListGatewayDownlinkQueueDepths returns the number of downlinks pending a
+	// TX acknowledgement per gateway, ordered by queue depth (descending).
+	ListGatewayDownlinkQueueDepths(ctx context.Context, in *ListGatewayDownlinkQueueDepthsRequest, opts ...grpc.CallOption) (*ListGatewayDownlinkQueueDepthsResponse, error)
+	// GetGatewayStatsAggregates returns the aggregated gateway stats for the
+	// given aggregation interval and time range.
+	GetGatewayStatsAggregates(ctx context.Context, in *GetGatewayStatsAggregatesRequest, opts ...grpc.CallOption) (*GetGatewayStatsAggregatesResponse, error)
+	// GetGatewayNoiseFloor returns the last reported noise-floor and channel
+	// utilization of each channel of the given gateway.
+	GetGatewayNoiseFloor(ctx context.Context, in *GetGatewayNoiseFloorRequest, opts ...grpc.CallOption) (*GetGatewayNoiseFloorResponse, error)
+}
+
+type networkServerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkServerServiceClient(cc *grpc.ClientConn) NetworkServerServiceClient {
+	return &networkServerServiceClient{cc}
+}
+
+func (c *networkServerServiceClient) CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error) {
+	out := new(CreateServiceProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateServiceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error) {
+	out := new(GetServiceProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetServiceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateServiceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteServiceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error) {
+	out := new(CreateRoutingProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateRoutingProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error) {
+	out := new(GetRoutingProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRoutingProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateRoutingProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteRoutingProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
+	out := new(CreateDeviceProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error) {
+	out := new(GetDeviceProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDeviceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDeviceProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
+	out := new(GetDeviceResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) TransferDevice(ctx context.Context, in *TransferDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/TransferDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ActivateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeactivateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
+	out := new(GetDeviceActivationResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceActivation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceClassHealth(ctx context.Context, in *GetDeviceClassHealthRequest, opts ...grpc.CallOption) (*GetDeviceClassHealthResponse, error) {
+	out := new(GetDeviceClassHealthResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceClassHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ProvisionABPDevice(ctx context.Context, in *ProvisionABPDeviceRequest, opts ...grpc.CallOption) (*ProvisionABPDeviceResponse, error) {
+	out := new(ProvisionABPDeviceResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ProvisionABPDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateDeviceQueueItem(ctx context.Context, in *CreateDeviceQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateDeviceQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) FlushDeviceQueueForDevEUI(ctx context.Context, in *FlushDeviceQueueForDevEUIRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushDeviceQueueForDevEUI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetDeviceQueueItemsForDevEUI(ctx context.Context, in *GetDeviceQueueItemsForDevEUIRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsForDevEUIResponse, error) {
+	out := new(GetDeviceQueueItemsForDevEUIResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetDeviceQueueItemsForDevEUI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetNextDownlinkFCntForDevEUI(ctx context.Context, in *GetNextDownlinkFCntForDevEUIRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntForDevEUIResponse, error) {
+	out := new(GetNextDownlinkFCntForDevEUIResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetNextDownlinkFCntForDevEUI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetRandomDevAddr(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
+	out := new(GetRandomDevAddrResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRandomDevAddr", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateMACCommandQueueItem(ctx context.Context, in *CreateMACCommandQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMACCommandQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/SendProprietaryPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateGateway", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
+	out := new(GetGatewayResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGateway", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateGateway", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteGateway", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateGatewayProfile(ctx context.Context, in *CreateGatewayProfileRequest, opts ...grpc.CallOption) (*CreateGatewayProfileResponse, error) {
+	out := new(CreateGatewayProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateGatewayProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayProfile(ctx context.Context, in *GetGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayProfileResponse, error) {
+	out := new(GetGatewayProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateGatewayProfile(ctx context.Context, in *UpdateGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateGatewayProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteGatewayProfile(ctx context.Context, in *DeleteGatewayProfileRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteGatewayProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateFrequencyPlan(ctx context.Context, in *CreateFrequencyPlanRequest, opts ...grpc.CallOption) (*CreateFrequencyPlanResponse, error) {
+	out := new(CreateFrequencyPlanResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateFrequencyPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetFrequencyPlan(ctx context.Context, in *GetFrequencyPlanRequest, opts ...grpc.CallOption) (*GetFrequencyPlanResponse, error) {
+	out := new(GetFrequencyPlanResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetFrequencyPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateFrequencyPlan(ctx context.Context, in *UpdateFrequencyPlanRequest, opts ...grpc.CallOption) (*UpdateFrequencyPlanResponse, error) {
+	out := new(UpdateFrequencyPlanResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateFrequencyPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteFrequencyPlan(ctx context.Context, in *DeleteFrequencyPlanRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteFrequencyPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetFrequencyPlanVersions(ctx context.Context, in *GetFrequencyPlanVersionsRequest, opts ...grpc.CallOption) (*GetFrequencyPlanVersionsResponse, error) {
+	out := new(GetFrequencyPlanVersionsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetFrequencyPlanVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
+	out := new(GetGatewayStatsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForGatewayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[0], "/ns.NetworkServerService/StreamFrameLogsForGateway", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerServiceStreamFrameLogsForGatewayClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServerService_StreamFrameLogsForGatewayClient interface {
+	Recv() (*StreamFrameLogsForGatewayResponse, error)
+	grpc.ClientStream
+}
+
+type networkServerServiceStreamFrameLogsForGatewayClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerServiceStreamFrameLogsForGatewayClient) Recv() (*StreamFrameLogsForGatewayResponse, error) {
+	m := new(StreamFrameLogsForGatewayResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerServiceClient) StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServerService_StreamFrameLogsForDeviceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[1], "/ns.NetworkServerService/StreamFrameLogsForDevice", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerServiceStreamFrameLogsForDeviceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServerService_StreamFrameLogsForDeviceClient interface {
+	Recv() (*StreamFrameLogsForDeviceResponse, error)
+	grpc.ClientStream
+}
+
+type networkServerServiceStreamFrameLogsForDeviceClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerServiceStreamFrameLogsForDeviceClient) Recv() (*StreamFrameLogsForDeviceResponse, error) {
+	m := new(StreamFrameLogsForDeviceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerServiceClient) CreateFrameLogCapture(ctx context.Context, in *CreateFrameLogCaptureRequest, opts ...grpc.CallOption) (*CreateFrameLogCaptureResponse, error) {
+	out := new(CreateFrameLogCaptureResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateFrameLogCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetFrameLogCapture(ctx context.Context, in *GetFrameLogCaptureRequest, opts ...grpc.CallOption) (*GetFrameLogCaptureResponse, error) {
+	out := new(GetFrameLogCaptureResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetFrameLogCapture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error) {
+	out := new(CreateMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error) {
+	out := new(GetMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/AddDeviceToMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueMulticastQueueItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) FlushMulticastQueueForMulticastGroup(ctx context.Context, in *FlushMulticastQueueForMulticastGroupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, in *GetMulticastQueueItemsForMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	out := new(GetMulticastQueueItemsForMulticastGroupResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastGroupCoverage(ctx context.Context, in *GetMulticastGroupCoverageRequest, opts ...grpc.CallOption) (*GetMulticastGroupCoverageResponse, error) {
+	out := new(GetMulticastGroupCoverageResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastGroupCoverage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EstimateMulticastSession(ctx context.Context, in *EstimateMulticastSessionRequest, opts ...grpc.CallOption) (*EstimateMulticastSessionResponse, error) {
+	out := new(EstimateMulticastSessionResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EstimateMulticastSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastTXStatus(ctx context.Context, in *GetMulticastTXStatusRequest, opts ...grpc.CallOption) (*GetMulticastTXStatusResponse, error) {
+	out := new(GetMulticastTXStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastTXStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) StartMulticastSetup(ctx context.Context, in *StartMulticastSetupRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/StartMulticastSetup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetMulticastSetupStatus(ctx context.Context, in *GetMulticastSetupStatusRequest, opts ...grpc.CallOption) (*GetMulticastSetupStatusResponse, error) {
+	out := new(GetMulticastSetupStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetMulticastSetupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) EnqueueCertificationCommand(ctx context.Context, in *EnqueueCertificationCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/EnqueueCertificationCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) CreateRoamingAgreement(ctx context.Context, in *CreateRoamingAgreementRequest, opts ...grpc.CallOption) (*CreateRoamingAgreementResponse, error) {
+	out := new(CreateRoamingAgreementResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/CreateRoamingAgreement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetRoamingAgreement(ctx context.Context, in *GetRoamingAgreementRequest, opts ...grpc.CallOption) (*GetRoamingAgreementResponse, error) {
+	out := new(GetRoamingAgreementResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRoamingAgreement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) UpdateRoamingAgreement(ctx context.Context, in *UpdateRoamingAgreementRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/UpdateRoamingAgreement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DeleteRoamingAgreement(ctx context.Context, in *DeleteRoamingAgreementRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DeleteRoamingAgreement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ListRoamingAgreements(ctx context.Context, in *ListRoamingAgreementsRequest, opts ...grpc.CallOption) (*ListRoamingAgreementsResponse, error) {
+	out := new(ListRoamingAgreementsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ListRoamingAgreements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetVersion(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ReloadConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ReloadConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetSecurityEvents(ctx context.Context, in *GetSecurityEventsRequest, opts ...grpc.CallOption) (*GetSecurityEventsResponse, error) {
+	out := new(GetSecurityEventsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetSecurityEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayContributions(ctx context.Context, in *GetGatewayContributionsRequest, opts ...grpc.CallOption) (*GetGatewayContributionsResponse, error) {
+	out := new(GetGatewayContributionsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayContributions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) StreamGatewayContributions(ctx context.Context, in *StreamGatewayContributionsRequest, opts ...grpc.CallOption) (NetworkServerService_StreamGatewayContributionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NetworkServerService_serviceDesc.Streams[2], "/ns.NetworkServerService/StreamGatewayContributions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerServiceStreamGatewayContributionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServerService_StreamGatewayContributionsClient interface {
+	Recv() (*GatewayContributionEvent, error)
+	grpc.ClientStream
+}
+
+type networkServerServiceStreamGatewayContributionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerServiceStreamGatewayContributionsClient) Recv() (*GatewayContributionEvent, error) {
+	m := new(GatewayContributionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerServiceClient) GetRedisMemoryUsage(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetRedisMemoryUsageResponse, error) {
+	out := new(GetRedisMemoryUsageResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetRedisMemoryUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayConfigurationStatus(ctx context.Context, in *GetGatewayConfigurationStatusRequest, opts ...grpc.CallOption) (*GetGatewayConfigurationStatusResponse, error) {
+	out := new(GetGatewayConfigurationStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayConfigurationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayConfigurationStatusForGatewayProfile(ctx context.Context, in *GetGatewayConfigurationStatusForGatewayProfileRequest, opts ...grpc.CallOption) (*GetGatewayConfigurationStatusForGatewayProfileResponse, error) {
+	out := new(GetGatewayConfigurationStatusForGatewayProfileResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayConfigurationStatusForGatewayProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) DecodePHYPayload(ctx context.Context, in *DecodePHYPayloadRequest, opts ...grpc.CallOption) (*DecodePHYPayloadResponse, error) {
+	out := new(DecodePHYPayloadResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/DecodePHYPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetSchedulerBacklog(ctx context.Context, in *GetSchedulerBacklogRequest, opts ...grpc.CallOption) (*GetSchedulerBacklogResponse, error) {
+	out := new(GetSchedulerBacklogResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetSchedulerBacklog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) StartGatewayDrain(ctx context.Context, in *StartGatewayDrainRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/StartGatewayDrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) StopGatewayDrain(ctx context.Context, in *StopGatewayDrainRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/StopGatewayDrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayDrainStatus(ctx context.Context, in *GetGatewayDrainStatusRequest, opts ...grpc.CallOption) (*GetGatewayDrainStatusResponse, error) {
+	out := new(GetGatewayDrainStatusResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayDrainStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayInventory(ctx context.Context, in *GetGatewayInventoryRequest, opts ...grpc.CallOption) (*GetGatewayInventoryResponse, error) {
+	out := new(GetGatewayInventoryResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayInventory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ListGatewayInventory(ctx context.Context, in *ListGatewayInventoryRequest, opts ...grpc.CallOption) (*ListGatewayInventoryResponse, error) {
+	out := new(ListGatewayInventoryResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ListGatewayInventory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayConnectionState(ctx context.Context, in *GetGatewayConnectionStateRequest, opts ...grpc.CallOption) (*GetGatewayConnectionStateResponse, error) {
+	out := new(GetGatewayConnectionStateResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayConnectionState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ListGatewayConnectionStates(ctx context.Context, in *ListGatewayConnectionStatesRequest, opts ...grpc.CallOption) (*ListGatewayConnectionStatesResponse, error) {
+	out := new(ListGatewayConnectionStatesResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ListGatewayConnectionStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ExecGatewayCommand(ctx context.Context, in *ExecGatewayCommandRequest, opts ...grpc.CallOption) (*ExecGatewayCommandResponse, error) {
+	out := new(ExecGatewayCommandResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ExecGatewayCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayCommandExecResponse(ctx context.Context, in *GetGatewayCommandExecResponseRequest, opts ...grpc.CallOption) (*GetGatewayCommandExecResponseResponse, error) {
+	out := new(GetGatewayCommandExecResponseResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayCommandExecResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayDownlinkTXState(ctx context.Context, in *GetGatewayDownlinkTXStateRequest, opts ...grpc.CallOption) (*GetGatewayDownlinkTXStateResponse, error) {
+	out := new(GetGatewayDownlinkTXStateResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayDownlinkTXState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) ListGatewayDownlinkQueueDepths(ctx context.Context, in *ListGatewayDownlinkQueueDepthsRequest, opts ...grpc.CallOption) (*ListGatewayDownlinkQueueDepthsResponse, error) {
+	out := new(ListGatewayDownlinkQueueDepthsResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/ListGatewayDownlinkQueueDepths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayStatsAggregates(ctx context.Context, in *GetGatewayStatsAggregatesRequest, opts ...grpc.CallOption) (*GetGatewayStatsAggregatesResponse, error) {
+	out := new(GetGatewayStatsAggregatesResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayStatsAggregates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerServiceClient) GetGatewayNoiseFloor(ctx context.Context, in *GetGatewayNoiseFloorRequest, opts ...grpc.CallOption) (*GetGatewayNoiseFloorResponse, error) {
+	out := new(GetGatewayNoiseFloorResponse)
+	err := c.cc.Invoke(ctx, "/ns.NetworkServerService/GetGatewayNoiseFloor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NetworkServerServiceServer is the server API for NetworkServerService service.
+type NetworkServerServiceServer interface {
+	// CreateServiceProfile creates the given service-profile.
+	CreateServiceProfile(context.Context, *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error)
+	// GetServiceProfile returns the service-profile matching the given id.
+	GetServiceProfile(context.Context, *GetServiceProfileRequest) (*GetServiceProfileResponse, error)
+	// UpdateServiceProfile updates the given service-profile.
+	UpdateServiceProfile(context.Context, *UpdateServiceProfileRequest) (*empty.Empty, error)
+	// DeleteServiceProfile deletes the service-profile matching the given id.
+	DeleteServiceProfile(context.Context, *DeleteServiceProfileRequest) (*empty.Empty, error)
+	// CreateRoutingProfile creates the given routing-profile.
+	CreateRoutingProfile(context.Context, *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error)
+	// GetRoutingProfile returns the routing-profile matching the given id.
+	GetRoutingProfile(context.Context, *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error)
+	// UpdateRoutingProfile updates the given routing-profile.
+	UpdateRoutingProfile(context.Context, *UpdateRoutingProfileRequest) (*empty.Empty, error)
+	// DeleteRoutingProfile deletes the routing-profile matching the given id.
+	DeleteRoutingProfile(context.Context, *DeleteRoutingProfileRequest) (*empty.Empty, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile matching the given id.
+	GetDeviceProfile(context.Context, *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(context.Context, *UpdateDeviceProfileRequest) (*empty.Empty, error)
+	// DeleteDeviceProfile deletes the device-profile matching the given id.
+	DeleteDeviceProfile(context.Context, *DeleteDeviceProfileRequest) (*empty.Empty, error)
+	// CreateDevice creates the given device.
+	CreateDevice(context.Context, *CreateDeviceRequest) (*empty.Empty, error)
+	// GetDevice returns the device matching the given DevEUI.
+	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
+	// UpdateDevice updates the given device.
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*empty.Empty, error)
+	// TransferDevice moves the given device to an other service-profile and /
+	// or routing-profile, preserving its device-session and device-queue.
+	TransferDevice(context.Context, *TransferDeviceRequest) (*empty.Empty, error)
+	// DeleteDevice deletes the device matching the given DevEUI.
+	DeleteDevice(context.Context, *DeleteDeviceRequest) (*empty.Empty, error)
+	// ActivateDevice activates a device (ABP).
+	ActivateDevice(context.Context, *ActivateDeviceRequest) (*empty.Empty, error)
+	// DeactivateDevice de-activates a device.
+	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*empty.Empty, error)
+	// GetDeviceActivation returns the device activation details.
+	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// GetDeviceClassHealth returns the Class-B / Class-C downlink statistics
+	// of the device and whether the device behaves according to its
+	// configured device-class.
+	GetDeviceClassHealth(context.Context, *GetDeviceClassHealthRequest) (*GetDeviceClassHealthResponse, error)
+	// ProvisionABPDevice creates the given device and activates it (ABP) in
+	// a single call. When no DevAddr is given, a free DevAddr is allocated.
+	ProvisionABPDevice(context.Context, *ProvisionABPDeviceRequest) (*ProvisionABPDeviceResponse, error)
+	// CreateDeviceQueueItem creates the given device-queue item.
+	CreateDeviceQueueItem(context.Context, *CreateDeviceQueueItemRequest) (*empty.Empty, error)
+	// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
+	FlushDeviceQueueForDevEUI(context.Context, *FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error)
+	// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
+	GetDeviceQueueItemsForDevEUI(context.Context, *GetDeviceQueueItemsForDevEUIRequest) (*GetDeviceQueueItemsForDevEUIResponse, error)
+	// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
+	// This also takes device-queue items for the given DevEUI into consideration.
+	GetNextDownlinkFCntForDevEUI(context.Context, *GetNextDownlinkFCntForDevEUIRequest) (*GetNextDownlinkFCntForDevEUIResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(context.Context, *empty.Empty) (*GetRandomDevAddrResponse, error)
+	// CreateMACCommandQueueItem adds the downlink mac-command to the queue.
+	CreateMACCommandQueueItem(context.Context, *CreateMACCommandQueueItemRequest) (*empty.Empty, error)
+	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
+	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*empty.Empty, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(context.Context, *CreateGatewayRequest) (*empty.Empty, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(context.Context, *UpdateGatewayRequest) (*empty.Empty, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(context.Context, *DeleteGatewayRequest) (*empty.Empty, error)
+	// CreateGatewayProfile creates the given gateway-profile.
+	CreateGatewayProfile(context.Context, *CreateGatewayProfileRequest) (*CreateGatewayProfileResponse, error)
+	// GetGatewayProfile returns the gateway-profile given an id.
+	GetGatewayProfile(context.Context, *GetGatewayProfileRequest) (*GetGatewayProfileResponse, error)
+	// UpdateGatewayProfile updates the given gateway-profile.
+	UpdateGatewayProfile(context.Context, *UpdateGatewayProfileRequest) (*empty.Empty, error)
+	// DeleteGatewayProfile deletes the gateway-profile matching a given id.
+	DeleteGatewayProfile(context.Context, *DeleteGatewayProfileRequest) (*empty.Empty, error)
+	// CreateFrequencyPlan creates the given frequency-plan.
+	CreateFrequencyPlan(context.Context, *CreateFrequencyPlanRequest) (*CreateFrequencyPlanResponse, error)
+	// GetFrequencyPlan returns the frequency-plan given an id and optional version.
+	GetFrequencyPlan(context.Context, *GetFrequencyPlanRequest) (*GetFrequencyPlanResponse, error)
+	// UpdateFrequencyPlan updates the given frequency-plan.
+	// Each update creates a new frequency-plan version.
+	UpdateFrequencyPlan(context.Context, *UpdateFrequencyPlanRequest) (*UpdateFrequencyPlanResponse, error)
+	// DeleteFrequencyPlan deletes the frequency-plan matching a given id.
+	DeleteFrequencyPlan(context.Context, *DeleteFrequencyPlanRequest) (*empty.Empty, error)
+	// GetFrequencyPlanVersions returns the versions of the given frequency-plan.
+	GetFrequencyPlanVersions(context.Context, *GetFrequencyPlanVersionsRequest) (*GetFrequencyPlanVersionsResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+	// StreamFrameLogsForGateway returns a stream of frames seen by the given gateway.
+	StreamFrameLogsForGateway(*StreamFrameLogsForGatewayRequest, NetworkServerService_StreamFrameLogsForGatewayServer) error
+	// StreamFrameLogsForDevice returns a stream of frames seen by the given device.
+	StreamFrameLogsForDevice(*StreamFrameLogsForDeviceRequest, NetworkServerService_StreamFrameLogsForDeviceServer) error
+	// CreateFrameLogCapture starts a bounded frame-log capture session for
+	// the given device or gateway.
+	CreateFrameLogCapture(context.Context, *CreateFrameLogCaptureRequest) (*CreateFrameLogCaptureResponse, error)
+	// GetFrameLogCapture returns the status of the given capture session and
+	// the frames captured so far, encoded in the requested format.
+	GetFrameLogCapture(context.Context, *GetFrameLogCaptureRequest) (*GetFrameLogCaptureResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(context.Context, *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group given an id.
+	GetMulticastGroup(context.Context, *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(context.Context, *UpdateMulticastGroupRequest) (*empty.Empty, error)
+	// DeleteMulticastGroup deletes a multicast-group given an id.
+	DeleteMulticastGroup(context.Context, *DeleteMulticastGroupRequest) (*empty.Empty, error)
+	// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+	AddDeviceToMulticastGroup(context.Context, *AddDeviceToMulticastGroupRequest) (*empty.Empty, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+	RemoveDeviceFromMulticastGroup(context.Context, *RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error)
+	// EnqueueMulticastQueueItem enqueues the given multicast queue-item and
+	// increments the frame-counter after enqueueing.
+	EnqueueMulticastQueueItem(context.Context, *EnqueueMulticastQueueItemRequest) (*empty.Empty, error)
+	// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
+	FlushMulticastQueueForMulticastGroup(context.Context, *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error)
+	// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
+	GetMulticastQueueItemsForMulticastGroup(context.Context, *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error)
+	// GetMulticastGroupCoverage returns for each device of the multicast-group
+	// the gateways of the multicast gateway-set which can reach the device.
+	GetMulticastGroupCoverage(context.Context, *GetMulticastGroupCoverageRequest) (*GetMulticastGroupCoverageResponse, error)
+	// EstimateMulticastSession estimates the airtime per gateway, the
+	// duration and the duty-cycle feasibility of a multicast session, without
+	// enqueueing any frames.
+	EstimateMulticastSession(context.Context, *EstimateMulticastSessionRequest) (*EstimateMulticastSessionResponse, error)
+	// GetMulticastTXStatus returns per frame of the multicast-group the
+	// transmission status for each gateway of the gateway-set.
+	GetMulticastTXStatus(context.Context, *GetMulticastTXStatusRequest) (*GetMulticastTXStatusResponse, error)
+	// StartMulticastSetup starts the remote multicast setup of the devices
+	// of the multicast-group, using the LoRaWAN Remote Multicast Setup
+	// package. This replaces an existing setup of the multicast-group.
+	StartMulticastSetup(context.Context, *StartMulticastSetupRequest) (*empty.Empty, error)
+	// GetMulticastSetupStatus returns the remote multicast setup status of
+	// each device of the multicast-group.
+	GetMulticastSetupStatus(context.Context, *GetMulticastSetupStatusRequest) (*GetMulticastSetupStatusResponse, error)
+	// EnqueueCertificationCommand enqueues the given LoRaWAN Certification
+	// Protocol command(s) for a device in certification test mode.
+	EnqueueCertificationCommand(context.Context, *EnqueueCertificationCommandRequest) (*empty.Empty, error)
+	// CreateRoamingAgreement creates the given roaming agreement.
+	CreateRoamingAgreement(context.Context, *CreateRoamingAgreementRequest) (*CreateRoamingAgreementResponse, error)
+	// GetRoamingAgreement returns the roaming agreement matching the given id.
+	GetRoamingAgreement(context.Context, *GetRoamingAgreementRequest) (*GetRoamingAgreementResponse, error)
+	// UpdateRoamingAgreement updates the given roaming agreement.
+	UpdateRoamingAgreement(context.Context, *UpdateRoamingAgreementRequest) (*empty.Empty, error)
+	// DeleteRoamingAgreement deletes the roaming agreement matching the given id.
+	DeleteRoamingAgreement(context.Context, *DeleteRoamingAgreementRequest) (*empty.Empty, error)
+	// ListRoamingAgreements returns the roaming agreements, ordered by NetID.
+	ListRoamingAgreements(context.Context, *ListRoamingAgreementsRequest) (*ListRoamingAgreementsResponse, error)
+	// GetVersion returns the LoRa Server version.
+	GetVersion(context.Context, *empty.Empty) (*GetVersionResponse, error)
+	// ReloadConfiguration reloads the settings of the configuration file
+	// which can be changed without restarting LoRa Server (e.g. the log-level).
+	ReloadConfiguration(context.Context, *empty.Empty) (*empty.Empty, error)
+	// GetSecurityEvents returns the most recent security events.
+	GetSecurityEvents(context.Context, *GetSecurityEventsRequest) (*GetSecurityEventsResponse, error)
+	// GetGatewayContributions returns the contribution metrics of a gateway
+	// per aggregation interval.
+	GetGatewayContributions(context.Context, *GetGatewayContributionsRequest) (*GetGatewayContributionsResponse, error)
+	// StreamGatewayContributions returns a stream of gateway contribution events.
+	StreamGatewayContributions(*StreamGatewayContributionsRequest, NetworkServerService_StreamGatewayContributionsServer) error
+	// GetRedisMemoryUsage returns the last sample of the Redis memory usage
+	// per key class.
+	GetRedisMemoryUsage(context.Context, *empty.Empty) (*GetRedisMemoryUsageResponse, error)
+	// GetGatewayConfigurationStatus returns the configuration rollout status
+	// of the given gateway.
+	GetGatewayConfigurationStatus(context.Context, *GetGatewayConfigurationStatusRequest) (*GetGatewayConfigurationStatusResponse, error)
+	// GetGatewayConfigurationStatusForGatewayProfile returns the configuration
+	// rollout status of the gateways using the given gateway-profile.
+	GetGatewayConfigurationStatusForGatewayProfile(context.Context, *GetGatewayConfigurationStatusForGatewayProfileRequest) (*GetGatewayConfigurationStatusForGatewayProfileResponse, error)
+	// DecodePHYPayload decodes the given PHYPayload (for debugging purposes).
+	// Data frames are matched against the device-sessions to validate the
+	// MIC and to decrypt the mac-commands and (when authorized) FRMPayload.
+	DecodePHYPayload(context.Context, *DecodePHYPayloadRequest) (*DecodePHYPayloadResponse, error)
+	// GetSchedulerBacklog returns the downlink scheduler backlog, either
+	// global or for a single gateway.
+	GetSchedulerBacklog(context.Context, *GetSchedulerBacklogRequest) (*GetSchedulerBacklogResponse, error)
+	// StartGatewayDrain puts the gateway in maintenance drain mode. A
+	// draining gateway is no longer selected for new downlinks (including
+	// multicast), while the outstanding downlinks are still transmitted.
+	StartGatewayDrain(context.Context, *StartGatewayDrainRequest) (*empty.Empty, error)
+	// StopGatewayDrain takes the gateway out of maintenance drain mode.
+	StopGatewayDrain(context.Context, *StopGatewayDrainRequest) (*empty.Empty, error)
+	// GetGatewayDrainStatus returns the drain status of the gateway, including
+	// whether it is safe to power off the gateway.
+	GetGatewayDrainStatus(context.Context, *GetGatewayDrainStatusRequest) (*GetGatewayDrainStatusResponse, error)
+	// GetGatewayInventory returns the inventory (model and versions) of the
+	// gateway, as reported by the gateway stats.
+	GetGatewayInventory(context.Context, *GetGatewayInventoryRequest) (*GetGatewayInventoryResponse, error)
+	// ListGatewayInventory returns the gateway inventories, ordered by
+	// gateway ID.
+	ListGatewayInventory(context.Context, *ListGatewayInventoryRequest) (*ListGatewayInventoryResponse, error)
+	// GetGatewayConnectionState returns the connection state (online /
+	// offline) of the gateway, as reported by the gateway backend.
+	GetGatewayConnectionState(context.Context, *GetGatewayConnectionStateRequest) (*GetGatewayConnectionStateResponse, error)
+	// ListGatewayConnectionStates returns the gateway connection states,
+	// ordered by gateway ID.
+	ListGatewayConnectionStates(context.Context, *ListGatewayConnectionStatesRequest) (*ListGatewayConnectionStatesResponse, error)
+	// ExecGatewayCommand relays the command execution request (e.g. reboot)
+	// through the gateway backend to the gateway. When a timeout is given,
+	// it waits for the command execution response.
+	ExecGatewayCommand(context.Context, *ExecGatewayCommandRequest) (*ExecGatewayCommandResponse, error)
+	// GetGatewayCommandExecResponse returns the command execution response
+	// for the given execution ID.
+	GetGatewayCommandExecResponse(context.Context, *GetGatewayCommandExecResponseRequest) (*GetGatewayCommandExecResponseResponse, error)
+	// GetGatewayDownlinkTXState returns the downlinks sent to the gateway
+	// which are pending a TX acknowledgement (in flight).
+	GetGatewayDownlinkTXState(context.Context, *GetGatewayDownlinkTXStateRequest) (*GetGatewayDownlinkTXStateResponse, error)
+	// ListGatewayDownlinkQueueDepths returns the number of downlinks pending a
+	// TX acknowledgement per gateway, ordered by queue depth (descending).
+	ListGatewayDownlinkQueueDepths(context.Context, *ListGatewayDownlinkQueueDepthsRequest) (*ListGatewayDownlinkQueueDepthsResponse, error)
+	// GetGatewayStatsAggregates returns the aggregated gateway stats for the
+	// given aggregation interval and time range.
+	GetGatewayStatsAggregates(context.Context, *GetGatewayStatsAggregatesRequest) (*GetGatewayStatsAggregatesResponse, error)
+	// GetGatewayNoiseFloor returns the last reported noise-floor and channel
+	// utilization of each channel of the given gateway.
+	GetGatewayNoiseFloor(context.Context, *GetGatewayNoiseFloorRequest) (*GetGatewayNoiseFloorResponse, error)
+}
+
+// UnimplementedNetworkServerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedNetworkServerServiceServer struct {
+}
+
+func (*UnimplementedNetworkServerServiceServer) CreateServiceProfile(ctx context.Context, req *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetServiceProfile(ctx context.Context, req *GetServiceProfileRequest) (*GetServiceProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateServiceProfile(ctx context.Context, req *UpdateServiceProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteServiceProfile(ctx context.Context, req *DeleteServiceProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServiceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateRoutingProfile(ctx context.Context, req *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoutingProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetRoutingProfile(ctx context.Context, req *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateRoutingProfile(ctx context.Context, req *UpdateRoutingProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoutingProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteRoutingProfile(ctx context.Context, req *DeleteRoutingProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoutingProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateDeviceProfile(ctx context.Context, req *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetDeviceProfile(ctx context.Context, req *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateDeviceProfile(ctx context.Context, req *UpdateDeviceProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteDeviceProfile(ctx context.Context, req *DeleteDeviceProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateDevice(ctx context.Context, req *CreateDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetDevice(ctx context.Context, req *GetDeviceRequest) (*GetDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateDevice(ctx context.Context, req *UpdateDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) TransferDevice(ctx context.Context, req *TransferDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteDevice(ctx context.Context, req *DeleteDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ActivateDevice(ctx context.Context, req *ActivateDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeactivateDevice(ctx context.Context, req *DeactivateDeviceRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetDeviceActivation(ctx context.Context, req *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceActivation not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetDeviceClassHealth(ctx context.Context, req *GetDeviceClassHealthRequest) (*GetDeviceClassHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceClassHealth not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ProvisionABPDevice(ctx context.Context, req *ProvisionABPDeviceRequest) (*ProvisionABPDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvisionABPDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateDeviceQueueItem(ctx context.Context, req *CreateDeviceQueueItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceQueueItem not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) FlushDeviceQueueForDevEUI(ctx context.Context, req *FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushDeviceQueueForDevEUI not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *GetDeviceQueueItemsForDevEUIRequest) (*GetDeviceQueueItemsForDevEUIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceQueueItemsForDevEUI not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetNextDownlinkFCntForDevEUI(ctx context.Context, req *GetNextDownlinkFCntForDevEUIRequest) (*GetNextDownlinkFCntForDevEUIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextDownlinkFCntForDevEUI not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*GetRandomDevAddrResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRandomDevAddr not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateMACCommandQueueItem(ctx context.Context, req *CreateMACCommandQueueItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMACCommandQueueItem not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) SendProprietaryPayload(ctx context.Context, req *SendProprietaryPayloadRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendProprietaryPayload not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateGateway(ctx context.Context, req *CreateGatewayRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGateway not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGateway(ctx context.Context, req *GetGatewayRequest) (*GetGatewayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGateway not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateGateway(ctx context.Context, req *UpdateGatewayRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGateway not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteGateway(ctx context.Context, req *DeleteGatewayRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGateway not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateGatewayProfile(ctx context.Context, req *CreateGatewayProfileRequest) (*CreateGatewayProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGatewayProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayProfile(ctx context.Context, req *GetGatewayProfileRequest) (*GetGatewayProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateGatewayProfile(ctx context.Context, req *UpdateGatewayProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGatewayProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteGatewayProfile(ctx context.Context, req *DeleteGatewayProfileRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGatewayProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateFrequencyPlan(ctx context.Context, req *CreateFrequencyPlanRequest) (*CreateFrequencyPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFrequencyPlan not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetFrequencyPlan(ctx context.Context, req *GetFrequencyPlanRequest) (*GetFrequencyPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequencyPlan not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateFrequencyPlan(ctx context.Context, req *UpdateFrequencyPlanRequest) (*UpdateFrequencyPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFrequencyPlan not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteFrequencyPlan(ctx context.Context, req *DeleteFrequencyPlanRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFrequencyPlan not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetFrequencyPlanVersions(ctx context.Context, req *GetFrequencyPlanVersionsRequest) (*GetFrequencyPlanVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequencyPlanVersions not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayStats(ctx context.Context, req *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayStats not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StreamFrameLogsForGateway(req *StreamFrameLogsForGatewayRequest, srv NetworkServerService_StreamFrameLogsForGatewayServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFrameLogsForGateway not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StreamFrameLogsForDevice(req *StreamFrameLogsForDeviceRequest, srv NetworkServerService_StreamFrameLogsForDeviceServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFrameLogsForDevice not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateFrameLogCapture(ctx context.Context, req *CreateFrameLogCaptureRequest) (*CreateFrameLogCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFrameLogCapture not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetFrameLogCapture(ctx context.Context, req *GetFrameLogCaptureRequest) (*GetFrameLogCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrameLogCapture not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateMulticastGroup(ctx context.Context, req *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetMulticastGroup(ctx context.Context, req *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateMulticastGroup(ctx context.Context, req *UpdateMulticastGroupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteMulticastGroup(ctx context.Context, req *DeleteMulticastGroupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) AddDeviceToMulticastGroup(ctx context.Context, req *AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDeviceToMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) RemoveDeviceFromMulticastGroup(ctx context.Context, req *RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDeviceFromMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) EnqueueMulticastQueueItem(ctx context.Context, req *EnqueueMulticastQueueItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueMulticastQueueItem not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) FlushMulticastQueueForMulticastGroup(ctx context.Context, req *FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlushMulticastQueueForMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, req *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastQueueItemsForMulticastGroup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetMulticastGroupCoverage(ctx context.Context, req *GetMulticastGroupCoverageRequest) (*GetMulticastGroupCoverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastGroupCoverage not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) EstimateMulticastSession(ctx context.Context, req *EstimateMulticastSessionRequest) (*EstimateMulticastSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateMulticastSession not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetMulticastTXStatus(ctx context.Context, req *GetMulticastTXStatusRequest) (*GetMulticastTXStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastTXStatus not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StartMulticastSetup(ctx context.Context, req *StartMulticastSetupRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMulticastSetup not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetMulticastSetupStatus(ctx context.Context, req *GetMulticastSetupStatusRequest) (*GetMulticastSetupStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastSetupStatus not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) EnqueueCertificationCommand(ctx context.Context, req *EnqueueCertificationCommandRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueCertificationCommand not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) CreateRoamingAgreement(ctx context.Context, req *CreateRoamingAgreementRequest) (*CreateRoamingAgreementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoamingAgreement not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetRoamingAgreement(ctx context.Context, req *GetRoamingAgreementRequest) (*GetRoamingAgreementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoamingAgreement not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) UpdateRoamingAgreement(ctx context.Context, req *UpdateRoamingAgreementRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoamingAgreement not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DeleteRoamingAgreement(ctx context.Context, req *DeleteRoamingAgreementRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoamingAgreement not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ListRoamingAgreements(ctx context.Context, req *ListRoamingAgreementsRequest) (*ListRoamingAgreementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoamingAgreements not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetVersion(ctx context.Context, req *empty.Empty) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ReloadConfiguration(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfiguration not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetSecurityEvents(ctx context.Context, req *GetSecurityEventsRequest) (*GetSecurityEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecurityEvents not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayContributions(ctx context.Context, req *GetGatewayContributionsRequest) (*GetGatewayContributionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayContributions not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StreamGatewayContributions(req *StreamGatewayContributionsRequest, srv NetworkServerService_StreamGatewayContributionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamGatewayContributions not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetRedisMemoryUsage(ctx context.Context, req *empty.Empty) (*GetRedisMemoryUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRedisMemoryUsage not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayConfigurationStatus(ctx context.Context, req *GetGatewayConfigurationStatusRequest) (*GetGatewayConfigurationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayConfigurationStatus not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayConfigurationStatusForGatewayProfile(ctx context.Context, req *GetGatewayConfigurationStatusForGatewayProfileRequest) (*GetGatewayConfigurationStatusForGatewayProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayConfigurationStatusForGatewayProfile not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) DecodePHYPayload(ctx context.Context, req *DecodePHYPayloadRequest) (*DecodePHYPayloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodePHYPayload not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetSchedulerBacklog(ctx context.Context, req *GetSchedulerBacklogRequest) (*GetSchedulerBacklogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedulerBacklog not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StartGatewayDrain(ctx context.Context, req *StartGatewayDrainRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGatewayDrain not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) StopGatewayDrain(ctx context.Context, req *StopGatewayDrainRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopGatewayDrain not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayDrainStatus(ctx context.Context, req *GetGatewayDrainStatusRequest) (*GetGatewayDrainStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayDrainStatus not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayInventory(ctx context.Context, req *GetGatewayInventoryRequest) (*GetGatewayInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayInventory not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ListGatewayInventory(ctx context.Context, req *ListGatewayInventoryRequest) (*ListGatewayInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGatewayInventory not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayConnectionState(ctx context.Context, req *GetGatewayConnectionStateRequest) (*GetGatewayConnectionStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayConnectionState not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ListGatewayConnectionStates(ctx context.Context, req *ListGatewayConnectionStatesRequest) (*ListGatewayConnectionStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGatewayConnectionStates not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ExecGatewayCommand(ctx context.Context, req *ExecGatewayCommandRequest) (*ExecGatewayCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecGatewayCommand not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayCommandExecResponse(ctx context.Context, req *GetGatewayCommandExecResponseRequest) (*GetGatewayCommandExecResponseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayCommandExecResponse not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayDownlinkTXState(ctx context.Context, req *GetGatewayDownlinkTXStateRequest) (*GetGatewayDownlinkTXStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayDownlinkTXState not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) ListGatewayDownlinkQueueDepths(ctx context.Context, req *ListGatewayDownlinkQueueDepthsRequest) (*ListGatewayDownlinkQueueDepthsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGatewayDownlinkQueueDepths not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayStatsAggregates(ctx context.Context, req *GetGatewayStatsAggregatesRequest) (*GetGatewayStatsAggregatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayStatsAggregates not implemented")
+}
+func (*UnimplementedNetworkServerServiceServer) GetGatewayNoiseFloor(ctx context.Context, req *GetGatewayNoiseFloorRequest) (*GetGatewayNoiseFloorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGatewayNoiseFloor not implemented")
+}
+
+func RegisterNetworkServerServiceServer(s *grpc.Server, srv NetworkServerServiceServer) {
+	s.RegisterService(&_NetworkServerService_serviceDesc, srv)
+}
+
+func _NetworkServerService_CreateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateServiceProfile(ctx, req.(*CreateServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetServiceProfile(ctx, req.(*GetServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateServiceProfile(ctx, req.(*UpdateServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteServiceProfile(ctx, req.(*DeleteServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoutingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateRoutingProfile(ctx, req.(*CreateRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetRoutingProfile(ctx, req.(*GetRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoutingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateRoutingProfile(ctx, req.(*UpdateRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoutingProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteRoutingProfile(ctx, req.(*DeleteRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateDeviceProfile(ctx, req.(*CreateDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceProfile(ctx, req.(*GetDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateDeviceProfile(ctx, req.(*UpdateDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteDeviceProfile(ctx, req.(*DeleteDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_TransferDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).TransferDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/TransferDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).TransferDevice(ctx, req.(*TransferDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_ActivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ActivateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ActivateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ActivateDevice(ctx, req.(*ActivateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeactivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeactivateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeactivateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeactivateDevice(ctx, req.(*DeactivateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceActivationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceActivation(ctx, req.(*GetDeviceActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceClassHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceClassHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceClassHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceClassHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceClassHealth(ctx, req.(*GetDeviceClassHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_ProvisionABPDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionABPDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).ProvisionABPDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/ProvisionABPDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).ProvisionABPDevice(ctx, req.(*ProvisionABPDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateDeviceQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateDeviceQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateDeviceQueueItem(ctx, req.(*CreateDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_FlushDeviceQueueForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushDeviceQueueForDevEUIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).FlushDeviceQueueForDevEUI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/FlushDeviceQueueForDevEUI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).FlushDeviceQueueForDevEUI(ctx, req.(*FlushDeviceQueueForDevEUIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetDeviceQueueItemsForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceQueueItemsForDevEUIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetDeviceQueueItemsForDevEUI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetDeviceQueueItemsForDevEUI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetDeviceQueueItemsForDevEUI(ctx, req.(*GetDeviceQueueItemsForDevEUIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetNextDownlinkFCntForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextDownlinkFCntForDevEUIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetNextDownlinkFCntForDevEUI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetNextDownlinkFCntForDevEUI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetNextDownlinkFCntForDevEUI(ctx, req.(*GetNextDownlinkFCntForDevEUIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetRandomDevAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetRandomDevAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetRandomDevAddr(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateMACCommandQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMACCommandQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateMACCommandQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateMACCommandQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateMACCommandQueueItem(ctx, req.(*CreateMACCommandQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendProprietaryPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).SendProprietaryPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/SendProprietaryPayload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).SendProprietaryPayload(ctx, req.(*SendProprietaryPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetGateway(ctx, req.(*GetGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGatewayProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateGatewayProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateGatewayProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateGatewayProfile(ctx, req.(*CreateGatewayProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetGatewayProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetGatewayProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetGatewayProfile(ctx, req.(*GetGatewayProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_UpdateGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateGatewayProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateGatewayProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateGatewayProfile(ctx, req.(*UpdateGatewayProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_DeleteGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewayProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteGatewayProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteGatewayProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteGatewayProfile(ctx, req.(*DeleteGatewayProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_CreateFrequencyPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFrequencyPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).CreateFrequencyPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/CreateFrequencyPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).CreateFrequencyPlan(ctx, req.(*CreateFrequencyPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServerService_GetFrequencyPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrequencyPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetFrequencyPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetFrequencyPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetFrequencyPlan(ctx, req.(*GetFrequencyPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedNetworkServerServiceServer) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, req *GetMulticastQueueItemsForMulticastGroupRequest) (*GetMulticastQueueItemsForMulticastGroupResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetMulticastQueueItemsForMulticastGroup not implemented")
+
+func _NetworkServerService_UpdateFrequencyPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFrequencyPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).UpdateFrequencyPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/UpdateFrequencyPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).UpdateFrequencyPlan(ctx, req.(*UpdateFrequencyPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedNetworkServerServiceServer) GetVersion(ctx context.Context, req *empty.Empty) (*GetVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+
+func _NetworkServerService_DeleteFrequencyPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFrequencyPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).DeleteFrequencyPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/DeleteFrequencyPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).DeleteFrequencyPlan(ctx, req.(*DeleteFrequencyPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterNetworkServerServiceServer(s *grpc.Server, srv NetworkServerServiceServer) {
-	s.RegisterService(&_NetworkServerService_serviceDesc, srv)
+func _NetworkServerService_GetFrequencyPlanVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrequencyPlanVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServiceServer).GetFrequencyPlanVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServerService/GetFrequencyPlanVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServiceServer).GetFrequencyPlanVersions(ctx, req.(*GetFrequencyPlanVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateServiceProfileRequest)
+func _NetworkServerService_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateServiceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateServiceProfile",
+		FullMethod: "/ns.NetworkServerService/GetGatewayStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateServiceProfile(ctx, req.(*CreateServiceProfileRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceProfileRequest)
+func _NetworkServerService_StreamFrameLogsForGateway_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFrameLogsForGatewayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServiceServer).StreamFrameLogsForGateway(m, &networkServerServiceStreamFrameLogsForGatewayServer{stream})
+}
+
+type NetworkServerService_StreamFrameLogsForGatewayServer interface {
+	Send(*StreamFrameLogsForGatewayResponse) error
+	grpc.ServerStream
+}
+
+type networkServerServiceStreamFrameLogsForGatewayServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerServiceStreamFrameLogsForGatewayServer) Send(m *StreamFrameLogsForGatewayResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServerService_StreamFrameLogsForDevice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFrameLogsForDeviceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServiceServer).StreamFrameLogsForDevice(m, &networkServerServiceStreamFrameLogsForDeviceServer{stream})
+}
+
+type NetworkServerService_StreamFrameLogsForDeviceServer interface {
+	Send(*StreamFrameLogsForDeviceResponse) error
+	grpc.ServerStream
+}
+
+type networkServerServiceStreamFrameLogsForDeviceServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerServiceStreamFrameLogsForDeviceServer) Send(m *StreamFrameLogsForDeviceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServerService_CreateFrameLogCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFrameLogCaptureRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetServiceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).CreateFrameLogCapture(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetServiceProfile",
+		FullMethod: "/ns.NetworkServerService/CreateFrameLogCapture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetServiceProfile(ctx, req.(*GetServiceProfileRequest))
+		return srv.(NetworkServerServiceServer).CreateFrameLogCapture(ctx, req.(*CreateFrameLogCaptureRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServiceProfileRequest)
+func _NetworkServerService_GetFrameLogCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrameLogCaptureRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateServiceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).GetFrameLogCapture(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateServiceProfile",
+		FullMethod: "/ns.NetworkServerService/GetFrameLogCapture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateServiceProfile(ctx, req.(*UpdateServiceProfileRequest))
+		return srv.(NetworkServerServiceServer).GetFrameLogCapture(ctx, req.(*GetFrameLogCaptureRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServiceProfileRequest)
+func _NetworkServerService_CreateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteServiceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteServiceProfile",
+		FullMethod: "/ns.NetworkServerService/CreateMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteServiceProfile(ctx, req.(*DeleteServiceProfileRequest))
+		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, req.(*CreateMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoutingProfileRequest)
+func _NetworkServerService_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateRoutingProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateRoutingProfile",
+		FullMethod: "/ns.NetworkServerService/GetMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateRoutingProfile(ctx, req.(*CreateRoutingProfileRequest))
+		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, req.(*GetMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoutingProfileRequest)
+func _NetworkServerService_UpdateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetRoutingProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetRoutingProfile",
+		FullMethod: "/ns.NetworkServerService/UpdateMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetRoutingProfile(ctx, req.(*GetRoutingProfileRequest))
+		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, req.(*UpdateMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRoutingProfileRequest)
+func _NetworkServerService_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateRoutingProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateRoutingProfile",
+		FullMethod: "/ns.NetworkServerService/DeleteMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateRoutingProfile(ctx, req.(*UpdateRoutingProfileRequest))
+		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, req.(*DeleteMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRoutingProfileRequest)
+func _NetworkServerService_AddDeviceToMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDeviceToMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteRoutingProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteRoutingProfile",
+		FullMethod: "/ns.NetworkServerService/AddDeviceToMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteRoutingProfile(ctx, req.(*DeleteRoutingProfileRequest))
+		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, req.(*AddDeviceToMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceProfileRequest)
+func _NetworkServerService_RemoveDeviceFromMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceFromMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateDeviceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateDeviceProfile",
+		FullMethod: "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateDeviceProfile(ctx, req.(*CreateDeviceProfileRequest))
+		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, req.(*RemoveDeviceFromMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceProfileRequest)
+func _NetworkServerService_EnqueueMulticastQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastQueueItemRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetDeviceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetDeviceProfile",
+		FullMethod: "/ns.NetworkServerService/EnqueueMulticastQueueItem",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetDeviceProfile(ctx, req.(*GetDeviceProfileRequest))
+		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, req.(*EnqueueMulticastQueueItemRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceProfileRequest)
+func _NetworkServerService_FlushMulticastQueueForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushMulticastQueueForMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateDeviceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateDeviceProfile",
+		FullMethod: "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateDeviceProfile(ctx, req.(*UpdateDeviceProfileRequest))
+		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, req.(*FlushMulticastQueueForMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDeviceProfileRequest)
+func _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastQueueItemsForMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteDeviceProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteDeviceProfile",
+		FullMethod: "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteDeviceProfile(ctx, req.(*DeleteDeviceProfileRequest))
+		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, req.(*GetMulticastQueueItemsForMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceRequest)
+func _NetworkServerService_GetMulticastGroupCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupCoverageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).GetMulticastGroupCoverage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateDevice",
+		FullMethod: "/ns.NetworkServerService/GetMulticastGroupCoverage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+		return srv.(NetworkServerServiceServer).GetMulticastGroupCoverage(ctx, req.(*GetMulticastGroupCoverageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceRequest)
+func _NetworkServerService_EstimateMulticastSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateMulticastSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).EstimateMulticastSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetDevice",
+		FullMethod: "/ns.NetworkServerService/EstimateMulticastSession",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetDevice(ctx, req.(*GetDeviceRequest))
+		return srv.(NetworkServerServiceServer).EstimateMulticastSession(ctx, req.(*EstimateMulticastSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceRequest)
+func _NetworkServerService_GetMulticastTXStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastTXStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).GetMulticastTXStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateDevice",
+		FullMethod: "/ns.NetworkServerService/GetMulticastTXStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+		return srv.(NetworkServerServiceServer).GetMulticastTXStatus(ctx, req.(*GetMulticastTXStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDeviceRequest)
+func _NetworkServerService_StartMulticastSetup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMulticastSetupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).StartMulticastSetup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteDevice",
+		FullMethod: "/ns.NetworkServerService/StartMulticastSetup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+		return srv.(NetworkServerServiceServer).StartMulticastSetup(ctx, req.(*StartMulticastSetupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_ActivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ActivateDeviceRequest)
+func _NetworkServerService_GetMulticastSetupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastSetupStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).ActivateDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).GetMulticastSetupStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/ActivateDevice",
+		FullMethod: "/ns.NetworkServerService/GetMulticastSetupStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).ActivateDevice(ctx, req.(*ActivateDeviceRequest))
+		return srv.(NetworkServerServiceServer).GetMulticastSetupStatus(ctx, req.(*GetMulticastSetupStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeactivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeactivateDeviceRequest)
+func _NetworkServerService_EnqueueCertificationCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueCertificationCommandRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeactivateDevice(ctx, in)
+		return srv.(NetworkServerServiceServer).EnqueueCertificationCommand(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeactivateDevice",
+		FullMethod: "/ns.NetworkServerService/EnqueueCertificationCommand",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeactivateDevice(ctx, req.(*DeactivateDeviceRequest))
+		return srv.(NetworkServerServiceServer).EnqueueCertificationCommand(ctx, req.(*EnqueueCertificationCommandRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetDeviceActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceActivationRequest)
+func _NetworkServerService_CreateRoamingAgreement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoamingAgreementRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetDeviceActivation(ctx, in)
+		return srv.(NetworkServerServiceServer).CreateRoamingAgreement(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetDeviceActivation",
+		FullMethod: "/ns.NetworkServerService/CreateRoamingAgreement",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetDeviceActivation(ctx, req.(*GetDeviceActivationRequest))
+		return srv.(NetworkServerServiceServer).CreateRoamingAgreement(ctx, req.(*CreateRoamingAgreementRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceQueueItemRequest)
+func _NetworkServerService_GetRoamingAgreement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoamingAgreementRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateDeviceQueueItem(ctx, in)
+		return srv.(NetworkServerServiceServer).GetRoamingAgreement(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateDeviceQueueItem",
+		FullMethod: "/ns.NetworkServerService/GetRoamingAgreement",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateDeviceQueueItem(ctx, req.(*CreateDeviceQueueItemRequest))
+		return srv.(NetworkServerServiceServer).GetRoamingAgreement(ctx, req.(*GetRoamingAgreementRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_FlushDeviceQueueForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FlushDeviceQueueForDevEUIRequest)
+func _NetworkServerService_UpdateRoamingAgreement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoamingAgreementRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).FlushDeviceQueueForDevEUI(ctx, in)
+		return srv.(NetworkServerServiceServer).UpdateRoamingAgreement(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/FlushDeviceQueueForDevEUI",
+		FullMethod: "/ns.NetworkServerService/UpdateRoamingAgreement",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).FlushDeviceQueueForDevEUI(ctx, req.(*FlushDeviceQueueForDevEUIRequest))
+		return srv.(NetworkServerServiceServer).UpdateRoamingAgreement(ctx, req.(*UpdateRoamingAgreementRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetDeviceQueueItemsForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceQueueItemsForDevEUIRequest)
+func _NetworkServerService_DeleteRoamingAgreement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoamingAgreementRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetDeviceQueueItemsForDevEUI(ctx, in)
+		return srv.(NetworkServerServiceServer).DeleteRoamingAgreement(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetDeviceQueueItemsForDevEUI",
+		FullMethod: "/ns.NetworkServerService/DeleteRoamingAgreement",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetDeviceQueueItemsForDevEUI(ctx, req.(*GetDeviceQueueItemsForDevEUIRequest))
+		return srv.(NetworkServerServiceServer).DeleteRoamingAgreement(ctx, req.(*DeleteRoamingAgreementRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetNextDownlinkFCntForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNextDownlinkFCntForDevEUIRequest)
+func _NetworkServerService_ListRoamingAgreements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoamingAgreementsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetNextDownlinkFCntForDevEUI(ctx, in)
+		return srv.(NetworkServerServiceServer).ListRoamingAgreements(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetNextDownlinkFCntForDevEUI",
+		FullMethod: "/ns.NetworkServerService/ListRoamingAgreements",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetNextDownlinkFCntForDevEUI(ctx, req.(*GetNextDownlinkFCntForDevEUIRequest))
+		return srv.(NetworkServerServiceServer).ListRoamingAgreements(ctx, req.(*ListRoamingAgreementsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _NetworkServerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetRandomDevAddr(ctx, in)
+		return srv.(NetworkServerServiceServer).GetVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetRandomDevAddr",
+		FullMethod: "/ns.NetworkServerService/GetVersion",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetRandomDevAddr(ctx, req.(*empty.Empty))
+		return srv.(NetworkServerServiceServer).GetVersion(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateMACCommandQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMACCommandQueueItemRequest)
+func _NetworkServerService_ReloadConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateMACCommandQueueItem(ctx, in)
+		return srv.(NetworkServerServiceServer).ReloadConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateMACCommandQueueItem",
+		FullMethod: "/ns.NetworkServerService/ReloadConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateMACCommandQueueItem(ctx, req.(*CreateMACCommandQueueItemRequest))
+		return srv.(NetworkServerServiceServer).ReloadConfiguration(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendProprietaryPayloadRequest)
+func _NetworkServerService_GetSecurityEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecurityEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).SendProprietaryPayload(ctx, in)
+		return srv.(NetworkServerServiceServer).GetSecurityEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/SendProprietaryPayload",
+		FullMethod: "/ns.NetworkServerService/GetSecurityEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).SendProprietaryPayload(ctx, req.(*SendProprietaryPayloadRequest))
+		return srv.(NetworkServerServiceServer).GetSecurityEvents(ctx, req.(*GetSecurityEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGatewayRequest)
+func _NetworkServerService_GetGatewayContributions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayContributionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateGateway(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayContributions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateGateway",
+		FullMethod: "/ns.NetworkServerService/GetGatewayContributions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayContributions(ctx, req.(*GetGatewayContributionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayRequest)
+func _NetworkServerService_StreamGatewayContributions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamGatewayContributionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServiceServer).StreamGatewayContributions(m, &networkServerServiceStreamGatewayContributionsServer{stream})
+}
+
+type NetworkServerService_StreamGatewayContributionsServer interface {
+	Send(*GatewayContributionEvent) error
+	grpc.ServerStream
+}
+
+type networkServerServiceStreamGatewayContributionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerServiceStreamGatewayContributionsServer) Send(m *GatewayContributionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServerService_GetRedisMemoryUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetGateway(ctx, in)
+		return srv.(NetworkServerServiceServer).GetRedisMemoryUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetGateway",
+		FullMethod: "/ns.NetworkServerService/GetRedisMemoryUsage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetGateway(ctx, req.(*GetGatewayRequest))
+		return srv.(NetworkServerServiceServer).GetRedisMemoryUsage(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGatewayRequest)
+func _NetworkServerService_GetGatewayConfigurationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayConfigurationStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateGateway(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayConfigurationStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateGateway",
+		FullMethod: "/ns.NetworkServerService/GetGatewayConfigurationStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayConfigurationStatus(ctx, req.(*GetGatewayConfigurationStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGatewayRequest)
+func _NetworkServerService_GetGatewayConfigurationStatusForGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayConfigurationStatusForGatewayProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteGateway(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayConfigurationStatusForGatewayProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteGateway",
+		FullMethod: "/ns.NetworkServerService/GetGatewayConfigurationStatusForGatewayProfile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayConfigurationStatusForGatewayProfile(ctx, req.(*GetGatewayConfigurationStatusForGatewayProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_CreateGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGatewayProfileRequest)
+func _NetworkServerService_DecodePHYPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodePHYPayloadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateGatewayProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).DecodePHYPayload(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateGatewayProfile",
+		FullMethod: "/ns.NetworkServerService/DecodePHYPayload",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateGatewayProfile(ctx, req.(*CreateGatewayProfileRequest))
+		return srv.(NetworkServerServiceServer).DecodePHYPayload(ctx, req.(*DecodePHYPayloadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayProfileRequest)
+func _NetworkServerService_GetSchedulerBacklog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchedulerBacklogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetGatewayProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).GetSchedulerBacklog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetGatewayProfile",
+		FullMethod: "/ns.NetworkServerService/GetSchedulerBacklog",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetGatewayProfile(ctx, req.(*GetGatewayProfileRequest))
+		return srv.(NetworkServerServiceServer).GetSchedulerBacklog(ctx, req.(*GetSchedulerBacklogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGatewayProfileRequest)
+func _NetworkServerService_StartGatewayDrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGatewayDrainRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateGatewayProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).StartGatewayDrain(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateGatewayProfile",
+		FullMethod: "/ns.NetworkServerService/StartGatewayDrain",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateGatewayProfile(ctx, req.(*UpdateGatewayProfileRequest))
+		return srv.(NetworkServerServiceServer).StartGatewayDrain(ctx, req.(*StartGatewayDrainRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteGatewayProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGatewayProfileRequest)
+func _NetworkServerService_StopGatewayDrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopGatewayDrainRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteGatewayProfile(ctx, in)
+		return srv.(NetworkServerServiceServer).StopGatewayDrain(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteGatewayProfile",
+		FullMethod: "/ns.NetworkServerService/StopGatewayDrain",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteGatewayProfile(ctx, req.(*DeleteGatewayProfileRequest))
+		return srv.(NetworkServerServiceServer).StopGatewayDrain(ctx, req.(*StopGatewayDrainRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayStatsRequest)
+func _NetworkServerService_GetGatewayDrainStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayDrainStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetGatewayStats(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayDrainStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetGatewayStats",
+		FullMethod: "/ns.NetworkServerService/GetGatewayDrainStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayDrainStatus(ctx, req.(*GetGatewayDrainStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_StreamFrameLogsForGateway_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StreamFrameLogsForGatewayRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(NetworkServerServiceServer).StreamFrameLogsForGateway(m, &networkServerServiceStreamFrameLogsForGatewayServer{stream})
-}
-
-type NetworkServerService_StreamFrameLogsForGatewayServer interface {
-	Send(*StreamFrameLogsForGatewayResponse) error
-	grpc.ServerStream
-}
-
-type networkServerServiceStreamFrameLogsForGatewayServer struct {
-	grpc.ServerStream
-}
-
-func (x *networkServerServiceStreamFrameLogsForGatewayServer) Send(m *StreamFrameLogsForGatewayResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _NetworkServerService_StreamFrameLogsForDevice_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StreamFrameLogsForDeviceRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(NetworkServerServiceServer).StreamFrameLogsForDevice(m, &networkServerServiceStreamFrameLogsForDeviceServer{stream})
-}
-
-type NetworkServerService_StreamFrameLogsForDeviceServer interface {
-	Send(*StreamFrameLogsForDeviceResponse) error
-	grpc.ServerStream
-}
-
-type networkServerServiceStreamFrameLogsForDeviceServer struct {
-	grpc.ServerStream
-}
-
-func (x *networkServerServiceStreamFrameLogsForDeviceServer) Send(m *StreamFrameLogsForDeviceResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _NetworkServerService_CreateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateMulticastGroupRequest)
+func _NetworkServerService_GetGatewayInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayInventoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayInventory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/CreateMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/GetGatewayInventory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).CreateMulticastGroup(ctx, req.(*CreateMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayInventory(ctx, req.(*GetGatewayInventoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMulticastGroupRequest)
+func _NetworkServerService_ListGatewayInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayInventoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).ListGatewayInventory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/ListGatewayInventory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetMulticastGroup(ctx, req.(*GetMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).ListGatewayInventory(ctx, req.(*ListGatewayInventoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_UpdateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateMulticastGroupRequest)
+func _NetworkServerService_GetGatewayConnectionState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayConnectionStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayConnectionState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/UpdateMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/GetGatewayConnectionState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).UpdateMulticastGroup(ctx, req.(*UpdateMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayConnectionState(ctx, req.(*GetGatewayConnectionStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteMulticastGroupRequest)
+func _NetworkServerService_ListGatewayConnectionStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayConnectionStatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).ListGatewayConnectionStates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/DeleteMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/ListGatewayConnectionStates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).DeleteMulticastGroup(ctx, req.(*DeleteMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).ListGatewayConnectionStates(ctx, req.(*ListGatewayConnectionStatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_AddDeviceToMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddDeviceToMulticastGroupRequest)
+func _NetworkServerService_ExecGatewayCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecGatewayCommandRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).ExecGatewayCommand(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/AddDeviceToMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/ExecGatewayCommand",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).AddDeviceToMulticastGroup(ctx, req.(*AddDeviceToMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).ExecGatewayCommand(ctx, req.(*ExecGatewayCommandRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_RemoveDeviceFromMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveDeviceFromMulticastGroupRequest)
+func _NetworkServerService_GetGatewayCommandExecResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayCommandExecResponseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayCommandExecResponse(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/RemoveDeviceFromMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/GetGatewayCommandExecResponse",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).RemoveDeviceFromMulticastGroup(ctx, req.(*RemoveDeviceFromMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayCommandExecResponse(ctx, req.(*GetGatewayCommandExecResponseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_EnqueueMulticastQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnqueueMulticastQueueItemRequest)
+func _NetworkServerService_GetGatewayDownlinkTXState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayDownlinkTXStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayDownlinkTXState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/EnqueueMulticastQueueItem",
+		FullMethod: "/ns.NetworkServerService/GetGatewayDownlinkTXState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).EnqueueMulticastQueueItem(ctx, req.(*EnqueueMulticastQueueItemRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayDownlinkTXState(ctx, req.(*GetGatewayDownlinkTXStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_FlushMulticastQueueForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FlushMulticastQueueForMulticastGroupRequest)
+func _NetworkServerService_ListGatewayDownlinkQueueDepths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayDownlinkQueueDepthsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).ListGatewayDownlinkQueueDepths(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/FlushMulticastQueueForMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/ListGatewayDownlinkQueueDepths",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).FlushMulticastQueueForMulticastGroup(ctx, req.(*FlushMulticastQueueForMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).ListGatewayDownlinkQueueDepths(ctx, req.(*ListGatewayDownlinkQueueDepthsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetMulticastQueueItemsForMulticastGroupRequest)
+func _NetworkServerService_GetGatewayStatsAggregates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayStatsAggregatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayStatsAggregates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetMulticastQueueItemsForMulticastGroup",
+		FullMethod: "/ns.NetworkServerService/GetGatewayStatsAggregates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetMulticastQueueItemsForMulticastGroup(ctx, req.(*GetMulticastQueueItemsForMulticastGroupRequest))
+		return srv.(NetworkServerServiceServer).GetGatewayStatsAggregates(ctx, req.(*GetGatewayStatsAggregatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServerService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _NetworkServerService_GetGatewayNoiseFloor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayNoiseFloorRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServiceServer).GetVersion(ctx, in)
+		return srv.(NetworkServerServiceServer).GetGatewayNoiseFloor(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServerService/GetVersion",
+		FullMethod: "/ns.NetworkServerService/GetGatewayNoiseFloor",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServiceServer).GetVersion(ctx, req.(*empty.Empty))
+		return srv.(NetworkServerServiceServer).GetGatewayNoiseFloor(ctx, req.(*GetGatewayNoiseFloorRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -5970,6 +14193,10 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDevice",
 			Handler:    _NetworkServerService_UpdateDevice_Handler,
 		},
+		{
+			MethodName: "TransferDevice",
+			Handler:    _NetworkServerService_TransferDevice_Handler,
+		},
 		{
 			MethodName: "DeleteDevice",
 			Handler:    _NetworkServerService_DeleteDevice_Handler,
@@ -5986,6 +14213,14 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeviceActivation",
 			Handler:    _NetworkServerService_GetDeviceActivation_Handler,
 		},
+		{
+			MethodName: "GetDeviceClassHealth",
+			Handler:    _NetworkServerService_GetDeviceClassHealth_Handler,
+		},
+		{
+			MethodName: "ProvisionABPDevice",
+			Handler:    _NetworkServerService_ProvisionABPDevice_Handler,
+		},
 		{
 			MethodName: "CreateDeviceQueueItem",
 			Handler:    _NetworkServerService_CreateDeviceQueueItem_Handler,
@@ -6046,10 +14281,38 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGatewayProfile",
 			Handler:    _NetworkServerService_DeleteGatewayProfile_Handler,
 		},
+		{
+			MethodName: "CreateFrequencyPlan",
+			Handler:    _NetworkServerService_CreateFrequencyPlan_Handler,
+		},
+		{
+			MethodName: "GetFrequencyPlan",
+			Handler:    _NetworkServerService_GetFrequencyPlan_Handler,
+		},
+		{
+			MethodName: "UpdateFrequencyPlan",
+			Handler:    _NetworkServerService_UpdateFrequencyPlan_Handler,
+		},
+		{
+			MethodName: "DeleteFrequencyPlan",
+			Handler:    _NetworkServerService_DeleteFrequencyPlan_Handler,
+		},
+		{
+			MethodName: "GetFrequencyPlanVersions",
+			Handler:    _NetworkServerService_GetFrequencyPlanVersions_Handler,
+		},
 		{
 			MethodName: "GetGatewayStats",
 			Handler:    _NetworkServerService_GetGatewayStats_Handler,
 		},
+		{
+			MethodName: "CreateFrameLogCapture",
+			Handler:    _NetworkServerService_CreateFrameLogCapture_Handler,
+		},
+		{
+			MethodName: "GetFrameLogCapture",
+			Handler:    _NetworkServerService_GetFrameLogCapture_Handler,
+		},
 		{
 			MethodName: "CreateMulticastGroup",
 			Handler:    _NetworkServerService_CreateMulticastGroup_Handler,
@@ -6086,10 +14349,138 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMulticastQueueItemsForMulticastGroup",
 			Handler:    _NetworkServerService_GetMulticastQueueItemsForMulticastGroup_Handler,
 		},
+		{
+			MethodName: "GetMulticastGroupCoverage",
+			Handler:    _NetworkServerService_GetMulticastGroupCoverage_Handler,
+		},
+		{
+			MethodName: "EstimateMulticastSession",
+			Handler:    _NetworkServerService_EstimateMulticastSession_Handler,
+		},
+		{
+			MethodName: "GetMulticastTXStatus",
+			Handler:    _NetworkServerService_GetMulticastTXStatus_Handler,
+		},
+		{
+			MethodName: "StartMulticastSetup",
+			Handler:    _NetworkServerService_StartMulticastSetup_Handler,
+		},
+		{
+			MethodName: "GetMulticastSetupStatus",
+			Handler:    _NetworkServerService_GetMulticastSetupStatus_Handler,
+		},
+		{
+			MethodName: "EnqueueCertificationCommand",
+			Handler:    _NetworkServerService_EnqueueCertificationCommand_Handler,
+		},
+		{
+			MethodName: "CreateRoamingAgreement",
+			Handler:    _NetworkServerService_CreateRoamingAgreement_Handler,
+		},
+		{
+			MethodName: "GetRoamingAgreement",
+			Handler:    _NetworkServerService_GetRoamingAgreement_Handler,
+		},
+		{
+			MethodName: "UpdateRoamingAgreement",
+			Handler:    _NetworkServerService_UpdateRoamingAgreement_Handler,
+		},
+		{
+			MethodName: "DeleteRoamingAgreement",
+			Handler:    _NetworkServerService_DeleteRoamingAgreement_Handler,
+		},
+		{
+			MethodName: "ListRoamingAgreements",
+			Handler:    _NetworkServerService_ListRoamingAgreements_Handler,
+		},
 		{
 			MethodName: "GetVersion",
 			Handler:    _NetworkServerService_GetVersion_Handler,
 		},
+		{
+			MethodName: "ReloadConfiguration",
+			Handler:    _NetworkServerService_ReloadConfiguration_Handler,
+		},
+		{
+			MethodName: "GetSecurityEvents",
+			Handler:    _NetworkServerService_GetSecurityEvents_Handler,
+		},
+		{
+			MethodName: "GetGatewayContributions",
+			Handler:    _NetworkServerService_GetGatewayContributions_Handler,
+		},
+		{
+			MethodName: "GetRedisMemoryUsage",
+			Handler:    _NetworkServerService_GetRedisMemoryUsage_Handler,
+		},
+		{
+			MethodName: "GetGatewayConfigurationStatus",
+			Handler:    _NetworkServerService_GetGatewayConfigurationStatus_Handler,
+		},
+		{
+			MethodName: "GetGatewayConfigurationStatusForGatewayProfile",
+			Handler:    _NetworkServerService_GetGatewayConfigurationStatusForGatewayProfile_Handler,
+		},
+		{
+			MethodName: "DecodePHYPayload",
+			Handler:    _NetworkServerService_DecodePHYPayload_Handler,
+		},
+		{
+			MethodName: "GetSchedulerBacklog",
+			Handler:    _NetworkServerService_GetSchedulerBacklog_Handler,
+		},
+		{
+			MethodName: "StartGatewayDrain",
+			Handler:    _NetworkServerService_StartGatewayDrain_Handler,
+		},
+		{
+			MethodName: "StopGatewayDrain",
+			Handler:    _NetworkServerService_StopGatewayDrain_Handler,
+		},
+		{
+			MethodName: "GetGatewayDrainStatus",
+			Handler:    _NetworkServerService_GetGatewayDrainStatus_Handler,
+		},
+		{
+			MethodName: "GetGatewayInventory",
+			Handler:    _NetworkServerService_GetGatewayInventory_Handler,
+		},
+		{
+			MethodName: "ListGatewayInventory",
+			Handler:    _NetworkServerService_ListGatewayInventory_Handler,
+		},
+		{
+			MethodName: "GetGatewayConnectionState",
+			Handler:    _NetworkServerService_GetGatewayConnectionState_Handler,
+		},
+		{
+			MethodName: "ListGatewayConnectionStates",
+			Handler:    _NetworkServerService_ListGatewayConnectionStates_Handler,
+		},
+		{
+			MethodName: "ExecGatewayCommand",
+			Handler:    _NetworkServerService_ExecGatewayCommand_Handler,
+		},
+		{
+			MethodName: "GetGatewayCommandExecResponse",
+			Handler:    _NetworkServerService_GetGatewayCommandExecResponse_Handler,
+		},
+		{
+			MethodName: "GetGatewayDownlinkTXState",
+			Handler:    _NetworkServerService_GetGatewayDownlinkTXState_Handler,
+		},
+		{
+			MethodName: "ListGatewayDownlinkQueueDepths",
+			Handler:    _NetworkServerService_ListGatewayDownlinkQueueDepths_Handler,
+		},
+		{
+			MethodName: "GetGatewayStatsAggregates",
+			Handler:    _NetworkServerService_GetGatewayStatsAggregates_Handler,
+		},
+		{
+			MethodName: "GetGatewayNoiseFloor",
+			Handler:    _NetworkServerService_GetGatewayNoiseFloor_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -6102,6 +14493,11 @@ var _NetworkServerService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _NetworkServerService_StreamFrameLogsForDevice_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamGatewayContributions",
+			Handler:       _NetworkServerService_StreamGatewayContributions_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "ns.proto",
 }
@@ -47,6 +47,104 @@ func (RatePolicy) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9610db3cccb08234, []int{0}
 }
 
+type FCntValidationMode int32
+
+const (
+	// Strict validation of the 32 bit frame-counter.
+	FCntValidationMode_STRICT FCntValidationMode = 0
+	// Strict validation of the 16 bit frame-counter, which rolls over
+	// from 65535 to 0.
+	FCntValidationMode_ROLLOVER_16BIT FCntValidationMode = 1
+	// Relaxed validation, frame-counter resets are accepted.
+	FCntValidationMode_RELAXED FCntValidationMode = 2
+)
+
+var FCntValidationMode_name = map[int32]string{
+	0: "STRICT",
+	1: "ROLLOVER_16BIT",
+	2: "RELAXED",
+}
+
+var FCntValidationMode_value = map[string]int32{
+	"STRICT":         0,
+	"ROLLOVER_16BIT": 1,
+	"RELAXED":        2,
+}
+
+func (x FCntValidationMode) String() string {
+	return proto.EnumName(FCntValidationMode_name, int32(x))
+}
+
+func (FCntValidationMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9610db3cccb08234, []int{1}
+}
+
+type DeviceQueueOverflowPolicy int32
+
+const (
+	// Reject the new device-queue item.
+	DeviceQueueOverflowPolicy_REJECT DeviceQueueOverflowPolicy = 0
+	// Drop the oldest device-queue item.
+	DeviceQueueOverflowPolicy_DROP_OLDEST DeviceQueueOverflowPolicy = 1
+	// Drop the device-queue item with the lowest priority.
+	DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY DeviceQueueOverflowPolicy = 2
+)
+
+var DeviceQueueOverflowPolicy_name = map[int32]string{
+	0: "REJECT",
+	1: "DROP_OLDEST",
+	2: "DROP_LOWEST_PRIORITY",
+}
+
+var DeviceQueueOverflowPolicy_value = map[string]int32{
+	"REJECT":               0,
+	"DROP_OLDEST":          1,
+	"DROP_LOWEST_PRIORITY": 2,
+}
+
+func (x DeviceQueueOverflowPolicy) String() string {
+	return proto.EnumName(DeviceQueueOverflowPolicy_name, int32(x))
+}
+
+func (DeviceQueueOverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9610db3cccb08234, []int{2}
+}
+
+type UplinkFingerprintSensitivity int32
+
+const (
+	// Uplink fingerprint check is disabled.
+	UplinkFingerprintSensitivity_FINGERPRINT_DISABLED UplinkFingerprintSensitivity = 0
+	// Only uplinks deviating strongly from the fingerprint are reported.
+	UplinkFingerprintSensitivity_FINGERPRINT_LOW UplinkFingerprintSensitivity = 1
+	// Balanced sensitivity.
+	UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM UplinkFingerprintSensitivity = 2
+	// Small deviations from the fingerprint are reported.
+	UplinkFingerprintSensitivity_FINGERPRINT_HIGH UplinkFingerprintSensitivity = 3
+)
+
+var UplinkFingerprintSensitivity_name = map[int32]string{
+	0: "FINGERPRINT_DISABLED",
+	1: "FINGERPRINT_LOW",
+	2: "FINGERPRINT_MEDIUM",
+	3: "FINGERPRINT_HIGH",
+}
+
+var UplinkFingerprintSensitivity_value = map[string]int32{
+	"FINGERPRINT_DISABLED": 0,
+	"FINGERPRINT_LOW":      1,
+	"FINGERPRINT_MEDIUM":   2,
+	"FINGERPRINT_HIGH":     3,
+}
+
+func (x UplinkFingerprintSensitivity) String() string {
+	return proto.EnumName(UplinkFingerprintSensitivity_name, int32(x))
+}
+
+func (UplinkFingerprintSensitivity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9610db3cccb08234, []int{3}
+}
+
 type ServiceProfile struct {
 	// Service-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -87,10 +185,79 @@ type ServiceProfile struct {
 	// Target Packet Error Rate.
 	TargetPer uint32 `protobuf:"varint,19,opt,name=target_per,json=targetPer,proto3" json:"target_per,omitempty"`
 	// Minimum number of receiving GWs (informative).
-	MinGwDiversity       uint32   `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MinGwDiversity uint32 `protobuf:"varint,20,opt,name=min_gw_diversity,json=minGwDiversity,proto3" json:"min_gw_diversity,omitempty"`
+	// Webhook URL.
+	// When set, network-server events are posted to this URL.
+	WebhookUrl string `protobuf:"bytes,21,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	// Webhook secret.
+	// When set, the request body is signed using HMAC-SHA256.
+	// Note: when retrieving the service-profile, the webhook_secret is not
+	// returned for security reasons. When updating the service-profile, an
+	// empty webhook_secret does not clear the secret, unless the webhook_url
+	// is also left blank.
+	WebhookSecret string `protobuf:"bytes,22,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`
+	// Webhook events.
+	// The events to send (join, error, status, fcnt_reset). When empty, all
+	// events are sent.
+	WebhookEvents []string `protobuf:"bytes,23,rep,name=webhook_events,json=webhookEvents,proto3" json:"webhook_events,omitempty"`
+	// Gateway isolation.
+	// When set, only the gateways within allowed_gateway_ids are used for
+	// the uplink and downlink traffic of the devices using this
+	// service-profile.
+	GatewayIsolation bool `protobuf:"varint,24,opt,name=gateway_isolation,json=gatewayIsolation,proto3" json:"gateway_isolation,omitempty"`
+	// Allowed gateway IDs (when gateway_isolation is set).
+	AllowedGatewayIds [][]byte `protobuf:"bytes,25,rep,name=allowed_gateway_ids,json=allowedGatewayIds,proto3" json:"allowed_gateway_ids,omitempty"`
+	// Clock synchronization.
+	// When set, the network-server answers the AppTimeReq requests of the
+	// LoRaWAN Application Layer Clock Synchronization package (FPort 202)
+	// using the gateway GPS time, instead of forwarding these to the
+	// application-server. This requires the network-server to be able to
+	// unwrap the AppSKey of the device.
+	ClockSyncEnabled bool `protobuf:"varint,26,opt,name=clock_sync_enabled,json=clockSyncEnabled,proto3" json:"clock_sync_enabled,omitempty"`
+	// Remote multicast setup.
+	// When set, the network-server handles the answers of the LoRaWAN
+	// Remote Multicast Setup package (FPort 200), instead of forwarding
+	// these to the application-server (see StartMulticastSetup). This
+	// requires the network-server to be able to unwrap the AppSKey of the
+	// device.
+	MulticastSetupEnabled bool `protobuf:"varint,27,opt,name=multicast_setup_enabled,json=multicastSetupEnabled,proto3" json:"multicast_setup_enabled,omitempty"`
+	// Device-queue max. size (0 = unlimited).
+	// Max. number of device-queue items per device.
+	DeviceQueueMaxSize uint32 `protobuf:"varint,28,opt,name=device_queue_max_size,json=deviceQueueMaxSize,proto3" json:"device_queue_max_size,omitempty"`
+	// Device-queue overflow policy.
+	// Defines the behavior when enqueueing an item to a device-queue which
+	// reached its max. size. Dropped items are reported to the
+	// application-server using a DEVICE_QUEUE_ITEM_OVERFLOW error.
+	DeviceQueueOverflowPolicy DeviceQueueOverflowPolicy `protobuf:"varint,29,opt,name=device_queue_overflow_policy,json=deviceQueueOverflowPolicy,proto3,enum=ns.DeviceQueueOverflowPolicy" json:"device_queue_overflow_policy,omitempty"`
+	// Redact the FRMPayload in frame-logs.
+	// When set, the FRMPayload of application frames (FPort > 0) is replaced
+	// by zeros in the frame-log streams and captures. All meta-data is kept.
+	FrameLogRedactPayload bool `protobuf:"varint,30,opt,name=frame_log_redact_payload,json=frameLogRedactPayload,proto3" json:"frame_log_redact_payload,omitempty"`
+	// Truncate the DevEUI in frame-logs.
+	// When set, only the first 4 bytes of the DevEUI in (re)join-requests
+	// are kept in the frame-log streams and captures.
+	FrameLogTruncateDevEui bool `protobuf:"varint,31,opt,name=frame_log_truncate_dev_eui,json=frameLogTruncateDevEui,proto3" json:"frame_log_truncate_dev_eui,omitempty"`
+	// Disable downlinks.
+	// When set, no downlinks (join-accept, data, mac-commands and multicast)
+	// are sent to the devices of this service-profile. As devices can not
+	// receive a join-accept, OTAA join-requests are rejected. Enqueueing
+	// device-queue or multicast-queue items returns a FailedPrecondition
+	// error.
+	DownlinkDisabled bool `protobuf:"varint,32,opt,name=downlink_disabled,json=downlinkDisabled,proto3" json:"downlink_disabled,omitempty"`
+	// Max. downlink TX power (dBm, 0 = no cap).
+	// The TX power of all downlinks (join-accept, data and multicast) to the
+	// devices of this service-profile is limited to this value.
+	DlMaxTxPower int32 `protobuf:"varint,33,opt,name=dl_max_tx_power,json=dlMaxTxPower,proto3" json:"dl_max_tx_power,omitempty"`
+	// Uplink fingerprint sensitivity.
+	// When enabled, the RF fingerprint (receiving gateways, RSSI, SNR and
+	// uplink interval) of each device is learned and uplinks with a valid
+	// MIC which do not match the fingerprint are reported as
+	// uplink_fingerprint_mismatch security event, as these could indicate
+	// a cloned device.
+	UplinkFingerprintSensitivity UplinkFingerprintSensitivity `protobuf:"varint,34,opt,name=uplink_fingerprint_sensitivity,json=uplinkFingerprintSensitivity,proto3,enum=ns.UplinkFingerprintSensitivity" json:"uplink_fingerprint_sensitivity,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{}                     `json:"-"`
+	XXX_unrecognized             []byte                       `json:"-"`
+	XXX_sizecache                int32                        `json:"-"`
 }
 
 func (m *ServiceProfile) Reset()         { *m = ServiceProfile{} }
@@ -258,6 +425,104 @@ func (m *ServiceProfile) GetMinGwDiversity() uint32 {
 	return 0
 }
 
+func (m *ServiceProfile) GetWebhookUrl() string {
+	if m != nil {
+		return m.WebhookUrl
+	}
+	return ""
+}
+
+func (m *ServiceProfile) GetWebhookSecret() string {
+	if m != nil {
+		return m.WebhookSecret
+	}
+	return ""
+}
+
+func (m *ServiceProfile) GetWebhookEvents() []string {
+	if m != nil {
+		return m.WebhookEvents
+	}
+	return nil
+}
+
+func (m *ServiceProfile) GetGatewayIsolation() bool {
+	if m != nil {
+		return m.GatewayIsolation
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetAllowedGatewayIds() [][]byte {
+	if m != nil {
+		return m.AllowedGatewayIds
+	}
+	return nil
+}
+
+func (m *ServiceProfile) GetClockSyncEnabled() bool {
+	if m != nil {
+		return m.ClockSyncEnabled
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetMulticastSetupEnabled() bool {
+	if m != nil {
+		return m.MulticastSetupEnabled
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetDeviceQueueMaxSize() uint32 {
+	if m != nil {
+		return m.DeviceQueueMaxSize
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetDeviceQueueOverflowPolicy() DeviceQueueOverflowPolicy {
+	if m != nil {
+		return m.DeviceQueueOverflowPolicy
+	}
+	return DeviceQueueOverflowPolicy_REJECT
+}
+
+func (m *ServiceProfile) GetFrameLogRedactPayload() bool {
+	if m != nil {
+		return m.FrameLogRedactPayload
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetFrameLogTruncateDevEui() bool {
+	if m != nil {
+		return m.FrameLogTruncateDevEui
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetDownlinkDisabled() bool {
+	if m != nil {
+		return m.DownlinkDisabled
+	}
+	return false
+}
+
+func (m *ServiceProfile) GetDlMaxTxPower() int32 {
+	if m != nil {
+		return m.DlMaxTxPower
+	}
+	return 0
+}
+
+func (m *ServiceProfile) GetUplinkFingerprintSensitivity() UplinkFingerprintSensitivity {
+	if m != nil {
+		return m.UplinkFingerprintSensitivity
+	}
+	return UplinkFingerprintSensitivity_FINGERPRINT_DISABLED
+}
+
 type DeviceProfile struct {
 	// Device-profile ID.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -306,7 +571,13 @@ type DeviceProfile struct {
 	// Geolocation minimum buffer size.
 	// When > 0, geolocation will only be performed when the buffer has
 	// at least the given size.
-	GeolocMinBufferSize  uint32   `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	GeolocMinBufferSize uint32 `protobuf:"varint,22,opt,name=geoloc_min_buffer_size,json=geolocMinBufferSize,proto3" json:"geoloc_min_buffer_size,omitempty"`
+	// Frame-counter validation mode.
+	FCntValidationMode FCntValidationMode `protobuf:"varint,23,opt,name=f_cnt_validation_mode,json=fCntValidationMode,proto3,enum=ns.FCntValidationMode" json:"f_cnt_validation_mode,omitempty"`
+	// Max. frame-counter gap.
+	// When 0, the max. gap as defined by the LoRaWAN Regional Parameters
+	// is used.
+	FCntMaxGap           uint32   `protobuf:"varint,24,opt,name=f_cnt_max_gap,json=fCntMaxGap,proto3" json:"f_cnt_max_gap,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -491,6 +762,20 @@ func (m *DeviceProfile) GetGeolocMinBufferSize() uint32 {
 	return 0
 }
 
+func (m *DeviceProfile) GetFCntValidationMode() FCntValidationMode {
+	if m != nil {
+		return m.FCntValidationMode
+	}
+	return FCntValidationMode_STRICT
+}
+
+func (m *DeviceProfile) GetFCntMaxGap() uint32 {
+	if m != nil {
+		return m.FCntMaxGap
+	}
+	return 0
+}
+
 type RoutingProfile struct {
 	// ID of the routing profile.
 	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -504,7 +789,26 @@ type RoutingProfile struct {
 	// Note: when retrieving the routing-profile, the tls_key is not returned
 	// for security reasons. When updating the routing-profile, an empty tls_key
 	// does not clear the certificate, unless the tls_cert is also left blank.
-	TlsKey               string   `protobuf:"bytes,5,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key,omitempty"`
+	TlsKey string `protobuf:"bytes,5,opt,name=tls_key,json=tlsKey,proto3" json:"tls_key,omitempty"`
+	// Failover application-server IDs.
+	// These are tried in order when the application-server is unavailable.
+	// The same CA / TLS certificates are used for connecting.
+	FailoverAsIds []string `protobuf:"bytes,6,rep,name=failover_as_ids,json=failoverAsIds,proto3" json:"failover_as_ids,omitempty"`
+	// Round-robin load-balancing.
+	// When set, requests are distributed over the as_id and failover_as_ids
+	// application-servers.
+	RoundRobin bool `protobuf:"varint,7,opt,name=round_robin,json=roundRobin,proto3" json:"round_robin,omitempty"`
+	// Downlink hook URL.
+	// When set, this URL is invoked (HTTP POST) before a device-queue item
+	// is sent to a device. The hook can modify or veto the payload.
+	DownlinkHookUrl string `protobuf:"bytes,8,opt,name=downlink_hook_url,json=downlinkHookUrl,proto3" json:"downlink_hook_url,omitempty"`
+	// Downlink hook secret.
+	// When set, the request body is signed using HMAC-SHA256.
+	// Note: when retrieving the routing-profile, the downlink_hook_secret is
+	// not returned for security reasons. When updating the routing-profile,
+	// an empty downlink_hook_secret does not clear the secret, unless the
+	// downlink_hook_url is also left blank.
+	DownlinkHookSecret   string   `protobuf:"bytes,9,opt,name=downlink_hook_secret,json=downlinkHookSecret,proto3" json:"downlink_hook_secret,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -570,8 +874,39 @@ func (m *RoutingProfile) GetTlsKey() string {
 	return ""
 }
 
+func (m *RoutingProfile) GetFailoverAsIds() []string {
+	if m != nil {
+		return m.FailoverAsIds
+	}
+	return nil
+}
+
+func (m *RoutingProfile) GetRoundRobin() bool {
+	if m != nil {
+		return m.RoundRobin
+	}
+	return false
+}
+
+func (m *RoutingProfile) GetDownlinkHookUrl() string {
+	if m != nil {
+		return m.DownlinkHookUrl
+	}
+	return ""
+}
+
+func (m *RoutingProfile) GetDownlinkHookSecret() string {
+	if m != nil {
+		return m.DownlinkHookSecret
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("ns.RatePolicy", RatePolicy_name, RatePolicy_value)
+	proto.RegisterEnum("ns.FCntValidationMode", FCntValidationMode_name, FCntValidationMode_value)
+	proto.RegisterEnum("ns.DeviceQueueOverflowPolicy", DeviceQueueOverflowPolicy_name, DeviceQueueOverflowPolicy_value)
+	proto.RegisterEnum("ns.UplinkFingerprintSensitivity", UplinkFingerprintSensitivity_name, UplinkFingerprintSensitivity_value)
 	proto.RegisterType((*ServiceProfile)(nil), "ns.ServiceProfile")
 	proto.RegisterType((*DeviceProfile)(nil), "ns.DeviceProfile")
 	proto.RegisterType((*RoutingProfile)(nil), "ns.RoutingProfile")
@@ -580,66 +915,105 @@ func init() {
 func init() { proto.RegisterFile("profiles.proto", fileDescriptor_9610db3cccb08234) }
 
 var fileDescriptor_9610db3cccb08234 = []byte{
-	// 964 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x9d, 0xd3, 0xc4, 0xb1, 0x19, 0x4b, 0x71, 0xe8, 0x24, 0x55, 0xf7, 0xe9, 0xa5, 0xc3, 0x60,
-	0x14, 0x58, 0xb6, 0x38, 0x03, 0x86, 0x3d, 0x36, 0xf6, 0x1a, 0x6c, 0x9d, 0x51, 0x83, 0x29, 0xf6,
-	0x4a, 0xd0, 0x22, 0xed, 0x70, 0x96, 0x44, 0xe5, 0x92, 0x8a, 0xed, 0x3e, 0xee, 0x17, 0xee, 0x87,
-	0xec, 0x47, 0x0c, 0xbc, 0x92, 0xed, 0x34, 0xdd, 0xfa, 0x66, 0x9f, 0x73, 0xae, 0x0e, 0xef, 0xe5,
-	0xb9, 0x12, 0x09, 0x73, 0x30, 0x53, 0x9d, 0x28, 0x7b, 0x9e, 0x83, 0x71, 0x86, 0xee, 0x64, 0xf6,
-	0xec, 0x9f, 0x3d, 0x12, 0xde, 0x28, 0xb8, 0xd7, 0xb1, 0x1a, 0x97, 0x2c, 0x0d, 0xc9, 0x8e, 0x96,
-	0x51, 0xad, 0x5b, 0xeb, 0xb5, 0xd8, 0x8e, 0x96, 0xf4, 0x29, 0xd9, 0x2f, 0x12, 0x0e, 0xc2, 0xa9,
-	0x68, 0xa7, 0x5b, 0xeb, 0x05, 0xac, 0x5e, 0x24, 0x4c, 0x38, 0x45, 0xbf, 0x21, 0x61, 0x91, 0xf0,
-	0x49, 0x11, 0xcf, 0x95, 0xe3, 0x56, 0xbf, 0x53, 0xd1, 0x13, 0xe4, 0x5b, 0x45, 0x72, 0x85, 0xe0,
-	0x8d, 0x7e, 0xa7, 0xe8, 0x8f, 0xa8, 0xf2, 0xe5, 0x3c, 0x37, 0x89, 0x8e, 0x57, 0xd1, 0x6e, 0xb7,
-	0xd6, 0x0b, 0xfb, 0xe1, 0x79, 0x66, 0xcf, 0xfd, 0x73, 0xc6, 0x88, 0xfa, 0xaa, 0xed, 0x3f, 0x6f,
-	0x2a, 0x2b, 0xd3, 0xbd, 0xd2, 0x54, 0x6e, 0x4c, 0xe5, 0xfb, 0xa6, 0xf5, 0xd2, 0x54, 0x3e, 0x32,
-	0x95, 0xef, 0x9b, 0xee, 0xff, 0xb7, 0xa9, 0x7c, 0x68, 0xfa, 0x2d, 0x39, 0x14, 0x52, 0xf2, 0xd9,
-	0x82, 0xa7, 0xca, 0x09, 0x29, 0x9c, 0x88, 0x1a, 0xdd, 0x5a, 0xaf, 0xc1, 0x02, 0x21, 0xe5, 0xf5,
-	0x62, 0x54, 0x81, 0xf4, 0x3b, 0xd2, 0x91, 0xea, 0x9e, 0x5b, 0x27, 0x5c, 0x61, 0x39, 0xa8, 0x3b,
-	0x3e, 0x05, 0x75, 0x17, 0x35, 0xf1, 0x20, 0x6d, 0xa9, 0xee, 0x6f, 0x90, 0x61, 0xea, 0xee, 0x15,
-	0xa8, 0x3b, 0xfa, 0x33, 0x79, 0x06, 0x2a, 0x37, 0xe0, 0xf8, 0x83, 0xaa, 0x89, 0x70, 0x4e, 0xc1,
-	0x2a, 0x22, 0x68, 0x70, 0x5a, 0x0a, 0x86, 0xeb, 0xd2, 0xab, 0x92, 0xa5, 0x3f, 0x91, 0xe8, 0xc3,
-	0xd2, 0x54, 0xc0, 0x4c, 0x67, 0xd1, 0x01, 0x56, 0x9e, 0x3c, 0xaa, 0x1c, 0x21, 0x49, 0x4f, 0x48,
-	0x5d, 0x02, 0x4f, 0x75, 0x16, 0xb5, 0xf0, 0x54, 0x7b, 0x12, 0x46, 0x5b, 0x58, 0x2c, 0xa3, 0x60,
-	0x03, 0x8b, 0x25, 0xfd, 0x9a, 0xb4, 0xe2, 0x5b, 0x91, 0x65, 0x2a, 0xe1, 0xa9, 0xb0, 0xf3, 0x28,
-	0xc4, 0xcb, 0x3f, 0xa8, 0xb0, 0x91, 0xb0, 0x73, 0xfa, 0x05, 0x21, 0x39, 0x70, 0x91, 0x24, 0x66,
-	0xa1, 0x64, 0x74, 0x88, 0xde, 0xcd, 0x1c, 0x5e, 0x96, 0x80, 0xa7, 0x6f, 0xb7, 0x74, 0xbb, 0xa4,
-	0x6f, 0x1f, 0xd2, 0x20, 0x36, 0xf4, 0x51, 0x49, 0x83, 0x58, 0xd3, 0x5f, 0x92, 0x83, 0x6c, 0x31,
-	0xe7, 0x33, 0x65, 0x78, 0x62, 0xe2, 0x88, 0x96, 0x7c, 0xb6, 0x98, 0x5f, 0x2b, 0xf3, 0xbb, 0x89,
-	0x7d, 0xb9, 0x13, 0x30, 0x53, 0x8e, 0xe7, 0x0a, 0xa2, 0x0e, 0x1e, 0xbd, 0x59, 0x22, 0x63, 0x05,
-	0xb4, 0x47, 0xda, 0xa9, 0xce, 0xfc, 0xbd, 0x49, 0x7d, 0xaf, 0xc0, 0x6a, 0xb7, 0x8a, 0x8e, 0x51,
-	0x14, 0xa6, 0x3a, 0xbb, 0x5e, 0x0c, 0xd7, 0xe8, 0xd9, 0xdf, 0x75, 0x12, 0x0c, 0xd5, 0xc7, 0xd2,
-	0xde, 0x23, 0x6d, 0x5b, 0xe4, 0x7e, 0xa4, 0x96, 0xc7, 0x89, 0xb0, 0x96, 0x4f, 0x30, 0xf6, 0x0d,
-	0x16, 0xae, 0xf1, 0x81, 0x87, 0xaf, 0x7c, 0x5a, 0x2a, 0x01, 0x77, 0x3a, 0x55, 0xa6, 0x70, 0x55,
-	0xfe, 0x03, 0x84, 0xaf, 0xde, 0x96, 0xa0, 0x7f, 0x62, 0xae, 0xb3, 0x19, 0xb7, 0x89, 0xc1, 0xf3,
-	0x6b, 0x23, 0x71, 0x05, 0x02, 0x16, 0x7a, 0xfc, 0x26, 0x31, 0xbe, 0x09, 0x6d, 0x24, 0xed, 0x92,
-	0xd6, 0x56, 0x29, 0xa1, 0x4a, 0x3e, 0x59, 0xab, 0x86, 0xe0, 0xd3, 0xbf, 0x55, 0x60, 0xe8, 0xaa,
-	0xf4, 0xaf, 0x35, 0x18, 0xb8, 0x0f, 0x7b, 0x88, 0x31, 0xff, 0x8f, 0x7b, 0x18, 0x6c, 0x7b, 0x88,
-	0x37, 0x3d, 0x34, 0x1e, 0xf4, 0x30, 0x58, 0xf7, 0xf0, 0x15, 0x39, 0x48, 0x45, 0xcc, 0x71, 0x8c,
-	0x26, 0xc3, 0xa4, 0x37, 0x19, 0x49, 0x45, 0xfc, 0x47, 0x89, 0xd0, 0x73, 0xd2, 0x01, 0x35, 0xe3,
-	0xb9, 0x00, 0x91, 0xfa, 0x95, 0xb8, 0xd7, 0x28, 0x24, 0x28, 0x3c, 0x02, 0x35, 0x1b, 0x23, 0xc3,
-	0x2a, 0x82, 0x7e, 0x4e, 0x08, 0x2c, 0xb9, 0x54, 0x89, 0x58, 0xf1, 0x0b, 0x8c, 0x72, 0xc0, 0x1a,
-	0xb0, 0x1c, 0x7a, 0xe0, 0x82, 0x3e, 0x27, 0xa1, 0x67, 0x81, 0x9b, 0xe9, 0xd4, 0x2a, 0xc7, 0x2f,
-	0xaa, 0x14, 0x1f, 0xc0, 0x72, 0x08, 0x6f, 0x10, 0xbb, 0xa0, 0x67, 0x24, 0xf0, 0x22, 0xe1, 0x04,
-	0xee, 0x79, 0xbf, 0x8a, 0xb4, 0xd7, 0x54, 0x58, 0x9f, 0x7e, 0x4a, 0x9a, 0xb0, 0xc4, 0x41, 0xf1,
-	0x3e, 0xa6, 0x3a, 0x60, 0xfb, 0xb0, 0xf4, 0x43, 0xea, 0xd3, 0x1f, 0xc8, 0xf1, 0x54, 0xc4, 0xce,
-	0xc0, 0x8a, 0xe7, 0xa0, 0xbc, 0x8d, 0xd7, 0xd9, 0xe8, 0xb0, 0xfb, 0xa4, 0x17, 0x30, 0x5a, 0x71,
-	0x63, 0xa4, 0x7c, 0x85, 0xa5, 0xcf, 0x48, 0x23, 0x15, 0x4b, 0xae, 0x34, 0xe4, 0x18, 0xf1, 0x80,
-	0xed, 0xa7, 0x62, 0xf9, 0x8b, 0x86, 0xdc, 0x5f, 0x8c, 0xa7, 0x64, 0xe1, 0x56, 0x3c, 0x5e, 0xc5,
-	0x89, 0xc2, 0x90, 0x07, 0xac, 0x95, 0x8a, 0xe5, 0xb0, 0x70, 0xab, 0x81, 0xc7, 0xe8, 0x73, 0x12,
-	0x6c, 0x2e, 0xe6, 0x4f, 0xa3, 0xb3, 0x2a, 0xe9, 0xad, 0x35, 0xf8, 0x9b, 0xd1, 0x19, 0xfd, 0x8c,
-	0x34, 0x61, 0xca, 0x41, 0xcd, 0xfc, 0x00, 0x3b, 0x38, 0xc0, 0x06, 0x4c, 0x19, 0xfe, 0xa7, 0xdf,
-	0x93, 0xe3, 0xcd, 0x13, 0x2e, 0xfb, 0x13, 0xed, 0xf8, 0x94, 0xc7, 0x99, 0xc3, 0xb8, 0x37, 0xd8,
-	0xd1, 0x9a, 0x43, 0xea, 0xd5, 0x20, 0x73, 0xf4, 0x05, 0x39, 0x9a, 0x29, 0x93, 0x98, 0x98, 0x4f,
-	0x8a, 0xe9, 0x54, 0x01, 0x77, 0x2e, 0x89, 0x4e, 0xf0, 0x6c, 0x87, 0x25, 0x71, 0x85, 0xf8, 0x5b,
-	0x97, 0xd0, 0x4b, 0x72, 0x5a, 0x69, 0xfd, 0x3a, 0x55, 0x7a, 0x7c, 0xc7, 0x9e, 0x62, 0x41, 0xa7,
-	0x64, 0x47, 0x3a, 0x2b, 0x6b, 0xfc, 0xab, 0xf6, 0xec, 0xaf, 0x1a, 0x09, 0x99, 0x29, 0x9c, 0xce,
-	0x66, 0xff, 0xb7, 0x53, 0x1d, 0xb2, 0x27, 0x2c, 0xd7, 0x12, 0x17, 0xa9, 0xc9, 0x76, 0x85, 0xfd,
-	0x15, 0x3f, 0x2b, 0xb1, 0xe0, 0xb1, 0x82, 0x72, 0x6d, 0x9a, 0xac, 0x1e, 0x8b, 0x81, 0x02, 0xe7,
-	0xa7, 0xec, 0x12, 0x5b, 0x32, 0xbb, 0xc8, 0xec, 0xbb, 0xc4, 0x22, 0xf5, 0x94, 0xf8, 0x9f, 0x7c,
-	0xae, 0x56, 0xb8, 0x1b, 0x4d, 0x56, 0x77, 0x89, 0x7d, 0xad, 0x56, 0x2f, 0xba, 0x84, 0x3c, 0x78,
-	0x8f, 0x37, 0xc8, 0xee, 0x90, 0xbd, 0x19, 0xb7, 0x3f, 0xf1, 0xbf, 0x46, 0x2f, 0xd9, 0xeb, 0x76,
-	0x6d, 0x52, 0xc7, 0x6f, 0xde, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x88, 0x3f, 0x1d,
-	0x05, 0x07, 0x00, 0x00,
+	// 1595 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x57, 0xed, 0x72, 0x1b, 0xb7,
+	0x15, 0x35, 0x25, 0x5b, 0x22, 0x2f, 0x45, 0x8a, 0x82, 0xbe, 0x56, 0x8e, 0x6c, 0xd3, 0x4a, 0xd3,
+	0xe1, 0xa8, 0xad, 0x1b, 0xc9, 0x9d, 0x74, 0xda, 0x7f, 0x92, 0x48, 0xcb, 0x4c, 0xc8, 0x90, 0x05,
+	0x69, 0xa7, 0xfd, 0x53, 0x0c, 0xb8, 0x00, 0x69, 0x94, 0xcb, 0xc5, 0x0a, 0x8b, 0xe5, 0x47, 0xfa,
+	0x44, 0x7d, 0x89, 0x3e, 0x4c, 0x9f, 0xa4, 0x83, 0xbb, 0xcb, 0x0f, 0xc7, 0xb1, 0xff, 0x89, 0xe7,
+	0x9c, 0x8b, 0xbb, 0xb8, 0xd8, 0x73, 0xb0, 0x82, 0x72, 0x64, 0xf4, 0x50, 0x05, 0x32, 0x7e, 0x15,
+	0x19, 0x6d, 0x35, 0xd9, 0x0a, 0xe3, 0x8b, 0xff, 0x15, 0xa1, 0xdc, 0x93, 0x66, 0xaa, 0x7c, 0xd9,
+	0x4d, 0x59, 0x52, 0x86, 0x2d, 0x25, 0xbc, 0x5c, 0x35, 0x57, 0xdb, 0xa3, 0x5b, 0x4a, 0x90, 0x53,
+	0xd8, 0x4d, 0x02, 0x66, 0xb8, 0x95, 0xde, 0x56, 0x35, 0x57, 0x2b, 0xd1, 0x9d, 0x24, 0xa0, 0xdc,
+	0x4a, 0xf2, 0x1b, 0x28, 0x27, 0x01, 0x1b, 0x24, 0xfe, 0x58, 0x5a, 0x16, 0xab, 0x9f, 0xa5, 0xb7,
+	0x8d, 0xfc, 0x5e, 0x12, 0xdc, 0x22, 0xd8, 0x53, 0x3f, 0x4b, 0xf2, 0x27, 0x28, 0x67, 0xe5, 0x2c,
+	0xd2, 0x81, 0xf2, 0x17, 0xde, 0xe3, 0x6a, 0xae, 0x56, 0xbe, 0x2e, 0xbf, 0x0a, 0xe3, 0x57, 0x6e,
+	0x9d, 0x2e, 0xa2, 0xae, 0x6a, 0xfd, 0xcb, 0x35, 0x15, 0x59, 0xd3, 0x27, 0x69, 0x53, 0xb1, 0x6a,
+	0x2a, 0x3e, 0x6e, 0xba, 0x93, 0x36, 0x15, 0xbf, 0x68, 0x2a, 0x3e, 0x6e, 0xba, 0xfb, 0xeb, 0x4d,
+	0xc5, 0x66, 0xd3, 0xdf, 0xc2, 0x3e, 0x17, 0x82, 0x8d, 0x66, 0x6c, 0x22, 0x2d, 0x17, 0xdc, 0x72,
+	0x2f, 0x5f, 0xcd, 0xd5, 0xf2, 0xb4, 0xc4, 0x85, 0xb8, 0x9f, 0xb5, 0x33, 0x90, 0xfc, 0x01, 0x0e,
+	0x85, 0x9c, 0xb2, 0xd8, 0x72, 0x9b, 0xc4, 0xcc, 0xc8, 0x07, 0x36, 0x34, 0xf2, 0xc1, 0x2b, 0xe0,
+	0x83, 0x54, 0x84, 0x9c, 0xf6, 0x90, 0xa1, 0xf2, 0xe1, 0x8d, 0x91, 0x0f, 0xe4, 0x2f, 0x70, 0x66,
+	0x64, 0xa4, 0x8d, 0x65, 0x1b, 0x55, 0x03, 0x6e, 0xad, 0x34, 0x0b, 0x0f, 0xb0, 0xc1, 0x49, 0x2a,
+	0xa8, 0x2f, 0x4b, 0x6f, 0x53, 0x96, 0xfc, 0x19, 0xbc, 0x4f, 0x4b, 0x27, 0xdc, 0x8c, 0x54, 0xe8,
+	0x15, 0xb1, 0xf2, 0xf8, 0x17, 0x95, 0x6d, 0x24, 0xc9, 0x31, 0xec, 0x08, 0xc3, 0x26, 0x2a, 0xf4,
+	0xf6, 0xf0, 0xa9, 0x9e, 0x08, 0xd3, 0x5e, 0xc3, 0x7c, 0xee, 0x95, 0x56, 0x30, 0x9f, 0x93, 0x97,
+	0xb0, 0xe7, 0x7f, 0xe0, 0x61, 0x28, 0x03, 0x36, 0xe1, 0xf1, 0xd8, 0x2b, 0xe3, 0xe1, 0x17, 0x33,
+	0xac, 0xcd, 0xe3, 0x31, 0x79, 0x06, 0x10, 0x19, 0xc6, 0x83, 0x40, 0xcf, 0xa4, 0xf0, 0xf6, 0xb1,
+	0x77, 0x21, 0x32, 0x37, 0x29, 0xe0, 0xe8, 0x0f, 0x6b, 0xba, 0x92, 0xd2, 0x1f, 0x36, 0x69, 0xc3,
+	0x57, 0xf4, 0x41, 0x4a, 0x1b, 0xbe, 0xa4, 0x9f, 0x43, 0x31, 0x9c, 0x8d, 0xd9, 0x48, 0x6a, 0x16,
+	0x68, 0xdf, 0x23, 0x29, 0x1f, 0xce, 0xc6, 0xf7, 0x52, 0xb7, 0xb4, 0xef, 0xca, 0x2d, 0x37, 0x23,
+	0x69, 0x59, 0x24, 0x8d, 0x77, 0x88, 0x8f, 0x5e, 0x48, 0x91, 0xae, 0x34, 0xa4, 0x06, 0x95, 0x89,
+	0x0a, 0xdd, 0xb9, 0x09, 0x35, 0x95, 0x26, 0x56, 0x76, 0xe1, 0x1d, 0xa1, 0xa8, 0x3c, 0x51, 0xe1,
+	0xfd, 0xac, 0xbe, 0x44, 0xc9, 0x0b, 0x28, 0xce, 0xe4, 0xe0, 0x83, 0xd6, 0x63, 0x96, 0x98, 0xc0,
+	0x3b, 0xae, 0xe6, 0x6a, 0x05, 0x0a, 0x19, 0xf4, 0xce, 0x04, 0xe4, 0x1b, 0x28, 0x2f, 0x05, 0xb1,
+	0xf4, 0x8d, 0xb4, 0xde, 0x09, 0x6a, 0x4a, 0x19, 0xda, 0x43, 0x70, 0x53, 0x26, 0xa7, 0x32, 0xb4,
+	0xb1, 0x77, 0x5a, 0xdd, 0xde, 0x90, 0x35, 0x10, 0x24, 0xbf, 0x83, 0x83, 0x11, 0xb7, 0x72, 0xc6,
+	0x17, 0x4c, 0xc5, 0x3a, 0xe0, 0x56, 0xe9, 0xd0, 0xf3, 0x70, 0x77, 0x95, 0x8c, 0x68, 0x2e, 0x71,
+	0xf2, 0x0a, 0x0e, 0xb3, 0x01, 0xb1, 0x55, 0x91, 0x88, 0xbd, 0xb3, 0xea, 0x76, 0x6d, 0x8f, 0x1e,
+	0x64, 0xd4, 0x7d, 0x56, 0x25, 0x62, 0xf2, 0x7b, 0x20, 0x7e, 0xa0, 0xfd, 0x31, 0x8b, 0x17, 0xa1,
+	0xcf, 0x64, 0xc8, 0x07, 0x81, 0x14, 0xde, 0xd3, 0x74, 0x75, 0x64, 0x7a, 0x8b, 0xd0, 0x6f, 0xa4,
+	0x38, 0xf9, 0x0e, 0x4e, 0x27, 0x49, 0x60, 0x95, 0xcf, 0x63, 0xcb, 0x62, 0x69, 0x93, 0x68, 0x55,
+	0xf2, 0x55, 0xfa, 0x22, 0xad, 0xe8, 0x9e, 0x63, 0x97, 0x75, 0x57, 0x70, 0x2c, 0xa4, 0x8b, 0x07,
+	0xf6, 0x90, 0xc8, 0x44, 0xba, 0x77, 0x27, 0xb5, 0xdd, 0x39, 0x0e, 0x98, 0xa4, 0xe4, 0xdf, 0x1c,
+	0xd7, 0xe6, 0x73, 0x34, 0xdf, 0x3f, 0xe1, 0xfc, 0xa3, 0x12, 0x3d, 0x95, 0x66, 0x18, 0xe8, 0xd9,
+	0xd2, 0x8a, 0xcf, 0xd0, 0x8a, 0xcf, 0x9c, 0x15, 0xeb, 0xeb, 0xea, 0x4e, 0xa6, 0xca, 0x9c, 0x79,
+	0x26, 0x3e, 0x47, 0x39, 0x53, 0x0c, 0x0d, 0x9f, 0x48, 0x16, 0xe8, 0x11, 0x33, 0x52, 0x70, 0xdf,
+	0xb2, 0x88, 0x2f, 0x02, 0xcd, 0x85, 0xf7, 0x3c, 0xdd, 0x0b, 0xf2, 0x2d, 0x3d, 0xa2, 0xc8, 0x76,
+	0x53, 0x92, 0xfc, 0x15, 0x9e, 0xae, 0x0b, 0xad, 0x49, 0x42, 0xdf, 0x05, 0x84, 0x73, 0x96, 0x4c,
+	0x94, 0xf7, 0x22, 0x75, 0xe2, 0xb2, 0xb4, 0x9f, 0xf1, 0x75, 0x39, 0x6d, 0x24, 0xca, 0x1d, 0xa5,
+	0xd0, 0xb3, 0x30, 0x50, 0xe1, 0x98, 0x09, 0x15, 0xa7, 0x93, 0xab, 0xa6, 0xc3, 0x5e, 0x12, 0xf5,
+	0x0c, 0x27, 0xdf, 0xc0, 0xbe, 0x08, 0x70, 0x54, 0x76, 0xce, 0x22, 0x3d, 0x93, 0xc6, 0x7b, 0x59,
+	0xcd, 0xd5, 0x9e, 0xb8, 0xbc, 0x69, 0xf3, 0x79, 0x7f, 0xde, 0x75, 0x18, 0x19, 0xc2, 0xf3, 0x24,
+	0xc2, 0x15, 0x87, 0x2a, 0x1c, 0x49, 0x13, 0x19, 0x15, 0xba, 0xc3, 0x09, 0x63, 0x65, 0xd5, 0xd4,
+	0xbd, 0xc5, 0x17, 0x38, 0xaa, 0xaa, 0x1b, 0xd5, 0x3b, 0x54, 0xbe, 0x59, 0x0b, 0x7b, 0x6b, 0x1d,
+	0x3d, 0x4f, 0xbe, 0xc0, 0x5e, 0xfc, 0x77, 0x17, 0x4a, 0x75, 0xf9, 0xa5, 0x8c, 0xaf, 0x41, 0x25,
+	0x4e, 0x22, 0x17, 0x24, 0x31, 0xf3, 0x03, 0x1e, 0xc7, 0x6c, 0x80, 0x61, 0x9f, 0xa7, 0xe5, 0x25,
+	0x7e, 0xe7, 0xe0, 0x5b, 0x97, 0x91, 0x99, 0x80, 0x59, 0x35, 0x91, 0x3a, 0xb1, 0x59, 0xea, 0x97,
+	0x10, 0xbe, 0xed, 0xa7, 0xa0, 0x5b, 0x31, 0x52, 0xe1, 0x88, 0xc5, 0x81, 0x46, 0xd7, 0x2a, 0x2d,
+	0x30, 0xf8, 0x4b, 0xb4, 0xec, 0xf0, 0x5e, 0xa0, 0x9d, 0x75, 0x95, 0x16, 0xa4, 0x0a, 0x7b, 0x6b,
+	0xa5, 0x30, 0x59, 0xde, 0xc3, 0x52, 0x55, 0x37, 0x2e, 0xf3, 0xd7, 0x0a, 0x8c, 0xda, 0x2c, 0xf3,
+	0x97, 0x1a, 0x8c, 0xd9, 0x4f, 0xf7, 0xe0, 0x7b, 0xbb, 0xbf, 0xb2, 0x87, 0xbb, 0xf5, 0x1e, 0xfc,
+	0xd5, 0x1e, 0xf2, 0x1b, 0x7b, 0xb8, 0x5b, 0xee, 0xe1, 0x05, 0x14, 0x27, 0xdc, 0x67, 0x18, 0x1e,
+	0x3a, 0xc4, 0x7c, 0x2f, 0x50, 0x98, 0x70, 0xff, 0x7d, 0x8a, 0x38, 0xcb, 0x1a, 0x39, 0x62, 0x11,
+	0x37, 0x7c, 0xe2, 0x2e, 0x82, 0xa9, 0x42, 0x21, 0xa0, 0xf0, 0xc0, 0xc8, 0x51, 0x17, 0x19, 0x9a,
+	0x11, 0xe4, 0x1c, 0xc0, 0xcc, 0x99, 0x90, 0x01, 0x5f, 0xb0, 0x2b, 0x0c, 0xf0, 0x12, 0xcd, 0x9b,
+	0x79, 0xdd, 0x01, 0x57, 0xe4, 0x6b, 0x28, 0x3b, 0xd6, 0x30, 0x3d, 0x1c, 0xc6, 0xd2, 0xb2, 0xab,
+	0x2c, 0xbb, 0x8b, 0x66, 0x5e, 0x37, 0x1d, 0xc4, 0xae, 0xc8, 0x05, 0x94, 0x9c, 0x88, 0x5b, 0x8e,
+	0xb7, 0xdb, 0xb5, 0x57, 0x5a, 0x69, 0x32, 0xec, 0x9a, 0x3c, 0x85, 0x82, 0x99, 0xe3, 0xa0, 0xd8,
+	0x35, 0x66, 0x79, 0x89, 0xee, 0x9a, 0xb9, 0x1b, 0xd2, 0x35, 0xf9, 0x16, 0x8e, 0x86, 0xdc, 0xb7,
+	0xda, 0x2c, 0x58, 0x64, 0xa4, 0x6b, 0xe3, 0x74, 0xb1, 0xb7, 0x5f, 0xdd, 0x76, 0x76, 0xce, 0xb8,
+	0x2e, 0x52, 0xae, 0x22, 0x26, 0x67, 0x90, 0x77, 0x6f, 0xb2, 0x54, 0x26, 0xc2, 0x60, 0x2f, 0xd1,
+	0xdd, 0x09, 0x9f, 0x37, 0x94, 0x89, 0xdc, 0xc1, 0x38, 0x4a, 0x24, 0x76, 0xc1, 0xfc, 0x85, 0x1f,
+	0x48, 0x8c, 0xf6, 0x12, 0xdd, 0x9b, 0xf0, 0x79, 0x3d, 0xb1, 0x8b, 0x3b, 0x87, 0x91, 0xaf, 0xa1,
+	0xb4, 0x3a, 0x98, 0x7f, 0x69, 0x15, 0x66, 0xf9, 0xbe, 0xb7, 0x04, 0xbf, 0xd7, 0x2a, 0x24, 0x5f,
+	0x41, 0xc1, 0x0c, 0x99, 0x91, 0x23, 0x37, 0xc0, 0x43, 0x1c, 0x60, 0xde, 0x0c, 0x29, 0xfe, 0x26,
+	0x7f, 0x84, 0xa3, 0xd5, 0x0a, 0xaf, 0xaf, 0x07, 0xca, 0xb2, 0x21, 0xf3, 0x43, 0x8b, 0x21, 0x9f,
+	0xa7, 0x07, 0x4b, 0x0e, 0xa9, 0x37, 0x77, 0xa1, 0x25, 0x97, 0x70, 0x30, 0x92, 0x3a, 0xd0, 0x3e,
+	0x1b, 0x24, 0xc3, 0xa1, 0x34, 0xcc, 0xda, 0x34, 0xed, 0x4b, 0x74, 0x3f, 0x25, 0x6e, 0x11, 0xef,
+	0xdb, 0x80, 0xbc, 0x86, 0x93, 0x4c, 0xeb, 0x2e, 0x91, 0x4c, 0x8f, 0x11, 0x77, 0x82, 0x05, 0x87,
+	0x29, 0xdb, 0x56, 0x61, 0x5a, 0x83, 0x19, 0xd7, 0x84, 0x63, 0x7c, 0x04, 0x36, 0xe5, 0x81, 0x12,
+	0x18, 0xe0, 0x6c, 0xa2, 0x85, 0xf4, 0x4e, 0xd1, 0xb1, 0x27, 0xce, 0xb1, 0xee, 0x49, 0xde, 0xaf,
+	0xe8, 0xb6, 0x16, 0x92, 0x92, 0xe1, 0x27, 0x18, 0x79, 0x09, 0xa5, 0x74, 0x29, 0x37, 0xca, 0x11,
+	0x8f, 0xf0, 0x82, 0x28, 0x51, 0x70, 0xd2, 0x36, 0x9f, 0xdf, 0xf3, 0xe8, 0xe2, 0x3f, 0x5b, 0x50,
+	0xa6, 0x3a, 0xb1, 0x2a, 0x1c, 0x7d, 0xce, 0xc1, 0x87, 0xf0, 0x84, 0xc7, 0x4c, 0x09, 0xb4, 0x6d,
+	0x81, 0x3e, 0xe6, 0x71, 0x13, 0x3f, 0xdd, 0x7c, 0xce, 0x7c, 0x69, 0x52, 0x93, 0x16, 0xe8, 0x8e,
+	0xcf, 0xef, 0xa4, 0xb1, 0xee, 0x4c, 0x6d, 0x10, 0xa7, 0xcc, 0x63, 0x64, 0x76, 0x6d, 0x10, 0x23,
+	0x75, 0x0a, 0xee, 0x4f, 0x36, 0x96, 0x0b, 0x74, 0x62, 0x81, 0xee, 0xd8, 0x20, 0xfe, 0x41, 0xe2,
+	0xd7, 0xd1, 0x90, 0xab, 0xc0, 0xa5, 0x39, 0xc3, 0x56, 0xb1, 0xb7, 0x93, 0x5e, 0x7a, 0x4b, 0xf8,
+	0x26, 0x76, 0xf7, 0xd2, 0x0b, 0x28, 0x1a, 0x9d, 0x84, 0x82, 0x19, 0x3d, 0x50, 0x61, 0x66, 0x41,
+	0x40, 0x88, 0x3a, 0xc4, 0x1d, 0xce, 0x2a, 0x4a, 0x57, 0x57, 0x71, 0x1e, 0x7b, 0xed, 0x2f, 0x89,
+	0xb7, 0xd9, 0x7d, 0xfc, 0x2d, 0x1c, 0x7d, 0xac, 0xcd, 0x6e, 0xe5, 0xd4, 0x8b, 0x64, 0x53, 0x9e,
+	0x5e, 0xcd, 0x97, 0x55, 0x80, 0x8d, 0x4f, 0xba, 0x3c, 0x3c, 0xae, 0xd3, 0x4e, 0xb7, 0xf2, 0xc8,
+	0xfd, 0xd5, 0xbe, 0xa1, 0x3f, 0x54, 0x72, 0x97, 0x37, 0x40, 0x3e, 0x3d, 0x1a, 0x02, 0xb0, 0xd3,
+	0xeb, 0xd3, 0xe6, 0x5d, 0xbf, 0xf2, 0x88, 0x10, 0x28, 0xd3, 0x4e, 0xab, 0xd5, 0x79, 0xdf, 0xa0,
+	0xec, 0xea, 0xbb, 0xdb, 0x66, 0xbf, 0x92, 0x23, 0x45, 0xd8, 0xa5, 0x8d, 0xd6, 0xcd, 0xdf, 0x1b,
+	0xf5, 0xca, 0xd6, 0x25, 0x85, 0xb3, 0xcf, 0x5e, 0x5d, 0x6e, 0x25, 0xda, 0xf8, 0xbe, 0x81, 0x2b,
+	0xed, 0x43, 0xd1, 0xf5, 0x67, 0x9d, 0x56, 0xbd, 0xd1, 0x73, 0xcb, 0x78, 0x70, 0x84, 0x40, 0xab,
+	0xf3, 0x53, 0xa3, 0xd7, 0x67, 0x5d, 0xda, 0xec, 0xd0, 0x66, 0xff, 0x1f, 0x95, 0xad, 0xcb, 0x7f,
+	0xc3, 0xf9, 0x97, 0x32, 0xde, 0x55, 0xbe, 0x69, 0xfe, 0x78, 0xdf, 0xa0, 0x5d, 0xda, 0xfc, 0xb1,
+	0xcf, 0xea, 0xcd, 0xde, 0xcd, 0x6d, 0xab, 0x51, 0xaf, 0x3c, 0x22, 0x87, 0xb0, 0xbf, 0xc9, 0xb4,
+	0x3a, 0x3f, 0x55, 0x72, 0xe4, 0x04, 0xc8, 0x26, 0xd8, 0x6e, 0xd4, 0x9b, 0xef, 0xda, 0x95, 0x2d,
+	0x72, 0x04, 0x95, 0x4d, 0xfc, 0x6d, 0xf3, 0xfe, 0x6d, 0x65, 0x7b, 0xb0, 0x83, 0xff, 0x12, 0xbc,
+	0xfe, 0xff, 0x00, 0x04, 0x3f, 0xed, 0x50, 0x24, 0x0c, 0x00, 0x00,
 }
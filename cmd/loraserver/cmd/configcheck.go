@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/partition"
+	"github.com/mxc-foundation/lpwan-server/internal/secrets"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const redacted = "********"
+
+// secretConfigKeys contains the configuration keys of which the value is
+// redacted when printing the effective configuration.
+var secretConfigKeys = map[string]bool{
+	"password":                   true,
+	"token":                      true,
+	"secret":                     true,
+	"kek":                        true,
+	"key":                        true,
+	"events_connection_string":   true,
+	"commands_connection_string": true,
+}
+
+// secretConfigKeySuffixes contains the suffixes of the configuration keys of
+// which the value is redacted, e.g. jwt_secret or app_key.
+var secretConfigKeySuffixes = []string{
+	"_password",
+	"_token",
+	"_secret",
+	"_key",
+}
+
+// pathConfigKeys contains the configuration keys which have a secret suffix,
+// but of which the value is a file path. Note that a PEM encoded value is
+// always redacted.
+var pathConfigKeys = map[string]bool{
+	"tls_key": true,
+}
+
+// urlConfigKeys contains the configuration keys of which the password
+// within the value (URL or DSN) is redacted.
+var urlConfigKeys = map[string]bool{
+	"dsn":    true,
+	"url":    true,
+	"server": true,
+}
+
+var dsnPasswordRegexp = regexp.MustCompile(`(password=)\S+`)
+
+var configCheckQuiet bool
+
+var configCheckCmd = &cobra.Command{
+	Use:          "configcheck",
+	Short:        "Validate the configuration and print the effective configuration",
+	Long:         "Validate the configuration and print the effective configuration (configuration file, environment variables and defaults merged), with secrets redacted.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !configCheckQuiet {
+			conf := config.C
+			redactConfig(reflect.ValueOf(&conf).Elem())
+
+			t := template.Must(template.New("config").Parse(configTemplate))
+			if err := t.Execute(os.Stdout, &conf); err != nil {
+				return errors.Wrap(err, "execute config template error")
+			}
+		}
+
+		errs := checkConfig(config.C)
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "- %s\n", err)
+		}
+		if len(errs) != 0 {
+			return fmt.Errorf("configuration contains %d error(s)", len(errs))
+		}
+
+		fmt.Fprintln(os.Stderr, "configuration is valid")
+		return nil
+	},
+}
+
+func init() {
+	configCheckCmd.Flags().BoolVarP(&configCheckQuiet, "quiet", "q", false, "do not print the effective configuration")
+}
+
+// checkConfig validates the given configuration and returns all errors
+// found. Note that the net_id has already been validated on loading.
+func checkConfig(conf config.Config) []error {
+	var errs []error
+
+	if conf.Redis.Cluster.Enabled {
+		if len(conf.Redis.Cluster.Nodes) == 0 {
+			errs = append(errs, errors.New("redis.cluster.nodes must not be empty"))
+		}
+		if conf.Redis.LocalCache.Enabled {
+			errs = append(errs, errors.New("redis.local_cache is not supported in combination with redis.cluster"))
+		}
+	}
+
+	if conf.Redis.Sentinel.MasterName != "" {
+		if len(conf.Redis.Sentinel.Addresses) == 0 {
+			errs = append(errs, errors.New("redis.sentinel.addresses must not be empty"))
+		}
+		if conf.Redis.Cluster.Enabled {
+			errs = append(errs, errors.New("redis.sentinel can not be used in combination with redis.cluster"))
+		}
+		if conf.Redis.LocalCache.Enabled {
+			errs = append(errs, errors.New("redis.local_cache is not supported in combination with redis.sentinel"))
+		}
+	}
+
+	ns := conf.NetworkServer
+	if ns.DeduplicationDelay <= 0 {
+		errs = append(errs, errors.New("network_server.deduplication_delay must be greater than 0"))
+	}
+	if ns.Deduplication.Shards < 0 {
+		errs = append(errs, errors.New("network_server.deduplication.shards must not be negative"))
+	}
+	if ns.Scheduler.SchedulerInterval <= 0 {
+		errs = append(errs, errors.New("network_server.scheduler.scheduler_interval must be greater than 0"))
+	}
+	if ns.LeaderElection.Enabled && (ns.LeaderElection.RenewInterval <= 0 || ns.LeaderElection.RenewInterval >= ns.LeaderElection.LockTTL) {
+		errs = append(errs, errors.New("network_server.leader_election.renew_interval must be greater than 0 and less than lock_ttl"))
+	}
+	if ns.Partitioning.Enabled {
+		if ns.Partitioning.Count <= 0 {
+			errs = append(errs, errors.New("network_server.partitioning.count must be greater than 0"))
+		}
+		for _, p := range ns.Partitioning.Partitions {
+			if p < 0 || p >= ns.Partitioning.Count {
+				errs = append(errs, fmt.Errorf("network_server.partitioning.partitions: partition %d is out of range", p))
+			}
+		}
+		if len(ns.Partitioning.Partitions) == 0 && (ns.Partitioning.RenewInterval <= 0 || ns.Partitioning.RenewInterval >= ns.Partitioning.ClaimTTL) {
+			errs = append(errs, errors.New("network_server.partitioning.renew_interval must be greater than 0 and less than claim_ttl"))
+		}
+		if err := partition.CheckGatewayBackends(conf); err != nil {
+			errs = append(errs, errors.Wrap(err, "network_server.partitioning can not be used with the gateway backend"))
+		}
+	}
+
+	backendTypeKey := "network_server.gateway.backend.type"
+	if len(ns.Gateway.Backend.Types) != 0 {
+		backendTypeKey = "network_server.gateway.backend.types"
+	}
+	backendTypes := make(map[string]bool)
+	for _, t := range gatewayBackendTypes(conf) {
+		switch t {
+		case "mqtt", "gcp_pub_sub", "azure_iot_hub", "basic_station", "kafka", "nats", "semtech_udp", "concentratord":
+		default:
+			errs = append(errs, fmt.Errorf("%s: unexpected type '%s'", backendTypeKey, t))
+		}
+		if backendTypes[t] {
+			errs = append(errs, fmt.Errorf("%s: duplicate type '%s'", backendTypeKey, t))
+		}
+		backendTypes[t] = true
+	}
+
+	if ns.Scheduler.TXAck.Timeout < 0 {
+		errs = append(errs, errors.New("network_server.scheduler.tx_ack.timeout must not be negative"))
+	}
+	if ns.Scheduler.TXAck.RetryGateways < 0 {
+		errs = append(errs, errors.New("network_server.scheduler.tx_ack.retry_gateways must not be negative"))
+	}
+
+	if backendTypes["mqtt"] {
+		mqtt := ns.Gateway.Backend.MQTT
+		if mqtt.ShardCount < 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.mqtt.shard_count must not be negative"))
+		}
+		if mqtt.ShardCount == 0 && len(mqtt.Shards) != 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.mqtt.shards requires shard_count to be set"))
+		}
+		for _, shard := range mqtt.Shards {
+			if shard < 0 || (mqtt.ShardCount > 0 && shard >= mqtt.ShardCount) {
+				errs = append(errs, fmt.Errorf("network_server.gateway.backend.mqtt.shards: shard %d must be between 0 and shard_count - 1", shard))
+			}
+		}
+		if mqtt.MaxReconnectInterval < 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.mqtt.max_reconnect_interval must not be negative"))
+		}
+		if mqtt.OutboundBuffer.Size < 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.mqtt.outbound_buffer.size must not be negative"))
+		}
+		if mqtt.OutboundBuffer.Size > 0 && mqtt.OutboundBuffer.TTL <= 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.mqtt.outbound_buffer.ttl must be set when the outbound buffer is enabled"))
+		}
+	}
+
+	if backendTypes["concentratord"] {
+		concentratord := ns.Gateway.Backend.Concentratord
+		if concentratord.EventURL == "" || concentratord.CommandURL == "" {
+			errs = append(errs, errors.New("network_server.gateway.backend.concentratord: event_url and command_url must be set"))
+		}
+		if concentratord.CommandTimeout <= 0 {
+			errs = append(errs, errors.New("network_server.gateway.backend.concentratord.command_timeout must be greater than 0"))
+		}
+	}
+
+	if statsAgg := ns.Gateway.StatsAggregation; statsAgg.Enabled {
+		if statsAgg.FlushInterval <= 0 {
+			errs = append(errs, errors.New("network_server.gateway.stats_aggregation.flush_interval must be greater than 0"))
+		}
+		if len(statsAgg.Intervals) == 0 {
+			errs = append(errs, errors.New("network_server.gateway.stats_aggregation.intervals must not be empty"))
+		}
+		intervals := make(map[time.Duration]bool)
+		for _, i := range statsAgg.Intervals {
+			if i.Interval <= 0 {
+				errs = append(errs, errors.New("network_server.gateway.stats_aggregation.intervals: interval must be greater than 0"))
+			}
+			if i.Retention < 0 {
+				errs = append(errs, fmt.Errorf("network_server.gateway.stats_aggregation.intervals: retention of interval %s must not be negative", i.Interval))
+			}
+			if intervals[i.Interval] {
+				errs = append(errs, fmt.Errorf("network_server.gateway.stats_aggregation.intervals: duplicate interval %s", i.Interval))
+			}
+			intervals[i.Interval] = true
+		}
+	}
+
+	switch conf.Janitor.DeviceSessionIntegrity.Policy {
+	case "log", "repair":
+	default:
+		errs = append(errs, fmt.Errorf("janitor.device_session_integrity.policy: unexpected policy '%s'", conf.Janitor.DeviceSessionIntegrity.Policy))
+	}
+
+	switch conf.NetworkServer.UplinkAttestation.Algorithm {
+	case "", "hmac_sha256", "ed25519":
+	default:
+		errs = append(errs, fmt.Errorf("network_server.uplink_attestation.algorithm: unexpected algorithm '%s'", conf.NetworkServer.UplinkAttestation.Algorithm))
+	}
+
+	errs = append(errs, checkBandConfig(conf)...)
+	errs = append(errs, checkKEKConfig(conf)...)
+	errs = append(errs, checkCertificatesConfig(conf)...)
+	errs = append(errs, checkAccountingConfig(conf)...)
+
+	if s := conf.JoinServer.Default.Server; s != "" {
+		if _, err := url.Parse(s); err != nil {
+			errs = append(errs, errors.Wrap(err, "join_server.default.server"))
+		}
+	}
+
+	return errs
+}
+
+// checkBandConfig validates the band, regions and network-settings against
+// the configured band.
+func checkBandConfig(conf config.Config) []error {
+	if err := band.Setup(conf); err != nil {
+		// the other checks depend on the band
+		return []error{errors.Wrap(err, "network_server.band")}
+	}
+
+	var errs []error
+	b := band.Band()
+	ns := conf.NetworkServer.NetworkSettings
+
+	if ns.RX2DR != -1 {
+		if _, err := b.GetDataRate(ns.RX2DR); err != nil {
+			errs = append(errs, errors.Wrap(err, "network_server.network_settings.rx2_dr"))
+		}
+	}
+	if ns.RX2Frequency != -1 && ns.RX2Frequency <= 0 {
+		errs = append(errs, fmt.Errorf("network_server.network_settings.rx2_frequency: invalid frequency %d", ns.RX2Frequency))
+	}
+	if _, err := b.GetRX1DataRateIndex(0, ns.RX1DROffset); err != nil {
+		errs = append(errs, errors.Wrap(err, "network_server.network_settings.rx1_dr_offset"))
+	}
+	if ns.RX1Delay < 0 || ns.RX1Delay > 15 {
+		errs = append(errs, errors.New("network_server.network_settings.rx1_delay: must be between 0 and 15"))
+	}
+	if ns.RXWindow < 0 || ns.RXWindow > 2 {
+		errs = append(errs, errors.New("network_server.network_settings.rx_window: must be 0, 1 or 2"))
+	}
+	if _, err := b.GetDataRate(ns.ClassB.PingSlotDR); err != nil {
+		errs = append(errs, errors.Wrap(err, "network_server.network_settings.class_b.ping_slot_dr"))
+	}
+	for _, c := range ns.EnabledUplinkChannels {
+		if _, err := b.GetUplinkChannel(c); err != nil {
+			errs = append(errs, errors.Wrapf(err, "network_server.network_settings.enabled_uplink_channels: channel %d", c))
+		}
+	}
+	for _, c := range ns.ExtraChannels {
+		if _, err := b.GetDataRate(c.MinDR); err != nil {
+			errs = append(errs, errors.Wrapf(err, "network_server.network_settings.extra_channels: min_dr of %d", c.Frequency))
+		}
+		if _, err := b.GetDataRate(c.MaxDR); err != nil {
+			errs = append(errs, errors.Wrapf(err, "network_server.network_settings.extra_channels: max_dr of %d", c.Frequency))
+		}
+	}
+
+	return errs
+}
+
+// checkKEKConfig validates the join-server key-encryption keys.
+func checkKEKConfig(conf config.Config) []error {
+	errs := checkKEKSet("join_server.kek.set", conf.JoinServer.KEK.Set)
+
+	for i, js := range conf.JoinServer.KEK.JoinServers {
+		var from, to lorawan.EUI64
+		if err := from.UnmarshalText([]byte(js.JoinEUIFrom)); err != nil {
+			errs = append(errs, fmt.Errorf("join_server.kek.join_servers[%d]: join_eui_from must be a HEX encoded EUI64", i))
+		}
+		if err := to.UnmarshalText([]byte(js.JoinEUITo)); err != nil {
+			errs = append(errs, fmt.Errorf("join_server.kek.join_servers[%d]: join_eui_to must be a HEX encoded EUI64", i))
+		}
+		if bytes.Compare(from[:], to[:]) > 0 {
+			errs = append(errs, fmt.Errorf("join_server.kek.join_servers[%d]: join_eui_from must not be greater than join_eui_to", i))
+		}
+
+		errs = append(errs, checkKEKSet(fmt.Sprintf("join_server.kek.join_servers[%d].set", i), js.Set)...)
+	}
+
+	return errs
+}
+
+// checkCertificatesConfig validates the automatic TLS certificates settings.
+func checkCertificatesConfig(conf config.Config) []error {
+	var errs []error
+	c := conf.Certificates
+
+	switch c.Provider {
+	case "":
+		if conf.Metrics.Prometheus.TLS {
+			errs = append(errs, errors.New("metrics.prometheus.tls requires a certificates.provider"))
+		}
+		return errs
+	case "acme":
+		if c.ACME.DirectoryURL == "" {
+			errs = append(errs, errors.New("certificates.acme.directory_url must not be empty"))
+		}
+	case "ca":
+		if c.CA.URL == "" {
+			errs = append(errs, errors.New("certificates.ca.url must not be empty"))
+		} else if _, err := url.Parse(c.CA.URL); err != nil {
+			errs = append(errs, errors.Wrap(err, "certificates.ca.url"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("certificates.provider: unexpected provider '%s'", c.Provider))
+	}
+
+	if len(c.Hostnames) == 0 {
+		errs = append(errs, errors.New("certificates.hostnames must not be empty"))
+	}
+	if c.RenewBefore <= 0 {
+		errs = append(errs, errors.New("certificates.renew_before must be greater than 0"))
+	}
+
+	return errs
+}
+
+// checkAccountingConfig validates the accounting settings.
+func checkAccountingConfig(conf config.Config) []error {
+	var errs []error
+	c := conf.Accounting
+
+	if !c.Enabled {
+		return nil
+	}
+
+	switch c.Sink {
+	case "postgres":
+	case "mqtt":
+		if c.MQTT.Server == "" {
+			errs = append(errs, errors.New("accounting.mqtt.server must not be empty"))
+		}
+		if c.MQTT.QOS != 1 && c.MQTT.QOS != 2 {
+			errs = append(errs, errors.New("accounting.mqtt.qos must be 1 or 2"))
+		}
+		if c.MQTT.ForwardInterval <= 0 {
+			errs = append(errs, errors.New("accounting.mqtt.forward_interval must be greater than 0"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("accounting.sink: unexpected sink '%s'", c.Sink))
+	}
+
+	return errs
+}
+
+func checkKEKSet(prefix string, set []struct {
+	Label string
+	KEK   string `mapstructure:"kek"`
+}) []error {
+	var errs []error
+	labels := make(map[string]bool)
+
+	for i, k := range set {
+		if k.Label == "" {
+			errs = append(errs, fmt.Errorf("%s[%d]: label must not be empty", prefix, i))
+		} else if labels[k.Label] {
+			errs = append(errs, fmt.Errorf("%s[%d]: label '%s' is used more than once", prefix, i, k.Label))
+		}
+		labels[k.Label] = true
+
+		// the secret is only resolved on startup
+		if secrets.IsReference(k.KEK) {
+			continue
+		}
+
+		b, err := hex.DecodeString(k.KEK)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: kek must be HEX encoded", prefix, i))
+			continue
+		}
+		if l := len(b); l != 16 && l != 24 && l != 32 {
+			errs = append(errs, fmt.Errorf("%s[%d]: kek must be a 128, 192 or 256 bit key (got %d bits)", prefix, i, l*8))
+		}
+	}
+
+	return errs
+}
+
+// redactConfig redacts the secrets of the given (addressable) config
+// struct value. Slices are copied so that the original configuration is not
+// modified.
+func redactConfig(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		name := configKeyName(t.Field(i))
+
+		switch f.Kind() {
+		case reflect.Struct:
+			redactConfig(f)
+		case reflect.Slice:
+			if f.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			c := reflect.MakeSlice(f.Type(), f.Len(), f.Len())
+			reflect.Copy(c, f)
+			f.Set(c)
+			for j := 0; j < c.Len(); j++ {
+				redactConfig(c.Index(j))
+			}
+		case reflect.String:
+			// secret references do not contain the secret itself
+			if f.String() == "" || secrets.IsReference(f.String()) {
+				continue
+			}
+			if isSecretConfigKey(name) || nstls.IsPEM(f.String()) {
+				f.SetString(redacted)
+			} else if urlConfigKeys[name] {
+				f.SetString(redactURL(f.String()))
+			}
+		}
+	}
+}
+
+// isSecretConfigKey returns true when the value of the given configuration
+// key must be redacted.
+func isSecretConfigKey(name string) bool {
+	if secretConfigKeys[name] {
+		return true
+	}
+	if pathConfigKeys[name] {
+		return false
+	}
+	for _, suffix := range secretConfigKeySuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL redacts the password of the given URL or key=value DSN.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return dsnPasswordRegexp.ReplaceAllString(s, "${1}"+redacted)
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	return u.String()
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+func TestIsSecretConfigKey(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Secret bool
+	}{
+		{"password", true},
+		{"secret", true},
+		{"jwt_secret", true},
+		{"app_key", true},
+		{"auth_token", true},
+		{"admin_password", true},
+		{"tls_key", false},
+		{"tls_cert", false},
+		{"bind", false},
+		{"keyspace", false},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Secret, isSecretConfigKey(tst.Name))
+		})
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.Redis.URL = "redis://:secret@localhost:6379"
+	conf.Redis.Cluster.Password = "secret"
+	conf.NetworkServer.FrameLog.WebSocket.JWTSecret = "secret"
+	conf.NetworkServer.FrameLog.WebSocket.TLSKey = "/etc/loraserver/tls.key"
+	conf.NetworkServer.FrameLog.WebSocket.Bind = "0.0.0.0:8002"
+	conf.Simulator.AppKey = "01020304050607080102030405060708"
+
+	redactConfig(reflect.ValueOf(&conf).Elem())
+
+	assert.NotContains(conf.Redis.URL, "secret")
+	assert.Equal(redacted, conf.Redis.Cluster.Password)
+	assert.Equal(redacted, conf.NetworkServer.FrameLog.WebSocket.JWTSecret)
+	assert.Equal("/etc/loraserver/tls.key", conf.NetworkServer.FrameLog.WebSocket.TLSKey)
+	assert.Equal("0.0.0.0:8002", conf.NetworkServer.FrameLog.WebSocket.Bind)
+	assert.Equal(redacted, conf.Simulator.AppKey)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+var configEnvCmd = &cobra.Command{
+	Use:   "configenv",
+	Short: "Print the environment variables for each configuration key",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tVARIABLE\tLEGACY VARIABLE")
+		for _, key := range configKeys(reflect.TypeOf(config.C)) {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", key, envName(key), strings.ToUpper(key))
+		}
+		return w.Flush()
+	},
+}
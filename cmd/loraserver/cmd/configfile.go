@@ -84,6 +84,70 @@ idle_timeout="{{ .Redis.IdleTimeout }}"
 # When zero, there is no limit on the number of connections in the pool.
 max_active={{ .Redis.MaxActive }}
 
+  # In-process cache for Redis values.
+  #
+  # When enabled, the device-sessions and the cached device-, service- and
+  # routing-profiles and gateways are cached in-process, to reduce the number
+  # of Redis reads. Cached values are invalidated using Redis keyspace
+  # notifications, which must be enabled (notify-keyspace-events must contain
+  # Kg$xe). When the notification subscription is interrupted, the cache
+  # is flushed and disabled until re-subscribed.
+  [redis.local_cache]
+  # Enable the local cache.
+  enabled={{ .Redis.LocalCache.Enabled }}
+
+  # Max number of cached values.
+  size={{ .Redis.LocalCache.Size }}
+
+  # Max duration a value is cached.
+  #
+  # This is a safety-net in case a notification has been missed.
+  ttl="{{ .Redis.LocalCache.TTL }}"
+
+  # Configure notify-keyspace-events.
+  #
+  # When set, LoRa Server enables the missing keyspace notification classes
+  # on start. When not set, LoRa Server fails to start when these are not
+  # enabled. Note that this requires the CONFIG command.
+  configure_keyspace_events={{ .Redis.LocalCache.ConfigureKeyspaceEvents }}
+
+  # Redis Cluster.
+  #
+  # When enabled, LoRa Server connects to the Redis Cluster using the given
+  # nodes instead of the Redis url. The keys which are used together (e.g.
+  # the keys of a device) are hash-tagged so that these are stored in the
+  # same slot. Note that the local cache is not supported in combination
+  # with Redis Cluster.
+  [redis.cluster]
+  # Enable Redis Cluster.
+  enabled={{ .Redis.Cluster.Enabled }}
+
+  # Cluster nodes (hostname:port).
+  #
+  # These nodes are used to discover the cluster topology, not all the
+  # nodes of the cluster need to be configured.
+  nodes=[{{ range $index, $element := .Redis.Cluster.Nodes }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
+  # Password (optional).
+  password="{{ .Redis.Cluster.Password }}"
+
+  # Redis Sentinel.
+  #
+  # When the master name is set, LoRa Server retrieves the address of the
+  # Redis primary from the given Sentinels, instead of using the host of
+  # the Redis url (the password and database of the url are still used).
+  # On a failover, the connections to the former primary are closed and
+  # new connections are made to the promoted primary. Note that the local
+  # cache is not supported in combination with Redis Sentinel.
+  [redis.sentinel]
+  # Master name.
+  #
+  # The name of the monitored primary, as configured in the Sentinels.
+  master_name="{{ .Redis.Sentinel.MasterName }}"
+
+  # Sentinel addresses (hostname:port).
+  addresses=[{{ range $index, $element := .Redis.Sentinel.Addresses }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
 
 [m2m_server]
 m2m_server={{ .M2MServer.M2MServer }}
@@ -124,6 +188,17 @@ device_session_ttl="{{ .NetworkServer.DeviceSessionTTL }}"
 # unable to respond to the device within its receive-window.
 get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
 
+# Dry-run (shadow) mode.
+#
+# When enabled, LoRa Server handles the uplink frames as usual (device-sessions,
+# ADR, frame-logs and metrics are updated), but it does not transmit any
+# downlinks or gateway configurations and it does not contact the
+# application-server or join-server. Join-requests will therefore fail.
+# This can be used to validate a new version against live traffic. Make sure
+# to use a copy of the production database and Redis, and not to use the
+# same (shared) MQTT subscription as the production instances.
+dry_run={{ .NetworkServer.DryRun }}
+
 
   # LoRaWAN regional band configuration.
   #
@@ -178,6 +253,51 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # In case a repeater might used, set this flag to true.
   repeater_compatible={{ .NetworkServer.Band.RepeaterCompatible }}
 
+  # Additional regions.
+  #
+  # Besides the band configured above (the default region), LoRa Server
+  # can serve additional regions. The region of a gateway is set in its
+  # gateway-profile, the region of a device is resolved from the gateway
+  # which received its join-request. Gateways and devices without region
+  # use the default band.
+  #
+  # Note: for additional regions, the RX2 and Class-B parameters are the
+  # defaults of the band.
+  #
+  # Example:
+  # [[network_server.regions]]
+  # name="in865"
+  # band="IN_865_867"
+  # downlink_dwell_time_400ms=false
+  # repeater_compatible=false
+  # disable_default_channels=false
+  #
+  #   [[network_server.regions.extra_channels]]
+  #   frequency=865402500
+  #   min_dr=0
+  #   max_dr=5
+{{ range $index, $element := .NetworkServer.Regions }}
+  [[network_server.regions]]
+  name="{{ $element.Name }}"
+  band="{{ $element.Band }}"
+  downlink_dwell_time_400ms={{ $element.DownlinkDwellTime400ms }}
+  repeater_compatible={{ $element.RepeaterCompatible }}
+  disable_default_channels={{ $element.DisableDefaultChannels }}
+{{ range $i, $ch := $element.ExtraChannels }}
+    [[network_server.regions.extra_channels]]
+    frequency={{ $ch.Frequency }}
+    min_dr={{ $ch.MinDR }}
+    max_dr={{ $ch.MaxDR }}
+    downlink_only={{ $ch.DownlinkOnly }}
+    uplink_frequency={{ $ch.UplinkFrequency }}
+{{ end }}
+{{ range $i, $g := $element.ChannelGroups }}
+    [[network_server.regions.channel_groups]]
+    name="{{ $g.Name }}"
+    uplink_channels=[{{ range $j, $c := $g.UplinkChannels }}{{ if $j }}, {{ end }}{{ $c }}{{ end }}]
+    downlink_frequencies=[{{ range $j, $f := $g.DownlinkFrequencies }}{{ if $j }}, {{ end }}{{ $f }}{{ end }}]
+{{ end }}
+{{ end }}
 
   # LoRaWAN network related settings.
   [network_server.network_settings]
@@ -250,6 +370,14 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # When set, this globally disables ADR.
   disable_adr={{ .NetworkServer.NetworkSettings.DisableADR }}
 
+  # Frame-counter reset threshold
+  #
+  # For device-profiles using the relaxed frame-counter validation mode, an
+  # uplink frame-counter which is lower than expected is only accepted as
+  # frame-counter reset (e.g. after a power-cycle of the device) when it is
+  # below this threshold.
+  fcnt_reset_threshold={{ .NetworkServer.NetworkSettings.FCntResetThreshold }}
+
   # Enable only a given sub-set of channels
   #
   # Use this when ony a sub-set of the by default enabled channels are being
@@ -270,6 +398,10 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # The other channels (or channel / data-rate changes) will be (re)configured
   # using the NewChannelReq mac-command.
   #
+  # A channel with downlink_only=true is not added as uplink channel. Instead
+  # its frequency is used as RX1 frequency for uplinks received on the
+  # configured uplink_frequency.
+  #
   # Example:
   # [[network_server.network_settings.extra_channels]]
   # frequency=867100000
@@ -295,11 +427,55 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   # frequency=867900000
   # min_dr=0
   # max_dr=5
+
+  # [[network_server.network_settings.extra_channels]]
+  # frequency=869525000
+  # downlink_only=true
+  # uplink_frequency=867900000
 {{ range $index, $element := .NetworkServer.NetworkSettings.ExtraChannels }}
   [[network_server.network_settings.extra_channels]]
   frequency={{ $element.Frequency }}
   min_dr={{ $element.MinDR }}
   max_dr={{ $element.MaxDR }}
+  downlink_only={{ $element.DownlinkOnly }}
+  uplink_frequency={{ $element.UplinkFrequency }}
+{{ end }}
+
+  # Disable the default channels.
+  #
+  # When set, the default channels of the band are disabled and only the
+  # extra channels configured above are used (fully custom channel-plan).
+  # As the LoRaWAN Regional Parameters do not allow removing the default
+  # channels from the device, these are turned off using the LinkADRReq
+  # channel-mask after the activation. Note that the data-rates of the
+  # extra channels must exist within the band.
+  disable_default_channels={{ .NetworkServer.NetworkSettings.DisableDefaultChannels }}
+
+
+  # Channel-groups.
+  #
+  # Some regional variants require selecting a group of channels per
+  # deployment, e.g. the CN470 antenna-type plans or the AU915 alternative
+  # channel-groups. A channel-group enables only the given uplink channels
+  # (channel-numbers of the band, including the extra channels) and is
+  # selected using the channel_group field of the gateway-profile.
+  # Channel-groups can also be configured for the additional regions, using
+  # [[network_server.regions.channel_groups]].
+  #
+  # When downlink_frequencies is set, the RX1 frequency of the n-th uplink
+  # channel of the group is the (n modulo the number of downlink frequencies)
+  # downlink frequency. When not set, the RX1 frequency of the band is used.
+  #
+  # Example:
+  # [[network_server.network_settings.channel_groups]]
+  # name="type-a-1"
+  # uplink_channels=[0, 1, 2, 3, 4, 5, 6, 7]
+  # downlink_frequencies=[483900000, 484100000, 484300000, 484500000, 484700000, 484900000, 485100000, 485300000]
+{{ range $index, $element := .NetworkServer.NetworkSettings.ChannelGroups }}
+  [[network_server.network_settings.channel_groups]]
+  name="{{ $element.Name }}"
+  uplink_channels=[{{ range $j, $c := $element.UplinkChannels }}{{ if $j }}, {{ end }}{{ $c }}{{ end }}]
+  downlink_frequencies=[{{ range $j, $f := $element.DownlinkFrequencies }}{{ if $j }}, {{ end }}{{ $f }}{{ end }}]
 {{ end }}
 
   # Class B settings
@@ -353,6 +529,142 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     # after a preceeding downlink tx (per device).
     downlink_lock_duration="{{ .NetworkServer.Scheduler.ClassC.DownlinkLockDuration }}"
 
+    # Multicast settings.
+    [network_server.scheduler.multicast]
+    # Max. number of retries per gateway.
+    #
+    # When a gateway reports that a multicast frame could not be transmitted
+    # (e.g. too late or a collision), the frame is re-scheduled for this
+    # gateway until the max. number of retries has been reached.
+    tx_retries={{ .NetworkServer.Scheduler.Multicast.TXRetries }}
+
+    # Transmission status TTL.
+    #
+    # The per-frame and per-gateway transmission status of multicast frames
+    # (see the GetMulticastTXStatus API method) is kept for this duration
+    # after the last update.
+    tx_status_ttl="{{ .NetworkServer.Scheduler.Multicast.TXStatusTTL }}"
+
+    # TX acknowledgement settings.
+    [network_server.scheduler.tx_ack]
+    # TX acknowledgement timeout.
+    #
+    # When set, a downlink for which no TX acknowledgement has been received
+    # within this duration is handled as if the gateway reported that it
+    # could not be transmitted: the next saved downlink-frame (e.g. RX2 or the
+    # frame for the next-best gateway) is sent. Only set this when all
+    # gateways send TX acknowledgements, else downlinks could be transmitted
+    # twice. Note that the retry must be sent to the gateway before the
+    # scheduled transmission time of the frame (e.g. RX2 is opened one
+    # second after RX1). 0 disables the timeout.
+    timeout="{{ .NetworkServer.Scheduler.TXAck.Timeout }}"
+
+    # Number of next-best gateways used for retrying a downlink.
+    #
+    # When set, the downlink-frames (e.g. RX1 and RX2) are also prepared for
+    # the given number of next-best gateways which received the uplink of the
+    # device. On a negative TX acknowledgement or TX acknowledgement timeout,
+    # the frames are tried in order of transmission time (e.g. RX1 of the
+    # next-best gateway before RX2 of the first gateway).
+    retry_gateways={{ .NetworkServer.Scheduler.TXAck.RetryGateways }}
+
+
+  # Leader election.
+  #
+  # When running multiple LoRa Server instances (sharing the same Redis and
+  # PostgreSQL database), enable the leader election so that only one instance
+  # runs the multicast, Class-B and Class-C schedulers and the provisioning
+  # synchronization. All instances handle uplink frames. When the leader fails,
+  # an other instance takes over after the lock TTL has expired.
+  [network_server.leader_election]
+  # Enable leader election.
+  #
+  # When disabled, this instance always runs the schedulers.
+  enabled={{ .NetworkServer.LeaderElection.Enabled }}
+
+  # Lock TTL.
+  #
+  # The duration after which the leadership expires when not renewed.
+  lock_ttl="{{ .NetworkServer.LeaderElection.LockTTL }}"
+
+  # Renew interval.
+  #
+  # The interval in which the leadership is renewed or acquired. This must
+  # be less than the lock TTL.
+  renew_interval="{{ .NetworkServer.LeaderElection.RenewInterval }}"
+
+  # Device partitioning.
+  #
+  # When enabled, the DevAddr space (and the DevEUI space for join- and
+  # rejoin-requests) is divided into partitions. Each instance only handles
+  # the uplink frames of the partitions it owns and ignores all other uplink
+  # frames. This requires that every instance receives all uplink frames,
+  # therefore only the mqtt (without shared_subscription_group and shards)
+  # and nats (with an empty queue_group, without jetstream) gateway backends
+  # are supported. The other gateway backends load-balance the uplink frames
+  # over the instances, or the gateways connect to a single instance.
+  [network_server.partitioning]
+  # Enable device partitioning.
+  enabled={{ .NetworkServer.Partitioning.Enabled }}
+
+  # Partition count.
+  #
+  # The number of partitions. This must be the same for all instances and
+  # should be (much) greater than the number of instances.
+  count={{ .NetworkServer.Partitioning.Count }}
+
+  # Static partitions.
+  #
+  # The partitions (0 ... count-1) owned by this instance, e.g. [0, 1, 2, 3].
+  # When empty, the partitions are claimed through Redis, each instance
+  # claiming an equal share. Partitions of a failed instance are claimed by
+  # the other instances after the claim TTL has expired.
+  partitions=[{{ range $index, $element := .NetworkServer.Partitioning.Partitions }}{{ if $index }}, {{ end }}{{ $element }}{{ end }}]
+
+  # Claim TTL.
+  #
+  # The duration after which a partition claim expires when not renewed.
+  claim_ttl="{{ .NetworkServer.Partitioning.ClaimTTL }}"
+
+  # Renew interval.
+  #
+  # The interval in which the partition claims are renewed and rebalanced.
+  # This must be less than the claim TTL.
+  renew_interval="{{ .NetworkServer.Partitioning.RenewInterval }}"
+
+  # Feature-flags.
+  #
+  # Feature-flags gate new behavior, so that it can be rolled out to a
+  # percentage of the devices (per service-profile). Whether a feature is
+  # enabled for a device is stable as long as the percentage is not lowered.
+  # The percentages can be overridden at runtime using the
+  # 'loraserver feature-flag' command (stored in Redis).
+  [network_server.feature_flags]
+  # Refresh interval.
+  #
+  # The interval in which the Redis overrides are read. Set this to 0 to
+  # disable the Redis overrides.
+  refresh_interval="{{ .NetworkServer.FeatureFlags.RefreshInterval }}"
+
+  # Example:
+  # [[network_server.feature_flags.flags]]
+  # name="example_feature"
+  # percentage=10
+  #
+  #   [[network_server.feature_flags.flags.service_profiles]]
+  #   id="00000000-0000-0000-0000-000000000000"
+  #   percentage=50
+{{ range $index, $element := .NetworkServer.FeatureFlags.Flags }}
+  [[network_server.feature_flags.flags]]
+  name="{{ $element.Name }}"
+  percentage={{ $element.Percentage }}
+{{ range $i, $sp := $element.ServiceProfiles }}
+    [[network_server.feature_flags.flags.service_profiles]]
+    id="{{ $sp.ID }}"
+    percentage={{ $sp.Percentage }}
+{{ end }}
+{{ end }}
+
 
   # Network-server API
   #
@@ -372,6 +684,179 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
   tls_key="{{ .NetworkServer.API.TLSKey }}"
 
 
+  # Zero-downtime restart (handover).
+  #
+  # When enabled, sending SIGUSR2 to LoRa Server starts a new process (using
+  # the current executable, e.g. after an upgrade) which takes over the API
+  # and Prometheus listeners. Once the new process has subscribed to the
+  # gateway backend and is serving, the current process stops gracefully.
+  # Note that the MQTT client_id must be left blank (or be unique), as both
+  # processes are connected for a short time. Not supported on Windows.
+  [network_server.handover]
+  enabled={{ .NetworkServer.Handover.Enabled }}
+
+  # Max. time to wait for the new process to become ready. On timeout, the
+  # new process is killed and the current process keeps running.
+  ready_timeout="{{ .NetworkServer.Handover.ReadyTimeout }}"
+
+
+  # Webhook settings.
+  #
+  # The webhook URL, secret and events are configured per service-profile.
+  [network_server.webhook]
+  # Timeout of a single webhook request.
+  timeout="{{ .NetworkServer.Webhook.Timeout }}"
+
+  # Max. number of retries on a failed request.
+  #
+  # Requests are retried on connection errors and on 5xx or 429 responses.
+  max_retries={{ .NetworkServer.Webhook.MaxRetries }}
+
+  # Interval before the first retry.
+  #
+  # This interval doubles on every retry.
+  retry_interval="{{ .NetworkServer.Webhook.RetryInterval }}"
+
+
+  # Downlink hook settings.
+  #
+  # The downlink hook URL and secret are configured per routing-profile.
+  # The hook is invoked before a device-queue item is sent and can modify
+  # or veto the payload.
+  [network_server.downlink_hook]
+  # Timeout of the hook request.
+  #
+  # As the hook is invoked within the downlink timing budget, the request
+  # is not retried.
+  timeout="{{ .NetworkServer.DownlinkHook.Timeout }}"
+
+  # Fail policy.
+  #
+  # Defines the behavior when the hook could not be invoked (e.g. timeout):
+  #   closed - the device-queue item is held back until the next downlink
+  #            opportunity
+  #   open   - the device-queue item is sent unmodified
+  fail_policy="{{ .NetworkServer.DownlinkHook.FailPolicy }}"
+
+
+  # Uplink attestation settings.
+  #
+  # When configured, each uplink forwarded to the application-server is
+  # signed by the network-server. The signature covers the PHYPayload, the
+  # RX / TX meta-data and the network-server timestamp and is included in the
+  # application-server request and in the accounting record.
+  [network_server.uplink_attestation]
+  # Signature algorithm.
+  #
+  # Valid options are:
+  #   hmac_sha256 - HMAC-SHA256 using a shared key
+  #   ed25519     - Ed25519 signature (the public key is logged on startup)
+  #
+  # Leave this empty to disable uplink attestation.
+  algorithm="{{ .NetworkServer.UplinkAttestation.Algorithm }}"
+
+  # Key ID.
+  #
+  # This ID is included in the attestation so that the receiver can select
+  # the key to verify the signature with (e.g. after a key rotation).
+  key_id="{{ .NetworkServer.UplinkAttestation.KeyID }}"
+
+  # Key (HEX encoded).
+  #
+  # For hmac_sha256 this is the shared key, for ed25519 this is either the
+  # 32 byte seed or the 64 byte private key.
+  key="{{ .NetworkServer.UplinkAttestation.Key }}"
+
+
+  # Frame-log capture settings.
+  #
+  # Capture sessions buffer the frames of a device or gateway in Redis for
+  # a bounded duration, so that they can be downloaded as a single file.
+  [network_server.frame_log.capture]
+  # Max. duration of a capture session.
+  max_duration="{{ .NetworkServer.FrameLog.Capture.MaxDuration }}"
+
+  # Max. number of frames per capture session.
+  max_frames={{ .NetworkServer.FrameLog.Capture.MaxFrames }}
+
+  # Time after the end of a capture session, after which the captured
+  # frames are removed.
+  ttl="{{ .NetworkServer.FrameLog.Capture.TTL }}"
+
+
+  # Frame-log WebSocket server settings.
+  #
+  # This server streams the frame-logs of a device or gateway as JSON
+  # messages over WebSocket, so that browser based consoles can connect
+  # without a gRPC-web proxy. Connections are authenticated using a HS256
+  # signed JWT token, which must contain an expiration (exp) and either
+  # the dev_eui or gateway_id claim.
+  [network_server.frame_log.websocket]
+  # ip:port to bind the WebSocket server to (when empty, the server is
+  # disabled).
+  bind="{{ .NetworkServer.FrameLog.WebSocket.Bind }}"
+
+  # TLS certificate and key files (optional).
+  tls_cert="{{ .NetworkServer.FrameLog.WebSocket.TLSCert }}"
+  tls_key="{{ .NetworkServer.FrameLog.WebSocket.TLSKey }}"
+
+  # Secret used for validating the JWT tokens.
+  jwt_secret="{{ .NetworkServer.FrameLog.WebSocket.JWTSecret }}"
+
+  # Allowed WebSocket origins (when empty, all origins are allowed).
+  allowed_origins=[{{ range $i, $o := .NetworkServer.FrameLog.WebSocket.AllowedOrigins }}{{ if $i }}, {{ end }}"{{ $o }}"{{ end }}]
+
+
+  # Uplink de-duplication.
+  #
+  # The frames received by the gateways are collected in Redis during the
+  # deduplication_delay. When shards is set, the de-duplication keys are
+  # spread over the given number of key prefixes (shards), based on a hash of
+  # the frame. All receptions of a frame are still collected in a single set,
+  # which is removed shortly after the deduplication_delay instead of after
+  # twice the deduplication_delay. Set shards to 0 to use the legacy keys.
+  # Note: the number of shards must be equal for all LoRa Server instances.
+  #
+  # Migrating between the legacy keys and the sharded keys (in both
+  # directions) is done in two steps:
+  #   1. set shards and migrate_legacy_keys=true and restart all instances
+  #      one by one, the instances write and lock both key layouts
+  #   2. set migrate_legacy_keys=false and restart all instances one by one
+  # (when migrating back to the legacy keys, set shards=0 in the second step)
+  [network_server.deduplication]
+  shards={{ .NetworkServer.Deduplication.Shards }}
+  migrate_legacy_keys={{ .NetworkServer.Deduplication.MigrateLegacyKeys }}
+
+
+  # Downlink latency budget.
+  #
+  # The time between receiving the uplink and publishing the Class-A
+  # downlink (data or join-accept) to the gateway is exposed by the
+  # downlink_latency_seconds metric. When this exceeds the given fraction of
+  # the RX1 delay, a warning is logged and the
+  # downlink_latency_budget_exceeded_count metric is incremented. Note that
+  # this includes the deduplication_delay and get_downlink_data_delay.
+  [network_server.latency_budget]
+  # Fraction of the RX1 delay (set to 0 to disable the check).
+  rx1_delay_fraction={{ .NetworkServer.LatencyBudget.RX1DelayFraction }}
+
+
+  # Gateway settings.
+  [network_server.gateway]
+  # Gateway meta-data update.
+  #
+  # Before handling an uplink, the rx-info set is updated with the gateway
+  # meta-data (location and fine-timestamp decryption). Valid options are:
+  #  * sync: the meta-data is updated before handling the uplink
+  #  * async: the uplink is handled without waiting for the meta-data,
+  #    which is only added to the gateway frame-logs. As the gateway
+  #    location is not forwarded to the application-server and the
+  #    fine-timestamp is not decrypted, this can't be used together with
+  #    geolocation
+  #  * disabled: the meta-data is not updated
+  meta_data_update="{{ .NetworkServer.Gateway.MetaDataUpdate }}"
+
+
   # Backend defines the gateway backend settings.
   #
   # The gateway backend handles the communication with the gateway(s) part of
@@ -385,8 +870,26 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     #  * mqtt
     #  * gcp_pub_sub
     #  * azure_iot_hub
+    #  * basic_station
+    #  * kafka
+    #  * nats
+    #  * semtech_udp
+    #  * concentratord
     type="{{ .NetworkServer.Gateway.Backend.Type }}"
 
+    # Multiple backends (optional).
+    #
+    # Use this to run multiple gateway backends concurrently, e.g. for a
+    # mixed fleet of MQTT and Basic Station gateways. When set, this takes
+    # precedence over the type setting. The uplinks of all backends are
+    # handled and the downlinks are sent using the backend from which the
+    # last event of the gateway was received. When this is not yet known
+    # (e.g. directly after a restart), the downlink is sent using all
+    # backends.
+    #
+    # Example: types=["mqtt", "basic_station"]
+    types=[{{ range $index, $element := .NetworkServer.Gateway.Backend.Types }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
 
     # MQTT gateway backend settings.
     #
@@ -402,15 +905,75 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     # absolutely needed.
 
     # Event topic template.
+    #
+    # Use "{{ "{{ .Shard }}" }}" as an substitution for the shard (see shard_count).
     event_topic="{{ .NetworkServer.Gateway.Backend.MQTT.EventTopic }}"
 
+    # State topic.
+    #
+    # LoRa Server subscribes to this topic to receive the connection state
+    # (online / offline) of the gateways, published by the LoRa Gateway
+    # Bridge as retained message and as MQTT last-will message. The state
+    # is stored and can be retrieved using the GetGatewayConnectionState and
+    # ListGatewayConnectionStates API methods. Leave empty to disable.
+    #
+    # Use "{{ "{{ .Shard }}" }}" as an substitution for the shard (see shard_count).
+    state_topic="{{ .NetworkServer.Gateway.Backend.MQTT.StateTopic }}"
+
     # Command topic template.
     #
     # Use:
     #   * "{{ "{{ .GatewayID }}" }}" as an substitution for the LoRa gateway ID
     #   * "{{ "{{ .CommandType }}" }}" as an substitution for the command type
+    #   * "{{ "{{ .Shard }}" }}" as an substitution for the shard (see shard_count)
     command_topic_template="{{ .NetworkServer.Gateway.Backend.MQTT.CommandTopicTemplate }}"
 
+    # Shard count.
+    #
+    # When set (> 0), the gateways are partitioned into the given number of
+    # shards. The shard of a gateway is the FNV-1a (32 bit) hash of the
+    # 8 byte gateway ID, modulo the shard count. Use the shard in the topic
+    # templates to partition the gateway traffic over multiple topics (and
+    # e.g. brokers, with a separate LoRa Server instance group per broker).
+    # The LoRa Gateway Bridge (or MQTT bridge) must publish the events of each
+    # gateway to the topic of its shard. 0 disables sharding.
+    shard_count={{ .NetworkServer.Gateway.Backend.MQTT.ShardCount }}
+
+    # Shards to subscribe to.
+    #
+    # The event and state topics are subscribed for each of these shards.
+    # When empty, all shards (0 to shard_count - 1) are subscribed to.
+    # Example: shards=[0, 1]
+    shards=[{{ range $i, $s := .NetworkServer.Gateway.Backend.MQTT.Shards }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}]
+
+    # Shared subscription group (optional).
+    #
+    # When set, LoRa Server subscribes to the event topic using a shared
+    # subscription ($share/<group>/<event_topic>). The broker then delivers
+    # each gateway event to only one of the LoRa Server instances using the
+    # same group, instead of to all instances, so that the uplink ingestion
+    # can be scaled horizontally.
+    #
+    # Note: LoRa Server connects using MQTT 3.1.1, MQTT 5 is not supported.
+    # Shared subscriptions are an MQTT 5 feature, the broker must also offer
+    # these to MQTT 3.1.1 clients. This is the case for Mosquitto 1.6+,
+    # EMQ X, HiveMQ and VerneMQ, but not e.g. for the RabbitMQ MQTT plugin.
+    # A broker without this support rejects the subscription or treats it as
+    # a regular topic filter, in which case no events are received.
+    shared_subscription_group="{{ .NetworkServer.Gateway.Backend.MQTT.SharedSubscriptionGroup }}"
+
+    # Verify gateway ID.
+    #
+    # When enabled, events of which the gateway ID in the payload does not
+    # match the gateway ID in the topic (the first '+' wildcard of the
+    # event_topic) are rejected. Use this when the gateways authenticate at
+    # the MQTT broker using a client-certificate and the broker only allows
+    # a gateway to publish to the topics containing the CN of its
+    # certificate (e.g. Mosquitto use_identity_as_username and an ACL
+    # "pattern write gateway/%u/event/+"). The gateway ID of the topic is
+    # then the authenticated identity of the gateway.
+    verify_gateway_id={{ .NetworkServer.Gateway.Backend.MQTT.VerifyGatewayID }}
+
     # MQTT server (e.g. scheme://host:port where scheme is tcp, ssl or ws)
     server="{{ .NetworkServer.Gateway.Backend.MQTT.Server }}"
 
@@ -457,6 +1020,33 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     # TLS key file (optional)
     tls_key="{{ .NetworkServer.Gateway.Backend.MQTT.TLSKey }}"
 
+    # Max reconnect interval.
+    #
+    # When the connection to the MQTT broker is lost (or the initial connect
+    # fails), LoRa Server re-connects using an exponential backoff, starting
+    # at 1 second and doubling after each failed attempt up to this interval.
+    max_reconnect_interval="{{ .NetworkServer.Gateway.Backend.MQTT.MaxReconnectInterval }}"
+
+    # Outbound buffer.
+    #
+    # Commands (e.g. downlinks) which could not be published because the
+    # MQTT broker is (temporarily) unavailable are buffered and re-published
+    # using an exponential backoff, so that e.g. Class-B and Class-C downlinks
+    # survive short broker outages. Downlinks of which the time of
+    # transmission has passed (e.g. a missed Class-A receive window) are
+    # dropped.
+    [network_server.gateway.backend.mqtt.outbound_buffer]
+    # Max number of buffered commands.
+    #
+    # When the buffer is full, publishing a command fails. 0 disables the
+    # buffer.
+    size={{ .NetworkServer.Gateway.Backend.MQTT.OutboundBuffer.Size }}
+
+    # Max duration for which a command is buffered.
+    #
+    # Commands which could not be published within this duration are dropped.
+    ttl="{{ .NetworkServer.Gateway.Backend.MQTT.OutboundBuffer.TTL }}"
+
 
     # Google Cloud Pub/Sub backend.
     #
@@ -503,6 +1093,276 @@ get_downlink_data_delay="{{ .NetworkServer.GetDownlinkDataDelay }}"
     commands_connection_string="{{ .NetworkServer.Gateway.Backend.AzureIoTHub.CommandsConnectionString }}"
 
 
+    # Basic Station backend.
+    #
+    # Use this backend for gateways running the Semtech Basic Station
+    # packet-forwarder. The gateways connect directly to LoRa Server (using
+    # the LNS protocol over WebSocket), a LoRa Gateway Bridge is not needed.
+    #
+    # The station must be configured with the router-info URI, e.g.
+    # ws://localhost:3001/router-info (or wss:// when TLS is configured).
+    # The router configuration (region and channel-plan) is derived from the
+    # [network_server.band] and [network_server.network_settings] settings.
+    [network_server.gateway.backend.basic_station]
+    # ip:port to bind the WebSocket server to.
+    bind="{{ .NetworkServer.Gateway.Backend.BasicStation.Bind }}"
+
+    # TLS certificate and key files (optional).
+    #
+    # When set, the WebSocket server will use TLS (wss://).
+    tls_cert="{{ .NetworkServer.Gateway.Backend.BasicStation.TLSCert }}"
+    tls_key="{{ .NetworkServer.Gateway.Backend.BasicStation.TLSKey }}"
+
+    # CA certificate file (optional).
+    #
+    # When set (requires tls_cert and tls_key), the gateways must connect
+    # using a client-certificate signed by this CA. The common name of the
+    # client-certificate must match the gateway ID.
+    ca_cert="{{ .NetworkServer.Gateway.Backend.BasicStation.CACert }}"
+
+    # Stats interval.
+    #
+    # Basic Station does not send gateway stats. This backend generates the
+    # gateway stats of each connected gateway using this interval.
+    stats_interval="{{ .NetworkServer.Gateway.Backend.BasicStation.StatsInterval }}"
+
+    # Region (optional).
+    #
+    # The Basic Station region name (e.g. EU863). When not set, this is
+    # derived from the configured band.
+    region="{{ .NetworkServer.Gateway.Backend.BasicStation.Region }}"
+
+    # Frequency range in Hz (optional).
+    #
+    # The frequency range used by the gateways. When not set, this is derived
+    # from the configured band.
+    frequency_min={{ .NetworkServer.Gateway.Backend.BasicStation.FrequencyMin }}
+    frequency_max={{ .NetworkServer.Gateway.Backend.BasicStation.FrequencyMax }}
+
+
+    # Kafka backend.
+    #
+    # Use this backend to consume the gateway events from and to produce the
+    # gateway commands to Kafka. The events are consumed using a
+    # consumer-group, so that the uplink ingestion can be scaled
+    # horizontally over multiple LoRa Server instances sharing the same
+    # group_id. All messages use the gateway ID (HEX encoded) as key, so that
+    # the messages of a gateway are kept in order.
+    [network_server.gateway.backend.kafka]
+    # Kafka brokers (host:port).
+    brokers=[{{ range $index, $element := .NetworkServer.Gateway.Backend.Kafka.Brokers }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
+
+    # Consumer-group ID.
+    group_id="{{ .NetworkServer.Gateway.Backend.Kafka.GroupID }}"
+
+    # Event topic template.
+    #
+    # The template is rendered for each event type (up, stats and ack) to
+    # get the topics to consume. When multiple event types share the same
+    # topic, the event type must be set in the 'event' message header.
+    event_topic_template="{{ .NetworkServer.Gateway.Backend.Kafka.EventTopicTemplate }}"
+
+    # Command topic template.
+    #
+    # The template can contain the GatewayID and CommandType (down or
+    # config). The command type is also set in the 'command' message header.
+    command_topic_template="{{ .NetworkServer.Gateway.Backend.Kafka.CommandTopicTemplate }}"
+
+    # Use TLS for the broker connections.
+    tls={{ .NetworkServer.Gateway.Backend.Kafka.TLS }}
+
+    # CA certificate file (optional).
+    #
+    # Use this when the brokers use a certificate which is not signed by a
+    # trusted CA.
+    ca_cert="{{ .NetworkServer.Gateway.Backend.Kafka.CACert }}"
+
+    # TLS client certificate and key files (optional).
+    tls_cert="{{ .NetworkServer.Gateway.Backend.Kafka.TLSCert }}"
+    tls_key="{{ .NetworkServer.Gateway.Backend.Kafka.TLSKey }}"
+
+
+    # NATS backend.
+    #
+    # Use this backend to receive the gateway events from and to publish the
+    # gateway commands to NATS. The events are received using a queue group,
+    # so that the uplink ingestion can be scaled horizontally over multiple
+    # LoRa Server instances sharing the same queue_group. The event type is
+    # the last token of the event subject.
+    [network_server.gateway.backend.nats]
+    # NATS server URL.
+    server="{{ .NetworkServer.Gateway.Backend.NATS.Server }}"
+
+    # Connect with the given username (optional).
+    username="{{ .NetworkServer.Gateway.Backend.NATS.Username }}"
+
+    # Connect with the given password (optional).
+    password="{{ .NetworkServer.Gateway.Backend.NATS.Password }}"
+
+    # CA certificate file (optional).
+    #
+    # Use this when the server uses a certificate which is not signed by a
+    # trusted CA.
+    ca_cert="{{ .NetworkServer.Gateway.Backend.NATS.CACert }}"
+
+    # TLS client certificate and key files (optional).
+    tls_cert="{{ .NetworkServer.Gateway.Backend.NATS.TLSCert }}"
+    tls_key="{{ .NetworkServer.Gateway.Backend.NATS.TLSKey }}"
+
+    # Event subject.
+    #
+    # The subject (including wildcards) to receive the gateway events from.
+    event_subject="{{ .NetworkServer.Gateway.Backend.NATS.EventSubject }}"
+
+    # Command subject template.
+    #
+    # The template can contain the GatewayID and CommandType (down or
+    # config).
+    command_subject_template="{{ .NetworkServer.Gateway.Backend.NATS.CommandSubjectTemplate }}"
+
+    # Queue group.
+    #
+    # This is used when JetStream is disabled.
+    queue_group="{{ .NetworkServer.Gateway.Backend.NATS.QueueGroup }}"
+
+    # Use JetStream.
+    #
+    # When enabled, the gateway events and commands are persisted in
+    # JetStream streams. The events are consumed using a durable consumer
+    # and are acknowledged once handled, so that events are not lost when
+    # LoRa Server is restarted. The streams are created when they do not
+    # yet exist.
+    jetstream={{ .NetworkServer.Gateway.Backend.NATS.JetStream }}
+
+    # Event stream name.
+    event_stream="{{ .NetworkServer.Gateway.Backend.NATS.EventStream }}"
+
+    # Command stream name.
+    #
+    # The subjects of this stream are derived from the command subject
+    # template.
+    command_stream="{{ .NetworkServer.Gateway.Backend.NATS.CommandStream }}"
+
+    # Max. age of the messages in the created streams.
+    stream_max_age="{{ .NetworkServer.Gateway.Backend.NATS.StreamMaxAge }}"
+
+    # Durable consumer name.
+    #
+    # LoRa Server instances sharing the same durable name share the events
+    # of the event stream.
+    durable_name="{{ .NetworkServer.Gateway.Backend.NATS.DurableName }}"
+
+    # Ack wait.
+    #
+    # Events which have not been acknowledged within this duration are
+    # re-delivered.
+    ack_wait="{{ .NetworkServer.Gateway.Backend.NATS.AckWait }}"
+
+
+    # Semtech UDP packet-forwarder backend.
+    #
+    # Use this backend to connect gateways running the Semtech UDP
+    # packet-forwarder directly to LoRa Server, without a LoRa Gateway
+    # Bridge. The packet-forwarder must be configured with the address of
+    # LoRa Server as server_address and the bind port as serv_port_up and
+    # serv_port_down. As the channel configuration can not be sent over
+    # this protocol, it must be set in the packet-forwarder configuration.
+    [network_server.gateway.backend.semtech_udp]
+    # ip:port to bind the UDP listener to.
+    bind="{{ .NetworkServer.Gateway.Backend.SemtechUDP.Bind }}"
+
+    # Gateway timeout.
+    #
+    # A gateway is considered disconnected (and downlinks can no longer be
+    # sent to it) when no PULL_DATA packet has been received within this
+    # duration.
+    gateway_timeout="{{ .NetworkServer.Gateway.Backend.SemtechUDP.GatewayTimeout }}"
+
+
+    # ChirpStack Concentratord backend.
+    #
+    # Use this backend when LoRa Server runs on the gateway itself. It
+    # communicates directly with the ChirpStack Concentratord over ZeroMQ,
+    # without a LoRa Gateway Bridge. The gateway ID is retrieved from the
+    # Concentratord on start. The URLs must match the api.event_bind and
+    # api.command_bind settings of the Concentratord.
+    [network_server.gateway.backend.concentratord]
+    # Event socket URL.
+    event_url="{{ .NetworkServer.Gateway.Backend.Concentratord.EventURL }}"
+
+    # Command socket URL.
+    command_url="{{ .NetworkServer.Gateway.Backend.Concentratord.CommandURL }}"
+
+    # Command timeout.
+    #
+    # This defines the max. duration to wait for the reply of the
+    # Concentratord on a command (e.g. a downlink frame).
+    command_timeout="{{ .NetworkServer.Gateway.Backend.Concentratord.CommandTimeout }}"
+
+  # Gateway contribution metrics.
+  #
+  # When enabled, LoRa Server counts per gateway and per aggregation interval
+  # (see the metrics.redis settings) the unique frames for which the gateway
+  # was the first or the only receiver, the (de-duplicated) frames received
+  # by the gateway and the downlinks transmitted by the gateway. These
+  # metrics are exposed through the GetGatewayContributions API method and
+  # the StreamGatewayContributions event feed.
+  [network_server.gateway.contribution]
+  enabled={{ .NetworkServer.Gateway.Contribution.Enabled }}
+
+
+  # Gateway stats aggregation.
+  #
+  # When enabled, the gateway stats (including the RX / TX packet counts per
+  # frequency and the TX packet counts per acknowledgement status) are
+  # aggregated per gateway for each of the configured intervals and stored
+  # in PostgreSQL. The aggregates are exposed by the GetGatewayStatsAggregates
+  # API method. The intervals are aligned to the Unix epoch (UTC).
+  #
+  # Aggregates older than the retention of their interval are removed by the
+  # gateway_stats_retention janitor task (retention 0 = keep forever).
+  [network_server.gateway.stats_aggregation]
+  enabled={{ .NetworkServer.Gateway.StatsAggregation.Enabled }}
+
+  # Flush interval.
+  #
+  # The aggregated stats are buffered in memory and added to the stored
+  # aggregates at this interval.
+  flush_interval="{{ .NetworkServer.Gateway.StatsAggregation.FlushInterval }}"
+{{ range $index, $element := .NetworkServer.Gateway.StatsAggregation.Intervals }}
+  [[network_server.gateway.stats_aggregation.intervals]]
+  interval="{{ $element.Interval }}"
+  retention="{{ $element.Retention }}"
+{{ end }}
+
+  # Gateway scheduling constraints.
+  #
+  # The gateway model, firmware and bridge / concentrator versions are parsed
+  # from the gateway stats meta-data and are exposed by the
+  # GetGatewayInventory and ListGatewayInventory API methods. Scheduling
+  # constraints can be configured per gateway model (matched against the
+  # model of the inventory):
+  #  * no_gps_timing: the gateway is not used for GPS time-synchronized
+  #    (Class-B) downlinks
+  #  * max_downlink_queue_depth: the gateway is not used for new downlinks
+  #    while the number of downlinks awaiting a TX acknowledgement is equal
+  #    to or exceeds this value (0 = no limit)
+  #
+  # Example:
+  # [[network_server.gateway.constraints]]
+  # model="example-model-x"
+  # no_gps_timing=true
+  #
+  # [[network_server.gateway.constraints]]
+  # model="example-model-y"
+  # max_downlink_queue_depth=2
+{{ range $index, $element := .NetworkServer.Gateway.Constraints }}
+  [[network_server.gateway.constraints]]
+  model="{{ $element.Model }}"
+  no_gps_timing={{ $element.NoGPSTiming }}
+  max_downlink_queue_depth={{ $element.MaxDownlinkQueueDepth }}
+{{ end }}
+
   # Geolocation settings.
   #
   # When set, LoRa Server will use the configured geolocation server to
@@ -568,6 +1428,136 @@ timezone="{{ .Metrics.Timezone }}"
   # See also: https://github.com/grpc-ecosystem/go-grpc-prometheus#histograms
   api_timing_histogram={{ .Metrics.Prometheus.APITimingHistogram }}
 
+  # Enable TLS for the metrics endpoint.
+  #
+  # When set, the metrics endpoint is served over HTTPS using the certificate
+  # obtained by the [certificates] provider (which must be configured).
+  tls={{ .Metrics.Prometheus.TLS }}
+
+
+# Automatic TLS certificates.
+#
+# When a provider is configured, the TLS certificate of the network-server
+# API (and the metrics endpoint when enabled) is obtained and renewed
+# automatically. Renewed certificates are used for new connections without
+# a restart. In this case the network_server.api tls_cert and tls_key
+# settings are ignored, the ca_cert setting is still used for verifying the
+# client certificates.
+[certificates]
+# Certificate provider.
+#
+# Valid options are:
+#  * ""    (no automatic certificates, use the configured certificate files)
+#  * acme  (ACME, e.g. Let's Encrypt)
+#  * ca    (internal CA signing certificate-signing requests)
+provider="{{ .Certificates.Provider }}"
+
+# Hostnames.
+#
+# The hostnames (or ip-addresses, internal CA only) for which to obtain the
+# certificate. The first hostname is used when the client does not send the
+# server-name.
+hostnames=[{{ if .Certificates.Hostnames|len }}"{{ end }}{{ range $index, $elm := .Certificates.Hostnames }}{{ if $index }}", "{{ end }}{{ $elm }}{{ end }}{{ if .Certificates.Hostnames|len }}"{{ end }}]
+
+# Storage directory.
+#
+# The obtained certificates and keys are stored in this directory, so that
+# these are re-used after a restart.
+storage_dir="{{ .Certificates.StorageDir }}"
+
+# Renew before.
+#
+# The certificate is renewed when it expires within the given duration.
+renew_before="{{ .Certificates.RenewBefore }}"
+
+  # ACME provider.
+  [certificates.acme]
+  # ACME directory URL.
+  directory_url="{{ .Certificates.ACME.DirectoryURL }}"
+
+  # Contact e-mail address (optional).
+  email="{{ .Certificates.ACME.Email }}"
+
+  # HTTP-01 challenge server bind (optional).
+  #
+  # The ip:port to bind the HTTP-01 challenge server to (the ACME server
+  # connects to port 80). When not set, only the TLS-ALPN-01 challenge is
+  # supported, which requires the API to be reachable on port 443.
+  http_bind="{{ .Certificates.ACME.HTTPBind }}"
+
+
+  # Internal CA provider.
+  #
+  # The PEM encoded certificate-signing request is posted to the given URL
+  # (Content-Type: application/pkcs10). The response must contain the PEM
+  # encoded certificate (chain).
+  [certificates.ca]
+  # CSR signing endpoint URL.
+  url="{{ .Certificates.CA.URL }}"
+
+  # CA certificate used to verify the signing endpoint certificate (optional).
+  ca_cert="{{ .Certificates.CA.CACert }}"
+
+  # Bearer token used for authenticating with the signing endpoint (optional).
+  token="{{ .Certificates.CA.Token }}"
+
+
+# Application-server client settings.
+#
+# The connections to the application-servers (as configured by the
+# routing-profiles) are pooled and shared by the routing-profiles using the
+# same application-server and certificates.
+[application_server]
+# Connections per server.
+#
+# The number of connections opened to each application-server. The requests
+# are distributed over the healthy connections.
+connections_per_server={{ .ApplicationServer.ConnectionsPerServer }}
+
+# Idle timeout.
+#
+# Connections which have not been used for this duration are closed.
+# Set this to 0 to never close connections.
+idle_timeout="{{ .ApplicationServer.IdleTimeout }}"
+
+  # Uplink batching.
+  #
+  # When enabled, the uplink data is sent to the application-server in
+  # batches (HandleUplinkDataBatch), reducing the number of requests. When
+  # the application-server does not implement this method, LoRa Server
+  # falls back to sending the uplink data one by one.
+  [application_server.batch]
+  # Enable uplink batching.
+  enabled={{ .ApplicationServer.Batch.Enabled }}
+
+  # Max. batch size.
+  #
+  # The batch is sent once it contains this number of items.
+  max_size={{ .ApplicationServer.Batch.MaxSize }}
+
+  # Batch interval.
+  #
+  # The max. duration the uplink data is held back before the batch is sent.
+  interval="{{ .ApplicationServer.Batch.Interval }}"
+
+  # Health-check.
+  #
+  # When enabled, the application-servers of the routing-profiles with
+  # failover application-servers are periodically checked using the gRPC
+  # health service. An application-server failing the health-check is
+  # skipped until it passes the health-check again, after which LoRa Server
+  # fails back to it. Application-servers which do not implement the gRPC
+  # health service are considered healthy when they can be reached.
+  [application_server.health_check]
+  # Enable the health-check.
+  enabled={{ .ApplicationServer.HealthCheck.Enabled }}
+
+  # Health-check interval.
+  interval="{{ .ApplicationServer.HealthCheck.Interval }}"
+
+  # Health-check timeout.
+  timeout="{{ .ApplicationServer.HealthCheck.Timeout }}"
+
 
 # Join-server settings.
 [join_server]
@@ -584,6 +1574,11 @@ resolve_join_eui={{ .JoinServer.ResolveJoinEUI }}
 # This configures the domain suffix used for resolving the join-server.
 resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
 
+# Resolve cache TTL.
+#
+# This defines how long a resolved join-server is cached.
+resolve_cache_ttl="{{ .JoinServer.ResolveCacheTTL }}"
+
 
   # Join-server certificates.
   #
@@ -637,6 +1632,21 @@ resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
   tls_key="{{ .JoinServer.Default.TLSKey }}"
 
 
+  # Join-server KEK (Key Encryption Key) settings.
+  [join_server.kek]
+  # Require wrapped network session-keys.
+  #
+  # When set, a (re)join-accept is rejected when the network session-keys
+  # received from the join-server are not wrapped with a KEK.
+  require_wrapped_keys={{ .JoinServer.KEK.RequireWrappedKeys }}
+
+  # Store wrapped network session-keys.
+  #
+  # When set, the wrapped network session-keys received from the join-server
+  # are stored as key-envelope and are only unwrapped when the device-session
+  # is used (e.g. to handle an uplink). Each unwrap operation is logged.
+  store_wrapped_keys={{ .JoinServer.KEK.StoreWrappedKeys }}
+
   # Join-server KEK set.
   #
   # These KEKs (Key Encryption Keys) are used to decrypt the network related
@@ -657,6 +1667,34 @@ resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
   kek="{{ $element.KEK }}"
   {{ end }}
 
+  # Join-server specific KEK sets.
+  #
+  # For devices of which the JoinEUI is within the JoinEUI range of a
+  # join-server, the KEK set of this join-server is used instead of the
+  # KEK set above.
+  #
+  # Example (the [[join_server.kek.join_servers]] can be repeated):
+  # [[join_server.kek.join_servers]]
+  # # First JoinEUI of the range.
+  # join_eui_from="0102030405060700"
+
+  # # Last JoinEUI of the range.
+  # join_eui_to="01020304050607ff"
+
+  #   [[join_server.kek.join_servers.set]]
+  #   label="000000"
+  #   kek="01020304050607080102030405060708"
+  {{ range $index, $element := .JoinServer.KEK.JoinServers }}
+  [[join_server.kek.join_servers]]
+  join_eui_from="{{ $element.JoinEUIFrom }}"
+  join_eui_to="{{ $element.JoinEUITo }}"
+  {{ range $i, $k := $element.Set }}
+    [[join_server.kek.join_servers.set]]
+    label="{{ $k.Label }}"
+    kek="{{ $k.KEK }}"
+  {{ end }}
+  {{ end }}
+
   # Network-controller configuration.
   [network_controller]
   # hostname:port of the network-controller api server (optional)
@@ -670,6 +1708,487 @@ resolve_domain_suffix="{{ .JoinServer.ResolveDomainSuffix }}"
 
   # tls key used by the network-controller client (optional)
   tls_key="{{ .NetworkController.TLSKey }}"
+
+  # Downlink mac-command interception.
+  #
+  # When enabled, LoRa Server opens a bi-directional stream to the
+  # network-controller and sends the mac-commands it is about to send
+  # before each downlink. The network-controller responds with the
+  # mac-commands to send, making it possible to veto or modify mac-commands
+  # or to inject its own mac-commands.
+  [network_controller.mac_command_interception]
+  # Enable mac-command interception.
+  enabled={{ .NetworkController.MACCommandInterception.Enabled }}
+
+  # Max. time to wait for the network-controller response.
+  #
+  # As this is part of the downlink path, this value must be kept well
+  # below the RX1 delay.
+  timeout="{{ .NetworkController.MACCommandInterception.Timeout }}"
+
+  # Policy in case of a timeout or stream error.
+  #
+  # Valid options are:
+  #  * open:   send the mac-commands as proposed by LoRa Server
+  #  * closed: do not send any mac-commands
+  fail_policy="{{ .NetworkController.MACCommandInterception.FailPolicy }}"
+
+
+# Roaming settings.
+#
+# The roaming agreements with partner networks are managed using the
+# network-server API.
+[roaming]
+# Resolve NetID.
+#
+# When set to true, LoRa Server will use the NetID to resolve the
+# Backend Interfaces endpoint of the partner network using DNS. LoRa Server
+# will fallback on the server of the roaming agreement when resolving the
+# NetID fails.
+resolve_net_id={{ .Roaming.ResolveNetID }}
+
+# Resolve NetID domain suffix.
+#
+# This configures the domain suffix used for resolving the NetID.
+resolve_net_id_domain_suffix="{{ .Roaming.ResolveNetIDDomainSuffix }}"
+
+# Resolve cache TTL.
+#
+# This defines how long a resolved NetID is cached.
+resolve_cache_ttl="{{ .Roaming.ResolveCacheTTL }}"
+
+# CA certificate (optional).
+#
+# When set, this CA certificate will be used to validate the server
+# certificate of the Backend Interfaces endpoint of the partner networks.
+ca_cert="{{ .Roaming.CACert }}"
+
+# TLS certificate (optional).
+#
+# When set, this certificate and key will be used as client-certificate
+# for authenticating against the partner networks.
+tls_cert="{{ .Roaming.TLSCert }}"
+
+# TLS key (optional).
+tls_key="{{ .Roaming.TLSKey }}"
+
+
+# Provisioning synchronization.
+#
+# When a server is configured, LoRa Server periodically retrieves the
+# routing-, service-, device- and gateway-profiles and devices from this
+# (HTTP) provisioning API and creates or updates these in its database.
+# This makes it possible to run LoRa Server without an application-server
+# managing these objects. Objects are never deleted by the synchronization.
+[provisioning_sync]
+# Provisioning API URL (optional).
+#
+# The response must be a JSON object with the routingProfiles, serviceProfiles,
+# deviceProfiles, gatewayProfiles and devices keys. Each item must be encoded
+# using the JSON mapping of the corresponding network-server API message.
+server="{{ .ProvisioningSync.Server }}"
+
+# Bearer token (optional).
+token="{{ .ProvisioningSync.Token }}"
+
+# Synchronization interval.
+interval="{{ .ProvisioningSync.Interval }}"
+
+
+# Janitor (background maintenance) tasks.
+#
+# Each task has the following settings:
+#   enabled:  run the task
+#   interval: interval between two runs
+#   jitter:   max. random duration added to each interval, so that
+#             multiple instances do not run the task at the same time
+#
+# When leader_election is enabled, the tasks only run on the leader instance.
+# The result of the last run can be inspected using the 'loraserver janitor
+# list' command, a task can be triggered using 'loraserver janitor run <task>'.
+[janitor]
+  # Removes the DevEUIs from the DevAddr lookup sets, of which the
+  # device-session does not exist anymore or uses a different DevAddr.
+  [janitor.device_session_gc]
+  enabled={{ .Janitor.DeviceSessionGC.Enabled }}
+  interval="{{ .Janitor.DeviceSessionGC.Interval }}"
+  jitter="{{ .Janitor.DeviceSessionGC.Jitter }}"
+
+  # Removes the uplink de-duplication keys without expiration.
+  [janitor.deduplication_sweep]
+  enabled={{ .Janitor.DeduplicationSweep.Enabled }}
+  interval="{{ .Janitor.DeduplicationSweep.Interval }}"
+  jitter="{{ .Janitor.DeduplicationSweep.Jitter }}"
+
+  # Removes the pending device-queue items of which the timeout expired
+  # before the device-session TTL (the device can not acknowledge these
+  # anymore) and notifies the application-server.
+  [janitor.device_queue_cleanup]
+  enabled={{ .Janitor.DeviceQueueCleanup.Enabled }}
+  interval="{{ .Janitor.DeviceQueueCleanup.Interval }}"
+  jitter="{{ .Janitor.DeviceQueueCleanup.Jitter }}"
+
+  # Removes the device-queue items of which the TTL expired and notifies the
+  # application-server (DEVICE_QUEUE_ITEM_EXPIRED error).
+  [janitor.device_queue_expiry]
+  enabled={{ .Janitor.DeviceQueueExpiry.Enabled }}
+  interval="{{ .Janitor.DeviceQueueExpiry.Interval }}"
+  jitter="{{ .Janitor.DeviceQueueExpiry.Jitter }}"
+
+  # Removes the gateway stats aggregates which are older than the retention
+  # of their aggregation interval (see
+  # network_server.gateway.stats_aggregation).
+  [janitor.gateway_stats_retention]
+  enabled={{ .Janitor.GatewayStatsRetention.Enabled }}
+  interval="{{ .Janitor.GatewayStatsRetention.Interval }}"
+  jitter="{{ .Janitor.GatewayStatsRetention.Jitter }}"
+
+  # Re-computes the gateway-set of each multicast-group from the last
+  # received uplinks of its devices, so that devices that moved are still
+  # covered. A changed gateway-set is adopted immediately when the current
+  # gateway-set leaves devices uncovered, otherwise only after it has been
+  # selected for the given number of consecutive runs (hysteresis).
+  # Changes are sent as multicast_gateway_set webhook event.
+  [janitor.multicast_gateway_set]
+  enabled={{ .Janitor.MulticastGatewaySet.Enabled }}
+  interval="{{ .Janitor.MulticastGatewaySet.Interval }}"
+  jitter="{{ .Janitor.MulticastGatewaySet.Jitter }}"
+  hysteresis={{ .Janitor.MulticastGatewaySet.Hysteresis }}
+
+  # Validates the device-sessions against the band of their region and
+  # reports the impossible states (e.g. a TXPowerIndex, data-rate or enabled
+  # uplink channel which is not defined by the band), e.g. caused by
+  # corrupted device-sessions. The number of issues is exposed as
+  # Prometheus metric.
+  #
+  # Policy:
+  #   log:    log a warning for each device-session with issues
+  #   repair: log a warning and reset the invalid values to safe defaults
+  [janitor.device_session_integrity]
+  enabled={{ .Janitor.DeviceSessionIntegrity.Enabled }}
+  interval="{{ .Janitor.DeviceSessionIntegrity.Interval }}"
+  jitter="{{ .Janitor.DeviceSessionIntegrity.Jitter }}"
+  policy="{{ .Janitor.DeviceSessionIntegrity.Policy }}"
+
+  # Samples the Redis memory usage per key class (sessions, deduplication,
+  # framelog, queues, metrics and other). For each class, the memory usage
+  # of up to samples_per_class keys is retrieved and extrapolated to the
+  # total number of keys of the class. The result is exposed as Prometheus
+  # metrics and using the GetRedisMemoryUsage API method.
+  [janitor.redis_memory_usage]
+  enabled={{ .Janitor.RedisMemoryUsage.Enabled }}
+  interval="{{ .Janitor.RedisMemoryUsage.Interval }}"
+  jitter="{{ .Janitor.RedisMemoryUsage.Jitter }}"
+  samples_per_class={{ .Janitor.RedisMemoryUsage.SamplesPerClass }}
+
+    # Memory usage caps (bytes).
+    #
+    # A warning is logged when the estimated memory usage of a key class
+    # exceeds its cap. Set to 0 to disable.
+    [janitor.redis_memory_usage.caps]
+    sessions={{ .Janitor.RedisMemoryUsage.Caps.Sessions }}
+    deduplication={{ .Janitor.RedisMemoryUsage.Caps.Deduplication }}
+    framelog={{ .Janitor.RedisMemoryUsage.Caps.FrameLog }}
+    queues={{ .Janitor.RedisMemoryUsage.Caps.Queues }}
+    metrics={{ .Janitor.RedisMemoryUsage.Caps.Metrics }}
+
+
+# Security events.
+#
+# Security related occurrences (MIC failure bursts, DevNonce replays, join
+# floods and API authentication failures) are emitted as security events.
+# The events are stored in Redis (see the GetSecurityEvents API method) and
+# forwarded to the configured sinks.
+[security]
+# Max. number of stored events.
+max_events={{ .Security.MaxEvents }}
+
+# Rate-limit interval.
+#
+# Events of the same type for the same device (or source) are emitted at
+# most once within this interval.
+rate_limit_interval="{{ .Security.RateLimitInterval }}"
+
+  # MIC failure burst.
+  #
+  # A mic_failure_burst event is emitted when for a DevAddr the given number
+  # of uplinks within the given window could not be matched to a
+  # device-session (invalid MIC or frame-counter). Set the threshold to 0 to
+  # disable.
+  [security.mic_failure_burst]
+  threshold={{ .Security.MICFailureBurst.Threshold }}
+  window="{{ .Security.MICFailureBurst.Window }}"
+
+  # Join flood.
+  #
+  # A join_flood event is emitted when for a DevEUI the given number of
+  # join-requests is received within the given window. Set the threshold to 0
+  # to disable.
+  [security.join_flood]
+  threshold={{ .Security.JoinFlood.Threshold }}
+  window="{{ .Security.JoinFlood.Window }}"
+
+  # Syslog sink.
+  #
+  # The events are written in the Common Event Format (CEF).
+  [security.syslog]
+  # Enable the syslog sink.
+  enabled={{ .Security.Syslog.Enabled }}
+
+  # Network (udp, tcp or empty for the local syslog daemon).
+  network="{{ .Security.Syslog.Network }}"
+
+  # Address (host:port, empty for the local syslog daemon).
+  address="{{ .Security.Syslog.Address }}"
+
+  # Tag.
+  tag="{{ .Security.Syslog.Tag }}"
+
+  # Webhook sink.
+  #
+  # The events are posted JSON encoded, with the event query parameter set
+  # to security.
+  [security.webhook]
+  # Webhook URL (when empty, the webhook sink is disabled).
+  url="{{ .Security.Webhook.URL }}"
+
+  # Webhook secret used for signing the request body (optional).
+  secret="{{ .Security.Webhook.Secret }}"
+
+
+# Accounting.
+#
+# When enabled, an accounting record is created for every successfully
+# processed uplink and for every downlink acknowledged by the gateway as
+# transmitted. A record contains the DevEUI, gateway IDs, airtime, data-rate,
+# payload size, frame-counter and frequency. Records are first stored in the
+# accounting_record table.
+[accounting]
+# Enable accounting.
+enabled={{ .Accounting.Enabled }}
+
+# Sink.
+#
+# Valid options are:
+#  * postgres: the records are kept in the accounting_record table
+#  * mqtt:     the records are published to a MQTT broker and removed from
+#              the accounting_record table once the publication has been
+#              acknowledged (at-least-once, use the record id to
+#              de-duplicate)
+sink="{{ .Accounting.Sink }}"
+
+  # MQTT sink.
+  [accounting.mqtt]
+  # MQTT server (e.g. scheme://host:port where scheme is tcp, ssl or ws)
+  server="{{ .Accounting.MQTT.Server }}"
+
+  # Connect with the given username (optional)
+  username="{{ .Accounting.MQTT.Username }}"
+
+  # Connect with the given password (optional)
+  password="{{ .Accounting.MQTT.Password }}"
+
+  # Client ID (optional)
+  client_id="{{ .Accounting.MQTT.ClientID }}"
+
+  # Quality of service level (1 or 2)
+  qos={{ .Accounting.MQTT.QOS }}
+
+  # Topic template.
+  #
+  # Available fields are .Direction (uplink or downlink) and .DevEUI.
+  topic_template="{{ .Accounting.MQTT.TopicTemplate }}"
+
+  # CA certificate file (optional)
+  ca_cert="{{ .Accounting.MQTT.CACert }}"
+
+  # TLS certificate file (optional)
+  tls_cert="{{ .Accounting.MQTT.TLSCert }}"
+
+  # TLS key file (optional)
+  tls_key="{{ .Accounting.MQTT.TLSKey }}"
+
+  # Forward interval.
+  #
+  # The interval in which the stored records are published.
+  forward_interval="{{ .Accounting.MQTT.ForwardInterval }}"
+
+  # Max. number of records published per batch.
+  batch_size={{ .Accounting.MQTT.BatchSize }}
+
+
+# Simulator settings.
+#
+# These settings are used by the simulate command, which runs an end-to-end
+# simulation of virtual (LoRaWAN 1.0.x) devices and gateways against a
+# running LoRa Server instance, using the MQTT gateway backend configured
+# above. The virtual gateways and devices are created when they do not yet
+# exist. For OTAA devices, the join-server must know the devices and the
+# app_key.
+[simulator]
+# Service-profile ID of the virtual devices.
+service_profile_id="{{ .Simulator.ServiceProfileID }}"
+
+# Device-profile ID of the virtual devices (must be a 1.0.x profile).
+device_profile_id="{{ .Simulator.DeviceProfileID }}"
+
+# Routing-profile ID of the virtual devices and gateways.
+routing_profile_id="{{ .Simulator.RoutingProfileID }}"
+
+# Random seed.
+#
+# Using the same seed results in the same sequence of channels, data-rates,
+# payloads and RF meta-data.
+seed={{ .Simulator.Seed }}
+
+# Duration of the simulation.
+duration="{{ .Simulator.Duration }}"
+
+# Gateway marshaler (json or protobuf).
+marshaler="{{ .Simulator.Marshaler }}"
+
+# Number of virtual gateways.
+gateway_count={{ .Simulator.GatewayCount }}
+
+# Gateway ID of the first virtual gateway, the following gateways are
+# numbered sequentially.
+gateway_id_base="{{ .Simulator.GatewayIDBase }}"
+
+# Number of virtual OTAA devices.
+otaa_device_count={{ .Simulator.OTAADeviceCount }}
+
+# Number of virtual ABP devices.
+abp_device_count={{ .Simulator.ABPDeviceCount }}
+
+# DevEUI of the first virtual device, the following devices are numbered
+# sequentially (first the OTAA, then the ABP devices).
+dev_eui_base="{{ .Simulator.DevEUIBase }}"
+
+# JoinEUI of the OTAA devices.
+join_eui="{{ .Simulator.JoinEUI }}"
+
+# AppKey of the OTAA devices.
+app_key="{{ .Simulator.AppKey }}"
+
+# Uplink interval of each device.
+uplink_interval="{{ .Simulator.UplinkInterval }}"
+
+# FPort of the uplinks.
+f_port={{ .Simulator.FPort }}
+
+# Payload size of the uplinks.
+payload_size={{ .Simulator.PayloadSize }}
+
+# Ratio of confirmed uplinks (0.0 - 1.0).
+confirmed_ratio={{ .Simulator.ConfirmedRatio }}
+
+# RSSI range of the uplinks (each uplink is received by a random subset
+# of the gateways, each with a random RSSI and SNR within the range).
+rssi_min={{ .Simulator.RSSIMin }}
+rssi_max={{ .Simulator.RSSIMax }}
+
+# SNR range of the uplinks.
+snr_min={{ .Simulator.SNRMin }}
+snr_max={{ .Simulator.SNRMax }}
+
+# Min. ratio of OTAA devices that must have joined.
+min_join_success_ratio={{ .Simulator.MinJoinSuccessRatio }}
+
+# Min. ratio of confirmed uplinks that must have been acknowledged.
+min_downlink_delivery_ratio={{ .Simulator.MinDownlinkDeliveryRatio }}
+
+# Recovery assertion.
+#
+# When set, the confirmed uplinks sent after this duration (relative to the
+# start of the simulation) are asserted separately. Combined with a
+# fault-injection scenario of which the faults end before this duration,
+# this asserts that LoRa Server recovers from the injected faults.
+recovery_after="{{ .Simulator.RecoveryAfter }}"
+
+# Min. ratio of confirmed uplinks sent after recovery_after that must have
+# been acknowledged.
+min_recovery_downlink_delivery_ratio={{ .Simulator.MinRecoveryDownlinkDeliveryRatio }}
+
+
+# Fault-injection settings.
+#
+# Fault-injection drops, delays or corrupts calls to Redis, PostgreSQL,
+# the gateway backend, the application-server and the join-server
+# according to the rules of a scenario file. It is intended to rehearse
+# failures (e.g. a Redis failover) in combination with the simulate
+# command. It can also be enabled by compiling LoRa Server with the
+# faultinjection build tag.
+#
+# Never enable this in production!
+[fault_injection]
+# Enable fault-injection.
+enabled={{ .FaultInjection.Enabled }}
+
+# Scenario file (TOML, JSON or YAML).
+#
+# Example (TOML):
+#
+# [[rules]]
+# name="redis outage"
+# target="redis"           # redis, postgresql, gateway, application_server or join_server
+# operation="*"            # redis command, sql keyword, uplink / downlink / config or method name
+# action="drop"            # drop, delay or corrupt
+# probability=1.0          # probability (0.0 - 1.0] with which the rule applies
+# start_after="1m"         # start of the rule, relative to the start of LoRa Server
+# duration="30s"           # duration of the rule, omit for unlimited
+#
+# [[rules]]
+# name="slow application-server"
+# target="application_server"
+# operation="HandleUplinkData"
+# action="delay"
+# delay="2s"
+# probability=0.5
+scenario="{{ .FaultInjection.Scenario }}"
+
+
+# Secrets settings.
+#
+# Any (string) configuration value can refer to a secret stored in HashiCorp
+# Vault using the vault:<path>#<field> syntax, e.g.:
+#
+#   dsn="vault:secret/data/loraserver#postgresql_dsn"
+#
+# Both the KV (version 1 and 2) and the dynamic secrets engines are supported.
+# The tls_cert, tls_key and ca_cert settings accept the PEM encoded value
+# as an alternative to the file path, so that these can be stored in Vault too.
+#
+# The leases of the secrets are renewed before they expire. When a lease can
+# not be renewed, the secrets are read again and the configuration is
+# reloaded. Note that the PostgreSQL, Redis, API and KEK settings are only
+# applied on (re)start.
+[secrets.vault]
+# Vault address (e.g. https://vault:8200).
+#
+# When not set, the VAULT_ADDR environment variable is used.
+address="{{ .Secrets.Vault.Address }}"
+
+# CA certificate used to verify the Vault server certificate (optional).
+#
+# When not set, the VAULT_CACERT environment variable is used.
+ca_cert="{{ .Secrets.Vault.CACert }}"
+
+# File containing the Vault token (e.g. written by the Vault agent).
+#
+# When not set, the VAULT_TOKEN environment variable is used. Else the
+# AppRole authentication method is used (when configured).
+token_file="{{ .Secrets.Vault.TokenFile }}"
+
+  # AppRole authentication.
+  [secrets.vault.approle]
+  # Mount path of the AppRole authentication method.
+  mount_path="{{ .Secrets.Vault.AppRole.MountPath }}"
+
+  # Role ID.
+  role_id="{{ .Secrets.Vault.AppRole.RoleID }}"
+
+  # File containing the secret ID.
+  secret_id_file="{{ .Secrets.Vault.AppRole.SecretIDFile }}"
 `
 
 var configCmd = &cobra.Command{
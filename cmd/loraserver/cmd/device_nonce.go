@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// deviceNonceExportBatchSize defines the number of nonces read per query.
+const deviceNonceExportBatchSize = 1000
+
+var deviceNonceCmd = &cobra.Command{
+	Use:   "device-nonce",
+	Short: "Export and import the used (Dev)Nonces",
+	Long: `Export and import the used (Dev)Nonces.
+
+The used nonces are kept when a device is removed, so that a re-provisioned
+device can not replay its previous join-requests. Use export and import to
+move the used nonces to a new LoRa Server installation. The nonces are
+exported as one JSON object per line.`,
+}
+
+var deviceNonceExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Export the used nonces to stdout",
+	Example: `loraserver device-nonce export > nonces.json`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupDeviceNonceCmd()
+
+		w := bufio.NewWriter(os.Stdout)
+		enc := json.NewEncoder(w)
+
+		for offset := 0; ; offset += deviceNonceExportBatchSize {
+			nonces, err := storage.GetDeviceNonces(context.Background(), storage.DB(), deviceNonceExportBatchSize, offset)
+			if err != nil {
+				log.WithError(err).Fatal("get device-nonces error")
+			}
+
+			for _, n := range nonces {
+				if err := enc.Encode(n); err != nil {
+					log.WithError(err).Fatal("encode device-nonce error")
+				}
+			}
+
+			if len(nonces) < deviceNonceExportBatchSize {
+				break
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+var deviceNonceImportCmd = &cobra.Command{
+	Use:     "import <file>",
+	Short:   "Import the used nonces from the given file (- for stdin)",
+	Example: `loraserver device-nonce import nonces.json`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupDeviceNonceCmd()
+
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.WithError(err).Fatal("open file error")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var count int
+		dec := json.NewDecoder(r)
+		for {
+			var n storage.DeviceNonce
+			if err := dec.Decode(&n); err != nil {
+				if err == io.EOF {
+					break
+				}
+				log.WithError(err).Fatal("decode device-nonce error")
+			}
+
+			if err := storage.CreateDeviceNonce(context.Background(), storage.DB(), &n); err != nil {
+				log.WithError(err).WithField("dev_eui", n.DevEUI).Fatal("create device-nonce error")
+			}
+			count++
+		}
+
+		log.WithField("count", count).Info("device-nonces imported")
+	},
+}
+
+func init() {
+	deviceNonceCmd.AddCommand(deviceNonceExportCmd)
+	deviceNonceCmd.AddCommand(deviceNonceImportCmd)
+}
+
+func mustSetupDeviceNonceCmd() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// deviceSessionField defines a device-session field which can be set using
+// the device-session set command.
+type deviceSessionField struct {
+	description string
+	get         func(ds storage.DeviceSession) interface{}
+	set         func(ds *storage.DeviceSession, b loraband.Band, value string) error
+}
+
+var deviceSessionFields = map[string]deviceSessionField{
+	"f_cnt_up": {
+		description: "uplink frame-counter (next expected value)",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.FCntUp },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			return parseUint32(value, &ds.FCntUp)
+		},
+	},
+	"n_f_cnt_down": {
+		description: "network (or LoRaWAN 1.0) downlink frame-counter",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.NFCntDown },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			return parseUint32(value, &ds.NFCntDown)
+		},
+	},
+	"a_f_cnt_down": {
+		description: "application downlink frame-counter (LoRaWAN 1.1)",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.AFCntDown },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			return parseUint32(value, &ds.AFCntDown)
+		},
+	},
+	"skip_f_cnt_validation": {
+		description: "disable the frame-counter validation (true / false)",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.SkipFCntValidation },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			ds.SkipFCntValidation = v
+			return nil
+		},
+	},
+	"dr": {
+		description: "uplink data-rate",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.DR },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			dr, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			if _, err := b.GetDataRate(dr); err != nil {
+				return errors.Wrap(err, "invalid data-rate")
+			}
+			ds.DR = dr
+			return nil
+		},
+	},
+	"tx_power_index": {
+		description: "uplink tx-power index",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.TXPowerIndex },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			i, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			if _, err := b.GetTXPowerOffset(i); err != nil {
+				return errors.Wrap(err, "invalid tx-power index")
+			}
+			ds.TXPowerIndex = i
+			return nil
+		},
+	},
+	"nb_trans": {
+		description: "number of transmissions of unconfirmed uplinks (1 - 15)",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.NbTrans },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			n, err := strconv.ParseUint(value, 10, 8)
+			if err != nil {
+				return err
+			}
+			if n < 1 || n > 15 {
+				return errors.New("nb_trans must be between 1 and 15")
+			}
+			ds.NbTrans = uint8(n)
+			return nil
+		},
+	},
+	"enabled_uplink_channels": {
+		description: "comma-separated list of uplink channels enabled on the device",
+		get:         func(ds storage.DeviceSession) interface{} { return ds.EnabledUplinkChannels },
+		set: func(ds *storage.DeviceSession, b loraband.Band, value string) error {
+			var channels []int
+			for _, s := range strings.Split(value, ",") {
+				i, err := strconv.Atoi(strings.TrimSpace(s))
+				if err != nil {
+					return err
+				}
+				if _, err := b.GetUplinkChannel(i); err != nil {
+					return errors.Wrapf(err, "invalid uplink channel %d", i)
+				}
+				channels = append(channels, i)
+			}
+			if len(channels) == 0 {
+				return errors.New("at least one uplink channel must be enabled")
+			}
+			sort.Ints(channels)
+			ds.EnabledUplinkChannels = channels
+			return nil
+		},
+	},
+}
+
+var deviceSessionCmd = &cobra.Command{
+	Use:   "device-session",
+	Short: "Inspect and repair device-sessions",
+	Long: `Inspect and repair device-sessions.
+
+These commands can be used while LoRa Server is running. Updates are only
+saved when the device-session has not been modified in the meantime (e.g. by
+an uplink), else the update is retried.`,
+}
+
+var deviceSessionGetCmd = &cobra.Command{
+	Use:     "get <DevEUI>",
+	Short:   "Print the device-session and pending mac-commands as JSON",
+	Example: `loraserver device-session get 0102030405060708`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		devEUI := mustSetupDeviceSessionCmd(args[0])
+
+		ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+		if err != nil {
+			log.WithError(err).Fatal("get device-session error")
+		}
+
+		pending, err := storage.GetPendingMACCommands(context.Background(), storage.RedisPool(), devEUI)
+		if err != nil {
+			log.WithError(err).Fatal("get pending mac-commands error")
+		}
+
+		b, err := json.MarshalIndent(struct {
+			DeviceSession      storage.DeviceSession
+			PendingMACCommands []storage.MACCommandBlock
+		}{ds, pending}, "", "    ")
+		if err != nil {
+			log.WithError(err).Fatal("json marshal error")
+		}
+
+		fmt.Println(string(b))
+	},
+}
+
+var deviceSessionSetCmd = &cobra.Command{
+	Use:     "set <DevEUI> <field>=<value> [<field>=<value>...]",
+	Short:   "Update one or multiple device-session fields",
+	Example: `loraserver device-session set 0102030405060708 f_cnt_up=10 enabled_uplink_channels=0,1,2`,
+	Args:    cobra.MinimumNArgs(2),
+	Long:    "Update one or multiple device-session fields. The following fields can be set:\n\n" + deviceSessionFieldsHelp(),
+	Run: func(cmd *cobra.Command, args []string) {
+		devEUI := mustSetupDeviceSessionCmd(args[0])
+
+		values := make(map[string]string)
+		var names []string
+		for _, arg := range args[1:] {
+			kv := strings.SplitN(arg, "=", 2)
+			if len(kv) != 2 {
+				log.Fatalf("invalid argument '%s', expected <field>=<value>", arg)
+			}
+			if _, ok := deviceSessionFields[kv[0]]; !ok {
+				log.Fatalf("unknown field '%s'", kv[0])
+			}
+			values[kv[0]] = kv[1]
+			names = append(names, kv[0])
+		}
+
+		var before storage.DeviceSession
+		after, err := storage.UpdateDeviceSession(context.Background(), storage.RedisPool(), devEUI, func(ds *storage.DeviceSession) error {
+			before = *ds
+			b := band.Get(ds.Region)
+
+			for _, name := range names {
+				if err := deviceSessionFields[name].set(ds, b, values[name]); err != nil {
+					return errors.Wrapf(err, "set %s error", name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.WithError(err).Fatal("update device-session error")
+		}
+
+		for _, name := range names {
+			f := deviceSessionFields[name]
+			fmt.Printf("%s: %v -> %v\n", name, f.get(before), f.get(after))
+		}
+	},
+}
+
+var deviceSessionDeleteCmd = &cobra.Command{
+	Use:     "delete <DevEUI>",
+	Short:   "Delete the device-session (the device must re-join)",
+	Example: `loraserver device-session delete 0102030405060708`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		devEUI := mustSetupDeviceSessionCmd(args[0])
+
+		if err := storage.DeleteDeviceSession(context.Background(), storage.RedisPool(), devEUI); err != nil {
+			log.WithError(err).Fatal("delete device-session error")
+		}
+	},
+}
+
+var deviceSessionDeletePendingCmd = &cobra.Command{
+	Use:     "delete-pending <DevEUI> <CID>",
+	Short:   "Delete a pending mac-command block (e.g. when never answered)",
+	Example: `loraserver device-session delete-pending 0102030405060708 0x03`,
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		devEUI := mustSetupDeviceSessionCmd(args[0])
+
+		cid, err := strconv.ParseUint(args[1], 0, 8)
+		if err != nil {
+			log.WithError(err).Fatal("decode CID error")
+		}
+
+		if err := storage.DeletePendingMACCommand(context.Background(), storage.RedisPool(), devEUI, lorawan.CID(cid)); err != nil {
+			log.WithError(err).Fatal("delete pending mac-command error")
+		}
+	},
+}
+
+var deviceSessionExportCmd = &cobra.Command{
+	Use:   "export <DevEUI>",
+	Short: "Export the device-session, device-queue and multicast-groups as JSON",
+	Long: `Export the device-session, pending mac-commands, device-queue items and
+multicast-group memberships as JSON, so that the device can be moved to an
+other LoRa Server instance (using import) without losing queued downlinks.`,
+	Example: `loraserver device-session export 0102030405060708 > device.json`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		devEUI := mustSetupDeviceSessionCmd(args[0])
+
+		exp, err := storage.ExportDeviceSession(context.Background(), storage.DB(), storage.RedisPool(), devEUI)
+		if err != nil {
+			log.WithError(err).Fatal("export device-session error")
+		}
+
+		b, err := json.MarshalIndent(exp, "", "    ")
+		if err != nil {
+			log.WithError(err).Fatal("json marshal error")
+		}
+
+		fmt.Println(string(b))
+	},
+}
+
+var deviceSessionImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a device-session export from the given file (- for stdin)",
+	Long: `Import a device-session export from the given file (- for stdin).
+
+The device (and the multicast-groups it belongs to) must already be
+provisioned. The device-queue of the device is replaced by the exported
+device-queue items.`,
+	Example: `loraserver device-session import device.json`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupDeviceSessionStorage()
+
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.WithError(err).Fatal("open file error")
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var exp storage.DeviceSessionExport
+		if err := json.NewDecoder(r).Decode(&exp); err != nil {
+			log.WithError(err).Fatal("decode device-session export error")
+		}
+
+		err := storage.Transaction(func(tx sqlx.Ext) error {
+			return storage.ImportDeviceSession(context.Background(), tx, storage.RedisPool(), exp)
+		})
+		if err != nil {
+			log.WithError(err).WithField("dev_eui", exp.DeviceSession.DevEUI).Fatal("import device-session error")
+		}
+	},
+}
+
+func init() {
+	deviceSessionCmd.AddCommand(deviceSessionGetCmd)
+	deviceSessionCmd.AddCommand(deviceSessionSetCmd)
+	deviceSessionCmd.AddCommand(deviceSessionDeleteCmd)
+	deviceSessionCmd.AddCommand(deviceSessionDeletePendingCmd)
+	deviceSessionCmd.AddCommand(deviceSessionExportCmd)
+	deviceSessionCmd.AddCommand(deviceSessionImportCmd)
+}
+
+func mustSetupDeviceSessionCmd(devEUIStr string) lorawan.EUI64 {
+	mustSetupDeviceSessionStorage()
+
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(devEUIStr)); err != nil {
+		log.WithError(err).Fatal("decode DevEUI error")
+	}
+
+	return devEUI
+}
+
+func mustSetupDeviceSessionStorage() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := band.Setup(config.C); err != nil {
+		log.WithError(err).Fatal("setup band error")
+	}
+
+	if err := kek.Setup(config.C); err != nil {
+		log.WithError(err).Fatal("setup kek error")
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func deviceSessionFieldsHelp() string {
+	var names []string
+	for name := range deviceSessionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []string
+	for _, name := range names {
+		out = append(out, fmt.Sprintf("  %-25s %s", name, deviceSessionFields[name].description))
+	}
+	return strings.Join(out, "\n")
+}
+
+func parseUint32(value string, v *uint32) error {
+	i, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return err
+	}
+	*v = uint32(i)
+	return nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/gofrs/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/featureflag"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+var featureFlagServiceProfileID string
+
+var featureFlagCmd = &cobra.Command{
+	Use:   "feature-flag",
+	Short: "List and override feature-flags",
+	Long: `List and override feature-flags.
+
+Overrides are stored in Redis and are picked up by all running LoRa Server
+instances within the configured refresh interval.`,
+}
+
+var featureFlagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured feature-flags and Redis overrides",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupFeatureFlagCmd()
+
+		if err := featureflag.Refresh(storage.RedisPool()); err != nil {
+			log.WithError(err).Fatal("refresh feature-flag overrides error")
+		}
+		flags, overrides := featureflag.GetFlags()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSOURCE\tSERVICE-PROFILE\tPERCENTAGE")
+		for _, set := range []struct {
+			source string
+			flags  map[string]featureflag.Flag
+		}{
+			{"config", flags},
+			{"redis", overrides},
+		} {
+			var names []string
+			for name := range set.flags {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				f := set.flags[name]
+				if f.Percentage != -1 {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", name, set.source, "*", f.Percentage)
+				}
+				for id, p := range f.ServiceProfiles {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", name, set.source, id, p)
+				}
+			}
+		}
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+var featureFlagSetCmd = &cobra.Command{
+	Use:     "set <name> <percentage>",
+	Short:   "Override the percentage of a feature-flag",
+	Example: `loraserver feature-flag set example_feature 25 --service-profile-id 2fb8a1fd-2bbc-4d77-a62b-a83a8bbe1d90`,
+	Args:    cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupFeatureFlagCmd()
+
+		percentage, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.WithError(err).Fatal("decode percentage error")
+		}
+
+		if err := featureflag.SetOverride(context.Background(), storage.RedisPool(), args[0], mustGetFeatureFlagServiceProfileID(), percentage); err != nil {
+			log.WithError(err).Fatal("set feature-flag override error")
+		}
+	},
+}
+
+var featureFlagClearCmd = &cobra.Command{
+	Use:     "clear <name>",
+	Short:   "Remove the override of a feature-flag",
+	Example: `loraserver feature-flag clear example_feature`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupFeatureFlagCmd()
+
+		if err := featureflag.DeleteOverride(context.Background(), storage.RedisPool(), args[0], mustGetFeatureFlagServiceProfileID()); err != nil {
+			log.WithError(err).Fatal("delete feature-flag override error")
+		}
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{featureFlagSetCmd, featureFlagClearCmd} {
+		c.Flags().StringVar(&featureFlagServiceProfileID, "service-profile-id", "", "service-profile ID (when not set, the override applies to all service-profiles)")
+	}
+
+	featureFlagCmd.AddCommand(featureFlagListCmd)
+	featureFlagCmd.AddCommand(featureFlagSetCmd)
+	featureFlagCmd.AddCommand(featureFlagClearCmd)
+}
+
+func mustSetupFeatureFlagCmd() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+
+	// the refresh loop is not needed
+	conf := config.C
+	conf.NetworkServer.FeatureFlags.RefreshInterval = 0
+	if err := featureflag.Setup(conf); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func mustGetFeatureFlagServiceProfileID() uuid.UUID {
+	if featureFlagServiceProfileID == "" {
+		return uuid.Nil
+	}
+
+	id, err := uuid.FromString(featureFlagServiceProfileID)
+	if err != nil {
+		log.WithError(err).Fatal("decode service-profile id error")
+	}
+	return id
+}
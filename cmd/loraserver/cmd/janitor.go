@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/janitor"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+var janitorCmd = &cobra.Command{
+	Use:   "janitor",
+	Short: "Inspect and run the janitor (maintenance) tasks",
+}
+
+var janitorListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the enabled janitor tasks and the result of their last run",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupJanitorCmd()
+
+		tasks := janitor.MaintenanceTasks(config.C)
+		if config.C.ProvisioningSync.Server != "" {
+			tasks = append(tasks, janitor.Task{
+				Name:       "provisioning_sync",
+				Interval:   config.C.ProvisioningSync.Interval,
+				LeaderOnly: true,
+			})
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tINTERVAL\tJITTER\tLAST RUN\tDURATION\tITEMS\tERROR")
+		for _, t := range tasks {
+			status, err := janitor.GetStatus(context.Background(), storage.RedisPool(), t.Name)
+			if err != nil {
+				log.WithError(err).WithField("task", t.Name).Fatal("get janitor task status error")
+			}
+
+			lastRun := "never"
+			if !status.LastRun.IsZero() {
+				lastRun = status.LastRun.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", t.Name, t.Interval, t.Jitter, lastRun, status.Duration, status.Items, status.Error)
+		}
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+var janitorRunCmd = &cobra.Command{
+	Use:     "run <task>",
+	Short:   "Run a janitor task once",
+	Example: `loraserver janitor run device_session_gc`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mustSetupJanitorCmd()
+
+		for _, t := range janitor.MaintenanceTasks(config.C) {
+			if t.Name != args[0] {
+				continue
+			}
+
+			if err := janitor.Run(context.Background(), t); err != nil {
+				log.WithError(err).WithField("task", t.Name).Fatal("run janitor task error")
+			}
+			return
+		}
+
+		log.WithField("task", args[0]).Fatal(janitor.ErrUnknownTask)
+	},
+}
+
+func init() {
+	janitorCmd.AddCommand(janitorListCmd)
+	janitorCmd.AddCommand(janitorRunCmd)
+}
+
+func mustSetupJanitorCmd() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
@@ -22,6 +23,14 @@ var printDSCmd = &cobra.Command{
 			log.Fatalf("hex encoded DevEUI must be given as an argument")
 		}
 
+		if err := resolveSecrets(); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := kek.Setup(config.C); err != nil {
+			log.WithError(err).Fatal("setup kek error")
+		}
+
 		if err := storage.Setup(config.C); err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// roamingAccountingExportBatchSize defines the number of records read per
+// query.
+const roamingAccountingExportBatchSize = 1000
+
+var (
+	roamingAccountingNetID  string
+	roamingAccountingFrom   string
+	roamingAccountingTo     string
+	roamingAccountingFormat string
+)
+
+var roamingAccountingCmd = &cobra.Command{
+	Use:   "roaming-accounting",
+	Short: "Export the roaming accounting records",
+	Long: `Export the roaming accounting records.
+
+For every roamed uplink and downlink, LoRa Server stores an accounting record
+containing the NetID of the partner network, the DevAddr, the payload size,
+the RF meta-data and the timestamps. These records can be exported for the
+settlement with the partner networks.`,
+}
+
+var roamingAccountingExportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Export the roaming accounting records to stdout",
+	Example: `loraserver roaming-accounting export --net-id 000013 --from 2019-09-01T00:00:00Z --to 2019-10-01T00:00:00Z --format csv > september.csv`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := storage.RoamingAccountingRecordFilter{
+			Limit: roamingAccountingExportBatchSize,
+		}
+
+		if roamingAccountingNetID != "" {
+			var netID lorawan.NetID
+			if err := netID.UnmarshalText([]byte(roamingAccountingNetID)); err != nil {
+				log.WithError(err).Fatal("decode NetID error")
+			}
+			filter.NetID = &netID
+		}
+
+		var err error
+		if roamingAccountingFrom != "" {
+			if filter.Start, err = time.Parse(time.RFC3339, roamingAccountingFrom); err != nil {
+				log.WithError(err).Fatal("parse from timestamp error")
+			}
+		}
+		if roamingAccountingTo != "" {
+			if filter.End, err = time.Parse(time.RFC3339, roamingAccountingTo); err != nil {
+				log.WithError(err).Fatal("parse to timestamp error")
+			}
+		}
+
+		var write func(r storage.RoamingAccountingRecord) error
+		var flush func() error
+		w := bufio.NewWriter(os.Stdout)
+
+		switch roamingAccountingFormat {
+		case "json":
+			enc := json.NewEncoder(w)
+			write = func(r storage.RoamingAccountingRecord) error {
+				return enc.Encode(r)
+			}
+			flush = w.Flush
+		case "csv":
+			cw := csv.NewWriter(w)
+			if err := cw.Write(roamingAccountingCSVHeader); err != nil {
+				log.Fatal(err)
+			}
+			write = func(r storage.RoamingAccountingRecord) error {
+				return cw.Write(roamingAccountingRecordToCSV(r))
+			}
+			flush = func() error {
+				cw.Flush()
+				if err := cw.Error(); err != nil {
+					return err
+				}
+				return w.Flush()
+			}
+		default:
+			log.WithField("format", roamingAccountingFormat).Fatal("unknown format")
+		}
+
+		mustSetupRoamingAccountingCmd()
+
+		for {
+			records, err := storage.GetRoamingAccountingRecords(context.Background(), storage.DB(), filter)
+			if err != nil {
+				log.WithError(err).Fatal("get roaming accounting records error")
+			}
+
+			for _, r := range records {
+				if err := write(r); err != nil {
+					log.WithError(err).Fatal("write roaming accounting record error")
+				}
+				filter.AfterID = r.ID
+			}
+
+			if len(records) < roamingAccountingExportBatchSize {
+				break
+			}
+		}
+
+		if err := flush(); err != nil {
+			log.WithError(err).Fatal("flush error")
+		}
+	},
+}
+
+var roamingAccountingCSVHeader = []string{
+	"id",
+	"created_at",
+	"role",
+	"direction",
+	"net_id",
+	"dev_addr",
+	"gateway_ids",
+	"gateway_time",
+	"payload_size",
+	"airtime_ms",
+	"frequency",
+	"dr",
+	"rssi",
+	"snr",
+}
+
+func roamingAccountingRecordToCSV(r storage.RoamingAccountingRecord) []string {
+	var gatewayIDs []string
+	for _, id := range r.GatewayIDs {
+		gatewayIDs = append(gatewayIDs, id.String())
+	}
+
+	var gatewayTime, rssi, snr string
+	if r.GatewayTime != nil {
+		gatewayTime = r.GatewayTime.UTC().Format(time.RFC3339Nano)
+	}
+	if r.RSSI != nil {
+		rssi = strconv.Itoa(*r.RSSI)
+	}
+	if r.SNR != nil {
+		snr = strconv.FormatFloat(*r.SNR, 'f', -1, 64)
+	}
+
+	return []string{
+		strconv.FormatInt(r.ID, 10),
+		r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		string(r.Role),
+		string(r.Direction),
+		r.NetID.String(),
+		r.DevAddr.String(),
+		strings.Join(gatewayIDs, " "),
+		gatewayTime,
+		strconv.Itoa(r.PayloadSize),
+		strconv.FormatFloat(float64(r.Airtime)/float64(time.Millisecond), 'f', -1, 64),
+		strconv.Itoa(r.Frequency),
+		strconv.Itoa(r.DR),
+		rssi,
+		snr,
+	}
+}
+
+func init() {
+	roamingAccountingExportCmd.Flags().StringVar(&roamingAccountingNetID, "net-id", "", "NetID of the partner network (optional)")
+	roamingAccountingExportCmd.Flags().StringVar(&roamingAccountingFrom, "from", "", "export records created at or after this RFC3339 timestamp (optional)")
+	roamingAccountingExportCmd.Flags().StringVar(&roamingAccountingTo, "to", "", "export records created before this RFC3339 timestamp (optional)")
+	roamingAccountingExportCmd.Flags().StringVar(&roamingAccountingFormat, "format", "json", "output format (json or csv)")
+
+	roamingAccountingCmd.AddCommand(roamingAccountingExportCmd)
+}
+
+func mustSetupRoamingAccountingCmd() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -3,12 +3,17 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,6 +25,13 @@ import (
 var cfgFile string
 var version string
 
+// configSearchPaths contains the paths in which the configuration file is
+// searched when no configuration file is given.
+var configSearchPaths = []string{".", "$HOME/.config/loraserver", "/etc/loraserver"}
+
+// configFileName is the name of the configuration file in the search paths.
+const configFileName = "loraserver.toml"
+
 var bands = []string{
 	string(band.AS_923),
 	string(band.AU_915_928),
@@ -54,6 +66,8 @@ func init() {
 	viper.SetDefault("redis.url", "redis://localhost:6379")
 	viper.SetDefault("redis.max_idle", 10)
 	viper.SetDefault("redis.idle_timeout", 5*time.Minute)
+	viper.SetDefault("redis.local_cache.size", 10000)
+	viper.SetDefault("redis.local_cache.ttl", time.Minute)
 
 	viper.SetDefault("postgresql.dsn", "postgres://localhost/loraserver_ns?sslmode=disable")
 	viper.SetDefault("postgresql.automigrate", true)
@@ -65,13 +79,25 @@ func init() {
 
 	viper.SetDefault("network_server.deduplication_delay", 200*time.Millisecond)
 	viper.SetDefault("network_server.get_downlink_data_delay", 100*time.Millisecond)
+	viper.SetDefault("network_server.latency_budget.rx1_delay_fraction", 0.5)
 	viper.SetDefault("network_server.device_session_ttl", time.Hour*24*31)
 
 	viper.SetDefault("network_server.gateway.stats.aggregation_intervals", []string{"minute", "hour", "day"})
 	viper.SetDefault("network_server.gateway.stats.create_gateway_on_stats", true)
 	viper.SetDefault("network_server.gateway.backend.mqtt.server", "tcp://localhost:1883")
 
+	viper.SetDefault("application_server.connections_per_server", 1)
+	viper.SetDefault("application_server.idle_timeout", 10*time.Minute)
+	viper.SetDefault("application_server.batch.max_size", 100)
+	viper.SetDefault("application_server.batch.interval", 10*time.Millisecond)
+	viper.SetDefault("application_server.health_check.enabled", true)
+	viper.SetDefault("application_server.health_check.interval", 10*time.Second)
+	viper.SetDefault("application_server.health_check.timeout", time.Second)
+
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
+	viper.SetDefault("join_server.resolve_cache_ttl", time.Hour)
+	viper.SetDefault("roaming.resolve_net_id_domain_suffix", ".netids.lorawan.net")
+	viper.SetDefault("roaming.resolve_cache_ttl", time.Hour)
 
 	viper.SetDefault("network_server.network_settings.installation_margin", 10)
 	viper.SetDefault("network_server.network_settings.rx1_delay", 1)
@@ -79,18 +105,103 @@ func init() {
 	viper.SetDefault("network_server.network_settings.rx2_dr", -1)
 	viper.SetDefault("network_server.network_settings.downlink_tx_power", -1)
 	viper.SetDefault("network_server.network_settings.disable_adr", false)
+	viper.SetDefault("network_server.network_settings.fcnt_reset_threshold", 16)
 
 	viper.SetDefault("network_server.gateway.backend.type", "mqtt")
+	viper.SetDefault("network_server.gateway.meta_data_update", "sync")
 
 	viper.SetDefault("network_server.scheduler.scheduler_interval", 1*time.Second)
 	viper.SetDefault("network_server.scheduler.class_c.downlink_lock_duration", 2*time.Second)
+	viper.SetDefault("network_server.scheduler.multicast.tx_retries", 2)
+	viper.SetDefault("network_server.scheduler.multicast.tx_status_ttl", 24*time.Hour)
+	viper.SetDefault("network_server.leader_election.lock_ttl", 10*time.Second)
+	viper.SetDefault("network_server.leader_election.renew_interval", 3*time.Second)
+	viper.SetDefault("network_server.partitioning.count", 16)
+	viper.SetDefault("network_server.partitioning.claim_ttl", 10*time.Second)
+	viper.SetDefault("network_server.partitioning.renew_interval", 3*time.Second)
+	viper.SetDefault("network_server.feature_flags.refresh_interval", 10*time.Second)
 	viper.SetDefault("network_server.gateway.backend.mqtt.event_topic", "gateway/+/event/+")
+	viper.SetDefault("network_server.gateway.backend.mqtt.state_topic", "gateway/+/state/conn")
 	viper.SetDefault("network_server.gateway.backend.mqtt.command_topic_template", "gateway/{{ .GatewayID }}/command/{{ .CommandType }}")
 	viper.SetDefault("network_server.gateway.backend.mqtt.clean_session", true)
+	viper.SetDefault("network_server.gateway.backend.mqtt.max_reconnect_interval", time.Minute)
+	viper.SetDefault("network_server.gateway.backend.mqtt.outbound_buffer.size", 1000)
+	viper.SetDefault("network_server.gateway.backend.mqtt.outbound_buffer.ttl", 30*time.Second)
 	viper.SetDefault("join_server.resolve_domain_suffix", ".joineuis.lora-alliance.org")
 	viper.SetDefault("join_server.default.server", "http://localhost:8003")
 
 	viper.SetDefault("network_server.gateway.backend.gcp_pub_sub.uplink_retention_duration", time.Hour*24)
+	viper.SetDefault("network_server.gateway.backend.basic_station.bind", "0.0.0.0:3001")
+	viper.SetDefault("network_server.gateway.backend.basic_station.stats_interval", 30*time.Second)
+	viper.SetDefault("network_server.gateway.backend.kafka.brokers", []string{"localhost:9092"})
+	viper.SetDefault("network_server.gateway.backend.kafka.group_id", "loraserver")
+	viper.SetDefault("network_server.gateway.backend.kafka.event_topic_template", "gateway.event.{{ .EventType }}")
+	viper.SetDefault("network_server.gateway.backend.kafka.command_topic_template", "gateway.command.{{ .CommandType }}")
+	viper.SetDefault("network_server.gateway.backend.nats.server", "nats://localhost:4222")
+	viper.SetDefault("network_server.gateway.backend.nats.event_subject", "gateway.*.event.*")
+	viper.SetDefault("network_server.gateway.backend.nats.command_subject_template", "gateway.{{ .GatewayID }}.command.{{ .CommandType }}")
+	viper.SetDefault("network_server.gateway.backend.nats.queue_group", "loraserver")
+	viper.SetDefault("network_server.gateway.backend.nats.event_stream", "GATEWAY_EVENTS")
+	viper.SetDefault("network_server.gateway.backend.nats.command_stream", "GATEWAY_COMMANDS")
+	viper.SetDefault("network_server.gateway.backend.nats.stream_max_age", 24*time.Hour)
+	viper.SetDefault("network_server.gateway.backend.nats.durable_name", "loraserver")
+	viper.SetDefault("network_server.gateway.backend.nats.ack_wait", 30*time.Second)
+	viper.SetDefault("network_server.gateway.backend.semtech_udp.bind", "0.0.0.0:1700")
+	viper.SetDefault("network_server.gateway.backend.semtech_udp.gateway_timeout", time.Minute)
+	viper.SetDefault("network_server.gateway.backend.concentratord.event_url", "ipc:///tmp/concentratord_event")
+	viper.SetDefault("network_server.gateway.backend.concentratord.command_url", "ipc:///tmp/concentratord_command")
+	viper.SetDefault("network_server.gateway.backend.concentratord.command_timeout", time.Second)
+	viper.SetDefault("network_server.gateway.stats_aggregation.flush_interval", 10*time.Second)
+	viper.SetDefault("network_server.gateway.stats_aggregation.intervals", []map[string]interface{}{
+		{"interval": time.Minute, "retention": 24 * time.Hour},
+		{"interval": 5 * time.Minute, "retention": 7 * 24 * time.Hour},
+		{"interval": time.Hour, "retention": 90 * 24 * time.Hour},
+		{"interval": 24 * time.Hour, "retention": 2 * 365 * 24 * time.Hour},
+	})
+
+	viper.SetDefault("network_server.handover.ready_timeout", time.Minute)
+
+	viper.SetDefault("network_server.webhook.timeout", 5*time.Second)
+	viper.SetDefault("network_server.webhook.max_retries", 3)
+	viper.SetDefault("network_server.webhook.retry_interval", time.Second)
+	viper.SetDefault("network_server.downlink_hook.timeout", 200*time.Millisecond)
+	viper.SetDefault("network_server.downlink_hook.fail_policy", "closed")
+	viper.SetDefault("network_server.frame_log.capture.max_duration", time.Hour)
+	viper.SetDefault("network_server.frame_log.capture.max_frames", 10000)
+	viper.SetDefault("network_server.frame_log.capture.ttl", 24*time.Hour)
+
+	viper.SetDefault("network_controller.mac_command_interception.timeout", 100*time.Millisecond)
+	viper.SetDefault("network_controller.mac_command_interception.fail_policy", "open")
+
+	viper.SetDefault("provisioning_sync.interval", 5*time.Minute)
+
+	viper.SetDefault("janitor.device_session_gc.enabled", true)
+	viper.SetDefault("janitor.device_session_gc.interval", time.Hour)
+	viper.SetDefault("janitor.device_session_gc.jitter", 5*time.Minute)
+	viper.SetDefault("janitor.deduplication_sweep.enabled", true)
+	viper.SetDefault("janitor.deduplication_sweep.interval", 10*time.Minute)
+	viper.SetDefault("janitor.deduplication_sweep.jitter", time.Minute)
+	viper.SetDefault("janitor.device_queue_cleanup.enabled", true)
+	viper.SetDefault("janitor.device_queue_cleanup.interval", time.Minute)
+	viper.SetDefault("janitor.device_queue_cleanup.jitter", 10*time.Second)
+	viper.SetDefault("janitor.device_queue_expiry.enabled", true)
+	viper.SetDefault("janitor.device_queue_expiry.interval", time.Minute)
+	viper.SetDefault("janitor.device_queue_expiry.jitter", 10*time.Second)
+	viper.SetDefault("janitor.multicast_gateway_set.enabled", true)
+	viper.SetDefault("janitor.multicast_gateway_set.interval", 15*time.Minute)
+	viper.SetDefault("janitor.multicast_gateway_set.jitter", time.Minute)
+	viper.SetDefault("janitor.multicast_gateway_set.hysteresis", 3)
+	viper.SetDefault("janitor.device_session_integrity.enabled", true)
+	viper.SetDefault("janitor.device_session_integrity.interval", 6*time.Hour)
+	viper.SetDefault("janitor.device_session_integrity.jitter", 10*time.Minute)
+	viper.SetDefault("janitor.device_session_integrity.policy", "log")
+	viper.SetDefault("janitor.gateway_stats_retention.enabled", true)
+	viper.SetDefault("janitor.gateway_stats_retention.interval", time.Hour)
+	viper.SetDefault("janitor.gateway_stats_retention.jitter", 5*time.Minute)
+	viper.SetDefault("janitor.redis_memory_usage.enabled", true)
+	viper.SetDefault("janitor.redis_memory_usage.interval", 15*time.Minute)
+	viper.SetDefault("janitor.redis_memory_usage.jitter", time.Minute)
+	viper.SetDefault("janitor.redis_memory_usage.samples_per_class", 100)
 
 	viper.SetDefault("metrics.timezone", "Local")
 	viper.SetDefault("metrics.redis.aggregation_intervals", []string{"MINUTE", "HOUR", "DAY", "MONTH"})
@@ -99,9 +210,57 @@ func init() {
 	viper.SetDefault("metrics.redis.day_aggregation_ttl", time.Hour*24*90)
 	viper.SetDefault("metrics.redis.month_aggregation_ttl", time.Hour*24*730)
 
+	viper.SetDefault("certificates.storage_dir", "/var/lib/loraserver/certificates")
+	viper.SetDefault("certificates.renew_before", time.Hour*24*30)
+	viper.SetDefault("certificates.acme.directory_url", "https://acme-v02.api.letsencrypt.org/directory")
+
+	viper.SetDefault("security.max_events", 1000)
+	viper.SetDefault("security.rate_limit_interval", time.Minute)
+	viper.SetDefault("security.mic_failure_burst.threshold", 10)
+	viper.SetDefault("security.mic_failure_burst.window", time.Minute)
+	viper.SetDefault("security.join_flood.threshold", 10)
+	viper.SetDefault("security.join_flood.window", time.Minute)
+	viper.SetDefault("security.syslog.tag", "loraserver")
+	viper.SetDefault("accounting.sink", "postgres")
+	viper.SetDefault("accounting.mqtt.server", "tcp://localhost:1883")
+	viper.SetDefault("simulator.seed", 1)
+	viper.SetDefault("simulator.duration", 5*time.Minute)
+	viper.SetDefault("simulator.marshaler", "json")
+	viper.SetDefault("simulator.gateway_count", 1)
+	viper.SetDefault("simulator.gateway_id_base", "0101010100000000")
+	viper.SetDefault("simulator.otaa_device_count", 1)
+	viper.SetDefault("simulator.abp_device_count", 1)
+	viper.SetDefault("simulator.dev_eui_base", "0202020200000000")
+	viper.SetDefault("simulator.join_eui", "0000000000000000")
+	viper.SetDefault("simulator.uplink_interval", time.Minute)
+	viper.SetDefault("simulator.f_port", 10)
+	viper.SetDefault("simulator.payload_size", 10)
+	viper.SetDefault("simulator.confirmed_ratio", 0.1)
+	viper.SetDefault("simulator.rssi_min", -120)
+	viper.SetDefault("simulator.rssi_max", -50)
+	viper.SetDefault("simulator.snr_min", -10)
+	viper.SetDefault("simulator.snr_max", 10)
+	viper.SetDefault("simulator.min_join_success_ratio", 1)
+	viper.SetDefault("simulator.min_downlink_delivery_ratio", 0.9)
+	viper.SetDefault("simulator.min_recovery_downlink_delivery_ratio", 0.9)
+	viper.SetDefault("accounting.mqtt.qos", 1)
+	viper.SetDefault("accounting.mqtt.topic_template", "accounting/{{ .Direction }}/{{ .DevEUI }}")
+	viper.SetDefault("accounting.mqtt.forward_interval", time.Second)
+	viper.SetDefault("accounting.mqtt.batch_size", 100)
+
+	viper.SetDefault("secrets.vault.approle.mount_path", "approle")
+
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(configEnvCmd)
+	rootCmd.AddCommand(configCheckCmd)
 	rootCmd.AddCommand(printDSCmd)
+	rootCmd.AddCommand(deviceSessionCmd)
+	rootCmd.AddCommand(deviceNonceCmd)
+	rootCmd.AddCommand(featureFlagCmd)
+	rootCmd.AddCommand(janitorCmd)
+	rootCmd.AddCommand(roamingAccountingCmd)
+	rootCmd.AddCommand(simulateCmd)
 }
 
 // Execute executes the root command.
@@ -115,31 +274,45 @@ func Execute(v string) {
 func initConfig() {
 	config.Version = version
 
-	if cfgFile != "" {
-		b, err := ioutil.ReadFile(cfgFile)
+	conf, err := loadConfig()
+	if err != nil {
+		log.WithError(err).WithField("config", cfgFile).Fatal("load configuration error")
+	}
+	config.C = conf
+}
+
+// loadConfig reads the configuration file and environment variables and
+// returns the resulting configuration. It is used on startup and when
+// reloading the configuration.
+func loadConfig() (config.Config, error) {
+	var conf config.Config
+
+	configFile := cfgFile
+	if configFile == "" {
+		configFile = findConfigFile()
+		if configFile == "" {
+			log.Warning("No configuration file found, using defaults. See: https://www.loraserver.io/loraserver/install/config/")
+		}
+	}
+
+	// The configuration file is only parsed after the environment variable
+	// references have been expanded.
+	if configFile != "" {
+		b, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			return conf, errors.Wrap(err, "read config file error")
+		}
+		b, err = expandEnv(b)
 		if err != nil {
-			log.WithError(err).WithField("config", cfgFile).Fatal("error loading config file")
+			return conf, errors.Wrap(err, "expand environment variables error")
 		}
 		viper.SetConfigType("toml")
 		if err := viper.ReadConfig(bytes.NewBuffer(b)); err != nil {
-			log.WithError(err).WithField("config", cfgFile).Fatal("error loading config file")
-		}
-	} else {
-		viper.SetConfigName("loraserver")
-		viper.AddConfigPath(".")
-		viper.AddConfigPath("$HOME/.config/loraserver")
-		viper.AddConfigPath("/etc/loraserver")
-		if err := viper.ReadInConfig(); err != nil {
-			switch err.(type) {
-			case viper.ConfigFileNotFoundError:
-				log.Warning("No configuration file found, using defaults. See: https://www.loraserver.io/loraserver/install/config/")
-			default:
-				log.WithError(err).Fatal("read configuration file error")
-			}
+			return conf, errors.Wrap(err, "read config error")
 		}
 	}
 
-	viperBindEnvs(config.C)
+	viperBindEnvs(conf)
 
 	viperHooks := mapstructure.ComposeDecodeHookFunc(
 		viperDecodeJSONSlice,
@@ -147,36 +320,234 @@ func initConfig() {
 		mapstructure.StringToSliceHookFunc(","),
 	)
 
-	if err := viper.Unmarshal(&config.C, viper.DecodeHook(viperHooks)); err != nil {
-		log.WithError(err).Fatal("unmarshal config error")
+	if err := viper.Unmarshal(&conf, viper.DecodeHook(viperHooks)); err != nil {
+		return conf, errors.Wrap(err, "unmarshal config error")
+	}
+
+	if err := conf.NetworkServer.NetID.UnmarshalText([]byte(conf.NetworkServer.NetIDString)); err != nil {
+		return conf, errors.Wrap(err, "decode net_id error")
 	}
 
-	if err := config.C.NetworkServer.NetID.UnmarshalText([]byte(config.C.NetworkServer.NetIDString)); err != nil {
-		log.WithError(err).Fatal("decode net_id error")
+	return conf, nil
+}
+
+// findConfigFile returns the path of the first configuration file found in
+// the search paths, or an empty string when no configuration file exists.
+// The file is not parsed, as it must be parsed after expanding the
+// environment variables.
+func findConfigFile() string {
+	for _, dir := range configSearchPaths {
+		p := filepath.Join(os.ExpandEnv(dir), configFileName)
+		if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+			return p
+		}
 	}
+	return ""
 }
 
-func viperBindEnvs(iface interface{}, parts ...string) {
-	ifv := reflect.ValueOf(iface)
-	ift := reflect.TypeOf(iface)
-	for i := 0; i < ift.NumField(); i++ {
-		v := ifv.Field(i)
-		t := ift.Field(i)
-		tv, ok := t.Tag.Lookup("mapstructure")
-		if !ok {
-			tv = strings.ToLower(t.Name)
+// viperBindEnvs binds each configuration key to an environment variable.
+// The shell-friendly name (see envName) is used when set, else the legacy
+// name (the upper-cased key, e.g. NETWORK_SERVER.BAND.NAME).
+func viperBindEnvs(iface interface{}) {
+	for _, key := range configKeys(reflect.TypeOf(iface)) {
+		if _, ok := os.LookupEnv(envName(key)); ok {
+			viper.BindEnv(key, envName(key))
+		} else {
+			viper.BindEnv(key)
 		}
+	}
+}
+
+// configKeys returns the configuration keys of the given config struct type.
+// Slices (e.g. lists of structures) are returned as a single key, which can
+// be set using a JSON encoded value.
+func configKeys(t reflect.Type, parts ...string) []string {
+	var out []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tv := configKeyName(f)
 		if tv == "-" {
 			continue
 		}
 
-		switch v.Kind() {
+		switch f.Type.Kind() {
 		case reflect.Struct:
-			viperBindEnvs(v.Interface(), append(parts, tv)...)
+			out = append(out, configKeys(f.Type, append(parts, tv)...)...)
+		case reflect.Interface, reflect.Func, reflect.Chan:
+			// not configurable (e.g. runtime objects)
 		default:
-			key := strings.Join(append(parts, tv), ".")
-			viper.BindEnv(key)
+			out = append(out, strings.Join(append(parts, tv), "."))
+		}
+	}
+	return out
+}
+
+// configKeyName returns the configuration key name of the given struct
+// field (the mapstructure tag or the lower-cased field name).
+func configKeyName(f reflect.StructField) string {
+	if tv, ok := f.Tag.Lookup("mapstructure"); ok {
+		return tv
+	}
+	return strings.ToLower(f.Name)
+}
+
+// envName returns the environment variable name for the given configuration
+// key, e.g. NETWORK_SERVER__BAND__NAME for network_server.band.name.
+func envName(key string) string {
+	return strings.ToUpper(strings.Replace(key, ".", "__", -1))
+}
+
+// envRefRegexp matches the ${VAR} and ${VAR:-default} reference at the start
+// of the input.
+var envRefRegexp = regexp.MustCompile(`\A\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// TOML contexts in which an environment variable reference can appear.
+const (
+	tomlBare = iota
+	tomlComment
+	tomlBasicString
+	tomlMultiLineBasicString
+	tomlLiteralString
+	tomlMultiLineLiteralString
+)
+
+// expandEnv replaces the ${VAR} and ${VAR:-default} references in the given
+// configuration file content by the value of the environment variable. It
+// returns an error when a referenced variable without default is not set.
+// References in comments are ignored. Values are escaped when the reference
+// is within a basic string and inserted as-is outside strings (e.g. for
+// numbers). Values which can not be represented within a literal string
+// result in an error. Default values are always inserted as-is.
+func expandEnv(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	var missing, invalid []string
+	state := tomlBare
+
+	for i := 0; i < len(b); {
+		rest := b[i:]
+
+		if state == tomlComment {
+			if rest[0] == '\n' {
+				state = tomlBare
+			}
+			out.WriteByte(rest[0])
+			i++
+			continue
+		}
+
+		// skip escape sequences, e.g. \" or \$
+		if (state == tomlBasicString || state == tomlMultiLineBasicString) && rest[0] == '\\' && len(rest) > 1 {
+			out.Write(rest[:2])
+			i += 2
+			continue
+		}
+
+		if m := envRefRegexp.FindSubmatchIndex(rest); m != nil {
+			name := string(rest[m[2]:m[3]])
+			if v, ok := os.LookupEnv(name); ok {
+				encoded, ok := tomlEncode(state, v)
+				if !ok {
+					invalid = append(invalid, name)
+				}
+				out.WriteString(encoded)
+			} else if m[4] != -1 {
+				out.Write(rest[m[6]:m[7]])
+			} else {
+				missing = append(missing, name)
+			}
+			i += m[1]
+			continue
+		}
+
+		n := 1
+		switch state {
+		case tomlBare:
+			switch {
+			case rest[0] == '#':
+				state = tomlComment
+			case bytes.HasPrefix(rest, []byte(`"""`)):
+				state = tomlMultiLineBasicString
+				n = 3
+			case rest[0] == '"':
+				state = tomlBasicString
+			case bytes.HasPrefix(rest, []byte(`'''`)):
+				state = tomlMultiLineLiteralString
+				n = 3
+			case rest[0] == '\'':
+				state = tomlLiteralString
+			}
+		case tomlBasicString:
+			if rest[0] == '"' || rest[0] == '\n' {
+				state = tomlBare
+			}
+		case tomlLiteralString:
+			if rest[0] == '\'' || rest[0] == '\n' {
+				state = tomlBare
+			}
+		case tomlMultiLineBasicString:
+			if bytes.HasPrefix(rest, []byte(`"""`)) {
+				state = tomlBare
+				n = 3
+			}
+		case tomlMultiLineLiteralString:
+			if bytes.HasPrefix(rest, []byte(`'''`)) {
+				state = tomlBare
+				n = 3
+			}
+		}
+
+		out.Write(rest[:n])
+		i += n
+	}
+
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("environment variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+
+	if len(invalid) != 0 {
+		return nil, fmt.Errorf("environment variable(s) can not be used in a literal string: %s", strings.Join(invalid, ", "))
+	}
+
+	return out.Bytes(), nil
+}
+
+// tomlEncode encodes the given value for the given TOML context. It returns
+// false when the value can not be represented within a literal string.
+func tomlEncode(state int, v string) (string, bool) {
+	switch state {
+	case tomlBasicString, tomlMultiLineBasicString:
+		var out strings.Builder
+		for _, r := range v {
+			switch r {
+			case '"':
+				out.WriteString(`\"`)
+			case '\\':
+				out.WriteString(`\\`)
+			case '\b':
+				out.WriteString(`\b`)
+			case '\t':
+				out.WriteString(`\t`)
+			case '\n':
+				out.WriteString(`\n`)
+			case '\f':
+				out.WriteString(`\f`)
+			case '\r':
+				out.WriteString(`\r`)
+			default:
+				if r < 0x20 || r == 0x7f {
+					fmt.Fprintf(&out, `\u%04X`, r)
+				} else {
+					out.WriteRune(r)
+				}
+			}
 		}
+		return out.String(), true
+	case tomlLiteralString:
+		return v, !strings.ContainsAny(v, "'\r\n")
+	case tomlMultiLineLiteralString:
+		return v, !strings.Contains(v, "'''")
+	default:
+		return v, true
 	}
 }
 
@@ -1,10 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"os/signal"
 	"strings"
@@ -13,24 +13,54 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mxc-foundation/lpwan-server/api/geo"
 	"github.com/mxc-foundation/lpwan-server/api/nc"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
 	"github.com/mxc-foundation/lpwan-server/internal/adr"
 	"github.com/mxc-foundation/lpwan-server/internal/api"
+	"github.com/mxc-foundation/lpwan-server/internal/api/ws"
+	"github.com/mxc-foundation/lpwan-server/internal/attestation"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/controller"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/downlinkhook"
 	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/azureiothub"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/basicstation"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/concentratord"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/gcppubsub"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/kafka"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/mqtt"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/nats"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/semtechudp"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/geolocationserver"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/m2m_client"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/certmanager"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
+	"github.com/mxc-foundation/lpwan-server/internal/dryrun"
+	"github.com/mxc-foundation/lpwan-server/internal/faultinjection"
+	"github.com/mxc-foundation/lpwan-server/internal/featureflag"
+	"github.com/mxc-foundation/lpwan-server/internal/framelog"
 	"github.com/mxc-foundation/lpwan-server/internal/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/constraint"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/stats"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
+	"github.com/mxc-foundation/lpwan-server/internal/janitor"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
+	"github.com/mxc-foundation/lpwan-server/internal/leader"
 	"github.com/mxc-foundation/lpwan-server/internal/metrics"
 	"github.com/mxc-foundation/lpwan-server/internal/migrations/code"
+	"github.com/mxc-foundation/lpwan-server/internal/partition"
+	"github.com/mxc-foundation/lpwan-server/internal/provisioning"
+	"github.com/mxc-foundation/lpwan-server/internal/reload"
+	"github.com/mxc-foundation/lpwan-server/internal/roaming"
+	"github.com/mxc-foundation/lpwan-server/internal/secrets"
+	"github.com/mxc-foundation/lpwan-server/internal/security"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
 	"github.com/mxc-foundation/lpwan-server/internal/uplink"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -42,31 +72,62 @@ import (
 func run(cmd *cobra.Command, args []string) error {
 	var server = new(uplink.Server)
 	var gwStats = new(gateway.StatsHandler)
+	var gwConnState = new(gateway.ConnStateHandler)
+	var gwCommandExec = new(gateway.CommandExecResponseHandler)
+	var gwNoiseFloor = new(gateway.NoiseFloorReportHandler)
 
 	tasks := []func() error{
+		resolveSecrets,
 		setLogLevel,
 		setupBand,
 		setRXParameters,
 		printStartMessage,
+		setupCertificates,
 		setupMetrics,
 		enableUplinkChannels,
+		setupKEK,
 		setupStorage,
+		setupFeatureFlags,
 		setGatewayBackend,
 		setupApplicationServer,
+		setupWebhook,
+		setupDownlinkHook,
+		setupUplinkAttestation,
+		setupSecurity,
+		setupAccounting,
+		setupFrameLog,
+		setupGatewayContribution,
+		setupGatewayStatsAggregation,
+		setupGatewayConstraints,
 		setupADR,
 		setupGeolocationServer,
 		setupJoinServer,
+		setupRoaming,
 		setupNetworkController,
+		setupDryRun,
+		setupFaultInjection,
 		setupUplink,
 		setupDownlink,
 		fixV2RedisCache,
 		migrateGatewayStats,
 		flushGatewayCache,
+		migrateRedisHashTags,
 		setupAPI,
+		setupFrameLogWebSocket,
+		setupReload,
+		setupSecretsRenewal,
+		setupPartitioning,
 		startLoRaServer(server),
 		startStatsServer(gwStats),
+		startConnStateHandler(gwConnState),
+		startCommandExecResponseHandler(gwCommandExec),
+		startNoiseFloorReportHandler(gwNoiseFloor),
+		setupLeaderElection,
+		setupJanitor,
 		startQueueScheduler,
+		setupProvisioningSync,
 		setupM2MServer,
+		setupHandover,
 	}
 
 	for _, t := range tasks {
@@ -78,7 +139,12 @@ func run(cmd *cobra.Command, args []string) error {
 	sigChan := make(chan os.Signal)
 	exitChan := make(chan struct{})
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-	log.WithField("signal", <-sigChan).Info("signal received")
+	select {
+	case s := <-sigChan:
+		log.WithField("signal", s).Info("signal received")
+	case <-handover.Done():
+		log.Info("handover completed")
+	}
 	go func() {
 		log.Warning("stopping loraserver")
 		if err := server.Stop(); err != nil {
@@ -87,6 +153,23 @@ func run(cmd *cobra.Command, args []string) error {
 		if err := gwStats.Stop(); err != nil {
 			log.Fatal(err)
 		}
+		if err := gwConnState.Stop(); err != nil {
+			log.Fatal(err)
+		}
+		if err := gwCommandExec.Stop(); err != nil {
+			log.Fatal(err)
+		}
+		if err := gwNoiseFloor.Stop(); err != nil {
+			log.Fatal(err)
+		}
+		if err := leader.Resign(context.Background(), storage.RedisPool()); err != nil {
+			log.WithError(err).Error("resign leadership error")
+		}
+		if err := partition.Release(context.Background(), storage.RedisPool()); err != nil {
+			log.WithError(err).Error("release partitions error")
+		}
+		api.Stop()
+		ws.Stop()
 		exitChan <- struct{}{}
 	}()
 	select {
@@ -98,6 +181,49 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func resolveSecrets() error {
+	if err := secrets.Resolve(context.Background(), &config.C); err != nil {
+		return errors.Wrap(err, "resolve secrets error")
+	}
+	return nil
+}
+
+// loadConfigWithSecrets loads the configuration and resolves the secrets.
+func loadConfigWithSecrets() (config.Config, error) {
+	conf, err := loadConfig()
+	if err != nil {
+		return conf, err
+	}
+
+	if err := secrets.Resolve(context.Background(), &conf); err != nil {
+		return conf, errors.Wrap(err, "resolve secrets error")
+	}
+
+	return conf, nil
+}
+
+func setupReload() error {
+	reload.Setup(loadConfigWithSecrets)
+
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	go func() {
+		for range hupChan {
+			log.Info("SIGHUP received, reloading configuration")
+			if err := reload.Reload(context.Background()); err != nil {
+				log.WithError(err).Error("reload configuration error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func setupSecretsRenewal() error {
+	secrets.StartRenewal(reload.Reload)
+	return nil
+}
+
 func setupBand() error {
 	if err := band.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup band error")
@@ -194,6 +320,62 @@ func enableUplinkChannels() error {
 	return nil
 }
 
+func setupCertificates() error {
+	if err := certmanager.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup certificates error")
+	}
+	return nil
+}
+
+func setupSecurity() error {
+	if err := security.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup security error")
+	}
+	return nil
+}
+
+func setupAccounting() error {
+	if err := accounting.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup accounting error")
+	}
+	return nil
+}
+
+func setupFrameLog() error {
+	if err := framelog.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup frame-log error")
+	}
+	return nil
+}
+
+func setupGatewayContribution() error {
+	if err := contribution.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup gateway contribution error")
+	}
+	return nil
+}
+
+func setupGatewayStatsAggregation() error {
+	if err := stats.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup gateway stats aggregation error")
+	}
+	return nil
+}
+
+func setupGatewayConstraints() error {
+	if err := constraint.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup gateway constraints error")
+	}
+	return nil
+}
+
+func setupKEK() error {
+	if err := kek.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup kek error")
+	}
+	return nil
+}
+
 func setupStorage() error {
 	if err := storage.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup storage error")
@@ -201,6 +383,13 @@ func setupStorage() error {
 	return nil
 }
 
+func setupFeatureFlags() error {
+	if err := featureflag.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup feature-flags error")
+	}
+	return nil
+}
+
 func setupADR() error {
 	if err := adr.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup adr error")
@@ -209,10 +398,42 @@ func setupADR() error {
 }
 
 func setGatewayBackend() error {
+	var backends []gwbackend.Gateway
+
+	for _, t := range gatewayBackendTypes(config.C) {
+		gw, err := newGatewayBackend(t)
+		if err != nil {
+			for _, b := range backends {
+				b.Close()
+			}
+			return errors.Wrapf(err, "gateway-backend %s setup failed", t)
+		}
+		backends = append(backends, gw)
+	}
+
+	if len(backends) == 1 {
+		gwbackend.SetBackend(backends[0])
+	} else {
+		gwbackend.SetBackend(gwbackend.NewMultiBackend(backends...))
+	}
+
+	return nil
+}
+
+// gatewayBackendTypes returns the configured gateway backend types. The
+// types setting takes precedence over the (single) type setting.
+func gatewayBackendTypes(conf config.Config) []string {
+	if len(conf.NetworkServer.Gateway.Backend.Types) != 0 {
+		return conf.NetworkServer.Gateway.Backend.Types
+	}
+	return []string{conf.NetworkServer.Gateway.Backend.Type}
+}
+
+func newGatewayBackend(t string) (gwbackend.Gateway, error) {
 	var err error
 	var gw gwbackend.Gateway
 
-	switch config.C.NetworkServer.Gateway.Backend.Type {
+	switch t {
 	case "mqtt":
 		gw, err = mqtt.NewBackend(
 			storage.RedisPool(),
@@ -222,21 +443,47 @@ func setGatewayBackend() error {
 		gw, err = gcppubsub.NewBackend(config.C)
 	case "azure_iot_hub":
 		gw, err = azureiothub.NewBackend(config.C)
+	case "basic_station":
+		gw, err = basicstation.NewBackend(config.C)
+	case "kafka":
+		gw, err = kafka.NewBackend(config.C)
+	case "nats":
+		gw, err = nats.NewBackend(config.C)
+	case "semtech_udp":
+		gw, err = semtechudp.NewBackend(config.C)
+	case "concentratord":
+		gw, err = concentratord.NewBackend(config.C)
 	default:
-		return fmt.Errorf("unexpected gateway backend type: %s", config.C.NetworkServer.Gateway.Backend.Type)
+		return nil, fmt.Errorf("unexpected gateway backend type: %s", t)
 	}
 
-	if err != nil {
-		return errors.Wrap(err, "gateway-backend setup failed")
+	return gw, err
+}
+
+func setupApplicationServer() error {
+	if err := applicationserver.Setup(config.C); err != nil {
+		return errors.Wrap(err, "application-server setup error")
+	}
+	return nil
+}
+
+func setupWebhook() error {
+	if err := webhook.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup webhook error")
 	}
+	return nil
+}
 
-	gwbackend.SetBackend(gw)
+func setupDownlinkHook() error {
+	if err := downlinkhook.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup downlink hook error")
+	}
 	return nil
 }
 
-func setupApplicationServer() error {
-	if err := applicationserver.Setup(); err != nil {
-		return errors.Wrap(err, "application-server setup error")
+func setupUplinkAttestation() error {
+	if err := attestation.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup uplink attestation error")
 	}
 	return nil
 }
@@ -249,10 +496,8 @@ func setupGeolocationServer() error {
 	}
 
 	log.WithFields(log.Fields{
-		"server":   config.C.GeolocationServer.Server,
-		"ca_cert":  config.C.GeolocationServer.CACert,
-		"tls_cert": config.C.GeolocationServer.TLSCert,
-		"tls_key":  config.C.GeolocationServer.TLSKey,
+		"server": config.C.GeolocationServer.Server,
+		"tls":    config.C.GeolocationServer.TLSCert != "",
 	}).Info("connecting to geolocation-server")
 
 	var dialOptions []grpc.DialOption
@@ -281,15 +526,34 @@ func setupJoinServer() error {
 	return nil
 }
 
+func setupRoaming() error {
+	if err := roaming.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup roaming error")
+	}
+	return nil
+}
+
+func setupDryRun() error {
+	if err := dryrun.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup dry-run error")
+	}
+	return nil
+}
+
+func setupFaultInjection() error {
+	if err := faultinjection.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup fault-injection error")
+	}
+	return nil
+}
+
 func setupNetworkController() error {
 	// TODO: move this logic to controller.Setup function
 	if config.C.NetworkController.Server != "" {
 		// setup network-controller client
 		log.WithFields(log.Fields{
-			"server":   config.C.NetworkController.Server,
-			"ca-cert":  config.C.NetworkController.CACert,
-			"tls-cert": config.C.NetworkController.TLSCert,
-			"tls-key":  config.C.NetworkController.TLSKey,
+			"server": config.C.NetworkController.Server,
+			"tls":    config.C.NetworkController.TLSCert != "",
 		}).Info("connecting to network-controller")
 		var ncDialOptions []grpc.DialOption
 		if config.C.NetworkController.TLSCert != "" && config.C.NetworkController.TLSKey != "" {
@@ -332,6 +596,13 @@ func setupAPI() error {
 	return nil
 }
 
+func setupFrameLogWebSocket() error {
+	if err := ws.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup frame-log websocket error")
+	}
+	return nil
+}
+
 func startLoRaServer(server *uplink.Server) func() error {
 	return func() error {
 		*server = *uplink.NewServer()
@@ -349,6 +620,76 @@ func startStatsServer(gwStats *gateway.StatsHandler) func() error {
 	}
 }
 
+func startConnStateHandler(gwConnState *gateway.ConnStateHandler) func() error {
+	return func() error {
+		*gwConnState = *gateway.NewConnStateHandler()
+		if err := gwConnState.Start(); err != nil {
+			return errors.Wrap(err, "start gateway connection state handler error")
+		}
+		return nil
+	}
+}
+
+func startCommandExecResponseHandler(gwCommandExec *gateway.CommandExecResponseHandler) func() error {
+	return func() error {
+		*gwCommandExec = *gateway.NewCommandExecResponseHandler()
+		if err := gwCommandExec.Start(); err != nil {
+			return errors.Wrap(err, "start gateway command execution response handler error")
+		}
+		return nil
+	}
+}
+
+func startNoiseFloorReportHandler(gwNoiseFloor *gateway.NoiseFloorReportHandler) func() error {
+	return func() error {
+		*gwNoiseFloor = *gateway.NewNoiseFloorReportHandler()
+		if err := gwNoiseFloor.Start(); err != nil {
+			return errors.Wrap(err, "start gateway noise-floor report handler error")
+		}
+		return nil
+	}
+}
+
+func setupProvisioningSync() error {
+	if err := provisioning.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup provisioning sync error")
+	}
+	return nil
+}
+
+func setupHandover() error {
+	if err := handover.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup handover error")
+	}
+
+	// when started by a handover, the previous process stops once ready
+	if err := handover.Ready(); err != nil {
+		return errors.Wrap(err, "signal handover ready error")
+	}
+	return nil
+}
+
+func setupJanitor() error {
+	if err := janitor.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup janitor error")
+	}
+	return nil
+}
+
+func setupLeaderElection() error {
+	if err := leader.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup leader election error")
+	}
+	return nil
+}
+
+func setupPartitioning() error {
+	if err := partition.Setup(config.C); err != nil {
+		return errors.Wrap(err, "setup partitioning error")
+	}
+	return nil
+}
+
 func startQueueScheduler() error {
 	log.Info("starting downlink device-queue scheduler")
 	go downlink.DeviceQueueSchedulerLoop()
@@ -356,28 +697,32 @@ func startQueueScheduler() error {
 	log.Info("starting multicast scheduler")
 	go downlink.MulticastQueueSchedulerLoop()
 
+	if config.C.NetworkServer.Scheduler.TXAck.Timeout != 0 {
+		log.Info("starting downlink tx acknowledgement timeout handler")
+		go ack.TXAckTimeoutLoop()
+	}
+
 	return nil
 }
 
 func mustGetTransportCredentials(tlsCert, tlsKey, caCert string, verifyClientCert bool) credentials.TransportCredentials {
-	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+	// the values are not logged as they might contain the (PEM encoded)
+	// key itself, read errors do contain the file path
+	cert, err := nstls.LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"cert": tlsCert,
-			"key":  tlsKey,
-		}).Fatalf("load key-pair error: %s", err)
+		log.Fatalf("load key-pair error: %s", err)
 	}
 
 	var caCertPool *x509.CertPool
 	if caCert != "" {
-		rawCaCert, err := ioutil.ReadFile(caCert)
+		rawCaCert, err := nstls.ReadPEM(caCert)
 		if err != nil {
-			log.WithField("ca", caCert).Fatalf("load ca cert error: %s", err)
+			log.Fatalf("load ca cert error: %s", err)
 		}
 
 		caCertPool = x509.NewCertPool()
 		if !caCertPool.AppendCertsFromPEM(rawCaCert) {
-			log.WithField("ca_cert", caCert).Fatal("append ca certificate error")
+			log.Fatal("append ca certificate error")
 		}
 	}
 
@@ -413,6 +758,12 @@ func flushGatewayCache() error {
 	})
 }
 
+func migrateRedisHashTags() error {
+	return code.Migrate("redis_hash_tags", func(db sqlx.Ext) error {
+		return code.MigrateRedisHashTags(storage.RedisPool())
+	})
+}
+
 func setupM2MServer() error {
 	if err := m2m_client.Setup(config.C); err != nil {
 		return errors.Wrap(err, "setup m2m-server error")
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"EXPAND_ENV_HOST":    "localhost",
+		"EXPAND_ENV_PORT":    "8000",
+		"EXPAND_ENV_QUOTE":   `pa"ss\word`,
+		"EXPAND_ENV_NEWLINE": "line1\nline2",
+		"EXPAND_ENV_SINGLE":  "it's",
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+	defer func() {
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	}()
+
+	tests := []struct {
+		Name          string
+		Config        string
+		Expected      string
+		ExpectedError string
+	}{
+		{
+			Name:     "basic string",
+			Config:   `bind="${EXPAND_ENV_HOST}:${EXPAND_ENV_PORT}"`,
+			Expected: `bind="localhost:8000"`,
+		},
+		{
+			Name:     "bare value",
+			Config:   `port=${EXPAND_ENV_PORT}`,
+			Expected: `port=8000`,
+		},
+		{
+			Name:     "default value",
+			Config:   `dsn="${EXPAND_ENV_NOT_SET:-postgres://localhost/loraserver}"`,
+			Expected: `dsn="postgres://localhost/loraserver"`,
+		},
+		{
+			Name:     "set value overrides default",
+			Config:   `host="${EXPAND_ENV_HOST:-example.com}"`,
+			Expected: `host="localhost"`,
+		},
+		{
+			Name:          "not set",
+			Config:        `host="${EXPAND_ENV_NOT_SET}"`,
+			ExpectedError: "environment variable(s) not set: EXPAND_ENV_NOT_SET",
+		},
+		{
+			Name:     "not set in comment",
+			Config:   "# host=\"${EXPAND_ENV_NOT_SET}\"\nhost=\"${EXPAND_ENV_HOST}\"",
+			Expected: "# host=\"${EXPAND_ENV_NOT_SET}\"\nhost=\"localhost\"",
+		},
+		{
+			Name:     "not set in trailing comment",
+			Config:   "host=\"#${EXPAND_ENV_HOST}\" # ${EXPAND_ENV_NOT_SET}",
+			Expected: "host=\"#localhost\" # ${EXPAND_ENV_NOT_SET}",
+		},
+		{
+			Name:     "escaped in basic string",
+			Config:   `password="${EXPAND_ENV_QUOTE}"`,
+			Expected: `password="pa\"ss\\word"`,
+		},
+		{
+			Name:     "escaped in multi-line basic string",
+			Config:   `text="""${EXPAND_ENV_NEWLINE}"""`,
+			Expected: `text="""line1\nline2"""`,
+		},
+		{
+			Name:     "literal string",
+			Config:   `host='${EXPAND_ENV_HOST}'`,
+			Expected: `host='localhost'`,
+		},
+		{
+			Name:          "invalid in literal string",
+			Config:        `text='${EXPAND_ENV_SINGLE}'`,
+			ExpectedError: "environment variable(s) can not be used in a literal string: EXPAND_ENV_SINGLE",
+		},
+		{
+			Name:     "multi-line literal string",
+			Config:   `text='''${EXPAND_ENV_SINGLE}'''`,
+			Expected: `text='''it's'''`,
+		},
+		{
+			Name:     "quote in literal string",
+			Config:   `a='"' # ${EXPAND_ENV_NOT_SET}` + "\nb=\"${EXPAND_ENV_HOST}\"",
+			Expected: `a='"' # ${EXPAND_ENV_NOT_SET}` + "\nb=\"localhost\"",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := expandEnv([]byte(tst.Config))
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tst.Expected, string(out))
+		})
+	}
+
+	t.Run("Parsed value", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := expandEnv([]byte("[section]\n# ${EXPAND_ENV_NOT_SET}\npassword=\"${EXPAND_ENV_QUOTE}\"\ntext=\"${EXPAND_ENV_NEWLINE}\"\nport=${EXPAND_ENV_PORT}\n"))
+		assert.NoError(err)
+
+		v := viper.New()
+		v.SetConfigType("toml")
+		assert.NoError(v.ReadConfig(bytes.NewBuffer(out)))
+		assert.Equal(`pa"ss\word`, v.GetString("section.password"))
+		assert.Equal("line1\nline2", v.GetString("section.text"))
+		assert.Equal(8000, v.GetInt("section.port"))
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		Key      string
+		Expected string
+	}{
+		{"general.log_level", "GENERAL__LOG_LEVEL"},
+		{"network_server.band.name", "NETWORK_SERVER__BAND__NAME"},
+		{"network_server.gateway.backend.mqtt.server", "NETWORK_SERVER__GATEWAY__BACKEND__MQTT__SERVER"},
+		{"redis", "REDIS"},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Key, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, envName(tst.Key))
+		})
+	}
+}
+
+func TestConfigKeys(t *testing.T) {
+	type nested struct {
+		Name    string
+		Enabled bool `mapstructure:"enabled"`
+	}
+
+	tests := []struct {
+		Name     string
+		Type     reflect.Type
+		Expected []string
+	}{
+		{
+			Name: "mapstructure tags and field names",
+			Type: reflect.TypeOf(struct {
+				LogLevel int `mapstructure:"log_level"`
+				Server   string
+			}{}),
+			Expected: []string{"log_level", "server"},
+		},
+		{
+			Name: "nested structs",
+			Type: reflect.TypeOf(struct {
+				Backend struct {
+					MQTT nested `mapstructure:"mqtt"`
+				} `mapstructure:"backend"`
+			}{}),
+			Expected: []string{"backend.mqtt.name", "backend.mqtt.enabled"},
+		},
+		{
+			Name: "slices are a single key",
+			Type: reflect.TypeOf(struct {
+				Regions []nested `mapstructure:"regions"`
+				Shards  []int    `mapstructure:"shards"`
+			}{}),
+			Expected: []string{"regions", "shards"},
+		},
+		{
+			Name: "skipped fields",
+			Type: reflect.TypeOf(struct {
+				Ignored  string      `mapstructure:"-"`
+				Func     func()      `mapstructure:"func"`
+				Iface    interface{} `mapstructure:"iface"`
+				Interval int64       `mapstructure:"interval"`
+			}{}),
+			Expected: []string{"interval"},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, configKeys(tst.Type))
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	os.Setenv("LOAD_CONFIG_LOG_LEVEL", "5")
+	os.Setenv("LOAD_CONFIG_NET_ID", "010203")
+	defer os.Unsetenv("LOAD_CONFIG_LOG_LEVEL")
+	defer os.Unsetenv("LOAD_CONFIG_NET_ID")
+
+	// the bare log_level value is invalid TOML before expanding it
+	configData := []byte("[general]\nlog_level=${LOAD_CONFIG_LOG_LEVEL}\n\n[network_server]\nnet_id=\"${LOAD_CONFIG_NET_ID}\"\n")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+
+	tests := []struct {
+		Name    string
+		Flag    bool
+		WorkDir bool
+		Dir     string
+	}{
+		{
+			Name: "config flag",
+			Flag: true,
+		},
+		{
+			Name:    "working directory",
+			WorkDir: true,
+		},
+		{
+			Name: "home directory",
+			Dir:  ".config/loraserver",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			tmp, err := ioutil.TempDir("", "loraserver")
+			assert.NoError(err)
+			defer os.RemoveAll(tmp)
+
+			// isolate from configuration files on the host
+			workDir := filepath.Join(tmp, "work")
+			assert.NoError(os.MkdirAll(workDir, 0755))
+			assert.NoError(os.Chdir(workDir))
+			defer os.Chdir(wd)
+			os.Setenv("HOME", tmp)
+
+			dir := filepath.Join(tmp, tst.Dir)
+			if tst.WorkDir {
+				dir = workDir
+			}
+			assert.NoError(os.MkdirAll(dir, 0755))
+			path := filepath.Join(dir, configFileName)
+			assert.NoError(ioutil.WriteFile(path, configData, 0644))
+
+			if tst.Flag {
+				cfgFile = path
+				defer func() { cfgFile = "" }()
+			} else if tst.WorkDir {
+				assert.Equal(configFileName, findConfigFile())
+			} else {
+				assert.Equal(path, findConfigFile())
+			}
+
+			conf, err := loadConfig()
+			assert.NoError(err)
+			assert.Equal(5, conf.General.LogLevel)
+			assert.Equal("010203", conf.NetworkServer.NetID.String())
+		})
+	}
+}
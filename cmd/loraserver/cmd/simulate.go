@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/simulator"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+var (
+	simulateDuration    time.Duration
+	simulateGateways    int
+	simulateOTAADevices int
+	simulateABPDevices  int
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Run an end-to-end simulation of virtual devices and gateways",
+	Long: `Run an end-to-end simulation of virtual devices and gateways.
+
+The virtual gateways publish the uplinks of the virtual (LoRaWAN 1.0.x)
+OTAA and ABP devices to the MQTT gateway backend and consume the downlinks
+sent by LoRa Server, which must be running. The simulation is configured in
+the [simulator] section of the configuration file. The result is printed as
+JSON and the command exits with a non-zero exit code when one of the
+assertions fails.`,
+	Example: `loraserver simulate --duration 10m --gateways 3 --otaa-devices 100 --abp-devices 100`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flags().Changed("duration") {
+			config.C.Simulator.Duration = simulateDuration
+		}
+		if cmd.Flags().Changed("gateways") {
+			config.C.Simulator.GatewayCount = simulateGateways
+		}
+		if cmd.Flags().Changed("otaa-devices") {
+			config.C.Simulator.OTAADeviceCount = simulateOTAADevices
+		}
+		if cmd.Flags().Changed("abp-devices") {
+			config.C.Simulator.ABPDeviceCount = simulateABPDevices
+		}
+
+		mustSetupSimulateCmd()
+
+		sim, err := simulator.New(config.C)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigChan
+			log.Warning("simulator: signal received, stopping simulation")
+			cancel()
+		}()
+
+		result, err := sim.Run(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			log.Fatal(err)
+		}
+
+		if !result.Passed() {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	simulateCmd.Flags().DurationVar(&simulateDuration, "duration", 0, "duration of the simulation (overrides simulator.duration)")
+	simulateCmd.Flags().IntVar(&simulateGateways, "gateways", 0, "number of virtual gateways (overrides simulator.gateway_count)")
+	simulateCmd.Flags().IntVar(&simulateOTAADevices, "otaa-devices", 0, "number of virtual OTAA devices (overrides simulator.otaa_device_count)")
+	simulateCmd.Flags().IntVar(&simulateABPDevices, "abp-devices", 0, "number of virtual ABP devices (overrides simulator.abp_device_count)")
+}
+
+func mustSetupSimulateCmd() {
+	if err := resolveSecrets(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := band.Setup(config.C); err != nil {
+		log.WithError(err).Fatal("setup band error")
+	}
+
+	if err := storage.Setup(config.C); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,273 @@
+// Package accounting implements the per-frame accounting records, used by the
+// billing and reward pipelines. A record is created for every successfully
+// processed uplink and for every downlink which has been acknowledged by the
+// gateway as transmitted.
+//
+// Records are always written to the accounting_record table first, which
+// acts as a durable outbox. When the MQTT sink is configured, a record is
+// only removed from this table once the broker has acknowledged its
+// publication, guaranteeing at-least-once delivery.
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	pendingDownlinkKeyTempl = "lora:ns:accounting:downlink:%d"
+
+	// pendingDownlinkTTL defines how long a sent downlink waits for its
+	// tx acknowledgement.
+	pendingDownlinkTTL = time.Minute
+)
+
+// Available sinks.
+const (
+	SinkPostgres = "postgres"
+	SinkMQTT     = "mqtt"
+)
+
+var (
+	mux     sync.RWMutex
+	enabled bool
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	c := conf.Accounting
+
+	mux.Lock()
+	enabled = c.Enabled
+	mux.Unlock()
+
+	if !c.Enabled {
+		return nil
+	}
+
+	switch c.Sink {
+	case SinkPostgres:
+		log.Info("accounting: storing accounting records in postgresql")
+	case SinkMQTT:
+		f, err := newMQTTForwarder(conf)
+		if err != nil {
+			return errors.Wrap(err, "new mqtt forwarder error")
+		}
+		go f.forwardLoop()
+	default:
+		return fmt.Errorf("accounting: unknown sink: %s", c.Sink)
+	}
+
+	return nil
+}
+
+// Enabled returns true when accounting is enabled.
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return enabled
+}
+
+// Uplink creates the accounting record for the given successfully processed
+// uplink. The attestation is nil when uplink attestation is disabled.
+func Uplink(ctx context.Context, devEUI lorawan.EUI64, fCnt uint32, rxPacket models.RXPacket, att *as.UplinkAttestation) error {
+	if !Enabled() {
+		return nil
+	}
+
+	b, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	airtime, err := Airtime(rxPacket.TXInfo, len(b), true)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	r := storage.AccountingRecord{
+		Direction:   storage.AccountingUplink,
+		DevEUI:      devEUI,
+		Airtime:     airtime,
+		DR:          rxPacket.DR,
+		PayloadSize: len(b),
+		FCnt:        fCnt,
+		Frequency:   int(rxPacket.TXInfo.Frequency),
+	}
+
+	if att != nil {
+		r.AttestationKeyID = att.KeyId
+		r.AttestationSignature = att.Signature
+	}
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		r.GatewayIDs = append(r.GatewayIDs, helpers.GetGatewayID(rxInfo))
+	}
+
+	if err := storage.CreateAccountingRecord(ctx, storage.DB(), &r); err != nil {
+		return errors.Wrap(err, "create accounting record error")
+	}
+
+	return nil
+}
+
+// DownlinkSent stores the accounting record for the given downlink frame,
+// sent to the gateway. The record is created once the gateway acknowledges
+// the transmission, see HandleDownlinkTXAck. As the full frame-counter is
+// not transmitted, the frame-counter of a downlink record contains the 16
+// least significant bits only.
+func DownlinkSent(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, frame gw.DownlinkFrame) error {
+	if !Enabled() {
+		return nil
+	}
+
+	if frame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(frame.TxInfo)
+
+	var region string
+	if band.HasRegions() {
+		var err error
+		region, err = storage.GetRegionForGateway(ctx, storage.DB(), gatewayID)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get region for gateway error")
+		}
+	}
+
+	dr, err := helpers.GetDataRateIndex(false, frame.TxInfo, band.Get(region))
+	if err != nil {
+		return errors.Wrap(err, "get data-rate index error")
+	}
+
+	airtime, err := Airtime(frame.TxInfo, len(frame.PhyPayload), false)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	var fCnt uint32
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(frame.PhyPayload); err != nil {
+		return errors.Wrap(err, "unmarshal phypayload error")
+	}
+	if macPL, ok := phy.MACPayload.(*lorawan.MACPayload); ok {
+		fCnt = macPL.FHDR.FCnt
+	}
+
+	r := storage.AccountingRecord{
+		Direction:   storage.AccountingDownlink,
+		DevEUI:      devEUI,
+		GatewayIDs:  []lorawan.EUI64{gatewayID},
+		Airtime:     airtime,
+		DR:          dr,
+		PayloadSize: len(frame.PhyPayload),
+		FCnt:        fCnt,
+		Frequency:   int(frame.TxInfo.Frequency),
+	}
+
+	if err := savePendingDownlink(p, frame.Token, r); err != nil {
+		return errors.Wrap(err, "save pending downlink error")
+	}
+
+	return nil
+}
+
+// HandleDownlinkTXAck creates the accounting record for the downlink matching
+// the given token, in case the acknowledgement does not contain an error.
+// In case of an error, the pending record is discarded as the next downlink
+// opportunity (if any) is sent as a new downlink.
+func HandleDownlinkTXAck(ctx context.Context, p storage.RedisClient, token uint16, ack gw.DownlinkTXAck) error {
+	if !Enabled() {
+		return nil
+	}
+
+	r, err := popPendingDownlink(p, uint32(token))
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get pending downlink error")
+	}
+
+	if ack.Error != "" {
+		log.WithFields(log.Fields{
+			"dev_eui": r.DevEUI,
+			"error":   ack.Error,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Debug("accounting: downlink not transmitted, discarding record")
+		return nil
+	}
+
+	r.CreatedAt = time.Now()
+	if err := storage.CreateAccountingRecord(ctx, storage.DB(), &r); err != nil {
+		return errors.Wrap(err, "create accounting record error")
+	}
+
+	return nil
+}
+
+func savePendingDownlink(p storage.RedisClient, token uint32, r storage.AccountingRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "marshal record error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err = c.Do("PSETEX", fmt.Sprintf(pendingDownlinkKeyTempl, token), int64(pendingDownlinkTTL/time.Millisecond), b)
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+func popPendingDownlink(p storage.RedisClient, token uint32) (storage.AccountingRecord, error) {
+	var r storage.AccountingRecord
+	key := fmt.Sprintf(pendingDownlinkKeyTempl, token)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return r, errors.Wrap(err, "exec error")
+	}
+
+	b, err := redis.Bytes(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return r, storage.ErrDoesNotExist
+		}
+		return r, errors.Wrap(err, "get error")
+	}
+
+	if err := json.Unmarshal(b, &r); err != nil {
+		return r, errors.Wrap(err, "unmarshal record error")
+	}
+
+	return r, nil
+}
@@ -0,0 +1,321 @@
+package accounting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type AccountingTestSuite struct {
+	suite.Suite
+}
+
+func (ts *AccountingTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+
+	conf.Accounting.Enabled = true
+	conf.Accounting.Sink = SinkPostgres
+	assert.NoError(Setup(conf))
+}
+
+func (ts *AccountingTestSuite) TearDownSuite() {
+	Setup(test.GetConfig())
+}
+
+func (ts *AccountingTestSuite) SetupTest() {
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *AccountingTestSuite) TestUplink() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	fPort := uint8(1)
+	rxPacket := models.RXPacket{
+		DR: 5,
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:    10,
+				},
+				FPort: &fPort,
+				FRMPayload: []lorawan.Payload{
+					&lorawan.DataPayload{Bytes: make([]byte, 10)},
+				},
+			},
+		},
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency:  868100000,
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					Bandwidth:       125,
+					SpreadingFactor: 7,
+					CodeRate:        "4/5",
+				},
+			},
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}},
+			{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}},
+		},
+	}
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	assert.NoError(Uplink(ctx, devEUI, 10, rxPacket, &as.UplinkAttestation{
+		KeyId:     "ns-1",
+		Signature: []byte{1, 2, 3, 4},
+	}))
+
+	records, err := storage.GetAccountingRecords(ctx, storage.DB(), 10, false)
+	assert.NoError(err)
+	assert.Len(records, 1)
+
+	r := records[0]
+	assert.Equal(storage.AccountingUplink, r.Direction)
+	assert.Equal(devEUI, r.DevEUI)
+	assert.Equal([]lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}}, r.GatewayIDs)
+	assert.Equal(61696*time.Microsecond, r.Airtime)
+	assert.Equal(5, r.DR)
+	assert.Equal(23, r.PayloadSize)
+	assert.EqualValues(10, r.FCnt)
+	assert.Equal(868100000, r.Frequency)
+	assert.Equal("ns-1", r.AttestationKeyID)
+	assert.Equal([]byte{1, 2, 3, 4}, r.AttestationSignature)
+}
+
+func (ts *AccountingTestSuite) TestDownlink() {
+	ctx := context.Background()
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:    3,
+			},
+		},
+	}
+	phyB, err := phy.MarshalBinary()
+	require.NoError(ts.T(), err)
+
+	frame := gw.DownlinkFrame{
+		Token:      1234,
+		PhyPayload: phyB,
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId:  []byte{1, 1, 1, 1, 1, 1, 1, 1},
+			Frequency:  868100000,
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					Bandwidth:             125,
+					SpreadingFactor:       7,
+					CodeRate:              "4/5",
+					PolarizationInversion: true,
+				},
+			},
+		},
+	}
+
+	ts.T().Run("Not transmitted", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DownlinkSent(ctx, storage.RedisPool(), devEUI, frame))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{Token: 1234, Error: "TOO_LATE"}))
+
+		records, err := storage.GetAccountingRecords(ctx, storage.DB(), 10, false)
+		assert.NoError(err)
+		assert.Len(records, 0)
+	})
+
+	ts.T().Run("Transmitted", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DownlinkSent(ctx, storage.RedisPool(), devEUI, frame))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{Token: 1234}))
+
+		records, err := storage.GetAccountingRecords(ctx, storage.DB(), 10, false)
+		assert.NoError(err)
+		assert.Len(records, 1)
+
+		r := records[0]
+		assert.Equal(storage.AccountingDownlink, r.Direction)
+		assert.Equal(devEUI, r.DevEUI)
+		assert.Equal([]lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}}, r.GatewayIDs)
+		assert.Equal(41216*time.Microsecond, r.Airtime)
+		assert.Equal(5, r.DR)
+		assert.Equal(12, r.PayloadSize)
+		assert.EqualValues(3, r.FCnt)
+	})
+
+	ts.T().Run("Acknowledgement is handled once", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{Token: 1234}))
+
+		records, err := storage.GetAccountingRecords(ctx, storage.DB(), 10, false)
+		assert.NoError(err)
+		assert.Len(records, 1)
+	})
+}
+
+func (ts *AccountingTestSuite) TestRoaming() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	netID := lorawan.NetID{1, 2, 3}
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+	rxPacket := models.RXPacket{
+		DR: 5,
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: devAddr,
+				},
+			},
+		},
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency:  868100000,
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					Bandwidth:       125,
+					SpreadingFactor: 7,
+					CodeRate:        "4/5",
+				},
+			},
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -100, LoraSnr: -2},
+			{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Rssi: -50, LoraSnr: 7},
+		},
+	}
+	assert.NoError(RoamingUplink(ctx, storage.RoamingRoleFNS, netID, devAddr, rxPacket))
+
+	records, err := storage.GetRoamingAccountingRecords(ctx, storage.DB(), storage.RoamingAccountingRecordFilter{Limit: 10})
+	assert.NoError(err)
+	assert.Len(records, 1)
+
+	r := records[0]
+	assert.Equal(storage.RoamingRoleFNS, r.Role)
+	assert.Equal(storage.AccountingUplink, r.Direction)
+	assert.Equal(netID, r.NetID)
+	assert.Equal(devAddr, r.DevAddr)
+	assert.Equal([]lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}}, r.GatewayIDs)
+	assert.Equal(12, r.PayloadSize)
+	assert.Equal(5, r.DR)
+	assert.Equal(-50, *r.RSSI)
+	assert.Equal(float64(7), *r.SNR)
+}
+
+func TestAccounting(t *testing.T) {
+	suite.Run(t, new(AccountingTestSuite))
+}
+
+func TestAirtime(t *testing.T) {
+	tests := []struct {
+		Name        string
+		TXInfo      gw.UplinkTXInfo
+		PayloadSize int
+		Uplink      bool
+		Expected    time.Duration
+	}{
+		{
+			Name: "SF7 uplink",
+			TXInfo: gw.UplinkTXInfo{
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{Bandwidth: 125, SpreadingFactor: 7, CodeRate: "4/5"},
+				},
+			},
+			PayloadSize: 23,
+			Uplink:      true,
+			Expected:    61696 * time.Microsecond,
+		},
+		{
+			Name: "SF12 uplink (low data-rate optimization)",
+			TXInfo: gw.UplinkTXInfo{
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{Bandwidth: 125, SpreadingFactor: 12, CodeRate: "4/5"},
+				},
+			},
+			PayloadSize: 23,
+			Uplink:      true,
+			Expected:    1482752 * time.Microsecond,
+		},
+		{
+			Name: "SF7 downlink",
+			TXInfo: gw.UplinkTXInfo{
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{Bandwidth: 125, SpreadingFactor: 7},
+				},
+			},
+			PayloadSize: 12,
+			Expected:    41216 * time.Microsecond,
+		},
+		{
+			Name: "FSK",
+			TXInfo: gw.UplinkTXInfo{
+				Modulation: common.Modulation_FSK,
+				ModulationInfo: &gw.UplinkTXInfo_FskModulationInfo{
+					FskModulationInfo: &gw.FSKModulationInfo{Bitrate: 50000},
+				},
+			},
+			PayloadSize: 23,
+			Uplink:      true,
+			Expected:    5440 * time.Microsecond,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			airtime, err := Airtime(&tst.TXInfo, tst.PayloadSize, tst.Uplink)
+			assert.NoError(err)
+			assert.Equal(tst.Expected, airtime)
+		})
+	}
+
+	t.Run("Invalid code-rate", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := Airtime(&gw.UplinkTXInfo{
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{Bandwidth: 125, SpreadingFactor: 7, CodeRate: "4/9"},
+			},
+		}, 10, true)
+		assert.Error(err)
+	})
+}
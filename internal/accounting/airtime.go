@@ -0,0 +1,103 @@
+package accounting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+)
+
+const (
+	loraPreambleLength = 8
+	fskPreambleLength  = 5
+	fskSyncWordLength  = 3
+	fskLengthLength    = 1
+	fskCRCLength       = 2
+
+	// the low data-rate optimization is enabled for symbol durations of
+	// 16ms and above
+	lowDataRateOptimizeSymbolTime = 16 * time.Millisecond
+)
+
+// Airtime returns the time-on-air of a frame with the given payload size
+// (in bytes) and modulation. Uplink frames have an explicit header and
+// a payload CRC, downlink frames an explicit header only.
+func Airtime(v helpers.DataRateGetter, payloadSize int, uplink bool) (time.Duration, error) {
+	switch v.GetModulation() {
+	case common.Modulation_LORA:
+		modInfo := v.GetLoraModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("lora_modulation_info must not be nil")
+		}
+		if modInfo.Bandwidth == 0 {
+			return 0, errors.New("bandwidth must not be 0")
+		}
+
+		cr, err := codeRate(modInfo.CodeRate)
+		if err != nil {
+			return 0, err
+		}
+
+		return loraAirtime(payloadSize, int(modInfo.SpreadingFactor), int(modInfo.Bandwidth)*1000, cr, uplink), nil
+	case common.Modulation_FSK:
+		modInfo := v.GetFskModulationInfo()
+		if modInfo == nil {
+			return 0, errors.New("fsk_modulation_info must not be nil")
+		}
+		if modInfo.Bitrate == 0 {
+			return 0, errors.New("bitrate must not be 0")
+		}
+
+		bits := (fskPreambleLength + fskSyncWordLength + fskLengthLength + payloadSize + fskCRCLength) * 8
+		return time.Duration(bits) * time.Second / time.Duration(modInfo.Bitrate), nil
+	default:
+		return 0, fmt.Errorf("unknown modulation: %s", v.GetModulation())
+	}
+}
+
+// loraAirtime implements the time-on-air formula from the SX1276 datasheet.
+// The bandwidth is in Hz.
+func loraAirtime(payloadSize, sf, bandwidth, cr int, crc bool) time.Duration {
+	tSym := time.Duration(1<<uint(sf)) * time.Second / time.Duration(bandwidth)
+
+	var de, crcBits int
+	if tSym >= lowDataRateOptimizeSymbolTime {
+		de = 1
+	}
+	if crc {
+		crcBits = 16
+	}
+
+	// explicit header
+	numerator := 8*payloadSize - 4*sf + 28 + crcBits
+	denominator := 4 * (sf - 2*de)
+
+	payloadSymbols := 8
+	if numerator > 0 {
+		payloadSymbols += ((numerator + denominator - 1) / denominator) * (cr + 4)
+	}
+
+	preamble := time.Duration(4*loraPreambleLength+17) * tSym / 4
+
+	return preamble + time.Duration(payloadSymbols)*tSym
+}
+
+// codeRate returns the code-rate value (1 - 4) for the given code-rate
+// string. An empty code-rate defaults to 4/5.
+func codeRate(s string) (int, error) {
+	switch s {
+	case "", "4/5":
+		return 1, nil
+	case "4/6":
+		return 2, nil
+	case "4/7":
+		return 3, nil
+	case "4/8":
+		return 4, nil
+	default:
+		return 0, fmt.Errorf("unknown code-rate: %s", s)
+	}
+}
@@ -0,0 +1,197 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"text/template"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const defaultBatchSize = 100
+
+// mqttForwarder publishes the stored accounting records to a MQTT broker.
+// Records are removed after the broker has acknowledged the publication, as
+// records might be published more than once, consumers must de-duplicate
+// records using the record id.
+type mqttForwarder struct {
+	conn            paho.Client
+	topicTemplate   *template.Template
+	qos             byte
+	forwardInterval time.Duration
+	batchSize       int
+}
+
+func newMQTTForwarder(c config.Config) (*mqttForwarder, error) {
+	conf := c.Accounting.MQTT
+
+	if conf.QOS == 0 {
+		return nil, errors.New("accounting.mqtt.qos must be 1 or 2 for at-least-once delivery")
+	}
+
+	f := mqttForwarder{
+		qos:             conf.QOS,
+		forwardInterval: conf.ForwardInterval,
+		batchSize:       conf.BatchSize,
+	}
+	if f.batchSize <= 0 {
+		f.batchSize = defaultBatchSize
+	}
+
+	var err error
+	f.topicTemplate, err = template.New("topic").Parse(conf.TopicTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse topic template error")
+	}
+
+	opts := paho.NewClientOptions()
+	opts.AddBroker(conf.Server)
+	opts.SetUsername(conf.Username)
+	opts.SetPassword(conf.Password)
+	opts.SetClientID(conf.ClientID)
+	opts.SetCleanSession(true)
+	opts.SetAutoReconnect(true)
+
+	tlsConfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "load tls config error")
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	log.WithField("server", conf.Server).Info("accounting: connecting to mqtt broker")
+	f.conn = paho.NewClient(opts)
+	for {
+		if token := f.conn.Connect(); token.Wait() && token.Error() != nil {
+			log.Errorf("accounting: connecting to mqtt broker failed, will retry in 2s: %s", token.Error())
+			time.Sleep(2 * time.Second)
+		} else {
+			break
+		}
+	}
+
+	return &f, nil
+}
+
+func (f *mqttForwarder) forwardLoop() {
+	for {
+		ctxID, err := uuid.NewV4()
+		if err != nil {
+			log.WithError(err).Error("accounting: get new uuid error")
+		}
+
+		ctx := context.Background()
+		ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+
+		n, err := f.forward(ctx)
+		if err != nil {
+			log.WithError(err).WithField("ctx_id", ctxID).Error("accounting: forward accounting records error")
+		}
+
+		// continue directly when the batch was full
+		if n < f.batchSize {
+			time.Sleep(f.forwardInterval)
+		}
+	}
+}
+
+// forward publishes a batch of accounting records and returns the number of
+// published records. Publication stops at the first error, the published
+// records are removed in that case too.
+func (f *mqttForwarder) forward(ctx context.Context) (int, error) {
+	var published []int64
+	var publishErr error
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		records, err := storage.GetAccountingRecords(ctx, tx, f.batchSize, true)
+		if err != nil {
+			return errors.Wrap(err, "get accounting records error")
+		}
+
+		for _, r := range records {
+			if err := f.publish(r); err != nil {
+				publishErr = err
+				break
+			}
+			published = append(published, r.ID)
+		}
+
+		if err := storage.DeleteAccountingRecords(ctx, tx, published); err != nil {
+			return errors.Wrap(err, "delete accounting records error")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(published), publishErr
+}
+
+func (f *mqttForwarder) publish(r storage.AccountingRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "marshal record error")
+	}
+
+	topic := bytes.NewBuffer(nil)
+	if err := f.topicTemplate.Execute(topic, r); err != nil {
+		return errors.Wrap(err, "execute topic template error")
+	}
+
+	if token := f.conn.Publish(topic.String(), f.qos, false, b); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "publish error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":    r.ID,
+		"topic": topic.String(),
+	}).Debug("accounting: accounting record published")
+
+	return nil
+}
+
+func newTLSConfig(caCert, tlsCert, tlsKey string) (*tls.Config, error) {
+	if caCert == "" && tlsCert == "" && tlsKey == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caCert != "" {
+		b, err := nstls.ReadPEM(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca certificate error")
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(b) {
+			return nil, errors.New("append ca certificate error")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if tlsCert != "" && tlsKey != "" {
+		kp, err := nstls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{kp}
+	}
+
+	return tlsConfig, nil
+}
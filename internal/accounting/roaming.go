@@ -0,0 +1,114 @@
+package accounting
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// RoamingUplink creates the roaming accounting record for the given roamed
+// uplink. Unlike the per-frame accounting records, the roaming records are
+// always created as these are required for the settlement with the partner
+// network.
+func RoamingUplink(ctx context.Context, role storage.RoamingRole, netID lorawan.NetID, devAddr lorawan.DevAddr, rxPacket models.RXPacket) error {
+	b, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	airtime, err := Airtime(rxPacket.TXInfo, len(b), true)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	r := storage.RoamingAccountingRecord{
+		Role:        role,
+		Direction:   storage.AccountingUplink,
+		NetID:       netID,
+		DevAddr:     devAddr,
+		PayloadSize: len(b),
+		Airtime:     airtime,
+		Frequency:   int(rxPacket.TXInfo.Frequency),
+		DR:          rxPacket.DR,
+	}
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		r.GatewayIDs = append(r.GatewayIDs, helpers.GetGatewayID(rxInfo))
+
+		// use the RF meta-data of the gateway with the best signal
+		if r.RSSI == nil || int(rxInfo.Rssi) > *r.RSSI {
+			rssi := int(rxInfo.Rssi)
+			snr := rxInfo.LoraSnr
+			r.RSSI = &rssi
+			r.SNR = &snr
+		}
+
+		if r.GatewayTime == nil && rxInfo.Time != nil {
+			ts, err := ptypes.Timestamp(rxInfo.Time)
+			if err == nil {
+				r.GatewayTime = &ts
+			}
+		}
+	}
+
+	if err := storage.CreateRoamingAccountingRecord(ctx, storage.DB(), &r); err != nil {
+		return errors.Wrap(err, "create roaming accounting record error")
+	}
+
+	return nil
+}
+
+// RoamingDownlink creates the roaming accounting record for the given roamed
+// downlink.
+func RoamingDownlink(ctx context.Context, role storage.RoamingRole, netID lorawan.NetID, devAddr lorawan.DevAddr, frame gw.DownlinkFrame) error {
+	if frame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(frame.TxInfo)
+
+	var region string
+	if band.HasRegions() {
+		var err error
+		region, err = storage.GetRegionForGateway(ctx, storage.DB(), gatewayID)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get region for gateway error")
+		}
+	}
+
+	dr, err := helpers.GetDataRateIndex(false, frame.TxInfo, band.Get(region))
+	if err != nil {
+		return errors.Wrap(err, "get data-rate index error")
+	}
+
+	airtime, err := Airtime(frame.TxInfo, len(frame.PhyPayload), false)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	r := storage.RoamingAccountingRecord{
+		Role:        role,
+		Direction:   storage.AccountingDownlink,
+		NetID:       netID,
+		DevAddr:     devAddr,
+		GatewayIDs:  []lorawan.EUI64{gatewayID},
+		PayloadSize: len(frame.PhyPayload),
+		Airtime:     airtime,
+		Frequency:   int(frame.TxInfo.Frequency),
+		DR:          dr,
+	}
+
+	if err := storage.CreateRoamingAccountingRecord(ctx, storage.DB(), &r); err != nil {
+		return errors.Wrap(err, "create roaming accounting record error")
+	}
+
+	return nil
+}
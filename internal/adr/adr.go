@@ -3,6 +3,8 @@ package adr
 import (
 	"context"
 	"fmt"
+	"math"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -24,17 +26,28 @@ var pktLossRateTable = [][3]uint8{
 // disableADR disables the ADR engine when set to true.
 var disableADR bool
 
-// installationMargin defines the ADR installation-margin.
-var installationMargin float64
+// installationMargin defines the ADR installation-margin (float64 bits).
+// It is accessed atomically as it can be changed at runtime.
+var installationMargin uint64
 
 // Setup configures the adr engine.
 func Setup(c config.Config) error {
 	disableADR = c.NetworkServer.NetworkSettings.DisableADR
-	installationMargin = c.NetworkServer.NetworkSettings.InstallationMargin
+	SetInstallationMargin(c.NetworkServer.NetworkSettings.InstallationMargin)
 
 	return nil
 }
 
+// SetInstallationMargin sets the ADR installation-margin.
+// This is safe to call while the ADR engine is in use.
+func SetInstallationMargin(margin float64) {
+	atomic.StoreUint64(&installationMargin, math.Float64bits(margin))
+}
+
+func getInstallationMargin() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&installationMargin))
+}
+
 // HandleADR handles ADR in case requested by the node and configured
 // in the device-session.
 func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.DeviceSession, linkADRReqBlock *storage.MACCommandBlock) ([]storage.MACCommandBlock, error) {
@@ -57,7 +70,7 @@ func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.Device
 		}
 	}
 
-	dr, err := band.Band().GetDataRate(ds.DR)
+	dr, err := band.Get(ds.Region).GetDataRate(ds.DR)
 	if err != nil {
 		return nil, errors.Wrap(err, "get data-rate error")
 	}
@@ -67,7 +80,7 @@ func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.Device
 		return nil, err
 	}
 
-	snrMargin := snrM - requiredSNR - installationMargin
+	snrMargin := snrM - requiredSNR - getInstallationMargin()
 	nStep := int(snrMargin / 3)
 
 	// In case of negative steps the ADR algorithm will increase the TXPower
@@ -86,7 +99,13 @@ func HandleADR(ctx context.Context, sp storage.ServiceProfile, ds storage.Device
 		idealDR = maxSupportedDR
 		idealTXPowerIndex = ds.TXPowerIndex
 	} else {
-		idealTXPowerIndex, idealDR = getIdealTXPowerOffsetAndDR(nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+		idealTXPowerIndex, idealDR = getIdealTXPowerOffsetAndDR(ds.Region, nStep, ds.TXPowerIndex, ds.DR, ds.MinSupportedTXPowerIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+	}
+
+	// make sure the data-rate is allowed under the acknowledged uplink
+	// dwell-time (e.g. AS923 does not allow DR0 and DR1 with 400ms dwell-time)
+	if minDR := band.GetMinAllowedDataRate(ds.Region, ds.UplinkDwellTime400ms, ds.MACVersion, "", maxSupportedDR); minDR != -1 && idealDR < minDR {
+		idealDR = minDR
 	}
 
 	idealNbRep := getNbRep(ds.NbTrans, ds.GetPacketLossPercentage())
@@ -177,10 +196,14 @@ func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
 	return pktLossRateTable[3][currentNbRep-1]
 }
 
-func getMaxTXPowerOffsetIndex() int {
+// getMaxTXPowerOffsetIndex returns the max TX power offset index of the band
+// of the given region.
+func getMaxTXPowerOffsetIndex(region string) int {
+	b := band.Get(region)
+
 	var idx int
 	for i := 0; ; i++ {
-		offset, err := band.Band().GetTXPowerOffset(i)
+		offset, err := b.GetTXPowerOffset(i)
 		if err != nil {
 			break
 		}
@@ -196,10 +219,10 @@ func getMaxSupportedTXPowerOffsetIndexForDevice(ds storage.DeviceSession) int {
 	if ds.MaxSupportedTXPowerIndex != 0 {
 		return ds.MaxSupportedTXPowerIndex
 	}
-	return getMaxTXPowerOffsetIndex()
+	return getMaxTXPowerOffsetIndex(ds.Region)
 }
 
-func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR int) (int, int) {
+func getIdealTXPowerOffsetAndDR(region string, nStep int, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR int) (int, int) {
 	if nStep == 0 {
 		return txPowerOffsetIndex, dr
 	}
@@ -211,7 +234,7 @@ func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPow
 			// might not be equal to the getMaxAllowedDR value.
 			dr++
 
-		} else if txPowerOffsetIndex < getMaxTXPowerOffsetIndex() && txPowerOffsetIndex < maxSupportedTXPowerOffsetIndex {
+		} else if txPowerOffsetIndex < getMaxTXPowerOffsetIndex(region) && txPowerOffsetIndex < maxSupportedTXPowerOffsetIndex {
 			// maxSupportedTXPowerOffsetIndex is the max supported TXPower
 			// index by the node. Depending the Regional Parameters
 			// specification the node is implementing, this might not be
@@ -221,8 +244,8 @@ func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPow
 		}
 
 		nStep--
-		if txPowerOffsetIndex >= getMaxTXPowerOffsetIndex() {
-			return getMaxTXPowerOffsetIndex(), dr
+		if txPowerOffsetIndex >= getMaxTXPowerOffsetIndex(region) {
+			return getMaxTXPowerOffsetIndex(region), dr
 		}
 
 	} else {
@@ -234,7 +257,7 @@ func getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPow
 		}
 	}
 
-	return getIdealTXPowerOffsetAndDR(nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
+	return getIdealTXPowerOffsetAndDR(region, nStep, txPowerOffsetIndex, dr, minSupportedTXPowerOffsetIndex, maxSupportedTXPowerOffsetIndex, maxSupportedDR)
 }
 
 func getRequiredSNRForSF(sf int) (float64, error) {
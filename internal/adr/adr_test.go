@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 	. "github.com/smartystreets/goconvey/convey"
@@ -41,13 +44,13 @@ func TestADR(t *testing.T) {
 		})
 
 		Convey("getMaxTXPowerOffsetIndex returns 7", func() {
-			So(getMaxTXPowerOffsetIndex(), ShouldEqual, 7)
+			So(getMaxTXPowerOffsetIndex(""), ShouldEqual, 7)
 		})
 
 		Convey("Testing getMaxSupportedTXPowerOffsetIndexForDevice", func() {
 			Convey("When no MaxSupportedTXPowerIndex is set on the device session, it returns getMaxTXPowerOffsetIndex", func() {
 				ds := storage.DeviceSession{}
-				So(getMaxSupportedTXPowerOffsetIndexForDevice(ds), ShouldEqual, getMaxTXPowerOffsetIndex())
+				So(getMaxSupportedTXPowerOffsetIndexForDevice(ds), ShouldEqual, getMaxTXPowerOffsetIndex(""))
 			})
 
 			Convey("When MaxSupportedTXPowerIndex is set on the device session, this value is returned", func() {
@@ -55,7 +58,7 @@ func TestADR(t *testing.T) {
 					MaxSupportedTXPowerIndex: 3,
 				}
 				So(getMaxSupportedTXPowerOffsetIndexForDevice(ds), ShouldEqual, ds.MaxSupportedTXPowerIndex)
-				So(getMaxSupportedTXPowerOffsetIndexForDevice(ds), ShouldNotEqual, getMaxTXPowerOffsetIndex())
+				So(getMaxSupportedTXPowerOffsetIndexForDevice(ds), ShouldNotEqual, getMaxTXPowerOffsetIndex(""))
 			})
 		})
 
@@ -76,7 +79,7 @@ func TestADR(t *testing.T) {
 					NStep:                    0,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       3,
 					ExpectedDR:               3,
 					ExpectedTXPowerIndex:     1,
@@ -86,7 +89,7 @@ func TestADR(t *testing.T) {
 					NStep:                    1,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       4,
 					ExpectedDR:               5,
 					ExpectedTXPowerIndex:     1,
@@ -96,7 +99,7 @@ func TestADR(t *testing.T) {
 					NStep:                    1,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       5,
 					ExpectedDR:               5,
 					ExpectedTXPowerIndex:     2,
@@ -106,7 +109,7 @@ func TestADR(t *testing.T) {
 					NStep:                    2,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       3,
 					ExpectedDR:               5,
 					ExpectedTXPowerIndex:     1,
@@ -116,7 +119,7 @@ func TestADR(t *testing.T) {
 					NStep:                    2,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           4,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       3,
 					ExpectedDR:               4,
 					ExpectedTXPowerIndex:     2,
@@ -126,7 +129,7 @@ func TestADR(t *testing.T) {
 					NStep:                    2,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       4,
 					ExpectedDR:               5,
 					ExpectedTXPowerIndex:     2,
@@ -136,7 +139,7 @@ func TestADR(t *testing.T) {
 					NStep:                    2,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       5,
 					ExpectedDR:               5,
 					ExpectedTXPowerIndex:     3,
@@ -156,7 +159,7 @@ func TestADR(t *testing.T) {
 					NStep:                    -1,
 					TXPowerIndex:             1,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       4,
 					ExpectedDR:               4,
 					ExpectedTXPowerIndex:     0,
@@ -166,7 +169,7 @@ func TestADR(t *testing.T) {
 					NStep:                    -1,
 					TXPowerIndex:             0,
 					MaxSupportedDR:           5,
-					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(), // 5
+					MaxSupportedTXPowerIndex: getMaxTXPowerOffsetIndex(""), // 5
 					DR:                       4,
 					ExpectedDR:               4,
 					ExpectedTXPowerIndex:     0,
@@ -185,7 +188,7 @@ func TestADR(t *testing.T) {
 			for i, tst := range testTable {
 				Convey(fmt.Sprintf("Testing '%s' with NStep: %d, TXPowerOffsetIndex: %d, DR: %d [%d]", tst.Name, tst.NStep, tst.TXPowerIndex, tst.DR, i), func() {
 					Convey(fmt.Sprintf("Then the ideal TXPowerOffsetIndex is %d and DR %d", tst.ExpectedTXPowerIndex, tst.ExpectedDR), func() {
-						idealTXPowerIndex, idealDR := getIdealTXPowerOffsetAndDR(tst.NStep, tst.TXPowerIndex, tst.DR, tst.MinSupportedTXPowerIndex, tst.MaxSupportedTXPowerIndex, tst.MaxSupportedDR)
+						idealTXPowerIndex, idealDR := getIdealTXPowerOffsetAndDR("", tst.NStep, tst.TXPowerIndex, tst.DR, tst.MinSupportedTXPowerIndex, tst.MaxSupportedTXPowerIndex, tst.MaxSupportedDR)
 						So(idealTXPowerIndex, ShouldEqual, tst.ExpectedTXPowerIndex)
 						So(idealDR, ShouldEqual, tst.ExpectedDR)
 					})
@@ -659,3 +662,36 @@ func TestADR(t *testing.T) {
 		})
 	})
 }
+
+func TestADRRegions(t *testing.T) {
+	conf := test.GetConfig()
+	conf.NetworkServer.Regions = []config.Region{
+		{Name: "us915", Band: loraband.US_902_928},
+	}
+	if err := band.Setup(conf); err != nil {
+		t.Fatal(err)
+	}
+	defer band.Setup(test.GetConfig())
+
+	Convey("Given gateways in the default (EU868) and the us915 region", t, func() {
+		Convey("Then getMaxTXPowerOffsetIndex returns the index of the band of the region", func() {
+			So(getMaxTXPowerOffsetIndex(""), ShouldEqual, 7)
+			So(getMaxTXPowerOffsetIndex("us915"), ShouldEqual, 10)
+		})
+
+		Convey("Then getMaxSupportedTXPowerOffsetIndexForDevice uses the region of the device-session", func() {
+			So(getMaxSupportedTXPowerOffsetIndexForDevice(storage.DeviceSession{}), ShouldEqual, 7)
+			So(getMaxSupportedTXPowerOffsetIndexForDevice(storage.DeviceSession{Region: "us915"}), ShouldEqual, 10)
+		})
+
+		Convey("Then getIdealTXPowerOffsetAndDR caps the TX power offset index per region", func() {
+			txPowerIndex, dr := getIdealTXPowerOffsetAndDR("", 10, 5, 5, 0, 14, 5)
+			So(txPowerIndex, ShouldEqual, 7)
+			So(dr, ShouldEqual, 5)
+
+			txPowerIndex, dr = getIdealTXPowerOffsetAndDR("us915", 10, 5, 3, 0, 14, 3)
+			So(txPowerIndex, ShouldEqual, 10)
+			So(dr, ShouldEqual, 3)
+		})
+	})
+}
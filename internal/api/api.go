@@ -1,8 +1,6 @@
 package api
 
 import (
-	"net"
-
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
@@ -10,47 +8,71 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/mxc-foundation/lpwan-server/api/ns"
+	"github.com/mxc-foundation/lpwan-server/internal/certmanager"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/tls"
 )
 
+var server *grpc.Server
+
 func Setup(c config.Config) error {
 	apiConfig := c.NetworkServer.API
 
+	// the tls settings are not logged as these might contain the (PEM
+	// encoded) key itself
 	log.WithFields(log.Fields{
-		"bind":     apiConfig.Bind,
-		"ca-cert":  apiConfig.CACert,
-		"tls-cert": apiConfig.TLSCert,
-		"tls-key":  apiConfig.TLSKey,
+		"bind": apiConfig.Bind,
+		"tls":  apiConfig.TLSCert != "" || certmanager.Enabled(),
 	}).Info("api: starting network-server api server")
 
 	opts := serverOptions()
 
-	if apiConfig.CACert != "" || apiConfig.TLSCert != "" || apiConfig.TLSKey != "" {
+	if certmanager.Enabled() {
+		tlsConfig, err := certmanager.TLSConfig(apiConfig.CACert)
+		if err != nil {
+			return errors.Wrap(err, "get tls config error")
+		}
+
+		opts = append(opts, grpc.Creds(securityCredentials{credentials.NewTLS(tlsConfig)}))
+	} else if apiConfig.CACert != "" || apiConfig.TLSCert != "" || apiConfig.TLSKey != "" {
 		creds, err := tls.GetTransportCredentials(apiConfig.CACert, apiConfig.TLSCert, apiConfig.TLSKey, true)
 		if err != nil {
 			return errors.Wrap(err, "get transport credentials error")
 		}
 
-		opts = append(opts, grpc.Creds(creds))
+		opts = append(opts, grpc.Creds(securityCredentials{creds}))
 	}
 
 	gs := grpc.NewServer(opts...)
 	nsAPI := NewNetworkServerAPI()
 	ns.RegisterNetworkServerServiceServer(gs, nsAPI)
 
-	ln, err := net.Listen("tcp", apiConfig.Bind)
+	ln, err := handover.Listen("api", apiConfig.Bind)
 	if err != nil {
 		return errors.Wrap(err, "start api listener error")
 	}
 	go gs.Serve(ln)
+	server = gs
 
 	return nil
 }
 
+// Stop stops the api server gracefully, it waits until the pending requests
+// have been completed.
+func Stop() {
+	if server == nil {
+		return
+	}
+
+	log.Info("api: stopping network-server api server")
+	server.GracefulStop()
+}
+
 func serverOptions() []grpc.ServerOption {
 	logrusEntry := log.NewEntry(log.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
@@ -0,0 +1,139 @@
+package asclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+// batchTimeout defines the timeout for sending a batch.
+const batchTimeout = time.Second
+
+// uplinkBatcher collects HandleUplinkData calls and sends these as a single
+// HandleUplinkDataBatch call, once the batch is full or after the batch
+// interval. When the application-server does not implement the batch
+// method, it falls back to individual HandleUplinkData calls.
+type uplinkBatcher struct {
+	hostname string
+	maxSize  int
+	interval time.Duration
+	pick     func() as.ApplicationServerServiceClient
+
+	unsupported int32
+
+	mu      sync.Mutex
+	current *uplinkBatch
+}
+
+type uplinkBatch struct {
+	ctxs  []context.Context
+	items []*as.HandleUplinkDataRequest
+
+	// done is closed once the batch has been sent, after which errs
+	// contains the error for each item.
+	done chan struct{}
+	errs []error
+}
+
+func newUplinkBatcher(hostname string, maxSize int, interval time.Duration, pick func() as.ApplicationServerServiceClient) *uplinkBatcher {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+
+	return &uplinkBatcher{
+		hostname: hostname,
+		maxSize:  maxSize,
+		interval: interval,
+		pick:     pick,
+	}
+}
+
+// handleUplinkData adds the given item to the current batch and blocks
+// until the batch has been sent or the given context is done.
+func (b *uplinkBatcher) handleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest) error {
+	if atomic.LoadInt32(&b.unsupported) == 1 {
+		_, err := b.pick().HandleUplinkData(ctx, in)
+		return err
+	}
+
+	b.mu.Lock()
+	batch := b.current
+	if batch == nil {
+		batch = &uplinkBatch{
+			done: make(chan struct{}),
+		}
+		b.current = batch
+		go b.flushAfterInterval(batch)
+	}
+
+	i := len(batch.items)
+	batch.ctxs = append(batch.ctxs, ctx)
+	batch.items = append(batch.items, in)
+
+	full := len(batch.items) >= b.maxSize
+	if full {
+		b.current = nil
+	}
+	b.mu.Unlock()
+
+	if full {
+		go b.flush(batch)
+	}
+
+	select {
+	case <-batch.done:
+		return batch.errs[i]
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *uplinkBatcher) flushAfterInterval(batch *uplinkBatch) {
+	<-clock.After(b.interval)
+
+	b.mu.Lock()
+	if b.current != batch {
+		// the batch was full and has already been sent
+		b.mu.Unlock()
+		return
+	}
+	b.current = nil
+	b.mu.Unlock()
+
+	b.flush(batch)
+}
+
+func (b *uplinkBatcher) flush(batch *uplinkBatch) {
+	defer close(batch.done)
+
+	batch.errs = make([]error, len(batch.items))
+
+	ctx, cancel := context.WithTimeout(context.Background(), batchTimeout)
+	defer cancel()
+
+	_, err := b.pick().HandleUplinkDataBatch(ctx, &as.HandleUplinkDataBatchRequest{
+		Items: batch.items,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		if atomic.CompareAndSwapInt32(&b.unsupported, 0, 1) {
+			log.WithField("server", b.hostname).Warning("application-server does not implement HandleUplinkDataBatch, batching disabled")
+		}
+
+		for i := range batch.items {
+			_, batch.errs[i] = b.pick().HandleUplinkData(batch.ctxs[i], batch.items[i])
+		}
+		return
+	}
+
+	for i := range batch.errs {
+		batch.errs[i] = err
+	}
+}
@@ -0,0 +1,184 @@
+package asclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+type testBatchClient struct {
+	as.ApplicationServerServiceClient
+
+	mu       sync.Mutex
+	batchErr error
+	batches  [][]uint32
+	singles  []uint32
+}
+
+func (c *testBatchClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.singles = append(c.singles, in.FCnt)
+	return &empty.Empty{}, nil
+}
+
+func (c *testBatchClient) HandleUplinkDataBatch(ctx context.Context, in *as.HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.batchErr != nil {
+		return nil, c.batchErr
+	}
+
+	var fCnts []uint32
+	for _, item := range in.Items {
+		fCnts = append(fCnts, item.FCnt)
+	}
+	c.batches = append(c.batches, fCnts)
+	return &empty.Empty{}, nil
+}
+
+func TestUplinkBatcher(t *testing.T) {
+	m := clock.NewMock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.Set(m)
+	defer clock.Set(nil)
+
+	// handle calls handleUplinkData for the given frame-counters in
+	// parallel and returns the errors once all calls have returned.
+	handle := func(b *uplinkBatcher, fCnts ...uint32) []error {
+		var wg sync.WaitGroup
+		errs := make([]error, len(fCnts))
+		for i := range fCnts {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = b.handleUplinkData(context.Background(), &as.HandleUplinkDataRequest{FCnt: fCnts[i]})
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	t.Run("Interval", func(t *testing.T) {
+		assert := require.New(t)
+		client := testBatchClient{}
+		b := newUplinkBatcher("as-1", 3, time.Second, func() as.ApplicationServerServiceClient { return &client })
+
+		done := make(chan []error)
+		go func() {
+			done <- handle(b, 1)
+		}()
+
+		for m.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		m.Add(time.Second)
+
+		select {
+		case errs := <-done:
+			assert.NoError(errs[0])
+		case <-time.After(time.Second):
+			t.Fatal("batch was not sent")
+		}
+		assert.Equal([][]uint32{{1}}, client.batches)
+	})
+
+	t.Run("Full batch", func(t *testing.T) {
+		assert := require.New(t)
+		client := testBatchClient{}
+		b := newUplinkBatcher("as-1", 3, time.Second, func() as.ApplicationServerServiceClient { return &client })
+
+		for _, err := range handle(b, 1, 2, 3) {
+			assert.NoError(err)
+		}
+		assert.Len(client.batches, 1)
+		assert.ElementsMatch([]uint32{1, 2, 3}, client.batches[0])
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		assert := require.New(t)
+		client := testBatchClient{
+			batchErr: status.Error(codes.Unavailable, "unavailable"),
+		}
+		b := newUplinkBatcher("as-1", 2, time.Second, func() as.ApplicationServerServiceClient { return &client })
+
+		for _, err := range handle(b, 1, 2) {
+			assert.Equal(codes.Unavailable, status.Code(err))
+		}
+	})
+
+	t.Run("Unimplemented falls back to single calls", func(t *testing.T) {
+		assert := require.New(t)
+		client := testBatchClient{
+			batchErr: status.Error(codes.Unimplemented, "unimplemented"),
+		}
+		b := newUplinkBatcher("as-1", 2, time.Second, func() as.ApplicationServerServiceClient { return &client })
+
+		for _, err := range handle(b, 1, 2) {
+			assert.NoError(err)
+		}
+		assert.ElementsMatch([]uint32{1, 2}, client.singles)
+
+		// batching is disabled
+		for _, err := range handle(b, 3) {
+			assert.NoError(err)
+		}
+		assert.ElementsMatch([]uint32{1, 2, 3}, client.singles)
+		assert.Len(client.batches, 0)
+	})
+}
+
+func TestPoolCloseIdle(t *testing.T) {
+	assert := require.New(t)
+
+	m := clock.NewMock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.Set(m)
+	defer clock.Set(nil)
+
+	p := pool{
+		opts: PoolOptions{
+			IdleTimeout: time.Minute,
+		},
+		entries: make(map[string][]*entry),
+	}
+
+	newEntry := func(caCert []byte) *entry {
+		e := entry{
+			hostname: "as-1",
+			caCert:   caCert,
+			ready:    make(chan struct{}),
+			lastUsed: clock.Now().UnixNano(),
+		}
+		close(e.ready)
+		p.entries[e.hostname] = append(p.entries[e.hostname], &e)
+		return &e
+	}
+
+	// the same application-server, used with different certificates
+	e1 := newEntry([]byte{1})
+	e2 := newEntry([]byte{2})
+	assert.Equal(e1, p.lookup("as-1", []byte{1}, nil, nil))
+	assert.Equal(e2, p.lookup("as-1", []byte{2}, nil, nil))
+	assert.Nil(p.lookup("as-1", nil, nil, nil))
+
+	m.Add(time.Minute)
+	e2.lastUsed = clock.Now().UnixNano()
+	m.Add(time.Second)
+
+	p.closeIdle()
+	assert.Nil(p.lookup("as-1", []byte{1}, nil, nil))
+	assert.Equal(e2, p.lookup("as-1", []byte{2}, nil, nil))
+
+	m.Add(time.Minute)
+	p.closeIdle()
+	assert.Len(p.entries, 0)
+}
@@ -0,0 +1,354 @@
+package asclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+)
+
+// UnhealthyDuration defines the duration an application-server is skipped
+// after it has been detected as unavailable. It is not used for the
+// application-servers which are health-checked.
+var UnhealthyDuration = 30 * time.Second
+
+// healthCheckExpiryIntervals defines the number of health-check intervals
+// after which an application-server which is no longer used by a failover
+// client is no longer health-checked.
+const healthCheckExpiryIntervals = 10
+
+var (
+	healthMux      sync.RWMutex
+	unhealthyUntil = make(map[string]time.Time)
+
+	counterMux sync.Mutex
+	counters   = make(map[string]*uint32)
+
+	watchMux           sync.Mutex
+	healthCheckEnabled bool
+	watched            = make(map[watchKey]*watchedServer)
+)
+
+// HealthChecker defines the interface implemented by pools which are able
+// to check the health of an application-server.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context, hostname string, caCert, tlsCert, tlsKey []byte) error
+}
+
+type watchKey struct {
+	hostname string
+	caCert   string
+	tlsCert  string
+	tlsKey   string
+}
+
+type watchedServer struct {
+	checker  HealthChecker
+	lastUsed time.Time
+}
+
+type failoverClient struct {
+	pool       Pool
+	hostnames  []string
+	caCert     []byte
+	tlsCert    []byte
+	tlsKey     []byte
+	roundRobin bool
+}
+
+// NewFailoverClient returns an ApplicationServerServiceClient which fails over
+// to the next application-server (in the given order) when an
+// application-server is unavailable. Unavailable application-servers are
+// skipped for UnhealthyDuration, unless all application-servers are
+// unavailable. When the health-check has been started and the pool
+// implements HealthChecker, unavailable application-servers are skipped
+// until their health-check succeeds. When roundRobin is set, the requests
+// are distributed over the given application-servers.
+func NewFailoverClient(p Pool, hostnames []string, caCert, tlsCert, tlsKey []byte, roundRobin bool) as.ApplicationServerServiceClient {
+	if checker, ok := p.(HealthChecker); ok {
+		watch(checker, hostnames, caCert, tlsCert, tlsKey)
+	}
+
+	return &failoverClient{
+		pool:       p,
+		hostnames:  hostnames,
+		caCert:     caCert,
+		tlsCert:    tlsCert,
+		tlsKey:     tlsKey,
+		roundRobin: roundRobin,
+	}
+}
+
+// HandleUplinkData handles uplink data received from an end-device.
+func (c *failoverClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleUplinkData(ctx, in, opts...)
+	})
+}
+
+// HandleUplinkDataBatch handles a batch of uplink data received from
+// end-devices.
+func (c *failoverClient) HandleUplinkDataBatch(ctx context.Context, in *as.HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleUplinkDataBatch(ctx, in, opts...)
+	})
+}
+
+// HandleProprietaryUplink handles proprietary uplink payloads.
+func (c *failoverClient) HandleProprietaryUplink(ctx context.Context, in *as.HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleProprietaryUplink(ctx, in, opts...)
+	})
+}
+
+// HandleError handles an error message.
+func (c *failoverClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleError(ctx, in, opts...)
+	})
+}
+
+// HandleDownlinkACK handles a downlink ACK or nACK response.
+func (c *failoverClient) HandleDownlinkACK(ctx context.Context, in *as.HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleDownlinkACK(ctx, in, opts...)
+	})
+}
+
+// HandleGatewayStats handles the given gateway stats.
+func (c *failoverClient) HandleGatewayStats(ctx context.Context, in *as.HandleGatewayStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.HandleGatewayStats(ctx, in, opts...)
+	})
+}
+
+// SetDeviceStatus updates the device-status for a device.
+func (c *failoverClient) SetDeviceStatus(ctx context.Context, in *as.SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.SetDeviceStatus(ctx, in, opts...)
+	})
+}
+
+// SetDeviceLocation updates the device-location for a device.
+func (c *failoverClient) SetDeviceLocation(ctx context.Context, in *as.SetDeviceLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.invoke(func(client as.ApplicationServerServiceClient) (*empty.Empty, error) {
+		return client.SetDeviceLocation(ctx, in, opts...)
+	})
+}
+
+func (c *failoverClient) invoke(f func(as.ApplicationServerServiceClient) (*empty.Empty, error)) (*empty.Empty, error) {
+	var lastErr error
+
+	for _, hostname := range c.candidates() {
+		client, err := c.pool.Get(hostname, c.caCert, c.tlsCert, c.tlsKey)
+		if err != nil {
+			lastErr = err
+			setUnhealthy(hostname, err)
+			continue
+		}
+
+		resp, err := f(client)
+		if err != nil {
+			if status.Code(err) == codes.Unavailable {
+				lastErr = err
+				setUnhealthy(hostname, err)
+				continue
+			}
+			return nil, err
+		}
+
+		setHealthy(hostname)
+		return resp, nil
+	}
+
+	return nil, errors.Wrap(lastErr, "all application-servers failed")
+}
+
+// candidates returns the hostnames in the order in which they must be tried.
+// The healthy application-servers are returned first.
+func (c *failoverClient) candidates() []string {
+	hostnames := c.hostnames
+
+	if c.roundRobin && len(hostnames) > 1 {
+		offset := int(nextCounter(strings.Join(hostnames, ",")) % uint32(len(hostnames)))
+		hostnames = append(append([]string{}, hostnames[offset:]...), hostnames[:offset]...)
+	}
+
+	var healthy, unhealthy []string
+	now := time.Now()
+
+	healthMux.RLock()
+	for _, hostname := range hostnames {
+		// a zero time means unhealthy until the health-check succeeds
+		if until, ok := unhealthyUntil[hostname]; ok && (until.IsZero() || now.Before(until)) {
+			unhealthy = append(unhealthy, hostname)
+		} else {
+			healthy = append(healthy, hostname)
+		}
+	}
+	healthMux.RUnlock()
+
+	return append(healthy, unhealthy...)
+}
+
+func nextCounter(key string) uint32 {
+	counterMux.Lock()
+	counter, ok := counters[key]
+	if !ok {
+		counter = new(uint32)
+		counters[key] = counter
+	}
+	counterMux.Unlock()
+
+	return atomic.AddUint32(counter, 1) - 1
+}
+
+func setUnhealthy(hostname string, err error) {
+	log.WithError(err).WithField("server", hostname).Warning("application-server unavailable, failing over")
+
+	until := time.Now().Add(UnhealthyDuration)
+	if isWatched(hostname) {
+		until = time.Time{}
+	}
+
+	healthMux.Lock()
+	unhealthyUntil[hostname] = until
+	healthMux.Unlock()
+}
+
+func setHealthy(hostname string) {
+	healthMux.RLock()
+	_, ok := unhealthyUntil[hostname]
+	healthMux.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	healthMux.Lock()
+	delete(unhealthyUntil, hostname)
+	healthMux.Unlock()
+
+	log.WithField("server", hostname).Info("application-server available again")
+}
+
+// StartHealthCheck starts the loop checking the health of the
+// application-servers used by the failover clients. The health-check
+// drives the selection of the application-servers: an application-server
+// failing the health-check is skipped until it passes the health-check
+// again, after which the failover client fails back to it.
+func StartHealthCheck(interval, timeout time.Duration) {
+	watchMux.Lock()
+	healthCheckEnabled = true
+	watchMux.Unlock()
+
+	go func() {
+		for {
+			time.Sleep(interval)
+			checkHealth(timeout, time.Now().Add(-healthCheckExpiryIntervals*interval))
+		}
+	}()
+}
+
+// watch adds the given application-servers to the health-check.
+func watch(checker HealthChecker, hostnames []string, caCert, tlsCert, tlsKey []byte) {
+	watchMux.Lock()
+	defer watchMux.Unlock()
+
+	if !healthCheckEnabled {
+		return
+	}
+
+	now := time.Now()
+
+	for _, hostname := range hostnames {
+		k := watchKey{
+			hostname: hostname,
+			caCert:   string(caCert),
+			tlsCert:  string(tlsCert),
+			tlsKey:   string(tlsKey),
+		}
+
+		if s, ok := watched[k]; ok {
+			s.lastUsed = now
+			continue
+		}
+
+		watched[k] = &watchedServer{
+			checker:  checker,
+			lastUsed: now,
+		}
+	}
+}
+
+func isWatched(hostname string) bool {
+	watchMux.Lock()
+	defer watchMux.Unlock()
+
+	for k := range watched {
+		if k.hostname == hostname {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkHealth checks the health of the watched application-servers.
+// Application-servers which have not been used since the given expiry
+// are removed from the health-check. An application-server is healthy
+// when one of its health-checks (e.g. using different certificates)
+// succeeds.
+func checkHealth(timeout time.Duration, expiry time.Time) {
+	watchMux.Lock()
+	servers := make(map[watchKey]HealthChecker)
+	for k, s := range watched {
+		if s.lastUsed.Before(expiry) {
+			delete(watched, k)
+			continue
+		}
+		servers[k] = s.checker
+	}
+	watchMux.Unlock()
+
+	errs := make(map[string]error)
+	for k, checker := range servers {
+		if err, ok := errs[k.hostname]; ok && err == nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		errs[k.hostname] = checker.HealthCheck(ctx, k.hostname, []byte(k.caCert), []byte(k.tlsCert), []byte(k.tlsKey))
+		cancel()
+	}
+
+	for hostname, err := range errs {
+		if err != nil {
+			setUnhealthyByHealthCheck(hostname, err)
+		} else {
+			setHealthy(hostname)
+		}
+	}
+}
+
+func setUnhealthyByHealthCheck(hostname string, err error) {
+	healthMux.Lock()
+	until, ok := unhealthyUntil[hostname]
+	unhealthyUntil[hostname] = time.Time{}
+	healthMux.Unlock()
+
+	// only log the state change
+	if !ok || !until.IsZero() {
+		log.WithError(err).WithField("server", hostname).Warning("application-server health-check failed")
+	}
+}
@@ -0,0 +1,264 @@
+package asclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+)
+
+type testClient struct {
+	as.ApplicationServerServiceClient
+
+	hostname string
+	err      error
+	calls    *[]string
+}
+
+func (c *testClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	*c.calls = append(*c.calls, c.hostname)
+	return &empty.Empty{}, c.err
+}
+
+type testPool struct {
+	clients    map[string]*testClient
+	healthErrs map[string]error
+}
+
+func (p *testPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
+	return p.clients[hostname], nil
+}
+
+func (p *testPool) HealthCheck(ctx context.Context, hostname string, caCert, tlsCert, tlsKey []byte) error {
+	return p.healthErrs[hostname]
+}
+
+func TestFailoverClient(t *testing.T) {
+	tests := []struct {
+		name          string
+		roundRobin    bool
+		errors        map[string]error
+		expectedCalls [][]string
+		expectedError bool
+	}{
+		{
+			name: "primary available",
+			expectedCalls: [][]string{
+				{"as-1"},
+				{"as-1"},
+			},
+		},
+		{
+			name: "primary unavailable",
+			errors: map[string]error{
+				"as-1": status.Error(codes.Unavailable, "unavailable"),
+			},
+			expectedCalls: [][]string{
+				{"as-1", "as-2"},
+				{"as-2"},
+			},
+		},
+		{
+			name: "non-unavailable error does not fail over",
+			errors: map[string]error{
+				"as-1": status.Error(codes.InvalidArgument, "invalid argument"),
+			},
+			expectedCalls: [][]string{
+				{"as-1"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "all unavailable",
+			errors: map[string]error{
+				"as-1": status.Error(codes.Unavailable, "unavailable"),
+				"as-2": status.Error(codes.Unavailable, "unavailable"),
+			},
+			expectedCalls: [][]string{
+				{"as-1", "as-2"},
+			},
+			expectedError: true,
+		},
+		{
+			name:       "round-robin",
+			roundRobin: true,
+			expectedCalls: [][]string{
+				{"as-1"},
+				{"as-2"},
+				{"as-1"},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			healthMux.Lock()
+			unhealthyUntil = make(map[string]time.Time)
+			healthMux.Unlock()
+
+			counterMux.Lock()
+			counters = make(map[string]*uint32)
+			counterMux.Unlock()
+
+			var calls []string
+			p := testPool{
+				clients: make(map[string]*testClient),
+			}
+			for _, hostname := range []string{"as-1", "as-2"} {
+				p.clients[hostname] = &testClient{
+					hostname: hostname,
+					err:      tst.errors[hostname],
+					calls:    &calls,
+				}
+			}
+
+			client := NewFailoverClient(&p, []string{"as-1", "as-2"}, nil, nil, nil, tst.roundRobin)
+
+			for _, expected := range tst.expectedCalls {
+				calls = nil
+				_, err := client.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+				if tst.expectedError {
+					assert.Error(err)
+				} else {
+					assert.NoError(err)
+				}
+				assert.Equal(expected, calls)
+			}
+		})
+	}
+}
+
+func TestFailoverClientHealthCheck(t *testing.T) {
+	assert := require.New(t)
+
+	healthMux.Lock()
+	unhealthyUntil = make(map[string]time.Time)
+	healthMux.Unlock()
+
+	watchMux.Lock()
+	healthCheckEnabled = true
+	watched = make(map[watchKey]*watchedServer)
+	watchMux.Unlock()
+
+	defer func() {
+		watchMux.Lock()
+		healthCheckEnabled = false
+		watched = make(map[watchKey]*watchedServer)
+		watchMux.Unlock()
+	}()
+
+	var calls []string
+	p := testPool{
+		clients:    make(map[string]*testClient),
+		healthErrs: make(map[string]error),
+	}
+	for _, hostname := range []string{"as-1", "as-2"} {
+		p.clients[hostname] = &testClient{
+			hostname: hostname,
+			calls:    &calls,
+		}
+	}
+
+	client := NewFailoverClient(&p, []string{"as-1", "as-2"}, nil, nil, nil, false)
+	assert.True(isWatched("as-1"))
+	assert.True(isWatched("as-2"))
+
+	call := func(assert *require.Assertions) []string {
+		calls = nil
+		_, err := client.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+		assert.NoError(err)
+		return calls
+	}
+
+	t.Run("Health-check fails", func(t *testing.T) {
+		assert := require.New(t)
+		p.healthErrs["as-1"] = status.Error(codes.Unavailable, "unavailable")
+		checkHealth(time.Second, time.Time{})
+
+		assert.Equal([]string{"as-2"}, call(assert))
+	})
+
+	t.Run("Health-check succeeds again", func(t *testing.T) {
+		assert := require.New(t)
+		p.healthErrs["as-1"] = nil
+		checkHealth(time.Second, time.Time{})
+
+		assert.Equal([]string{"as-1"}, call(assert))
+	})
+
+	t.Run("Unavailable until health-check succeeds", func(t *testing.T) {
+		assert := require.New(t)
+		p.clients["as-1"].err = status.Error(codes.Unavailable, "unavailable")
+		assert.Equal([]string{"as-1", "as-2"}, call(assert))
+
+		p.clients["as-1"].err = nil
+
+		// the unhealthy duration does not apply to health-checked
+		// application-servers
+		healthMux.RLock()
+		assert.True(unhealthyUntil["as-1"].IsZero())
+		healthMux.RUnlock()
+		assert.Equal([]string{"as-2"}, call(assert))
+
+		checkHealth(time.Second, time.Time{})
+		assert.Equal([]string{"as-1"}, call(assert))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		assert := require.New(t)
+		checkHealth(time.Second, time.Now().Add(time.Minute))
+		assert.False(isWatched("as-1"))
+		assert.False(isWatched("as-2"))
+	})
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	assert := require.New(t)
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(err)
+
+	healthServer := health.NewServer()
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(ln)
+	defer server.Stop()
+
+	// server without health service
+	lnNoHealth, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(err)
+
+	serverNoHealth := grpc.NewServer()
+	go serverNoHealth.Serve(lnNoHealth)
+	defer serverNoHealth.Stop()
+
+	p := NewPool().(HealthChecker)
+
+	t.Run("Serving", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(p.HealthCheck(context.Background(), ln.Addr().String(), nil, nil, nil))
+	})
+
+	t.Run("Not serving", func(t *testing.T) {
+		assert := require.New(t)
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		assert.Error(p.HealthCheck(context.Background(), ln.Addr().String(), nil, nil, nil))
+	})
+
+	t.Run("Health service not implemented", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(p.HealthCheck(context.Background(), lnNoHealth.Addr().String(), nil, nil, nil))
+	})
+}
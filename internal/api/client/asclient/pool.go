@@ -5,16 +5,24 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 
 	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 )
 
@@ -23,64 +31,307 @@ type Pool interface {
 	Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error)
 }
 
-type client struct {
-	client     as.ApplicationServerServiceClient
-	clientConn *grpc.ClientConn
-	caCert     []byte
-	tlsCert    []byte
-	tlsKey     []byte
+// PoolOptions defines the options of the application-server client pool.
+type PoolOptions struct {
+	// ConnectionsPerServer defines the number of connections (sub-connections)
+	// opened to each application-server. The requests are distributed over
+	// the healthy connections.
+	ConnectionsPerServer int
+
+	// IdleTimeout defines the duration after which unused connections are
+	// closed. When set to 0, connections are never closed.
+	IdleTimeout time.Duration
+
+	// BatchEnabled enables the micro-batching of HandleUplinkData calls.
+	BatchEnabled bool
+
+	// BatchMaxSize defines the max. number of items in a batch.
+	BatchMaxSize int
+
+	// BatchInterval defines the max. duration an item is held back before
+	// the batch is sent.
+	BatchInterval time.Duration
+}
+
+// entry holds the connections to an application-server, using the given
+// certificates. An entry is shared by all the routing-profiles using the
+// same application-server and certificates.
+type entry struct {
+	hostname string
+	caCert   []byte
+	tlsCert  []byte
+	tlsKey   []byte
+
+	// ready is closed once the connections have been set up (or failed).
+	ready chan struct{}
+	err   error
+
+	clientConns []*grpc.ClientConn
+	clients     []as.ApplicationServerServiceClient
+	batcher     *uplinkBatcher
+
+	next     uint32
+	lastUsed int64
 }
 
 type pool struct {
 	sync.RWMutex
-	clients map[string]client
+	opts    PoolOptions
+	entries map[string][]*entry
 }
 
-// NewPool creates a new Pool.
+// NewPool creates a new Pool, using a single connection per
+// application-server and without batching.
 func NewPool() Pool {
-	return &pool{
-		clients: make(map[string]client),
+	return NewPoolWithOptions(PoolOptions{})
+}
+
+// NewPoolWithOptions creates a new Pool using the given options.
+func NewPoolWithOptions(opts PoolOptions) Pool {
+	if opts.ConnectionsPerServer < 1 {
+		opts.ConnectionsPerServer = 1
 	}
+
+	p := pool{
+		opts:    opts,
+		entries: make(map[string][]*entry),
+	}
+
+	if opts.IdleTimeout > 0 {
+		go p.closeIdleLoop()
+	}
+
+	return &p
 }
 
 // Get Returns an ApplicationServerClient for the given server (hostname:ip).
+// The connections are set up on the first call, concurrent calls for the
+// same server wait for the connections to be set up.
 func (p *pool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
-	defer p.Unlock()
-	p.Lock()
+	e, err := p.getEntry(hostname, caCert, tlsCert, tlsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.StoreInt64(&e.lastUsed, clock.Now().UnixNano())
+
+	return &pooledClient{
+		ApplicationServerServiceClient: e.pick(),
+		batcher:                        e.batcher,
+	}, nil
+}
 
-	var connect bool
-	c, ok := p.clients[hostname]
-	if !ok {
-		connect = true
+// HealthCheck checks the health of the given server using the gRPC health
+// service. A server which does not implement the health service is
+// considered healthy, as it could be reached. Health-checks do not keep
+// idle connections open.
+func (p *pool) HealthCheck(ctx context.Context, hostname string, caCert, tlsCert, tlsKey []byte) error {
+	e, err := p.getEntry(hostname, caCert, tlsCert, tlsKey)
+	if err != nil {
+		return err
+	}
+
+	resp, err := healthpb.NewHealthClient(e.clientConns[e.pickIndex()]).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return errors.Wrap(err, "health-check error")
 	}
 
-	// if the connection exists in the map, but when the certificates changed
-	// try to close the connection and re-connect
-	if ok && (!bytes.Equal(c.caCert, caCert) || !bytes.Equal(c.tlsCert, tlsCert) || !bytes.Equal(c.tlsKey, tlsKey)) {
-		c.clientConn.Close()
-		delete(p.clients, hostname)
-		connect = true
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("application-server is not serving, status: %s", resp.Status)
 	}
 
-	if connect {
-		clientConn, asClient, err := p.createClient(hostname, caCert, tlsCert, tlsKey)
+	return nil
+}
+
+// getEntry returns the entry for the given server. The connections are set
+// up on the first call, concurrent calls for the same server wait for the
+// connections to be set up.
+func (p *pool) getEntry(hostname string, caCert, tlsCert, tlsKey []byte) (*entry, error) {
+	p.RLock()
+	e := p.lookup(hostname, caCert, tlsCert, tlsKey)
+	p.RUnlock()
+
+	if e == nil {
+		var created bool
+
+		p.Lock()
+		e = p.lookup(hostname, caCert, tlsCert, tlsKey)
+		if e == nil {
+			e = &entry{
+				hostname: hostname,
+				caCert:   caCert,
+				tlsCert:  tlsCert,
+				tlsKey:   tlsKey,
+				ready:    make(chan struct{}),
+			}
+			p.entries[hostname] = append(p.entries[hostname], e)
+			created = true
+		}
+		p.Unlock()
+
+		// the connections are set up outside the lock, so that an
+		// unavailable application-server does not block the other ones
+		if created {
+			p.connect(e)
+		}
+	}
+
+	<-e.ready
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e, nil
+}
+
+func (p *pool) lookup(hostname string, caCert, tlsCert, tlsKey []byte) *entry {
+	for _, e := range p.entries[hostname] {
+		if bytes.Equal(e.caCert, caCert) && bytes.Equal(e.tlsCert, tlsCert) && bytes.Equal(e.tlsKey, tlsKey) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (p *pool) connect(e *entry) {
+	defer close(e.ready)
+
+	for i := 0; i < p.opts.ConnectionsPerServer; i++ {
+		clientConn, err := p.createClient(e.hostname, e.caCert, e.tlsCert, e.tlsKey)
 		if err != nil {
-			return nil, errors.Wrap(err, "create application-server api client error")
+			e.err = errors.Wrap(err, "create application-server api client error")
+			break
+		}
+
+		e.clientConns = append(e.clientConns, clientConn)
+		e.clients = append(e.clients, as.NewApplicationServerServiceClient(clientConn))
+	}
+
+	if e.err != nil {
+		// remove the entry, so that the next call retries
+		e.close()
+		p.remove(e)
+		return
+	}
+
+	if p.opts.BatchEnabled {
+		e.batcher = newUplinkBatcher(e.hostname, p.opts.BatchMaxSize, p.opts.BatchInterval, e.pick)
+	}
+
+	atomic.StoreInt64(&e.lastUsed, clock.Now().UnixNano())
+}
+
+func (p *pool) remove(e *entry) {
+	p.Lock()
+	defer p.Unlock()
+
+	entries := p.entries[e.hostname]
+	for i := range entries {
+		if entries[i] == e {
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+
+	if len(entries) == 0 {
+		delete(p.entries, e.hostname)
+	} else {
+		p.entries[e.hostname] = entries
+	}
+}
+
+// closeIdleLoop closes the connections which have not been used for the
+// configured idle timeout, e.g. after the certificates of a routing-profile
+// have been updated.
+func (p *pool) closeIdleLoop() {
+	for {
+		<-clock.After(p.opts.IdleTimeout / 2)
+		p.closeIdle()
+	}
+}
+
+func (p *pool) closeIdle() {
+	var idle []*entry
+	deadline := clock.Now().Add(-p.opts.IdleTimeout).UnixNano()
+
+	p.RLock()
+	for _, entries := range p.entries {
+		for _, e := range entries {
+			select {
+			case <-e.ready:
+			default:
+				// still connecting
+				continue
+			}
+
+			if atomic.LoadInt64(&e.lastUsed) < deadline {
+				idle = append(idle, e)
+			}
 		}
-		c = client{
-			client:     asClient,
-			clientConn: clientConn,
-			caCert:     caCert,
-			tlsCert:    tlsCert,
-			tlsKey:     tlsKey,
+	}
+	p.RUnlock()
+
+	for _, e := range idle {
+		log.WithField("server", e.hostname).Info("closing idle application-server client")
+		p.remove(e)
+		e.close()
+	}
+}
+
+// pick returns the client of the next healthy connection. When none of the
+// connections is healthy, the next connection is returned so that the
+// error is returned to the caller.
+func (e *entry) pick() as.ApplicationServerServiceClient {
+	return e.clients[e.pickIndex()]
+}
+
+// pickIndex returns the index of the next healthy connection.
+func (e *entry) pickIndex() uint32 {
+	n := uint32(len(e.clients))
+	start := atomic.AddUint32(&e.next, 1) - 1
+
+	for i := uint32(0); i < n; i++ {
+		idx := (start + i) % n
+		switch e.clientConns[idx].GetState() {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			continue
+		default:
+			return idx
 		}
-		p.clients[hostname] = c
 	}
 
-	return c.client, nil
+	return start % n
+}
+
+func (e *entry) close() {
+	for _, clientConn := range e.clientConns {
+		clientConn.Close()
+	}
+}
+
+// pooledClient is the client returned by the pool. When batching is
+// enabled, the HandleUplinkData calls are added to the batch.
+type pooledClient struct {
+	as.ApplicationServerServiceClient
+	batcher *uplinkBatcher
+}
+
+// HandleUplinkData handles uplink data received from an end-device.
+func (c *pooledClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if c.batcher == nil {
+		return c.ApplicationServerServiceClient.HandleUplinkData(ctx, in, opts...)
+	}
+
+	if err := c.batcher.handleUplinkData(ctx, in); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
 }
 
-func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*grpc.ClientConn, as.ApplicationServerServiceClient, error) {
+func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*grpc.ClientConn, error) {
 	logrusEntry := log.NewEntry(log.StandardLogger())
 	logrusOpts := []grpc_logrus.Option{
 		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
@@ -103,14 +354,14 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 		log.WithField("server", hostname).Info("creating application-server client")
 		cert, err := tls.X509KeyPair(tlsCert, tlsKey)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "load x509 keypair error")
+			return nil, errors.Wrap(err, "load x509 keypair error")
 		}
 
 		var caCertPool *x509.CertPool
 		if len(caCert) != 0 {
 			caCertPool = x509.NewCertPool()
 			if !caCertPool.AppendCertsFromPEM(caCert) {
-				return nil, nil, errors.Wrap(err, "append ca cert to pool error")
+				return nil, errors.Wrap(err, "append ca cert to pool error")
 			}
 		}
 
@@ -123,10 +374,10 @@ func (p *pool) createClient(hostname string, caCert, tlsCert, tlsKey []byte) (*g
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	asClient, err := grpc.DialContext(ctx, hostname, asOpts...)
+	clientConn, err := grpc.DialContext(ctx, hostname, asOpts...)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "dial application-server api error")
+		return nil, errors.Wrap(err, "dial application-server api error")
 	}
 
-	return asClient, as.NewApplicationServerServiceClient(asClient), nil
+	return clientConn, nil
 }
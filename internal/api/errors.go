@@ -5,9 +5,12 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/certification"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/proprietary"
+	"github.com/mxc-foundation/lpwan-server/internal/framelog"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
@@ -19,8 +22,21 @@ var errToCode = map[error]codes.Code{
 	data.ErrMaxPayloadSizeExceeded: codes.InvalidArgument,
 
 	proprietary.ErrInvalidDataRate: codes.Internal,
+	proprietary.ErrEmitTimeInPast:  codes.InvalidArgument,
 
-	multicast.ErrInvalidFCnt: codes.InvalidArgument,
+	multicast.ErrInvalidFCnt:            codes.InvalidArgument,
+	multicast.ErrInvalidFragmentCount:   codes.InvalidArgument,
+	multicast.ErrMaxPayloadSizeExceeded: codes.InvalidArgument,
+
+	certification.ErrAppSKeyNotAvailable: codes.FailedPrecondition,
+	certification.ErrInvalidCommand:      codes.InvalidArgument,
+
+	gwbackend.ErrNotSupported: codes.Unimplemented,
+
+	framelog.ErrCaptureDoesNotExist:     codes.NotFound,
+	framelog.ErrInvalidCaptureTarget:    codes.InvalidArgument,
+	framelog.ErrInvalidCaptureDuration:  codes.InvalidArgument,
+	framelog.ErrInvalidCaptureMaxFrames: codes.InvalidArgument,
 
 	storage.ErrAlreadyExists:                  codes.AlreadyExists,
 	storage.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
@@ -28,6 +44,12 @@ var errToCode = map[error]codes.Code{
 	storage.ErrInvalidName:                    codes.InvalidArgument,
 	storage.ErrInvalidAggregationInterval:     codes.InvalidArgument,
 	storage.ErrInvalidFPort:                   codes.InvalidArgument,
+	storage.ErrInvalidGeofence:                codes.InvalidArgument,
+	storage.ErrInvalidMulticastGroupSetup:     codes.InvalidArgument,
+	storage.ErrFrequencyPlanInUse:             codes.FailedPrecondition,
+	storage.ErrDeviceSessionChanged:           codes.Aborted,
+	storage.ErrDeviceQueueFull:                codes.ResourceExhausted,
+	storage.ErrDownlinkDisabled:               codes.FailedPrecondition,
 }
 
 func errToRPCError(err error) error {
@@ -1,12 +1,20 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -15,15 +23,23 @@ import (
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/api/ns"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/certification"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
 	proprietarydown "github.com/mxc-foundation/lpwan-server/internal/downlink/proprietary"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/constraint"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
 	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/reload"
+	"github.com/mxc-foundation/lpwan-server/internal/security"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
@@ -34,6 +50,10 @@ const defaultCodeRate = "4/5"
 // there is enough time between scheduling and the actual Class-B ping-slot.
 const classBScheduleMargin = 5 * time.Second
 
+// devAddrAllocationAttempts defines the max. number of random DevAddrs that
+// are tried when allocating a free DevAddr.
+const devAddrAllocationAttempts = 10
+
 // NetworkServerAPI defines the nework-server API.
 type NetworkServerAPI struct{}
 
@@ -70,6 +90,26 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		NwkGeoLoc:              req.ServiceProfile.NwkGeoLoc,
 		TargetPER:              int(req.ServiceProfile.TargetPer),
 		MinGWDiversity:         int(req.ServiceProfile.MinGwDiversity),
+		WebhookURL:             req.ServiceProfile.WebhookUrl,
+		WebhookSecret:          req.ServiceProfile.WebhookSecret,
+		WebhookEvents:          req.ServiceProfile.WebhookEvents,
+		GatewayIsolation:       req.ServiceProfile.GatewayIsolation,
+		AllowedGatewayIDs:      req.ServiceProfile.AllowedGatewayIds,
+		ClockSyncEnabled:       req.ServiceProfile.ClockSyncEnabled,
+		MulticastSetupEnabled:  req.ServiceProfile.MulticastSetupEnabled,
+		DeviceQueueMaxSize:     int(req.ServiceProfile.DeviceQueueMaxSize),
+		FrameLogRedactPayload:  req.ServiceProfile.FrameLogRedactPayload,
+		FrameLogTruncateDevEUI: req.ServiceProfile.FrameLogTruncateDevEui,
+		DownlinkDisabled:       req.ServiceProfile.DownlinkDisabled,
+		DLMaxTXPower:           int(req.ServiceProfile.DlMaxTxPower),
+	}
+
+	if err := webhook.ValidateEvents(sp.WebhookEvents); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateAllowedGatewayIDs(sp.AllowedGatewayIDs); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
 	}
 
 	switch req.ServiceProfile.UlRatePolicy {
@@ -86,6 +126,26 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.DeviceQueueOverflowPolicy {
+	case ns.DeviceQueueOverflowPolicy_REJECT:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowReject
+	case ns.DeviceQueueOverflowPolicy_DROP_OLDEST:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowDropOldest
+	case ns.DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowDropLowestPriority
+	}
+
+	switch req.ServiceProfile.UplinkFingerprintSensitivity {
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_DISABLED:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityDisabled
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_LOW:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityLow
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityMedium
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_HIGH:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityHigh
+	}
+
 	if err := storage.CreateServiceProfile(ctx, storage.DB(), &sp); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -125,6 +185,17 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 			NwkGeoLoc:              sp.NwkGeoLoc,
 			TargetPer:              uint32(sp.TargetPER),
 			MinGwDiversity:         uint32(sp.MinGWDiversity),
+			WebhookUrl:             sp.WebhookURL,
+			WebhookEvents:          sp.WebhookEvents,
+			GatewayIsolation:       sp.GatewayIsolation,
+			AllowedGatewayIds:      sp.AllowedGatewayIDs,
+			ClockSyncEnabled:       sp.ClockSyncEnabled,
+			MulticastSetupEnabled:  sp.MulticastSetupEnabled,
+			DeviceQueueMaxSize:     uint32(sp.DeviceQueueMaxSize),
+			FrameLogRedactPayload:  sp.FrameLogRedactPayload,
+			FrameLogTruncateDevEui: sp.FrameLogTruncateDevEUI,
+			DownlinkDisabled:       sp.DownlinkDisabled,
+			DlMaxTxPower:           int32(sp.DLMaxTXPower),
 		},
 	}
 
@@ -152,6 +223,26 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 		resp.ServiceProfile.DlRatePolicy = ns.RatePolicy_DROP
 	}
 
+	switch sp.DeviceQueueOverflowPolicy {
+	case storage.DeviceQueueOverflowReject:
+		resp.ServiceProfile.DeviceQueueOverflowPolicy = ns.DeviceQueueOverflowPolicy_REJECT
+	case storage.DeviceQueueOverflowDropOldest:
+		resp.ServiceProfile.DeviceQueueOverflowPolicy = ns.DeviceQueueOverflowPolicy_DROP_OLDEST
+	case storage.DeviceQueueOverflowDropLowestPriority:
+		resp.ServiceProfile.DeviceQueueOverflowPolicy = ns.DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY
+	}
+
+	switch sp.UplinkFingerprintSensitivity {
+	case storage.FingerprintSensitivityDisabled:
+		resp.ServiceProfile.UplinkFingerprintSensitivity = ns.UplinkFingerprintSensitivity_FINGERPRINT_DISABLED
+	case storage.FingerprintSensitivityLow:
+		resp.ServiceProfile.UplinkFingerprintSensitivity = ns.UplinkFingerprintSensitivity_FINGERPRINT_LOW
+	case storage.FingerprintSensitivityMedium:
+		resp.ServiceProfile.UplinkFingerprintSensitivity = ns.UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM
+	case storage.FingerprintSensitivityHigh:
+		resp.ServiceProfile.UplinkFingerprintSensitivity = ns.UplinkFingerprintSensitivity_FINGERPRINT_HIGH
+	}
+
 	return &resp, nil
 }
 
@@ -186,6 +277,33 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 	sp.NwkGeoLoc = req.ServiceProfile.NwkGeoLoc
 	sp.TargetPER = int(req.ServiceProfile.TargetPer)
 	sp.MinGWDiversity = int(req.ServiceProfile.MinGwDiversity)
+	sp.WebhookURL = req.ServiceProfile.WebhookUrl
+	sp.WebhookEvents = req.ServiceProfile.WebhookEvents
+	sp.GatewayIsolation = req.ServiceProfile.GatewayIsolation
+	sp.AllowedGatewayIDs = req.ServiceProfile.AllowedGatewayIds
+	sp.ClockSyncEnabled = req.ServiceProfile.ClockSyncEnabled
+	sp.MulticastSetupEnabled = req.ServiceProfile.MulticastSetupEnabled
+	sp.DeviceQueueMaxSize = int(req.ServiceProfile.DeviceQueueMaxSize)
+	sp.FrameLogRedactPayload = req.ServiceProfile.FrameLogRedactPayload
+	sp.FrameLogTruncateDevEUI = req.ServiceProfile.FrameLogTruncateDevEui
+	sp.DownlinkDisabled = req.ServiceProfile.DownlinkDisabled
+	sp.DLMaxTXPower = int(req.ServiceProfile.DlMaxTxPower)
+
+	if req.ServiceProfile.WebhookSecret != "" {
+		sp.WebhookSecret = req.ServiceProfile.WebhookSecret
+	}
+
+	if sp.WebhookURL == "" {
+		sp.WebhookSecret = ""
+	}
+
+	if err := webhook.ValidateEvents(sp.WebhookEvents); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateAllowedGatewayIDs(sp.AllowedGatewayIDs); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
 
 	switch req.ServiceProfile.UlRatePolicy {
 	case ns.RatePolicy_MARK:
@@ -201,6 +319,26 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 		sp.DLRatePolicy = storage.Drop
 	}
 
+	switch req.ServiceProfile.DeviceQueueOverflowPolicy {
+	case ns.DeviceQueueOverflowPolicy_REJECT:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowReject
+	case ns.DeviceQueueOverflowPolicy_DROP_OLDEST:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowDropOldest
+	case ns.DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY:
+		sp.DeviceQueueOverflowPolicy = storage.DeviceQueueOverflowDropLowestPriority
+	}
+
+	switch req.ServiceProfile.UplinkFingerprintSensitivity {
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_DISABLED:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityDisabled
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_LOW:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityLow
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityMedium
+	case ns.UplinkFingerprintSensitivity_FINGERPRINT_HIGH:
+		sp.UplinkFingerprintSensitivity = storage.FingerprintSensitivityHigh
+	}
+
 	if err := storage.FlushServiceProfileCache(ctx, storage.RedisPool(), sp.ID); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -238,11 +376,16 @@ func (n *NetworkServerAPI) CreateRoutingProfile(ctx context.Context, req *ns.Cre
 	copy(rpID[:], req.RoutingProfile.Id)
 
 	rp := storage.RoutingProfile{
-		ID:      rpID,
-		ASID:    req.RoutingProfile.AsId,
-		CACert:  req.RoutingProfile.CaCert,
-		TLSCert: req.RoutingProfile.TlsCert,
-		TLSKey:  req.RoutingProfile.TlsKey,
+		ID:            rpID,
+		ASID:          req.RoutingProfile.AsId,
+		CACert:        req.RoutingProfile.CaCert,
+		TLSCert:       req.RoutingProfile.TlsCert,
+		TLSKey:        req.RoutingProfile.TlsKey,
+		FailoverASIDs: req.RoutingProfile.FailoverAsIds,
+		RoundRobin:    req.RoutingProfile.RoundRobin,
+
+		DownlinkHookURL:    req.RoutingProfile.DownlinkHookUrl,
+		DownlinkHookSecret: req.RoutingProfile.DownlinkHookSecret,
 	}
 	if err := storage.CreateRoutingProfile(ctx, storage.DB(), &rp); err != nil {
 		return nil, errToRPCError(err)
@@ -265,10 +408,14 @@ func (n *NetworkServerAPI) GetRoutingProfile(ctx context.Context, req *ns.GetRou
 
 	resp := ns.GetRoutingProfileResponse{
 		RoutingProfile: &ns.RoutingProfile{
-			Id:      rp.ID.Bytes(),
-			AsId:    rp.ASID,
-			CaCert:  rp.CACert,
-			TlsCert: rp.TLSCert,
+			Id:            rp.ID.Bytes(),
+			AsId:          rp.ASID,
+			CaCert:        rp.CACert,
+			TlsCert:       rp.TLSCert,
+			FailoverAsIds: rp.FailoverASIDs,
+			RoundRobin:    rp.RoundRobin,
+
+			DownlinkHookUrl: rp.DownlinkHookURL,
 		},
 	}
 
@@ -302,6 +449,8 @@ func (n *NetworkServerAPI) UpdateRoutingProfile(ctx context.Context, req *ns.Upd
 	rp.ASID = req.RoutingProfile.AsId
 	rp.CACert = req.RoutingProfile.CaCert
 	rp.TLSCert = req.RoutingProfile.TlsCert
+	rp.FailoverASIDs = req.RoutingProfile.FailoverAsIds
+	rp.RoundRobin = req.RoutingProfile.RoundRobin
 
 	if req.RoutingProfile.TlsKey != "" {
 		rp.TLSKey = req.RoutingProfile.TlsKey
@@ -311,6 +460,19 @@ func (n *NetworkServerAPI) UpdateRoutingProfile(ctx context.Context, req *ns.Upd
 		rp.TLSKey = ""
 	}
 
+	rp.DownlinkHookURL = req.RoutingProfile.DownlinkHookUrl
+	if req.RoutingProfile.DownlinkHookSecret != "" {
+		rp.DownlinkHookSecret = req.RoutingProfile.DownlinkHookSecret
+	}
+
+	if rp.DownlinkHookURL == "" {
+		rp.DownlinkHookSecret = ""
+	}
+
+	if err := storage.FlushRoutingProfileCache(ctx, storage.RedisPool(), rp.ID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	if err := storage.UpdateRoutingProfile(ctx, storage.DB(), &rp); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -323,6 +485,10 @@ func (n *NetworkServerAPI) DeleteRoutingProfile(ctx context.Context, req *ns.Del
 	var rpID uuid.UUID
 	copy(rpID[:], req.Id)
 
+	if err := storage.FlushRoutingProfileCache(ctx, storage.RedisPool(), rpID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	if err := storage.DeleteRoutingProfile(ctx, storage.DB(), rpID); err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -368,6 +534,16 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 		RFRegion:            band.Band().Name(),
 		GeolocBufferTTL:     int(req.DeviceProfile.GeolocBufferTtl),
 		GeolocMinBufferSize: int(req.DeviceProfile.GeolocMinBufferSize),
+		FCntMaxGap:          int(req.DeviceProfile.FCntMaxGap),
+	}
+
+	switch req.DeviceProfile.FCntValidationMode {
+	case ns.FCntValidationMode_STRICT:
+		dp.FCntValidationMode = storage.FCntValidationStrict
+	case ns.FCntValidationMode_ROLLOVER_16BIT:
+		dp.FCntValidationMode = storage.FCntValidationRollover16Bit
+	case ns.FCntValidationMode_RELAXED:
+		dp.FCntValidationMode = storage.FCntValidationRelaxed
 	}
 
 	if err := storage.CreateDeviceProfile(ctx, storage.DB(), &dp); err != nil {
@@ -418,9 +594,19 @@ func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDevi
 			Supports_32BitFCnt:  dp.Supports32bitFCnt,
 			GeolocBufferTtl:     uint32(dp.GeolocBufferTTL),
 			GeolocMinBufferSize: uint32(dp.GeolocMinBufferSize),
+			FCntMaxGap:          uint32(dp.FCntMaxGap),
 		},
 	}
 
+	switch dp.FCntValidationMode {
+	case storage.FCntValidationStrict:
+		resp.DeviceProfile.FCntValidationMode = ns.FCntValidationMode_STRICT
+	case storage.FCntValidationRollover16Bit:
+		resp.DeviceProfile.FCntValidationMode = ns.FCntValidationMode_ROLLOVER_16BIT
+	case storage.FCntValidationRelaxed:
+		resp.DeviceProfile.FCntValidationMode = ns.FCntValidationMode_RELAXED
+	}
+
 	resp.CreatedAt, err = ptypes.TimestampProto(dp.CreatedAt)
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -475,6 +661,16 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 	dp.RFRegion = band.Band().Name()
 	dp.GeolocBufferTTL = int(req.DeviceProfile.GeolocBufferTtl)
 	dp.GeolocMinBufferSize = int(req.DeviceProfile.GeolocMinBufferSize)
+	dp.FCntMaxGap = int(req.DeviceProfile.FCntMaxGap)
+
+	switch req.DeviceProfile.FCntValidationMode {
+	case ns.FCntValidationMode_STRICT:
+		dp.FCntValidationMode = storage.FCntValidationStrict
+	case ns.FCntValidationMode_ROLLOVER_16BIT:
+		dp.FCntValidationMode = storage.FCntValidationRollover16Bit
+	case ns.FCntValidationMode_RELAXED:
+		dp.FCntValidationMode = storage.FCntValidationRelaxed
+	}
 
 	if err := storage.FlushDeviceProfileCache(ctx, storage.RedisPool(), dp.ID); err != nil {
 		return nil, errToRPCError(err)
@@ -518,12 +714,13 @@ func (n *NetworkServerAPI) CreateDevice(ctx context.Context, req *ns.CreateDevic
 	copy(spID[:], req.Device.ServiceProfileId)
 
 	d := storage.Device{
-		DevEUI:            devEUI,
-		DeviceProfileID:   dpID,
-		ServiceProfileID:  spID,
-		RoutingProfileID:  rpID,
-		SkipFCntCheck:     req.Device.SkipFCntCheck,
-		ReferenceAltitude: req.Device.ReferenceAltitude,
+		DevEUI:                devEUI,
+		DeviceProfileID:       dpID,
+		ServiceProfileID:      spID,
+		RoutingProfileID:      rpID,
+		SkipFCntCheck:         req.Device.SkipFCntCheck,
+		ReferenceAltitude:     req.Device.ReferenceAltitude,
+		CertificationTestMode: req.Device.CertificationTestMode,
 	}
 	if err := storage.CreateDevice(ctx, storage.DB(), &d); err != nil {
 		return nil, errToRPCError(err)
@@ -544,12 +741,13 @@ func (n *NetworkServerAPI) GetDevice(ctx context.Context, req *ns.GetDeviceReque
 
 	resp := ns.GetDeviceResponse{
 		Device: &ns.Device{
-			DevEui:            d.DevEUI[:],
-			SkipFCntCheck:     d.SkipFCntCheck,
-			DeviceProfileId:   d.DeviceProfileID[:],
-			ServiceProfileId:  d.ServiceProfileID[:],
-			RoutingProfileId:  d.RoutingProfileID[:],
-			ReferenceAltitude: d.ReferenceAltitude,
+			DevEui:                d.DevEUI[:],
+			SkipFCntCheck:         d.SkipFCntCheck,
+			DeviceProfileId:       d.DeviceProfileID[:],
+			ServiceProfileId:      d.ServiceProfileID[:],
+			RoutingProfileId:      d.RoutingProfileID[:],
+			ReferenceAltitude:     d.ReferenceAltitude,
+			CertificationTestMode: d.CertificationTestMode,
 		},
 	}
 
@@ -590,14 +788,105 @@ func (n *NetworkServerAPI) UpdateDevice(ctx context.Context, req *ns.UpdateDevic
 	d.RoutingProfileID = rpID
 	d.SkipFCntCheck = req.Device.SkipFCntCheck
 	d.ReferenceAltitude = req.Device.ReferenceAltitude
+	d.CertificationTestMode = req.Device.CertificationTestMode
 
 	if err := storage.UpdateDevice(ctx, storage.DB(), &d); err != nil {
 		return nil, errToRPCError(err)
 	}
 
+	// the certification test mode is carried by the device-session, so that
+	// it does not require a re-activation of the device
+	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), devEUI)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return nil, errToRPCError(err)
+	}
+	if err == nil && ds.CertificationTestMode != d.CertificationTestMode {
+		ds.CertificationTestMode = d.CertificationTestMode
+		ds.CertificationActivated = false
+		ds.CertificationEchoPayload = nil
+
+		if err := storage.SaveDeviceSession(ctx, storage.RedisPool(), ds); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// TransferDevice moves the given device to an other service-profile and / or
+// routing-profile, preserving its device-session and device-queue.
+func (n *NetworkServerAPI) TransferDevice(ctx context.Context, req *ns.TransferDeviceRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if len(req.ServiceProfileId) != 0 {
+		copy(d.ServiceProfileID[:], req.ServiceProfileId)
+		if _, err := storage.GetServiceProfile(ctx, storage.DB(), d.ServiceProfileID); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	if len(req.RoutingProfileId) != 0 {
+		copy(d.RoutingProfileID[:], req.RoutingProfileId)
+		if _, err := storage.GetRoutingProfile(ctx, storage.DB(), d.RoutingProfileID); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	// the device-session is updated within the transaction, so that the
+	// device is not updated when updating the device-session fails
+	var active bool
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		if err := storage.UpdateDevice(ctx, tx, &d); err != nil {
+			return err
+		}
+
+		_, err := storage.UpdateDeviceSession(ctx, storage.RedisPool(), devEUI, func(ds *storage.DeviceSession) error {
+			transferDeviceSession(ds, d)
+			return nil
+		})
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return err
+		}
+
+		active = true
+		return nil
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// a pending LinkADRReq was based on the previous service-profile, the
+	// ADR engine will re-evaluate the data-rate on the next uplink
+	if active {
+		if err := storage.DeletePendingMACCommand(ctx, storage.RedisPool(), devEUI, lorawan.LinkADRReq); err != nil && err != storage.ErrDoesNotExist {
+			return nil, errToRPCError(err)
+		}
+	}
+
 	return &empty.Empty{}, nil
 }
 
+// transferDeviceSession sets the profiles of the given device to the
+// device-session (and its pending rejoin device-session). The last
+// device-status request is reset so that the device-status request
+// interval of the new service-profile is applied.
+func transferDeviceSession(ds *storage.DeviceSession, d storage.Device) {
+	for s := ds; s != nil; s = s.PendingRejoinDeviceSession {
+		s.ServiceProfileID = d.ServiceProfileID
+		s.RoutingProfileID = d.RoutingProfileID
+		s.LastDevStatusRequested = time.Time{}
+	}
+}
+
 // DeleteDevice deletes the device matching the given DevEUI.
 func (n *NetworkServerAPI) DeleteDevice(ctx context.Context, req *ns.DeleteDeviceRequest) (*empty.Empty, error) {
 	var devEUI lorawan.EUI64
@@ -612,6 +901,10 @@ func (n *NetworkServerAPI) DeleteDevice(ctx context.Context, req *ns.DeleteDevic
 			return errToRPCError(err)
 		}
 
+		if err := storage.DeleteDeviceClassStats(ctx, storage.RedisPool(), devEUI); err != nil {
+			return errToRPCError(err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -628,14 +921,7 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 	}
 
 	var devEUI lorawan.EUI64
-	var devAddr lorawan.DevAddr
-	var sNwkSIntKey, fNwkSIntKey, nwkSEncKey lorawan.AES128Key
-
 	copy(devEUI[:], req.DeviceActivation.DevEui)
-	copy(devAddr[:], req.DeviceActivation.DevAddr)
-	copy(sNwkSIntKey[:], req.DeviceActivation.SNwkSIntKey)
-	copy(fNwkSIntKey[:], req.DeviceActivation.FNwkSIntKey)
-	copy(nwkSEncKey[:], req.DeviceActivation.NwkSEncKey)
 
 	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
 	if err != nil {
@@ -647,6 +933,139 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		return nil, errToRPCError(err)
 	}
 
+	ds := deviceSessionForActivation(d, dp, req.DeviceActivation)
+
+	setDeviceModeForActivation(&d, dp)
+	if err := storage.UpdateDevice(ctx, storage.DB(), &d); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SaveDeviceSession(ctx, storage.RedisPool(), ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), d.DevEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.FlushMACCommandQueue(ctx, storage.RedisPool(), ds.DevEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// ProvisionABPDevice creates the given device and activates it (ABP) in a
+// single call. When no DevAddr is given, a free DevAddr is allocated.
+func (n *NetworkServerAPI) ProvisionABPDevice(ctx context.Context, req *ns.ProvisionABPDeviceRequest) (*ns.ProvisionABPDeviceResponse, error) {
+	if req.Device == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "device must not be nil")
+	}
+	if req.DeviceActivation == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "device_activation must not be nil")
+	}
+
+	var devEUI lorawan.EUI64
+	var dpID, spID, rpID uuid.UUID
+
+	copy(devEUI[:], req.Device.DevEui)
+	copy(dpID[:], req.Device.DeviceProfileId)
+	copy(rpID[:], req.Device.RoutingProfileId)
+	copy(spID[:], req.Device.ServiceProfileId)
+
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), dpID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	if dp.SupportsJoin {
+		return nil, grpc.Errorf(codes.InvalidArgument, "device-profile supports OTAA")
+	}
+
+	act := *req.DeviceActivation
+	act.DevEui = devEUI[:]
+	if err := validateABPActivation(dp, &act); err != nil {
+		return nil, err
+	}
+
+	if len(act.DevAddr) == 0 {
+		devAddr, err := allocateDevAddr(ctx)
+		if err != nil {
+			return nil, err
+		}
+		act.DevAddr = devAddr[:]
+	} else {
+		var devAddr lorawan.DevAddr
+		copy(devAddr[:], act.DevAddr)
+		if err := validateDevAddr(ctx, devAddr, devEUI); err != nil {
+			return nil, err
+		}
+	}
+
+	d := storage.Device{
+		DevEUI:                devEUI,
+		DeviceProfileID:       dpID,
+		ServiceProfileID:      spID,
+		RoutingProfileID:      rpID,
+		SkipFCntCheck:         req.Device.SkipFCntCheck,
+		ReferenceAltitude:     req.Device.ReferenceAltitude,
+		CertificationTestMode: req.Device.CertificationTestMode,
+	}
+	setDeviceModeForActivation(&d, dp)
+
+	ds := deviceSessionForActivation(d, dp, &act)
+
+	if req.AppSKey != nil {
+		ds.AppSKeyEvelope = &storage.KeyEnvelope{
+			KEKLabel: req.AppSKey.KekLabel,
+			AESKey:   req.AppSKey.AesKey,
+		}
+	}
+
+	if len(req.EnabledUplinkChannels) != 0 {
+		channels := make(map[int]struct{})
+		for _, i := range band.Get(ds.Region).GetUplinkChannelIndices() {
+			channels[i] = struct{}{}
+		}
+
+		ds.EnabledUplinkChannels = nil
+		for _, c := range req.EnabledUplinkChannels {
+			if _, ok := channels[int(c)]; !ok {
+				return nil, grpc.Errorf(codes.InvalidArgument, "invalid uplink channel: %d", c)
+			}
+			ds.EnabledUplinkChannels = append(ds.EnabledUplinkChannels, int(c))
+		}
+	}
+
+	// the device-session is stored within the transaction, so that the
+	// device is not created when storing the device-session fails
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		if err := storage.CreateDevice(ctx, tx, &d); err != nil {
+			return err
+		}
+		return storage.SaveDeviceSession(ctx, storage.RedisPool(), ds)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.ProvisionABPDeviceResponse{
+		DevAddr: ds.DevAddr[:],
+	}, nil
+}
+
+// deviceSessionForActivation returns the device-session for the given
+// device-activation (ABP), reset to the device boot parameters.
+func deviceSessionForActivation(d storage.Device, dp storage.DeviceProfile, act *ns.DeviceActivation) storage.DeviceSession {
+	var devEUI lorawan.EUI64
+	var devAddr lorawan.DevAddr
+	var sNwkSIntKey, fNwkSIntKey, nwkSEncKey lorawan.AES128Key
+
+	copy(devEUI[:], act.DevEui)
+	copy(devAddr[:], act.DevAddr)
+	copy(sNwkSIntKey[:], act.SNwkSIntKey)
+	copy(fNwkSIntKey[:], act.FNwkSIntKey)
+	copy(nwkSEncKey[:], act.NwkSEncKey)
+
 	ds := storage.DeviceSession{
 		DeviceProfileID:  d.DeviceProfileID,
 		ServiceProfileID: d.ServiceProfileID,
@@ -657,16 +1076,26 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		SNwkSIntKey:        sNwkSIntKey,
 		FNwkSIntKey:        fNwkSIntKey,
 		NwkSEncKey:         nwkSEncKey,
-		FCntUp:             req.DeviceActivation.FCntUp,
-		NFCntDown:          req.DeviceActivation.NFCntDown,
-		AFCntDown:          req.DeviceActivation.AFCntDown,
-		SkipFCntValidation: req.DeviceActivation.SkipFCntCheck || d.SkipFCntCheck,
+		FCntUp:             act.FCntUp,
+		NFCntDown:          act.NFCntDown,
+		AFCntDown:          act.AFCntDown,
+		SkipFCntValidation: act.SkipFCntCheck || d.SkipFCntCheck,
+
+		CertificationTestMode: d.CertificationTestMode,
 
 		RXWindow: storage.RX1,
 
 		MACVersion: dp.MACVersion,
 	}
 
+	// reset the device-session to the device boot parameters
+	ds.ResetToBootParameters(dp)
+
+	return ds
+}
+
+// setDeviceModeForActivation sets the device mode after an activation.
+func setDeviceModeForActivation(d *storage.Device, dp storage.DeviceProfile) {
 	// The device is never set to DeviceModeB because the device first needs to
 	// aquire a Class-B beacon lock and will signal this to the network-server.
 	if dp.SupportsClassC {
@@ -674,26 +1103,92 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 	} else {
 		d.Mode = storage.DeviceModeA
 	}
-	if err := storage.UpdateDevice(ctx, storage.DB(), &d); err != nil {
-		return nil, errToRPCError(err)
+}
+
+// validateABPActivation validates the session-keys of the given
+// device-activation. For LoRaWAN 1.0 devices, the network session-keys
+// must be equal.
+func validateABPActivation(dp storage.DeviceProfile, act *ns.DeviceActivation) error {
+	for _, k := range []struct {
+		name string
+		key  []byte
+	}{
+		{"s_nwk_s_int_key", act.SNwkSIntKey},
+		{"f_nwk_s_int_key", act.FNwkSIntKey},
+		{"nwk_s_enc_key", act.NwkSEncKey},
+	} {
+		if len(k.key) != len(lorawan.AES128Key{}) {
+			return grpc.Errorf(codes.InvalidArgument, "%s must be %d bytes", k.name, len(lorawan.AES128Key{}))
+		}
 	}
 
-	// reset the device-session to the device boot parameters
-	ds.ResetToBootParameters(dp)
+	if len(act.DevAddr) != 0 && len(act.DevAddr) != len(lorawan.DevAddr{}) {
+		return grpc.Errorf(codes.InvalidArgument, "dev_addr must be %d bytes", len(lorawan.DevAddr{}))
+	}
 
-	if err := storage.SaveDeviceSession(ctx, storage.RedisPool(), ds); err != nil {
-		return nil, errToRPCError(err)
+	if !strings.HasPrefix(dp.MACVersion, "1.1") {
+		if !bytes.Equal(act.SNwkSIntKey, act.FNwkSIntKey) || !bytes.Equal(act.SNwkSIntKey, act.NwkSEncKey) {
+			return grpc.Errorf(codes.InvalidArgument, "network session-keys must be equal for LoRaWAN %s devices", dp.MACVersion)
+		}
 	}
 
-	if err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), d.DevEUI); err != nil {
-		return nil, errToRPCError(err)
+	return nil
+}
+
+// validateDevAddr validates that the given DevAddr matches the NetID of the
+// network-server and that it is not in use by another device.
+func validateDevAddr(ctx context.Context, devAddr lorawan.DevAddr, devEUI lorawan.EUI64) error {
+	if !devAddr.IsNetID(config.C.NetworkServer.NetID) {
+		return grpc.Errorf(codes.InvalidArgument, "dev_addr %s does not match net_id %s", devAddr, config.C.NetworkServer.NetID)
 	}
 
-	if err := storage.FlushMACCommandQueue(ctx, storage.RedisPool(), ds.DevEUI); err != nil {
-		return nil, errToRPCError(err)
+	inUse, err := devAddrInUse(ctx, devAddr, devEUI)
+	if err != nil {
+		return errToRPCError(err)
+	}
+	if inUse {
+		return grpc.Errorf(codes.AlreadyExists, "dev_addr %s is in use by another device", devAddr)
 	}
 
-	return &empty.Empty{}, nil
+	return nil
+}
+
+// allocateDevAddr returns a random DevAddr, prefixed with the NwkID of the
+// network-server, which is not in use by any device.
+func allocateDevAddr(ctx context.Context) (lorawan.DevAddr, error) {
+	for i := 0; i < devAddrAllocationAttempts; i++ {
+		devAddr, err := storage.GetRandomDevAddr(config.C.NetworkServer.NetID)
+		if err != nil {
+			return devAddr, errToRPCError(err)
+		}
+
+		inUse, err := devAddrInUse(ctx, devAddr, lorawan.EUI64{})
+		if err != nil {
+			return devAddr, errToRPCError(err)
+		}
+		if !inUse {
+			return devAddr, nil
+		}
+	}
+
+	return lorawan.DevAddr{}, grpc.Errorf(codes.ResourceExhausted, "no free dev_addr found after %d attempts", devAddrAllocationAttempts)
+}
+
+// devAddrInUse returns true when the given DevAddr is used by the
+// device-session of a device other than the given DevEUI.
+func devAddrInUse(ctx context.Context, devAddr lorawan.DevAddr, devEUI lorawan.EUI64) (bool, error) {
+	sessions, err := storage.GetDeviceSessionsForDevAddr(ctx, storage.RedisPool(), devAddr)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ds := range sessions {
+		if ds.DevEUI != devEUI {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 // DeactivateDevice de-activates a device.
@@ -737,6 +1232,57 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 	}, nil
 }
 
+// GetDeviceClassHealth returns the Class-B / Class-C downlink statistics of
+// the device and whether the device behaves according to its configured
+// device-class.
+func (n *NetworkServerAPI) GetDeviceClassHealth(ctx context.Context, req *ns.GetDeviceClassHealthRequest) (*ns.GetDeviceClassHealthResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	s, err := storage.GetDeviceClassStats(ctx, storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// the statistics are reset on the next downlink after a device-class
+	// change
+	if s.Mode != d.Mode {
+		s = storage.DeviceClassStats{Mode: d.Mode}
+	}
+
+	resp := ns.GetDeviceClassHealthResponse{
+		DeviceClass:         string(d.Mode),
+		Downlinks:           uint32(s.Downlinks),
+		ConfirmedDownlinks:  uint32(s.ConfirmedDownlinks),
+		Acknowledged:        uint32(s.Acknowledged),
+		Timeouts:            uint32(s.Timeouts),
+		ConsecutiveTimeouts: uint32(s.ConsecutiveTimeouts),
+	}
+
+	switch s.Status() {
+	case storage.DeviceClassHealthUnknown:
+		resp.Status = ns.DeviceClassHealthStatus_CLASS_HEALTH_UNKNOWN
+	case storage.DeviceClassHealthOK:
+		resp.Status = ns.DeviceClassHealthStatus_CLASS_HEALTH_OK
+	case storage.DeviceClassHealthMisclassified:
+		resp.Status = ns.DeviceClassHealthStatus_CLASS_HEALTH_MISCLASSIFIED
+	}
+
+	if s.LastAcknowledgedAt != nil {
+		resp.LastAcknowledgedAt, err = ptypes.TimestampProto(*s.LastAcknowledgedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &resp, nil
+}
+
 // GetRandomDevAddr returns a random DevAddr.
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *empty.Empty) (*ns.GetRandomDevAddrResponse, error) {
 	devAddr, err := storage.GetRandomDevAddr(config.C.NetworkServer.NetID)
@@ -790,7 +1336,16 @@ func (n *NetworkServerAPI) SendProprietaryPayload(ctx context.Context, req *ns.S
 		gwIDs = append(gwIDs, id)
 	}
 
-	err := proprietarydown.Handle(ctx, req.MacPayload, mic, gwIDs, req.PolarizationInversion, int(req.Frequency), int(req.Dr))
+	var emitAt *time.Duration
+	if req.TimeSinceGpsEpoch != nil {
+		d, err := ptypes.Duration(req.TimeSinceGpsEpoch)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		emitAt = &d
+	}
+
+	err := proprietarydown.Handle(ctx, req.MacPayload, mic, gwIDs, req.PolarizationInversion, int(req.Frequency), int(req.Dr), emitAt)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -813,12 +1368,21 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 			Latitude:  req.Gateway.Location.Latitude,
 			Longitude: req.Gateway.Location.Longitude,
 		},
-		Altitude: req.Gateway.Location.Altitude,
+		Altitude:    req.Gateway.Location.Altitude,
+		SubBand:     int(req.Gateway.SubBand),
+		AntennaGain: float64(req.Gateway.AntennaGain),
+		CableLoss:   float64(req.Gateway.CableLoss),
 	}
 
 	// Gateway ID
 	copy(gw.GatewayID[:], req.Gateway.Id)
 
+	if gw.SubBand != 0 {
+		if _, err := band.GetSubBandChannels(gw.SubBand); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%s", err)
+		}
+	}
+
 	// Gateway-profile ID.
 	if b := req.Gateway.GatewayProfileId; len(b) != 0 {
 		var gpID uuid.UUID
@@ -829,25 +1393,13 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 	// Routing Profile ID.
 	copy(gw.RoutingProfileID[:], req.Gateway.RoutingProfileId)
 
-	for _, board := range req.Gateway.Boards {
-		var gwBoard storage.GatewayBoard
+	boards, err := gatewayBoardsFromPB(req.Gateway.Boards)
+	if err != nil {
+		return nil, err
+	}
+	gw.Boards = boards
 
-		if b := board.FpgaId; len(b) != 0 {
-			var fpgaID lorawan.EUI64
-			copy(fpgaID[:], b)
-			gwBoard.FPGAID = &fpgaID
-		}
-
-		if b := board.FineTimestampKey; len(b) != 0 {
-			var key lorawan.AES128Key
-			copy(key[:], b)
-			gwBoard.FineTimestampKey = &key
-		}
-
-		gw.Boards = append(gw.Boards, gwBoard)
-	}
-
-	err := storage.Transaction(func(tx sqlx.Ext) error {
+	err = storage.Transaction(func(tx sqlx.Ext) error {
 		if err := storage.CreateGateway(ctx, tx, &gw); err != nil {
 			return errToRPCError(err)
 		}
@@ -874,6 +1426,9 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 		Gateway: &ns.Gateway{
 			Id:               gw.GatewayID[:],
 			RoutingProfileId: gw.RoutingProfileID[:],
+			SubBand:          uint32(gw.SubBand),
+			AntennaGain:      float32(gw.AntennaGain),
+			CableLoss:        float32(gw.CableLoss),
 			Location: &common.Location{
 				Latitude:  gw.Location.Latitude,
 				Longitude: gw.Location.Longitude,
@@ -897,18 +1452,7 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 		resp.LastSeenAt, _ = ptypes.TimestampProto(*gw.LastSeenAt)
 	}
 
-	for i := range gw.Boards {
-		var gwBoard ns.GatewayBoard
-		if gw.Boards[i].FPGAID != nil {
-			gwBoard.FpgaId = gw.Boards[i].FPGAID[:]
-		}
-
-		if gw.Boards[i].FineTimestampKey != nil {
-			gwBoard.FineTimestampKey = gw.Boards[i].FineTimestampKey[:]
-		}
-
-		resp.Gateway.Boards = append(resp.Gateway.Boards, &gwBoard)
-	}
+	resp.Gateway.Boards = gatewayBoardsToPB(gw.Boards)
 
 	return &resp, nil
 }
@@ -949,24 +1493,20 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 	}
 	gw.Altitude = req.Gateway.Location.Altitude
 
-	gw.Boards = nil
-	for _, board := range req.Gateway.Boards {
-		var gwBoard storage.GatewayBoard
-
-		if b := board.FpgaId; len(b) != 0 {
-			var fpgaID lorawan.EUI64
-			copy(fpgaID[:], b)
-			gwBoard.FPGAID = &fpgaID
-		}
-
-		if b := board.FineTimestampKey; len(b) != 0 {
-			var key lorawan.AES128Key
-			copy(key[:], b)
-			gwBoard.FineTimestampKey = &key
+	gw.AntennaGain = float64(req.Gateway.AntennaGain)
+	gw.CableLoss = float64(req.Gateway.CableLoss)
+	gw.SubBand = int(req.Gateway.SubBand)
+	if gw.SubBand != 0 {
+		if _, err := band.GetSubBandChannels(gw.SubBand); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%s", err)
 		}
+	}
 
-		gw.Boards = append(gw.Boards, gwBoard)
+	boards, err := gatewayBoardsFromPB(req.Gateway.Boards)
+	if err != nil {
+		return nil, err
 	}
+	gw.Boards = boards
 
 	if err = storage.FlushGatewayCache(ctx, storage.RedisPool(), gw.GatewayID); err != nil {
 		return nil, errToRPCError(err)
@@ -998,6 +1538,10 @@ func (n *NetworkServerAPI) DeleteGateway(ctx context.Context, req *ns.DeleteGate
 		return nil, errToRPCError(err)
 	}
 
+	if err := storage.StopGatewayDrain(ctx, storage.RedisPool(), id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	return &empty.Empty{}, nil
 }
 
@@ -1047,6 +1591,11 @@ func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForG
 	var id lorawan.EUI64
 	copy(id[:], req.GatewayId)
 
+	filter, err := frameLogFilterFromPB(req.Filter)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		err := framelog.GetFrameLogForGateway(srv.Context(), storage.RedisPool(), id, frameLogChan)
 		if err != nil {
@@ -1056,6 +1605,10 @@ func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForG
 	}()
 
 	for fl := range frameLogChan {
+		if !filter.Match(fl) {
+			continue
+		}
+
 		resp := ns.StreamFrameLogsForGatewayResponse{}
 
 		if fl.UplinkFrame != nil {
@@ -1084,6 +1637,11 @@ func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDe
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEui)
 
+	filter, err := frameLogFilterFromPB(req.Filter)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		err := framelog.GetFrameLogForDevice(srv.Context(), storage.RedisPool(), devEUI, frameLogChan)
 		if err != nil {
@@ -1093,6 +1651,10 @@ func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDe
 	}()
 
 	for fl := range frameLogChan {
+		if !filter.Match(fl) {
+			continue
+		}
+
 		resp := ns.StreamFrameLogsForDeviceResponse{}
 
 		if fl.UplinkFrame != nil {
@@ -1115,6 +1677,262 @@ func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDe
 	return nil
 }
 
+// CreateFrameLogCapture starts a bounded frame-log capture session for the
+// given device or gateway.
+func (n *NetworkServerAPI) CreateFrameLogCapture(ctx context.Context, req *ns.CreateFrameLogCaptureRequest) (*ns.CreateFrameLogCaptureResponse, error) {
+	var c framelog.Capture
+	var err error
+
+	if len(req.DevEui) != 0 {
+		if err := c.DevEUI.UnmarshalBinary(req.DevEui); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+	if len(req.GatewayId) != 0 {
+		if err := c.GatewayID.UnmarshalBinary(req.GatewayId); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	c.Filter, err = frameLogFilterFromPB(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	c.MaxFrames = int(req.MaxFrames)
+
+	if req.Duration == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "duration must not be nil")
+	}
+	duration, err := ptypes.Duration(req.Duration)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := framelog.StartCapture(ctx, storage.RedisPool(), &c, duration); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.CreateFrameLogCaptureResponse{
+		Id: c.ID.Bytes(),
+	}
+	resp.EndsAt, err = ptypes.TimestampProto(c.EndsAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// GetFrameLogCapture returns the status of the given capture session and the
+// frames captured so far.
+func (n *NetworkServerAPI) GetFrameLogCapture(ctx context.Context, req *ns.GetFrameLogCaptureRequest) (*ns.GetFrameLogCaptureResponse, error) {
+	var id uuid.UUID
+	copy(id[:], req.Id)
+
+	c, err := framelog.GetCapture(ctx, storage.RedisPool(), id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	frames, err := framelog.GetCapturedFrames(ctx, storage.RedisPool(), id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var buf bytes.Buffer
+	switch req.Format {
+	case ns.FrameLogCaptureFormat_JSON:
+		err = framelog.WriteCaptureJSON(&buf, c, frames)
+	case ns.FrameLogCaptureFormat_PCAPNG:
+		var keys []framelog.SessionKeyHint
+		if req.IncludeSessionKeys {
+			keys, err = frameLogSessionKeyHints(ctx, c)
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+		}
+		err = framelog.WriteCapturePCAPNG(&buf, c, frames, keys)
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "unsupported format: %s", req.Format)
+	}
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetFrameLogCaptureResponse{
+		Completed:  c.Completed(len(frames)),
+		FrameCount: uint32(len(frames)),
+		Data:       buf.Bytes(),
+	}
+
+	resp.CreatedAt, err = ptypes.TimestampProto(c.CreatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	resp.EndsAt, err = ptypes.TimestampProto(c.EndsAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// frameLogSessionKeyHints returns the session-key hints for the device of
+// the given capture. It returns no hints for gateway captures or when the
+// device has no device-session.
+func frameLogSessionKeyHints(ctx context.Context, c framelog.Capture) ([]framelog.SessionKeyHint, error) {
+	if c.DevEUI == (lorawan.EUI64{}) {
+		return nil, nil
+	}
+
+	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), c.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	hint := framelog.SessionKeyHint{
+		DevAddr: ds.DevAddr,
+		NwkSKey: ds.NwkSEncKey,
+	}
+
+	if env := ds.AppSKeyEvelope; env != nil && env.KEKLabel == "" && len(env.AESKey) == len(lorawan.AES128Key{}) {
+		var key lorawan.AES128Key
+		copy(key[:], env.AESKey)
+		hint.AppSKey = &key
+	}
+
+	return []framelog.SessionKeyHint{hint}, nil
+}
+
+// decodeDataPHYPayload validates the MIC of the given data PHYPayload using
+// the device-session of the given DevEUI, or the device-sessions matching
+// its DevAddr. When the MIC is valid, the mac-commands and (when authorized)
+// the FRMPayload are decrypted.
+func decodeDataPHYPayload(ctx context.Context, req *ns.DecodePHYPayloadRequest, devEUI *lorawan.EUI64, phy *lorawan.PHYPayload, resp *ns.DecodePHYPayloadResponse) error {
+	macPL := phy.MACPayload.(*lorawan.MACPayload)
+	uplink := phy.MHDR.MType == lorawan.UnconfirmedDataUp || phy.MHDR.MType == lorawan.ConfirmedDataUp
+
+	var sessions []storage.DeviceSession
+	if devEUI != nil {
+		ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), *devEUI)
+		if err != nil {
+			return err
+		}
+		sessions = append(sessions, ds)
+	} else {
+		var err error
+		sessions, err = storage.GetDeviceSessionsForDevAddr(ctx, storage.RedisPool(), macPL.FHDR.DevAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	fCnt := macPL.FHDR.FCnt
+	for _, ds := range sessions {
+		var micOK bool
+		var err error
+
+		// the PHYPayload only contains the 16 LSB of the frame-counter
+		if uplink {
+			macPL.FHDR.FCnt = closestFullFCnt(ds.FCntUp, fCnt)
+			micOK, err = phy.ValidateUplinkDataMIC(ds.GetMACVersion(), ds.ConfFCnt, uint8(req.TxDr), uint8(req.TxCh), ds.FNwkSIntKey, ds.SNwkSIntKey)
+		} else {
+			fCntDown := ds.NFCntDown
+			if ds.GetMACVersion() != lorawan.LoRaWAN1_0 && macPL.FPort != nil && *macPL.FPort > 0 {
+				fCntDown = ds.AFCntDown
+			}
+			macPL.FHDR.FCnt = closestFullFCnt(fCntDown, fCnt)
+			micOK, err = phy.ValidateDownlinkDataMIC(ds.GetMACVersion(), ds.FCntUp-1, ds.SNwkSIntKey)
+		}
+		if err != nil {
+			return errors.Wrap(err, "validate mic error")
+		}
+
+		if !micOK {
+			macPL.FHDR.FCnt = fCnt
+			if devEUI != nil {
+				resp.DevEui = ds.DevEUI[:]
+			}
+			continue
+		}
+
+		resp.DevEui = ds.DevEUI[:]
+		resp.MicValid = true
+		resp.FCnt = macPL.FHDR.FCnt
+
+		return decryptDataPHYPayload(ctx, req, ds, phy, resp)
+	}
+
+	return nil
+}
+
+// decryptDataPHYPayload decrypts the mac-commands of the given PHYPayload.
+// The application FRMPayload is only decrypted when requested, when the
+// AppSKey is known to the network-server and when the service-profile of
+// the device does not redact the FRMPayload.
+func decryptDataPHYPayload(ctx context.Context, req *ns.DecodePHYPayloadRequest, ds storage.DeviceSession, phy *lorawan.PHYPayload, resp *ns.DecodePHYPayloadResponse) error {
+	macPL := phy.MACPayload.(*lorawan.MACPayload)
+
+	if ds.GetMACVersion() == lorawan.LoRaWAN1_0 {
+		if err := phy.DecodeFOptsToMACCommands(); err != nil {
+			return errors.Wrap(err, "decode fOpts to mac-commands error")
+		}
+	} else {
+		if err := phy.DecryptFOpts(ds.NwkSEncKey); err != nil {
+			return errors.Wrap(err, "decrypt fOpts mac-commands error")
+		}
+	}
+
+	if macPL.FPort != nil && *macPL.FPort == 0 {
+		if err := phy.DecryptFRMPayload(ds.NwkSEncKey); err != nil {
+			return errors.Wrap(err, "decrypt FRMPayload mac-commands error")
+		}
+	}
+	resp.MacCommandsDecrypted = true
+
+	if !req.DecryptFrmPayload || macPL.FPort == nil || *macPL.FPort == 0 {
+		return nil
+	}
+
+	sp, err := storage.GetAndCacheServiceProfile(ctx, storage.DB(), storage.RedisPool(), ds.ServiceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get service-profile error")
+	}
+	if sp.FrameLogRedactPayload {
+		return nil
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx, ds)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get AppSKey error")
+	}
+
+	if err := phy.DecryptFRMPayload(appSKey); err != nil {
+		return errors.Wrap(err, "decrypt FRMPayload error")
+	}
+	resp.FrmPayloadDecrypted = true
+
+	return nil
+}
+
+// closestFullFCnt returns the full 32 bit frame-counter, having the 16 LSB
+// of the given fCnt, which is closest to the given reference frame-counter.
+func closestFullFCnt(ref, fCnt uint32) uint32 {
+	full := (ref &^ 0xffff) | (fCnt & 0xffff)
+	if full > ref && full-ref > 0x8000 && full >= 0x10000 {
+		full -= 0x10000
+	} else if ref > full && ref-full > 0x8000 && full < math.MaxUint32-0xffff {
+		full += 0x10000
+	}
+	return full
+}
+
 // CreateGatewayProfile creates the given gateway-profile.
 func (n *NetworkServerAPI) CreateGatewayProfile(ctx context.Context, req *ns.CreateGatewayProfileRequest) (*ns.CreateGatewayProfileResponse, error) {
 	if req.GatewayProfile == nil {
@@ -1124,8 +1942,24 @@ func (n *NetworkServerAPI) CreateGatewayProfile(ctx context.Context, req *ns.Cre
 	var gpID uuid.UUID
 	copy(gpID[:], req.GatewayProfile.Id)
 
+	if !band.IsRegion(req.GatewayProfile.Region) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown region: %s", req.GatewayProfile.Region)
+	}
+	if !band.IsChannelGroup(req.GatewayProfile.Region, req.GatewayProfile.ChannelGroup) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown channel-group: %s", req.GatewayProfile.ChannelGroup)
+	}
+
 	gc := storage.GatewayProfile{
-		ID: gpID,
+		ID:           gpID,
+		Region:       req.GatewayProfile.Region,
+		ChannelGroup: req.GatewayProfile.ChannelGroup,
+	}
+	setGatewayProfileLBT(&gc, req.GatewayProfile.Lbt)
+	if err := setGatewayProfileFrequencyPlan(ctx, &gc, req.GatewayProfile); err != nil {
+		return nil, err
+	}
+	if err := setGatewayProfileReconfigurationWindow(&gc, req.GatewayProfile.ReconfigurationWindow); err != nil {
+		return nil, err
 	}
 
 	for _, c := range req.GatewayProfile.Channels {
@@ -1175,10 +2009,34 @@ func (n *NetworkServerAPI) GetGatewayProfile(ctx context.Context, req *ns.GetGat
 
 	out := ns.GetGatewayProfileResponse{
 		GatewayProfile: &ns.GatewayProfile{
-			Id: gc.ID.Bytes(),
+			Id:                   gc.ID.Bytes(),
+			Region:               gc.Region,
+			ChannelGroup:         gc.ChannelGroup,
+			FrequencyPlanVersion: uint32(gc.FrequencyPlanVersion),
 		},
 	}
 
+	if gc.FrequencyPlanID != nil {
+		out.GatewayProfile.FrequencyPlanId = gc.FrequencyPlanID.Bytes()
+	}
+
+	if gc.ReconfigurationWindowDuration != 0 {
+		out.GatewayProfile.ReconfigurationWindow = &ns.GatewayProfileReconfigurationWindow{
+			StartMinute:     uint32(gc.ReconfigurationWindowStart),
+			DurationMinutes: uint32(gc.ReconfigurationWindowDuration),
+		}
+	}
+
+	if gc.LBTEnabled {
+		out.GatewayProfile.Lbt = &ns.GatewayProfileLBT{
+			RssiTarget: int32(gc.LBTRSSITarget),
+			ScanTime:   uint32(gc.LBTScanTime),
+		}
+		for _, f := range gc.LBTFrequencies {
+			out.GatewayProfile.Lbt.Frequencies = append(out.GatewayProfile.Lbt.Frequencies, uint32(f))
+		}
+	}
+
 	out.CreatedAt, err = ptypes.TimestampProto(gc.CreatedAt)
 	if err != nil {
 		return nil, errToRPCError(err)
@@ -1231,6 +2089,22 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 		return nil, errToRPCError(err)
 	}
 
+	if !band.IsRegion(req.GatewayProfile.Region) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown region: %s", req.GatewayProfile.Region)
+	}
+	if !band.IsChannelGroup(req.GatewayProfile.Region, req.GatewayProfile.ChannelGroup) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "unknown channel-group: %s", req.GatewayProfile.ChannelGroup)
+	}
+	gc.Region = req.GatewayProfile.Region
+	gc.ChannelGroup = req.GatewayProfile.ChannelGroup
+	setGatewayProfileLBT(&gc, req.GatewayProfile.Lbt)
+	if err := setGatewayProfileFrequencyPlan(ctx, &gc, req.GatewayProfile); err != nil {
+		return nil, err
+	}
+	if err := setGatewayProfileReconfigurationWindow(&gc, req.GatewayProfile.ReconfigurationWindow); err != nil {
+		return nil, err
+	}
+
 	gc.Channels = []int64{}
 	for _, c := range req.GatewayProfile.Channels {
 		gc.Channels = append(gc.Channels, int64(c))
@@ -1268,427 +2142,2134 @@ func (n *NetworkServerAPI) UpdateGatewayProfile(ctx context.Context, req *ns.Upd
 	return &empty.Empty{}, nil
 }
 
-// DeleteGatewayProfile deletes the gateway-profile matching a given id.
-func (n *NetworkServerAPI) DeleteGatewayProfile(ctx context.Context, req *ns.DeleteGatewayProfileRequest) (*empty.Empty, error) {
-	var gpID uuid.UUID
-	copy(gpID[:], req.Id)
-
-	if err := storage.DeleteGatewayProfile(ctx, storage.DB(), gpID); err != nil {
-		return nil, errToRPCError(err)
+// validateAllowedGatewayIDs validates that each allowed gateway ID is a
+// valid gateway ID.
+func validateAllowedGatewayIDs(ids [][]byte) error {
+	for _, id := range ids {
+		if len(id) != len(lorawan.EUI64{}) {
+			return fmt.Errorf("invalid allowed gateway id: %x", id)
+		}
 	}
-
-	return &empty.Empty{}, nil
+	return nil
 }
 
-// CreateDeviceQueueItem creates the given device-queue item.
-func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.CreateDeviceQueueItemRequest) (*empty.Empty, error) {
-	if req.Item == nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+func geofenceFromPB(g *ns.MulticastGeofence) *storage.Geofence {
+	if g == nil {
+		return nil
 	}
 
-	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.Item.DevEui)
-
-	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	out := storage.Geofence{
+		Radius: g.Radius,
 	}
-
-	dp, err := storage.GetAndCacheDeviceProfile(ctx, storage.DB(), storage.RedisPool(), d.DeviceProfileID)
-	if err != nil {
-		return nil, errToRPCError(err)
+	if g.Center != nil {
+		out.Center = storage.GPSPoint{
+			Latitude:  g.Center.Latitude,
+			Longitude: g.Center.Longitude,
+		}
 	}
-
-	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), d.DevEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	for _, p := range g.Polygon {
+		out.Polygon = append(out.Polygon, storage.GPSPoint{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+		})
 	}
 
-	var devAddr lorawan.DevAddr
-	copy(devAddr[:], req.Item.DevAddr)
+	return &out
+}
 
-	if (devAddr != lorawan.DevAddr{0, 0, 0, 0} && ds.DevAddr != devAddr) {
-		return nil, grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
+func geofenceToPB(g *storage.Geofence) *ns.MulticastGeofence {
+	if g == nil {
+		return nil
 	}
 
-	qi := storage.DeviceQueueItem{
-		DevAddr:    devAddr,
-		DevEUI:     d.DevEUI,
-		FRMPayload: req.Item.FrmPayload,
-		FCnt:       req.Item.FCnt,
-		FPort:      uint8(req.Item.FPort),
-		Confirmed:  req.Item.Confirmed,
+	out := ns.MulticastGeofence{
+		Radius: g.Radius,
+	}
+	if g.Radius > 0 {
+		out.Center = &common.Location{
+			Latitude:  g.Center.Latitude,
+			Longitude: g.Center.Longitude,
+		}
+	}
+	for _, p := range g.Polygon {
+		out.Polygon = append(out.Polygon, &common.Location{
+			Latitude:  p.Latitude,
+			Longitude: p.Longitude,
+		})
 	}
 
-	// When the device is operating in Class-B and has a beacon lock, calculate
-	// the next ping-slot.
-	if dp.SupportsClassB {
-		// check if device is currently active and is operating in Class-B mode
-		if err == nil && ds.BeaconLocked {
-			scheduleAfterGPSEpochTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx, storage.DB(), d.DevEUI)
-			if err != nil {
-				return nil, errToRPCError(err)
-			}
+	return &out
+}
 
-			if scheduleAfterGPSEpochTS == 0 {
-				scheduleAfterGPSEpochTS = gps.Time(time.Now()).TimeSinceGPSEpoch()
-			}
+func gatewayBoardsFromPB(boards []*ns.GatewayBoard) ([]storage.GatewayBoard, error) {
+	var out []storage.GatewayBoard
 
-			// take some margin into account
-			scheduleAfterGPSEpochTS += classBScheduleMargin
+	for _, board := range boards {
+		var gwBoard storage.GatewayBoard
 
-			gpsEpochTS, err := classb.GetNextPingSlotAfter(scheduleAfterGPSEpochTS, ds.DevAddr, ds.PingSlotNb)
-			if err != nil {
-				return nil, errToRPCError(err)
+		if b := board.FpgaId; len(b) != 0 {
+			var fpgaID lorawan.EUI64
+			copy(fpgaID[:], b)
+			gwBoard.FPGAID = &fpgaID
+		}
+
+		if b := board.FineTimestampKey; len(b) != 0 {
+			var key lorawan.AES128Key
+			copy(key[:], b)
+			gwBoard.FineTimestampKey = &key
+		}
+
+		for _, antenna := range board.Antennas {
+			if antenna.Azimuth < 0 || antenna.Azimuth >= 360 {
+				return nil, grpc.Errorf(codes.InvalidArgument, "antenna azimuth must be between 0 and 360")
+			}
+			if antenna.Beamwidth < 0 || antenna.Beamwidth > 360 {
+				return nil, grpc.Errorf(codes.InvalidArgument, "antenna beamwidth must be between 0 and 360")
 			}
 
-			timeoutTime := time.Time(gps.NewFromTimeSinceGPSEpoch(gpsEpochTS)).Add(time.Second * time.Duration(dp.ClassBTimeout))
-			qi.EmitAtTimeSinceGPSEpoch = &gpsEpochTS
-			qi.TimeoutAfter = &timeoutTime
+			gwBoard.Antennas = append(gwBoard.Antennas, storage.GatewayAntenna{
+				Gain:      float64(antenna.Gain),
+				CableLoss: float64(antenna.CableLoss),
+				Azimuth:   float64(antenna.Azimuth),
+				Beamwidth: float64(antenna.Beamwidth),
+			})
 		}
-	}
 
-	err = storage.CreateDeviceQueueItem(ctx, storage.DB(), &qi)
-	if err != nil {
-		return nil, errToRPCError(err)
+		out = append(out, gwBoard)
 	}
 
-	return &empty.Empty{}, nil
+	return out, nil
 }
 
-// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
-func (n *NetworkServerAPI) FlushDeviceQueueForDevEUI(ctx context.Context, req *ns.FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error) {
-	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.DevEui)
+func gatewayBoardsToPB(boards []storage.GatewayBoard) []*ns.GatewayBoard {
+	var out []*ns.GatewayBoard
 
-	err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), devEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	for i := range boards {
+		var gwBoard ns.GatewayBoard
+		if boards[i].FPGAID != nil {
+			gwBoard.FpgaId = boards[i].FPGAID[:]
+		}
+
+		if boards[i].FineTimestampKey != nil {
+			gwBoard.FineTimestampKey = boards[i].FineTimestampKey[:]
+		}
+
+		for _, antenna := range boards[i].Antennas {
+			gwBoard.Antennas = append(gwBoard.Antennas, &ns.GatewayAntenna{
+				Gain:      float32(antenna.Gain),
+				CableLoss: float32(antenna.CableLoss),
+				Azimuth:   float32(antenna.Azimuth),
+				Beamwidth: float32(antenna.Beamwidth),
+			})
+		}
+
+		out = append(out, &gwBoard)
 	}
 
-	return &empty.Empty{}, nil
+	return out
 }
 
-// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
-func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *ns.GetDeviceQueueItemsForDevEUIRequest) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
-	var devEUI lorawan.EUI64
-	copy(devEUI[:], req.DevEui)
+func gatewayConfigurationStatusToPB(s storage.GatewayConfigurationStatus) (*ns.GatewayConfigurationStatus, error) {
+	out := ns.GatewayConfigurationStatus{
+		GatewayId: s.GatewayID[:],
+		Version:   s.Version,
+	}
 
-	items, err := storage.GetDeviceQueueItemsForDevEUI(ctx, storage.DB(), devEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	switch s.State {
+	case storage.GatewayConfigurationScheduled:
+		out.State = ns.GatewayConfigurationState_CONFIGURATION_SCHEDULED
+	case storage.GatewayConfigurationSent:
+		out.State = ns.GatewayConfigurationState_CONFIGURATION_SENT
+	case storage.GatewayConfigurationApplied:
+		out.State = ns.GatewayConfigurationState_CONFIGURATION_APPLIED
+	default:
+		out.State = ns.GatewayConfigurationState_CONFIGURATION_UNKNOWN
 	}
 
-	var out ns.GetDeviceQueueItemsForDevEUIResponse
-	for i := range items {
-		qi := ns.DeviceQueueItem{
-			DevAddr:    items[i].DevAddr[:],
-			DevEui:     items[i].DevEUI[:],
-			FrmPayload: items[i].FRMPayload,
-			FCnt:       items[i].FCnt,
-			FPort:      uint32(items[i].FPort),
-			Confirmed:  items[i].Confirmed,
+	var err error
+	if !s.UpdatedAt.IsZero() {
+		out.UpdatedAt, err = ptypes.TimestampProto(s.UpdatedAt)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		out.Items = append(out.Items, &qi)
+	if s.ScheduledAt != nil {
+		out.ScheduledAt, err = ptypes.TimestampProto(*s.ScheduledAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.SentAt != nil {
+		out.SentAt, err = ptypes.TimestampProto(*s.SentAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.AppliedAt != nil {
+		out.AppliedAt, err = ptypes.TimestampProto(*s.AppliedAt)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &out, nil
 }
 
-// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
-// This also takes device-queue items for the given DevEUI into consideration.
-// In case the device is not activated, this will return an error as no
-// device-session exists.
-func (n *NetworkServerAPI) GetNextDownlinkFCntForDevEUI(ctx context.Context, req *ns.GetNextDownlinkFCntForDevEUIRequest) (*ns.GetNextDownlinkFCntForDevEUIResponse, error) {
-	var devEUI lorawan.EUI64
-	var resp ns.GetNextDownlinkFCntForDevEUIResponse
+// frameLogFilterFromPB returns the frame-log filter for the given (optional)
+// filter message.
+func frameLogFilterFromPB(f *ns.FrameLogFilter) (framelog.Filter, error) {
+	var out framelog.Filter
+	if f == nil {
+		return out, nil
+	}
 
-	copy(devEUI[:], req.DevEui)
+	if f.FPortMin > 255 || f.FPortMax > 255 || f.FPortMin > f.FPortMax {
+		return out, grpc.Errorf(codes.InvalidArgument, "invalid f_port range: %d - %d", f.FPortMin, f.FPortMax)
+	}
 
-	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), devEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	for _, mType := range f.MTypes {
+		out.MTypes = append(out.MTypes, lorawan.MType(mType))
 	}
 
-	if ds.GetMACVersion() == lorawan.LoRaWAN1_0 {
-		resp.FCnt = ds.NFCntDown
-	} else {
-		resp.FCnt = ds.AFCntDown
+	for _, b := range f.GatewayIds {
+		var id lorawan.EUI64
+		if err := id.UnmarshalBinary(b); err != nil {
+			return out, grpc.Errorf(codes.InvalidArgument, "invalid gateway id: %x", b)
+		}
+		out.GatewayIDs = append(out.GatewayIDs, id)
 	}
 
-	items, err := storage.GetDeviceQueueItemsForDevEUI(ctx, storage.DB(), devEUI)
-	if err != nil {
-		return nil, errToRPCError(err)
+	out.FPortMin = uint8(f.FPortMin)
+	out.FPortMax = uint8(f.FPortMax)
+	out.MinRSSI = int(f.MinRssi)
+	out.MACCommandsOnly = f.MacCommandsOnly
+
+	return out, nil
+}
+
+// validateMaxAirtimePercentage validates the max. airtime percentage of a
+// multicast-group.
+func validateMaxAirtimePercentage(p float64) error {
+	if p < 0 || p > 100 {
+		return fmt.Errorf("invalid max airtime percentage: %f", p)
 	}
-	if count := len(items); count != 0 {
-		resp.FCnt = items[count-1].FCnt + 1 // we want the next usable frame-counter
+	return nil
+}
+
+func setGatewayProfileLBT(gc *storage.GatewayProfile, lbt *ns.GatewayProfileLBT) {
+	gc.LBTEnabled = lbt != nil
+	gc.LBTRSSITarget = 0
+	gc.LBTScanTime = 0
+	gc.LBTFrequencies = nil
+
+	if lbt == nil {
+		return
 	}
 
-	return &resp, nil
+	gc.LBTRSSITarget = int(lbt.RssiTarget)
+	gc.LBTScanTime = int(lbt.ScanTime)
+	for _, f := range lbt.Frequencies {
+		gc.LBTFrequencies = append(gc.LBTFrequencies, int64(f))
+	}
 }
 
-// CreateMulticastGroup creates the given multicast-group.
-func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.CreateMulticastGroupRequest) (*ns.CreateMulticastGroupResponse, error) {
-	if req.MulticastGroup == nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
-	}
+// setGatewayProfileFrequencyPlan assigns the frequency-plan (version) to the
+// gateway-profile. It returns an error when the frequency-plan or the given
+// version does not exist.
+func setGatewayProfileFrequencyPlan(ctx context.Context, gc *storage.GatewayProfile, gp *ns.GatewayProfile) error {
+	gc.FrequencyPlanID = nil
+	gc.FrequencyPlanVersion = 0
 
-	mg := storage.MulticastGroup{
-		FCnt:           req.MulticastGroup.FCnt,
-		DR:             int(req.MulticastGroup.Dr),
-		Frequency:      int(req.MulticastGroup.Frequency),
-		PingSlotPeriod: int(req.MulticastGroup.PingSlotPeriod),
+	if len(gp.FrequencyPlanId) == 0 {
+		return nil
 	}
 
-	switch req.MulticastGroup.GroupType {
-	case ns.MulticastGroupType_CLASS_B:
-		mg.GroupType = storage.MulticastGroupB
-	case ns.MulticastGroupType_CLASS_C:
-		mg.GroupType = storage.MulticastGroupC
-	default:
-		return nil, grpc.Errorf(codes.InvalidArgument, "invalid group_type")
+	var fpID uuid.UUID
+	copy(fpID[:], gp.FrequencyPlanId)
+
+	if _, err := storage.GetFrequencyPlanVersion(ctx, storage.DB(), fpID, int(gp.FrequencyPlanVersion)); err != nil {
+		if err == storage.ErrDoesNotExist {
+			return grpc.Errorf(codes.InvalidArgument, "frequency-plan %s version %d does not exist", fpID, gp.FrequencyPlanVersion)
+		}
+		return errToRPCError(err)
 	}
 
-	copy(mg.ID[:], req.MulticastGroup.Id)
-	copy(mg.MCAddr[:], req.MulticastGroup.McAddr)
-	copy(mg.MCNwkSKey[:], req.MulticastGroup.McNwkSKey)
-	copy(mg.ServiceProfileID[:], req.MulticastGroup.ServiceProfileId)
-	copy(mg.RoutingProfileID[:], req.MulticastGroup.RoutingProfileId)
+	gc.FrequencyPlanID = &fpID
+	gc.FrequencyPlanVersion = int(gp.FrequencyPlanVersion)
 
-	if err := storage.CreateMulticastGroup(ctx, storage.DB(), &mg); err != nil {
-		return nil, errToRPCError(err)
+	return nil
+}
+
+// setGatewayProfileReconfigurationWindow sets the reconfiguration window of
+// the gateway-profile. When the window is nil, configuration updates are
+// sent immediately.
+func setGatewayProfileReconfigurationWindow(gc *storage.GatewayProfile, w *ns.GatewayProfileReconfigurationWindow) error {
+	gc.ReconfigurationWindowStart = 0
+	gc.ReconfigurationWindowDuration = 0
+
+	if w == nil {
+		return nil
 	}
 
-	return &ns.CreateMulticastGroupResponse{
-		Id: mg.ID.Bytes(),
-	}, nil
+	if w.StartMinute >= 24*60 {
+		return grpc.Errorf(codes.InvalidArgument, "reconfiguration window start_minute must be less than %d", 24*60)
+	}
+	if w.DurationMinutes == 0 || w.DurationMinutes > 24*60 {
+		return grpc.Errorf(codes.InvalidArgument, "reconfiguration window duration_minutes must be between 1 and %d", 24*60)
+	}
+
+	gc.ReconfigurationWindowStart = int(w.StartMinute)
+	gc.ReconfigurationWindowDuration = int(w.DurationMinutes)
+
+	return nil
 }
 
-// GetMulticastGroup returns the multicast-group given an id.
-func (n *NetworkServerAPI) GetMulticastGroup(ctx context.Context, req *ns.GetMulticastGroupRequest) (*ns.GetMulticastGroupResponse, error) {
-	var mgID uuid.UUID
-	copy(mgID[:], req.Id)
+// DeleteGatewayProfile deletes the gateway-profile matching a given id.
+func (n *NetworkServerAPI) DeleteGatewayProfile(ctx context.Context, req *ns.DeleteGatewayProfileRequest) (*empty.Empty, error) {
+	var gpID uuid.UUID
+	copy(gpID[:], req.Id)
 
-	mg, err := storage.GetMulticastGroup(ctx, storage.DB(), mgID, false)
-	if err != nil {
+	if err := storage.DeleteGatewayProfile(ctx, storage.DB(), gpID); err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	resp := ns.GetMulticastGroupResponse{
-		MulticastGroup: &ns.MulticastGroup{
-			Id:               mg.ID.Bytes(),
-			McAddr:           mg.MCAddr[:],
-			McNwkSKey:        mg.MCNwkSKey[:],
-			FCnt:             mg.FCnt,
-			Dr:               uint32(mg.DR),
-			Frequency:        uint32(mg.Frequency),
-			PingSlotPeriod:   uint32(mg.PingSlotPeriod),
-			ServiceProfileId: mg.ServiceProfileID.Bytes(),
-			RoutingProfileId: mg.RoutingProfileID.Bytes(),
-		},
-	}
+	return &empty.Empty{}, nil
+}
 
-	switch mg.GroupType {
-	case storage.MulticastGroupB:
-		resp.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_B
-	case storage.MulticastGroupC:
-		resp.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_C
-	default:
-		return nil, grpc.Errorf(codes.Internal, "invalid group-type: %s", mg.GroupType)
+// CreateFrequencyPlan creates the given frequency-plan.
+func (n *NetworkServerAPI) CreateFrequencyPlan(ctx context.Context, req *ns.CreateFrequencyPlanRequest) (*ns.CreateFrequencyPlanResponse, error) {
+	if req.FrequencyPlan == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frequency_plan must not be nil")
 	}
 
-	resp.CreatedAt, err = ptypes.TimestampProto(mg.CreatedAt)
+	fp := frequencyPlanFromProto(req.FrequencyPlan)
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		return storage.CreateFrequencyPlan(ctx, tx, &fp)
+	})
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	resp.UpdatedAt, err = ptypes.TimestampProto(mg.UpdatedAt)
+	return &ns.CreateFrequencyPlanResponse{Id: fp.ID.Bytes()}, nil
+}
+
+// GetFrequencyPlan returns the frequency-plan given an id and optional version.
+func (n *NetworkServerAPI) GetFrequencyPlan(ctx context.Context, req *ns.GetFrequencyPlanRequest) (*ns.GetFrequencyPlanResponse, error) {
+	var fpID uuid.UUID
+	copy(fpID[:], req.Id)
+
+	fp, err := storage.GetFrequencyPlanVersion(ctx, storage.DB(), fpID, int(req.Version))
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	return &resp, nil
-}
-
-// UpdateMulticastGroup updates the given multicast-group.
-func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.UpdateMulticastGroupRequest) (*empty.Empty, error) {
-	if req.MulticastGroup == nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
+	out := ns.GetFrequencyPlanResponse{
+		FrequencyPlan: &ns.FrequencyPlan{
+			Id:                fp.ID.Bytes(),
+			Name:              fp.Name,
+			Rx2Frequency:      uint32(fp.RX2Frequency),
+			Rx2Dr:             uint32(fp.RX2DR),
+			BeaconFrequency:   uint32(fp.BeaconFrequency),
+			BeaconDr:          uint32(fp.BeaconDR),
+			PingSlotFrequency: uint32(fp.PingSlotFrequency),
+			PingSlotDr:        uint32(fp.PingSlotDR),
+		},
+		Version: uint32(fp.Version),
 	}
 
-	var mgID uuid.UUID
-	copy(mgID[:], req.MulticastGroup.Id)
+	for _, c := range fp.Channels {
+		out.FrequencyPlan.Channels = append(out.FrequencyPlan.Channels, uint32(c))
+	}
 
-	err := storage.Transaction(func(tx sqlx.Ext) error {
-		mg, err := storage.GetMulticastGroup(ctx, tx, mgID, true)
-		if err != nil {
-			return errToRPCError(err)
+	for _, ec := range fp.ExtraChannels {
+		c := ns.GatewayProfileExtraChannel{
+			Frequency: uint32(ec.Frequency),
+			Bandwidth: uint32(ec.Bandwidth),
+			Bitrate:   uint32(ec.Bitrate),
 		}
 
-		copy(mg.MCAddr[:], req.MulticastGroup.McAddr)
-		copy(mg.MCNwkSKey[:], req.MulticastGroup.McNwkSKey)
-		copy(mg.ServiceProfileID[:], req.MulticastGroup.ServiceProfileId)
-		copy(mg.RoutingProfileID[:], req.MulticastGroup.RoutingProfileId)
-		mg.FCnt = req.MulticastGroup.FCnt
-		mg.DR = int(req.MulticastGroup.Dr)
-		mg.Frequency = int(req.MulticastGroup.Frequency)
-		mg.PingSlotPeriod = int(req.MulticastGroup.PingSlotPeriod)
-
-		switch req.MulticastGroup.GroupType {
-		case ns.MulticastGroupType_CLASS_B:
-			mg.GroupType = storage.MulticastGroupB
-		case ns.MulticastGroupType_CLASS_C:
-			mg.GroupType = storage.MulticastGroupC
+		switch ec.Modulation {
+		case storage.ModulationFSK:
+			c.Modulation = common.Modulation_FSK
 		default:
-			return grpc.Errorf(codes.InvalidArgument, "invalid group_type")
+			c.Modulation = common.Modulation_LORA
 		}
 
-		if err := storage.UpdateMulticastGroup(ctx, tx, &mg); err != nil {
-			return errToRPCError(err)
+		for _, sf := range ec.SpreadingFactors {
+			c.SpreadingFactors = append(c.SpreadingFactors, uint32(sf))
 		}
 
-		return nil
-	})
+		out.FrequencyPlan.ExtraChannels = append(out.FrequencyPlan.ExtraChannels, &c)
+	}
+
+	out.CreatedAt, err = ptypes.TimestampProto(fp.CreatedAt)
 	if err != nil {
-		return nil, err
+		return nil, errToRPCError(err)
 	}
 
-	return &empty.Empty{}, nil
+	out.UpdatedAt, err = ptypes.TimestampProto(fp.UpdatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &out, nil
 }
 
-// DeleteMulticastGroup deletes a multicast-group given an id.
-func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.DeleteMulticastGroupRequest) (*empty.Empty, error) {
-	var mgID uuid.UUID
-	copy(mgID[:], req.Id)
+// UpdateFrequencyPlan updates the given frequency-plan.
+// Each update creates a new frequency-plan version.
+func (n *NetworkServerAPI) UpdateFrequencyPlan(ctx context.Context, req *ns.UpdateFrequencyPlanRequest) (*ns.UpdateFrequencyPlanResponse, error) {
+	if req.FrequencyPlan == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frequency_plan must not be nil")
+	}
 
-	if err := storage.DeleteMulticastGroup(ctx, storage.DB(), mgID); err != nil {
+	fp := frequencyPlanFromProto(req.FrequencyPlan)
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		return storage.UpdateFrequencyPlan(ctx, tx, &fp)
+	})
+	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	return &empty.Empty{}, nil
+	return &ns.UpdateFrequencyPlanResponse{Version: uint32(fp.Version)}, nil
 }
 
-// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
-func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *ns.AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
-	var devEUI lorawan.EUI64
-	var mgID uuid.UUID
-	copy(devEUI[:], req.DevEui)
-	copy(mgID[:], req.MulticastGroupId)
+// DeleteFrequencyPlan deletes the frequency-plan matching a given id.
+func (n *NetworkServerAPI) DeleteFrequencyPlan(ctx context.Context, req *ns.DeleteFrequencyPlanRequest) (*empty.Empty, error) {
+	var fpID uuid.UUID
+	copy(fpID[:], req.Id)
 
-	if err := storage.AddDeviceToMulticastGroup(ctx, storage.DB(), devEUI, mgID); err != nil {
+	if err := storage.DeleteFrequencyPlan(ctx, storage.DB(), fpID); err != nil {
 		return nil, errToRPCError(err)
 	}
 
 	return &empty.Empty{}, nil
 }
 
-// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
-func (n *NetworkServerAPI) RemoveDeviceFromMulticastGroup(ctx context.Context, req *ns.RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
-	var devEUI lorawan.EUI64
-	var mgID uuid.UUID
-	copy(devEUI[:], req.DevEui)
-	copy(mgID[:], req.MulticastGroupId)
+// GetFrequencyPlanVersions returns the versions of the given frequency-plan.
+func (n *NetworkServerAPI) GetFrequencyPlanVersions(ctx context.Context, req *ns.GetFrequencyPlanVersionsRequest) (*ns.GetFrequencyPlanVersionsResponse, error) {
+	var fpID uuid.UUID
+	copy(fpID[:], req.Id)
 
-	if err := storage.RemoveDeviceFromMulticastGroup(ctx, storage.DB(), devEUI, mgID); err != nil {
+	versions, err := storage.GetFrequencyPlanVersions(ctx, storage.DB(), fpID)
+	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	return &empty.Empty{}, nil
-}
+	var out ns.GetFrequencyPlanVersionsResponse
+	for _, v := range versions {
+		createdAt, err := ptypes.TimestampProto(v.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
 
-// EnqueueMulticastQueueItem creates the given multicast queue-item.
-func (n *NetworkServerAPI) EnqueueMulticastQueueItem(ctx context.Context, req *ns.EnqueueMulticastQueueItemRequest) (*empty.Empty, error) {
-	if req.MulticastQueueItem == nil {
-		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_queue_item must not be nil")
+		out.Versions = append(out.Versions, &ns.FrequencyPlanVersion{
+			Version:   uint32(v.Version),
+			CreatedAt: createdAt,
+		})
 	}
 
-	var mgID uuid.UUID
-	copy(mgID[:], req.MulticastQueueItem.MulticastGroupId)
+	return &out, nil
+}
 
-	qi := storage.MulticastQueueItem{
-		MulticastGroupID: mgID,
-		FCnt:             req.MulticastQueueItem.FCnt,
-		FPort:            uint8(req.MulticastQueueItem.FPort),
-		FRMPayload:       req.MulticastQueueItem.FrmPayload,
+func frequencyPlanFromProto(in *ns.FrequencyPlan) storage.FrequencyPlan {
+	var fpID uuid.UUID
+	copy(fpID[:], in.Id)
+
+	fp := storage.FrequencyPlan{
+		ID:                fpID,
+		Name:              in.Name,
+		RX2Frequency:      int(in.Rx2Frequency),
+		RX2DR:             int(in.Rx2Dr),
+		BeaconFrequency:   int(in.BeaconFrequency),
+		BeaconDR:          int(in.BeaconDr),
+		PingSlotFrequency: int(in.PingSlotFrequency),
+		PingSlotDR:        int(in.PingSlotDr),
 	}
 
-	err := storage.Transaction(func(tx sqlx.Ext) error {
-		return multicast.EnqueueQueueItem(ctx, storage.RedisPool(), tx, qi)
-	})
+	for _, c := range in.Channels {
+		fp.Channels = append(fp.Channels, int64(c))
+	}
+
+	for _, ec := range in.ExtraChannels {
+		c := storage.ExtraChannel{
+			Frequency: int(ec.Frequency),
+			Bandwidth: int(ec.Bandwidth),
+			Bitrate:   int(ec.Bitrate),
+		}
+
+		switch ec.Modulation {
+		case common.Modulation_FSK:
+			c.Modulation = storage.ModulationFSK
+		default:
+			c.Modulation = storage.ModulationLoRa
+		}
+
+		for _, sf := range ec.SpreadingFactors {
+			c.SpreadingFactors = append(c.SpreadingFactors, int64(sf))
+		}
+
+		fp.ExtraChannels = append(fp.ExtraChannels, c)
+	}
+
+	return fp
+}
+
+// CreateRoamingAgreement creates the given roaming agreement.
+func (n *NetworkServerAPI) CreateRoamingAgreement(ctx context.Context, req *ns.CreateRoamingAgreementRequest) (*ns.CreateRoamingAgreementResponse, error) {
+	if req.RoamingAgreement == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "roaming_agreement must not be nil")
+	}
+
+	ra, err := roamingAgreementFromProto(req.RoamingAgreement)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.CreateRoamingAgreement(ctx, storage.DB(), &ra); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.CreateRoamingAgreementResponse{Id: ra.ID.Bytes()}, nil
+}
+
+// GetRoamingAgreement returns the roaming agreement matching the given id.
+func (n *NetworkServerAPI) GetRoamingAgreement(ctx context.Context, req *ns.GetRoamingAgreementRequest) (*ns.GetRoamingAgreementResponse, error) {
+	var raID uuid.UUID
+	copy(raID[:], req.Id)
+
+	ra, err := storage.GetRoamingAgreement(ctx, storage.DB(), raID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.GetRoamingAgreementResponse{
+		RoamingAgreement: roamingAgreementToProto(ra),
+	}
+
+	out.CreatedAt, err = ptypes.TimestampProto(ra.CreatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out.UpdatedAt, err = ptypes.TimestampProto(ra.UpdatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &out, nil
+}
+
+// UpdateRoamingAgreement updates the given roaming agreement.
+func (n *NetworkServerAPI) UpdateRoamingAgreement(ctx context.Context, req *ns.UpdateRoamingAgreementRequest) (*empty.Empty, error) {
+	if req.RoamingAgreement == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "roaming_agreement must not be nil")
+	}
+
+	ra, err := roamingAgreementFromProto(req.RoamingAgreement)
 	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.UpdateRoamingAgreement(ctx, storage.DB(), &ra); err != nil {
 		return nil, errToRPCError(err)
 	}
 
 	return &empty.Empty{}, nil
 }
 
-// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
-func (n *NetworkServerAPI) FlushMulticastQueueForMulticastGroup(ctx context.Context, req *ns.FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error) {
-	var mgID uuid.UUID
-	copy(mgID[:], req.MulticastGroupId)
+// DeleteRoamingAgreement deletes the roaming agreement matching the given id.
+func (n *NetworkServerAPI) DeleteRoamingAgreement(ctx context.Context, req *ns.DeleteRoamingAgreementRequest) (*empty.Empty, error) {
+	var raID uuid.UUID
+	copy(raID[:], req.Id)
 
-	if err := storage.FlushMulticastQueueForMulticastGroup(ctx, storage.DB(), mgID); err != nil {
+	if err := storage.DeleteRoamingAgreement(ctx, storage.DB(), raID); err != nil {
 		return nil, errToRPCError(err)
 	}
 
 	return &empty.Empty{}, nil
 }
 
-// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
-func (n *NetworkServerAPI) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, req *ns.GetMulticastQueueItemsForMulticastGroupRequest) (*ns.GetMulticastQueueItemsForMulticastGroupResponse, error) {
-	var mgID uuid.UUID
-	copy(mgID[:], req.MulticastGroupId)
+// ListRoamingAgreements returns the roaming agreements, ordered by NetID.
+func (n *NetworkServerAPI) ListRoamingAgreements(ctx context.Context, req *ns.ListRoamingAgreementsRequest) (*ns.ListRoamingAgreementsResponse, error) {
+	count, err := storage.GetRoamingAgreementCount(ctx, storage.DB())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
 
-	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, storage.DB(), mgID)
+	items, err := storage.GetRoamingAgreements(ctx, storage.DB(), int(req.Limit), int(req.Offset))
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	counterSeen := make(map[uint32]struct{})
-	var out ns.GetMulticastQueueItemsForMulticastGroupResponse
-	for i := range items {
-		// we need to de-duplicate the queue-items as they are created per-gateway
-		if _, seen := counterSeen[items[i].FCnt]; seen {
-			continue
+	out := ns.ListRoamingAgreementsResponse{
+		TotalCount: uint32(count),
+	}
+	for _, ra := range items {
+		out.Result = append(out.Result, roamingAgreementToProto(ra))
+	}
+
+	return &out, nil
+}
+
+func roamingAgreementFromProto(in *ns.RoamingAgreement) (storage.RoamingAgreement, error) {
+	ra := storage.RoamingAgreement{
+		Server:                  in.Server,
+		PassiveRoaming:          in.PassiveRoaming,
+		HandoverRoaming:         in.HandoverRoaming,
+		PassiveRoamingStateless: in.PassiveRoamingStateless,
+		BillingReference:        in.BillingReference,
+	}
+	copy(ra.ID[:], in.Id)
+
+	if len(in.NetId) != len(ra.NetID) {
+		return ra, grpc.Errorf(codes.InvalidArgument, "net_id must be exactly %d bytes", len(ra.NetID))
+	}
+	copy(ra.NetID[:], in.NetId)
+
+	if in.PassiveRoamingLifetime != nil {
+		lifetime, err := ptypes.Duration(in.PassiveRoamingLifetime)
+		if err != nil {
+			return ra, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if lifetime < 0 {
+			return ra, grpc.Errorf(codes.InvalidArgument, "passive_roaming_lifetime must not be negative")
 		}
+		ra.PassiveRoamingLifetime = lifetime
+	}
 
-		qi := ns.MulticastQueueItem{
-			MulticastGroupId: items[i].MulticastGroupID.Bytes(),
-			FrmPayload:       items[i].FRMPayload,
-			FCnt:             items[i].FCnt,
-			FPort:            uint32(items[i].FPort),
+	return ra, nil
+}
+
+func roamingAgreementToProto(ra storage.RoamingAgreement) *ns.RoamingAgreement {
+	return &ns.RoamingAgreement{
+		Id:                      ra.ID.Bytes(),
+		NetId:                   ra.NetID[:],
+		Server:                  ra.Server,
+		PassiveRoaming:          ra.PassiveRoaming,
+		HandoverRoaming:         ra.HandoverRoaming,
+		PassiveRoamingLifetime:  ptypes.DurationProto(ra.PassiveRoamingLifetime),
+		PassiveRoamingStateless: ra.PassiveRoamingStateless,
+		BillingReference:        ra.BillingReference,
+	}
+}
+
+// CreateDeviceQueueItem creates the given device-queue item.
+func (n *NetworkServerAPI) CreateDeviceQueueItem(ctx context.Context, req *ns.CreateDeviceQueueItemRequest) (*empty.Empty, error) {
+	if req.Item == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.Item.DevEui)
+
+	d, err := storage.GetDevice(ctx, storage.DB(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp, err := storage.GetAndCacheDeviceProfile(ctx, storage.DB(), storage.RedisPool(), d.DeviceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), d.DevEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var devAddr lorawan.DevAddr
+	copy(devAddr[:], req.Item.DevAddr)
+
+	if (devAddr != lorawan.DevAddr{0, 0, 0, 0} && ds.DevAddr != devAddr) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "device security-context out of sync")
+	}
+
+	qi := storage.DeviceQueueItem{
+		DevAddr:    devAddr,
+		DevEUI:     d.DevEUI,
+		FRMPayload: req.Item.FrmPayload,
+		FCnt:       req.Item.FCnt,
+		FPort:      uint8(req.Item.FPort),
+		Confirmed:  req.Item.Confirmed,
+		Priority:   int(req.Item.Priority),
+	}
+
+	if req.Item.Ttl != nil {
+		ttl, err := ptypes.Duration(req.Item.Ttl)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
 		}
-		counterSeen[items[i].FCnt] = struct{}{}
-		out.MulticastQueueItems = append(out.MulticastQueueItems, &qi)
+		if ttl <= 0 {
+			return nil, grpc.Errorf(codes.InvalidArgument, "ttl must be greater than 0")
+		}
+
+		expiresAt := time.Now().Add(ttl)
+		qi.ExpiresAt = &expiresAt
 	}
 
-	return &out, nil
+	// When the device is operating in Class-B and has a beacon lock, calculate
+	// the next ping-slot.
+	if dp.SupportsClassB {
+		// check if device is currently active and is operating in Class-B mode
+		if err == nil && ds.BeaconLocked {
+			scheduleAfterGPSEpochTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx, storage.DB(), d.DevEUI)
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+
+			if scheduleAfterGPSEpochTS == 0 {
+				scheduleAfterGPSEpochTS = gps.Time(time.Now()).TimeSinceGPSEpoch()
+			}
+
+			// take some margin into account
+			scheduleAfterGPSEpochTS += classBScheduleMargin
+
+			gpsEpochTS, err := classb.GetNextPingSlotAfter(scheduleAfterGPSEpochTS, ds.DevAddr, ds.PingSlotNb)
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+
+			timeoutTime := time.Time(gps.NewFromTimeSinceGPSEpoch(gpsEpochTS)).Add(time.Second * time.Duration(dp.ClassBTimeout))
+			qi.EmitAtTimeSinceGPSEpoch = &gpsEpochTS
+			qi.TimeoutAfter = &timeoutTime
+		}
+	}
+
+	sp, err := storage.GetAndCacheServiceProfile(ctx, storage.DB(), storage.RedisPool(), d.ServiceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if sp.DownlinkDisabled {
+		return nil, errToRPCError(storage.ErrDownlinkDisabled)
+	}
+
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		if err := storage.ApplyDeviceQueueOverflowPolicy(ctx, tx, sp, d, qi); err != nil {
+			return err
+		}
+		return storage.CreateDeviceQueueItem(ctx, tx, &qi)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
 }
 
-// GetVersion returns the LoRa Server version.
-func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*ns.GetVersionResponse, error) {
-	region, ok := map[string]common.Region{
-		common.Region_AS923.String(): common.Region_AS923,
-		common.Region_AU915.String(): common.Region_AU915,
-		common.Region_CN470.String(): common.Region_CN470,
-		common.Region_CN779.String(): common.Region_CN779,
-		common.Region_EU433.String(): common.Region_EU433,
-		common.Region_EU868.String(): common.Region_EU868,
-		common.Region_IN865.String(): common.Region_IN865,
-		common.Region_KR920.String(): common.Region_KR920,
-		common.Region_RU864.String(): common.Region_RU864,
-		common.Region_US915.String(): common.Region_US915,
-	}[band.Band().Name()]
+// FlushDeviceQueueForDevEUI flushes the device-queue for the given DevEUI.
+func (n *NetworkServerAPI) FlushDeviceQueueForDevEUI(ctx context.Context, req *ns.FlushDeviceQueueForDevEUIRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
 
-	if !ok {
-		log.WithFields(log.Fields{
-			"band_name": band.Band().Name(),
-		}).Warning("unknown band to common name mapping")
+	err := storage.FlushDeviceQueueForDevEUI(ctx, storage.DB(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
 	}
 
-	return &ns.GetVersionResponse{
-		Region:  region,
-		Version: config.Version,
-	}, nil
+	return &empty.Empty{}, nil
+}
+
+// GetDeviceQueueItemsForDevEUI returns all device-queue items for the given DevEUI.
+func (n *NetworkServerAPI) GetDeviceQueueItemsForDevEUI(ctx context.Context, req *ns.GetDeviceQueueItemsForDevEUIRequest) (*ns.GetDeviceQueueItemsForDevEUIResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	items, err := storage.GetDeviceQueueItemsForDevEUI(ctx, storage.DB(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetDeviceQueueItemsForDevEUIResponse
+	for i := range items {
+		qi := ns.DeviceQueueItem{
+			DevAddr:    items[i].DevAddr[:],
+			DevEui:     items[i].DevEUI[:],
+			FrmPayload: items[i].FRMPayload,
+			FCnt:       items[i].FCnt,
+			FPort:      uint32(items[i].FPort),
+			Confirmed:  items[i].Confirmed,
+			Priority:   uint32(items[i].Priority),
+		}
+
+		if items[i].ExpiresAt != nil {
+			qi.ExpiresAt, err = ptypes.TimestampProto(*items[i].ExpiresAt)
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+		}
+
+		out.Items = append(out.Items, &qi)
+	}
+
+	return &out, nil
+}
+
+// GetNextDownlinkFCntForDevEUI returns the next FCnt that must be used.
+// This also takes device-queue items for the given DevEUI into consideration.
+// In case the device is not activated, this will return an error as no
+// device-session exists.
+func (n *NetworkServerAPI) GetNextDownlinkFCntForDevEUI(ctx context.Context, req *ns.GetNextDownlinkFCntForDevEUIRequest) (*ns.GetNextDownlinkFCntForDevEUIResponse, error) {
+	var devEUI lorawan.EUI64
+	var resp ns.GetNextDownlinkFCntForDevEUIResponse
+
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp.FCnt, err = storage.GetNextDeviceQueueItemFCnt(ctx, storage.DB(), ds)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// CreateMulticastGroup creates the given multicast-group.
+func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.CreateMulticastGroupRequest) (*ns.CreateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
+	}
+
+	if err := validateMaxAirtimePercentage(req.MulticastGroup.MaxAirtimePercentage); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	mg := storage.MulticastGroup{
+		FCnt:                 req.MulticastGroup.FCnt,
+		DR:                   int(req.MulticastGroup.Dr),
+		Frequency:            int(req.MulticastGroup.Frequency),
+		PingSlotPeriod:       int(req.MulticastGroup.PingSlotPeriod),
+		MaxFramesPerMinute:   int(req.MulticastGroup.MaxFramesPerMinute),
+		MaxAirtimePercentage: req.MulticastGroup.MaxAirtimePercentage,
+	}
+
+	switch req.MulticastGroup.GroupType {
+	case ns.MulticastGroupType_CLASS_B:
+		mg.GroupType = storage.MulticastGroupB
+	case ns.MulticastGroupType_CLASS_C:
+		mg.GroupType = storage.MulticastGroupC
+	default:
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid group_type")
+	}
+
+	copy(mg.ID[:], req.MulticastGroup.Id)
+	copy(mg.MCAddr[:], req.MulticastGroup.McAddr)
+	copy(mg.MCNwkSKey[:], req.MulticastGroup.McNwkSKey)
+	copy(mg.ServiceProfileID[:], req.MulticastGroup.ServiceProfileId)
+	copy(mg.RoutingProfileID[:], req.MulticastGroup.RoutingProfileId)
+
+	if err := storage.CreateMulticastGroup(ctx, storage.DB(), &mg); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.CreateMulticastGroupResponse{
+		Id: mg.ID.Bytes(),
+	}, nil
+}
+
+// GetMulticastGroup returns the multicast-group given an id.
+func (n *NetworkServerAPI) GetMulticastGroup(ctx context.Context, req *ns.GetMulticastGroupRequest) (*ns.GetMulticastGroupResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.Id)
+
+	mg, err := storage.GetMulticastGroup(ctx, storage.DB(), mgID, false)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetMulticastGroupResponse{
+		MulticastGroup: &ns.MulticastGroup{
+			Id:                   mg.ID.Bytes(),
+			McAddr:               mg.MCAddr[:],
+			McNwkSKey:            mg.MCNwkSKey[:],
+			FCnt:                 mg.FCnt,
+			Dr:                   uint32(mg.DR),
+			Frequency:            uint32(mg.Frequency),
+			PingSlotPeriod:       uint32(mg.PingSlotPeriod),
+			ServiceProfileId:     mg.ServiceProfileID.Bytes(),
+			RoutingProfileId:     mg.RoutingProfileID.Bytes(),
+			MaxFramesPerMinute:   uint32(mg.MaxFramesPerMinute),
+			MaxAirtimePercentage: mg.MaxAirtimePercentage,
+		},
+	}
+
+	switch mg.GroupType {
+	case storage.MulticastGroupB:
+		resp.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_B
+	case storage.MulticastGroupC:
+		resp.MulticastGroup.GroupType = ns.MulticastGroupType_CLASS_C
+	default:
+		return nil, grpc.Errorf(codes.Internal, "invalid group-type: %s", mg.GroupType)
+	}
+
+	resp.CreatedAt, err = ptypes.TimestampProto(mg.CreatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp.UpdatedAt, err = ptypes.TimestampProto(mg.UpdatedAt)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &resp, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group.
+func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.UpdateMulticastGroupRequest) (*empty.Empty, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_group must not be nil")
+	}
+
+	if err := validateMaxAirtimePercentage(req.MulticastGroup.MaxAirtimePercentage); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroup.Id)
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		mg, err := storage.GetMulticastGroup(ctx, tx, mgID, true)
+		if err != nil {
+			return errToRPCError(err)
+		}
+
+		copy(mg.MCAddr[:], req.MulticastGroup.McAddr)
+		copy(mg.MCNwkSKey[:], req.MulticastGroup.McNwkSKey)
+		copy(mg.ServiceProfileID[:], req.MulticastGroup.ServiceProfileId)
+		copy(mg.RoutingProfileID[:], req.MulticastGroup.RoutingProfileId)
+		mg.FCnt = req.MulticastGroup.FCnt
+		mg.DR = int(req.MulticastGroup.Dr)
+		mg.Frequency = int(req.MulticastGroup.Frequency)
+		mg.PingSlotPeriod = int(req.MulticastGroup.PingSlotPeriod)
+		mg.MaxFramesPerMinute = int(req.MulticastGroup.MaxFramesPerMinute)
+		mg.MaxAirtimePercentage = req.MulticastGroup.MaxAirtimePercentage
+
+		switch req.MulticastGroup.GroupType {
+		case ns.MulticastGroupType_CLASS_B:
+			mg.GroupType = storage.MulticastGroupB
+		case ns.MulticastGroupType_CLASS_C:
+			mg.GroupType = storage.MulticastGroupC
+		default:
+			return grpc.Errorf(codes.InvalidArgument, "invalid group_type")
+		}
+
+		if err := storage.UpdateMulticastGroup(ctx, tx, &mg); err != nil {
+			return errToRPCError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// DeleteMulticastGroup deletes a multicast-group given an id.
+func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.DeleteMulticastGroupRequest) (*empty.Empty, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.Id)
+
+	if err := storage.DeleteMulticastGroup(ctx, storage.DB(), mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the given multicast-group.
+func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *ns.AddDeviceToMulticastGroupRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	var mgID uuid.UUID
+	copy(devEUI[:], req.DevEui)
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.AddDeviceToMulticastGroup(ctx, storage.DB(), devEUI, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the given multicast-group.
+func (n *NetworkServerAPI) RemoveDeviceFromMulticastGroup(ctx context.Context, req *ns.RemoveDeviceFromMulticastGroupRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	var mgID uuid.UUID
+	copy(devEUI[:], req.DevEui)
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.RemoveDeviceFromMulticastGroup(ctx, storage.DB(), devEUI, mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// EnqueueMulticastQueueItem creates the given multicast queue-item.
+func (n *NetworkServerAPI) EnqueueMulticastQueueItem(ctx context.Context, req *ns.EnqueueMulticastQueueItemRequest) (*empty.Empty, error) {
+	if req.MulticastQueueItem == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicast_queue_item must not be nil")
+	}
+
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastQueueItem.MulticastGroupId)
+
+	qi := storage.MulticastQueueItem{
+		MulticastGroupID: mgID,
+		FCnt:             req.MulticastQueueItem.FCnt,
+		FPort:            uint8(req.MulticastQueueItem.FPort),
+		FRMPayload:       req.MulticastQueueItem.FrmPayload,
+		Geofence:         geofenceFromPB(req.MulticastQueueItem.Geofence),
+	}
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		return multicast.EnqueueQueueItem(ctx, storage.RedisPool(), tx, qi)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// FlushMulticastQueueForMulticastGroup flushes the multicast device-queue given a multicast-group id.
+func (n *NetworkServerAPI) FlushMulticastQueueForMulticastGroup(ctx context.Context, req *ns.FlushMulticastQueueForMulticastGroupRequest) (*empty.Empty, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	if err := storage.FlushMulticastQueueForMulticastGroup(ctx, storage.DB(), mgID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastQueueItemsForMulticastGroup returns the queue-items given a multicast-group id.
+func (n *NetworkServerAPI) GetMulticastQueueItemsForMulticastGroup(ctx context.Context, req *ns.GetMulticastQueueItemsForMulticastGroupRequest) (*ns.GetMulticastQueueItemsForMulticastGroupResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	counterSeen := make(map[uint32]struct{})
+	var out ns.GetMulticastQueueItemsForMulticastGroupResponse
+	for i := range items {
+		// we need to de-duplicate the queue-items as they are created per-gateway
+		if _, seen := counterSeen[items[i].FCnt]; seen {
+			continue
+		}
+
+		qi := ns.MulticastQueueItem{
+			MulticastGroupId: items[i].MulticastGroupID.Bytes(),
+			FrmPayload:       items[i].FRMPayload,
+			FCnt:             items[i].FCnt,
+			FPort:            uint32(items[i].FPort),
+			Geofence:         geofenceToPB(items[i].Geofence),
+		}
+		counterSeen[items[i].FCnt] = struct{}{}
+		out.MulticastQueueItems = append(out.MulticastQueueItems, &qi)
+	}
+
+	return &out, nil
+}
+
+// GetMulticastGroupCoverage returns for each device of the multicast-group
+// the gateways of the multicast gateway-set which can reach the device.
+func (n *NetworkServerAPI) GetMulticastGroupCoverage(ctx context.Context, req *ns.GetMulticastGroupCoverageRequest) (*ns.GetMulticastGroupCoverageResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	coverage, err := multicast.GetCoverage(ctx, storage.RedisPool(), storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.GetMulticastGroupCoverageResponse{
+		UncoveredCount: uint32(coverage.UncoveredCount()),
+	}
+
+	for i := range coverage.GatewayIDs {
+		out.GatewayIds = append(out.GatewayIds, coverage.GatewayIDs[i][:])
+	}
+
+	for _, dc := range coverage.Devices {
+		devEUI := dc.DevEUI
+
+		d := ns.MulticastDeviceCoverage{
+			DevEui:  devEUI[:],
+			Covered: dc.Covered(),
+		}
+
+		for _, gc := range dc.Gateways {
+			gatewayID := gc.GatewayID
+
+			d.Gateways = append(d.Gateways, &ns.MulticastGatewayCoverage{
+				GatewayId:    gatewayID[:],
+				Rssi:         int32(gc.RSSI),
+				LoraSnr:      gc.LoRaSNR,
+				SnrMarginMet: gc.SNRMarginMet,
+			})
+		}
+
+		out.Devices = append(out.Devices, &d)
+	}
+
+	return &out, nil
+}
+
+// EstimateMulticastSession estimates the airtime per gateway, the duration
+// and the duty-cycle feasibility of a multicast session.
+func (n *NetworkServerAPI) EstimateMulticastSession(ctx context.Context, req *ns.EstimateMulticastSessionRequest) (*ns.EstimateMulticastSessionResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	est, err := multicast.EstimateSession(ctx, storage.RedisPool(), storage.DB(), mgID, int(req.PayloadSize), int(req.FragmentCount), int(req.Dr), req.MaxDutyCycle)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.EstimateMulticastSessionResponse{
+		FrameAirtime:      ptypes.DurationProto(est.FrameAirtime),
+		Duration:          ptypes.DurationProto(est.Duration),
+		MinDuration:       ptypes.DurationProto(est.MinDuration),
+		DutyCycleFeasible: est.DutyCycleFeasible,
+	}
+
+	for _, ga := range est.Gateways {
+		gatewayID := ga.GatewayID
+
+		out.Gateways = append(out.Gateways, &ns.MulticastGatewayAirtime{
+			GatewayId: gatewayID[:],
+			Airtime:   ptypes.DurationProto(ga.Airtime),
+			DutyCycle: ga.DutyCycle,
+		})
+	}
+
+	return &out, nil
+}
+
+// GetMulticastTXStatus returns per frame of the multicast-group the
+// transmission status for each gateway.
+func (n *NetworkServerAPI) GetMulticastTXStatus(ctx context.Context, req *ns.GetMulticastTXStatusRequest) (*ns.GetMulticastTXStatusResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	frames, err := multicast.GetTXStatus(ctx, storage.RedisPool(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetMulticastTXStatusResponse
+	for _, f := range frames {
+		frame := ns.MulticastFrameTXStatus{
+			FCnt: f.FCnt,
+		}
+
+		for _, s := range f.Gateways {
+			gatewayID := s.GatewayID
+
+			gs := ns.MulticastGatewayTXStatus{
+				GatewayId: gatewayID[:],
+				Error:     s.Error,
+				Retries:   uint32(s.Retries),
+			}
+
+			switch s.State {
+			case multicast.TXPending:
+				gs.State = ns.MulticastTXState_PENDING
+			case multicast.TXAcked:
+				gs.State = ns.MulticastTXState_ACKED
+			case multicast.TXFailed:
+				gs.State = ns.MulticastTXState_FAILED
+			default:
+				return nil, grpc.Errorf(codes.Internal, "invalid tx state: %s", s.State)
+			}
+
+			frame.Gateways = append(frame.Gateways, &gs)
+		}
+
+		out.Frames = append(out.Frames, &frame)
+	}
+
+	return &out, nil
+}
+
+// StartMulticastSetup starts the remote multicast setup of the devices of
+// the multicast-group.
+func (n *NetworkServerAPI) StartMulticastSetup(ctx context.Context, req *ns.StartMulticastSetupRequest) (*empty.Empty, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	sessionTime, err := ptypes.Timestamp(req.SessionTime)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		mg, err := storage.GetMulticastGroup(ctx, tx, mgID, true)
+		if err != nil {
+			return err
+		}
+
+		s := storage.MulticastGroupSetup{
+			MulticastGroupID: mg.ID,
+			McGroupID:        int(req.McGroupId),
+			McKeyEncrypted:   req.McKeyEncrypted,
+			MinMcFCnt:        mg.FCnt,
+			MaxMcFCnt:        req.MaxMcFCnt,
+			SessionTime:      sessionTime,
+			SessionTimeOut:   int(req.SessionTimeOut),
+		}
+		if s.MaxMcFCnt == 0 {
+			s.MaxMcFCnt = math.MaxUint32
+		}
+
+		return multicast.StartSetup(ctx, storage.RedisPool(), tx, s)
+	})
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetMulticastSetupStatus returns the remote multicast setup status of each
+// device of the multicast-group.
+func (n *NetworkServerAPI) GetMulticastSetupStatus(ctx context.Context, req *ns.GetMulticastSetupStatusRequest) (*ns.GetMulticastSetupStatusResponse, error) {
+	var mgID uuid.UUID
+	copy(mgID[:], req.MulticastGroupId)
+
+	s, err := storage.GetMulticastGroupSetup(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	devices, err := storage.GetMulticastGroupSetupDevices(ctx, storage.DB(), mgID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.GetMulticastSetupStatusResponse{
+		McGroupId: uint32(s.McGroupID),
+	}
+
+	out.SessionTime, err = ptypes.TimestampProto(s.SessionTime)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	for _, d := range devices {
+		devEUI := d.DevEUI
+
+		ds := ns.MulticastDeviceSetupStatus{
+			DevEui: devEUI[:],
+			Error:  d.Error,
+		}
+
+		ds.UpdatedAt, err = ptypes.TimestampProto(d.UpdatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		switch d.State {
+		case storage.MulticastSetupGroupPending:
+			ds.State = ns.MulticastSetupState_SETUP_GROUP_PENDING
+		case storage.MulticastSetupSessionPending:
+			ds.State = ns.MulticastSetupState_SETUP_SESSION_PENDING
+		case storage.MulticastSetupCompleted:
+			ds.State = ns.MulticastSetupState_SETUP_COMPLETED
+		case storage.MulticastSetupFailed:
+			ds.State = ns.MulticastSetupState_SETUP_FAILED
+		default:
+			return nil, grpc.Errorf(codes.Internal, "invalid setup state: %s", d.State)
+		}
+
+		out.Devices = append(out.Devices, &ds)
+	}
+
+	return &out, nil
+}
+
+// EnqueueCertificationCommand enqueues the given certification command(s)
+// for a device in certification test mode.
+func (n *NetworkServerAPI) EnqueueCertificationCommand(ctx context.Context, req *ns.EnqueueCertificationCommandRequest) (*empty.Empty, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEui)
+
+	ds, err := storage.GetDeviceSession(ctx, storage.RedisPool(), devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if !ds.CertificationTestMode {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "certification test mode is not enabled for device")
+	}
+
+	if err := certification.EnqueueCommands(ctx, storage.DB(), &ds, req.Payload); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.SaveDeviceSession(ctx, storage.RedisPool(), ds); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetVersion returns the LoRa Server version.
+func (n *NetworkServerAPI) GetVersion(ctx context.Context, req *empty.Empty) (*ns.GetVersionResponse, error) {
+	region, ok := map[string]common.Region{
+		common.Region_AS923.String(): common.Region_AS923,
+		common.Region_AU915.String(): common.Region_AU915,
+		common.Region_CN470.String(): common.Region_CN470,
+		common.Region_CN779.String(): common.Region_CN779,
+		common.Region_EU433.String(): common.Region_EU433,
+		common.Region_EU868.String(): common.Region_EU868,
+		common.Region_IN865.String(): common.Region_IN865,
+		common.Region_KR920.String(): common.Region_KR920,
+		common.Region_RU864.String(): common.Region_RU864,
+		common.Region_US915.String(): common.Region_US915,
+	}[band.Band().Name()]
+
+	if !ok {
+		log.WithFields(log.Fields{
+			"band_name": band.Band().Name(),
+		}).Warning("unknown band to common name mapping")
+	}
+
+	return &ns.GetVersionResponse{
+		Region:  region,
+		Version: config.Version,
+	}, nil
+}
+
+// ReloadConfiguration reloads the settings of the configuration file which
+// can be changed without restarting LoRa Server.
+func (n *NetworkServerAPI) ReloadConfiguration(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	if err := reload.Reload(ctx); err != nil {
+		if err == reload.ErrNotConfigured {
+			return nil, grpc.Errorf(codes.Unimplemented, err.Error())
+		}
+		return nil, grpc.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// GetSecurityEvents returns the most recent security events.
+func (n *NetworkServerAPI) GetSecurityEvents(ctx context.Context, req *ns.GetSecurityEventsRequest) (*ns.GetSecurityEventsResponse, error) {
+	filter := security.Filter{
+		MinSeverity: security.Severity(req.MinSeverity),
+		Limit:       int(req.Limit),
+	}
+	copy(filter.DevEUI[:], req.DevEui)
+
+	events, err := security.GetEvents(ctx, storage.RedisPool(), filter)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetSecurityEventsResponse
+	for i := range events {
+		e := events[i]
+		ts, err := ptypes.TimestampProto(e.Time)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Events = append(resp.Events, &ns.SecurityEvent{
+			Time:     ts,
+			Type:     string(e.Type),
+			Severity: ns.SecuritySeverity(e.Severity),
+			DevEui:   e.DevEUI[:],
+			DevAddr:  e.DevAddr[:],
+			Source:   e.Source,
+			Message:  e.Message,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetRedisMemoryUsage returns the last sample of the Redis memory usage per
+// key class.
+func (n *NetworkServerAPI) GetRedisMemoryUsage(ctx context.Context, req *empty.Empty) (*ns.GetRedisMemoryUsageResponse, error) {
+	usage, err := storage.GetRedisMemoryUsage(ctx, storage.RedisPool())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetRedisMemoryUsageResponse{
+		UsedMemory: usage.UsedMemory,
+	}
+
+	if !usage.SampledAt.IsZero() {
+		resp.SampledAt, err = ptypes.TimestampProto(usage.SampledAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	for _, class := range usage.Classes {
+		resp.KeyClasses = append(resp.KeyClasses, &ns.RedisKeyClassMemoryUsage{
+			KeyClass: string(class.Class),
+			Keys:     class.Keys,
+			Bytes:    class.Bytes,
+			CapBytes: class.CapBytes,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetGatewayConfigurationStatus returns the configuration rollout status of
+// the given gateway.
+func (n *NetworkServerAPI) GetGatewayConfigurationStatus(ctx context.Context, req *ns.GetGatewayConfigurationStatusRequest) (*ns.GetGatewayConfigurationStatusResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	// make sure the gateway exists, a gateway without status has an
+	// unknown state
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	s, err := storage.GetGatewayConfigurationStatus(ctx, storage.DB(), gatewayID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return nil, errToRPCError(err)
+	}
+	s.GatewayID = gatewayID
+
+	status, err := gatewayConfigurationStatusToPB(s)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetGatewayConfigurationStatusResponse{
+		Status: status,
+	}, nil
+}
+
+// GetGatewayConfigurationStatusForGatewayProfile returns the configuration
+// rollout status of the gateways using the given gateway-profile.
+func (n *NetworkServerAPI) GetGatewayConfigurationStatusForGatewayProfile(ctx context.Context, req *ns.GetGatewayConfigurationStatusForGatewayProfileRequest) (*ns.GetGatewayConfigurationStatusForGatewayProfileResponse, error) {
+	var gpID uuid.UUID
+	copy(gpID[:], req.GatewayProfileId)
+
+	if _, err := storage.GetGatewayProfile(ctx, storage.DB(), gpID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	statuses, err := storage.GetGatewayConfigurationStatusForGatewayProfile(ctx, storage.DB(), gpID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetGatewayConfigurationStatusForGatewayProfileResponse
+	for _, s := range statuses {
+		status, err := gatewayConfigurationStatusToPB(s)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		resp.Result = append(resp.Result, status)
+	}
+
+	return &resp, nil
+}
+
+// DecodePHYPayload decodes the given PHYPayload (for debugging purposes).
+func (n *NetworkServerAPI) DecodePHYPayload(ctx context.Context, req *ns.DecodePHYPayloadRequest) (*ns.DecodePHYPayloadResponse, error) {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(req.PhyPayload); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "decode phy_payload error: %s", err)
+	}
+
+	var devEUI *lorawan.EUI64
+	if len(req.DevEui) != 0 {
+		var eui lorawan.EUI64
+		if err := eui.UnmarshalBinary(req.DevEui); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "invalid dev_eui: %x", req.DevEui)
+		}
+		devEUI = &eui
+	}
+
+	resp := ns.DecodePHYPayloadResponse{
+		MType: ns.MType(phy.MHDR.MType),
+	}
+
+	if _, ok := phy.MACPayload.(*lorawan.MACPayload); ok {
+		if err := decodeDataPHYPayload(ctx, req, devEUI, &phy, &resp); err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	b, err := json.Marshal(phy)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+	resp.PhyPayloadJson = string(b)
+
+	log.WithFields(log.Fields{
+		"m_type":                phy.MHDR.MType,
+		"dev_eui":               fmt.Sprintf("%x", resp.DevEui),
+		"frm_payload_decrypted": resp.FrmPayloadDecrypted,
+	}).Info("phypayload decoded")
+
+	return &resp, nil
+}
+
+// GetSchedulerBacklog returns the downlink scheduler backlog, either global
+// or for a single gateway.
+func (n *NetworkServerAPI) GetSchedulerBacklog(ctx context.Context, req *ns.GetSchedulerBacklogRequest) (*ns.GetSchedulerBacklogResponse, error) {
+	var gatewayID *lorawan.EUI64
+	var filter func(lorawan.EUI64) (bool, error)
+
+	if len(req.GatewayId) != 0 {
+		gatewayID = new(lorawan.EUI64)
+		if err := gatewayID.UnmarshalBinary(req.GatewayId); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		if _, err := storage.GetGateway(ctx, storage.DB(), *gatewayID); err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		// Class-B and Class-C downlinks are sent using the gateways which
+		// received the last uplink of the device
+		filter = func(devEUI lorawan.EUI64) (bool, error) {
+			rxInfoSet, err := storage.GetDeviceGatewayRXInfoSet(ctx, storage.RedisPool(), devEUI)
+			if err != nil {
+				if err == storage.ErrDoesNotExist {
+					return false, nil
+				}
+				return false, err
+			}
+
+			for _, rxInfo := range rxInfoSet.Items {
+				if rxInfo.GatewayID == *gatewayID {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		}
+	}
+
+	dqBacklog, err := storage.GetDeviceQueueBacklog(ctx, storage.DB(), filter)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	mqBacklog, err := storage.GetMulticastQueueBacklog(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	txAckPending, err := storage.GetDownlinkTXAckPendingCount(ctx, storage.RedisPool(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetSchedulerBacklogResponse{
+		ClassCItems:             uint32(dqBacklog.ClassCItems),
+		ClassCDevices:           uint32(dqBacklog.ClassCDevices),
+		MulticastDueItems:       uint32(mqBacklog.DueItems),
+		MulticastScheduledItems: uint32(mqBacklog.ScheduledItems),
+		TxAckPending:            uint32(txAckPending),
+	}
+
+	for _, period := range dqBacklog.ClassBPeriods {
+		resp.ClassBBeaconPeriods = append(resp.ClassBBeaconPeriods, &ns.BeaconPeriodBacklog{
+			TimeSinceGpsEpoch: ptypes.DurationProto(period.TimeSinceGPSEpoch),
+			Items:             uint32(period.Items),
+		})
+	}
+
+	return &resp, nil
+}
+
+// StartGatewayDrain puts the gateway in maintenance drain mode.
+func (n *NetworkServerAPI) StartGatewayDrain(ctx context.Context, req *ns.StartGatewayDrainRequest) (*empty.Empty, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.StartGatewayDrain(ctx, storage.RedisPool(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+	}).Info("gateway drain mode started")
+
+	return &empty.Empty{}, nil
+}
+
+// StopGatewayDrain takes the gateway out of maintenance drain mode.
+func (n *NetworkServerAPI) StopGatewayDrain(ctx context.Context, req *ns.StopGatewayDrainRequest) (*empty.Empty, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := storage.StopGatewayDrain(ctx, storage.RedisPool(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+	}).Info("gateway drain mode stopped")
+
+	return &empty.Empty{}, nil
+}
+
+// GetGatewayDrainStatus returns the drain status of the gateway. It is safe
+// to power off the gateway when it is draining and there are no downlinks
+// awaiting a TX acknowledgement and no multicast-queue items for the gateway.
+func (n *NetworkServerAPI) GetGatewayDrainStatus(ctx context.Context, req *ns.GetGatewayDrainStatusRequest) (*ns.GetGatewayDrainStatusResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	startedAt, err := storage.GetGatewayDrainStartedAt(ctx, storage.RedisPool(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	txAckPending, err := storage.GetDownlinkTXAckPendingCount(ctx, storage.RedisPool(), &gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	mqBacklog, err := storage.GetMulticastQueueBacklog(ctx, storage.DB(), &gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetGatewayDrainStatusResponse{
+		Draining:            startedAt != nil,
+		TxAckPending:        uint32(txAckPending),
+		MulticastQueueItems: uint32(mqBacklog.DueItems + mqBacklog.ScheduledItems),
+	}
+	resp.SafeToPowerOff = resp.Draining && resp.TxAckPending == 0 && resp.MulticastQueueItems == 0
+
+	if startedAt != nil {
+		resp.StartedAt, err = ptypes.TimestampProto(*startedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+	}
+
+	return &resp, nil
+}
+
+// GetGatewayInventory returns the inventory (model and versions) of the
+// gateway, as reported by the gateway stats.
+func (n *NetworkServerAPI) GetGatewayInventory(ctx context.Context, req *ns.GetGatewayInventoryRequest) (*ns.GetGatewayInventoryResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	inv, err := storage.GetGatewayInventory(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	invPB, err := gatewayInventoryToProto(inv)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetGatewayInventoryResponse{
+		Inventory: invPB,
+	}, nil
+}
+
+// ListGatewayInventory returns the gateway inventories, ordered by gateway
+// ID.
+func (n *NetworkServerAPI) ListGatewayInventory(ctx context.Context, req *ns.ListGatewayInventoryRequest) (*ns.ListGatewayInventoryResponse, error) {
+	count, err := storage.GetGatewayInventoryCount(ctx, storage.DB(), req.Model)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	items, err := storage.GetGatewayInventories(ctx, storage.DB(), int(req.Limit), int(req.Offset), req.Model)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.ListGatewayInventoryResponse{
+		TotalCount: uint32(count),
+	}
+	for _, inv := range items {
+		invPB, err := gatewayInventoryToProto(inv)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		out.Result = append(out.Result, invPB)
+	}
+
+	return &out, nil
+}
+
+// GetGatewayConnectionState returns the connection state (online / offline)
+// of the gateway, as reported by the gateway backend.
+func (n *NetworkServerAPI) GetGatewayConnectionState(ctx context.Context, req *ns.GetGatewayConnectionStateRequest) (*ns.GetGatewayConnectionStateResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	s, err := storage.GetGatewayConnectionState(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	statePB, err := gatewayConnectionStateToProto(s)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetGatewayConnectionStateResponse{
+		State: statePB,
+	}, nil
+}
+
+// ListGatewayConnectionStates returns the gateway connection states, ordered
+// by gateway ID.
+func (n *NetworkServerAPI) ListGatewayConnectionStates(ctx context.Context, req *ns.ListGatewayConnectionStatesRequest) (*ns.ListGatewayConnectionStatesResponse, error) {
+	count, err := storage.GetGatewayConnectionStateCount(ctx, storage.DB(), req.OfflineOnly)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	items, err := storage.GetGatewayConnectionStates(ctx, storage.DB(), int(req.Limit), int(req.Offset), req.OfflineOnly)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.ListGatewayConnectionStatesResponse{
+		TotalCount: uint32(count),
+	}
+	for _, s := range items {
+		statePB, err := gatewayConnectionStateToProto(s)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		out.Result = append(out.Result, statePB)
+	}
+
+	return &out, nil
+}
+
+// maxGatewayCommandExecTimeout defines the max duration for which the API
+// waits for a gateway command execution response. Waiting blocks a Redis
+// connection, therefore this is limited.
+const maxGatewayCommandExecTimeout = time.Minute
+
+// ExecGatewayCommand relays the command execution request (e.g. reboot)
+// through the gateway backend to the gateway. When a timeout is given, it
+// waits for the command execution response.
+func (n *NetworkServerAPI) ExecGatewayCommand(ctx context.Context, req *ns.ExecGatewayCommandRequest) (*ns.ExecGatewayCommandResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if req.Command == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "command must not be empty")
+	}
+
+	timeout, err := getGatewayCommandExecTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	ceb, ok := gwbackend.Backend().(gwbackend.CommandExecBackend)
+	if !ok {
+		return nil, errToRPCError(gwbackend.ErrNotSupported)
+	}
+
+	execID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if err := ceb.SendGatewayCommandExecRequest(gw.GatewayCommandExecRequest{
+		GatewayId:   gatewayID[:],
+		Command:     req.Command,
+		ExecId:      execID.Bytes(),
+		Stdin:       req.Stdin,
+		Environment: req.Environment,
+	}); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"command":    req.Command,
+		"exec_id":    execID,
+	}).Info("gateway command execution requested")
+
+	out := ns.ExecGatewayCommandResponse{
+		ExecId: execID.Bytes(),
+	}
+
+	if timeout == 0 {
+		return &out, nil
+	}
+
+	resp, err := storage.GetGatewayCommandExecResponse(ctx, storage.RedisPool(), execID, timeout)
+	if err != nil {
+		// the response can still be retrieved using the exec ID
+		if err == storage.ErrDoesNotExist {
+			return &out, nil
+		}
+		return nil, errToRPCError(err)
+	}
+	out.Response = &resp
+
+	return &out, nil
+}
+
+// GetGatewayCommandExecResponse returns the command execution response for
+// the given execution ID.
+func (n *NetworkServerAPI) GetGatewayCommandExecResponse(ctx context.Context, req *ns.GetGatewayCommandExecResponseRequest) (*ns.GetGatewayCommandExecResponseResponse, error) {
+	execID, err := uuid.FromBytes(req.ExecId)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	timeout, err := getGatewayCommandExecTimeout(req.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := storage.GetGatewayCommandExecResponse(ctx, storage.RedisPool(), execID, timeout)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetGatewayCommandExecResponseResponse{
+		Response: &resp,
+	}, nil
+}
+
+func getGatewayCommandExecTimeout(d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+
+	timeout, err := ptypes.Duration(d)
+	if err != nil {
+		return 0, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if timeout < 0 || timeout > maxGatewayCommandExecTimeout {
+		return 0, grpc.Errorf(codes.InvalidArgument, "timeout must be between 0s and %s", maxGatewayCommandExecTimeout)
+	}
+
+	return timeout, nil
+}
+
+// GetGatewayDownlinkTXState returns the downlinks sent to the gateway which
+// are pending a TX acknowledgement (in flight).
+func (n *NetworkServerAPI) GetGatewayDownlinkTXState(ctx context.Context, req *ns.GetGatewayDownlinkTXStateRequest) (*ns.GetGatewayDownlinkTXStateResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if _, err := storage.GetGateway(ctx, storage.DB(), gatewayID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	pending, err := storage.GetDownlinkTXPending(ctx, storage.RedisPool(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	out := ns.GetGatewayDownlinkTXStateResponse{
+		QueueDepth: uint32(len(pending)),
+	}
+	for _, p := range pending {
+		pb := ns.DownlinkTXPending{
+			Token:      uint32(p.Token),
+			DownlinkId: p.DownlinkFrame.DownlinkId,
+			TxInfo:     p.DownlinkFrame.TxInfo,
+		}
+		pb.CreatedAt, err = ptypes.TimestampProto(p.CreatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		out.Pending = append(out.Pending, &pb)
+	}
+
+	return &out, nil
+}
+
+// ListGatewayDownlinkQueueDepths returns the number of downlinks pending a TX
+// acknowledgement per gateway, ordered by queue depth (descending).
+func (n *NetworkServerAPI) ListGatewayDownlinkQueueDepths(ctx context.Context, req *ns.ListGatewayDownlinkQueueDepthsRequest) (*ns.ListGatewayDownlinkQueueDepthsResponse, error) {
+	depths, err := storage.GetGatewayDownlinkQueueDepths(ctx, storage.RedisPool())
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if req.Limit != 0 && len(depths) > int(req.Limit) {
+		depths = depths[:int(req.Limit)]
+	}
+
+	var out ns.ListGatewayDownlinkQueueDepthsResponse
+	for _, d := range depths {
+		out.Result = append(out.Result, &ns.GatewayDownlinkQueueDepth{
+			GatewayId:  d.GatewayID[:],
+			QueueDepth: uint32(d.QueueDepth),
+		})
+	}
+
+	return &out, nil
+}
+
+// GetGatewayStatsAggregates returns the aggregated gateway stats for the
+// given aggregation interval and time range.
+func (n *NetworkServerAPI) GetGatewayStatsAggregates(ctx context.Context, req *ns.GetGatewayStatsAggregatesRequest) (*ns.GetGatewayStatsAggregatesResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	if req.Interval == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "interval must not be nil")
+	}
+
+	interval, err := ptypes.Duration(req.Interval)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	start, err := ptypes.Timestamp(req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	end, err := ptypes.Timestamp(req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	aggs, err := storage.GetGatewayStatsAggregates(ctx, storage.DB(), gatewayID, interval, start, end)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetGatewayStatsAggregatesResponse
+	for _, agg := range aggs {
+		row, err := gatewayStatsAggregateToProto(agg)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		out.Result = append(out.Result, row)
+	}
+
+	return &out, nil
+}
+
+func gatewayStatsAggregateToProto(agg storage.GatewayStatsAggregate) (*ns.GatewayStatsAggregate, error) {
+	out := ns.GatewayStatsAggregate{
+		RxPacketsReceived:     agg.RXPacketsReceived,
+		RxPacketsReceivedOk:   agg.RXPacketsReceivedOK,
+		TxPacketsReceived:     agg.TXPacketsReceived,
+		TxPacketsEmitted:      agg.TXPacketsEmitted,
+		RxPacketsPerFrequency: make(map[uint32]uint32),
+		TxPacketsPerFrequency: make(map[uint32]uint32),
+		TxPacketsPerStatus:    make(map[string]uint32),
+	}
+
+	var err error
+	out.Timestamp, err = ptypes.TimestampProto(agg.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range []struct {
+		from storage.GatewayStatsCounts
+		to   map[uint32]uint32
+	}{
+		{agg.RXPacketsPerFrequency, out.RxPacketsPerFrequency},
+		{agg.TXPacketsPerFrequency, out.TxPacketsPerFrequency},
+	} {
+		for k, v := range c.from {
+			freq, err := strconv.ParseUint(k, 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse frequency error")
+			}
+			c.to[uint32(freq)] = v
+		}
+	}
+
+	for k, v := range agg.TXPacketsPerStatus {
+		out.TxPacketsPerStatus[k] = v
+	}
+
+	return &out, nil
+}
+
+// GetGatewayNoiseFloor returns the last reported noise-floor and channel
+// utilization of each channel of the given gateway.
+func (n *NetworkServerAPI) GetGatewayNoiseFloor(ctx context.Context, req *ns.GetGatewayNoiseFloorRequest) (*ns.GetGatewayNoiseFloorResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	nfs, err := storage.GetGatewayNoiseFloors(ctx, storage.DB(), gatewayID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var out ns.GetGatewayNoiseFloorResponse
+	for _, nf := range nfs {
+		updatedAt, err := ptypes.TimestampProto(nf.UpdatedAt)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		row := ns.GatewayChannelNoiseFloor{
+			Frequency:          nf.Frequency,
+			Bandwidth:          nf.Bandwidth,
+			NoiseFloor:         nf.NoiseFloor,
+			ChannelUtilization: nf.ChannelUtilization,
+			RssiHistogram:      make(map[int32]uint32),
+			UpdatedAt:          updatedAt,
+		}
+		for k, v := range nf.RSSIHistogram {
+			row.RssiHistogram[k] = v
+		}
+
+		out.Result = append(out.Result, &row)
+	}
+
+	return &out, nil
+}
+
+func gatewayConnectionStateToProto(s storage.GatewayConnectionState) (*ns.GatewayConnectionState, error) {
+	out := ns.GatewayConnectionState{
+		GatewayId: s.GatewayID[:],
+		Online:    s.Online,
+	}
+
+	var err error
+	out.UpdatedAt, err = ptypes.TimestampProto(s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func gatewayInventoryToProto(inv storage.GatewayInventory) (*ns.GatewayInventory, error) {
+	c := constraint.GetConstraints(inv.Model)
+
+	out := ns.GatewayInventory{
+		GatewayId:             inv.GatewayID[:],
+		Model:                 inv.Model,
+		FirmwareVersion:       inv.FirmwareVersion,
+		BridgeVersion:         inv.BridgeVersion,
+		ConcentratorVersion:   inv.ConcentratorVersion,
+		NoGpsTiming:           c.NoGPSTiming,
+		MaxDownlinkQueueDepth: uint32(c.MaxDownlinkQueueDepth),
+	}
+
+	var err error
+	out.UpdatedAt, err = ptypes.TimestampProto(inv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// GetGatewayContributions returns the contribution metrics of a gateway per
+// aggregation interval.
+func (n *NetworkServerAPI) GetGatewayContributions(ctx context.Context, req *ns.GetGatewayContributionsRequest) (*ns.GetGatewayContributionsResponse, error) {
+	gatewayID := helpers.GetGatewayID(req)
+
+	start, err := ptypes.Timestamp(req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	end, err := ptypes.Timestamp(req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	metrics, err := storage.GetMetrics(ctx, storage.RedisPool(), storage.AggregationInterval(req.Interval.String()), contribution.MetricsName(gatewayID), start, end)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetGatewayContributionsResponse
+
+	for _, m := range metrics {
+		row := ns.GatewayContributions{
+			FirstReceiverCount: uint32(m.Metrics[contribution.MetricFirstReceiver]),
+			OnlyReceiverCount:  uint32(m.Metrics[contribution.MetricOnlyReceiver]),
+			UplinkCount:        uint32(m.Metrics[contribution.MetricUplink]),
+			DownlinkCount:      uint32(m.Metrics[contribution.MetricDownlink]),
+		}
+
+		row.Timestamp, err = ptypes.TimestampProto(m.Time)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+
+		resp.Result = append(resp.Result, &row)
+	}
+
+	return &resp, nil
+}
+
+// StreamGatewayContributions returns a stream of gateway contribution events.
+func (n *NetworkServerAPI) StreamGatewayContributions(req *ns.StreamGatewayContributionsRequest, srv ns.NetworkServerService_StreamGatewayContributionsServer) error {
+	eventChan := make(chan contribution.Event)
+	var id lorawan.EUI64
+	copy(id[:], req.GatewayId)
+
+	go func() {
+		err := contribution.GetEvents(srv.Context(), storage.RedisPool(), id, eventChan)
+		if err != nil {
+			log.WithError(err).Error("get gateway contribution events error")
+		}
+		close(eventChan)
+	}()
+
+	for e := range eventChan {
+		ts, err := ptypes.TimestampProto(e.Time)
+		if err != nil {
+			log.WithError(err).Error("timestamp proto error")
+			continue
+		}
+
+		resp := ns.GatewayContributionEvent{
+			Time:          ts,
+			GatewayId:     e.GatewayID[:],
+			FirstReceiver: e.FirstReceiver,
+			OnlyReceiver:  e.OnlyReceiver,
+		}
+		if e.Type == contribution.Downlink {
+			resp.Type = ns.GatewayContributionType_DOWNLINK
+		}
+
+		if err := srv.Send(&resp); err != nil {
+			log.WithError(err).Error("error sending gateway contribution event")
+		}
+	}
+
+	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
@@ -13,7 +15,10 @@ import (
 
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/api/ns"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
@@ -86,16 +91,18 @@ func (ts *NetworkServerAPITestSuite) TestMulticastGroup() {
 			assert := require.New(t)
 
 			mgUpdated := ns.MulticastGroup{
-				Id:               createResp.Id,
-				McAddr:           []byte{4, 3, 2, 1},
-				McNwkSKey:        []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
-				FCnt:             20,
-				GroupType:        ns.MulticastGroupType_CLASS_C,
-				Dr:               3,
-				Frequency:        868100000,
-				PingSlotPeriod:   32,
-				RoutingProfileId: rp.ID[:],
-				ServiceProfileId: sp.ID[:],
+				Id:                   createResp.Id,
+				McAddr:               []byte{4, 3, 2, 1},
+				McNwkSKey:            []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+				FCnt:                 20,
+				GroupType:            ns.MulticastGroupType_CLASS_C,
+				Dr:                   3,
+				Frequency:            868100000,
+				PingSlotPeriod:       32,
+				RoutingProfileId:     rp.ID[:],
+				ServiceProfileId:     sp.ID[:],
+				MaxFramesPerMinute:   10,
+				MaxAirtimePercentage: 5,
 			}
 
 			_, err := ts.api.UpdateMulticastGroup(context.Background(), &ns.UpdateMulticastGroupRequest{
@@ -110,6 +117,18 @@ func (ts *NetworkServerAPITestSuite) TestMulticastGroup() {
 			assert.Equal(&mgUpdated, getResp.MulticastGroup)
 		})
 
+		t.Run("Update invalid max airtime percentage", func(t *testing.T) {
+			assert := require.New(t)
+
+			mgUpdated := mg
+			mgUpdated.MaxAirtimePercentage = 101
+
+			_, err := ts.api.UpdateMulticastGroup(context.Background(), &ns.UpdateMulticastGroupRequest{
+				MulticastGroup: &mgUpdated,
+			})
+			assert.Equal(codes.InvalidArgument, grpc.Code(err))
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			assert := require.New(t)
 
@@ -361,6 +380,25 @@ func (ts *NetworkServerAPITestSuite) TestMulticastQueue() {
 				}
 			})
 		})
+
+		ts.T().Run("Coverage", func(t *testing.T) {
+			assert := require.New(t)
+
+			resp, err := ts.api.GetMulticastGroupCoverage(context.Background(), &ns.GetMulticastGroupCoverageRequest{
+				MulticastGroupId: mg.ID.Bytes(),
+			})
+			assert.NoError(err)
+			assert.Len(resp.GatewayIds, 2)
+			assert.Len(resp.Devices, 2)
+			assert.EqualValues(0, resp.UncoveredCount)
+
+			for _, d := range resp.Devices {
+				assert.True(d.Covered)
+				assert.Len(d.Gateways, 1)
+				assert.EqualValues(50, d.Gateways[0].Rssi)
+				assert.EqualValues(5, d.Gateways[0].LoraSnr)
+			}
+		})
 	})
 
 }
@@ -780,6 +818,1021 @@ func (ts *NetworkServerAPITestSuite) TestDevice() {
 	})
 }
 
+func (ts *NetworkServerAPITestSuite) TestTransferDevice() {
+	assert := require.New(ts.T())
+
+	var rps []storage.RoutingProfile
+	var sps []storage.ServiceProfile
+	for i := 0; i < 2; i++ {
+		rp := storage.RoutingProfile{}
+		assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+		rps = append(rps, rp)
+
+		sp := storage.ServiceProfile{}
+		assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+		sps = append(sps, sp)
+	}
+
+	dp := storage.DeviceProfile{
+		MACVersion: "1.0.2",
+	}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DeviceProfileID:  dp.ID,
+		ServiceProfileID: sps[0].ID,
+		RoutingProfileID: rps[0].ID,
+	}
+	assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &d))
+
+	ts.T().Run("Inactive device", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.TransferDevice(context.Background(), &ns.TransferDeviceRequest{
+			DevEui:           d.DevEUI[:],
+			ServiceProfileId: sps[1].ID.Bytes(),
+		})
+		assert.NoError(err)
+
+		dGet, err := storage.GetDevice(context.Background(), storage.DB(), d.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sps[1].ID, dGet.ServiceProfileID)
+		assert.Equal(rps[0].ID, dGet.RoutingProfileID)
+	})
+
+	ts.T().Run("Active device", func(t *testing.T) {
+		assert := require.New(t)
+
+		ds := storage.DeviceSession{
+			DevEUI:           d.DevEUI,
+			DevAddr:          lorawan.DevAddr{1, 2, 3, 4},
+			DeviceProfileID:  dp.ID,
+			ServiceProfileID: sps[1].ID,
+			RoutingProfileID: rps[0].ID,
+			FCntUp:           10,
+			NFCntDown:        5,
+		}
+		assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), ds))
+		assert.NoError(storage.SetPendingMACCommand(context.Background(), storage.RedisPool(), d.DevEUI, storage.MACCommandBlock{
+			CID: lorawan.LinkADRReq,
+			MACCommands: storage.MACCommands{
+				{CID: lorawan.LinkADRReq, Payload: &lorawan.LinkADRReqPayload{}},
+			},
+		}))
+
+		qi := storage.DeviceQueueItem{
+			DevAddr: ds.DevAddr,
+			DevEUI:  d.DevEUI,
+			FCnt:    5,
+			FPort:   10,
+		}
+		assert.NoError(storage.CreateDeviceQueueItem(context.Background(), storage.DB(), &qi))
+
+		_, err := ts.api.TransferDevice(context.Background(), &ns.TransferDeviceRequest{
+			DevEui:           d.DevEUI[:],
+			ServiceProfileId: sps[0].ID.Bytes(),
+			RoutingProfileId: rps[1].ID.Bytes(),
+		})
+		assert.NoError(err)
+
+		dGet, err := storage.GetDevice(context.Background(), storage.DB(), d.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sps[0].ID, dGet.ServiceProfileID)
+		assert.Equal(rps[1].ID, dGet.RoutingProfileID)
+
+		dsGet, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), d.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sps[0].ID, dsGet.ServiceProfileID)
+		assert.Equal(rps[1].ID, dsGet.RoutingProfileID)
+		assert.Equal(ds.DevAddr, dsGet.DevAddr)
+		assert.EqualValues(10, dsGet.FCntUp)
+		assert.EqualValues(5, dsGet.NFCntDown)
+
+		items, err := storage.GetDeviceQueueItemsForDevEUI(context.Background(), storage.DB(), d.DevEUI)
+		assert.NoError(err)
+		assert.Len(items, 1)
+
+		pending, err := storage.GetPendingMACCommand(context.Background(), storage.RedisPool(), d.DevEUI, lorawan.LinkADRReq)
+		assert.NoError(err)
+		assert.Nil(pending)
+	})
+
+	ts.T().Run("Service-profile does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		spID, err := uuid.NewV4()
+		assert.NoError(err)
+
+		_, err = ts.api.TransferDevice(context.Background(), &ns.TransferDeviceRequest{
+			DevEui:           d.DevEUI[:],
+			ServiceProfileId: spID.Bytes(),
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+
+		dGet, err := storage.GetDevice(context.Background(), storage.DB(), d.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sps[0].ID, dGet.ServiceProfileID)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGetDeviceClassHealth() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+	sp := storage.ServiceProfile{}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+	dp := storage.DeviceProfile{}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DeviceProfileID:  dp.ID,
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+		Mode:             storage.DeviceModeC,
+	}
+	assert.NoError(storage.CreateDevice(context.Background(), storage.DB(), &d))
+
+	ts.T().Run("No statistics", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetDeviceClassHealth(context.Background(), &ns.GetDeviceClassHealthRequest{
+			DevEui: d.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&ns.GetDeviceClassHealthResponse{
+			DeviceClass: "C",
+			Status:      ns.DeviceClassHealthStatus_CLASS_HEALTH_UNKNOWN,
+		}, resp)
+	})
+
+	ts.T().Run("Misclassified", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 0; i < storage.DeviceClassMisclassifiedTimeouts; i++ {
+			fCnt := uint32(i)
+			assert.NoError(storage.RecordDeviceClassDownlink(context.Background(), storage.RedisPool(), d.DevEUI, storage.DeviceModeC, true, fCnt))
+			assert.NoError(storage.RecordDeviceClassTimeout(context.Background(), storage.DB(), storage.RedisPool(), d.DevEUI, fCnt))
+		}
+
+		resp, err := ts.api.GetDeviceClassHealth(context.Background(), &ns.GetDeviceClassHealthRequest{
+			DevEui: d.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(&ns.GetDeviceClassHealthResponse{
+			DeviceClass:         "C",
+			Status:              ns.DeviceClassHealthStatus_CLASS_HEALTH_MISCLASSIFIED,
+			Downlinks:           3,
+			ConfirmedDownlinks:  3,
+			Timeouts:            3,
+			ConsecutiveTimeouts: 3,
+		}, resp)
+	})
+
+	ts.T().Run("Device does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetDeviceClassHealth(context.Background(), &ns.GetDeviceClassHealthRequest{
+			DevEui: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestProvisionABPDevice() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	sp := storage.ServiceProfile{}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	dp := storage.DeviceProfile{
+		MACVersion:     "1.0.2",
+		SupportsClassC: true,
+	}
+	assert.NoError(storage.CreateDeviceProfile(context.Background(), storage.DB(), &dp))
+
+	key := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	newRequest := func(devEUI lorawan.EUI64) *ns.ProvisionABPDeviceRequest {
+		return &ns.ProvisionABPDeviceRequest{
+			Device: &ns.Device{
+				DevEui:           devEUI[:],
+				DeviceProfileId:  dp.ID.Bytes(),
+				ServiceProfileId: sp.ID.Bytes(),
+				RoutingProfileId: rp.ID.Bytes(),
+			},
+			DeviceActivation: &ns.DeviceActivation{
+				SNwkSIntKey: key[:],
+				FNwkSIntKey: key[:],
+				NwkSEncKey:  key[:],
+				FCntUp:      10,
+				NFCntDown:   11,
+			},
+		}
+	}
+
+	ts.T().Run("With DevAddr", func(t *testing.T) {
+		assert := require.New(t)
+
+		devEUI := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		req := newRequest(devEUI)
+		req.DeviceActivation.DevAddr = []byte{1, 2, 3, 4}
+		req.AppSKey = &common.KeyEnvelope{AesKey: key[:]}
+		req.EnabledUplinkChannels = []uint32{0, 1}
+
+		resp, err := ts.api.ProvisionABPDevice(context.Background(), req)
+		assert.NoError(err)
+		assert.Equal([]byte{1, 2, 3, 4}, resp.DevAddr)
+
+		d, err := storage.GetDevice(context.Background(), storage.DB(), devEUI)
+		assert.NoError(err)
+		assert.Equal(storage.DeviceModeC, d.Mode)
+
+		ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, ds.DevAddr)
+		assert.Equal(key, ds.NwkSEncKey)
+		assert.EqualValues(10, ds.FCntUp)
+		assert.EqualValues(11, ds.NFCntDown)
+		assert.Equal([]int{0, 1}, ds.EnabledUplinkChannels)
+		assert.NotNil(ds.AppSKeyEvelope)
+		assert.Equal(key[:], ds.AppSKeyEvelope.AESKey)
+
+		t.Run("DevAddr in use", func(t *testing.T) {
+			assert := require.New(t)
+
+			req := newRequest(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2})
+			req.DeviceActivation.DevAddr = []byte{1, 2, 3, 4}
+
+			_, err := ts.api.ProvisionABPDevice(context.Background(), req)
+			assert.Equal(codes.AlreadyExists, grpc.Code(err))
+		})
+
+		t.Run("Device exists", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.api.ProvisionABPDevice(context.Background(), newRequest(devEUI))
+			assert.Equal(codes.AlreadyExists, grpc.Code(err))
+		})
+	})
+
+	ts.T().Run("Allocate DevAddr", func(t *testing.T) {
+		assert := require.New(t)
+
+		devEUI := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+		resp, err := ts.api.ProvisionABPDevice(context.Background(), newRequest(devEUI))
+		assert.NoError(err)
+
+		var devAddr lorawan.DevAddr
+		copy(devAddr[:], resp.DevAddr)
+		assert.True(devAddr.IsNetID(config.C.NetworkServer.NetID))
+
+		ds, err := storage.GetDeviceSession(context.Background(), storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(devAddr, ds.DevAddr)
+	})
+
+	ts.T().Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			Name   string
+			Modify func(*ns.ProvisionABPDeviceRequest)
+		}{
+			{
+				Name: "DevAddr not matching NetID",
+				Modify: func(req *ns.ProvisionABPDeviceRequest) {
+					req.DeviceActivation.DevAddr = []byte{0xfe, 2, 3, 4}
+				},
+			},
+			{
+				Name: "Invalid key length",
+				Modify: func(req *ns.ProvisionABPDeviceRequest) {
+					req.DeviceActivation.NwkSEncKey = []byte{1, 2, 3}
+				},
+			},
+			{
+				Name: "Keys not equal for LoRaWAN 1.0",
+				Modify: func(req *ns.ProvisionABPDeviceRequest) {
+					req.DeviceActivation.NwkSEncKey = make([]byte, 16)
+				},
+			},
+			{
+				Name: "Invalid uplink channel",
+				Modify: func(req *ns.ProvisionABPDeviceRequest) {
+					req.EnabledUplinkChannels = []uint32{0, 100}
+				},
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+
+				devEUI := lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}
+				req := newRequest(devEUI)
+				tst.Modify(req)
+
+				_, err := ts.api.ProvisionABPDevice(context.Background(), req)
+				assert.Equal(codes.InvalidArgument, grpc.Code(err))
+
+				_, err = storage.GetDevice(context.Background(), storage.DB(), devEUI)
+				assert.Equal(storage.ErrDoesNotExist, errors.Cause(err))
+			})
+		}
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestDecodePHYPayload() {
+	assert := require.New(ts.T())
+
+	sp := storage.ServiceProfile{}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	nwkSKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	appSKey := lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	ds := storage.DeviceSession{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:          lorawan.DevAddr{1, 2, 3, 4},
+		ServiceProfileID: sp.ID,
+		MACVersion:       "1.0.2",
+		FNwkSIntKey:      nwkSKey,
+		SNwkSIntKey:      nwkSKey,
+		NwkSEncKey:       nwkSKey,
+		FCntUp:           0x10005,
+		AppSKeyEvelope: &storage.KeyEnvelope{
+			AESKey: appSKey[:],
+		},
+	}
+	assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), ds))
+
+	fPort := uint8(10)
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ds.DevAddr,
+				FCnt:    0x10004,
+				FOpts: []lorawan.Payload{
+					&lorawan.MACCommand{CID: lorawan.LinkCheckReq},
+				},
+			},
+			FPort: &fPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+			},
+		},
+	}
+	assert.NoError(phy.EncryptFRMPayload(appSKey))
+	assert.NoError(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, nwkSKey, nwkSKey))
+	phyB, err := phy.MarshalBinary()
+	assert.NoError(err)
+
+	ts.T().Run("Invalid PHYPayload", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload: []byte{1, 2, 3},
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Match by DevAddr", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload: phyB,
+		})
+		assert.NoError(err)
+		assert.Equal(ns.MType_UNCONFIRMED_DATA_UP, resp.MType)
+		assert.Equal(ds.DevEUI[:], resp.DevEui)
+		assert.True(resp.MicValid)
+		assert.EqualValues(0x10004, resp.FCnt)
+		assert.True(resp.MacCommandsDecrypted)
+		assert.False(resp.FrmPayloadDecrypted)
+		assert.NotEmpty(resp.PhyPayloadJson)
+	})
+
+	ts.T().Run("Decrypt FRMPayload", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload:        phyB,
+			DecryptFrmPayload: true,
+		})
+		assert.NoError(err)
+		assert.True(resp.MicValid)
+		assert.True(resp.FrmPayloadDecrypted)
+	})
+
+	ts.T().Run("Decrypt FRMPayload redacted by service-profile", func(t *testing.T) {
+		assert := require.New(t)
+
+		sp.FrameLogRedactPayload = true
+		assert.NoError(storage.UpdateServiceProfile(context.Background(), storage.DB(), &sp))
+		assert.NoError(storage.FlushServiceProfileCache(context.Background(), storage.RedisPool(), sp.ID))
+
+		resp, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload:        phyB,
+			DecryptFrmPayload: true,
+		})
+		assert.NoError(err)
+		assert.True(resp.MicValid)
+		assert.False(resp.FrmPayloadDecrypted)
+	})
+
+	ts.T().Run("Invalid MIC for DevEUI", func(t *testing.T) {
+		assert := require.New(t)
+
+		ds2 := ds
+		ds2.DevEUI = lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		ds2.DevAddr = lorawan.DevAddr{2, 2, 2, 2}
+		ds2.FNwkSIntKey = appSKey
+		ds2.SNwkSIntKey = appSKey
+		assert.NoError(storage.SaveDeviceSession(context.Background(), storage.RedisPool(), ds2))
+
+		resp, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload: phyB,
+			DevEui:     ds2.DevEUI[:],
+		})
+		assert.NoError(err)
+		assert.Equal(ds2.DevEUI[:], resp.DevEui)
+		assert.False(resp.MicValid)
+		assert.False(resp.MacCommandsDecrypted)
+	})
+
+	ts.T().Run("No matching device-session", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.DeleteDeviceSession(context.Background(), storage.RedisPool(), ds.DevEUI))
+
+		resp, err := ts.api.DecodePHYPayload(context.Background(), &ns.DecodePHYPayloadRequest{
+			PhyPayload: phyB,
+		})
+		assert.NoError(err)
+		assert.Len(resp.DevEui, 0)
+		assert.False(resp.MicValid)
+		assert.NotEmpty(resp.PhyPayloadJson)
+	})
+}
+
 func TestNetworkServerAPINew(t *testing.T) {
 	suite.Run(t, new(NetworkServerAPITestSuite))
 }
+
+func (ts *NetworkServerAPITestSuite) TestGetSchedulerBacklog() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+		Token:  123,
+		TxInfo: &gw.DownlinkTXInfo{GatewayId: gateway.GatewayID[:]},
+	}))
+	assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+		Token:  123,
+		TxInfo: &gw.DownlinkTXInfo{GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1}},
+	}))
+
+	ts.T().Run("Global", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetSchedulerBacklog(context.Background(), &ns.GetSchedulerBacklogRequest{})
+		assert.NoError(err)
+		assert.EqualValues(2, resp.TxAckPending)
+	})
+
+	ts.T().Run("Gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetSchedulerBacklog(context.Background(), &ns.GetSchedulerBacklogRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.EqualValues(1, resp.TxAckPending)
+	})
+
+	ts.T().Run("Gateway does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetSchedulerBacklog(context.Background(), &ns.GetSchedulerBacklogRequest{
+			GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayDrain() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	ts.T().Run("Not draining", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetGatewayDrainStatus(context.Background(), &ns.GetGatewayDrainStatusRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.False(resp.Draining)
+		assert.False(resp.SafeToPowerOff)
+		assert.Nil(resp.StartedAt)
+	})
+
+	ts.T().Run("Draining with pending TX ack", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.StartGatewayDrain(context.Background(), &ns.StartGatewayDrainRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+
+		assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+			Token:  123,
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: gateway.GatewayID[:]},
+		}))
+
+		resp, err := ts.api.GetGatewayDrainStatus(context.Background(), &ns.GetGatewayDrainStatusRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.True(resp.Draining)
+		assert.NotNil(resp.StartedAt)
+		assert.EqualValues(1, resp.TxAckPending)
+		assert.False(resp.SafeToPowerOff)
+	})
+
+	ts.T().Run("Safe to power off", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.DeleteDownlinkTXAckPending(context.Background(), storage.RedisPool(), gateway.GatewayID, 123))
+
+		resp, err := ts.api.GetGatewayDrainStatus(context.Background(), &ns.GetGatewayDrainStatusRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.True(resp.Draining)
+		assert.EqualValues(0, resp.TxAckPending)
+		assert.True(resp.SafeToPowerOff)
+	})
+
+	ts.T().Run("Stop drain", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.StopGatewayDrain(context.Background(), &ns.StopGatewayDrainRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+
+		resp, err := ts.api.GetGatewayDrainStatus(context.Background(), &ns.GetGatewayDrainStatusRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.False(resp.Draining)
+	})
+
+	ts.T().Run("Gateway does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.StartGatewayDrain(context.Background(), &ns.StartGatewayDrainRequest{
+			GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayInventory() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gw := storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gw))
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetGatewayInventory(context.Background(), &ns.GetGatewayInventoryRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.SaveGatewayInventory(context.Background(), storage.DB(), &storage.GatewayInventory{
+			GatewayID:           gw.GatewayID,
+			Model:               "model-x",
+			FirmwareVersion:     "1.0.0",
+			BridgeVersion:       "3.0.0",
+			ConcentratorVersion: "5.0.1",
+		}))
+
+		resp, err := ts.api.GetGatewayInventory(context.Background(), &ns.GetGatewayInventoryRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Equal(gw.GatewayID[:], resp.Inventory.GatewayId)
+		assert.Equal("model-x", resp.Inventory.Model)
+		assert.Equal("1.0.0", resp.Inventory.FirmwareVersion)
+		assert.Equal("3.0.0", resp.Inventory.BridgeVersion)
+		assert.Equal("5.0.1", resp.Inventory.ConcentratorVersion)
+		assert.NotNil(resp.Inventory.UpdatedAt)
+	})
+
+	ts.T().Run("List", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.ListGatewayInventory(context.Background(), &ns.ListGatewayInventoryRequest{
+			Limit: 10,
+			Model: "model-x",
+		})
+		assert.NoError(err)
+		assert.EqualValues(1, resp.TotalCount)
+		assert.Len(resp.Result, 1)
+
+		resp, err = ts.api.ListGatewayInventory(context.Background(), &ns.ListGatewayInventoryRequest{
+			Limit: 10,
+			Model: "model-y",
+		})
+		assert.NoError(err)
+		assert.EqualValues(0, resp.TotalCount)
+		assert.Len(resp.Result, 0)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayConnectionState() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gw := storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gw))
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetGatewayConnectionState(context.Background(), &ns.GetGatewayConnectionStateRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.SaveGatewayConnectionState(context.Background(), storage.DB(), &storage.GatewayConnectionState{
+			GatewayID: gw.GatewayID,
+			Online:    false,
+		}))
+
+		resp, err := ts.api.GetGatewayConnectionState(context.Background(), &ns.GetGatewayConnectionStateRequest{
+			GatewayId: gw.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Equal(gw.GatewayID[:], resp.State.GatewayId)
+		assert.False(resp.State.Online)
+		assert.NotNil(resp.State.UpdatedAt)
+	})
+
+	ts.T().Run("List", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.ListGatewayConnectionStates(context.Background(), &ns.ListGatewayConnectionStatesRequest{
+			Limit:       10,
+			OfflineOnly: true,
+		})
+		assert.NoError(err)
+		assert.EqualValues(1, resp.TotalCount)
+		assert.Len(resp.Result, 1)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayCommandExec() {
+	assert := require.New(ts.T())
+
+	gwBackend := test.NewGatewayBackend()
+	gwbackend.SetBackend(gwBackend)
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	ts.T().Run("Exec unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.ExecGatewayCommand(context.Background(), &ns.ExecGatewayCommandRequest{
+			GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+			Command:   "reboot",
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("Exec invalid timeout", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.ExecGatewayCommand(context.Background(), &ns.ExecGatewayCommandRequest{
+			GatewayId: gateway.GatewayID[:],
+			Command:   "reboot",
+			Timeout:   ptypes.DurationProto(time.Hour),
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Exec", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.ExecGatewayCommand(context.Background(), &ns.ExecGatewayCommandRequest{
+			GatewayId:   gateway.GatewayID[:],
+			Command:     "reboot",
+			Stdin:       []byte("hello"),
+			Environment: map[string]string{"FOO": "bar"},
+		})
+		assert.NoError(err)
+		assert.Nil(resp.Response)
+
+		req := <-gwBackend.CommandExecRequestChan
+		assert.Equal(gw.GatewayCommandExecRequest{
+			GatewayId:   gateway.GatewayID[:],
+			Command:     "reboot",
+			ExecId:      resp.ExecId,
+			Stdin:       []byte("hello"),
+			Environment: map[string]string{"FOO": "bar"},
+		}, req)
+
+		t.Run("Get response not yet received", func(t *testing.T) {
+			assert := require.New(t)
+
+			_, err := ts.api.GetGatewayCommandExecResponse(context.Background(), &ns.GetGatewayCommandExecResponseRequest{
+				ExecId: resp.ExecId,
+			})
+			assert.Equal(codes.NotFound, grpc.Code(err))
+		})
+
+		t.Run("Get response", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(storage.SaveGatewayCommandExecResponse(context.Background(), storage.RedisPool(), gw.GatewayCommandExecResponse{
+				GatewayId: gateway.GatewayID[:],
+				ExecId:    resp.ExecId,
+				Stdout:    []byte("rebooting"),
+			}))
+
+			getResp, err := ts.api.GetGatewayCommandExecResponse(context.Background(), &ns.GetGatewayCommandExecResponseRequest{
+				ExecId:  resp.ExecId,
+				Timeout: ptypes.DurationProto(time.Second),
+			})
+			assert.NoError(err)
+			assert.Equal([]byte("rebooting"), getResp.Response.Stdout)
+		})
+	})
+
+	ts.T().Run("Exec with timeout", func(t *testing.T) {
+		assert := require.New(t)
+
+		go func() {
+			req := <-gwBackend.CommandExecRequestChan
+			storage.SaveGatewayCommandExecResponse(context.Background(), storage.RedisPool(), gw.GatewayCommandExecResponse{
+				GatewayId: req.GatewayId,
+				ExecId:    req.ExecId,
+				Stdout:    []byte("ok"),
+			})
+		}()
+
+		resp, err := ts.api.ExecGatewayCommand(context.Background(), &ns.ExecGatewayCommandRequest{
+			GatewayId: gateway.GatewayID[:],
+			Command:   "config",
+			Timeout:   ptypes.DurationProto(5 * time.Second),
+		})
+		assert.NoError(err)
+		assert.NotNil(resp.Response)
+		assert.Equal(resp.ExecId, resp.Response.ExecId)
+		assert.Equal([]byte("ok"), resp.Response.Stdout)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGatewayDownlinkTXState() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{1, 1, 2, 2, 3, 3, 4, 4},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	ts.T().Run("Get unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetGatewayDownlinkTXState(context.Background(), &ns.GetGatewayDownlinkTXStateRequest{
+			GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+		})
+		assert.Equal(codes.NotFound, grpc.Code(err))
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		downID := uuid.Must(uuid.NewV4())
+		assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+			Token:      123,
+			DownlinkId: downID.Bytes(),
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gateway.GatewayID[:],
+				Frequency: 868100000,
+				Timing:    gw.DownlinkTiming_DELAY,
+			},
+		}))
+		assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+			Token:  123,
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1}},
+		}))
+		assert.NoError(storage.SetDownlinkTXAckPending(context.Background(), storage.RedisPool(), gw.DownlinkFrame{
+			Token:  124,
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1}},
+		}))
+
+		resp, err := ts.api.GetGatewayDownlinkTXState(context.Background(), &ns.GetGatewayDownlinkTXStateRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.EqualValues(1, resp.QueueDepth)
+		assert.Len(resp.Pending, 1)
+		assert.EqualValues(123, resp.Pending[0].Token)
+		assert.Equal(downID.Bytes(), resp.Pending[0].DownlinkId)
+		assert.NotNil(resp.Pending[0].CreatedAt)
+		assert.EqualValues(868100000, resp.Pending[0].TxInfo.Frequency)
+		assert.Equal(gw.DownlinkTiming_DELAY, resp.Pending[0].TxInfo.Timing)
+	})
+
+	ts.T().Run("List queue depths", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.ListGatewayDownlinkQueueDepths(context.Background(), &ns.ListGatewayDownlinkQueueDepthsRequest{})
+		assert.NoError(err)
+		assert.Len(resp.Result, 2)
+		assert.Equal([]byte{8, 7, 6, 5, 4, 3, 2, 1}, resp.Result[0].GatewayId)
+		assert.EqualValues(2, resp.Result[0].QueueDepth)
+		assert.Equal(gateway.GatewayID[:], resp.Result[1].GatewayId)
+		assert.EqualValues(1, resp.Result[1].QueueDepth)
+
+		resp, err = ts.api.ListGatewayDownlinkQueueDepths(context.Background(), &ns.ListGatewayDownlinkQueueDepthsRequest{
+			Limit: 1,
+		})
+		assert.NoError(err)
+		assert.Len(resp.Result, 1)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGetGatewayStatsAggregates() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{2, 2, 3, 3, 4, 4, 5, 5},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	now := time.Now().Truncate(time.Hour)
+	assert.NoError(storage.SaveGatewayStatsAggregate(context.Background(), storage.DB(), storage.GatewayStatsAggregate{
+		GatewayID:           gateway.GatewayID,
+		Interval:            time.Hour,
+		Timestamp:           now,
+		RXPacketsReceived:   10,
+		RXPacketsReceivedOK: 8,
+		RXPacketsPerFrequency: storage.GatewayStatsCounts{
+			"868100000": 10,
+		},
+		TXPacketsPerStatus: storage.GatewayStatsCounts{
+			"TOO_LATE": 2,
+		},
+	}))
+
+	ts.T().Run("Interval not set", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.api.GetGatewayStatsAggregates(context.Background(), &ns.GetGatewayStatsAggregatesRequest{
+			GatewayId:      gateway.GatewayID[:],
+			StartTimestamp: ptypes.TimestampNow(),
+			EndTimestamp:   ptypes.TimestampNow(),
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		start, _ := ptypes.TimestampProto(now.Add(-time.Hour))
+		end, _ := ptypes.TimestampProto(now)
+
+		resp, err := ts.api.GetGatewayStatsAggregates(context.Background(), &ns.GetGatewayStatsAggregatesRequest{
+			GatewayId:      gateway.GatewayID[:],
+			Interval:       ptypes.DurationProto(time.Hour),
+			StartTimestamp: start,
+			EndTimestamp:   end,
+		})
+		assert.NoError(err)
+		assert.Len(resp.Result, 1)
+		assert.Equal(end, resp.Result[0].Timestamp)
+		assert.EqualValues(10, resp.Result[0].RxPacketsReceived)
+		assert.EqualValues(8, resp.Result[0].RxPacketsReceivedOk)
+		assert.Equal(map[uint32]uint32{868100000: 10}, resp.Result[0].RxPacketsPerFrequency)
+		assert.Equal(map[uint32]uint32{}, resp.Result[0].TxPacketsPerFrequency)
+		assert.Equal(map[string]uint32{"TOO_LATE": 2}, resp.Result[0].TxPacketsPerStatus)
+	})
+}
+
+func (ts *NetworkServerAPITestSuite) TestGetGatewayNoiseFloor() {
+	assert := require.New(ts.T())
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	gateway := storage.Gateway{
+		GatewayID:        lorawan.EUI64{3, 3, 4, 4, 5, 5, 6, 6},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &gateway))
+
+	ts.T().Run("No reports", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := ts.api.GetGatewayNoiseFloor(context.Background(), &ns.GetGatewayNoiseFloorRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Len(resp.Result, 0)
+	})
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.SaveGatewayNoiseFloor(context.Background(), storage.DB(), &storage.GatewayNoiseFloor{
+			GatewayID:          gateway.GatewayID,
+			Frequency:          868100000,
+			Bandwidth:          125000,
+			NoiseFloor:         -118.5,
+			ChannelUtilization: 0.25,
+			RSSIHistogram: storage.RSSIHistogram{
+				-120: 10,
+			},
+		}))
+
+		resp, err := ts.api.GetGatewayNoiseFloor(context.Background(), &ns.GetGatewayNoiseFloorRequest{
+			GatewayId: gateway.GatewayID[:],
+		})
+		assert.NoError(err)
+		assert.Len(resp.Result, 1)
+		assert.NotNil(resp.Result[0].UpdatedAt)
+		resp.Result[0].UpdatedAt = nil
+		assert.Equal(&ns.GatewayChannelNoiseFloor{
+			Frequency:          868100000,
+			Bandwidth:          125000,
+			NoiseFloor:         -118.5,
+			ChannelUtilization: 0.25,
+			RssiHistogram: map[int32]uint32{
+				-120: 10,
+			},
+		}, resp.Result[0])
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/mxc-foundation/lpwan-server/api/ns"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
 	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
@@ -220,26 +221,37 @@ func TestNetworkServerAPI(t *testing.T) {
 			Convey("Then UpdateServiceProfile updates the service-profile", func() {
 				_, err := api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
 					ServiceProfile: &ns.ServiceProfile{
-						Id:                     resp.Id,
-						UlRate:                 2,
-						UlBucketSize:           3,
-						UlRatePolicy:           ns.RatePolicy_MARK,
-						DlRate:                 4,
-						DlBucketSize:           5,
-						DlRatePolicy:           ns.RatePolicy_DROP,
-						AddGwMetadata:          false,
-						DevStatusReqFreq:       6,
-						ReportDevStatusBattery: false,
-						ReportDevStatusMargin:  false,
-						DrMin:                  7,
-						DrMax:                  8,
-						ChannelMask:            []byte{3, 2, 1},
-						PrAllowed:              false,
-						HrAllowed:              false,
-						RaAllowed:              false,
-						NwkGeoLoc:              false,
-						TargetPer:              2,
-						MinGwDiversity:         8,
+						Id:                           resp.Id,
+						UlRate:                       2,
+						UlBucketSize:                 3,
+						UlRatePolicy:                 ns.RatePolicy_MARK,
+						DlRate:                       4,
+						DlBucketSize:                 5,
+						DlRatePolicy:                 ns.RatePolicy_DROP,
+						AddGwMetadata:                false,
+						DevStatusReqFreq:             6,
+						ReportDevStatusBattery:       false,
+						ReportDevStatusMargin:        false,
+						DrMin:                        7,
+						DrMax:                        8,
+						ChannelMask:                  []byte{3, 2, 1},
+						PrAllowed:                    false,
+						HrAllowed:                    false,
+						RaAllowed:                    false,
+						NwkGeoLoc:                    false,
+						TargetPer:                    2,
+						MinGwDiversity:               8,
+						GatewayIsolation:             true,
+						AllowedGatewayIds:            [][]byte{{1, 2, 3, 4, 5, 6, 7, 8}},
+						ClockSyncEnabled:             true,
+						MulticastSetupEnabled:        true,
+						DeviceQueueMaxSize:           10,
+						DeviceQueueOverflowPolicy:    ns.DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY,
+						FrameLogRedactPayload:        true,
+						FrameLogTruncateDevEui:       true,
+						DownlinkDisabled:             true,
+						DlMaxTxPower:                 14,
+						UplinkFingerprintSensitivity: ns.UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM,
 					},
 				})
 				So(err, ShouldBeNil)
@@ -249,27 +261,50 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 				So(err, ShouldBeNil)
 				So(getResp.ServiceProfile, ShouldResemble, &ns.ServiceProfile{
-					Id:                     resp.Id,
-					UlRate:                 2,
-					UlBucketSize:           3,
-					UlRatePolicy:           ns.RatePolicy_MARK,
-					DlRate:                 4,
-					DlBucketSize:           5,
-					DlRatePolicy:           ns.RatePolicy_DROP,
-					AddGwMetadata:          false,
-					DevStatusReqFreq:       6,
-					ReportDevStatusBattery: false,
-					ReportDevStatusMargin:  false,
-					DrMin:                  7,
-					DrMax:                  8,
-					ChannelMask:            []byte{3, 2, 1},
-					PrAllowed:              false,
-					HrAllowed:              false,
-					RaAllowed:              false,
-					NwkGeoLoc:              false,
-					TargetPer:              2,
-					MinGwDiversity:         8,
+					Id:                           resp.Id,
+					UlRate:                       2,
+					UlBucketSize:                 3,
+					UlRatePolicy:                 ns.RatePolicy_MARK,
+					DlRate:                       4,
+					DlBucketSize:                 5,
+					DlRatePolicy:                 ns.RatePolicy_DROP,
+					AddGwMetadata:                false,
+					DevStatusReqFreq:             6,
+					ReportDevStatusBattery:       false,
+					ReportDevStatusMargin:        false,
+					DrMin:                        7,
+					DrMax:                        8,
+					ChannelMask:                  []byte{3, 2, 1},
+					PrAllowed:                    false,
+					HrAllowed:                    false,
+					RaAllowed:                    false,
+					NwkGeoLoc:                    false,
+					TargetPer:                    2,
+					MinGwDiversity:               8,
+					GatewayIsolation:             true,
+					AllowedGatewayIds:            [][]byte{{1, 2, 3, 4, 5, 6, 7, 8}},
+					ClockSyncEnabled:             true,
+					MulticastSetupEnabled:        true,
+					DeviceQueueMaxSize:           10,
+					DeviceQueueOverflowPolicy:    ns.DeviceQueueOverflowPolicy_DROP_LOWEST_PRIORITY,
+					FrameLogRedactPayload:        true,
+					FrameLogTruncateDevEui:       true,
+					DownlinkDisabled:             true,
+					DlMaxTxPower:                 14,
+					UplinkFingerprintSensitivity: ns.UplinkFingerprintSensitivity_FINGERPRINT_MEDIUM,
+				})
+			})
+
+			Convey("Then UpdateServiceProfile with an invalid allowed gateway id returns an error", func() {
+				_, err := api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
+					ServiceProfile: &ns.ServiceProfile{
+						Id:                resp.Id,
+						GatewayIsolation:  true,
+						AllowedGatewayIds: [][]byte{{1, 2, 3}},
+					},
 				})
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
 			})
 
 			Convey("Then DeleteServiceProfile deletes the service-profile", func() {
@@ -320,6 +355,9 @@ func TestNetworkServerAPI(t *testing.T) {
 						CaCert:  "CACERT2",
 						TlsCert: "TLSCERT2",
 						TlsKey:  "TLSKEY2",
+
+						DownlinkHookUrl:    "https://hook.example.com/downlink",
+						DownlinkHookSecret: "secret",
 					},
 				})
 				So(err, ShouldBeNil)
@@ -333,6 +371,8 @@ func TestNetworkServerAPI(t *testing.T) {
 					AsId:    "new-application-server:1234",
 					CaCert:  "CACERT2",
 					TlsCert: "TLSCERT2",
+
+					DownlinkHookUrl: "https://hook.example.com/downlink",
 				})
 			})
 
@@ -373,6 +413,8 @@ func TestNetworkServerAPI(t *testing.T) {
 					Supports_32BitFCnt:  true,
 					GeolocBufferTtl:     60,
 					GeolocMinBufferSize: 3,
+					FCntValidationMode:  ns.FCntValidationMode_RELAXED,
+					FCntMaxGap:          100,
 				},
 			})
 			So(err, ShouldBeNil)
@@ -407,6 +449,8 @@ func TestNetworkServerAPI(t *testing.T) {
 					Supports_32BitFCnt:  true,
 					GeolocBufferTtl:     60,
 					GeolocMinBufferSize: 3,
+					FCntValidationMode:  ns.FCntValidationMode_RELAXED,
+					FCntMaxGap:          100,
 				})
 			})
 		})
@@ -771,6 +815,40 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("Given some contributions for this gateway", func() {
+				now := time.Now().UTC()
+				metrics := storage.MetricsRecord{
+					Time: now,
+					Metrics: map[string]float64{
+						contribution.MetricFirstReceiver: 3,
+						contribution.MetricOnlyReceiver:  2,
+						contribution.MetricUplink:        5,
+						contribution.MetricDownlink:      1,
+					},
+				}
+				So(storage.SaveMetricsForInterval(context.Background(), storage.RedisPool(), storage.AggregationMinute, contribution.MetricsName(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}), metrics), ShouldBeNil)
+
+				Convey("Then GetGatewayContributions returns these contributions", func() {
+					start, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
+					end, _ := ptypes.TimestampProto(now)
+					nowTrunc, _ := ptypes.TimestampProto(now.Truncate(time.Minute))
+
+					resp, err := api.GetGatewayContributions(ctx, &ns.GetGatewayContributionsRequest{
+						GatewayId:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Interval:       ns.AggregationInterval_MINUTE,
+						StartTimestamp: start,
+						EndTimestamp:   end,
+					})
+					So(err, ShouldBeNil)
+					So(resp.Result, ShouldHaveLength, 1)
+					So(resp.Result[0].Timestamp, ShouldResemble, nowTrunc)
+					So(resp.Result[0].FirstReceiverCount, ShouldEqual, 3)
+					So(resp.Result[0].OnlyReceiverCount, ShouldEqual, 2)
+					So(resp.Result[0].UplinkCount, ShouldEqual, 5)
+					So(resp.Result[0].DownlinkCount, ShouldEqual, 1)
+				})
+			})
+
 			Convey("When creating a gateway-profile object", func() {
 				req := ns.CreateGatewayProfileRequest{
 					GatewayProfile: &ns.GatewayProfile{
@@ -842,6 +920,10 @@ func TestNetworkServerAPI(t *testing.T) {
 									SpreadingFactors: []uint32{10, 11, 12},
 								},
 							},
+							ReconfigurationWindow: &ns.GatewayProfileReconfigurationWindow{
+								StartMinute:     120,
+								DurationMinutes: 60,
+							},
 						},
 					}
 					_, err := api.UpdateGatewayProfile(ctx, &updateReq)
@@ -868,7 +950,24 @@ func TestNetworkServerAPI(t *testing.T) {
 								SpreadingFactors: []uint32{10, 11, 12},
 							},
 						},
+						ReconfigurationWindow: &ns.GatewayProfileReconfigurationWindow{
+							StartMinute:     120,
+							DurationMinutes: 60,
+						},
+					})
+				})
+
+				Convey("Then updating with an invalid reconfiguration window returns an error", func() {
+					_, err := api.UpdateGatewayProfile(ctx, &ns.UpdateGatewayProfileRequest{
+						GatewayProfile: &ns.GatewayProfile{
+							Id: createResp.Id,
+							ReconfigurationWindow: &ns.GatewayProfileReconfigurationWindow{
+								StartMinute:     24 * 60,
+								DurationMinutes: 60,
+							},
+						},
 					})
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
 				})
 
 				Convey("Then it can be deleted", func() {
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/mxc-foundation/lpwan-server/internal/security"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// securityCredentials wraps the server transport credentials and emits a
+// security event on failed TLS handshakes (e.g. a missing or invalid client
+// certificate).
+type securityCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c securityCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	sConn, authInfo, err := c.TransportCredentials.ServerHandshake(conn)
+	if err != nil {
+		// the port is omitted so that the events are rate-limited per host
+		source := conn.RemoteAddr().String()
+		if host, _, err := net.SplitHostPort(source); err == nil {
+			source = host
+		}
+
+		security.AuthFailure(context.Background(), storage.RedisPool(), source, err)
+	}
+
+	return sConn, authInfo, err
+}
+
+func (c securityCredentials) Clone() credentials.TransportCredentials {
+	return securityCredentials{c.TransportCredentials.Clone()}
+}
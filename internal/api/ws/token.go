@@ -0,0 +1,103 @@
+package ws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Token errors.
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+	ErrTokenScope   = errors.New("token is not valid for the requested frame-log")
+)
+
+// tokenClaims contains the claims of a frame-log token. A token is scoped
+// to either a single device or a single gateway.
+type tokenClaims struct {
+	ExpiresAt int64          `json:"exp"`
+	NotBefore int64          `json:"nbf"`
+	DevEUI    *lorawan.EUI64 `json:"dev_eui"`
+	GatewayID *lorawan.EUI64 `json:"gateway_id"`
+}
+
+// validateToken validates the given HS256 signed JWT token and returns its
+// claims.
+func validateToken(secret []byte, token string, now time.Time) (tokenClaims, error) {
+	var claims tokenClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, ErrInvalidToken
+	}
+
+	headerB, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerB, &header); err != nil {
+		return claims, ErrInvalidToken
+	}
+	if header.Alg != "HS256" {
+		return claims, ErrInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return claims, ErrInvalidToken
+	}
+
+	claimsB, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, ErrInvalidToken
+	}
+	if err := json.Unmarshal(claimsB, &claims); err != nil {
+		return claims, ErrInvalidToken
+	}
+
+	if claims.ExpiresAt == 0 || !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return claims, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Before(time.Unix(claims.NotBefore, 0)) {
+		return claims, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// authorize returns an error when the claims do not grant access to the
+// frame-log of the given target.
+func (c tokenClaims) authorize(t target) error {
+	switch {
+	case t.devEUI != nil:
+		if c.DevEUI == nil || *c.DevEUI != *t.devEUI {
+			return ErrTokenScope
+		}
+	case t.gatewayID != nil:
+		if c.GatewayID == nil || *c.GatewayID != *t.gatewayID {
+			return ErrTokenScope
+		}
+	default:
+		return ErrTokenScope
+	}
+
+	return nil
+}
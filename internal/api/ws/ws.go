@@ -0,0 +1,335 @@
+// Package ws implements the WebSocket frame-log API, so that browser based
+// consoles can stream frame-logs without a gRPC-web proxy.
+package ws
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/ns"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const (
+	deviceFrameLogPath  = "/frame-logs/device/"
+	gatewayFrameLogPath = "/frame-logs/gateway/"
+)
+
+var server *http.Server
+
+// Setup starts the WebSocket frame-log server. It is a no-op when no bind
+// address is configured.
+func Setup(conf config.Config) error {
+	wsConf := conf.NetworkServer.FrameLog.WebSocket
+	if wsConf.Bind == "" {
+		return nil
+	}
+
+	if wsConf.JWTSecret == "" {
+		return errors.New("jwt_secret must be set when the frame-log websocket server is enabled")
+	}
+
+	log.WithFields(log.Fields{
+		"bind": wsConf.Bind,
+		"tls":  wsConf.TLSCert != "",
+	}).Info("api/ws: starting frame-log websocket server")
+
+	h := handler{
+		jwtSecret:      []byte(wsConf.JWTSecret),
+		allowedOrigins: wsConf.AllowedOrigins,
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(deviceFrameLogPath, h)
+	mux.Handle(gatewayFrameLogPath, h)
+
+	server = &http.Server{
+		Handler: mux,
+		Addr:    wsConf.Bind,
+	}
+
+	ln, err := handover.Listen("framelog_websocket", wsConf.Bind)
+	if err != nil {
+		return errors.Wrap(err, "start frame-log websocket listener error")
+	}
+
+	if wsConf.TLSCert != "" || wsConf.TLSKey != "" {
+		cert, err := nstls.LoadX509KeyPair(wsConf.TLSCert, wsConf.TLSKey)
+		if err != nil {
+			return errors.Wrap(err, "load x509 keypair error")
+		}
+		ln = tls.NewListener(ln, &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		})
+	}
+
+	go func() {
+		err := server.Serve(ln)
+		if err != http.ErrServerClosed {
+			log.WithError(err).Error("api/ws: frame-log websocket server error")
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the WebSocket frame-log server.
+func Stop() {
+	if server == nil {
+		return
+	}
+
+	log.Info("api/ws: stopping frame-log websocket server")
+	server.Close()
+}
+
+// target defines the device or gateway of which the frame-log is requested.
+type target struct {
+	devEUI    *lorawan.EUI64
+	gatewayID *lorawan.EUI64
+}
+
+type handler struct {
+	jwtSecret      []byte
+	allowedOrigins []string
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t, err := parseTarget(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	claims, err := validateToken(h.jwtSecret, getToken(r), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if err := claims.authorize(t); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			streamFrameLogs(conn, t, filter)
+		},
+	}.ServeHTTP(w, r)
+}
+
+// checkOrigin validates the Origin header against the allowed origins.
+// When no origins are configured, all origins are allowed as the requests
+// are authenticated by token.
+func (h handler) checkOrigin(_ *websocket.Config, r *http.Request) error {
+	if len(h.allowedOrigins) == 0 {
+		return nil
+	}
+
+	origin := r.Header.Get("Origin")
+	for _, o := range h.allowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("origin not allowed: %s", origin)
+}
+
+// streamFrameLogs writes the matching frame-logs of the given target to the
+// connection, until the connection is closed by the client.
+func streamFrameLogs(conn *websocket.Conn, t target, filter framelog.Filter) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the client is not expected to send messages, reading is only used
+	// to detect that the connection has been closed
+	go func() {
+		var msg []byte
+		for {
+			if err := websocket.Message.Receive(conn, &msg); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	frameLogChan := make(chan framelog.FrameLog)
+	go func() {
+		var err error
+		if t.devEUI != nil {
+			err = framelog.GetFrameLogForDevice(ctx, storage.RedisPool(), *t.devEUI, frameLogChan)
+		} else {
+			err = framelog.GetFrameLogForGateway(ctx, storage.RedisPool(), *t.gatewayID, frameLogChan)
+		}
+		if err != nil {
+			log.WithError(err).Error("api/ws: get frame-log error")
+		}
+		close(frameLogChan)
+	}()
+
+	for fl := range frameLogChan {
+		if !filter.Match(fl) {
+			continue
+		}
+
+		b, err := marshalFrameLog(fl, time.Now())
+		if err != nil {
+			log.WithError(err).Error("api/ws: marshal frame-log error")
+			continue
+		}
+
+		if err := websocket.Message.Send(conn, string(b)); err != nil {
+			cancel()
+		}
+	}
+}
+
+// marshalFrameLog returns the JSON message for the given frame-log. The
+// format is the same as the frames of a JSON frame-log capture.
+func marshalFrameLog(fl framelog.FrameLog, ts time.Time) ([]byte, error) {
+	out := struct {
+		Time           time.Time       `json:"time"`
+		UplinkFrameSet json.RawMessage `json:"uplinkFrameSet,omitempty"`
+		DownlinkFrame  json.RawMessage `json:"downlinkFrame,omitempty"`
+	}{
+		Time: ts,
+	}
+
+	m := &jsonpb.Marshaler{}
+
+	if fl.UplinkFrame != nil {
+		s, err := m.MarshalToString(fl.UplinkFrame)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal uplink frame-set error")
+		}
+		out.UplinkFrameSet = json.RawMessage(s)
+	}
+
+	if fl.DownlinkFrame != nil {
+		s, err := m.MarshalToString(fl.DownlinkFrame)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal downlink frame error")
+		}
+		out.DownlinkFrame = json.RawMessage(s)
+	}
+
+	return json.Marshal(out)
+}
+
+// getToken returns the token from the Authorization header or, as browsers
+// can not set headers on WebSocket requests, from the token query parameter.
+func getToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return r.URL.Query().Get("token")
+}
+
+func parseTarget(path string) (target, error) {
+	var t target
+	var id lorawan.EUI64
+
+	switch {
+	case strings.HasPrefix(path, deviceFrameLogPath):
+		if err := id.UnmarshalText([]byte(strings.TrimPrefix(path, deviceFrameLogPath))); err != nil {
+			return t, errors.Wrap(err, "invalid dev_eui")
+		}
+		t.devEUI = &id
+	case strings.HasPrefix(path, gatewayFrameLogPath):
+		if err := id.UnmarshalText([]byte(strings.TrimPrefix(path, gatewayFrameLogPath))); err != nil {
+			return t, errors.Wrap(err, "invalid gateway_id")
+		}
+		t.gatewayID = &id
+	default:
+		return t, errors.New("unknown frame-log path")
+	}
+
+	return t, nil
+}
+
+// parseFilter returns the frame-log filter from the given query parameters.
+// The parameters are the same as the fields of the gRPC FrameLogFilter.
+func parseFilter(q url.Values) (framelog.Filter, error) {
+	var f framelog.Filter
+
+	for _, s := range q["m_type"] {
+		mType, ok := ns.MType_value[strings.ToUpper(s)]
+		if !ok {
+			return f, errors.Errorf("invalid m_type: %s", s)
+		}
+		f.MTypes = append(f.MTypes, lorawan.MType(mType))
+	}
+
+	for _, s := range q["gateway_id"] {
+		var id lorawan.EUI64
+		if err := id.UnmarshalText([]byte(s)); err != nil {
+			return f, errors.Errorf("invalid gateway_id: %s", s)
+		}
+		f.GatewayIDs = append(f.GatewayIDs, id)
+	}
+
+	if s := q.Get("f_port_min"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return f, errors.Errorf("invalid f_port_min: %s", s)
+		}
+		f.FPortMin = uint8(v)
+	}
+
+	if s := q.Get("f_port_max"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 8)
+		if err != nil {
+			return f, errors.Errorf("invalid f_port_max: %s", s)
+		}
+		f.FPortMax = uint8(v)
+	}
+
+	if f.FPortMin > f.FPortMax {
+		return f, errors.Errorf("invalid f_port range: %d - %d", f.FPortMin, f.FPortMax)
+	}
+
+	if s := q.Get("min_rssi"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return f, errors.Errorf("invalid min_rssi: %s", s)
+		}
+		f.MinRSSI = v
+	}
+
+	if s := q.Get("mac_commands_only"); s != "" {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return f, errors.Errorf("invalid mac_commands_only: %s", s)
+		}
+		f.MACCommandsOnly = v
+	}
+
+	return f, nil
+}
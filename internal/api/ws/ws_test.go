@@ -0,0 +1,202 @@
+package ws
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+)
+
+func newToken(secret, header, claims string) string {
+	enc := base64.RawURLEncoding
+	s := enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(s))
+
+	return s + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateToken(t *testing.T) {
+	now := time.Unix(1000, 0)
+	header := `{"alg":"HS256","typ":"JWT"}`
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	gatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+	tests := []struct {
+		Name  string
+		Token string
+		Error error
+	}{
+		{
+			Name:  "valid token",
+			Token: newToken("secret", header, `{"exp":2000,"dev_eui":"0102030405060708"}`),
+		},
+		{
+			Name:  "invalid signature",
+			Token: newToken("other", header, `{"exp":2000,"dev_eui":"0102030405060708"}`),
+			Error: ErrInvalidToken,
+		},
+		{
+			Name:  "invalid algorithm",
+			Token: newToken("secret", `{"alg":"none"}`, `{"exp":2000,"dev_eui":"0102030405060708"}`),
+			Error: ErrInvalidToken,
+		},
+		{
+			Name:  "expired",
+			Token: newToken("secret", header, `{"exp":1000,"dev_eui":"0102030405060708"}`),
+			Error: ErrTokenExpired,
+		},
+		{
+			Name:  "no expiration",
+			Token: newToken("secret", header, `{"dev_eui":"0102030405060708"}`),
+			Error: ErrTokenExpired,
+		},
+		{
+			Name:  "not yet valid",
+			Token: newToken("secret", header, `{"exp":2000,"nbf":1500,"dev_eui":"0102030405060708"}`),
+			Error: ErrInvalidToken,
+		},
+		{
+			Name:  "malformed",
+			Token: "foo.bar",
+			Error: ErrInvalidToken,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			claims, err := validateToken([]byte("secret"), tst.Token, now)
+			assert.Equal(tst.Error, err)
+			if err != nil {
+				return
+			}
+
+			assert.NoError(claims.authorize(target{devEUI: &devEUI}))
+			assert.Equal(ErrTokenScope, claims.authorize(target{devEUI: &gatewayID}))
+			assert.Equal(ErrTokenScope, claims.authorize(target{gatewayID: &devEUI}))
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Query  string
+		Filter framelog.Filter
+		Error  bool
+	}{
+		{
+			Name: "empty",
+		},
+		{
+			Name:  "all parameters",
+			Query: "m_type=CONFIRMED_DATA_UP&m_type=join_request&gateway_id=0102030405060708&f_port_min=1&f_port_max=10&min_rssi=-100&mac_commands_only=true",
+			Filter: framelog.Filter{
+				MTypes:          []lorawan.MType{lorawan.ConfirmedDataUp, lorawan.JoinRequest},
+				GatewayIDs:      []lorawan.EUI64{{1, 2, 3, 4, 5, 6, 7, 8}},
+				FPortMin:        1,
+				FPortMax:        10,
+				MinRSSI:         -100,
+				MACCommandsOnly: true,
+			},
+		},
+		{
+			Name:  "invalid m_type",
+			Query: "m_type=FOO",
+			Error: true,
+		},
+		{
+			Name:  "invalid f_port range",
+			Query: "f_port_min=10&f_port_max=1",
+			Error: true,
+		},
+		{
+			Name:  "invalid f_port",
+			Query: "f_port_max=256",
+			Error: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			q, err := url.ParseQuery(tst.Query)
+			assert.NoError(err)
+
+			f, err := parseFilter(q)
+			if tst.Error {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Filter, f)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := handler{jwtSecret: []byte("secret")}
+	header := `{"alg":"HS256"}`
+
+	tests := []struct {
+		Name          string
+		Path          string
+		Authorization string
+		StatusCode    int
+	}{
+		{
+			Name:       "unknown path",
+			Path:       "/frame-logs/device/foo",
+			StatusCode: http.StatusNotFound,
+		},
+		{
+			Name:       "invalid filter",
+			Path:       "/frame-logs/device/0102030405060708?m_type=FOO",
+			StatusCode: http.StatusBadRequest,
+		},
+		{
+			Name:       "no token",
+			Path:       "/frame-logs/device/0102030405060708",
+			StatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:       "token for other device",
+			Path:       "/frame-logs/device/0102030405060708?token=" + newToken("secret", header, `{"exp":9999999999,"dev_eui":"0807060504030201"}`),
+			StatusCode: http.StatusForbidden,
+		},
+		{
+			Name:          "bearer token for gateway used for device",
+			Path:          "/frame-logs/device/0102030405060708",
+			Authorization: "Bearer " + newToken("secret", header, `{"exp":9999999999,"gateway_id":"0102030405060708"}`),
+			StatusCode:    http.StatusForbidden,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			r := httptest.NewRequest("GET", tst.Path, nil)
+			if tst.Authorization != "" {
+				r.Header.Set("Authorization", tst.Authorization)
+			}
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+			assert.Equal(tst.StatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package attestation implements the uplink attestation. When configured,
+// every uplink handled by the network-server is signed using the
+// network-server identity key. The signature is included in the
+// application-server delivery and in the accounting record, so that the
+// receivers can verify that the uplink has been handled by this
+// network-server.
+package attestation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+)
+
+// Available signature algorithms.
+const (
+	AlgorithmHMACSHA256 = "hmac_sha256"
+	AlgorithmEd25519    = "ed25519"
+)
+
+var (
+	mux        sync.RWMutex
+	enabled    bool
+	algorithm  as.UplinkAttestationAlgorithm
+	keyID      string
+	hmacKey    []byte
+	privateKey ed25519.PrivateKey
+)
+
+// Setup configures the attestation package.
+func Setup(conf config.Config) error {
+	c := conf.NetworkServer.UplinkAttestation
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	enabled = false
+	hmacKey = nil
+	privateKey = nil
+	keyID = c.KeyID
+
+	if c.Algorithm == "" {
+		return nil
+	}
+
+	key, err := hex.DecodeString(c.Key)
+	if err != nil {
+		return errors.Wrap(err, "attestation: decode key error")
+	}
+
+	switch c.Algorithm {
+	case AlgorithmHMACSHA256:
+		if len(key) == 0 {
+			return errors.New("attestation: key must be set")
+		}
+		algorithm = as.UplinkAttestationAlgorithm_HMAC_SHA256
+		hmacKey = key
+	case AlgorithmEd25519:
+		switch len(key) {
+		case ed25519.SeedSize:
+			privateKey = ed25519.NewKeyFromSeed(key)
+		case ed25519.PrivateKeySize:
+			privateKey = ed25519.PrivateKey(key)
+		default:
+			return fmt.Errorf("attestation: ed25519 key must be %d (seed) or %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize)
+		}
+		algorithm = as.UplinkAttestationAlgorithm_ED25519
+
+		log.WithFields(log.Fields{
+			"key_id":     keyID,
+			"public_key": hex.EncodeToString(privateKey.Public().(ed25519.PublicKey)),
+		}).Info("attestation: ed25519 public key")
+	default:
+		return fmt.Errorf("attestation: unknown algorithm: %s", c.Algorithm)
+	}
+
+	enabled = true
+
+	log.WithFields(log.Fields{
+		"algorithm": c.Algorithm,
+		"key_id":    keyID,
+	}).Info("attestation: uplink attestation enabled")
+
+	return nil
+}
+
+// Enabled returns true when uplink attestation is enabled.
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return enabled
+}
+
+// SignUplink returns the attestation of the given uplink, handled by the
+// network-server at the given time. It returns nil when attestation is
+// disabled.
+func SignUplink(devEUI lorawan.EUI64, fCnt uint32, rxPacket models.RXPacket, t time.Time) (*as.UplinkAttestation, error) {
+	if !Enabled() {
+		return nil, nil
+	}
+
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	nsTime, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "timestamp proto error")
+	}
+
+	b, err := proto.Marshal(&as.UplinkAttestationPayload{
+		DevEui:     devEUI[:],
+		FCnt:       fCnt,
+		PhyPayload: phyB,
+		TxInfo:     rxPacket.TXInfo,
+		RxInfo:     rxPacket.RXInfoSet,
+		NsTime:     nsTime,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal attestation payload error")
+	}
+
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return &as.UplinkAttestation{
+		Payload:   b,
+		Algorithm: algorithm,
+		KeyId:     keyID,
+		Signature: sign(b),
+	}, nil
+}
+
+// Verify returns true when the signature of the given attestation is valid
+// for the configured key.
+func Verify(att *as.UplinkAttestation) bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	if !enabled || att == nil || att.Algorithm != algorithm {
+		return false
+	}
+
+	switch algorithm {
+	case as.UplinkAttestationAlgorithm_HMAC_SHA256:
+		return hmac.Equal(sign(att.Payload), att.Signature)
+	case as.UplinkAttestationAlgorithm_ED25519:
+		return ed25519.Verify(privateKey.Public().(ed25519.PublicKey), att.Payload, att.Signature)
+	default:
+		return false
+	}
+}
+
+// sign returns the signature of the given bytes. The caller must hold the
+// read-lock.
+func sign(b []byte) []byte {
+	switch algorithm {
+	case as.UplinkAttestationAlgorithm_ED25519:
+		return ed25519.Sign(privateKey, b)
+	default:
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write(b)
+		return mac.Sum(nil)
+	}
+}
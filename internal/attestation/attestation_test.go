@@ -0,0 +1,127 @@
+package attestation
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+)
+
+func TestSignUplink(t *testing.T) {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	now := time.Now()
+	rxPacket := models.RXPacket{
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:    10,
+				},
+			},
+		},
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -60},
+		},
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(Setup(config.Config{}))
+		assert.False(Enabled())
+
+		att, err := SignUplink(devEUI, 10, rxPacket, now)
+		assert.NoError(err)
+		assert.Nil(att)
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		assert := require.New(t)
+
+		var conf config.Config
+		conf.NetworkServer.UplinkAttestation.Algorithm = AlgorithmEd25519
+		conf.NetworkServer.UplinkAttestation.Key = "0102"
+		assert.Error(Setup(conf))
+		assert.False(Enabled())
+	})
+
+	t.Run("HMAC-SHA256", func(t *testing.T) {
+		assert := require.New(t)
+
+		var conf config.Config
+		conf.NetworkServer.UplinkAttestation.Algorithm = AlgorithmHMACSHA256
+		conf.NetworkServer.UplinkAttestation.Key = "01020304"
+		conf.NetworkServer.UplinkAttestation.KeyID = "ns-1"
+		assert.NoError(Setup(conf))
+		assert.True(Enabled())
+
+		att, err := SignUplink(devEUI, 10, rxPacket, now)
+		assert.NoError(err)
+		assert.Equal(as.UplinkAttestationAlgorithm_HMAC_SHA256, att.Algorithm)
+		assert.Equal("ns-1", att.KeyId)
+
+		mac := hmac.New(sha256.New, []byte{1, 2, 3, 4})
+		mac.Write(att.Payload)
+		assert.Equal(mac.Sum(nil), att.Signature)
+		assert.True(Verify(att))
+
+		var pl as.UplinkAttestationPayload
+		assert.NoError(proto.Unmarshal(att.Payload, &pl))
+		assert.Equal(devEUI[:], pl.DevEui)
+		assert.EqualValues(10, pl.FCnt)
+		assert.True(proto.Equal(rxPacket.TXInfo, pl.TxInfo))
+		assert.Len(pl.RxInfo, 1)
+		assert.True(proto.Equal(rxPacket.RXInfoSet[0], pl.RxInfo[0]))
+
+		phyB, err := rxPacket.PHYPayload.MarshalBinary()
+		assert.NoError(err)
+		assert.Equal(phyB, pl.PhyPayload)
+
+		nsTime, err := ptypes.Timestamp(pl.NsTime)
+		assert.NoError(err)
+		assert.True(nsTime.Equal(now))
+
+		// tampered payload
+		att.Payload[0]++
+		assert.False(Verify(att))
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		assert := require.New(t)
+
+		seed := make([]byte, ed25519.SeedSize)
+		seed[0] = 1
+
+		var conf config.Config
+		conf.NetworkServer.UplinkAttestation.Algorithm = AlgorithmEd25519
+		conf.NetworkServer.UplinkAttestation.Key = hex.EncodeToString(seed)
+		assert.NoError(Setup(conf))
+
+		att, err := SignUplink(devEUI, 10, rxPacket, now)
+		assert.NoError(err)
+		assert.Equal(as.UplinkAttestationAlgorithm_ED25519, att.Algorithm)
+
+		pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+		assert.True(ed25519.Verify(pub, att.Payload, att.Signature))
+		assert.True(Verify(att))
+	})
+}
@@ -1,6 +1,9 @@
 package applicationserver
 
-import "github.com/mxc-foundation/lpwan-server/internal/api/client/asclient"
+import (
+	"github.com/mxc-foundation/lpwan-server/internal/api/client/asclient"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
 
 var pool asclient.Pool
 
@@ -15,7 +18,18 @@ func Pool() asclient.Pool {
 }
 
 // Setup sets up the application-server pool.
-func Setup() error {
-	pool = asclient.NewPool()
+func Setup(conf config.Config) error {
+	pool = asclient.NewPoolWithOptions(asclient.PoolOptions{
+		ConnectionsPerServer: conf.ApplicationServer.ConnectionsPerServer,
+		IdleTimeout:          conf.ApplicationServer.IdleTimeout,
+		BatchEnabled:         conf.ApplicationServer.Batch.Enabled,
+		BatchMaxSize:         conf.ApplicationServer.Batch.MaxSize,
+		BatchInterval:        conf.ApplicationServer.Batch.Interval,
+	})
+
+	if hc := conf.ApplicationServer.HealthCheck; hc.Enabled {
+		asclient.StartHealthCheck(hc.Interval, hc.Timeout)
+	}
+
 	return nil
 }
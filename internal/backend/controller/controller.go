@@ -13,6 +13,7 @@ func init() {
 
 // SetClient sets up the given controller client.
 func SetClient(c nc.NetworkControllerServiceClient) {
+	resetStream()
 	client = c
 }
 
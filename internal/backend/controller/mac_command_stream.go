@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/api/nc"
+)
+
+// ErrStreamClosed is returned when the mac-command stream was closed before
+// the network-controller responded.
+var ErrStreamClosed = errors.New("mac-command stream closed")
+
+var macStream macCommandStream
+
+// macCommandStream multiplexes the downlink mac-command requests over a
+// single bi-directional stream. Responses are matched to the requests by
+// their ID.
+type macCommandStream struct {
+	sync.Mutex
+
+	stream  nc.NetworkControllerService_HandleDownlinkMACCommandsClient
+	cancel  context.CancelFunc
+	nextID  uint64
+	pending map[uint64]chan *nc.HandleDownlinkMACCommandsResponse
+}
+
+// HandleDownlinkMACCommands sends the given request to the network-controller
+// and waits for its response. The stream is (re)opened when needed. The
+// given context must be used to set the max. time to wait for the response.
+func HandleDownlinkMACCommands(ctx context.Context, req nc.HandleDownlinkMACCommandsRequest) (*nc.HandleDownlinkMACCommandsResponse, error) {
+	return macStream.handle(ctx, req)
+}
+
+// resetStream closes the current mac-command stream (if any). It is called
+// when a new client is set.
+func resetStream() {
+	macStream.Lock()
+	defer macStream.Unlock()
+	macStream.closeLocked()
+}
+
+func (s *macCommandStream) handle(ctx context.Context, req nc.HandleDownlinkMACCommandsRequest) (*nc.HandleDownlinkMACCommandsResponse, error) {
+	respChan := make(chan *nc.HandleDownlinkMACCommandsResponse, 1)
+
+	s.Lock()
+	if s.stream == nil {
+		if err := s.openLocked(); err != nil {
+			s.Unlock()
+			return nil, errors.Wrap(err, "open mac-command stream error")
+		}
+	}
+
+	s.nextID++
+	req.Id = s.nextID
+	s.pending[req.Id] = respChan
+
+	if err := s.stream.Send(&req); err != nil {
+		s.closeLocked()
+		s.Unlock()
+		return nil, errors.Wrap(err, "send mac-command request error")
+	}
+	s.Unlock()
+
+	select {
+	case resp, ok := <-respChan:
+		if !ok {
+			return nil, ErrStreamClosed
+		}
+		return resp, nil
+	case <-ctx.Done():
+		s.Lock()
+		delete(s.pending, req.Id)
+		s.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (s *macCommandStream) openLocked() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := Client().HandleDownlinkMACCommands(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	s.stream = stream
+	s.cancel = cancel
+	s.pending = make(map[uint64]chan *nc.HandleDownlinkMACCommandsResponse)
+
+	go s.receiveLoop(stream)
+
+	return nil
+}
+
+// closeLocked closes the stream and all pending requests.
+func (s *macCommandStream) closeLocked() {
+	if s.stream == nil {
+		return
+	}
+
+	s.cancel()
+	for id, c := range s.pending {
+		close(c)
+		delete(s.pending, id)
+	}
+
+	s.stream = nil
+	s.cancel = nil
+}
+
+func (s *macCommandStream) receiveLoop(stream nc.NetworkControllerService_HandleDownlinkMACCommandsClient) {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			log.WithError(err).Warning("network-controller mac-command stream closed")
+
+			s.Lock()
+			// make sure we don't close a stream that has already been replaced
+			if s.stream == stream {
+				s.closeLocked()
+			}
+			s.Unlock()
+			return
+		}
+
+		s.Lock()
+		c, ok := s.pending[resp.Id]
+		if ok {
+			delete(s.pending, resp.Id)
+		}
+		s.Unlock()
+
+		if !ok {
+			log.WithField("id", resp.Id).Warning("network-controller responded to unknown or expired mac-command request")
+			continue
+		}
+
+		c <- resp
+	}
+}
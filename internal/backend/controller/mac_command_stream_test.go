@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/nc"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestHandleDownlinkMACCommands(t *testing.T) {
+	// respond with a single block using the max-size as cid, so that the
+	// responses can be matched to the requests. requests without max-size
+	// are left unanswered.
+	ncClient := test.NewNetworkControllerClient()
+	ncClient.HandleDownlinkMACCommandsFunc = func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+		if req.MaxSize == 0 {
+			return nil
+		}
+
+		return &nc.HandleDownlinkMACCommandsResponse{
+			MacCommandBlocks: []*nc.MACCommandBlock{
+				{Cid: req.MaxSize},
+			},
+		}
+	}
+	SetClient(ncClient)
+	defer SetClient(&NopNetworkControllerClient{})
+
+	t.Run("Response", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := HandleDownlinkMACCommands(context.Background(), nc.HandleDownlinkMACCommandsRequest{
+			DevEui:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			MaxSize: 3,
+		})
+		assert.NoError(err)
+		assert.Len(resp.MacCommandBlocks, 1)
+		assert.EqualValues(3, resp.MacCommandBlocks[0].Cid)
+
+		req := <-ncClient.HandleDownlinkMACCommandsChan
+		assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}, req.DevEui)
+		assert.Equal(resp.Id, req.Id)
+	})
+
+	t.Run("Concurrent requests", func(t *testing.T) {
+		assert := require.New(t)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+
+		for i := 1; i <= 10; i++ {
+			wg.Add(1)
+			go func(maxSize uint32) {
+				defer wg.Done()
+
+				resp, err := HandleDownlinkMACCommands(context.Background(), nc.HandleDownlinkMACCommandsRequest{
+					MaxSize: maxSize,
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				if len(resp.MacCommandBlocks) != 1 || resp.MacCommandBlocks[0].Cid != maxSize {
+					errs <- fmt.Errorf("unexpected response for max-size %d: %v", maxSize, resp.MacCommandBlocks)
+				}
+			}(uint32(i))
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(err)
+		}
+
+		for i := 0; i < 10; i++ {
+			<-ncClient.HandleDownlinkMACCommandsChan
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		assert := require.New(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := HandleDownlinkMACCommands(ctx, nc.HandleDownlinkMACCommandsRequest{})
+		assert.Equal(context.DeadlineExceeded, err)
+		<-ncClient.HandleDownlinkMACCommandsChan
+
+		macStream.Lock()
+		assert.Len(macStream.pending, 0)
+		macStream.Unlock()
+	})
+
+	t.Run("Stream closed", func(t *testing.T) {
+		assert := require.New(t)
+
+		go func() {
+			<-ncClient.HandleDownlinkMACCommandsChan
+			resetStream()
+		}()
+
+		_, err := HandleDownlinkMACCommands(context.Background(), nc.HandleDownlinkMACCommandsRequest{})
+		assert.Equal(ErrStreamClosed, err)
+
+		// the stream is re-opened on the next request
+		resp, err := HandleDownlinkMACCommands(context.Background(), nc.HandleDownlinkMACCommandsRequest{
+			MaxSize: 5,
+		})
+		assert.NoError(err)
+		assert.EqualValues(5, resp.MacCommandBlocks[0].Cid)
+		<-ncClient.HandleDownlinkMACCommandsChan
+	})
+
+	t.Run("Unimplemented", func(t *testing.T) {
+		assert := require.New(t)
+
+		SetClient(test.NewNetworkControllerClient())
+
+		_, err := HandleDownlinkMACCommands(context.Background(), nc.HandleDownlinkMACCommandsRequest{})
+		assert.Error(err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mxc-foundation/lpwan-server/api/nc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 // NopNetworkControllerClient is a dummy network-controller client which is
@@ -24,3 +25,9 @@ func (n *NopNetworkControllerClient) HandleUplinkMetaData(ctx context.Context, i
 func (n *NopNetworkControllerClient) HandleUplinkMACCommand(ctx context.Context, in *nc.HandleUplinkMACCommandRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, nil
 }
+
+// HandleDownlinkMACCommands returns an Unimplemented error, as there is no
+// network-controller to intercept the downlink mac-commands.
+func (n *NopNetworkControllerClient) HandleDownlinkMACCommands(ctx context.Context, opts ...grpc.CallOption) (nc.NetworkControllerService_HandleDownlinkMACCommandsClient, error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "no network-controller configured")
+}
@@ -0,0 +1,141 @@
+// Package downlinkhook implements the downlink payload hook, which is
+// invoked (per routing-profile) before a device-queue item is sent to a
+// device. The hook can modify or veto the payload.
+package downlinkhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// maxResponseSize defines the max. size of the hook response body.
+const maxResponseSize = 64 * 1024
+
+var (
+	timeout    = 200 * time.Millisecond
+	failClosed = true
+	httpClient = &http.Client{Timeout: timeout}
+)
+
+// Endpoint defines a downlink hook endpoint.
+type Endpoint struct {
+	// URL of the endpoint.
+	URL string
+
+	// Secret used for signing the request body. When empty, the request will
+	// not be signed.
+	Secret string
+}
+
+// Request contains the device-queue item about to be sent.
+type Request struct {
+	DevEUI         lorawan.EUI64   `json:"devEUI"`
+	DevAddr        lorawan.DevAddr `json:"devAddr"`
+	FCnt           uint32          `json:"fCnt"`
+	FPort          uint8           `json:"fPort"`
+	Confirmed      bool            `json:"confirmed"`
+	FRMPayload     []byte          `json:"frmPayload"`
+	MaxPayloadSize int             `json:"maxPayloadSize"`
+}
+
+// Response contains the hook response. When Veto is set, the item must not
+// be sent. When FRMPayload is not nil, it replaces the payload of the item.
+type Response struct {
+	FRMPayload []byte `json:"frmPayload"`
+	Veto       bool   `json:"veto"`
+	Reason     string `json:"reason"`
+}
+
+// Setup configures the downlinkhook package.
+func Setup(conf config.Config) error {
+	hookConf := conf.NetworkServer.DownlinkHook
+
+	if hookConf.Timeout != 0 {
+		timeout = hookConf.Timeout
+	}
+
+	switch hookConf.FailPolicy {
+	case "", "closed":
+		failClosed = true
+	case "open":
+		failClosed = false
+	default:
+		return fmt.Errorf("invalid downlink hook fail policy: %s", hookConf.FailPolicy)
+	}
+
+	httpClient = &http.Client{Timeout: timeout}
+
+	return nil
+}
+
+// SetHTTPClient sets the HTTP client used for invoking the hook.
+func SetHTTPClient(c *http.Client) {
+	httpClient = c
+}
+
+// FailClosed returns true when the device-queue item must be held back in
+// case the hook could not be invoked. When false, the item is sent
+// unmodified.
+func FailClosed() bool {
+	return failClosed
+}
+
+// Invoke synchronously invokes the hook for the given request. A 2xx
+// response with an empty body leaves the item unmodified. Any other
+// response is returned as an error. As the hook is invoked within the
+// downlink timing budget, the request is not retried.
+func Invoke(ctx context.Context, ep Endpoint, hookReq Request) (Response, error) {
+	var resp Response
+
+	b, err := json.Marshal(hookReq)
+	if err != nil {
+		return resp, errors.Wrap(err, "marshal json error")
+	}
+
+	req, err := http.NewRequest("POST", ep.URL, bytes.NewReader(b))
+	if err != nil {
+		return resp, errors.Wrap(err, "new request error")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if ep.Secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(ep.Secret, b))
+	}
+
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return resp, errors.Wrap(err, "http request error")
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return resp, fmt.Errorf("expected 2xx response, got: %d", httpResp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return resp, errors.Wrap(err, "read response body error")
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return resp, nil
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, errors.Wrap(err, "unmarshal json error")
+	}
+
+	return resp, nil
+}
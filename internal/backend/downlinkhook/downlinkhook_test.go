@@ -0,0 +1,93 @@
+package downlinkhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+)
+
+func TestInvoke(t *testing.T) {
+	hookReq := Request{
+		DevEUI:         lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr:        lorawan.DevAddr{1, 2, 3, 4},
+		FCnt:           10,
+		FPort:          2,
+		FRMPayload:     []byte{1, 2, 3},
+		MaxPayloadSize: 51,
+	}
+	hookReqB, err := json.Marshal(hookReq)
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name         string
+		StatusCode   int
+		ResponseBody string
+		Expected     Response
+		ExpectedErr  bool
+	}{
+		{
+			Name:       "empty response",
+			StatusCode: http.StatusNoContent,
+		},
+		{
+			Name:         "modified payload",
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"frmPayload": "AwIB"}`,
+			Expected:     Response{FRMPayload: []byte{3, 2, 1}},
+		},
+		{
+			Name:         "veto",
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"veto": true, "reason": "policy"}`,
+			Expected:     Response{Veto: true, Reason: "policy"},
+		},
+		{
+			Name:        "error response",
+			StatusCode:  http.StatusInternalServerError,
+			ExpectedErr: true,
+		},
+		{
+			Name:         "invalid response",
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{`,
+			ExpectedErr:  true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var signature string
+			var body []byte
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				signature = r.Header.Get(webhook.SignatureHeader)
+				body, _ = ioutil.ReadAll(r.Body)
+
+				w.WriteHeader(tst.StatusCode)
+				w.Write([]byte(tst.ResponseBody))
+			}))
+			defer server.Close()
+
+			resp, err := Invoke(context.Background(), Endpoint{URL: server.URL, Secret: "secret"}, hookReq)
+			if tst.ExpectedErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+				assert.Equal(tst.Expected, resp)
+			}
+
+			assert.Equal(hookReqB, body)
+			assert.Equal(webhook.Sign("secret", hookReqB), signature)
+		})
+	}
+}
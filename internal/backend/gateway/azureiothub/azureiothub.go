@@ -23,20 +23,21 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
-// Backend implement an Azure IoT Hub backend.
+// Backend implements an Azure IoT Hub backend.
 type Backend struct {
 	sync.RWMutex
 
 	ctx    context.Context
 	cancel context.CancelFunc
-	closed bool
+	wg     sync.WaitGroup
 
 	uplinkFrameChan   chan gw.UplinkFrame
 	gatewayStatsChan  chan gw.GatewayStats
 	downlinkTxAckChan chan gw.DownlinkTXAck
-	gatewayMarshaler  map[lorawan.EUI64]marshaler.Type
+	gatewayMarshalers *marshaler.GatewayMarshalers
 
 	queueName string
 	ns        *servicebus.Namespace
@@ -61,7 +62,7 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 		uplinkFrameChan:   make(chan gw.UplinkFrame),
 		gatewayStatsChan:  make(chan gw.GatewayStats),
 		downlinkTxAckChan: make(chan gw.DownlinkTXAck),
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(storage.RedisPool()),
 
 		ctx: context.Background(),
 
@@ -94,18 +95,23 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 		return nil, errors.Wrap(err, "new queue client error")
 	}
 
+	b.wg.Add(1)
 	go func() {
+		defer b.wg.Done()
+
 		log.WithField("queue", b.queueName).Info("gateway/azure_iot_hub: starting queue consumer")
 		for {
-			if b.closed {
+			err := b.queue.Receive(b.ctx, servicebus.HandlerFunc(b.eventHandler))
+
+			// the context is cancelled when the backend is closed
+			if b.ctx.Err() != nil {
 				break
 			}
 
-			if err := b.queue.Receive(b.ctx, servicebus.HandlerFunc(b.eventHandler)); err != nil {
+			if err != nil {
 				log.WithError(err).Error("gateway/azure_iot_hub: receive from queue error")
 				time.Sleep(time.Second * 2)
 			}
-
 		}
 	}()
 
@@ -141,6 +147,7 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 	return &b, nil
 }
 
+// SendTXPacket sends the given downlink frame to the gateway.
 func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
 	if pl.TxInfo == nil {
 		return errors.New("tx_info must not be nil")
@@ -160,6 +167,7 @@ func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
 	}, gatewayID, "down", bb)
 }
 
+// SendGatewayConfigPacket sends the given gateway configuration to the gateway.
 func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
 	gatewayID := helpers.GetGatewayID(&pl)
 	t := b.getGatewayMarshaler(gatewayID)
@@ -172,21 +180,28 @@ func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
 	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
 }
 
+// RXPacketChan returns the channel to which uplink frames are published.
 func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
 	return b.uplinkFrameChan
 }
 
+// StatsPacketChan returns the channel to which gateway stats are published.
 func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
 	return b.gatewayStatsChan
 }
 
+// DownlinkTXAckChan returns the downlink tx ack channel.
 func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTxAckChan
 }
 
+// Close closes the backend.
 func (b *Backend) Close() error {
 	log.Info("gateway/azure_iot_hub: closing backend")
 	b.cancel()
+
+	log.Info("gateway/azure_iot_hub: handling last messages")
+	b.wg.Wait()
 	close(b.uplinkFrameChan)
 	close(b.gatewayStatsChan)
 	close(b.downlinkTxAckChan)
@@ -195,17 +210,11 @@ func (b *Backend) Close() error {
 }
 
 func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
-	b.Lock()
-	defer b.Unlock()
-
-	b.gatewayMarshaler[gatewayID] = t
+	b.gatewayMarshalers.Set(gatewayID, t)
 }
 
 func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
-	b.RLock()
-	defer b.RUnlock()
-
-	return b.gatewayMarshaler[gatewayID]
+	return b.gatewayMarshalers.Get(gatewayID)
 }
 
 func (b *Backend) eventHandler(ctx context.Context, msg *servicebus.Message) error {
@@ -293,7 +302,7 @@ func (b *Backend) handleUplinkFrame(gatewayID lorawan.EUI64, data []byte) error
 	}
 
 	// make sure that the registered gateway is not using a different gateway_id
-	// than the ID used during the registration in Cloud IoT Core.
+	// than the ID used during the registration in IoT Hub.
 	if !bytes.Equal(uplinkFrame.RxInfo.GatewayId, gatewayID[:]) {
 		return errors.New("gateway_id is not equal to expected gateway_id")
 	}
@@ -320,7 +329,7 @@ func (b *Backend) handleGatewayStats(gatewayID lorawan.EUI64, data []byte) error
 	b.setGatewayMarshaler(gatewayID, t)
 
 	// make sure that the registered gateway is not using a different gateway_id
-	// than the ID used during the registration in Cloud IoT Core.
+	// than the ID used during the registration in IoT Hub.
 	if !bytes.Equal(gatewayStats.GatewayId, gatewayID[:]) {
 		return errors.New("gateway_id is not equal to expected gateway_id")
 	}
@@ -347,7 +356,7 @@ func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) erro
 	b.setGatewayMarshaler(gatewayID, t)
 
 	// make sure that the registered gateway is not using a different gateway_id
-	// than the ID used during the registration in Cloud IoT Core.
+	// than the ID used during the registration in IoT Hub.
 	if !bytes.Equal(ack.GatewayId, gatewayID[:]) {
 		return errors.New("gateway_id is not equal to expected gateway_id")
 	}
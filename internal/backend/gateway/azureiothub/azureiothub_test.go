@@ -0,0 +1,131 @@
+package azureiothub
+
+import (
+	"testing"
+
+	servicebus "github.com/Azure/azure-service-bus-go"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+	backend *Backend
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	ts.backend = &Backend{
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(nil),
+		uplinkFrameChan:   make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:  make(chan gw.GatewayStats, 10),
+		downlinkTxAckChan: make(chan gw.DownlinkTXAck, 10),
+	}
+}
+
+func (ts *BackendTestSuite) TestHandleEventMessage() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo:     &gw.UplinkTXInfo{},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}
+	upB, err := proto.Marshal(&uplinkFrame)
+	assert.NoError(err)
+
+	stats := gw.GatewayStats{
+		GatewayId:         gatewayID[:],
+		RxPacketsReceived: 10,
+	}
+	statsB, err := proto.Marshal(&stats)
+	assert.NoError(err)
+
+	ack := gw.DownlinkTXAck{
+		GatewayId: gatewayID[:],
+		Token:     12345,
+	}
+	ackB, err := proto.Marshal(&ack)
+	assert.NoError(err)
+
+	ts.T().Run("Uplink", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.handleEventMessage(&servicebus.Message{
+			Data: upB,
+			UserProperties: map[string]interface{}{
+				"iothub-connection-device-id": gatewayID.String(),
+				"up":                          "",
+			},
+		}))
+
+		rec := <-ts.backend.RXPacketChan()
+		assert.Equal(uplinkFrame.PhyPayload, rec.PhyPayload)
+		assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
+	})
+
+	ts.T().Run("Stats", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.handleEventMessage(&servicebus.Message{
+			Data: statsB,
+			UserProperties: map[string]interface{}{
+				"iothub-connection-device-id": gatewayID.String(),
+				"stats":                       "",
+			},
+		}))
+
+		rec := <-ts.backend.StatsPacketChan()
+		assert.EqualValues(10, rec.RxPacketsReceived)
+	})
+
+	ts.T().Run("Ack", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.handleEventMessage(&servicebus.Message{
+			Data: ackB,
+			UserProperties: map[string]interface{}{
+				"iothub-connection-device-id": gatewayID.String(),
+				"ack":                         "",
+			},
+		}))
+
+		rec := <-ts.backend.DownlinkTXAckChan()
+		assert.EqualValues(12345, rec.Token)
+	})
+
+	ts.T().Run("Gateway ID mismatch", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.handleEventMessage(&servicebus.Message{
+			Data: upB,
+			UserProperties: map[string]interface{}{
+				"iothub-connection-device-id": "0807060504030201",
+				"up":                          "",
+			},
+		}))
+		assert.Len(ts.backend.RXPacketChan(), 0)
+	})
+
+	ts.T().Run("Device ID missing", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Error(ts.backend.handleEventMessage(&servicebus.Message{
+			Data: upB,
+			UserProperties: map[string]interface{}{
+				"up": "",
+			},
+		}))
+	})
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
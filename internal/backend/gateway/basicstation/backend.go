@@ -0,0 +1,729 @@
+// Package basicstation implements a gateway backend speaking the Semtech
+// Basic Station LNS protocol (over WebSocket), so that gateways running
+// Basic Station can connect to LoRa Server without a LoRa Gateway Bridge.
+package basicstation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const (
+	routerInfoPath = "/router-info"
+	gatewayPath    = "/gateway/"
+
+	writeTimeout = 10 * time.Second
+)
+
+// getGatewayRegion returns the region of the given gateway. It is a variable
+// so that it can be overridden in the tests.
+var getGatewayRegion = func(gatewayID lorawan.EUI64) string {
+	return storage.GetGatewayRegion(context.Background(), storage.DB(), gatewayID)
+}
+
+// connection holds the WebSocket connection of a gateway.
+type connection struct {
+	sync.Mutex
+
+	conn    *websocket.Conn
+	ip      string
+	region  string
+	version version
+
+	// downlinkIDs contains the downlink IDs of the pending downlinks (by
+	// diid), for setting the downlink ID of the tx acknowledgement.
+	downlinkIDs map[int64][]byte
+
+	rxPacketsReceived   uint32
+	rxPacketsReceivedOK uint32
+	txPacketsReceived   uint32
+	txPacketsEmitted    uint32
+}
+
+// Backend implements a Basic Station backend.
+type Backend struct {
+	sync.RWMutex
+
+	server   *http.Server
+	ln       net.Listener
+	scheme   string
+	closed   bool
+	gateways map[lorawan.EUI64]*connection
+
+	// regions contains the band and router configuration by region, the
+	// default region is stored under the empty key.
+	regions       map[string]regionBand
+	statsInterval time.Duration
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+}
+
+// regionBand holds the band and the router configuration of a region.
+type regionBand struct {
+	band         loraband.Band
+	routerConfig routerConfig
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.BasicStation
+
+	b := Backend{
+		scheme:        "ws",
+		gateways:      make(map[lorawan.EUI64]*connection),
+		statsInterval: conf.StatsInterval,
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+	}
+
+	if err := b.setupRegions(c); err != nil {
+		return nil, err
+	}
+
+	b.server = &http.Server{
+		Handler: b.handler(),
+		Addr:    conf.Bind,
+	}
+
+	var err error
+	b.ln, err = handover.Listen("gateway_basic_station", conf.Bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "start basic station listener error")
+	}
+
+	if conf.TLSCert != "" || conf.TLSKey != "" {
+		cert, err := nstls.LoadX509KeyPair(conf.TLSCert, conf.TLSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load x509 keypair error")
+		}
+
+		tlsConfig := tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+
+		if conf.CACert != "" {
+			rawCACert, err := nstls.ReadPEM(conf.CACert)
+			if err != nil {
+				return nil, errors.Wrap(err, "load ca certificate error")
+			}
+
+			tlsConfig.ClientCAs = x509.NewCertPool()
+			if !tlsConfig.ClientCAs.AppendCertsFromPEM(rawCACert) {
+				return nil, errors.New("append ca certificate error")
+			}
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+
+		b.scheme = "wss"
+		b.ln = tls.NewListener(b.ln, &tlsConfig)
+	}
+
+	log.WithFields(log.Fields{
+		"bind":   conf.Bind,
+		"region": b.regions[""].routerConfig.Region,
+		"tls":    b.scheme == "wss",
+	}).Info("gateway/basic_station: starting basic station websocket server")
+
+	go func() {
+		err := b.server.Serve(b.ln)
+		if err != http.ErrServerClosed {
+			log.WithError(err).Error("gateway/basic_station: websocket server error")
+		}
+	}()
+
+	return &b, nil
+}
+
+// setupRegions sets up the band and router configuration of the default
+// region, the configured regions and their channel-groups. The Basic Station
+// region and frequency range overrides only apply to the default region.
+func (b *Backend) setupRegions(c config.Config) error {
+	conf := c.NetworkServer.Gateway.Backend.BasicStation
+
+	rc, err := getRegionConfig(c.NetworkServer.Band.Name)
+	if err != nil {
+		return err
+	}
+	if conf.Region != "" {
+		rc.region = conf.Region
+	}
+	if conf.FrequencyMin != 0 {
+		rc.minFrequency = conf.FrequencyMin
+	}
+	if conf.FrequencyMax != 0 {
+		rc.maxFrequency = conf.FrequencyMax
+	}
+
+	b.regions = make(map[string]regionBand)
+	if err := b.setupRegion("", rc); err != nil {
+		return err
+	}
+	for _, g := range c.NetworkServer.NetworkSettings.ChannelGroups {
+		if err := b.setupRegion(band.RegionWithChannelGroup("", g.Name), rc); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range c.NetworkServer.Regions {
+		rc, err := getRegionConfig(r.Band)
+		if err != nil {
+			return errors.Wrapf(err, "region %s", r.Name)
+		}
+
+		if err := b.setupRegion(r.Name, rc); err != nil {
+			return err
+		}
+		for _, g := range r.ChannelGroups {
+			if err := b.setupRegion(band.RegionWithChannelGroup(r.Name, g.Name), rc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *Backend) setupRegion(region string, rc regionConfig) error {
+	rb := regionBand{
+		band: band.Get(region),
+	}
+
+	var err error
+	rb.routerConfig, err = getRouterConfig(rb.band, rc)
+	if err != nil {
+		return errors.Wrap(err, "get router config error")
+	}
+
+	b.regions[region] = rb
+	return nil
+}
+
+// getRegionBand returns the band and router configuration of the given
+// region, it falls back to the default region when the region is unknown.
+func (b *Backend) getRegionBand(region string) regionBand {
+	if rb, ok := b.regions[region]; ok {
+		return rb
+	}
+	return b.regions[""]
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	c, ok := b.getConnection(gatewayID)
+	if !ok {
+		return fmt.Errorf("gateway %s is not connected", gatewayID)
+	}
+
+	dnmsg, err := getDownlinkFrame(b.getRegionBand(c.region).band, pl)
+	if err != nil {
+		return errors.Wrap(err, "get downlink message error")
+	}
+
+	c.Lock()
+	c.downlinkIDs[dnmsg.DIID] = pl.DownlinkId
+	c.txPacketsReceived++
+	c.Unlock()
+
+	if err := c.send(dnmsg); err != nil {
+		return errors.Wrap(err, "send downlink message error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/basic_station: downlink message sent to gateway")
+
+	basicStationCommandCounter(string(downlinkMessage)).Inc()
+
+	return nil
+}
+
+// SendGatewayConfigPacket is not supported by Basic Station. The channel
+// configuration is sent as router configuration when the gateway connects.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	log.WithField("gateway_id", helpers.GetGatewayID(&pl)).Debug("gateway/basic_station: gateway configuration is not supported, ignoring")
+	return nil
+}
+
+// RXPacketChan returns the uplink-frame channel.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx acknowledgement channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	log.Info("gateway/basic_station: closing backend")
+
+	b.Lock()
+	b.closed = true
+	for _, c := range b.gateways {
+		c.conn.Close()
+	}
+	b.Unlock()
+
+	if err := b.server.Close(); err != nil {
+		return errors.Wrap(err, "close websocket server error")
+	}
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+
+	return nil
+}
+
+func (b *Backend) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(routerInfoPath, b.handleRouterInfo)
+	mux.HandleFunc(gatewayPath, b.handleGateway)
+	return mux
+}
+
+// handleRouterInfo handles the discovery request of the station, returning
+// the URI to which the station must connect.
+func (b *Backend) handleRouterInfo(w http.ResponseWriter, r *http.Request) {
+	websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			var req routerInfoRequest
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				log.WithError(err).Error("gateway/basic_station: receive router-info request error")
+				return
+			}
+
+			var resp routerInfoResponse
+
+			gatewayID, err := parseRouter(req.Router)
+			if err != nil {
+				resp.Router = string(req.Router)
+				resp.Error = err.Error()
+			} else {
+				resp.Router = formatEUI(gatewayID)
+				resp.Muxs = formatEUI(lorawan.EUI64{})
+				resp.URI = fmt.Sprintf("%s://%s%s%s", b.scheme, r.Host, gatewayPath, gatewayID)
+			}
+
+			if err := websocket.JSON.Send(conn, resp); err != nil {
+				log.WithError(err).Error("gateway/basic_station: send router-info response error")
+			}
+
+			basicStationEventCounter("router_info").Inc()
+		},
+	}.ServeHTTP(w, r)
+}
+
+// handleGateway handles the data connection of the station.
+func (b *Backend) handleGateway(w http.ResponseWriter, r *http.Request) {
+	gatewayID, err := parseEUI(strings.TrimPrefix(r.URL.Path, gatewayPath))
+	if err != nil {
+		http.Error(w, "invalid gateway id", http.StatusNotFound)
+		return
+	}
+
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+
+	// make sure that the gateway is not using a different gateway id than
+	// the id of its client-certificate
+	var authenticated bool
+	if r.TLS != nil && len(r.TLS.PeerCertificates) != 0 {
+		cn := r.TLS.PeerCertificates[0].Subject.CommonName
+		certID, err := parseEUI(cn)
+		if err != nil || certID != gatewayID {
+			log.WithFields(log.Fields{
+				"gateway_id": gatewayID,
+				"cn":         cn,
+				"ip":         ip,
+			}).Warning("gateway/basic_station: gateway id does not match client-certificate")
+			http.Error(w, "gateway id does not match client-certificate", http.StatusForbidden)
+			return
+		}
+		authenticated = true
+	}
+
+	websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			b.handleConnection(gatewayID, ip, authenticated, conn)
+		},
+	}.ServeHTTP(w, r)
+}
+
+func (b *Backend) handleConnection(gatewayID lorawan.EUI64, ip string, authenticated bool, conn *websocket.Conn) {
+	c := &connection{
+		conn:        conn,
+		ip:          ip,
+		region:      getGatewayRegion(gatewayID),
+		downlinkIDs: make(map[int64][]byte),
+	}
+
+	if !b.setConnection(gatewayID, c) {
+		conn.Close()
+		return
+	}
+	defer b.deleteConnection(gatewayID, c)
+
+	log.WithFields(log.Fields{
+		"gateway_id":    gatewayID,
+		"ip":            ip,
+		"region":        c.region,
+		"authenticated": authenticated,
+	}).Info("gateway/basic_station: gateway connected")
+
+	done := make(chan struct{})
+	defer close(done)
+	go b.statsLoop(gatewayID, c, done)
+
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			log.WithError(err).WithField("gateway_id", gatewayID).Info("gateway/basic_station: gateway disconnected")
+			return
+		}
+
+		if err := b.handleMessage(gatewayID, c, msg); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": gatewayID,
+				"message":    string(msg),
+			}).Error("gateway/basic_station: handle message error")
+		}
+	}
+}
+
+func (b *Backend) handleMessage(gatewayID lorawan.EUI64, c *connection, msg []byte) error {
+	var mt messageTypeMessage
+	if err := json.Unmarshal(msg, &mt); err != nil {
+		return errors.Wrap(err, "unmarshal message-type error")
+	}
+
+	basicStationEventCounter(string(mt.MessageType)).Inc()
+
+	switch mt.MessageType {
+	case versionMessage:
+		return b.handleVersion(gatewayID, c, msg)
+	case uplinkDataFrameMessage:
+		var pl uplinkDataFrame
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal uplink data frame error")
+		}
+		phy, err := pl.phyPayload()
+		if err != nil {
+			return errors.Wrap(err, "get phypayload error")
+		}
+		return b.handleUplinkFrame(gatewayID, c, phy, pl.radioMetaData)
+	case joinRequestMessage:
+		var pl joinRequest
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal join-request error")
+		}
+		phy, err := pl.phyPayload()
+		if err != nil {
+			return errors.Wrap(err, "get phypayload error")
+		}
+		return b.handleUplinkFrame(gatewayID, c, phy, pl.radioMetaData)
+	case proprietaryDataFrameMessage:
+		var pl proprietaryDataFrame
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal proprietary data frame error")
+		}
+		phy, err := hex.DecodeString(pl.FRMPayload)
+		if err != nil {
+			return errors.Wrap(err, "decode FRMPayload error")
+		}
+		return b.handleUplinkFrame(gatewayID, c, phy, pl.radioMetaData)
+	case downlinkTransmittedMessage:
+		var pl downlinkTransmitted
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal downlink transmitted error")
+		}
+		return b.handleDownlinkTransmitted(gatewayID, c, pl)
+	case timeSyncMessage:
+		var pl timeSync
+		if err := json.Unmarshal(msg, &pl); err != nil {
+			return errors.Wrap(err, "unmarshal timesync error")
+		}
+		return c.send(getTimeSyncResponse(pl, time.Now()))
+	default:
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"msgtype":    mt.MessageType,
+		}).Debug("gateway/basic_station: unexpected message-type received, ignoring")
+	}
+
+	return nil
+}
+
+func (b *Backend) handleVersion(gatewayID lorawan.EUI64, c *connection, msg []byte) error {
+	var v version
+	if err := json.Unmarshal(msg, &v); err != nil {
+		return errors.Wrap(err, "unmarshal version error")
+	}
+
+	c.Lock()
+	c.version = v
+	c.Unlock()
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"station":    v.Station,
+		"firmware":   v.Firmware,
+		"model":      v.Model,
+		"protocol":   v.Protocol,
+	}).Info("gateway/basic_station: version received from gateway")
+
+	if err := c.send(b.getRegionBand(c.region).routerConfig); err != nil {
+		return errors.Wrap(err, "send router config error")
+	}
+
+	basicStationCommandCounter(string(routerConfigMessage)).Inc()
+
+	return nil
+}
+
+func (b *Backend) handleUplinkFrame(gatewayID lorawan.EUI64, c *connection, phy []byte, md radioMetaData) error {
+	c.Lock()
+	c.rxPacketsReceived++
+	c.Unlock()
+
+	uplinkFrame, err := getUplinkFrame(b.getRegionBand(c.region).band, gatewayID, phy, md)
+	if err != nil {
+		return errors.Wrap(err, "get uplink frame error")
+	}
+
+	uplinkID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	uplinkFrame.RxInfo.UplinkId = uplinkID[:]
+
+	c.Lock()
+	c.rxPacketsReceivedOK++
+	c.Unlock()
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  uplinkID,
+	}).Info("gateway/basic_station: uplink received from gateway")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTransmitted(gatewayID lorawan.EUI64, c *connection, pl downlinkTransmitted) error {
+	c.Lock()
+	downlinkID := c.downlinkIDs[pl.DIID]
+	delete(c.downlinkIDs, pl.DIID)
+	c.txPacketsEmitted++
+	c.Unlock()
+
+	ack := gw.DownlinkTXAck{
+		GatewayId:  gatewayID[:],
+		Token:      uint32(pl.DIID),
+		DownlinkId: downlinkID,
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": helpers.GetDownlinkID(&ack),
+	}).Info("gateway/basic_station: ack received from gateway")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+// statsLoop periodically generates the gateway stats of the connected
+// gateway, as Basic Station does not send these itself.
+func (b *Backend) statsLoop(gatewayID lorawan.EUI64, c *connection, done chan struct{}) {
+	if b.statsInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(b.statsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case t := <-ticker.C:
+			stats, err := c.getAndResetStats(gatewayID, t)
+			if err != nil {
+				log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/basic_station: get gateway stats error")
+				continue
+			}
+
+			b.RLock()
+			closed := b.closed
+			b.RUnlock()
+			if closed {
+				return
+			}
+
+			b.gatewayStatsChan <- stats
+		}
+	}
+}
+
+func (b *Backend) getConnection(gatewayID lorawan.EUI64) (*connection, bool) {
+	b.RLock()
+	defer b.RUnlock()
+
+	c, ok := b.gateways[gatewayID]
+	return c, ok
+}
+
+// setConnection registers the connection of the gateway. An existing
+// connection of the same gateway is closed. It returns false when the
+// backend is closed.
+func (b *Backend) setConnection(gatewayID lorawan.EUI64, c *connection) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return false
+	}
+
+	if existing, ok := b.gateways[gatewayID]; ok {
+		log.WithField("gateway_id", gatewayID).Warning("gateway/basic_station: gateway is already connected, closing existing connection")
+		existing.conn.Close()
+	} else {
+		basicStationConnectedGatewaysGauge().Inc()
+	}
+
+	b.gateways[gatewayID] = c
+	return true
+}
+
+// deleteConnection removes the connection of the gateway, unless it has
+// been replaced by a new connection.
+func (b *Backend) deleteConnection(gatewayID lorawan.EUI64, c *connection) {
+	b.Lock()
+	defer b.Unlock()
+
+	c.conn.Close()
+
+	if b.gateways[gatewayID] == c {
+		delete(b.gateways, gatewayID)
+		basicStationConnectedGatewaysGauge().Dec()
+	}
+}
+
+// send sends the given message JSON encoded to the gateway.
+func (c *connection) send(v interface{}) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return errors.Wrap(err, "set write deadline error")
+	}
+
+	return websocket.JSON.Send(c.conn, v)
+}
+
+func (c *connection) getAndResetStats(gatewayID lorawan.EUI64, t time.Time) (gw.GatewayStats, error) {
+	statsID, err := uuid.NewV4()
+	if err != nil {
+		return gw.GatewayStats{}, errors.Wrap(err, "new uuid error")
+	}
+
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return gw.GatewayStats{}, errors.Wrap(err, "timestamp proto error")
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	stats := gw.GatewayStats{
+		GatewayId:           gatewayID[:],
+		Ip:                  c.ip,
+		Time:                ts,
+		RxPacketsReceived:   c.rxPacketsReceived,
+		RxPacketsReceivedOk: c.rxPacketsReceivedOK,
+		TxPacketsReceived:   c.txPacketsReceived,
+		TxPacketsEmitted:    c.txPacketsEmitted,
+		StatsId:             statsID[:],
+		MetaData: map[string]string{
+			"station":  c.version.Station,
+			"firmware": c.version.Firmware,
+			"package":  c.version.Package,
+			"model":    c.version.Model,
+		},
+	}
+
+	c.rxPacketsReceived = 0
+	c.rxPacketsReceivedOK = 0
+	c.txPacketsReceived = 0
+	c.txPacketsEmitted = 0
+
+	return stats, nil
+}
+
+// parseRouter parses the router field of the router-info request, which is
+// either a number or a string.
+func parseRouter(b json.RawMessage) (lorawan.EUI64, error) {
+	var eui lorawan.EUI64
+
+	var id uint64
+	if err := json.Unmarshal(b, &id); err == nil {
+		for i := range eui {
+			eui[len(eui)-1-i] = byte(id >> (8 * uint(i)))
+		}
+		return eui, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return eui, errors.New("router must be a number or a string")
+	}
+
+	return parseEUI(s)
+}
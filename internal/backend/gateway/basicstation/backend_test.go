@@ -0,0 +1,407 @@
+package basicstation
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend     *Backend
+	server      *httptest.Server
+	gatewayID   lorawan.EUI64
+	usGatewayID lorawan.EUI64
+
+	getGatewayRegion func(lorawan.EUI64) string
+}
+
+func (ts *BackendTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	conf.NetworkServer.Regions = []config.Region{
+		{Name: "us915", Band: loraband.US_902_928},
+	}
+	assert.NoError(band.Setup(conf))
+
+	ts.gatewayID = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts.usGatewayID = lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+	ts.getGatewayRegion = getGatewayRegion
+	getGatewayRegion = func(gatewayID lorawan.EUI64) string {
+		if gatewayID == ts.usGatewayID {
+			return "us915"
+		}
+		return ""
+	}
+
+	ts.backend = &Backend{
+		scheme:   "ws",
+		gateways: make(map[lorawan.EUI64]*connection),
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:  make(chan gw.GatewayStats, 10),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck, 10),
+	}
+	assert.NoError(ts.backend.setupRegions(conf))
+
+	ts.server = httptest.NewServer(ts.backend.handler())
+}
+
+func (ts *BackendTestSuite) TearDownSuite() {
+	ts.server.Close()
+	getGatewayRegion = ts.getGatewayRegion
+	band.Setup(test.GetConfig())
+}
+
+func (ts *BackendTestSuite) dial(path string) *websocket.Conn {
+	url := strings.Replace(ts.server.URL, "http://", "ws://", 1) + path
+	conn, err := websocket.Dial(url, "", ts.server.URL)
+	require.NoError(ts.T(), err)
+	return conn
+}
+
+func (ts *BackendTestSuite) TestRouterInfo() {
+	assert := require.New(ts.T())
+
+	conn := ts.dial(routerInfoPath)
+	defer conn.Close()
+
+	assert.NoError(websocket.Message.Send(conn, `{"router": "0102:0304:0506:0708"}`))
+
+	var resp routerInfoResponse
+	assert.NoError(websocket.JSON.Receive(conn, &resp))
+	assert.Equal("01-02-03-04-05-06-07-08", resp.Router)
+	assert.True(strings.HasSuffix(resp.URI, "/gateway/0102030405060708"))
+	assert.Equal("", resp.Error)
+}
+
+func (ts *BackendTestSuite) TestGateway() {
+	assert := require.New(ts.T())
+
+	conn := ts.dial(gatewayPath + ts.gatewayID.String())
+	defer conn.Close()
+
+	ts.T().Run("Version", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{"msgtype": "version", "station": "2.0.3", "protocol": 2}`))
+
+		var rc routerConfig
+		assert.NoError(websocket.JSON.Receive(conn, &rc))
+		assert.Equal(routerConfigMessage, rc.MessageType)
+		assert.Equal("EU863", rc.Region)
+		assert.Equal([2]uint32{863000000, 870000000}, rc.FrequencyRange)
+		assert.Equal([3]int{12, 125, 0}, rc.DataRates[0])
+		assert.Equal([3]int{-1, 0, 0}, rc.DataRates[15])
+		assert.Len(rc.SX1301Config, 1)
+	})
+
+	ts.T().Run("Uplink data frame", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{
+			"msgtype": "updf",
+			"MHdr": 64,
+			"DevAddr": 16909060,
+			"FCtrl": 128,
+			"FCnt": 10,
+			"FOpts": "",
+			"FPort": 1,
+			"FRMPayload": "0102",
+			"MIC": 67305985,
+			"DR": 5,
+			"Freq": 868100000,
+			"upinfo": {"rctx": 1, "xtime": 2, "rssi": -60, "snr": 5.5, "rxtime": 1560000000.5}
+		}`))
+
+		uplinkFrame := <-ts.backend.RXPacketChan()
+		assert.Equal([]byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x80, 0x0a, 0x00, 0x01, 0x01, 0x02, 0x01, 0x02, 0x03, 0x04}, uplinkFrame.PhyPayload)
+		assert.Equal(ts.gatewayID[:], uplinkFrame.RxInfo.GatewayId)
+		assert.EqualValues(-60, uplinkFrame.RxInfo.Rssi)
+		assert.Equal(5.5, uplinkFrame.RxInfo.LoraSnr)
+		assert.Equal([]byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1}, uplinkFrame.RxInfo.Context)
+		assert.Len(uplinkFrame.RxInfo.UplinkId, 16)
+		assert.EqualValues(868100000, uplinkFrame.TxInfo.Frequency)
+		assert.EqualValues(7, uplinkFrame.TxInfo.GetLoraModulationInfo().SpreadingFactor)
+
+		rxTime, err := ptypes.Timestamp(uplinkFrame.RxInfo.Time)
+		assert.NoError(err)
+		assert.True(rxTime.Equal(time.Unix(1560000000, 500000000)))
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(uplinkFrame.PhyPayload))
+		assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, phy.MACPayload.(*lorawan.MACPayload).FHDR.DevAddr)
+	})
+
+	ts.T().Run("Join-request", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{
+			"msgtype": "jreq",
+			"MHdr": 0,
+			"JoinEui": "01-01-01-01-01-01-01-01",
+			"DevEui": "02-02-02-02-02-02-02-03",
+			"DevNonce": 258,
+			"MIC": 67305985,
+			"DR": 0,
+			"Freq": 868100000,
+			"upinfo": {"rctx": 0, "xtime": 0, "rssi": -100, "snr": -5}
+		}`))
+
+		uplinkFrame := <-ts.backend.RXPacketChan()
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(uplinkFrame.PhyPayload))
+		jr := phy.MACPayload.(*lorawan.JoinRequestPayload)
+		assert.Equal(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, jr.JoinEUI)
+		assert.Equal(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 3}, jr.DevEUI)
+		assert.Equal(lorawan.DevNonce(258), jr.DevNonce)
+		assert.Equal(lorawan.MIC{1, 2, 3, 4}, phy.MIC)
+	})
+
+	ts.T().Run("Downlink", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3},
+			Token:      1234,
+			DownlinkId: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId:  ts.gatewayID[:],
+				Frequency:  868100000,
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{
+						SpreadingFactor: 7,
+						Bandwidth:       125,
+					},
+				},
+				Timing: gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+				Context: []byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1},
+			},
+		}))
+
+		var msg map[string]interface{}
+		assert.NoError(websocket.JSON.Receive(conn, &msg))
+		assert.Equal(map[string]interface{}{
+			"msgtype":  "dnmsg",
+			"DevEui":   "00-00-00-00-00-00-00-00",
+			"dC":       float64(0),
+			"diid":     float64(1234),
+			"pdu":      "010203",
+			"priority": float64(0),
+			"rctx":     float64(1),
+			"xtime":    float64(2),
+			"RxDelay":  float64(1),
+			"RX1DR":    float64(5),
+			"RX1Freq":  float64(868100000),
+		}, msg)
+
+		assert.NoError(websocket.Message.Send(conn, `{"msgtype": "dntxed", "diid": 1234, "DevEui": "00-00-00-00-00-00-00-00", "rctx": 1, "xtime": 2}`))
+
+		ack := <-ts.backend.DownlinkTXAckChan()
+		assert.Equal(ts.gatewayID[:], ack.GatewayId)
+		assert.EqualValues(1234, ack.Token)
+		assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, ack.DownlinkId)
+	})
+
+	ts.T().Run("Time-sync", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{"msgtype": "timesync", "txtime": 1.5}`))
+
+		var resp timeSync
+		assert.NoError(websocket.JSON.Receive(conn, &resp))
+		assert.Equal(timeSyncMessage, resp.MessageType)
+		assert.Equal(1.5, resp.TxTime)
+		assert.NotEqual(int64(0), resp.GPSTime)
+	})
+
+	ts.T().Run("Stats", func(t *testing.T) {
+		assert := require.New(t)
+
+		c, ok := ts.backend.getConnection(ts.gatewayID)
+		assert.True(ok)
+
+		stats, err := c.getAndResetStats(ts.gatewayID, time.Now())
+		assert.NoError(err)
+		assert.EqualValues(2, stats.RxPacketsReceived)
+		assert.EqualValues(2, stats.RxPacketsReceivedOk)
+		assert.EqualValues(1, stats.TxPacketsReceived)
+		assert.EqualValues(1, stats.TxPacketsEmitted)
+		assert.Equal("2.0.3", stats.MetaData["station"])
+	})
+
+	ts.T().Run("Not connected", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Error(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			},
+		}))
+	})
+
+	assert.NoError(conn.Close())
+}
+
+func (ts *BackendTestSuite) TestGatewayRegions() {
+	assert := require.New(ts.T())
+
+	conn := ts.dial(gatewayPath + ts.usGatewayID.String())
+	defer conn.Close()
+
+	ts.T().Run("Version", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{"msgtype": "version", "station": "2.0.3", "protocol": 2}`))
+
+		var rc routerConfig
+		assert.NoError(websocket.JSON.Receive(conn, &rc))
+		assert.Equal("US902", rc.Region)
+		assert.Equal([2]uint32{902000000, 928000000}, rc.FrequencyRange)
+		assert.Equal([3]int{10, 125, 0}, rc.DataRates[0])
+	})
+
+	ts.T().Run("Uplink data frame", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(websocket.Message.Send(conn, `{
+			"msgtype": "updf",
+			"MHdr": 64,
+			"DevAddr": 16909060,
+			"FCtrl": 128,
+			"FCnt": 10,
+			"FOpts": "",
+			"FPort": 1,
+			"FRMPayload": "0102",
+			"MIC": 67305985,
+			"DR": 0,
+			"Freq": 902300000,
+			"upinfo": {"rctx": 1, "xtime": 2, "rssi": -60, "snr": 5.5}
+		}`))
+
+		uplinkFrame := <-ts.backend.RXPacketChan()
+		assert.Equal(ts.usGatewayID[:], uplinkFrame.RxInfo.GatewayId)
+		assert.EqualValues(10, uplinkFrame.TxInfo.GetLoraModulationInfo().SpreadingFactor)
+		assert.EqualValues(125, uplinkFrame.TxInfo.GetLoraModulationInfo().Bandwidth)
+	})
+
+	ts.T().Run("Downlink", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3},
+			Token:      1235,
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId:  ts.usGatewayID[:],
+				Frequency:  923300000,
+				Modulation: common.Modulation_LORA,
+				ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+					LoraModulationInfo: &gw.LoRaModulationInfo{
+						SpreadingFactor: 10,
+						Bandwidth:       500,
+					},
+				},
+				Timing: gw.DownlinkTiming_DELAY,
+				TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+					DelayTimingInfo: &gw.DelayTimingInfo{
+						Delay: ptypes.DurationProto(time.Second),
+					},
+				},
+				Context: []byte{0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1},
+			},
+		}))
+
+		var msg map[string]interface{}
+		assert.NoError(websocket.JSON.Receive(conn, &msg))
+		assert.Equal(float64(10), msg["RX1DR"])
+		assert.Equal(float64(923300000), msg["RX1Freq"])
+	})
+
+	ts.T().Run("The default region is used for other gateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		c, ok := ts.backend.getConnection(ts.usGatewayID)
+		assert.True(ok)
+		assert.Equal("us915", c.region)
+
+		assert.Equal("EU863", ts.backend.getRegionBand("").routerConfig.Region)
+		assert.Equal("EU863", ts.backend.getRegionBand("unknown").routerConfig.Region)
+	})
+
+	assert.NoError(conn.Close())
+}
+
+func TestParseEUI(t *testing.T) {
+	tests := []struct {
+		In          string
+		Expected    lorawan.EUI64
+		ExpectedErr bool
+	}{
+		{In: "0102030405060708", Expected: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+		{In: "01-02-03-04-05-06-07-08", Expected: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+		{In: "01:02:03:04:05:06:07:08", Expected: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+		{In: "b827:ebff:fe61:51cf", Expected: lorawan.EUI64{0xb8, 0x27, 0xeb, 0xff, 0xfe, 0x61, 0x51, 0xcf}},
+		{In: "::1", Expected: lorawan.EUI64{0, 0, 0, 0, 0, 0, 0, 1}},
+		{In: "1::", Expected: lorawan.EUI64{0, 1, 0, 0, 0, 0, 0, 0}},
+		{In: "1::2", Expected: lorawan.EUI64{0, 1, 0, 0, 0, 0, 0, 2}},
+		{In: "1:2", ExpectedErr: true},
+		{In: "0102", ExpectedErr: true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.In, func(t *testing.T) {
+			assert := require.New(t)
+
+			eui, err := parseEUI(tst.In)
+			if tst.ExpectedErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, eui)
+		})
+	}
+}
+
+func TestParseRouter(t *testing.T) {
+	assert := require.New(t)
+
+	eui, err := parseRouter(json.RawMessage(`72623859790382856`))
+	assert.NoError(err)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, eui)
+
+	eui, err = parseRouter(json.RawMessage(`"01-02-03-04-05-06-07-08"`))
+	assert.NoError(err)
+	assert.Equal(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, eui)
+
+	_, err = parseRouter(json.RawMessage(`true`))
+	assert.Error(err)
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
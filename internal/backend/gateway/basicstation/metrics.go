@@ -0,0 +1,35 @@
+package basicstation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_basic_station_event_count",
+		Help: "The number of received events by the Basic Station backend (per event type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_basic_station_command_count",
+		Help: "The number of sent commands by the Basic Station backend (per command type).",
+	}, []string{"command"})
+
+	gwc = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backend_basic_station_connected_gateways",
+		Help: "The number of gateways connected to the Basic Station backend.",
+	})
+)
+
+func basicStationEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func basicStationCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
+
+func basicStationConnectedGatewaysGauge() prometheus.Gauge {
+	return gwc
+}
@@ -0,0 +1,646 @@
+package basicstation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/brocaar/lorawan/gps"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+)
+
+// messageType defines the Basic Station message type.
+type messageType string
+
+// Basic Station message types.
+const (
+	versionMessage              messageType = "version"
+	routerConfigMessage         messageType = "router_config"
+	uplinkDataFrameMessage      messageType = "updf"
+	joinRequestMessage          messageType = "jreq"
+	proprietaryDataFrameMessage messageType = "propdf"
+	downlinkMessage             messageType = "dnmsg"
+	downlinkTransmittedMessage  messageType = "dntxed"
+	timeSyncMessage             messageType = "timesync"
+)
+
+// Basic Station device classes (dnmsg dC field).
+const (
+	deviceClassA = 0
+	deviceClassB = 1
+	deviceClassC = 2
+)
+
+// regionConfig defines the Basic Station region name and frequency range
+// for a band.
+type regionConfig struct {
+	region       string
+	minFrequency uint32
+	maxFrequency uint32
+}
+
+// regionConfigs contains the Basic Station region configuration per band
+// (by common name).
+var regionConfigs = map[loraband.Name]regionConfig{
+	loraband.AS923: {"AS923", 915000000, 928000000},
+	loraband.AU915: {"AU915", 915000000, 928000000},
+	loraband.CN470: {"CN470", 470000000, 510000000},
+	loraband.CN779: {"CN779", 779000000, 787000000},
+	loraband.EU433: {"EU433", 433050000, 434790000},
+	loraband.EU868: {"EU863", 863000000, 870000000},
+	loraband.IN865: {"IN865", 865000000, 867000000},
+	loraband.KR920: {"KR920", 920900000, 923300000},
+	loraband.RU864: {"RU864", 864000000, 870000000},
+	loraband.US915: {"US902", 902000000, 928000000},
+}
+
+// deprecatedBandNames maps the deprecated band names to their common name.
+var deprecatedBandNames = map[loraband.Name]loraband.Name{
+	loraband.AS_923:     loraband.AS923,
+	loraband.AU_915_928: loraband.AU915,
+	loraband.CN_470_510: loraband.CN470,
+	loraband.CN_779_787: loraband.CN779,
+	loraband.EU_433:     loraband.EU433,
+	loraband.EU_863_870: loraband.EU868,
+	loraband.IN_865_867: loraband.IN865,
+	loraband.KR_920_923: loraband.KR920,
+	loraband.RU_864_870: loraband.RU864,
+	loraband.US_902_928: loraband.US915,
+}
+
+// getRegionConfig returns the Basic Station region configuration for the
+// given band name.
+func getRegionConfig(name loraband.Name) (regionConfig, error) {
+	if n, ok := deprecatedBandNames[name]; ok {
+		name = n
+	}
+
+	rc, ok := regionConfigs[name]
+	if !ok {
+		return rc, fmt.Errorf("band %s is not supported by basic station", name)
+	}
+	return rc, nil
+}
+
+// messageTypeMessage is used for decoding the message type.
+type messageTypeMessage struct {
+	MessageType messageType `json:"msgtype"`
+}
+
+// version is sent by the station after connecting.
+type version struct {
+	MessageType messageType `json:"msgtype"`
+	Station     string      `json:"station"`
+	Firmware    string      `json:"firmware"`
+	Package     string      `json:"package"`
+	Model       string      `json:"model"`
+	Protocol    int         `json:"protocol"`
+	Features    string      `json:"features"`
+}
+
+// routerInfoRequest is sent by the station to the discovery endpoint.
+type routerInfoRequest struct {
+	Router json.RawMessage `json:"router"`
+}
+
+// routerInfoResponse is the response of the discovery endpoint.
+type routerInfoResponse struct {
+	Router string `json:"router"`
+	Muxs   string `json:"muxs"`
+	URI    string `json:"uri,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// routerConfig is sent to the station in reply of the version message.
+type routerConfig struct {
+	MessageType    messageType              `json:"msgtype"`
+	NetID          []uint32                 `json:"NetID"`
+	JoinEUI        [][2]uint64              `json:"JoinEui"`
+	Region         string                   `json:"region"`
+	HardwareSpec   string                   `json:"hwspec"`
+	FrequencyRange [2]uint32                `json:"freq_range"`
+	DataRates      [16][3]int               `json:"DRs"`
+	SX1301Config   []map[string]interface{} `json:"sx1301_conf"`
+	NoCCA          bool                     `json:"nocca"`
+	NoDutyCycle    bool                     `json:"nodc"`
+	NoDwellTime    bool                     `json:"nodwell"`
+}
+
+type sx1301Radio struct {
+	Enable    bool   `json:"enable"`
+	Frequency uint32 `json:"freq"`
+}
+
+type sx1301Channel struct {
+	Enable       bool  `json:"enable"`
+	Radio        int   `json:"radio"`
+	IF           int32 `json:"if"`
+	Bandwidth    int   `json:"bandwidth,omitempty"`
+	SpreadFactor int   `json:"spread_factor,omitempty"`
+}
+
+// radioMetaData contains the radio meta-data of an uplink message.
+type radioMetaData struct {
+	DR        int    `json:"DR"`
+	Frequency uint32 `json:"Freq"`
+	UpInfo    upInfo `json:"upinfo"`
+}
+
+type upInfo struct {
+	RCtx    int64   `json:"rctx"`
+	XTime   int64   `json:"xtime"`
+	GPSTime int64   `json:"gpstime"`
+	RSSI    float64 `json:"rssi"`
+	SNR     float64 `json:"snr"`
+	RxTime  float64 `json:"rxtime"`
+}
+
+// uplinkDataFrame contains the (parsed) uplink data frame.
+type uplinkDataFrame struct {
+	radioMetaData
+
+	MHDR       uint8  `json:"MHdr"`
+	DevAddr    int32  `json:"DevAddr"`
+	FCtrl      uint8  `json:"FCtrl"`
+	FCnt       uint16 `json:"FCnt"`
+	FOpts      string `json:"FOpts"`
+	FPort      int    `json:"FPort"`
+	FRMPayload string `json:"FRMPayload"`
+	MIC        int32  `json:"MIC"`
+}
+
+// joinRequest contains the (parsed) join-request.
+type joinRequest struct {
+	radioMetaData
+
+	MHDR     uint8  `json:"MHdr"`
+	JoinEUI  string `json:"JoinEui"`
+	DevEUI   string `json:"DevEui"`
+	DevNonce uint16 `json:"DevNonce"`
+	MIC      int32  `json:"MIC"`
+}
+
+// proprietaryDataFrame contains the proprietary frame.
+type proprietaryDataFrame struct {
+	radioMetaData
+
+	FRMPayload string `json:"FRMPayload"`
+}
+
+// downlinkFrame is sent to the station for scheduling a downlink.
+type downlinkFrame struct {
+	MessageType messageType `json:"msgtype"`
+	DevEUI      string      `json:"DevEui"`
+	DeviceClass int         `json:"dC"`
+	DIID        int64       `json:"diid"`
+	PDU         string      `json:"pdu"`
+	Priority    int         `json:"priority"`
+	RCtx        *int64      `json:"rctx,omitempty"`
+	XTime       *int64      `json:"xtime,omitempty"`
+
+	// Class-A
+	RxDelay *int    `json:"RxDelay,omitempty"`
+	RX1DR   *int    `json:"RX1DR,omitempty"`
+	RX1Freq *uint32 `json:"RX1Freq,omitempty"`
+
+	// Class-C
+	RX2DR   *int    `json:"RX2DR,omitempty"`
+	RX2Freq *uint32 `json:"RX2Freq,omitempty"`
+
+	// Class-B
+	DR        *int    `json:"DR,omitempty"`
+	Frequency *uint32 `json:"Freq,omitempty"`
+	GPSTime   *int64  `json:"gpstime,omitempty"`
+}
+
+// downlinkTransmitted is sent by the station when the downlink has been
+// transmitted.
+type downlinkTransmitted struct {
+	MessageType messageType `json:"msgtype"`
+	DIID        int64       `json:"diid"`
+	DevEUI      string      `json:"DevEui"`
+	RCtx        int64       `json:"rctx"`
+	XTime       int64       `json:"xtime"`
+	TxTime      float64     `json:"txtime"`
+	GPSTime     int64       `json:"gpstime"`
+}
+
+// timeSync is sent by the station for requesting the GPS time. The response
+// contains the GPS time, set by the network-server.
+type timeSync struct {
+	MessageType messageType `json:"msgtype"`
+	TxTime      float64     `json:"txtime"`
+	GPSTime     int64       `json:"gpstime,omitempty"`
+}
+
+// parseEUI parses the given EUI, which can be formatted as HEX string
+// (optionally separated by dashes or colons) or in the ID6 format
+// (e.g. ::1 or 1:2:3:4).
+func parseEUI(s string) (lorawan.EUI64, error) {
+	var eui lorawan.EUI64
+
+	// id6 format
+	if strings.Count(s, ":") > 0 && strings.Count(s, ":") < 7 {
+		groups := strings.Split(s, ":")
+		if i := strings.Index(s, "::"); i != -1 {
+			left := strings.Split(strings.Trim(s[:i], ":"), ":")
+			right := strings.Split(strings.Trim(s[i+2:], ":"), ":")
+			if s[:i] == "" {
+				left = nil
+			}
+			if s[i+2:] == "" {
+				right = nil
+			}
+			if len(left)+len(right) > 3 {
+				return eui, fmt.Errorf("invalid id6: %s", s)
+			}
+
+			groups = append(left, make([]string, 4-len(left)-len(right))...)
+			groups = append(groups, right...)
+		}
+		if len(groups) != 4 {
+			return eui, fmt.Errorf("invalid id6: %s", s)
+		}
+
+		for i, g := range groups {
+			if g == "" {
+				g = "0"
+			}
+			v, err := strconv.ParseUint(g, 16, 16)
+			if err != nil {
+				return eui, fmt.Errorf("invalid id6: %s", s)
+			}
+			binary.BigEndian.PutUint16(eui[i*2:], uint16(v))
+		}
+
+		return eui, nil
+	}
+
+	s = strings.Replace(s, "-", "", -1)
+	s = strings.Replace(s, ":", "", -1)
+	if err := eui.UnmarshalText([]byte(s)); err != nil {
+		return eui, err
+	}
+	return eui, nil
+}
+
+// formatEUI returns the EUI in the dashed format used by Basic Station.
+func formatEUI(eui lorawan.EUI64) string {
+	var parts []string
+	for _, b := range eui {
+		parts = append(parts, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(parts, "-")
+}
+
+// getRouterConfig returns the router configuration for the given band.
+func getRouterConfig(b loraband.Band, rc regionConfig) (routerConfig, error) {
+	out := routerConfig{
+		MessageType:    routerConfigMessage,
+		Region:         rc.region,
+		HardwareSpec:   "sx1301/1",
+		FrequencyRange: [2]uint32{rc.minFrequency, rc.maxFrequency},
+	}
+
+	for i := range out.DataRates {
+		dr, err := b.GetDataRate(i)
+		if err != nil {
+			out.DataRates[i] = [3]int{-1, 0, 0}
+			continue
+		}
+
+		// some bands implement different data-rates with the same parameters
+		// for uplink and downlink
+		var dnOnly int
+		if idx, err := b.GetDataRateIndex(true, dr); err != nil || idx != i {
+			dnOnly = 1
+		}
+
+		switch dr.Modulation {
+		case loraband.LoRaModulation:
+			out.DataRates[i] = [3]int{dr.SpreadFactor, dr.Bandwidth, dnOnly}
+		case loraband.FSKModulation:
+			out.DataRates[i] = [3]int{0, 0, dnOnly}
+		}
+	}
+
+	conf, err := getSX1301Config(b)
+	if err != nil {
+		return out, errors.Wrap(err, "get sx1301 config error")
+	}
+	out.SX1301Config = []map[string]interface{}{conf}
+
+	return out, nil
+}
+
+// getSX1301Config returns the SX1301 concentrator configuration for the
+// enabled uplink channels of the given band. The channels are assigned to
+// the two radios, in order of frequency.
+func getSX1301Config(b loraband.Band) (map[string]interface{}, error) {
+	// max. frequency span covered by a radio
+	const radioSpan = 925000
+
+	var multiSF, loraStd, fsk []loraband.Channel
+	for _, i := range b.GetEnabledUplinkChannelIndices() {
+		c, err := b.GetUplinkChannel(i)
+		if err != nil {
+			return nil, errors.Wrap(err, "get uplink channel error")
+		}
+
+		dr, err := b.GetDataRate(c.MaxDR)
+		if err != nil {
+			return nil, errors.Wrap(err, "get data-rate error")
+		}
+
+		switch {
+		case dr.Modulation == loraband.FSKModulation:
+			fsk = append(fsk, c)
+		case c.MinDR == c.MaxDR || dr.Bandwidth != 125:
+			loraStd = append(loraStd, c)
+		default:
+			multiSF = append(multiSF, c)
+		}
+	}
+
+	if len(multiSF) > 8 {
+		return nil, fmt.Errorf("max. 8 multi-SF channels are supported, %d are enabled", len(multiSF))
+	}
+	if len(loraStd) > 1 || len(fsk) > 1 {
+		return nil, errors.New("max. one LoRa standard and one FSK channel are supported")
+	}
+
+	var all []loraband.Channel
+	all = append(all, multiSF...)
+	all = append(all, loraStd...)
+	all = append(all, fsk...)
+	if len(all) == 0 {
+		return nil, errors.New("no uplink channels are enabled")
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Frequency < all[j].Frequency })
+
+	// assign the channels to the radios
+	var radios [2][]int
+	radio := 0
+	for _, c := range all {
+		if len(radios[radio]) != 0 && c.Frequency-radios[radio][0] > radioSpan {
+			radio++
+			if radio > 1 {
+				return nil, errors.New("the enabled uplink channels do not fit within the frequency span of two radios")
+			}
+		}
+		radios[radio] = append(radios[radio], c.Frequency)
+	}
+
+	out := make(map[string]interface{})
+	var centers [2]int
+	for i, freqs := range radios {
+		if len(freqs) == 0 {
+			out[fmt.Sprintf("radio_%d", i)] = sx1301Radio{}
+			continue
+		}
+		centers[i] = (freqs[0] + freqs[len(freqs)-1]) / 2
+		out[fmt.Sprintf("radio_%d", i)] = sx1301Radio{Enable: true, Frequency: uint32(centers[i])}
+	}
+
+	channel := func(freq int) sx1301Channel {
+		for i, freqs := range radios {
+			for _, f := range freqs {
+				if f == freq {
+					return sx1301Channel{Enable: true, Radio: i, IF: int32(freq - centers[i])}
+				}
+			}
+		}
+		return sx1301Channel{}
+	}
+
+	for i := 0; i < 8; i++ {
+		key := fmt.Sprintf("chan_multiSF_%d", i)
+		if i < len(multiSF) {
+			out[key] = channel(multiSF[i].Frequency)
+		} else {
+			out[key] = sx1301Channel{}
+		}
+	}
+
+	out["chan_Lora_std"] = sx1301Channel{}
+	if len(loraStd) == 1 {
+		dr, err := b.GetDataRate(loraStd[0].MaxDR)
+		if err != nil {
+			return nil, errors.Wrap(err, "get data-rate error")
+		}
+		c := channel(loraStd[0].Frequency)
+		c.Bandwidth = dr.Bandwidth * 1000
+		c.SpreadFactor = dr.SpreadFactor
+		out["chan_Lora_std"] = c
+	}
+
+	out["chan_FSK"] = sx1301Channel{}
+	if len(fsk) == 1 {
+		out["chan_FSK"] = channel(fsk[0].Frequency)
+	}
+
+	return out, nil
+}
+
+// getUplinkFrame returns the gw.UplinkFrame for the given PHYPayload and
+// radio meta-data.
+func getUplinkFrame(b loraband.Band, gatewayID lorawan.EUI64, phyPayload []byte, md radioMetaData) (gw.UplinkFrame, error) {
+	out := gw.UplinkFrame{
+		PhyPayload: phyPayload,
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: md.Frequency,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+			Rssi:      int32(md.UpInfo.RSSI),
+			LoraSnr:   md.UpInfo.SNR,
+			Context:   make([]byte, 16),
+		},
+	}
+
+	if err := helpers.SetUplinkTXInfoDataRate(out.TxInfo, md.DR, b); err != nil {
+		return out, errors.Wrap(err, "set data-rate error")
+	}
+
+	// the context is used for scheduling the downlink
+	binary.BigEndian.PutUint64(out.RxInfo.Context[0:8], uint64(md.UpInfo.XTime))
+	binary.BigEndian.PutUint64(out.RxInfo.Context[8:16], uint64(md.UpInfo.RCtx))
+
+	if md.UpInfo.RxTime != 0 {
+		sec, nsec := splitSeconds(md.UpInfo.RxTime)
+		t, err := ptypes.TimestampProto(time.Unix(sec, nsec))
+		if err != nil {
+			return out, errors.Wrap(err, "timestamp proto error")
+		}
+		out.RxInfo.Time = t
+	}
+
+	if md.UpInfo.GPSTime != 0 {
+		out.RxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(md.UpInfo.GPSTime) * time.Microsecond)
+	}
+
+	return out, nil
+}
+
+// phyPayload returns the PHYPayload bytes of the uplink data frame.
+func (f uplinkDataFrame) phyPayload() ([]byte, error) {
+	fOpts, err := hex.DecodeString(f.FOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode FOpts error")
+	}
+
+	frmPayload, err := hex.DecodeString(f.FRMPayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode FRMPayload error")
+	}
+
+	out := []byte{f.MHDR}
+	out = appendUint32(out, uint32(f.DevAddr))
+	out = append(out, f.FCtrl)
+	out = appendUint16(out, f.FCnt)
+	out = append(out, fOpts...)
+	if f.FPort >= 0 {
+		out = append(out, uint8(f.FPort))
+		out = append(out, frmPayload...)
+	}
+	out = appendUint32(out, uint32(f.MIC))
+
+	return out, nil
+}
+
+// phyPayload returns the PHYPayload bytes of the join-request.
+func (f joinRequest) phyPayload() ([]byte, error) {
+	joinEUI, err := parseEUI(f.JoinEUI)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse JoinEui error")
+	}
+
+	devEUI, err := parseEUI(f.DevEUI)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse DevEui error")
+	}
+
+	out := []byte{f.MHDR}
+	for i := len(joinEUI) - 1; i >= 0; i-- {
+		out = append(out, joinEUI[i])
+	}
+	for i := len(devEUI) - 1; i >= 0; i-- {
+		out = append(out, devEUI[i])
+	}
+	out = appendUint16(out, f.DevNonce)
+	out = appendUint32(out, uint32(f.MIC))
+
+	return out, nil
+}
+
+// getDownlinkFrame returns the Basic Station downlink message for the given
+// downlink frame.
+func getDownlinkFrame(b loraband.Band, pl gw.DownlinkFrame) (downlinkFrame, error) {
+	out := downlinkFrame{
+		MessageType: downlinkMessage,
+		DevEUI:      formatEUI(lorawan.EUI64{}),
+		DIID:        int64(pl.Token),
+		PDU:         hex.EncodeToString(pl.PhyPayload),
+	}
+
+	txInfo := pl.TxInfo
+
+	dr, err := helpers.GetDataRateIndex(false, txInfo, b)
+	if err != nil {
+		return out, errors.Wrap(err, "get data-rate index error")
+	}
+
+	if len(txInfo.Context) == 16 {
+		xTime := int64(binary.BigEndian.Uint64(txInfo.Context[0:8]))
+		rCtx := int64(binary.BigEndian.Uint64(txInfo.Context[8:16]))
+		out.XTime = &xTime
+		out.RCtx = &rCtx
+	}
+
+	switch txInfo.Timing {
+	case gw.DownlinkTiming_DELAY:
+		if out.XTime == nil {
+			return out, errors.New("context is required for delay timing")
+		}
+
+		timingInfo := txInfo.GetDelayTimingInfo()
+		if timingInfo == nil {
+			return out, errors.New("delay_timing_info must not be nil")
+		}
+		delay, err := ptypes.Duration(timingInfo.Delay)
+		if err != nil {
+			return out, errors.Wrap(err, "get delay error")
+		}
+
+		// The RX2 frame is scheduled by the network-server as a separate
+		// frame, using the RX1 delay + 1 second.
+		rxDelay := int(delay / time.Second)
+		out.DeviceClass = deviceClassA
+		out.RxDelay = &rxDelay
+		out.RX1DR = &dr
+		out.RX1Freq = &txInfo.Frequency
+	case gw.DownlinkTiming_IMMEDIATELY:
+		// xtime is only used as a hint for selecting the antenna
+		out.XTime = nil
+		out.DeviceClass = deviceClassC
+		out.RX2DR = &dr
+		out.RX2Freq = &txInfo.Frequency
+	case gw.DownlinkTiming_GPS_EPOCH:
+		timingInfo := txInfo.GetGpsEpochTimingInfo()
+		if timingInfo == nil {
+			return out, errors.New("gps_epoch_timing_info must not be nil")
+		}
+		d, err := ptypes.Duration(timingInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return out, errors.Wrap(err, "get time since gps epoch error")
+		}
+
+		gpsTime := int64(d / time.Microsecond)
+		out.XTime = nil
+		out.DeviceClass = deviceClassB
+		out.DR = &dr
+		out.Frequency = &txInfo.Frequency
+		out.GPSTime = &gpsTime
+	default:
+		return out, fmt.Errorf("unexpected timing: %s", txInfo.Timing)
+	}
+
+	return out, nil
+}
+
+// getTimeSyncResponse returns the response for the given time-sync request.
+func getTimeSyncResponse(req timeSync, t time.Time) timeSync {
+	return timeSync{
+		MessageType: timeSyncMessage,
+		TxTime:      req.TxTime,
+		GPSTime:     int64(gps.Time(t).TimeSinceGPSEpoch() / time.Microsecond),
+	}
+}
+
+func splitSeconds(f float64) (int64, int64) {
+	sec := int64(f)
+	return sec, int64((f - float64(sec)) * float64(time.Second))
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	var bb [2]byte
+	binary.LittleEndian.PutUint16(bb[:], v)
+	return append(b, bb[:]...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var bb [4]byte
+	binary.LittleEndian.PutUint32(bb[:], v)
+	return append(b, bb[:]...)
+}
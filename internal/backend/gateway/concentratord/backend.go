@@ -0,0 +1,448 @@
+// Package concentratord implements a gateway backend communicating directly
+// with the ChirpStack Concentratord over ZeroMQ, so that LoRa Server can run
+// on the gateway itself without a LoRa Gateway Bridge. The events (uplink
+// frames and gateway stats) are received from the event socket and the
+// commands (downlink frames and gateway configuration) are sent as requests
+// to the command socket. As a Concentratord instance handles a single
+// gateway, the backend only handles the gateway of which the ID is retrieved
+// from the Concentratord on start.
+package concentratord
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-zeromq/zmq4"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+)
+
+// redialInterval defines the interval between re-dialing the event socket
+// after a receive error, e.g. when the Concentratord has been restarted.
+const redialInterval = 2 * time.Second
+
+// Backend implements a Concentratord backend.
+type Backend struct {
+	// Mutex guards the command socket and the closed state. Requests must
+	// be sent one at a time, as a ZeroMQ REQ socket must alternate between
+	// sending a request and receiving its reply.
+	sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	closed bool
+
+	eventURL       string
+	commandURL     string
+	commandTimeout time.Duration
+
+	eventSock   zmq4.Socket
+	commandSock zmq4.Socket
+
+	// command sends the given command to the Concentratord and returns the
+	// reply. The caller must hold the lock.
+	command func(command string, data []byte) ([]byte, error)
+
+	gatewayID lorawan.EUI64
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+	noiseFloorChan    chan gw.NoiseFloorReport
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.Concentratord
+
+	b := Backend{
+		eventURL:       conf.EventURL,
+		commandURL:     conf.CommandURL,
+		commandTimeout: conf.CommandTimeout,
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+		noiseFloorChan:    make(chan gw.NoiseFloorReport),
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	b.command = b.commandRequest
+
+	log.WithFields(log.Fields{
+		"event_url":   b.eventURL,
+		"command_url": b.commandURL,
+	}).Info("gateway/concentratord: connecting to concentratord")
+
+	b.Lock()
+	gatewayID, err := b.command("gateway_id", nil)
+	b.Unlock()
+	if err != nil {
+		b.cancel()
+		return nil, errors.Wrap(err, "gateway/concentratord: get gateway id error")
+	}
+	if len(gatewayID) != len(b.gatewayID) {
+		b.cancel()
+		return nil, fmt.Errorf("gateway/concentratord: expected %d bytes gateway id, got %d", len(b.gatewayID), len(gatewayID))
+	}
+	copy(b.gatewayID[:], gatewayID)
+
+	if err := b.dialEventSock(); err != nil {
+		b.cancel()
+		return nil, errors.Wrap(err, "gateway/concentratord: dial event socket error")
+	}
+
+	log.WithField("gateway_id", b.gatewayID).Info("gateway/concentratord: connected to concentratord")
+
+	b.wg.Add(1)
+	go b.eventLoop()
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway. The reply of
+// the Concentratord is published as downlink tx acknowledgement.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	if gatewayID != b.gatewayID {
+		return fmt.Errorf("gateway %s is not connected", gatewayID)
+	}
+
+	downID := helpers.GetDownlinkID(&pl)
+
+	bb, err := proto.Marshal(&pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/concentratord: marshal downlink frame error")
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	reply, err := b.sendCommand(log.Fields{
+		"downlink_id": downID,
+	}, "down", bb)
+	if err != nil {
+		return err
+	}
+
+	var ack gw.DownlinkTXAck
+	if err := proto.Unmarshal(reply, &ack); err != nil {
+		return errors.Wrap(err, "gateway/concentratord: unmarshal downlink tx ack error")
+	}
+
+	// the ack is the reply to the downlink command, make sure it can be
+	// related to the downlink frame
+	if len(ack.GatewayId) == 0 {
+		ack.GatewayId = b.gatewayID[:]
+	}
+	if len(ack.DownlinkId) == 0 {
+		ack.DownlinkId = pl.DownlinkId
+	}
+	if ack.Token == 0 {
+		ack.Token = pl.Token
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+		"error":       ack.Error,
+	}).Info("gateway/concentratord: downlink tx ack received")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the
+// gateway.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	if gatewayID != b.gatewayID {
+		return fmt.Errorf("gateway %s is not connected", gatewayID)
+	}
+
+	bb, err := proto.Marshal(&pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/concentratord: marshal gateway configuration error")
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	_, err = b.sendCommand(log.Fields{}, "config", bb)
+	return err
+}
+
+// RXPacketChan returns the channel to which uplink frames are published.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel to which gateway stats are published.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx ack channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// NoiseFloorReportChan returns the channel to which the noise-floor reports
+// are published.
+func (b *Backend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	return b.noiseFloorChan
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	log.Info("gateway/concentratord: closing backend")
+	b.cancel()
+
+	log.Info("gateway/concentratord: handling last messages")
+	b.wg.Wait()
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.closed = true
+	b.resetCommandSock()
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.noiseFloorChan)
+
+	return nil
+}
+
+// sendCommand sends the given command and returns the reply. The caller
+// must hold the lock.
+func (b *Backend) sendCommand(fields log.Fields, command string, data []byte) ([]byte, error) {
+	if b.closed {
+		return nil, errors.New("gateway/concentratord: backend is closed")
+	}
+
+	start := time.Now()
+
+	reply, err := b.command(command, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/concentratord: send command error")
+	}
+
+	fields["duration"] = time.Now().Sub(start)
+	fields["gateway_id"] = b.gatewayID
+	fields["command"] = command
+
+	log.WithFields(fields).Info("gateway/concentratord: command sent")
+
+	concentratordCommandCounter(command).Inc()
+
+	return reply, nil
+}
+
+// commandRequest sends the given command to the command socket and waits
+// for the reply. On error, the command socket is closed so that it is
+// re-dialed on the next request. The caller must hold the lock.
+func (b *Backend) commandRequest(command string, data []byte) ([]byte, error) {
+	if b.commandSock == nil {
+		sock := zmq4.NewReq(b.ctx)
+		if err := sock.Dial(b.commandURL); err != nil {
+			sock.Close()
+			return nil, errors.Wrap(err, "dial command socket error")
+		}
+		b.commandSock = sock
+	}
+
+	if err := b.commandSock.SendMulti(zmq4.NewMsgFrom([]byte(command), data)); err != nil {
+		b.resetCommandSock()
+		return nil, errors.Wrap(err, "send command request error")
+	}
+
+	type result struct {
+		msg zmq4.Msg
+		err error
+	}
+	resultChan := make(chan result, 1)
+	sock := b.commandSock
+	go func() {
+		msg, err := sock.Recv()
+		resultChan <- result{msg: msg, err: err}
+	}()
+
+	select {
+	case res := <-resultChan:
+		if res.err != nil {
+			b.resetCommandSock()
+			return nil, errors.Wrap(res.err, "receive command reply error")
+		}
+		return res.msg.Bytes(), nil
+	case <-time.After(b.commandTimeout):
+		// closing the socket unblocks the pending receive
+		b.resetCommandSock()
+		return nil, errors.New("command reply timeout")
+	}
+}
+
+// resetCommandSock closes the command socket. The caller must hold the lock.
+func (b *Backend) resetCommandSock() {
+	if b.commandSock == nil {
+		return
+	}
+
+	if err := b.commandSock.Close(); err != nil {
+		log.WithError(err).Error("gateway/concentratord: close command socket error")
+	}
+	b.commandSock = nil
+}
+
+// dialEventSock (re-)dials the event socket and subscribes to all events.
+func (b *Backend) dialEventSock() error {
+	if b.eventSock != nil {
+		b.eventSock.Close()
+	}
+
+	b.eventSock = zmq4.NewSub(b.ctx)
+	if err := b.eventSock.Dial(b.eventURL); err != nil {
+		return errors.Wrap(err, "dial error")
+	}
+	if err := b.eventSock.SetOption(zmq4.OptionSubscribe, ""); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	return nil
+}
+
+// eventLoop receives and handles the events until the backend is closed.
+// An event consists of two frames, the event type and the Protobuf encoded
+// event.
+func (b *Backend) eventLoop() {
+	defer b.wg.Done()
+	defer func() {
+		b.eventSock.Close()
+	}()
+
+	for {
+		msg, err := b.eventSock.Recv()
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+
+			log.WithError(err).Error("gateway/concentratord: receive event error")
+
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-time.After(redialInterval):
+			}
+
+			if err := b.dialEventSock(); err != nil {
+				log.WithError(err).Error("gateway/concentratord: dial event socket error")
+			}
+			continue
+		}
+
+		if len(msg.Frames) != 2 {
+			log.WithField("frames", len(msg.Frames)).Warning("gateway/concentratord: expected two frames in event")
+			continue
+		}
+
+		b.handleEvent(string(msg.Frames[0]), msg.Frames[1])
+	}
+}
+
+// handleEvent handles the given event.
+func (b *Backend) handleEvent(typ string, data []byte) {
+	concentratordEventCounter(typ).Inc()
+
+	var err error
+
+	switch typ {
+	case "up":
+		err = b.handleUplinkFrame(data)
+	case "stats":
+		err = b.handleGatewayStats(data)
+	case "noise":
+		err = b.handleNoiseFloorReport(data)
+	default:
+		log.WithField("type", typ).Warning("gateway/concentratord: unexpected event type")
+	}
+
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"type":        typ,
+			"data_base64": base64.StdEncoding.EncodeToString(data),
+		}).Error("gateway/concentratord: handle received event error")
+	}
+}
+
+func (b *Backend) handleUplinkFrame(data []byte) error {
+	var uplinkFrame gw.UplinkFrame
+	if err := proto.Unmarshal(data, &uplinkFrame); err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	if uplinkFrame.RxInfo == nil {
+		return errors.New("rx_info must not be nil")
+	}
+
+	if uplinkFrame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(uplinkFrame.RxInfo),
+		"uplink_id":  helpers.GetUplinkID(uplinkFrame.RxInfo),
+	}).Info("gateway/concentratord: uplink event received")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleGatewayStats(data []byte) error {
+	var gatewayStats gw.GatewayStats
+	if err := proto.Unmarshal(data, &gatewayStats); err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&gatewayStats),
+		"stats_id":   helpers.GetStatsID(&gatewayStats),
+	}).Info("gateway/concentratord: stats event received")
+
+	b.gatewayStatsChan <- gatewayStats
+
+	return nil
+}
+
+func (b *Backend) handleNoiseFloorReport(data []byte) error {
+	var report gw.NoiseFloorReport
+	if err := proto.Unmarshal(data, &report); err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&report),
+		"channels":   len(report.Channels),
+	}).Info("gateway/concentratord: noise-floor event received")
+
+	b.noiseFloorChan <- report
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package concentratord
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+type testCommand struct {
+	command string
+	data    []byte
+}
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend   *Backend
+	commands  []testCommand
+	reply     []byte
+	gatewayID lorawan.EUI64
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	ts.commands = nil
+	ts.reply = nil
+	ts.gatewayID = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts.backend = &Backend{
+		gatewayID:         ts.gatewayID,
+		uplinkFrameChan:   make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:  make(chan gw.GatewayStats, 10),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck, 10),
+		noiseFloorChan:    make(chan gw.NoiseFloorReport, 10),
+	}
+	ts.backend.command = func(command string, data []byte) ([]byte, error) {
+		ts.commands = append(ts.commands, testCommand{command: command, data: data})
+		return ts.reply, nil
+	}
+}
+
+func (ts *BackendTestSuite) TestUplinkFrame() {
+	assert := require.New(ts.T())
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: ts.gatewayID[:],
+		},
+	}
+	b, err := proto.Marshal(&uplinkFrame)
+	assert.NoError(err)
+
+	ts.backend.handleEvent("up", b)
+
+	received := <-ts.backend.uplinkFrameChan
+	assert.Equal(uplinkFrame.PhyPayload, received.PhyPayload)
+}
+
+func (ts *BackendTestSuite) TestGatewayStats() {
+	assert := require.New(ts.T())
+
+	stats := gw.GatewayStats{
+		GatewayId:         ts.gatewayID[:],
+		RxPacketsReceived: 10,
+	}
+	b, err := proto.Marshal(&stats)
+	assert.NoError(err)
+
+	ts.backend.handleEvent("stats", b)
+
+	received := <-ts.backend.gatewayStatsChan
+	assert.EqualValues(10, received.RxPacketsReceived)
+}
+
+func (ts *BackendTestSuite) TestNoiseFloorReport() {
+	assert := require.New(ts.T())
+
+	report := gw.NoiseFloorReport{
+		GatewayId: ts.gatewayID[:],
+		Channels: []*gw.ChannelNoiseFloor{
+			{
+				Frequency:  868100000,
+				NoiseFloor: -118.5,
+			},
+		},
+	}
+	b, err := proto.Marshal(&report)
+	assert.NoError(err)
+
+	ts.backend.handleEvent("noise", b)
+
+	received := <-ts.backend.noiseFloorChan
+	assert.True(proto.Equal(&report, &received))
+}
+
+func (ts *BackendTestSuite) TestSendTXPacket() {
+	ts.T().Run("Acknowledged", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+
+		var err error
+		ts.reply, err = proto.Marshal(&gw.DownlinkTXAck{
+			Error: "TOO_LATE",
+		})
+		assert.NoError(err)
+
+		downlinkFrame := gw.DownlinkFrame{
+			PhyPayload: []byte{1, 2, 3, 4},
+			Token:      12345,
+			DownlinkId: []byte{1, 2, 3},
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+			},
+		}
+		assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+		assert.Len(ts.commands, 1)
+		assert.Equal("down", ts.commands[0].command)
+
+		var received gw.DownlinkFrame
+		assert.NoError(proto.Unmarshal(ts.commands[0].data, &received))
+		assert.Equal(downlinkFrame.PhyPayload, received.PhyPayload)
+
+		ack := <-ts.backend.downlinkTXAckChan
+		assert.Equal(gw.DownlinkTXAck{
+			GatewayId:  ts.gatewayID[:],
+			Token:      12345,
+			DownlinkId: []byte{1, 2, 3},
+			Error:      "TOO_LATE",
+		}, ack)
+	})
+
+	ts.T().Run("Other gateway", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+
+		gatewayID := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		assert.Error(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayID[:],
+			},
+		}))
+		assert.Len(ts.commands, 0)
+	})
+
+	ts.T().Run("Closed", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+		ts.backend.closed = true
+
+		assert.Error(ts.backend.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: ts.gatewayID[:],
+			},
+		}))
+		assert.Len(ts.commands, 0)
+	})
+}
+
+func (ts *BackendTestSuite) TestSendGatewayConfigPacket() {
+	assert := require.New(ts.T())
+
+	assert.NoError(ts.backend.SendGatewayConfigPacket(gw.GatewayConfiguration{
+		GatewayId: ts.gatewayID[:],
+		Version:   "12345",
+	}))
+
+	assert.Len(ts.commands, 1)
+	assert.Equal("config", ts.commands[0].command)
+
+	var received gw.GatewayConfiguration
+	assert.NoError(proto.Unmarshal(ts.commands[0].data, &received))
+	assert.Equal("12345", received.Version)
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
@@ -1,9 +1,18 @@
 package gateway
 
-import "github.com/mxc-foundation/lpwan-server/api/gw"
+import (
+	"errors"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
 
 var backend Gateway
 
+// ErrNotSupported is returned when the gateway backend does not support the
+// requested operation.
+var ErrNotSupported = errors.New("not supported by gateway backend")
+
 // Backend returns the gateway backend.
 func Backend() Gateway {
 	return backend
@@ -24,3 +33,47 @@ type Gateway interface {
 	DownlinkTXAckChan() chan gw.DownlinkTXAck              // channel containing the downlink tx acknowledgements
 	Close() error                                          // close the gateway backend.
 }
+
+// ConnStateBackend is implemented by gateway backends which receive the
+// connection state of the gateways (e.g. the MQTT last-will messages of the
+// LoRa Gateway Bridge).
+type ConnStateBackend interface {
+	// ConnStateChan returns the channel containing the received connection
+	// states. The channel is closed when the backend is closed. Wrapping
+	// backends return nil when the wrapped backend does not support this.
+	ConnStateChan() chan gw.ConnState
+}
+
+// CommandExecBackend is implemented by gateway backends which are able to
+// relay command execution requests to the gateway bridge (e.g. to reboot the
+// gateway) and to receive the command execution responses.
+type CommandExecBackend interface {
+	// SendGatewayCommandExecRequest sends the given command execution request
+	// to the gateway. Wrapping backends return ErrNotSupported when the
+	// wrapped backend does not support this.
+	SendGatewayCommandExecRequest(gw.GatewayCommandExecRequest) error
+
+	// GatewayCommandExecResponseChan returns the channel containing the
+	// received command execution responses. The channel is closed when the
+	// backend is closed. Wrapping backends return nil when the wrapped
+	// backend does not support this.
+	GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse
+}
+
+// NoiseFloorBackend is implemented by gateway backends which receive the
+// noise-floor / spectral-scan reports of the gateways.
+type NoiseFloorBackend interface {
+	// NoiseFloorReportChan returns the channel containing the received
+	// noise-floor reports. The channel is closed when the backend is closed.
+	// Wrapping backends return nil when the wrapped backend does not support
+	// this.
+	NoiseFloorReportChan() chan gw.NoiseFloorReport
+}
+
+// CredentialsUpdater is implemented by gateway backends of which the
+// credentials can be updated at runtime (e.g. on configuration reload).
+type CredentialsUpdater interface {
+	// UpdateCredentials updates the credentials from the given configuration.
+	// The backend must keep using its current credentials on error.
+	UpdateCredentials(config.Config) error
+}
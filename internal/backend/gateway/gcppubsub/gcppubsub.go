@@ -6,7 +6,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -20,13 +19,13 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 const uplinkSubscriptionTmpl = "%s-loraserver"
 
 // Backend implements a Google Cloud Pub/Sub backend.
 type Backend struct {
-	sync.RWMutex
 	ctx    context.Context
 	cancel context.CancelFunc
 
@@ -38,7 +37,7 @@ type Backend struct {
 	uplinkFrameChan   chan gw.UplinkFrame
 	gatewayStatsChan  chan gw.GatewayStats
 	downlinkTXAckChan chan gw.DownlinkTXAck
-	gatewayMarshaler  map[lorawan.EUI64]marshaler.Type
+	gatewayMarshalers *marshaler.GatewayMarshalers
 }
 
 // NewBackend creates a new Backend.
@@ -46,7 +45,7 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 	conf := c.NetworkServer.Gateway.Backend.GCPPubSub
 
 	b := Backend{
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(storage.RedisPool()),
 		uplinkFrameChan:   make(chan gw.UplinkFrame),
 		gatewayStatsChan:  make(chan gw.GatewayStats),
 		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
@@ -103,6 +102,9 @@ func NewBackend(c config.Config) (gateway.Gateway, error) {
 			Topic:             b.uplinkTopic,
 			RetentionDuration: conf.UplinkRetentionDuration,
 		})
+		if err != nil {
+			return nil, errors.Wrap(err, "gateway/gcp_pub_sub: create subscription error")
+		}
 	}
 
 	// consume uplink frames
@@ -181,17 +183,11 @@ func (b *Backend) Close() error {
 }
 
 func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
-	b.Lock()
-	defer b.Unlock()
-
-	b.gatewayMarshaler[gatewayID] = t
+	b.gatewayMarshalers.Set(gatewayID, t)
 }
 
 func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
-	b.RLock()
-	defer b.RUnlock()
-
-	return b.gatewayMarshaler[gatewayID]
+	return b.gatewayMarshalers.Get(gatewayID)
 }
 
 func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, data []byte) error {
@@ -227,16 +223,19 @@ func (b *Backend) receiveFunc(ctx context.Context, msg *pubsub.Message) {
 	gatewayIDStr, ok := msg.Attributes["deviceId"]
 	if !ok {
 		log.Error("gateway/gcp_pub_sub: received message does not contain 'deviceId' attribute")
+		return
 	}
 
 	typ, ok := msg.Attributes["subFolder"]
 	if !ok {
 		log.Error("gateway/gcp_pub_sub: received message does not contain 'subFolder' attribute")
+		return
 	}
 
 	gatewayIDStr = strings.Replace(gatewayIDStr, "gw-", "", 1)
 	if err := gatewayID.UnmarshalText([]byte(gatewayIDStr)); err != nil {
 		log.WithError(err).Error("gateway/gcp_pub_sub: unmarshal gateway id error")
+		return
 	}
 
 	gcpEventCounter(typ).Inc()
@@ -19,7 +19,7 @@ type BackendTestSuite struct {
 
 func (ts *BackendTestSuite) SetupSuite() {
 	ts.backend = &Backend{
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(nil),
 		uplinkFrameChan:   make(chan gw.UplinkFrame, 10),
 		gatewayStatsChan:  make(chan gw.GatewayStats, 10),
 		downlinkTXAckChan: make(chan gw.DownlinkTXAck, 10),
@@ -74,7 +74,7 @@ func (ts *BackendTestSuite) TestHandleUplinkFrame() {
 			assert.NoError(test.ExpectedError)
 		}
 
-		assert.Equal(marshaler.Protobuf, ts.backend.gatewayMarshaler[gatewayID])
+		assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
 
 		if err != nil {
 			continue
@@ -115,7 +115,7 @@ func (ts *BackendTestSuite) TestHandleGatewayStats() {
 			assert.NoError(test.ExpectedError)
 		}
 
-		assert.Equal(marshaler.Protobuf, ts.backend.gatewayMarshaler[gatewayID])
+		assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
 
 		if err != nil {
 			continue
@@ -156,7 +156,7 @@ func (ts *BackendTestSuite) TestHandleDownlinkTXAck() {
 			assert.NoError(test.ExpectedError)
 		}
 
-		assert.Equal(marshaler.Protobuf, ts.backend.gatewayMarshaler[gatewayID])
+		assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
 
 		if err != nil {
 			continue
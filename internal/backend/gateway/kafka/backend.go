@@ -0,0 +1,482 @@
+// Package kafka implements a Kafka gateway backend. The gateway events (uplink
+// frames, stats and TX acknowledgements) are consumed from the event topics
+// using a consumer-group, so that the events are distributed over the
+// LoRa Server instances sharing the same group ID. The gateway commands are
+// produced to the command topics, using the gateway ID as message key.
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	kafka "github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const (
+	// eventHeader contains the event type of the message. It is only
+	// required when multiple event types share the same topic.
+	eventHeader = "event"
+
+	// commandHeader contains the command type of the message.
+	commandHeader = "command"
+)
+
+// eventTypes contains the event types consumed by the backend.
+var eventTypes = []string{"up", "stats", "ack"}
+
+// messageWriter defines the interface for producing messages to a topic.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Backend implements a Kafka backend.
+type Backend struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	readers []*kafka.Reader
+
+	writersMux sync.Mutex
+	writers    map[string]messageWriter
+	newWriter  func(topic string) messageWriter
+
+	commandTopicTemplate *template.Template
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+	gatewayMarshalers *marshaler.GatewayMarshalers
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.Kafka
+
+	b := Backend{
+		writers:           make(map[string]messageWriter),
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(storage.RedisPool()),
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	if len(conf.Brokers) == 0 {
+		return nil, errors.New("gateway/kafka: at least one broker must be configured")
+	}
+
+	var err error
+	b.commandTopicTemplate, err = template.New("command").Parse(conf.CommandTopicTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/kafka: parse command topic template error")
+	}
+
+	eventTopics, err := getEventTopics(conf.EventTopicTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/kafka: get event topics error")
+	}
+
+	tlsConfig, err := newTLSConfig(conf.TLS, conf.CACert, conf.TLSCert, conf.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/kafka: new tls config error")
+	}
+
+	dialer := &kafka.Dialer{
+		Timeout:   10 * time.Second,
+		DualStack: true,
+		TLS:       tlsConfig,
+	}
+
+	b.newWriter = func(topic string) messageWriter {
+		return kafka.NewWriter(kafka.WriterConfig{
+			Brokers:  conf.Brokers,
+			Topic:    topic,
+			Dialer:   dialer,
+			Balancer: &kafka.Hash{},
+		})
+	}
+
+	for topic, types := range eventTopics {
+		log.WithFields(log.Fields{
+			"topic":    topic,
+			"group_id": conf.GroupID,
+		}).Info("gateway/kafka: consuming event topic")
+
+		r := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  conf.Brokers,
+			GroupID:  conf.GroupID,
+			Topic:    topic,
+			Dialer:   dialer,
+			MinBytes: 1,
+			MaxBytes: 10e6,
+		})
+		b.readers = append(b.readers, r)
+
+		b.wg.Add(1)
+		go b.consume(r, types)
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalDownlinkFrame(t, pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/kafka: marshal downlink frame error")
+	}
+
+	return b.publishCommand(log.Fields{
+		"downlink_id": downID,
+	}, gatewayID, "down", bb)
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the gateway.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalGatewayConfiguration(t, pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/kafka: marshal gateway configuration error")
+	}
+
+	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
+}
+
+// RXPacketChan returns the channel to which uplink frames are published.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel to which gateway stats are published.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx ack channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	log.Info("gateway/kafka: closing backend")
+	b.cancel()
+
+	for _, r := range b.readers {
+		if err := r.Close(); err != nil {
+			log.WithError(err).Error("gateway/kafka: close reader error")
+		}
+	}
+
+	log.Info("gateway/kafka: handling last messages")
+	b.wg.Wait()
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+
+	b.writersMux.Lock()
+	defer b.writersMux.Unlock()
+
+	for topic, w := range b.writers {
+		if err := w.Close(); err != nil {
+			log.WithError(err).WithField("topic", topic).Error("gateway/kafka: close writer error")
+		}
+	}
+
+	return nil
+}
+
+func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
+	b.gatewayMarshalers.Set(gatewayID, t)
+}
+
+func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
+	return b.gatewayMarshalers.Get(gatewayID)
+}
+
+// getWriter returns the writer for the given topic. Writers are created on
+// first use, as the command topic template can contain the gateway ID.
+func (b *Backend) getWriter(topic string) messageWriter {
+	b.writersMux.Lock()
+	defer b.writersMux.Unlock()
+
+	w, ok := b.writers[topic]
+	if !ok {
+		w = b.newWriter(topic)
+		b.writers[topic] = w
+	}
+
+	return w
+}
+
+func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, data []byte) error {
+	start := time.Now()
+
+	topic := bytes.NewBuffer(nil)
+	if err := b.commandTopicTemplate.Execute(topic, struct {
+		GatewayID   lorawan.EUI64
+		CommandType string
+	}{gatewayID, command}); err != nil {
+		return errors.Wrap(err, "execute command topic template error")
+	}
+
+	err := b.getWriter(topic.String()).WriteMessages(b.ctx, kafka.Message{
+		Key:   []byte(gatewayID.String()),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: commandHeader, Value: []byte(command)},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "write message error")
+	}
+
+	fields["duration"] = time.Now().Sub(start)
+	fields["gateway_id"] = gatewayID
+	fields["command"] = command
+	fields["topic"] = topic.String()
+
+	log.WithFields(fields).Info("gateway/kafka: message published")
+
+	kafkaCommandCounter(command).Inc()
+
+	return nil
+}
+
+// consume reads the messages of the given reader until the backend is
+// closed. The given event types are the event types of the topic.
+func (b *Backend) consume(r *kafka.Reader, types []string) {
+	defer b.wg.Done()
+
+	for {
+		msg, err := r.ReadMessage(b.ctx)
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+
+			log.WithError(err).Error("gateway/kafka: read message error")
+			time.Sleep(2 * time.Second)
+			continue
+		}
+
+		b.handleMessage(types, msg)
+	}
+}
+
+func (b *Backend) handleMessage(types []string, msg kafka.Message) {
+	var gatewayID lorawan.EUI64
+	if err := gatewayID.UnmarshalText(msg.Key); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic).Error("gateway/kafka: unmarshal gateway id error")
+		return
+	}
+
+	typ := getHeader(msg, eventHeader)
+	if typ == "" && len(types) == 1 {
+		typ = types[0]
+	}
+
+	kafkaEventCounter(typ).Inc()
+
+	var err error
+
+	switch typ {
+	case "up":
+		err = b.handleUplinkFrame(gatewayID, msg.Value)
+	case "stats":
+		err = b.handleGatewayStats(gatewayID, msg.Value)
+	case "ack":
+		err = b.handleDownlinkTXAck(gatewayID, msg.Value)
+	default:
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"type":       typ,
+			"topic":      msg.Topic,
+		}).Warning("gateway/kafka: unexpected message type")
+	}
+
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"gateway_id":  gatewayID,
+			"type":        typ,
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Value),
+		}).Error("gateway/kafka: handle received message error")
+	}
+}
+
+func (b *Backend) handleUplinkFrame(gatewayID lorawan.EUI64, data []byte) error {
+	var uplinkFrame gw.UplinkFrame
+	t, err := marshaler.UnmarshalUplinkFrame(data, &uplinkFrame)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	if uplinkFrame.RxInfo == nil {
+		return errors.New("rx_info must not be nil")
+	}
+
+	if uplinkFrame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	// make sure that the message key matches the gateway_id of the payload
+	if !bytes.Equal(uplinkFrame.RxInfo.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	upID := helpers.GetUplinkID(uplinkFrame.RxInfo)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  upID,
+	}).Info("gateway/kafka: uplink event received")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleGatewayStats(gatewayID lorawan.EUI64, data []byte) error {
+	var gatewayStats gw.GatewayStats
+	t, err := marshaler.UnmarshalGatewayStats(data, &gatewayStats)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the message key matches the gateway_id of the payload
+	if !bytes.Equal(gatewayStats.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	statsID := helpers.GetStatsID(&gatewayStats)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"stats_id":   statsID,
+	}).Info("gateway/kafka: stats event received")
+
+	b.gatewayStatsChan <- gatewayStats
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTXAck(gatewayID lorawan.EUI64, data []byte) error {
+	var ack gw.DownlinkTXAck
+	t, err := marshaler.UnmarshalDownlinkTXAck(data, &ack)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	b.setGatewayMarshaler(gatewayID, t)
+
+	// make sure that the message key matches the gateway_id of the payload
+	if !bytes.Equal(ack.GatewayId, gatewayID[:]) {
+		return errors.New("gateway_id is not equal to expected gateway_id")
+	}
+
+	downID := helpers.GetDownlinkID(&ack)
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/kafka: ack event received")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+// getEventTopics renders the event topic template for each event type and
+// returns the event types per topic. As the topics are consumed, the
+// template can only contain the EventType field.
+func getEventTopics(templ string) (map[string][]string, error) {
+	t, err := template.New("event").Parse(templ)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse event topic template error")
+	}
+
+	out := make(map[string][]string)
+	for _, typ := range eventTypes {
+		topic := bytes.NewBuffer(nil)
+		if err := t.Execute(topic, struct {
+			EventType string
+		}{typ}); err != nil {
+			return nil, errors.Wrap(err, "execute event topic template error")
+		}
+
+		out[topic.String()] = append(out[topic.String()], typ)
+	}
+
+	return out, nil
+}
+
+func getHeader(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func newTLSConfig(enabled bool, cafile, certFile, certKeyFile string) (*tls.Config, error) {
+	if !enabled && cafile == "" && certFile == "" && certKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cafile != "" {
+		cacert, err := nstls.ReadPEM(cafile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca certificate error")
+		}
+		certpool := x509.NewCertPool()
+		certpool.AppendCertsFromPEM(cacert)
+
+		tlsConfig.RootCAs = certpool
+	}
+
+	if certFile != "" && certKeyFile != "" {
+		kp, err := nstls.LoadX509KeyPair(certFile, certKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{kp}
+	}
+
+	return tlsConfig, nil
+}
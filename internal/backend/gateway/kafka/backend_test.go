@@ -0,0 +1,226 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+)
+
+type testWriter struct {
+	topic    string
+	messages []kafka.Message
+}
+
+func (w *testWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *testWriter) Close() error {
+	return nil
+}
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend *Backend
+	writers map[string]*testWriter
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	ts.writers = make(map[string]*testWriter)
+	ts.backend = &Backend{
+		ctx:                  context.Background(),
+		writers:              make(map[string]messageWriter),
+		commandTopicTemplate: template.Must(template.New("command").Parse("gateway.{{ .GatewayID }}.command.{{ .CommandType }}")),
+		gatewayMarshalers:    marshaler.NewGatewayMarshalers(nil),
+		uplinkFrameChan:      make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:     make(chan gw.GatewayStats, 10),
+		downlinkTXAckChan:    make(chan gw.DownlinkTXAck, 10),
+	}
+	ts.backend.newWriter = func(topic string) messageWriter {
+		w := &testWriter{topic: topic}
+		ts.writers[topic] = w
+		return w
+	}
+}
+
+func (ts *BackendTestSuite) TestGetEventTopics() {
+	tests := []struct {
+		Name          string
+		Template      string
+		Expected      map[string][]string
+		ExpectedError bool
+	}{
+		{
+			Name:     "topic per event type",
+			Template: "gateway.event.{{ .EventType }}",
+			Expected: map[string][]string{
+				"gateway.event.up":    {"up"},
+				"gateway.event.stats": {"stats"},
+				"gateway.event.ack":   {"ack"},
+			},
+		},
+		{
+			Name:     "single topic",
+			Template: "gateway.events",
+			Expected: map[string][]string{
+				"gateway.events": {"up", "stats", "ack"},
+			},
+		},
+		{
+			Name:          "gateway specific topic",
+			Template:      "gateway.{{ .GatewayID }}.events",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			topics, err := getEventTopics(tst.Template)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, topics)
+		})
+	}
+}
+
+func (ts *BackendTestSuite) TestUplinkFrame() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}
+	b, err := proto.Marshal(&uplinkFrame)
+	assert.NoError(err)
+
+	ts.T().Run("Event type from topic", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.backend.handleMessage([]string{"up"}, kafka.Message{
+			Key:   []byte(gatewayID.String()),
+			Value: b,
+		})
+
+		received := <-ts.backend.uplinkFrameChan
+		assert.Equal(uplinkFrame.PhyPayload, received.PhyPayload)
+		assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
+	})
+
+	ts.T().Run("Event type from header", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.backend.handleMessage(eventTypes, kafka.Message{
+			Key:   []byte(gatewayID.String()),
+			Value: b,
+			Headers: []kafka.Header{
+				{Key: eventHeader, Value: []byte("up")},
+			},
+		})
+
+		received := <-ts.backend.uplinkFrameChan
+		assert.Equal(uplinkFrame.PhyPayload, received.PhyPayload)
+	})
+
+	ts.T().Run("Gateway ID mismatch", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.backend.handleMessage([]string{"up"}, kafka.Message{
+			Key:   []byte("0807060504030201"),
+			Value: b,
+		})
+
+		assert.Len(ts.backend.uplinkFrameChan, 0)
+	})
+}
+
+func (ts *BackendTestSuite) TestGatewayStats() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	stats := gw.GatewayStats{
+		GatewayId:         gatewayID[:],
+		RxPacketsReceived: 10,
+	}
+	b, err := proto.Marshal(&stats)
+	assert.NoError(err)
+
+	ts.backend.handleMessage([]string{"stats"}, kafka.Message{
+		Key:   []byte(gatewayID.String()),
+		Value: b,
+	})
+
+	received := <-ts.backend.gatewayStatsChan
+	assert.EqualValues(10, received.RxPacketsReceived)
+}
+
+func (ts *BackendTestSuite) TestDownlinkTXAck() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ack := gw.DownlinkTXAck{
+		GatewayId: gatewayID[:],
+		Token:     12345,
+	}
+	b, err := proto.Marshal(&ack)
+	assert.NoError(err)
+
+	ts.backend.handleMessage([]string{"ack"}, kafka.Message{
+		Key:   []byte(gatewayID.String()),
+		Value: b,
+	})
+
+	received := <-ts.backend.downlinkTXAckChan
+	assert.EqualValues(12345, received.Token)
+}
+
+func (ts *BackendTestSuite) TestSendTXPacket() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts.backend.setGatewayMarshaler(gatewayID, marshaler.Protobuf)
+
+	downlinkFrame := gw.DownlinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		Token:      12345,
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}
+	assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+	w, ok := ts.writers["gateway.0102030405060708.command.down"]
+	assert.True(ok)
+	assert.Len(w.messages, 1)
+	assert.Equal([]byte("0102030405060708"), w.messages[0].Key)
+	assert.Equal([]kafka.Header{{Key: commandHeader, Value: []byte("down")}}, w.messages[0].Headers)
+
+	var received gw.DownlinkFrame
+	assert.NoError(proto.Unmarshal(w.messages[0].Value, &received))
+	assert.Equal(downlinkFrame.PhyPayload, received.PhyPayload)
+	assert.Equal(downlinkFrame.Token, received.Token)
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
@@ -0,0 +1,27 @@
+package marshaler
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+// UnmarshalConnState unmarshals a ConnState.
+func UnmarshalConnState(b []byte, state *gw.ConnState) (Type, error) {
+	t := getType(b)
+
+	switch t {
+	case Protobuf:
+		return t, proto.Unmarshal(b, state)
+	case JSON:
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return t, m.Unmarshal(bytes.NewReader(b), state)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,48 @@
+package marshaler
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestUnmarshalConnState(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		assert := require.New(t)
+
+		in := gw.ConnState{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			State:     gw.ConnState_ONLINE,
+		}
+		m := jsonpb.Marshaler{}
+		str, err := m.MarshalToString(&in)
+		assert.NoError(err)
+
+		var out gw.ConnState
+		typ, err := UnmarshalConnState([]byte(str), &out)
+		assert.NoError(err)
+		assert.Equal(JSON, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+
+	t.Run("Protobuf", func(t *testing.T) {
+		assert := require.New(t)
+
+		in := gw.ConnState{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			State:     gw.ConnState_ONLINE,
+		}
+		b, err := proto.Marshal(&in)
+		assert.NoError(err)
+
+		var out gw.ConnState
+		typ, err := UnmarshalConnState(b, &out)
+		assert.NoError(err)
+		assert.Equal(Protobuf, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+}
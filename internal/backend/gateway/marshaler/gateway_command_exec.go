@@ -0,0 +1,27 @@
+package marshaler
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+// UnmarshalGatewayCommandExecResponse unmarshals a GatewayCommandExecResponse.
+func UnmarshalGatewayCommandExecResponse(b []byte, resp *gw.GatewayCommandExecResponse) (Type, error) {
+	t := getType(b)
+
+	switch t {
+	case Protobuf:
+		return t, proto.Unmarshal(b, resp)
+	case JSON:
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return t, m.Unmarshal(bytes.NewReader(b), resp)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,50 @@
+package marshaler
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestUnmarshalGatewayCommandExecResponse(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		assert := require.New(t)
+
+		in := gw.GatewayCommandExecResponse{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			ExecId:    []byte{1, 2, 3, 4},
+			Stdout:    []byte("hello"),
+		}
+		m := jsonpb.Marshaler{}
+		str, err := m.MarshalToString(&in)
+		assert.NoError(err)
+
+		var out gw.GatewayCommandExecResponse
+		typ, err := UnmarshalGatewayCommandExecResponse([]byte(str), &out)
+		assert.NoError(err)
+		assert.Equal(JSON, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+
+	t.Run("Protobuf", func(t *testing.T) {
+		assert := require.New(t)
+
+		in := gw.GatewayCommandExecResponse{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			ExecId:    []byte{1, 2, 3, 4},
+			Stdout:    []byte("hello"),
+		}
+		b, err := proto.Marshal(&in)
+		assert.NoError(err)
+
+		var out gw.GatewayCommandExecResponse
+		typ, err := UnmarshalGatewayCommandExecResponse(b, &out)
+		assert.NoError(err)
+		assert.Equal(Protobuf, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+}
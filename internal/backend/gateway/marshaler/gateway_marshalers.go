@@ -0,0 +1,119 @@
+package marshaler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	// cacheDuration defines how long the marshaler type of a gateway is used
+	// from memory, before it is re-read from Redis. This makes sure that an
+	// encoding change detected by an other instance is picked up.
+	cacheDuration = time.Minute
+
+	// storeInterval defines the interval in which an unchanged marshaler
+	// type is re-stored in Redis, to refresh its expiration.
+	storeInterval = time.Hour
+)
+
+type gatewayMarshaler struct {
+	t         Type
+	updatedAt time.Time
+	storedAt  time.Time
+}
+
+// GatewayMarshalers keeps track of the marshaler type last used by each
+// gateway, so that the commands sent to a gateway are marshaled using the
+// same encoding. When a Redis pool is given, the marshaler types are shared
+// between the LoRa Server instances, so that an instance which did not
+// receive any events of the gateway (e.g. when using shared subscriptions)
+// still uses the right encoding.
+type GatewayMarshalers struct {
+	sync.RWMutex
+
+	redisPool storage.RedisClient
+	items     map[lorawan.EUI64]gatewayMarshaler
+}
+
+// NewGatewayMarshalers creates a new GatewayMarshalers. The Redis pool is
+// optional.
+func NewGatewayMarshalers(p storage.RedisClient) *GatewayMarshalers {
+	return &GatewayMarshalers{
+		redisPool: p,
+		items:     make(map[lorawan.EUI64]gatewayMarshaler),
+	}
+}
+
+// Set sets the marshaler type last used by the given gateway.
+func (g *GatewayMarshalers) Set(gatewayID lorawan.EUI64, t Type) {
+	now := time.Now()
+
+	g.Lock()
+	item, ok := g.items[gatewayID]
+	changed := !ok || item.t != t
+	store := g.redisPool != nil && (changed || now.Sub(item.storedAt) > storeInterval)
+
+	item.t = t
+	item.updatedAt = now
+	if store {
+		item.storedAt = now
+	}
+	g.items[gatewayID] = item
+	g.Unlock()
+
+	if changed {
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"marshaler":  t,
+		}).Debug("gateway/marshaler: gateway marshaler detected")
+	}
+
+	if store {
+		if err := storage.SetGatewayMarshaler(context.Background(), g.redisPool, gatewayID, t.String()); err != nil {
+			log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/marshaler: store gateway marshaler error")
+		}
+	}
+}
+
+// Get returns the marshaler type last used by the given gateway. It returns
+// Protobuf when the marshaler type of the gateway is unknown.
+func (g *GatewayMarshalers) Get(gatewayID lorawan.EUI64) Type {
+	now := time.Now()
+
+	g.RLock()
+	item, ok := g.items[gatewayID]
+	g.RUnlock()
+
+	if g.redisPool == nil || (ok && now.Sub(item.updatedAt) < cacheDuration) {
+		return item.t
+	}
+
+	s, err := storage.GetGatewayMarshaler(context.Background(), g.redisPool, gatewayID)
+	if err != nil {
+		if err != storage.ErrDoesNotExist {
+			log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/marshaler: get gateway marshaler error")
+		}
+		return item.t
+	}
+
+	t, err := ParseType(s)
+	if err != nil {
+		log.WithError(err).WithField("gateway_id", gatewayID).Error("gateway/marshaler: parse gateway marshaler error")
+		return item.t
+	}
+
+	g.Lock()
+	item = g.items[gatewayID]
+	item.t = t
+	item.updatedAt = now
+	g.items[gatewayID] = item
+	g.Unlock()
+
+	return t
+}
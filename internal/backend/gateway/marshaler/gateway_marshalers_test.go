@@ -0,0 +1,59 @@
+package marshaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestGetType(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(JSON, getType([]byte(`{"gatewayID": "AQIDBAUGBwg="}`)))
+	assert.Equal(JSON, getType([]byte(" \n{\"gatewayId\": \"AQIDBAUGBwg=\"}")))
+	assert.Equal(Protobuf, getType([]byte{0x0a, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}))
+	assert.Equal(Protobuf, getType(nil))
+}
+
+func TestGatewayMarshalers(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	t.Run("Without Redis", func(t *testing.T) {
+		assert := require.New(t)
+
+		g := NewGatewayMarshalers(nil)
+		assert.Equal(Protobuf, g.Get(gatewayID))
+
+		g.Set(gatewayID, JSON)
+		assert.Equal(JSON, g.Get(gatewayID))
+	})
+
+	t.Run("With Redis", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf := test.GetConfig()
+		assert.NoError(storage.Setup(conf))
+		test.MustFlushRedis(storage.RedisPool())
+
+		g1 := NewGatewayMarshalers(storage.RedisPool())
+		g2 := NewGatewayMarshalers(storage.RedisPool())
+
+		// the marshaler set by one instance is used by the other instance
+		g1.Set(gatewayID, JSON)
+		assert.Equal(JSON, g2.Get(gatewayID))
+
+		// the change is picked up once the cached type has expired
+		g1.Set(gatewayID, Protobuf)
+		assert.Equal(JSON, g2.Get(gatewayID))
+
+		item := g2.items[gatewayID]
+		item.updatedAt = time.Now().Add(-cacheDuration)
+		g2.items[gatewayID] = item
+		assert.Equal(Protobuf, g2.Get(gatewayID))
+	})
+}
@@ -2,7 +2,6 @@ package marshaler
 
 import (
 	"bytes"
-	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
@@ -12,13 +11,7 @@ import (
 
 // UnmarshalGatewayStats unmarshals an GatewayStats.
 func UnmarshalGatewayStats(b []byte, stats *gw.GatewayStats) (Type, error) {
-	var t Type
-
-	if strings.Contains(string(b), `"gatewayID"`) {
-		t = JSON
-	} else {
-		t = Protobuf
-	}
+	t := getType(b)
 
 	switch t {
 	case Protobuf:
@@ -1,5 +1,10 @@
 package marshaler
 
+import (
+	"bytes"
+	"fmt"
+)
+
 // Type defines the marshaler type.
 type Type int
 
@@ -8,3 +13,40 @@ const (
 	Protobuf Type = iota
 	JSON
 )
+
+// String implements the fmt.Stringer interface.
+func (t Type) String() string {
+	switch t {
+	case Protobuf:
+		return "protobuf"
+	case JSON:
+		return "json"
+	default:
+		return fmt.Sprintf("unknown_%d", int(t))
+	}
+}
+
+// ParseType parses the given marshaler type name.
+func ParseType(s string) (Type, error) {
+	switch s {
+	case "protobuf":
+		return Protobuf, nil
+	case "json":
+		return JSON, nil
+	default:
+		return Protobuf, fmt.Errorf("unknown marshaler type: %s", s)
+	}
+}
+
+// getType returns the marshaler type of the given payload. A JSON object
+// always starts with '{' (ignoring whitespace), which as first byte of a
+// Protobuf message would mean field 15 with the (unused) start-group wire
+// type. Unlike matching a field name, this does not depend on the casing of
+// the JSON field names.
+func getType(b []byte) Type {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	if len(b) != 0 && b[0] == '{' {
+		return JSON
+	}
+	return Protobuf
+}
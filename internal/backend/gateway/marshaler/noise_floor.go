@@ -0,0 +1,27 @@
+package marshaler
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+// UnmarshalNoiseFloorReport unmarshals a NoiseFloorReport.
+func UnmarshalNoiseFloorReport(b []byte, report *gw.NoiseFloorReport) (Type, error) {
+	t := getType(b)
+
+	switch t {
+	case Protobuf:
+		return t, proto.Unmarshal(b, report)
+	case JSON:
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return t, m.Unmarshal(bytes.NewReader(b), report)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,56 @@
+package marshaler
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestUnmarshalNoiseFloorReport(t *testing.T) {
+	in := gw.NoiseFloorReport{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Channels: []*gw.ChannelNoiseFloor{
+			{
+				Frequency:          868100000,
+				Bandwidth:          125000,
+				NoiseFloor:         -118.5,
+				ChannelUtilization: 0.25,
+				RssiHistogram: map[int32]uint32{
+					-120: 10,
+					-100: 2,
+				},
+			},
+		},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		assert := require.New(t)
+
+		m := jsonpb.Marshaler{}
+		str, err := m.MarshalToString(&in)
+		assert.NoError(err)
+
+		var out gw.NoiseFloorReport
+		typ, err := UnmarshalNoiseFloorReport([]byte(str), &out)
+		assert.NoError(err)
+		assert.Equal(JSON, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+
+	t.Run("Protobuf", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := proto.Marshal(&in)
+		assert.NoError(err)
+
+		var out gw.NoiseFloorReport
+		typ, err := UnmarshalNoiseFloorReport(b, &out)
+		assert.NoError(err)
+		assert.Equal(Protobuf, typ)
+		assert.True(proto.Equal(&in, &out))
+	})
+}
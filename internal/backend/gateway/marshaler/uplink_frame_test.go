@@ -54,3 +54,31 @@ func TestUnmarshalUplinkFrame(t *testing.T) {
 		assert.True(proto.Equal(&in, &out))
 	})
 }
+
+func BenchmarkUnmarshalUplinkFrame(b *testing.B) {
+	in := gw.UplinkFrame{
+		PhyPayload: []byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x00, 0x0a, 0x00, 0x01, 0x02, 0x03, 0x04},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Rssi:      -50,
+			LoraSnr:   5.5,
+		},
+	}
+	pl, err := proto.Marshal(&in)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var out gw.UplinkFrame
+		if _, err := UnmarshalUplinkFrame(pl, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
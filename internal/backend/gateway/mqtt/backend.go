@@ -7,7 +7,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"io/ioutil"
+	"hash/fnv"
 	"strings"
 	"sync"
 	"text/template"
@@ -25,6 +25,9 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/partition"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
 )
 
 const uplinkLockTTL = time.Millisecond * 500
@@ -38,36 +41,89 @@ const (
 
 // Backend implements a MQTT pub-sub backend.
 type Backend struct {
-	sync.RWMutex
-
 	wg sync.WaitGroup
 
 	rxPacketChan      chan gw.UplinkFrame
 	statsPacketChan   chan gw.GatewayStats
 	downlinkTXAckChan chan gw.DownlinkTXAck
+	connStateChan     chan gw.ConnState
+	execResponseChan  chan gw.GatewayCommandExecResponse
+	noiseFloorChan    chan gw.NoiseFloorReport
 
+	connMux              sync.RWMutex
 	conn                 paho.Client
-	redisPool            *redis.Pool
-	eventTopic           string
+	server               string
+	cleanSession         bool
+	clientID             string
+	credentials          credentials
+	redisPool            storage.RedisClient
+	subscriptionTopics   []string
+	stateTopics          []string
 	commandTopicTemplate *template.Template
 	qos                  uint8
 
-	gatewayMarshaler map[lorawan.EUI64]marshaler.Type
+	// shardCount contains the number of shards over which the gateways are
+	// partitioned. The shard of a gateway is passed to the topic templates.
+	shardCount int
+
+	// gatewayIDLevel and stateGatewayIDLevel contain the level of the event
+	// and state topic containing the gateway ID. When set (>= 0), messages of
+	// which the gateway ID does not match the gateway ID of the topic are
+	// rejected.
+	gatewayIDLevel      int
+	stateGatewayIDLevel int
+
+	// maxReconnectInterval contains the max interval between (re)connect
+	// attempts. When 0, the default of the MQTT client is used.
+	maxReconnectInterval time.Duration
+
+	// outboundChan buffers the commands which could not be published. It is
+	// nil when buffering is disabled.
+	outboundChan chan outboundCommand
+	outboundTTL  time.Duration
+
+	gatewayMarshalers *marshaler.GatewayMarshalers
+}
+
+// credentials contains the broker credentials and certificate files.
+type credentials struct {
+	username string
+	password string
+	caCert   string
+	tlsCert  string
+	tlsKey   string
 }
 
 // NewBackend creates a new Backend.
-func NewBackend(redisPool *redis.Pool, c config.Config) (gateway.Gateway, error) {
+func NewBackend(redisPool storage.RedisClient, c config.Config) (gateway.Gateway, error) {
 	conf := c.NetworkServer.Gateway.Backend.MQTT
 	var err error
 
 	b := Backend{
-		rxPacketChan:      make(chan gw.UplinkFrame),
-		statsPacketChan:   make(chan gw.GatewayStats),
-		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
-		gatewayMarshaler:  make(map[lorawan.EUI64]marshaler.Type),
-		redisPool:         redisPool,
-		eventTopic:        conf.EventTopic,
-		qos:               conf.QOS,
+		rxPacketChan:         make(chan gw.UplinkFrame),
+		statsPacketChan:      make(chan gw.GatewayStats),
+		downlinkTXAckChan:    make(chan gw.DownlinkTXAck),
+		connStateChan:        make(chan gw.ConnState),
+		execResponseChan:     make(chan gw.GatewayCommandExecResponse),
+		noiseFloorChan:       make(chan gw.NoiseFloorReport),
+		gatewayMarshalers:    marshaler.NewGatewayMarshalers(redisPool),
+		redisPool:            redisPool,
+		qos:                  conf.QOS,
+		shardCount:           conf.ShardCount,
+		gatewayIDLevel:       -1,
+		stateGatewayIDLevel:  -1,
+		maxReconnectInterval: conf.MaxReconnectInterval,
+		outboundTTL:          conf.OutboundBuffer.TTL,
+		server:               conf.Server,
+		cleanSession:         conf.CleanSession,
+		clientID:             conf.ClientID,
+		credentials: credentials{
+			username: conf.Username,
+			password: conf.Password,
+			caCert:   conf.CACert,
+			tlsCert:  conf.TLSCert,
+			tlsKey:   conf.TLSKey,
+		},
 	}
 
 	b.commandTopicTemplate, err = template.New("command").Parse(conf.CommandTopicTemplate)
@@ -75,33 +131,65 @@ func NewBackend(redisPool *redis.Pool, c config.Config) (gateway.Gateway, error)
 		return nil, errors.Wrap(err, "gateway/mqtt: parse command topic template error")
 	}
 
-	opts := paho.NewClientOptions()
-	opts.AddBroker(conf.Server)
-	opts.SetUsername(conf.Username)
-	opts.SetPassword(conf.Password)
-	opts.SetCleanSession(conf.CleanSession)
-	opts.SetClientID(conf.ClientID)
-	opts.SetOnConnectHandler(b.onConnected)
-	opts.SetConnectionLostHandler(b.onConnectionLost)
+	shards, err := getShards(conf.ShardCount, conf.Shards)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/mqtt: get shards error")
+	}
 
-	tlsconfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+	eventTopics, err := getShardTopics(conf.EventTopic, shards)
 	if err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"ca_cert":  conf.CACert,
-			"tls_cert": conf.TLSCert,
-			"tls_key":  conf.TLSKey,
-		}).Fatal("gateway/mqtt: error loading mqtt certificate files")
+		return nil, errors.Wrap(err, "gateway/mqtt: get event topics error")
 	}
-	if tlsconfig != nil {
-		opts.SetTLSConfig(tlsconfig)
+
+	for _, eventTopic := range eventTopics {
+		subscriptionTopic, err := getSubscriptionTopic(eventTopic, conf.SharedSubscriptionGroup)
+		if err != nil {
+			return nil, errors.Wrap(err, "gateway/mqtt: get subscription topic error")
+		}
+		b.subscriptionTopics = append(b.subscriptionTopics, subscriptionTopic)
+	}
+
+	if conf.StateTopic != "" {
+		b.stateTopics, err = getShardTopics(conf.StateTopic, shards)
+		if err != nil {
+			return nil, errors.Wrap(err, "gateway/mqtt: get state topics error")
+		}
+	}
+
+	if conf.VerifyGatewayID {
+		b.gatewayIDLevel, err = getGatewayIDLevel(eventTopics[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "gateway/mqtt: get gateway id topic level error")
+		}
+
+		if len(b.stateTopics) != 0 {
+			b.stateGatewayIDLevel, err = getGatewayIDLevel(b.stateTopics[0])
+			if err != nil {
+				return nil, errors.Wrap(err, "gateway/mqtt: get gateway id state topic level error")
+			}
+		}
+	}
+
+	opts, err := b.newClientOptions(b.credentials)
+	if err != nil {
+		// the certificate settings are not logged as these might contain
+		// the (PEM encoded) key itself, read errors contain the file path
+		log.WithError(err).Fatal("gateway/mqtt: error loading mqtt certificate files")
+	}
+
+	if conf.OutboundBuffer.Size > 0 {
+		b.outboundChan = make(chan outboundCommand, conf.OutboundBuffer.Size)
+		go b.outboundLoop()
 	}
 
 	log.WithField("server", conf.Server).Info("gateway/mqtt: connecting to mqtt broker")
 	b.conn = paho.NewClient(opts)
+	backoff := time.Second
 	for {
 		if token := b.conn.Connect(); token.Wait() && token.Error() != nil {
-			log.Errorf("gateway/mqtt: connecting to mqtt broker failed, will retry in 2s: %s", token.Error())
-			time.Sleep(2 * time.Second)
+			log.Errorf("gateway/mqtt: connecting to mqtt broker failed, will retry in %s: %s", backoff, token.Error())
+			time.Sleep(backoff)
+			backoff = nextBackoff(backoff, opts.MaxReconnectInterval)
 		} else {
 			break
 		}
@@ -117,9 +205,16 @@ func NewBackend(redisPool *redis.Pool, c config.Config) (gateway.Gateway, error)
 func (b *Backend) Close() error {
 	log.Info("gateway/mqtt: closing backend")
 
-	log.WithField("topic", b.eventTopic).Info("gateway/mqtt: unsubscribing from event topic")
-	if token := b.conn.Unsubscribe(b.eventTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("gateway/mqtt: unsubscribe from %s error: %s", b.eventTopic, token.Error())
+	log.WithField("topics", b.subscriptionTopics).Info("gateway/mqtt: unsubscribing from event topics")
+	if token := b.getConn().Unsubscribe(b.subscriptionTopics...); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("gateway/mqtt: unsubscribe from %s error: %s", b.subscriptionTopics, token.Error())
+	}
+
+	if len(b.stateTopics) != 0 {
+		log.WithField("topics", b.stateTopics).Info("gateway/mqtt: unsubscribing from state topics")
+		if token := b.getConn().Unsubscribe(b.stateTopics...); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("gateway/mqtt: unsubscribe from %s error: %s", b.stateTopics, token.Error())
+		}
 	}
 
 	log.Info("backend/gateway: handling last messages")
@@ -127,6 +222,9 @@ func (b *Backend) Close() error {
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
 	close(b.downlinkTXAckChan)
+	close(b.connStateChan)
+	close(b.execResponseChan)
+	close(b.noiseFloorChan)
 	return nil
 }
 
@@ -140,6 +238,22 @@ func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
 	return b.statsPacketChan
 }
 
+// ConnStateChan returns the connection state channel.
+func (b *Backend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// GatewayCommandExecResponseChan returns the gateway command execution
+// response channel.
+func (b *Backend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.execResponseChan
+}
+
+// NoiseFloorReportChan returns the noise-floor report channel.
+func (b *Backend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	return b.noiseFloorChan
+}
+
 // DownlinkTXAckChan returns the downlink tx ack channel.
 func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTXAckChan
@@ -166,6 +280,16 @@ func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfiguration)
 	return b.publishCommand(log.Fields{}, gatewayID, "config", &configPacket)
 }
 
+// SendGatewayCommandExecRequest sends the given command execution request to
+// the gateway.
+func (b *Backend) SendGatewayCommandExecRequest(req gw.GatewayCommandExecRequest) error {
+	gatewayID := helpers.GetGatewayID(&req)
+
+	return b.publishCommand(log.Fields{
+		"exec_id": hex.EncodeToString(req.ExecId),
+	}, gatewayID, "exec", &req)
+}
+
 func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, msg proto.Message) error {
 	t := b.getGatewayMarshaler(gatewayID)
 	bb, err := marshaler.MarshalCommand(t, msg)
@@ -176,7 +300,8 @@ func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, com
 	templateCtx := struct {
 		GatewayID   lorawan.EUI64
 		CommandType string
-	}{gatewayID, command}
+		Shard       int
+	}{gatewayID, command, getShard(gatewayID, b.shardCount)}
 	topic := bytes.NewBuffer(nil)
 	if err := b.commandTopicTemplate.Execute(topic, templateCtx); err != nil {
 		return errors.Wrap(err, "execute command topic template error")
@@ -191,8 +316,34 @@ func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, com
 
 	mqttCommandCounter(command).Inc()
 
-	if token := b.conn.Publish(topic.String(), b.qos, false, bb); token.Wait() && token.Error() != nil {
-		return errors.Wrap(err, "gateway/mqtt: publish gateway command error")
+	if err := b.publish(topic.String(), bb); err != nil {
+		if b.outboundChan == nil {
+			return errors.Wrap(err, "gateway/mqtt: publish gateway command error")
+		}
+
+		return b.bufferCommand(outboundCommand{
+			fields:   fields,
+			command:  command,
+			topic:    topic.String(),
+			payload:  bb,
+			deadline: getOutboundDeadline(msg, time.Now(), b.outboundTTL),
+		}, err)
+	}
+
+	return nil
+}
+
+// publish publishes the given payload. As the MQTT client silently drops
+// QoS 0 messages while re-connecting, an error is returned when the
+// connection is not open.
+func (b *Backend) publish(topic string, payload []byte) error {
+	conn := b.getConn()
+	if !conn.IsConnectionOpen() {
+		return paho.ErrNotConnected
+	}
+
+	if token := conn.Publish(topic, b.qos, false, payload); token.Wait() && token.Error() != nil {
+		return token.Error()
 	}
 
 	return nil
@@ -211,6 +362,12 @@ func (b *Backend) eventHandler(c paho.Client, msg paho.Message) {
 	} else if strings.HasSuffix(msg.Topic(), "stats") {
 		mqttEventCounter("stats").Inc()
 		b.statsPacketHandler(c, msg)
+	} else if strings.HasSuffix(msg.Topic(), "exec") {
+		mqttEventCounter("exec").Inc()
+		b.execResponseHandler(c, msg)
+	} else if strings.HasSuffix(msg.Topic(), "noise") {
+		mqttEventCounter("noise").Inc()
+		b.noiseFloorReportHandler(c, msg)
 	}
 }
 
@@ -242,6 +399,10 @@ func (b *Backend) rxPacketHandler(c paho.Client, msg paho.Message) {
 	}
 
 	gatewayID := helpers.GetGatewayID(uplinkFrame.RxInfo)
+	if err := verifyGatewayID(b.gatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
 	b.setGatewayMarshaler(gatewayID, t)
 	uplinkID := helpers.GetUplinkID(uplinkFrame.RxInfo)
 
@@ -250,6 +411,16 @@ func (b *Backend) rxPacketHandler(c paho.Client, msg paho.Message) {
 		"gateway_id": gatewayID,
 	}).Info("gateway/mqtt: uplink frame received")
 
+	// When partitioning is enabled, frames of devices within partitions
+	// owned by other instances must be ignored before acquiring the lock
+	// below, else these would never be handled.
+	if !partition.Owns(uplinkFrame.PhyPayload) {
+		log.WithFields(log.Fields{
+			"uplink_id": uplinkID,
+		}).Debug("gateway/mqtt: uplink frame belongs to other partition, ignoring")
+		return
+	}
+
 	// Since with MQTT all subscribers will receive the uplink messages sent
 	// by all the gateways, the first instance receiving the message must lock it,
 	// so that other instances can ignore the same message (from the same gw).
@@ -289,6 +460,10 @@ func (b *Backend) statsPacketHandler(c paho.Client, msg paho.Message) {
 
 	gatewayID := helpers.GetGatewayID(&gatewayStats)
 	statsID := helpers.GetStatsID(&gatewayStats)
+	if err := verifyGatewayID(b.gatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
 	b.setGatewayMarshaler(gatewayID, t)
 
 	// Since with MQTT all subscribers will receive the stats messages sent
@@ -330,6 +505,10 @@ func (b *Backend) ackPacketHandler(c paho.Client, msg paho.Message) {
 
 	gatewayID := helpers.GetGatewayID(&ack)
 	downlinkID := helpers.GetDownlinkID(&ack)
+	if err := verifyGatewayID(b.gatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
 	b.setGatewayMarshaler(gatewayID, t)
 
 	// Since with MQTT all subscribers will receive the ack messages sent
@@ -357,6 +536,97 @@ func (b *Backend) ackPacketHandler(c paho.Client, msg paho.Message) {
 	b.downlinkTXAckChan <- ack
 }
 
+func (b *Backend) connStateHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	mqttEventCounter("state").Inc()
+
+	// an empty payload is used to clear the retained state
+	if len(msg.Payload()) == 0 {
+		return
+	}
+
+	var state gw.ConnState
+	if _, err := marshaler.UnmarshalConnState(msg.Payload(), &state); err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal connection state error")
+		return
+	}
+
+	if len(state.GatewayId) != 8 {
+		log.WithField("topic", msg.Topic()).Error("gateway/mqtt: connection state contains invalid gateway_id")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&state)
+	if err := verifyGatewayID(b.stateGatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"state":      state.State,
+	}).Info("gateway/mqtt: gateway connection state received")
+	b.connStateChan <- state
+}
+
+func (b *Backend) execResponseHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var resp gw.GatewayCommandExecResponse
+	t, err := marshaler.UnmarshalGatewayCommandExecResponse(msg.Payload(), &resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal gateway command execution response error")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&resp)
+	if err := verifyGatewayID(b.gatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
+	b.setGatewayMarshaler(gatewayID, t)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"exec_id":    hex.EncodeToString(resp.ExecId),
+	}).Info("gateway/mqtt: gateway command execution response received")
+	b.execResponseChan <- resp
+}
+
+func (b *Backend) noiseFloorReportHandler(c paho.Client, msg paho.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var report gw.NoiseFloorReport
+	t, err := marshaler.UnmarshalNoiseFloorReport(msg.Payload(), &report)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).WithError(err).Error("gateway/mqtt: unmarshal noise-floor report error")
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(&report)
+	if err := verifyGatewayID(b.gatewayIDLevel, msg.Topic(), gatewayID); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("gateway/mqtt: verify gateway id error")
+		return
+	}
+	b.setGatewayMarshaler(gatewayID, t)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"channels":   len(report.Channels),
+	}).Info("gateway/mqtt: noise-floor report received")
+	b.noiseFloorChan <- report
+}
+
 func (b *Backend) onConnected(c paho.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
 
@@ -364,13 +634,32 @@ func (b *Backend) onConnected(c paho.Client) {
 
 	for {
 		log.WithFields(log.Fields{
-			"topic": b.eventTopic,
-			"qos":   b.qos,
-		}).Info("gateway/mqtt: subscribing to gateway event topic")
-		if token := b.conn.Subscribe(b.eventTopic, b.qos, b.eventHandler); token.Wait() && token.Error() != nil {
+			"topics": b.subscriptionTopics,
+			"qos":    b.qos,
+		}).Info("gateway/mqtt: subscribing to gateway event topics")
+		if token := c.SubscribeMultiple(b.getTopicFilters(b.subscriptionTopics), b.eventHandler); token.Wait() && token.Error() != nil {
 			log.WithError(token.Error()).WithFields(log.Fields{
-				"topic": b.eventTopic,
-				"qos":   b.qos,
+				"topics": b.subscriptionTopics,
+				"qos":    b.qos,
+			}).Errorf("gateway/mqtt: subscribe error")
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	// The state topic is not subscribed to using the shared subscription
+	// group, as retained messages are not delivered to shared subscriptions.
+	// The same state is therefore stored by each LoRa Server instance.
+	for len(b.stateTopics) != 0 {
+		log.WithFields(log.Fields{
+			"topics": b.stateTopics,
+			"qos":    b.qos,
+		}).Info("gateway/mqtt: subscribing to gateway state topics")
+		if token := c.SubscribeMultiple(b.getTopicFilters(b.stateTopics), b.connStateHandler); token.Wait() && token.Error() != nil {
+			log.WithError(token.Error()).WithFields(log.Fields{
+				"topics": b.stateTopics,
+				"qos":    b.qos,
 			}).Errorf("gateway/mqtt: subscribe error")
 			time.Sleep(time.Second)
 			continue
@@ -384,18 +673,224 @@ func (b *Backend) onConnectionLost(c paho.Client, reason error) {
 	mqttDisconnectCounter().Inc()
 }
 
-func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
-	b.Lock()
-	defer b.Unlock()
+// UpdateCredentials updates the broker credentials and certificates and
+// re-connects to the broker when these have changed. On error, the backend
+// re-connects using the previous credentials.
+func (b *Backend) UpdateCredentials(c config.Config) error {
+	conf := c.NetworkServer.Gateway.Backend.MQTT
+	creds := credentials{
+		username: conf.Username,
+		password: conf.Password,
+		caCert:   conf.CACert,
+		tlsCert:  conf.TLSCert,
+		tlsKey:   conf.TLSKey,
+	}
+
+	b.connMux.Lock()
+	defer b.connMux.Unlock()
+
+	if creds == b.credentials {
+		return nil
+	}
+
+	opts, err := b.newClientOptions(creds)
+	if err != nil {
+		return errors.Wrap(err, "gateway/mqtt: load certificate files error")
+	}
+
+	// As the client ID is re-used, the current connection must be closed
+	// first, else both clients would keep taking over the session.
+	log.WithField("server", b.server).Info("gateway/mqtt: re-connecting to mqtt broker with updated credentials")
+	b.conn.Disconnect(250)
+
+	conn := paho.NewClient(opts)
+	if token := conn.Connect(); token.Wait() && token.Error() != nil {
+		connErr := token.Error()
+		if token := b.conn.Connect(); token.Wait() && token.Error() != nil {
+			log.WithError(token.Error()).Error("gateway/mqtt: re-connecting with previous credentials failed")
+		}
+		return errors.Wrap(connErr, "gateway/mqtt: connect with updated credentials error")
+	}
+
+	b.conn = conn
+	b.credentials = creds
+
+	return nil
+}
+
+func (b *Backend) newClientOptions(creds credentials) (*paho.ClientOptions, error) {
+	opts := paho.NewClientOptions()
+	opts.AddBroker(b.server)
+	opts.SetUsername(creds.username)
+	opts.SetPassword(creds.password)
+	opts.SetCleanSession(b.cleanSession)
+	opts.SetClientID(b.clientID)
+	opts.SetOnConnectHandler(b.onConnected)
+	opts.SetConnectionLostHandler(b.onConnectionLost)
+	if b.maxReconnectInterval > 0 {
+		opts.SetMaxReconnectInterval(b.maxReconnectInterval)
+	}
+
+	tlsconfig, err := newTLSConfig(creds.caCert, creds.tlsCert, creds.tlsKey)
+	if err != nil {
+		return nil, err
+	}
+	if tlsconfig != nil {
+		opts.SetTLSConfig(tlsconfig)
+	}
+
+	return opts, nil
+}
+
+func (b *Backend) getConn() paho.Client {
+	b.connMux.RLock()
+	defer b.connMux.RUnlock()
 
-	b.gatewayMarshaler[gatewayID] = t
+	return b.conn
+}
+
+func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
+	b.gatewayMarshalers.Set(gatewayID, t)
 }
 
 func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
-	b.RLock()
-	defer b.RUnlock()
+	return b.gatewayMarshalers.Get(gatewayID)
+}
+
+// getTopicFilters returns the given topics with the configured QoS, as used
+// for subscribing to multiple topics at once.
+func (b *Backend) getTopicFilters(topics []string) map[string]byte {
+	filters := make(map[string]byte)
+	for _, topic := range topics {
+		filters[topic] = b.qos
+	}
+	return filters
+}
+
+// getShard returns the shard of the given gateway ID. The shard is the
+// FNV-1a (32 bit) hash of the (binary) gateway ID modulo the shard count.
+// When sharding is disabled (shard count <= 0), 0 is returned.
+func getShard(gatewayID lorawan.EUI64, shardCount int) int {
+	if shardCount <= 0 {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write(gatewayID[:])
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// getShards returns the shards to subscribe to. When no shards are given,
+// all shards are returned. When sharding is disabled, only shard 0 is
+// returned.
+func getShards(shardCount int, shards []int) ([]int, error) {
+	if shardCount <= 0 {
+		if len(shards) != 0 {
+			return nil, errors.New("shards can only be set when shard_count is set")
+		}
+		return []int{0}, nil
+	}
+
+	if len(shards) == 0 {
+		for i := 0; i < shardCount; i++ {
+			shards = append(shards, i)
+		}
+		return shards, nil
+	}
+
+	for _, shard := range shards {
+		if shard < 0 || shard >= shardCount {
+			return nil, fmt.Errorf("shard %d is out of range (shard_count: %d)", shard, shardCount)
+		}
+	}
+
+	return shards, nil
+}
+
+// getShardTopics executes the given topic template for each of the given
+// shards and returns the (unique) resulting topics. When the template does
+// not contain the shard, a single topic is returned.
+func getShardTopics(topicTemplate string, shards []int) ([]string, error) {
+	tmpl, err := template.New("topic").Parse(topicTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse topic template error")
+	}
+
+	var out []string
+	seen := make(map[string]struct{})
+
+	for _, shard := range shards {
+		topic := bytes.NewBuffer(nil)
+		if err := tmpl.Execute(topic, struct{ Shard int }{shard}); err != nil {
+			return nil, errors.Wrap(err, "execute topic template error")
+		}
+
+		if _, ok := seen[topic.String()]; ok {
+			continue
+		}
+		seen[topic.String()] = struct{}{}
+		out = append(out, topic.String())
+	}
+
+	return out, nil
+}
+
+// getSubscriptionTopic returns the topic to subscribe to. When a shared
+// subscription group is set, the shared subscription topic is returned, so
+// that the broker distributes the events over the subscribers of the group.
+func getSubscriptionTopic(eventTopic, group string) (string, error) {
+	if group == "" {
+		return eventTopic, nil
+	}
+
+	if strings.ContainsAny(group, "/+#") {
+		return "", fmt.Errorf("shared subscription group must not contain '/', '+' or '#': %s", group)
+	}
+
+	return fmt.Sprintf("$share/%s/%s", group, eventTopic), nil
+}
+
+// verifyGatewayID verifies that the given gateway ID (from the payload) is
+// equal to the gateway ID at the given level of the topic on which the
+// message was published. When the broker authenticates the gateways using
+// client-certificates and only allows a gateway to publish to the topics
+// containing the CN of its certificate, the gateway ID of the topic is the
+// authenticated identity. A level < 0 disables the verification.
+func verifyGatewayID(level int, topic string, gatewayID lorawan.EUI64) error {
+	if level < 0 {
+		return nil
+	}
+
+	levels := strings.Split(topic, "/")
+	if len(levels) <= level {
+		return fmt.Errorf("topic %s does not contain gateway id", topic)
+	}
+
+	var topicGatewayID lorawan.EUI64
+	if err := topicGatewayID.UnmarshalText([]byte(levels[level])); err != nil {
+		return errors.Wrap(err, "unmarshal topic gateway id error")
+	}
+
+	if topicGatewayID != gatewayID {
+		mqttGatewayIDMismatchCounter().Inc()
+		return fmt.Errorf("gateway_id %s does not match gateway_id %s of topic", gatewayID, topicGatewayID)
+	}
+
+	return nil
+}
+
+// getGatewayIDLevel returns the level of the given event topic containing
+// the gateway ID. This is the first single-level wildcard, which must not be
+// the last level (containing the event type).
+func getGatewayIDLevel(eventTopic string) (int, error) {
+	levels := strings.Split(eventTopic, "/")
+	for i, level := range levels[:len(levels)-1] {
+		if level == "+" {
+			return i, nil
+		}
+	}
 
-	return b.gatewayMarshaler[gatewayID]
+	return 0, fmt.Errorf("event topic %s does not contain a gateway id wildcard", eventTopic)
 }
 
 func newTLSConfig(cafile, certFile, certKeyFile string) (*tls.Config, error) {
@@ -407,7 +902,7 @@ func newTLSConfig(cafile, certFile, certKeyFile string) (*tls.Config, error) {
 
 	// Import trusted certificates from CAfile.pem.
 	if cafile != "" {
-		cacert, err := ioutil.ReadFile(cafile)
+		cacert, err := nstls.ReadPEM(cafile)
 		if err != nil {
 			log.WithError(err).Error("gateway/mqtt: could not load ca certificate")
 			return nil, err
@@ -420,7 +915,7 @@ func newTLSConfig(cafile, certFile, certKeyFile string) (*tls.Config, error) {
 
 	// Import certificate and the key
 	if certFile != "" && certKeyFile != "" {
-		kp, err := tls.LoadX509KeyPair(certFile, certKeyFile)
+		kp, err := nstls.LoadX509KeyPair(certFile, certKeyFile)
 		if err != nil {
 			log.WithError(err).Error("gateway/mqtt: could not load mqtt tls key-pair")
 			return nil, err
@@ -174,6 +174,223 @@ func (ts *BackendTestSuite) TestSendGatewayConfiguration() {
 	assert.Equal(gatewayConfig, configReceived)
 }
 
+func (ts *BackendTestSuite) TestGatewayCommandExecResponse() {
+	assert := require.New(ts.T())
+
+	resp := gw.GatewayCommandExecResponse{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		ExecId:    []byte{1, 2, 3, 4},
+		Stdout:    []byte("hello"),
+	}
+	b, err := proto.Marshal(&resp)
+	assert.NoError(err)
+
+	token := ts.mqttClient.Publish("gateway/0102030405060708/event/exec", 0, false, b)
+	token.Wait()
+	assert.NoError(token.Error())
+
+	received := <-ts.backend.(gateway.CommandExecBackend).GatewayCommandExecResponseChan()
+	if !proto.Equal(&resp, &received) {
+		assert.Equal(resp, received)
+	}
+}
+
+func (ts *BackendTestSuite) TestNoiseFloorReport() {
+	assert := require.New(ts.T())
+
+	report := gw.NoiseFloorReport{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Channels: []*gw.ChannelNoiseFloor{
+			{
+				Frequency:          868100000,
+				NoiseFloor:         -118.5,
+				ChannelUtilization: 0.25,
+			},
+		},
+	}
+	b, err := proto.Marshal(&report)
+	assert.NoError(err)
+
+	token := ts.mqttClient.Publish("gateway/0102030405060708/event/noise", 0, false, b)
+	token.Wait()
+	assert.NoError(token.Error())
+
+	received := <-ts.backend.(gateway.NoiseFloorBackend).NoiseFloorReportChan()
+	if !proto.Equal(&report, &received) {
+		assert.Equal(report, received)
+	}
+}
+
+func (ts *BackendTestSuite) TestSendGatewayCommandExecRequest() {
+	assert := require.New(ts.T())
+
+	execRequestChan := make(chan gw.GatewayCommandExecRequest)
+	token := ts.mqttClient.Subscribe("gateway/+/command/exec", 0, func(c paho.Client, msg paho.Message) {
+		var pl gw.GatewayCommandExecRequest
+		if err := proto.Unmarshal(msg.Payload(), &pl); err != nil {
+			panic(err)
+		}
+		execRequestChan <- pl
+	})
+	token.Wait()
+	assert.NoError(token.Error())
+
+	req := gw.GatewayCommandExecRequest{
+		GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Command:   "reboot",
+		ExecId:    []byte{1, 2, 3, 4},
+	}
+	assert.NoError(ts.backend.(gateway.CommandExecBackend).SendGatewayCommandExecRequest(req))
+
+	received := <-execRequestChan
+	if !proto.Equal(&req, &received) {
+		assert.Equal(req, received)
+	}
+}
+
+func TestGetSubscriptionTopic(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Group         string
+		Expected      string
+		ExpectedError bool
+	}{
+		{
+			Name:     "no shared subscription",
+			Expected: "gateway/+/event/+",
+		},
+		{
+			Name:     "shared subscription",
+			Group:    "loraserver",
+			Expected: "$share/loraserver/gateway/+/event/+",
+		},
+		{
+			Name:          "invalid group",
+			Group:         "lora/server",
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			topic, err := getSubscriptionTopic("gateway/+/event/+", tst.Group)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, topic)
+		})
+	}
+}
+
+func TestVerifyGatewayID(t *testing.T) {
+	assert := require.New(t)
+
+	level, err := getGatewayIDLevel("gateway/+/event/+")
+	assert.NoError(err)
+	assert.Equal(1, level)
+
+	_, err = getGatewayIDLevel("gateway/event/+")
+	assert.Error(err)
+
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		Name           string
+		GatewayIDLevel int
+		Topic          string
+		ExpectedError  bool
+	}{
+		{
+			Name:           "verification disabled",
+			GatewayIDLevel: -1,
+			Topic:          "gateway/0807060504030201/event/up",
+		},
+		{
+			Name:           "matching gateway id",
+			GatewayIDLevel: 1,
+			Topic:          "gateway/0102030405060708/event/up",
+		},
+		{
+			Name:           "gateway id mismatch",
+			GatewayIDLevel: 1,
+			Topic:          "gateway/0807060504030201/event/up",
+			ExpectedError:  true,
+		},
+		{
+			Name:           "invalid topic",
+			GatewayIDLevel: 1,
+			Topic:          "gateway",
+			ExpectedError:  true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			err := verifyGatewayID(tst.GatewayIDLevel, tst.Topic, gatewayID)
+			if tst.ExpectedError {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestShardTopics(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	t.Run("getShard", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Equal(0, getShard(gatewayID, 0))
+		assert.Equal(1, getShard(gatewayID, 4))
+	})
+
+	t.Run("getShards", func(t *testing.T) {
+		assert := require.New(t)
+
+		shards, err := getShards(0, nil)
+		assert.NoError(err)
+		assert.Equal([]int{0}, shards)
+
+		shards, err = getShards(4, nil)
+		assert.NoError(err)
+		assert.Equal([]int{0, 1, 2, 3}, shards)
+
+		shards, err = getShards(4, []int{1, 3})
+		assert.NoError(err)
+		assert.Equal([]int{1, 3}, shards)
+
+		_, err = getShards(4, []int{4})
+		assert.Error(err)
+
+		_, err = getShards(0, []int{1})
+		assert.Error(err)
+	})
+
+	t.Run("getShardTopics", func(t *testing.T) {
+		assert := require.New(t)
+
+		topics, err := getShardTopics("gateway/+/event/+", []int{0})
+		assert.NoError(err)
+		assert.Equal([]string{"gateway/+/event/+"}, topics)
+
+		topics, err = getShardTopics("shard/{{ .Shard }}/gateway/+/event/+", []int{1, 3})
+		assert.NoError(err)
+		assert.Equal([]string{"shard/1/gateway/+/event/+", "shard/3/gateway/+/event/+"}, topics)
+
+		level, err := getGatewayIDLevel(topics[0])
+		assert.NoError(err)
+		assert.Equal(3, level)
+	})
+}
+
 func TestBackend(t *testing.T) {
 	suite.Run(t, new(BackendTestSuite))
 }
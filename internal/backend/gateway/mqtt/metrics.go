@@ -25,6 +25,21 @@ var (
 		Name: "backend_mqtt_disconnect_count",
 		Help: "The number of times the MQTT backend disconnected from the MQTT broker.",
 	})
+
+	obc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_mqtt_outbound_buffered_count",
+		Help: "The number of commands buffered by the MQTT backend because these could not be published (per command).",
+	}, []string{"command"})
+
+	odc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_mqtt_outbound_dropped_count",
+		Help: "The number of buffered commands dropped by the MQTT backend because the buffer was full or the command expired (per command).",
+	}, []string{"command"})
+
+	mqttm = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backend_mqtt_gateway_id_mismatch_count",
+		Help: "The number of events rejected by the MQTT backend because the gateway ID did not match the topic.",
+	})
 )
 
 func mqttEventCounter(e string) prometheus.Counter {
@@ -42,3 +57,15 @@ func mqttConnectCounter() prometheus.Counter {
 func mqttDisconnectCounter() prometheus.Counter {
 	return mqttd
 }
+
+func mqttGatewayIDMismatchCounter() prometheus.Counter {
+	return mqttm
+}
+
+func mqttOutboundBufferedCounter(c string) prometheus.Counter {
+	return obc.With(prometheus.Labels{"command": c})
+}
+
+func mqttOutboundDroppedCounter(c string) prometheus.Counter {
+	return odc.With(prometheus.Labels{"command": c})
+}
@@ -0,0 +1,120 @@
+package mqtt
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+)
+
+// outboundMinBackoff and outboundMaxBackoff define the interval between the
+// publish retries of a buffered command. The interval doubles after each
+// failed retry.
+const (
+	outboundMinBackoff = 100 * time.Millisecond
+	outboundMaxBackoff = 5 * time.Second
+)
+
+// outboundCommand contains a (marshaled) command which could not be
+// published and which is buffered for re-publishing.
+type outboundCommand struct {
+	fields   log.Fields
+	command  string
+	topic    string
+	payload  []byte
+	deadline time.Time
+}
+
+// bufferCommand adds the given command to the outbound buffer. It returns
+// an error when the buffer is full.
+func (b *Backend) bufferCommand(cmd outboundCommand, publishErr error) error {
+	select {
+	case b.outboundChan <- cmd:
+		mqttOutboundBufferedCounter(cmd.command).Inc()
+		log.WithFields(cmd.fields).WithError(publishErr).Warning("gateway/mqtt: publish gateway command failed, command buffered")
+		return nil
+	default:
+		mqttOutboundDroppedCounter(cmd.command).Inc()
+		return errors.Wrap(publishErr, "gateway/mqtt: publish gateway command error (outbound buffer full)")
+	}
+}
+
+// outboundLoop re-publishes the buffered commands, in order. A command is
+// retried with an exponential backoff until it has been published or until
+// its deadline has passed.
+func (b *Backend) outboundLoop() {
+	for cmd := range b.outboundChan {
+		backoff := outboundMinBackoff
+
+		for {
+			if time.Now().After(cmd.deadline) {
+				mqttOutboundDroppedCounter(cmd.command).Inc()
+				log.WithFields(cmd.fields).Warning("gateway/mqtt: buffered gateway command expired, command dropped")
+				break
+			}
+
+			err := b.publish(cmd.topic, cmd.payload)
+			if err == nil {
+				log.WithFields(cmd.fields).Info("gateway/mqtt: buffered gateway command published")
+				break
+			}
+
+			sleep := backoff
+			if until := time.Until(cmd.deadline); until < sleep {
+				sleep = until
+			}
+			time.Sleep(sleep)
+			backoff = nextBackoff(backoff, outboundMaxBackoff)
+		}
+	}
+}
+
+// getOutboundDeadline returns the time until which the given command can be
+// re-published. This is at most the given TTL. For downlink-frames, this is
+// the time of transmission, as the gateway rejects downlinks received after
+// this time. For Class-A downlinks (delay timing), the delay is relative to
+// the uplink which has already been received, the returned time is
+// therefore an upper bound.
+func getOutboundDeadline(msg proto.Message, now time.Time, ttl time.Duration) time.Time {
+	deadline := now.Add(ttl)
+
+	frame, ok := msg.(*gw.DownlinkFrame)
+	if !ok || frame.TxInfo == nil {
+		return deadline
+	}
+
+	var txAt time.Time
+	switch frame.TxInfo.Timing {
+	case gw.DownlinkTiming_DELAY:
+		if ti := frame.TxInfo.GetDelayTimingInfo(); ti != nil && ti.Delay != nil {
+			if d, err := ptypes.Duration(ti.Delay); err == nil {
+				txAt = now.Add(d)
+			}
+		}
+	case gw.DownlinkTiming_GPS_EPOCH:
+		if ti := frame.TxInfo.GetGpsEpochTimingInfo(); ti != nil && ti.TimeSinceGpsEpoch != nil {
+			if d, err := ptypes.Duration(ti.TimeSinceGpsEpoch); err == nil {
+				txAt = time.Time(gps.NewFromTimeSinceGPSEpoch(d))
+			}
+		}
+	}
+
+	if !txAt.IsZero() && txAt.Before(deadline) {
+		return txAt
+	}
+	return deadline
+}
+
+// nextBackoff returns the doubled backoff, capped at the given max.
+func nextBackoff(backoff, max time.Duration) time.Duration {
+	backoff = backoff * 2
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
@@ -0,0 +1,108 @@
+package mqtt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+)
+
+func TestGetOutboundDeadline(t *testing.T) {
+	now := time.Now()
+	ttl := 30 * time.Second
+
+	tests := []struct {
+		name     string
+		msg      proto.Message
+		expected time.Time
+	}{
+		{
+			name:     "gateway configuration",
+			msg:      &gw.GatewayConfiguration{},
+			expected: now.Add(ttl),
+		},
+		{
+			name: "immediately",
+			msg: &gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Timing: gw.DownlinkTiming_IMMEDIATELY,
+					TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+						ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
+					},
+				},
+			},
+			expected: now.Add(ttl),
+		},
+		{
+			name: "delay",
+			msg: &gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Timing: gw.DownlinkTiming_DELAY,
+					TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+						DelayTimingInfo: &gw.DelayTimingInfo{
+							Delay: ptypes.DurationProto(time.Second),
+						},
+					},
+				},
+			},
+			expected: now.Add(time.Second),
+		},
+		{
+			name: "gps epoch",
+			msg: &gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Timing: gw.DownlinkTiming_GPS_EPOCH,
+					TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+						GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+							TimeSinceGpsEpoch: ptypes.DurationProto(gps.Time(now.Add(10 * time.Second)).TimeSinceGPSEpoch()),
+						},
+					},
+				},
+			},
+			expected: now.Add(10 * time.Second),
+		},
+		{
+			name: "gps epoch after ttl",
+			msg: &gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Timing: gw.DownlinkTiming_GPS_EPOCH,
+					TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+						GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+							TimeSinceGpsEpoch: ptypes.DurationProto(gps.Time(now.Add(time.Minute)).TimeSinceGPSEpoch()),
+						},
+					},
+				},
+			},
+			expected: now.Add(ttl),
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.True(tst.expected.Equal(getOutboundDeadline(tst.msg, now, ttl)))
+		})
+	}
+}
+
+func TestBufferCommand(t *testing.T) {
+	assert := require.New(t)
+
+	b := Backend{
+		outboundChan: make(chan outboundCommand, 1),
+	}
+	publishErr := errors.New("not connected")
+
+	assert.NoError(b.bufferCommand(outboundCommand{command: "down", fields: log.Fields{}}, publishErr))
+	assert.Error(b.bufferCommand(outboundCommand{command: "down", fields: log.Fields{}}, publishErr))
+
+	cmd := <-b.outboundChan
+	assert.Equal("down", cmd.command)
+}
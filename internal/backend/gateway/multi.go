@@ -0,0 +1,299 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// MultiBackend multiplexes the uplinks, stats and tx acknowledgements of
+// multiple gateway backends. The backend owning a gateway is learned from
+// the received events, and the downlinks and gateway configuration of the
+// gateway are routed to this backend.
+type MultiBackend struct {
+	sync.RWMutex
+	wg sync.WaitGroup
+
+	backends []Gateway
+	owners   map[lorawan.EUI64]Gateway
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+	connStateChan     chan gw.ConnState
+	execResponseChan  chan gw.GatewayCommandExecResponse
+	noiseFloorChan    chan gw.NoiseFloorReport
+}
+
+// NewMultiBackend creates a new MultiBackend for the given backends.
+func NewMultiBackend(backends ...Gateway) *MultiBackend {
+	b := MultiBackend{
+		backends: backends,
+		owners:   make(map[lorawan.EUI64]Gateway),
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+		connStateChan:     make(chan gw.ConnState),
+		execResponseChan:  make(chan gw.GatewayCommandExecResponse),
+		noiseFloorChan:    make(chan gw.NoiseFloorReport),
+	}
+
+	for _, backend := range backends {
+		b.wg.Add(3)
+		go b.forwardUplinkFrames(backend)
+		go b.forwardGatewayStats(backend)
+		go b.forwardDownlinkTXAcks(backend)
+
+		if csb, ok := backend.(ConnStateBackend); ok && csb.ConnStateChan() != nil {
+			b.wg.Add(1)
+			go b.forwardConnStates(backend, csb)
+		}
+
+		if ceb, ok := backend.(CommandExecBackend); ok && ceb.GatewayCommandExecResponseChan() != nil {
+			b.wg.Add(1)
+			go b.forwardGatewayCommandExecResponses(backend, ceb)
+		}
+
+		if nfb, ok := backend.(NoiseFloorBackend); ok && nfb.NoiseFloorReportChan() != nil {
+			b.wg.Add(1)
+			go b.forwardNoiseFloorReports(backend, nfb)
+		}
+	}
+
+	return &b
+}
+
+// SendTXPacket sends the given downlink frame to the backend owning the
+// gateway. When the owner is not (yet) known, e.g. directly after a restart,
+// the frame is sent to all backends.
+func (b *MultiBackend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	return b.send(pl.TxInfo.GatewayId, func(backend Gateway) error {
+		return backend.SendTXPacket(pl)
+	})
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the
+// backend owning the gateway. When the owner is not (yet) known, the
+// configuration is sent to all backends.
+func (b *MultiBackend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	return b.send(pl.GatewayId, func(backend Gateway) error {
+		return backend.SendGatewayConfigPacket(pl)
+	})
+}
+
+// SendGatewayCommandExecRequest sends the given command execution request to
+// the backend owning the gateway. When the owner is not (yet) known, the
+// request is sent to all backends supporting command execution.
+func (b *MultiBackend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	var supported bool
+	for _, backend := range b.backends {
+		if _, ok := backend.(CommandExecBackend); ok {
+			supported = true
+		}
+	}
+	if !supported {
+		return ErrNotSupported
+	}
+
+	return b.send(pl.GatewayId, func(backend Gateway) error {
+		ceb, ok := backend.(CommandExecBackend)
+		if !ok {
+			return ErrNotSupported
+		}
+		return ceb.SendGatewayCommandExecRequest(pl)
+	})
+}
+
+// RXPacketChan returns the uplink-frame channel.
+func (b *MultiBackend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+func (b *MultiBackend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx acknowledgement channel.
+func (b *MultiBackend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// ConnStateChan returns the connection state channel. It contains the
+// connection states of the backends implementing the ConnStateBackend
+// interface.
+func (b *MultiBackend) ConnStateChan() chan gw.ConnState {
+	return b.connStateChan
+}
+
+// GatewayCommandExecResponseChan returns the command execution response
+// channel. It contains the responses of the backends implementing the
+// CommandExecBackend interface.
+func (b *MultiBackend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.execResponseChan
+}
+
+// NoiseFloorReportChan returns the noise-floor report channel. It contains
+// the reports of the backends implementing the NoiseFloorBackend interface.
+func (b *MultiBackend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	return b.noiseFloorChan
+}
+
+// Close closes all backends. The channels are closed once the channels of
+// all backends have been closed.
+func (b *MultiBackend) Close() error {
+	var errs []string
+	for _, backend := range b.backends {
+		if err := backend.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	b.wg.Wait()
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	close(b.connStateChan)
+	close(b.execResponseChan)
+	close(b.noiseFloorChan)
+
+	if len(errs) != 0 {
+		return fmt.Errorf("close backends error: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// UpdateCredentials updates the credentials of the backends implementing
+// the CredentialsUpdater interface.
+func (b *MultiBackend) UpdateCredentials(conf config.Config) error {
+	for _, backend := range b.backends {
+		if u, ok := backend.(CredentialsUpdater); ok {
+			if err := u.UpdateCredentials(conf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (b *MultiBackend) send(gatewayIDB []byte, f func(Gateway) error) error {
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], gatewayIDB)
+
+	b.RLock()
+	owner, ok := b.owners[gatewayID]
+	b.RUnlock()
+
+	if ok {
+		return f(owner)
+	}
+
+	log.WithField("gateway_id", gatewayID).Debug("gateway: backend of gateway is unknown, sending to all backends")
+
+	var errs []string
+	for _, backend := range b.backends {
+		if err := f(backend); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) == len(b.backends) {
+		return fmt.Errorf("send to all backends failed: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// setOwner registers the backend from which an event of the gateway was
+// received as the owner of the gateway.
+func (b *MultiBackend) setOwner(gatewayIDB []byte, backend Gateway) {
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], gatewayIDB)
+
+	b.RLock()
+	owner := b.owners[gatewayID]
+	b.RUnlock()
+
+	if owner == backend {
+		return
+	}
+
+	b.Lock()
+	b.owners[gatewayID] = backend
+	b.Unlock()
+
+	log.WithField("gateway_id", gatewayID).Info("gateway: gateway backend of gateway registered")
+}
+
+func (b *MultiBackend) forwardUplinkFrames(backend Gateway) {
+	defer b.wg.Done()
+
+	for uplinkFrame := range backend.RXPacketChan() {
+		if uplinkFrame.RxInfo != nil {
+			b.setOwner(uplinkFrame.RxInfo.GatewayId, backend)
+		}
+		b.uplinkFrameChan <- uplinkFrame
+	}
+}
+
+func (b *MultiBackend) forwardGatewayStats(backend Gateway) {
+	defer b.wg.Done()
+
+	for stats := range backend.StatsPacketChan() {
+		b.setOwner(stats.GatewayId, backend)
+		b.gatewayStatsChan <- stats
+	}
+}
+
+func (b *MultiBackend) forwardDownlinkTXAcks(backend Gateway) {
+	defer b.wg.Done()
+
+	for ack := range backend.DownlinkTXAckChan() {
+		b.setOwner(ack.GatewayId, backend)
+		b.downlinkTXAckChan <- ack
+	}
+}
+
+func (b *MultiBackend) forwardConnStates(backend Gateway, csb ConnStateBackend) {
+	defer b.wg.Done()
+
+	for state := range csb.ConnStateChan() {
+		if state.State == gw.ConnState_ONLINE {
+			b.setOwner(state.GatewayId, backend)
+		}
+		b.connStateChan <- state
+	}
+}
+
+func (b *MultiBackend) forwardGatewayCommandExecResponses(backend Gateway, ceb CommandExecBackend) {
+	defer b.wg.Done()
+
+	for resp := range ceb.GatewayCommandExecResponseChan() {
+		b.setOwner(resp.GatewayId, backend)
+		b.execResponseChan <- resp
+	}
+}
+
+func (b *MultiBackend) forwardNoiseFloorReports(backend Gateway, nfb NoiseFloorBackend) {
+	defer b.wg.Done()
+
+	for report := range nfb.NoiseFloorReportChan() {
+		b.setOwner(report.GatewayId, backend)
+		b.noiseFloorChan <- report
+	}
+}
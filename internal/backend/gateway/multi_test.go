@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+type testBackend struct {
+	sendErr error
+
+	txPacketChan      chan gw.DownlinkFrame
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+}
+
+func newTestBackend() *testBackend {
+	return &testBackend{
+		txPacketChan:      make(chan gw.DownlinkFrame, 10),
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+	}
+}
+
+func (b *testBackend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if b.sendErr != nil {
+		return b.sendErr
+	}
+	b.txPacketChan <- pl
+	return nil
+}
+
+func (b *testBackend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	return b.sendErr
+}
+
+func (b *testBackend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+func (b *testBackend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+func (b *testBackend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+func (b *testBackend) Close() error {
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+	return nil
+}
+
+func TestMultiBackend(t *testing.T) {
+	assert := require.New(t)
+
+	gatewayA := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gatewayB := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+	gatewayC := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+
+	backendA := newTestBackend()
+	backendB := newTestBackend()
+	b := NewMultiBackend(backendA, backendB)
+
+	t.Run("Uplinks are multiplexed", func(t *testing.T) {
+		assert := require.New(t)
+
+		backendA.uplinkFrameChan <- gw.UplinkFrame{
+			PhyPayload: []byte{1},
+			RxInfo:     &gw.UplinkRXInfo{GatewayId: gatewayA[:]},
+		}
+		uplinkFrame := <-b.RXPacketChan()
+		assert.Equal([]byte{1}, uplinkFrame.PhyPayload)
+
+		backendB.gatewayStatsChan <- gw.GatewayStats{GatewayId: gatewayB[:]}
+		stats := <-b.StatsPacketChan()
+		assert.Equal(gatewayB[:], stats.GatewayId)
+
+		backendB.downlinkTXAckChan <- gw.DownlinkTXAck{GatewayId: gatewayB[:], Token: 123}
+		ack := <-b.DownlinkTXAckChan()
+		assert.EqualValues(123, ack.Token)
+	})
+
+	t.Run("Downlinks are routed to the owner", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(b.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayA[:]},
+		}))
+		assert.Len(backendA.txPacketChan, 1)
+		assert.Len(backendB.txPacketChan, 0)
+		<-backendA.txPacketChan
+
+		assert.NoError(b.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayB[:]},
+		}))
+		assert.Len(backendA.txPacketChan, 0)
+		assert.Len(backendB.txPacketChan, 1)
+		<-backendB.txPacketChan
+	})
+
+	t.Run("Unknown owner", func(t *testing.T) {
+		assert := require.New(t)
+
+		backendB.sendErr = errors.New("gateway is not connected")
+		assert.NoError(b.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayC[:]},
+		}))
+		assert.Len(backendA.txPacketChan, 1)
+		<-backendA.txPacketChan
+
+		backendA.sendErr = errors.New("gateway is not connected")
+		assert.Error(b.SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayC[:]},
+		}))
+	})
+
+	assert.NoError(b.Close())
+	_, ok := <-b.RXPacketChan()
+	assert.False(ok)
+}
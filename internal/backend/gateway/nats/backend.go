@@ -0,0 +1,523 @@
+// Package nats implements a NATS gateway backend. The gateway events (uplink
+// frames, stats and TX acknowledgements) are consumed using a queue group, so
+// that the events are distributed over the LoRa Server instances sharing the
+// same queue group. When JetStream is enabled, the events and commands are
+// persisted in streams and the events are consumed using a durable consumer.
+// An event is acknowledged once it has been handled, so that events which
+// were received but not yet handled are re-delivered after a restart.
+package nats
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+// fetchBatchSize defines the max. number of events fetched at once from the
+// JetStream consumer.
+const fetchBatchSize = 10
+
+// jsStreamNotFound is the description of the JetStream API error returned
+// when a stream does not exist.
+const jsStreamNotFound = "stream not found"
+
+// Backend implements a NATS backend.
+type Backend struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	conn *nats.Conn
+	js   nats.JetStreamContext
+
+	// subs contains the core NATS subscriptions. The JetStream subscription
+	// is not included, as unsubscribing would delete the durable consumer.
+	subs []*nats.Subscription
+
+	// publish publishes the given data to the given subject. When JetStream
+	// is enabled, it returns after the message has been persisted.
+	publish func(subject string, data []byte) error
+
+	commandSubjectTemplate *template.Template
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+	gatewayMarshalers *marshaler.GatewayMarshalers
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.NATS
+
+	b := Backend{
+		gatewayMarshalers: marshaler.NewGatewayMarshalers(storage.RedisPool()),
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	var err error
+	b.commandSubjectTemplate, err = template.New("command").Parse(conf.CommandSubjectTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/nats: parse command subject template error")
+	}
+
+	tlsConfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/nats: new tls config error")
+	}
+
+	opts := []nats.Option{
+		nats.Name("loraserver"),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			natsDisconnectCounter().Inc()
+			log.WithError(err).Error("gateway/nats: disconnected from server")
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			natsConnectCounter().Inc()
+			log.WithField("server", nc.ConnectedUrl()).Info("gateway/nats: reconnected to server")
+		}),
+	}
+	if conf.Username != "" || conf.Password != "" {
+		opts = append(opts, nats.UserInfo(conf.Username, conf.Password))
+	}
+	if tlsConfig != nil {
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	log.WithField("server", conf.Server).Info("gateway/nats: connecting to server")
+	b.conn, err = nats.Connect(conf.Server, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "gateway/nats: connect to server error")
+	}
+	natsConnectCounter().Inc()
+
+	if conf.JetStream {
+		if err := b.setupJetStream(conf.EventSubject, conf.EventStream, conf.CommandStream, conf.StreamMaxAge); err != nil {
+			b.conn.Close()
+			return nil, errors.Wrap(err, "gateway/nats: setup jetstream error")
+		}
+
+		log.WithFields(log.Fields{
+			"subject": conf.EventSubject,
+			"durable": conf.DurableName,
+		}).Info("gateway/nats: consuming event stream")
+
+		sub, err := b.js.PullSubscribe(conf.EventSubject, conf.DurableName, nats.AckWait(conf.AckWait))
+		if err != nil {
+			b.conn.Close()
+			return nil, errors.Wrap(err, "gateway/nats: subscribe to event stream error")
+		}
+
+		b.publish = func(subject string, data []byte) error {
+			_, err := b.js.Publish(subject, data)
+			return err
+		}
+
+		b.wg.Add(1)
+		go b.fetchEvents(sub)
+	} else {
+		log.WithFields(log.Fields{
+			"subject":     conf.EventSubject,
+			"queue_group": conf.QueueGroup,
+		}).Info("gateway/nats: subscribing to event subject")
+
+		msgChan := make(chan *nats.Msg, 64)
+		sub, err := b.conn.ChanQueueSubscribe(conf.EventSubject, conf.QueueGroup, msgChan)
+		if err != nil {
+			b.conn.Close()
+			return nil, errors.Wrap(err, "gateway/nats: subscribe to event subject error")
+		}
+		b.subs = append(b.subs, sub)
+
+		b.publish = b.conn.Publish
+
+		b.wg.Add(1)
+		go b.receiveEvents(msgChan)
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalDownlinkFrame(t, pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/nats: marshal downlink frame error")
+	}
+
+	return b.publishCommand(log.Fields{
+		"downlink_id": downID,
+	}, gatewayID, "down", bb)
+}
+
+// SendGatewayConfigPacket sends the given gateway configuration to the gateway.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	gatewayID := helpers.GetGatewayID(&pl)
+	t := b.getGatewayMarshaler(gatewayID)
+
+	bb, err := marshaler.MarshalGatewayConfiguration(t, pl)
+	if err != nil {
+		return errors.Wrap(err, "gateway/nats: marshal gateway configuration error")
+	}
+
+	return b.publishCommand(log.Fields{}, gatewayID, "config", bb)
+}
+
+// RXPacketChan returns the channel to which uplink frames are published.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the channel to which gateway stats are published.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx ack channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// Close closes the backend. Events which have been received from the
+// event stream but not yet handled are not acknowledged and will be
+// re-delivered.
+func (b *Backend) Close() error {
+	log.Info("gateway/nats: closing backend")
+	b.cancel()
+
+	for _, sub := range b.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			log.WithError(err).WithField("subject", sub.Subject).Error("gateway/nats: unsubscribe error")
+		}
+	}
+
+	log.Info("gateway/nats: handling last messages")
+	b.wg.Wait()
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+
+	b.conn.Close()
+
+	return nil
+}
+
+// setupJetStream creates the event and command streams, when they do not yet
+// exist. The subjects of the command stream are derived from the command
+// subject template.
+func (b *Backend) setupJetStream(eventSubject, eventStream, commandStream string, maxAge time.Duration) error {
+	var err error
+	b.js, err = b.conn.JetStream()
+	if err != nil {
+		return errors.Wrap(err, "get jetstream context error")
+	}
+
+	commandSubject, err := getCommandStreamSubject(b.commandSubjectTemplate)
+	if err != nil {
+		return errors.Wrap(err, "get command stream subject error")
+	}
+
+	return ensureStreams(b.js, map[string]string{
+		eventStream:   eventSubject,
+		commandStream: commandSubject,
+	}, maxAge)
+}
+
+// ensureStreams creates the given streams (name to subject), when they do not
+// yet exist.
+func ensureStreams(js nats.JetStreamManager, streams map[string]string, maxAge time.Duration) error {
+	for name, subject := range streams {
+		_, err := js.StreamInfo(name)
+		if err == nil {
+			continue
+		}
+		if !isStreamNotFound(err) {
+			return errors.Wrap(err, "get stream info error")
+		}
+
+		log.WithFields(log.Fields{
+			"stream":  name,
+			"subject": subject,
+		}).Info("gateway/nats: creating stream")
+
+		if _, err := js.AddStream(&nats.StreamConfig{
+			Name:     name,
+			Subjects: []string{subject},
+			Storage:  nats.FileStorage,
+			MaxAge:   maxAge,
+		}); err != nil {
+			return errors.Wrap(err, "add stream error")
+		}
+	}
+
+	return nil
+}
+
+// isStreamNotFound returns true when the given StreamInfo error is caused by
+// a missing stream. The nats.go client (v1.11) returns the description of the
+// JetStream API error as a plain error.
+func isStreamNotFound(err error) bool {
+	return strings.TrimPrefix(err.Error(), "nats: ") == jsStreamNotFound
+}
+
+func (b *Backend) setGatewayMarshaler(gatewayID lorawan.EUI64, t marshaler.Type) {
+	b.gatewayMarshalers.Set(gatewayID, t)
+}
+
+func (b *Backend) getGatewayMarshaler(gatewayID lorawan.EUI64) marshaler.Type {
+	return b.gatewayMarshalers.Get(gatewayID)
+}
+
+func (b *Backend) publishCommand(fields log.Fields, gatewayID lorawan.EUI64, command string, data []byte) error {
+	start := time.Now()
+
+	subject := bytes.NewBuffer(nil)
+	if err := b.commandSubjectTemplate.Execute(subject, struct {
+		GatewayID   lorawan.EUI64
+		CommandType string
+	}{gatewayID, command}); err != nil {
+		return errors.Wrap(err, "execute command subject template error")
+	}
+
+	if err := b.publish(subject.String(), data); err != nil {
+		return errors.Wrap(err, "publish message error")
+	}
+
+	fields["duration"] = time.Now().Sub(start)
+	fields["gateway_id"] = gatewayID
+	fields["command"] = command
+	fields["subject"] = subject.String()
+
+	log.WithFields(fields).Info("gateway/nats: message published")
+
+	natsCommandCounter(command).Inc()
+
+	return nil
+}
+
+// receiveEvents handles the events received through the given channel until
+// the backend is closed.
+func (b *Backend) receiveEvents(msgChan chan *nats.Msg) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case msg := <-msgChan:
+			b.handleMessage(msg.Subject, msg.Data)
+		}
+	}
+}
+
+// fetchEvents fetches and handles the events of the given JetStream
+// subscription until the backend is closed. Each event is acknowledged after
+// it has been handled.
+func (b *Backend) fetchEvents(sub *nats.Subscription) {
+	defer b.wg.Done()
+
+	for {
+		msgs, err := sub.Fetch(fetchBatchSize, nats.Context(b.ctx))
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+			if err == context.DeadlineExceeded || err == nats.ErrTimeout {
+				continue
+			}
+
+			log.WithError(err).Error("gateway/nats: fetch messages error")
+			time.Sleep(2 * time.Second)
+			continue
+		}
+
+		for _, msg := range msgs {
+			b.handleMessage(msg.Subject, msg.Data)
+
+			if err := msg.Ack(); err != nil {
+				log.WithError(err).WithField("subject", msg.Subject).Error("gateway/nats: ack message error")
+			}
+		}
+	}
+}
+
+// handleMessage handles the given event. The event type is the last token of
+// the subject.
+func (b *Backend) handleMessage(subject string, data []byte) {
+	typ := subject
+	if i := strings.LastIndex(subject, "."); i != -1 {
+		typ = subject[i+1:]
+	}
+
+	natsEventCounter(typ).Inc()
+
+	var err error
+
+	switch typ {
+	case "up":
+		err = b.handleUplinkFrame(data)
+	case "stats":
+		err = b.handleGatewayStats(data)
+	case "ack":
+		err = b.handleDownlinkTXAck(data)
+	default:
+		log.WithFields(log.Fields{
+			"type":    typ,
+			"subject": subject,
+		}).Warning("gateway/nats: unexpected message type")
+	}
+
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"type":        typ,
+			"subject":     subject,
+			"data_base64": base64.StdEncoding.EncodeToString(data),
+		}).Error("gateway/nats: handle received message error")
+	}
+}
+
+func (b *Backend) handleUplinkFrame(data []byte) error {
+	var uplinkFrame gw.UplinkFrame
+	t, err := marshaler.UnmarshalUplinkFrame(data, &uplinkFrame)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	if uplinkFrame.RxInfo == nil {
+		return errors.New("rx_info must not be nil")
+	}
+
+	if uplinkFrame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(uplinkFrame.RxInfo)
+	b.setGatewayMarshaler(gatewayID, t)
+	upID := helpers.GetUplinkID(uplinkFrame.RxInfo)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  upID,
+	}).Info("gateway/nats: uplink event received")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleGatewayStats(data []byte) error {
+	var gatewayStats gw.GatewayStats
+	t, err := marshaler.UnmarshalGatewayStats(data, &gatewayStats)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	gatewayID := helpers.GetGatewayID(&gatewayStats)
+	b.setGatewayMarshaler(gatewayID, t)
+	statsID := helpers.GetStatsID(&gatewayStats)
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"stats_id":   statsID,
+	}).Info("gateway/nats: stats event received")
+
+	b.gatewayStatsChan <- gatewayStats
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTXAck(data []byte) error {
+	var ack gw.DownlinkTXAck
+	t, err := marshaler.UnmarshalDownlinkTXAck(data, &ack)
+	if err != nil {
+		return errors.Wrap(err, "unmarshal error")
+	}
+
+	gatewayID := helpers.GetGatewayID(&ack)
+	b.setGatewayMarshaler(gatewayID, t)
+	downID := helpers.GetDownlinkID(&ack)
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/nats: ack event received")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+// getCommandStreamSubject returns the subject of the command stream, by
+// rendering the command subject template using wildcards for the gateway ID
+// and command type.
+func getCommandStreamSubject(t *template.Template) (string, error) {
+	subject := bytes.NewBuffer(nil)
+	if err := t.Execute(subject, struct {
+		GatewayID   string
+		CommandType string
+	}{"*", "*"}); err != nil {
+		return "", errors.Wrap(err, "execute command subject template error")
+	}
+
+	return subject.String(), nil
+}
+
+func newTLSConfig(cafile, certFile, certKeyFile string) (*tls.Config, error) {
+	if cafile == "" && certFile == "" && certKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if cafile != "" {
+		cacert, err := nstls.ReadPEM(cafile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca certificate error")
+		}
+		certpool := x509.NewCertPool()
+		certpool.AppendCertsFromPEM(cacert)
+
+		tlsConfig.RootCAs = certpool
+	}
+
+	if certFile != "" && certKeyFile != "" {
+		kp, err := nstls.LoadX509KeyPair(certFile, certKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{kp}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,219 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+)
+
+// testStreamManager implements the stream methods of the JetStream manager.
+type testStreamManager struct {
+	nats.JetStreamManager
+
+	streams map[string]*nats.StreamConfig
+	err     error
+}
+
+func (m *testStreamManager) StreamInfo(stream string, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	cfg, ok := m.streams[stream]
+	if !ok {
+		// the nats.go client returns the JetStream API error description
+		return nil, errors.New("stream not found")
+	}
+	return &nats.StreamInfo{Config: *cfg}, nil
+}
+
+func (m *testStreamManager) AddStream(cfg *nats.StreamConfig, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
+	m.streams[cfg.Name] = cfg
+	return &nats.StreamInfo{Config: *cfg}, nil
+}
+
+type testMessage struct {
+	subject string
+	data    []byte
+}
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend   *Backend
+	published []testMessage
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	ts.published = nil
+	ts.backend = &Backend{
+		ctx:                    context.Background(),
+		commandSubjectTemplate: template.Must(template.New("command").Parse("gateway.{{ .GatewayID }}.command.{{ .CommandType }}")),
+		gatewayMarshalers:      marshaler.NewGatewayMarshalers(nil),
+		uplinkFrameChan:        make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:       make(chan gw.GatewayStats, 10),
+		downlinkTXAckChan:      make(chan gw.DownlinkTXAck, 10),
+	}
+	ts.backend.publish = func(subject string, data []byte) error {
+		ts.published = append(ts.published, testMessage{subject: subject, data: data})
+		return nil
+	}
+}
+
+func (ts *BackendTestSuite) TestGetCommandStreamSubject() {
+	assert := require.New(ts.T())
+
+	subject, err := getCommandStreamSubject(ts.backend.commandSubjectTemplate)
+	assert.NoError(err)
+	assert.Equal("gateway.*.command.*", subject)
+}
+
+func (ts *BackendTestSuite) TestUplinkFrame() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	uplinkFrame := gw.UplinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}
+	b, err := proto.Marshal(&uplinkFrame)
+	assert.NoError(err)
+
+	ts.backend.handleMessage("gateway.0102030405060708.event.up", b)
+
+	received := <-ts.backend.uplinkFrameChan
+	assert.Equal(uplinkFrame.PhyPayload, received.PhyPayload)
+	assert.Equal(marshaler.Protobuf, ts.backend.getGatewayMarshaler(gatewayID))
+}
+
+func (ts *BackendTestSuite) TestGatewayStats() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	stats := gw.GatewayStats{
+		GatewayId:         gatewayID[:],
+		RxPacketsReceived: 10,
+	}
+	b, err := proto.Marshal(&stats)
+	assert.NoError(err)
+
+	ts.backend.handleMessage("gateway.0102030405060708.event.stats", b)
+
+	received := <-ts.backend.gatewayStatsChan
+	assert.EqualValues(10, received.RxPacketsReceived)
+}
+
+func (ts *BackendTestSuite) TestDownlinkTXAck() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ack := gw.DownlinkTXAck{
+		GatewayId: gatewayID[:],
+		Token:     12345,
+	}
+	b, err := proto.Marshal(&ack)
+	assert.NoError(err)
+
+	ts.backend.handleMessage("gateway.0102030405060708.event.ack", b)
+
+	received := <-ts.backend.downlinkTXAckChan
+	assert.EqualValues(12345, received.Token)
+}
+
+func (ts *BackendTestSuite) TestSendTXPacket() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts.backend.setGatewayMarshaler(gatewayID, marshaler.Protobuf)
+
+	downlinkFrame := gw.DownlinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		Token:      12345,
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId: gatewayID[:],
+		},
+	}
+	assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+	assert.Len(ts.published, 1)
+	assert.Equal("gateway.0102030405060708.command.down", ts.published[0].subject)
+
+	var received gw.DownlinkFrame
+	assert.NoError(proto.Unmarshal(ts.published[0].data, &received))
+	assert.Equal(downlinkFrame.PhyPayload, received.PhyPayload)
+	assert.Equal(downlinkFrame.Token, received.Token)
+}
+
+func TestEnsureStreams(t *testing.T) {
+	streams := map[string]string{
+		"EVENTS":   "gateway.*.event.*",
+		"COMMANDS": "gateway.*.command.*",
+	}
+
+	t.Run("Create missing stream", func(t *testing.T) {
+		assert := require.New(t)
+
+		existing := &nats.StreamConfig{
+			Name:     "EVENTS",
+			Subjects: []string{"gateway.*.event.*"},
+			MaxAge:   time.Minute,
+		}
+		m := testStreamManager{
+			streams: map[string]*nats.StreamConfig{
+				"EVENTS": existing,
+			},
+		}
+
+		assert.NoError(ensureStreams(&m, streams, time.Hour))
+		assert.Len(m.streams, 2)
+
+		// the existing stream is left untouched
+		assert.Equal(existing, m.streams["EVENTS"])
+		assert.Equal(&nats.StreamConfig{
+			Name:     "COMMANDS",
+			Subjects: []string{"gateway.*.command.*"},
+			Storage:  nats.FileStorage,
+			MaxAge:   time.Hour,
+		}, m.streams["COMMANDS"])
+	})
+
+	t.Run("Stream info error", func(t *testing.T) {
+		assert := require.New(t)
+
+		m := testStreamManager{
+			streams: make(map[string]*nats.StreamConfig),
+			err:     nats.ErrTimeout,
+		}
+
+		assert.Error(ensureStreams(&m, streams, time.Hour))
+		assert.Len(m.streams, 0)
+	})
+}
+
+func TestIsStreamNotFound(t *testing.T) {
+	assert := require.New(t)
+
+	assert.True(isStreamNotFound(errors.New("stream not found")))
+	assert.True(isStreamNotFound(errors.New("nats: stream not found")))
+	assert.False(isStreamNotFound(errors.New("insufficient resources")))
+	assert.False(isStreamNotFound(nats.ErrTimeout))
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
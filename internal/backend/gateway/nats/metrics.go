@@ -0,0 +1,44 @@
+package nats
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_nats_event_count",
+		Help: "The number of received events by the NATS backend (per event type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_nats_command_count",
+		Help: "The number of published commands by the NATS backend (per command type).",
+	}, []string{"command"})
+
+	natsc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backend_nats_connect_count",
+		Help: "The number of times the NATS backend connected to the NATS server.",
+	})
+
+	natsd = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "backend_nats_disconnect_count",
+		Help: "The number of times the NATS backend disconnected from the NATS server.",
+	})
+)
+
+func natsEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func natsCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
+
+func natsConnectCounter() prometheus.Counter {
+	return natsc
+}
+
+func natsDisconnectCounter() prometheus.Counter {
+	return natsd
+}
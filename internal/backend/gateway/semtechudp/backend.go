@@ -0,0 +1,412 @@
+// Package semtechudp implements a gateway backend speaking the Semtech UDP
+// packet-forwarder protocol, so that legacy gateways can connect to LoRa
+// Server without a LoRa Gateway Bridge.
+package semtechudp
+
+import (
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+)
+
+// maxPacketSize is the max. size of a UDP packet.
+const maxPacketSize = 65507
+
+// gatewayConnection holds the state of a gateway, as learned from its
+// PULL_DATA packets.
+type gatewayConnection struct {
+	addr            net.Addr
+	protocolVersion uint8
+	lastSeen        time.Time
+
+	// downlinkIDs contains the downlink IDs of the pending downlinks (by
+	// token), for setting the downlink ID of the tx acknowledgement.
+	downlinkIDs map[uint16][]byte
+}
+
+// Backend implements a Semtech UDP packet-forwarder backend.
+type Backend struct {
+	sync.RWMutex
+
+	conn           net.PacketConn
+	closed         bool
+	done           chan struct{}
+	wg             sync.WaitGroup
+	gatewayTimeout time.Duration
+	gateways       map[lorawan.EUI64]*gatewayConnection
+
+	uplinkFrameChan   chan gw.UplinkFrame
+	gatewayStatsChan  chan gw.GatewayStats
+	downlinkTXAckChan chan gw.DownlinkTXAck
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(c config.Config) (gateway.Gateway, error) {
+	conf := c.NetworkServer.Gateway.Backend.SemtechUDP
+
+	b := Backend{
+		done:           make(chan struct{}),
+		gatewayTimeout: conf.GatewayTimeout,
+		gateways:       make(map[lorawan.EUI64]*gatewayConnection),
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame),
+		gatewayStatsChan:  make(chan gw.GatewayStats),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck),
+	}
+
+	var err error
+	b.conn, err = handover.ListenPacket("gateway_semtech_udp", conf.Bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "start semtech udp listener error")
+	}
+
+	log.WithField("bind", conf.Bind).Info("gateway/semtech_udp: starting semtech udp listener")
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.readPackets()
+	}()
+
+	if b.gatewayTimeout != 0 {
+		go b.cleanupLoop()
+	}
+
+	return &b, nil
+}
+
+// SendTXPacket sends the given downlink frame to the gateway.
+func (b *Backend) SendTXPacket(pl gw.DownlinkFrame) error {
+	if pl.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(pl.TxInfo)
+	downID := helpers.GetDownlinkID(&pl)
+
+	b.Lock()
+	gwc, ok := b.gateways[gatewayID]
+	if !ok {
+		b.Unlock()
+		return fmt.Errorf("gateway %s is not connected", gatewayID)
+	}
+	addr := gwc.addr
+	protocolVersion := gwc.protocolVersion
+	// only protocol version 2 acknowledges the downlink using TX_ACK
+	if protocolVersion == protocolVersion2 {
+		gwc.downlinkIDs[uint16(pl.Token)] = pl.DownlinkId
+	}
+	b.Unlock()
+
+	packet, err := getPullRespPacket(protocolVersion, pl)
+	if err != nil {
+		return errors.Wrap(err, "get pull_resp packet error")
+	}
+
+	if err := b.sendPacket(addr, packet); err != nil {
+		return errors.Wrap(err, "send pull_resp packet error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  gatewayID,
+		"downlink_id": downID,
+	}).Info("gateway/semtech_udp: downlink sent to gateway")
+
+	semtechUDPCommandCounter(pullResp.String()).Inc()
+
+	return nil
+}
+
+// SendGatewayConfigPacket is not supported by the Semtech UDP protocol. The
+// channel configuration must be configured in the packet-forwarder
+// configuration.
+func (b *Backend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	log.WithField("gateway_id", helpers.GetGatewayID(&pl)).Debug("gateway/semtech_udp: gateway configuration is not supported, ignoring")
+	return nil
+}
+
+// RXPacketChan returns the uplink-frame channel.
+func (b *Backend) RXPacketChan() chan gw.UplinkFrame {
+	return b.uplinkFrameChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStats {
+	return b.gatewayStatsChan
+}
+
+// DownlinkTXAckChan returns the downlink tx acknowledgement channel.
+func (b *Backend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
+	return b.downlinkTXAckChan
+}
+
+// Close closes the backend. It waits until the received packets have been
+// handled before closing the channels.
+func (b *Backend) Close() error {
+	log.Info("gateway/semtech_udp: closing backend")
+
+	b.Lock()
+	b.closed = true
+	close(b.done)
+	b.Unlock()
+
+	if err := b.conn.Close(); err != nil {
+		return errors.Wrap(err, "close udp listener error")
+	}
+
+	b.wg.Wait()
+
+	close(b.uplinkFrameChan)
+	close(b.gatewayStatsChan)
+	close(b.downlinkTXAckChan)
+
+	return nil
+}
+
+func (b *Backend) isClosed() bool {
+	b.RLock()
+	defer b.RUnlock()
+	return b.closed
+}
+
+// readPackets reads the packets from the UDP connection until it is closed.
+// Each packet is handled in its own goroutine.
+func (b *Backend) readPackets() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		i, addr, err := b.conn.ReadFrom(buf)
+		if err != nil {
+			if !b.isClosed() {
+				log.WithError(err).Error("gateway/semtech_udp: read from udp connection error")
+			}
+			return
+		}
+
+		data := make([]byte, i)
+		copy(data, buf[:i])
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+
+			if err := b.handlePacket(addr, data); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"addr":        addr,
+					"data_base64": base64.StdEncoding.EncodeToString(data),
+				}).Error("gateway/semtech_udp: handle packet error")
+			}
+		}()
+	}
+}
+
+func (b *Backend) handlePacket(addr net.Addr, data []byte) error {
+	pt, err := getPacketType(data)
+	if err != nil {
+		return errors.Wrap(err, "get packet type error")
+	}
+
+	semtechUDPEventCounter(pt.String()).Inc()
+
+	switch pt {
+	case pushData:
+		return b.handlePushData(addr, data)
+	case pullData:
+		return b.handlePullData(addr, data)
+	case txACK:
+		return b.handleTXACK(data)
+	default:
+		return fmt.Errorf("unexpected packet type: %s", pt)
+	}
+}
+
+func (b *Backend) handlePullData(addr net.Addr, data []byte) error {
+	var p pullDataPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal pull_data packet error")
+	}
+
+	b.Lock()
+	gwc, ok := b.gateways[p.GatewayMAC]
+	if !ok {
+		gwc = &gatewayConnection{
+			downlinkIDs: make(map[uint16][]byte),
+		}
+		b.gateways[p.GatewayMAC] = gwc
+		semtechUDPConnectedGatewaysGauge().Inc()
+	}
+	gwc.addr = addr
+	gwc.protocolVersion = p.ProtocolVersion
+	gwc.lastSeen = time.Now()
+	b.Unlock()
+
+	if !ok {
+		log.WithFields(log.Fields{
+			"gateway_id":       p.GatewayMAC,
+			"addr":             addr,
+			"protocol_version": p.ProtocolVersion,
+		}).Info("gateway/semtech_udp: gateway connected")
+	}
+
+	if err := b.sendPacket(addr, pullACKPacket{header: p.header}); err != nil {
+		return errors.Wrap(err, "send pull_ack packet error")
+	}
+
+	semtechUDPCommandCounter(pullACK.String()).Inc()
+
+	return nil
+}
+
+func (b *Backend) handlePushData(addr net.Addr, data []byte) error {
+	var p pushDataPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal push_data packet error")
+	}
+
+	// ack the packet first, as the packet-forwarder is waiting for it
+	if err := b.sendPacket(addr, pushACKPacket{header: p.header}); err != nil {
+		return errors.Wrap(err, "send push_ack packet error")
+	}
+
+	semtechUDPCommandCounter(pushACK.String()).Inc()
+
+	for _, pk := range p.Payload.RXPK {
+		if err := b.handleRXPK(p.GatewayMAC, pk); err != nil {
+			log.WithError(err).WithField("gateway_id", p.GatewayMAC).Error("gateway/semtech_udp: handle rxpk error")
+		}
+	}
+
+	if p.Payload.Stat != nil {
+		ip, _, _ := net.SplitHostPort(addr.String())
+
+		stats, err := getGatewayStats(p.GatewayMAC, ip, *p.Payload.Stat)
+		if err != nil {
+			return errors.Wrap(err, "get gateway stats error")
+		}
+
+		log.WithFields(log.Fields{
+			"gateway_id": p.GatewayMAC,
+			"stats_id":   helpers.GetStatsID(&stats),
+		}).Info("gateway/semtech_udp: stats received from gateway")
+
+		b.gatewayStatsChan <- stats
+	}
+
+	return nil
+}
+
+func (b *Backend) handleRXPK(gatewayID lorawan.EUI64, pk rxpk) error {
+	// only frames with a valid CRC are forwarded
+	if pk.Stat != 1 {
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"stat":       pk.Stat,
+		}).Debug("gateway/semtech_udp: frame with invalid or missing crc, ignoring")
+		return nil
+	}
+
+	uplinkFrame, err := getUplinkFrame(gatewayID, pk)
+	if err != nil {
+		return errors.Wrap(err, "get uplink frame error")
+	}
+
+	uplinkID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	uplinkFrame.RxInfo.UplinkId = uplinkID[:]
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"uplink_id":  uplinkID,
+	}).Info("gateway/semtech_udp: uplink received from gateway")
+
+	b.uplinkFrameChan <- uplinkFrame
+
+	return nil
+}
+
+func (b *Backend) handleTXACK(data []byte) error {
+	var p txACKPacket
+	if err := p.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "unmarshal tx_ack packet error")
+	}
+
+	var downlinkID []byte
+	b.Lock()
+	if gwc, ok := b.gateways[p.GatewayMAC]; ok {
+		downlinkID = gwc.downlinkIDs[p.RandomToken]
+		delete(gwc.downlinkIDs, p.RandomToken)
+	}
+	b.Unlock()
+
+	ack := getDownlinkTXAck(p, downlinkID)
+
+	log.WithFields(log.Fields{
+		"gateway_id":  p.GatewayMAC,
+		"downlink_id": helpers.GetDownlinkID(&ack),
+		"error":       ack.Error,
+	}).Info("gateway/semtech_udp: ack received from gateway")
+
+	b.downlinkTXAckChan <- ack
+
+	return nil
+}
+
+// cleanupLoop periodically removes the gateways from which no PULL_DATA
+// has been received within the gateway timeout.
+func (b *Backend) cleanupLoop() {
+	ticker := time.NewTicker(b.gatewayTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			b.cleanupGateways(time.Now())
+		}
+	}
+}
+
+func (b *Backend) cleanupGateways(now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+
+	for gatewayID, gwc := range b.gateways {
+		if now.Sub(gwc.lastSeen) < b.gatewayTimeout {
+			continue
+		}
+
+		delete(b.gateways, gatewayID)
+		semtechUDPConnectedGatewaysGauge().Dec()
+
+		log.WithField("gateway_id", gatewayID).Info("gateway/semtech_udp: gateway timed out")
+	}
+}
+
+func (b *Backend) sendPacket(addr net.Addr, p encoding.BinaryMarshaler) error {
+	bb, err := p.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal packet error")
+	}
+
+	if _, err := b.conn.WriteTo(bb, addr); err != nil {
+		return errors.Wrap(err, "write to udp connection error")
+	}
+
+	return nil
+}
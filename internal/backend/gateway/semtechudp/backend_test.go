@@ -0,0 +1,462 @@
+package semtechudp
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+type BackendTestSuite struct {
+	suite.Suite
+
+	backend    *Backend
+	gatewayID  lorawan.EUI64
+	gatewayMAC []byte
+	gwConn     *net.UDPConn
+}
+
+func (ts *BackendTestSuite) SetupTest() {
+	assert := require.New(ts.T())
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(err)
+
+	ts.backend = &Backend{
+		conn:     conn,
+		done:     make(chan struct{}),
+		gateways: make(map[lorawan.EUI64]*gatewayConnection),
+
+		uplinkFrameChan:   make(chan gw.UplinkFrame, 10),
+		gatewayStatsChan:  make(chan gw.GatewayStats, 10),
+		downlinkTXAckChan: make(chan gw.DownlinkTXAck, 10),
+	}
+
+	ts.backend.wg.Add(1)
+	go func() {
+		defer ts.backend.wg.Done()
+		ts.backend.readPackets()
+	}()
+
+	ts.gatewayID = lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	ts.gatewayMAC = ts.gatewayID[:]
+
+	ts.gwConn, err = net.DialUDP("udp", nil, conn.LocalAddr().(*net.UDPAddr))
+	assert.NoError(err)
+}
+
+func (ts *BackendTestSuite) TearDownTest() {
+	ts.gwConn.Close()
+	require.NoError(ts.T(), ts.backend.Close())
+}
+
+func (ts *BackendTestSuite) send(b []byte) {
+	_, err := ts.gwConn.Write(b)
+	require.NoError(ts.T(), err)
+}
+
+func (ts *BackendTestSuite) receive() []byte {
+	assert := require.New(ts.T())
+
+	buf := make([]byte, maxPacketSize)
+	assert.NoError(ts.gwConn.SetReadDeadline(time.Now().Add(time.Second)))
+	i, err := ts.gwConn.Read(buf)
+	assert.NoError(err)
+	return buf[:i]
+}
+
+func (ts *BackendTestSuite) pullData() {
+	ts.send(append([]byte{0x02, 0x01, 0x02, 0x02}, ts.gatewayMAC...))
+	require.Equal(ts.T(), []byte{0x02, 0x01, 0x02, 0x04}, ts.receive())
+}
+
+func (ts *BackendTestSuite) TestPullData() {
+	assert := require.New(ts.T())
+
+	ts.pullData()
+
+	ts.backend.RLock()
+	gwc, ok := ts.backend.gateways[ts.gatewayID]
+	ts.backend.RUnlock()
+	assert.True(ok)
+	assert.Equal(protocolVersion2, gwc.protocolVersion)
+	assert.Equal(ts.gwConn.LocalAddr().String(), gwc.addr.String())
+}
+
+func (ts *BackendTestSuite) TestPushData() {
+	assert := require.New(ts.T())
+
+	pl := pushDataPayload{
+		RXPK: []rxpk{
+			{
+				Tmst: 1000000,
+				Freq: 868.1,
+				Chan: 1,
+				Stat: 1,
+				Modu: "LORA",
+				DatR: datR{LoRa: "SF7BW125"},
+				CodR: "4/5",
+				RSSI: -50,
+				LSNR: 5.5,
+				Size: 4,
+				Data: []byte{1, 2, 3, 4},
+			},
+			{
+				Stat: -1,
+				Modu: "LORA",
+				DatR: datR{LoRa: "SF7BW125"},
+				Data: []byte{4, 3, 2, 1},
+			},
+		},
+		Stat: &stat{
+			Time: expandedTime(time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)),
+			RXNb: 2,
+			RXOK: 1,
+		},
+	}
+	b, err := json.Marshal(pl)
+	assert.NoError(err)
+
+	ts.send(append(append([]byte{0x02, 0x03, 0x04, 0x00}, ts.gatewayMAC...), b...))
+	assert.Equal([]byte{0x02, 0x03, 0x04, 0x01}, ts.receive())
+
+	uplinkFrame := <-ts.backend.uplinkFrameChan
+	assert.Equal([]byte{1, 2, 3, 4}, uplinkFrame.PhyPayload)
+	assert.Equal(ts.gatewayMAC, uplinkFrame.RxInfo.GatewayId)
+	assert.Len(uplinkFrame.RxInfo.UplinkId, 16)
+
+	stats := <-ts.backend.gatewayStatsChan
+	assert.Equal(ts.gatewayMAC, stats.GatewayId)
+	assert.Equal("127.0.0.1", stats.Ip)
+	assert.EqualValues(2, stats.RxPacketsReceived)
+
+	// the frame with the invalid crc is not forwarded
+	assert.Len(ts.backend.uplinkFrameChan, 0)
+}
+
+func (ts *BackendTestSuite) TestSendTXPacket() {
+	assert := require.New(ts.T())
+
+	downlinkFrame := gw.DownlinkFrame{
+		PhyPayload: []byte{1, 2, 3, 4},
+		Token:      0x0201,
+		DownlinkId: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId:  ts.gatewayMAC,
+			Frequency:  868100000,
+			Power:      14,
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					Bandwidth:             125,
+					SpreadingFactor:       7,
+					CodeRate:              "4/5",
+					PolarizationInversion: true,
+				},
+			},
+			Timing: gw.DownlinkTiming_IMMEDIATELY,
+			TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+				ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
+			},
+		},
+	}
+
+	ts.T().Run("Gateway not connected", func(t *testing.T) {
+		assert := require.New(t)
+		assert.Error(ts.backend.SendTXPacket(downlinkFrame))
+	})
+
+	ts.pullData()
+	assert.NoError(ts.backend.SendTXPacket(downlinkFrame))
+
+	b := ts.receive()
+	assert.Equal([]byte{0x02, 0x01, 0x02, 0x03}, b[0:4])
+
+	var pl pullRespPayload
+	assert.NoError(json.Unmarshal(b[4:], &pl))
+	assert.True(pl.TXPK.Imme)
+	assert.Equal("SF7BW125", pl.TXPK.DatR.LoRa)
+	assert.Equal([]byte{1, 2, 3, 4}, pl.TXPK.Data)
+
+	ts.T().Run("TX ACK", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.send(append(append([]byte{0x02, 0x01, 0x02, 0x05}, ts.gatewayMAC...), []byte(`{"txpk_ack":{"error":"TOO_LATE"}}`)...))
+
+		ack := <-ts.backend.downlinkTXAckChan
+		assert.Equal(gw.DownlinkTXAck{
+			GatewayId:  ts.gatewayMAC,
+			Token:      0x0201,
+			Error:      "TOO_LATE",
+			DownlinkId: downlinkFrame.DownlinkId,
+		}, ack)
+		assert.Len(ts.backend.gateways[ts.gatewayID].downlinkIDs, 0)
+	})
+}
+
+func (ts *BackendTestSuite) TestCleanupGateways() {
+	assert := require.New(ts.T())
+
+	ts.backend.gatewayTimeout = time.Minute
+	ts.pullData()
+
+	ts.backend.cleanupGateways(time.Now())
+	assert.Len(ts.backend.gateways, 1)
+
+	ts.backend.cleanupGateways(time.Now().Add(time.Minute))
+	assert.Len(ts.backend.gateways, 0)
+}
+
+func TestBackend(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
+
+func TestGetUplinkFrame(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	rxTime := time.Date(2019, 8, 1, 12, 0, 0, 0, time.UTC)
+	rxTimeProto, _ := ptypes.TimestampProto(rxTime)
+	tmms := int64(1000)
+
+	tests := []struct {
+		Name          string
+		RXPK          rxpk
+		Expected      gw.UplinkFrame
+		ExpectedError bool
+	}{
+		{
+			Name: "LoRa",
+			RXPK: rxpk{
+				Time: &rxTime,
+				Tmms: &tmms,
+				Tmst: 0x01020304,
+				Freq: 868.1,
+				Chan: 2,
+				RFCh: 1,
+				Stat: 1,
+				Modu: "LORA",
+				DatR: datR{LoRa: "SF12BW250"},
+				CodR: "4/5",
+				RSSI: -120,
+				LSNR: -3.5,
+				Data: []byte{1, 2, 3},
+			},
+			Expected: gw.UplinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				TxInfo: &gw.UplinkTXInfo{
+					Frequency:  868100000,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       250,
+							SpreadingFactor: 12,
+							CodeRate:        "4/5",
+						},
+					},
+				},
+				RxInfo: &gw.UplinkRXInfo{
+					GatewayId:         gatewayID[:],
+					Time:              rxTimeProto,
+					TimeSinceGpsEpoch: ptypes.DurationProto(time.Second),
+					Rssi:              -120,
+					LoraSnr:           -3.5,
+					Channel:           2,
+					RfChain:           1,
+					Context:           []byte{1, 2, 3, 4},
+				},
+			},
+		},
+		{
+			Name: "FSK",
+			RXPK: rxpk{
+				Freq: 868.8,
+				Stat: 1,
+				Modu: "FSK",
+				DatR: datR{FSK: 50000},
+				Data: []byte{1, 2, 3},
+			},
+			Expected: gw.UplinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				TxInfo: &gw.UplinkTXInfo{
+					Frequency:  868800000,
+					Modulation: common.Modulation_FSK,
+					ModulationInfo: &gw.UplinkTXInfo_FskModulationInfo{
+						FskModulationInfo: &gw.FSKModulationInfo{
+							Bitrate: 50000,
+						},
+					},
+				},
+				RxInfo: &gw.UplinkRXInfo{
+					GatewayId: gatewayID[:],
+					Context:   []byte{0, 0, 0, 0},
+				},
+			},
+		},
+		{
+			Name: "invalid datr",
+			RXPK: rxpk{
+				Modu: "LORA",
+				DatR: datR{LoRa: "foo"},
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := getUplinkFrame(gatewayID, tst.RXPK)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, out)
+		})
+	}
+}
+
+func TestGetPullRespPacket(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	tmst := uint32(0x01020304 + 1000000)
+	tmms := int64(5000)
+
+	loraModInfo := &gw.DownlinkTXInfo_LoraModulationInfo{
+		LoraModulationInfo: &gw.LoRaModulationInfo{
+			Bandwidth:             125,
+			SpreadingFactor:       9,
+			CodeRate:              "4/5",
+			PolarizationInversion: true,
+		},
+	}
+
+	tests := []struct {
+		Name          string
+		DownlinkFrame gw.DownlinkFrame
+		Expected      txpk
+		ExpectedError bool
+	}{
+		{
+			Name: "delay timing",
+			DownlinkFrame: gw.DownlinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				TxInfo: &gw.DownlinkTXInfo{
+					GatewayId:      gatewayID[:],
+					Frequency:      868100000,
+					Power:          14,
+					Modulation:     common.Modulation_LORA,
+					ModulationInfo: loraModInfo,
+					Timing:         gw.DownlinkTiming_DELAY,
+					TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+						DelayTimingInfo: &gw.DelayTimingInfo{
+							Delay: ptypes.DurationProto(time.Second),
+						},
+					},
+					Context: []byte{1, 2, 3, 4},
+				},
+			},
+			Expected: txpk{
+				Tmst: &tmst,
+				Freq: 868.1,
+				Powe: 14,
+				Modu: "LORA",
+				DatR: datR{LoRa: "SF9BW125"},
+				CodR: "4/5",
+				IPol: true,
+				Size: 3,
+				Data: []byte{1, 2, 3},
+			},
+		},
+		{
+			Name: "gps epoch timing",
+			DownlinkFrame: gw.DownlinkFrame{
+				PhyPayload: []byte{1, 2, 3},
+				TxInfo: &gw.DownlinkTXInfo{
+					GatewayId:  gatewayID[:],
+					Frequency:  869525000,
+					Power:      27,
+					Modulation: common.Modulation_FSK,
+					ModulationInfo: &gw.DownlinkTXInfo_FskModulationInfo{
+						FskModulationInfo: &gw.FSKModulationInfo{
+							Bitrate: 50000,
+						},
+					},
+					Timing: gw.DownlinkTiming_GPS_EPOCH,
+					TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+						GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+							TimeSinceGpsEpoch: ptypes.DurationProto(5 * time.Second),
+						},
+					},
+				},
+			},
+			Expected: txpk{
+				Tmms: &tmms,
+				Freq: 869.525,
+				Powe: 27,
+				Modu: "FSK",
+				DatR: datR{FSK: 50000},
+				FDev: 25000,
+				Size: 3,
+				Data: []byte{1, 2, 3},
+			},
+		},
+		{
+			Name: "delay timing without context",
+			DownlinkFrame: gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Modulation:     common.Modulation_LORA,
+					ModulationInfo: loraModInfo,
+					Timing:         gw.DownlinkTiming_DELAY,
+					TimingInfo: &gw.DownlinkTXInfo_DelayTimingInfo{
+						DelayTimingInfo: &gw.DelayTimingInfo{
+							Delay: ptypes.DurationProto(time.Second),
+						},
+					},
+				},
+			},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := getPullRespPacket(protocolVersion2, tst.DownlinkFrame)
+			if tst.ExpectedError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, out.Payload.TXPK)
+		})
+	}
+}
+
+func TestDatR(t *testing.T) {
+	assert := require.New(t)
+
+	b, err := json.Marshal(datR{LoRa: "SF7BW125"})
+	assert.NoError(err)
+	assert.Equal(`"SF7BW125"`, string(b))
+
+	b, err = json.Marshal(datR{FSK: 50000})
+	assert.NoError(err)
+	assert.Equal(`50000`, string(b))
+
+	var d datR
+	assert.NoError(json.Unmarshal([]byte(`"SF12BW500"`), &d))
+	assert.Equal(datR{LoRa: "SF12BW500"}, d)
+
+	d = datR{}
+	assert.NoError(json.Unmarshal([]byte(`50000`), &d))
+	assert.Equal(datR{FSK: 50000}, d)
+}
@@ -0,0 +1,35 @@
+package semtechudp
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_semtech_udp_event_count",
+		Help: "The number of received packets by the Semtech UDP backend (per packet type).",
+	}, []string{"event"})
+
+	cc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "backend_semtech_udp_command_count",
+		Help: "The number of sent packets by the Semtech UDP backend (per packet type).",
+	}, []string{"command"})
+
+	gwc = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "backend_semtech_udp_connected_gateways",
+		Help: "The number of gateways connected to the Semtech UDP backend.",
+	})
+)
+
+func semtechUDPEventCounter(e string) prometheus.Counter {
+	return ec.With(prometheus.Labels{"event": e})
+}
+
+func semtechUDPCommandCounter(c string) prometheus.Counter {
+	return cc.With(prometheus.Labels{"command": c})
+}
+
+func semtechUDPConnectedGatewaysGauge() prometheus.Gauge {
+	return gwc
+}
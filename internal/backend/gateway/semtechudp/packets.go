@@ -0,0 +1,515 @@
+package semtechudp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+// packetType defines the Semtech UDP packet type.
+type packetType byte
+
+// Semtech UDP packet types.
+const (
+	pushData packetType = 0x00
+	pushACK  packetType = 0x01
+	pullData packetType = 0x02
+	pullResp packetType = 0x03
+	pullACK  packetType = 0x04
+	txACK    packetType = 0x05
+)
+
+// Semtech UDP protocol versions.
+const (
+	protocolVersion1 uint8 = 0x01
+	protocolVersion2 uint8 = 0x02
+)
+
+// Semtech UDP modulation identifiers.
+const (
+	modulationLoRa = "LORA"
+	modulationFSK  = "FSK"
+)
+
+// expandedTimeFormat is the time format used by the stat object.
+const expandedTimeFormat = "2006-01-02 15:04:05 MST"
+
+func (p packetType) String() string {
+	switch p {
+	case pushData:
+		return "push_data"
+	case pushACK:
+		return "push_ack"
+	case pullData:
+		return "pull_data"
+	case pullResp:
+		return "pull_resp"
+	case pullACK:
+		return "pull_ack"
+	case txACK:
+		return "tx_ack"
+	default:
+		return fmt.Sprintf("unknown_%d", byte(p))
+	}
+}
+
+// getPacketType returns the packet type of the given packet, after
+// validating the protocol version.
+func getPacketType(b []byte) (packetType, error) {
+	if len(b) < 4 {
+		return 0, errors.New("at least 4 bytes of data are expected")
+	}
+
+	if b[0] != protocolVersion1 && b[0] != protocolVersion2 {
+		return 0, fmt.Errorf("unsupported protocol version: %d", b[0])
+	}
+
+	return packetType(b[3]), nil
+}
+
+// header contains the fields shared by all packets.
+type header struct {
+	ProtocolVersion uint8
+	RandomToken     uint16
+}
+
+func (h header) marshal(pt packetType) []byte {
+	out := make([]byte, 4)
+	out[0] = h.ProtocolVersion
+	binary.LittleEndian.PutUint16(out[1:3], h.RandomToken)
+	out[3] = byte(pt)
+	return out
+}
+
+// unmarshalGatewayHeader decodes the header and gateway MAC of the packets
+// sent by the gateway and returns the remaining (JSON) payload.
+func unmarshalGatewayHeader(b []byte, pt packetType, h *header, mac *lorawan.EUI64) ([]byte, error) {
+	if len(b) < 12 {
+		return nil, errors.New("at least 12 bytes of data are expected")
+	}
+	if packetType(b[3]) != pt {
+		return nil, fmt.Errorf("identifier mismatch (expected: %s)", pt)
+	}
+
+	h.ProtocolVersion = b[0]
+	h.RandomToken = binary.LittleEndian.Uint16(b[1:3])
+	copy(mac[:], b[4:12])
+
+	return b[12:], nil
+}
+
+// pushDataPacket is used by the gateway to send uplink frames and stats.
+type pushDataPacket struct {
+	header
+	GatewayMAC lorawan.EUI64
+	Payload    pushDataPayload
+}
+
+// UnmarshalBinary decodes the packet from binary form.
+func (p *pushDataPacket) UnmarshalBinary(b []byte) error {
+	pl, err := unmarshalGatewayHeader(b, pushData, &p.header, &p.GatewayMAC)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(pl, &p.Payload)
+}
+
+// pushACKPacket is sent in reply of a pushDataPacket.
+type pushACKPacket struct {
+	header
+}
+
+// MarshalBinary encodes the packet into binary form.
+func (p pushACKPacket) MarshalBinary() ([]byte, error) {
+	return p.marshal(pushACK), nil
+}
+
+// pullDataPacket is periodically sent by the gateway, so that the server
+// knows the address to which downlinks must be sent.
+type pullDataPacket struct {
+	header
+	GatewayMAC lorawan.EUI64
+}
+
+// UnmarshalBinary decodes the packet from binary form.
+func (p *pullDataPacket) UnmarshalBinary(b []byte) error {
+	_, err := unmarshalGatewayHeader(b, pullData, &p.header, &p.GatewayMAC)
+	return err
+}
+
+// pullACKPacket is sent in reply of a pullDataPacket.
+type pullACKPacket struct {
+	header
+}
+
+// MarshalBinary encodes the packet into binary form.
+func (p pullACKPacket) MarshalBinary() ([]byte, error) {
+	return p.marshal(pullACK), nil
+}
+
+// pullRespPacket is used to send a downlink frame to the gateway.
+type pullRespPacket struct {
+	header
+	Payload pullRespPayload
+}
+
+// MarshalBinary encodes the packet into binary form.
+func (p pullRespPacket) MarshalBinary() ([]byte, error) {
+	pl, err := json.Marshal(p.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return append(p.marshal(pullResp), pl...), nil
+}
+
+// txACKPacket is sent by the gateway (protocol version 2) to acknowledge
+// the reception of a pullRespPacket.
+type txACKPacket struct {
+	header
+	GatewayMAC lorawan.EUI64
+	Payload    *txACKPayload
+}
+
+// UnmarshalBinary decodes the packet from binary form.
+func (p *txACKPacket) UnmarshalBinary(b []byte) error {
+	pl, err := unmarshalGatewayHeader(b, txACK, &p.header, &p.GatewayMAC)
+	if err != nil {
+		return err
+	}
+
+	// the payload is optional
+	if len(pl) == 0 || pl[0] == 0x00 {
+		return nil
+	}
+
+	p.Payload = &txACKPayload{}
+	return json.Unmarshal(pl, p.Payload)
+}
+
+// pushDataPayload contains the uplink frames and / or stats.
+type pushDataPayload struct {
+	RXPK []rxpk `json:"rxpk,omitempty"`
+	Stat *stat  `json:"stat,omitempty"`
+}
+
+// pullRespPayload contains the downlink frame.
+type pullRespPayload struct {
+	TXPK txpk `json:"txpk"`
+}
+
+// txACKPayload contains the result of the downlink.
+type txACKPayload struct {
+	TXPKACK txpkACK `json:"txpk_ack"`
+}
+
+// datR implements the data-rate, which is a string for LoRa (e.g.
+// SF7BW125) and a number (the bitrate) for FSK.
+type datR struct {
+	LoRa string
+	FSK  uint32
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d datR) MarshalJSON() ([]byte, error) {
+	if d.LoRa != "" {
+		return json.Marshal(d.LoRa)
+	}
+	return json.Marshal(d.FSK)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *datR) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &d.LoRa)
+	}
+	return json.Unmarshal(b, &d.FSK)
+}
+
+// expandedTime implements the time format used by the stat object.
+type expandedTime time.Time
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t expandedTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).UTC().Format(expandedTimeFormat))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *expandedTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	ts, err := time.Parse(expandedTimeFormat, s)
+	if err != nil {
+		return err
+	}
+	*t = expandedTime(ts)
+	return nil
+}
+
+// rxpk contains a received frame and its meta-data.
+type rxpk struct {
+	Time *time.Time `json:"time,omitempty"`
+	Tmms *int64     `json:"tmms,omitempty"`
+	Tmst uint32     `json:"tmst"`
+	Freq float64    `json:"freq"`
+	Chan uint32     `json:"chan"`
+	RFCh uint32     `json:"rfch"`
+	Stat int8       `json:"stat"`
+	Modu string     `json:"modu"`
+	DatR datR       `json:"datr"`
+	CodR string     `json:"codr"`
+	RSSI int32      `json:"rssi"`
+	LSNR float64    `json:"lsnr"`
+	Size uint16     `json:"size"`
+	Data []byte     `json:"data"`
+	Brd  uint32     `json:"brd"`
+	Ant  uint32     `json:"ant"`
+}
+
+// stat contains the gateway status and statistics.
+type stat struct {
+	Time expandedTime `json:"time"`
+	Lati *float64     `json:"lati,omitempty"`
+	Long *float64     `json:"long,omitempty"`
+	Alti *int32       `json:"alti,omitempty"`
+	RXNb uint32       `json:"rxnb"`
+	RXOK uint32       `json:"rxok"`
+	RXFW uint32       `json:"rxfw"`
+	ACKR float64      `json:"ackr"`
+	DWNb uint32       `json:"dwnb"`
+	TXNb uint32       `json:"txnb"`
+}
+
+// txpk contains the frame to emit and its meta-data.
+type txpk struct {
+	Imme bool    `json:"imme"`
+	Tmst *uint32 `json:"tmst,omitempty"`
+	Tmms *int64  `json:"tmms,omitempty"`
+	Freq float64 `json:"freq"`
+	RFCh uint32  `json:"rfch"`
+	Powe int32   `json:"powe"`
+	Modu string  `json:"modu"`
+	DatR datR    `json:"datr"`
+	CodR string  `json:"codr,omitempty"`
+	FDev uint32  `json:"fdev,omitempty"`
+	IPol bool    `json:"ipol"`
+	Size uint16  `json:"size"`
+	Data []byte  `json:"data"`
+	Brd  uint32  `json:"brd"`
+	Ant  uint32  `json:"ant"`
+}
+
+// txpkACK contains the downlink error (NONE on success).
+type txpkACK struct {
+	Error string `json:"error"`
+}
+
+// getUplinkFrame returns the gw.UplinkFrame for the given rxpk.
+func getUplinkFrame(gatewayID lorawan.EUI64, pk rxpk) (gw.UplinkFrame, error) {
+	out := gw.UplinkFrame{
+		PhyPayload: pk.Data,
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency: uint32(math.Round(pk.Freq * 1000000)),
+		},
+		RxInfo: &gw.UplinkRXInfo{
+			GatewayId: gatewayID[:],
+			Rssi:      pk.RSSI,
+			LoraSnr:   pk.LSNR,
+			Channel:   pk.Chan,
+			RfChain:   pk.RFCh,
+			Board:     pk.Brd,
+			Antenna:   pk.Ant,
+			Context:   make([]byte, 4),
+		},
+	}
+
+	switch pk.Modu {
+	case modulationLoRa:
+		var sf, bw uint32
+		if _, err := fmt.Sscanf(pk.DatR.LoRa, "SF%dBW%d", &sf, &bw); err != nil {
+			return out, errors.Wrapf(err, "parse datr %s error", pk.DatR.LoRa)
+		}
+
+		out.TxInfo.Modulation = common.Modulation_LORA
+		out.TxInfo.ModulationInfo = &gw.UplinkTXInfo_LoraModulationInfo{
+			LoraModulationInfo: &gw.LoRaModulationInfo{
+				Bandwidth:       bw,
+				SpreadingFactor: sf,
+				CodeRate:        pk.CodR,
+			},
+		}
+	case modulationFSK:
+		out.TxInfo.Modulation = common.Modulation_FSK
+		out.TxInfo.ModulationInfo = &gw.UplinkTXInfo_FskModulationInfo{
+			FskModulationInfo: &gw.FSKModulationInfo{
+				Bitrate: pk.DatR.FSK,
+			},
+		}
+	default:
+		return out, fmt.Errorf("unexpected modulation: %s", pk.Modu)
+	}
+
+	// the context is used for scheduling the downlink
+	binary.BigEndian.PutUint32(out.RxInfo.Context, pk.Tmst)
+
+	if pk.Time != nil {
+		t, err := ptypes.TimestampProto(*pk.Time)
+		if err != nil {
+			return out, errors.Wrap(err, "timestamp proto error")
+		}
+		out.RxInfo.Time = t
+	}
+
+	if pk.Tmms != nil {
+		out.RxInfo.TimeSinceGpsEpoch = ptypes.DurationProto(time.Duration(*pk.Tmms) * time.Millisecond)
+	}
+
+	return out, nil
+}
+
+// getGatewayStats returns the gw.GatewayStats for the given stat.
+func getGatewayStats(gatewayID lorawan.EUI64, ip string, st stat) (gw.GatewayStats, error) {
+	statsID, err := uuid.NewV4()
+	if err != nil {
+		return gw.GatewayStats{}, errors.Wrap(err, "new uuid error")
+	}
+
+	ts, err := ptypes.TimestampProto(time.Time(st.Time))
+	if err != nil {
+		return gw.GatewayStats{}, errors.Wrap(err, "timestamp proto error")
+	}
+
+	out := gw.GatewayStats{
+		GatewayId:           gatewayID[:],
+		Ip:                  ip,
+		Time:                ts,
+		RxPacketsReceived:   st.RXNb,
+		RxPacketsReceivedOk: st.RXOK,
+		TxPacketsReceived:   st.DWNb,
+		TxPacketsEmitted:    st.TXNb,
+		StatsId:             statsID[:],
+	}
+
+	if st.Lati != nil && st.Long != nil {
+		out.Location = &common.Location{
+			Latitude:  *st.Lati,
+			Longitude: *st.Long,
+			Source:    common.LocationSource_GPS,
+		}
+		if st.Alti != nil {
+			out.Location.Altitude = float64(*st.Alti)
+		}
+	}
+
+	return out, nil
+}
+
+// getPullRespPacket returns the pullRespPacket for the given downlink frame.
+func getPullRespPacket(protocolVersion uint8, pl gw.DownlinkFrame) (pullRespPacket, error) {
+	txInfo := pl.TxInfo
+
+	out := pullRespPacket{
+		header: header{
+			ProtocolVersion: protocolVersion,
+			RandomToken:     uint16(pl.Token),
+		},
+		Payload: pullRespPayload{
+			TXPK: txpk{
+				Freq: float64(txInfo.Frequency) / 1000000,
+				Powe: txInfo.Power,
+				Size: uint16(len(pl.PhyPayload)),
+				Data: pl.PhyPayload,
+				Brd:  txInfo.Board,
+				Ant:  txInfo.Antenna,
+			},
+		},
+	}
+
+	pk := &out.Payload.TXPK
+
+	switch txInfo.Modulation {
+	case common.Modulation_LORA:
+		modInfo := txInfo.GetLoraModulationInfo()
+		if modInfo == nil {
+			return out, errors.New("lora_modulation_info must not be nil")
+		}
+		pk.Modu = modulationLoRa
+		pk.DatR.LoRa = fmt.Sprintf("SF%dBW%d", modInfo.SpreadingFactor, modInfo.Bandwidth)
+		pk.CodR = modInfo.CodeRate
+		pk.IPol = modInfo.PolarizationInversion
+	case common.Modulation_FSK:
+		modInfo := txInfo.GetFskModulationInfo()
+		if modInfo == nil {
+			return out, errors.New("fsk_modulation_info must not be nil")
+		}
+		pk.Modu = modulationFSK
+		pk.DatR.FSK = modInfo.Bitrate
+		pk.FDev = modInfo.Bitrate / 2
+	default:
+		return out, fmt.Errorf("unexpected modulation: %s", txInfo.Modulation)
+	}
+
+	switch txInfo.Timing {
+	case gw.DownlinkTiming_DELAY:
+		if len(txInfo.Context) != 4 {
+			return out, errors.New("context must contain exactly 4 bytes for delay timing")
+		}
+
+		timingInfo := txInfo.GetDelayTimingInfo()
+		if timingInfo == nil {
+			return out, errors.New("delay_timing_info must not be nil")
+		}
+		delay, err := ptypes.Duration(timingInfo.Delay)
+		if err != nil {
+			return out, errors.Wrap(err, "get delay error")
+		}
+
+		tmst := binary.BigEndian.Uint32(txInfo.Context) + uint32(delay/time.Microsecond)
+		pk.Tmst = &tmst
+	case gw.DownlinkTiming_IMMEDIATELY:
+		pk.Imme = true
+	case gw.DownlinkTiming_GPS_EPOCH:
+		timingInfo := txInfo.GetGpsEpochTimingInfo()
+		if timingInfo == nil {
+			return out, errors.New("gps_epoch_timing_info must not be nil")
+		}
+		d, err := ptypes.Duration(timingInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			return out, errors.Wrap(err, "get time since gps epoch error")
+		}
+
+		tmms := int64(d / time.Millisecond)
+		pk.Tmms = &tmms
+	default:
+		return out, fmt.Errorf("unexpected timing: %s", txInfo.Timing)
+	}
+
+	return out, nil
+}
+
+// getDownlinkTXAck returns the gw.DownlinkTXAck for the given txACKPacket.
+func getDownlinkTXAck(p txACKPacket, downlinkID []byte) gw.DownlinkTXAck {
+	out := gw.DownlinkTXAck{
+		GatewayId:  p.GatewayMAC[:],
+		Token:      uint32(p.RandomToken),
+		DownlinkId: downlinkID,
+	}
+
+	if p.Payload != nil && p.Payload.TXPKACK.Error != "NONE" {
+		out.Error = p.Payload.TXPKACK.Error
+	}
+
+	return out
+}
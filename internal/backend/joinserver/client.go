@@ -7,13 +7,13 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan/backend"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
 )
 
 // Client defines the join-server client interface.
@@ -103,7 +103,7 @@ func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
 	tlsConfig := &tls.Config{}
 
 	if caCert != "" {
-		rawCACert, err := ioutil.ReadFile(caCert)
+		rawCACert, err := nstls.ReadPEM(caCert)
 		if err != nil {
 			return nil, errors.Wrap(err, "load ca cert error")
 		}
@@ -117,7 +117,7 @@ func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
 	}
 
 	if tlsCert != "" || tlsKey != "" {
-		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+		cert, err := nstls.LoadX509KeyPair(tlsCert, tlsKey)
 		if err != nil {
 			return nil, errors.Wrap(err, "load x509 keypair error")
 		}
@@ -1,16 +1,33 @@
 package joinserver
 
 import (
+	"sync"
+
 	"github.com/pkg/errors"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/resolver"
 )
 
-var p Pool
+var (
+	mux sync.RWMutex
+	p   Pool
+)
 
 // Setup sets up the joinserver backend.
 func Setup(c config.Config) error {
+	pp, err := NewPool(c)
+	if err != nil {
+		return err
+	}
+	SetPool(pp)
+
+	return nil
+}
+
+// NewPool creates a new join-server pool from the given configuration.
+func NewPool(c config.Config) (Pool, error) {
 	conf := c.JoinServer
 
 	defaultClient, err := NewClient(
@@ -20,14 +37,14 @@ func Setup(c config.Config) error {
 		conf.Default.TLSKey,
 	)
 	if err != nil {
-		return errors.Wrap(err, "joinserver: create default client error")
+		return nil, errors.Wrap(err, "joinserver: create default client error")
 	}
 
 	var certificates []certificate
 	for _, cert := range conf.Certificates {
 		var eui lorawan.EUI64
 		if err := eui.UnmarshalText([]byte(cert.JoinEUI)); err != nil {
-			return errors.Wrap(err, "joinserver: unmarshal JoinEUI error")
+			return nil, errors.Wrap(err, "joinserver: unmarshal JoinEUI error")
 		}
 
 		certificates = append(certificates, certificate{
@@ -38,23 +55,27 @@ func Setup(c config.Config) error {
 		})
 	}
 
-	p = &pool{
-		defaultClient:       defaultClient,
-		resolveJoinEUI:      conf.ResolveJoinEUI,
-		resolveDomainSuffix: conf.ResolveDomainSuffix,
-		clients:             make(map[lorawan.EUI64]poolClient),
-		certificates:        certificates,
-	}
-
-	return nil
+	return &pool{
+		defaultClient:  defaultClient,
+		resolveJoinEUI: conf.ResolveJoinEUI,
+		resolver:       resolver.New("", conf.ResolveDomainSuffix, conf.ResolveCacheTTL),
+		clients:        make(map[lorawan.EUI64]poolClient),
+		certificates:   certificates,
+	}, nil
 }
 
 // GetPool returns the joinserver pool.
 func GetPool() Pool {
+	mux.RLock()
+	defer mux.RUnlock()
+
 	return p
 }
 
 // SetPool sets the given join-server pool.
 func SetPool(pp Pool) {
+	mux.Lock()
+	defer mux.Unlock()
+
 	p = pp
 }
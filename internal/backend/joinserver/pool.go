@@ -1,15 +1,12 @@
 package joinserver
 
 import (
-	"fmt"
-	"net"
-	"strings"
 	"sync"
 
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/resolver"
 )
 
 // Pool defines the join-server client pool.
@@ -19,15 +16,16 @@ type Pool interface {
 
 type poolClient struct {
 	client Client
+	server string
 }
 
 type pool struct {
 	sync.RWMutex
-	defaultClient       Client
-	resolveJoinEUI      bool
-	clients             map[lorawan.EUI64]poolClient
-	certificates        []certificate
-	resolveDomainSuffix string
+	defaultClient  Client
+	resolveJoinEUI bool
+	resolver       *resolver.Resolver
+	clients        map[lorawan.EUI64]poolClient
+	certificates   []certificate
 }
 
 type certificate struct {
@@ -43,33 +41,35 @@ func (p *pool) Get(joinEUI lorawan.EUI64) (Client, error) {
 		return p.defaultClient, nil
 	}
 
+	// resolve the join-server EUI to an url (using DNS), the result is
+	// cached by the resolver
+	server, err := p.resolver.ResolveJoinEUI(joinEUI)
+	if err != nil {
+		log.WithField("join_eui", joinEUI).WithError(err).Warning("resolving JoinEUI failed, using default join-server")
+		return p.defaultClient, nil
+	}
+
 	p.RLock()
 	pc, ok := p.clients[joinEUI]
 	p.RUnlock()
-	if ok {
+	if ok && pc.server == server {
 		return pc.client, nil
 	}
 
-	client, err := p.resolveJoinServer(joinEUI)
+	client, err := p.newClient(joinEUI, server)
 	if err != nil {
-		log.WithField("join_eui", joinEUI).WithError(err).Warning("resolving JoinEUI failed, using default join-server")
+		log.WithField("join_eui", joinEUI).WithError(err).Warning("creating join-server client failed, using default join-server")
 		return p.defaultClient, nil
 	}
 
 	p.Lock()
-	p.clients[joinEUI] = poolClient{client: client}
+	p.clients[joinEUI] = poolClient{client: client, server: server}
 	p.Unlock()
 
 	return client, nil
 }
 
-func (p *pool) resolveJoinServer(joinEUI lorawan.EUI64) (Client, error) {
-	// resolve the join-server EUI to an url (using DNS)
-	server, err := p.resolveJoinEUIToJoinServerURL(joinEUI)
-	if err != nil {
-		return nil, errors.Wrap(err, "resolve joineui to join-server url error")
-	}
-
+func (p *pool) newClient(joinEUI lorawan.EUI64, server string) (Client, error) {
 	log.WithFields(log.Fields{
 		"join_eui": joinEUI,
 		"server":   server,
@@ -86,35 +86,3 @@ func (p *pool) resolveJoinServer(joinEUI lorawan.EUI64) (Client, error) {
 
 	return NewClient(server, caCert, tlsCert, tlsKey)
 }
-
-func (p *pool) resolveJoinEUIToJoinServerURL(joinEUI lorawan.EUI64) (string, error) {
-	server := p.joinEUIToServer(joinEUI)
-
-	return p.aToURL(server, true, 443)
-}
-
-func (p *pool) aToURL(server string, secure bool, port int) (string, error) {
-	_, err := net.LookupIP(server)
-	if err != nil {
-		return "", errors.Wrap(err, "lookup ip failed")
-	}
-
-	var protocol string
-	if secure {
-		protocol = "https://"
-	} else {
-		protocol = "http://"
-	}
-
-	return fmt.Sprintf("%s%s:%d/", protocol, server, port), nil
-}
-
-func (p *pool) joinEUIToServer(joinEUI lorawan.EUI64) string {
-	nibbles := strings.Split(joinEUI.String(), "")
-
-	for i, j := 0, len(nibbles)-1; i < j; i, j = i+1, j-1 {
-		nibbles[i], nibbles[j] = nibbles[j], nibbles[i]
-	}
-
-	return strings.Join(nibbles, ".") + p.resolveDomainSuffix
-}
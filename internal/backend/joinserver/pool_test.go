@@ -29,40 +29,16 @@ func (ts *PoolTestSuite) SetupSuite() {
 	assert.NoError(Setup(conf))
 }
 
-func (ts *PoolTestSuite) TestJoinEUIToServer() {
+func (ts *PoolTestSuite) TestGet() {
 	assert := require.New(ts.T())
 
-	assert.Equal("8.0.7.0.6.0.5.0.4.0.3.0.2.0.1.0.example.com", GetPool().(*pool).joinEUIToServer(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}))
-}
-
-func (ts *PoolTestSuite) TestAToServer() {
-	assert := require.New(ts.T())
-
-	tests := []struct {
-		Server   string
-		Secure   bool
-		Port     int
-		Expected string
-	}{
-		{
-			Server:   "example.com",
-			Secure:   false,
-			Port:     80,
-			Expected: "http://example.com:80/",
-		},
-		{
-			Server:   "example.com",
-			Secure:   true,
-			Port:     443,
-			Expected: "https://example.com:443/",
-		},
-	}
+	p := GetPool().(*pool)
+	assert.NotNil(p.resolver)
 
-	for _, tst := range tests {
-		url, err := GetPool().(*pool).aToURL(tst.Server, tst.Secure, tst.Port)
-		assert.NoError(err)
-		assert.Equal(tst.Expected, url)
-	}
+	// the JoinEUI can not be resolved, the default client is returned
+	c, err := p.Get(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.NoError(err)
+	assert.Equal(p.defaultClient, c)
 }
 
 func TestPool(t *testing.T) {
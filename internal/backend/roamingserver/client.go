@@ -0,0 +1,152 @@
+// Package roamingserver implements a client for the LoRaWAN Backend
+// Interfaces endpoint of a partner network, used for passive roaming.
+package roamingserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+// PRStartReq defines the PRStartReq message-type.
+const PRStartReq = "PRStartReq"
+
+// GWInfoElement defines the meta-data of a receiving gateway.
+type GWInfoElement struct {
+	ID        backend.HEXBytes `json:"ID"`
+	RFRegion  string           `json:"RFRegion,omitempty"`
+	RSSI      *int             `json:"RSSI,omitempty"`
+	SNR       *float64         `json:"SNR,omitempty"`
+	Lat       *float64         `json:"Lat,omitempty"`
+	Lon       *float64         `json:"Lon,omitempty"`
+	ULToken   backend.HEXBytes `json:"ULToken,omitempty"`
+	DLAllowed bool             `json:"DLAllowed"`
+}
+
+// ULMetaData defines the uplink meta-data.
+type ULMetaData struct {
+	DevAddr  *lorawan.DevAddr `json:"DevAddr,omitempty"`
+	DataRate *int             `json:"DataRate,omitempty"`
+	ULFreq   *float64         `json:"ULFreq,omitempty"`
+	RecvTime string           `json:"RecvTime"`
+	RFRegion string           `json:"RFRegion,omitempty"`
+	GWCnt    *int             `json:"GWCnt,omitempty"`
+	GWInfo   []GWInfoElement  `json:"GWInfo"`
+}
+
+// PRStartReqPayload defines the PRStartReq message payload.
+type PRStartReqPayload struct {
+	backend.BasePayload
+	PHYPayload backend.HEXBytes `json:"PHYPayload"`
+	ULMetaData ULMetaData       `json:"ULMetaData"`
+}
+
+// PRStartAnsPayload defines the PRStartAns message payload.
+type PRStartAnsPayload struct {
+	backend.BasePayload
+	Result   backend.Result `json:"Result"`
+	DevEUI   *lorawan.EUI64 `json:"DevEUI,omitempty"`
+	Lifetime *int           `json:"Lifetime,omitempty"`
+}
+
+// Client defines the roaming-server client interface.
+type Client interface {
+	PRStartReq(ctx context.Context, pl PRStartReqPayload) (PRStartAnsPayload, error)
+}
+
+type client struct {
+	server     string
+	httpClient *http.Client
+}
+
+// PRStartReq issues a passive-roaming start request.
+func (c *client) PRStartReq(ctx context.Context, pl PRStartReqPayload) (PRStartAnsPayload, error) {
+	var ans PRStartAnsPayload
+
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return ans, errors.Wrap(err, "marshal request error")
+	}
+
+	resp, err := c.httpClient.Post(c.server, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return ans, errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&ans)
+	if err != nil {
+		return ans, errors.Wrap(err, "unmarshal response error")
+	}
+
+	if ans.Result.ResultCode != backend.Success {
+		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+	}
+
+	return ans, nil
+}
+
+// NewClient creates a new roaming-server client.
+// If the caCert is set, it will configure the CA certificate to validate the
+// server certificate of the partner network. When the tlsCert and tlsKey are
+// set, then these will be configured as client-certificates for
+// authentication.
+func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
+	log.WithFields(log.Fields{
+		"server":   server,
+		"ca_cert":  caCert,
+		"tls_cert": tlsCert,
+		"tls_key":  tlsKey,
+	}).Info("configuring roaming-server client")
+
+	if caCert == "" && tlsCert == "" && tlsKey == "" {
+		return &client{
+			server:     server,
+			httpClient: http.DefaultClient,
+		}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caCert != "" {
+		rawCACert, err := nstls.ReadPEM(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca cert error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCACert) {
+			return nil, errors.New("append ca cert to pool error")
+		}
+
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if tlsCert != "" || tlsKey != "" {
+		cert, err := nstls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load x509 keypair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &client{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		server: server,
+	}, nil
+}
@@ -0,0 +1,255 @@
+// Package webhook implements a HTTP webhook sender for network-server events
+// (e.g. activations, errors and device-status).
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// Event defines the webhook event type.
+type Event string
+
+// Available events.
+const (
+	EventJoin      Event = "join"
+	EventError     Event = "error"
+	EventStatus    Event = "status"
+	EventFCntReset Event = "fcnt_reset"
+
+	EventMulticastGatewaySet Event = "multicast_gateway_set"
+	EventClassHealth         Event = "class_health"
+
+	// EventSecurity is only sent to the network-wide security webhook and
+	// can not be selected per service-profile.
+	EventSecurity Event = "security"
+)
+
+// SignatureHeader contains the HMAC-SHA256 signature (hex encoded) of the
+// request body, using the webhook secret as key.
+const SignatureHeader = "X-LoRa-Server-Signature"
+
+var (
+	timeout       = 5 * time.Second
+	maxRetries    = 3
+	retryInterval = time.Second
+	httpClient    = &http.Client{Timeout: timeout}
+)
+
+// Endpoint defines a webhook endpoint.
+type Endpoint struct {
+	// URL of the endpoint. The event type is added as event query parameter.
+	URL string
+
+	// Secret used for signing the request body. When empty, the request will
+	// not be signed.
+	Secret string
+
+	// Events to send. When empty, all events will be sent.
+	Events []string
+}
+
+// JoinEvent is sent on a (re)activation of a device.
+type JoinEvent struct {
+	DevEUI  lorawan.EUI64   `json:"devEUI"`
+	JoinEUI lorawan.EUI64   `json:"joinEUI"`
+	DevAddr lorawan.DevAddr `json:"devAddr"`
+}
+
+// ErrorEvent is sent on a device related error.
+type ErrorEvent struct {
+	DevEUI lorawan.EUI64 `json:"devEUI"`
+	Type   string        `json:"type"`
+	Error  string        `json:"error"`
+	FCnt   uint32        `json:"fCnt"`
+}
+
+// FCntResetEvent is sent when the uplink frame-counter of a device has been
+// reset (e.g. after a power-cycle of an ABP device).
+type FCntResetEvent struct {
+	DevEUI       lorawan.EUI64 `json:"devEUI"`
+	FCnt         uint32        `json:"fCnt"`
+	PreviousFCnt uint32        `json:"previousFCnt"`
+}
+
+// MulticastGatewaySetEvent is sent when the gateway-set of a
+// multicast-group has changed. Reason is either initial, uncovered (the
+// previous gateway-set did not cover all devices) or hysteresis (the new
+// gateway-set has been selected for multiple consecutive runs).
+type MulticastGatewaySetEvent struct {
+	MulticastGroupID   uuid.UUID       `json:"multicastGroupID"`
+	GatewayIDs         []lorawan.EUI64 `json:"gatewayIDs"`
+	PreviousGatewayIDs []lorawan.EUI64 `json:"previousGatewayIDs"`
+	Reason             string          `json:"reason"`
+}
+
+// ClassHealthEvent is sent when a device does not acknowledge the Class-B /
+// Class-C confirmed downlinks of its configured device-class.
+type ClassHealthEvent struct {
+	DevEUI              lorawan.EUI64 `json:"devEUI"`
+	Class               string        `json:"class"`
+	Status              string        `json:"status"`
+	ConfirmedDownlinks  int           `json:"confirmedDownlinks"`
+	Acknowledged        int           `json:"acknowledged"`
+	ConsecutiveTimeouts int           `json:"consecutiveTimeouts"`
+}
+
+// StatusEvent is sent on a received device-status.
+type StatusEvent struct {
+	DevEUI                  lorawan.EUI64 `json:"devEUI"`
+	Margin                  int           `json:"margin"`
+	ExternalPowerSource     bool          `json:"externalPowerSource"`
+	BatteryLevelUnavailable bool          `json:"batteryLevelUnavailable"`
+	BatteryLevel            float32       `json:"batteryLevel"`
+}
+
+// Setup configures the webhook package.
+func Setup(conf config.Config) error {
+	whConf := conf.NetworkServer.Webhook
+
+	if whConf.Timeout != 0 {
+		timeout = whConf.Timeout
+	}
+	maxRetries = whConf.MaxRetries
+	if whConf.RetryInterval != 0 {
+		retryInterval = whConf.RetryInterval
+	}
+
+	httpClient = &http.Client{Timeout: timeout}
+
+	return nil
+}
+
+// SetHTTPClient sets the HTTP client used for sending the events.
+func SetHTTPClient(c *http.Client) {
+	httpClient = c
+}
+
+// Send asynchronously sends the given event to the endpoint. It does nothing
+// when the endpoint has no URL or when the event has been filtered out.
+// On a failure, the request is retried (with an increasing interval).
+func Send(ctx context.Context, ep Endpoint, event Event, payload interface{}) {
+	if ep.URL == "" || !ep.wantsEvent(event) {
+		return
+	}
+
+	go func() {
+		if err := send(ep, event, payload); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"event":  event,
+				"ctx_id": ctx.Value(logging.ContextIDKey),
+			}).Error("webhook: send event error")
+		}
+	}()
+}
+
+func (ep Endpoint) wantsEvent(event Event) bool {
+	if len(ep.Events) == 0 {
+		return true
+	}
+
+	for _, e := range ep.Events {
+		if Event(e) == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func send(ep Endpoint, event Event, payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	u, err := url.Parse(ep.URL)
+	if err != nil {
+		return errors.Wrap(err, "parse url error")
+	}
+	q := u.Query()
+	q.Set("event", string(event))
+	u.RawQuery = q.Encode()
+
+	interval := retryInterval
+	for i := 0; ; i++ {
+		retry, err := post(u.String(), ep.Secret, b)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || i >= maxRetries {
+			return err
+		}
+
+		log.WithError(err).WithFields(log.Fields{
+			"event":   event,
+			"attempt": i + 1,
+		}).Warning("webhook: send event error, retrying")
+
+		time.Sleep(interval)
+		interval = interval * 2
+	}
+}
+
+// post posts the given body. It returns true when the request can be retried.
+func post(u, secret string, body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", u, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Wrap(err, "new request error")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "http request error")
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("expected 2xx response, got: %d", resp.StatusCode)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the given body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateEvents validates that the given events are valid webhook events.
+func ValidateEvents(events []string) error {
+	for _, e := range events {
+		switch Event(e) {
+		case EventJoin, EventError, EventStatus, EventFCntReset, EventMulticastGatewaySet, EventClassHealth:
+		default:
+			return fmt.Errorf("invalid webhook event: %s", e)
+		}
+	}
+
+	return nil
+}
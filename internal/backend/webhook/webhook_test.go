@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+type request struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+func TestSend(t *testing.T) {
+	assert := require.New(t)
+
+	retryInterval = time.Millisecond
+	maxRetries = 2
+
+	statusCodes := make(chan int, 10)
+	requests := make(chan request, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		requests <- request{
+			event:     r.URL.Query().Get("event"),
+			signature: r.Header.Get(SignatureHeader),
+			body:      b,
+		}
+
+		select {
+		case code := <-statusCodes:
+			w.WriteHeader(code)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	event := JoinEvent{
+		DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+	}
+	eventB, err := json.Marshal(event)
+	assert.NoError(err)
+
+	t.Run("Signed request", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(send(Endpoint{URL: server.URL, Secret: "secret"}, EventJoin, event))
+
+		req := <-requests
+		assert.Equal("join", req.event)
+		assert.Equal(eventB, req.body)
+		assert.Equal(Sign("secret", eventB), req.signature)
+	})
+
+	t.Run("Retry on 5xx", func(t *testing.T) {
+		assert := require.New(t)
+		statusCodes <- http.StatusServiceUnavailable
+		statusCodes <- http.StatusInternalServerError
+
+		assert.NoError(send(Endpoint{URL: server.URL}, EventJoin, event))
+		assert.Len(requests, 3)
+		for len(requests) > 0 {
+			req := <-requests
+			assert.Equal("", req.signature)
+		}
+	})
+
+	t.Run("No retry on 4xx", func(t *testing.T) {
+		assert := require.New(t)
+		statusCodes <- http.StatusBadRequest
+
+		assert.Error(send(Endpoint{URL: server.URL}, EventJoin, event))
+		assert.Len(requests, 1)
+		<-requests
+	})
+
+	t.Run("Filtered event", func(t *testing.T) {
+		assert := require.New(t)
+
+		Send(context.Background(), Endpoint{URL: server.URL, Events: []string{"error"}}, EventJoin, event)
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(requests, 0)
+	})
+}
+
+func TestValidateEvents(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(ValidateEvents([]string{"join", "error", "status", "fcnt_reset"}))
+	assert.Error(ValidateEvents([]string{"up"}))
+}
@@ -1,6 +1,8 @@
 package band
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 
 	"github.com/brocaar/lorawan"
@@ -8,28 +10,181 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 )
 
-var band loraband.Band
+var (
+	band    loraband.Band
+	regions map[string]loraband.Band
+)
 
 // Setup sets up the band with the given configuration.
 func Setup(c config.Config) error {
-	dwellTime := lorawan.DwellTimeNoLimit
-	if c.NetworkServer.Band.DownlinkDwellTime400ms {
-		dwellTime = lorawan.DwellTime400ms
+	dwellTimeBands = make(map[string]dwellTimeBand)
+	regionConfigs = make(map[string]regionConfig)
+	subBandMux.Lock()
+	subBandBands = make(map[string]loraband.Band)
+	subBandMux.Unlock()
+
+	var err error
+	band, err = setupRegion("", c.NetworkServer.Band.Name, c.NetworkServer.Band.RepeaterCompatible, c.NetworkServer.Band.DownlinkDwellTime400ms, c.NetworkServer.NetworkSettings.ExtraChannels, c.NetworkServer.NetworkSettings.DisableDefaultChannels)
+	if err != nil {
+		return err
+	}
+
+	regions = make(map[string]loraband.Band)
+	for _, r := range c.NetworkServer.Regions {
+		if r.Name == "" {
+			return errors.New("region name must not be empty")
+		}
+		if _, ok := regions[r.Name]; ok {
+			return fmt.Errorf("region %s is configured more than once", r.Name)
+		}
+
+		regionBand, err := setupRegion(r.Name, r.Band, r.RepeaterCompatible, r.DownlinkDwellTime400ms, r.ExtraChannels, r.DisableDefaultChannels)
+		if err != nil {
+			return errors.Wrapf(err, "region %s", r.Name)
+		}
+		regions[r.Name] = regionBand
+
+		if err := setupChannelGroups(r.Name, r.ChannelGroups); err != nil {
+			return errors.Wrapf(err, "region %s", r.Name)
+		}
+	}
+
+	return setupChannelGroups("", c.NetworkServer.NetworkSettings.ChannelGroups)
+}
+
+// setupRegion returns the band for the given region configuration. It also
+// sets up the band variant with the opposite dwell-time setting, which is
+// used for devices which have not (yet) acknowledged the configured
+// dwell-time.
+func setupRegion(region string, name loraband.Name, repeaterCompatible, downlinkDwellTime400ms bool, channels []config.ExtraChannel, disableDefaultChannels bool) (loraband.Band, error) {
+	return setupBands(region, regionConfig{
+		name:                   name,
+		repeaterCompatible:     repeaterCompatible,
+		downlinkDwellTime400ms: downlinkDwellTime400ms,
+		channels:               channels,
+		disableDefaultChannels: disableDefaultChannels,
+	})
+}
+
+func setupBands(region string, c regionConfig) (loraband.Band, error) {
+	var bands [2]loraband.Band
+	for i, dwellTime400ms := range []bool{c.downlinkDwellTime400ms, !c.downlinkDwellTime400ms} {
+		b, err := newBand(c, dwellTime400ms)
+		if err != nil {
+			return nil, err
+		}
+		bands[i] = b
+	}
+
+	dt := dwellTimeBand{}
+	if c.downlinkDwellTime400ms {
+		dt.dwellTime400ms, dt.noLimit = bands[0], bands[1]
+	} else {
+		dt.noLimit, dt.dwellTime400ms = bands[0], bands[1]
 	}
-	bandConfig, err := loraband.GetConfig(c.NetworkServer.Band.Name, c.NetworkServer.Band.RepeaterCompatible, dwellTime)
+	dwellTimeBands[region] = dt
+	regionConfigs[region] = c
+
+	return bands[0], nil
+}
+
+func newBand(c regionConfig, downlinkDwellTime400ms bool) (loraband.Band, error) {
+	b, err := getBand(c.name, c.repeaterCompatible, downlinkDwellTime400ms)
 	if err != nil {
-		return errors.Wrap(err, "get band config error")
+		return nil, err
 	}
-	for _, c := range config.C.NetworkServer.NetworkSettings.ExtraChannels {
-		if err := bandConfig.AddChannel(c.Frequency, c.MinDR, c.MaxDR); err != nil {
-			return errors.Wrap(err, "add channel error")
+	b, err = applyChannelPlan(b, c.channels, c.disableDefaultChannels)
+	if err != nil {
+		return nil, err
+	}
+	if c.channelGroup != nil {
+		b, err = applyChannelGroup(b, *c.channelGroup)
+		if err != nil {
+			return nil, errors.Wrapf(err, "channel-group %s", c.channelGroup.Name)
 		}
 	}
-	band = bandConfig
-	return nil
+	return newTableBand(b), nil
 }
 
-// Band returns the configured band.
+// applyChannelPlan adds the given extra channels to the band and optionally
+// disables the default channels. Disabled default channels are turned off
+// at the device using the LinkADRReq channel-mask.
+func applyChannelPlan(b loraband.Band, channels []config.ExtraChannel, disableDefaultChannels bool) (loraband.Band, error) {
+	rx1Frequencies := make(map[int]int)
+
+	for _, c := range channels {
+		if c.DownlinkOnly {
+			if c.UplinkFrequency == 0 {
+				return nil, fmt.Errorf("downlink-only channel %d requires an uplink_frequency", c.Frequency)
+			}
+			rx1Frequencies[c.UplinkFrequency] = c.Frequency
+			continue
+		}
+
+		if err := b.AddChannel(c.Frequency, c.MinDR, c.MaxDR); err != nil {
+			return nil, errors.Wrap(err, "add channel error")
+		}
+	}
+
+	if disableDefaultChannels {
+		for _, i := range b.GetStandardUplinkChannelIndices() {
+			if err := b.DisableUplinkChannelIndex(i); err != nil {
+				return nil, errors.Wrap(err, "disable uplink channel error")
+			}
+		}
+
+		if len(b.GetEnabledUplinkChannelIndices()) == 0 {
+			return nil, errors.New("disabling the default channels requires at least one extra uplink channel")
+		}
+	}
+
+	if len(rx1Frequencies) == 0 {
+		return b, nil
+	}
+
+	return &customBand{
+		Band:           b,
+		rx1Frequencies: rx1Frequencies,
+	}, nil
+}
+
+func getBand(name loraband.Name, repeaterCompatible, downlinkDwellTime400ms bool) (loraband.Band, error) {
+	dwellTime := lorawan.DwellTimeNoLimit
+	if downlinkDwellTime400ms {
+		dwellTime = lorawan.DwellTime400ms
+	}
+	b, err := loraband.GetConfig(name, repeaterCompatible, dwellTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "get band config error")
+	}
+	return b, nil
+}
+
+// Band returns the configured (default) band.
 func Band() loraband.Band {
 	return band
 }
+
+// Get returns the band for the given region. When the region is empty or
+// unknown, the default band is returned.
+func Get(region string) loraband.Band {
+	if b, ok := regions[region]; ok {
+		return b
+	}
+	return band
+}
+
+// IsRegion returns true when the given region is empty (default band) or
+// has been configured.
+func IsRegion(region string) bool {
+	if region == "" {
+		return true
+	}
+	_, ok := regions[region]
+	return ok
+}
+
+// HasRegions returns true when additional regions have been configured.
+func HasRegions() bool {
+	return len(regions) != 0
+}
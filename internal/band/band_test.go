@@ -0,0 +1,164 @@
+package band
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+func TestApplyChannelPlan(t *testing.T) {
+	t.Run("Downlink-only channel", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := loraband.GetConfig(loraband.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		assert.NoError(err)
+
+		b, err = applyChannelPlan(b, []config.ExtraChannel{
+			{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+			{Frequency: 869525000, DownlinkOnly: true, UplinkFrequency: 867100000},
+		}, false)
+		assert.NoError(err)
+
+		f, err := b.GetRX1FrequencyForUplinkFrequency(867100000)
+		assert.NoError(err)
+		assert.Equal(869525000, f)
+
+		f, err = b.GetRX1FrequencyForUplinkFrequency(868100000)
+		assert.NoError(err)
+		assert.Equal(868100000, f)
+
+		assert.Len(b.GetCustomUplinkChannelIndices(), 1)
+	})
+
+	t.Run("Downlink-only channel without uplink frequency", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := loraband.GetConfig(loraband.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		assert.NoError(err)
+
+		_, err = applyChannelPlan(b, []config.ExtraChannel{
+			{Frequency: 869525000, DownlinkOnly: true},
+		}, false)
+		assert.Error(err)
+	})
+
+	t.Run("Disable default channels", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := loraband.GetConfig(loraband.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		assert.NoError(err)
+
+		b, err = applyChannelPlan(b, []config.ExtraChannel{
+			{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+			{Frequency: 867300000, MinDR: 0, MaxDR: 5},
+		}, true)
+		assert.NoError(err)
+		assert.Equal([]int{3, 4}, b.GetEnabledUplinkChannelIndices())
+	})
+
+	t.Run("Disable default channels without extra channels", func(t *testing.T) {
+		assert := require.New(t)
+
+		b, err := loraband.GetConfig(loraband.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		assert.NoError(err)
+
+		_, err = applyChannelPlan(b, nil, true)
+		assert.Error(err)
+	})
+}
+
+func TestDwellTime(t *testing.T) {
+	assert := require.New(t)
+
+	dwellTimeBands = make(map[string]dwellTimeBand)
+	_, err := setupRegion("as923", loraband.AS_923, false, false, nil, false)
+	assert.NoError(err)
+
+	assert.True(IsDataRateAllowed("as923", false, "1.0.3", "B", 0))
+	assert.False(IsDataRateAllowed("as923", true, "1.0.3", "B", 0))
+	assert.False(IsDataRateAllowed("as923", true, "1.0.3", "B", 1))
+	assert.True(IsDataRateAllowed("as923", true, "1.0.3", "B", 2))
+
+	assert.Equal(0, GetMinAllowedDataRate("as923", false, "1.0.3", "B", 5))
+	assert.Equal(2, GetMinAllowedDataRate("as923", true, "1.0.3", "B", 5))
+	assert.Equal(-1, GetMinAllowedDataRate("as923", true, "1.0.3", "B", 1))
+}
+
+func TestSubBands(t *testing.T) {
+	assert := require.New(t)
+
+	dwellTimeBands = make(map[string]dwellTimeBand)
+	regionConfigs = make(map[string]regionConfig)
+	subBandBands = make(map[string]loraband.Band)
+	regions = map[string]loraband.Band{}
+
+	b, err := setupRegion("us915", loraband.US_902_928, false, false, nil, false)
+	assert.NoError(err)
+	regions["us915"] = b
+
+	assert.True(SupportsSubBands("us915"))
+
+	channels, err := GetSubBandChannels(2)
+	assert.NoError(err)
+	assert.Equal([]int{8, 9, 10, 11, 12, 13, 14, 15, 65}, channels)
+
+	_, err = GetSubBandChannels(9)
+	assert.Error(err)
+
+	assert.Equal(
+		[]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 64, 65},
+		GetForSubBands("us915", []int{2, 1}).GetEnabledUplinkChannelIndices(),
+	)
+
+	assert.Len(GetForSubBands("us915", nil).GetEnabledUplinkChannelIndices(), 72)
+}
+
+func TestChannelGroups(t *testing.T) {
+	assert := require.New(t)
+
+	dwellTimeBands = make(map[string]dwellTimeBand)
+	regionConfigs = make(map[string]regionConfig)
+	subBandBands = make(map[string]loraband.Band)
+	regions = map[string]loraband.Band{}
+
+	b, err := setupRegion("cn470", loraband.CN_470_510, false, false, nil, false)
+	assert.NoError(err)
+	regions["cn470"] = b
+
+	assert.NoError(setupChannelGroups("cn470", []config.ChannelGroup{
+		{
+			Name:                "type-a-1",
+			UplinkChannels:      []int{0, 1, 2},
+			DownlinkFrequencies: []int{483900000, 484100000},
+		},
+	}))
+
+	region := RegionWithChannelGroup("cn470", "type-a-1")
+	assert.Equal("cn470/type-a-1", region)
+	assert.True(IsRegion(region))
+	assert.True(IsChannelGroup("cn470", "type-a-1"))
+	assert.True(IsChannelGroup("cn470", ""))
+	assert.False(IsChannelGroup("cn470", "type-b-1"))
+	assert.False(SupportsSubBands(region))
+
+	assert.Equal([]int{0, 1, 2}, Get(region).GetEnabledUplinkChannelIndices())
+	assert.Len(Get("cn470").GetEnabledUplinkChannelIndices(), 96)
+
+	for uplink, rx1 := range map[int]int{
+		470300000: 483900000,
+		470500000: 484100000,
+		470700000: 483900000,
+	} {
+		f, err := Get(region).GetRX1FrequencyForUplinkFrequency(uplink)
+		assert.NoError(err)
+		assert.Equal(rx1, f)
+	}
+
+	assert.Error(setupChannelGroups("cn470", []config.ChannelGroup{
+		{Name: "empty"},
+	}))
+}
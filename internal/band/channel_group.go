@@ -0,0 +1,100 @@
+package band
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// RegionWithChannelGroup returns the region identifier for the given region
+// and channel-group. Each channel-group is set up as a variant of its region,
+// so that the returned identifier can be used everywhere a region is
+// expected. When the channel-group is empty, the region is returned.
+func RegionWithChannelGroup(region, channelGroup string) string {
+	if channelGroup == "" {
+		return region
+	}
+	return region + "/" + channelGroup
+}
+
+// IsChannelGroup returns true when the given channel-group is empty or has
+// been configured for the given region.
+func IsChannelGroup(region, channelGroup string) bool {
+	if channelGroup == "" {
+		return true
+	}
+	_, ok := regions[RegionWithChannelGroup(region, channelGroup)]
+	return ok
+}
+
+// setupChannelGroups sets up a band variant for each channel-group of the
+// given region.
+func setupChannelGroups(region string, groups []config.ChannelGroup) error {
+	for i := range groups {
+		g := groups[i]
+		if g.Name == "" {
+			return errors.New("channel-group name must not be empty")
+		}
+
+		key := RegionWithChannelGroup(region, g.Name)
+		if _, ok := regions[key]; ok {
+			return fmt.Errorf("channel-group %s is configured more than once", g.Name)
+		}
+
+		c := regionConfigs[region]
+		c.channelGroup = &g
+
+		b, err := setupBands(key, c)
+		if err != nil {
+			return err
+		}
+		regions[key] = b
+	}
+
+	return nil
+}
+
+// applyChannelGroup enables only the uplink channels of the given
+// channel-group. When downlink frequencies are configured, the RX1 frequency
+// of the n-th uplink channel of the group is the (n mod len) downlink
+// frequency, as used by the CN470 antenna-type plans.
+func applyChannelGroup(b loraband.Band, g config.ChannelGroup) (loraband.Band, error) {
+	if len(g.UplinkChannels) == 0 {
+		return nil, errors.New("channel-group requires at least one uplink channel")
+	}
+
+	for _, i := range b.GetEnabledUplinkChannelIndices() {
+		if err := b.DisableUplinkChannelIndex(i); err != nil {
+			return nil, errors.Wrap(err, "disable uplink channel error")
+		}
+	}
+
+	rx1Frequencies := make(map[int]int)
+	for n, i := range g.UplinkChannels {
+		if err := b.EnableUplinkChannelIndex(i); err != nil {
+			return nil, errors.Wrap(err, "enable uplink channel error")
+		}
+
+		if len(g.DownlinkFrequencies) == 0 {
+			continue
+		}
+
+		c, err := b.GetUplinkChannel(i)
+		if err != nil {
+			return nil, errors.Wrap(err, "get uplink channel error")
+		}
+		rx1Frequencies[c.Frequency] = g.DownlinkFrequencies[n%len(g.DownlinkFrequencies)]
+	}
+
+	if len(rx1Frequencies) == 0 {
+		return b, nil
+	}
+
+	return &customBand{
+		Band:           b,
+		rx1Frequencies: rx1Frequencies,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package band
+
+import (
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// customBand wraps a band to implement the parts of a custom channel-plan
+// which can't be expressed using the band itself (e.g. downlink-only
+// channels).
+type customBand struct {
+	loraband.Band
+
+	// rx1Frequencies maps the uplink frequency to the RX1 frequency.
+	rx1Frequencies map[int]int
+}
+
+// GetRX1FrequencyForUplinkFrequency returns the RX1 frequency for the given
+// uplink frequency. When a downlink-only channel has been configured for the
+// uplink frequency, its frequency is returned.
+func (b *customBand) GetRX1FrequencyForUplinkFrequency(uplinkFrequency int) (int, error) {
+	if f, ok := b.rx1Frequencies[uplinkFrequency]; ok {
+		return f, nil
+	}
+	return b.Band.GetRX1FrequencyForUplinkFrequency(uplinkFrequency)
+}
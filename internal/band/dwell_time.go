@@ -0,0 +1,57 @@
+package band
+
+import (
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// dwellTimeBand contains the band variants of a region for both
+// dwell-time settings.
+type dwellTimeBand struct {
+	noLimit        loraband.Band
+	dwellTime400ms loraband.Band
+}
+
+var dwellTimeBands map[string]dwellTimeBand
+
+// GetForDwellTime returns the band for the given region and dwell-time
+// setting. This must be used for looking up the allowed data-rates and max.
+// payload sizes, based on the dwell-time acknowledged by the device (using
+// the TXParamSetup mac-command).
+//
+// Note: the channel enable / disable state of the returned band might not
+// reflect the configured band, use Get for channel related lookups.
+func GetForDwellTime(region string, dwellTime400ms bool) loraband.Band {
+	dt, ok := dwellTimeBands[region]
+	if !ok {
+		return Get(region)
+	}
+
+	if dwellTime400ms {
+		return dt.dwellTime400ms
+	}
+	return dt.noLimit
+}
+
+// IsDataRateAllowed returns if the given data-rate can be used under the
+// given dwell-time setting. Data-rates of which the max. payload size is 0
+// (e.g. DR0 and DR1 for AS923 when the 400ms dwell-time applies) are not
+// allowed.
+func IsDataRateAllowed(region string, dwellTime400ms bool, macVersion, regParamsRevision string, dr int) bool {
+	ps, err := GetForDwellTime(region, dwellTime400ms).GetMaxPayloadSizeForDataRateIndex(macVersion, regParamsRevision, dr)
+	if err != nil {
+		return false
+	}
+	return ps.N > 0
+}
+
+// GetMinAllowedDataRate returns the lowest data-rate (up to maxDR) which is
+// allowed under the given dwell-time setting. It returns -1 when no
+// data-rate is allowed.
+func GetMinAllowedDataRate(region string, dwellTime400ms bool, macVersion, regParamsRevision string, maxDR int) int {
+	for dr := 0; dr <= maxDR; dr++ {
+		if IsDataRateAllowed(region, dwellTime400ms, macVersion, regParamsRevision, dr) {
+			return dr
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package band
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// regionConfig contains the configuration of a region, needed for
+// constructing band variants.
+type regionConfig struct {
+	name                   loraband.Name
+	repeaterCompatible     bool
+	downlinkDwellTime400ms bool
+	channels               []config.ExtraChannel
+	disableDefaultChannels bool
+	channelGroup           *config.ChannelGroup
+}
+
+var (
+	regionConfigs map[string]regionConfig
+
+	subBandMux   sync.Mutex
+	subBandBands map[string]loraband.Band
+)
+
+// SupportsSubBands returns true when the band of the given region consists
+// of sub-bands of 8 125kHz channels + 1 500kHz channel (e.g. US915 and
+// AU915). Regions with a channel-group don't support sub-bands as the
+// channel-group defines the enabled channels.
+func SupportsSubBands(region string) bool {
+	c, ok := regionConfigs[region]
+	if !ok || c.channelGroup != nil {
+		return false
+	}
+	return c.name == loraband.US_902_928 || c.name == loraband.AU_915_928
+}
+
+// GetSubBandChannels returns the uplink channel indices for the given
+// sub-band (1 - 8).
+func GetSubBandChannels(subBand int) ([]int, error) {
+	if subBand < 1 || subBand > 8 {
+		return nil, fmt.Errorf("invalid sub-band: %d", subBand)
+	}
+
+	var out []int
+	for i := (subBand - 1) * 8; i < subBand*8; i++ {
+		out = append(out, i)
+	}
+	return append(out, 64+subBand-1), nil
+}
+
+// GetForSubBands returns the band for the given region of which only the
+// channels of the given sub-bands are enabled. This must be used for the
+// join-accept CFList and LinkADRReq channel-mask generation, so that devices
+// only use the channels on which the gateways around them are listening.
+// When no sub-bands are given or when the region doesn't support sub-bands,
+// the configured band is returned.
+func GetForSubBands(region string, subBands []int) loraband.Band {
+	if len(subBands) == 0 || !SupportsSubBands(region) {
+		return Get(region)
+	}
+
+	sorted := append([]int{}, subBands...)
+	sort.Ints(sorted)
+	key := region + "/" + strings.Trim(fmt.Sprint(sorted), "[]")
+
+	subBandMux.Lock()
+	defer subBandMux.Unlock()
+
+	if b, ok := subBandBands[key]; ok {
+		return b
+	}
+
+	b, err := newSubBandBand(regionConfigs[region], sorted)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"region":    region,
+			"sub_bands": sorted,
+		}).Error("band: create sub-band band error")
+		return Get(region)
+	}
+	subBandBands[key] = b
+
+	return b
+}
+
+func newSubBandBand(c regionConfig, subBands []int) (loraband.Band, error) {
+	b, err := newBand(c, c.downlinkDwellTime400ms)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range b.GetEnabledUplinkChannelIndices() {
+		if err := b.DisableUplinkChannelIndex(i); err != nil {
+			return nil, errors.Wrap(err, "disable uplink channel error")
+		}
+	}
+
+	for _, subBand := range subBands {
+		channels, err := GetSubBandChannels(subBand)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range channels {
+			if err := b.EnableUplinkChannelIndex(i); err != nil {
+				return nil, errors.Wrap(err, "enable uplink channel error")
+			}
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,115 @@
+package band
+
+import (
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+// tableMaxDR defines the number of data-rate indices (0 - 15) for which the
+// lookup tables are precomputed.
+const tableMaxDR = 16
+
+// tableMaxRX1DROffset defines the number of RX1 data-rate offsets (0 - 7) for
+// which the RX1 data-rate table is precomputed.
+const tableMaxRX1DROffset = 8
+
+// tableMaxTXPower defines the number of TXPower indices (0 - 15) for which
+// the TXPower offset table is precomputed.
+const tableMaxTXPower = 16
+
+// tableMACVersions and tableRegParamsRevisions contain the LoRaWAN and
+// Regional Parameters versions for which the max. payload size table is
+// precomputed. Other combinations are looked up using the band.
+var (
+	tableMACVersions        = []string{"", "1.0.0", "1.0.1", "1.0.2", "1.0.3", "1.1.0"}
+	tableRegParamsRevisions = []string{"", "A", "B"}
+)
+
+type tableInt struct {
+	value int
+	err   error
+}
+
+type tableMaxPayloadSize struct {
+	value loraband.MaxPayloadSize
+	err   error
+}
+
+type tableVersion struct {
+	macVersion        string
+	regParamsRevision string
+}
+
+// tableBand wraps a band to serve the RX1 data-rate, max. payload size and
+// TXPower offset lookups from tables which are precomputed on setup, as
+// these are called (multiple times) for every frame. The tables only
+// depend on the band definition and are not affected by the channel
+// enable / disable state.
+type tableBand struct {
+	loraband.Band
+
+	rx1DataRates    [tableMaxDR][tableMaxRX1DROffset]tableInt
+	txPowerOffsets  [tableMaxTXPower]tableInt
+	maxPayloadSizes map[tableVersion]*[tableMaxDR]tableMaxPayloadSize
+}
+
+func newTableBand(b loraband.Band) *tableBand {
+	tb := tableBand{
+		Band:            b,
+		maxPayloadSizes: make(map[tableVersion]*[tableMaxDR]tableMaxPayloadSize),
+	}
+
+	for dr := 0; dr < tableMaxDR; dr++ {
+		for offset := 0; offset < tableMaxRX1DROffset; offset++ {
+			v, err := b.GetRX1DataRateIndex(dr, offset)
+			tb.rx1DataRates[dr][offset] = tableInt{value: v, err: err}
+		}
+	}
+
+	for i := 0; i < tableMaxTXPower; i++ {
+		v, err := b.GetTXPowerOffset(i)
+		tb.txPowerOffsets[i] = tableInt{value: v, err: err}
+	}
+
+	for _, macVersion := range tableMACVersions {
+		for _, regParamsRevision := range tableRegParamsRevisions {
+			var sizes [tableMaxDR]tableMaxPayloadSize
+			for dr := 0; dr < tableMaxDR; dr++ {
+				v, err := b.GetMaxPayloadSizeForDataRateIndex(macVersion, regParamsRevision, dr)
+				sizes[dr] = tableMaxPayloadSize{value: v, err: err}
+			}
+			tb.maxPayloadSizes[tableVersion{macVersion, regParamsRevision}] = &sizes
+		}
+	}
+
+	return &tb
+}
+
+// GetRX1DataRateIndex returns the RX1 data-rate index for the given uplink
+// data-rate and RX1 data-rate offset.
+func (b *tableBand) GetRX1DataRateIndex(uplinkDR, rx1DROffset int) (int, error) {
+	if uplinkDR < 0 || uplinkDR >= tableMaxDR || rx1DROffset < 0 || rx1DROffset >= tableMaxRX1DROffset {
+		return b.Band.GetRX1DataRateIndex(uplinkDR, rx1DROffset)
+	}
+	v := b.rx1DataRates[uplinkDR][rx1DROffset]
+	return v.value, v.err
+}
+
+// GetTXPowerOffset returns the TXPower offset for the given TXPower index.
+func (b *tableBand) GetTXPowerOffset(txPower int) (int, error) {
+	if txPower < 0 || txPower >= tableMaxTXPower {
+		return b.Band.GetTXPowerOffset(txPower)
+	}
+	v := b.txPowerOffsets[txPower]
+	return v.value, v.err
+}
+
+// GetMaxPayloadSizeForDataRateIndex returns the max. payload size for the
+// given LoRaWAN and Regional Parameters versions and data-rate index.
+func (b *tableBand) GetMaxPayloadSizeForDataRateIndex(macVersion, regParamsRevision string, dr int) (loraband.MaxPayloadSize, error) {
+	sizes, ok := b.maxPayloadSizes[tableVersion{macVersion, regParamsRevision}]
+	if !ok || dr < 0 || dr >= tableMaxDR {
+		return b.Band.GetMaxPayloadSizeForDataRateIndex(macVersion, regParamsRevision, dr)
+	}
+	v := sizes[dr]
+	return v.value, v.err
+}
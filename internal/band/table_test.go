@@ -0,0 +1,85 @@
+package band
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+)
+
+func TestTableBand(t *testing.T) {
+	names := []loraband.Name{
+		loraband.AS_923,
+		loraband.AU_915_928,
+		loraband.CN_470_510,
+		loraband.CN_779_787,
+		loraband.EU_433,
+		loraband.EU_863_870,
+		loraband.IN_865_867,
+		loraband.KR_920_923,
+		loraband.US_902_928,
+	}
+
+	for _, name := range names {
+		for _, dwellTime := range []lorawan.DwellTime{lorawan.DwellTimeNoLimit, lorawan.DwellTime400ms} {
+			t.Run(fmt.Sprintf("%s dwell-time %d", name, dwellTime), func(t *testing.T) {
+				assert := require.New(t)
+
+				b, err := loraband.GetConfig(name, false, dwellTime)
+				assert.NoError(err)
+				tb := newTableBand(b)
+
+				// the ranges exceed the table sizes, to also cover the
+				// fallback to the band
+				for dr := -1; dr <= tableMaxDR; dr++ {
+					for offset := -1; offset <= tableMaxRX1DROffset; offset++ {
+						expected, expectedErr := b.GetRX1DataRateIndex(dr, offset)
+						v, err := tb.GetRX1DataRateIndex(dr, offset)
+						assert.Equal(expectedErr, err, "dr: %d, offset: %d", dr, offset)
+						assert.Equal(expected, v, "dr: %d, offset: %d", dr, offset)
+					}
+
+					for _, version := range []tableVersion{{"", ""}, {"1.0.2", "B"}, {"1.0.3", "A"}, {"1.1.0", "A"}, {"1.2.0", "C"}} {
+						expected, expectedErr := b.GetMaxPayloadSizeForDataRateIndex(version.macVersion, version.regParamsRevision, dr)
+						v, err := tb.GetMaxPayloadSizeForDataRateIndex(version.macVersion, version.regParamsRevision, dr)
+						assert.Equal(expectedErr, err, "version: %v, dr: %d", version, dr)
+						assert.Equal(expected, v, "version: %v, dr: %d", version, dr)
+					}
+				}
+
+				for i := -1; i <= tableMaxTXPower; i++ {
+					expected, expectedErr := b.GetTXPowerOffset(i)
+					v, err := tb.GetTXPowerOffset(i)
+					assert.Equal(expectedErr, err, "tx power: %d", i)
+					assert.Equal(expected, v, "tx power: %d", i)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTableBand(b *testing.B) {
+	lb, err := loraband.GetConfig(loraband.EU_863_870, false, lorawan.DwellTimeNoLimit)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, tst := range []struct {
+		name string
+		band loraband.Band
+	}{
+		{"Band", lb},
+		{"Table", newTableBand(lb)},
+	} {
+		b.Run(tst.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tst.band.GetRX1DataRateIndex(5, 1)
+				tst.band.GetMaxPayloadSizeForDataRateIndex("1.0.3", "A", 5)
+				tst.band.GetTXPowerOffset(3)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Package certification implements the network-server side of the LoRaWAN
+// Certification Protocol (TS009), so that devices can be pre-certified
+// against this network-server. When the certification test mode is enabled
+// for a device, the frames on the certification FPort are handled by the
+// network-server (and are not forwarded to the application-server).
+//
+// On the first uplink of the device-session, the certification package of
+// the device is activated by sending a PackageVersionReq together with an
+// EchoIncPayloadReq. Other commands (e.g. DutResetReq or SwitchClassReq)
+// can be enqueued using the API. The answers of the device are logged and
+// the EchoIncPayloadAns is validated.
+package certification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// FPort is the FPort of the LoRaWAN Certification Protocol.
+const FPort = 224
+
+// Certification package commands. Only the commands of which the answer is
+// handled by the network-server are defined.
+const (
+	packageVersion = 0x00
+	echoIncPayload = 0x08
+	rxAppCnt       = 0x09
+	dutVersions    = 0x7f
+)
+
+// requestPayloadSize defines the payload size of the certification package
+// requests. The EchoIncPayloadReq payload takes the remaining bytes.
+var requestPayloadSize = map[byte]int{
+	0x00: 0, // PackageVersionReq
+	0x01: 0, // DutResetReq
+	0x02: 0, // DutJoinReq
+	0x03: 1, // SwitchClassReq
+	0x04: 1, // ADRBitChangeReq
+	0x05: 1, // RegionalDutyCycleCtrlReq
+	0x06: 1, // TxPeriodicityChangeReq
+	0x07: 1, // TxFramesCtrlReq
+	0x09: 0, // RxAppCntReq
+	0x0a: 0, // RxAppCntResetReq
+	0x20: 0, // LinkCheckReq
+	0x21: 0, // DeviceTimeReq
+	0x22: 1, // PingSlotInfoReq
+	0x7d: 6, // TxCwReq
+	0x7e: 0, // DutFPort224DisableReq
+	0x7f: 0, // DutVersionsReq
+}
+
+// echoPayloadSize defines the size of the EchoIncPayloadReq payload sent on
+// activation.
+const echoPayloadSize = 4
+
+// Errors.
+var (
+	ErrAppSKeyNotAvailable = errors.New("AppSKey not available")
+	ErrInvalidCommand      = errors.New("invalid certification command")
+	ErrEchoPayloadMismatch = errors.New("EchoIncPayloadAns does not match EchoIncPayloadReq")
+)
+
+// Activate activates the certification package of the device by enqueueing
+// a PackageVersionReq and an EchoIncPayloadReq. The given device-session is
+// updated, but not saved.
+func Activate(ctx context.Context, db sqlx.Ext, ds *storage.DeviceSession) error {
+	echoPL := make([]byte, echoPayloadSize)
+	if _, err := rand.Read(echoPL); err != nil {
+		return errors.Wrap(err, "read random bytes error")
+	}
+
+	b := append([]byte{packageVersion, echoIncPayload}, echoPL...)
+	if err := EnqueueCommands(ctx, db, ds, b); err != nil {
+		return err
+	}
+
+	ds.CertificationActivated = true
+
+	log.WithFields(log.Fields{
+		"dev_eui": ds.DevEUI,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}).Info("certification: certification package activation enqueued")
+
+	return nil
+}
+
+// EnqueueCommands validates and encrypts the given command(s) using the
+// AppSKey of the device and enqueues these on the certification FPort. As
+// the EchoIncPayloadReq payload has a variable length, this command must be
+// the last command. Its payload is stored in the given device-session
+// (which is not saved) for validating the EchoIncPayloadAns.
+func EnqueueCommands(ctx context.Context, db sqlx.Ext, ds *storage.DeviceSession, b []byte) error {
+	echoPL, err := validateRequests(b)
+	if err != nil {
+		return err
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx, *ds)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return ErrAppSKeyNotAvailable
+		}
+		return errors.Wrap(err, "get AppSKey error")
+	}
+
+	fCnt, err := storage.GetNextDeviceQueueItemFCnt(ctx, db, *ds)
+	if err != nil {
+		return errors.Wrap(err, "get next device-queue item fcnt error")
+	}
+
+	frmPayload, err := lorawan.EncryptFRMPayload(appSKey, false, ds.DevAddr, fCnt, b)
+	if err != nil {
+		return errors.Wrap(err, "encrypt FRMPayload error")
+	}
+
+	qi := storage.DeviceQueueItem{
+		DevAddr:    ds.DevAddr,
+		DevEUI:     ds.DevEUI,
+		FRMPayload: frmPayload,
+		FCnt:       fCnt,
+		FPort:      FPort,
+	}
+	if err := storage.CreateDeviceQueueItem(ctx, db, &qi); err != nil {
+		return errors.Wrap(err, "create device-queue item error")
+	}
+
+	if echoPL != nil {
+		ds.CertificationEchoPayload = echoPL
+	}
+
+	return nil
+}
+
+// HandleUplink handles the given (decrypted) certification package answers
+// of the device. The given device-session is updated, but not saved. As the
+// length of an unknown command is not known, the handling stops at the
+// first unknown command.
+func HandleUplink(ctx context.Context, ds *storage.DeviceSession, b []byte) error {
+	for len(b) != 0 {
+		cid := b[0]
+		b = b[1:]
+
+		fields := log.Fields{
+			"dev_eui": ds.DevEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}
+
+		switch cid {
+		case packageVersion:
+			if len(b) < 2 {
+				return errors.New("PackageVersionAns payload must be 2 bytes")
+			}
+			fields["package_identifier"] = b[0]
+			fields["package_version"] = b[1]
+			b = b[2:]
+
+			log.WithFields(fields).Info("certification: PackageVersionAns received")
+		case echoIncPayload:
+			// the echoed payload takes the remaining bytes
+			pl := b
+			b = nil
+
+			if err := validateEchoIncPayloadAns(ds, pl); err != nil {
+				return err
+			}
+			log.WithFields(fields).Info("certification: EchoIncPayloadAns validated")
+		case rxAppCnt:
+			if len(b) < 2 {
+				return errors.New("RxAppCntAns payload must be 2 bytes")
+			}
+			fields["rx_app_cnt"] = binary.LittleEndian.Uint16(b[0:2])
+			b = b[2:]
+
+			log.WithFields(fields).Info("certification: RxAppCntAns received")
+		case dutVersions:
+			if len(b) < 12 {
+				return errors.New("DutVersionsAns payload must be 12 bytes")
+			}
+			fields["fw_version"] = formatVersion(b[0:4])
+			fields["lrwan_version"] = formatVersion(b[4:8])
+			fields["lrwan_rp_version"] = formatVersion(b[8:12])
+			b = b[12:]
+
+			log.WithFields(fields).Info("certification: DutVersionsAns received")
+		default:
+			return fmt.Errorf("unknown command: %d", cid)
+		}
+	}
+
+	return nil
+}
+
+// validateEchoIncPayloadAns validates that each byte of the echoed payload
+// has been incremented by one.
+func validateEchoIncPayloadAns(ds *storage.DeviceSession, pl []byte) error {
+	expected := ds.CertificationEchoPayload
+	ds.CertificationEchoPayload = nil
+
+	if len(expected) != len(pl) {
+		return ErrEchoPayloadMismatch
+	}
+
+	for i := range expected {
+		if expected[i]+1 != pl[i] {
+			return ErrEchoPayloadMismatch
+		}
+	}
+
+	return nil
+}
+
+// validateRequests validates the given certification package requests. It
+// returns the EchoIncPayloadReq payload (or nil when there is no
+// EchoIncPayloadReq).
+func validateRequests(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return nil, errors.Wrap(ErrInvalidCommand, "commands must not be empty")
+	}
+
+	for len(b) != 0 {
+		cid := b[0]
+		b = b[1:]
+
+		if cid == echoIncPayload {
+			return append([]byte{}, b...), nil
+		}
+
+		size, ok := requestPayloadSize[cid]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidCommand, "unknown command: %d", cid)
+		}
+		if len(b) < size {
+			return nil, errors.Wrapf(ErrInvalidCommand, "payload of command %d must be %d bytes", cid, size)
+		}
+		b = b[size:]
+	}
+
+	return nil, nil
+}
+
+func formatVersion(b []byte) string {
+	return fmt.Sprintf("%d.%d.%d.%d", b[0], b[1], b[2], b[3])
+}
@@ -0,0 +1,139 @@
+package certification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+func TestHandleUplink(t *testing.T) {
+	tests := []struct {
+		Name                     string
+		EchoPayload              []byte
+		Payload                  []byte
+		ExpectedError            error
+		ExpectedErrorString      string
+		ExpectedEchoPayloadReset bool
+	}{
+		{
+			Name:    "PackageVersionAns",
+			Payload: []byte{0x00, 0x06, 0x01},
+		},
+		{
+			Name:    "PackageVersionAns and DutVersionsAns",
+			Payload: []byte{0x00, 0x06, 0x01, 0x7f, 1, 0, 0, 0, 1, 0, 4, 0, 2, 1, 0, 0},
+		},
+		{
+			Name:                     "valid EchoIncPayloadAns",
+			EchoPayload:              []byte{0x01, 0x02, 0xff},
+			Payload:                  []byte{0x08, 0x02, 0x03, 0x00},
+			ExpectedEchoPayloadReset: true,
+		},
+		{
+			Name:                     "invalid EchoIncPayloadAns",
+			EchoPayload:              []byte{0x01, 0x02, 0x03},
+			Payload:                  []byte{0x08, 0x01, 0x02, 0x03},
+			ExpectedError:            ErrEchoPayloadMismatch,
+			ExpectedEchoPayloadReset: true,
+		},
+		{
+			Name:                     "EchoIncPayloadAns length mismatch",
+			EchoPayload:              []byte{0x01, 0x02, 0x03},
+			Payload:                  []byte{0x08, 0x02, 0x03},
+			ExpectedError:            ErrEchoPayloadMismatch,
+			ExpectedEchoPayloadReset: true,
+		},
+		{
+			Name:                "RxAppCntAns too short",
+			Payload:             []byte{0x09, 0x01},
+			ExpectedErrorString: "RxAppCntAns payload must be 2 bytes",
+		},
+		{
+			Name:                "unknown command",
+			Payload:             []byte{0x00, 0x06, 0x01, 0x50},
+			ExpectedErrorString: "unknown command: 80",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ds := storage.DeviceSession{
+				CertificationEchoPayload: tst.EchoPayload,
+			}
+
+			err := HandleUplink(context.Background(), &ds, tst.Payload)
+			switch {
+			case tst.ExpectedError != nil:
+				assert.Equal(tst.ExpectedError, errors.Cause(err))
+			case tst.ExpectedErrorString != "":
+				assert.EqualError(err, tst.ExpectedErrorString)
+			default:
+				assert.NoError(err)
+			}
+
+			if tst.ExpectedEchoPayloadReset {
+				assert.Nil(ds.CertificationEchoPayload)
+			} else {
+				assert.Equal(tst.EchoPayload, ds.CertificationEchoPayload)
+			}
+		})
+	}
+}
+
+func TestValidateRequests(t *testing.T) {
+	tests := []struct {
+		Name                string
+		Payload             []byte
+		ExpectedEchoPayload []byte
+		ExpectedError       bool
+	}{
+		{
+			Name:    "DutResetReq",
+			Payload: []byte{0x01},
+		},
+		{
+			Name:    "SwitchClassReq and RxAppCntReq",
+			Payload: []byte{0x03, 0x02, 0x09},
+		},
+		{
+			Name:                "PackageVersionReq and EchoIncPayloadReq",
+			Payload:             []byte{0x00, 0x08, 0x01, 0x02, 0x03},
+			ExpectedEchoPayload: []byte{0x01, 0x02, 0x03},
+		},
+		{
+			Name:          "empty",
+			ExpectedError: true,
+		},
+		{
+			Name:          "unknown command",
+			Payload:       []byte{0x50},
+			ExpectedError: true,
+		},
+		{
+			Name:          "TxCwReq too short",
+			Payload:       []byte{0x7d, 0x01, 0x02},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			echoPL, err := validateRequests(tst.Payload)
+			if tst.ExpectedError {
+				assert.Equal(ErrInvalidCommand, errors.Cause(err))
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedEchoPayload, echoPL)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package certmanager
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
+)
+
+// setupACME returns the function returning the certificate obtained using
+// ACME. The certificate is obtained on the first TLS handshake and renewed
+// in the background.
+func setupACME(conf config.Config) (getCertificateFunc, error) {
+	c := conf.Certificates
+	if len(c.Hostnames) == 0 {
+		return nil, errors.New("certificates.hostnames must not be empty")
+	}
+
+	m := autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       autocert.DirCache(c.StorageDir),
+		HostPolicy:  autocert.HostWhitelist(c.Hostnames...),
+		RenewBefore: c.RenewBefore,
+		Email:       c.ACME.Email,
+		Client: &acme.Client{
+			DirectoryURL: c.ACME.DirectoryURL,
+		},
+	}
+
+	log.WithFields(log.Fields{
+		"directory_url": c.ACME.DirectoryURL,
+		"hostnames":     c.Hostnames,
+	}).Info("certmanager: using acme certificate provider")
+
+	if c.ACME.HTTPBind != "" {
+		log.WithField("bind", c.ACME.HTTPBind).Info("certmanager: starting acme http-01 challenge server")
+
+		ln, err := handover.Listen("acme", c.ACME.HTTPBind)
+		if err != nil {
+			return nil, errors.Wrap(err, "start acme http-01 challenge listener error")
+		}
+
+		server := http.Server{
+			Handler: m.HTTPHandler(nil),
+		}
+
+		go func() {
+			err := server.Serve(ln)
+			log.WithError(err).Error("certmanager: acme http-01 challenge server error")
+		}()
+	}
+
+	defaultHostname := c.Hostnames[0]
+
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		// clients connecting by ip-address do not send the server-name
+		if hello.ServerName == "" {
+			h := *hello
+			h.ServerName = defaultHostname
+			hello = &h
+		}
+
+		return m.GetCertificate(hello)
+	}, nil
+}
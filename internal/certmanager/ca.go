@@ -0,0 +1,252 @@
+package certmanager
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const (
+	caCertFile = "ca_cert.pem"
+	caKeyFile  = "ca_key.pem"
+
+	// maxCertificateSize defines the max. size of the signing response.
+	maxCertificateSize = 1 << 20
+)
+
+var (
+	caRequestTimeout    = 30 * time.Second
+	caRenewInterval     = time.Hour
+	caRenewRetryBackoff = time.Minute
+)
+
+type caProvider struct {
+	sync.RWMutex
+
+	url         string
+	token       string
+	hostnames   []string
+	storageDir  string
+	renewBefore time.Duration
+	httpClient  *http.Client
+
+	cert *tls.Certificate
+}
+
+// setupCA returns the function returning the certificate signed by the
+// internal CA. A previously obtained certificate is re-used when it does not
+// need to be renewed yet. The certificate is renewed in the background.
+func setupCA(conf config.Config) (getCertificateFunc, error) {
+	c := conf.Certificates
+	if len(c.Hostnames) == 0 {
+		return nil, errors.New("certificates.hostnames must not be empty")
+	}
+	if c.CA.URL == "" {
+		return nil, errors.New("certificates.ca.url must not be empty")
+	}
+
+	p := caProvider{
+		url:         c.CA.URL,
+		token:       c.CA.Token,
+		hostnames:   c.Hostnames,
+		storageDir:  c.StorageDir,
+		renewBefore: c.RenewBefore,
+		httpClient:  &http.Client{Timeout: caRequestTimeout},
+	}
+
+	if c.CA.CACert != "" {
+		rawCaCert, err := nstls.ReadPEM(c.CA.CACert)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca certificate error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCaCert) {
+			return nil, errors.New("append ca certificate error")
+		}
+
+		p.httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: caCertPool,
+			},
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"url":       c.CA.URL,
+		"hostnames": c.Hostnames,
+	}).Info("certmanager: using internal ca certificate provider")
+
+	cert, err := nstls.LoadX509KeyPair(filepath.Join(p.storageDir, caCertFile), filepath.Join(p.storageDir, caKeyFile))
+	if err == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err == nil {
+			p.cert = &cert
+		}
+	}
+
+	if p.needsRenewal() {
+		if err := p.renew(); err != nil {
+			// a valid certificate can still be used until it expires
+			if p.cert == nil || time.Now().After(p.cert.Leaf.NotAfter) {
+				return nil, errors.Wrap(err, "obtain certificate error")
+			}
+			log.WithError(err).Error("certmanager: renew certificate error")
+		}
+	}
+
+	go p.renewLoop()
+
+	return p.getCertificate, nil
+}
+
+func (p *caProvider) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.cert, nil
+}
+
+// needsRenewal returns true when there is no certificate or when the
+// certificate expires within the renew before duration.
+func (p *caProvider) needsRenewal() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.cert == nil || time.Until(p.cert.Leaf.NotAfter) < p.renewBefore
+}
+
+func (p *caProvider) renewLoop() {
+	interval := caRenewInterval
+
+	for {
+		time.Sleep(interval)
+		interval = caRenewInterval
+
+		if !p.needsRenewal() {
+			continue
+		}
+
+		if err := p.renew(); err != nil {
+			log.WithError(err).Error("certmanager: renew certificate error")
+			interval = caRenewRetryBackoff
+		}
+	}
+}
+
+// renew obtains a new certificate from the internal CA and stores it.
+func (p *caProvider) renew() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "generate key error")
+	}
+
+	csrTemplate := x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: p.hostnames[0],
+		},
+	}
+	for _, h := range p.hostnames {
+		if ip := net.ParseIP(h); ip != nil {
+			csrTemplate.IPAddresses = append(csrTemplate.IPAddresses, ip)
+		} else {
+			csrTemplate.DNSNames = append(csrTemplate.DNSNames, h)
+		}
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &csrTemplate, key)
+	if err != nil {
+		return errors.Wrap(err, "create certificate request error")
+	}
+
+	certPEM, err := p.sign(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}))
+	if err != nil {
+		return err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrap(err, "marshal key error")
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	// this also validates that the certificate matches the key
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return errors.Wrap(err, "load key-pair error")
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "parse certificate error")
+	}
+
+	if err := os.MkdirAll(p.storageDir, 0700); err != nil {
+		return errors.Wrap(err, "create storage directory error")
+	}
+	if err := ioutil.WriteFile(filepath.Join(p.storageDir, caKeyFile), keyPEM, 0600); err != nil {
+		return errors.Wrap(err, "write key error")
+	}
+	if err := ioutil.WriteFile(filepath.Join(p.storageDir, caCertFile), certPEM, 0644); err != nil {
+		return errors.Wrap(err, "write certificate error")
+	}
+
+	p.Lock()
+	p.cert = &cert
+	p.Unlock()
+
+	log.WithFields(log.Fields{
+		"serial":    cert.Leaf.SerialNumber,
+		"not_after": cert.Leaf.NotAfter,
+	}).Info("certmanager: certificate obtained")
+
+	return nil
+}
+
+// sign posts the given PEM encoded certificate-signing request to the
+// internal CA and returns the PEM encoded certificate (chain).
+func (p *caProvider) sign(csrPEM []byte) ([]byte, error) {
+	req, err := http.NewRequest("POST", p.url, bytes.NewReader(csrPEM))
+	if err != nil {
+		return nil, errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "sign request error")
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCertificateSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "read response error")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expected 200, got: %d (%s)", resp.StatusCode, string(b))
+	}
+
+	return b, nil
+}
@@ -0,0 +1,107 @@
+// Package certmanager implements the automatic provisioning and renewal of
+// the TLS certificate used by the API and metrics listeners. The certificate
+// is obtained using ACME (e.g. Let's Encrypt) or from an internal CA which
+// signs certificate-signing requests. Renewed certificates are used for new
+// connections, without restarting LoRa Server.
+package certmanager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+// ErrNotConfigured is returned when no certificate provider is configured.
+var ErrNotConfigured = errors.New("certificate provider is not configured")
+
+// getCertificateFunc returns the certificate for the given client hello.
+type getCertificateFunc func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+var (
+	mux            sync.RWMutex
+	getCertificate getCertificateFunc
+)
+
+// Setup configures the certificate provider. When a certificate can not be
+// obtained on startup, an error is returned.
+func Setup(conf config.Config) error {
+	var fn getCertificateFunc
+	var err error
+
+	switch conf.Certificates.Provider {
+	case "":
+	case "acme":
+		fn, err = setupACME(conf)
+	case "ca":
+		fn, err = setupCA(conf)
+	default:
+		return fmt.Errorf("unexpected certificate provider: %s", conf.Certificates.Provider)
+	}
+	if err != nil {
+		return err
+	}
+
+	mux.Lock()
+	getCertificate = fn
+	mux.Unlock()
+
+	return nil
+}
+
+// Enabled returns true when a certificate provider has been configured.
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return getCertificate != nil
+}
+
+// GetCertificate returns the current certificate. It can be used as
+// tls.Config.GetCertificate function.
+func GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	mux.RLock()
+	fn := getCertificate
+	mux.RUnlock()
+
+	if fn == nil {
+		return nil, ErrNotConfigured
+	}
+
+	return fn(hello)
+}
+
+// TLSConfig returns the server TLS configuration using the managed
+// certificate. When the (optional) CA certificate is given, the client
+// certificates are verified against it.
+func TLSConfig(caCert string) (*tls.Config, error) {
+	if !Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	tlsConfig := tls.Config{
+		GetCertificate: GetCertificate,
+	}
+
+	if caCert != "" {
+		rawCaCert, err := nstls.ReadPEM(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "load ca certificate error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCaCert) {
+			return nil, errors.New("append ca certificate error")
+		}
+
+		tlsConfig.ClientCAs = caCertPool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return &tlsConfig, nil
+}
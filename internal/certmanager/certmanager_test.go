@@ -0,0 +1,184 @@
+package certmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+type testCA struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	validity time.Duration
+	requests int
+	token    string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	assert := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(err)
+
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(err)
+
+	return &testCA{
+		cert:     cert,
+		key:      key,
+		validity: 12 * time.Hour,
+	}
+}
+
+func (ca *testCA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ca.requests++
+
+	if r.Header.Get("Authorization") != "Bearer "+ca.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	b, _ := ioutil.ReadAll(r.Body)
+	block, _ := pem.Decode(b)
+	if block == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(int64(ca.requests + 1)),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(ca.validity),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCAProvider(t *testing.T) {
+	assert := require.New(t)
+
+	storageDir, err := ioutil.TempDir("", "certmanager")
+	assert.NoError(err)
+	defer os.RemoveAll(storageDir)
+
+	ca := newTestCA(t)
+	ca.token = "secret"
+	server := httptest.NewServer(ca)
+	defer server.Close()
+
+	var conf config.Config
+	conf.Certificates.Provider = "ca"
+	conf.Certificates.Hostnames = []string{"ns.example.com", "127.0.0.1"}
+	conf.Certificates.StorageDir = storageDir
+	conf.Certificates.RenewBefore = time.Hour
+	conf.Certificates.CA.URL = server.URL
+	conf.Certificates.CA.Token = "secret"
+
+	defer Setup(config.Config{})
+
+	t.Run("Obtain certificate", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(Setup(conf))
+		assert.True(Enabled())
+		assert.Equal(1, ca.requests)
+
+		cert, err := GetCertificate(&tls.ClientHelloInfo{})
+		assert.NoError(err)
+		assert.Equal("ns.example.com", cert.Leaf.Subject.CommonName)
+		assert.Equal([]string{"ns.example.com"}, cert.Leaf.DNSNames)
+		assert.Len(cert.Leaf.IPAddresses, 1)
+	})
+
+	t.Run("Stored certificate is re-used", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(Setup(conf))
+		assert.Equal(1, ca.requests)
+	})
+
+	t.Run("Certificate is renewed", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := conf
+		c.Certificates.RenewBefore = 24 * time.Hour
+		assert.NoError(Setup(c))
+		assert.Equal(2, ca.requests)
+
+		cert, err := GetCertificate(&tls.ClientHelloInfo{})
+		assert.NoError(err)
+		assert.EqualValues(3, cert.Leaf.SerialNumber.Int64())
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(os.RemoveAll(storageDir))
+
+		c := conf
+		c.Certificates.CA.Token = "invalid"
+		assert.Error(Setup(c))
+	})
+}
+
+func TestSetup(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	assert.NoError(Setup(conf))
+	assert.False(Enabled())
+
+	_, err := GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(ErrNotConfigured, err)
+
+	_, err = TLSConfig("")
+	assert.Equal(ErrNotConfigured, err)
+
+	conf.Certificates.Provider = "invalid"
+	assert.Error(Setup(conf))
+
+	conf.Certificates.Provider = "acme"
+	assert.Error(Setup(conf), "hostnames must be set")
+}
@@ -9,9 +9,10 @@ import (
 // HandleChannelReconfigure handles the reconfiguration of active channels
 // on the node. This is needed in case only a sub-set of channels is used
 // (e.g. for the US band) or when a reconfiguration of active channels
-// happens.
+// happens. For regions consisting of sub-bands, only the channels of the
+// sub-bands of the gateways receiving the device are enabled.
 func HandleChannelReconfigure(ds storage.DeviceSession) ([]storage.MACCommandBlock, error) {
-	payloads := band.Band().GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
+	payloads := band.GetForSubBands(ds.Region, ds.SubBands).GetLinkADRReqPayloadsForEnabledUplinkChannelIndices(ds.EnabledUplinkChannels)
 	if len(payloads) == 0 {
 		return nil, nil
 	}
@@ -0,0 +1,85 @@
+// Package clock provides an abstraction of the wall-clock, used by the
+// timing-sensitive parts of LoRa Server (de-duplication, Class-B / Class-C
+// scheduling and TTL logic). By default the real clock is used. In tests and
+// in the simulation harness, the clock can be replaced by a Mock clock so
+// that RX windows, ping-slots and timeouts can be tested deterministically
+// and fast-forwarded.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock defines the clock interface.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+var (
+	mux sync.RWMutex
+	c   Clock = realClock{}
+)
+
+// Set sets the clock used by the package-level functions. Passing nil
+// restores the real clock.
+func Set(clock Clock) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if clock == nil {
+		clock = realClock{}
+	}
+	c = clock
+}
+
+// Get returns the clock used by the package-level functions.
+func Get() Clock {
+	mux.RLock()
+	defer mux.RUnlock()
+	return c
+}
+
+// Now returns the current time of the configured clock.
+func Now() time.Time {
+	return Get().Now()
+}
+
+// Sleep pauses the current goroutine for at least the duration d, using the
+// configured clock.
+func Sleep(d time.Duration) {
+	Get().Sleep(d)
+}
+
+// After waits for the duration to elapse, using the configured clock, and
+// then sends the current time on the returned channel.
+func After(d time.Duration) <-chan time.Time {
+	return Get().After(d)
+}
+
+// Since returns the time elapsed since t, using the configured clock.
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
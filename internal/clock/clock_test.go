@@ -0,0 +1,88 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMock(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMock(start)
+
+	t.Run("Now", func(t *testing.T) {
+		assert := require.New(t)
+		assert.True(m.Now().Equal(start))
+	})
+
+	t.Run("After zero duration", func(t *testing.T) {
+		assert := require.New(t)
+
+		select {
+		case ts := <-m.After(0):
+			assert.True(ts.Equal(start))
+		default:
+			t.Fatal("expected time on channel")
+		}
+	})
+
+	t.Run("After is released by Add", func(t *testing.T) {
+		assert := require.New(t)
+
+		c1 := m.After(time.Second)
+		c2 := m.After(2 * time.Second)
+		assert.Equal(2, m.Waiters())
+
+		m.Add(500 * time.Millisecond)
+		assert.Len(c1, 0)
+		assert.Len(c2, 0)
+
+		m.Add(500 * time.Millisecond)
+		assert.True((<-c1).Equal(start.Add(time.Second)))
+		assert.Len(c2, 0)
+		assert.Equal(1, m.Waiters())
+
+		m.Add(time.Minute)
+		assert.True((<-c2).Equal(start.Add(time.Second + time.Minute)))
+		assert.Equal(0, m.Waiters())
+	})
+
+	t.Run("Sleep", func(t *testing.T) {
+		assert := require.New(t)
+
+		done := make(chan struct{})
+		go func() {
+			m.Sleep(time.Hour)
+			close(done)
+		}()
+
+		for m.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		m.Add(time.Hour)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("sleep was not released")
+		}
+		assert.Equal(0, m.Waiters())
+	})
+
+	t.Run("Package-level functions", func(t *testing.T) {
+		assert := require.New(t)
+
+		Set(m)
+		defer Set(nil)
+
+		now := m.Now()
+		assert.True(Now().Equal(now))
+		m.Add(time.Minute)
+		assert.Equal(time.Minute, Since(now))
+
+		Set(nil)
+		assert.IsType(realClock{}, Get())
+	})
+}
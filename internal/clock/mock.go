@@ -0,0 +1,101 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Mock implements a clock which only advances when Add or Set is called.
+// Pending Sleep and After calls are released once the mock time passes
+// their deadline.
+type Mock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	until time.Time
+	c     chan time.Time
+}
+
+// NewMock returns a new Mock clock, set to the given time.
+func NewMock(t time.Time) *Mock {
+	return &Mock{now: t}
+}
+
+// Now returns the current mock time.
+func (m *Mock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// Sleep blocks until the mock time has been advanced by at least d.
+func (m *Mock) Sleep(d time.Duration) {
+	<-m.After(d)
+}
+
+// After returns a channel on which the mock time is sent once the mock time
+// has been advanced by at least d.
+func (m *Mock) After(d time.Duration) <-chan time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w := waiter{
+		until: m.now.Add(d),
+		c:     make(chan time.Time, 1),
+	}
+
+	if d <= 0 {
+		w.c <- m.now
+		return w.c
+	}
+
+	m.waiters = append(m.waiters, &w)
+	return w.c
+}
+
+// Add fast-forwards the mock time by d and releases the pending waiters
+// which deadline has passed, in order of their deadline.
+func (m *Mock) Add(d time.Duration) {
+	m.mu.Lock()
+	t := m.now.Add(d)
+	m.mu.Unlock()
+
+	m.Set(t)
+}
+
+// Set sets the mock time to t and releases the pending waiters which
+// deadline has passed, in order of their deadline. Setting the time
+// backwards does not release any waiters.
+func (m *Mock) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = t
+
+	sort.SliceStable(m.waiters, func(i, j int) bool {
+		return m.waiters[i].until.Before(m.waiters[j].until)
+	})
+
+	var pending []*waiter
+	for _, w := range m.waiters {
+		if w.until.After(m.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.c <- m.now
+	}
+	m.waiters = pending
+}
+
+// Waiters returns the number of pending Sleep and After calls. This can be
+// used in tests to wait until a goroutine is blocked on the clock before
+// advancing it.
+func (m *Mock) Waiters() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.waiters)
+}
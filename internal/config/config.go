@@ -27,6 +27,24 @@ type Config struct {
 		MaxIdle     int           `mapstructure:"max_idle"`
 		MaxActive   int           `mapstructure:"max_active"`
 		IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+		LocalCache struct {
+			Enabled                 bool          `mapstructure:"enabled"`
+			Size                    int           `mapstructure:"size"`
+			TTL                     time.Duration `mapstructure:"ttl"`
+			ConfigureKeyspaceEvents bool          `mapstructure:"configure_keyspace_events"`
+		} `mapstructure:"local_cache"`
+
+		Cluster struct {
+			Enabled  bool     `mapstructure:"enabled"`
+			Nodes    []string `mapstructure:"nodes"`
+			Password string   `mapstructure:"password"`
+		} `mapstructure:"cluster"`
+
+		Sentinel struct {
+			MasterName string   `mapstructure:"master_name"`
+			Addresses  []string `mapstructure:"addresses"`
+		} `mapstructure:"sentinel"`
 	}
 
 	NetworkServer struct {
@@ -35,6 +53,16 @@ type Config struct {
 		DeduplicationDelay   time.Duration `mapstructure:"deduplication_delay"`
 		DeviceSessionTTL     time.Duration `mapstructure:"device_session_ttl"`
 		GetDownlinkDataDelay time.Duration `mapstructure:"get_downlink_data_delay"`
+		DryRun               bool          `mapstructure:"dry_run"`
+
+		Deduplication struct {
+			Shards            int  `mapstructure:"shards"`
+			MigrateLegacyKeys bool `mapstructure:"migrate_legacy_keys"`
+		} `mapstructure:"deduplication"`
+
+		LatencyBudget struct {
+			RX1DelayFraction float64 `mapstructure:"rx1_delay_fraction"`
+		} `mapstructure:"latency_budget"`
 
 		Band struct {
 			Name                   band.Name
@@ -44,6 +72,8 @@ type Config struct {
 			RepeaterCompatible     bool    `mapstructure:"repeater_compatible"`
 		}
 
+		Regions []Region `mapstructure:"regions"`
+
 		NetworkSettings struct {
 			InstallationMargin    float64 `mapstructure:"installation_margin"`
 			RXWindow              int     `mapstructure:"rx_window"`
@@ -55,12 +85,11 @@ type Config struct {
 			EnabledUplinkChannels []int   `mapstructure:"enabled_uplink_channels"`
 			DisableMACCommands    bool    `mapstructure:"disable_mac_commands"`
 			DisableADR            bool    `mapstructure:"disable_adr"`
+			FCntResetThreshold    int     `mapstructure:"fcnt_reset_threshold"`
 
-			ExtraChannels []struct {
-				Frequency int
-				MinDR     int `mapstructure:"min_dr"`
-				MaxDR     int `mapstructure:"max_dr"`
-			} `mapstructure:"extra_channels"`
+			ExtraChannels          []ExtraChannel `mapstructure:"extra_channels"`
+			DisableDefaultChannels bool           `mapstructure:"disable_default_channels"`
+			ChannelGroups          []ChannelGroup `mapstructure:"channel_groups"`
 
 			ClassB struct {
 				PingSlotDR        int `mapstructure:"ping_slot_dr"`
@@ -80,8 +109,37 @@ type Config struct {
 			ClassC struct {
 				DownlinkLockDuration time.Duration `mapstructure:"downlink_lock_duration"`
 			} `mapstructure:"class_c"`
+
+			Multicast struct {
+				TXRetries   int           `mapstructure:"tx_retries"`
+				TXStatusTTL time.Duration `mapstructure:"tx_status_ttl"`
+			} `mapstructure:"multicast"`
+
+			TXAck struct {
+				Timeout       time.Duration `mapstructure:"timeout"`
+				RetryGateways int           `mapstructure:"retry_gateways"`
+			} `mapstructure:"tx_ack"`
 		} `mapstructure:"scheduler"`
 
+		LeaderElection struct {
+			Enabled       bool          `mapstructure:"enabled"`
+			LockTTL       time.Duration `mapstructure:"lock_ttl"`
+			RenewInterval time.Duration `mapstructure:"renew_interval"`
+		} `mapstructure:"leader_election"`
+
+		Partitioning struct {
+			Enabled       bool          `mapstructure:"enabled"`
+			Count         int           `mapstructure:"count"`
+			Partitions    []int         `mapstructure:"partitions"`
+			ClaimTTL      time.Duration `mapstructure:"claim_ttl"`
+			RenewInterval time.Duration `mapstructure:"renew_interval"`
+		} `mapstructure:"partitioning"`
+
+		FeatureFlags struct {
+			RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+			Flags           []FeatureFlag `mapstructure:"flags"`
+		} `mapstructure:"feature_flags"`
+
 		API struct {
 			Bind    string
 			CACert  string `mapstructure:"ca_cert"`
@@ -89,6 +147,44 @@ type Config struct {
 			TLSKey  string `mapstructure:"tls_key"`
 		} `mapstructure:"api"`
 
+		Handover struct {
+			Enabled      bool          `mapstructure:"enabled"`
+			ReadyTimeout time.Duration `mapstructure:"ready_timeout"`
+		} `mapstructure:"handover"`
+
+		Webhook struct {
+			Timeout       time.Duration `mapstructure:"timeout"`
+			MaxRetries    int           `mapstructure:"max_retries"`
+			RetryInterval time.Duration `mapstructure:"retry_interval"`
+		} `mapstructure:"webhook"`
+
+		DownlinkHook struct {
+			Timeout    time.Duration `mapstructure:"timeout"`
+			FailPolicy string        `mapstructure:"fail_policy"`
+		} `mapstructure:"downlink_hook"`
+
+		UplinkAttestation struct {
+			Algorithm string `mapstructure:"algorithm"`
+			KeyID     string `mapstructure:"key_id"`
+			Key       string `mapstructure:"key"`
+		} `mapstructure:"uplink_attestation"`
+
+		FrameLog struct {
+			Capture struct {
+				MaxDuration time.Duration `mapstructure:"max_duration"`
+				MaxFrames   int           `mapstructure:"max_frames"`
+				TTL         time.Duration `mapstructure:"ttl"`
+			} `mapstructure:"capture"`
+
+			WebSocket struct {
+				Bind           string   `mapstructure:"bind"`
+				TLSCert        string   `mapstructure:"tls_cert"`
+				TLSKey         string   `mapstructure:"tls_key"`
+				JWTSecret      string   `mapstructure:"jwt_secret"`
+				AllowedOrigins []string `mapstructure:"allowed_origins"`
+			} `mapstructure:"websocket"`
+		} `mapstructure:"frame_log"`
+
 		Gateway struct {
 			// Deprecated
 			Stats struct {
@@ -96,7 +192,8 @@ type Config struct {
 			}
 
 			Backend struct {
-				Type string `mapstructure:"type"`
+				Type  string   `mapstructure:"type"`
+				Types []string `mapstructure:"types"`
 
 				MQTT struct {
 					Server       string
@@ -109,8 +206,19 @@ type Config struct {
 					TLSCert      string `mapstructure:"tls_cert"`
 					TLSKey       string `mapstructure:"tls_key"`
 
-					EventTopic           string `mapstructure:"event_topic"`
-					CommandTopicTemplate string `mapstructure:"command_topic_template"`
+					EventTopic              string `mapstructure:"event_topic"`
+					StateTopic              string `mapstructure:"state_topic"`
+					CommandTopicTemplate    string `mapstructure:"command_topic_template"`
+					SharedSubscriptionGroup string `mapstructure:"shared_subscription_group"`
+					VerifyGatewayID         bool   `mapstructure:"verify_gateway_id"`
+					ShardCount              int    `mapstructure:"shard_count"`
+					Shards                  []int  `mapstructure:"shards"`
+
+					MaxReconnectInterval time.Duration `mapstructure:"max_reconnect_interval"`
+					OutboundBuffer       struct {
+						Size int           `mapstructure:"size"`
+						TTL  time.Duration `mapstructure:"ttl"`
+					} `mapstructure:"outbound_buffer"`
 				} `mapstructure:"mqtt"`
 
 				GCPPubSub struct {
@@ -125,7 +233,79 @@ type Config struct {
 					EventsConnectionString   string `mapstructure:"events_connection_string"`
 					CommandsConnectionString string `mapstructure:"commands_connection_string"`
 				} `mapstructure:"azure_iot_hub"`
+
+				BasicStation struct {
+					Bind          string        `mapstructure:"bind"`
+					TLSCert       string        `mapstructure:"tls_cert"`
+					TLSKey        string        `mapstructure:"tls_key"`
+					CACert        string        `mapstructure:"ca_cert"`
+					StatsInterval time.Duration `mapstructure:"stats_interval"`
+					Region        string        `mapstructure:"region"`
+					FrequencyMin  uint32        `mapstructure:"frequency_min"`
+					FrequencyMax  uint32        `mapstructure:"frequency_max"`
+				} `mapstructure:"basic_station"`
+
+				Kafka struct {
+					Brokers              []string `mapstructure:"brokers"`
+					GroupID              string   `mapstructure:"group_id"`
+					EventTopicTemplate   string   `mapstructure:"event_topic_template"`
+					CommandTopicTemplate string   `mapstructure:"command_topic_template"`
+					TLS                  bool     `mapstructure:"tls"`
+					CACert               string   `mapstructure:"ca_cert"`
+					TLSCert              string   `mapstructure:"tls_cert"`
+					TLSKey               string   `mapstructure:"tls_key"`
+				} `mapstructure:"kafka"`
+
+				NATS struct {
+					Server                 string        `mapstructure:"server"`
+					Username               string        `mapstructure:"username"`
+					Password               string        `mapstructure:"password"`
+					CACert                 string        `mapstructure:"ca_cert"`
+					TLSCert                string        `mapstructure:"tls_cert"`
+					TLSKey                 string        `mapstructure:"tls_key"`
+					EventSubject           string        `mapstructure:"event_subject"`
+					CommandSubjectTemplate string        `mapstructure:"command_subject_template"`
+					QueueGroup             string        `mapstructure:"queue_group"`
+					JetStream              bool          `mapstructure:"jetstream"`
+					EventStream            string        `mapstructure:"event_stream"`
+					CommandStream          string        `mapstructure:"command_stream"`
+					StreamMaxAge           time.Duration `mapstructure:"stream_max_age"`
+					DurableName            string        `mapstructure:"durable_name"`
+					AckWait                time.Duration `mapstructure:"ack_wait"`
+				} `mapstructure:"nats"`
+
+				SemtechUDP struct {
+					Bind           string        `mapstructure:"bind"`
+					GatewayTimeout time.Duration `mapstructure:"gateway_timeout"`
+				} `mapstructure:"semtech_udp"`
+
+				Concentratord struct {
+					EventURL       string        `mapstructure:"event_url"`
+					CommandURL     string        `mapstructure:"command_url"`
+					CommandTimeout time.Duration `mapstructure:"command_timeout"`
+				} `mapstructure:"concentratord"`
 			}
+
+			Contribution struct {
+				Enabled bool `mapstructure:"enabled"`
+			} `mapstructure:"contribution"`
+
+			StatsAggregation struct {
+				Enabled       bool          `mapstructure:"enabled"`
+				FlushInterval time.Duration `mapstructure:"flush_interval"`
+				Intervals     []struct {
+					Interval  time.Duration `mapstructure:"interval"`
+					Retention time.Duration `mapstructure:"retention"`
+				} `mapstructure:"intervals"`
+			} `mapstructure:"stats_aggregation"`
+
+			Constraints []struct {
+				Model                 string `mapstructure:"model"`
+				NoGPSTiming           bool   `mapstructure:"no_gps_timing"`
+				MaxDownlinkQueueDepth int    `mapstructure:"max_downlink_queue_depth"`
+			} `mapstructure:"constraints"`
+
+			MetaDataUpdate string `mapstructure:"meta_data_update"`
 		}
 	} `mapstructure:"network_server"`
 
@@ -136,9 +316,27 @@ type Config struct {
 		TLSKey  string `mapstructure:"tls_key"`
 	} `mapstructure:"geolocation_server"`
 
+	ApplicationServer struct {
+		ConnectionsPerServer int           `mapstructure:"connections_per_server"`
+		IdleTimeout          time.Duration `mapstructure:"idle_timeout"`
+
+		Batch struct {
+			Enabled  bool          `mapstructure:"enabled"`
+			MaxSize  int           `mapstructure:"max_size"`
+			Interval time.Duration `mapstructure:"interval"`
+		} `mapstructure:"batch"`
+
+		HealthCheck struct {
+			Enabled  bool          `mapstructure:"enabled"`
+			Interval time.Duration `mapstructure:"interval"`
+			Timeout  time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"health_check"`
+	} `mapstructure:"application_server"`
+
 	JoinServer struct {
-		ResolveJoinEUI      bool   `mapstructure:"resolve_join_eui"`
-		ResolveDomainSuffix string `mapstructure:"resolve_domain_suffix"`
+		ResolveJoinEUI      bool          `mapstructure:"resolve_join_eui"`
+		ResolveDomainSuffix string        `mapstructure:"resolve_domain_suffix"`
+		ResolveCacheTTL     time.Duration `mapstructure:"resolve_cache_ttl"`
 
 		Certificates []struct {
 			JoinEUI string `mapstructure:"join_eui"`
@@ -155,13 +353,35 @@ type Config struct {
 		}
 
 		KEK struct {
+			RequireWrappedKeys bool `mapstructure:"require_wrapped_keys"`
+			StoreWrappedKeys   bool `mapstructure:"store_wrapped_keys"`
+
 			Set []struct {
 				Label string
 				KEK   string `mapstructure:"kek"`
 			}
+
+			JoinServers []struct {
+				JoinEUIFrom string `mapstructure:"join_eui_from"`
+				JoinEUITo   string `mapstructure:"join_eui_to"`
+
+				Set []struct {
+					Label string
+					KEK   string `mapstructure:"kek"`
+				}
+			} `mapstructure:"join_servers"`
 		} `mapstructure:"kek"`
 	} `mapstructure:"join_server"`
 
+	Roaming struct {
+		ResolveNetID             bool          `mapstructure:"resolve_net_id"`
+		ResolveNetIDDomainSuffix string        `mapstructure:"resolve_net_id_domain_suffix"`
+		ResolveCacheTTL          time.Duration `mapstructure:"resolve_cache_ttl"`
+		CACert                   string        `mapstructure:"ca_cert"`
+		TLSCert                  string        `mapstructure:"tls_cert"`
+		TLSKey                   string        `mapstructure:"tls_key"`
+	} `mapstructure:"roaming"`
+
 	M2MServer struct {
 		M2MServer string `mapstructure:"m2m_server"`
 		CACert    string `mapstructure:"ca_cert"`
@@ -176,8 +396,51 @@ type Config struct {
 		CACert  string `mapstructure:"ca_cert"`
 		TLSCert string `mapstructure:"tls_cert"`
 		TLSKey  string `mapstructure:"tls_key"`
+
+		MACCommandInterception struct {
+			Enabled    bool          `mapstructure:"enabled"`
+			Timeout    time.Duration `mapstructure:"timeout"`
+			FailPolicy string        `mapstructure:"fail_policy"`
+		} `mapstructure:"mac_command_interception"`
 	} `mapstructure:"network_controller"`
 
+	ProvisioningSync struct {
+		Server   string        `mapstructure:"server"`
+		Token    string        `mapstructure:"token"`
+		Interval time.Duration `mapstructure:"interval"`
+	} `mapstructure:"provisioning_sync"`
+
+	Janitor struct {
+		DeviceSessionGC       JanitorTask `mapstructure:"device_session_gc"`
+		DeduplicationSweep    JanitorTask `mapstructure:"deduplication_sweep"`
+		DeviceQueueCleanup    JanitorTask `mapstructure:"device_queue_cleanup"`
+		DeviceQueueExpiry     JanitorTask `mapstructure:"device_queue_expiry"`
+		GatewayStatsRetention JanitorTask `mapstructure:"gateway_stats_retention"`
+
+		MulticastGatewaySet struct {
+			JanitorTask `mapstructure:",squash"`
+			Hysteresis  int `mapstructure:"hysteresis"`
+		} `mapstructure:"multicast_gateway_set"`
+
+		DeviceSessionIntegrity struct {
+			JanitorTask `mapstructure:",squash"`
+			Policy      string `mapstructure:"policy"`
+		} `mapstructure:"device_session_integrity"`
+
+		RedisMemoryUsage struct {
+			JanitorTask     `mapstructure:",squash"`
+			SamplesPerClass int `mapstructure:"samples_per_class"`
+
+			Caps struct {
+				Sessions      int64 `mapstructure:"sessions"`
+				Deduplication int64 `mapstructure:"deduplication"`
+				FrameLog      int64 `mapstructure:"framelog"`
+				Queues        int64 `mapstructure:"queues"`
+				Metrics       int64 `mapstructure:"metrics"`
+			} `mapstructure:"caps"`
+		} `mapstructure:"redis_memory_usage"`
+	} `mapstructure:"janitor"`
+
 	Metrics struct {
 		Timezone string `mapstructure:"timezone"`
 
@@ -193,8 +456,188 @@ type Config struct {
 			EndpointEnabled    bool   `mapstructure:"endpoint_enabled"`
 			Bind               string `mapstructure:"bind"`
 			APITimingHistogram bool   `mapstructure:"api_timing_histogram"`
+			TLS                bool   `mapstructure:"tls"`
 		}
 	} `mapstructure:"metrics"`
+
+	Certificates struct {
+		Provider    string        `mapstructure:"provider"`
+		Hostnames   []string      `mapstructure:"hostnames"`
+		StorageDir  string        `mapstructure:"storage_dir"`
+		RenewBefore time.Duration `mapstructure:"renew_before"`
+
+		ACME struct {
+			DirectoryURL string `mapstructure:"directory_url"`
+			Email        string `mapstructure:"email"`
+			HTTPBind     string `mapstructure:"http_bind"`
+		} `mapstructure:"acme"`
+
+		CA struct {
+			URL    string `mapstructure:"url"`
+			CACert string `mapstructure:"ca_cert"`
+			Token  string `mapstructure:"token"`
+		} `mapstructure:"ca"`
+	} `mapstructure:"certificates"`
+
+	Security struct {
+		MaxEvents         int           `mapstructure:"max_events"`
+		RateLimitInterval time.Duration `mapstructure:"rate_limit_interval"`
+
+		MICFailureBurst SecurityThreshold `mapstructure:"mic_failure_burst"`
+		JoinFlood       SecurityThreshold `mapstructure:"join_flood"`
+
+		Syslog struct {
+			Enabled bool   `mapstructure:"enabled"`
+			Network string `mapstructure:"network"`
+			Address string `mapstructure:"address"`
+			Tag     string `mapstructure:"tag"`
+		} `mapstructure:"syslog"`
+
+		Webhook struct {
+			URL    string `mapstructure:"url"`
+			Secret string `mapstructure:"secret"`
+		} `mapstructure:"webhook"`
+	} `mapstructure:"security"`
+
+	Accounting struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Sink    string `mapstructure:"sink"`
+
+		MQTT struct {
+			Server          string        `mapstructure:"server"`
+			Username        string        `mapstructure:"username"`
+			Password        string        `mapstructure:"password"`
+			ClientID        string        `mapstructure:"client_id"`
+			QOS             uint8         `mapstructure:"qos"`
+			TopicTemplate   string        `mapstructure:"topic_template"`
+			CACert          string        `mapstructure:"ca_cert"`
+			TLSCert         string        `mapstructure:"tls_cert"`
+			TLSKey          string        `mapstructure:"tls_key"`
+			ForwardInterval time.Duration `mapstructure:"forward_interval"`
+			BatchSize       int           `mapstructure:"batch_size"`
+		} `mapstructure:"mqtt"`
+	} `mapstructure:"accounting"`
+
+	Simulator struct {
+		ServiceProfileID string        `mapstructure:"service_profile_id"`
+		DeviceProfileID  string        `mapstructure:"device_profile_id"`
+		RoutingProfileID string        `mapstructure:"routing_profile_id"`
+		Seed             int64         `mapstructure:"seed"`
+		Duration         time.Duration `mapstructure:"duration"`
+		Marshaler        string        `mapstructure:"marshaler"`
+
+		GatewayCount  int    `mapstructure:"gateway_count"`
+		GatewayIDBase string `mapstructure:"gateway_id_base"`
+
+		OTAADeviceCount int           `mapstructure:"otaa_device_count"`
+		ABPDeviceCount  int           `mapstructure:"abp_device_count"`
+		DevEUIBase      string        `mapstructure:"dev_eui_base"`
+		JoinEUI         string        `mapstructure:"join_eui"`
+		AppKey          string        `mapstructure:"app_key"`
+		UplinkInterval  time.Duration `mapstructure:"uplink_interval"`
+		FPort           uint8         `mapstructure:"f_port"`
+		PayloadSize     int           `mapstructure:"payload_size"`
+		ConfirmedRatio  float64       `mapstructure:"confirmed_ratio"`
+
+		RSSIMin int     `mapstructure:"rssi_min"`
+		RSSIMax int     `mapstructure:"rssi_max"`
+		SNRMin  float64 `mapstructure:"snr_min"`
+		SNRMax  float64 `mapstructure:"snr_max"`
+
+		MinJoinSuccessRatio      float64 `mapstructure:"min_join_success_ratio"`
+		MinDownlinkDeliveryRatio float64 `mapstructure:"min_downlink_delivery_ratio"`
+
+		RecoveryAfter                    time.Duration `mapstructure:"recovery_after"`
+		MinRecoveryDownlinkDeliveryRatio float64       `mapstructure:"min_recovery_downlink_delivery_ratio"`
+	} `mapstructure:"simulator"`
+
+	FaultInjection struct {
+		Enabled  bool   `mapstructure:"enabled"`
+		Scenario string `mapstructure:"scenario"`
+	} `mapstructure:"fault_injection"`
+
+	Secrets struct {
+		Vault struct {
+			Address   string `mapstructure:"address"`
+			CACert    string `mapstructure:"ca_cert"`
+			TokenFile string `mapstructure:"token_file"`
+
+			AppRole struct {
+				MountPath    string `mapstructure:"mount_path"`
+				RoleID       string `mapstructure:"role_id"`
+				SecretIDFile string `mapstructure:"secret_id_file"`
+			} `mapstructure:"approle"`
+		} `mapstructure:"vault"`
+	} `mapstructure:"secrets"`
+}
+
+// ExtraChannel defines an extra (non-standard) channel of the channel-plan.
+type ExtraChannel struct {
+	Frequency int
+	MinDR     int `mapstructure:"min_dr"`
+	MaxDR     int `mapstructure:"max_dr"`
+
+	// DownlinkOnly channels are not added as uplink channel, but are used
+	// as RX1 frequency for the uplinks received on UplinkFrequency.
+	DownlinkOnly    bool `mapstructure:"downlink_only"`
+	UplinkFrequency int  `mapstructure:"uplink_frequency"`
+}
+
+// Region defines an additional region, which can be assigned to gateways
+// through the gateway-profile.
+type Region struct {
+	Name                   string    `mapstructure:"name"`
+	Band                   band.Name `mapstructure:"band"`
+	DownlinkDwellTime400ms bool      `mapstructure:"downlink_dwell_time_400ms"`
+	RepeaterCompatible     bool      `mapstructure:"repeater_compatible"`
+
+	ExtraChannels          []ExtraChannel `mapstructure:"extra_channels"`
+	DisableDefaultChannels bool           `mapstructure:"disable_default_channels"`
+	ChannelGroups          []ChannelGroup `mapstructure:"channel_groups"`
+}
+
+// ChannelGroup defines a group of uplink channels which can be selected per
+// gateway-profile (e.g. the CN470 antenna-type plans).
+type ChannelGroup struct {
+	Name           string
+	UplinkChannels []int `mapstructure:"uplink_channels"`
+
+	// DownlinkFrequencies defines the RX1 frequency for each uplink channel
+	// (modulo the number of downlink frequencies). When empty, the RX1
+	// frequency of the band is used.
+	DownlinkFrequencies []int `mapstructure:"downlink_frequencies"`
+}
+
+// FeatureFlag defines the rollout of a feature-flag.
+type FeatureFlag struct {
+	Name string `mapstructure:"name"`
+
+	// Percentage (0 - 100) of the devices for which the feature is enabled.
+	Percentage int `mapstructure:"percentage"`
+
+	// ServiceProfiles overrides the percentage per service-profile.
+	ServiceProfiles []FeatureFlagServiceProfile `mapstructure:"service_profiles"`
+}
+
+// FeatureFlagServiceProfile defines the feature-flag percentage for a
+// service-profile.
+type FeatureFlagServiceProfile struct {
+	ID         string `mapstructure:"id"`
+	Percentage int    `mapstructure:"percentage"`
+}
+
+// JanitorTask defines the scheduling of a janitor (maintenance) task.
+type JanitorTask struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
+	Jitter   time.Duration `mapstructure:"jitter"`
+}
+
+// SecurityThreshold defines the number of occurrences within the given
+// window after which a security event is emitted.
+type SecurityThreshold struct {
+	Threshold int           `mapstructure:"threshold"`
+	Window    time.Duration `mapstructure:"window"`
 }
 
 // SpreadFactorToRequiredSNRTable contains the required SNR to demodulate a
@@ -3,22 +3,48 @@ package ack
 import (
 	"context"
 	"encoding/binary"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
-	"github.com/pkg/errors"
 )
 
+// txAckTimeoutError is the error of the negative TX acknowledgement which is
+// handled when no TX acknowledgement was received within the timeout.
+const txAckTimeoutError = "TIMEOUT"
+
 var (
 	errAbort = errors.New("abort")
+
+	// txAckTimeoutInterval defines the interval in which the expired TX
+	// acknowledgement timeouts are handled.
+	txAckTimeoutInterval = 100 * time.Millisecond
+
+	txAckTimeout time.Duration
 )
 
 var handleDownlinkTXAckTasks = []func(*ackContext) error{
 	// smbPacketPayment,
-	abortOnNoError,
 	getToken,
+	handleTXAckTimeout,
+	handleTXAckPending,
+	handleAccounting,
+	handleContribution,
+	handleMulticast,
+	abortOnNoError,
 	getDownlinkFrame,
 	sendDownlinkFrame,
 }
@@ -30,6 +56,86 @@ type ackContext struct {
 	DevEUI        lorawan.EUI64
 	DownlinkTXAck gw.DownlinkTXAck
 	DownlinkFrame gw.DownlinkFrame
+
+	// TimedOut is set when the TX acknowledgement is generated because no TX
+	// acknowledgement was received within the timeout.
+	TimedOut bool
+
+	// Retried is set when the TX acknowledgement was received after the
+	// timeout, in which case the downlink has already been retried.
+	Retried bool
+}
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	txAckTimeout = conf.NetworkServer.Scheduler.TXAck.Timeout
+	return nil
+}
+
+// SetTXAckTimeout registers the TX acknowledgement timeout for the downlink
+// with the given token, sent to the given gateway. This must be called
+// before sending the downlink, as the TX acknowledgement could arrive before
+// the timeout has been registered. When no timeout is configured, this is a
+// no-op.
+func SetTXAckTimeout(ctx context.Context, gatewayID lorawan.EUI64, token uint16) error {
+	if txAckTimeout == 0 {
+		return nil
+	}
+
+	if err := storage.SetDownlinkTXAckTimeout(ctx, storage.RedisPool(), gatewayID, token, clock.Now().Add(txAckTimeout)); err != nil {
+		return errors.Wrap(err, "set downlink tx acknowledgement timeout error")
+	}
+
+	return nil
+}
+
+// TXAckTimeoutLoop starts an infinite loop handling the downlinks for which
+// no TX acknowledgement was received within the configured timeout.
+func TXAckTimeoutLoop() {
+	for {
+		if err := HandleTXAckTimeouts(context.Background()); err != nil {
+			log.WithError(err).Error("handle downlink tx acknowledgement timeouts error")
+		}
+		clock.Sleep(txAckTimeoutInterval)
+	}
+}
+
+// HandleTXAckTimeouts handles the expired TX acknowledgement timeouts. Each
+// expired timeout is handled as a negative TX acknowledgement, meaning that
+// the next saved downlink-frame (e.g. RX2 or the frame for the next-best
+// gateway) is sent. An expired timeout is handled by only one instance.
+func HandleTXAckTimeouts(ctx context.Context) error {
+	timeouts, err := storage.GetExpiredDownlinkTXAckTimeouts(ctx, storage.RedisPool())
+	if err != nil {
+		return errors.Wrap(err, "get expired downlink tx acknowledgement timeouts error")
+	}
+
+	for _, t := range timeouts {
+		ctxID, err := uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "get new uuid error")
+		}
+
+		actx := ackContext{
+			ctx: context.WithValue(ctx, logging.ContextIDKey, ctxID),
+			DownlinkTXAck: gw.DownlinkTXAck{
+				GatewayId: t.GatewayID[:],
+				Token:     uint32(t.Token),
+				Error:     txAckTimeoutError,
+			},
+			TimedOut: true,
+		}
+
+		if err := handleDownlinkTXAck(&actx); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": t.GatewayID,
+				"token":      t.Token,
+				"ctx_id":     ctxID,
+			}).Error("handle downlink tx acknowledgement timeout error")
+		}
+	}
+
+	return nil
 }
 
 // HandleDownlinkTXAck handles the given downlink TX acknowledgement.
@@ -39,8 +145,13 @@ func HandleDownlinkTXAck(ctx context.Context, downlinkTXAck gw.DownlinkTXAck) er
 		DownlinkTXAck: downlinkTXAck,
 	}
 
+	return handleDownlinkTXAck(&actx)
+}
+
+func handleDownlinkTXAck(actx *ackContext) error {
+
 	for _, t := range handleDownlinkTXAckTasks {
-		if err := t(&actx); err != nil {
+		if err := t(actx); err != nil {
 			if err == errAbort {
 				return nil
 			}
@@ -81,7 +192,93 @@ func getToken(ctx *ackContext) error {
 	return nil
 }
 
+func handleTXAckTimeout(ctx *ackContext) error {
+	if txAckTimeout == 0 {
+		return nil
+	}
+
+	gatewayID := helpers.GetGatewayID(&ctx.DownlinkTXAck)
+	deleted, err := storage.DeleteDownlinkTXAckTimeout(ctx.ctx, storage.RedisPool(), gatewayID, ctx.Token)
+	if err != nil {
+		if ctx.TimedOut {
+			return errors.Wrap(err, "delete downlink tx acknowledgement timeout error")
+		}
+		log.WithError(err).WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("delete downlink tx acknowledgement timeout error")
+		return nil
+	}
+
+	if ctx.TimedOut {
+		if !deleted {
+			// the TX acknowledgement has been received or the timeout is
+			// handled by an other instance
+			return errAbort
+		}
+
+		txAckTimeoutCounter().Inc()
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"token":      ctx.Token,
+			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("downlink tx acknowledgement timeout")
+		return nil
+	}
+
+	ctx.Retried = !deleted
+	return nil
+}
+
+func handleTXAckPending(ctx *ackContext) error {
+	if err := storage.DeleteDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), helpers.GetGatewayID(&ctx.DownlinkTXAck), ctx.Token); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("delete downlink tx acknowledgement pending error")
+	}
+	return nil
+}
+
+func handleAccounting(ctx *ackContext) error {
+	if err := accounting.HandleDownlinkTXAck(ctx.ctx, storage.RedisPool(), ctx.Token, ctx.DownlinkTXAck); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("handle downlink accounting record error")
+	}
+	return nil
+}
+
+func handleContribution(ctx *ackContext) error {
+	if err := contribution.HandleDownlinkTXAck(ctx.ctx, storage.RedisPool(), ctx.Token, ctx.DownlinkTXAck); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("handle downlink gateway contribution error")
+	}
+	return nil
+}
+
+func handleMulticast(ctx *ackContext) error {
+	if err := multicast.HandleDownlinkTXAck(ctx.ctx, storage.RedisPool(), storage.DB(), ctx.Token, ctx.DownlinkTXAck); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("handle multicast downlink tx acknowledgement error")
+	}
+	return nil
+}
+
 func getDownlinkFrame(ctx *ackContext) error {
+	if ctx.Retried {
+		log.WithFields(log.Fields{
+			"token":  ctx.Token,
+			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
+		}).Info("downlink has already been retried on tx acknowledgement timeout")
+		return errAbort
+	}
+
 	var err error
 	ctx.DevEUI, ctx.DownlinkFrame, err = storage.PopDownlinkFrame(ctx.ctx, storage.RedisPool(), uint32(ctx.Token))
 	if err != nil {
@@ -95,6 +292,40 @@ func getDownlinkFrame(ctx *ackContext) error {
 }
 
 func sendDownlinkFrame(ctx *ackContext) error {
+	if err := accounting.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DevEUI, ctx.DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink accounting record error")
+	}
+	if err := contribution.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink gateway contribution error")
+	}
+	if err := storage.SetDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement pending error")
+	}
+	if err := SetTXAckTimeout(ctx.ctx, helpers.GetGatewayID(ctx.DownlinkFrame.TxInfo), ctx.Token); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement timeout error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":    ctx.DevEUI,
+		"gateway_id": helpers.GetGatewayID(ctx.DownlinkFrame.TxInfo),
+		"token":      ctx.Token,
+		"error":      ctx.DownlinkTXAck.Error,
+		"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("retrying downlink-frame")
+	retryCounter().Inc()
+
 	if err := gateway.Backend().SendTXPacket(ctx.DownlinkFrame); err != nil {
 		return errors.Wrap(err, "send downlink-frame to gateway error")
 	}
@@ -0,0 +1,26 @@
+package ack
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tatc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "downlink_tx_ack_timeout_count",
+		Help: "The number of downlinks for which no TX acknowledgement was received within the timeout.",
+	})
+
+	rc = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "downlink_retry_count",
+		Help: "The number of downlink-frames sent as retry after a negative TX acknowledgement or TX acknowledgement timeout.",
+	})
+)
+
+func txAckTimeoutCounter() prometheus.Counter {
+	return tatc
+}
+
+func retryCounter() prometheus.Counter {
+	return rc
+}
@@ -12,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
@@ -115,7 +116,7 @@ func ScheduleDeviceQueueToPingSlotsForDevEUI(ctx context.Context, db sqlx.Ext, d
 		return errors.Wrap(err, "get device-queue items error")
 	}
 
-	scheduleAfterGPSEpochTS := gps.Time(time.Now().Add(scheduleMargin)).TimeSinceGPSEpoch()
+	scheduleAfterGPSEpochTS := gps.Time(clock.Now().Add(scheduleMargin)).TimeSinceGPSEpoch()
 
 	for _, qi := range queueItems {
 		if qi.IsPending {
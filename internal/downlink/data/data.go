@@ -10,16 +10,28 @@ import (
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/mxc-foundation/lpwan-server/api/as"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	m2m_api "github.com/mxc-foundation/lpwan-server/api/m2m_server"
+	"github.com/mxc-foundation/lpwan-server/api/nc"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
 	"github.com/mxc-foundation/lpwan-server/internal/adr"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/controller"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/downlinkhook"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/channels"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/latency"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/mxc_smb"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/constraint"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/maccommand"
@@ -65,6 +77,9 @@ var (
 	// Downlink TX power
 	downlinkTXPower int
 
+	// Number of next-best gateways used for retrying the downlink.
+	retryGateways int
+
 	// MAC Commands
 	disableMACCommands bool
 
@@ -78,6 +93,11 @@ var (
 	uplinkDwellTime400ms   bool
 	downlinkDwellTime400ms bool
 	uplinkMaxEIRPIndex     uint8
+
+	// Network-controller mac-command interception.
+	macCommandInterceptionEnabled    bool
+	macCommandInterceptionTimeout    time.Duration
+	macCommandInterceptionFailClosed bool
 )
 
 var setMACCommandsSet = setMACCommands(
@@ -95,9 +115,12 @@ var setMACCommandsSet = setMACCommands(
 var responseTasks = []func(*dataContext) error{
 	getDeviceProfile,
 	getServiceProfile,
+	checkDownlinkEnabled,
 	setDeviceGatewayRXInfo,
 	smbReorderGateways,
+	getFrequencyPlan,
 	setDataTXInfo,
+	setTXInfoForRetryGateways,
 	setToken,
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
@@ -112,9 +135,12 @@ var responseTasks = []func(*dataContext) error{
 var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	getDeviceProfile,
 	getServiceProfile,
+	checkDownlinkEnabled,
 	checkLastDownlinkTimestamp,
 	setDeviceGatewayRXInfo,
 	smbReorderGateways,
+	checkLBTDeferralMargin,
+	getFrequencyPlan,
 	forClass(storage.DeviceModeC,
 		setImmediately,
 		setTXInfoForRX2,
@@ -125,6 +151,7 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	forClass(storage.DeviceModeA,
 		returnInvalidDeviceClassError,
 	),
+	setTXInfoForRetryGateways,
 	setToken,
 	getNextDeviceQueueItem,
 	setMACCommandsSet,
@@ -132,6 +159,8 @@ var scheduleNextQueueItemTasks = []func(*dataContext) error{
 	setPHYPayloads,
 	sendDownlinkFrame,
 	saveDeviceSession,
+	saveRemainingFrames,
+	recordDeviceClassDownlink,
 	smbDlSent,
 }
 
@@ -152,6 +181,7 @@ func Setup(conf config.Config) error {
 	rxWindow = nsConf.RXWindow
 
 	downlinkTXPower = nsConf.DownlinkTXPower
+	retryGateways = conf.NetworkServer.Scheduler.TXAck.RetryGateways
 
 	disableMACCommands = nsConf.DisableMACCommands
 	disableADR = nsConf.DisableADR
@@ -167,6 +197,19 @@ func Setup(conf config.Config) error {
 	}
 	uplinkMaxEIRPIndex = lorawan.GetTXParamSetupEIRPIndex(maxEIRP)
 
+	interceptConf := conf.NetworkController.MACCommandInterception
+	macCommandInterceptionEnabled = interceptConf.Enabled
+	macCommandInterceptionTimeout = interceptConf.Timeout
+
+	switch interceptConf.FailPolicy {
+	case "", "open":
+		macCommandInterceptionFailClosed = false
+	case "closed":
+		macCommandInterceptionFailClosed = true
+	default:
+		return fmt.Errorf("invalid mac-command interception fail policy: %s", interceptConf.FailPolicy)
+	}
+
 	return nil
 }
 
@@ -191,6 +234,10 @@ type dataContext struct {
 	// downlinks.
 	DeviceGatewayRXInfo []storage.DeviceGatewayRXInfo
 
+	// FrequencyPlan holds the frequency-plan assigned to the gateway-profile
+	// of the gateway used for the downlink (if any).
+	FrequencyPlan *storage.FrequencyPlan
+
 	// MustSend defines if a frame must be send. In some cases (e.g. ADRACKReq)
 	// the network-server must respond, even when there are no mac-commands or
 	// FRMPayload.
@@ -332,7 +379,7 @@ func requestDevStatus(ctx *dataContext) error {
 	}
 
 	reqInterval := 24 * time.Hour / time.Duration(ctx.ServiceProfile.DevStatusReqFreq)
-	curInterval := time.Now().Sub(ctx.DeviceSession.LastDevStatusRequested)
+	curInterval := clock.Now().Sub(ctx.DeviceSession.LastDevStatusRequested)
 
 	if curInterval >= reqInterval {
 		ctx.MACCommands = append(ctx.MACCommands, maccommand.RequestDevStatus(ctx.ctx, &ctx.DeviceSession))
@@ -363,8 +410,15 @@ func setPingSlotParameters(ctx *dataContext) error {
 		return nil
 	}
 
-	if classBPingSlotDR != ctx.DeviceSession.PingSlotDR || classBPingSlotFrequency != ctx.DeviceSession.PingSlotFrequency {
-		block := maccommand.RequestPingSlotChannel(ctx.DeviceSession.DevEUI, classBPingSlotDR, classBPingSlotFrequency)
+	pingSlotDR := classBPingSlotDR
+	pingSlotFrequency := classBPingSlotFrequency
+	if ctx.FrequencyPlan != nil && ctx.FrequencyPlan.PingSlotFrequency != 0 {
+		pingSlotDR = ctx.FrequencyPlan.PingSlotDR
+		pingSlotFrequency = ctx.FrequencyPlan.PingSlotFrequency
+	}
+
+	if pingSlotDR != ctx.DeviceSession.PingSlotDR || pingSlotFrequency != ctx.DeviceSession.PingSlotFrequency {
+		block := maccommand.RequestPingSlotChannel(ctx.DeviceSession.DevEUI, pingSlotDR, pingSlotFrequency)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
@@ -372,8 +426,15 @@ func setPingSlotParameters(ctx *dataContext) error {
 }
 
 func setRXParameters(ctx *dataContext) error {
-	if ctx.DeviceSession.RX2Frequency != rx2Frequency || ctx.DeviceSession.RX2DR != uint8(rx2DR) || ctx.DeviceSession.RX1DROffset != uint8(rx1DROffset) {
-		block := maccommand.RequestRXParamSetup(rx1DROffset, rx2Frequency, rx2DR)
+	rx2Freq := rx2Frequency
+	rx2DataRate := rx2DR
+	if ctx.FrequencyPlan != nil && ctx.FrequencyPlan.RX2Frequency != 0 {
+		rx2Freq = ctx.FrequencyPlan.RX2Frequency
+		rx2DataRate = ctx.FrequencyPlan.RX2DR
+	}
+
+	if ctx.DeviceSession.RX2Frequency != rx2Freq || ctx.DeviceSession.RX2DR != uint8(rx2DataRate) || ctx.DeviceSession.RX1DROffset != uint8(rx1DROffset) {
+		block := maccommand.RequestRXParamSetup(rx1DROffset, rx2Freq, rx2DataRate)
 		ctx.MACCommands = append(ctx.MACCommands, block)
 	}
 
@@ -386,7 +447,7 @@ func setRXParameters(ctx *dataContext) error {
 }
 
 func setTXParameters(ctx *dataContext) error {
-	if !band.Band().ImplementsTXParamSetup(ctx.DeviceSession.MACVersion) {
+	if !band.Get(ctx.DeviceSession.Region).ImplementsTXParamSetup(ctx.DeviceSession.MACVersion) {
 		// band doesn't implement the TXParamSetup mac-command
 		return nil
 	}
@@ -469,30 +530,30 @@ func setTXInfoForRX1(ctx *dataContext) error {
 	}
 
 	// get rx1 data-rate
-	uplinkDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
+	uplinkDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate index error")
 	}
 
-	rx1DR, err := band.Band().GetRX1DataRateIndex(uplinkDR, int(ctx.DeviceSession.RX1DROffset))
+	rx1DR, err := band.Get(ctx.DeviceSession.Region).GetRX1DataRateIndex(uplinkDR, int(ctx.DeviceSession.RX1DROffset))
 	if err != nil {
 		return errors.Wrap(err, "get rx1 data-rate index error")
 	}
 
-	err = helpers.SetDownlinkTXInfoDataRate(&txInfo, rx1DR, band.Band())
+	err = helpers.SetDownlinkTXInfoDataRate(&txInfo, rx1DR, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
 
 	// get rx1 frequency
-	freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
+	freq, err := band.Get(ctx.DeviceSession.Region).GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
 	if err != nil {
 		return errors.Wrap(err, "get rx1 frequency error")
 	}
 	txInfo.Frequency = uint32(freq)
 
 	// get timestamp
-	delay := band.Band().GetDefaults().ReceiveDelay1
+	delay := band.Get(ctx.DeviceSession.Region).GetDefaults().ReceiveDelay1
 	if ctx.DeviceSession.RXDelay > 0 {
 		delay = time.Duration(ctx.DeviceSession.RXDelay) * time.Second
 	}
@@ -507,11 +568,12 @@ func setTXInfoForRX1(ctx *dataContext) error {
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, rx1DR)
+	plSize, err := band.GetForDwellTime(ctx.DeviceSession.Region, ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, rx1DR)
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -543,7 +605,7 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	}
 
 	// get data-rate
-	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, int(ctx.DeviceSession.RX2DR), band.Band())
+	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, int(ctx.DeviceSession.RX2DR), band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
@@ -552,12 +614,13 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	// get timestamp (when not tx immediately)
 	if !ctx.Immediately {
-		delay := band.Band().GetDefaults().ReceiveDelay2
+		delay := band.Get(ctx.DeviceSession.Region).GetDefaults().ReceiveDelay2
 		if ctx.DeviceSession.RXDelay > 0 {
 			delay = (time.Duration(ctx.DeviceSession.RXDelay) * time.Second) + time.Second
 		}
@@ -577,7 +640,7 @@ func setTXInfoForRX2(ctx *dataContext) error {
 	}
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.RX2DR))
+	plSize, err := band.GetForDwellTime(ctx.DeviceSession.Region, ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.RX2DR))
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -604,7 +667,7 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	}
 
 	// get data-rate
-	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.DeviceSession.PingSlotDR, band.Band())
+	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.DeviceSession.PingSlotDR, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
@@ -613,11 +676,12 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	// get remaining payload size
-	plSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.PingSlotDR))
+	plSize, err := band.GetForDwellTime(ctx.DeviceSession.Region, ctx.DeviceSession.DownlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex(ctx.DeviceProfile.MACVersion, ctx.DeviceProfile.RegParamsRevision, int(ctx.DeviceSession.PingSlotDR))
 	if err != nil {
 		return errors.Wrap(err, "get max-payload size error")
 	}
@@ -632,6 +696,63 @@ func setTXInfoForClassB(ctx *dataContext) error {
 	return nil
 }
 
+// setTXInfoForRetryGateways adds for each downlink-frame a copy for each of
+// the next-best gateways, so that on a negative TX acknowledgement or TX
+// acknowledgement timeout, the downlink can be retried using an other
+// gateway. The copies are added directly after the frame they are based on,
+// so that the frames remain ordered by transmission time (e.g. RX1 for all
+// gateways before RX2).
+func setTXInfoForRetryGateways(ctx *dataContext) error {
+	if retryGateways == 0 || len(ctx.DownlinkFrames) == 0 {
+		return nil
+	}
+
+	var rxInfos []storage.DeviceGatewayRXInfo
+	for _, rxInfo := range ctx.DeviceGatewayRXInfo[1:] {
+		if len(rxInfos) == retryGateways {
+			break
+		}
+
+		// the first gateway could be repeated in the slice (see
+		// smbReorderGateways)
+		if rxInfo.GatewayID == ctx.DeviceGatewayRXInfo[0].GatewayID {
+			continue
+		}
+		rxInfos = append(rxInfos, rxInfo)
+	}
+
+	var downlinkFrames []downlinkFrame
+	for _, df := range ctx.DownlinkFrames {
+		downlinkFrames = append(downlinkFrames, df)
+
+		for _, rxInfo := range rxInfos {
+			txInfo := *df.DownlinkFrame.TxInfo
+			txInfo.GatewayId = rxInfo.GatewayID[:]
+			txInfo.Board = rxInfo.Board
+			txInfo.Antenna = rxInfo.Antenna
+			txInfo.Context = rxInfo.Context
+
+			// get tx power
+			if downlinkTXPower != -1 {
+				txInfo.Power = int32(downlinkTXPower)
+			} else {
+				txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
+			}
+			txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
+
+			downlinkFrames = append(downlinkFrames, downlinkFrame{
+				DownlinkFrame: gw.DownlinkFrame{
+					TxInfo: &txInfo,
+				},
+				RemainingPayloadSize: df.RemainingPayloadSize,
+			})
+		}
+	}
+	ctx.DownlinkFrames = downlinkFrames
+
+	return nil
+}
+
 func getNextDeviceQueueItem(ctx *dataContext) error {
 	var fCnt uint32
 	if ctx.DeviceSession.GetMACVersion() == lorawan.LoRaWAN1_0 {
@@ -647,16 +768,36 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		remainingPayloadSize = ctx.DownlinkFrames[0].RemainingPayloadSize
 	}
 
-	qi, err := storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx.ctx, storage.DB(), ctx.DeviceSession.DevEUI, remainingPayloadSize, fCnt, ctx.DeviceSession.RoutingProfileID)
-	if err != nil {
-		if errors.Cause(err) == storage.ErrDoesNotExist {
+	var qi storage.DeviceQueueItem
+	var data []byte
+	for {
+		var err error
+		qi, err = storage.GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx.ctx, storage.DB(), ctx.DeviceSession.DevEUI, remainingPayloadSize, fCnt, ctx.DeviceSession.RoutingProfileID)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				return nil
+			}
+			return errors.Wrap(err, "get next device-queue item for max payload error")
+		}
+
+		var action downlinkHookAction
+		data, action, err = applyDownlinkHook(ctx, qi, remainingPayloadSize)
+		if err != nil {
+			return errors.Wrap(err, "apply downlink hook error")
+		}
+
+		if action == downlinkHookHold {
 			return nil
 		}
-		return errors.Wrap(err, "get next device-queue item for max payload error")
+		if action == downlinkHookDiscard {
+			// try next item
+			continue
+		}
+		break
 	}
 
 	ctx.Confirmed = qi.Confirmed
-	ctx.Data = qi.FRMPayload
+	ctx.Data = data
 	ctx.FPort = qi.FPort
 
 	for i := range ctx.DownlinkFrames {
@@ -690,7 +831,7 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 
 		if ctx.DeviceSession.PingSlotFrequency == 0 {
 			beaconTime := *qi.EmitAtTimeSinceGPSEpoch - (*qi.EmitAtTimeSinceGPSEpoch % (128 * time.Second))
-			freq, err := band.Band().GetPingSlotFrequency(ctx.DeviceSession.DevAddr, beaconTime)
+			freq, err := band.Get(ctx.DeviceSession.Region).GetPingSlotFrequency(ctx.DeviceSession.DevAddr, beaconTime)
 			if err != nil {
 				return errors.Wrap(err, "get ping-slot frequency error")
 			}
@@ -709,12 +850,17 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 		ctx.DeviceSession.ConfFCnt = qi.FCnt
 
 		// mark as pending and set timeout
-		timeout := time.Now()
+		timeout := clock.Now()
 		if ctx.DeviceProfile.SupportsClassC {
 			timeout = timeout.Add(time.Duration(ctx.DeviceProfile.ClassCTimeout) * time.Second)
 		}
 		qi.IsPending = true
 
+		// keep track of the transmissions for the delivery receipt
+		transmittedAt := clock.Now()
+		qi.TXCount++
+		qi.TransmittedAt = &transmittedAt
+
 		// in case of class-b it is already set, we don't want to overwrite it
 		if qi.TimeoutAfter == nil {
 			qi.TimeoutAfter = &timeout
@@ -728,6 +874,91 @@ func getNextDeviceQueueItem(ctx *dataContext) error {
 	return nil
 }
 
+// downlinkHookAction defines the action to take on a device-queue item after
+// invoking the downlink hook.
+type downlinkHookAction int
+
+const (
+	// downlinkHookSend sends the (modified) device-queue item.
+	downlinkHookSend downlinkHookAction = iota
+
+	// downlinkHookHold keeps the device-queue item in the queue, without
+	// sending it.
+	downlinkHookHold
+
+	// downlinkHookDiscard indicates the device-queue item has been removed
+	// from the queue.
+	downlinkHookDiscard
+)
+
+// applyDownlinkHook invokes the downlink hook of the routing-profile (if
+// configured) for the given device-queue item and returns the payload to
+// send. The payload stored in the device-queue is not modified, so that the
+// hook is invoked again on a re-transmission of a confirmed item.
+func applyDownlinkHook(ctx *dataContext, qi storage.DeviceQueueItem, maxPayloadSize int) ([]byte, downlinkHookAction, error) {
+	rp, err := storage.GetAndCacheRoutingProfile(ctx.ctx, storage.DB(), storage.RedisPool(), ctx.DeviceSession.RoutingProfileID)
+	if err != nil {
+		return nil, downlinkHookHold, errors.Wrap(err, "get routing-profile error")
+	}
+
+	if rp.DownlinkHookURL == "" {
+		return qi.FRMPayload, downlinkHookSend, nil
+	}
+
+	logFields := log.Fields{
+		"dev_eui":                qi.DevEUI,
+		"device_queue_item_fcnt": qi.FCnt,
+		"ctx_id":                 ctx.ctx.Value(logging.ContextIDKey),
+	}
+
+	resp, err := downlinkhook.Invoke(ctx.ctx, downlinkhook.Endpoint{
+		URL:    rp.DownlinkHookURL,
+		Secret: rp.DownlinkHookSecret,
+	}, downlinkhook.Request{
+		DevEUI:         qi.DevEUI,
+		DevAddr:        ctx.DeviceSession.DevAddr,
+		FCnt:           qi.FCnt,
+		FPort:          qi.FPort,
+		Confirmed:      qi.Confirmed,
+		FRMPayload:     qi.FRMPayload,
+		MaxPayloadSize: maxPayloadSize,
+	})
+	if err != nil {
+		if downlinkhook.FailClosed() {
+			log.WithError(err).WithFields(logFields).Error("downlink hook error, holding back device-queue item")
+			return nil, downlinkHookHold, nil
+		}
+
+		log.WithError(err).WithFields(logFields).Error("downlink hook error, sending device-queue item unmodified")
+		return qi.FRMPayload, downlinkHookSend, nil
+	}
+
+	if resp.Veto {
+		errStr := "vetoed by downlink hook"
+		if resp.Reason != "" {
+			errStr = errStr + ": " + resp.Reason
+		}
+
+		if err := storage.DiscardDeviceQueueItem(ctx.ctx, storage.DB(), qi, ctx.DeviceSession.RoutingProfileID, as.ErrorType_DEVICE_QUEUE_ITEM_VETOED, errStr); err != nil {
+			return nil, downlinkHookDiscard, errors.Wrap(err, "discard device-queue item error")
+		}
+		return nil, downlinkHookDiscard, nil
+	}
+
+	if resp.FRMPayload == nil {
+		return qi.FRMPayload, downlinkHookSend, nil
+	}
+
+	if len(resp.FRMPayload) > maxPayloadSize {
+		if err := storage.DiscardDeviceQueueItem(ctx.ctx, storage.DB(), qi, ctx.DeviceSession.RoutingProfileID, as.ErrorType_DEVICE_QUEUE_ITEM_SIZE, "payload modified by downlink hook exceeds max payload size"); err != nil {
+			return nil, downlinkHookDiscard, errors.Wrap(err, "discard device-queue item error")
+		}
+		return nil, downlinkHookDiscard, nil
+	}
+
+	return resp.FRMPayload, downlinkHookSend, nil
+}
+
 func filterIncompatibleMACCommands(macCommands []storage.MACCommandBlock) []storage.MACCommandBlock {
 	for _, mapping := range incompatibleMACCommands {
 		var seen bool
@@ -791,6 +1022,12 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 			remainingMACCommandSize = remainingPayloadSize
 		}
 
+		if macCommandInterceptionEnabled {
+			if err := interceptMACCommands(ctx, remainingMACCommandSize); err != nil {
+				return err
+			}
+		}
+
 		for i, block := range ctx.MACCommands {
 			macSize, err := block.Size()
 			if err != nil {
@@ -814,8 +1051,9 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 			}
 
 			// delete from queue, if external
+			// (mac-commands injected by the network-controller are not in the queue)
 			if block.External {
-				if err := storage.DeleteMACCommandQueueItem(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, block); err != nil {
+				if err := storage.DeleteMACCommandQueueItem(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, block); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
 					return errors.Wrap(err, "delete mac-command block from queue error")
 				}
 			}
@@ -825,10 +1063,108 @@ func setMACCommands(funcs ...func(*dataContext) error) func(*dataContext) error
 	}
 }
 
+// interceptMACCommands sends the mac-commands to the network-controller,
+// which responds with the mac-commands to send. In case of an error or
+// timeout, the configured fail policy is applied.
+func interceptMACCommands(ctx *dataContext, maxSize int) error {
+	req := nc.HandleDownlinkMACCommandsRequest{
+		DevEui:  ctx.DeviceSession.DevEUI[:],
+		MaxSize: uint32(maxSize),
+	}
+
+	for _, block := range ctx.MACCommands {
+		ncBlock := nc.MACCommandBlock{
+			Cid: uint32(block.CID),
+		}
+
+		for _, mac := range block.MACCommands {
+			b, err := mac.MarshalBinary()
+			if err != nil {
+				return errors.Wrap(err, "marshal mac-command error")
+			}
+			ncBlock.Commands = append(ncBlock.Commands, b)
+		}
+
+		req.MacCommandBlocks = append(req.MacCommandBlocks, &ncBlock)
+	}
+
+	blocks, err := func() ([]storage.MACCommandBlock, error) {
+		reqCtx, cancel := context.WithTimeout(ctx.ctx, macCommandInterceptionTimeout)
+		defer cancel()
+
+		resp, err := controller.HandleDownlinkMACCommands(reqCtx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		var out []storage.MACCommandBlock
+		used := make(map[int]bool)
+
+	RespLoop:
+		for _, ncBlock := range resp.MacCommandBlocks {
+			// re-use the original block when it is unmodified
+			for i := range req.MacCommandBlocks {
+				if !used[i] && proto.Equal(ncBlock, req.MacCommandBlocks[i]) {
+					used[i] = true
+					out = append(out, ctx.MACCommands[i])
+					continue RespLoop
+				}
+			}
+
+			// the block has been modified or injected by the network-controller
+			block := storage.MACCommandBlock{
+				CID:      lorawan.CID(ncBlock.Cid),
+				External: true,
+			}
+
+			for _, b := range ncBlock.Commands {
+				var mac lorawan.MACCommand
+				if err := mac.UnmarshalBinary(false, b); err != nil {
+					return nil, errors.Wrap(err, "unmarshal mac-command error")
+				}
+				block.MACCommands = append(block.MACCommands, mac)
+			}
+
+			out = append(out, block)
+		}
+
+		// Remove the vetoed or modified mac-commands from the queue, else
+		// these would be proposed again on the next downlink.
+		for i, block := range ctx.MACCommands {
+			if used[i] || !block.External {
+				continue
+			}
+
+			if err := storage.DeleteMACCommandQueueItem(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, block); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+				return nil, errors.Wrap(err, "delete mac-command block from queue error")
+			}
+		}
+
+		return out, nil
+	}()
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui":     ctx.DeviceSession.DevEUI,
+			"fail_closed": macCommandInterceptionFailClosed,
+			"ctx_id":      ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("intercept mac-commands by network-controller error")
+
+		if macCommandInterceptionFailClosed {
+			ctx.MACCommands = nil
+		}
+
+		return nil
+	}
+
+	ctx.MACCommands = blocks
+
+	return nil
+}
+
 func requestCustomChannelReconfiguration(ctx *dataContext) error {
 	wantedChannels := make(map[int]loraband.Channel)
-	for _, i := range band.Band().GetCustomUplinkChannelIndices() {
-		c, err := band.Band().GetUplinkChannel(i)
+	for _, i := range band.Get(ctx.DeviceSession.Region).GetCustomUplinkChannelIndices() {
+		c, err := band.Get(ctx.DeviceSession.Region).GetUplinkChannel(i)
 		if err != nil {
 			return errors.Wrap(err, "get uplink channel error")
 		}
@@ -868,6 +1204,12 @@ func requestChannelMaskReconfiguration(ctx *dataContext) error {
 }
 
 func requestADRChange(ctx *dataContext) error {
+	// in certification test mode, the data-rate and TX power are controlled
+	// by the certification test tool
+	if ctx.DeviceSession.CertificationTestMode {
+		return nil
+	}
+
 	var linkADRReq *storage.MACCommandBlock
 	for i := range ctx.MACCommands {
 		if ctx.MACCommands[i].CID == lorawan.LinkADRReq {
@@ -1029,16 +1371,60 @@ func sendDownlinkFrame(ctx *dataContext) error {
 		return nil
 	}
 
+	// the accounting record is created on the tx acknowledgement, it must be
+	// stored before sending as the acknowledgement could arrive first
+	if err := accounting.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink accounting record error")
+	}
+	if err := contribution.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink gateway contribution error")
+	}
+	if err := storage.SetDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement pending error")
+	}
+	if err := ack.SetTXAckTimeout(ctx.ctx, helpers.GetGatewayID(ctx.DownlinkFrames[0].DownlinkFrame.TxInfo), uint16(ctx.DownlinkFrames[0].DownlinkFrame.Token)); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement timeout error")
+	}
+
 	// send the packet to the gateway
 	if err := gateway.Backend().SendTXPacket(ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
 		return errors.Wrap(err, "send downlink-frame to gateway error")
 	}
 
+	// check the latency budget of the Class-A response
+	if ctx.RXPacket != nil {
+		rx1Delay := band.Get(ctx.DeviceSession.Region).GetDefaults().ReceiveDelay1
+		if ctx.DeviceSession.RXDelay > 0 {
+			rx1Delay = time.Duration(ctx.DeviceSession.RXDelay) * time.Second
+		}
+		latency.CheckDownlink(ctx.ctx, latency.FlowData, rx1Delay)
+	}
+
 	// set last downlink tx timestamp
-	ctx.DeviceSession.LastDownlinkTX = time.Now()
+	ctx.DeviceSession.LastDownlinkTX = clock.Now()
+
+	redaction := framelog.GetRedaction(ctx.ServiceProfile)
 
 	// log for gateway (with encrypted mac-commands)
-	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0].DownlinkFrame); err != nil {
+	if err := func() error {
+		frame, err := redaction.RedactDownlinkFrame(ctx.DownlinkFrames[0].DownlinkFrame)
+		if err != nil {
+			return errors.Wrap(err, "redact downlink frame error")
+		}
+		return framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), frame)
+	}(); err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"ctx_id": ctx.ctx.Value(logging.ContextIDKey),
 		}).Error("log downlink frame for gateway error")
@@ -1070,12 +1456,17 @@ func sendDownlinkFrame(ctx *dataContext) error {
 			return err
 		}
 
-		// log frame
-		if err := framelog.LogDownlinkFrameForDevEUI(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, gw.DownlinkFrame{
+		frame, err := redaction.RedactDownlinkFrame(gw.DownlinkFrame{
 			Token:      uint32(ctx.DownlinkFrames[0].DownlinkFrame.Token),
 			TxInfo:     ctx.DownlinkFrames[0].DownlinkFrame.TxInfo,
 			PhyPayload: phyB,
-		}); err != nil {
+		})
+		if err != nil {
+			return errors.Wrap(err, "redact downlink frame error")
+		}
+
+		// log frame
+		if err := framelog.LogDownlinkFrameForDevEUI(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, frame); err != nil {
 			return err
 		}
 
@@ -1114,6 +1505,22 @@ func getServiceProfile(ctx *dataContext) error {
 	return nil
 }
 
+// checkDownlinkEnabled aborts when downlinks are disabled by the
+// service-profile.
+func checkDownlinkEnabled(ctx *dataContext) error {
+	if !ctx.ServiceProfile.DownlinkDisabled {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            ctx.DeviceSession.DevEUI,
+		"service_profile_id": ctx.ServiceProfile.ID,
+		"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+	}).Debug("downlink/data: downlinks are disabled by the service-profile")
+
+	return ErrAbort
+}
+
 func setDeviceGatewayRXInfo(ctx *dataContext) error {
 	if ctx.RXPacket != nil {
 		// Class-A response.
@@ -1137,19 +1544,52 @@ func setDeviceGatewayRXInfo(ctx *dataContext) error {
 		ctx.DeviceGatewayRXInfo = rxInfo.Items
 	}
 
+	// the stored rx-info set could contain gateways which are no longer
+	// allowed by the service-profile
+	ctx.DeviceGatewayRXInfo = isolation.FilterDeviceGatewayRXInfo(ctx.ctx, ctx.ServiceProfile, ctx.DeviceGatewayRXInfo)
+
 	if len(ctx.DeviceGatewayRXInfo) == 0 {
 		return errors.New("DeviceGatewayRXInfo, the device needs to send an uplink first")
 	}
 
+	// gateways in maintenance drain mode must not be used for new downlinks
+	rxInfo, err := storage.FilterDrainingGateways(ctx.ctx, storage.RedisPool(), ctx.DeviceGatewayRXInfo)
+	if err != nil {
+		return errors.Wrap(err, "filter draining gateways error")
+	}
+	if len(rxInfo) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Info("downlink/data: all gateways are draining, skipping downlink")
+		return ErrAbort
+	}
+
+	// remove the gateways violating the scheduling constraints of their
+	// model, Class-B downlinks require GPS timing
+	gpsTiming := ctx.RXPacket == nil && ctx.DeviceMode == storage.DeviceModeB
+	rxInfo, err = constraint.FilterDeviceGatewayRXInfo(ctx.ctx, storage.DB(), storage.RedisPool(), rxInfo, gpsTiming)
+	if err != nil {
+		return errors.Wrap(err, "filter gateway constraints error")
+	}
+	if len(rxInfo) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Info("downlink/data: all gateways are excluded by their scheduling constraints, skipping downlink")
+		return ErrAbort
+	}
+	ctx.DeviceGatewayRXInfo = rxInfo
+
 	return nil
 }
 
 func checkLastDownlinkTimestamp(ctx *dataContext) error {
 	// in case of Class-C validate that between now and the last downlink
 	// tx timestamp is at least the class-c lock duration
-	if ctx.DeviceProfile.SupportsClassC && time.Now().Sub(ctx.DeviceSession.LastDownlinkTX) < classCDownlinkLockDuration {
+	if ctx.DeviceProfile.SupportsClassC && clock.Now().Sub(ctx.DeviceSession.LastDownlinkTX) < classCDownlinkLockDuration {
 		log.WithFields(log.Fields{
-			"time":                           time.Now(),
+			"time":                           clock.Now(),
 			"last_downlink_tx_time":          ctx.DeviceSession.LastDownlinkTX,
 			"class_c_downlink_lock_duration": classCDownlinkLockDuration,
 			"ctx_id":                         ctx.ctx.Value(logging.ContextIDKey),
@@ -1160,6 +1600,48 @@ func checkLastDownlinkTimestamp(ctx *dataContext) error {
 	return nil
 }
 
+// checkLBTDeferralMargin extends the Class-C downlink lock with the
+// listen-before-talk scan time of the gateway used for the downlink, as the
+// gateway might defer the previous transmission by this duration.
+func checkLBTDeferralMargin(ctx *dataContext) error {
+	if !ctx.DeviceProfile.SupportsClassC {
+		return nil
+	}
+
+	scanTime, err := storage.GetLBTScanTimeForGateway(ctx.ctx, storage.DB(), ctx.DeviceGatewayRXInfo[0].GatewayID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get lbt scan-time for gateway error")
+	}
+
+	if scanTime != 0 && clock.Now().Sub(ctx.DeviceSession.LastDownlinkTX) < classCDownlinkLockDuration+scanTime {
+		log.WithFields(log.Fields{
+			"dev_eui":       ctx.DeviceSession.DevEUI,
+			"lbt_scan_time": scanTime,
+			"ctx_id":        ctx.ctx.Value(logging.ContextIDKey),
+		}).Debug("skip next downlink queue scheduling due to lbt deferral margin")
+		return ErrAbort
+	}
+
+	return nil
+}
+
+// getFrequencyPlan retrieves the frequency-plan assigned to the
+// gateway-profile of the gateway used for the downlink. Its RX2 and ping-slot
+// settings take precedence over the network-server settings.
+func getFrequencyPlan(ctx *dataContext) error {
+	fp, err := storage.GetFrequencyPlanForGateway(ctx.ctx, storage.DB(), ctx.DeviceGatewayRXInfo[0].GatewayID)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get frequency-plan for gateway error")
+	}
+
+	ctx.FrequencyPlan = &fp
+
+	return nil
+}
+
 func saveRemainingFrames(ctx *dataContext) error {
 	if len(ctx.DownlinkFrames) < 2 {
 		return nil
@@ -1186,6 +1668,20 @@ func returnInvalidDeviceClassError(ctx *dataContext) error {
 	return errors.New("the device is in an invalid device-class for this action")
 }
 
+// recordDeviceClassDownlink updates the device-class statistics, used for
+// detecting devices which do not behave according to their configured
+// device-class.
+func recordDeviceClassDownlink(ctx *dataContext) error {
+	if err := storage.RecordDeviceClassDownlink(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.DeviceMode, ctx.Confirmed, ctx.DeviceSession.ConfFCnt); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("record device-class downlink error")
+	}
+
+	return nil
+}
+
 func smbDlSent(ctx *dataContext) error {
 
 	dlPkt := m2m_api.DlPkt{
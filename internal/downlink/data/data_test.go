@@ -3,15 +3,21 @@ package data
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/api/nc"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/controller"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
@@ -1024,3 +1030,304 @@ func TestSetTXParameters(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkHandleResponse(b *testing.B) {
+	if err := band.Setup(test.GetConfig()); err != nil {
+		b.Fatal(err)
+	}
+
+	// the tx power is set so that the tasks don't hit the storage
+	defer func(txPower, window int) {
+		downlinkTXPower = txPower
+		rxWindow = window
+	}(downlinkTXPower, rxWindow)
+	downlinkTXPower = 14
+	rxWindow = 0
+
+	tasks := []func(*dataContext) error{
+		setDataTXInfo,
+		setToken,
+		setPHYPayloads,
+	}
+
+	rxPacket := models.RXPacket{
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+	}
+	if err := helpers.SetUplinkTXInfoDataRate(rxPacket.TXInfo, 5, band.Band()); err != nil {
+		b.Fatal(err)
+	}
+
+	ds := storage.DeviceSession{
+		MACVersion:   "1.0.3",
+		DevAddr:      lorawan.DevAddr{1, 2, 3, 4},
+		FCntUp:       10,
+		RX2Frequency: 869525000,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ctx := dataContext{
+			ctx:           context.Background(),
+			DeviceSession: ds,
+			DeviceGatewayRXInfo: []storage.DeviceGatewayRXInfo{
+				{GatewayID: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+			},
+			RXPacket: &rxPacket,
+			ACK:      true,
+			FPort:    10,
+			Data:     []byte{1, 2, 3, 4},
+			MACCommands: []storage.MACCommandBlock{
+				{
+					CID: lorawan.DevStatusReq,
+					MACCommands: []lorawan.MACCommand{
+						{CID: lorawan.DevStatusReq},
+					},
+				},
+			},
+		}
+
+		for _, t := range tasks {
+			if err := t(&ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+type InterceptMACCommandsTestSuite struct {
+	suite.Suite
+
+	NCClient *test.NetworkControllerClient
+}
+
+func (ts *InterceptMACCommandsTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(band.Setup(conf))
+
+	ts.NCClient = test.NewNetworkControllerClient()
+	controller.SetClient(ts.NCClient)
+}
+
+func (ts *InterceptMACCommandsTestSuite) TearDownSuite() {
+	assert := require.New(ts.T())
+	assert.NoError(Setup(test.GetConfig()))
+	controller.SetClient(&controller.NopNetworkControllerClient{})
+}
+
+func (ts *InterceptMACCommandsTestSuite) TestInterceptMACCommands() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	linkADRReq := func(dr uint8) lorawan.MACCommand {
+		return lorawan.MACCommand{
+			CID: lorawan.LinkADRReq,
+			Payload: &lorawan.LinkADRReqPayload{
+				DataRate: dr,
+				TXPower:  2,
+				ChMask:   [16]bool{true, true, true},
+				Redundancy: lorawan.Redundancy{
+					NbRep: 1,
+				},
+			},
+		}
+	}
+
+	linkADRReqBytes := func(dr uint8) []byte {
+		b, err := linkADRReq(dr).MarshalBinary()
+		if err != nil {
+			panic(err)
+		}
+		return b
+	}
+
+	devStatusReq := storage.MACCommandBlock{
+		CID: lorawan.DevStatusReq,
+		MACCommands: storage.MACCommands{
+			{CID: lorawan.DevStatusReq},
+		},
+	}
+
+	// this block is in the mac-command queue
+	externalLinkADRReq := storage.MACCommandBlock{
+		CID:      lorawan.LinkADRReq,
+		External: true,
+		MACCommands: storage.MACCommands{
+			linkADRReq(3),
+		},
+	}
+
+	passFunc := func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+		return &nc.HandleDownlinkMACCommandsResponse{
+			MacCommandBlocks: req.MacCommandBlocks,
+		}
+	}
+
+	tests := []struct {
+		Name                string
+		FailPolicy          string
+		ResponseFunc        func(nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse
+		MACCommands         []storage.MACCommandBlock
+		ExpectedRequest     nc.HandleDownlinkMACCommandsRequest
+		ExpectedMACCommands []storage.MACCommandBlock
+		ExpectedQueue       []storage.MACCommandBlock
+	}{
+		{
+			Name:         "mac-commands pass unmodified",
+			ResponseFunc: passFunc,
+			MACCommands:  []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedRequest: nc.HandleDownlinkMACCommandsRequest{
+				DevEui:  devEUI[:],
+				MaxSize: 15,
+				MacCommandBlocks: []*nc.MACCommandBlock{
+					{Cid: uint32(lorawan.DevStatusReq), Commands: [][]byte{{byte(lorawan.DevStatusReq)}}},
+					{Cid: uint32(lorawan.LinkADRReq), Commands: [][]byte{linkADRReqBytes(3)}},
+				},
+			},
+			ExpectedMACCommands: []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedQueue:       []storage.MACCommandBlock{externalLinkADRReq},
+		},
+		{
+			Name: "mac-command modified",
+			ResponseFunc: func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+				return &nc.HandleDownlinkMACCommandsResponse{
+					MacCommandBlocks: []*nc.MACCommandBlock{
+						req.MacCommandBlocks[0],
+						{Cid: uint32(lorawan.LinkADRReq), Commands: [][]byte{linkADRReqBytes(5)}},
+					},
+				}
+			},
+			MACCommands: []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedMACCommands: []storage.MACCommandBlock{
+				devStatusReq,
+				{
+					CID:      lorawan.LinkADRReq,
+					External: true,
+					MACCommands: storage.MACCommands{
+						linkADRReq(5),
+					},
+				},
+			},
+		},
+		{
+			Name: "mac-commands dropped",
+			ResponseFunc: func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+				return &nc.HandleDownlinkMACCommandsResponse{}
+			},
+			MACCommands: []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+		},
+		{
+			Name:                "timeout with fail-open policy",
+			FailPolicy:          "open",
+			MACCommands:         []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedMACCommands: []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedQueue:       []storage.MACCommandBlock{externalLinkADRReq},
+		},
+		{
+			Name:          "timeout with fail-closed policy",
+			FailPolicy:    "closed",
+			MACCommands:   []storage.MACCommandBlock{devStatusReq, externalLinkADRReq},
+			ExpectedQueue: []storage.MACCommandBlock{externalLinkADRReq},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			conf := test.GetConfig()
+			conf.NetworkController.MACCommandInterception.Enabled = true
+			conf.NetworkController.MACCommandInterception.Timeout = 10 * time.Millisecond
+			conf.NetworkController.MACCommandInterception.FailPolicy = tst.FailPolicy
+			assert.NoError(Setup(conf))
+
+			test.MustFlushRedis(storage.RedisPool())
+			assert.NoError(storage.CreateMACCommandQueueItem(context.Background(), storage.RedisPool(), devEUI, externalLinkADRReq))
+
+			ts.NCClient.HandleDownlinkMACCommandsFunc = func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+				if tst.ResponseFunc == nil {
+					return nil
+				}
+				return tst.ResponseFunc(req)
+			}
+
+			ctx := dataContext{
+				ctx: context.Background(),
+				DeviceSession: storage.DeviceSession{
+					DevEUI: devEUI,
+				},
+				MACCommands: tst.MACCommands,
+			}
+
+			assert.NoError(interceptMACCommands(&ctx, 15))
+			assert.Equal(tst.ExpectedMACCommands, ctx.MACCommands)
+
+			req := <-ts.NCClient.HandleDownlinkMACCommandsChan
+			if tst.ExpectedRequest.DevEui != nil {
+				tst.ExpectedRequest.Id = req.Id
+				assert.Equal(tst.ExpectedRequest, req)
+			}
+
+			queue, err := storage.GetMACCommandQueueItems(context.Background(), storage.RedisPool(), devEUI)
+			assert.NoError(err)
+			assert.Equal(tst.ExpectedQueue, queue)
+		})
+	}
+
+	ts.T().Run("max-size truncation", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf := test.GetConfig()
+		conf.NetworkController.MACCommandInterception.Enabled = true
+		conf.NetworkController.MACCommandInterception.Timeout = time.Second
+		assert.NoError(Setup(conf))
+
+		test.MustFlushRedis(storage.RedisPool())
+
+		// the network-controller injects 20 bytes of mac-commands, but
+		// only 15 bytes fit in the FOpts field
+		ts.NCClient.HandleDownlinkMACCommandsFunc = func(req nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse {
+			var resp nc.HandleDownlinkMACCommandsResponse
+			for i := 0; i < 4; i++ {
+				resp.MacCommandBlocks = append(resp.MacCommandBlocks, &nc.MACCommandBlock{
+					Cid:      uint32(lorawan.LinkADRReq),
+					Commands: [][]byte{linkADRReqBytes(uint8(i))},
+				})
+			}
+			return &resp
+		}
+
+		ctx := dataContext{
+			ctx:   context.Background(),
+			FPort: 10,
+			DeviceSession: storage.DeviceSession{
+				DevEUI: devEUI,
+			},
+			DownlinkFrames: []downlinkFrame{
+				{
+					RemainingPayloadSize: 200,
+				},
+			},
+		}
+
+		assert.NoError(setMACCommands()(&ctx))
+
+		req := <-ts.NCClient.HandleDownlinkMACCommandsChan
+		assert.EqualValues(15, req.MaxSize)
+
+		assert.True(ctx.MoreData)
+		assert.Len(ctx.MACCommands, 3)
+		for i, block := range ctx.MACCommands {
+			assert.True(block.External)
+			assert.Equal(storage.MACCommands{linkADRReq(uint8(i))}, block.MACCommands)
+		}
+	})
+}
+
+func TestInterceptMACCommands(t *testing.T) {
+	suite.Run(t, new(InterceptMACCommandsTestSuite))
+}
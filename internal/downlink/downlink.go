@@ -6,8 +6,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/join"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/latency"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/proprietary"
 )
@@ -22,6 +24,10 @@ func Setup(conf config.Config) error {
 	nsConfig := conf.NetworkServer
 	schedulerInterval = nsConfig.Scheduler.SchedulerInterval
 
+	if err := ack.Setup(conf); err != nil {
+		return errors.Wrap(err, "setup downlink/ack error")
+	}
+
 	if err := data.Setup(conf); err != nil {
 		return errors.Wrap(err, "setup downlink/data error")
 	}
@@ -38,5 +44,9 @@ func Setup(conf config.Config) error {
 		return errors.Wrap(err, "setup downlink/proprietary error")
 	}
 
+	if err := latency.Setup(conf); err != nil {
+		return errors.Wrap(err, "setup downlink/latency error")
+	}
+
 	return nil
 }
@@ -13,11 +13,16 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	m2m_api "github.com/mxc-foundation/lpwan-server/api/m2m_server"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/latency"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/mxc_smb"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/constraint"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
@@ -29,12 +34,15 @@ import (
 var (
 	rxWindow        int
 	downlinkTXPower int
+	retryGateways   int
 )
 
 var tasks = []func(*joinContext) error{
+	getServiceProfile,
 	setDeviceGatewayRXInfo,
 	smbReorderGateways,
 	setTXInfo,
+	setTXInfoForRetryGateways,
 	setToken,
 	setDownlinkFrame,
 	sendJoinAcceptResponse,
@@ -47,6 +55,7 @@ type joinContext struct {
 
 	Token               uint16
 	DeviceSession       storage.DeviceSession
+	ServiceProfile      storage.ServiceProfile
 	DeviceGatewayRXInfo []storage.DeviceGatewayRXInfo
 	RXPacket            models.RXPacket
 	PHYPayload          lorawan.PHYPayload
@@ -63,6 +72,7 @@ func Setup(conf config.Config) error {
 	nsConfig := conf.NetworkServer.NetworkSettings
 	rxWindow = nsConfig.RXWindow
 	downlinkTXPower = nsConfig.DownlinkTXPower
+	retryGateways = conf.NetworkServer.Scheduler.TXAck.RetryGateways
 
 	return nil
 }
@@ -85,6 +95,20 @@ func Handle(ctx context.Context, ds storage.DeviceSession, rxPacket models.RXPac
 	return nil
 }
 
+func getServiceProfile(ctx *joinContext) error {
+	var err error
+	ctx.ServiceProfile, err = storage.GetAndCacheServiceProfile(ctx.ctx, storage.DB(), storage.RedisPool(), ctx.DeviceSession.ServiceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get service-profile error")
+	}
+
+	if ctx.ServiceProfile.DownlinkDisabled {
+		return storage.ErrDownlinkDisabled
+	}
+
+	return nil
+}
+
 func setDeviceGatewayRXInfo(ctx *joinContext) error {
 	for i := range ctx.RXPacket.RXInfoSet {
 		ctx.DeviceGatewayRXInfo = append(ctx.DeviceGatewayRXInfo, storage.DeviceGatewayRXInfo{
@@ -102,6 +126,26 @@ func setDeviceGatewayRXInfo(ctx *joinContext) error {
 		return errors.New("DeviceGatewayRXInfo is empty!")
 	}
 
+	// gateways in maintenance drain mode must not be used for new downlinks
+	rxInfo, err := storage.FilterDrainingGateways(ctx.ctx, storage.RedisPool(), ctx.DeviceGatewayRXInfo)
+	if err != nil {
+		return errors.Wrap(err, "filter draining gateways error")
+	}
+	if len(rxInfo) == 0 {
+		return errors.New("all gateways receiving the join-request are draining")
+	}
+
+	// remove the gateways violating the scheduling constraints of their
+	// model
+	rxInfo, err = constraint.FilterDeviceGatewayRXInfo(ctx.ctx, storage.DB(), storage.RedisPool(), rxInfo, false)
+	if err != nil {
+		return errors.Wrap(err, "filter gateway constraints error")
+	}
+	if len(rxInfo) == 0 {
+		return errors.New("all gateways receiving the join-request are excluded by their scheduling constraints")
+	}
+	ctx.DeviceGatewayRXInfo = rxInfo
+
 	return nil
 }
 
@@ -163,19 +207,19 @@ func setTXInfoForRX1(ctx *joinContext) error {
 	}
 
 	// get RX1 data-rate
-	rx1DR, err := band.Band().GetRX1DataRateIndex(ctx.RXPacket.DR, 0)
+	rx1DR, err := band.Get(ctx.DeviceSession.Region).GetRX1DataRateIndex(ctx.RXPacket.DR, 0)
 	if err != nil {
 		return errors.Wrap(err, "get rx1 data-rate index error")
 	}
 
 	// set data-rate
-	err = helpers.SetDownlinkTXInfoDataRate(&txInfo, rx1DR, band.Band())
+	err = helpers.SetDownlinkTXInfoDataRate(&txInfo, rx1DR, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
 
 	// set frequency
-	freq, err := band.Band().GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
+	freq, err := band.Get(ctx.DeviceSession.Region).GetRX1FrequencyForUplinkFrequency(int(ctx.RXPacket.TXInfo.Frequency))
 	if err != nil {
 		return errors.Wrap(err, "get rx1 frequency error")
 	}
@@ -185,14 +229,15 @@ func setTXInfoForRX1(ctx *joinContext) error {
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	// set timestamp
 	txInfo.Timing = gw.DownlinkTiming_DELAY
 	txInfo.TimingInfo = &gw.DownlinkTXInfo_DelayTimingInfo{
 		DelayTimingInfo: &gw.DelayTimingInfo{
-			Delay: ptypes.DurationProto(band.Band().GetDefaults().JoinAcceptDelay1),
+			Delay: ptypes.DurationProto(band.Get(ctx.DeviceSession.Region).GetDefaults().JoinAcceptDelay1),
 		},
 	}
 
@@ -209,12 +254,12 @@ func setTXInfoForRX2(ctx *joinContext) error {
 		GatewayId: rxInfo.GatewayID[:],
 		Board:     rxInfo.Board,
 		Antenna:   rxInfo.Antenna,
-		Frequency: uint32(band.Band().GetDefaults().RX2Frequency),
+		Frequency: uint32(band.Get(ctx.DeviceSession.Region).GetDefaults().RX2Frequency),
 		Context:   rxInfo.Context,
 	}
 
 	// set data-rate
-	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, band.Band().GetDefaults().RX2DataRate, band.Band())
+	err := helpers.SetDownlinkTXInfoDataRate(&txInfo, band.Get(ctx.DeviceSession.Region).GetDefaults().RX2DataRate, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "set downlink tx-info data-rate error")
 	}
@@ -223,14 +268,15 @@ func setTXInfoForRX2(ctx *joinContext) error {
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(int(txInfo.Frequency)))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	// set timestamp
 	txInfo.Timing = gw.DownlinkTiming_DELAY
 	txInfo.TimingInfo = &gw.DownlinkTXInfo_DelayTimingInfo{
 		DelayTimingInfo: &gw.DelayTimingInfo{
-			Delay: ptypes.DurationProto(band.Band().GetDefaults().JoinAcceptDelay2),
+			Delay: ptypes.DurationProto(band.Get(ctx.DeviceSession.Region).GetDefaults().JoinAcceptDelay2),
 		},
 	}
 
@@ -241,6 +287,59 @@ func setTXInfoForRX2(ctx *joinContext) error {
 	return nil
 }
 
+// setTXInfoForRetryGateways adds for each downlink-frame a copy for each of
+// the next-best gateways, so that on a negative TX acknowledgement or TX
+// acknowledgement timeout, the join-accept can be retried using an other
+// gateway. The copies are added directly after the frame they are based on,
+// so that the frames remain ordered by transmission time.
+func setTXInfoForRetryGateways(ctx *joinContext) error {
+	if retryGateways == 0 || len(ctx.DownlinkFrames) == 0 {
+		return nil
+	}
+
+	var rxInfos []storage.DeviceGatewayRXInfo
+	for _, rxInfo := range ctx.DeviceGatewayRXInfo[1:] {
+		if len(rxInfos) == retryGateways {
+			break
+		}
+
+		// the first gateway could be repeated in the slice (see
+		// smbReorderGateways)
+		if rxInfo.GatewayID == ctx.DeviceGatewayRXInfo[0].GatewayID {
+			continue
+		}
+		rxInfos = append(rxInfos, rxInfo)
+	}
+
+	var downlinkFrames []gw.DownlinkFrame
+	for _, df := range ctx.DownlinkFrames {
+		downlinkFrames = append(downlinkFrames, df)
+
+		for _, rxInfo := range rxInfos {
+			txInfo := *df.TxInfo
+			txInfo.GatewayId = rxInfo.GatewayID[:]
+			txInfo.Board = rxInfo.Board
+			txInfo.Antenna = rxInfo.Antenna
+			txInfo.Context = rxInfo.Context
+
+			// set tx power
+			if downlinkTXPower != -1 {
+				txInfo.Power = int32(downlinkTXPower)
+			} else {
+				txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, band.Get(ctx.DeviceSession.Region).GetDownlinkTXPower(int(txInfo.Frequency))))
+			}
+			txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
+
+			downlinkFrames = append(downlinkFrames, gw.DownlinkFrame{
+				TxInfo: &txInfo,
+			})
+		}
+	}
+	ctx.DownlinkFrames = downlinkFrames
+
+	return nil
+}
+
 func setToken(ctx *joinContext) error {
 	b := make([]byte, 2)
 	_, err := rand.Read(b)
@@ -280,11 +379,38 @@ func sendJoinAcceptResponse(ctx *joinContext) error {
 		return nil
 	}
 
+	if err := accounting.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink accounting record error")
+	}
+	if err := contribution.DownlinkSent(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("store downlink gateway contribution error")
+	}
+	if err := storage.SetDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement pending error")
+	}
+	if err := ack.SetTXAckTimeout(ctx.ctx, helpers.GetGatewayID(ctx.DownlinkFrames[0].TxInfo), uint16(ctx.DownlinkFrames[0].Token)); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("set downlink tx acknowledgement timeout error")
+	}
+
 	err := gateway.Backend().SendTXPacket(ctx.DownlinkFrames[0])
 	if err != nil {
 		return errors.Wrap(err, "send downlink frame error")
 	}
 
+	latency.CheckDownlink(ctx.ctx, latency.FlowJoin, band.Get(ctx.DeviceSession.Region).GetDefaults().JoinAcceptDelay1)
+
 	// log frame
 	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), ctx.DownlinkFrames[0]); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
@@ -0,0 +1,84 @@
+package join
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func BenchmarkHandle(b *testing.B) {
+	if err := band.Setup(test.GetConfig()); err != nil {
+		b.Fatal(err)
+	}
+
+	// the tx power is set so that the tasks don't hit the storage
+	defer func(txPower, window int) {
+		downlinkTXPower = txPower
+		rxWindow = window
+	}(downlinkTXPower, rxWindow)
+	downlinkTXPower = 14
+	rxWindow = 0
+
+	tasks := []func(*joinContext) error{
+		setDeviceGatewayRXInfo,
+		setTXInfo,
+		setToken,
+		setDownlinkFrame,
+	}
+
+	rxPacket := models.RXPacket{
+		DR: 5,
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		},
+	}
+	if err := helpers.SetUplinkTXInfoDataRate(rxPacket.TXInfo, 5, band.Band()); err != nil {
+		b.Fatal(err)
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinAccept,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.JoinAcceptPayload{
+			HomeNetID: lorawan.NetID{1, 2, 3},
+			DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+		},
+	}
+	var key lorawan.AES128Key
+	if err := phy.SetDownlinkJoinMIC(lorawan.JoinRequestType, lorawan.EUI64{}, lorawan.DevNonce(0), key); err != nil {
+		b.Fatal(err)
+	}
+	if err := phy.EncryptJoinAcceptPayload(key); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ctx := joinContext{
+			ctx:           context.Background(),
+			DeviceSession: storage.DeviceSession{},
+			RXPacket:      rxPacket,
+			PHYPayload:    phy,
+		}
+
+		for _, t := range tasks {
+			if err := t(&ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
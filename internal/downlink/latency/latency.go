@@ -0,0 +1,101 @@
+// Package latency implements the latency budget check of the Class-A
+// downlinks. The time between receiving the uplink and publishing the
+// downlink to the gateway must stay within a fraction of the RX1 delay, as
+// the remaining time is needed for the gateway round-trip. When the budget
+// is exceeded, a warning is logged and a metric is incremented so that
+// code changes or load eating into the RX window can be detected.
+package latency
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// Downlink flows.
+const (
+	FlowData = "data"
+	FlowJoin = "join"
+)
+
+type contextKey int
+
+const uplinkReceivedAtKey contextKey = iota
+
+// rx1DelayFraction holds the configured fraction (float64), it can be
+// changed at runtime.
+var rx1DelayFraction atomic.Value
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	SetRX1DelayFraction(conf.NetworkServer.LatencyBudget.RX1DelayFraction)
+	return nil
+}
+
+// SetRX1DelayFraction sets the fraction of the RX1 delay which may be used
+// between receiving the uplink and publishing the downlink. When set to 0,
+// the budget is not enforced (the latency is still measured).
+func SetRX1DelayFraction(f float64) {
+	rx1DelayFraction.Store(f)
+}
+
+func getRX1DelayFraction() float64 {
+	f, _ := rx1DelayFraction.Load().(float64)
+	return f
+}
+
+// ContextWithUplinkReceivedAt returns a copy of the given context with the
+// time at which the uplink was received by the network-server.
+func ContextWithUplinkReceivedAt(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, uplinkReceivedAtKey, t)
+}
+
+// UplinkReceivedAt returns the time at which the uplink was received by the
+// network-server. It returns false when the context does not hold this
+// time (e.g. for downlinks which are not a response to an uplink).
+func UplinkReceivedAt(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(uplinkReceivedAtKey).(time.Time)
+	return t, ok
+}
+
+// CheckDownlink measures the time between receiving the uplink and
+// publishing the downlink for the given flow. It returns false when the
+// latency budget (fraction of the given RX1 delay) has been exceeded. When
+// the context does not hold the uplink receive time, true is returned.
+func CheckDownlink(ctx context.Context, flow string, rx1Delay time.Duration) bool {
+	receivedAt, ok := UplinkReceivedAt(ctx)
+	if !ok {
+		return true
+	}
+
+	elapsed := clock.Since(receivedAt)
+	latencyHistogram(flow).Observe(elapsed.Seconds())
+
+	fraction := getRX1DelayFraction()
+	if fraction <= 0 {
+		return true
+	}
+
+	budget := time.Duration(float64(rx1Delay) * fraction)
+	if elapsed <= budget {
+		return true
+	}
+
+	budgetExceededCounter(flow).Inc()
+
+	log.WithFields(log.Fields{
+		"flow":      flow,
+		"latency":   elapsed,
+		"budget":    budget,
+		"rx1_delay": rx1Delay,
+		"ctx_id":    ctx.Value(logging.ContextIDKey),
+	}).Warning("latency: downlink latency budget exceeded")
+
+	return false
+}
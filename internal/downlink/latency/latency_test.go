@@ -0,0 +1,51 @@
+package latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+func TestCheckDownlink(t *testing.T) {
+	m := clock.NewMock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.Set(m)
+	defer clock.Set(nil)
+	defer SetRX1DelayFraction(0)
+
+	tests := []struct {
+		Name     string
+		Fraction float64
+		Elapsed  time.Duration
+		Expected bool
+	}{
+		{"within budget", 0.5, 400 * time.Millisecond, true},
+		{"at budget", 0.5, 500 * time.Millisecond, true},
+		{"budget exceeded", 0.5, 600 * time.Millisecond, false},
+		{"budget disabled", 0, 2 * time.Second, true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			SetRX1DelayFraction(tst.Fraction)
+
+			ctx := ContextWithUplinkReceivedAt(context.Background(), clock.Now())
+			m.Add(tst.Elapsed)
+
+			assert.Equal(tst.Expected, CheckDownlink(ctx, FlowData, time.Second))
+		})
+	}
+
+	t.Run("without uplink receive time", func(t *testing.T) {
+		assert := require.New(t)
+		SetRX1DelayFraction(0.5)
+
+		_, ok := UplinkReceivedAt(context.Background())
+		assert.False(ok)
+		assert.True(CheckDownlink(context.Background(), FlowData, time.Second))
+	})
+}
@@ -0,0 +1,27 @@
+package latency
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ldh = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "downlink_latency_seconds",
+		Help:    "The time between receiving the uplink and publishing the downlink (per flow).",
+		Buckets: []float64{0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 0.75, 1, 2, 5},
+	}, []string{"flow"})
+
+	bec = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "downlink_latency_budget_exceeded_count",
+		Help: "The number of downlinks for which the latency budget was exceeded (per flow).",
+	}, []string{"flow"})
+)
+
+func latencyHistogram(flow string) prometheus.Observer {
+	return ldh.With(prometheus.Labels{"flow": flow})
+}
+
+func budgetExceededCounter(flow string) prometheus.Counter {
+	return bec.With(prometheus.Labels{"flow": flow})
+}
@@ -0,0 +1,116 @@
+package multicast
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// GatewayCoverage defines the coverage of a device by a single gateway.
+type GatewayCoverage struct {
+	GatewayID    lorawan.EUI64
+	RSSI         int
+	LoRaSNR      float64
+	SNRMarginMet bool
+}
+
+// DeviceCoverage defines the coverage of a device of the multicast-group.
+type DeviceCoverage struct {
+	DevEUI   lorawan.EUI64
+	Gateways []GatewayCoverage
+}
+
+// Covered returns true when the device is covered by at least one gateway
+// of the gateway-set.
+func (c DeviceCoverage) Covered() bool {
+	return len(c.Gateways) != 0
+}
+
+// Coverage defines the coverage of a multicast-group.
+type Coverage struct {
+	GatewayIDs []lorawan.EUI64
+	Devices    []DeviceCoverage
+}
+
+// UncoveredCount returns the number of devices without coverage.
+func (c Coverage) UncoveredCount() int {
+	var count int
+	for _, d := range c.Devices {
+		if !d.Covered() {
+			count++
+		}
+	}
+	return count
+}
+
+// GetCoverage returns the coverage of the given multicast-group. Like
+// EnqueueQueueItem, it selects the gateway-set from the cached gateway
+// rx-info sets of the devices and returns per device the gateways of this
+// set which received the last uplink of the device. Devices for which no
+// gateway rx-info set is cached (e.g. no uplink was received yet) are
+// reported as not covered.
+func GetCoverage(ctx context.Context, p storage.RedisClient, db sqlx.Queryer, multicastGroupID uuid.UUID) (Coverage, error) {
+	var out Coverage
+
+	mg, err := storage.GetMulticastGroup(ctx, db, multicastGroupID, false)
+	if err != nil {
+		return out, errors.Wrap(err, "get multicast-group error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return out, errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	rxInfoSets, err := getDeviceGatewayRXInfoSets(ctx, p, db, mg, devEUIs)
+	if err != nil {
+		return out, err
+	}
+
+	out.GatewayIDs, err = getGatewaySetForMulticastGroup(ctx, p, db, mg, rxInfoSets)
+	if err != nil {
+		return out, err
+	}
+
+	gwSet := make(map[lorawan.EUI64]struct{})
+	for _, id := range out.GatewayIDs {
+		gwSet[id] = struct{}{}
+	}
+
+	rxInfoSetMap := make(map[lorawan.EUI64]storage.DeviceGatewayRXInfoSet)
+	for _, rxInfoSet := range rxInfoSets {
+		rxInfoSetMap[rxInfoSet.DevEUI] = rxInfoSet
+	}
+
+	for _, devEUI := range devEUIs {
+		dc := DeviceCoverage{
+			DevEUI: devEUI,
+		}
+
+		if rxInfoSet, ok := rxInfoSetMap[devEUI]; ok {
+			reqSNR := getRequiredSNR(rxInfoSet.Region, rxInfoSet.DR)
+
+			for _, item := range rxInfoSet.Items {
+				if _, ok := gwSet[item.GatewayID]; !ok {
+					continue
+				}
+
+				dc.Gateways = append(dc.Gateways, GatewayCoverage{
+					GatewayID:    item.GatewayID,
+					RSSI:         item.RSSI,
+					LoRaSNR:      item.LoRaSNR,
+					SNRMarginMet: item.LoRaSNR >= reqSNR,
+				})
+			}
+		}
+
+		out.Devices = append(out.Devices, dc)
+	}
+
+	return out, nil
+}
@@ -4,26 +4,40 @@ import (
 	"context"
 	"time"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/constraint"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
 	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 // EnqueueQueueItem selects the gateways that must be used to cover all devices
 // within the multicast-group and creates a queue-item for each individial
-// gateway.
+// gateway. The maintained gateway-set (see UpdateGatewaySet) is used as long
+// as it covers all devices. When the queue-item has a geofence, only the
+// gateways within the geofence are selected.
 // Note that an enqueue action increments the frame-counter of the multicast-group.
-func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storage.MulticastQueueItem) error {
+func EnqueueQueueItem(ctx context.Context, p storage.RedisClient, db sqlx.Ext, qi storage.MulticastQueueItem) error {
 	// Get multicast-group and lock it.
 	mg, err := storage.GetMulticastGroup(ctx, db, qi.MulticastGroupID, true)
 	if err != nil {
 		return errors.Wrap(err, "get multicast-group error")
 	}
 
+	sp, err := storage.GetAndCacheServiceProfile(ctx, db, p, mg.ServiceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get service-profile error")
+	}
+
+	if sp.DownlinkDisabled {
+		return storage.ErrDownlinkDisabled
+	}
+
 	if qi.FCnt < mg.FCnt {
 		return ErrInvalidFCnt
 	}
@@ -39,14 +53,28 @@ func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storag
 		return errors.Wrap(err, "get deveuis for multicast-group error")
 	}
 
-	rxInfoSets, err := storage.GetDeviceGatewayRXInfoSetForDevEUIs(ctx, p, devEUIs)
+	rxInfoSets, err := getDeviceGatewayRXInfoSets(ctx, p, db, mg, devEUIs)
 	if err != nil {
-		return errors.Wrap(err, "get device gateway rx-info set for deveuis errors")
+		return err
 	}
 
-	gatewayIDs, err := GetMinimumGatewaySet(rxInfoSets)
-	if err != nil {
-		return errors.Wrap(err, "get minimum gateway set error")
+	var gatewayIDs []lorawan.EUI64
+	if qi.Geofence != nil {
+		// the maintained gateway-set might contain gateways outside the
+		// geofence, therefore the gateway-set is always selected
+		if err := filterRXInfoSetsByGeofence(ctx, db, rxInfoSets, *qi.Geofence); err != nil {
+			return err
+		}
+
+		gatewayIDs, err = GetMinimumGatewaySet(rxInfoSets)
+		if err != nil {
+			return errors.Wrap(err, "get minimum gateway set error")
+		}
+	} else {
+		gatewayIDs, err = getGatewaySetForMulticastGroup(ctx, p, db, mg, rxInfoSets)
+		if err != nil {
+			return err
+		}
 	}
 
 	// for each gateway we increment the schedule_at timestamp with one second
@@ -58,11 +86,17 @@ func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storag
 		}
 
 		if ts.IsZero() {
-			ts = time.Now()
+			ts = clock.Now()
 		}
 
 		for _, gatewayID := range gatewayIDs {
-			ts = ts.Add(downlinkLockDuration)
+			// account for a possible listen-before-talk deferral
+			scanTime, err := storage.GetLBTScanTimeForGateway(ctx, db, gatewayID)
+			if err != nil && err != storage.ErrDoesNotExist {
+				return errors.Wrap(err, "get lbt scan-time for gateway error")
+			}
+
+			ts = ts.Add(downlinkLockDuration + scanTime)
 			qi.GatewayID = gatewayID
 			qi.ScheduleAt = ts
 			if err = storage.CreateMulticastQueueItem(ctx, db, &qi); err != nil {
@@ -84,7 +118,7 @@ func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storag
 		}
 
 		if scheduleTS == 0 {
-			scheduleTS = gps.Time(time.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+			scheduleTS = gps.Time(clock.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
 		}
 
 		for _, gatewayID := range gatewayIDs {
@@ -105,3 +139,83 @@ func EnqueueQueueItem(ctx context.Context, p *redis.Pool, db sqlx.Ext, qi storag
 
 	return nil
 }
+
+// getDeviceGatewayRXInfoSets returns the device gateway rx-info sets for the
+// given devices, only containing the gateways allowed by the service-profile
+// of the multicast-group, which are not draining and which do not violate the
+// scheduling constraints of their model.
+func getDeviceGatewayRXInfoSets(ctx context.Context, p storage.RedisClient, db sqlx.Queryer, mg storage.MulticastGroup, devEUIs []lorawan.EUI64) ([]storage.DeviceGatewayRXInfoSet, error) {
+	rxInfoSets, err := storage.GetDeviceGatewayRXInfoSetForDevEUIs(ctx, p, devEUIs)
+	if err != nil {
+		return nil, errors.Wrap(err, "get device gateway rx-info set for deveuis errors")
+	}
+
+	sp, err := storage.GetAndCacheServiceProfile(ctx, db, p, mg.ServiceProfileID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get service-profile error")
+	}
+
+	for i := range rxInfoSets {
+		rxInfoSets[i].Items = isolation.FilterDeviceGatewayRXInfo(ctx, sp, rxInfoSets[i].Items)
+
+		// gateways in maintenance drain mode must not be used for new
+		// multicast downlinks
+		rxInfoSets[i].Items, err = storage.FilterDrainingGateways(ctx, p, rxInfoSets[i].Items)
+		if err != nil {
+			return nil, errors.Wrap(err, "filter draining gateways error")
+		}
+
+		// gateways violating the scheduling constraints of their model must
+		// not be used, Class-B multicast requires GPS timing
+		rxInfoSets[i].Items, err = constraint.FilterDeviceGatewayRXInfo(ctx, db, p, rxInfoSets[i].Items, mg.GroupType == storage.MulticastGroupB)
+		if err != nil {
+			return nil, errors.Wrap(err, "filter gateway constraints error")
+		}
+
+		// the gateways receiving the same uplink are expected to be in the
+		// same region
+		if len(rxInfoSets[i].Items) != 0 {
+			rxInfoSets[i].Region = storage.GetGatewayRegion(ctx, db, rxInfoSets[i].Items[0].GatewayID)
+		}
+	}
+
+	return rxInfoSets, nil
+}
+
+// filterRXInfoSetsByGeofence removes the gateways outside the given geofence
+// from the given device gateway rx-info sets. Unknown gateways and gateways
+// without location are considered to be outside the geofence.
+func filterRXInfoSetsByGeofence(ctx context.Context, db sqlx.Queryer, rxInfoSets []storage.DeviceGatewayRXInfoSet, geofence storage.Geofence) error {
+	inGeofence := make(map[lorawan.EUI64]bool)
+	for _, gatewayID := range getGatewaySet(rxInfoSets) {
+		gw, err := storage.GetGateway(ctx, db, gatewayID)
+		if err != nil {
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				continue
+			}
+			return errors.Wrap(err, "get gateway error")
+		}
+		inGeofence[gatewayID] = gatewayInGeofence(gw, geofence)
+	}
+
+	for i := range rxInfoSets {
+		var items []storage.DeviceGatewayRXInfo
+		for _, item := range rxInfoSets[i].Items {
+			if inGeofence[item.GatewayID] {
+				items = append(items, item)
+			}
+		}
+		rxInfoSets[i].Items = items
+	}
+
+	return nil
+}
+
+// gatewayInGeofence returns true when the gateway has a location and this
+// location is within the given geofence.
+func gatewayInGeofence(gw storage.Gateway, geofence storage.Geofence) bool {
+	if gw.Location == (storage.GPSPoint{}) {
+		return false
+	}
+	return geofence.Contains(gw.Location)
+}
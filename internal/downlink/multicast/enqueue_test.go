@@ -4,13 +4,16 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/brocaar/lorawan"
 
+	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
@@ -179,6 +182,481 @@ func (ts *EnqueueQueueItemTestCase) TestClassB() {
 	assert.Equal(qi.FCnt+1, mg.FCnt)
 }
 
+func (ts *EnqueueQueueItemTestCase) TestCoverage() {
+	assert := require.New(ts.T())
+
+	// device without gateway rx-info set
+	d := storage.Device{
+		DevEUI:           lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 3},
+		ServiceProfileID: ts.Devices[0].ServiceProfileID,
+		RoutingProfileID: ts.Devices[0].RoutingProfileID,
+		DeviceProfileID:  ts.Devices[0].DeviceProfileID,
+	}
+	assert.NoError(storage.CreateDevice(context.Background(), ts.tx, &d))
+	assert.NoError(storage.AddDeviceToMulticastGroup(context.Background(), ts.tx, d.DevEUI, ts.MulticastGroup.ID))
+
+	coverage, err := GetCoverage(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+
+	assert.ElementsMatch([]lorawan.EUI64{ts.Gateways[0].GatewayID, ts.Gateways[1].GatewayID}, coverage.GatewayIDs)
+	assert.Len(coverage.Devices, 3)
+	assert.Equal(1, coverage.UncoveredCount())
+
+	devices := make(map[lorawan.EUI64]DeviceCoverage)
+	for _, dc := range coverage.Devices {
+		devices[dc.DevEUI] = dc
+	}
+
+	for i := range ts.Devices {
+		dc := devices[ts.Devices[i].DevEUI]
+		assert.True(dc.Covered())
+		assert.Equal([]GatewayCoverage{
+			{
+				GatewayID:    ts.Gateways[i].GatewayID,
+				RSSI:         50,
+				LoRaSNR:      5,
+				SNRMarginMet: true,
+			},
+		}, dc.Gateways)
+	}
+
+	assert.False(devices[d.DevEUI].Covered())
+}
+
+func (ts *EnqueueQueueItemTestCase) TestEstimateSession() {
+	ts.T().Run("Class-C", func(t *testing.T) {
+		assert := require.New(t)
+
+		est, err := EstimateSession(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 10, 5, 3, 0)
+		assert.NoError(err)
+
+		assert.Equal(185344*time.Microsecond, est.FrameAirtime)
+		assert.Equal(10*downlinkLockDuration+est.FrameAirtime, est.Duration)
+		assert.Equal(92672*time.Millisecond, est.MinDuration)
+		assert.True(est.DutyCycleFeasible)
+
+		assert.Len(est.Gateways, 2)
+		for _, ga := range est.Gateways {
+			assert.Equal(926720*time.Microsecond, ga.Airtime)
+			assert.InDelta(0.0257, ga.DutyCycle, 0.0001)
+		}
+	})
+
+	ts.T().Run("Duty-cycle exceeded", func(t *testing.T) {
+		assert := require.New(t)
+
+		est, err := EstimateSession(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 10, 500, 3, 1)
+		assert.NoError(err)
+		assert.False(est.DutyCycleFeasible)
+	})
+
+	ts.T().Run("Max payload size exceeded", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := EstimateSession(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 200, 5, 3, 0)
+		assert.Equal(ErrMaxPayloadSizeExceeded, err)
+	})
+
+	ts.T().Run("Invalid fragment count", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := EstimateSession(context.Background(), storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 10, 0, 3, 0)
+		assert.Equal(ErrInvalidFragmentCount, err)
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestUpdateGatewaySet() {
+	ctx := context.Background()
+	gw1 := ts.Gateways[0].GatewayID
+	gw2 := ts.Gateways[1].GatewayID
+
+	setGateway := func(assert *require.Assertions, devEUI, gatewayID lorawan.EUI64) {
+		assert.NoError(storage.SaveDeviceGatewayRXInfoSet(ctx, storage.RedisPool(), storage.DeviceGatewayRXInfoSet{
+			DevEUI: devEUI,
+			DR:     3,
+			Items: []storage.DeviceGatewayRXInfo{
+				{
+					GatewayID: gatewayID,
+					RSSI:      50,
+					LoRaSNR:   5,
+				},
+			},
+		}))
+	}
+
+	getGatewayIDs := func(assert *require.Assertions) []lorawan.EUI64 {
+		gs, err := storage.GetMulticastGroupGatewaySet(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		return gs.GatewayIDs
+	}
+
+	ts.T().Run("Initial", func(t *testing.T) {
+		assert := require.New(t)
+
+		changed, err := UpdateGatewaySet(ctx, storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 2)
+		assert.NoError(err)
+		assert.True(changed)
+		assert.ElementsMatch([]lorawan.EUI64{gw1, gw2}, getGatewayIDs(assert))
+
+		changed, err = UpdateGatewaySet(ctx, storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 2)
+		assert.NoError(err)
+		assert.False(changed)
+	})
+
+	ts.T().Run("Hysteresis", func(t *testing.T) {
+		assert := require.New(t)
+
+		// the current gateway-set still covers both devices
+		setGateway(assert, ts.Devices[1].DevEUI, gw1)
+
+		changed, err := UpdateGatewaySet(ctx, storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 2)
+		assert.NoError(err)
+		assert.False(changed)
+		assert.ElementsMatch([]lorawan.EUI64{gw1, gw2}, getGatewayIDs(assert))
+
+		changed, err = UpdateGatewaySet(ctx, storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 2)
+		assert.NoError(err)
+		assert.True(changed)
+		assert.Equal([]lorawan.EUI64{gw1}, getGatewayIDs(assert))
+	})
+
+	ts.T().Run("Uncovered", func(t *testing.T) {
+		assert := require.New(t)
+
+		setGateway(assert, ts.Devices[0].DevEUI, gw2)
+		setGateway(assert, ts.Devices[1].DevEUI, gw2)
+
+		changed, err := UpdateGatewaySet(ctx, storage.RedisPool(), ts.tx, ts.MulticastGroup.ID, 2)
+		assert.NoError(err)
+		assert.True(changed)
+		assert.Equal([]lorawan.EUI64{gw2}, getGatewayIDs(assert))
+
+		// the maintained gateway-set is used for enqueueing
+		assert.NoError(EnqueueQueueItem(ctx, storage.RedisPool(), ts.tx, storage.MulticastQueueItem{
+			MulticastGroupID: ts.MulticastGroup.ID,
+			FCnt:             11,
+			FPort:            2,
+			FRMPayload:       []byte{1, 2, 3, 4},
+		}))
+
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(items, 1)
+		assert.Equal(gw2, items[0].GatewayID)
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestRateLimit() {
+	ctx := context.Background()
+	gatewayID := ts.Gateways[0].GatewayID
+	txTime := time.Now().Truncate(time.Minute)
+
+	airtime, err := getFrameAirtime("", ts.MulticastGroup.DR, 4)
+	ts.Require().NoError(err)
+
+	ts.T().Run("No limits", func(t *testing.T) {
+		assert := require.New(t)
+
+		mg := ts.MulticastGroup
+		assert.NoError(incrementRateLimit(storage.RedisPool(), mg, gatewayID, txTime, airtime))
+
+		deferUntil, err := getRateLimitDeferral(ctx, storage.RedisPool(), mg, gatewayID, txTime, airtime)
+		assert.NoError(err)
+		assert.True(deferUntil.IsZero())
+	})
+
+	ts.T().Run("Max frames per minute", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+
+		mg := ts.MulticastGroup
+		mg.MaxFramesPerMinute = 2
+
+		for i := 0; i < 2; i++ {
+			deferUntil, err := getRateLimitDeferral(ctx, storage.RedisPool(), mg, gatewayID, txTime, airtime)
+			assert.NoError(err)
+			assert.True(deferUntil.IsZero())
+			assert.NoError(incrementRateLimit(storage.RedisPool(), mg, gatewayID, txTime, airtime))
+		}
+
+		deferUntil, err := getRateLimitDeferral(ctx, storage.RedisPool(), mg, gatewayID, txTime, airtime)
+		assert.NoError(err)
+		assert.Equal(txTime.Add(time.Minute), deferUntil)
+
+		// the limit is per gateway
+		deferUntil, err = getRateLimitDeferral(ctx, storage.RedisPool(), mg, ts.Gateways[1].GatewayID, txTime, airtime)
+		assert.NoError(err)
+		assert.True(deferUntil.IsZero())
+	})
+
+	ts.T().Run("Max airtime percentage", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+
+		mg := ts.MulticastGroup
+		// allows for one frame and a half per hour
+		mg.MaxAirtimePercentage = float64(airtime) * 1.5 / float64(time.Hour) * 100
+
+		deferUntil, err := getRateLimitDeferral(ctx, storage.RedisPool(), mg, gatewayID, txTime, airtime)
+		assert.NoError(err)
+		assert.True(deferUntil.IsZero())
+		assert.NoError(incrementRateLimit(storage.RedisPool(), mg, gatewayID, txTime, airtime))
+
+		deferUntil, err = getRateLimitDeferral(ctx, storage.RedisPool(), mg, gatewayID, txTime, airtime)
+		assert.NoError(err)
+		assert.Equal(txTime.Truncate(time.Hour).Add(time.Hour), deferUntil)
+	})
+
+	ts.T().Run("Queue-item is deferred", func(t *testing.T) {
+		assert := require.New(t)
+		test.MustFlushRedis(storage.RedisPool())
+
+		mg := ts.MulticastGroup
+		mg.MaxFramesPerMinute = 1
+		assert.NoError(storage.UpdateMulticastGroup(ctx, ts.tx, &mg))
+
+		now := time.Now()
+		assert.NoError(incrementRateLimit(storage.RedisPool(), mg, gatewayID, now, airtime))
+
+		qi := storage.MulticastQueueItem{
+			ScheduleAt:       now,
+			MulticastGroupID: mg.ID,
+			GatewayID:        gatewayID,
+			FCnt:             mg.FCnt,
+			FPort:            2,
+			FRMPayload:       []byte{1, 2, 3, 4},
+		}
+		assert.NoError(storage.CreateMulticastQueueItem(ctx, ts.tx, &qi))
+		assert.NoError(HandleScheduleQueueItem(ctx, ts.tx, qi))
+
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, mg.ID)
+		assert.NoError(err)
+		assert.Len(items, 1)
+		assert.True(items[0].ScheduleAt.Equal(now.Truncate(time.Minute).Add(time.Minute)))
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestTXStatus() {
+	ctx := context.Background()
+	gw1 := ts.Gateways[0].GatewayID
+	gw2 := ts.Gateways[1].GatewayID
+
+	txRetries = 1
+	defer func() {
+		txRetries = 0
+	}()
+
+	qi1 := storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		GatewayID:        gw1,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+	}
+	qi2 := qi1
+	qi2.GatewayID = gw2
+
+	ts.T().Run("Unknown token", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), ts.tx, 1234, gw.DownlinkTXAck{}))
+	})
+
+	ts.T().Run("Sent", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(txSent(ctx, storage.RedisPool(), 1, qi1))
+		assert.NoError(txSent(ctx, storage.RedisPool(), 2, qi2))
+
+		frames, err := GetTXStatus(ctx, storage.RedisPool(), ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Equal([]FrameTXStatus{
+			{
+				FCnt: 11,
+				Gateways: []GatewayTXStatus{
+					{GatewayID: gw1, State: TXPending},
+					{GatewayID: gw2, State: TXPending},
+				},
+			},
+		}, frames)
+	})
+
+	ts.T().Run("Acked and retried", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), ts.tx, 1, gw.DownlinkTXAck{Token: 1}))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), ts.tx, 2, gw.DownlinkTXAck{Token: 2, Error: "TOO_LATE"}))
+
+		frames, err := GetTXStatus(ctx, storage.RedisPool(), ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Equal([]FrameTXStatus{
+			{
+				FCnt: 11,
+				Gateways: []GatewayTXStatus{
+					{GatewayID: gw1, State: TXAcked},
+					{GatewayID: gw2, State: TXPending, Error: "TOO_LATE", Retries: 1},
+				},
+			},
+		}, frames)
+
+		// the frame has been re-scheduled for the second gateway
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(items, 1)
+		assert.Equal(gw2, items[0].GatewayID)
+		assert.EqualValues(11, items[0].FCnt)
+	})
+
+	ts.T().Run("Failed", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(txSent(ctx, storage.RedisPool(), 3, qi2))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), ts.tx, 3, gw.DownlinkTXAck{Token: 3, Error: "COLLISION_PACKET"}))
+
+		frames, err := GetTXStatus(ctx, storage.RedisPool(), ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Equal(GatewayTXStatus{GatewayID: gw2, State: TXFailed, Error: "COLLISION_PACKET", Retries: 1}, frames[0].Gateways[1])
+
+		// the max. number of retries has been reached
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(items, 1)
+	})
+}
+
+func (ts *EnqueueQueueItemTestCase) TestGeofence() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	amsterdam := storage.GPSPoint{Latitude: 52.3676, Longitude: 4.9041}
+	paris := storage.GPSPoint{Latitude: 48.8566, Longitude: 2.3522}
+
+	ts.Gateways[0].Location = amsterdam
+	ts.Gateways[1].Location = paris
+	for i := range ts.Gateways {
+		assert.NoError(storage.UpdateGateway(ctx, ts.tx, &ts.Gateways[i]))
+	}
+
+	geofence := storage.Geofence{
+		Center: amsterdam,
+		Radius: 50000,
+	}
+
+	assert.NoError(EnqueueQueueItem(ctx, storage.RedisPool(), ts.tx, storage.MulticastQueueItem{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		FCnt:             11,
+		FPort:            2,
+		FRMPayload:       []byte{1, 2, 3, 4},
+		Geofence:         &geofence,
+	}))
+
+	items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, ts.MulticastGroup.ID)
+	assert.NoError(err)
+	assert.Len(items, 1)
+	assert.Equal(ts.Gateways[0].GatewayID, items[0].GatewayID)
+	assert.Equal(&geofence, items[0].Geofence)
+
+	ts.T().Run("Gateway moved outside geofence", func(t *testing.T) {
+		assert := require.New(t)
+
+		ts.Gateways[0].Location = paris
+		assert.NoError(storage.UpdateGateway(ctx, ts.tx, &ts.Gateways[0]))
+
+		assert.NoError(HandleScheduleQueueItem(ctx, ts.tx, items[0]))
+
+		items, err := storage.GetMulticastQueueItemsForMulticastGroup(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(items, 0)
+	})
+}
+
 func TestEnqueueQueueItem(t *testing.T) {
 	suite.Run(t, new(EnqueueQueueItemTestCase))
 }
+
+func (ts *EnqueueQueueItemTestCase) TestSetup() {
+	ctx := context.Background()
+	appSKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	// only the first device has been activated
+	ds := storage.DeviceSession{
+		DevEUI:         ts.Devices[0].DevEUI,
+		DevAddr:        lorawan.DevAddr{1, 2, 3, 4},
+		MACVersion:     "1.0.3",
+		NFCntDown:      5,
+		AppSKeyEvelope: &storage.KeyEnvelope{AESKey: appSKey[:]},
+	}
+	require.NoError(ts.T(), storage.SaveDeviceSession(ctx, storage.RedisPool(), ds))
+
+	s := storage.MulticastGroupSetup{
+		MulticastGroupID: ts.MulticastGroup.ID,
+		McGroupID:        1,
+		McKeyEncrypted:   []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		MinMcFCnt:        11,
+		MaxMcFCnt:        100,
+		SessionTime:      time.Time(gps.NewFromTimeSinceGPSEpoch(1000 * time.Second)),
+		SessionTimeOut:   8,
+	}
+
+	getCommands := func(t *testing.T) [][]byte {
+		assert := require.New(t)
+
+		items, err := storage.GetDeviceQueueItemsForDevEUI(ctx, ts.tx, ds.DevEUI)
+		assert.NoError(err)
+
+		var out [][]byte
+		for _, qi := range items {
+			assert.EqualValues(SetupFPort, qi.FPort)
+			b, err := lorawan.EncryptFRMPayload(appSKey, false, ds.DevAddr, qi.FCnt, qi.FRMPayload)
+			assert.NoError(err)
+			out = append(out, b)
+		}
+		return out
+	}
+
+	ts.T().Run("Start", func(t *testing.T) {
+		assert := require.New(t)
+		assert.NoError(StartSetup(ctx, storage.RedisPool(), ts.tx, s))
+
+		devices, err := storage.GetMulticastGroupSetupDevices(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Len(devices, 2)
+		assert.Equal(storage.MulticastSetupGroupPending, devices[0].State)
+		assert.Equal(storage.MulticastSetupFailed, devices[1].State)
+		assert.NotEqual("", devices[1].Error)
+
+		assert.Equal([][]byte{
+			{0x02, 0x01, 0x04, 0x03, 0x02, 0x01, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 11, 0, 0, 0, 100, 0, 0, 0},
+		}, getCommands(t))
+	})
+
+	ts.T().Run("McGroupSetupAns", func(t *testing.T) {
+		assert := require.New(t)
+
+		// PackageVersionAns + McGroupSetupAns
+		assert.NoError(HandleSetupAnswers(ctx, storage.RedisPool(), ts.tx, ds.DevEUI, []byte{0x00, 0x02, 0x01, 0x02, 0x01}))
+
+		devices, err := storage.GetMulticastGroupSetupDevices(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Equal(storage.MulticastSetupSessionPending, devices[0].State)
+
+		commands := getCommands(t)
+		assert.Len(commands, 2)
+		assert.Equal([]byte{0x04, 0x01, 0xe8, 0x03, 0x00, 0x00, 0x08, 0x28, 0x76, 0x84, 0x03}, commands[1])
+	})
+
+	ts.T().Run("McClassCSessionAns", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(HandleSetupAnswers(ctx, storage.RedisPool(), ts.tx, ds.DevEUI, []byte{0x04, 0x01, 0x10, 0x00, 0x00}))
+
+		devices, err := storage.GetMulticastGroupSetupDevices(ctx, ts.tx, ts.MulticastGroup.ID)
+		assert.NoError(err)
+		assert.Equal(storage.MulticastSetupCompleted, devices[0].State)
+		assert.Equal("", devices[0].Error)
+	})
+
+	ts.T().Run("Unknown command", func(t *testing.T) {
+		assert := require.New(t)
+		assert.Error(HandleSetupAnswers(ctx, storage.RedisPool(), ts.tx, ds.DevEUI, []byte{0x07}))
+	})
+}
@@ -4,5 +4,7 @@ import "github.com/pkg/errors"
 
 // Errors
 var (
-	ErrInvalidFCnt = errors.New("invalid frame-counter value")
+	ErrInvalidFCnt            = errors.New("invalid frame-counter value")
+	ErrInvalidFragmentCount   = errors.New("fragment count must be greater than 0")
+	ErrMaxPayloadSizeExceeded = errors.New("maximum payload size exceeded")
 )
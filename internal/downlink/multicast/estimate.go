@@ -0,0 +1,190 @@
+package multicast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	// frameOverhead is the size of the MHDR, FHDR (without FOpts), FPort
+	// and MIC of a multicast downlink.
+	frameOverhead = 13
+
+	// defaultMaxDutyCycle is the max. duty-cycle (percentage) used when
+	// none is given.
+	defaultMaxDutyCycle = 1.0
+
+	// dutyCycleObservationPeriod is the minimum period over which the
+	// duty-cycle is calculated.
+	dutyCycleObservationPeriod = time.Hour
+)
+
+// GatewayAirtime defines the airtime of a multicast session for a single
+// gateway.
+type GatewayAirtime struct {
+	GatewayID lorawan.EUI64
+	Airtime   time.Duration
+	DutyCycle float64
+}
+
+// SessionEstimate defines the estimate of a multicast session.
+type SessionEstimate struct {
+	FrameAirtime      time.Duration
+	Gateways          []GatewayAirtime
+	Duration          time.Duration
+	MinDuration       time.Duration
+	DutyCycleFeasible bool
+}
+
+// EstimateSession estimates the airtime per gateway, the duration and the
+// duty-cycle feasibility of a multicast session of the given number of
+// fragments, without enqueueing any frames. The gateway-set and the
+// scheduling of the frames are the same as for EnqueueQueueItem, taking
+// already enqueued frames into account.
+func EstimateSession(ctx context.Context, p storage.RedisClient, db sqlx.Queryer, multicastGroupID uuid.UUID, payloadSize, fragmentCount, dr int, maxDutyCycle float64) (SessionEstimate, error) {
+	var out SessionEstimate
+
+	if fragmentCount <= 0 {
+		return out, ErrInvalidFragmentCount
+	}
+
+	if maxDutyCycle <= 0 {
+		maxDutyCycle = defaultMaxDutyCycle
+	}
+
+	maxSize, err := band.GetForDwellTime("", downlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex("", "", dr)
+	if err != nil {
+		return out, errors.Wrap(err, "get max payload-size for data-rate index error")
+	}
+	if payloadSize > maxSize.N {
+		return out, ErrMaxPayloadSizeExceeded
+	}
+
+	out.FrameAirtime, err = getFrameAirtime("", dr, payloadSize)
+	if err != nil {
+		return out, err
+	}
+
+	mg, err := storage.GetMulticastGroup(ctx, db, multicastGroupID, false)
+	if err != nil {
+		return out, errors.Wrap(err, "get multicast-group error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return out, errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	rxInfoSets, err := getDeviceGatewayRXInfoSets(ctx, p, db, mg, devEUIs)
+	if err != nil {
+		return out, err
+	}
+
+	gatewayIDs, err := getGatewaySetForMulticastGroup(ctx, p, db, mg, rxInfoSets)
+	if err != nil {
+		return out, err
+	}
+
+	if len(gatewayIDs) == 0 {
+		return out, nil
+	}
+
+	now := clock.Now()
+
+	var end time.Time
+	switch mg.GroupType {
+	case storage.MulticastGroupB:
+		end, err = estimateClassBEnd(ctx, db, mg, now, len(gatewayIDs)*fragmentCount)
+	case storage.MulticastGroupC:
+		end, err = estimateClassCEnd(ctx, db, mg, now, gatewayIDs, fragmentCount)
+	default:
+		err = fmt.Errorf("unknown multicast-group type: %s", mg.GroupType)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	gatewayAirtime := time.Duration(fragmentCount) * out.FrameAirtime
+	out.Duration = end.Add(out.FrameAirtime).Sub(now)
+	out.MinDuration = time.Duration(float64(gatewayAirtime) * 100 / maxDutyCycle)
+
+	period := out.Duration
+	if period < dutyCycleObservationPeriod {
+		period = dutyCycleObservationPeriod
+	}
+	dutyCycle := float64(gatewayAirtime) / float64(period) * 100
+
+	out.DutyCycleFeasible = dutyCycle <= maxDutyCycle
+	for _, id := range gatewayIDs {
+		out.Gateways = append(out.Gateways, GatewayAirtime{
+			GatewayID: id,
+			Airtime:   gatewayAirtime,
+			DutyCycle: dutyCycle,
+		})
+	}
+
+	return out, nil
+}
+
+// estimateClassBEnd returns the emit time of the last of the given number of
+// frames, using the next ping-slot for each frame.
+func estimateClassBEnd(ctx context.Context, db sqlx.Queryer, mg storage.MulticastGroup, now time.Time, frames int) (time.Time, error) {
+	var pingSlotNb int
+	if mg.PingSlotPeriod != 0 {
+		pingSlotNb = (1 << 12) / mg.PingSlotPeriod
+	}
+
+	scheduleTS, err := storage.GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "get maximum emit at time since gps epoch error")
+	}
+
+	if scheduleTS == 0 {
+		scheduleTS = gps.Time(now.Add(classBEnqueueMargin)).TimeSinceGPSEpoch()
+	}
+
+	for i := 0; i < frames; i++ {
+		scheduleTS, err = classb.GetNextPingSlotAfter(scheduleTS, mg.MCAddr, pingSlotNb)
+		if err != nil {
+			return time.Time{}, errors.Wrap(err, "get next ping-slot after error")
+		}
+	}
+
+	return time.Time(gps.NewFromTimeSinceGPSEpoch(scheduleTS)), nil
+}
+
+// estimateClassCEnd returns the schedule time of the last frame, when
+// sending the given number of fragments through each gateway.
+func estimateClassCEnd(ctx context.Context, db sqlx.Queryer, mg storage.MulticastGroup, now time.Time, gatewayIDs []lorawan.EUI64, fragmentCount int) (time.Time, error) {
+	ts, err := storage.GetMaxScheduleAtForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "get maximum schedule at error")
+	}
+
+	if ts.IsZero() {
+		ts = now
+	}
+
+	var perFragment time.Duration
+	for _, gatewayID := range gatewayIDs {
+		scanTime, err := storage.GetLBTScanTimeForGateway(ctx, db, gatewayID)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return time.Time{}, errors.Wrap(err, "get lbt scan-time for gateway error")
+		}
+		perFragment += downlinkLockDuration + scanTime
+	}
+
+	return ts.Add(time.Duration(fragmentCount) * perFragment), nil
+}
@@ -0,0 +1,231 @@
+package multicast
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// Gateway-set change reasons.
+const (
+	gatewaySetReasonInitial    = "initial"
+	gatewaySetReasonUncovered  = "uncovered"
+	gatewaySetReasonHysteresis = "hysteresis"
+)
+
+// UpdateGatewaySets updates the maintained gateway-set of every
+// multicast-group (see UpdateGatewaySet). It returns the number of
+// gateway-sets that have changed.
+func UpdateGatewaySets(ctx context.Context, p storage.RedisClient, hysteresis int) (int, error) {
+	ids, err := storage.GetMulticastGroupIDs(ctx, storage.DB())
+	if err != nil {
+		return 0, errors.Wrap(err, "get multicast-group ids error")
+	}
+
+	var count int
+	for _, id := range ids {
+		var changed bool
+		err := storage.Transaction(func(tx sqlx.Ext) error {
+			var err error
+			changed, err = UpdateGatewaySet(ctx, p, tx, id, hysteresis)
+			return err
+		})
+		if err != nil {
+			// the multicast-group might have been deleted in the meantime
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				continue
+			}
+			return count, errors.Wrap(err, "update gateway-set error")
+		}
+
+		if changed {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// UpdateGatewaySet re-computes the gateway-set of the given multicast-group
+// from the current gateway rx-info sets of its devices. When the maintained
+// gateway-set leaves devices uncovered, the new gateway-set is adopted
+// immediately. Otherwise a changed gateway-set is only adopted after it has
+// been selected for hysteresis consecutive runs, to avoid flapping between
+// gateway-sets of similar quality. It returns true when the gateway-set has
+// changed.
+func UpdateGatewaySet(ctx context.Context, p storage.RedisClient, db sqlx.Ext, multicastGroupID uuid.UUID, hysteresis int) (bool, error) {
+	// lock the multicast-group to avoid a concurrent update
+	mg, err := storage.GetMulticastGroup(ctx, db, multicastGroupID, true)
+	if err != nil {
+		return false, errors.Wrap(err, "get multicast-group error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return false, errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	rxInfoSets, err := getDeviceGatewayRXInfoSets(ctx, p, db, mg, devEUIs)
+	if err != nil {
+		return false, err
+	}
+
+	gatewayIDs, err := GetMinimumGatewaySet(rxInfoSets)
+	if err != nil {
+		return false, errors.Wrap(err, "get minimum gateway set error")
+	}
+
+	gs, err := storage.GetMulticastGroupGatewaySet(ctx, db, mg.ID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return false, errors.Wrap(err, "get multicast-group gateway-set error")
+	}
+	exists := err == nil
+	gs.MulticastGroupID = mg.ID
+
+	var reason string
+	switch {
+	case !exists:
+		reason = gatewaySetReasonInitial
+	case equalGatewaySet(gs.GatewayIDs, gatewayIDs):
+		if gs.PendingCount == 0 {
+			return false, nil
+		}
+
+		// the pending gateway-set did not persist
+		gs.PendingGatewayIDs = nil
+		gs.PendingCount = 0
+		if err := storage.SaveMulticastGroupGatewaySet(ctx, db, &gs); err != nil {
+			return false, errors.Wrap(err, "save multicast-group gateway-set error")
+		}
+		return false, nil
+	case uncoveredCount(rxInfoSets, gs.GatewayIDs) != 0:
+		reason = gatewaySetReasonUncovered
+	default:
+		if equalGatewaySet(gs.PendingGatewayIDs, gatewayIDs) {
+			gs.PendingCount++
+		} else {
+			gs.PendingGatewayIDs = gatewayIDs
+			gs.PendingCount = 1
+		}
+
+		if gs.PendingCount < hysteresis {
+			if err := storage.SaveMulticastGroupGatewaySet(ctx, db, &gs); err != nil {
+				return false, errors.Wrap(err, "save multicast-group gateway-set error")
+			}
+			return false, nil
+		}
+
+		reason = gatewaySetReasonHysteresis
+	}
+
+	previous := gs.GatewayIDs
+	gs.GatewayIDs = gatewayIDs
+	gs.PendingGatewayIDs = nil
+	gs.PendingCount = 0
+
+	if err := storage.SaveMulticastGroupGatewaySet(ctx, db, &gs); err != nil {
+		return false, errors.Wrap(err, "save multicast-group gateway-set error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": mg.ID,
+		"gateway_ids":        gatewayIDs,
+		"previous":           previous,
+		"reason":             reason,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast: gateway-set changed")
+
+	sp, err := storage.GetAndCacheServiceProfile(ctx, db, p, mg.ServiceProfileID)
+	if err != nil {
+		return false, errors.Wrap(err, "get service-profile error")
+	}
+
+	webhook.Send(ctx, sp.WebhookEndpoint(), webhook.EventMulticastGatewaySet, webhook.MulticastGatewaySetEvent{
+		MulticastGroupID:   mg.ID,
+		GatewayIDs:         gatewayIDs,
+		PreviousGatewayIDs: previous,
+		Reason:             reason,
+	})
+
+	return true, nil
+}
+
+// getGatewaySetForMulticastGroup returns the gateway-set to use for the
+// given multicast-group. This is the maintained gateway-set, as long as it
+// covers all devices for which a gateway rx-info set is available and none
+// of its gateways is draining. Otherwise the minimum gateway-set is returned.
+func getGatewaySetForMulticastGroup(ctx context.Context, p storage.RedisClient, db sqlx.Queryer, mg storage.MulticastGroup, rxInfoSets []storage.DeviceGatewayRXInfoSet) ([]lorawan.EUI64, error) {
+	gs, err := storage.GetMulticastGroupGatewaySet(ctx, db, mg.ID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return nil, errors.Wrap(err, "get multicast-group gateway-set error")
+	}
+
+	if err == nil && uncoveredCount(rxInfoSets, gs.GatewayIDs) == 0 {
+		// the maintained gateway-set might contain gateways which have been
+		// put in drain mode since
+		draining, err := storage.GetDrainingGateways(ctx, p, gs.GatewayIDs)
+		if err != nil {
+			return nil, errors.Wrap(err, "get draining gateways error")
+		}
+
+		if len(draining) == 0 {
+			return gs.GatewayIDs, nil
+		}
+	}
+
+	gatewayIDs, err := GetMinimumGatewaySet(rxInfoSets)
+	if err != nil {
+		return nil, errors.Wrap(err, "get minimum gateway set error")
+	}
+
+	return gatewayIDs, nil
+}
+
+// uncoveredCount returns the number of devices which have been received by
+// at least one gateway, but not by any of the given gateways.
+func uncoveredCount(rxInfoSets []storage.DeviceGatewayRXInfoSet, gatewayIDs []lorawan.EUI64) int {
+	var count int
+	for _, rxInfoSet := range rxInfoSets {
+		if len(rxInfoSet.Items) == 0 {
+			continue
+		}
+
+		var covered bool
+		for _, item := range rxInfoSet.Items {
+			if gwInGWSet(item.GatewayID, gatewayIDs) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			count++
+		}
+	}
+
+	return count
+}
+
+// equalGatewaySet returns true when both sets contain the same gateways,
+// regardless of their order.
+func equalGatewaySet(a, b []lorawan.EUI64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, id := range a {
+		if !gwInGWSet(id, b) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -114,17 +114,7 @@ func gwInGWSet(gatewayID lorawan.EUI64, gwSet []lorawan.EUI64) bool {
 
 func addDeviceEdges(g *simple.WeightedUndirectedGraph, rxInfoSets []storage.DeviceGatewayRXInfoSet) {
 	for _, rxInfo := range rxInfoSets {
-		dr, err := band.Band().GetDataRate(rxInfo.DR)
-		if err != nil {
-			log.WithError(err).WithFields(log.Fields{
-				"dr": dr,
-			}).Error("invalid data-data")
-		}
-
-		reqSNR, ok := spreadFactorToRequiredSNRTable[dr.SpreadFactor]
-		if ok {
-			reqSNR += installationMargin
-		}
+		reqSNR := getRequiredSNR(rxInfo.Region, rxInfo.DR)
 
 		var hasReqSNR bool
 
@@ -150,6 +140,26 @@ func addDeviceEdges(g *simple.WeightedUndirectedGraph, rxInfoSets []storage.Devi
 	}
 }
 
+// getRequiredSNR returns the required SNR (including the installation
+// margin) to demodulate a frame using the given data-rate of the band of the
+// given region.
+func getRequiredSNR(region string, dr int) float64 {
+	dataRate, err := band.Get(region).GetDataRate(dr)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"region": region,
+			"dr":     dr,
+		}).Error("invalid data-data")
+	}
+
+	reqSNR, ok := spreadFactorToRequiredSNRTable[dataRate.SpreadFactor]
+	if ok {
+		reqSNR += getInstallationMargin()
+	}
+
+	return reqSNR
+}
+
 type deviceGatewayEdge struct {
 	gatewayID lorawan.EUI64
 	devEUI    lorawan.EUI64
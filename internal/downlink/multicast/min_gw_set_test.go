@@ -6,7 +6,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
@@ -149,3 +151,46 @@ func TestGetMinimumGatewaySet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRequiredSNRRegions(t *testing.T) {
+	assert := require.New(t)
+	conf := test.GetConfig()
+	conf.NetworkServer.Regions = []config.Region{
+		{Name: "us915", Band: loraband.US_902_928},
+	}
+	assert.NoError(band.Setup(conf))
+	defer band.Setup(test.GetConfig())
+
+	// DR0 is SF12 for EU868 and SF10 for US915
+	assert.Equal(spreadFactorToRequiredSNRTable[12]+getInstallationMargin(), getRequiredSNR("", 0))
+	assert.Equal(spreadFactorToRequiredSNRTable[10]+getInstallationMargin(), getRequiredSNR("us915", 0))
+
+	euAirtime, err := getFrameAirtime("", 0, 10)
+	assert.NoError(err)
+	usAirtime, err := getFrameAirtime("us915", 0, 10)
+	assert.NoError(err)
+	assert.True(usAirtime < euAirtime)
+
+	t.Run("the gateways of a device in the us915 region must meet the SF10 SNR", func(t *testing.T) {
+		assert := require.New(t)
+
+		gws, err := GetMinimumGatewaySet([]storage.DeviceGatewayRXInfoSet{
+			{
+				DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				Region: "us915",
+				Items: []storage.DeviceGatewayRXInfo{
+					{
+						GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 1},
+						LoRaSNR:   spreadFactorToRequiredSNRTable[12] + getInstallationMargin(),
+					},
+					{
+						GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+						LoRaSNR:   spreadFactorToRequiredSNRTable[10] + getInstallationMargin(),
+					},
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Equal([]lorawan.EUI64{{2, 2, 2, 2, 2, 2, 2, 2}}, gws)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -18,6 +20,8 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
@@ -32,26 +36,37 @@ type multicastContext struct {
 	DB                 sqlx.Ext
 	MulticastGroup     storage.MulticastGroup
 	MulticastQueueItem storage.MulticastQueueItem
+	ServiceProfile     storage.ServiceProfile
 	TXInfo             gw.DownlinkTXInfo
 	PHYPayload         lorawan.PHYPayload
+	Airtime            time.Duration
 }
 
 var multicastTasks = []func(*multicastContext) error{
 	getMulticastGroup,
+	getServiceProfile,
+	validateRateLimit,
 	setToken,
 	removeQueueItem,
+	validateDownlinkEnabled,
 	validatePayloadSize,
+	validateGateway,
+	validateGeofence,
 	setTXInfo,
 	setPHYPayload,
 	sendDownlinkData,
+	saveTXStatus,
+	incrementRateLimitCounters,
 }
 
 var (
 	downlinkLockDuration time.Duration
 	schedulerInterval    time.Duration
-	installationMargin   float64
+	installationMargin   uint64 // float64 bits, accessed atomically
 	downlinkTXPower      int
 
+	downlinkDwellTime400ms bool
+
 	// TODO: make configurable
 	classBEnqueueMargin = time.Second * 5
 )
@@ -60,12 +75,28 @@ var (
 func Setup(conf config.Config) error {
 	downlinkLockDuration = conf.NetworkServer.Scheduler.ClassC.DownlinkLockDuration
 	schedulerInterval = conf.NetworkServer.Scheduler.SchedulerInterval
-	installationMargin = conf.NetworkServer.NetworkSettings.InstallationMargin
+	SetInstallationMargin(conf.NetworkServer.NetworkSettings.InstallationMargin)
 	downlinkTXPower = conf.NetworkServer.NetworkSettings.DownlinkTXPower
+	downlinkDwellTime400ms = conf.NetworkServer.Band.DownlinkDwellTime400ms
+	txRetries = conf.NetworkServer.Scheduler.Multicast.TXRetries
+	if conf.NetworkServer.Scheduler.Multicast.TXStatusTTL != 0 {
+		txStatusTTL = conf.NetworkServer.Scheduler.Multicast.TXStatusTTL
+	}
 
 	return nil
 }
 
+// SetInstallationMargin sets the installation-margin used for selecting the
+// gateways for multicast transmission. This is safe to call while the
+// multicast scheduler is running.
+func SetInstallationMargin(margin float64) {
+	atomic.StoreUint64(&installationMargin, math.Float64bits(margin))
+}
+
+func getInstallationMargin() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&installationMargin))
+}
+
 // HandleScheduleNextQueueItem handles the scheduling of the next queue-item
 // for the given multicast-group.
 func HandleScheduleNextQueueItem(ctx context.Context, db sqlx.Ext, mg storage.MulticastGroup) error {
@@ -117,6 +148,16 @@ func getMulticastGroup(ctx *multicastContext) error {
 	return nil
 }
 
+func getServiceProfile(ctx *multicastContext) error {
+	var err error
+	ctx.ServiceProfile, err = storage.GetAndCacheServiceProfile(ctx.ctx, ctx.DB, storage.RedisPool(), ctx.MulticastGroup.ServiceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get service-profile error")
+	}
+
+	return nil
+}
+
 func setToken(ctx *multicastContext) error {
 	b := make([]byte, 2)
 	_, err := rand.Read(b)
@@ -135,8 +176,36 @@ func removeQueueItem(ctx *multicastContext) error {
 	return nil
 }
 
+// validateDownlinkEnabled validates that downlinks are enabled by the
+// service-profile of the multicast-group. As the queue-item has already been
+// removed, it is dropped.
+func validateDownlinkEnabled(ctx *multicastContext) error {
+	if !ctx.ServiceProfile.DownlinkDisabled {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": ctx.MulticastGroup.ID,
+		"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+	}).Warning("downlinks are disabled by the service-profile of the multicast-group")
+
+	return errAbort
+}
+
 func validatePayloadSize(ctx *multicastContext) error {
-	maxSize, err := band.Band().GetMaxPayloadSizeForDataRateIndex("", "", ctx.MulticastGroup.DR)
+	// as the dwell-time state of the individual devices is unknown, the
+	// configured downlink dwell-time is used
+	if !band.IsDataRateAllowed("", downlinkDwellTime400ms, "", "", ctx.MulticastGroup.DR) {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"dr":                 ctx.MulticastGroup.DR,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("data-rate is not allowed under the configured dwell-time")
+
+		return errAbort
+	}
+
+	maxSize, err := band.GetForDwellTime("", downlinkDwellTime400ms).GetMaxPayloadSizeForDataRateIndex("", "", ctx.MulticastGroup.DR)
 	if err != nil {
 		return errors.Wrap(err, "get max payload-size for data-rate index error")
 	}
@@ -156,6 +225,48 @@ func validatePayloadSize(ctx *multicastContext) error {
 	return nil
 }
 
+// validateGateway validates that the gateway of the queue-item is allowed by
+// the service-profile of the multicast-group.
+func validateGateway(ctx *multicastContext) error {
+	if !isolation.DownlinkAllowed(ctx.ctx, ctx.ServiceProfile, ctx.MulticastQueueItem.GatewayID) {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"gateway_id":         ctx.MulticastQueueItem.GatewayID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("gateway is not allowed by the service-profile of the multicast-group")
+
+		return errAbort
+	}
+
+	return nil
+}
+
+// validateGeofence validates that the gateway of the queue-item is (still)
+// within the geofence of the queue-item, as the gateway might have moved
+// since the queue-item was enqueued.
+func validateGeofence(ctx *multicastContext) error {
+	if ctx.MulticastQueueItem.Geofence == nil {
+		return nil
+	}
+
+	g, err := storage.GetGateway(ctx.ctx, ctx.DB, ctx.MulticastQueueItem.GatewayID)
+	if err != nil {
+		return errors.Wrap(err, "get gateway error")
+	}
+
+	if !gatewayInGeofence(g, *ctx.MulticastQueueItem.Geofence) {
+		log.WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"gateway_id":         ctx.MulticastQueueItem.GatewayID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("gateway is outside the geofence of the multicast queue-item")
+
+		return errAbort
+	}
+
+	return nil
+}
+
 func setTXInfo(ctx *multicastContext) error {
 	txInfo := gw.DownlinkTXInfo{
 		GatewayId: ctx.MulticastQueueItem.GatewayID[:],
@@ -176,15 +287,18 @@ func setTXInfo(ctx *multicastContext) error {
 		}
 	}
 
-	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.MulticastGroup.DR, band.Band()); err != nil {
+	b := band.Get(storage.GetGatewayRegion(ctx.ctx, ctx.DB, ctx.MulticastQueueItem.GatewayID))
+
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.MulticastGroup.DR, b); err != nil {
 		return errors.Wrap(err, "set data-rate error")
 	}
 
 	if downlinkTXPower != -1 {
 		txInfo.Power = int32(downlinkTXPower)
 	} else {
-		txInfo.Power = int32(band.Band().GetDownlinkTXPower(ctx.MulticastGroup.Frequency))
+		txInfo.Power = int32(storage.GetDownlinkTXPowerForGateway(ctx.ctx, ctx.DB, storage.RedisPool(), helpers.GetGatewayID(&txInfo), txInfo.Board, txInfo.Antenna, b.GetDownlinkTXPower(ctx.MulticastGroup.Frequency)))
 	}
+	txInfo.Power = int32(ctx.ServiceProfile.CapDownlinkTXPower(int(txInfo.Power)))
 
 	ctx.TXInfo = txInfo
 
@@ -236,11 +350,25 @@ func sendDownlinkData(ctx *multicastContext) error {
 		PhyPayload: phyB,
 	}
 
+	if err := contribution.DownlinkSent(ctx.ctx, storage.RedisPool(), downlinkFrame); err != nil {
+		log.WithError(err).Error("store downlink gateway contribution error")
+	}
+	if err := storage.SetDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), downlinkFrame); err != nil {
+		log.WithError(err).Error("set downlink tx acknowledgement pending error")
+	}
+
 	if err := gateway.Backend().SendTXPacket(downlinkFrame); err != nil {
 		return errors.Wrap(err, "send downlink frame to gateway error")
 	}
 
-	if err := framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), downlinkFrame); err != nil {
+	if err := func() error {
+		frame, err := framelog.GetRedaction(ctx.ServiceProfile).RedactDownlinkFrame(downlinkFrame)
+		if err != nil {
+			return errors.Wrap(err, "redact downlink frame error")
+		}
+
+		return framelog.LogDownlinkFrameForGateway(ctx.ctx, storage.RedisPool(), frame)
+	}(); err != nil {
 		log.WithError(err).Error("log downlink frame for gateway error")
 	}
 
@@ -0,0 +1,195 @@
+package multicast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	rateLimitFramesKeyTempl  = "lora:ns:mg:{%s:gw:%s}:frames:%d"
+	rateLimitAirtimeKeyTempl = "lora:ns:mg:{%s:gw:%s}:airtime:%d"
+)
+
+var (
+	// frameRateWindow is the window of the max. frames per minute.
+	frameRateWindow = time.Minute
+
+	// airtimeWindow is the window of the max. airtime percentage.
+	airtimeWindow = dutyCycleObservationPeriod
+)
+
+// validateRateLimit defers the queue-item to the next window when
+// transmitting it would exceed the max. frames per minute or the max.
+// airtime percentage of the multicast-group for the gateway.
+func validateRateLimit(ctx *multicastContext) error {
+	mg := ctx.MulticastGroup
+	if mg.MaxFramesPerMinute <= 0 && mg.MaxAirtimePercentage <= 0 {
+		return nil
+	}
+
+	region := storage.GetGatewayRegion(ctx.ctx, ctx.DB, ctx.MulticastQueueItem.GatewayID)
+	airtime, err := getFrameAirtime(region, mg.DR, len(ctx.MulticastQueueItem.FRMPayload))
+	if err != nil {
+		return err
+	}
+
+	txTime := getTXTime(ctx.MulticastQueueItem)
+	deferUntil, err := getRateLimitDeferral(ctx.ctx, storage.RedisPool(), mg, ctx.MulticastQueueItem.GatewayID, txTime, airtime)
+	if err != nil {
+		return err
+	}
+
+	if deferUntil.IsZero() {
+		ctx.Airtime = airtime
+		return nil
+	}
+
+	qi := ctx.MulticastQueueItem
+	if qi.EmitAtTimeSinceGPSEpoch == nil {
+		qi.ScheduleAt = deferUntil
+	} else {
+		var pingSlotNb int
+		if mg.PingSlotPeriod != 0 {
+			pingSlotNb = (1 << 12) / mg.PingSlotPeriod
+		}
+
+		emitAt, err := classb.GetNextPingSlotAfter(gps.Time(deferUntil).TimeSinceGPSEpoch(), mg.MCAddr, pingSlotNb)
+		if err != nil {
+			return errors.Wrap(err, "get next ping-slot after error")
+		}
+
+		qi.EmitAtTimeSinceGPSEpoch = &emitAt
+		qi.ScheduleAt = time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * schedulerInterval)
+	}
+
+	if err := storage.UpdateMulticastQueueItemSchedule(ctx.ctx, ctx.DB, qi); err != nil {
+		return errors.Wrap(err, "update multicast queue-item schedule error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": mg.ID,
+		"gateway_id":         qi.GatewayID,
+		"f_cnt":              qi.FCnt,
+		"schedule_at":        qi.ScheduleAt,
+		"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("multicast queue-item deferred because of rate-limit")
+
+	return errAbort
+}
+
+// incrementRateLimitCounters increments the frame and airtime counters of
+// the transmitted queue-item.
+func incrementRateLimitCounters(ctx *multicastContext) error {
+	if ctx.MulticastGroup.MaxFramesPerMinute <= 0 && ctx.MulticastGroup.MaxAirtimePercentage <= 0 {
+		return nil
+	}
+
+	if err := incrementRateLimit(storage.RedisPool(), ctx.MulticastGroup, ctx.MulticastQueueItem.GatewayID, getTXTime(ctx.MulticastQueueItem), ctx.Airtime); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("increment multicast rate-limit counters error")
+	}
+
+	return nil
+}
+
+// getRateLimitDeferral returns the time until which a frame with the given
+// airtime, transmitted at the given time, must be deferred. It returns a
+// zero time when the frame can be transmitted. The first frame of the
+// airtime window is always allowed, even if its airtime exceeds the max.
+// airtime percentage.
+func getRateLimitDeferral(ctx context.Context, p storage.RedisClient, mg storage.MulticastGroup, gatewayID lorawan.EUI64, txTime time.Time, airtime time.Duration) (time.Time, error) {
+	var deferUntil time.Time
+
+	frameWindow := txTime.Truncate(frameRateWindow)
+	airtimeWindowStart := txTime.Truncate(airtimeWindow)
+
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.Int64s(c.Do("MGET",
+		fmt.Sprintf(rateLimitFramesKeyTempl, mg.ID, gatewayID, frameWindow.Unix()),
+		fmt.Sprintf(rateLimitAirtimeKeyTempl, mg.ID, gatewayID, airtimeWindowStart.Unix()),
+	))
+	if err != nil {
+		return deferUntil, errors.Wrap(err, "get rate-limit counters error")
+	}
+	frames, airtimeUsed := vals[0], time.Duration(vals[1])*time.Millisecond
+
+	if mg.MaxFramesPerMinute > 0 && frames >= int64(mg.MaxFramesPerMinute) {
+		deferUntil = frameWindow.Add(frameRateWindow)
+	}
+
+	if mg.MaxAirtimePercentage > 0 && airtimeUsed > 0 {
+		maxAirtime := time.Duration(float64(airtimeWindow) * mg.MaxAirtimePercentage / 100)
+		if airtimeUsed+airtime > maxAirtime {
+			if t := airtimeWindowStart.Add(airtimeWindow); t.After(deferUntil) {
+				deferUntil = t
+			}
+		}
+	}
+
+	return deferUntil, nil
+}
+
+// incrementRateLimit increments the frame and airtime counters for the
+// windows of the given transmission time.
+func incrementRateLimit(p storage.RedisClient, mg storage.MulticastGroup, gatewayID lorawan.EUI64, txTime time.Time, airtime time.Duration) error {
+	framesKey := fmt.Sprintf(rateLimitFramesKeyTempl, mg.ID, gatewayID, txTime.Truncate(frameRateWindow).Unix())
+	airtimeKey := fmt.Sprintf(rateLimitAirtimeKeyTempl, mg.ID, gatewayID, txTime.Truncate(airtimeWindow).Unix())
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("INCR", framesKey)
+	c.Send("PEXPIRE", framesKey, int64(2*frameRateWindow/time.Millisecond))
+	c.Send("INCRBY", airtimeKey, int64(airtime/time.Millisecond))
+	c.Send("PEXPIRE", airtimeKey, int64(2*airtimeWindow/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// getFrameAirtime returns the airtime of a multicast frame with the given
+// FRMPayload size, using the band of the given region.
+func getFrameAirtime(region string, dr, size int) (time.Duration, error) {
+	var txInfo gw.DownlinkTXInfo
+	if err := helpers.SetDownlinkTXInfoDataRate(&txInfo, dr, band.Get(region)); err != nil {
+		return 0, errors.Wrap(err, "set downlink tx-info data-rate error")
+	}
+
+	airtime, err := accounting.Airtime(&txInfo, size+frameOverhead, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "get airtime error")
+	}
+
+	return airtime, nil
+}
+
+// getTXTime returns the (expected) transmission time of the queue-item.
+func getTXTime(qi storage.MulticastQueueItem) time.Time {
+	if qi.EmitAtTimeSinceGPSEpoch != nil {
+		return time.Time(gps.NewFromTimeSinceGPSEpoch(*qi.EmitAtTimeSinceGPSEpoch))
+	}
+	return clock.Now()
+}
@@ -0,0 +1,330 @@
+package multicast
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// SetupFPort is the FPort of the LoRaWAN Remote Multicast Setup package.
+const SetupFPort = 200
+
+// Remote Multicast Setup package commands.
+const (
+	setupPackageVersion = 0x00
+	mcGroupStatus       = 0x01
+	mcGroupSetup        = 0x02
+	mcGroupDelete       = 0x03
+	mcClassCSession     = 0x04
+	mcClassBSession     = 0x05
+)
+
+// classBSessionTimeGranularity defines the granularity of the Class-B
+// session time (the start of a beacon-period).
+const classBSessionTimeGranularity = 128 * time.Second
+
+// StartSetup starts the remote multicast setup of the devices of the given
+// multicast-group, by enqueueing the McGroupSetupReq for each device. Once a
+// device has answered, the McClassCSessionReq or McClassBSessionReq is
+// enqueued (see HandleSetupAnswers). Devices for which the McGroupSetupReq
+// can not be enqueued (e.g. the AppSKey is not available) are marked as
+// failed.
+func StartSetup(ctx context.Context, p storage.RedisClient, db sqlx.Ext, s storage.MulticastGroupSetup) error {
+	mg, err := storage.GetMulticastGroup(ctx, db, s.MulticastGroupID, false)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	if err := storage.CreateMulticastGroupSetup(ctx, db, &s); err != nil {
+		return errors.Wrap(err, "create multicast-group setup error")
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(ctx, db, mg.ID)
+	if err != nil {
+		return errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	b, err := getMcGroupSetupReq(mg, s)
+	if err != nil {
+		return err
+	}
+
+	for _, devEUI := range devEUIs {
+		d := storage.MulticastGroupSetupDevice{
+			MulticastGroupID: mg.ID,
+			DevEUI:           devEUI,
+			State:            storage.MulticastSetupGroupPending,
+		}
+
+		if err := enqueueSetupCommand(ctx, p, db, devEUI, b); err != nil {
+			d.State = storage.MulticastSetupFailed
+			d.Error = err.Error()
+		}
+
+		if err := storage.SaveMulticastGroupSetupDevice(ctx, db, &d); err != nil {
+			return errors.Wrap(err, "save multicast-group setup device error")
+		}
+	}
+
+	return nil
+}
+
+// HandleSetupAnswers handles the given (decrypted) Remote Multicast Setup
+// package answers of the given device and updates the setup status of the
+// device. As the length of an unknown command is not known, the handling
+// stops at the first unknown command.
+func HandleSetupAnswers(ctx context.Context, p storage.RedisClient, db sqlx.Ext, devEUI lorawan.EUI64, b []byte) error {
+	for len(b) != 0 {
+		cid := b[0]
+		b = b[1:]
+
+		var size int
+		switch cid {
+		case setupPackageVersion:
+			size = 2
+		case mcGroupStatus:
+			if len(b) == 0 {
+				return errors.New("McGroupStatusAns payload must be at least 1 byte")
+			}
+			// status + McGroupID and McAddr for each group in the mask
+			size = 1
+			for mask := b[0] & 0x0f; mask != 0; mask &= mask - 1 {
+				size += 5
+			}
+		case mcGroupSetup, mcGroupDelete:
+			size = 1
+		case mcClassCSession, mcClassBSession:
+			if len(b) == 0 {
+				return errors.New("session answer payload must be at least 1 byte")
+			}
+			// the TimeToStart is only present without errors
+			size = 1
+			if b[0]&0x1c == 0 {
+				size = 4
+			}
+		default:
+			return fmt.Errorf("unknown command: %d", cid)
+		}
+
+		if len(b) < size {
+			return fmt.Errorf("payload of command %d must be %d bytes", cid, size)
+		}
+		pl := b[:size]
+		b = b[size:]
+
+		var err error
+		switch cid {
+		case mcGroupSetup:
+			err = handleMcGroupSetupAns(ctx, p, db, devEUI, pl)
+		case mcClassCSession, mcClassBSession:
+			err = handleMcSessionAns(ctx, db, devEUI, pl)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func handleMcGroupSetupAns(ctx context.Context, p storage.RedisClient, db sqlx.Ext, devEUI lorawan.EUI64, pl []byte) error {
+	d, s, err := storage.GetMulticastGroupSetupDeviceForMcGroupID(ctx, db, devEUI, int(pl[0]&0x03))
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get multicast-group setup device error")
+	}
+
+	if d.State != storage.MulticastSetupGroupPending {
+		return nil
+	}
+
+	if pl[0]&0x04 != 0 {
+		d.State = storage.MulticastSetupFailed
+		d.Error = "McGroupID error"
+		return saveSetupDevice(ctx, db, d)
+	}
+
+	mg, err := storage.GetMulticastGroup(ctx, db, s.MulticastGroupID, false)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-group error")
+	}
+
+	b, err := getMcSessionReq(mg, s)
+	if err != nil {
+		return err
+	}
+
+	d.State = storage.MulticastSetupSessionPending
+	if err := enqueueSetupCommand(ctx, p, db, devEUI, b); err != nil {
+		d.State = storage.MulticastSetupFailed
+		d.Error = err.Error()
+	}
+
+	return saveSetupDevice(ctx, db, d)
+}
+
+func handleMcSessionAns(ctx context.Context, db sqlx.Ext, devEUI lorawan.EUI64, pl []byte) error {
+	d, _, err := storage.GetMulticastGroupSetupDeviceForMcGroupID(ctx, db, devEUI, int(pl[0]&0x03))
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get multicast-group setup device error")
+	}
+
+	if d.State != storage.MulticastSetupSessionPending {
+		return nil
+	}
+
+	d.State = storage.MulticastSetupCompleted
+	switch {
+	case pl[0]&0x10 != 0:
+		d.State = storage.MulticastSetupFailed
+		d.Error = "McGroup undefined"
+	case pl[0]&0x0c == 0x0c:
+		d.State = storage.MulticastSetupFailed
+		d.Error = "DR and frequency error"
+	case pl[0]&0x08 != 0:
+		d.State = storage.MulticastSetupFailed
+		d.Error = "frequency error"
+	case pl[0]&0x04 != 0:
+		d.State = storage.MulticastSetupFailed
+		d.Error = "DR error"
+	}
+
+	return saveSetupDevice(ctx, db, d)
+}
+
+func saveSetupDevice(ctx context.Context, db sqlx.Execer, d storage.MulticastGroupSetupDevice) error {
+	if err := storage.SaveMulticastGroupSetupDevice(ctx, db, &d); err != nil {
+		return errors.Wrap(err, "save multicast-group setup device error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": d.MulticastGroupID,
+		"dev_eui":            d.DevEUI,
+		"state":              d.State,
+		"error":              d.Error,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast: device setup state changed")
+
+	return nil
+}
+
+// enqueueSetupCommand encrypts the given command(s) using the AppSKey of the
+// device and enqueues these on the Remote Multicast Setup FPort.
+func enqueueSetupCommand(ctx context.Context, p storage.RedisClient, db sqlx.Ext, devEUI lorawan.EUI64, b []byte) error {
+	ds, err := storage.GetDeviceSession(ctx, p, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get device-session error")
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx, ds)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return errors.New("AppSKey not available")
+		}
+		return errors.Wrap(err, "get AppSKey error")
+	}
+
+	fCnt, err := storage.GetNextDeviceQueueItemFCnt(ctx, db, ds)
+	if err != nil {
+		return errors.Wrap(err, "get next device-queue item fcnt error")
+	}
+
+	frmPayload, err := lorawan.EncryptFRMPayload(appSKey, false, ds.DevAddr, fCnt, b)
+	if err != nil {
+		return errors.Wrap(err, "encrypt FRMPayload error")
+	}
+
+	qi := storage.DeviceQueueItem{
+		DevAddr:    ds.DevAddr,
+		DevEUI:     ds.DevEUI,
+		FRMPayload: frmPayload,
+		FCnt:       fCnt,
+		FPort:      SetupFPort,
+	}
+	if err := storage.CreateDeviceQueueItem(ctx, db, &qi); err != nil {
+		return errors.Wrap(err, "create device-queue item error")
+	}
+
+	return nil
+}
+
+// getMcGroupSetupReq returns the McGroupSetupReq command.
+func getMcGroupSetupReq(mg storage.MulticastGroup, s storage.MulticastGroupSetup) ([]byte, error) {
+	mcAddr, err := mg.MCAddr.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal mc_addr error")
+	}
+
+	b := make([]byte, 30)
+	b[0] = mcGroupSetup
+	b[1] = uint8(s.McGroupID) & 0x03
+	copy(b[2:6], mcAddr)
+	copy(b[6:22], s.McKeyEncrypted)
+	binary.LittleEndian.PutUint32(b[22:26], s.MinMcFCnt)
+	binary.LittleEndian.PutUint32(b[26:30], s.MaxMcFCnt)
+
+	return b, nil
+}
+
+// getMcSessionReq returns the McClassCSessionReq or McClassBSessionReq
+// command, depending the multicast-group type.
+func getMcSessionReq(mg storage.MulticastGroup, s storage.MulticastGroupSetup) ([]byte, error) {
+	sessionTime := gps.Time(s.SessionTime).TimeSinceGPSEpoch()
+
+	b := make([]byte, 11)
+	b[0] = mcClassCSession
+	b[1] = uint8(s.McGroupID) & 0x03
+	b[6] = uint8(s.SessionTimeOut) & 0x0f
+
+	if mg.GroupType == storage.MulticastGroupB {
+		// the session must start at a beacon-period
+		if r := sessionTime % classBSessionTimeGranularity; r != 0 {
+			sessionTime += classBSessionTimeGranularity - r
+		}
+
+		periodicity, err := getPingSlotPeriodicity(mg.PingSlotPeriod)
+		if err != nil {
+			return nil, err
+		}
+
+		b[0] = mcClassBSession
+		b[6] |= periodicity << 4
+	}
+
+	binary.LittleEndian.PutUint32(b[2:6], uint32(sessionTime/time.Second))
+
+	freq := uint32(mg.Frequency / 100)
+	b[7] = uint8(freq)
+	b[8] = uint8(freq >> 8)
+	b[9] = uint8(freq >> 16)
+	b[10] = uint8(mg.DR)
+
+	return b, nil
+}
+
+// getPingSlotPeriodicity returns the periodicity (0 - 7) for the given
+// ping-slot period (32 * 2^periodicity).
+func getPingSlotPeriodicity(pingSlotPeriod int) (uint8, error) {
+	for i := uint8(0); i < 8; i++ {
+		if 32<<i == pingSlotPeriod {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid ping-slot period: %d", pingSlotPeriod)
+}
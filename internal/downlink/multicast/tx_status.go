@@ -0,0 +1,304 @@
+package multicast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	pendingTXKeyTempl = "lora:ns:mg:tx:%d"
+	txStatusKeyTempl  = "lora:ns:mg:%s:txstatus"
+
+	// pendingTXTTL defines how long a sent multicast frame waits for its
+	// tx acknowledgement.
+	pendingTXTTL = time.Minute
+)
+
+// TXState defines the transmission state of a multicast frame for a
+// single gateway.
+type TXState string
+
+// Available transmission states.
+const (
+	TXPending TXState = "pending"
+	TXAcked   TXState = "acked"
+	TXFailed  TXState = "failed"
+)
+
+var (
+	txRetries   int
+	txStatusTTL = 24 * time.Hour
+)
+
+// GatewayTXStatus defines the transmission status of a multicast frame for
+// a single gateway.
+type GatewayTXStatus struct {
+	GatewayID lorawan.EUI64 `json:"gatewayID"`
+	State     TXState       `json:"state"`
+	Error     string        `json:"error"`
+	Retries   int           `json:"retries"`
+}
+
+// FrameTXStatus defines the transmission status of a multicast frame.
+type FrameTXStatus struct {
+	FCnt     uint32
+	Gateways []GatewayTXStatus
+}
+
+// saveTXStatus stores the queue-item under the token of the sent frame, so
+// that it can be correlated with the tx acknowledgement of the gateway, and
+// marks the frame as pending for the gateway.
+func saveTXStatus(ctx *multicastContext) error {
+	if err := txSent(ctx.ctx, storage.RedisPool(), ctx.Token, ctx.MulticastQueueItem); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"multicast_group_id": ctx.MulticastGroup.ID,
+			"ctx_id":             ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save multicast tx status error")
+	}
+
+	return nil
+}
+
+func txSent(ctx context.Context, p storage.RedisClient, token uint16, qi storage.MulticastQueueItem) error {
+	s, err := getGatewayTXStatus(p, qi.MulticastGroupID, qi.FCnt, qi.GatewayID)
+	if err != nil {
+		return err
+	}
+	s.State = TXPending
+
+	b, err := json.Marshal(qi)
+	if err != nil {
+		return errors.Wrap(err, "marshal queue-item error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err = c.Do("PSETEX", fmt.Sprintf(pendingTXKeyTempl, token), int64(pendingTXTTL/time.Millisecond), b)
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return saveGatewayTXStatus(p, qi.MulticastGroupID, qi.FCnt, s)
+}
+
+// HandleDownlinkTXAck updates the transmission status of the multicast frame
+// matching the given token. When the gateway reports an error, the frame is
+// re-scheduled for this gateway until the max. number of retries has been
+// reached. It does nothing when the token does not match a multicast frame.
+func HandleDownlinkTXAck(ctx context.Context, p storage.RedisClient, db sqlx.Ext, token uint16, ack gw.DownlinkTXAck) error {
+	qi, err := popPendingTX(p, token)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get pending multicast frame error")
+	}
+
+	s, err := getGatewayTXStatus(p, qi.MulticastGroupID, qi.FCnt, qi.GatewayID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ack.Error == "":
+		s.State = TXAcked
+	case s.Retries < txRetries:
+		s.State = TXPending
+		s.Error = ack.Error
+		s.Retries++
+
+		if err := retryQueueItem(ctx, db, qi); err != nil {
+			return errors.Wrap(err, "retry multicast queue-item error")
+		}
+	default:
+		s.State = TXFailed
+		s.Error = ack.Error
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": qi.MulticastGroupID,
+		"gateway_id":         qi.GatewayID,
+		"f_cnt":              qi.FCnt,
+		"state":              s.State,
+		"error":              s.Error,
+		"retries":            s.Retries,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast: tx acknowledgement received")
+
+	return saveGatewayTXStatus(p, qi.MulticastGroupID, qi.FCnt, s)
+}
+
+// GetTXStatus returns the transmission status of the frames of the given
+// multicast-group, ordered by frame-counter.
+func GetTXStatus(ctx context.Context, p storage.RedisClient, multicastGroupID uuid.UUID) ([]FrameTXStatus, error) {
+	c := p.Get()
+	defer c.Close()
+
+	vals, err := redis.ByteSlices(c.Do("HVALS", fmt.Sprintf(txStatusKeyTempl, multicastGroupID)))
+	if err != nil {
+		return nil, errors.Wrap(err, "hvals error")
+	}
+
+	frames := make(map[uint32][]GatewayTXStatus)
+	for _, b := range vals {
+		var s struct {
+			GatewayTXStatus
+			FCnt uint32 `json:"fCnt"`
+		}
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, errors.Wrap(err, "unmarshal tx status error")
+		}
+		frames[s.FCnt] = append(frames[s.FCnt], s.GatewayTXStatus)
+	}
+
+	var out []FrameTXStatus
+	for fCnt, gateways := range frames {
+		sort.Slice(gateways, func(i, j int) bool {
+			return eui64Int64(gateways[i].GatewayID) < eui64Int64(gateways[j].GatewayID)
+		})
+
+		out = append(out, FrameTXStatus{
+			FCnt:     fCnt,
+			Gateways: gateways,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].FCnt < out[j].FCnt
+	})
+
+	return out, nil
+}
+
+// retryQueueItem re-creates the given queue-item, scheduled at the next
+// transmission opportunity.
+func retryQueueItem(ctx context.Context, db sqlx.Ext, qi storage.MulticastQueueItem) error {
+	qi.ID = 0
+
+	if qi.EmitAtTimeSinceGPSEpoch == nil {
+		qi.ScheduleAt = clock.Now().Add(downlinkLockDuration)
+	} else {
+		mg, err := storage.GetMulticastGroup(ctx, db, qi.MulticastGroupID, false)
+		if err != nil {
+			return errors.Wrap(err, "get multicast-group error")
+		}
+
+		var pingSlotNb int
+		if mg.PingSlotPeriod != 0 {
+			pingSlotNb = (1 << 12) / mg.PingSlotPeriod
+		}
+
+		emitAt, err := classb.GetNextPingSlotAfter(gps.Time(clock.Now().Add(classBEnqueueMargin)).TimeSinceGPSEpoch(), mg.MCAddr, pingSlotNb)
+		if err != nil {
+			return errors.Wrap(err, "get next ping-slot after error")
+		}
+
+		qi.EmitAtTimeSinceGPSEpoch = &emitAt
+		qi.ScheduleAt = time.Time(gps.NewFromTimeSinceGPSEpoch(emitAt)).Add(-2 * schedulerInterval)
+	}
+
+	if err := storage.CreateMulticastQueueItem(ctx, db, &qi); err != nil {
+		return errors.Wrap(err, "create multicast queue-item error")
+	}
+
+	return nil
+}
+
+func popPendingTX(p storage.RedisClient, token uint16) (storage.MulticastQueueItem, error) {
+	var qi storage.MulticastQueueItem
+	key := fmt.Sprintf(pendingTXKeyTempl, token)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return qi, errors.Wrap(err, "exec error")
+	}
+
+	b, err := redis.Bytes(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return qi, storage.ErrDoesNotExist
+		}
+		return qi, errors.Wrap(err, "get error")
+	}
+
+	if err := json.Unmarshal(b, &qi); err != nil {
+		return qi, errors.Wrap(err, "unmarshal queue-item error")
+	}
+
+	return qi, nil
+}
+
+func getGatewayTXStatus(p storage.RedisClient, multicastGroupID uuid.UUID, fCnt uint32, gatewayID lorawan.EUI64) (GatewayTXStatus, error) {
+	s := GatewayTXStatus{
+		GatewayID: gatewayID,
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("HGET", fmt.Sprintf(txStatusKeyTempl, multicastGroupID), txStatusField(fCnt, gatewayID)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return s, nil
+		}
+		return s, errors.Wrap(err, "hget error")
+	}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, errors.Wrap(err, "unmarshal tx status error")
+	}
+
+	return s, nil
+}
+
+func saveGatewayTXStatus(p storage.RedisClient, multicastGroupID uuid.UUID, fCnt uint32, s GatewayTXStatus) error {
+	b, err := json.Marshal(struct {
+		GatewayTXStatus
+		FCnt uint32 `json:"fCnt"`
+	}{s, fCnt})
+	if err != nil {
+		return errors.Wrap(err, "marshal tx status error")
+	}
+
+	key := fmt.Sprintf(txStatusKeyTempl, multicastGroupID)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HSET", key, txStatusField(fCnt, s.GatewayID), b)
+	c.Send("PEXPIRE", key, int64(txStatusTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+func txStatusField(fCnt uint32, gatewayID lorawan.EUI64) string {
+	return fmt.Sprintf("%d:%s", fCnt, gatewayID)
+}
@@ -5,4 +5,5 @@ import "errors"
 // errors
 var (
 	ErrInvalidDataRate = errors.New("invalid data-rate")
+	ErrEmitTimeInPast  = errors.New("emit time is in the past")
 )
@@ -4,23 +4,30 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/common"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 const defaultCodeRate = "4/5"
 
 var tasks = []func(*proprietaryContext) error{
+	validateEmitTime,
 	setToken,
 	sendProprietaryDown,
 }
@@ -35,6 +42,10 @@ type proprietaryContext struct {
 	IPol        bool
 	Frequency   int
 	DR          int
+
+	// EmitAtTimeSinceGPSEpoch is set when the frame must be transmitted by
+	// all gateways at the same (GPS) time.
+	EmitAtTimeSinceGPSEpoch *time.Duration
 }
 
 var (
@@ -48,16 +59,19 @@ func Setup(conf config.Config) error {
 	return nil
 }
 
-// Handle handles a proprietary downlink.
-func Handle(ctx context.Context, macPayload []byte, mic lorawan.MIC, gwMACs []lorawan.EUI64, iPol bool, frequency, dr int) error {
+// Handle handles a proprietary downlink. When emitAt is not nil, the frame is
+// transmitted by all gateways at the given time since GPS epoch, else it is
+// transmitted immediately.
+func Handle(ctx context.Context, macPayload []byte, mic lorawan.MIC, gwMACs []lorawan.EUI64, iPol bool, frequency, dr int, emitAt *time.Duration) error {
 	pctx := proprietaryContext{
-		ctx:         ctx,
-		MACPayload:  macPayload,
-		MIC:         mic,
-		GatewayMACs: gwMACs,
-		IPol:        iPol,
-		Frequency:   frequency,
-		DR:          dr,
+		ctx:                     ctx,
+		MACPayload:              macPayload,
+		MIC:                     mic,
+		GatewayMACs:             gwMACs,
+		IPol:                    iPol,
+		Frequency:               frequency,
+		DR:                      dr,
+		EmitAtTimeSinceGPSEpoch: emitAt,
 	}
 
 	for _, t := range tasks {
@@ -69,6 +83,18 @@ func Handle(ctx context.Context, macPayload []byte, mic lorawan.MIC, gwMACs []lo
 	return nil
 }
 
+func validateEmitTime(ctx *proprietaryContext) error {
+	if ctx.EmitAtTimeSinceGPSEpoch == nil {
+		return nil
+	}
+
+	if *ctx.EmitAtTimeSinceGPSEpoch <= gps.Time(clock.Now()).TimeSinceGPSEpoch() {
+		return ErrEmitTimeInPast
+	}
+
+	return nil
+}
+
 func setToken(ctx *proprietaryContext) error {
 	b := make([]byte, 2)
 	_, err := rand.Read(b)
@@ -80,13 +106,6 @@ func setToken(ctx *proprietaryContext) error {
 }
 
 func sendProprietaryDown(ctx *proprietaryContext) error {
-	var txPower int
-	if downlinkTXPower != -1 {
-		txPower = downlinkTXPower
-	} else {
-		txPower = band.Band().GetDownlinkTXPower(ctx.Frequency)
-	}
-
 	var downID uuid.UUID
 	if ctxID := ctx.ctx.Value(logging.ContextIDKey); ctxID != nil {
 		if id, ok := ctxID.(uuid.UUID); ok {
@@ -108,18 +127,36 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 	}
 
 	for _, mac := range ctx.GatewayMACs {
+		b := band.Get(storage.GetGatewayRegion(ctx.ctx, storage.DB(), mac))
+
+		var txPower int
+		if downlinkTXPower != -1 {
+			txPower = downlinkTXPower
+		} else {
+			txPower = storage.GetDownlinkTXPowerForGateway(ctx.ctx, storage.DB(), storage.RedisPool(), mac, 0, 0, b.GetDownlinkTXPower(ctx.Frequency))
+		}
+
 		txInfo := gw.DownlinkTXInfo{
 			GatewayId: mac[:],
 			Frequency: uint32(ctx.Frequency),
 			Power:     int32(txPower),
+		}
 
-			Timing: gw.DownlinkTiming_IMMEDIATELY,
-			TimingInfo: &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
+		if ctx.EmitAtTimeSinceGPSEpoch == nil {
+			txInfo.Timing = gw.DownlinkTiming_IMMEDIATELY
+			txInfo.TimingInfo = &gw.DownlinkTXInfo_ImmediatelyTimingInfo{
 				ImmediatelyTimingInfo: &gw.ImmediatelyTimingInfo{},
-			},
+			}
+		} else {
+			txInfo.Timing = gw.DownlinkTiming_GPS_EPOCH
+			txInfo.TimingInfo = &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+				GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+					TimeSinceGpsEpoch: ptypes.DurationProto(*ctx.EmitAtTimeSinceGPSEpoch),
+				},
+			}
 		}
 
-		err = helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.DR, band.Band())
+		err = helpers.SetDownlinkTXInfoDataRate(&txInfo, ctx.DR, b)
 		if err != nil {
 			return errors.Wrap(err, "set downlink tx-info data-rate error")
 		}
@@ -132,12 +169,21 @@ func sendProprietaryDown(ctx *proprietaryContext) error {
 			}
 		}
 
-		if err := gateway.Backend().SendTXPacket(gw.DownlinkFrame{
+		downlinkFrame := gw.DownlinkFrame{
 			Token:      uint32(ctx.Token),
 			DownlinkId: downID[:],
 			TxInfo:     &txInfo,
 			PhyPayload: phyB,
-		}); err != nil {
+		}
+
+		if err := storage.SetDownlinkTXAckPending(ctx.ctx, storage.RedisPool(), downlinkFrame); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": mac,
+				"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+			}).Error("set downlink tx acknowledgement pending error")
+		}
+
+		if err := gateway.Backend().SendTXPacket(downlinkFrame); err != nil {
 			return errors.Wrap(err, "send tx packet to gateway error")
 		}
 	}
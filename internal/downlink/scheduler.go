@@ -2,21 +2,22 @@ package downlink
 
 import (
 	"context"
-	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
+	"github.com/mxc-foundation/lpwan-server/internal/leader"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 // DeviceQueueSchedulerLoop starts an infinit loop calling the scheduler loop for Class-B
-// and Class-C sheduling.
+// and Class-C sheduling. Batches are only scheduled by the leader instance.
 func DeviceQueueSchedulerLoop() {
 	for {
 		ctx := context.Background()
@@ -30,17 +31,27 @@ func DeviceQueueSchedulerLoop() {
 			"ctx_id": ctxID,
 		}).Debug("running class-b / class-c scheduler batch")
 
+		if err := leader.Fence(ctx, storage.RedisPool()); err != nil {
+			if err != leader.ErrNotLeader {
+				log.WithFields(log.Fields{
+					"ctx_id": ctxID,
+				}).WithError(err).Error("class-b / class-c scheduler fence error")
+			}
+			clock.Sleep(schedulerInterval)
+			continue
+		}
+
 		if err := ScheduleDeviceQueueBatch(ctx, schedulerBatchSize); err != nil {
 			log.WithFields(log.Fields{
 				"ctx_id": ctxID,
 			}).WithError(err).Error("class-b / class-c scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+		clock.Sleep(schedulerInterval)
 	}
 }
 
 // MulticastQueueSchedulerLoop starts an infinit loop calling the multicast
-// scheduler loop.
+// scheduler loop. Batches are only scheduled by the leader instance.
 func MulticastQueueSchedulerLoop() {
 	for {
 		ctx := context.Background()
@@ -54,12 +65,22 @@ func MulticastQueueSchedulerLoop() {
 			"ctx_id": ctxID,
 		}).Debug("running multicast scheduler batch")
 
+		if err := leader.Fence(ctx, storage.RedisPool()); err != nil {
+			if err != leader.ErrNotLeader {
+				log.WithFields(log.Fields{
+					"ctx_id": ctxID,
+				}).WithError(err).Error("multicast scheduler fence error")
+			}
+			clock.Sleep(schedulerInterval)
+			continue
+		}
+
 		if err := ScheduleMulticastQueueBatch(ctx, schedulerBatchSize); err != nil {
 			log.WithFields(log.Fields{
 				"ctx_id": ctxID,
 			}).WithError(err).Error("multicast scheduler error")
 		}
-		time.Sleep(schedulerInterval)
+		clock.Sleep(schedulerInterval)
 	}
 }
 
@@ -0,0 +1,65 @@
+package dryrun
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// asPool returns an application-server client which drops all requests.
+type asPool struct{}
+
+func (p *asPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
+	return &asClient{hostname: hostname}, nil
+}
+
+type asClient struct {
+	hostname string
+}
+
+func (c *asClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleUplinkData")
+}
+
+func (c *asClient) HandleUplinkDataBatch(ctx context.Context, in *as.HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleUplinkDataBatch")
+}
+
+func (c *asClient) HandleProprietaryUplink(ctx context.Context, in *as.HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleProprietaryUplink")
+}
+
+func (c *asClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleError")
+}
+
+func (c *asClient) HandleDownlinkACK(ctx context.Context, in *as.HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleDownlinkACK")
+}
+
+func (c *asClient) HandleGatewayStats(ctx context.Context, in *as.HandleGatewayStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "HandleGatewayStats")
+}
+
+func (c *asClient) SetDeviceStatus(ctx context.Context, in *as.SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "SetDeviceStatus")
+}
+
+func (c *asClient) SetDeviceLocation(ctx context.Context, in *as.SetDeviceLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.drop(ctx, "SetDeviceLocation")
+}
+
+func (c *asClient) drop(ctx context.Context, method string) (*empty.Empty, error) {
+	log.WithFields(log.Fields{
+		"hostname": c.hostname,
+		"method":   method,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}).Debug("dry-run: application-server request not sent")
+
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,61 @@
+// Package dryrun implements the dry-run (shadow) mode. In this mode, LoRa
+// Server handles the uplink frames as usual (device-sessions, ADR, frame-logs
+// and metrics are updated), but it never transmits to the gateways and it
+// never contacts the application-server, the join-server, the webhook and
+// downlink payload hook endpoints or the roaming partners. This makes it
+// possible to validate a new version against live traffic, using a copy of
+// the production database.
+package dryrun
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/downlinkhook"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/roaming"
+)
+
+// ErrDryRun is returned by the join-server client in dry-run mode.
+var ErrDryRun = errors.New("dry-run mode, join-server is not contacted")
+
+var enabled int32
+
+// Setup replaces the gateway backend, application-server pool, join-server
+// pool, webhook and downlink payload hook HTTP clients and roaming-server
+// clients by their dry-run variants when dry-run mode is enabled. This must
+// be called after these have been set up.
+func Setup(conf config.Config) error {
+	if !conf.NetworkServer.DryRun {
+		atomic.StoreInt32(&enabled, 0)
+		return nil
+	}
+
+	if gwbackend.Backend() == nil {
+		return errors.New("dry-run: gateway backend is not configured")
+	}
+
+	log.Warning("dry-run: dry-run mode enabled, downlinks will not be transmitted and the application-server, join-server, webhooks, downlink hooks and roaming partners will not be contacted")
+
+	gwbackend.SetBackend(&gatewayBackend{Gateway: gwbackend.Backend()})
+	applicationserver.SetPool(&asPool{})
+	joinserver.SetPool(&jsPool{})
+	webhook.SetHTTPClient(&http.Client{Transport: &httpTransport{}})
+	downlinkhook.SetHTTPClient(&http.Client{Transport: &httpTransport{}})
+	roaming.SetNewClientFunc(newRoamingClient)
+	atomic.StoreInt32(&enabled, 1)
+
+	return nil
+}
+
+// Enabled returns true when dry-run mode is enabled.
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) == 1
+}
@@ -0,0 +1,117 @@
+package dryrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/downlinkhook"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/roamingserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestDryRun(t *testing.T) {
+	assert := require.New(t)
+
+	gwBackend := test.NewGatewayBackend()
+	gwbackend.SetBackend(gwBackend)
+
+	requests := make(chan *http.Request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+	}))
+	defer server.Close()
+
+	var conf config.Config
+	assert.NoError(Setup(conf))
+	assert.False(Enabled())
+
+	conf.NetworkServer.DryRun = true
+	assert.NoError(Setup(conf))
+	defer Setup(config.Config{})
+	assert.True(Enabled())
+
+	t.Run("Downlinks are not transmitted", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(gwbackend.Backend().SendTXPacket(gw.DownlinkFrame{
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		}))
+		assert.NoError(gwbackend.Backend().SendGatewayConfigPacket(gw.GatewayConfiguration{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		}))
+		assert.Len(gwBackend.TXPacketChan, 0)
+		assert.Len(gwBackend.GatewayConfigPacketChan, 0)
+	})
+
+	t.Run("Uplinks are received", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Equal(gwBackend.RXPacketChan(), gwbackend.Backend().RXPacketChan())
+	})
+
+	t.Run("Application-server is not contacted", func(t *testing.T) {
+		assert := require.New(t)
+
+		client, err := applicationserver.Pool().Get("as:8001", nil, nil, nil)
+		assert.NoError(err)
+		_, err = client.HandleUplinkData(context.Background(), &as.HandleUplinkDataRequest{})
+		assert.NoError(err)
+	})
+
+	t.Run("Join-server is not contacted", func(t *testing.T) {
+		assert := require.New(t)
+
+		client, err := joinserver.GetPool().Get(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+		assert.NoError(err)
+		_, err = client.JoinReq(context.Background(), backend.JoinReqPayload{})
+		assert.Equal(ErrDryRun, err)
+	})
+
+	t.Run("Webhooks are not sent", func(t *testing.T) {
+		assert := require.New(t)
+
+		webhook.Send(context.Background(), webhook.Endpoint{URL: server.URL}, webhook.EventJoin, webhook.JoinEvent{})
+
+		select {
+		case <-requests:
+			assert.Fail("webhook was sent")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("Downlink hook is not invoked", func(t *testing.T) {
+		assert := require.New(t)
+
+		resp, err := downlinkhook.Invoke(context.Background(), downlinkhook.Endpoint{URL: server.URL}, downlinkhook.Request{
+			FRMPayload: []byte{1, 2, 3},
+		})
+		assert.NoError(err)
+		assert.Equal(downlinkhook.Response{}, resp)
+		assert.Len(requests, 0)
+	})
+
+	t.Run("Uplinks are not forwarded to roaming partners", func(t *testing.T) {
+		assert := require.New(t)
+
+		client, err := newRoamingClient(server.URL, "", "", "")
+		assert.NoError(err)
+		ans, err := client.PRStartReq(context.Background(), roamingserver.PRStartReqPayload{})
+		assert.NoError(err)
+		assert.Equal(backend.Success, ans.Result.ResultCode)
+		assert.Len(requests, 0)
+	})
+}
@@ -0,0 +1,86 @@
+package dryrun
+
+import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+)
+
+// gatewayBackend wraps a gateway backend. It consumes the uplink frames,
+// gateway stats and tx acknowledgements from the wrapped backend, but it
+// drops all downlink frames and gateway configurations.
+type gatewayBackend struct {
+	gwbackend.Gateway
+}
+
+// SendTXPacket logs and drops the given downlink frame.
+func (b *gatewayBackend) SendTXPacket(pl gw.DownlinkFrame) error {
+	log.WithFields(log.Fields{
+		"gateway_id":  helpers.GetGatewayID(pl.TxInfo),
+		"downlink_id": helpers.GetDownlinkID(&pl),
+		"token":       pl.Token,
+	}).Info("dry-run: downlink frame not transmitted")
+
+	return nil
+}
+
+// SendGatewayConfigPacket logs and drops the given gateway configuration.
+func (b *gatewayBackend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&pl),
+		"version":    pl.Version,
+	}).Info("dry-run: gateway configuration not sent")
+
+	return nil
+}
+
+// ConnStateChan returns the connection state channel of the wrapped backend.
+// It returns nil when not supported by the wrapped backend.
+func (b *gatewayBackend) ConnStateChan() chan gw.ConnState {
+	if csb, ok := b.Gateway.(gwbackend.ConnStateBackend); ok {
+		return csb.ConnStateChan()
+	}
+	return nil
+}
+
+// SendGatewayCommandExecRequest logs and drops the given command execution
+// request.
+func (b *gatewayBackend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	log.WithFields(log.Fields{
+		"gateway_id": helpers.GetGatewayID(&pl),
+		"command":    pl.Command,
+	}).Info("dry-run: gateway command execution request not sent")
+
+	return nil
+}
+
+// GatewayCommandExecResponseChan returns the command execution response
+// channel of the wrapped backend. It returns nil when not supported by the
+// wrapped backend.
+func (b *gatewayBackend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	if ceb, ok := b.Gateway.(gwbackend.CommandExecBackend); ok {
+		return ceb.GatewayCommandExecResponseChan()
+	}
+	return nil
+}
+
+// NoiseFloorReportChan returns the noise-floor report channel of the wrapped
+// backend. It returns nil when not supported by the wrapped backend.
+func (b *gatewayBackend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	if nfb, ok := b.Gateway.(gwbackend.NoiseFloorBackend); ok {
+		return nfb.NoiseFloorReportChan()
+	}
+	return nil
+}
+
+// UpdateCredentials updates the credentials of the wrapped backend (when
+// supported).
+func (b *gatewayBackend) UpdateCredentials(conf config.Config) error {
+	if u, ok := b.Gateway.(gwbackend.CredentialsUpdater); ok {
+		return u.UpdateCredentials(conf)
+	}
+	return nil
+}
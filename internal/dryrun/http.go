@@ -0,0 +1,37 @@
+package dryrun
+
+import (
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// httpTransport drops all HTTP requests and responds with an empty
+// 204 No Content response. It is used for the webhooks and the downlink
+// payload hook, for which an empty response leaves the payload unmodified.
+type httpTransport struct{}
+
+// RoundTrip logs and drops the given request.
+func (t *httpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	// the query is not logged as it might contain credentials
+	log.WithFields(log.Fields{
+		"method": req.Method,
+		"host":   req.URL.Host,
+		"path":   req.URL.Path,
+	}).Info("dry-run: http request not sent")
+
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package dryrun
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// jsPool returns a join-server client which fails all requests with
+// ErrDryRun, as without join-server there is no join-accept to continue
+// with.
+type jsPool struct{}
+
+func (p *jsPool) Get(joinEUI lorawan.EUI64) (joinserver.Client, error) {
+	return &jsClient{joinEUI: joinEUI}, nil
+}
+
+type jsClient struct {
+	joinEUI lorawan.EUI64
+}
+
+func (c *jsClient) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+	log.WithFields(log.Fields{
+		"join_eui": c.joinEUI,
+		"dev_eui":  pl.DevEUI,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}).Info("dry-run: join-request not sent to join-server")
+
+	return backend.JoinAnsPayload{}, ErrDryRun
+}
+
+func (c *jsClient) RejoinReq(ctx context.Context, pl backend.RejoinReqPayload) (backend.RejoinAnsPayload, error) {
+	log.WithFields(log.Fields{
+		"join_eui": c.joinEUI,
+		"dev_eui":  pl.DevEUI,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}).Info("dry-run: rejoin-request not sent to join-server")
+
+	return backend.RejoinAnsPayload{}, ErrDryRun
+}
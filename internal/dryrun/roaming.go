@@ -0,0 +1,36 @@
+package dryrun
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/roamingserver"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// newRoamingClient returns a roaming-server client which drops all requests.
+func newRoamingClient(server, caCert, tlsCert, tlsKey string) (roamingserver.Client, error) {
+	return &roamingClient{server: server}, nil
+}
+
+type roamingClient struct {
+	server string
+}
+
+// PRStartReq logs and drops the given request. It returns a successful
+// answer, so that the uplink is handled as forwarded.
+func (c *roamingClient) PRStartReq(ctx context.Context, pl roamingserver.PRStartReqPayload) (roamingserver.PRStartAnsPayload, error) {
+	log.WithFields(log.Fields{
+		"server":      c.server,
+		"receiver_id": pl.ReceiverID,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("dry-run: uplink not forwarded to roaming-server")
+
+	return roamingserver.PRStartAnsPayload{
+		Result: backend.Result{
+			ResultCode: backend.Success,
+		},
+	}, nil
+}
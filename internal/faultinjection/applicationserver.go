@@ -0,0 +1,85 @@
+package faultinjection
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/api/client/asclient"
+)
+
+// asPool wraps an application-server pool. The application-server rules
+// are applied to each request, using the method name as operation.
+type asPool struct {
+	pool asclient.Pool
+}
+
+func (p *asPool) Get(hostname string, caCert, tlsCert, tlsKey []byte) (as.ApplicationServerServiceClient, error) {
+	client, err := p.pool.Get(hostname, caCert, tlsCert, tlsKey)
+	if err != nil {
+		return nil, err
+	}
+	return &asClient{client: client}, nil
+}
+
+type asClient struct {
+	client as.ApplicationServerServiceClient
+}
+
+func (c *asClient) HandleUplinkData(ctx context.Context, in *as.HandleUplinkDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleUplinkData"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleUplinkData(ctx, in, opts...)
+}
+
+func (c *asClient) HandleUplinkDataBatch(ctx context.Context, in *as.HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleUplinkDataBatch"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleUplinkDataBatch(ctx, in, opts...)
+}
+
+func (c *asClient) HandleProprietaryUplink(ctx context.Context, in *as.HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleProprietaryUplink"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleProprietaryUplink(ctx, in, opts...)
+}
+
+func (c *asClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleError"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleError(ctx, in, opts...)
+}
+
+func (c *asClient) HandleDownlinkACK(ctx context.Context, in *as.HandleDownlinkACKRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleDownlinkACK"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleDownlinkACK(ctx, in, opts...)
+}
+
+func (c *asClient) HandleGatewayStats(ctx context.Context, in *as.HandleGatewayStatsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "HandleGatewayStats"); err != nil {
+		return nil, err
+	}
+	return c.client.HandleGatewayStats(ctx, in, opts...)
+}
+
+func (c *asClient) SetDeviceStatus(ctx context.Context, in *as.SetDeviceStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "SetDeviceStatus"); err != nil {
+		return nil, err
+	}
+	return c.client.SetDeviceStatus(ctx, in, opts...)
+}
+
+func (c *asClient) SetDeviceLocation(ctx context.Context, in *as.SetDeviceLocationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, err := Apply(ApplicationServer, "SetDeviceLocation"); err != nil {
+		return nil, err
+	}
+	return c.client.SetDeviceLocation(ctx, in, opts...)
+}
@@ -0,0 +1,7 @@
+//go:build !faultinjection
+// +build !faultinjection
+
+package faultinjection
+
+// buildEnabled is true when compiled with the faultinjection build tag.
+const buildEnabled = false
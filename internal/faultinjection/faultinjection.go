@@ -0,0 +1,341 @@
+// Package faultinjection implements an optional fault-injection layer, used
+// to rehearse failures (e.g. a Redis failover) before they happen in
+// production. According to the rules of a scenario file, calls to Redis,
+// PostgreSQL, the gateway backend, the application-server and the
+// join-server are dropped, delayed or corrupted.
+//
+// Fault-injection is enabled by the fault_injection.enabled configuration
+// option, or by compiling LoRa Server with the faultinjection build tag.
+// It must never be enabled in production.
+package faultinjection
+
+import (
+	"math/rand"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// ErrInjected is returned by the calls that are dropped.
+var ErrInjected = errors.New("fault-injection: injected fault")
+
+// Target defines the component to which a rule applies.
+type Target string
+
+// Targets.
+const (
+	Redis             Target = "redis"
+	PostgreSQL        Target = "postgresql"
+	Gateway           Target = "gateway"
+	ApplicationServer Target = "application_server"
+	JoinServer        Target = "join_server"
+)
+
+// Action defines the fault to inject.
+type Action string
+
+// Actions.
+const (
+	Drop    Action = "drop"
+	Delay   Action = "delay"
+	Corrupt Action = "corrupt"
+)
+
+// Scenario defines a fault-injection scenario.
+type Scenario struct {
+	// Seed of the random source used for the rule probabilities. When not
+	// set, a time based seed is used.
+	Seed  int64  `mapstructure:"seed"`
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// Rule defines a fault-injection rule.
+type Rule struct {
+	Name   string `mapstructure:"name"`
+	Target Target `mapstructure:"target"`
+
+	// Operation is matched (case-insensitive, path.Match syntax) against the
+	// operation of the call. This is the Redis command, the first keyword
+	// of the SQL query, uplink / downlink / config for the gateway backend
+	// or the method name for the application-server and join-server. When
+	// empty, the rule applies to all operations.
+	Operation string `mapstructure:"operation"`
+
+	Action Action        `mapstructure:"action"`
+	Delay  time.Duration `mapstructure:"delay"`
+
+	// Probability (0 - 1] with which the rule applies. When not set, the
+	// rule always applies.
+	Probability float64 `mapstructure:"probability"`
+
+	// StartAfter and Duration define the window, relative to the start of
+	// the network-server, in which the rule is active. When the duration is
+	// not set, the rule stays active.
+	StartAfter time.Duration `mapstructure:"start_after"`
+	Duration   time.Duration `mapstructure:"duration"`
+}
+
+type scenario struct {
+	Scenario
+
+	start  time.Time
+	rndMux sync.Mutex
+	rnd    *rand.Rand
+}
+
+var (
+	enabled int32
+
+	mux sync.RWMutex
+	sc  *scenario
+)
+
+// Setup loads the configured scenario and wraps the gateway backend,
+// application-server pool and join-server pool so that faults can be
+// injected. This must be called after these have been set up.
+func Setup(conf config.Config) error {
+	if !conf.FaultInjection.Enabled && !buildEnabled {
+		atomic.StoreInt32(&enabled, 0)
+		setScenario(nil)
+		return nil
+	}
+
+	s, err := LoadScenario(conf.FaultInjection.Scenario)
+	if err != nil {
+		return errors.Wrap(err, "fault-injection: load scenario error")
+	}
+
+	if gwbackend.Backend() == nil {
+		return errors.New("fault-injection: gateway backend is not configured")
+	}
+
+	log.WithFields(log.Fields{
+		"scenario": conf.FaultInjection.Scenario,
+		"rules":    len(s.Rules),
+	}).Warning("fault-injection: fault-injection enabled, this must never be used in production")
+
+	gwbackend.SetBackend(newGatewayBackend(gwbackend.Backend()))
+	applicationserver.SetPool(&asPool{pool: applicationserver.Pool()})
+	joinserver.SetPool(&jsPool{pool: joinserver.GetPool()})
+
+	setScenario(&s)
+	atomic.StoreInt32(&enabled, 1)
+
+	return nil
+}
+
+// Enabled returns true when fault-injection is enabled.
+func Enabled() bool {
+	return atomic.LoadInt32(&enabled) == 1
+}
+
+// LoadScenario loads and validates the scenario from the given file. The
+// file can be in any format supported by the configuration file (e.g.
+// TOML, JSON or YAML).
+func LoadScenario(file string) (Scenario, error) {
+	var s Scenario
+
+	if file == "" {
+		return s, errors.New("scenario file is not configured")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return s, errors.Wrap(err, "read scenario file error")
+	}
+	if err := v.Unmarshal(&s); err != nil {
+		return s, errors.Wrap(err, "unmarshal scenario error")
+	}
+
+	if err := s.Validate(); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// Validate validates the scenario rules.
+func (s Scenario) Validate() error {
+	for i, r := range s.Rules {
+		switch r.Target {
+		case Redis, PostgreSQL, Gateway, ApplicationServer, JoinServer:
+		default:
+			return errors.Errorf("rule %d: invalid target: %s", i, r.Target)
+		}
+
+		switch r.Action {
+		case Drop:
+		case Delay:
+			if r.Delay <= 0 {
+				return errors.Errorf("rule %d: delay must be greater than 0", i)
+			}
+		case Corrupt:
+			if r.Target == PostgreSQL || r.Target == ApplicationServer {
+				return errors.Errorf("rule %d: corrupt is not supported for target: %s", i, r.Target)
+			}
+		default:
+			return errors.Errorf("rule %d: invalid action: %s", i, r.Action)
+		}
+
+		if r.Probability < 0 || r.Probability > 1 {
+			return errors.Errorf("rule %d: probability must be between 0 and 1", i)
+		}
+
+		if _, err := path.Match(r.Operation, ""); err != nil {
+			return errors.Wrapf(err, "rule %d: invalid operation pattern", i)
+		}
+	}
+
+	return nil
+}
+
+// SetScenario activates the given scenario, without wrapping any of the
+// backends. This is intended for testing.
+func SetScenario(s Scenario) {
+	setScenario(&s)
+	atomic.StoreInt32(&enabled, 1)
+}
+
+// Reset de-activates the scenario.
+func Reset() {
+	atomic.StoreInt32(&enabled, 0)
+	setScenario(nil)
+}
+
+func setScenario(s *Scenario) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if s == nil {
+		sc = nil
+		return
+	}
+
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	sc = &scenario{
+		Scenario: *s,
+		start:    clock.Now(),
+		rnd:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Apply applies the active rules for the given target and operation.
+// Delays are applied in place, ErrInjected is returned when the call must
+// be dropped and true is returned when the data must be corrupted (see
+// CorruptBytes).
+func Apply(target Target, operation string) (bool, error) {
+	if !Enabled() {
+		return false, nil
+	}
+
+	mux.RLock()
+	s := sc
+	mux.RUnlock()
+	if s == nil {
+		return false, nil
+	}
+
+	return s.apply(target, operation)
+}
+
+func (s *scenario) apply(target Target, operation string) (bool, error) {
+	elapsed := clock.Since(s.start)
+	operation = strings.ToLower(operation)
+
+	var corrupt bool
+	for _, r := range s.Rules {
+		if r.Target != target || !r.isActive(elapsed) || !r.matchOperation(operation) || !s.sample(r.Probability) {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"rule":      r.Name,
+			"target":    target,
+			"operation": operation,
+			"action":    r.Action,
+		}).Debug("fault-injection: injecting fault")
+
+		switch r.Action {
+		case Drop:
+			return false, ErrInjected
+		case Delay:
+			clock.Sleep(r.Delay)
+		case Corrupt:
+			corrupt = true
+		}
+	}
+
+	return corrupt, nil
+}
+
+func (s *scenario) sample(probability float64) bool {
+	if probability == 0 || probability == 1 {
+		return true
+	}
+
+	s.rndMux.Lock()
+	defer s.rndMux.Unlock()
+	return s.rnd.Float64() < probability
+}
+
+// CorruptBytes returns a copy of the given bytes with a single random bit
+// flipped.
+func CorruptBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return b
+	}
+
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	mux.RLock()
+	s := sc
+	mux.RUnlock()
+
+	var n int
+	if s != nil {
+		s.rndMux.Lock()
+		n = s.rnd.Intn(len(out) * 8)
+		s.rndMux.Unlock()
+	} else {
+		n = rand.Intn(len(out) * 8)
+	}
+
+	out[n/8] ^= 1 << uint(n%8)
+	return out
+}
+
+func (r Rule) isActive(elapsed time.Duration) bool {
+	if elapsed < r.StartAfter {
+		return false
+	}
+	if r.Duration != 0 && elapsed >= r.StartAfter+r.Duration {
+		return false
+	}
+	return true
+}
+
+func (r Rule) matchOperation(operation string) bool {
+	if r.Operation == "" {
+		return true
+	}
+	ok, _ := path.Match(strings.ToLower(r.Operation), operation)
+	return ok
+}
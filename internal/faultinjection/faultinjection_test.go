@@ -0,0 +1,231 @@
+package faultinjection
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+func TestLoadScenario(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "faultinjection")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "scenario.toml")
+	assert.NoError(ioutil.WriteFile(file, []byte(`
+seed=1
+
+[[rules]]
+name="redis outage"
+target="redis"
+action="drop"
+start_after="1m"
+duration="30s"
+
+[[rules]]
+name="slow application-server"
+target="application_server"
+operation="HandleUplinkData"
+action="delay"
+delay="2s"
+probability=0.5
+`), 0600))
+
+	s, err := LoadScenario(file)
+	assert.NoError(err)
+	assert.Equal(Scenario{
+		Seed: 1,
+		Rules: []Rule{
+			{
+				Name:       "redis outage",
+				Target:     Redis,
+				Action:     Drop,
+				StartAfter: time.Minute,
+				Duration:   30 * time.Second,
+			},
+			{
+				Name:        "slow application-server",
+				Target:      ApplicationServer,
+				Operation:   "HandleUplinkData",
+				Action:      Delay,
+				Delay:       2 * time.Second,
+				Probability: 0.5,
+			},
+		},
+	}, s)
+
+	_, err = LoadScenario("")
+	assert.Error(err)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Rule  Rule
+		Error bool
+	}{
+		{
+			Name: "valid drop",
+			Rule: Rule{Target: Redis, Action: Drop},
+		},
+		{
+			Name:  "invalid target",
+			Rule:  Rule{Target: "mysql", Action: Drop},
+			Error: true,
+		},
+		{
+			Name:  "invalid action",
+			Rule:  Rule{Target: Redis, Action: "explode"},
+			Error: true,
+		},
+		{
+			Name:  "delay without duration",
+			Rule:  Rule{Target: Redis, Action: Delay},
+			Error: true,
+		},
+		{
+			Name:  "corrupt postgresql",
+			Rule:  Rule{Target: PostgreSQL, Action: Corrupt},
+			Error: true,
+		},
+		{
+			Name:  "invalid probability",
+			Rule:  Rule{Target: Redis, Action: Drop, Probability: 1.5},
+			Error: true,
+		},
+		{
+			Name:  "invalid operation pattern",
+			Rule:  Rule{Target: Redis, Action: Drop, Operation: "["},
+			Error: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			err := Scenario{Rules: []Rule{tst.Rule}}.Validate()
+			if tst.Error {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	assert := require.New(t)
+
+	m := clock.NewMock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.Set(m)
+	defer clock.Set(nil)
+	defer Reset()
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+		corrupt, err := Apply(Redis, "GET")
+		assert.NoError(err)
+		assert.False(corrupt)
+	})
+
+	SetScenario(Scenario{
+		Rules: []Rule{
+			{
+				Name:       "redis outage",
+				Target:     Redis,
+				Action:     Drop,
+				StartAfter: time.Minute,
+				Duration:   30 * time.Second,
+			},
+			{
+				Name:      "corrupt join-answers",
+				Target:    JoinServer,
+				Operation: "joinreq",
+				Action:    Corrupt,
+			},
+			{
+				Name:   "slow application-server",
+				Target: ApplicationServer,
+				Action: Delay,
+				Delay:  time.Second,
+			},
+		},
+	})
+	assert.True(Enabled())
+
+	t.Run("Drop window", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := Apply(Redis, "GET")
+		assert.NoError(err)
+
+		m.Add(time.Minute)
+		_, err = Apply(Redis, "GET")
+		assert.Equal(ErrInjected, err)
+
+		m.Add(30 * time.Second)
+		_, err = Apply(Redis, "GET")
+		assert.NoError(err)
+	})
+
+	t.Run("Corrupt matching operation", func(t *testing.T) {
+		assert := require.New(t)
+
+		corrupt, err := Apply(JoinServer, "JoinReq")
+		assert.NoError(err)
+		assert.True(corrupt)
+
+		corrupt, err = Apply(JoinServer, "RejoinReq")
+		assert.NoError(err)
+		assert.False(corrupt)
+	})
+
+	t.Run("Delay", func(t *testing.T) {
+		assert := require.New(t)
+
+		done := make(chan struct{})
+		go func() {
+			Apply(ApplicationServer, "HandleUplinkData")
+			close(done)
+		}()
+
+		for m.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		m.Add(time.Second)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("delay was not released")
+		}
+		assert.Equal(0, m.Waiters())
+	})
+}
+
+func TestCorruptBytes(t *testing.T) {
+	assert := require.New(t)
+
+	b := []byte{1, 2, 3, 4}
+	out := CorruptBytes(b)
+	assert.Equal([]byte{1, 2, 3, 4}, b)
+	assert.Len(out, len(b))
+
+	var flipped int
+	for i := range b {
+		for x := b[i] ^ out[i]; x != 0; x &= x - 1 {
+			flipped++
+		}
+	}
+	assert.Equal(1, flipped)
+
+	assert.Len(CorruptBytes(nil), 0)
+}
@@ -0,0 +1,134 @@
+package faultinjection
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// gatewayBackend wraps a gateway backend. The gateway rules are applied to
+// the uplink frames (operation uplink), the downlink frames (operation
+// downlink) and the gateway configurations (operation config).
+type gatewayBackend struct {
+	gwbackend.Gateway
+
+	rxPacketChan chan gw.UplinkFrame
+}
+
+func newGatewayBackend(b gwbackend.Gateway) *gatewayBackend {
+	gb := gatewayBackend{
+		Gateway:      b,
+		rxPacketChan: make(chan gw.UplinkFrame),
+	}
+
+	go gb.forwardUplinkFrames()
+
+	return &gb
+}
+
+// SendTXPacket applies the gateway rules before sending the downlink frame.
+func (b *gatewayBackend) SendTXPacket(pl gw.DownlinkFrame) error {
+	corrupt, err := Apply(Gateway, "downlink")
+	if err != nil {
+		return err
+	}
+	if corrupt {
+		pl.PhyPayload = CorruptBytes(pl.PhyPayload)
+	}
+
+	return b.Gateway.SendTXPacket(pl)
+}
+
+// SendGatewayConfigPacket applies the gateway rules before sending the
+// gateway configuration.
+func (b *gatewayBackend) SendGatewayConfigPacket(pl gw.GatewayConfiguration) error {
+	if _, err := Apply(Gateway, "config"); err != nil {
+		return err
+	}
+
+	return b.Gateway.SendGatewayConfigPacket(pl)
+}
+
+// RXPacketChan returns the channel containing the received uplink frames
+// to which the gateway rules have been applied.
+func (b *gatewayBackend) RXPacketChan() chan gw.UplinkFrame {
+	return b.rxPacketChan
+}
+
+// ConnStateChan returns the connection state channel of the wrapped backend.
+// It returns nil when not supported by the wrapped backend.
+func (b *gatewayBackend) ConnStateChan() chan gw.ConnState {
+	if csb, ok := b.Gateway.(gwbackend.ConnStateBackend); ok {
+		return csb.ConnStateChan()
+	}
+	return nil
+}
+
+// SendGatewayCommandExecRequest sends the given command execution request
+// using the wrapped backend. It returns ErrNotSupported when not supported by
+// the wrapped backend.
+func (b *gatewayBackend) SendGatewayCommandExecRequest(pl gw.GatewayCommandExecRequest) error {
+	if ceb, ok := b.Gateway.(gwbackend.CommandExecBackend); ok {
+		return ceb.SendGatewayCommandExecRequest(pl)
+	}
+	return gwbackend.ErrNotSupported
+}
+
+// GatewayCommandExecResponseChan returns the command execution response
+// channel of the wrapped backend. It returns nil when not supported by the
+// wrapped backend.
+func (b *gatewayBackend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	if ceb, ok := b.Gateway.(gwbackend.CommandExecBackend); ok {
+		return ceb.GatewayCommandExecResponseChan()
+	}
+	return nil
+}
+
+// NoiseFloorReportChan returns the noise-floor report channel of the wrapped
+// backend. It returns nil when not supported by the wrapped backend.
+func (b *gatewayBackend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	if nfb, ok := b.Gateway.(gwbackend.NoiseFloorBackend); ok {
+		return nfb.NoiseFloorReportChan()
+	}
+	return nil
+}
+
+// UpdateCredentials updates the credentials of the wrapped backend (when
+// supported).
+func (b *gatewayBackend) UpdateCredentials(conf config.Config) error {
+	if u, ok := b.Gateway.(gwbackend.CredentialsUpdater); ok {
+		return u.UpdateCredentials(conf)
+	}
+	return nil
+}
+
+func (b *gatewayBackend) forwardUplinkFrames() {
+	var wg sync.WaitGroup
+
+	for uf := range b.Gateway.RXPacketChan() {
+		// each frame is handled in its own goroutine so that a delayed
+		// frame does not delay the other frames
+		wg.Add(1)
+		go func(uf gw.UplinkFrame) {
+			defer wg.Done()
+
+			corrupt, err := Apply(Gateway, "uplink")
+			if err != nil {
+				log.Debug("fault-injection: uplink frame dropped")
+				return
+			}
+			if corrupt {
+				uf.PhyPayload = CorruptBytes(uf.PhyPayload)
+			}
+
+			b.rxPacketChan <- uf
+		}(uf)
+	}
+
+	wg.Wait()
+	close(b.rxPacketChan)
+}
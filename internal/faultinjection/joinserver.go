@@ -0,0 +1,54 @@
+package faultinjection
+
+import (
+	"context"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+)
+
+// jsPool wraps a join-server pool. The join-server rules are applied to
+// each request, using the method name (JoinReq or RejoinReq) as operation.
+// Corrupted answers have a single bit of the PHYPayload flipped.
+type jsPool struct {
+	pool joinserver.Pool
+}
+
+func (p *jsPool) Get(joinEUI lorawan.EUI64) (joinserver.Client, error) {
+	client, err := p.pool.Get(joinEUI)
+	if err != nil {
+		return nil, err
+	}
+	return &jsClient{client: client}, nil
+}
+
+type jsClient struct {
+	client joinserver.Client
+}
+
+func (c *jsClient) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+	corrupt, err := Apply(JoinServer, "JoinReq")
+	if err != nil {
+		return backend.JoinAnsPayload{}, err
+	}
+
+	ans, err := c.client.JoinReq(ctx, pl)
+	if err == nil && corrupt {
+		ans.PHYPayload = CorruptBytes(ans.PHYPayload)
+	}
+	return ans, err
+}
+
+func (c *jsClient) RejoinReq(ctx context.Context, pl backend.RejoinReqPayload) (backend.RejoinAnsPayload, error) {
+	corrupt, err := Apply(JoinServer, "RejoinReq")
+	if err != nil {
+		return backend.RejoinAnsPayload{}, err
+	}
+
+	ans, err := c.client.RejoinReq(ctx, pl)
+	if err == nil && corrupt {
+		ans.PHYPayload = CorruptBytes(ans.PHYPayload)
+	}
+	return ans, err
+}
@@ -0,0 +1,56 @@
+package faultinjection
+
+import (
+	"github.com/gomodule/redigo/redis"
+)
+
+// redisConn wraps a Redis connection.
+type redisConn struct {
+	redis.Conn
+}
+
+// WrapRedisConn wraps the given Redis connection so that faults can be
+// injected in the Do and Send calls. When fault-injection is disabled, the
+// calls are passed through unmodified.
+func WrapRedisConn(c redis.Conn) redis.Conn {
+	return &redisConn{Conn: c}
+}
+
+// Do applies the Redis rules before executing the command. Corrupted
+// replies have a single bit flipped.
+func (c *redisConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	corrupt, err := Apply(Redis, commandName)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.Conn.Do(commandName, args...)
+	if err != nil || !corrupt {
+		return reply, err
+	}
+
+	return corruptReply(reply), nil
+}
+
+// Send applies the Redis rules before buffering the command.
+func (c *redisConn) Send(commandName string, args ...interface{}) error {
+	if _, err := Apply(Redis, commandName); err != nil {
+		return err
+	}
+	return c.Conn.Send(commandName, args...)
+}
+
+func corruptReply(reply interface{}) interface{} {
+	switch v := reply.(type) {
+	case []byte:
+		return CorruptBytes(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i := range v {
+			out[i] = corruptReply(v[i])
+		}
+		return out
+	default:
+		return reply
+	}
+}
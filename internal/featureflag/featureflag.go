@@ -0,0 +1,276 @@
+// Package featureflag implements feature-flags for the gradual rollout of
+// new behavior. A feature-flag is enabled for a percentage of the devices,
+// optionally overridden per service-profile. The percentages are configured
+// in the configuration file and can be overridden at runtime in Redis.
+//
+// Whether a feature-flag is enabled for a device is determined by the hash
+// of the feature-flag name and DevEUI, so that a device stays within the
+// rollout when the percentage is increased.
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	overrideKeyTempl   = "lora:ns:feature_flag:%s"
+	overrideKeyPattern = "lora:ns:feature_flag:*"
+
+	// defaultField is the hash field containing the override for all
+	// service-profiles.
+	defaultField = "default"
+)
+
+// Flag defines the rollout of a feature-flag.
+type Flag struct {
+	// Percentage (0 - 100) of the devices for which the feature is enabled.
+	// For Redis overrides this is -1 when only service-profile overrides
+	// are set.
+	Percentage int
+
+	// ServiceProfiles contains the percentage per service-profile.
+	ServiceProfiles map[uuid.UUID]int
+}
+
+var (
+	mux       sync.RWMutex
+	flags     map[string]Flag
+	overrides map[string]Flag
+)
+
+// Setup configures the feature-flags and starts the loop refreshing the
+// Redis overrides.
+func Setup(conf config.Config) error {
+	c := conf.NetworkServer.FeatureFlags
+
+	ff := make(map[string]Flag)
+	for _, f := range c.Flags {
+		if f.Name == "" {
+			return errors.New("featureflag: name must not be empty")
+		}
+		if _, ok := ff[f.Name]; ok {
+			return fmt.Errorf("featureflag: flag %s is configured more than once", f.Name)
+		}
+
+		flag := Flag{
+			Percentage:      f.Percentage,
+			ServiceProfiles: make(map[uuid.UUID]int),
+		}
+		if err := validatePercentage(flag.Percentage); err != nil {
+			return errors.Wrapf(err, "featureflag: flag %s", f.Name)
+		}
+
+		for _, sp := range f.ServiceProfiles {
+			id, err := uuid.FromString(sp.ID)
+			if err != nil {
+				return errors.Wrapf(err, "featureflag: flag %s: decode service-profile id error", f.Name)
+			}
+			if err := validatePercentage(sp.Percentage); err != nil {
+				return errors.Wrapf(err, "featureflag: flag %s", f.Name)
+			}
+			flag.ServiceProfiles[id] = sp.Percentage
+		}
+
+		ff[f.Name] = flag
+	}
+
+	mux.Lock()
+	flags = ff
+	overrides = nil
+	mux.Unlock()
+
+	if c.RefreshInterval <= 0 {
+		return nil
+	}
+
+	if err := refresh(storage.RedisPool()); err != nil {
+		return errors.Wrap(err, "featureflag: refresh overrides error")
+	}
+
+	go func() {
+		for {
+			time.Sleep(c.RefreshInterval)
+			if err := refresh(storage.RedisPool()); err != nil {
+				log.WithError(err).Error("featureflag: refresh overrides error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Enabled returns true when the given feature-flag is enabled for the given
+// device. The percentage is resolved in the following order: the Redis
+// override for the service-profile, the Redis override for all
+// service-profiles, the configured service-profile percentage and the
+// configured percentage. Unknown feature-flags are disabled.
+func Enabled(name string, serviceProfileID uuid.UUID, devEUI lorawan.EUI64) bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	percentage := -1
+	for _, m := range []map[string]Flag{overrides, flags} {
+		f, ok := m[name]
+		if !ok {
+			continue
+		}
+		if p, ok := f.ServiceProfiles[serviceProfileID]; ok {
+			percentage = p
+			break
+		}
+		if f.Percentage != -1 {
+			percentage = f.Percentage
+			break
+		}
+	}
+
+	if percentage <= 0 {
+		return false
+	}
+
+	return bucket(name, devEUI) < percentage
+}
+
+// GetFlags returns the configured feature-flags and the (cached) Redis
+// overrides.
+func GetFlags() (map[string]Flag, map[string]Flag) {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return flags, overrides
+}
+
+// SetOverride sets the Redis override percentage for the given feature-flag.
+// When the service-profile ID is nil, the override applies to all
+// service-profiles (without service-profile override).
+func SetOverride(ctx context.Context, p storage.RedisClient, name string, serviceProfileID uuid.UUID, percentage int) error {
+	if err := validatePercentage(percentage); err != nil {
+		return err
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("HSET", fmt.Sprintf(overrideKeyTempl, name), overrideField(serviceProfileID), percentage); err != nil {
+		return errors.Wrap(err, "hset error")
+	}
+
+	log.WithFields(log.Fields{
+		"name":               name,
+		"service_profile_id": serviceProfileID,
+		"percentage":         percentage,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("featureflag: override set")
+
+	return nil
+}
+
+// DeleteOverride deletes the Redis override for the given feature-flag and
+// service-profile (or all service-profiles when nil).
+func DeleteOverride(ctx context.Context, p storage.RedisClient, name string, serviceProfileID uuid.UUID) error {
+	c := p.Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("HDEL", fmt.Sprintf(overrideKeyTempl, name), overrideField(serviceProfileID)))
+	if err != nil {
+		return errors.Wrap(err, "hdel error")
+	}
+	if n == 0 {
+		return storage.ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"name":               name,
+		"service_profile_id": serviceProfileID,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("featureflag: override deleted")
+
+	return nil
+}
+
+// Refresh reads the Redis overrides, replacing the cached overrides.
+func Refresh(p storage.RedisClient) error {
+	return refresh(p)
+}
+
+func refresh(p storage.RedisClient) error {
+	c := p.Get()
+	defer c.Close()
+
+	keys, err := storage.ScanKeys(p, overrideKeyPattern)
+	if err != nil {
+		return errors.Wrap(err, "scan overrides error")
+	}
+
+	ov := make(map[string]Flag)
+	for _, key := range keys {
+		values, err := redis.IntMap(c.Do("HGETALL", key))
+		if err != nil {
+			return errors.Wrap(err, "hgetall error")
+		}
+
+		flag := Flag{
+			Percentage:      -1,
+			ServiceProfiles: make(map[uuid.UUID]int),
+		}
+		for field, percentage := range values {
+			if field == defaultField {
+				flag.Percentage = percentage
+				continue
+			}
+
+			id, err := uuid.FromString(field)
+			if err != nil {
+				log.WithField("key", key).WithError(err).Warning("featureflag: invalid service-profile id in override")
+				continue
+			}
+			flag.ServiceProfiles[id] = percentage
+		}
+
+		ov[strings.TrimPrefix(key, fmt.Sprintf(overrideKeyTempl, ""))] = flag
+	}
+
+	mux.Lock()
+	overrides = ov
+	mux.Unlock()
+
+	return nil
+}
+
+// bucket returns the bucket (0 - 99) of the given device for the given
+// feature-flag.
+func bucket(name string, devEUI lorawan.EUI64) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write(devEUI[:])
+	return int(h.Sum32() % 100)
+}
+
+func overrideField(serviceProfileID uuid.UUID) string {
+	if serviceProfileID == uuid.Nil {
+		return defaultField
+	}
+	return serviceProfileID.String()
+}
+
+func validatePercentage(p int) error {
+	if p < 0 || p > 100 {
+		return fmt.Errorf("percentage must be between 0 and 100, got %d", p)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package featureflag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	spID, err := uuid.NewV4()
+	assert.NoError(err)
+
+	conf.NetworkServer.FeatureFlags.Flags = []config.FeatureFlag{
+		{
+			Name:       "test_feature",
+			Percentage: 0,
+			ServiceProfiles: []config.FeatureFlagServiceProfile{
+				{ID: spID.String(), Percentage: 100},
+			},
+		},
+		{
+			Name:       "half_feature",
+			Percentage: 50,
+		},
+	}
+	assert.NoError(Setup(conf))
+	defer Setup(config.Config{})
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	t.Run("Configured percentages", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.True(Enabled("test_feature", spID, devEUI))
+		assert.False(Enabled("test_feature", uuid.Nil, devEUI))
+		assert.False(Enabled("unknown_feature", spID, devEUI))
+
+		var enabled int
+		for i := 0; i < 1000; i++ {
+			if Enabled("half_feature", uuid.Nil, lorawan.EUI64{byte(i >> 8), byte(i)}) {
+				enabled++
+			}
+		}
+		assert.InDelta(500, enabled, 100)
+	})
+
+	t.Run("Redis overrides", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(SetOverride(context.Background(), storage.RedisPool(), "test_feature", uuid.Nil, 100))
+		assert.NoError(SetOverride(context.Background(), storage.RedisPool(), "test_feature", spID, 0))
+		assert.Error(SetOverride(context.Background(), storage.RedisPool(), "test_feature", spID, 101))
+		assert.NoError(Refresh(storage.RedisPool()))
+
+		assert.False(Enabled("test_feature", spID, devEUI))
+		assert.True(Enabled("test_feature", uuid.Nil, devEUI))
+
+		assert.NoError(DeleteOverride(context.Background(), storage.RedisPool(), "test_feature", spID))
+		assert.Equal(storage.ErrDoesNotExist, DeleteOverride(context.Background(), storage.RedisPool(), "test_feature", spID))
+		assert.NoError(Refresh(storage.RedisPool()))
+
+		assert.True(Enabled("test_feature", spID, devEUI))
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		assert := require.New(t)
+
+		var c config.Config
+		c.NetworkServer.FeatureFlags.Flags = []config.FeatureFlag{{Name: "a", Percentage: 200}}
+		assert.Error(Setup(c))
+
+		c.NetworkServer.FeatureFlags.Flags = []config.FeatureFlag{{Name: "a"}, {Name: "a"}}
+		assert.Error(Setup(c))
+	})
+}
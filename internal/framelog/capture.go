@@ -0,0 +1,366 @@
+package framelog
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/jsonpb"
+	proto "github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	captureKeyTempl       = "lora:ns:framelog:capture:%s"
+	captureFramesKeyTempl = "lora:ns:framelog:capture:%s:frames"
+)
+
+// Capture errors.
+var (
+	ErrCaptureDoesNotExist     = errors.New("frame-log capture does not exist")
+	ErrInvalidCaptureTarget    = errors.New("frame-log capture requires either a DevEUI or a gateway ID")
+	ErrInvalidCaptureDuration  = errors.New("invalid frame-log capture duration")
+	ErrInvalidCaptureMaxFrames = errors.New("invalid frame-log capture max. frames")
+)
+
+var (
+	mux                sync.RWMutex
+	captureMaxDuration = time.Hour
+	captureMaxFrames   = 10000
+	captureTTL         = 24 * time.Hour
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	c := conf.NetworkServer.FrameLog.Capture
+	if c.MaxDuration > 0 {
+		captureMaxDuration = c.MaxDuration
+	}
+	if c.MaxFrames > 0 {
+		captureMaxFrames = c.MaxFrames
+	}
+	if c.TTL > 0 {
+		captureTTL = c.TTL
+	}
+
+	return nil
+}
+
+// Capture defines a bounded frame-log capture session for a device or a
+// gateway. The captured frames are buffered in Redis, so that they can be
+// downloaded from any LoRa Server instance.
+type Capture struct {
+	ID        uuid.UUID
+	DevEUI    lorawan.EUI64
+	GatewayID lorawan.EUI64
+	Filter    Filter
+	MaxFrames int
+	CreatedAt time.Time
+	EndsAt    time.Time
+}
+
+// CapturedFrame contains a captured frame and the time it was captured.
+type CapturedFrame struct {
+	FrameLog
+	Time time.Time
+}
+
+// capturedFrame is the Redis representation of a CapturedFrame.
+type capturedFrame struct {
+	Time          time.Time
+	UplinkFrame   []byte
+	DownlinkFrame []byte
+}
+
+// StartCapture stores the given capture and starts capturing the frames of
+// the device or gateway for the given duration (or until the max. number of
+// frames has been captured). When MaxFrames is 0, the configured max. is
+// used.
+func StartCapture(ctx context.Context, p storage.RedisClient, c *Capture, duration time.Duration) error {
+	mux.RLock()
+	maxDuration := captureMaxDuration
+	maxFrames := captureMaxFrames
+	ttl := captureTTL
+	mux.RUnlock()
+
+	if (c.DevEUI == lorawan.EUI64{}) == (c.GatewayID == lorawan.EUI64{}) {
+		return ErrInvalidCaptureTarget
+	}
+
+	if duration <= 0 || duration > maxDuration {
+		return errors.Wrapf(ErrInvalidCaptureDuration, "duration must be > 0 and <= %s", maxDuration)
+	}
+
+	if c.MaxFrames == 0 {
+		c.MaxFrames = maxFrames
+	}
+	if c.MaxFrames < 0 || c.MaxFrames > maxFrames {
+		return errors.Wrapf(ErrInvalidCaptureMaxFrames, "max. frames must be <= %d", maxFrames)
+	}
+
+	var err error
+	c.ID, err = uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	c.CreatedAt = time.Now()
+	c.EndsAt = c.CreatedAt.Add(duration)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	conn := p.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("PSETEX", fmt.Sprintf(captureKeyTempl, c.ID), int64((duration+ttl)/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "set capture error")
+	}
+
+	log.WithFields(log.Fields{
+		"capture_id": c.ID,
+		"dev_eui":    c.DevEUI,
+		"gateway_id": c.GatewayID,
+		"ends_at":    c.EndsAt,
+		"max_frames": c.MaxFrames,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("framelog: capture started")
+
+	go runCapture(p, *c, duration+ttl)
+
+	return nil
+}
+
+// GetCapture returns the capture for the given ID.
+func GetCapture(ctx context.Context, p storage.RedisClient, id uuid.UUID) (Capture, error) {
+	var c Capture
+
+	conn := p.Get()
+	defer conn.Close()
+
+	b, err := redis.Bytes(conn.Do("GET", fmt.Sprintf(captureKeyTempl, id)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return c, ErrCaptureDoesNotExist
+		}
+		return c, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&c); err != nil {
+		return c, errors.Wrap(err, "gob decode error")
+	}
+
+	return c, nil
+}
+
+// GetCapturedFrames returns the frames captured so far for the given
+// capture ID.
+func GetCapturedFrames(ctx context.Context, p storage.RedisClient, id uuid.UUID) ([]CapturedFrame, error) {
+	conn := p.Get()
+	defer conn.Close()
+
+	items, err := redis.ByteSlices(conn.Do("LRANGE", fmt.Sprintf(captureFramesKeyTempl, id), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read captured frames error")
+	}
+
+	out := make([]CapturedFrame, 0, len(items))
+	for _, b := range items {
+		var cf capturedFrame
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&cf); err != nil {
+			return nil, errors.Wrap(err, "gob decode error")
+		}
+
+		frame := CapturedFrame{Time: cf.Time}
+		if cf.UplinkFrame != nil {
+			frame.UplinkFrame = &gw.UplinkFrameSet{}
+			if err := proto.Unmarshal(cf.UplinkFrame, frame.UplinkFrame); err != nil {
+				return nil, errors.Wrap(err, "unmarshal uplink frame-set error")
+			}
+		}
+		if cf.DownlinkFrame != nil {
+			frame.DownlinkFrame = &gw.DownlinkFrame{}
+			if err := proto.Unmarshal(cf.DownlinkFrame, frame.DownlinkFrame); err != nil {
+				return nil, errors.Wrap(err, "unmarshal downlink frame error")
+			}
+		}
+
+		out = append(out, frame)
+	}
+
+	return out, nil
+}
+
+// Completed returns true when the capture has ended, given the number of
+// frames captured so far.
+func (c Capture) Completed(frameCount int) bool {
+	return frameCount >= c.MaxFrames || !time.Now().Before(c.EndsAt)
+}
+
+// WriteCaptureJSON writes the capture and its frames as a single JSON
+// document to w.
+func WriteCaptureJSON(w io.Writer, c Capture, frames []CapturedFrame) error {
+	type jsonFrame struct {
+		Time           time.Time       `json:"time"`
+		UplinkFrameSet json.RawMessage `json:"uplinkFrameSet,omitempty"`
+		DownlinkFrame  json.RawMessage `json:"downlinkFrame,omitempty"`
+	}
+
+	out := struct {
+		ID        uuid.UUID      `json:"id"`
+		DevEUI    *lorawan.EUI64 `json:"devEUI,omitempty"`
+		GatewayID *lorawan.EUI64 `json:"gatewayID,omitempty"`
+		CreatedAt time.Time      `json:"createdAt"`
+		EndsAt    time.Time      `json:"endsAt"`
+		Frames    []jsonFrame    `json:"frames"`
+	}{
+		ID:        c.ID,
+		CreatedAt: c.CreatedAt,
+		EndsAt:    c.EndsAt,
+		Frames:    make([]jsonFrame, 0, len(frames)),
+	}
+
+	if c.DevEUI != (lorawan.EUI64{}) {
+		out.DevEUI = &c.DevEUI
+	}
+	if c.GatewayID != (lorawan.EUI64{}) {
+		out.GatewayID = &c.GatewayID
+	}
+
+	m := &jsonpb.Marshaler{}
+	for _, f := range frames {
+		jf := jsonFrame{Time: f.Time}
+
+		if f.UplinkFrame != nil {
+			s, err := m.MarshalToString(f.UplinkFrame)
+			if err != nil {
+				return errors.Wrap(err, "marshal uplink frame-set error")
+			}
+			jf.UplinkFrameSet = json.RawMessage(s)
+		}
+
+		if f.DownlinkFrame != nil {
+			s, err := m.MarshalToString(f.DownlinkFrame)
+			if err != nil {
+				return errors.Wrap(err, "marshal downlink frame error")
+			}
+			jf.DownlinkFrame = json.RawMessage(s)
+		}
+
+		out.Frames = append(out.Frames, jf)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(out)
+}
+
+// runCapture subscribes to the frame-log of the capture target and buffers
+// the matching frames until the capture has ended.
+func runCapture(p storage.RedisClient, c Capture, ttl time.Duration) {
+	ctx, cancel := context.WithDeadline(context.Background(), c.EndsAt)
+	defer cancel()
+
+	frameLogChan := make(chan FrameLog)
+
+	go func() {
+		var err error
+		if c.DevEUI != (lorawan.EUI64{}) {
+			err = GetFrameLogForDevice(ctx, p, c.DevEUI, frameLogChan)
+		} else {
+			err = GetFrameLogForGateway(ctx, p, c.GatewayID, frameLogChan)
+		}
+		if err != nil {
+			log.WithError(err).WithField("capture_id", c.ID).Error("framelog: get frame-log for capture error")
+		}
+		close(frameLogChan)
+	}()
+
+	var count int
+	for fl := range frameLogChan {
+		// keep draining the channel until the subscription has been closed
+		if count >= c.MaxFrames || !c.Filter.Match(fl) {
+			continue
+		}
+
+		n, err := appendCapturedFrame(p, c.ID, fl, ttl)
+		if err != nil {
+			log.WithError(err).WithField("capture_id", c.ID).Error("framelog: append captured frame error")
+			continue
+		}
+
+		count = n
+		if count >= c.MaxFrames {
+			cancel()
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"capture_id": c.ID,
+		"frames":     count,
+	}).Info("framelog: capture completed")
+}
+
+func appendCapturedFrame(p storage.RedisClient, id uuid.UUID, fl FrameLog, ttl time.Duration) (int, error) {
+	cf := capturedFrame{
+		Time: time.Now(),
+	}
+
+	var err error
+	if fl.UplinkFrame != nil {
+		cf.UplinkFrame, err = proto.Marshal(fl.UplinkFrame)
+		if err != nil {
+			return 0, errors.Wrap(err, "marshal uplink frame-set error")
+		}
+	}
+	if fl.DownlinkFrame != nil {
+		cf.DownlinkFrame, err = proto.Marshal(fl.DownlinkFrame)
+		if err != nil {
+			return 0, errors.Wrap(err, "marshal downlink frame error")
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cf); err != nil {
+		return 0, errors.Wrap(err, "gob encode error")
+	}
+
+	conn := p.Get()
+	defer conn.Close()
+
+	key := fmt.Sprintf(captureFramesKeyTempl, id)
+
+	conn.Send("MULTI")
+	conn.Send("RPUSH", key, buf.Bytes())
+	conn.Send("PEXPIRE", key, int64(ttl/time.Millisecond))
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "append captured frame error")
+	}
+
+	n, err := redis.Int(values[0], nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "read list length error")
+	}
+
+	return n, nil
+}
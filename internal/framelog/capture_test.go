@@ -0,0 +1,95 @@
+package framelog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+func (ts *FrameLogTestSuite) TestCapture() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	ts.T().Run("Invalid target", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := Capture{}
+		assert.Equal(ErrInvalidCaptureTarget, StartCapture(ctx, storage.RedisPool(), &c, time.Minute))
+	})
+
+	ts.T().Run("Invalid duration", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := Capture{DevEUI: ts.DevEUI}
+		err := StartCapture(ctx, storage.RedisPool(), &c, 2*time.Hour)
+		assert.Error(err)
+	})
+
+	ts.T().Run("Does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetCapture(ctx, storage.RedisPool(), uuid.Must(uuid.NewV4()))
+		assert.Equal(ErrCaptureDoesNotExist, err)
+	})
+
+	c := Capture{
+		DevEUI:    ts.DevEUI,
+		MaxFrames: 2,
+	}
+	assert.NoError(StartCapture(ctx, storage.RedisPool(), &c, time.Minute))
+
+	// some time for subscribing
+	time.Sleep(100 * time.Millisecond)
+
+	ts.T().Run("Capture frames", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 0; i < 3; i++ {
+			assert.NoError(LogUplinkFrameForDevEUI(ctx, storage.RedisPool(), ts.DevEUI, gw.UplinkFrameSet{
+				PhyPayload: []byte{byte(i)},
+			}))
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		cGet, err := GetCapture(ctx, storage.RedisPool(), c.ID)
+		assert.NoError(err)
+		assert.Equal(c.DevEUI, cGet.DevEUI)
+		assert.Equal(2, cGet.MaxFrames)
+
+		frames, err := GetCapturedFrames(ctx, storage.RedisPool(), c.ID)
+		assert.NoError(err)
+		assert.Len(frames, 2)
+		assert.Equal([]byte{0}, frames[0].UplinkFrame.PhyPayload)
+		assert.Equal([]byte{1}, frames[1].UplinkFrame.PhyPayload)
+		assert.True(cGet.Completed(len(frames)))
+
+		t.Run("JSON", func(t *testing.T) {
+			assert := require.New(t)
+
+			var buf bytes.Buffer
+			assert.NoError(WriteCaptureJSON(&buf, cGet, frames))
+
+			var out struct {
+				DevEUI lorawan.EUI64 `json:"devEUI"`
+				Frames []struct {
+					UplinkFrameSet struct {
+						PhyPayload []byte `json:"phyPayload"`
+					} `json:"uplinkFrameSet"`
+				} `json:"frames"`
+			}
+			assert.NoError(json.Unmarshal(buf.Bytes(), &out))
+			assert.Equal(ts.DevEUI, out.DevEUI)
+			assert.Len(out.Frames, 2)
+			assert.Equal([]byte{1}, out.Frames[1].UplinkFrameSet.PhyPayload)
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package framelog
+
+import (
+	"github.com/brocaar/lorawan"
+)
+
+// Filter defines the filter criteria for a frame-log subscription. The zero
+// value matches all frames.
+type Filter struct {
+	// MTypes contains the message-types to match (empty = all).
+	MTypes []lorawan.MType
+
+	// FPortMin and FPortMax define the (inclusive) FPort range of data
+	// frames to match. When both are 0, the FPort is not filtered.
+	FPortMin uint8
+	FPortMax uint8
+
+	// MinRSSI defines the min. RSSI of uplink frames (0 = disabled).
+	MinRSSI int
+
+	// GatewayIDs contains the gateway IDs to match (empty = all).
+	GatewayIDs []lorawan.EUI64
+
+	// MACCommandsOnly only matches data frames containing mac-commands.
+	MACCommandsOnly bool
+}
+
+// Match returns true when the given frame-log matches the filter.
+// Frames of which the PHYPayload can not be decoded only match filters
+// that do not depend on the PHYPayload.
+func (f Filter) Match(fl FrameLog) bool {
+	var b []byte
+	switch {
+	case fl.UplinkFrame != nil:
+		b = fl.UplinkFrame.PhyPayload
+		if !f.matchUplinkMetaData(fl) {
+			return false
+		}
+	case fl.DownlinkFrame != nil:
+		b = fl.DownlinkFrame.PhyPayload
+		if !f.matchDownlinkMetaData(fl) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if len(f.MTypes) == 0 && f.FPortMin == 0 && f.FPortMax == 0 && !f.MACCommandsOnly {
+		return true
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		return false
+	}
+
+	if len(f.MTypes) != 0 {
+		var found bool
+		for _, mType := range f.MTypes {
+			if phy.MHDR.MType == mType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.FPortMin == 0 && f.FPortMax == 0 && !f.MACCommandsOnly {
+		return true
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return false
+	}
+
+	if f.FPortMin != 0 || f.FPortMax != 0 {
+		if macPL.FPort == nil || *macPL.FPort < f.FPortMin || *macPL.FPort > f.FPortMax {
+			return false
+		}
+	}
+
+	if f.MACCommandsOnly {
+		if len(macPL.FHDR.FOpts) == 0 && (macPL.FPort == nil || *macPL.FPort != 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Filter) matchUplinkMetaData(fl FrameLog) bool {
+	if f.MinRSSI != 0 {
+		var found bool
+		for _, rxInfo := range fl.UplinkFrame.RxInfo {
+			if int(rxInfo.Rssi) >= f.MinRSSI {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.GatewayIDs) != 0 {
+		var found bool
+		for _, rxInfo := range fl.UplinkFrame.RxInfo {
+			var id lorawan.EUI64
+			copy(id[:], rxInfo.GatewayId)
+			if f.hasGatewayID(id) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Filter) matchDownlinkMetaData(fl FrameLog) bool {
+	if len(f.GatewayIDs) != 0 {
+		if fl.DownlinkFrame.TxInfo == nil {
+			return false
+		}
+
+		var id lorawan.EUI64
+		copy(id[:], fl.DownlinkFrame.TxInfo.GatewayId)
+		if !f.hasGatewayID(id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Filter) hasGatewayID(id lorawan.EUI64) bool {
+	for _, gwID := range f.GatewayIDs {
+		if gwID == id {
+			return true
+		}
+	}
+	return false
+}
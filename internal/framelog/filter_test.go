@@ -0,0 +1,138 @@
+package framelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestFilter(t *testing.T) {
+	assert := require.New(t)
+
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	dataPHY := func(mType lorawan.MType, fPort uint8) []byte {
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: mType,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				},
+				FPort:      &fPort,
+				FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3}}},
+			},
+		}
+		b, err := phy.MarshalBinary()
+		assert.NoError(err)
+		return b
+	}
+
+	uplink := func(phy []byte, rssi int32) FrameLog {
+		return FrameLog{
+			UplinkFrame: &gw.UplinkFrameSet{
+				PhyPayload: phy,
+				RxInfo: []*gw.UplinkRXInfo{
+					{GatewayId: gatewayID[:], Rssi: rssi},
+				},
+			},
+		}
+	}
+
+	downlink := func(phy []byte) FrameLog {
+		return FrameLog{
+			DownlinkFrame: &gw.DownlinkFrame{
+				PhyPayload: phy,
+				TxInfo: &gw.DownlinkTXInfo{
+					GatewayId: gatewayID[:],
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		Name     string
+		Filter   Filter
+		FrameLog FrameLog
+		Match    bool
+	}{
+		{
+			Name:     "empty filter",
+			FrameLog: uplink([]byte{1, 2, 3}, -100),
+			Match:    true,
+		},
+		{
+			Name:     "mtype matches",
+			Filter:   Filter{MTypes: []lorawan.MType{lorawan.ConfirmedDataUp}},
+			FrameLog: uplink(dataPHY(lorawan.ConfirmedDataUp, 10), -100),
+			Match:    true,
+		},
+		{
+			Name:     "mtype does not match",
+			Filter:   Filter{MTypes: []lorawan.MType{lorawan.ConfirmedDataUp}},
+			FrameLog: downlink(dataPHY(lorawan.UnconfirmedDataDown, 10)),
+		},
+		{
+			Name:     "fport in range",
+			Filter:   Filter{FPortMin: 10, FPortMax: 20},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 10), -100),
+			Match:    true,
+		},
+		{
+			Name:     "fport out of range",
+			Filter:   Filter{FPortMin: 10, FPortMax: 20},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 21), -100),
+		},
+		{
+			Name:     "rssi above min",
+			Filter:   Filter{MinRSSI: -110},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 10), -100),
+			Match:    true,
+		},
+		{
+			Name:     "rssi below min",
+			Filter:   Filter{MinRSSI: -90},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 10), -100),
+		},
+		{
+			Name:     "rssi filter does not apply to downlinks",
+			Filter:   Filter{MinRSSI: -90},
+			FrameLog: downlink(dataPHY(lorawan.UnconfirmedDataDown, 10)),
+			Match:    true,
+		},
+		{
+			Name:     "gateway matches",
+			Filter:   Filter{GatewayIDs: []lorawan.EUI64{gatewayID}},
+			FrameLog: downlink(dataPHY(lorawan.UnconfirmedDataDown, 10)),
+			Match:    true,
+		},
+		{
+			Name:     "gateway does not match",
+			Filter:   Filter{GatewayIDs: []lorawan.EUI64{{8, 7, 6, 5, 4, 3, 2, 1}}},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 10), -100),
+		},
+		{
+			Name:     "mac-commands only with fport 0",
+			Filter:   Filter{MACCommandsOnly: true},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 0), -100),
+			Match:    true,
+		},
+		{
+			Name:     "mac-commands only without mac-commands",
+			Filter:   Filter{MACCommandsOnly: true},
+			FrameLog: uplink(dataPHY(lorawan.UnconfirmedDataUp, 10), -100),
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Match, tst.Filter.Match(tst.FrameLog))
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 const (
@@ -28,28 +30,35 @@ type FrameLog struct {
 }
 
 // LogUplinkFrameForGateways logs the given frame to all the gateway pub-sub keys.
-func LogUplinkFrameForGateways(ctx context.Context, p *redis.Pool, uplinkFrameSet gw.UplinkFrameSet) error {
+func LogUplinkFrameForGateways(ctx context.Context, p storage.RedisClient, uplinkFrameSet gw.UplinkFrameSet) error {
 	c := p.Get()
 	defer c.Close()
 
+	// the buffer and frame-log are re-used for each gateway, this is safe as
+	// the command arguments are written to the connection buffer by Send
+	buf := helpers.GetProtoBuffer()
+	defer helpers.PutProtoBuffer(buf)
+
+	rxInfo := make([]*gw.UplinkRXInfo, 1)
+	frameLog := gw.UplinkFrameSet{
+		PhyPayload: uplinkFrameSet.PhyPayload,
+		TxInfo:     uplinkFrameSet.TxInfo,
+		RxInfo:     rxInfo,
+	}
+
 	c.Send("MULTI")
 	for _, rx := range uplinkFrameSet.RxInfo {
 		var id lorawan.EUI64
 		copy(id[:], rx.GatewayId)
 
-		frameLog := gw.UplinkFrameSet{
-			PhyPayload: uplinkFrameSet.PhyPayload,
-			TxInfo:     uplinkFrameSet.TxInfo,
-			RxInfo:     []*gw.UplinkRXInfo{rx},
-		}
-
-		b, err := proto.Marshal(&frameLog)
-		if err != nil {
+		rxInfo[0] = rx
+		buf.Reset()
+		if err := buf.Marshal(&frameLog); err != nil {
 			return errors.Wrap(err, "marshal uplink frame-set error")
 		}
 
 		key := fmt.Sprintf(gatewayFrameLogUplinkPubSubKeyTempl, id)
-		c.Send("PUBLISH", key, b)
+		c.Send("PUBLISH", key, buf.Bytes())
 	}
 	_, err := c.Do("EXEC")
 	if err != nil {
@@ -60,7 +69,7 @@ func LogUplinkFrameForGateways(ctx context.Context, p *redis.Pool, uplinkFrameSe
 }
 
 // LogDownlinkFrameForGateway logs the given frame to the gateway pub-sub key.
-func LogDownlinkFrameForGateway(ctx context.Context, p *redis.Pool, frame gw.DownlinkFrame) error {
+func LogDownlinkFrameForGateway(ctx context.Context, p storage.RedisClient, frame gw.DownlinkFrame) error {
 	var id lorawan.EUI64
 	copy(id[:], frame.TxInfo.GatewayId)
 
@@ -69,12 +78,14 @@ func LogDownlinkFrameForGateway(ctx context.Context, p *redis.Pool, frame gw.Dow
 
 	key := fmt.Sprintf(gatewayFrameLogDownlinkPubSubKeyTempl, id)
 
-	b, err := proto.Marshal(&frame)
-	if err != nil {
+	buf := helpers.GetProtoBuffer()
+	defer helpers.PutProtoBuffer(buf)
+
+	if err := buf.Marshal(&frame); err != nil {
 		return errors.Wrap(err, "marshal downlink frame error")
 	}
 
-	_, err = c.Do("PUBLISH", key, b)
+	_, err := c.Do("PUBLISH", key, buf.Bytes())
 	if err != nil {
 		return errors.Wrap(err, "publish frame to gateway channel error")
 	}
@@ -82,18 +93,20 @@ func LogDownlinkFrameForGateway(ctx context.Context, p *redis.Pool, frame gw.Dow
 }
 
 // LogDownlinkFrameForDevEUI logs the given frame to the device pub-sub key.
-func LogDownlinkFrameForDevEUI(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, frame gw.DownlinkFrame) error {
+func LogDownlinkFrameForDevEUI(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, frame gw.DownlinkFrame) error {
 	c := p.Get()
 	defer c.Close()
 
 	key := fmt.Sprintf(deviceFrameLogDownlinkPubSubKeyTempl, devEUI)
 
-	b, err := proto.Marshal(&frame)
-	if err != nil {
+	buf := helpers.GetProtoBuffer()
+	defer helpers.PutProtoBuffer(buf)
+
+	if err := buf.Marshal(&frame); err != nil {
 		return errors.Wrap(err, "marshal downlink frame error")
 	}
 
-	_, err = c.Do("PUBLISH", key, b)
+	_, err := c.Do("PUBLISH", key, buf.Bytes())
 	if err != nil {
 		return errors.Wrap(err, "publish frame to device channel error")
 	}
@@ -101,17 +114,19 @@ func LogDownlinkFrameForDevEUI(ctx context.Context, p *redis.Pool, devEUI lorawa
 }
 
 // LogUplinkFrameForDevEUI logs the given frame to the pub-sub key of the given DevEUI.
-func LogUplinkFrameForDevEUI(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, frame gw.UplinkFrameSet) error {
+func LogUplinkFrameForDevEUI(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, frame gw.UplinkFrameSet) error {
 	c := p.Get()
 	defer c.Close()
 
-	b, err := proto.Marshal(&frame)
-	if err != nil {
+	buf := helpers.GetProtoBuffer()
+	defer helpers.PutProtoBuffer(buf)
+
+	if err := buf.Marshal(&frame); err != nil {
 		return errors.Wrap(err, "marshal uplink frame error")
 	}
 
 	key := fmt.Sprintf(deviceFrameLogUplinkPubSubKeyTempl, devEUI)
-	_, err = c.Do("PUBLISH", key, b)
+	_, err := c.Do("PUBLISH", key, buf.Bytes())
 	if err != nil {
 		return errors.Wrap(err, "publish frame to device channel error")
 	}
@@ -120,7 +135,7 @@ func LogUplinkFrameForDevEUI(ctx context.Context, p *redis.Pool, devEUI lorawan.
 
 // GetFrameLogForGateway subscribes to the uplink and downlink frame logs
 // for the given gateway and sends this to the given channel.
-func GetFrameLogForGateway(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64, frameLogChan chan FrameLog) error {
+func GetFrameLogForGateway(ctx context.Context, p storage.RedisClient, gatewayID lorawan.EUI64, frameLogChan chan FrameLog) error {
 	uplinkKey := fmt.Sprintf(gatewayFrameLogUplinkPubSubKeyTempl, gatewayID)
 	downlinkKey := fmt.Sprintf(gatewayFrameLogDownlinkPubSubKeyTempl, gatewayID)
 	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, frameLogChan)
@@ -128,13 +143,13 @@ func GetFrameLogForGateway(ctx context.Context, p *redis.Pool, gatewayID lorawan
 
 // GetFrameLogForDevice subscribes to the uplink and downlink frame logs
 // for the given device and sends this to the given channel.
-func GetFrameLogForDevice(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, frameLogChan chan FrameLog) error {
+func GetFrameLogForDevice(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, frameLogChan chan FrameLog) error {
 	uplinkKey := fmt.Sprintf(deviceFrameLogUplinkPubSubKeyTempl, devEUI)
 	downlinkKey := fmt.Sprintf(deviceFrameLogDownlinkPubSubKeyTempl, devEUI)
 	return getFrameLogs(ctx, p, uplinkKey, downlinkKey, frameLogChan)
 }
 
-func getFrameLogs(ctx context.Context, p *redis.Pool, uplinkKey, downlinkKey string, frameLogChan chan FrameLog) error {
+func getFrameLogs(ctx context.Context, p storage.RedisClient, uplinkKey, downlinkKey string, frameLogChan chan FrameLog) error {
 	c := p.Get()
 	defer c.Close()
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -159,3 +160,52 @@ func (ts *FrameLogTestSuite) TestGetFrameLogForDevice() {
 func TestFrameLog(t *testing.T) {
 	suite.Run(t, new(FrameLogTestSuite))
 }
+
+// discardConn implements a redis.Conn which discards all commands.
+type discardConn struct{}
+
+func (discardConn) Close() error                                   { return nil }
+func (discardConn) Err() error                                     { return nil }
+func (discardConn) Do(string, ...interface{}) (interface{}, error) { return nil, nil }
+func (discardConn) Send(string, ...interface{}) error              { return nil }
+func (discardConn) Flush() error                                   { return nil }
+func (discardConn) Receive() (interface{}, error)                  { return nil, nil }
+
+func BenchmarkLogUplinkFrameForGateways(b *testing.B) {
+	p := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return discardConn{}, nil
+		},
+	}
+
+	uplinkFrameSet := gw.UplinkFrameSet{
+		PhyPayload: []byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x00, 0x0a, 0x00, 0x01, 0x02, 0x03, 0x04},
+		TxInfo: &gw.UplinkTXInfo{
+			Frequency:  868100000,
+			Modulation: common.Modulation_LORA,
+			ModulationInfo: &gw.UplinkTXInfo_LoraModulationInfo{
+				LoraModulationInfo: &gw.LoRaModulationInfo{
+					Bandwidth:       125,
+					SpreadingFactor: 7,
+					CodeRate:        "4/5",
+				},
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		uplinkFrameSet.RxInfo = append(uplinkFrameSet.RxInfo, &gw.UplinkRXInfo{
+			GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, byte(i)},
+			Rssi:      -50,
+			LoraSnr:   5.5,
+		})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := LogUplinkFrameForGateways(context.Background(), p, uplinkFrameSet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package framelog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+// pcapng block types and options.
+const (
+	pcapngSectionHeaderBlock        = 0x0a0d0d0a
+	pcapngInterfaceDescriptionBlock = 0x00000001
+	pcapngEnhancedPacketBlock       = 0x00000006
+	pcapngByteOrderMagic            = 0x1a2b3c4d
+
+	pcapngOptEndOfOpt = 0
+	pcapngOptComment  = 1
+	pcapngOptTSResol  = 9
+
+	// linkTypeLoRaTap is the LINKTYPE_LORATAP link-type.
+	linkTypeLoRaTap = 270
+
+	// loRaTapHeaderLength is the length of the LoRaTap (version 0) header.
+	loRaTapHeaderLength = 15
+
+	// loRaTapSyncWordLoRaWAN is the LoRaWAN (public network) sync-word. The
+	// Wireshark LoRaTap dissector only hands off frames with this sync-word
+	// to the LoRaWAN dissector.
+	loRaTapSyncWordLoRaWAN = 0x34
+)
+
+// SessionKeyHint contains the session-keys of a device, which are written
+// to the capture file so that the frames can be decrypted by Wireshark.
+type SessionKeyHint struct {
+	DevAddr lorawan.DevAddr
+	NwkSKey lorawan.AES128Key
+	AppSKey *lorawan.AES128Key
+}
+
+// WriteCapturePCAPNG writes the captured frames as pcapng to w, using the
+// LoRaTap link-type so that the Wireshark LoRaWAN dissector can be used.
+// Uplink frames are written once per receiving gateway. The given key hints
+// are written as section comment, in the format of the Wireshark
+// "LoRaWAN Session Keys" table.
+func WriteCapturePCAPNG(w io.Writer, c Capture, frames []CapturedFrame, keys []SessionKeyHint) error {
+	var comments []string
+	comments = append(comments, fmt.Sprintf("LoRa Server frame-log capture %s", c.ID))
+	if len(keys) != 0 {
+		comments = append(comments, "LoRaWAN session keys (dev_addr, nwkskey, appskey):")
+		for _, k := range keys {
+			var appSKey string
+			if k.AppSKey != nil {
+				appSKey = k.AppSKey.String()
+			}
+			comments = append(comments, fmt.Sprintf(`"%s","%s","%s"`, k.DevAddr, k.NwkSKey, appSKey))
+		}
+	}
+
+	if err := writePCAPNGBlock(w, pcapngSectionHeaderBlock, sectionHeaderBody(), pcapngOption(pcapngOptComment, []byte(strings.Join(comments, "\n")))); err != nil {
+		return errors.Wrap(err, "write section header block error")
+	}
+
+	if err := writePCAPNGBlock(w, pcapngInterfaceDescriptionBlock, interfaceDescriptionBody(), pcapngOption(pcapngOptTSResol, []byte{9})); err != nil {
+		return errors.Wrap(err, "write interface description block error")
+	}
+
+	for _, f := range frames {
+		if f.UplinkFrame != nil {
+			for _, rxInfo := range f.UplinkFrame.RxInfo {
+				var gatewayID lorawan.EUI64
+				copy(gatewayID[:], rxInfo.GatewayId)
+
+				header := loRaTapHeader(f.UplinkFrame.TxInfo.GetFrequency(), f.UplinkFrame.TxInfo.GetLoraModulationInfo(), int(rxInfo.Rssi), rxInfo.LoraSnr)
+				comment := fmt.Sprintf("uplink, gateway %s", gatewayID)
+				if err := writeEnhancedPacketBlock(w, f, append(header, f.UplinkFrame.PhyPayload...), comment); err != nil {
+					return err
+				}
+			}
+		}
+
+		if f.DownlinkFrame != nil {
+			txInfo := f.DownlinkFrame.TxInfo
+			var gatewayID lorawan.EUI64
+			copy(gatewayID[:], txInfo.GetGatewayId())
+
+			header := loRaTapHeader(txInfo.GetFrequency(), txInfo.GetLoraModulationInfo(), 0, 0)
+			comment := fmt.Sprintf("downlink, gateway %s", gatewayID)
+			if err := writeEnhancedPacketBlock(w, f, append(header, f.DownlinkFrame.PhyPayload...), comment); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func sectionHeaderBody() []byte {
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint32(b[0:4], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(b[4:6], 1) // major version
+	binary.LittleEndian.PutUint16(b[6:8], 0) // minor version
+	binary.LittleEndian.PutUint64(b[8:16], 0xffffffffffffffff)
+	return b
+}
+
+func interfaceDescriptionBody() []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint16(b[0:2], linkTypeLoRaTap)
+	binary.LittleEndian.PutUint32(b[4:8], 0) // no snap-length limit
+	return b
+}
+
+func writeEnhancedPacketBlock(w io.Writer, f CapturedFrame, data []byte, comment string) error {
+	ts := uint64(f.Time.UnixNano())
+
+	body := make([]byte, 20, 20+len(data)+3)
+	binary.LittleEndian.PutUint32(body[0:4], 0) // interface id
+	binary.LittleEndian.PutUint32(body[4:8], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(ts))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(len(data)))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(len(data)))
+	body = append(body, pad32(data)...)
+
+	if err := writePCAPNGBlock(w, pcapngEnhancedPacketBlock, body, pcapngOption(pcapngOptComment, []byte(comment))); err != nil {
+		return errors.Wrap(err, "write enhanced packet block error")
+	}
+	return nil
+}
+
+// loRaTapHeader returns the LoRaTap (version 0) header for the given
+// meta-data.
+func loRaTapHeader(frequency uint32, modInfo *gw.LoRaModulationInfo, rssi int, snr float64) []byte {
+	b := make([]byte, loRaTapHeaderLength)
+	b[0] = 0 // version
+	binary.BigEndian.PutUint16(b[2:4], loRaTapHeaderLength)
+	binary.BigEndian.PutUint32(b[4:8], frequency)
+	if modInfo != nil {
+		b[8] = uint8(modInfo.Bandwidth / 125)
+		b[9] = uint8(modInfo.SpreadingFactor)
+	}
+
+	// RSSI is encoded as -139 + value (dBm), SNR as value / 4 (dB).
+	if rssi != 0 {
+		b[10] = clampUint8(rssi + 139) // packet rssi
+		b[11] = clampUint8(rssi + 139) // max rssi
+	}
+	b[13] = uint8(int8(snr * 4))
+	b[14] = loRaTapSyncWordLoRaWAN
+
+	return b
+}
+
+func pcapngOption(code uint16, value []byte) []byte {
+	b := make([]byte, 4, 4+len(value)+3+4)
+	binary.LittleEndian.PutUint16(b[0:2], code)
+	binary.LittleEndian.PutUint16(b[2:4], uint16(len(value)))
+	b = append(b, pad32(value)...)
+
+	// opt_endofopt
+	return append(b, byte(pcapngOptEndOfOpt), 0, 0, 0)
+}
+
+func writePCAPNGBlock(w io.Writer, blockType uint32, body, options []byte) error {
+	length := uint32(12 + len(body) + len(options))
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, blockType)
+	binary.Write(&buf, binary.LittleEndian, length)
+	buf.Write(body)
+	buf.Write(options)
+	binary.Write(&buf, binary.LittleEndian, length)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func pad32(b []byte) []byte {
+	if n := len(b) % 4; n != 0 {
+		return append(b[:len(b):len(b)], make([]byte, 4-n)...)
+	}
+	return b
+}
+
+func clampUint8(v int) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
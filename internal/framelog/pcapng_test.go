@@ -0,0 +1,107 @@
+package framelog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestWriteCapturePCAPNG(t *testing.T) {
+	assert := require.New(t)
+
+	modInfo := &gw.UplinkTXInfo_LoraModulationInfo{
+		LoraModulationInfo: &gw.LoRaModulationInfo{
+			Bandwidth:       125,
+			SpreadingFactor: 7,
+		},
+	}
+
+	frames := []CapturedFrame{
+		{
+			Time: time.Unix(1, 0),
+			FrameLog: FrameLog{
+				UplinkFrame: &gw.UplinkFrameSet{
+					PhyPayload: []byte{1, 2, 3, 4, 5},
+					TxInfo: &gw.UplinkTXInfo{
+						Frequency:      868100000,
+						ModulationInfo: modInfo,
+					},
+					RxInfo: []*gw.UplinkRXInfo{
+						{GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Rssi: -50, LoraSnr: 5},
+						{GatewayId: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Rssi: -100, LoraSnr: -2.5},
+					},
+				},
+			},
+		},
+		{
+			Time: time.Unix(2, 0),
+			FrameLog: FrameLog{
+				DownlinkFrame: &gw.DownlinkFrame{
+					PhyPayload: []byte{6, 7, 8},
+					TxInfo: &gw.DownlinkTXInfo{
+						GatewayId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+						Frequency: 869525000,
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(WriteCapturePCAPNG(&buf, Capture{}, frames, []SessionKeyHint{
+		{DevAddr: lorawan.DevAddr{1, 2, 3, 4}},
+	}))
+
+	// split the output into blocks
+	type block struct {
+		Type uint32
+		Body []byte
+	}
+	var blocks []block
+	b := buf.Bytes()
+	for len(b) > 0 {
+		assert.True(len(b) >= 12)
+		blockType := binary.LittleEndian.Uint32(b[0:4])
+		length := binary.LittleEndian.Uint32(b[4:8])
+		assert.Equal(uint32(0), length%4)
+		assert.Equal(length, binary.LittleEndian.Uint32(b[length-4:length]))
+		blocks = append(blocks, block{Type: blockType, Body: b[8 : length-4]})
+		b = b[length:]
+	}
+
+	assert.Len(blocks, 5)
+	assert.EqualValues(pcapngSectionHeaderBlock, blocks[0].Type)
+	assert.EqualValues(pcapngByteOrderMagic, binary.LittleEndian.Uint32(blocks[0].Body[0:4]))
+	assert.Contains(string(blocks[0].Body), `"01020304"`)
+
+	assert.EqualValues(pcapngInterfaceDescriptionBlock, blocks[1].Type)
+	assert.EqualValues(linkTypeLoRaTap, binary.LittleEndian.Uint16(blocks[1].Body[0:2]))
+
+	for i, phy := range [][]byte{{1, 2, 3, 4, 5}, {1, 2, 3, 4, 5}, {6, 7, 8}} {
+		epb := blocks[i+2]
+		assert.EqualValues(pcapngEnhancedPacketBlock, epb.Type)
+
+		capLen := binary.LittleEndian.Uint32(epb.Body[12:16])
+		assert.EqualValues(loRaTapHeaderLength+len(phy), capLen)
+
+		data := epb.Body[20 : 20+capLen]
+		assert.Equal(byte(0), data[0])
+		assert.EqualValues(loRaTapHeaderLength, binary.BigEndian.Uint16(data[2:4]))
+		assert.Equal(byte(loRaTapSyncWordLoRaWAN), data[14])
+		assert.Equal(phy, data[loRaTapHeaderLength:])
+	}
+
+	// rssi and snr of the second gateway
+	data := blocks[3].Body[20:]
+	assert.EqualValues(868100000, binary.BigEndian.Uint32(data[4:8]))
+	assert.Equal(byte(1), data[8])
+	assert.Equal(byte(7), data[9])
+	assert.Equal(byte(39), data[10])
+	assert.Equal(int8(-10), int8(data[13]))
+}
@@ -0,0 +1,135 @@
+package framelog
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+type contextKey int
+
+const redactionContextKey contextKey = iota
+
+// truncatedDevEUILength defines the number of DevEUI bytes which are kept
+// when truncating the DevEUI.
+const truncatedDevEUILength = 4
+
+// Redaction defines the redaction applied to logged frames.
+type Redaction struct {
+	// FRMPayload replaces the FRMPayload of application frames (FPort > 0)
+	// by zeros.
+	FRMPayload bool
+
+	// TruncateDevEUI only keeps the first bytes of the DevEUI of
+	// (re)join-requests.
+	TruncateDevEUI bool
+}
+
+// GetRedaction returns the redaction configured by the given
+// service-profile.
+func GetRedaction(sp storage.ServiceProfile) Redaction {
+	return Redaction{
+		FRMPayload:     sp.FrameLogRedactPayload,
+		TruncateDevEUI: sp.FrameLogTruncateDevEUI,
+	}
+}
+
+// ContextWithRedaction returns a context to which the uplink handlers can
+// set the redaction (using SetRedaction), once the service-profile of the
+// device is known. This is used for logging the uplink frames to the
+// gateway frame-logs, as these are collected before the device is known.
+func ContextWithRedaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, redactionContextKey, &Redaction{})
+}
+
+// SetRedaction sets the redaction in the given context. It is a no-op when
+// the context was not created by ContextWithRedaction.
+func SetRedaction(ctx context.Context, r Redaction) {
+	if ptr, ok := ctx.Value(redactionContextKey).(*Redaction); ok {
+		*ptr = r
+	}
+}
+
+// GetRedactionFromContext returns the redaction set in the given context.
+func GetRedactionFromContext(ctx context.Context) Redaction {
+	if ptr, ok := ctx.Value(redactionContextKey).(*Redaction); ok {
+		return *ptr
+	}
+	return Redaction{}
+}
+
+// RedactUplinkFrameSet returns a copy of the given uplink frame-set with the
+// redaction applied to its PHYPayload.
+func (r Redaction) RedactUplinkFrameSet(f gw.UplinkFrameSet) (gw.UplinkFrameSet, error) {
+	var err error
+	f.PhyPayload, err = r.redactPHYPayload(f.PhyPayload)
+	return f, err
+}
+
+// RedactDownlinkFrame returns a copy of the given downlink frame with the
+// redaction applied to its PHYPayload.
+func (r Redaction) RedactDownlinkFrame(f gw.DownlinkFrame) (gw.DownlinkFrame, error) {
+	var err error
+	f.PhyPayload, err = r.redactPHYPayload(f.PhyPayload)
+	return f, err
+}
+
+func (r Redaction) redactPHYPayload(b []byte) ([]byte, error) {
+	if !r.FRMPayload && !r.TruncateDevEUI {
+		return b, nil
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		return nil, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	var changed bool
+
+	switch pl := phy.MACPayload.(type) {
+	case *lorawan.MACPayload:
+		if r.FRMPayload && pl.FPort != nil && *pl.FPort != 0 {
+			for _, p := range pl.FRMPayload {
+				if dp, ok := p.(*lorawan.DataPayload); ok {
+					dp.Bytes = make([]byte, len(dp.Bytes))
+					changed = true
+				}
+			}
+		}
+	case *lorawan.JoinRequestPayload:
+		if r.TruncateDevEUI {
+			truncateDevEUI(&pl.DevEUI)
+			changed = true
+		}
+	case *lorawan.RejoinRequestType02Payload:
+		if r.TruncateDevEUI {
+			truncateDevEUI(&pl.DevEUI)
+			changed = true
+		}
+	case *lorawan.RejoinRequestType1Payload:
+		if r.TruncateDevEUI {
+			truncateDevEUI(&pl.DevEUI)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return b, nil
+	}
+
+	out, err := phy.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal phypayload error")
+	}
+	return out, nil
+}
+
+func truncateDevEUI(devEUI *lorawan.EUI64) {
+	for i := truncatedDevEUILength; i < len(devEUI); i++ {
+		devEUI[i] = 0
+	}
+}
@@ -0,0 +1,100 @@
+package framelog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestRedaction(t *testing.T) {
+	assert := require.New(t)
+
+	fPort := uint8(10)
+	dataPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:    10,
+			},
+			FPort:      &fPort,
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3}}},
+		},
+		MIC: lorawan.MIC{1, 2, 3, 4},
+	}
+	dataB, err := dataPHY.MarshalBinary()
+	assert.NoError(err)
+
+	joinPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinRequest,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.JoinRequestPayload{
+			JoinEUI:  lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			DevNonce: 1,
+		},
+	}
+	joinB, err := joinPHY.MarshalBinary()
+	assert.NoError(err)
+
+	t.Run("No redaction", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := Redaction{}.RedactUplinkFrameSet(gw.UplinkFrameSet{PhyPayload: dataB})
+		assert.NoError(err)
+		assert.Equal(dataB, out.PhyPayload)
+	})
+
+	t.Run("FRMPayload", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := Redaction{FRMPayload: true}.RedactDownlinkFrame(gw.DownlinkFrame{PhyPayload: dataB})
+		assert.NoError(err)
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(out.PhyPayload))
+		macPL := phy.MACPayload.(*lorawan.MACPayload)
+		assert.Equal(uint32(10), macPL.FHDR.FCnt)
+		assert.Equal(dataPHY.MIC, phy.MIC)
+		assert.Equal([]lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{0, 0, 0}}}, macPL.FRMPayload)
+
+		// the join-request is not modified
+		out, err = Redaction{FRMPayload: true}.RedactDownlinkFrame(gw.DownlinkFrame{PhyPayload: joinB})
+		assert.NoError(err)
+		assert.Equal(joinB, out.PhyPayload)
+	})
+
+	t.Run("Truncate DevEUI", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := Redaction{TruncateDevEUI: true}.RedactUplinkFrameSet(gw.UplinkFrameSet{PhyPayload: joinB})
+		assert.NoError(err)
+
+		var phy lorawan.PHYPayload
+		assert.NoError(phy.UnmarshalBinary(out.PhyPayload))
+		jrPL := phy.MACPayload.(*lorawan.JoinRequestPayload)
+		assert.Equal(lorawan.EUI64{1, 2, 3, 4, 0, 0, 0, 0}, jrPL.DevEUI)
+		assert.Equal(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, jrPL.JoinEUI)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		assert := require.New(t)
+
+		// no-op without redaction context
+		SetRedaction(context.Background(), Redaction{FRMPayload: true})
+		assert.Equal(Redaction{}, GetRedactionFromContext(context.Background()))
+
+		ctx := ContextWithRedaction(context.Background())
+		SetRedaction(ctx, Redaction{FRMPayload: true})
+		assert.Equal(Redaction{FRMPayload: true}, GetRedactionFromContext(ctx))
+	})
+}
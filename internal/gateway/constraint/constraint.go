@@ -0,0 +1,137 @@
+// Package constraint implements the gateway scheduling constraints. The
+// constraints are configured per gateway model and are matched against the
+// model of the gateway inventory (parsed from the gateway stats). Gateways
+// violating a constraint are removed from the downlink gateway candidates,
+// e.g. gateways without GPS timing are not used for Class-B downlinks and
+// gateways with a limited downlink queue are not used while the number of
+// downlinks awaiting a TX acknowledgement has reached the limit.
+package constraint
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	constraintNoGPSTiming           = "no_gps_timing"
+	constraintMaxDownlinkQueueDepth = "max_downlink_queue_depth"
+)
+
+// Constraints defines the scheduling constraints of a gateway model.
+type Constraints struct {
+	// NoGPSTiming indicates that the gateway can not be used for GPS
+	// time-synchronized (Class-B) downlinks.
+	NoGPSTiming bool
+
+	// MaxDownlinkQueueDepth defines the max. number of downlinks awaiting
+	// a TX acknowledgement (0 = no limit).
+	MaxDownlinkQueueDepth int
+}
+
+var (
+	mux         sync.RWMutex
+	constraints map[string]Constraints
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	constraints = make(map[string]Constraints)
+	for _, c := range conf.NetworkServer.Gateway.Constraints {
+		if c.Model == "" {
+			return errors.New("gateway constraint model must be set")
+		}
+		if c.MaxDownlinkQueueDepth < 0 {
+			return errors.New("gateway constraint max_downlink_queue_depth must not be negative")
+		}
+
+		constraints[c.Model] = Constraints{
+			NoGPSTiming:           c.NoGPSTiming,
+			MaxDownlinkQueueDepth: c.MaxDownlinkQueueDepth,
+		}
+	}
+
+	return nil
+}
+
+// GetConstraints returns the constraints for the given gateway model. It
+// returns an empty Constraints when no constraints are configured for the
+// model.
+func GetConstraints(model string) Constraints {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return constraints[model]
+}
+
+// FilterDeviceGatewayRXInfo returns the items of the given slice of which the
+// gateway does not violate the constraints of its model. When gpsTiming is
+// set, the downlink requires GPS timing (Class-B).
+func FilterDeviceGatewayRXInfo(ctx context.Context, db sqlx.Queryer, p storage.RedisClient, items []storage.DeviceGatewayRXInfo, gpsTiming bool) ([]storage.DeviceGatewayRXInfo, error) {
+	mux.RLock()
+	configured := len(constraints) != 0
+	mux.RUnlock()
+
+	if !configured || len(items) == 0 {
+		return items, nil
+	}
+
+	var ids []lorawan.EUI64
+	for _, item := range items {
+		ids = append(ids, item.GatewayID)
+	}
+
+	models, err := storage.GetGatewayModels(ctx, db, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "get gateway models error")
+	}
+
+	var out []storage.DeviceGatewayRXInfo
+	for _, item := range items {
+		model := models[item.GatewayID]
+		c := GetConstraints(model)
+
+		if gpsTiming && c.NoGPSTiming {
+			filtered(ctx, item.GatewayID, model, constraintNoGPSTiming)
+			continue
+		}
+
+		if c.MaxDownlinkQueueDepth != 0 {
+			gatewayID := item.GatewayID
+			count, err := storage.GetDownlinkTXAckPendingCount(ctx, p, &gatewayID)
+			if err != nil {
+				return nil, errors.Wrap(err, "get downlink tx ack pending count error")
+			}
+			if count >= c.MaxDownlinkQueueDepth {
+				filtered(ctx, item.GatewayID, model, constraintMaxDownlinkQueueDepth)
+				continue
+			}
+		}
+
+		out = append(out, item)
+	}
+
+	return out, nil
+}
+
+func filtered(ctx context.Context, gatewayID lorawan.EUI64, model, constraint string) {
+	filteredGatewayCounter(model, constraint).Inc()
+
+	log.WithFields(log.Fields{
+		"gateway_id": gatewayID,
+		"model":      model,
+		"constraint": constraint,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Debug("constraint: gateway removed from downlink candidates")
+}
@@ -0,0 +1,121 @@
+package constraint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type ConstraintTestSuite struct {
+	suite.Suite
+
+	gateways []storage.Gateway
+}
+
+func (ts *ConstraintTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustResetDB(storage.DB().DB)
+
+	conf.NetworkServer.Gateway.Constraints = append(conf.NetworkServer.Gateway.Constraints, struct {
+		Model                 string `mapstructure:"model"`
+		NoGPSTiming           bool   `mapstructure:"no_gps_timing"`
+		MaxDownlinkQueueDepth int    `mapstructure:"max_downlink_queue_depth"`
+	}{
+		Model:       "model-x",
+		NoGPSTiming: true,
+	}, struct {
+		Model                 string `mapstructure:"model"`
+		NoGPSTiming           bool   `mapstructure:"no_gps_timing"`
+		MaxDownlinkQueueDepth int    `mapstructure:"max_downlink_queue_depth"`
+	}{
+		Model:                 "model-y",
+		MaxDownlinkQueueDepth: 1,
+	})
+	assert.NoError(Setup(conf))
+
+	rp := storage.RoutingProfile{}
+	assert.NoError(storage.CreateRoutingProfile(context.Background(), storage.DB(), &rp))
+
+	ts.gateways = []storage.Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RoutingProfileID: rp.ID},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RoutingProfileID: rp.ID},
+		{GatewayID: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, RoutingProfileID: rp.ID},
+	}
+	models := []string{"model-x", "model-y", "model-z"}
+	for i := range ts.gateways {
+		assert.NoError(storage.CreateGateway(context.Background(), storage.DB(), &ts.gateways[i]))
+		assert.NoError(storage.SaveGatewayInventory(context.Background(), storage.DB(), &storage.GatewayInventory{
+			GatewayID: ts.gateways[i].GatewayID,
+			Model:     models[i],
+		}))
+	}
+}
+
+func (ts *ConstraintTestSuite) TearDownSuite() {
+	Setup(test.GetConfig())
+}
+
+func (ts *ConstraintTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *ConstraintTestSuite) TestGetConstraints() {
+	assert := require.New(ts.T())
+
+	assert.Equal(Constraints{NoGPSTiming: true}, GetConstraints("model-x"))
+	assert.Equal(Constraints{MaxDownlinkQueueDepth: 1}, GetConstraints("model-y"))
+	assert.Equal(Constraints{}, GetConstraints("model-z"))
+}
+
+func (ts *ConstraintTestSuite) TestFilterDeviceGatewayRXInfo() {
+	ctx := context.Background()
+
+	var items []storage.DeviceGatewayRXInfo
+	for _, gw := range ts.gateways {
+		items = append(items, storage.DeviceGatewayRXInfo{GatewayID: gw.GatewayID})
+	}
+	// unknown gateway (no inventory)
+	items = append(items, storage.DeviceGatewayRXInfo{GatewayID: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}})
+
+	ts.T().Run("No GPS timing required", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := FilterDeviceGatewayRXInfo(ctx, storage.DB(), storage.RedisPool(), items, false)
+		assert.NoError(err)
+		assert.Equal(items, out)
+	})
+
+	ts.T().Run("GPS timing required", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := FilterDeviceGatewayRXInfo(ctx, storage.DB(), storage.RedisPool(), items, true)
+		assert.NoError(err)
+		assert.Equal(items[1:], out)
+	})
+
+	ts.T().Run("Downlink queue depth reached", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.SetDownlinkTXAckPending(ctx, storage.RedisPool(), gw.DownlinkFrame{
+			Token:  123,
+			TxInfo: &gw.DownlinkTXInfo{GatewayId: ts.gateways[1].GatewayID[:]},
+		}))
+
+		out, err := FilterDeviceGatewayRXInfo(ctx, storage.DB(), storage.RedisPool(), items, false)
+		assert.NoError(err)
+		assert.Equal([]storage.DeviceGatewayRXInfo{items[0], items[2], items[3]}, out)
+	})
+}
+
+func TestConstraint(t *testing.T) {
+	suite.Run(t, new(ConstraintTestSuite))
+}
@@ -0,0 +1,17 @@
+package constraint
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	fgc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_constraint_filtered_count",
+		Help: "The number of downlink opportunities through gateways removed by a scheduling constraint (per model and constraint).",
+	}, []string{"model", "constraint"})
+)
+
+func filteredGatewayCounter(model, constraint string) prometheus.Counter {
+	return fgc.With(prometheus.Labels{"model": model, "constraint": constraint})
+}
@@ -0,0 +1,316 @@
+// Package contribution implements the gateway contribution metrics, used for
+// the gateway reward calculation. Per gateway and per aggregation interval
+// it counts the unique frames for which the gateway was the first or the only
+// receiver, the (de-duplicated) frames received by the gateway and the
+// downlinks transmitted by the gateway.
+//
+// Only uplinks which have been successfully handled (e.g. passed the MIC and
+// frame-counter validation) and downlinks acknowledged by the gateway to which
+// the downlink was sent are counted, so that gateways can not inflate their
+// contribution by forwarding fabricated or replayed frames. The first receiver
+// is the gateway of which the frame was received first by LoRa Server (not
+// the timestamp reported by the gateway).
+package contribution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	eventsPubSubKey         = "lora:ns:gw:pubsub:contribution"
+	pendingDownlinkKeyTempl = "lora:ns:gw:contribution:downlink:%d"
+
+	// pendingDownlinkTTL defines how long a sent downlink waits for its
+	// tx acknowledgement.
+	pendingDownlinkTTL = time.Minute
+)
+
+// Metric names.
+const (
+	MetricFirstReceiver = "first_receiver"
+	MetricOnlyReceiver  = "only_receiver"
+	MetricUplink        = "uplink"
+	MetricDownlink      = "downlink"
+)
+
+// EventType defines the contribution event type.
+type EventType string
+
+// Available event types.
+const (
+	Uplink   EventType = "uplink"
+	Downlink EventType = "downlink"
+)
+
+// Event defines a gateway contribution event.
+type Event struct {
+	Time          time.Time     `json:"time"`
+	GatewayID     lorawan.EUI64 `json:"gatewayID"`
+	Type          EventType     `json:"type"`
+	FirstReceiver bool          `json:"firstReceiver"`
+	OnlyReceiver  bool          `json:"onlyReceiver"`
+}
+
+var (
+	mux     sync.RWMutex
+	enabled bool
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	enabled = conf.NetworkServer.Gateway.Contribution.Enabled
+
+	return nil
+}
+
+// Enabled returns true when the contribution metrics are enabled.
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return enabled
+}
+
+// MetricsName returns the metrics name of the given gateway, see
+// storage.GetMetrics.
+func MetricsName(gatewayID lorawan.EUI64) string {
+	return "gw:contribution:" + gatewayID.String()
+}
+
+// HandleUplink counts the contribution of each gateway within the given
+// rx-info set. The first gateway ID is the gateway of which LoRa Server
+// received the frame first.
+func HandleUplink(ctx context.Context, p storage.RedisClient, firstGatewayID lorawan.EUI64, rxInfoSet []*gw.UplinkRXInfo) error {
+	if !Enabled() {
+		return nil
+	}
+
+	// a gateway could have received the frame on multiple antennas / boards
+	var gatewayIDs []lorawan.EUI64
+	seen := make(map[lorawan.EUI64]struct{})
+	for _, rxInfo := range rxInfoSet {
+		id := helpers.GetGatewayID(rxInfo)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		gatewayIDs = append(gatewayIDs, id)
+	}
+
+	now := time.Now()
+
+	for _, id := range gatewayIDs {
+		e := Event{
+			Time:          now,
+			GatewayID:     id,
+			Type:          Uplink,
+			FirstReceiver: id == firstGatewayID,
+			OnlyReceiver:  len(gatewayIDs) == 1,
+		}
+
+		if err := handleEvent(ctx, p, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DownlinkSent stores the gateway to which the given downlink frame was sent.
+// The contribution is counted once the gateway acknowledges the
+// transmission, see HandleDownlinkTXAck.
+func DownlinkSent(ctx context.Context, p storage.RedisClient, frame gw.DownlinkFrame) error {
+	if !Enabled() {
+		return nil
+	}
+
+	if frame.TxInfo == nil {
+		return errors.New("tx_info must not be nil")
+	}
+
+	gatewayID := helpers.GetGatewayID(frame.TxInfo)
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(pendingDownlinkKeyTempl, frame.Token), int64(pendingDownlinkTTL/time.Millisecond), gatewayID[:])
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+// HandleDownlinkTXAck counts the downlink contribution for the given
+// acknowledgement, in case it does not contain an error and it was sent by
+// the gateway to which the downlink was sent.
+func HandleDownlinkTXAck(ctx context.Context, p storage.RedisClient, token uint16, ack gw.DownlinkTXAck) error {
+	if !Enabled() {
+		return nil
+	}
+
+	key := fmt.Sprintf(pendingDownlinkKeyTempl, token)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	b, err := redis.Bytes(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil
+		}
+		return errors.Wrap(err, "get error")
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], b)
+
+	if ack.Error != "" {
+		return nil
+	}
+
+	if ackGatewayID := helpers.GetGatewayID(&ack); ackGatewayID != gatewayID {
+		log.WithFields(log.Fields{
+			"gateway_id":     gatewayID,
+			"ack_gateway_id": ackGatewayID,
+			"ctx_id":         ctx.Value(logging.ContextIDKey),
+		}).Warning("contribution: tx acknowledgement gateway does not match downlink gateway")
+		return nil
+	}
+
+	return handleEvent(ctx, p, Event{
+		Time:      time.Now(),
+		GatewayID: gatewayID,
+		Type:      Downlink,
+	})
+}
+
+// GetEvents subscribes to the contribution events and sends these to the
+// given channel until the given context is cancelled. When the gateway ID is
+// not empty, only the events of the given gateway are returned.
+func GetEvents(ctx context.Context, p storage.RedisClient, gatewayID lorawan.EUI64, eventChan chan Event) error {
+	c := p.Get()
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(eventsPubSubKey); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				var e Event
+				if err := json.Unmarshal(v.Data, &e); err != nil {
+					log.WithError(err).Error("contribution: decode event error")
+					continue
+				}
+
+				if gatewayID != (lorawan.EUI64{}) && gatewayID != e.GatewayID {
+					continue
+				}
+
+				eventChan <- e
+			case redis.Subscription:
+				if v.Count == 0 {
+					done <- nil
+					return
+				}
+			case error:
+				done <- v
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			if err := psc.Ping(""); err != nil {
+				log.WithError(err).Error("contribution: subscription ping error")
+				break loop
+			}
+		case <-ctx.Done():
+			break loop
+		case err := <-done:
+			return err
+		}
+	}
+
+	if err := psc.Unsubscribe(); err != nil {
+		return errors.Wrap(err, "unsubscribe error")
+	}
+
+	return <-done
+}
+
+// handleEvent stores the metrics of the given event and publishes it.
+func handleEvent(ctx context.Context, p storage.RedisClient, e Event) error {
+	metrics := make(map[string]float64)
+	switch e.Type {
+	case Uplink:
+		metrics[MetricUplink] = 1
+		if e.FirstReceiver {
+			metrics[MetricFirstReceiver] = 1
+		}
+		if e.OnlyReceiver {
+			metrics[MetricOnlyReceiver] = 1
+		}
+	case Downlink:
+		metrics[MetricDownlink] = 1
+	}
+
+	if err := storage.SaveMetrics(ctx, p, MetricsName(e.GatewayID), storage.MetricsRecord{
+		Time:    e.Time,
+		Metrics: metrics,
+	}); err != nil {
+		return errors.Wrap(err, "save metrics error")
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("PUBLISH", eventsPubSubKey, b); err != nil {
+		return errors.Wrap(err, "publish event error")
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package contribution
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type ContributionTestSuite struct {
+	suite.Suite
+}
+
+func (ts *ContributionTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+
+	assert.NoError(storage.SetAggregationIntervals([]storage.AggregationInterval{storage.AggregationMinute}))
+	storage.SetMetricsTTL(time.Minute, time.Minute, time.Minute, time.Minute)
+
+	conf.NetworkServer.Gateway.Contribution.Enabled = true
+	assert.NoError(Setup(conf))
+}
+
+func (ts *ContributionTestSuite) TearDownSuite() {
+	Setup(test.GetConfig())
+}
+
+func (ts *ContributionTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *ContributionTestSuite) getMetrics(gatewayID lorawan.EUI64) map[string]float64 {
+	assert := require.New(ts.T())
+
+	now := time.Now()
+	metrics, err := storage.GetMetrics(context.Background(), storage.RedisPool(), storage.AggregationMinute, MetricsName(gatewayID), now.Add(-time.Minute), now)
+	assert.NoError(err)
+
+	out := make(map[string]float64)
+	for _, m := range metrics {
+		for k, v := range m.Metrics {
+			out[k] += v
+		}
+	}
+	return out
+}
+
+func (ts *ContributionTestSuite) TestUplink() {
+	ctx := context.Background()
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	ts.T().Run("Only receiver", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(HandleUplink(ctx, storage.RedisPool(), gw1, []*gw.UplinkRXInfo{
+			{GatewayId: gw1[:], Board: 0},
+			{GatewayId: gw1[:], Board: 1},
+		}))
+
+		assert.Equal(map[string]float64{
+			MetricFirstReceiver: 1,
+			MetricOnlyReceiver:  1,
+			MetricUplink:        1,
+		}, ts.getMetrics(gw1))
+	})
+
+	ts.T().Run("Multiple receivers", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(HandleUplink(ctx, storage.RedisPool(), gw2, []*gw.UplinkRXInfo{
+			{GatewayId: gw1[:]},
+			{GatewayId: gw2[:]},
+		}))
+
+		assert.Equal(map[string]float64{
+			MetricFirstReceiver: 1,
+			MetricOnlyReceiver:  1,
+			MetricUplink:        2,
+		}, ts.getMetrics(gw1))
+
+		assert.Equal(map[string]float64{
+			MetricFirstReceiver: 1,
+			MetricUplink:        1,
+		}, ts.getMetrics(gw2))
+	})
+}
+
+func (ts *ContributionTestSuite) TestDownlink() {
+	ctx := context.Background()
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	frame := gw.DownlinkFrame{
+		Token: 1234,
+		TxInfo: &gw.DownlinkTXInfo{
+			GatewayId: gw1[:],
+		},
+	}
+
+	ts.T().Run("Not transmitted", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DownlinkSent(ctx, storage.RedisPool(), frame))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{GatewayId: gw1[:], Token: 1234, Error: "TOO_LATE"}))
+		assert.Len(ts.getMetrics(gw1), 0)
+	})
+
+	ts.T().Run("Acknowledged by other gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DownlinkSent(ctx, storage.RedisPool(), frame))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{GatewayId: gw2[:], Token: 1234}))
+		assert.Len(ts.getMetrics(gw1), 0)
+		assert.Len(ts.getMetrics(gw2), 0)
+	})
+
+	ts.T().Run("Transmitted", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DownlinkSent(ctx, storage.RedisPool(), frame))
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{GatewayId: gw1[:], Token: 1234}))
+		assert.Equal(map[string]float64{
+			MetricDownlink: 1,
+		}, ts.getMetrics(gw1))
+
+		// the acknowledgement is only counted once
+		assert.NoError(HandleDownlinkTXAck(ctx, storage.RedisPool(), 1234, gw.DownlinkTXAck{GatewayId: gw1[:], Token: 1234}))
+		assert.Equal(map[string]float64{
+			MetricDownlink: 1,
+		}, ts.getMetrics(gw1))
+	})
+}
+
+func (ts *ContributionTestSuite) TestGetEvents() {
+	assert := require.New(ts.T())
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventChan := make(chan Event)
+	go func() {
+		if err := GetEvents(ctx, storage.RedisPool(), gw2, eventChan); err != nil {
+			panic(err)
+		}
+	}()
+
+	// give the subscriber some time to subscribe
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(HandleUplink(context.Background(), storage.RedisPool(), gw1, []*gw.UplinkRXInfo{
+		{GatewayId: gw1[:]},
+		{GatewayId: gw2[:]},
+	}))
+
+	e := <-eventChan
+	assert.Equal(gw2, e.GatewayID)
+	assert.Equal(Uplink, e.Type)
+	assert.False(e.FirstReceiver)
+	assert.False(e.OnlyReceiver)
+}
+
+func TestContribution(t *testing.T) {
+	suite.Run(t, new(ContributionTestSuite))
+}
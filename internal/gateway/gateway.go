@@ -10,7 +10,6 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes"
-	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -66,10 +65,185 @@ func (s *StatsHandler) Start() error {
 	return nil
 }
 
-// Stop waits for the stats handler to complete the pending packets.
-// At this stage the gateway backend must already been closed.
+// Stop waits for the stats handler to complete the pending packets and
+// flushes the aggregated stats. At this stage the gateway backend must
+// already been closed.
 func (s *StatsHandler) Stop() error {
 	s.wg.Wait()
+	return stats.Stop()
+}
+
+// ConnStateHandler handles the gateway connection states received by the
+// gateway backend.
+type ConnStateHandler struct {
+	wg sync.WaitGroup
+}
+
+// NewConnStateHandler creates a new ConnStateHandler.
+func NewConnStateHandler() *ConnStateHandler {
+	return &ConnStateHandler{}
+}
+
+// Start starts the connection state handler. It is a no-op when the gateway
+// backend does not report connection states. The states are handled in
+// order, so that the last received state of a gateway is stored.
+func (h *ConnStateHandler) Start() error {
+	csb, ok := gateway.Backend().(gateway.ConnStateBackend)
+	if !ok || csb.ConnStateChan() == nil {
+		return nil
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		for state := range csb.ConnStateChan() {
+			if err := handleConnState(context.Background(), state); err != nil {
+				log.WithError(err).WithField("gateway_id", helpers.GetGatewayID(&state)).Error("gateway: handle gateway connection state error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop waits for the connection state handler to complete the pending
+// states. At this stage the gateway backend must already been closed.
+func (h *ConnStateHandler) Stop() error {
+	h.wg.Wait()
+	return nil
+}
+
+func handleConnState(ctx context.Context, state gw.ConnState) error {
+	s := storage.GatewayConnectionState{
+		GatewayID: helpers.GetGatewayID(&state),
+		Online:    state.State == gw.ConnState_ONLINE,
+	}
+
+	if err := storage.SaveGatewayConnectionState(ctx, storage.DB(), &s); err != nil {
+		if err == storage.ErrDoesNotExist {
+			log.WithField("gateway_id", s.GatewayID).Debug("gateway: connection state of unknown gateway ignored")
+			return nil
+		}
+		return errors.Wrap(err, "save gateway connection state error")
+	}
+
+	return nil
+}
+
+// CommandExecResponseHandler handles the gateway command execution responses
+// received by the gateway backend.
+type CommandExecResponseHandler struct {
+	wg sync.WaitGroup
+}
+
+// NewCommandExecResponseHandler creates a new CommandExecResponseHandler.
+func NewCommandExecResponseHandler() *CommandExecResponseHandler {
+	return &CommandExecResponseHandler{}
+}
+
+// Start starts the command execution response handler. It is a no-op when
+// the gateway backend does not support gateway commands.
+func (h *CommandExecResponseHandler) Start() error {
+	ceb, ok := gateway.Backend().(gateway.CommandExecBackend)
+	if !ok || ceb.GatewayCommandExecResponseChan() == nil {
+		return nil
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		for resp := range ceb.GatewayCommandExecResponseChan() {
+			if err := storage.SaveGatewayCommandExecResponse(context.Background(), storage.RedisPool(), resp); err != nil {
+				log.WithError(err).WithField("gateway_id", helpers.GetGatewayID(&resp)).Error("gateway: save gateway command execution response error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop waits for the command execution response handler to complete the
+// pending responses. At this stage the gateway backend must already been
+// closed.
+func (h *CommandExecResponseHandler) Stop() error {
+	h.wg.Wait()
+	return nil
+}
+
+// NoiseFloorReportHandler handles the noise-floor reports received by the
+// gateway backend.
+type NoiseFloorReportHandler struct {
+	wg sync.WaitGroup
+}
+
+// NewNoiseFloorReportHandler creates a new NoiseFloorReportHandler.
+func NewNoiseFloorReportHandler() *NoiseFloorReportHandler {
+	return &NoiseFloorReportHandler{}
+}
+
+// Start starts the noise-floor report handler. It is a no-op when the
+// gateway backend does not support noise-floor reports.
+func (h *NoiseFloorReportHandler) Start() error {
+	nfb, ok := gateway.Backend().(gateway.NoiseFloorBackend)
+	if !ok || nfb.NoiseFloorReportChan() == nil {
+		return nil
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		for report := range nfb.NoiseFloorReportChan() {
+			if err := handleNoiseFloorReport(context.Background(), report); err != nil {
+				log.WithError(err).WithField("gateway_id", helpers.GetGatewayID(&report)).Error("gateway: handle noise-floor report error")
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop waits for the noise-floor report handler to complete the pending
+// reports. At this stage the gateway backend must already been closed.
+func (h *NoiseFloorReportHandler) Stop() error {
+	h.wg.Wait()
+	return nil
+}
+
+func handleNoiseFloorReport(ctx context.Context, report gw.NoiseFloorReport) error {
+	gatewayID := helpers.GetGatewayID(&report)
+
+	err := storage.Transaction(func(tx sqlx.Ext) error {
+		for _, c := range report.Channels {
+			if c == nil {
+				continue
+			}
+
+			nf := storage.GatewayNoiseFloor{
+				GatewayID:          gatewayID,
+				Frequency:          c.Frequency,
+				Bandwidth:          c.Bandwidth,
+				NoiseFloor:         c.NoiseFloor,
+				ChannelUtilization: c.ChannelUtilization,
+				RSSIHistogram:      storage.RSSIHistogram(c.RssiHistogram),
+			}
+
+			if err := storage.SaveGatewayNoiseFloor(ctx, tx, &nf); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			log.WithField("gateway_id", gatewayID).Debug("gateway: noise-floor report of unknown gateway ignored")
+			return nil
+		}
+		return errors.Wrap(err, "save gateway noise-floor error")
+	}
+
 	return nil
 }
 
@@ -78,92 +252,112 @@ func (s *StatsHandler) Stop() error {
 //   - add the gateway location
 //   - set the FPGA id if available
 //   - decrypt the fine-timestamp (if available and AES key is set)
-func UpdateMetaDataInRxInfoSet(ctx context.Context, db sqlx.Queryer, p *redis.Pool, rxInfo []*gw.UplinkRXInfo) error {
+//
+// The rx-info elements are updated concurrently, therefore the given db
+// must be safe for concurrent use.
+func UpdateMetaDataInRxInfoSet(ctx context.Context, db sqlx.Queryer, p storage.RedisClient, rxInfo []*gw.UplinkRXInfo) error {
+	if len(rxInfo) == 1 {
+		updateMetaDataInRxInfo(ctx, db, p, rxInfo[0])
+		return nil
+	}
+
+	var wg sync.WaitGroup
 	for i := range rxInfo {
-		id := helpers.GetGatewayID(rxInfo[i])
-		g, err := storage.GetAndCacheGateway(ctx, db, p, id)
-		if err != nil {
+		wg.Add(1)
+		go func(rxInfo *gw.UplinkRXInfo) {
+			defer wg.Done()
+			updateMetaDataInRxInfo(ctx, db, p, rxInfo)
+		}(rxInfo[i])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// updateMetaDataInRxInfo updates the gateway meta-data of a single rx-info
+// element. Errors are logged.
+func updateMetaDataInRxInfo(ctx context.Context, db sqlx.Queryer, p storage.RedisClient, rxInfo *gw.UplinkRXInfo) {
+	id := helpers.GetGatewayID(rxInfo)
+	g, err := storage.GetAndCacheGateway(ctx, db, p, id)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+			"gateway_id": id,
+		}).WithError(err).Error("get gateway error")
+		return
+	}
+
+	// set gateway location
+	rxInfo.Location = &common.Location{
+		Latitude:  g.Location.Latitude,
+		Longitude: g.Location.Longitude,
+		Altitude:  g.Altitude,
+	}
+
+	var board storage.GatewayBoard
+	if int(rxInfo.Board) < len(g.Boards) {
+		board = g.Boards[int(rxInfo.Board)]
+	}
+
+	// set FPGA ID
+	// this is useful when the AES decryption key is not set as it
+	// indicates which key to use for decryption
+	if rxInfo.FineTimestampType == gw.FineTimestampType_ENCRYPTED && board.FPGAID != nil {
+		tsInfo := rxInfo.GetEncryptedFineTimestamp()
+		if tsInfo == nil {
 			log.WithFields(log.Fields{
 				"ctx_id":     ctx.Value(logging.ContextIDKey),
 				"gateway_id": id,
-			}).WithError(err).Error("get gateway error")
-			continue
+			}).Error("encrypted_fine_timestamp must not be nil")
+			return
 		}
 
-		// set gateway location
-		rxInfo[i].Location = &common.Location{
-			Latitude:  g.Location.Latitude,
-			Longitude: g.Location.Longitude,
-			Altitude:  g.Altitude,
+		if len(tsInfo.FpgaId) == 0 {
+			tsInfo.FpgaId = board.FPGAID[:]
 		}
+	}
 
-		var board storage.GatewayBoard
-		if int(rxInfo[i].Board) < len(g.Boards) {
-			board = g.Boards[int(rxInfo[i].Board)]
+	// decrypt fine-timestamp when the AES key is known
+	if rxInfo.FineTimestampType == gw.FineTimestampType_ENCRYPTED && board.FineTimestampKey != nil {
+		tsInfo := rxInfo.GetEncryptedFineTimestamp()
+		if tsInfo == nil {
+			log.WithFields(log.Fields{
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+				"gateway_id": id,
+			}).Error("encrypted_fine_timestamp must not be nil")
+			return
 		}
 
-		// set FPGA ID
-		// this is useful when the AES decryption key is not set as it
-		// indicates which key to use for decryption
-		if rxInfo[i].FineTimestampType == gw.FineTimestampType_ENCRYPTED && board.FPGAID != nil {
-			tsInfo := rxInfo[i].GetEncryptedFineTimestamp()
-			if tsInfo == nil {
-				log.WithFields(log.Fields{
-					"ctx_id":     ctx.Value(logging.ContextIDKey),
-					"gateway_id": id,
-				}).Error("encrypted_fine_timestamp must not be nil")
-				continue
-			}
-
-			if len(tsInfo.FpgaId) == 0 {
-				tsInfo.FpgaId = board.FPGAID[:]
-			}
+		if rxInfo.Time == nil {
+			log.WithFields(log.Fields{
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+				"gateway_id": id,
+			}).Error("time must not be nil")
+			return
 		}
 
-		// decrypt fine-timestamp when the AES key is known
-		if rxInfo[i].FineTimestampType == gw.FineTimestampType_ENCRYPTED && board.FineTimestampKey != nil {
-			tsInfo := rxInfo[i].GetEncryptedFineTimestamp()
-			if tsInfo == nil {
-				log.WithFields(log.Fields{
-					"ctx_id":     ctx.Value(logging.ContextIDKey),
-					"gateway_id": id,
-				}).Error("encrypted_fine_timestamp must not be nil")
-				continue
-			}
-
-			if rxInfo[i].Time == nil {
-				log.WithFields(log.Fields{
-					"ctx_id":     ctx.Value(logging.ContextIDKey),
-					"gateway_id": id,
-				}).Error("time must not be nil")
-				continue
-			}
-
-			rxTime, err := ptypes.Timestamp(rxInfo[i].Time)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"ctx_id":     ctx.Value(logging.ContextIDKey),
-					"gateway_id": id,
-				}).WithError(err).Error("get timestamp error")
-			}
+		rxTime, err := ptypes.Timestamp(rxInfo.Time)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+				"gateway_id": id,
+			}).WithError(err).Error("get timestamp error")
+		}
 
-			plainTS, err := decryptFineTimestamp(*board.FineTimestampKey, rxTime, *tsInfo)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"ctx_id":     ctx.Value(logging.ContextIDKey),
-					"gateway_id": id,
-				}).WithError(err).Error("decrypt fine-timestamp error")
-				continue
-			}
+		plainTS, err := decryptFineTimestamp(*board.FineTimestampKey, rxTime, *tsInfo)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+				"gateway_id": id,
+			}).WithError(err).Error("decrypt fine-timestamp error")
+			return
+		}
 
-			rxInfo[i].FineTimestampType = gw.FineTimestampType_PLAIN
-			rxInfo[i].FineTimestamp = &gw.UplinkRXInfo_PlainFineTimestamp{
-				PlainFineTimestamp: &plainTS,
-			}
+		rxInfo.FineTimestampType = gw.FineTimestampType_PLAIN
+		rxInfo.FineTimestamp = &gw.UplinkRXInfo_PlainFineTimestamp{
+			PlainFineTimestamp: &plainTS,
 		}
 	}
-
-	return nil
 }
 
 func decryptFineTimestamp(key lorawan.AES128Key, rxTime time.Time, ts gw.EncryptedFineTimestamp) (gw.PlainFineTimestamp, error) {
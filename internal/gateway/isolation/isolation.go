@@ -0,0 +1,100 @@
+// Package isolation implements the per service-profile (tenant) gateway
+// isolation. When enabled for a service-profile, the receptions of gateways
+// which are not within the allowed gateways of the service-profile are
+// removed from the uplink before it is used (e.g. for ADR, the
+// application-server forwarding and the downlink gateway selection) and
+// downlinks are never scheduled through these gateways.
+//
+// Note that the de-duplication happens before the device (and thus the
+// service-profile) is known, the filtering is therefore applied to the
+// de-duplicated set of receptions.
+package isolation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// ErrNoAllowedGateways is returned when a frame has only been received by
+// gateways which are not allowed by the service-profile.
+var ErrNoAllowedGateways = errors.New("frame received by not-allowed gateways only")
+
+const (
+	directionUplink   = "uplink"
+	directionDownlink = "downlink"
+)
+
+// FilterRXInfoSet returns the rx-info elements of the given set which have
+// been received by gateways allowed by the given service-profile. It returns
+// ErrNoAllowedGateways when none of the gateways is allowed.
+func FilterRXInfoSet(ctx context.Context, sp storage.ServiceProfile, rxInfoSet []*gw.UplinkRXInfo) ([]*gw.UplinkRXInfo, error) {
+	if !sp.GatewayIsolation {
+		return rxInfoSet, nil
+	}
+
+	var out []*gw.UplinkRXInfo
+	for i := range rxInfoSet {
+		gatewayID := helpers.GetGatewayID(rxInfoSet[i])
+		if !sp.IsGatewayAllowed(gatewayID) {
+			crossTenant(ctx, sp, gatewayID, directionUplink)
+			continue
+		}
+		out = append(out, rxInfoSet[i])
+	}
+
+	if len(out) == 0 {
+		rejectedUplinkCounter(sp.ID.String()).Inc()
+		return nil, ErrNoAllowedGateways
+	}
+
+	return out, nil
+}
+
+// FilterDeviceGatewayRXInfo returns the items of the given slice of which
+// the gateway is allowed by the given service-profile.
+func FilterDeviceGatewayRXInfo(ctx context.Context, sp storage.ServiceProfile, items []storage.DeviceGatewayRXInfo) []storage.DeviceGatewayRXInfo {
+	if !sp.GatewayIsolation {
+		return items
+	}
+
+	var out []storage.DeviceGatewayRXInfo
+	for i := range items {
+		if !sp.IsGatewayAllowed(items[i].GatewayID) {
+			crossTenant(ctx, sp, items[i].GatewayID, directionDownlink)
+			continue
+		}
+		out = append(out, items[i])
+	}
+
+	return out
+}
+
+// DownlinkAllowed returns true when the given gateway may be used for
+// sending a downlink on behalf of the given service-profile.
+func DownlinkAllowed(ctx context.Context, sp storage.ServiceProfile, gatewayID lorawan.EUI64) bool {
+	if sp.IsGatewayAllowed(gatewayID) {
+		return true
+	}
+
+	crossTenant(ctx, sp, gatewayID, directionDownlink)
+	return false
+}
+
+func crossTenant(ctx context.Context, sp storage.ServiceProfile, gatewayID lorawan.EUI64, direction string) {
+	crossTenantCounter(sp.ID.String(), direction).Inc()
+
+	log.WithFields(log.Fields{
+		"service_profile_id": sp.ID,
+		"gateway_id":         gatewayID,
+		"direction":          direction,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Debug("isolation: gateway not allowed by service-profile")
+}
@@ -0,0 +1,91 @@
+package isolation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+func TestFilterRXInfoSet(t *testing.T) {
+	ctx := context.Background()
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	rxInfoSet := []*gw.UplinkRXInfo{
+		{GatewayId: gw1[:]},
+		{GatewayId: gw2[:]},
+	}
+
+	tests := []struct {
+		Name           string
+		ServiceProfile storage.ServiceProfile
+		Expected       []*gw.UplinkRXInfo
+		ExpectedError  error
+	}{
+		{
+			Name:     "isolation disabled",
+			Expected: rxInfoSet,
+		},
+		{
+			Name: "one gateway allowed",
+			ServiceProfile: storage.ServiceProfile{
+				GatewayIsolation:  true,
+				AllowedGatewayIDs: pq.ByteaArray{gw2[:]},
+			},
+			Expected: []*gw.UplinkRXInfo{
+				{GatewayId: gw2[:]},
+			},
+		},
+		{
+			Name: "no gateway allowed",
+			ServiceProfile: storage.ServiceProfile{
+				GatewayIsolation:  true,
+				AllowedGatewayIDs: pq.ByteaArray{{3, 3, 3, 3, 3, 3, 3, 3}},
+			},
+			ExpectedError: ErrNoAllowedGateways,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := FilterRXInfoSet(ctx, tst.ServiceProfile, rxInfoSet)
+			assert.Equal(tst.ExpectedError, err)
+			assert.Equal(tst.Expected, out)
+		})
+	}
+}
+
+func TestFilterDeviceGatewayRXInfo(t *testing.T) {
+	assert := require.New(t)
+	ctx := context.Background()
+
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	items := []storage.DeviceGatewayRXInfo{
+		{GatewayID: gw1},
+		{GatewayID: gw2},
+	}
+
+	sp := storage.ServiceProfile{
+		GatewayIsolation:  true,
+		AllowedGatewayIDs: pq.ByteaArray{gw1[:]},
+	}
+
+	assert.Equal([]storage.DeviceGatewayRXInfo{{GatewayID: gw1}}, FilterDeviceGatewayRXInfo(ctx, sp, items))
+	assert.True(DownlinkAllowed(ctx, sp, gw1))
+	assert.False(DownlinkAllowed(ctx, sp, gw2))
+
+	sp.GatewayIsolation = false
+	assert.Equal(items, FilterDeviceGatewayRXInfo(ctx, sp, items))
+	assert.True(DownlinkAllowed(ctx, sp, gw2))
+}
@@ -0,0 +1,26 @@
+package isolation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ctc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_isolation_cross_tenant_count",
+		Help: "The number of uplink receptions and downlink opportunities through gateways not allowed by the service-profile (per service-profile and direction).",
+	}, []string{"service_profile_id", "direction"})
+
+	ruc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_isolation_rejected_uplink_count",
+		Help: "The number of uplinks rejected as these were only received by gateways not allowed by the service-profile (per service-profile).",
+	}, []string{"service_profile_id"})
+)
+
+func crossTenantCounter(serviceProfileID, direction string) prometheus.Counter {
+	return ctc.With(prometheus.Labels{"service_profile_id": serviceProfileID, "direction": direction})
+}
+
+func rejectedUplinkCounter(serviceProfileID string) prometheus.Counter {
+	return ruc.With(prometheus.Labels{"service_profile_id": serviceProfileID})
+}
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// aggregateKey identifies a buffered aggregate.
+type aggregateKey struct {
+	gatewayID lorawan.EUI64
+	interval  time.Duration
+	timestamp int64
+}
+
+var (
+	aggregateMux       sync.Mutex
+	aggregateIntervals []time.Duration
+	aggregateBuffer    = make(map[aggregateKey]*storage.GatewayStatsAggregate)
+	aggregateDone      chan struct{}
+)
+
+// Setup configures the package. When the stats aggregation is enabled, it
+// starts the loop flushing the aggregated stats to the database.
+func Setup(conf config.Config) error {
+	aggConf := conf.NetworkServer.Gateway.StatsAggregation
+
+	aggregateMux.Lock()
+	defer aggregateMux.Unlock()
+
+	aggregateIntervals = nil
+	if !aggConf.Enabled {
+		return nil
+	}
+
+	for _, i := range aggConf.Intervals {
+		aggregateIntervals = append(aggregateIntervals, i.Interval)
+	}
+
+	log.WithFields(log.Fields{
+		"intervals":      aggregateIntervals,
+		"flush_interval": aggConf.FlushInterval,
+	}).Info("gateway/stats: starting stats aggregation")
+
+	aggregateDone = make(chan struct{})
+	go flushLoop(aggConf.FlushInterval, aggregateDone)
+
+	return nil
+}
+
+// Stop stops the flush loop and flushes the pending aggregates. At this
+// stage, the handling of the gateway stats must have been completed.
+func Stop() error {
+	aggregateMux.Lock()
+	if aggregateDone != nil {
+		close(aggregateDone)
+		aggregateDone = nil
+	}
+	aggregateMux.Unlock()
+
+	return Flush(context.Background())
+}
+
+// Flush saves the aggregated stats which have been buffered since the last
+// flush. Aggregates which could not be saved are dropped.
+func Flush(ctx context.Context) error {
+	aggregateMux.Lock()
+	buffer := aggregateBuffer
+	aggregateBuffer = make(map[aggregateKey]*storage.GatewayStatsAggregate)
+	aggregateMux.Unlock()
+
+	var errCount int
+	for _, agg := range buffer {
+		if err := storage.SaveGatewayStatsAggregate(ctx, storage.DB(), *agg); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": agg.GatewayID,
+				"interval":   agg.Interval,
+				"timestamp":  agg.Timestamp,
+			}).Error("gateway/stats: save gateway stats aggregate error")
+			errCount++
+		}
+	}
+
+	if errCount != 0 {
+		return fmt.Errorf("save %d of %d gateway stats aggregates failed", errCount, len(buffer))
+	}
+
+	return nil
+}
+
+func flushLoop(interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := Flush(context.Background()); err != nil {
+				log.WithError(err).Error("gateway/stats: flush gateway stats aggregates error")
+			}
+		}
+	}
+}
+
+// aggregateGatewayStats adds the gateway stats to the buffered aggregate of
+// each aggregation interval. The stats are aggregated by the time of
+// receiving, as the gateway time might not be set or be inaccurate.
+func aggregateGatewayStats(ctx *statsContext) error {
+	aggregateMux.Lock()
+	defer aggregateMux.Unlock()
+
+	addGatewayStats(aggregateBuffer, aggregateIntervals, ctx.gateway.GatewayID, time.Now(), ctx.gatewayStats)
+	return nil
+}
+
+// addGatewayStats adds the given stats to the aggregates of the given
+// intervals. The aggregation timestamp is the start of the interval, the
+// intervals are aligned to the Unix epoch (UTC).
+func addGatewayStats(buffer map[aggregateKey]*storage.GatewayStatsAggregate, intervals []time.Duration, gatewayID lorawan.EUI64, now time.Time, stats gw.GatewayStats) {
+	for _, interval := range intervals {
+		ts := now.Truncate(interval)
+		key := aggregateKey{
+			gatewayID: gatewayID,
+			interval:  interval,
+			timestamp: ts.UnixNano(),
+		}
+
+		agg, ok := buffer[key]
+		if !ok {
+			agg = &storage.GatewayStatsAggregate{
+				GatewayID:             gatewayID,
+				Interval:              interval,
+				Timestamp:             ts,
+				RXPacketsPerFrequency: make(storage.GatewayStatsCounts),
+				TXPacketsPerFrequency: make(storage.GatewayStatsCounts),
+				TXPacketsPerStatus:    make(storage.GatewayStatsCounts),
+			}
+			buffer[key] = agg
+		}
+
+		agg.RXPacketsReceived += stats.RxPacketsReceived
+		agg.RXPacketsReceivedOK += stats.RxPacketsReceivedOk
+		agg.TXPacketsReceived += stats.TxPacketsReceived
+		agg.TXPacketsEmitted += stats.TxPacketsEmitted
+		agg.RXPacketsPerFrequency.Add(frequencyCounts(stats.RxPacketsPerFrequency))
+		agg.TXPacketsPerFrequency.Add(frequencyCounts(stats.TxPacketsPerFrequency))
+		agg.TXPacketsPerStatus.Add(stats.TxPacketsPerStatus)
+	}
+}
+
+func frequencyCounts(counts map[uint32]uint32) storage.GatewayStatsCounts {
+	out := make(storage.GatewayStatsCounts, len(counts))
+	for freq, count := range counts {
+		out[strconv.FormatUint(uint64(freq), 10)] = count
+	}
+	return out
+}
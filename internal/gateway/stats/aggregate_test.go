@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+func TestAddGatewayStats(t *testing.T) {
+	assert := require.New(t)
+
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	intervals := []time.Duration{time.Minute, time.Hour}
+	now := time.Date(2019, 10, 1, 12, 30, 15, 0, time.UTC)
+	buffer := make(map[aggregateKey]*storage.GatewayStatsAggregate)
+
+	stats := gw.GatewayStats{
+		GatewayId:         gatewayID[:],
+		RxPacketsReceived: 10,
+		TxPacketsEmitted:  2,
+		RxPacketsPerFrequency: map[uint32]uint32{
+			868100000: 6,
+			868300000: 4,
+		},
+		TxPacketsPerFrequency: map[uint32]uint32{
+			869525000: 2,
+		},
+		TxPacketsPerStatus: map[string]uint32{
+			"OK":       2,
+			"TOO_LATE": 1,
+		},
+	}
+
+	addGatewayStats(buffer, intervals, gatewayID, now, stats)
+	addGatewayStats(buffer, intervals, gatewayID, now.Add(10*time.Second), stats)
+	addGatewayStats(buffer, intervals, gatewayID, now.Add(time.Minute), stats)
+	assert.Len(buffer, 3)
+
+	minute := buffer[aggregateKey{
+		gatewayID: gatewayID,
+		interval:  time.Minute,
+		timestamp: time.Date(2019, 10, 1, 12, 30, 0, 0, time.UTC).UnixNano(),
+	}]
+	assert.NotNil(minute)
+	assert.EqualValues(20, minute.RXPacketsReceived)
+	assert.EqualValues(4, minute.TXPacketsEmitted)
+
+	hour := buffer[aggregateKey{
+		gatewayID: gatewayID,
+		interval:  time.Hour,
+		timestamp: time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC).UnixNano(),
+	}]
+	assert.NotNil(hour)
+	assert.True(time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC).Equal(hour.Timestamp))
+	assert.EqualValues(30, hour.RXPacketsReceived)
+	assert.Equal(storage.GatewayStatsCounts{
+		"868100000": 18,
+		"868300000": 12,
+	}, hour.RXPacketsPerFrequency)
+	assert.Equal(storage.GatewayStatsCounts{
+		"869525000": 6,
+	}, hour.TXPacketsPerFrequency)
+	assert.Equal(storage.GatewayStatsCounts{
+		"OK":       6,
+		"TOO_LATE": 3,
+	}, hour.TXPacketsPerStatus)
+}
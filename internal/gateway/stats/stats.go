@@ -2,6 +2,7 @@ package stats
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -27,6 +28,8 @@ type statsContext struct {
 var tasks = []func(*statsContext) error{
 	getGateway,
 	updateGatewayState,
+	updateGatewayInventory,
+	aggregateGatewayStats,
 	handleGatewayConfigurationUpdate,
 	forwardGatewayStats,
 }
@@ -82,6 +85,25 @@ func updateGatewayState(ctx *statsContext) error {
 	return nil
 }
 
+// updateGatewayInventory persists the model and firmware, bridge and
+// concentrator versions reported in the stats meta-data. A failure is logged
+// as this must not block the handling of the stats.
+func updateGatewayInventory(ctx *statsContext) error {
+	inv, ok := storage.ParseGatewayInventory(ctx.gateway.GatewayID, ctx.gatewayStats.MetaData)
+	if !ok {
+		return nil
+	}
+
+	if err := storage.SaveGatewayInventory(ctx.ctx, storage.DB(), &inv); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"gateway_id": ctx.gateway.GatewayID,
+			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("save gateway inventory error")
+	}
+
+	return nil
+}
+
 func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 	if ctx.gateway.GatewayProfileID == nil {
 		log.WithFields(log.Fields{
@@ -95,23 +117,93 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 	if err != nil {
 		return errors.Wrap(err, "get gateway-profile error")
 	}
+	version := gwProfile.GetVersion()
+
+	// When a frequency-plan is assigned, its channels replace the channels
+	// of the gateway-profile. The frequency-plan version is part of the
+	// configuration version so that a new frequency-plan version (or a
+	// rollback) triggers a configuration update.
+	var beacon *gw.BeaconConfiguration
+	fp, err := storage.GetFrequencyPlanForGatewayProfile(ctx.ctx, storage.DB(), gwProfile)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get frequency-plan error")
+	}
+	if err == nil {
+		gwProfile.Channels = fp.Channels
+		gwProfile.ExtraChannels = fp.ExtraChannels
+		version = fmt.Sprintf("%s/%d", version, fp.Version)
 
-	if gwProfile.GetVersion() == ctx.gatewayStats.ConfigVersion {
+		if fp.BeaconFrequency != 0 {
+			dr, err := band.Get(band.RegionWithChannelGroup(gwProfile.Region, gwProfile.ChannelGroup)).GetDataRate(fp.BeaconDR)
+			if err != nil {
+				return errors.Wrap(err, "get beacon data-rate error")
+			}
+
+			beacon = &gw.BeaconConfiguration{
+				Frequency:       uint32(fp.BeaconFrequency),
+				Bandwidth:       uint32(dr.Bandwidth),
+				SpreadingFactor: uint32(dr.SpreadFactor),
+			}
+		}
+	}
+
+	if version == ctx.gatewayStats.ConfigVersion {
 		log.WithFields(log.Fields{
 			"gateway_id": ctx.gateway.GatewayID,
 			"version":    ctx.gatewayStats.ConfigVersion,
 			"ctx_id":     ctx.ctx.Value(logging.ContextIDKey),
 		}).Debug("gateway configuration is up-to-date")
+
+		if err := storage.SetGatewayConfigurationApplied(ctx.ctx, storage.DB(), ctx.gateway.GatewayID, version); err != nil {
+			return errors.Wrap(err, "set gateway configuration applied error")
+		}
+		return nil
+	}
+
+	status, err := storage.GetGatewayConfigurationStatus(ctx.ctx, storage.DB(), ctx.gateway.GatewayID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get gateway configuration status error")
+	}
+	status.GatewayID = ctx.gateway.GatewayID
+
+	// Outside the reconfiguration window, the configuration update is
+	// deferred to the next window. Gateways which do not report a
+	// configuration version yet are configured immediately, as there is no
+	// traffic to interrupt.
+	now := time.Now()
+	if ctx.gatewayStats.ConfigVersion != "" && !gwProfile.InReconfigurationWindow(now) {
+		if status.Version == version && status.State == storage.GatewayConfigurationScheduled {
+			return nil
+		}
+
+		scheduledAt := gwProfile.NextReconfigurationWindow(now)
+		status.Version = version
+		status.State = storage.GatewayConfigurationScheduled
+		status.ScheduledAt = &scheduledAt
+		status.SentAt = nil
+		status.AppliedAt = nil
+
+		if err := storage.SaveGatewayConfigurationStatus(ctx.ctx, storage.DB(), &status); err != nil {
+			return errors.Wrap(err, "save gateway configuration status error")
+		}
+
+		log.WithFields(log.Fields{
+			"gateway_id":   ctx.gateway.GatewayID,
+			"version":      version,
+			"scheduled_at": scheduledAt,
+			"ctx_id":       ctx.ctx.Value(logging.ContextIDKey),
+		}).Info("gateway configuration update scheduled")
 		return nil
 	}
 
 	configPacket := gw.GatewayConfiguration{
 		GatewayId: ctx.gateway.GatewayID[:],
-		Version:   gwProfile.GetVersion(),
+		Version:   version,
+		Beacon:    beacon,
 	}
 
 	for _, i := range gwProfile.Channels {
-		c, err := band.Band().GetUplinkChannel(int(i))
+		c, err := band.Get(band.RegionWithChannelGroup(gwProfile.Region, gwProfile.ChannelGroup)).GetUplinkChannel(int(i))
 		if err != nil {
 			return errors.Wrap(err, "get channel error")
 		}
@@ -124,7 +216,7 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 		modConfig := gw.LoRaModulationConfig{}
 
 		for drI := c.MaxDR; drI >= c.MinDR; drI-- {
-			dr, err := band.Band().GetDataRate(drI)
+			dr, err := band.Get(band.RegionWithChannelGroup(gwProfile.Region, gwProfile.ChannelGroup)).GetDataRate(drI)
 			if err != nil {
 				return errors.Wrap(err, "get data-rate error")
 			}
@@ -174,10 +266,36 @@ func handleGatewayConfigurationUpdate(ctx *statsContext) error {
 		configPacket.Channels = append(configPacket.Channels, &gwC)
 	}
 
+	if gwProfile.LBTEnabled {
+		configPacket.Lbt = &gw.LBTConfiguration{
+			RssiTarget: int32(gwProfile.LBTRSSITarget),
+			ScanTime:   uint32(gwProfile.LBTScanTime),
+		}
+		for _, f := range gwProfile.LBTFrequencies {
+			configPacket.Lbt.Frequencies = append(configPacket.Lbt.Frequencies, uint32(f))
+		}
+	}
+
 	if err := gateway.Backend().SendGatewayConfigPacket(configPacket); err != nil {
 		return errors.Wrap(err, "send gateway-configuration packet error")
 	}
 
+	// The configuration is re-sent on every stats interval until the gateway
+	// reports the new version; the status is only stored on the first send.
+	if status.Version == version && status.State == storage.GatewayConfigurationSent {
+		return nil
+	}
+
+	status.Version = version
+	status.State = storage.GatewayConfigurationSent
+	status.ScheduledAt = nil
+	status.SentAt = &now
+	status.AppliedAt = nil
+
+	if err := storage.SaveGatewayConfigurationStatus(ctx.ctx, storage.DB(), &status); err != nil {
+		return errors.Wrap(err, "save gateway configuration status error")
+	}
+
 	return nil
 }
 
@@ -157,6 +157,91 @@ func (ts *GatewayConfigurationTestSuite) TestUpdate() {
 				},
 			},
 		}, gwConfig)
+
+		status, err := storage.GetGatewayConfigurationStatus(context.Background(), storage.DB(), ts.gateway.GatewayID)
+		assert.NoError(err)
+		assert.Equal(gp.GetVersion(), status.Version)
+		assert.Equal(storage.GatewayConfigurationSent, status.State)
+		assert.NotNil(status.SentAt)
+
+		t.Run("Applied", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId:     ts.gateway.GatewayID[:],
+				ConfigVersion: gp.GetVersion(),
+			}))
+			assert.Equal(0, len(ts.backend.GatewayConfigPacketChan))
+
+			status, err := storage.GetGatewayConfigurationStatus(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(storage.GatewayConfigurationApplied, status.State)
+			assert.NotNil(status.AppliedAt)
+		})
+
+		t.Run("Outside reconfiguration window", func(t *testing.T) {
+			assert := require.New(t)
+
+			now := time.Now().UTC()
+			minute := now.Hour()*60 + now.Minute()
+
+			gp.ReconfigurationWindowStart = (minute + 120) % (24 * 60)
+			gp.ReconfigurationWindowDuration = 60
+			assert.NoError(storage.UpdateGatewayProfile(context.Background(), storage.DB(), &gp))
+			gp, err = storage.GetGatewayProfile(context.Background(), storage.DB(), gp.ID)
+			assert.NoError(err)
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId:     ts.gateway.GatewayID[:],
+				ConfigVersion: "1.2.3",
+			}))
+			assert.Equal(0, len(ts.backend.GatewayConfigPacketChan))
+
+			status, err := storage.GetGatewayConfigurationStatus(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), status.Version)
+			assert.Equal(storage.GatewayConfigurationScheduled, status.State)
+			assert.NotNil(status.ScheduledAt)
+			assert.True(status.ScheduledAt.After(now))
+
+			t.Run("Unconfigured gateway", func(t *testing.T) {
+				assert := require.New(t)
+
+				assert.NoError(Handle(context.Background(), gw.GatewayStats{
+					GatewayId: ts.gateway.GatewayID[:],
+				}))
+
+				gwConfig := <-ts.backend.GatewayConfigPacketChan
+				assert.Equal(gp.GetVersion(), gwConfig.Version)
+			})
+		})
+
+		t.Run("Within reconfiguration window", func(t *testing.T) {
+			assert := require.New(t)
+
+			now := time.Now().UTC()
+			minute := now.Hour()*60 + now.Minute()
+
+			gp.ReconfigurationWindowStart = (minute + 24*60 - 10) % (24 * 60)
+			gp.ReconfigurationWindowDuration = 60
+			assert.NoError(storage.UpdateGatewayProfile(context.Background(), storage.DB(), &gp))
+			gp, err = storage.GetGatewayProfile(context.Background(), storage.DB(), gp.ID)
+			assert.NoError(err)
+
+			assert.NoError(Handle(context.Background(), gw.GatewayStats{
+				GatewayId:     ts.gateway.GatewayID[:],
+				ConfigVersion: "1.2.3",
+			}))
+
+			gwConfig := <-ts.backend.GatewayConfigPacketChan
+			assert.Equal(gp.GetVersion(), gwConfig.Version)
+
+			status, err := storage.GetGatewayConfigurationStatus(context.Background(), storage.DB(), ts.gateway.GatewayID)
+			assert.NoError(err)
+			assert.Equal(gp.GetVersion(), status.Version)
+			assert.Equal(storage.GatewayConfigurationSent, status.State)
+			assert.Nil(status.ScheduledAt)
+		})
 	})
 }
 
@@ -0,0 +1,340 @@
+// Package handover implements the zero-downtime restart of LoRa Server. On
+// SIGUSR2, the running process starts a new process (using the current
+// executable and arguments), passing it the listener sockets. Once the new
+// process is ready (it has set up the gateway backend and started serving),
+// the running process stops and drains.
+package handover
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+const (
+	// envListeners contains the (comma separated) names of the inherited
+	// listeners. The file descriptor of each listener is 3 + its index.
+	envListeners = "LORASERVER_HANDOVER_LISTENERS"
+
+	// envReadyFD contains the file descriptor to which the new process
+	// writes when it is ready.
+	envReadyFD = "LORASERVER_HANDOVER_READY_FD"
+)
+
+// ErrNotSupported is returned when the handover is not supported on the
+// current platform.
+var ErrNotSupported = errors.New("handover is not supported on this platform")
+
+type filer interface {
+	File() (*os.File, error)
+}
+
+var (
+	mux          sync.Mutex
+	names        []string
+	listeners    = make(map[string]filer)
+	readyTimeout time.Duration
+	done         = make(chan struct{})
+)
+
+// Setup configures the handover and starts handling the handover signal.
+func Setup(conf config.Config) error {
+	if !conf.NetworkServer.Handover.Enabled {
+		return nil
+	}
+
+	if len(handoverSignals) == 0 {
+		return ErrNotSupported
+	}
+
+	mux.Lock()
+	readyTimeout = conf.NetworkServer.Handover.ReadyTimeout
+	mux.Unlock()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, handoverSignals...)
+
+	go func() {
+		for s := range sigChan {
+			log.WithField("signal", s).Info("handover: signal received, starting new process")
+			if err := handover(); err != nil {
+				log.WithError(err).Error("handover: handover error")
+				continue
+			}
+
+			log.Info("handover: new process is ready")
+			signal.Stop(sigChan)
+			close(done)
+			return
+		}
+	}()
+
+	return nil
+}
+
+// Listen returns the listener with the given name. When inherited from the
+// previous process, the inherited listener is returned, else a new TCP
+// listener is created for the given address.
+func Listen(name, address string) (net.Listener, error) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if _, ok := listeners[name]; ok {
+		return nil, fmt.Errorf("listener %s already exists", name)
+	}
+
+	ln, err := inheritedListener(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "inherit listener error")
+	}
+
+	if ln == nil {
+		ln, err = net.Listen("tcp", address)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		log.WithFields(log.Fields{
+			"name": name,
+			"addr": ln.Addr(),
+		}).Info("handover: using inherited listener")
+	}
+
+	fl, ok := ln.(filer)
+	if !ok {
+		ln.Close()
+		return nil, fmt.Errorf("listener %s can not be passed", name)
+	}
+
+	names = append(names, name)
+	listeners[name] = fl
+
+	return ln, nil
+}
+
+// ListenPacket returns the packet connection with the given name. When
+// inherited from the previous process, the inherited connection is returned,
+// else a new UDP connection is created for the given address.
+func ListenPacket(name, address string) (net.PacketConn, error) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if _, ok := listeners[name]; ok {
+		return nil, fmt.Errorf("listener %s already exists", name)
+	}
+
+	var conn net.PacketConn
+	f := inheritedFile(name)
+	if f != nil {
+		defer f.Close()
+
+		var err error
+		conn, err = net.FilePacketConn(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "inherit packet connection error")
+		}
+
+		log.WithFields(log.Fields{
+			"name": name,
+			"addr": conn.LocalAddr(),
+		}).Info("handover: using inherited packet connection")
+	} else {
+		var err error
+		conn, err = net.ListenPacket("udp", address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fl, ok := conn.(filer)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("packet connection %s can not be passed", name)
+	}
+
+	names = append(names, name)
+	listeners[name] = fl
+
+	return conn, nil
+}
+
+// Ready signals the previous process that the handover has completed. It
+// is a no-op when the process was not started by a handover.
+func Ready() error {
+	fdStr := os.Getenv(envReadyFD)
+	if fdStr == "" {
+		return nil
+	}
+
+	os.Unsetenv(envReadyFD)
+	os.Unsetenv(envListeners)
+
+	fd, err := strconv.Atoi(fdStr)
+	if err != nil {
+		return errors.Wrap(err, "parse ready fd error")
+	}
+
+	f := os.NewFile(uintptr(fd), "handover-ready")
+	defer f.Close()
+
+	if _, err := f.Write([]byte{1}); err != nil {
+		return errors.Wrap(err, "write ready fd error")
+	}
+
+	// the previous process is the main process known to systemd
+	if err := notifySystemd(fmt.Sprintf("MAINPID=%d", os.Getpid())); err != nil {
+		log.WithError(err).Warning("handover: notify systemd error")
+	}
+
+	return nil
+}
+
+// Done returns a channel which is closed once the new process is ready and
+// the current process must stop.
+func Done() <-chan struct{} {
+	return done
+}
+
+func inheritedListener(name string) (net.Listener, error) {
+	f := inheritedFile(name)
+	if f == nil {
+		return nil, nil
+	}
+	defer f.Close()
+
+	return net.FileListener(f)
+}
+
+// inheritedFile returns the inherited file of the listener with the given
+// name, or nil when not inherited.
+func inheritedFile(name string) *os.File {
+	inherited := os.Getenv(envListeners)
+	if inherited == "" {
+		return nil
+	}
+
+	for i, n := range strings.Split(inherited, ",") {
+		if n == name {
+			return os.NewFile(uintptr(3+i), name)
+		}
+	}
+
+	return nil
+}
+
+// handover starts the new process and waits until it is ready.
+func handover() error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "get executable error")
+	}
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	for _, name := range names {
+		f, err := listeners[name].File()
+		if err != nil {
+			return errors.Wrapf(err, "get listener %s file error", name)
+		}
+		files = append(files, f)
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return errors.Wrap(err, "create pipe error")
+	}
+	defer readyR.Close()
+	files = append(files, readyW)
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = files
+	cmd.Env = append(handoverEnviron(),
+		fmt.Sprintf("%s=%s", envListeners, strings.Join(names, ",")),
+		fmt.Sprintf("%s=%d", envReadyFD, 3+len(names)),
+	)
+
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "start new process error")
+	}
+
+	// close the write-end, so that the read returns when the new process
+	// exits before being ready
+	readyW.Close()
+	files = files[:len(files)-1]
+
+	readyChan := make(chan error, 1)
+	go func() {
+		b := make([]byte, 1)
+		_, err := readyR.Read(b)
+		readyChan <- err
+	}()
+
+	select {
+	case err := <-readyChan:
+		if err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return errors.Wrap(err, "new process exited before being ready")
+		}
+	case <-time.After(readyTimeout):
+		cmd.Process.Kill()
+		cmd.Wait()
+		return errors.New("timeout waiting for new process to become ready")
+	}
+
+	log.WithField("pid", cmd.Process.Pid).Info("handover: new process started")
+
+	return cmd.Process.Release()
+}
+
+// handoverEnviron returns the environment without the handover variables
+// (in case the current process was started by a handover).
+func handoverEnviron() []string {
+	var out []string
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, envListeners+"=") || strings.HasPrefix(e, envReadyFD+"=") {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// notifySystemd sends the given state to systemd (when running as systemd
+// service with NotifyAccess=all).
+func notifySystemd(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return errors.Wrap(err, "dial notify socket error")
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
@@ -0,0 +1,76 @@
+package handover
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen(t *testing.T) {
+	assert := require.New(t)
+
+	ln, err := Listen("test", "127.0.0.1:0")
+	assert.NoError(err)
+	defer ln.Close()
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		assert := require.New(t)
+		_, err := Listen("test", "127.0.0.1:0")
+		assert.Error(err)
+	})
+
+	t.Run("Listener can be passed", func(t *testing.T) {
+		assert := require.New(t)
+		fl, ok := ln.(filer)
+		assert.True(ok)
+
+		f, err := fl.File()
+		assert.NoError(err)
+		assert.NoError(f.Close())
+	})
+}
+
+func TestListenPacket(t *testing.T) {
+	assert := require.New(t)
+
+	conn, err := ListenPacket("test-udp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer conn.Close()
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		assert := require.New(t)
+		_, err := ListenPacket("test-udp", "127.0.0.1:0")
+		assert.Error(err)
+	})
+
+	t.Run("Packet connection can be passed", func(t *testing.T) {
+		assert := require.New(t)
+		fl, ok := conn.(filer)
+		assert.True(ok)
+
+		f, err := fl.File()
+		assert.NoError(err)
+		assert.NoError(f.Close())
+	})
+}
+
+func TestReadyNotStartedByHandover(t *testing.T) {
+	assert := require.New(t)
+	os.Unsetenv(envReadyFD)
+	assert.NoError(Ready())
+}
+
+func TestHandoverEnviron(t *testing.T) {
+	assert := require.New(t)
+
+	os.Setenv(envListeners, "api,metrics")
+	os.Setenv(envReadyFD, "5")
+	defer os.Unsetenv(envListeners)
+	defer os.Unsetenv(envReadyFD)
+
+	for _, e := range handoverEnviron() {
+		assert.NotContains(e, envListeners)
+		assert.NotContains(e, envReadyFD)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+// +build !windows
+
+package handover
+
+import (
+	"os"
+	"syscall"
+)
+
+// handoverSignals defines the signals triggering the handover.
+var handoverSignals = []os.Signal{syscall.SIGUSR2}
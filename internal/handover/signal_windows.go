@@ -0,0 +1,7 @@
+package handover
+
+import "os"
+
+// handoverSignals is empty as passing the listener sockets to a new process
+// is not supported on Windows.
+var handoverSignals []os.Signal
@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// maxPooledProtoBufferSize defines the max capacity of a buffer returned to
+// the pool, so that a single large message does not stay allocated.
+const maxPooledProtoBufferSize = 64 * 1024
+
+var protoBufferPool = sync.Pool{
+	New: func() interface{} {
+		return proto.NewBuffer(make([]byte, 0, 512))
+	},
+}
+
+// GetProtoBuffer returns an empty protobuf buffer from the pool, for
+// marshaling messages on the hot path without allocating a new byte slice
+// for each message. The marshaled bytes must no longer be referenced when
+// the buffer is returned using PutProtoBuffer.
+func GetProtoBuffer() *proto.Buffer {
+	b := protoBufferPool.Get().(*proto.Buffer)
+	b.Reset()
+	return b
+}
+
+// PutProtoBuffer returns the given buffer to the pool.
+func PutProtoBuffer(b *proto.Buffer) {
+	if cap(b.Bytes()) > maxPooledProtoBufferSize {
+		return
+	}
+	protoBufferPool.Put(b)
+}
@@ -0,0 +1,86 @@
+package janitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// Device-session integrity policies.
+const (
+	DeviceSessionIntegrityPolicyLog    = "log"
+	DeviceSessionIntegrityPolicyRepair = "repair"
+)
+
+// checkDeviceSessionIntegrity validates all device-sessions and logs the
+// device-sessions with impossible states. When repair is set, these
+// device-sessions are repaired. It returns the number of device-sessions
+// with issues.
+func checkDeviceSessionIntegrity(ctx context.Context, p storage.RedisClient, repair bool) (int, error) {
+	devEUIs, err := storage.GetDeviceSessionDevEUIs(ctx, p)
+	if err != nil {
+		return 0, errors.Wrap(err, "get device-session deveuis error")
+	}
+
+	action := DeviceSessionIntegrityPolicyLog
+	if repair {
+		action = DeviceSessionIntegrityPolicyRepair
+	}
+
+	var count int
+	for _, devEUI := range devEUIs {
+		ds, err := storage.GetDeviceSession(ctx, p, devEUI)
+		if err != nil {
+			// the device-session might have expired in the meantime
+			if errors.Cause(err) == storage.ErrDoesNotExist {
+				continue
+			}
+
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Error("janitor: get device-session error")
+			continue
+		}
+
+		issues := storage.ValidateDeviceSession(ds)
+		if len(issues) == 0 {
+			continue
+		}
+		count++
+
+		for _, issue := range issues {
+			deviceSessionIssueCounter(string(issue), action).Inc()
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"issues":  issues,
+			"action":  action,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Warning("janitor: device-session contains impossible state")
+
+		if !repair {
+			continue
+		}
+
+		_, err = storage.UpdateDeviceSession(ctx, p, devEUI, func(ds *storage.DeviceSession) error {
+			// the device-session might have been updated since it was
+			// validated
+			storage.RepairDeviceSession(ds, storage.ValidateDeviceSession(*ds))
+			return nil
+		})
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Error("janitor: repair device-session error")
+		}
+	}
+
+	return count, nil
+}
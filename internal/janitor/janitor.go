@@ -0,0 +1,207 @@
+// Package janitor implements the scheduling of the background (maintenance)
+// tasks. Each task runs with its own interval and jitter, optionally on the
+// leader instance only. The result of the last run is stored in Redis so that
+// it can be inspected (see the janitor command), and is exposed as Prometheus
+// metrics.
+package janitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/leader"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const statusKeyTempl = "lora:ns:janitor:%s"
+
+// ErrUnknownTask is returned when the task does not exist.
+var ErrUnknownTask = errors.New("unknown task")
+
+// TaskFunc performs a single run of a task. It returns the number of handled
+// (e.g. removed) items.
+type TaskFunc func(ctx context.Context) (int, error)
+
+// Task defines a background task.
+type Task struct {
+	Name string
+
+	// Interval between two runs. A random duration between 0 and Jitter is
+	// added to each interval, so that the instances do not run a task at
+	// the same time.
+	Interval time.Duration
+	Jitter   time.Duration
+
+	// LeaderOnly tasks only run on the leader instance.
+	LeaderOnly bool
+
+	Run TaskFunc
+}
+
+// Status contains the result of the last run of a task.
+type Status struct {
+	LastRun  time.Time     `json:"lastRun"`
+	Duration time.Duration `json:"duration"`
+	Items    int           `json:"items"`
+	Error    string        `json:"error,omitempty"`
+}
+
+var (
+	mux   sync.RWMutex
+	tasks = make(map[string]Task)
+)
+
+// Start registers the given task and starts its scheduling loop.
+func Start(t Task) error {
+	if t.Interval <= 0 {
+		return fmt.Errorf("janitor: task %s: interval must be greater than 0", t.Name)
+	}
+
+	if t.Jitter < 0 {
+		return fmt.Errorf("janitor: task %s: jitter must not be negative", t.Name)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	if _, ok := tasks[t.Name]; ok {
+		return fmt.Errorf("janitor: task %s already started", t.Name)
+	}
+	tasks[t.Name] = t
+
+	log.WithFields(log.Fields{
+		"task":        t.Name,
+		"interval":    t.Interval,
+		"jitter":      t.Jitter,
+		"leader_only": t.LeaderOnly,
+	}).Info("janitor: starting task")
+
+	go func() {
+		// the first run is only delayed by the jitter
+		time.Sleep(jitter(t.Jitter))
+		for {
+			if err := runTask(context.Background(), t, true); err != nil {
+				log.WithError(err).WithField("task", t.Name).Error("janitor: run task error")
+			}
+			time.Sleep(t.Interval + jitter(t.Jitter))
+		}
+	}()
+
+	return nil
+}
+
+// Run runs the given task once (without leader check), e.g. when triggered
+// by an operator.
+func Run(ctx context.Context, t Task) error {
+	return runTask(ctx, t, false)
+}
+
+// GetStatus returns the status of the last run of the given task. When the
+// task has not run yet, an empty status is returned.
+func GetStatus(ctx context.Context, p storage.RedisClient, name string) (Status, error) {
+	var status Status
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(statusKeyTempl, name)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return status, nil
+		}
+		return status, errors.Wrap(err, "get status error")
+	}
+
+	if err := json.Unmarshal(b, &status); err != nil {
+		return status, errors.Wrap(err, "unmarshal status error")
+	}
+
+	return status, nil
+}
+
+func runTask(ctx context.Context, t Task, fence bool) error {
+	ctxID, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "get new uuid error")
+	}
+	ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+
+	if fence && t.LeaderOnly {
+		if err := leader.Fence(ctx, storage.RedisPool()); err != nil {
+			if err == leader.ErrNotLeader {
+				taskRunCounter(t.Name, "skipped").Inc()
+				return nil
+			}
+			return errors.Wrap(err, "leader fence error")
+		}
+	}
+
+	start := time.Now()
+	items, runErr := t.Run(ctx)
+	duration := time.Since(start)
+
+	status := Status{
+		LastRun:  start,
+		Duration: duration,
+		Items:    items,
+	}
+
+	taskDurationHistogram(t.Name).Observe(duration.Seconds())
+	taskItemCounter(t.Name).Add(float64(items))
+	if runErr != nil {
+		status.Error = runErr.Error()
+		taskRunCounter(t.Name, "error").Inc()
+	} else {
+		taskRunCounter(t.Name, "success").Inc()
+	}
+
+	if err := saveStatus(storage.RedisPool(), t.Name, status); err != nil {
+		log.WithError(err).WithField("task", t.Name).Error("janitor: save status error")
+	}
+
+	if runErr != nil {
+		return runErr
+	}
+
+	log.WithFields(log.Fields{
+		"task":     t.Name,
+		"items":    items,
+		"duration": duration,
+		"ctx_id":   ctxID,
+	}).Info("janitor: task completed")
+
+	return nil
+}
+
+func saveStatus(p storage.RedisClient, name string, status Status) error {
+	b, err := json.Marshal(status)
+	if err != nil {
+		return errors.Wrap(err, "marshal status error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("SET", fmt.Sprintf(statusKeyTempl, name), b); err != nil {
+		return errors.Wrap(err, "set status error")
+	}
+
+	return nil
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
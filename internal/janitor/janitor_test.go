@@ -0,0 +1,88 @@
+package janitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestJanitor(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	t.Run("Start validates the task", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Error(Start(Task{Name: "test_invalid_interval"}))
+		assert.Error(Start(Task{Name: "test_invalid_jitter", Interval: time.Hour, Jitter: -time.Second}))
+	})
+
+	t.Run("Start rejects duplicate tasks", func(t *testing.T) {
+		assert := require.New(t)
+
+		task := Task{
+			Name:     "test_duplicate",
+			Interval: time.Hour,
+			Jitter:   time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return 0, nil
+			},
+		}
+
+		assert.NoError(Start(task))
+		assert.Error(Start(task))
+	})
+
+	t.Run("No status before the first run", func(t *testing.T) {
+		assert := require.New(t)
+
+		status, err := GetStatus(context.Background(), storage.RedisPool(), "test_run")
+		assert.NoError(err)
+		assert.True(status.LastRun.IsZero())
+	})
+
+	t.Run("Run stores the status", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(Run(context.Background(), Task{
+			Name:       "test_run",
+			Interval:   time.Hour,
+			LeaderOnly: true,
+			Run: func(ctx context.Context) (int, error) {
+				return 3, nil
+			},
+		}))
+
+		status, err := GetStatus(context.Background(), storage.RedisPool(), "test_run")
+		assert.NoError(err)
+		assert.False(status.LastRun.IsZero())
+		assert.Equal(3, status.Items)
+		assert.Equal("", status.Error)
+	})
+
+	t.Run("Run stores the error", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Error(Run(context.Background(), Task{
+			Name:     "test_error",
+			Interval: time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return 1, errors.New("boom")
+			},
+		}))
+
+		status, err := GetStatus(context.Background(), storage.RedisPool(), "test_error")
+		assert.NoError(err)
+		assert.Equal(1, status.Items)
+		assert.Equal("boom", status.Error)
+	})
+}
@@ -0,0 +1,71 @@
+package janitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	trc = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "janitor_task_run_count",
+		Help: "The number of janitor task runs (per task and result).",
+	}, []string{"task", "result"})
+
+	tic = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "janitor_task_item_count",
+		Help: "The number of items handled by the janitor tasks (per task).",
+	}, []string{"task"})
+
+	tdh = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "janitor_task_duration_seconds",
+		Help: "The duration of the janitor task runs (per task).",
+	}, []string{"task"})
+
+	rmb = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_memory_usage_bytes",
+		Help: "The estimated Redis memory usage (per key class).",
+	}, []string{"class"})
+
+	rmk = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_memory_usage_keys",
+		Help: "The number of Redis keys (per key class).",
+	}, []string{"class"})
+
+	rmc = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "redis_memory_usage_cap_exceeded",
+		Help: "Set to 1 when the estimated Redis memory usage exceeds the configured cap (per key class).",
+	}, []string{"class"})
+
+	dsi = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "device_session_integrity_issue_count",
+		Help: "The number of device-session integrity issues found (per issue and action).",
+	}, []string{"issue", "action"})
+)
+
+func taskRunCounter(task, result string) prometheus.Counter {
+	return trc.With(prometheus.Labels{"task": task, "result": result})
+}
+
+func taskItemCounter(task string) prometheus.Counter {
+	return tic.With(prometheus.Labels{"task": task})
+}
+
+func taskDurationHistogram(task string) prometheus.Observer {
+	return tdh.With(prometheus.Labels{"task": task})
+}
+
+func redisMemoryUsageBytesGauge(class string) prometheus.Gauge {
+	return rmb.With(prometheus.Labels{"class": class})
+}
+
+func redisMemoryUsageKeysGauge(class string) prometheus.Gauge {
+	return rmk.With(prometheus.Labels{"class": class})
+}
+
+func redisMemoryUsageCapExceededGauge(class string) prometheus.Gauge {
+	return rmc.With(prometheus.Labels{"class": class})
+}
+
+func deviceSessionIssueCounter(issue, action string) prometheus.Counter {
+	return dsi.With(prometheus.Labels{"issue": issue, "action": action})
+}
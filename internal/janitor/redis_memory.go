@@ -0,0 +1,49 @@
+package janitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// updateRedisMemoryUsage samples the Redis memory usage per key class,
+// updates the metrics, logs a warning for each key class exceeding its cap
+// and stores the sample. It returns the number of sampled key classes.
+func updateRedisMemoryUsage(ctx context.Context, p storage.RedisClient, samplesPerClass int, caps map[storage.RedisKeyClass]int64) (int, error) {
+	usage, err := storage.SampleRedisMemoryUsage(ctx, p, samplesPerClass)
+	if err != nil {
+		return 0, errors.Wrap(err, "sample redis memory usage error")
+	}
+
+	for i := range usage.Classes {
+		class := &usage.Classes[i]
+		class.CapBytes = caps[class.Class]
+
+		redisMemoryUsageBytesGauge(string(class.Class)).Set(float64(class.Bytes))
+		redisMemoryUsageKeysGauge(string(class.Class)).Set(float64(class.Keys))
+
+		if class.CapBytes == 0 || class.Bytes <= class.CapBytes {
+			redisMemoryUsageCapExceededGauge(string(class.Class)).Set(0)
+			continue
+		}
+
+		redisMemoryUsageCapExceededGauge(string(class.Class)).Set(1)
+		log.WithFields(log.Fields{
+			"class":     class.Class,
+			"keys":      class.Keys,
+			"bytes":     class.Bytes,
+			"cap_bytes": class.CapBytes,
+			"ctx_id":    ctx.Value(logging.ContextIDKey),
+		}).Warning("janitor: redis memory usage exceeds cap")
+	}
+
+	if err := storage.SaveRedisMemoryUsage(ctx, p, usage); err != nil {
+		return 0, errors.Wrap(err, "save redis memory usage error")
+	}
+
+	return len(usage.Classes), nil
+}
@@ -0,0 +1,170 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/uplink"
+)
+
+// deviceQueueCleanupBatchSize defines the max. number of expired device-queue
+// items deleted per run.
+const deviceQueueCleanupBatchSize = 100
+
+// Setup starts the enabled maintenance tasks.
+func Setup(conf config.Config) error {
+	for _, t := range MaintenanceTasks(conf) {
+		if err := Start(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MaintenanceTasks returns the enabled maintenance tasks.
+func MaintenanceTasks(conf config.Config) []Task {
+	deviceSessionTTL := conf.NetworkServer.DeviceSessionTTL
+	multicastGatewaySetHysteresis := conf.Janitor.MulticastGatewaySet.Hysteresis
+	gatewayStatsIntervals := conf.NetworkServer.Gateway.StatsAggregation.Intervals
+	redisMemoryUsage := conf.Janitor.RedisMemoryUsage
+	repairDeviceSessions := conf.Janitor.DeviceSessionIntegrity.Policy == DeviceSessionIntegrityPolicyRepair
+	redisMemoryCaps := map[storage.RedisKeyClass]int64{
+		storage.RedisKeyClassSessions:      redisMemoryUsage.Caps.Sessions,
+		storage.RedisKeyClassDeduplication: redisMemoryUsage.Caps.Deduplication,
+		storage.RedisKeyClassFrameLog:      redisMemoryUsage.Caps.FrameLog,
+		storage.RedisKeyClassQueues:        redisMemoryUsage.Caps.Queues,
+		storage.RedisKeyClassMetrics:       redisMemoryUsage.Caps.Metrics,
+	}
+
+	definitions := []struct {
+		conf config.JanitorTask
+		task Task
+	}{
+		{
+			conf: conf.Janitor.DeviceSessionGC,
+			task: Task{
+				Name:       "device_session_gc",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					return storage.DeleteStaleDevAddrMembers(ctx, storage.RedisPool())
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.DeduplicationSweep,
+			task: Task{
+				Name:       "deduplication_sweep",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					return uplink.SweepDeduplicationKeys(ctx, storage.RedisPool())
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.DeviceQueueCleanup,
+			task: Task{
+				Name:       "device_queue_cleanup",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					// after the device-session TTL, the device can not
+					// acknowledge the pending item anymore
+					var count int
+					err := storage.Transaction(func(tx sqlx.Ext) error {
+						var err error
+						count, err = storage.DeleteExpiredPendingDeviceQueueItems(ctx, tx, time.Now().Add(-deviceSessionTTL), deviceQueueCleanupBatchSize)
+						return err
+					})
+					return count, err
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.DeviceQueueExpiry,
+			task: Task{
+				Name:       "device_queue_expiry",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					var count int
+					err := storage.Transaction(func(tx sqlx.Ext) error {
+						var err error
+						count, err = storage.DeleteExpiredDeviceQueueItems(ctx, tx, time.Now(), deviceQueueCleanupBatchSize)
+						return err
+					})
+					return count, err
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.GatewayStatsRetention,
+			task: Task{
+				Name:       "gateway_stats_retention",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					var count int
+					for _, i := range gatewayStatsIntervals {
+						if i.Retention <= 0 {
+							continue
+						}
+
+						c, err := storage.DeleteGatewayStatsAggregatesBefore(ctx, storage.DB(), i.Interval, time.Now().Add(-i.Retention))
+						if err != nil {
+							return count, err
+						}
+						count += c
+					}
+					return count, nil
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.MulticastGatewaySet.JanitorTask,
+			task: Task{
+				Name:       "multicast_gateway_set",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					return multicast.UpdateGatewaySets(ctx, storage.RedisPool(), multicastGatewaySetHysteresis)
+				},
+			},
+		},
+		{
+			conf: conf.Janitor.DeviceSessionIntegrity.JanitorTask,
+			task: Task{
+				Name:       "device_session_integrity",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					return checkDeviceSessionIntegrity(ctx, storage.RedisPool(), repairDeviceSessions)
+				},
+			},
+		},
+		{
+			conf: redisMemoryUsage.JanitorTask,
+			task: Task{
+				Name:       "redis_memory_usage",
+				LeaderOnly: true,
+				Run: func(ctx context.Context) (int, error) {
+					return updateRedisMemoryUsage(ctx, storage.RedisPool(), redisMemoryUsage.SamplesPerClass, redisMemoryCaps)
+				},
+			},
+		},
+	}
+
+	var out []Task
+	for _, d := range definitions {
+		if !d.conf.Enabled {
+			continue
+		}
+
+		t := d.task
+		t.Interval = d.conf.Interval
+		t.Jitter = d.conf.Jitter
+		out = append(out, t)
+	}
+
+	return out
+}
@@ -0,0 +1,159 @@
+// Package kek implements the unwrapping of the session-keys received from
+// the join-servers, using the KEK (Key Encryption Key) set of the join-server
+// responsible for the JoinEUI of the device. Each unwrap operation is logged
+// for auditing.
+package kek
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"encoding/hex"
+	"fmt"
+
+	keywrap "github.com/NickBall/go-aes-key-wrap"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// ErrNotWrapped is returned when the key-wrapping policy requires the
+// session-keys to be wrapped, but the key-envelope contains a plain key.
+var ErrNotWrapped = errors.New("session-key is not wrapped")
+
+type kekSet map[string][]byte
+
+type joinServer struct {
+	from lorawan.EUI64
+	to   lorawan.EUI64
+	keks kekSet
+}
+
+var (
+	defaultKEKs        kekSet
+	joinServers        []joinServer
+	requireWrappedKeys bool
+	storeWrappedKeys   bool
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	defaultKEKs = make(kekSet)
+	joinServers = nil
+	requireWrappedKeys = conf.JoinServer.KEK.RequireWrappedKeys
+	storeWrappedKeys = conf.JoinServer.KEK.StoreWrappedKeys
+
+	for _, k := range conf.JoinServer.KEK.Set {
+		kek, err := hex.DecodeString(k.KEK)
+		if err != nil {
+			return errors.Wrap(err, "decode kek error")
+		}
+
+		defaultKEKs[k.Label] = kek
+	}
+
+	for i, js := range conf.JoinServer.KEK.JoinServers {
+		s := joinServer{
+			keks: make(kekSet),
+		}
+
+		if err := s.from.UnmarshalText([]byte(js.JoinEUIFrom)); err != nil {
+			return errors.Wrapf(err, "join_servers[%d]: decode join_eui_from error", i)
+		}
+		if err := s.to.UnmarshalText([]byte(js.JoinEUITo)); err != nil {
+			return errors.Wrapf(err, "join_servers[%d]: decode join_eui_to error", i)
+		}
+		if bytes.Compare(s.from[:], s.to[:]) > 0 {
+			return fmt.Errorf("join_servers[%d]: join_eui_from must not be greater than join_eui_to", i)
+		}
+
+		for _, k := range js.Set {
+			kek, err := hex.DecodeString(k.KEK)
+			if err != nil {
+				return errors.Wrapf(err, "join_servers[%d]: decode kek error", i)
+			}
+
+			s.keks[k.Label] = kek
+		}
+
+		joinServers = append(joinServers, s)
+	}
+
+	return nil
+}
+
+// StoreWrappedKeys returns true when the wrapped network session-keys must
+// be stored as key-envelopes, in which case these are only unwrapped when
+// the device-session is used.
+func StoreWrappedKeys() bool {
+	return storeWrappedKeys
+}
+
+// Check validates the given network session-key envelope received from the
+// join-server against the key-wrapping policy and the KEK set of the
+// join-server responsible for the given JoinEUI, without unwrapping it.
+func Check(joinEUI lorawan.EUI64, ke *backend.KeyEnvelope) error {
+	if ke.KEKLabel == "" {
+		if requireWrappedKeys {
+			return ErrNotWrapped
+		}
+		return nil
+	}
+
+	if _, ok := getKEKSet(joinEUI)[ke.KEKLabel]; !ok {
+		return fmt.Errorf("unknown kek label for join_eui %s: %s", joinEUI, ke.KEKLabel)
+	}
+
+	return nil
+}
+
+// Unwrap returns the decrypted key from the given key-envelope, using the
+// KEK set of the join-server responsible for the given JoinEUI.
+func Unwrap(ctx context.Context, devEUI, joinEUI lorawan.EUI64, ke *backend.KeyEnvelope) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	if err := Check(joinEUI, ke); err != nil {
+		return key, err
+	}
+
+	if ke.KEKLabel == "" {
+		copy(key[:], ke.AESKey[:])
+		return key, nil
+	}
+
+	block, err := aes.NewCipher(getKEKSet(joinEUI)[ke.KEKLabel])
+	if err != nil {
+		return key, errors.Wrap(err, "new cipher error")
+	}
+
+	b, err := keywrap.Unwrap(block, ke.AESKey[:])
+	if err != nil {
+		return key, errors.Wrap(err, "unwrap key error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   devEUI,
+		"join_eui":  joinEUI,
+		"kek_label": ke.KEKLabel,
+		"ctx_id":    ctx.Value(logging.ContextIDKey),
+	}).Info("kek: session-key unwrapped")
+
+	copy(key[:], b)
+	return key, nil
+}
+
+// getKEKSet returns the KEK set of the join-server responsible for the given
+// JoinEUI, or the default KEK set.
+func getKEKSet(joinEUI lorawan.EUI64) kekSet {
+	for _, js := range joinServers {
+		if bytes.Compare(joinEUI[:], js.from[:]) >= 0 && bytes.Compare(joinEUI[:], js.to[:]) <= 0 {
+			return js.keks
+		}
+	}
+
+	return defaultKEKs
+}
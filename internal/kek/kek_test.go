@@ -0,0 +1,114 @@
+package kek
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+func TestUnwrap(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.JoinServer.KEK.Set = []struct {
+		Label string
+		KEK   string `mapstructure:"kek"`
+	}{
+		{
+			Label: "010203",
+			KEK:   "00000000000000000000000000000000",
+		},
+	}
+	conf.JoinServer.KEK.JoinServers = append(conf.JoinServer.KEK.JoinServers, struct {
+		JoinEUIFrom string `mapstructure:"join_eui_from"`
+		JoinEUITo   string `mapstructure:"join_eui_to"`
+
+		Set []struct {
+			Label string
+			KEK   string `mapstructure:"kek"`
+		}
+	}{
+		JoinEUIFrom: "0102030405060700",
+		JoinEUITo:   "01020304050607ff",
+		Set: []struct {
+			Label string
+			KEK   string `mapstructure:"kek"`
+		}{
+			{
+				Label: "010203",
+				KEK:   "01010101010101010101010101010101",
+			},
+		},
+	})
+
+	devEUI := lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8}
+	wrapped := backend.KeyEnvelope{
+		KEKLabel: "010203",
+		AESKey:   []byte{246, 176, 184, 31, 61, 48, 41, 18, 85, 145, 192, 176, 184, 141, 118, 201, 59, 72, 172, 164, 4, 22, 133, 211},
+	}
+	plain := backend.KeyEnvelope{
+		AESKey: []byte{88, 148, 152, 153, 48, 146, 207, 219, 95, 210, 224, 42, 199, 81, 11, 241},
+	}
+
+	assert.NoError(Setup(conf))
+
+	t.Run("Default KEK set", func(t *testing.T) {
+		assert := require.New(t)
+
+		key, err := Unwrap(context.Background(), devEUI, lorawan.EUI64{1, 2, 3, 4, 5, 6, 8, 0}, &wrapped)
+		assert.NoError(err)
+		assert.Equal(lorawan.AES128Key{88, 148, 152, 153, 48, 146, 207, 219, 95, 210, 224, 42, 199, 81, 11, 241}, key)
+	})
+
+	t.Run("KEK set of the join-server", func(t *testing.T) {
+		assert := require.New(t)
+
+		// the key is wrapped with the default KEK, not with the KEK of the
+		// join-server responsible for this JoinEUI
+		_, err := Unwrap(context.Background(), devEUI, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, &wrapped)
+		assert.Error(err)
+	})
+
+	t.Run("Unknown KEK label", func(t *testing.T) {
+		assert := require.New(t)
+
+		ke := wrapped
+		ke.KEKLabel = "040506"
+		assert.Error(Check(lorawan.EUI64{1, 2, 3, 4, 5, 6, 8, 0}, &ke))
+
+		_, err := Unwrap(context.Background(), devEUI, lorawan.EUI64{1, 2, 3, 4, 5, 6, 8, 0}, &ke)
+		assert.Error(err)
+	})
+
+	t.Run("Plain key", func(t *testing.T) {
+		assert := require.New(t)
+
+		key, err := Unwrap(context.Background(), devEUI, lorawan.EUI64{1, 2, 3, 4, 5, 6, 8, 0}, &plain)
+		assert.NoError(err)
+		assert.Equal(lorawan.AES128Key{88, 148, 152, 153, 48, 146, 207, 219, 95, 210, 224, 42, 199, 81, 11, 241}, key)
+	})
+
+	t.Run("Plain key with require wrapped keys", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := conf
+		c.JoinServer.KEK.RequireWrappedKeys = true
+		assert.NoError(Setup(c))
+		defer Setup(conf)
+
+		_, err := Unwrap(context.Background(), devEUI, lorawan.EUI64{1, 2, 3, 4, 5, 6, 8, 0}, &plain)
+		assert.Equal(ErrNotWrapped, err)
+	})
+
+	t.Run("Invalid JoinEUI range", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.JoinServer.KEK.JoinServers[0].JoinEUITo = "0102030405060600"
+		assert.Error(Setup(conf))
+	})
+}
@@ -0,0 +1,255 @@
+// Package leader implements the leader election between LoRa Server
+// instances sharing the same Redis and PostgreSQL database. Only the leader
+// runs the singleton background tasks (the Class-B / Class-C and multicast
+// schedulers and the leader-only janitor tasks, e.g. the provisioning
+// synchronization), all instances handle uplink frames.
+//
+// The leader holds a Redis lock which must be renewed before it expires.
+// Each time the leadership is acquired, a fencing token is incremented so
+// that a previous leader (e.g. after a long pause) detects that it has been
+// replaced before performing work.
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	lockKey  = "lora:ns:leader:lock"
+	tokenKey = "lora:ns:leader:token"
+)
+
+// ErrNotLeader is returned when this instance is not (or no longer) the
+// leader.
+var ErrNotLeader = errors.New("instance is not the leader")
+
+var renewScript = redis.NewScript(1, `
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("pexpire", KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+var releaseScript = redis.NewScript(1, `
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	end
+	return 0
+`)
+
+var (
+	mux        sync.RWMutex
+	enabled    bool
+	instanceID string
+	token      int64
+	validUntil time.Time
+
+	lockTTL       time.Duration
+	renewInterval time.Duration
+)
+
+// Setup configures the leader election and starts the election loop.
+// When the leader election is disabled, this instance always acts as leader.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	enabled = conf.NetworkServer.LeaderElection.Enabled
+	lockTTL = conf.NetworkServer.LeaderElection.LockTTL
+	renewInterval = conf.NetworkServer.LeaderElection.RenewInterval
+
+	if !enabled {
+		return nil
+	}
+
+	if renewInterval <= 0 || renewInterval >= lockTTL {
+		return errors.New("leader: renew_interval must be greater than 0 and less than lock_ttl")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	instanceID = id.String()
+
+	log.WithFields(log.Fields{
+		"instance_id":    instanceID,
+		"lock_ttl":       lockTTL,
+		"renew_interval": renewInterval,
+	}).Info("leader: starting leader election")
+
+	go func() {
+		for {
+			if err := campaign(context.Background(), storage.RedisPool()); err != nil {
+				log.WithError(err).Error("leader: campaign error")
+			}
+			time.Sleep(renewInterval)
+		}
+	}()
+
+	return nil
+}
+
+// IsLeader returns true when this instance holds a non-expired leadership.
+func IsLeader() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return isLeader()
+}
+
+// Token returns the fencing token of the current leadership, or 0 when this
+// instance is not the leader or when the leader election is disabled.
+func Token() int64 {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	if !enabled || !isLeader() {
+		return 0
+	}
+	return token
+}
+
+// Fence must be called before performing a singleton task. It returns
+// ErrNotLeader when this instance is not the leader, or when another
+// instance has acquired the leadership since (its fencing token is newer).
+func Fence(ctx context.Context, p storage.RedisClient) error {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	if !enabled {
+		return nil
+	}
+
+	if !isLeader() {
+		return ErrNotLeader
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	current, err := redis.Int64(c.Do("GET", tokenKey))
+	if err != nil {
+		return errors.Wrap(err, "get fencing token error")
+	}
+
+	if current != token {
+		log.WithFields(log.Fields{
+			"token":         token,
+			"current_token": current,
+			"ctx_id":        ctx.Value(logging.ContextIDKey),
+		}).Warning("leader: fencing token is outdated")
+		return ErrNotLeader
+	}
+
+	return nil
+}
+
+// Resign releases the leadership (when held), so that an other instance can
+// take over without waiting for the lock to expire.
+func Resign(ctx context.Context, p storage.RedisClient) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if !enabled || token == 0 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := releaseScript.Do(c, lockKey, instanceID); err != nil {
+		return errors.Wrap(err, "release lock error")
+	}
+
+	log.WithFields(log.Fields{
+		"instance_id": instanceID,
+		"token":       token,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("leader: leadership released")
+
+	token = 0
+	validUntil = time.Time{}
+
+	return nil
+}
+
+// campaign renews the leadership when held, else it tries to acquire it.
+func campaign(ctx context.Context, p storage.RedisClient) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	c := p.Get()
+	defer c.Close()
+
+	// the lock expires in Redis at least lockTTL after this moment
+	start := time.Now()
+	ttl := int64(lockTTL / time.Millisecond)
+
+	if token != 0 {
+		n, err := redis.Int(renewScript.Do(c, lockKey, instanceID, ttl))
+		if err != nil {
+			// the leadership expires locally when not renewed in time
+			return errors.Wrap(err, "renew lock error")
+		}
+		if n == 1 {
+			validUntil = start.Add(lockTTL)
+			return nil
+		}
+
+		log.WithFields(log.Fields{
+			"instance_id": instanceID,
+			"token":       token,
+			"ctx_id":      ctx.Value(logging.ContextIDKey),
+		}).Warning("leader: leadership lost")
+
+		token = 0
+		validUntil = time.Time{}
+	}
+
+	_, err := redis.String(c.Do("SET", lockKey, instanceID, "PX", ttl, "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// an other instance is the leader
+			return nil
+		}
+		return errors.Wrap(err, "acquire lock error")
+	}
+
+	t, err := redis.Int64(c.Do("INCR", tokenKey))
+	if err != nil {
+		if _, rErr := releaseScript.Do(c, lockKey, instanceID); rErr != nil {
+			log.WithError(rErr).Error("leader: release lock error")
+		}
+		return errors.Wrap(err, "increment fencing token error")
+	}
+
+	token = t
+	validUntil = start.Add(lockTTL)
+
+	log.WithFields(log.Fields{
+		"instance_id": instanceID,
+		"token":       token,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("leader: leadership acquired")
+
+	return nil
+}
+
+func isLeader() bool {
+	if !enabled {
+		return true
+	}
+	return token != 0 && time.Now().Before(validUntil)
+}
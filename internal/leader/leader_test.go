@@ -0,0 +1,103 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestLeaderElection(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	// the election loop is not started, instances are simulated by
+	// switching the instance ID
+	enabled = true
+	lockTTL = time.Second
+	defer func() {
+		enabled = false
+		token = 0
+	}()
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+
+		enabled = false
+		assert.True(IsLeader())
+		assert.NoError(Fence(context.Background(), storage.RedisPool()))
+		enabled = true
+	})
+
+	t.Run("Instance A acquires the leadership", func(t *testing.T) {
+		assert := require.New(t)
+
+		instanceID = "a"
+		assert.NoError(campaign(context.Background(), storage.RedisPool()))
+		assert.True(IsLeader())
+		assert.EqualValues(1, Token())
+		assert.NoError(Fence(context.Background(), storage.RedisPool()))
+
+		t.Run("Renew", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(campaign(context.Background(), storage.RedisPool()))
+			assert.EqualValues(1, Token())
+		})
+	})
+
+	t.Run("Instance B can not acquire the leadership", func(t *testing.T) {
+		assert := require.New(t)
+
+		instanceID = "b"
+		token = 0
+		assert.NoError(campaign(context.Background(), storage.RedisPool()))
+		assert.False(IsLeader())
+		assert.Equal(ErrNotLeader, Fence(context.Background(), storage.RedisPool()))
+	})
+
+	t.Run("Instance B takes over after the lock expired", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := storage.RedisPool().Get()
+		_, err := c.Do("DEL", lockKey)
+		c.Close()
+		assert.NoError(err)
+
+		assert.NoError(campaign(context.Background(), storage.RedisPool()))
+		assert.True(IsLeader())
+		assert.EqualValues(2, Token())
+
+		t.Run("Instance A is fenced", func(t *testing.T) {
+			assert := require.New(t)
+
+			instanceID = "a"
+			token = 1
+			assert.Equal(ErrNotLeader, Fence(context.Background(), storage.RedisPool()))
+
+			assert.NoError(campaign(context.Background(), storage.RedisPool()))
+			assert.False(IsLeader())
+		})
+	})
+
+	t.Run("Resign", func(t *testing.T) {
+		assert := require.New(t)
+
+		instanceID = "b"
+		token = 2
+		validUntil = time.Now().Add(lockTTL)
+		assert.NoError(Resign(context.Background(), storage.RedisPool()))
+		assert.False(IsLeader())
+
+		instanceID = "a"
+		assert.NoError(campaign(context.Background(), storage.RedisPool()))
+		assert.EqualValues(3, Token())
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
@@ -84,6 +85,14 @@ func handleDevStatusAns(ctx context.Context, ds *storage.DeviceSession, sp stora
 			req.Margin = int32(pl.Margin)
 		}
 
+		webhook.Send(ctx, sp.WebhookEndpoint(), webhook.EventStatus, webhook.StatusEvent{
+			DevEUI:                  ds.DevEUI,
+			Margin:                  int(req.Margin),
+			ExternalPowerSource:     req.ExternalPowerSource,
+			BatteryLevelUnavailable: req.BatteryLevelUnavailable,
+			BatteryLevel:            req.BatteryLevel,
+		})
+
 		_, err := asClient.SetDeviceStatus(ctx, &req)
 		if err != nil {
 			log.WithFields(log.Fields{
@@ -1,13 +1,17 @@
 package metrics
 
 import (
+	"crypto/tls"
 	"net/http"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/mxc-foundation/lpwan-server/internal/certmanager"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/handover"
 )
 
 // Setup setsup the metrics server.
@@ -22,6 +26,7 @@ func Setup(c config.Config) error {
 
 	log.WithFields(log.Fields{
 		"bind": c.Metrics.Prometheus.Bind,
+		"tls":  c.Metrics.Prometheus.TLS,
 	}).Info("metrics: starting prometheus metrics server")
 
 	server := http.Server{
@@ -29,8 +34,21 @@ func Setup(c config.Config) error {
 		Addr:    c.Metrics.Prometheus.Bind,
 	}
 
+	ln, err := handover.Listen("metrics", c.Metrics.Prometheus.Bind)
+	if err != nil {
+		return errors.Wrap(err, "start prometheus metrics listener error")
+	}
+
+	if c.Metrics.Prometheus.TLS {
+		tlsConfig, err := certmanager.TLSConfig("")
+		if err != nil {
+			return errors.Wrap(err, "get tls config error")
+		}
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
 	go func() {
-		err := server.ListenAndServe()
+		err := server.Serve(ln)
 		log.WithError(err).Error("metrics: prometheus metrics server error")
 	}()
 
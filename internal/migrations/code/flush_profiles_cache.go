@@ -16,7 +16,7 @@ import (
 // cache in Redis. As the struct changed the cached value from LoRa Server v1
 // can't be unmarshaled into the LoRa Server v2 struct and therefore we need
 // to flush the cache.
-func FlushProfilesCache(p *redis.Pool, db sqlx.Queryer) error {
+func FlushProfilesCache(p storage.RedisClient, db sqlx.Queryer) error {
 	c := p.Get()
 	defer c.Close()
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -14,7 +13,7 @@ import (
 )
 
 // MigrateGatewayStats migrates the gateway stats from PostgreSQL to Redis.
-func MigrateGatewayStats(p *redis.Pool, db sqlx.Queryer) error {
+func MigrateGatewayStats(p storage.RedisClient, db sqlx.Queryer) error {
 	log.Info("migrating gateway stats")
 
 	var row struct {
@@ -0,0 +1,125 @@
+package code
+
+import (
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	deviceKeyPrefix  = "lora:ns:device:"
+	metricsKeyPrefix = "lora:ns:metrics:"
+)
+
+// MigrateRedisHashTags renames the device and metrics Redis keys to the
+// hash-tagged key format, so that the keys which are used together are
+// stored in the same Redis Cluster slot. Keys with a short TTL (e.g. the
+// de-duplication and downlink-frame keys) are not migrated, these expire
+// within minutes.
+func MigrateRedisHashTags(p storage.RedisClient) error {
+	log.Info("migrating redis keys to hash-tagged format")
+
+	var renamed int
+	for _, prefix := range []string{deviceKeyPrefix, metricsKeyPrefix} {
+		keys, err := storage.ScanKeys(p, prefix+"*")
+		if err != nil {
+			return errors.Wrap(err, "scan keys error")
+		}
+
+		for _, key := range keys {
+			newKey := hashTaggedKey(key)
+			if newKey == "" {
+				continue
+			}
+
+			if err := renameKey(p, key, newKey); err != nil {
+				return errors.Wrapf(err, "rename key %s error", key)
+			}
+			renamed++
+		}
+	}
+
+	log.WithField("count", renamed).Info("redis keys migrated to hash-tagged format")
+
+	return nil
+}
+
+// hashTaggedKey returns the hash-tagged key for the given key. It returns
+// an empty string when the key is already hash-tagged or when it is not
+// recognized.
+func hashTaggedKey(key string) string {
+	if strings.Contains(key, "{") {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(key, deviceKeyPrefix):
+		// lora:ns:device:<DevEUI>[:suffix]
+		rest := strings.TrimPrefix(key, deviceKeyPrefix)
+		if len(rest) < 16 || (len(rest) > 16 && rest[16] != ':') {
+			return ""
+		}
+		return deviceKeyPrefix + "{" + rest[:16] + "}" + rest[16:]
+	case strings.HasPrefix(key, metricsKeyPrefix):
+		// lora:ns:metrics:<name>:<aggregation>:<timestamp>, the name might
+		// contain a colon
+		rest := strings.TrimPrefix(key, metricsKeyPrefix)
+		i := strings.LastIndex(rest, ":")
+		if i == -1 {
+			return ""
+		}
+		j := strings.LastIndex(rest[:i], ":")
+		if j == -1 {
+			return ""
+		}
+		return metricsKeyPrefix + "{" + rest[:i] + "}" + rest[i:]
+	default:
+		return ""
+	}
+}
+
+// renameKey renames the given key, keeping its expiration. When the new key
+// already exists (e.g. written by an upgraded instance), the old key is
+// deleted. As the old and new key are (in case of Redis Cluster) stored in
+// different slots, RENAME can not be used. Instead the key is copied using
+// DUMP and RESTORE, after which the old key is deleted.
+func renameKey(p storage.RedisClient, key, newKey string) error {
+	c := p.Get()
+	defer c.Close()
+
+	dump, err := redis.Bytes(c.Do("DUMP", key))
+	if err != nil {
+		if err == redis.ErrNil {
+			// expired since the scan
+			return nil
+		}
+		return errors.Wrap(err, "dump error")
+	}
+
+	ttl, err := redis.Int64(c.Do("PTTL", key))
+	if err != nil {
+		return errors.Wrap(err, "get ttl error")
+	}
+	switch ttl {
+	case -2:
+		// expired since the dump
+		return nil
+	case -1:
+		// no expiration
+		ttl = 0
+	}
+
+	if _, err := c.Do("RESTORE", newKey, ttl, dump); err != nil && !strings.HasPrefix(err.Error(), "BUSYKEY") {
+		return errors.Wrap(err, "restore error")
+	}
+
+	if _, err := c.Do("DEL", key); err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+
+	return nil
+}
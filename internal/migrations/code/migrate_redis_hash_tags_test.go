@@ -0,0 +1,94 @@
+package code
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestHashTaggedKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"lora:ns:device:0102030405060708", "lora:ns:device:{0102030405060708}"},
+		{"lora:ns:device:0102030405060708:gwrx", "lora:ns:device:{0102030405060708}:gwrx"},
+		{"lora:ns:device:0102030405060708:mac:pending:3", "lora:ns:device:{0102030405060708}:mac:pending:3"},
+		{"lora:ns:device:{0102030405060708}", ""},
+		{"lora:ns:device:0102", ""},
+		{"lora:ns:metrics:gw:0102030405060708:HOUR:12345", "lora:ns:metrics:{gw:0102030405060708:HOUR}:12345"},
+		{"lora:ns:metrics:{gw:0102030405060708:HOUR}:12345", ""},
+		{"lora:ns:metrics:12345", ""},
+		{"lora:ns:devaddr:01020304", ""},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.key, func(t *testing.T) {
+			require.Equal(t, tst.expected, hashTaggedKey(tst.key))
+		})
+	}
+}
+
+type MigrateRedisHashTagsTestSuite struct {
+	suite.Suite
+}
+
+func (ts *MigrateRedisHashTagsTestSuite) SetupSuite() {
+	conf := test.GetConfig()
+	if err := storage.Setup(conf); err != nil {
+		panic(err)
+	}
+}
+
+func (ts *MigrateRedisHashTagsTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *MigrateRedisHashTagsTestSuite) TestMigrateRedisHashTags() {
+	assert := require.New(ts.T())
+
+	c := storage.RedisPool().Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", "lora:ns:device:0102030405060708", 60000, "session")
+	assert.NoError(err)
+	_, err = c.Do("HSET", "lora:ns:metrics:gw:0102030405060708:HOUR:12345", "rx_count", 1)
+	assert.NoError(err)
+
+	// the tagged key has already been written by an upgraded instance
+	_, err = c.Do("SET", "lora:ns:device:0807060504030201:gwrx", "old")
+	assert.NoError(err)
+	_, err = c.Do("SET", "lora:ns:device:{0807060504030201}:gwrx", "new")
+	assert.NoError(err)
+
+	assert.NoError(MigrateRedisHashTags(storage.RedisPool()))
+
+	val, err := redis.String(c.Do("GET", "lora:ns:device:{0102030405060708}"))
+	assert.NoError(err)
+	assert.Equal("session", val)
+
+	ttl, err := redis.Int(c.Do("PTTL", "lora:ns:device:{0102030405060708}"))
+	assert.NoError(err)
+	assert.True(ttl > 0)
+
+	n, err := redis.Int(c.Do("HGET", "lora:ns:metrics:{gw:0102030405060708:HOUR}:12345", "rx_count"))
+	assert.NoError(err)
+	assert.Equal(1, n)
+
+	val, err = redis.String(c.Do("GET", "lora:ns:device:{0807060504030201}:gwrx"))
+	assert.NoError(err)
+	assert.Equal("new", val)
+
+	keys, err := storage.ScanKeys(storage.RedisPool(), "lora:ns:*")
+	assert.NoError(err)
+	assert.Len(keys, 3)
+}
+
+func TestMigrateRedisHashTags(t *testing.T) {
+	suite.Run(t, new(MigrateRedisHashTagsTestSuite))
+}
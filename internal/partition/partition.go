@@ -0,0 +1,398 @@
+// Package partition implements the partitioning of devices across LoRa
+// Server instances. Each instance handles the uplink frames of the devices
+// within the partitions it owns and ignores all other uplink frames, so that
+// uplinks are never processed twice and instances do not compete for the
+// same per-device locks.
+//
+// The partition of a frame is the FNV-1a hash of the DevAddr (data frames)
+// or the DevEUI (join- and rejoin-requests) modulo the partition count.
+// Partitions are either configured statically, or claimed by the instances
+// through Redis. In the latter case, each instance claims an equal share of
+// the partitions and the partitions of a failed instance are claimed by the
+// other instances after the claim TTL has expired.
+//
+// The partitioning requires that every instance receives every uplink frame,
+// as the frames of the partitions owned by other instances are dropped. This
+// is the case for the MQTT and NATS gateway backends, without shared
+// subscriptions, queue groups or MQTT shards.
+package partition
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	claimKeyTempl = "lora:ns:partition:%d"
+	instancesKey  = "lora:ns:partition:instances"
+)
+
+var renewScript = redis.NewScript(1, `
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("pexpire", KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+var releaseScript = redis.NewScript(1, `
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	end
+	return 0
+`)
+
+var (
+	mux        sync.RWMutex
+	enabled    bool
+	count      int
+	static     bool
+	instanceID string
+
+	claimTTL      time.Duration
+	renewInterval time.Duration
+
+	// owned holds the owned partitions and the time until the claim is
+	// valid (not used for static partitions).
+	owned = make(map[int]time.Time)
+)
+
+// Setup configures the partitioning. When partitioning is disabled, this
+// instance handles all uplink frames.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	c := conf.NetworkServer.Partitioning
+	if c.Enabled {
+		if err := CheckGatewayBackends(conf); err != nil {
+			return errors.Wrap(err, "partition")
+		}
+	}
+
+	enabled = c.Enabled
+	count = c.Count
+	static = false
+	claimTTL = c.ClaimTTL
+	renewInterval = c.RenewInterval
+	owned = make(map[int]time.Time)
+
+	if !enabled {
+		return nil
+	}
+
+	if count <= 0 {
+		return errors.New("partition: count must be greater than 0")
+	}
+
+	if len(c.Partitions) != 0 {
+		static = true
+		for _, p := range c.Partitions {
+			if p < 0 || p >= count {
+				return fmt.Errorf("partition: partition %d is out of range (count: %d)", p, count)
+			}
+			owned[p] = time.Time{}
+		}
+
+		log.WithFields(log.Fields{
+			"count":      count,
+			"partitions": c.Partitions,
+		}).Info("partition: using static partitions")
+
+		return nil
+	}
+
+	if renewInterval <= 0 || renewInterval >= claimTTL {
+		return errors.New("partition: renew_interval must be greater than 0 and less than claim_ttl")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "new uuid error")
+	}
+	instanceID = id.String()
+
+	log.WithFields(log.Fields{
+		"instance_id":    instanceID,
+		"count":          count,
+		"claim_ttl":      claimTTL,
+		"renew_interval": renewInterval,
+	}).Info("partition: starting partition claiming")
+
+	go func() {
+		for {
+			if err := rebalance(context.Background(), storage.RedisPool()); err != nil {
+				log.WithError(err).Error("partition: rebalance error")
+			}
+			time.Sleep(renewInterval)
+		}
+	}()
+
+	return nil
+}
+
+// CheckGatewayBackends returns an error when one of the configured gateway
+// backends does not deliver every uplink frame to every instance. With such
+// a backend, the frames dropped for the partitions owned by other instances
+// are never handled, as the other instances do not receive these frames.
+func CheckGatewayBackends(conf config.Config) error {
+	backend := conf.NetworkServer.Gateway.Backend
+	types := backend.Types
+	if len(types) == 0 {
+		types = []string{backend.Type}
+	}
+
+	for _, t := range types {
+		switch t {
+		case "mqtt":
+			if backend.MQTT.SharedSubscriptionGroup != "" {
+				return errors.New("the mqtt shared_subscription_group load-balances the uplink frames over the instances")
+			}
+			if len(backend.MQTT.Shards) != 0 {
+				return errors.New("the mqtt shards subscribe to the uplink frames of a subset of the gateways")
+			}
+		case "nats":
+			if backend.NATS.JetStream {
+				return errors.New("the nats jetstream consumer load-balances the uplink frames over the instances")
+			}
+			if backend.NATS.QueueGroup != "" {
+				return errors.New("the nats queue_group load-balances the uplink frames over the instances")
+			}
+		case "kafka", "gcp_pub_sub", "azure_iot_hub":
+			return fmt.Errorf("the %s gateway backend load-balances the uplink frames over the instances", t)
+		default:
+			// the gateways are connected to a single instance
+			return fmt.Errorf("the %s gateway backend does not deliver the uplink frames to all instances", t)
+		}
+	}
+
+	return nil
+}
+
+// Owns returns true when this instance must handle the given PHYPayload.
+func Owns(phyPayload []byte) bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	if !enabled {
+		return true
+	}
+
+	until, ok := owned[partitionForPHYPayload(phyPayload, count)]
+	if !ok {
+		return false
+	}
+	return static || time.Now().Before(until)
+}
+
+// Partitions returns the (sorted) partitions owned by this instance.
+func Partitions() []int {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	var out []int
+	for p, until := range owned {
+		if static || time.Now().Before(until) {
+			out = append(out, p)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+// Release releases the claimed partitions, so that the other instances can
+// take over without waiting for the claims to expire.
+func Release(ctx context.Context, p storage.RedisClient) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if !enabled || static {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for i := range owned {
+		if _, err := releaseScript.Do(c, fmt.Sprintf(claimKeyTempl, i), instanceID); err != nil {
+			return errors.Wrap(err, "release partition error")
+		}
+		delete(owned, i)
+	}
+
+	if _, err := c.Do("ZREM", instancesKey, instanceID); err != nil {
+		return errors.Wrap(err, "remove instance error")
+	}
+
+	log.WithFields(log.Fields{
+		"instance_id": instanceID,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("partition: partitions released")
+
+	return nil
+}
+
+// rebalance renews the claimed partitions and claims or releases partitions
+// so that each live instance owns an equal share of the partitions.
+func rebalance(ctx context.Context, p storage.RedisClient) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	c := p.Get()
+	defer c.Close()
+
+	// the claims expire in Redis at least claimTTL after this moment
+	start := time.Now()
+	ttl := int64(claimTTL / time.Millisecond)
+	nowMS := start.UnixNano() / int64(time.Millisecond)
+
+	// register this instance and remove the instances which did not report
+	// within the claim TTL
+	c.Send("MULTI")
+	c.Send("ZADD", instancesKey, nowMS, instanceID)
+	c.Send("ZREMRANGEBYSCORE", instancesKey, "-inf", nowMS-ttl)
+	c.Send("ZCARD", instancesKey)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return errors.Wrap(err, "register instance error")
+	}
+	instances, err := redis.Int(values[2], nil)
+	if err != nil {
+		return errors.Wrap(err, "get instance count error")
+	}
+	if instances == 0 {
+		instances = 1
+	}
+	target := (count + instances - 1) / instances
+
+	var renewed, released, claimed []int
+
+	for i := range owned {
+		n, err := redis.Int(renewScript.Do(c, fmt.Sprintf(claimKeyTempl, i), instanceID, ttl))
+		if err != nil {
+			// the claim expires locally when not renewed in time
+			return errors.Wrap(err, "renew partition error")
+		}
+		if n == 1 {
+			owned[i] = start.Add(claimTTL)
+			renewed = append(renewed, i)
+		} else {
+			delete(owned, i)
+			log.WithFields(log.Fields{
+				"partition": i,
+				"ctx_id":    ctx.Value(logging.ContextIDKey),
+			}).Warning("partition: partition lost")
+		}
+	}
+
+	// release the partitions above the target share (highest first), so
+	// that new instances are able to claim these
+	sort.Sort(sort.Reverse(sort.IntSlice(renewed)))
+	for _, i := range renewed {
+		if len(owned) <= target {
+			break
+		}
+		if _, err := releaseScript.Do(c, fmt.Sprintf(claimKeyTempl, i), instanceID); err != nil {
+			return errors.Wrap(err, "release partition error")
+		}
+		delete(owned, i)
+		released = append(released, i)
+	}
+
+	for i := 0; i < count && len(owned) < target; i++ {
+		if _, ok := owned[i]; ok {
+			continue
+		}
+
+		_, err := redis.String(c.Do("SET", fmt.Sprintf(claimKeyTempl, i), instanceID, "PX", ttl, "NX"))
+		if err != nil {
+			if err == redis.ErrNil {
+				// claimed by an other instance
+				continue
+			}
+			return errors.Wrap(err, "claim partition error")
+		}
+		owned[i] = start.Add(claimTTL)
+		claimed = append(claimed, i)
+	}
+
+	if len(claimed) != 0 || len(released) != 0 {
+		log.WithFields(log.Fields{
+			"instances": instances,
+			"claimed":   claimed,
+			"released":  released,
+			"owned":     len(owned),
+			"ctx_id":    ctx.Value(logging.ContextIDKey),
+		}).Info("partition: partitions rebalanced")
+	}
+
+	return nil
+}
+
+// partitionForPHYPayload returns the partition for the given PHYPayload.
+// Frames without device identifier (e.g. proprietary frames) and invalid
+// frames belong to partition 0.
+func partitionForPHYPayload(b []byte, count int) int {
+	key := partitionKey(b)
+	if key == nil {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(count))
+}
+
+// partitionKey returns the DevAddr or DevEUI bytes of the given PHYPayload,
+// without validating the frame (the MIC has not been validated yet).
+func partitionKey(b []byte) []byte {
+	if len(b) < 1 {
+		return nil
+	}
+
+	switch lorawan.MType(b[0] >> 5) {
+	case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
+		// MHDR | DevAddr (4) | ...
+		if len(b) < 5 {
+			return nil
+		}
+		return b[1:5]
+	case lorawan.JoinRequest:
+		// MHDR | JoinEUI (8) | DevEUI (8) | ...
+		if len(b) < 17 {
+			return nil
+		}
+		return b[9:17]
+	case lorawan.RejoinRequest:
+		if len(b) < 2 {
+			return nil
+		}
+		// type 1: MHDR | type | JoinEUI (8) | DevEUI (8) | ...
+		// type 0 and 2: MHDR | type | NetID (3) | DevEUI (8) | ...
+		if b[1] == byte(lorawan.RejoinRequestType1) {
+			if len(b) < 18 {
+				return nil
+			}
+			return b[10:18]
+		}
+		if len(b) < 13 {
+			return nil
+		}
+		return b[5:13]
+	default:
+		return nil
+	}
+}
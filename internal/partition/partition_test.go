@@ -0,0 +1,257 @@
+package partition
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+func TestPartitionKey(t *testing.T) {
+	tests := []struct {
+		Name       string
+		PHYPayload lorawan.PHYPayload
+		Expected   []byte
+	}{
+		{
+			Name: "unconfirmed data-up",
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{MType: lorawan.UnconfirmedDataUp, Major: lorawan.LoRaWANR1},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{DevAddr: lorawan.DevAddr{1, 2, 3, 4}},
+				},
+			},
+			Expected: []byte{4, 3, 2, 1},
+		},
+		{
+			Name: "join-request",
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{MType: lorawan.JoinRequest, Major: lorawan.LoRaWANR1},
+				MACPayload: &lorawan.JoinRequestPayload{
+					JoinEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+					DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+			Expected: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		{
+			Name: "rejoin-request type 0",
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{MType: lorawan.RejoinRequest, Major: lorawan.LoRaWANR1},
+				MACPayload: &lorawan.RejoinRequestType02Payload{
+					RejoinType: lorawan.RejoinRequestType0,
+					NetID:      lorawan.NetID{1, 2, 3},
+					DevEUI:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+			Expected: []byte{8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		{
+			Name: "proprietary",
+			PHYPayload: lorawan.PHYPayload{
+				MHDR:       lorawan.MHDR{MType: lorawan.Proprietary, Major: lorawan.LoRaWANR1},
+				MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4, 5}},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			b, err := tst.PHYPayload.MarshalBinary()
+			assert.NoError(err)
+			assert.Equal(tst.Expected, partitionKey(b))
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Nil(partitionKey(nil))
+		assert.Nil(partitionKey([]byte{0x40, 1, 2}))
+		assert.Equal(0, partitionForPHYPayload([]byte{0x40, 1, 2}, 16))
+	})
+}
+
+func TestStaticPartitions(t *testing.T) {
+	assert := require.New(t)
+
+	var conf config.Config
+	conf.NetworkServer.Gateway.Backend.Type = "mqtt"
+	conf.NetworkServer.Partitioning.Enabled = true
+	conf.NetworkServer.Partitioning.Count = 2
+	conf.NetworkServer.Partitioning.Partitions = []int{1}
+	assert.NoError(Setup(conf))
+	defer Setup(config.Config{})
+
+	var owns, ignores int
+	for i := 0; i < 100; i++ {
+		b := []byte{0x40, byte(i), 0, 0, 0}
+		if Owns(b) {
+			assert.Equal(1, partitionForPHYPayload(b, 2))
+			owns++
+		} else {
+			ignores++
+		}
+	}
+	assert.NotZero(owns)
+	assert.NotZero(ignores)
+	assert.Equal([]int{1}, Partitions())
+
+	conf.NetworkServer.Partitioning.Partitions = []int{2}
+	assert.Error(Setup(conf))
+}
+
+func TestCheckGatewayBackends(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Config        func(*config.Config)
+		ExpectedError string
+	}{
+		{
+			Name: "mqtt",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "mqtt"
+			},
+		},
+		{
+			Name: "mqtt shared subscription",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "mqtt"
+				c.NetworkServer.Gateway.Backend.MQTT.SharedSubscriptionGroup = "loraserver"
+			},
+			ExpectedError: "the mqtt shared_subscription_group load-balances the uplink frames over the instances",
+		},
+		{
+			Name: "mqtt shards",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "mqtt"
+				c.NetworkServer.Gateway.Backend.MQTT.ShardCount = 4
+				c.NetworkServer.Gateway.Backend.MQTT.Shards = []int{0, 1}
+			},
+			ExpectedError: "the mqtt shards subscribe to the uplink frames of a subset of the gateways",
+		},
+		{
+			Name: "nats without queue group",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "nats"
+			},
+		},
+		{
+			Name: "nats queue group",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "nats"
+				c.NetworkServer.Gateway.Backend.NATS.QueueGroup = "loraserver"
+			},
+			ExpectedError: "the nats queue_group load-balances the uplink frames over the instances",
+		},
+		{
+			Name: "nats jetstream",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "nats"
+				c.NetworkServer.Gateway.Backend.NATS.JetStream = true
+			},
+			ExpectedError: "the nats jetstream consumer load-balances the uplink frames over the instances",
+		},
+		{
+			Name: "kafka consumer group",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Types = []string{"mqtt", "kafka"}
+			},
+			ExpectedError: "the kafka gateway backend load-balances the uplink frames over the instances",
+		},
+		{
+			Name: "gcp pub/sub subscription",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "gcp_pub_sub"
+			},
+			ExpectedError: "the gcp_pub_sub gateway backend load-balances the uplink frames over the instances",
+		},
+		{
+			Name: "semtech udp",
+			Config: func(c *config.Config) {
+				c.NetworkServer.Gateway.Backend.Type = "semtech_udp"
+			},
+			ExpectedError: "the semtech_udp gateway backend does not deliver the uplink frames to all instances",
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			var conf config.Config
+			tst.Config(&conf)
+			err := CheckGatewayBackends(conf)
+			if tst.ExpectedError != "" {
+				assert.EqualError(err, tst.ExpectedError)
+
+				// Setup rejects the combination
+				conf.NetworkServer.Partitioning.Enabled = true
+				conf.NetworkServer.Partitioning.Count = 2
+				conf.NetworkServer.Partitioning.Partitions = []int{0}
+				assert.Error(Setup(conf))
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	test.MustFlushRedis(storage.RedisPool())
+
+	// the rebalance loop is not started, instances are simulated by
+	// switching the instance ID and owned partitions
+	enabled = true
+	static = false
+	count = 4
+	claimTTL = time.Second
+	defer func() {
+		enabled = false
+		owned = make(map[int]time.Time)
+	}()
+
+	instanceID = "a"
+	owned = make(map[int]time.Time)
+	assert.NoError(rebalance(context.Background(), storage.RedisPool()))
+	assert.Equal([]int{0, 1, 2, 3}, Partitions())
+	ownedA := owned
+
+	// instance b joins, but all partitions are claimed by a
+	instanceID = "b"
+	owned = make(map[int]time.Time)
+	assert.NoError(rebalance(context.Background(), storage.RedisPool()))
+	assert.Len(Partitions(), 0)
+	ownedB := owned
+
+	// a releases its partitions above its share
+	instanceID = "a"
+	owned = ownedA
+	assert.NoError(rebalance(context.Background(), storage.RedisPool()))
+	assert.Equal([]int{0, 1}, Partitions())
+
+	// b claims the released partitions
+	instanceID = "b"
+	owned = ownedB
+	assert.NoError(rebalance(context.Background(), storage.RedisPool()))
+	assert.Equal([]int{2, 3}, Partitions())
+
+	// b leaves, a takes over
+	assert.NoError(Release(context.Background(), storage.RedisPool()))
+	instanceID = "a"
+	owned = ownedA
+	assert.NoError(rebalance(context.Background(), storage.RedisPool()))
+	assert.Equal([]int{0, 1, 2, 3}, Partitions())
+}
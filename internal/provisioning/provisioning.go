@@ -0,0 +1,400 @@
+// Package provisioning implements the synchronization of routing-, service-,
+// device- and gateway-profiles and devices from an external provisioning API.
+// This makes it possible to run LoRa Server without an application-server
+// managing these objects through the network-server API.
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/mxc-foundation/lpwan-server/api/ns"
+	"github.com/mxc-foundation/lpwan-server/internal/api"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/janitor"
+)
+
+// document defines the document returned by the provisioning API.
+// Each item must be encoded using the JSON mapping of the corresponding
+// network-server API message.
+type document struct {
+	RoutingProfiles []json.RawMessage `json:"routingProfiles"`
+	ServiceProfiles []json.RawMessage `json:"serviceProfiles"`
+	DeviceProfiles  []json.RawMessage `json:"deviceProfiles"`
+	GatewayProfiles []json.RawMessage `json:"gatewayProfiles"`
+	Devices         []json.RawMessage `json:"devices"`
+}
+
+// reconciler implements the create / update / delete logic for a single
+// object type.
+type reconciler struct {
+	name      string
+	newObject func() proto.Message
+	key       func(proto.Message) []byte
+	exists    func(context.Context, proto.Message) (bool, error)
+	create    func(context.Context, proto.Message) error
+	update    func(context.Context, proto.Message) error
+	delete    func(context.Context, []byte) error
+}
+
+var (
+	server     string
+	token      string
+	interval   time.Duration
+	httpClient = &http.Client{Timeout: 30 * time.Second}
+
+	nsAPI = api.NewNetworkServerAPI()
+
+	// applied contains the hash of the last applied item per object key.
+	// This avoids updating (and flushing the caches of) unchanged objects
+	// on every synchronization. It also contains the objects that must be
+	// deleted once they have been removed from the provisioning document.
+	appliedMux sync.Mutex
+	applied    = make(map[string][sha256.Size]byte)
+)
+
+// Setup configures the provisioning package and starts the synchronization
+// janitor task (when a provisioning server has been configured).
+func Setup(conf config.Config) error {
+	server = conf.ProvisioningSync.Server
+	token = conf.ProvisioningSync.Token
+	interval = conf.ProvisioningSync.Interval
+
+	if server == "" {
+		return nil
+	}
+
+	log.WithField("server", server).Info("provisioning: starting synchronization")
+
+	// only the leader instance synchronizes
+	return janitor.Start(janitor.Task{
+		Name:       "provisioning_sync",
+		Interval:   interval,
+		LeaderOnly: true,
+		Run: func(ctx context.Context) (int, error) {
+			return 0, Sync(ctx)
+		},
+	})
+}
+
+// Sync retrieves the provisioning document and reconciles its objects.
+// Objects that do not exist are created, existing objects are updated.
+// Objects that have been synchronized by this instance and that have been
+// removed from the document are deleted. Other objects (e.g. created by the
+// application-server) are never deleted.
+func Sync(ctx context.Context) error {
+	doc, err := getDocument()
+	if err != nil {
+		return errors.Wrap(err, "get provisioning document error")
+	}
+
+	// the order matters as objects refer to each other
+	sets := []struct {
+		items      []json.RawMessage
+		reconciler reconciler
+	}{
+		{doc.RoutingProfiles, routingProfileReconciler},
+		{doc.ServiceProfiles, serviceProfileReconciler},
+		{doc.DeviceProfiles, deviceProfileReconciler},
+		{doc.GatewayProfiles, gatewayProfileReconciler},
+		{doc.Devices, deviceReconciler},
+	}
+
+	var failed int
+	seen := make(map[string]bool)
+
+	for _, set := range sets {
+		for _, item := range set.items {
+			key, err := reconcile(ctx, set.reconciler, item)
+			if key != "" {
+				seen[key] = true
+			}
+			if err != nil {
+				failed++
+				log.WithError(err).WithField("type", set.reconciler.name).Error("provisioning: reconcile error")
+			}
+		}
+	}
+
+	// An item which could not be decoded might refer to an object that
+	// was synchronized before, in which case it must not be deleted.
+	if failed != 0 {
+		return fmt.Errorf("%d object(s) failed to reconcile", failed)
+	}
+
+	// objects are deleted in reverse order, as objects refer to each other
+	for i := len(sets) - 1; i >= 0; i-- {
+		for _, key := range removedKeys(sets[i].reconciler, seen) {
+			if err := remove(ctx, sets[i].reconciler, key); err != nil {
+				failed++
+				log.WithError(err).WithField("type", sets[i].reconciler.name).Error("provisioning: delete error")
+			}
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d object(s) failed to delete", failed)
+	}
+
+	return nil
+}
+
+func getDocument() (document, error) {
+	var doc document
+
+	req, err := http.NewRequest("GET", server, nil)
+	if err != nil {
+		return doc, errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return doc, errors.Wrap(err, "http request error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return doc, fmt.Errorf("expected 200, got: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return doc, errors.Wrap(err, "decode json error")
+	}
+
+	return doc, nil
+}
+
+// reconcile creates or updates the given item. It returns the object key,
+// which is empty when the item could not be decoded.
+func reconcile(ctx context.Context, r reconciler, item json.RawMessage) (string, error) {
+	obj := r.newObject()
+	if err := (&jsonpb.Unmarshaler{}).Unmarshal(bytes.NewReader(item), obj); err != nil {
+		return "", errors.Wrap(err, "unmarshal json error")
+	}
+
+	b, err := proto.Marshal(obj)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal protobuf error")
+	}
+	hash := sha256.Sum256(b)
+	key := objectKey(r, r.key(obj))
+
+	appliedMux.Lock()
+	prev, ok := applied[key]
+	appliedMux.Unlock()
+	if ok && prev == hash {
+		return key, nil
+	}
+
+	exists, err := r.exists(ctx, obj)
+	if err != nil {
+		return key, errors.Wrap(err, "get error")
+	}
+
+	if exists {
+		err = r.update(ctx, obj)
+	} else {
+		err = r.create(ctx, obj)
+	}
+	if err != nil {
+		return key, errors.Wrapf(err, "create or update %s error", key)
+	}
+
+	appliedMux.Lock()
+	applied[key] = hash
+	appliedMux.Unlock()
+
+	log.WithFields(log.Fields{
+		"type":    r.name,
+		"key":     hex.EncodeToString(r.key(obj)),
+		"created": !exists,
+	}).Info("provisioning: object synchronized")
+
+	return key, nil
+}
+
+// removedKeys returns the keys of the applied objects of the given type
+// which are no longer part of the provisioning document.
+func removedKeys(r reconciler, seen map[string]bool) []string {
+	var out []string
+	prefix := r.name + "/"
+
+	appliedMux.Lock()
+	defer appliedMux.Unlock()
+
+	for key := range applied {
+		if strings.HasPrefix(key, prefix) && !seen[key] {
+			out = append(out, key)
+		}
+	}
+
+	return out
+}
+
+// remove deletes the object with the given key. An object which has already
+// been deleted (e.g. through the network-server API) is ignored.
+func remove(ctx context.Context, r reconciler, key string) error {
+	id, err := hex.DecodeString(strings.TrimPrefix(key, r.name+"/"))
+	if err != nil {
+		return errors.Wrap(err, "decode key error")
+	}
+
+	if err := r.delete(ctx, id); err != nil && grpc.Code(err) != codes.NotFound {
+		return errors.Wrapf(err, "delete %s error", key)
+	}
+
+	appliedMux.Lock()
+	delete(applied, key)
+	appliedMux.Unlock()
+
+	log.WithFields(log.Fields{
+		"type": r.name,
+		"key":  hex.EncodeToString(id),
+	}).Info("provisioning: object deleted")
+
+	return nil
+}
+
+func objectKey(r reconciler, id []byte) string {
+	return r.name + "/" + hex.EncodeToString(id)
+}
+
+// notFound returns false, nil when the given error is a not found error.
+func notFound(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if grpc.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	return false, err
+}
+
+var routingProfileReconciler = reconciler{
+	name:      "routing-profile",
+	newObject: func() proto.Message { return &ns.RoutingProfile{} },
+	key:       func(m proto.Message) []byte { return m.(*ns.RoutingProfile).Id },
+	exists: func(ctx context.Context, m proto.Message) (bool, error) {
+		_, err := nsAPI.GetRoutingProfile(ctx, &ns.GetRoutingProfileRequest{Id: m.(*ns.RoutingProfile).Id})
+		return notFound(err)
+	},
+	create: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.CreateRoutingProfile(ctx, &ns.CreateRoutingProfileRequest{RoutingProfile: m.(*ns.RoutingProfile)})
+		return err
+	},
+	update: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.UpdateRoutingProfile(ctx, &ns.UpdateRoutingProfileRequest{RoutingProfile: m.(*ns.RoutingProfile)})
+		return err
+	},
+	delete: func(ctx context.Context, id []byte) error {
+		_, err := nsAPI.DeleteRoutingProfile(ctx, &ns.DeleteRoutingProfileRequest{Id: id})
+		return err
+	},
+}
+
+var serviceProfileReconciler = reconciler{
+	name:      "service-profile",
+	newObject: func() proto.Message { return &ns.ServiceProfile{} },
+	key:       func(m proto.Message) []byte { return m.(*ns.ServiceProfile).Id },
+	exists: func(ctx context.Context, m proto.Message) (bool, error) {
+		_, err := nsAPI.GetServiceProfile(ctx, &ns.GetServiceProfileRequest{Id: m.(*ns.ServiceProfile).Id})
+		return notFound(err)
+	},
+	create: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.CreateServiceProfile(ctx, &ns.CreateServiceProfileRequest{ServiceProfile: m.(*ns.ServiceProfile)})
+		return err
+	},
+	update: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{ServiceProfile: m.(*ns.ServiceProfile)})
+		return err
+	},
+	delete: func(ctx context.Context, id []byte) error {
+		_, err := nsAPI.DeleteServiceProfile(ctx, &ns.DeleteServiceProfileRequest{Id: id})
+		return err
+	},
+}
+
+var deviceProfileReconciler = reconciler{
+	name:      "device-profile",
+	newObject: func() proto.Message { return &ns.DeviceProfile{} },
+	key:       func(m proto.Message) []byte { return m.(*ns.DeviceProfile).Id },
+	exists: func(ctx context.Context, m proto.Message) (bool, error) {
+		_, err := nsAPI.GetDeviceProfile(ctx, &ns.GetDeviceProfileRequest{Id: m.(*ns.DeviceProfile).Id})
+		return notFound(err)
+	},
+	create: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.CreateDeviceProfile(ctx, &ns.CreateDeviceProfileRequest{DeviceProfile: m.(*ns.DeviceProfile)})
+		return err
+	},
+	update: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.UpdateDeviceProfile(ctx, &ns.UpdateDeviceProfileRequest{DeviceProfile: m.(*ns.DeviceProfile)})
+		return err
+	},
+	delete: func(ctx context.Context, id []byte) error {
+		_, err := nsAPI.DeleteDeviceProfile(ctx, &ns.DeleteDeviceProfileRequest{Id: id})
+		return err
+	},
+}
+
+var gatewayProfileReconciler = reconciler{
+	name:      "gateway-profile",
+	newObject: func() proto.Message { return &ns.GatewayProfile{} },
+	key:       func(m proto.Message) []byte { return m.(*ns.GatewayProfile).Id },
+	exists: func(ctx context.Context, m proto.Message) (bool, error) {
+		_, err := nsAPI.GetGatewayProfile(ctx, &ns.GetGatewayProfileRequest{Id: m.(*ns.GatewayProfile).Id})
+		return notFound(err)
+	},
+	create: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.CreateGatewayProfile(ctx, &ns.CreateGatewayProfileRequest{GatewayProfile: m.(*ns.GatewayProfile)})
+		return err
+	},
+	update: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.UpdateGatewayProfile(ctx, &ns.UpdateGatewayProfileRequest{GatewayProfile: m.(*ns.GatewayProfile)})
+		return err
+	},
+	delete: func(ctx context.Context, id []byte) error {
+		_, err := nsAPI.DeleteGatewayProfile(ctx, &ns.DeleteGatewayProfileRequest{Id: id})
+		return err
+	},
+}
+
+var deviceReconciler = reconciler{
+	name:      "device",
+	newObject: func() proto.Message { return &ns.Device{} },
+	key:       func(m proto.Message) []byte { return m.(*ns.Device).DevEui },
+	exists: func(ctx context.Context, m proto.Message) (bool, error) {
+		_, err := nsAPI.GetDevice(ctx, &ns.GetDeviceRequest{DevEui: m.(*ns.Device).DevEui})
+		return notFound(err)
+	},
+	create: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.CreateDevice(ctx, &ns.CreateDeviceRequest{Device: m.(*ns.Device)})
+		return err
+	},
+	update: func(ctx context.Context, m proto.Message) error {
+		_, err := nsAPI.UpdateDevice(ctx, &ns.UpdateDeviceRequest{Device: m.(*ns.Device)})
+		return err
+	},
+	delete: func(ctx context.Context, id []byte) error {
+		_, err := nsAPI.DeleteDevice(ctx, &ns.DeleteDeviceRequest{DevEui: id})
+		return err
+	},
+}
@@ -0,0 +1,245 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/ns"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type SyncTestSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+
+	mux    sync.Mutex
+	status int
+	doc    document
+
+	rp  ns.RoutingProfile
+	sp  ns.ServiceProfile
+	dp  ns.DeviceProfile
+	dev ns.Device
+}
+
+func (ts *SyncTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+	assert.NoError(band.Setup(conf))
+
+	ts.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.mux.Lock()
+		defer ts.mux.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(ts.status)
+		json.NewEncoder(w).Encode(ts.doc)
+	}))
+
+	server = ts.server.URL
+	token = "secret"
+}
+
+func (ts *SyncTestSuite) TearDownSuite() {
+	ts.server.Close()
+	server = ""
+	token = ""
+}
+
+func (ts *SyncTestSuite) SetupTest() {
+	test.MustResetDB(storage.DB().DB)
+	test.MustFlushRedis(storage.RedisPool())
+
+	appliedMux.Lock()
+	applied = make(map[string][sha256.Size]byte)
+	appliedMux.Unlock()
+
+	rpID, _ := uuid.NewV4()
+	spID, _ := uuid.NewV4()
+	dpID, _ := uuid.NewV4()
+
+	ts.rp = ns.RoutingProfile{
+		Id:   rpID.Bytes(),
+		AsId: "as:8001",
+	}
+	ts.sp = ns.ServiceProfile{
+		Id:    spID.Bytes(),
+		DrMax: 5,
+	}
+	ts.dp = ns.DeviceProfile{
+		Id:           dpID.Bytes(),
+		MacVersion:   "1.0.2",
+		SupportsJoin: true,
+	}
+	ts.dev = ns.Device{
+		DevEui:           []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileId: rpID.Bytes(),
+		ServiceProfileId: spID.Bytes(),
+		DeviceProfileId:  dpID.Bytes(),
+	}
+}
+
+// setDocument sets the document returned by the provisioning server.
+func (ts *SyncTestSuite) setDocument(status int, rps []*ns.RoutingProfile, sps []*ns.ServiceProfile, dps []*ns.DeviceProfile, devs []*ns.Device) {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+
+	ts.status = status
+	ts.doc = document{}
+
+	for _, m := range rps {
+		ts.doc.RoutingProfiles = append(ts.doc.RoutingProfiles, ts.marshal(m))
+	}
+	for _, m := range sps {
+		ts.doc.ServiceProfiles = append(ts.doc.ServiceProfiles, ts.marshal(m))
+	}
+	for _, m := range dps {
+		ts.doc.DeviceProfiles = append(ts.doc.DeviceProfiles, ts.marshal(m))
+	}
+	for _, m := range devs {
+		ts.doc.Devices = append(ts.doc.Devices, ts.marshal(m))
+	}
+}
+
+func (ts *SyncTestSuite) marshal(m proto.Message) json.RawMessage {
+	var buf bytes.Buffer
+	ts.Require().NoError((&jsonpb.Marshaler{}).Marshal(&buf, m))
+	return json.RawMessage(buf.Bytes())
+}
+
+func (ts *SyncTestSuite) setAll(status int) {
+	ts.setDocument(status, []*ns.RoutingProfile{&ts.rp}, []*ns.ServiceProfile{&ts.sp}, []*ns.DeviceProfile{&ts.dp}, []*ns.Device{&ts.dev})
+}
+
+func (ts *SyncTestSuite) TestCreate() {
+	assert := require.New(ts.T())
+	ts.setAll(http.StatusOK)
+
+	assert.NoError(Sync(context.Background()))
+
+	rp, err := storage.GetRoutingProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.rp.Id))
+	assert.NoError(err)
+	assert.Equal("as:8001", rp.ASID)
+
+	sp, err := storage.GetServiceProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.sp.Id))
+	assert.NoError(err)
+	assert.Equal(5, sp.DRMax)
+
+	dp, err := storage.GetDeviceProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.dp.Id))
+	assert.NoError(err)
+	assert.Equal("1.0.2", dp.MACVersion)
+
+	d, err := storage.GetDevice(context.Background(), storage.DB(), lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.NoError(err)
+	assert.Equal(uuid.FromBytesOrNil(ts.dp.Id), d.DeviceProfileID)
+
+	// a second sync does not fail on the existing objects
+	assert.NoError(Sync(context.Background()))
+}
+
+func (ts *SyncTestSuite) TestUpdate() {
+	assert := require.New(ts.T())
+	ts.setAll(http.StatusOK)
+	assert.NoError(Sync(context.Background()))
+
+	ts.rp.AsId = "as:8002"
+	ts.dp.SupportsClassC = true
+	ts.dp.ClassCTimeout = 10
+	ts.setAll(http.StatusOK)
+	assert.NoError(Sync(context.Background()))
+
+	rp, err := storage.GetRoutingProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.rp.Id))
+	assert.NoError(err)
+	assert.Equal("as:8002", rp.ASID)
+
+	dp, err := storage.GetDeviceProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.dp.Id))
+	assert.NoError(err)
+	assert.True(dp.SupportsClassC)
+	assert.Equal(10, dp.ClassCTimeout)
+}
+
+func (ts *SyncTestSuite) TestDelete() {
+	assert := require.New(ts.T())
+	ts.setAll(http.StatusOK)
+	assert.NoError(Sync(context.Background()))
+
+	// a service-profile not created by the synchronization
+	sp := storage.ServiceProfile{}
+	assert.NoError(storage.CreateServiceProfile(context.Background(), storage.DB(), &sp))
+
+	// remove the device and device-profile from the document
+	ts.setDocument(http.StatusOK, []*ns.RoutingProfile{&ts.rp}, []*ns.ServiceProfile{&ts.sp}, nil, nil)
+	assert.NoError(Sync(context.Background()))
+
+	_, err := storage.GetDevice(context.Background(), storage.DB(), lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.Equal(storage.ErrDoesNotExist, errors.Cause(err))
+
+	_, err = storage.GetDeviceProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.dp.Id))
+	assert.Equal(storage.ErrDoesNotExist, errors.Cause(err))
+
+	_, err = storage.GetRoutingProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.rp.Id))
+	assert.NoError(err)
+
+	_, err = storage.GetServiceProfile(context.Background(), storage.DB(), sp.ID)
+	assert.NoError(err)
+
+	// an invalid item prevents the deletion
+	ts.setDocument(http.StatusOK, nil, nil, nil, nil)
+	ts.mux.Lock()
+	ts.doc.ServiceProfiles = []json.RawMessage{json.RawMessage(`{"id": 1}`)}
+	ts.mux.Unlock()
+	assert.Error(Sync(context.Background()))
+
+	_, err = storage.GetRoutingProfile(context.Background(), storage.DB(), uuid.FromBytesOrNil(ts.rp.Id))
+	assert.NoError(err)
+}
+
+func (ts *SyncTestSuite) TestErrorResponse() {
+	assert := require.New(ts.T())
+	ts.setAll(http.StatusOK)
+	assert.NoError(Sync(context.Background()))
+
+	// the objects are not deleted when the provisioning server returns
+	// an error
+	ts.setDocument(http.StatusInternalServerError, nil, nil, nil, nil)
+	assert.Error(Sync(context.Background()))
+
+	_, err := storage.GetDevice(context.Background(), storage.DB(), lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+	assert.NoError(err)
+
+	ts.T().Run("Invalid token", func(t *testing.T) {
+		assert := require.New(t)
+
+		token = "invalid"
+		defer func() { token = "secret" }()
+
+		ts.setAll(http.StatusOK)
+		assert.Error(Sync(context.Background()))
+	})
+}
+
+func TestSync(t *testing.T) {
+	suite.Run(t, new(SyncTestSuite))
+}
@@ -0,0 +1,111 @@
+// Package reload implements the runtime reloading of the configuration
+// settings which can be safely changed without restarting LoRa Server.
+package reload
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/adr"
+	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
+	"github.com/mxc-foundation/lpwan-server/internal/dryrun"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/uplink"
+)
+
+// ErrNotConfigured is returned when reloading before Setup has been called.
+var ErrNotConfigured = errors.New("configuration reload is not configured")
+
+// Loader returns the (re)loaded configuration.
+type Loader func() (config.Config, error)
+
+var (
+	mux    sync.Mutex
+	loader Loader
+)
+
+// Setup configures the function used for loading the configuration.
+func Setup(l Loader) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	loader = l
+}
+
+// Reload loads the configuration and applies the following settings:
+//
+//   - general.log_level
+//   - network_server.deduplication_delay
+//   - network_server.network_settings.installation_margin
+//   - network_server.gateway.backend.mqtt credentials and certificates
+//   - join_server (default join-server and certificates)
+//
+// The new configuration is validated first. When the validation or the
+// update of the gateway backend credentials fails, none of the settings are
+// applied. All other settings require a restart.
+func Reload(ctx context.Context) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if loader == nil {
+		return ErrNotConfigured
+	}
+
+	conf, err := loader()
+	if err != nil {
+		return errors.Wrap(err, "load configuration error")
+	}
+
+	if err := validate(conf); err != nil {
+		return errors.Wrap(err, "validate configuration error")
+	}
+
+	jsPool, err := joinserver.NewPool(conf)
+	if err != nil {
+		return errors.Wrap(err, "create join-server pool error")
+	}
+
+	if u, ok := gwbackend.Backend().(gwbackend.CredentialsUpdater); ok {
+		if err := u.UpdateCredentials(conf); err != nil {
+			return errors.Wrap(err, "update gateway backend credentials error")
+		}
+	}
+
+	log.SetLevel(log.Level(uint8(conf.General.LogLevel)))
+	uplink.SetDeduplicationDelay(conf.NetworkServer.DeduplicationDelay)
+	adr.SetInstallationMargin(conf.NetworkServer.NetworkSettings.InstallationMargin)
+	multicast.SetInstallationMargin(conf.NetworkServer.NetworkSettings.InstallationMargin)
+
+	// in dry-run mode, the join-server must never be contacted
+	if !dryrun.Enabled() {
+		joinserver.SetPool(jsPool)
+	}
+
+	log.WithFields(log.Fields{
+		"log_level":           conf.General.LogLevel,
+		"deduplication_delay": conf.NetworkServer.DeduplicationDelay,
+		"installation_margin": conf.NetworkServer.NetworkSettings.InstallationMargin,
+		"ctx_id":              ctx.Value(logging.ContextIDKey),
+	}).Info("reload: configuration reloaded")
+
+	return nil
+}
+
+func validate(conf config.Config) error {
+	if conf.General.LogLevel < int(log.PanicLevel) || conf.General.LogLevel > int(log.DebugLevel) {
+		return fmt.Errorf("invalid log_level: %d", conf.General.LogLevel)
+	}
+
+	if conf.NetworkServer.DeduplicationDelay <= 0 {
+		return fmt.Errorf("invalid deduplication_delay: %s", conf.NetworkServer.DeduplicationDelay)
+	}
+
+	return nil
+}
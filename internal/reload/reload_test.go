@@ -0,0 +1,62 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+func TestReload(t *testing.T) {
+	level := log.GetLevel()
+	defer log.SetLevel(level)
+
+	var conf config.Config
+	var loadErr error
+	Setup(func() (config.Config, error) {
+		return conf, loadErr
+	})
+	defer Setup(nil)
+
+	t.Run("Valid configuration", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.General.LogLevel = int(log.ErrorLevel)
+		conf.NetworkServer.DeduplicationDelay = 100 * time.Millisecond
+		conf.JoinServer.Default.Server = "http://localhost:8003"
+
+		assert.NoError(Reload(context.Background()))
+		assert.Equal(log.ErrorLevel, log.GetLevel())
+		assert.NotNil(joinserver.GetPool())
+	})
+
+	t.Run("Invalid log level", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.General.LogLevel = 10
+		assert.Error(Reload(context.Background()))
+		assert.Equal(log.ErrorLevel, log.GetLevel())
+	})
+
+	t.Run("Invalid deduplication delay", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.General.LogLevel = int(log.DebugLevel)
+		conf.NetworkServer.DeduplicationDelay = 0
+		assert.Error(Reload(context.Background()))
+		assert.Equal(log.ErrorLevel, log.GetLevel())
+	})
+
+	t.Run("Load error", func(t *testing.T) {
+		assert := require.New(t)
+
+		loadErr = errors.New("boom")
+		assert.Error(Reload(context.Background()))
+	})
+}
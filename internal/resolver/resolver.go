@@ -0,0 +1,118 @@
+// Package resolver implements the DNS based resolving of the NetID and
+// JoinEUI to the Backend Interfaces endpoint of the network-server or
+// join-server, following the LoRa Alliance DNS scheme.
+package resolver
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// negativeCacheTTL defines how long a failed lookup is cached. This is
+// shorter than the (configurable) cache TTL so that a temporary DNS error
+// does not disable the resolving for too long.
+const negativeCacheTTL = time.Minute
+
+// Resolver resolves the NetID and JoinEUI to the server URL, caching the
+// result of the lookups.
+type Resolver struct {
+	netIDDomainSuffix   string
+	joinEUIDomainSuffix string
+	cacheTTL            time.Duration
+
+	// lookupIP is used for the DNS lookups (set to net.LookupIP by New).
+	lookupIP func(host string) ([]net.IP, error)
+
+	mux   sync.Mutex
+	cache map[string]cacheItem
+}
+
+type cacheItem struct {
+	url       string
+	err       error
+	expiresAt time.Time
+}
+
+// New creates a new Resolver. The domain suffixes are appended to the
+// NetID and JoinEUI based names, e.g. ".netids.lorawan.net" and
+// ".joineuis.lorawan.net".
+func New(netIDDomainSuffix, joinEUIDomainSuffix string, cacheTTL time.Duration) *Resolver {
+	return &Resolver{
+		netIDDomainSuffix:   netIDDomainSuffix,
+		joinEUIDomainSuffix: joinEUIDomainSuffix,
+		cacheTTL:            cacheTTL,
+		lookupIP:            net.LookupIP,
+		cache:               make(map[string]cacheItem),
+	}
+}
+
+// ResolveNetID returns the network-server URL for the given NetID.
+func (r *Resolver) ResolveNetID(netID lorawan.NetID) (string, error) {
+	return r.resolve(NetIDToServer(netID, r.netIDDomainSuffix))
+}
+
+// ResolveJoinEUI returns the join-server URL for the given JoinEUI.
+func (r *Resolver) ResolveJoinEUI(joinEUI lorawan.EUI64) (string, error) {
+	return r.resolve(JoinEUIToServer(joinEUI, r.joinEUIDomainSuffix))
+}
+
+func (r *Resolver) resolve(server string) (string, error) {
+	now := time.Now()
+
+	r.mux.Lock()
+	item, ok := r.cache[server]
+	r.mux.Unlock()
+	if ok && now.Before(item.expiresAt) {
+		return item.url, item.err
+	}
+
+	item = cacheItem{expiresAt: now.Add(r.cacheTTL)}
+	if _, err := r.lookupIP(server); err != nil {
+		item.err = errors.Wrapf(err, "lookup %s error", server)
+		item.expiresAt = now.Add(negativeCacheTTL)
+	} else {
+		item.url = ServerToURL(server, true, 443)
+	}
+
+	r.mux.Lock()
+	r.cache[server] = item
+	r.mux.Unlock()
+
+	return item.url, item.err
+}
+
+// NetIDToServer returns the DNS name for the given NetID.
+func NetIDToServer(netID lorawan.NetID, suffix string) string {
+	return netID.String() + suffix
+}
+
+// JoinEUIToServer returns the DNS name for the given JoinEUI. This is the
+// reversed nibbles of the JoinEUI, separated by dots.
+func JoinEUIToServer(joinEUI lorawan.EUI64, suffix string) string {
+	nibbles := strings.Split(joinEUI.String(), "")
+
+	for i, j := 0, len(nibbles)-1; i < j; i, j = i+1, j-1 {
+		nibbles[i], nibbles[j] = nibbles[j], nibbles[i]
+	}
+
+	return strings.Join(nibbles, ".") + suffix
+}
+
+// ServerToURL returns the URL for the given server hostname.
+func ServerToURL(server string, secure bool, port int) string {
+	var protocol string
+	if secure {
+		protocol = "https://"
+	} else {
+		protocol = "http://"
+	}
+
+	return fmt.Sprintf("%s%s:%d/", protocol, server, port)
+}
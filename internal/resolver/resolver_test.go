@@ -0,0 +1,112 @@
+package resolver
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestJoinEUIToServer(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal("8.0.7.0.6.0.5.0.4.0.3.0.2.0.1.0.example.com", JoinEUIToServer(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, ".example.com"))
+}
+
+func TestNetIDToServer(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal("010203.netids.example.com", NetIDToServer(lorawan.NetID{1, 2, 3}, ".netids.example.com"))
+}
+
+func TestServerToURL(t *testing.T) {
+	assert := require.New(t)
+
+	tests := []struct {
+		Server   string
+		Secure   bool
+		Port     int
+		Expected string
+	}{
+		{
+			Server:   "example.com",
+			Secure:   false,
+			Port:     80,
+			Expected: "http://example.com:80/",
+		},
+		{
+			Server:   "example.com",
+			Secure:   true,
+			Port:     443,
+			Expected: "https://example.com:443/",
+		},
+	}
+
+	for _, tst := range tests {
+		assert.Equal(tst.Expected, ServerToURL(tst.Server, tst.Secure, tst.Port))
+	}
+}
+
+func TestResolver(t *testing.T) {
+	assert := require.New(t)
+
+	var lookups []string
+	hosts := map[string]bool{
+		"010203.netids.example.com": true,
+	}
+
+	r := New(".netids.example.com", ".joineuis.example.com", time.Hour)
+	r.lookupIP = func(host string) ([]net.IP, error) {
+		lookups = append(lookups, host)
+		if hosts[host] {
+			return []net.IP{net.IPv4(127, 0, 0, 1)}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	t.Run("Resolve NetID", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 0; i < 2; i++ {
+			url, err := r.ResolveNetID(lorawan.NetID{1, 2, 3})
+			assert.NoError(err)
+			assert.Equal("https://010203.netids.example.com:443/", url)
+		}
+
+		// the second call is served from the cache
+		assert.Equal([]string{"010203.netids.example.com"}, lookups)
+	})
+
+	t.Run("Resolve JoinEUI failure is cached", func(t *testing.T) {
+		assert := require.New(t)
+		lookups = nil
+
+		for i := 0; i < 2; i++ {
+			_, err := r.ResolveJoinEUI(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			assert.Error(err)
+		}
+		assert.Len(lookups, 1)
+	})
+
+	t.Run("Expired cache", func(t *testing.T) {
+		assert := require.New(t)
+		lookups = nil
+
+		r.mux.Lock()
+		for k, v := range r.cache {
+			v.expiresAt = time.Now()
+			r.cache[k] = v
+		}
+		r.mux.Unlock()
+
+		_, err := r.ResolveNetID(lorawan.NetID{1, 2, 3})
+		assert.NoError(err)
+		assert.Len(lookups, 1)
+	})
+
+	assert.Len(r.cache, 2)
+}
@@ -0,0 +1,199 @@
+package roaming
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/roamingserver"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// IsRoamingDevAddr returns true when the given DevAddr does not belong to
+// the NetID of this network, in which case the device might be a device of
+// a partner network.
+func IsRoamingDevAddr(devAddr lorawan.DevAddr) bool {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	return !devAddrHasNetID(devAddr, ownNetID)
+}
+
+// HandlePassiveRoamingUplink forwards the given data uplink to the partner
+// network, as forwarding network-server (fNS). It returns ErrNoAgreement
+// when there is no passive-roaming agreement matching the DevAddr of the
+// uplink, in which case the uplink must be handled as a regular uplink.
+//
+// Only the stateless passive-roaming mode is supported, in which each uplink
+// is forwarded using a PRStartReq and no passive-roaming session is kept.
+func HandlePassiveRoamingUplink(ctx context.Context, rxPacket models.RXPacket) error {
+	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return errors.New("expected *lorawan.MACPayload")
+	}
+	devAddr := macPL.FHDR.DevAddr
+
+	ra, err := getPassiveRoamingAgreementForDevAddr(ctx, devAddr)
+	if err != nil {
+		return err
+	}
+
+	if !ra.PassiveRoamingStateless {
+		return ErrStatefulPassiveRoaming
+	}
+
+	server, err := getServer(ctx, ra)
+	if err != nil {
+		return errors.Wrap(err, "get server error")
+	}
+
+	client, err := getClient(server)
+	if err != nil {
+		return errors.Wrap(err, "get roaming-server client error")
+	}
+
+	req, err := getPRStartReqPayload(ra.NetID, devAddr, rxPacket)
+	if err != nil {
+		return errors.Wrap(err, "get prstartreq payload error")
+	}
+
+	ans, err := client.PRStartReq(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "prstartreq error")
+	}
+
+	// In the stateless mode, there is no session to which the lifetime
+	// applies. Each uplink results in a new PRStartReq.
+	if ans.Lifetime != nil && *ans.Lifetime != 0 {
+		log.WithFields(log.Fields{
+			"net_id":   ra.NetID,
+			"dev_addr": devAddr,
+			"lifetime": *ans.Lifetime,
+			"ctx_id":   ctx.Value(logging.ContextIDKey),
+		}).Warning("roaming: partner returned non-zero lifetime for stateless passive-roaming, ignoring")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":   ra.NetID,
+		"dev_addr": devAddr,
+		"server":   server,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}).Info("roaming: uplink forwarded to partner network")
+
+	if err := accounting.RoamingUplink(ctx, storage.RoamingRoleFNS, ra.NetID, devAddr, rxPacket); err != nil {
+		return errors.Wrap(err, "create roaming accounting record error")
+	}
+
+	return nil
+}
+
+func getPassiveRoamingAgreementForDevAddr(ctx context.Context, devAddr lorawan.DevAddr) (storage.RoamingAgreement, error) {
+	agreements, err := storage.GetPassiveRoamingAgreements(ctx, storage.DB())
+	if err != nil {
+		return storage.RoamingAgreement{}, errors.Wrap(err, "get passive-roaming agreements error")
+	}
+
+	for _, ra := range agreements {
+		if devAddrHasNetID(devAddr, ra.NetID) {
+			return ra, nil
+		}
+	}
+
+	return storage.RoamingAgreement{}, ErrNoAgreement
+}
+
+func getPRStartReqPayload(receiverNetID lorawan.NetID, devAddr lorawan.DevAddr, rxPacket models.RXPacket) (roamingserver.PRStartReqPayload, error) {
+	var req roamingserver.PRStartReqPayload
+
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return req, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return req, errors.Wrap(err, "read random bytes error")
+	}
+
+	mux.RLock()
+	senderNetID := ownNetID
+	region := rfRegion
+	mux.RUnlock()
+
+	dr := rxPacket.DR
+	freq := float64(rxPacket.TXInfo.Frequency) / 1000000
+	gwCnt := len(rxPacket.RXInfoSet)
+
+	req = roamingserver.PRStartReqPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        senderNetID.String(),
+			ReceiverID:      receiverNetID.String(),
+			TransactionID:   binary.LittleEndian.Uint32(randomBytes),
+			MessageType:     roamingserver.PRStartReq,
+		},
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: roamingserver.ULMetaData{
+			DevAddr:  &devAddr,
+			DataRate: &dr,
+			ULFreq:   &freq,
+			RecvTime: time.Now().UTC().Format(time.RFC3339Nano),
+			RFRegion: region,
+			GWCnt:    &gwCnt,
+		},
+	}
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		rssi := int(rxInfo.Rssi)
+		snr := rxInfo.LoraSnr
+
+		gwInfo := roamingserver.GWInfoElement{
+			ID:       backend.HEXBytes(rxInfo.GatewayId),
+			RFRegion: region,
+			RSSI:     &rssi,
+			SNR:      &snr,
+			// downlinks are not supported in stateless passive-roaming
+			DLAllowed: false,
+		}
+
+		if rxInfo.Location != nil {
+			lat := rxInfo.Location.Latitude
+			lon := rxInfo.Location.Longitude
+			gwInfo.Lat = &lat
+			gwInfo.Lon = &lon
+		}
+
+		req.ULMetaData.GWInfo = append(req.ULMetaData.GWInfo, gwInfo)
+	}
+
+	return req, nil
+}
+
+// devAddrHasNetID returns true when the given DevAddr has the prefix (type
+// and NwkID) of the given NetID. The prefix mask is obtained by setting the
+// prefix on an all-zeros and an all-ones DevAddr, the bits that are equal
+// belong to the prefix.
+func devAddrHasNetID(devAddr lorawan.DevAddr, netID lorawan.NetID) bool {
+	zeros := lorawan.DevAddr{}
+	ones := lorawan.DevAddr{0xff, 0xff, 0xff, 0xff}
+	zeros.SetAddrPrefix(netID)
+	ones.SetAddrPrefix(netID)
+
+	for i := range devAddr {
+		mask := ^(zeros[i] ^ ones[i])
+		if devAddr[i]&mask != zeros[i]&mask {
+			return false
+		}
+	}
+
+	return true
+}
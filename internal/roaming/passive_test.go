@@ -0,0 +1,119 @@
+package roaming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+)
+
+func TestDevAddrHasNetID(t *testing.T) {
+	tests := []struct {
+		Name     string
+		DevAddr  lorawan.DevAddr
+		NetID    lorawan.NetID
+		Expected bool
+	}{
+		{
+			Name:     "type 0, matching NwkID",
+			DevAddr:  lorawan.DevAddr{0x26, 0x01, 0x02, 0x03},
+			NetID:    lorawan.NetID{0x00, 0x00, 0x13},
+			Expected: true,
+		},
+		{
+			Name:     "type 0, matching NwkID, last NwkAddr bit set",
+			DevAddr:  lorawan.DevAddr{0x27, 0xff, 0xff, 0xff},
+			NetID:    lorawan.NetID{0x00, 0x00, 0x13},
+			Expected: true,
+		},
+		{
+			Name:     "type 0, other NwkID",
+			DevAddr:  lorawan.DevAddr{0x28, 0x01, 0x02, 0x03},
+			NetID:    lorawan.NetID{0x00, 0x00, 0x13},
+			Expected: false,
+		},
+		{
+			Name:     "type 0, NetID 000000",
+			DevAddr:  lorawan.DevAddr{0x01, 0x02, 0x03, 0x04},
+			NetID:    lorawan.NetID{0x00, 0x00, 0x00},
+			Expected: true,
+		},
+		{
+			Name:     "type 0, NetID 000000, other NwkID",
+			DevAddr:  lorawan.DevAddr{0x26, 0x01, 0x02, 0x03},
+			NetID:    lorawan.NetID{0x00, 0x00, 0x00},
+			Expected: false,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, devAddrHasNetID(tst.DevAddr, tst.NetID))
+		})
+	}
+}
+
+func TestGetPRStartReqPayload(t *testing.T) {
+	assert := require.New(t)
+
+	mux.Lock()
+	ownNetID = lorawan.NetID{0x00, 0x00, 0x01}
+	rfRegion = "EU_863_870"
+	mux.Unlock()
+
+	devAddr := lorawan.DevAddr{0x26, 0x01, 0x02, 0x03}
+	rxPacket := models.RXPacket{
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: devAddr,
+				},
+			},
+		},
+		TXInfo: &gw.UplinkTXInfo{
+			Frequency: 868100000,
+		},
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Rssi:      -50,
+				LoraSnr:   5.5,
+				Location: &common.Location{
+					Latitude:  1.123,
+					Longitude: 2.123,
+				},
+			},
+		},
+		DR: 3,
+	}
+
+	req, err := getPRStartReqPayload(lorawan.NetID{0x00, 0x00, 0x13}, devAddr, rxPacket)
+	assert.NoError(err)
+
+	assert.Equal("000001", req.SenderID)
+	assert.Equal("000013", req.ReceiverID)
+	assert.EqualValues("PRStartReq", req.MessageType)
+	assert.Equal(devAddr, *req.ULMetaData.DevAddr)
+	assert.Equal(3, *req.ULMetaData.DataRate)
+	assert.Equal(868.1, *req.ULMetaData.ULFreq)
+	assert.Equal(1, *req.ULMetaData.GWCnt)
+	assert.Equal("EU_863_870", req.ULMetaData.RFRegion)
+	assert.Len(req.ULMetaData.GWInfo, 1)
+
+	gwInfo := req.ULMetaData.GWInfo[0]
+	assert.EqualValues([]byte{1, 2, 3, 4, 5, 6, 7, 8}, gwInfo.ID)
+	assert.Equal(-50, *gwInfo.RSSI)
+	assert.Equal(5.5, *gwInfo.SNR)
+	assert.Equal(1.123, *gwInfo.Lat)
+	assert.Equal(2.123, *gwInfo.Lon)
+	assert.False(gwInfo.DLAllowed)
+}
@@ -0,0 +1,136 @@
+// Package roaming implements the roaming with partner networks, based on
+// the roaming agreements stored in the database.
+package roaming
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/roamingserver"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/resolver"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// Roaming errors.
+var (
+	ErrNoAgreement = errors.New("no roaming agreement for netid")
+	ErrNoServer    = errors.New("no server configured for roaming agreement")
+
+	ErrStatefulPassiveRoaming = errors.New("stateful passive-roaming is not supported")
+)
+
+var (
+	mux          sync.RWMutex
+	resolveNetID bool
+	netIDRes     *resolver.Resolver
+
+	ownNetID lorawan.NetID
+	rfRegion string
+	caCert   string
+	tlsCert  string
+	tlsKey   string
+	clients  map[string]roamingserver.Client
+
+	// newClient is used for creating the roaming-server clients, it can be
+	// overwritten for testing.
+	newClient = roamingserver.NewClient
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	resolveNetID = conf.Roaming.ResolveNetID
+	netIDRes = resolver.New(conf.Roaming.ResolveNetIDDomainSuffix, "", conf.Roaming.ResolveCacheTTL)
+
+	ownNetID = conf.NetworkServer.NetID
+	rfRegion = string(conf.NetworkServer.Band.Name)
+	caCert = conf.Roaming.CACert
+	tlsCert = conf.Roaming.TLSCert
+	tlsKey = conf.Roaming.TLSKey
+	clients = make(map[string]roamingserver.Client)
+
+	return nil
+}
+
+// SetNewClientFunc sets the function used for creating the roaming-server
+// clients. The clients created by the previous function are removed.
+func SetNewClientFunc(f func(server, caCert, tlsCert, tlsKey string) (roamingserver.Client, error)) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	newClient = f
+	clients = make(map[string]roamingserver.Client)
+}
+
+// GetAgreementAndServer returns the roaming agreement for the given NetID
+// and the server of the partner network. When resolving the NetID is
+// enabled, the server is resolved using DNS, falling back on the server of
+// the roaming agreement when resolving fails.
+func GetAgreementAndServer(ctx context.Context, db sqlx.Queryer, netID lorawan.NetID) (storage.RoamingAgreement, string, error) {
+	ra, err := storage.GetRoamingAgreementForNetID(ctx, db, netID)
+	if err != nil {
+		if err == storage.ErrDoesNotExist {
+			return ra, "", ErrNoAgreement
+		}
+		return ra, "", errors.Wrap(err, "get roaming agreement error")
+	}
+
+	server, err := getServer(ctx, ra)
+	return ra, server, err
+}
+
+func getServer(ctx context.Context, ra storage.RoamingAgreement) (string, error) {
+	mux.RLock()
+	res := netIDRes
+	resolve := resolveNetID
+	mux.RUnlock()
+
+	if resolve && res != nil {
+		server, err := res.ResolveNetID(ra.NetID)
+		if err == nil {
+			return server, nil
+		}
+
+		log.WithError(err).WithFields(log.Fields{
+			"net_id": ra.NetID,
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).Warning("roaming: resolving netid failed, using roaming agreement server")
+	}
+
+	if ra.Server == "" {
+		return "", ErrNoServer
+	}
+
+	return ra.Server, nil
+}
+
+// getClient returns the (cached) roaming-server client for the given server.
+func getClient(server string) (roamingserver.Client, error) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if c, ok := clients[server]; ok {
+		return c, nil
+	}
+
+	c, err := newClient(server, caCert, tlsCert, tlsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "new roaming-server client error")
+	}
+
+	if clients == nil {
+		clients = make(map[string]roamingserver.Client)
+	}
+	clients[server] = c
+
+	return c, nil
+}
@@ -0,0 +1,34 @@
+package roaming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/backend/roamingserver"
+)
+
+type testClient struct {
+	roamingserver.Client
+	server string
+}
+
+func TestSetNewClientFunc(t *testing.T) {
+	assert := require.New(t)
+
+	defer SetNewClientFunc(roamingserver.NewClient)
+
+	c, err := getClient("https://ns.example.com")
+	assert.NoError(err)
+	_, ok := c.(*testClient)
+	assert.False(ok)
+
+	SetNewClientFunc(func(server, caCert, tlsCert, tlsKey string) (roamingserver.Client, error) {
+		return &testClient{server: server}, nil
+	})
+
+	// the previously created client has been removed
+	c, err = getClient("https://ns.example.com")
+	assert.NoError(err)
+	assert.Equal(&testClient{server: "https://ns.example.com"}, c)
+}
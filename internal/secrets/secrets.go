@@ -0,0 +1,294 @@
+// Package secrets implements the resolving of configuration values referring
+// to secrets stored in HashiCorp Vault, and the renewal of their leases.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// vaultPrefix is the prefix of the configuration values referring to a Vault
+// secret, e.g. vault:secret/data/loraserver#postgresql_dsn.
+const vaultPrefix = "vault:"
+
+var (
+	// maxRenewalWait defines the max. time between checking the leases for
+	// renewal (e.g. the leases change after a configuration reload).
+	maxRenewalWait = time.Minute
+
+	// refreshRetryInterval defines the time to wait before retrying a
+	// failed refresh.
+	refreshRetryInterval = 30 * time.Second
+)
+
+// Refresher re-reads the secrets, e.g. by reloading the configuration.
+type Refresher func(ctx context.Context) error
+
+type lease struct {
+	id        string
+	token     bool
+	renewable bool
+	renewAt   time.Time
+}
+
+var (
+	mux    sync.Mutex
+	client *vaultClient
+	leases []lease
+)
+
+type reference struct {
+	key   string
+	value reflect.Value
+}
+
+// IsReference returns true when the given configuration value refers to a
+// secret.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, vaultPrefix)
+}
+
+// Resolve replaces the configuration values referring to a secret with the
+// value of the secret. The leases of the read secrets replace the leases of
+// the previous Resolve call.
+func Resolve(ctx context.Context, conf *config.Config) error {
+	var refs []reference
+	collectReferences(reflect.ValueOf(conf).Elem(), nil, &refs)
+
+	if len(refs) == 0 {
+		mux.Lock()
+		client = nil
+		leases = nil
+		mux.Unlock()
+		return nil
+	}
+
+	c, auth, err := newVaultClient(ctx, *conf)
+	if err != nil {
+		return errors.Wrap(err, "new vault client error")
+	}
+
+	var newLeases []lease
+	if auth != nil && auth.LeaseDuration > 0 {
+		newLeases = append(newLeases, newLease("", true, auth.Renewable, auth.LeaseDuration))
+	}
+
+	// each secret is read once, e.g. the certificate and key fields of a
+	// dynamic secret must belong to the same response
+	responses := make(map[string]vaultResponse)
+	for _, ref := range refs {
+		path, field, err := parseReference(ref.value.String())
+		if err != nil {
+			return errors.Wrapf(err, "%s", ref.key)
+		}
+
+		resp, ok := responses[path]
+		if !ok {
+			resp, err = c.read(ctx, path)
+			if err != nil {
+				return errors.Wrapf(err, "%s: read secret %s error", ref.key, path)
+			}
+			responses[path] = resp
+
+			if resp.LeaseDuration > 0 {
+				newLeases = append(newLeases, newLease(resp.LeaseID, false, resp.Renewable, resp.LeaseDuration))
+			}
+		}
+
+		value, err := secretField(resp, field)
+		if err != nil {
+			return errors.Wrapf(err, "%s: secret %s", ref.key, path)
+		}
+		ref.value.SetString(value)
+	}
+
+	mux.Lock()
+	client = c
+	leases = newLeases
+	mux.Unlock()
+
+	log.WithFields(log.Fields{
+		"references": len(refs),
+		"secrets":    len(responses),
+	}).Info("secrets: secrets resolved")
+
+	return nil
+}
+
+// StartRenewal starts renewing the leases of the resolved secrets before they
+// expire. When a lease can not be renewed, refresh is called to read the
+// secrets again.
+func StartRenewal(refresh Refresher) {
+	go func() {
+		for {
+			time.Sleep(nextRenewal())
+			renew(context.Background(), refresh)
+		}
+	}()
+}
+
+func newLease(id string, token, renewable bool, seconds int) lease {
+	return lease{
+		id:        id,
+		token:     token,
+		renewable: renewable,
+		renewAt:   time.Now().Add(renewAfter(time.Duration(seconds) * time.Second)),
+	}
+}
+
+// renewAfter returns the duration after which a lease with the given
+// duration must be renewed.
+func renewAfter(d time.Duration) time.Duration {
+	return d * 2 / 3
+}
+
+func nextRenewal() time.Duration {
+	mux.Lock()
+	defer mux.Unlock()
+
+	wait := maxRenewalWait
+	for _, l := range leases {
+		if d := time.Until(l.renewAt); d < wait {
+			wait = d
+		}
+	}
+
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait
+}
+
+func renew(ctx context.Context, refresh Refresher) {
+	mux.Lock()
+	now := time.Now()
+	var refreshNeeded bool
+
+	for i := range leases {
+		l := &leases[i]
+		if now.Before(l.renewAt) {
+			continue
+		}
+
+		// KV secrets only have a lease duration (as refresh interval)
+		if !l.renewable || (l.id == "" && !l.token) {
+			refreshNeeded = true
+			continue
+		}
+
+		var d time.Duration
+		var err error
+		if l.token {
+			d, err = client.renewToken(ctx)
+		} else {
+			d, err = client.renewLease(ctx, l.id)
+		}
+		if err == nil && d <= 0 {
+			err = errors.New("lease expired")
+		}
+		if err != nil {
+			log.WithError(err).WithField("lease_id", l.id).Warning("secrets: renew lease error")
+			refreshNeeded = true
+			continue
+		}
+
+		l.renewAt = now.Add(renewAfter(d))
+		log.WithFields(log.Fields{
+			"lease_id": l.id,
+			"duration": d,
+		}).Debug("secrets: lease renewed")
+	}
+	mux.Unlock()
+
+	if !refreshNeeded {
+		return
+	}
+
+	log.Info("secrets: refreshing secrets")
+	if err := refresh(ctx); err != nil {
+		log.WithError(err).Error("secrets: refresh secrets error")
+
+		// postpone the due leases, on success these are replaced by Resolve
+		mux.Lock()
+		for i := range leases {
+			if !now.Before(leases[i].renewAt) {
+				leases[i].renewAt = time.Now().Add(refreshRetryInterval)
+			}
+		}
+		mux.Unlock()
+	}
+}
+
+// collectReferences collects the (settable) string values referring to a
+// secret.
+func collectReferences(v reflect.Value, parts []string, out *[]reference) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			name := strings.ToLower(f.Name)
+			if tv, ok := f.Tag.Lookup("mapstructure"); ok {
+				name = tv
+			}
+			collectReferences(v.Field(i), append(parts, name), out)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectReferences(v.Index(i), append(parts, fmt.Sprintf("%d", i)), out)
+		}
+	case reflect.String:
+		if v.CanSet() && IsReference(v.String()) {
+			*out = append(*out, reference{
+				key:   strings.Join(parts, "."),
+				value: v,
+			})
+		}
+	}
+}
+
+// parseReference returns the path and field of the given reference.
+func parseReference(ref string) (string, string, error) {
+	s := strings.TrimPrefix(ref, vaultPrefix)
+	i := strings.LastIndex(s, "#")
+	if i <= 0 || i == len(s)-1 {
+		return "", "", fmt.Errorf("invalid secret reference '%s', expected %s<path>#<field>", ref, vaultPrefix)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// secretField returns the given field of the secret. For KV version 2
+// secrets, the field is looked up in the nested data object.
+func secretField(resp vaultResponse, field string) (string, error) {
+	data := resp.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	v, ok := data[field]
+	if !ok || v == nil {
+		return "", fmt.Errorf("field %s does not exist", field)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+}
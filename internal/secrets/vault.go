@@ -0,0 +1,209 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const vaultRequestTimeout = 10 * time.Second
+
+// vaultClient implements a minimal client for the Vault HTTP API.
+type vaultClient struct {
+	address    string
+	token      string
+	httpClient *http.Client
+}
+
+type vaultResponse struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *vaultAuth             `json:"auth"`
+	Errors        []string               `json:"errors"`
+}
+
+type vaultAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// newVaultClient returns a new authenticated Vault client. The token is read
+// from the token file, the VAULT_TOKEN environment variable or obtained
+// using the AppRole login (in which case the auth response is returned too).
+func newVaultClient(ctx context.Context, conf config.Config) (*vaultClient, *vaultAuth, error) {
+	vc := conf.Secrets.Vault
+
+	address := vc.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, nil, errors.New("vault address is not configured")
+	}
+
+	caCert := vc.CACert
+	if caCert == "" {
+		caCert = os.Getenv("VAULT_CACERT")
+	}
+
+	c := vaultClient{
+		address: strings.TrimSuffix(address, "/"),
+		httpClient: &http.Client{
+			Timeout: vaultRequestTimeout,
+		},
+	}
+
+	if caCert != "" {
+		rawCACert, err := nstls.ReadPEM(caCert)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "load vault ca cert error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCACert) {
+			return nil, nil, errors.New("append vault ca cert to pool error")
+		}
+
+		c.httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: caCertPool,
+			},
+		}
+	}
+
+	switch {
+	case vc.TokenFile != "":
+		b, err := ioutil.ReadFile(vc.TokenFile)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "read vault token file error")
+		}
+		c.token = strings.TrimSpace(string(b))
+	case os.Getenv("VAULT_TOKEN") != "":
+		c.token = os.Getenv("VAULT_TOKEN")
+	case vc.AppRole.RoleID != "":
+		auth, err := c.appRoleLogin(ctx, vc.AppRole.MountPath, vc.AppRole.RoleID, vc.AppRole.SecretIDFile)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "vault approle login error")
+		}
+		c.token = auth.ClientToken
+		return &c, auth, nil
+	default:
+		return nil, nil, errors.New("vault token is not configured")
+	}
+
+	return &c, nil, nil
+}
+
+func (c *vaultClient) appRoleLogin(ctx context.Context, mountPath, roleID, secretIDFile string) (*vaultAuth, error) {
+	var secretID string
+	if secretIDFile != "" {
+		b, err := ioutil.ReadFile(secretIDFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read secret id file error")
+		}
+		secretID = strings.TrimSpace(string(b))
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("auth/%s/login", strings.Trim(mountPath, "/")), map[string]string{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return nil, errors.New("no client token returned")
+	}
+
+	return resp.Auth, nil
+}
+
+// read reads the secret at the given path.
+func (c *vaultClient) read(ctx context.Context, path string) (vaultResponse, error) {
+	return c.do(ctx, http.MethodGet, path, nil)
+}
+
+// renewLease renews the given lease and returns the new lease duration.
+func (c *vaultClient) renewLease(ctx context.Context, leaseID string) (time.Duration, error) {
+	resp, err := c.do(ctx, http.MethodPut, "sys/leases/renew", map[string]string{
+		"lease_id": leaseID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// renewToken renews the client token and returns the new lease duration.
+func (c *vaultClient) renewToken(ctx context.Context) (time.Duration, error) {
+	resp, err := c.do(ctx, http.MethodPost, "auth/token/renew-self", nil)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Auth == nil {
+		return 0, errors.New("no auth returned")
+	}
+	return time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
+
+func (c *vaultClient) do(ctx context.Context, method, path string, body interface{}) (vaultResponse, error) {
+	var out vaultResponse
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return out, errors.Wrap(err, "marshal request error")
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.address+"/v1/"+strings.TrimPrefix(path, "/"), r)
+	if err != nil {
+		return out, errors.Wrap(err, "new request error")
+	}
+	req = req.WithContext(ctx)
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return out, errors.Wrap(err, "http request error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return out, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil && err != io.EOF {
+		return out, errors.Wrap(err, "decode response error")
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return out, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(out.Errors, ", "))
+	}
+
+	return out, nil
+}
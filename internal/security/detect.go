@@ -0,0 +1,107 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// GatewaySource returns the IDs of the gateways which received the given
+// packet, to be used as event source.
+func GatewaySource(rxPacket models.RXPacket) string {
+	var ids []string
+	for _, rx := range rxPacket.RXInfoSet {
+		var id lorawan.EUI64
+		copy(id[:], rx.GatewayId)
+		ids = append(ids, id.String())
+	}
+	return strings.Join(ids, ",")
+}
+
+// MICFailure registers an uplink for which no device-session could be found
+// with a valid frame-counter and MIC. When the configured threshold is
+// reached within the window, a mic_failure_burst event is emitted.
+func MICFailure(ctx context.Context, p storage.RedisClient, devAddr lorawan.DevAddr, source string) {
+	mux.RLock()
+	threshold := micFailureBurst
+	mux.RUnlock()
+
+	ok, err := countOccurrence(p, MICFailureBurst, devAddr.String(), threshold)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_addr": devAddr,
+			"ctx_id":   ctx.Value(logging.ContextIDKey),
+		}).Error("security: count mic failure error")
+		return
+	}
+	if !ok {
+		return
+	}
+
+	Emit(ctx, p, Event{
+		Type:     MICFailureBurst,
+		Severity: SeverityMedium,
+		DevAddr:  devAddr,
+		Source:   source,
+		Message:  fmt.Sprintf("%d mic or frame-counter failures within %s", threshold.Threshold, threshold.Window),
+	})
+}
+
+// JoinRequest registers a join-request for the given DevEUI. When the
+// configured threshold is reached within the window, a join_flood event is
+// emitted.
+func JoinRequest(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, source string) {
+	mux.RLock()
+	threshold := joinFlood
+	mux.RUnlock()
+
+	ok, err := countOccurrence(p, JoinFlood, devEUI.String(), threshold)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("security: count join-request error")
+		return
+	}
+	if !ok {
+		return
+	}
+
+	Emit(ctx, p, Event{
+		Type:     JoinFlood,
+		Severity: SeverityMedium,
+		DevEUI:   devEUI,
+		Source:   source,
+		Message:  fmt.Sprintf("%d join-requests within %s", threshold.Threshold, threshold.Window),
+	})
+}
+
+// DevNonceReuse emits a dev_nonce_replay event for a join-request re-using
+// an already used DevNonce.
+func DevNonceReuse(ctx context.Context, p storage.RedisClient, joinEUI, devEUI lorawan.EUI64, devNonce lorawan.DevNonce, source string) {
+	Emit(ctx, p, Event{
+		Type:     DevNonceReplay,
+		Severity: SeverityHigh,
+		DevEUI:   devEUI,
+		Source:   source,
+		Message:  fmt.Sprintf("dev-nonce %d has already been used for join_eui %s", devNonce, joinEUI),
+	})
+}
+
+// AuthFailure emits an api_auth_failure event for a failed authentication
+// (e.g. a missing or invalid client-certificate) by the given remote address.
+func AuthFailure(ctx context.Context, p storage.RedisClient, remoteAddr string, err error) {
+	Emit(ctx, p, Event{
+		Type:     APIAuthFailure,
+		Severity: SeverityLow,
+		Source:   remoteAddr,
+		Message:  fmt.Sprintf("api authentication failed: %s", err),
+	})
+}
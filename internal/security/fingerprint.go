@@ -0,0 +1,303 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	fingerprintKeyTempl = "lora:ns:device:{%s}:fingerprint"
+	fingerprintTTL      = 30 * 24 * time.Hour
+
+	// fingerprintMinUplinks defines the number of uplinks from which the
+	// fingerprint is learned before uplinks are checked against it.
+	fingerprintMinUplinks = 20
+
+	// fingerprintMaxGateways defines the max. number of gateways kept in the
+	// fingerprint. When exceeded, the least seen gateway is removed.
+	fingerprintMaxGateways = 32
+
+	// fingerprintAlpha defines the weight of a new uplink in the moving
+	// averages.
+	fingerprintAlpha = 0.05
+
+	// fingerprintMinRSSIStdDev and fingerprintMinSNRStdDev define the min.
+	// standard deviation used for the deviation scores, so that a very
+	// stable device does not raise an event on a small fluctuation.
+	fingerprintMinRSSIStdDev = 4.0
+	fingerprintMinSNRStdDev  = 2.0
+)
+
+// Fingerprint contains the RF fingerprint of a device, learned from the
+// uplinks with a valid MIC.
+type Fingerprint struct {
+	Uplinks      int
+	Gateways     map[lorawan.EUI64]int
+	RSSIMean     float64
+	RSSIVar      float64
+	SNRMean      float64
+	SNRVar       float64
+	IntervalMean float64 // seconds
+	LastUplinkAt time.Time
+}
+
+// FingerprintScore contains the deviation of an uplink from the fingerprint,
+// per characteristic.
+type FingerprintScore struct {
+	Gateways float64 // 0 - 2, 2 when none of the gateways are known
+	RSSI     float64 // 0 - 2
+	SNR      float64 // 0 - 2
+	Timing   float64 // 0 or 1
+}
+
+// Total returns the total score.
+func (s FingerprintScore) Total() float64 {
+	return s.Gateways + s.RSSI + s.SNR + s.Timing
+}
+
+// fingerprintThresholds defines the total score threshold per sensitivity.
+var fingerprintThresholds = map[storage.FingerprintSensitivity]float64{
+	storage.FingerprintSensitivityLow:    5,
+	storage.FingerprintSensitivityMedium: 4,
+	storage.FingerprintSensitivityHigh:   3,
+}
+
+// Score returns the deviation of the given uplink from the fingerprint.
+func (f Fingerprint) Score(rxPacket models.RXPacket, t time.Time) FingerprintScore {
+	var s FingerprintScore
+
+	rssi, snr, gatewayIDs := uplinkRFMetrics(rxPacket)
+	if len(gatewayIDs) == 0 {
+		return s
+	}
+
+	var unknown int
+	for _, id := range gatewayIDs {
+		if _, ok := f.Gateways[id]; !ok {
+			unknown++
+		}
+	}
+	s.Gateways = 2 * float64(unknown) / float64(len(gatewayIDs))
+
+	s.RSSI = deviationScore(rssi, f.RSSIMean, f.RSSIVar, fingerprintMinRSSIStdDev)
+	s.SNR = deviationScore(snr, f.SNRMean, f.SNRVar, fingerprintMinSNRStdDev)
+
+	// an uplink received much faster than usual could indicate a second
+	// device using the same session
+	if f.IntervalMean > 0 && !f.LastUplinkAt.IsZero() {
+		if t.Sub(f.LastUplinkAt).Seconds() < f.IntervalMean/10 {
+			s.Timing = 1
+		}
+	}
+
+	return s
+}
+
+// Update updates the fingerprint with the given uplink.
+func (f *Fingerprint) Update(rxPacket models.RXPacket, t time.Time) {
+	rssi, snr, gatewayIDs := uplinkRFMetrics(rxPacket)
+	if len(gatewayIDs) == 0 {
+		return
+	}
+
+	if f.Gateways == nil {
+		f.Gateways = make(map[lorawan.EUI64]int)
+	}
+	for _, id := range gatewayIDs {
+		f.Gateways[id]++
+	}
+	for len(f.Gateways) > fingerprintMaxGateways {
+		var minID lorawan.EUI64
+		minCount := -1
+		for id, count := range f.Gateways {
+			if minCount == -1 || count < minCount {
+				minID = id
+				minCount = count
+			}
+		}
+		delete(f.Gateways, minID)
+	}
+
+	if f.Uplinks == 0 {
+		f.RSSIMean = rssi
+		f.SNRMean = snr
+	} else {
+		f.RSSIMean, f.RSSIVar = updateMovingStats(f.RSSIMean, f.RSSIVar, rssi)
+		f.SNRMean, f.SNRVar = updateMovingStats(f.SNRMean, f.SNRVar, snr)
+	}
+
+	if !f.LastUplinkAt.IsZero() && t.After(f.LastUplinkAt) {
+		interval := t.Sub(f.LastUplinkAt).Seconds()
+		if f.IntervalMean == 0 {
+			f.IntervalMean = interval
+		} else {
+			f.IntervalMean += fingerprintAlpha * (interval - f.IntervalMean)
+		}
+	}
+
+	f.LastUplinkAt = t
+	f.Uplinks++
+}
+
+// GetFingerprint returns the fingerprint of the given device. When no
+// fingerprint exists, an empty fingerprint is returned.
+func GetFingerprint(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64) (Fingerprint, error) {
+	var f Fingerprint
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(fingerprintKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return f, nil
+		}
+		return f, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&f); err != nil {
+		return f, errors.Wrap(err, "gob decode error")
+	}
+
+	return f, nil
+}
+
+// SaveFingerprint saves the fingerprint of the given device.
+func SaveFingerprint(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, f Fingerprint) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(f); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(fingerprintKeyTempl, devEUI), int64(fingerprintTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+// DeleteFingerprint deletes the fingerprint of the given device, e.g. after
+// the device has been relocated.
+func DeleteFingerprint(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(fingerprintKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "del error")
+	}
+
+	return nil
+}
+
+// UplinkFingerprint checks the given uplink (with a valid MIC) against the
+// fingerprint of the device and updates the fingerprint. When the uplink
+// deviates from the fingerprint more than allowed by the given sensitivity,
+// an uplink_fingerprint_mismatch event is emitted. Errors are logged.
+func UplinkFingerprint(ctx context.Context, p storage.RedisClient, devEUI lorawan.EUI64, sensitivity storage.FingerprintSensitivity, rxPacket models.RXPacket) {
+	threshold, ok := fingerprintThresholds[sensitivity]
+	if !ok {
+		return
+	}
+
+	logFields := log.Fields{
+		"dev_eui": devEUI,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}
+
+	f, err := GetFingerprint(ctx, p, devEUI)
+	if err != nil {
+		log.WithError(err).WithFields(logFields).Error("security: get fingerprint error")
+		return
+	}
+
+	now := time.Now()
+
+	if f.Uplinks >= fingerprintMinUplinks {
+		if score := f.Score(rxPacket, now); score.Total() >= threshold {
+			Emit(ctx, p, Event{
+				Type:     UplinkFingerprintMismatch,
+				Severity: SeverityHigh,
+				DevEUI:   devEUI,
+				Source:   GatewaySource(rxPacket),
+				Message:  fmt.Sprintf("uplink does not match device fingerprint (%s)", score),
+			})
+		}
+	}
+
+	f.Update(rxPacket, now)
+
+	if err := SaveFingerprint(ctx, p, devEUI, f); err != nil {
+		log.WithError(err).WithFields(logFields).Error("security: save fingerprint error")
+	}
+}
+
+// String implements fmt.Stringer.
+func (s FingerprintScore) String() string {
+	var parts []string
+	for _, v := range []struct {
+		name  string
+		value float64
+	}{
+		{"gateways", s.Gateways},
+		{"rssi", s.RSSI},
+		{"snr", s.SNR},
+		{"timing", s.Timing},
+	} {
+		parts = append(parts, fmt.Sprintf("%s: %.1f", v.name, v.value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// uplinkRFMetrics returns the best RSSI, the best SNR and the IDs of the
+// gateways receiving the uplink.
+func uplinkRFMetrics(rxPacket models.RXPacket) (float64, float64, []lorawan.EUI64) {
+	var gatewayIDs []lorawan.EUI64
+	rssi := math.Inf(-1)
+	snr := math.Inf(-1)
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		var id lorawan.EUI64
+		copy(id[:], rxInfo.GatewayId)
+		gatewayIDs = append(gatewayIDs, id)
+
+		rssi = math.Max(rssi, float64(rxInfo.Rssi))
+		snr = math.Max(snr, rxInfo.LoraSnr)
+	}
+
+	return rssi, snr, gatewayIDs
+}
+
+// deviationScore returns the deviation of the given value from the mean in
+// standard deviations, divided by 3 and capped at 2.
+func deviationScore(value, mean, variance, minStdDev float64) float64 {
+	stdDev := math.Max(math.Sqrt(variance), minStdDev)
+	return math.Min(math.Abs(value-mean)/stdDev/3, 2)
+}
+
+// updateMovingStats updates the exponentially weighted moving mean and
+// variance with the given value.
+func updateMovingStats(mean, variance, value float64) (float64, float64) {
+	diff := value - mean
+	incr := fingerprintAlpha * diff
+	mean += incr
+	variance = (1 - fingerprintAlpha) * (variance + diff*incr)
+	return mean, variance
+}
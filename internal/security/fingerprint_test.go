@@ -0,0 +1,154 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+func fingerprintRXPacket(gatewayID lorawan.EUI64, rssi int32, snr float64) models.RXPacket {
+	return models.RXPacket{
+		RXInfoSet: []*gw.UplinkRXInfo{
+			{
+				GatewayId: gatewayID[:],
+				Rssi:      rssi,
+				LoraSnr:   snr,
+			},
+		},
+	}
+}
+
+func (ts *SecurityTestSuite) TestUplinkFingerprint() {
+	ctx := context.Background()
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	knownGatewayID := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	otherGatewayID := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	learn := func(assert *require.Assertions, sensitivity storage.FingerprintSensitivity) {
+		for i := 0; i < fingerprintMinUplinks; i++ {
+			UplinkFingerprint(ctx, storage.RedisPool(), devEUI, sensitivity, fingerprintRXPacket(knownGatewayID, -50, 7))
+		}
+
+		f, err := GetFingerprint(ctx, storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(fingerprintMinUplinks, f.Uplinks)
+		assert.Equal(map[lorawan.EUI64]int{knownGatewayID: fingerprintMinUplinks}, f.Gateways)
+		assert.InDelta(-50, f.RSSIMean, 0.001)
+		assert.InDelta(7, f.SNRMean, 0.001)
+	}
+
+	ts.T().Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+
+		UplinkFingerprint(ctx, storage.RedisPool(), devEUI, storage.FingerprintSensitivityDisabled, fingerprintRXPacket(knownGatewayID, -50, 7))
+
+		f, err := GetFingerprint(ctx, storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(0, f.Uplinks)
+	})
+
+	ts.T().Run("Matching uplink", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+
+		learn(assert, storage.FingerprintSensitivityHigh)
+		UplinkFingerprint(ctx, storage.RedisPool(), devEUI, storage.FingerprintSensitivityHigh, fingerprintRXPacket(knownGatewayID, -52, 6))
+
+		events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+		assert.NoError(err)
+		assert.Len(events, 0)
+	})
+
+	ts.T().Run("Mismatching uplink", func(t *testing.T) {
+		assert := require.New(t)
+		ts.SetupTest()
+
+		learn(assert, storage.FingerprintSensitivityMedium)
+		UplinkFingerprint(ctx, storage.RedisPool(), devEUI, storage.FingerprintSensitivityMedium, fingerprintRXPacket(otherGatewayID, -120, -15))
+
+		events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+		assert.NoError(err)
+		assert.Len(events, 1)
+		assert.Equal(UplinkFingerprintMismatch, events[0].Type)
+		assert.Equal(SeverityHigh, events[0].Severity)
+		assert.Equal(devEUI, events[0].DevEUI)
+		assert.Equal(otherGatewayID.String(), events[0].Source)
+
+		f, err := GetFingerprint(ctx, storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(fingerprintMinUplinks+1, f.Uplinks)
+		assert.Equal(1, f.Gateways[otherGatewayID])
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeleteFingerprint(ctx, storage.RedisPool(), devEUI))
+
+		f, err := GetFingerprint(ctx, storage.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(Fingerprint{}, f)
+	})
+}
+
+func TestFingerprintScore(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	start := time.Now()
+
+	var f Fingerprint
+	for i := 0; i < fingerprintMinUplinks; i++ {
+		f.Update(fingerprintRXPacket(gatewayID, -80, 5), start.Add(time.Duration(i)*10*time.Minute))
+	}
+	last := f.LastUplinkAt
+
+	tests := []struct {
+		Name     string
+		RXPacket models.RXPacket
+		Time     time.Time
+		Expected FingerprintScore
+	}{
+		{
+			Name:     "matching",
+			RXPacket: fingerprintRXPacket(gatewayID, -80, 5),
+			Time:     last.Add(10 * time.Minute),
+		},
+		{
+			Name:     "unknown gateway",
+			RXPacket: fingerprintRXPacket(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, -80, 5),
+			Time:     last.Add(10 * time.Minute),
+			Expected: FingerprintScore{Gateways: 2},
+		},
+		{
+			Name:     "rssi and snr deviation",
+			RXPacket: fingerprintRXPacket(gatewayID, -92, 11),
+			Time:     last.Add(10 * time.Minute),
+			Expected: FingerprintScore{RSSI: 1, SNR: 1},
+		},
+		{
+			Name:     "unexpected interval",
+			RXPacket: fingerprintRXPacket(gatewayID, -80, 5),
+			Time:     last.Add(time.Second),
+			Expected: FingerprintScore{Timing: 1},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			score := f.Score(tst.RXPacket, tst.Time)
+			assert.InDelta(tst.Expected.Gateways, score.Gateways, 0.001)
+			assert.InDelta(tst.Expected.RSSI, score.RSSI, 0.001)
+			assert.InDelta(tst.Expected.SNR, score.SNR, 0.001)
+			assert.InDelta(tst.Expected.Timing, score.Timing, 0.001)
+		})
+	}
+}
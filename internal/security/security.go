@@ -0,0 +1,290 @@
+// Package security implements the security-event pipeline. Security related
+// occurrences (e.g. MIC failure bursts, DevNonce replays, join floods and API
+// authentication failures) are emitted as events with a severity. Emitted
+// events are rate-limited, stored in Redis so that these can be queried
+// through the API and forwarded to the configured sinks (syslog and / or
+// webhook).
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+const (
+	eventsKey           = "lora:ns:security:events"
+	rateLimitKeyTempl   = "lora:ns:security:rl:%s:%s"
+	occurrenceKeyTempl  = "lora:ns:security:count:%s:%s"
+	defaultEventsMaxLen = 1000
+)
+
+// Severity defines the event severity.
+type Severity int
+
+// Available severities.
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// EventType defines the event type.
+type EventType string
+
+// Available event types.
+const (
+	MICFailureBurst           EventType = "mic_failure_burst"
+	DevNonceReplay            EventType = "dev_nonce_replay"
+	JoinFlood                 EventType = "join_flood"
+	APIAuthFailure            EventType = "api_auth_failure"
+	UplinkFingerprintMismatch EventType = "uplink_fingerprint_mismatch"
+)
+
+// Event defines a security event.
+type Event struct {
+	Time     time.Time       `json:"time"`
+	Type     EventType       `json:"type"`
+	Severity Severity        `json:"severity"`
+	DevEUI   lorawan.EUI64   `json:"devEUI"`
+	DevAddr  lorawan.DevAddr `json:"devAddr"`
+	Source   string          `json:"source"`
+	Message  string          `json:"message"`
+}
+
+// key returns the subject of the event, used for rate-limiting.
+func (e Event) key() string {
+	if e.DevEUI != (lorawan.EUI64{}) {
+		return e.DevEUI.String()
+	}
+	if e.DevAddr != (lorawan.DevAddr{}) {
+		return e.DevAddr.String()
+	}
+	return e.Source
+}
+
+// Filter defines the filters used for querying the stored events.
+type Filter struct {
+	MinSeverity Severity
+	DevEUI      lorawan.EUI64
+	Limit       int
+}
+
+// sink defines the interface of an event sink.
+type sink interface {
+	Send(ctx context.Context, e Event) error
+}
+
+var (
+	mux               sync.RWMutex
+	maxEvents         int
+	rateLimitInterval time.Duration
+	micFailureBurst   config.SecurityThreshold
+	joinFlood         config.SecurityThreshold
+	sinks             []sink
+)
+
+// Setup configures the package.
+func Setup(conf config.Config) error {
+	c := conf.Security
+
+	var s []sink
+	if c.Syslog.Enabled {
+		ss, err := newSyslogSink(c.Syslog.Network, c.Syslog.Address, c.Syslog.Tag)
+		if err != nil {
+			return errors.Wrap(err, "new syslog sink error")
+		}
+		s = append(s, ss)
+	}
+	if c.Webhook.URL != "" {
+		s = append(s, newWebhookSink(c.Webhook.URL, c.Webhook.Secret))
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	maxEvents = c.MaxEvents
+	rateLimitInterval = c.RateLimitInterval
+	micFailureBurst = c.MICFailureBurst
+	joinFlood = c.JoinFlood
+	sinks = s
+
+	return nil
+}
+
+// Emit emits the given event, unless an event of the same type for the same
+// subject (DevEUI, DevAddr or source) has been emitted within the configured
+// rate-limit interval. Errors are logged.
+func Emit(ctx context.Context, p storage.RedisClient, e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	logFields := log.Fields{
+		"type":     e.Type,
+		"severity": e.Severity,
+		"dev_eui":  e.DevEUI,
+		"dev_addr": e.DevAddr,
+		"source":   e.Source,
+		"ctx_id":   ctx.Value(logging.ContextIDKey),
+	}
+
+	mux.RLock()
+	interval := rateLimitInterval
+	max := maxEvents
+	s := sinks
+	mux.RUnlock()
+
+	if interval > 0 {
+		ok, err := acquireRateLimit(p, e, interval)
+		if err != nil {
+			log.WithError(err).WithFields(logFields).Error("security: acquire rate-limit error")
+			return
+		}
+		if !ok {
+			log.WithFields(logFields).Debug("security: event rate-limited")
+			return
+		}
+	}
+
+	log.WithFields(logFields).WithField("message", e.Message).Warning("security: event emitted")
+
+	if err := storeEvent(p, e, max); err != nil {
+		log.WithError(err).WithFields(logFields).Error("security: store event error")
+	}
+
+	for _, sink := range s {
+		if err := sink.Send(ctx, e); err != nil {
+			log.WithError(err).WithFields(logFields).Error("security: send event to sink error")
+		}
+	}
+}
+
+// GetEvents returns the stored events matching the given filter, the most
+// recent event first.
+func GetEvents(ctx context.Context, p storage.RedisClient, filter Filter) ([]Event, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", eventsKey, 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "read events error")
+	}
+
+	var out []Event
+	for _, b := range values {
+		var e Event
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, errors.Wrap(err, "unmarshal event error")
+		}
+
+		if e.Severity < filter.MinSeverity {
+			continue
+		}
+		if filter.DevEUI != (lorawan.EUI64{}) && filter.DevEUI != e.DevEUI {
+			continue
+		}
+
+		out = append(out, e)
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// acquireRateLimit returns true when the event may be emitted.
+func acquireRateLimit(p storage.RedisClient, e Event, interval time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(rateLimitKeyTempl, e.Type, e.key())
+	_, err := redis.String(c.Do("SET", key, "1", "PX", int64(interval/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "set rate-limit key error")
+	}
+
+	return true, nil
+}
+
+// storeEvent stores the given event, keeping the given max. number of events.
+func storeEvent(p storage.RedisClient, e Event, max int) error {
+	if max <= 0 {
+		max = defaultEventsMaxLen
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", eventsKey, b)
+	c.Send("LTRIM", eventsKey, 0, max-1)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// countOccurrence increments the number of occurrences within the window of
+// the given threshold and returns true when the threshold has been reached.
+func countOccurrence(p storage.RedisClient, t EventType, key string, threshold config.SecurityThreshold) (bool, error) {
+	if threshold.Threshold <= 0 || threshold.Window <= 0 {
+		return false, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	k := fmt.Sprintf(occurrenceKeyTempl, t, key)
+
+	count, err := redis.Int(c.Do("INCR", k))
+	if err != nil {
+		return false, errors.Wrap(err, "increment count error")
+	}
+
+	// the window starts at the first occurrence
+	if count == 1 {
+		if _, err := c.Do("PEXPIRE", k, int64(threshold.Window/time.Millisecond)); err != nil {
+			return false, errors.Wrap(err, "set count expire error")
+		}
+	}
+
+	return count == threshold.Threshold, nil
+}
@@ -0,0 +1,153 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
+)
+
+type SecurityTestSuite struct {
+	suite.Suite
+}
+
+func (ts *SecurityTestSuite) SetupSuite() {
+	assert := require.New(ts.T())
+	conf := test.GetConfig()
+	assert.NoError(storage.Setup(conf))
+
+	conf.Security.RateLimitInterval = time.Minute
+	conf.Security.MaxEvents = 10
+	conf.Security.MICFailureBurst.Threshold = 3
+	conf.Security.MICFailureBurst.Window = time.Minute
+	conf.Security.JoinFlood.Threshold = 2
+	conf.Security.JoinFlood.Window = time.Minute
+	assert.NoError(Setup(conf))
+}
+
+func (ts *SecurityTestSuite) SetupTest() {
+	test.MustFlushRedis(storage.RedisPool())
+}
+
+func (ts *SecurityTestSuite) TestEmit() {
+	ctx := context.Background()
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Rate-limited", func(t *testing.T) {
+		assert := require.New(t)
+
+		DevNonceReuse(ctx, storage.RedisPool(), lorawan.EUI64{}, devEUI, 1, "0102030405060708")
+		DevNonceReuse(ctx, storage.RedisPool(), lorawan.EUI64{}, devEUI, 2, "0102030405060708")
+
+		events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+		assert.NoError(err)
+		assert.Len(events, 1)
+		assert.Equal(DevNonceReplay, events[0].Type)
+		assert.Equal(SeverityHigh, events[0].Severity)
+		assert.Equal(devEUI, events[0].DevEUI)
+	})
+
+	ts.T().Run("Filter", func(t *testing.T) {
+		assert := require.New(t)
+
+		AuthFailure(ctx, storage.RedisPool(), "127.0.0.1", context.Canceled)
+
+		events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+		assert.NoError(err)
+		assert.Len(events, 2)
+		assert.Equal(APIAuthFailure, events[0].Type)
+
+		events, err = GetEvents(ctx, storage.RedisPool(), Filter{MinSeverity: SeverityHigh})
+		assert.NoError(err)
+		assert.Len(events, 1)
+		assert.Equal(DevNonceReplay, events[0].Type)
+
+		events, err = GetEvents(ctx, storage.RedisPool(), Filter{DevEUI: devEUI})
+		assert.NoError(err)
+		assert.Len(events, 1)
+
+		events, err = GetEvents(ctx, storage.RedisPool(), Filter{Limit: 1})
+		assert.NoError(err)
+		assert.Len(events, 1)
+		assert.Equal(APIAuthFailure, events[0].Type)
+	})
+
+	ts.T().Run("Max events", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 0; i < 20; i++ {
+			Emit(ctx, storage.RedisPool(), Event{
+				Type:   APIAuthFailure,
+				Source: string(rune('a' + i)),
+			})
+		}
+
+		events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+		assert.NoError(err)
+		assert.Len(events, 10)
+	})
+}
+
+func (ts *SecurityTestSuite) TestMICFailure() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	devAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+	for i := 0; i < 2; i++ {
+		MICFailure(ctx, storage.RedisPool(), devAddr, "0102030405060708")
+	}
+
+	events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+	assert.NoError(err)
+	assert.Len(events, 0)
+
+	MICFailure(ctx, storage.RedisPool(), devAddr, "0102030405060708")
+
+	events, err = GetEvents(ctx, storage.RedisPool(), Filter{})
+	assert.NoError(err)
+	assert.Len(events, 1)
+	assert.Equal(MICFailureBurst, events[0].Type)
+	assert.Equal(devAddr, events[0].DevAddr)
+}
+
+func (ts *SecurityTestSuite) TestJoinRequest() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	JoinRequest(ctx, storage.RedisPool(), devEUI, "")
+	JoinRequest(ctx, storage.RedisPool(), devEUI, "")
+
+	events, err := GetEvents(ctx, storage.RedisPool(), Filter{})
+	assert.NoError(err)
+	assert.Len(events, 1)
+	assert.Equal(JoinFlood, events[0].Type)
+}
+
+func TestSecurity(t *testing.T) {
+	suite.Run(t, new(SecurityTestSuite))
+}
+
+func TestFormatCEF(t *testing.T) {
+	assert := require.New(t)
+
+	e := Event{
+		Time:     time.Unix(1, 0),
+		Type:     DevNonceReplay,
+		Severity: SeverityHigh,
+		DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		Source:   "0807060504030201",
+		Message:  "dev-nonce a=b",
+	}
+
+	assert.Equal(`CEF:0|MXC|LoRa Server||dev_nonce_replay|dev nonce replay|8|rt=1000 msg=dev-nonce a\=b src=0807060504030201 deviceExternalId=0102030405060708`, formatCEF(e))
+}
@@ -0,0 +1,101 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+var cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+var cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+
+// cefSeverity maps the severity to the CEF (0 - 10) severity.
+var cefSeverity = map[Severity]int{
+	SeverityLow:      3,
+	SeverityMedium:   5,
+	SeverityHigh:     8,
+	SeverityCritical: 10,
+}
+
+// formatCEF returns the event in the ArcSight Common Event Format.
+func formatCEF(e Event) string {
+	ext := []string{
+		fmt.Sprintf("rt=%d", e.Time.UnixNano()/1e6),
+		"msg=" + cefExtensionEscaper.Replace(e.Message),
+	}
+	if e.Source != "" {
+		ext = append(ext, "src="+cefExtensionEscaper.Replace(e.Source))
+	}
+	if e.DevEUI != (lorawan.EUI64{}) {
+		ext = append(ext, "deviceExternalId="+e.DevEUI.String())
+	}
+	if e.DevAddr != (lorawan.DevAddr{}) {
+		ext = append(ext, "cs1Label=devAddr", "cs1="+e.DevAddr.String())
+	}
+
+	return fmt.Sprintf("CEF:0|MXC|LoRa Server|%s|%s|%s|%d|%s",
+		cefHeaderEscaper.Replace(config.Version),
+		cefHeaderEscaper.Replace(string(e.Type)),
+		cefHeaderEscaper.Replace(strings.Replace(string(e.Type), "_", " ", -1)),
+		cefSeverity[e.Severity],
+		strings.Join(ext, " "),
+	)
+}
+
+// syslogSink writes the events in CEF format to syslog.
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+// newSyslogSink creates a new syslog sink. When network and address are
+// empty, the local syslog daemon is used.
+func newSyslogSink(network, address, tag string) (*syslogSink, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_WARNING|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial syslog error")
+	}
+
+	return &syslogSink{w: w}, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, e Event) error {
+	msg := formatCEF(e)
+
+	switch e.Severity {
+	case SeverityLow:
+		return s.w.Notice(msg)
+	case SeverityMedium:
+		return s.w.Warning(msg)
+	case SeverityHigh:
+		return s.w.Err(msg)
+	default:
+		return s.w.Crit(msg)
+	}
+}
+
+// webhookSink posts the events (JSON encoded) to a webhook endpoint.
+type webhookSink struct {
+	endpoint webhook.Endpoint
+}
+
+func newWebhookSink(url, secret string) *webhookSink {
+	return &webhookSink{
+		endpoint: webhook.Endpoint{
+			URL:    url,
+			Secret: secret,
+		},
+	}
+}
+
+func (s *webhookSink) Send(ctx context.Context, e Event) error {
+	// the request is sent (and retried) asynchronously
+	webhook.Send(ctx, s.endpoint, webhook.EventSecurity, e)
+	return nil
+}
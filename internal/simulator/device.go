@@ -0,0 +1,246 @@
+package simulator
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+// device implements a virtual LoRaWAN 1.0.x device.
+type device struct {
+	sync.Mutex
+
+	devEUI  lorawan.EUI64
+	joinEUI lorawan.EUI64
+	appKey  lorawan.AES128Key
+	otaa    bool
+
+	// pending join-request
+	devNonce    lorawan.DevNonce
+	joinPending bool
+	joinedC     chan struct{}
+
+	// session
+	joined   bool
+	devAddr  lorawan.DevAddr
+	nwkSKey  lorawan.AES128Key
+	appSKey  lorawan.AES128Key
+	fCntUp   uint32
+	fCntDown uint32
+
+	// ackPending is set when a confirmed downlink must be acknowledged by
+	// the next uplink.
+	ackPending bool
+
+	// confirmedPending is set when the last uplink was a confirmed uplink
+	// for which no ACK has been received yet, sent at confirmedAt.
+	confirmedPending bool
+	confirmedAt      time.Time
+}
+
+// newJoinRequest returns a new (signed) join-request. Note that the
+// DevNonce is always read from the crypto random source, as the
+// network-server rejects re-used DevNonces.
+func (d *device) newJoinRequest() (lorawan.PHYPayload, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return lorawan.PHYPayload{}, errors.Wrap(err, "read random bytes error")
+	}
+	d.devNonce = lorawan.DevNonce(binary.LittleEndian.Uint16(b))
+	d.joinPending = true
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinRequest,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.JoinRequestPayload{
+			JoinEUI:  d.joinEUI,
+			DevEUI:   d.devEUI,
+			DevNonce: d.devNonce,
+		},
+	}
+
+	if err := phy.SetUplinkJoinMIC(d.appKey); err != nil {
+		return phy, errors.Wrap(err, "set uplink join mic error")
+	}
+
+	return phy, nil
+}
+
+// handleJoinAccept handles the given (encrypted) join-accept PHYPayload. It
+// returns false when the join-accept is not intended for this device. The
+// PHYPayload is passed as bytes as decrypting it modifies the payload.
+func (d *device) handleJoinAccept(b []byte) (bool, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if !d.otaa || !d.joinPending {
+		return false, nil
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		return false, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	if err := phy.DecryptJoinAcceptPayload(d.appKey); err != nil {
+		return false, nil
+	}
+
+	ok, err := phy.ValidateDownlinkJoinMIC(lorawan.JoinRequestType, d.joinEUI, d.devNonce, d.appKey)
+	if err != nil || !ok {
+		return false, nil
+	}
+
+	jaPL, ok := phy.MACPayload.(*lorawan.JoinAcceptPayload)
+	if !ok {
+		return false, errors.Errorf("expected *lorawan.JoinAcceptPayload, got: %T", phy.MACPayload)
+	}
+
+	d.nwkSKey, err = getSessionKey(0x01, d.appKey, jaPL.JoinNonce, jaPL.HomeNetID, d.devNonce)
+	if err != nil {
+		return false, errors.Wrap(err, "get nwkskey error")
+	}
+	d.appSKey, err = getSessionKey(0x02, d.appKey, jaPL.JoinNonce, jaPL.HomeNetID, d.devNonce)
+	if err != nil {
+		return false, errors.Wrap(err, "get appskey error")
+	}
+
+	d.devAddr = jaPL.DevAddr
+	d.fCntUp = 0
+	d.fCntDown = 0
+	d.ackPending = false
+	d.confirmedPending = false
+	d.joinPending = false
+	d.joined = true
+
+	select {
+	case d.joinedC <- struct{}{}:
+	default:
+	}
+
+	return true, nil
+}
+
+// newDataUp returns a new (encrypted and signed) data uplink.
+func (d *device) newDataUp(fPort uint8, payload []byte, confirmed bool) (lorawan.PHYPayload, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	mType := lorawan.UnconfirmedDataUp
+	if confirmed {
+		mType = lorawan.ConfirmedDataUp
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: mType,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: d.devAddr,
+				FCtrl: lorawan.FCtrl{
+					ACK: d.ackPending,
+				},
+				FCnt: d.fCntUp,
+			},
+			FPort: &fPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: payload},
+			},
+		},
+	}
+
+	if err := phy.EncryptFRMPayload(d.appSKey); err != nil {
+		return phy, errors.Wrap(err, "encrypt frmpayload error")
+	}
+
+	if err := phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, d.nwkSKey, d.nwkSKey); err != nil {
+		return phy, errors.Wrap(err, "set uplink data mic error")
+	}
+
+	d.fCntUp++
+	d.ackPending = false
+	d.confirmedPending = confirmed
+	if confirmed {
+		d.confirmedAt = clock.Now()
+	}
+
+	return phy, nil
+}
+
+// handleDataDown handles the given data downlink. It returns true and the
+// time at which the confirmed uplink was sent when the downlink acknowledges
+// a pending confirmed uplink.
+func (d *device) handleDataDown(phy lorawan.PHYPayload) (bool, time.Time) {
+	d.Lock()
+	defer d.Unlock()
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return false, time.Time{}
+	}
+
+	d.fCntDown = macPL.FHDR.FCnt
+	if phy.MHDR.MType == lorawan.ConfirmedDataDown {
+		d.ackPending = true
+	}
+
+	if macPL.FHDR.FCtrl.ACK && d.confirmedPending {
+		d.confirmedPending = false
+		return true, d.confirmedAt
+	}
+
+	return false, time.Time{}
+}
+
+func (d *device) isJoined() bool {
+	d.Lock()
+	defer d.Unlock()
+	return d.joined
+}
+
+func (d *device) getDevAddr() lorawan.DevAddr {
+	d.Lock()
+	defer d.Unlock()
+	return d.devAddr
+}
+
+// getSessionKey returns the LoRaWAN 1.0.x session key for the given type
+// (0x01 = NwkSKey, 0x02 = AppSKey):
+// aes128_encrypt(AppKey, type | JoinNonce | NetID | DevNonce | pad16)
+func getSessionKey(typ byte, appKey lorawan.AES128Key, joinNonce lorawan.JoinNonce, netID lorawan.NetID, devNonce lorawan.DevNonce) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+	b := make([]byte, 16)
+
+	b[0] = typ
+	b[1] = byte(joinNonce)
+	b[2] = byte(joinNonce >> 8)
+	b[3] = byte(joinNonce >> 16)
+	// the NetID is transmitted LSB first
+	b[4] = netID[2]
+	b[5] = netID[1]
+	b[6] = netID[0]
+	b[7] = byte(devNonce)
+	b[8] = byte(devNonce >> 8)
+
+	block, err := aes.NewCipher(appKey[:])
+	if err != nil {
+		return key, err
+	}
+	block.Encrypt(key[:], b)
+
+	return key, nil
+}
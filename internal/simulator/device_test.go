@@ -0,0 +1,120 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestDeviceOTAA(t *testing.T) {
+	assert := require.New(t)
+
+	d := device{
+		devEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		joinEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		appKey:  lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		otaa:    true,
+		joinedC: make(chan struct{}, 1),
+	}
+
+	jrPHY, err := d.newJoinRequest()
+	assert.NoError(err)
+	jrPL, ok := jrPHY.MACPayload.(*lorawan.JoinRequestPayload)
+	assert.True(ok)
+	assert.Equal(d.devEUI, jrPL.DevEUI)
+	assert.Equal(d.joinEUI, jrPL.JoinEUI)
+
+	newJoinAccept := func(key lorawan.AES128Key) []byte {
+		jaPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinAccept,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinAcceptPayload{
+				JoinNonce: 197121,
+				HomeNetID: lorawan.NetID{1, 2, 3},
+				DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+			},
+		}
+		assert.NoError(jaPHY.SetDownlinkJoinMIC(lorawan.JoinRequestType, d.joinEUI, jrPL.DevNonce, key))
+		assert.NoError(jaPHY.EncryptJoinAcceptPayload(key))
+		b, err := jaPHY.MarshalBinary()
+		assert.NoError(err)
+		return b
+	}
+
+	t.Run("Join-accept for other device", func(t *testing.T) {
+		assert := require.New(t)
+
+		ok, err := d.handleJoinAccept(newJoinAccept(lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1}))
+		assert.NoError(err)
+		assert.False(ok)
+		assert.False(d.isJoined())
+	})
+
+	t.Run("Join-accept", func(t *testing.T) {
+		assert := require.New(t)
+
+		ok, err := d.handleJoinAccept(newJoinAccept(d.appKey))
+		assert.NoError(err)
+		assert.True(ok)
+		assert.True(d.isJoined())
+		assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, d.getDevAddr())
+		assert.Len(d.joinedC, 1)
+
+		nwkSKey, err := getSessionKey(0x01, d.appKey, 197121, lorawan.NetID{1, 2, 3}, jrPL.DevNonce)
+		assert.NoError(err)
+		appSKey, err := getSessionKey(0x02, d.appKey, 197121, lorawan.NetID{1, 2, 3}, jrPL.DevNonce)
+		assert.NoError(err)
+		assert.Equal(nwkSKey, d.nwkSKey)
+		assert.Equal(appSKey, d.appSKey)
+		assert.NotEqual(nwkSKey, appSKey)
+	})
+
+	t.Run("Data uplink and downlink", func(t *testing.T) {
+		assert := require.New(t)
+
+		phy, err := d.newDataUp(10, []byte{1, 2, 3}, true)
+		assert.NoError(err)
+		assert.Equal(lorawan.ConfirmedDataUp, phy.MHDR.MType)
+		assert.Equal(uint32(1), d.fCntUp)
+
+		assert.NoError(phy.DecryptFRMPayload(d.appSKey))
+		macPL := phy.MACPayload.(*lorawan.MACPayload)
+		assert.Equal(lorawan.DevAddr{1, 2, 3, 4}, macPL.FHDR.DevAddr)
+		assert.Equal([]lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3}}}, macPL.FRMPayload)
+
+		down := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.ConfirmedDataDown,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCtrl: lorawan.FCtrl{
+						ACK: true,
+					},
+				},
+			},
+		}
+		acked, confirmedAt := d.handleDataDown(down)
+		assert.True(acked)
+		assert.False(confirmedAt.IsZero())
+		acked, _ = d.handleDataDown(down)
+		assert.False(acked)
+
+		// the confirmed downlink must be acknowledged by the next uplink
+		phy, err = d.newDataUp(10, []byte{1, 2, 3}, false)
+		assert.NoError(err)
+		assert.True(phy.MACPayload.(*lorawan.MACPayload).FHDR.FCtrl.ACK)
+	})
+}
+
+func TestAddToEUI64(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(lorawan.EUI64{1, 1, 1, 1, 0, 0, 1, 0}, addToEUI64(lorawan.EUI64{1, 1, 1, 1, 0, 0, 0, 255}, 1))
+}
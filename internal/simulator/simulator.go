@@ -0,0 +1,809 @@
+// Package simulator implements an end-to-end simulation of virtual
+// (LoRaWAN 1.0.x) devices and gateways. The virtual gateways are connected
+// to the network-server through the MQTT gateway backend, so that the whole
+// uplink and downlink pipeline is exercised.
+//
+// All timing (uplink interval, join timeout and simulation duration) is
+// derived from the clock package. When the simulation runs in the same
+// process as the network-server, a mock clock can be configured using
+// clock.Set to fast-forward the simulation deterministically.
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/gateway/marshaler"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	nstls "github.com/mxc-foundation/lpwan-server/internal/tls"
+)
+
+const (
+	// joinTimeout defines the time to wait for a join-accept before
+	// re-transmitting the join-request.
+	joinTimeout = 10 * time.Second
+
+	// gracePeriod defines the time to wait for pending downlinks after the
+	// simulation has ended.
+	gracePeriod = 5 * time.Second
+)
+
+// Result contains the result of a simulation.
+type Result struct {
+	JoinRequests          int64   `json:"joinRequests"`
+	JoinedDevices         int     `json:"joinedDevices"`
+	OTAADevices           int     `json:"otaaDevices"`
+	Uplinks               int64   `json:"uplinks"`
+	ConfirmedUplinks      int64   `json:"confirmedUplinks"`
+	Downlinks             int64   `json:"downlinks"`
+	Acks                  int64   `json:"acks"`
+	JoinSuccessRatio      float64 `json:"joinSuccessRatio"`
+	DownlinkDeliveryRatio float64 `json:"downlinkDeliveryRatio"`
+
+	// Recovery contains the confirmed uplinks and acks of the confirmed
+	// uplinks sent after recovery_after (when configured).
+	RecoveryConfirmedUplinks      int64   `json:"recoveryConfirmedUplinks"`
+	RecoveryAcks                  int64   `json:"recoveryAcks"`
+	RecoveryDownlinkDeliveryRatio float64 `json:"recoveryDownlinkDeliveryRatio"`
+
+	// Failures contains the failed assertions.
+	Failures []string `json:"failures"`
+}
+
+// Passed returns true when all the assertions passed.
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+type counters struct {
+	joinRequests     int64
+	uplinks          int64
+	confirmedUplinks int64
+	downlinks        int64
+	acks             int64
+
+	recoveryConfirmedUplinks int64
+	recoveryAcks             int64
+}
+
+// Simulation implements the simulation of virtual devices and gateways.
+type Simulation struct {
+	conf       simulatorConfig
+	mqttConf   mqttConfig
+	marshaler  marshaler.Type
+	gatewayIDs []lorawan.EUI64
+	devices    []*device
+	counters   counters
+
+	rndMux sync.Mutex
+	rnd    *rand.Rand
+
+	conn  paho.Client
+	start time.Time
+}
+
+type simulatorConfig struct {
+	serviceProfileID uuid.UUID
+	deviceProfileID  uuid.UUID
+	routingProfileID uuid.UUID
+	netID            lorawan.NetID
+
+	duration       time.Duration
+	uplinkInterval time.Duration
+	fPort          uint8
+	payloadSize    int
+	confirmedRatio float64
+
+	rssiMin, rssiMax int
+	snrMin, snrMax   float64
+
+	minJoinSuccessRatio      float64
+	minDownlinkDeliveryRatio float64
+
+	recoveryAfter                    time.Duration
+	minRecoveryDownlinkDeliveryRatio float64
+}
+
+type mqttConfig struct {
+	server               string
+	username             string
+	password             string
+	qos                  uint8
+	caCert               string
+	tlsCert              string
+	tlsKey               string
+	eventTopic           string
+	commandTopicTemplate *template.Template
+}
+
+// New creates a new simulation from the given configuration.
+func New(conf config.Config) (*Simulation, error) {
+	c := conf.Simulator
+
+	if conf.NetworkServer.Gateway.Backend.Type != "mqtt" {
+		return nil, errors.New("simulator: only the mqtt gateway backend is supported")
+	}
+
+	s := Simulation{
+		rnd: rand.New(rand.NewSource(c.Seed)),
+		conf: simulatorConfig{
+			netID:                    conf.NetworkServer.NetID,
+			duration:                 c.Duration,
+			uplinkInterval:           c.UplinkInterval,
+			fPort:                    c.FPort,
+			payloadSize:              c.PayloadSize,
+			confirmedRatio:           c.ConfirmedRatio,
+			rssiMin:                  c.RSSIMin,
+			rssiMax:                  c.RSSIMax,
+			snrMin:                   c.SNRMin,
+			snrMax:                   c.SNRMax,
+			minJoinSuccessRatio:      c.MinJoinSuccessRatio,
+			minDownlinkDeliveryRatio: c.MinDownlinkDeliveryRatio,
+
+			recoveryAfter:                    c.RecoveryAfter,
+			minRecoveryDownlinkDeliveryRatio: c.MinRecoveryDownlinkDeliveryRatio,
+		},
+	}
+
+	if c.UplinkInterval <= 0 {
+		return nil, errors.New("simulator: uplink_interval must be greater than 0")
+	}
+	if c.RSSIMax < c.RSSIMin || c.SNRMax < c.SNRMin {
+		return nil, errors.New("simulator: rssi_max and snr_max must not be less than rssi_min and snr_min")
+	}
+
+	for _, id := range []struct {
+		name string
+		str  string
+		id   *uuid.UUID
+	}{
+		{"service_profile_id", c.ServiceProfileID, &s.conf.serviceProfileID},
+		{"device_profile_id", c.DeviceProfileID, &s.conf.deviceProfileID},
+		{"routing_profile_id", c.RoutingProfileID, &s.conf.routingProfileID},
+	} {
+		if err := id.id.UnmarshalText([]byte(id.str)); err != nil {
+			return nil, errors.Wrapf(err, "simulator: decode %s error", id.name)
+		}
+	}
+
+	switch c.Marshaler {
+	case "json":
+		s.marshaler = marshaler.JSON
+	case "protobuf":
+		s.marshaler = marshaler.Protobuf
+	default:
+		return nil, fmt.Errorf("simulator: unknown marshaler: %s", c.Marshaler)
+	}
+
+	var gatewayIDBase lorawan.EUI64
+	if err := gatewayIDBase.UnmarshalText([]byte(c.GatewayIDBase)); err != nil {
+		return nil, errors.Wrap(err, "simulator: decode gateway_id_base error")
+	}
+	for i := 0; i < c.GatewayCount; i++ {
+		s.gatewayIDs = append(s.gatewayIDs, addToEUI64(gatewayIDBase, uint64(i)))
+	}
+	if len(s.gatewayIDs) == 0 {
+		return nil, errors.New("simulator: gateway_count must be greater than 0")
+	}
+
+	var devEUIBase, joinEUI lorawan.EUI64
+	var appKey lorawan.AES128Key
+	if err := devEUIBase.UnmarshalText([]byte(c.DevEUIBase)); err != nil {
+		return nil, errors.Wrap(err, "simulator: decode dev_eui_base error")
+	}
+	if err := joinEUI.UnmarshalText([]byte(c.JoinEUI)); err != nil {
+		return nil, errors.Wrap(err, "simulator: decode join_eui error")
+	}
+	if c.OTAADeviceCount > 0 {
+		if err := appKey.UnmarshalText([]byte(c.AppKey)); err != nil {
+			return nil, errors.Wrap(err, "simulator: decode app_key error")
+		}
+	}
+
+	for i := 0; i < c.OTAADeviceCount+c.ABPDeviceCount; i++ {
+		s.devices = append(s.devices, &device{
+			devEUI:  addToEUI64(devEUIBase, uint64(i)),
+			joinEUI: joinEUI,
+			appKey:  appKey,
+			otaa:    i < c.OTAADeviceCount,
+			joinedC: make(chan struct{}, 1),
+		})
+	}
+
+	mqttConf := conf.NetworkServer.Gateway.Backend.MQTT
+	s.mqttConf = mqttConfig{
+		server:     mqttConf.Server,
+		username:   mqttConf.Username,
+		password:   mqttConf.Password,
+		qos:        mqttConf.QOS,
+		caCert:     mqttConf.CACert,
+		tlsCert:    mqttConf.TLSCert,
+		tlsKey:     mqttConf.TLSKey,
+		eventTopic: mqttConf.EventTopic,
+	}
+
+	var err error
+	s.mqttConf.commandTopicTemplate, err = template.New("command").Parse(mqttConf.CommandTopicTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulator: parse command topic template error")
+	}
+
+	return &s, nil
+}
+
+// Run provisions the virtual gateways and devices and runs the simulation
+// for the configured duration (or until the context is cancelled).
+func (s *Simulation) Run(ctx context.Context) (Result, error) {
+	if err := s.provision(ctx); err != nil {
+		return Result{}, errors.Wrap(err, "simulator: provision error")
+	}
+
+	if err := s.connect(); err != nil {
+		return Result{}, errors.Wrap(err, "simulator: connect error")
+	}
+	defer s.conn.Disconnect(250)
+
+	// the duration is measured using the clock package, so that the
+	// simulation can be fast-forwarded when a mock clock is configured
+	s.start = clock.Now()
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-runCtx.Done():
+		case <-clock.After(s.conf.duration):
+			cancel()
+		}
+	}()
+
+	log.WithFields(log.Fields{
+		"gateways": len(s.gatewayIDs),
+		"devices":  len(s.devices),
+		"duration": s.conf.duration,
+	}).Info("simulator: simulation started")
+
+	var wg sync.WaitGroup
+	for i := range s.devices {
+		// spread the devices over the uplink interval
+		offset := time.Duration(s.int63n(int64(s.conf.uplinkInterval)))
+
+		wg.Add(1)
+		go func(d *device) {
+			defer wg.Done()
+			s.runDevice(runCtx, d, offset)
+		}(s.devices[i])
+	}
+	wg.Wait()
+
+	log.WithField("grace_period", gracePeriod).Info("simulator: simulation ended, waiting for pending downlinks")
+	clock.Sleep(gracePeriod)
+
+	return s.result(), nil
+}
+
+func (s *Simulation) result() Result {
+	r := Result{
+		JoinRequests:     atomic.LoadInt64(&s.counters.joinRequests),
+		Uplinks:          atomic.LoadInt64(&s.counters.uplinks),
+		ConfirmedUplinks: atomic.LoadInt64(&s.counters.confirmedUplinks),
+		Downlinks:        atomic.LoadInt64(&s.counters.downlinks),
+		Acks:             atomic.LoadInt64(&s.counters.acks),
+
+		RecoveryConfirmedUplinks: atomic.LoadInt64(&s.counters.recoveryConfirmedUplinks),
+		RecoveryAcks:             atomic.LoadInt64(&s.counters.recoveryAcks),
+	}
+
+	for _, d := range s.devices {
+		if !d.otaa {
+			continue
+		}
+		r.OTAADevices++
+		if d.isJoined() {
+			r.JoinedDevices++
+		}
+	}
+
+	r.JoinSuccessRatio = 1
+	if r.OTAADevices != 0 {
+		r.JoinSuccessRatio = float64(r.JoinedDevices) / float64(r.OTAADevices)
+	}
+
+	r.DownlinkDeliveryRatio = 1
+	if r.ConfirmedUplinks != 0 {
+		r.DownlinkDeliveryRatio = float64(r.Acks) / float64(r.ConfirmedUplinks)
+	}
+
+	r.RecoveryDownlinkDeliveryRatio = 1
+	if r.RecoveryConfirmedUplinks != 0 {
+		r.RecoveryDownlinkDeliveryRatio = float64(r.RecoveryAcks) / float64(r.RecoveryConfirmedUplinks)
+	}
+
+	if r.JoinSuccessRatio < s.conf.minJoinSuccessRatio {
+		r.Failures = append(r.Failures, fmt.Sprintf("join success ratio %.3f is below %.3f", r.JoinSuccessRatio, s.conf.minJoinSuccessRatio))
+	}
+	if r.DownlinkDeliveryRatio < s.conf.minDownlinkDeliveryRatio {
+		r.Failures = append(r.Failures, fmt.Sprintf("downlink delivery ratio %.3f is below %.3f", r.DownlinkDeliveryRatio, s.conf.minDownlinkDeliveryRatio))
+	}
+	if s.conf.recoveryAfter != 0 && r.RecoveryDownlinkDeliveryRatio < s.conf.minRecoveryDownlinkDeliveryRatio {
+		r.Failures = append(r.Failures, fmt.Sprintf("recovery downlink delivery ratio %.3f is below %.3f", r.RecoveryDownlinkDeliveryRatio, s.conf.minRecoveryDownlinkDeliveryRatio))
+	}
+
+	return r
+}
+
+// provision creates the virtual gateways and devices when these do not
+// yet exist and activates the ABP devices.
+func (s *Simulation) provision(ctx context.Context) error {
+	for _, id := range s.gatewayIDs {
+		_, err := storage.GetGateway(ctx, storage.DB(), id)
+		if err == nil {
+			continue
+		}
+		if err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get gateway error")
+		}
+
+		if err := storage.CreateGateway(ctx, storage.DB(), &storage.Gateway{
+			GatewayID:        id,
+			RoutingProfileID: s.conf.routingProfileID,
+		}); err != nil {
+			return errors.Wrap(err, "create gateway error")
+		}
+	}
+
+	dp, err := storage.GetDeviceProfile(ctx, storage.DB(), s.conf.deviceProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get device-profile error")
+	}
+	if !strings.HasPrefix(dp.MACVersion, "1.0") {
+		return fmt.Errorf("device-profile mac-version must be 1.0.x, got: %s", dp.MACVersion)
+	}
+
+	for _, d := range s.devices {
+		_, err := storage.GetDevice(ctx, storage.DB(), d.devEUI)
+		if err != nil {
+			if err != storage.ErrDoesNotExist {
+				return errors.Wrap(err, "get device error")
+			}
+
+			if err := storage.CreateDevice(ctx, storage.DB(), &storage.Device{
+				DevEUI:           d.devEUI,
+				DeviceProfileID:  s.conf.deviceProfileID,
+				ServiceProfileID: s.conf.serviceProfileID,
+				RoutingProfileID: s.conf.routingProfileID,
+			}); err != nil {
+				return errors.Wrap(err, "create device error")
+			}
+		}
+
+		if d.otaa {
+			continue
+		}
+
+		if err := s.activateDevice(ctx, d, dp); err != nil {
+			return errors.Wrap(err, "activate device error")
+		}
+	}
+
+	return nil
+}
+
+// activateDevice activates the given ABP device with a random DevAddr and
+// random session-keys.
+func (s *Simulation) activateDevice(ctx context.Context, d *device, dp storage.DeviceProfile) error {
+	devAddr, err := storage.GetRandomDevAddr(s.conf.netID)
+	if err != nil {
+		return errors.Wrap(err, "get random devaddr error")
+	}
+
+	d.Lock()
+	d.devAddr = devAddr
+	s.rndMux.Lock()
+	s.rnd.Read(d.nwkSKey[:])
+	s.rnd.Read(d.appSKey[:])
+	s.rndMux.Unlock()
+	d.joined = true
+	ds := storage.DeviceSession{
+		DeviceProfileID:  s.conf.deviceProfileID,
+		ServiceProfileID: s.conf.serviceProfileID,
+		RoutingProfileID: s.conf.routingProfileID,
+		DevEUI:           d.devEUI,
+		DevAddr:          d.devAddr,
+		SNwkSIntKey:      d.nwkSKey,
+		FNwkSIntKey:      d.nwkSKey,
+		NwkSEncKey:       d.nwkSKey,
+		RXWindow:         storage.RX1,
+		MACVersion:       dp.MACVersion,
+	}
+	d.Unlock()
+
+	ds.ResetToBootParameters(dp)
+
+	return storage.SaveDeviceSession(ctx, storage.RedisPool(), ds)
+}
+
+func (s *Simulation) runDevice(ctx context.Context, d *device, offset time.Duration) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-clock.After(offset):
+	}
+
+	for d.otaa && !d.isJoined() {
+		if err := s.sendJoinRequest(d); err != nil {
+			log.WithError(err).WithField("dev_eui", d.devEUI).Error("simulator: send join-request error")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.joinedC:
+		case <-clock.After(joinTimeout):
+		}
+	}
+
+	for {
+		if err := s.sendDataUp(d); err != nil {
+			log.WithError(err).WithField("dev_eui", d.devEUI).Error("simulator: send uplink error")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(s.conf.uplinkInterval):
+		}
+	}
+}
+
+func (s *Simulation) sendJoinRequest(d *device) error {
+	phy, err := d.newJoinRequest()
+	if err != nil {
+		return err
+	}
+
+	atomic.AddInt64(&s.counters.joinRequests, 1)
+	return s.sendUplink(phy)
+}
+
+func (s *Simulation) sendDataUp(d *device) error {
+	payload := make([]byte, s.conf.payloadSize)
+	s.rndMux.Lock()
+	s.rnd.Read(payload)
+	confirmed := s.rnd.Float64() < s.conf.confirmedRatio
+	s.rndMux.Unlock()
+
+	phy, err := d.newDataUp(s.conf.fPort, payload, confirmed)
+	if err != nil {
+		return err
+	}
+
+	atomic.AddInt64(&s.counters.uplinks, 1)
+	if confirmed {
+		atomic.AddInt64(&s.counters.confirmedUplinks, 1)
+		if s.isRecovery(clock.Now()) {
+			atomic.AddInt64(&s.counters.recoveryConfirmedUplinks, 1)
+		}
+	}
+
+	return s.sendUplink(phy)
+}
+
+// sendUplink publishes the given PHYPayload for a random (non-empty) subset
+// of the virtual gateways, using a random uplink channel and data-rate.
+func (s *Simulation) sendUplink(phy lorawan.PHYPayload) error {
+	b, err := phy.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	txInfo, err := s.getUplinkTXInfo()
+	if err != nil {
+		return errors.Wrap(err, "get uplink tx-info error")
+	}
+
+	s.rndMux.Lock()
+	gatewayIDs := make([]lorawan.EUI64, len(s.gatewayIDs))
+	for i, j := range s.rnd.Perm(len(s.gatewayIDs)) {
+		gatewayIDs[i] = s.gatewayIDs[j]
+	}
+	gatewayIDs = gatewayIDs[:1+s.rnd.Intn(len(gatewayIDs))]
+	s.rndMux.Unlock()
+
+	for _, gatewayID := range gatewayIDs {
+		rxInfo, err := s.getUplinkRXInfo(gatewayID)
+		if err != nil {
+			return errors.Wrap(err, "get uplink rx-info error")
+		}
+
+		if err := s.publishEvent(gatewayID, "up", &gw.UplinkFrame{
+			PhyPayload: b,
+			TxInfo:     txInfo,
+			RxInfo:     rxInfo,
+		}); err != nil {
+			return errors.Wrap(err, "publish uplink error")
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulation) getUplinkTXInfo() (*gw.UplinkTXInfo, error) {
+	var channels []int
+	for i := 0; ; i++ {
+		if _, err := band.Band().GetUplinkChannel(i); err != nil {
+			break
+		}
+		channels = append(channels, i)
+	}
+	if len(channels) == 0 {
+		return nil, errors.New("no uplink channels")
+	}
+
+	s.rndMux.Lock()
+	ch := channels[s.rnd.Intn(len(channels))]
+	s.rndMux.Unlock()
+
+	c, err := band.Band().GetUplinkChannel(ch)
+	if err != nil {
+		return nil, errors.Wrap(err, "get uplink channel error")
+	}
+
+	s.rndMux.Lock()
+	dr := c.MinDR + s.rnd.Intn(c.MaxDR-c.MinDR+1)
+	s.rndMux.Unlock()
+
+	txInfo := gw.UplinkTXInfo{
+		Frequency: uint32(c.Frequency),
+	}
+	if err := helpers.SetUplinkTXInfoDataRate(&txInfo, dr, band.Band()); err != nil {
+		return nil, errors.Wrap(err, "set uplink tx-info data-rate error")
+	}
+
+	return &txInfo, nil
+}
+
+func (s *Simulation) getUplinkRXInfo(gatewayID lorawan.EUI64) (*gw.UplinkRXInfo, error) {
+	uplinkID, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "new uuid error")
+	}
+
+	s.rndMux.Lock()
+	rssi := s.conf.rssiMin + s.rnd.Intn(s.conf.rssiMax-s.conf.rssiMin+1)
+	snr := s.conf.snrMin + s.rnd.Float64()*(s.conf.snrMax-s.conf.snrMin)
+	s.rndMux.Unlock()
+
+	// the context holds the (concentrator) timestamp in microseconds
+	timestamp := make([]byte, 4)
+	binary.BigEndian.PutUint32(timestamp, uint32(clock.Now().UnixNano()/int64(time.Microsecond)))
+
+	return &gw.UplinkRXInfo{
+		GatewayId: gatewayID[:],
+		Rssi:      int32(rssi),
+		LoraSnr:   snr,
+		Context:   timestamp,
+		UplinkId:  uplinkID.Bytes(),
+	}, nil
+}
+
+// handleDownlinkFrame handles the downlink frames sent by the network-server
+// to the virtual gateways. Each downlink is acknowledged.
+func (s *Simulation) handleDownlinkFrame(c paho.Client, msg paho.Message) {
+	var df gw.DownlinkFrame
+	if err := unmarshalDownlinkFrame(msg.Payload(), &df); err != nil {
+		log.WithError(err).WithField("topic", msg.Topic()).Error("simulator: unmarshal downlink frame error")
+		return
+	}
+
+	if df.TxInfo == nil {
+		return
+	}
+
+	gatewayID := helpers.GetGatewayID(df.TxInfo)
+	if !s.isGateway(gatewayID) {
+		return
+	}
+
+	if err := s.publishEvent(gatewayID, "ack", &gw.DownlinkTXAck{
+		GatewayId:  gatewayID[:],
+		Token:      df.Token,
+		DownlinkId: df.DownlinkId,
+	}); err != nil {
+		log.WithError(err).WithField("gateway_id", gatewayID).Error("simulator: publish downlink tx ack error")
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(df.PhyPayload); err != nil {
+		log.WithError(err).Error("simulator: unmarshal downlink phypayload error")
+		return
+	}
+
+	atomic.AddInt64(&s.counters.downlinks, 1)
+
+	switch phy.MHDR.MType {
+	case lorawan.JoinAccept:
+		for _, d := range s.devices {
+			ok, err := d.handleJoinAccept(df.PhyPayload)
+			if err != nil {
+				log.WithError(err).WithField("dev_eui", d.devEUI).Error("simulator: handle join-accept error")
+			}
+			if ok {
+				log.WithFields(log.Fields{
+					"dev_eui":  d.devEUI,
+					"dev_addr": d.getDevAddr(),
+				}).Info("simulator: device joined")
+				return
+			}
+		}
+	case lorawan.UnconfirmedDataDown, lorawan.ConfirmedDataDown:
+		macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+		if !ok {
+			return
+		}
+
+		for _, d := range s.devices {
+			if !d.isJoined() || d.getDevAddr() != macPL.FHDR.DevAddr {
+				continue
+			}
+
+			if acked, confirmedAt := d.handleDataDown(phy); acked {
+				atomic.AddInt64(&s.counters.acks, 1)
+				if s.isRecovery(confirmedAt) {
+					atomic.AddInt64(&s.counters.recoveryAcks, 1)
+				}
+			}
+			return
+		}
+	}
+}
+
+// isRecovery returns true when recovery_after is configured and the given
+// time is after the start of the simulation + recovery_after.
+func (s *Simulation) isRecovery(t time.Time) bool {
+	return s.conf.recoveryAfter != 0 && !t.Before(s.start.Add(s.conf.recoveryAfter))
+}
+
+func (s *Simulation) isGateway(id lorawan.EUI64) bool {
+	for _, gatewayID := range s.gatewayIDs {
+		if gatewayID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulation) connect() error {
+	opts := paho.NewClientOptions()
+	opts.AddBroker(s.mqttConf.server)
+	opts.SetUsername(s.mqttConf.username)
+	opts.SetPassword(s.mqttConf.password)
+	opts.SetCleanSession(true)
+	opts.SetAutoReconnect(true)
+
+	tlsConfig, err := newTLSConfig(s.mqttConf.caCert, s.mqttConf.tlsCert, s.mqttConf.tlsKey)
+	if err != nil {
+		return errors.Wrap(err, "load tls config error")
+	}
+	if tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	commandTopic := bytes.NewBuffer(nil)
+	if err := s.mqttConf.commandTopicTemplate.Execute(commandTopic, struct {
+		GatewayID   string
+		CommandType string
+	}{"+", "down"}); err != nil {
+		return errors.Wrap(err, "execute command topic template error")
+	}
+
+	opts.SetOnConnectHandler(func(c paho.Client) {
+		log.WithField("topic", commandTopic.String()).Info("simulator: subscribing to gateway command topic")
+		if token := c.Subscribe(commandTopic.String(), s.mqttConf.qos, s.handleDownlinkFrame); token.Wait() && token.Error() != nil {
+			log.WithError(token.Error()).Error("simulator: subscribe error")
+		}
+	})
+
+	log.WithField("server", s.mqttConf.server).Info("simulator: connecting to mqtt broker")
+	s.conn = paho.NewClient(opts)
+	if token := s.conn.Connect(); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "connect to mqtt broker error")
+	}
+
+	return nil
+}
+
+// publishEvent publishes the given gateway event. The topic is derived from
+// the event topic of the network-server, by replacing the first wildcard by
+// the gateway ID and the second wildcard by the event type, e.g.
+// gateway/+/event/+ becomes gateway/0101010100000000/event/up.
+func (s *Simulation) publishEvent(gatewayID lorawan.EUI64, event string, msg proto.Message) error {
+	topic := strings.Replace(s.mqttConf.eventTopic, "+", gatewayID.String(), 1)
+	topic = strings.Replace(topic, "+", event, 1)
+
+	b, err := marshaler.MarshalCommand(s.marshaler, msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	if token := s.conn.Publish(topic, s.mqttConf.qos, false, b); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "publish event error")
+	}
+
+	return nil
+}
+
+func (s *Simulation) int63n(n int64) int64 {
+	s.rndMux.Lock()
+	defer s.rndMux.Unlock()
+	return s.rnd.Int63n(n)
+}
+
+func unmarshalDownlinkFrame(b []byte, df *gw.DownlinkFrame) error {
+	if len(b) != 0 && b[0] == '{' {
+		m := jsonpb.Unmarshaler{
+			AllowUnknownFields: true,
+		}
+		return m.Unmarshal(bytes.NewReader(b), df)
+	}
+	return proto.Unmarshal(b, df)
+}
+
+// addToEUI64 returns the given EUI64 incremented by n.
+func addToEUI64(eui lorawan.EUI64, n uint64) lorawan.EUI64 {
+	var out lorawan.EUI64
+	binary.BigEndian.PutUint64(out[:], binary.BigEndian.Uint64(eui[:])+n)
+	return out
+}
+
+func newTLSConfig(caCert, tlsCert, tlsKey string) (*tls.Config, error) {
+	if caCert == "" && tlsCert == "" && tlsKey == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+
+	if caCert != "" {
+		b, err := nstls.ReadPEM(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca certificate error")
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(b) {
+			return nil, errors.New("append ca certificate error")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if tlsCert != "" && tlsKey != "" {
+		kp, err := nstls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig.Certificates = []tls.Certificate{kp}
+	}
+
+	return tlsConfig, nil
+}
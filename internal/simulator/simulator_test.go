@@ -0,0 +1,92 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResult(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Devices       []*device
+		Counters      counters
+		RecoveryAfter time.Duration
+		Passed        bool
+	}{
+		{
+			Name: "all joined and acknowledged",
+			Devices: []*device{
+				{otaa: true, joined: true},
+				{otaa: false, joined: true},
+			},
+			Counters: counters{confirmedUplinks: 10, acks: 10},
+			Passed:   true,
+		},
+		{
+			Name: "join failed",
+			Devices: []*device{
+				{otaa: true, joined: true},
+				{otaa: true, joined: false},
+			},
+			Counters: counters{confirmedUplinks: 10, acks: 10},
+			Passed:   false,
+		},
+		{
+			Name: "missing acks",
+			Devices: []*device{
+				{otaa: false, joined: true},
+			},
+			Counters: counters{confirmedUplinks: 10, acks: 5},
+			Passed:   false,
+		},
+		{
+			Name: "recovered after injected faults",
+			Devices: []*device{
+				{otaa: false, joined: true},
+			},
+			Counters:      counters{confirmedUplinks: 20, acks: 19, recoveryConfirmedUplinks: 10, recoveryAcks: 10},
+			RecoveryAfter: time.Minute,
+			Passed:        true,
+		},
+		{
+			Name: "not recovered after injected faults",
+			Devices: []*device{
+				{otaa: false, joined: true},
+			},
+			Counters:      counters{confirmedUplinks: 100, acks: 95, recoveryConfirmedUplinks: 10, recoveryAcks: 5},
+			RecoveryAfter: time.Minute,
+			Passed:        false,
+		},
+		{
+			Name: "recovery assertion disabled",
+			Devices: []*device{
+				{otaa: false, joined: true},
+			},
+			Counters: counters{confirmedUplinks: 100, acks: 95, recoveryConfirmedUplinks: 10, recoveryAcks: 5},
+			Passed:   true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			s := Simulation{
+				devices:  tst.Devices,
+				counters: tst.Counters,
+				conf: simulatorConfig{
+					minJoinSuccessRatio:      1,
+					minDownlinkDeliveryRatio: 0.9,
+
+					recoveryAfter:                    tst.RecoveryAfter,
+					minRecoveryDownlinkDeliveryRatio: 0.9,
+				},
+			}
+
+			r := s.result()
+			assert.Equal(tst.Passed, r.Passed(), "failures: %v", r.Failures)
+		})
+	}
+}
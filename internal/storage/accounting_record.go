@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// AccountingDirection defines the direction of an accounted frame.
+type AccountingDirection string
+
+// Accounting directions.
+const (
+	AccountingUplink   AccountingDirection = "uplink"
+	AccountingDownlink AccountingDirection = "downlink"
+)
+
+// AccountingRecord defines the accounting record of a successfully processed
+// uplink or a transmitted downlink frame.
+type AccountingRecord struct {
+	ID          int64               `db:"id" json:"id"`
+	CreatedAt   time.Time           `db:"created_at" json:"createdAt"`
+	Direction   AccountingDirection `db:"direction" json:"direction"`
+	DevEUI      lorawan.EUI64       `db:"dev_eui" json:"devEUI"`
+	GatewayIDs  []lorawan.EUI64     `db:"-" json:"gatewayIDs"`
+	Airtime     time.Duration       `db:"airtime" json:"airtime"`
+	DR          int                 `db:"dr" json:"dr"`
+	PayloadSize int                 `db:"payload_size" json:"payloadSize"`
+	FCnt        uint32              `db:"f_cnt" json:"fCnt"`
+	Frequency   int                 `db:"frequency" json:"frequency"`
+
+	// AttestationKeyID and AttestationSignature contain the uplink
+	// attestation (when enabled).
+	AttestationKeyID     string `db:"attestation_key_id" json:"attestationKeyID,omitempty"`
+	AttestationSignature []byte `db:"attestation_signature" json:"attestationSignature,omitempty"`
+}
+
+// CreateAccountingRecord creates the given accounting record.
+func CreateAccountingRecord(ctx context.Context, db sqlx.Queryer, r *AccountingRecord) error {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+
+	gatewayIDs := make(pq.ByteaArray, 0, len(r.GatewayIDs))
+	for i := range r.GatewayIDs {
+		gatewayIDs = append(gatewayIDs, r.GatewayIDs[i][:])
+	}
+
+	err := sqlx.Get(db, &r.ID, `
+		insert into accounting_record (
+			created_at,
+			direction,
+			dev_eui,
+			gateway_ids,
+			airtime,
+			dr,
+			payload_size,
+			f_cnt,
+			frequency,
+			attestation_key_id,
+			attestation_signature
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		returning id`,
+		r.CreatedAt,
+		r.Direction,
+		r.DevEUI[:],
+		gatewayIDs,
+		r.Airtime,
+		r.DR,
+		r.PayloadSize,
+		r.FCnt,
+		r.Frequency,
+		r.AttestationKeyID,
+		r.AttestationSignature,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":        r.ID,
+		"direction": r.Direction,
+		"dev_eui":   r.DevEUI,
+		"ctx_id":    ctx.Value(logging.ContextIDKey),
+	}).Debug("accounting record created")
+
+	return nil
+}
+
+// GetAccountingRecords returns the accounting records, ordered by id. When
+// lock is true, the returned records are locked (skipping the records locked
+// by an other transaction) until the end of the transaction.
+func GetAccountingRecords(ctx context.Context, db sqlx.Queryer, limit int, lock bool) ([]AccountingRecord, error) {
+	query := `
+		select
+			id,
+			created_at,
+			direction,
+			dev_eui,
+			gateway_ids,
+			airtime,
+			dr,
+			payload_size,
+			f_cnt,
+			frequency,
+			attestation_key_id,
+			attestation_signature
+		from
+			accounting_record
+		order by
+			id
+		limit $1`
+	if lock {
+		query += `
+		for update skip locked`
+	}
+
+	rows, err := db.Queryx(query, limit)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	defer rows.Close()
+
+	var out []AccountingRecord
+	for rows.Next() {
+		var r AccountingRecord
+		var devEUI []byte
+		var gatewayIDs pq.ByteaArray
+
+		err := rows.Scan(
+			&r.ID,
+			&r.CreatedAt,
+			&r.Direction,
+			&devEUI,
+			&gatewayIDs,
+			&r.Airtime,
+			&r.DR,
+			&r.PayloadSize,
+			&r.FCnt,
+			&r.Frequency,
+			&r.AttestationKeyID,
+			&r.AttestationSignature,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan error")
+		}
+
+		copy(r.DevEUI[:], devEUI)
+		for _, b := range gatewayIDs {
+			var id lorawan.EUI64
+			copy(id[:], b)
+			r.GatewayIDs = append(r.GatewayIDs, id)
+		}
+
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+
+	return out, nil
+}
+
+// DeleteAccountingRecords deletes the accounting records matching the given
+// ids.
+func DeleteAccountingRecords(ctx context.Context, db sqlx.Execer, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec(`
+		delete from accounting_record
+		where
+			id = any($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	log.WithFields(log.Fields{
+		"count":  len(ids),
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Debug("accounting records deleted")
+
+	return nil
+}
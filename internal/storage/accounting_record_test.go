@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestAccountingRecord() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	records := []AccountingRecord{
+		{
+			Direction:   AccountingUplink,
+			DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			GatewayIDs:  []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}},
+			Airtime:     61696 * time.Microsecond,
+			DR:          5,
+			PayloadSize: 23,
+			FCnt:        10,
+			Frequency:   868100000,
+		},
+		{
+			Direction:   AccountingDownlink,
+			DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			GatewayIDs:  []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+			Airtime:     46336 * time.Microsecond,
+			DR:          5,
+			PayloadSize: 12,
+			FCnt:        3,
+			Frequency:   868100000,
+		},
+	}
+
+	for i := range records {
+		assert.NoError(CreateAccountingRecord(ctx, ts.Tx(), &records[i]))
+		assert.NotEqual(0, records[i].ID)
+	}
+
+	out, err := GetAccountingRecords(ctx, ts.Tx(), 10, true)
+	assert.NoError(err)
+	assert.Len(out, 2)
+
+	for i := range out {
+		assert.True(out[i].CreatedAt.Round(time.Second).Equal(records[i].CreatedAt.Round(time.Second)))
+		out[i].CreatedAt = records[i].CreatedAt
+		assert.Equal(records[i], out[i])
+	}
+
+	out, err = GetAccountingRecords(ctx, ts.Tx(), 1, false)
+	assert.NoError(err)
+	assert.Len(out, 1)
+	assert.Equal(records[0].ID, out[0].ID)
+
+	assert.NoError(DeleteAccountingRecords(ctx, ts.Tx(), []int64{records[0].ID}))
+
+	out, err = GetAccountingRecords(ctx, ts.Tx(), 10, false)
+	assert.NoError(err)
+	assert.Len(out, 1)
+	assert.Equal(records[1].ID, out[0].ID)
+}
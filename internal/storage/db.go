@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -10,10 +12,12 @@ import (
 	// register postgresql driver
 	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/faultinjection"
 )
 
 // redisPool holds Redis connection pool.
-var redisPool *redis.Pool
+var redisPool RedisClient
 
 // db holds the PostgreSQL connection pool.
 var db *DBLogger
@@ -32,12 +36,19 @@ type DBLogger struct {
 
 // Beginx returns a transaction with logging.
 func (db *DBLogger) Beginx() (*TxLogger, error) {
+	if err := injectFault("begin"); err != nil {
+		return nil, err
+	}
 	tx, err := db.DB.Beginx()
 	return &TxLogger{tx}, err
 }
 
 // Query logs the queries executed by the Query method.
 func (db *DBLogger) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	rows, err := db.DB.Query(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -46,6 +57,10 @@ func (db *DBLogger) Query(query string, args ...interface{}) (*sql.Rows, error)
 
 // Queryx logs the queries executed by the Queryx method.
 func (db *DBLogger) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	rows, err := db.DB.Queryx(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -54,6 +69,14 @@ func (db *DBLogger) Queryx(query string, args ...interface{}) (*sqlx.Rows, error
 
 // QueryRowx logs the queries executed by the QueryRowx method.
 func (db *DBLogger) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	if err := injectFault(query); err != nil {
+		// the error of a sqlx.Row can not be set, therefore the query is
+		// executed using a cancelled context
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return db.DB.QueryRowxContext(ctx, query, args...)
+	}
+
 	start := time.Now()
 	row := db.DB.QueryRowx(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -62,6 +85,10 @@ func (db *DBLogger) QueryRowx(query string, args ...interface{}) *sqlx.Row {
 
 // Exec logs the queries executed by the Exec method.
 func (db *DBLogger) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	res, err := db.DB.Exec(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -75,6 +102,10 @@ type TxLogger struct {
 
 // Query logs the queries executed by the Query method.
 func (q *TxLogger) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	rows, err := q.Tx.Query(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -83,6 +114,10 @@ func (q *TxLogger) Query(query string, args ...interface{}) (*sql.Rows, error) {
 
 // Queryx logs the queries executed by the Queryx method.
 func (q *TxLogger) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	rows, err := q.Tx.Queryx(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -91,6 +126,14 @@ func (q *TxLogger) Queryx(query string, args ...interface{}) (*sqlx.Rows, error)
 
 // QueryRowx logs the queries executed by the QueryRowx method.
 func (q *TxLogger) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	if err := injectFault(query); err != nil {
+		// the error of a sqlx.Row can not be set, therefore the query is
+		// executed using a cancelled context
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return q.Tx.QueryRowxContext(ctx, query, args...)
+	}
+
 	start := time.Now()
 	row := q.Tx.QueryRowx(query, args...)
 	logQuery(query, time.Since(start), args...)
@@ -99,12 +142,32 @@ func (q *TxLogger) QueryRowx(query string, args ...interface{}) *sqlx.Row {
 
 // Exec logs the queries executed by the Exec method.
 func (q *TxLogger) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := injectFault(query); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	res, err := q.Tx.Exec(query, args...)
 	logQuery(query, time.Since(start), args...)
 	return res, err
 }
 
+// injectFault applies the PostgreSQL fault-injection rules, using the first
+// keyword of the query as operation.
+func injectFault(query string) error {
+	if !faultinjection.Enabled() {
+		return nil
+	}
+
+	query = strings.TrimSpace(query)
+	if i := strings.IndexAny(query, " \t\n"); i != -1 {
+		query = query[:i]
+	}
+
+	_, err := faultinjection.Apply(faultinjection.PostgreSQL, query)
+	return err
+}
+
 func logQuery(query string, duration time.Duration, args ...interface{}) {
 	log.WithFields(log.Fields{
 		"query":    query,
@@ -119,6 +182,6 @@ func DB() *DBLogger {
 }
 
 // RedisPool returns the RedisPool object.
-func RedisPool() *redis.Pool {
+func RedisPool() RedisClient {
 	return redisPool
 }
@@ -33,6 +33,10 @@ type Device struct {
 	SkipFCntCheck     bool          `db:"skip_fcnt_check"`
 	ReferenceAltitude float64       `db:"reference_altitude"`
 	Mode              DeviceMode    `db:"mode"`
+
+	// CertificationTestMode enables the LoRaWAN certification test mode
+	// (TS009) for the device.
+	CertificationTestMode bool `db:"certification_test_mode"`
 }
 
 // DeviceActivation defines the device-activation for a LoRaWAN device.
@@ -65,8 +69,9 @@ func CreateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 			routing_profile_id,
 			skip_fcnt_check,
 			reference_altitude,
-			mode
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			mode,
+			certification_test_mode
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 		d.DevEUI[:],
 		d.CreatedAt,
 		d.UpdatedAt,
@@ -76,6 +81,7 @@ func CreateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 		d.SkipFCntCheck,
 		d.ReferenceAltitude,
 		d.Mode,
+		d.CertificationTestMode,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -111,7 +117,8 @@ func UpdateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 			routing_profile_id = $5,
 			skip_fcnt_check = $6,
 			reference_altitude = $7,
-			mode = $8
+			mode = $8,
+			certification_test_mode = $9
 		where
 			dev_eui = $1`,
 		d.DevEUI[:],
@@ -122,6 +129,7 @@ func UpdateDevice(ctx context.Context, db sqlx.Execer, d *Device) error {
 		d.SkipFCntCheck,
 		d.ReferenceAltitude,
 		d.Mode,
+		d.CertificationTestMode,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -167,7 +175,19 @@ func DeleteDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) err
 func CreateDeviceActivation(ctx context.Context, db sqlx.Queryer, da *DeviceActivation) error {
 	da.CreatedAt = time.Now()
 
+	// the dev-nonce is also stored in the device_nonce table, as the
+	// device-activations are removed together with the device
 	err := sqlx.Get(db, &da.ID, `
+		with nonce as (
+			insert into device_nonce (
+				join_eui,
+				dev_eui,
+				join_req_type,
+				dev_nonce,
+				created_at
+			) values ($3, $2, $9, $8, $1)
+			on conflict do nothing
+		)
 		insert into device_activation (
 			created_at,
 			dev_eui,
@@ -245,33 +265,3 @@ func GetLastDeviceActivationForDevEUI(ctx context.Context, db sqlx.Queryer, devE
 
 	return da, nil
 }
-
-// ValidateDevNonce validates the given dev-nonce for the given
-// DevEUI / JoinEUI combination.
-func ValidateDevNonce(ctx context.Context, db sqlx.Queryer, joinEUI, devEUI lorawan.EUI64, nonce lorawan.DevNonce, joinType lorawan.JoinType) error {
-	var count int
-	err := sqlx.Get(db, &count, `
-		select
-			count(*)
-		from
-			device_activation
-		where
-			dev_eui = $1
-			and join_eui = $2
-			and dev_nonce = $3
-			and join_req_type = $4`,
-		devEUI,
-		joinEUI,
-		nonce,
-		joinType,
-	)
-	if err != nil {
-		return handlePSQLError(err, "select error")
-	}
-
-	if count != 0 {
-		return ErrAlreadyExists
-	}
-
-	return nil
-}
@@ -0,0 +1,239 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+const deviceClassStatsKeyTempl = "lora:ns:device:{%s}:class-stats"
+
+// DeviceClassMisclassifiedTimeouts defines the number of consecutive
+// unacknowledged Class-B / Class-C confirmed downlinks after which the
+// configured device-class is considered misclassified.
+const DeviceClassMisclassifiedTimeouts = 3
+
+// DeviceClassHealthStatus defines the health status of the configured
+// device-class.
+type DeviceClassHealthStatus string
+
+// Available device-class health statuses.
+const (
+	// DeviceClassHealthUnknown indicates that no confirmed Class-B / Class-C
+	// downlink has been acknowledged (yet).
+	DeviceClassHealthUnknown DeviceClassHealthStatus = "UNKNOWN"
+
+	// DeviceClassHealthOK indicates that the device acknowledges its
+	// Class-B / Class-C confirmed downlinks.
+	DeviceClassHealthOK DeviceClassHealthStatus = "OK"
+
+	// DeviceClassHealthMisclassified indicates that the last
+	// DeviceClassMisclassifiedTimeouts Class-B / Class-C confirmed
+	// downlinks have not been acknowledged by the device.
+	DeviceClassHealthMisclassified DeviceClassHealthStatus = "MISCLASSIFIED"
+)
+
+// DeviceClassStats contains the Class-B / Class-C downlink statistics of a
+// device, used for detecting devices which do not behave according to their
+// configured device-class. The statistics are reset when the device-class
+// changes.
+type DeviceClassStats struct {
+	Mode                DeviceMode
+	Downlinks           int
+	ConfirmedDownlinks  int
+	Acknowledged        int
+	Timeouts            int
+	ConsecutiveTimeouts int
+	LastAcknowledgedAt  *time.Time
+
+	// PendingFCnt contains the frame-counter of the Class-B / Class-C
+	// confirmed downlink awaiting an acknowledgement.
+	PendingFCnt *uint32
+}
+
+// Status returns the device-class health status.
+func (s DeviceClassStats) Status() DeviceClassHealthStatus {
+	if s.ConsecutiveTimeouts >= DeviceClassMisclassifiedTimeouts {
+		return DeviceClassHealthMisclassified
+	}
+	if s.Acknowledged > 0 {
+		return DeviceClassHealthOK
+	}
+	return DeviceClassHealthUnknown
+}
+
+// GetDeviceClassStats returns the device-class statistics for the given
+// device. When no statistics exist, empty statistics are returned.
+func GetDeviceClassStats(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) (DeviceClassStats, error) {
+	var s DeviceClassStats
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(deviceClassStatsKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return s, nil
+		}
+		return s, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&s); err != nil {
+		return s, errors.Wrap(err, "gob decode error")
+	}
+
+	return s, nil
+}
+
+// DeleteDeviceClassStats deletes the device-class statistics for the given
+// device.
+func DeleteDeviceClassStats(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(deviceClassStatsKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "del error")
+	}
+
+	return nil
+}
+
+// RecordDeviceClassDownlink registers a Class-B / Class-C downlink sent to
+// the given device. For a confirmed downlink, the frame-counter is stored so
+// that the acknowledgement (or timeout) can be matched.
+func RecordDeviceClassDownlink(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, mode DeviceMode, confirmed bool, fCnt uint32) error {
+	s, err := GetDeviceClassStats(ctx, p, devEUI)
+	if err != nil {
+		return err
+	}
+
+	if s.Mode != mode {
+		s = DeviceClassStats{Mode: mode}
+	}
+
+	s.Downlinks++
+	if confirmed {
+		s.ConfirmedDownlinks++
+		s.PendingFCnt = &fCnt
+	}
+
+	return saveDeviceClassStats(p, devEUI, s)
+}
+
+// RecordDeviceClassACK registers the acknowledgement of the confirmed
+// downlink with the given frame-counter. It does nothing when this was not
+// a Class-B / Class-C downlink.
+func RecordDeviceClassACK(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, fCnt uint32) error {
+	s, err := GetDeviceClassStats(ctx, p, devEUI)
+	if err != nil {
+		return err
+	}
+
+	if s.PendingFCnt == nil || *s.PendingFCnt != fCnt {
+		return nil
+	}
+
+	now := clock.Now()
+	s.Acknowledged++
+	s.ConsecutiveTimeouts = 0
+	s.LastAcknowledgedAt = &now
+	s.PendingFCnt = nil
+
+	return saveDeviceClassStats(p, devEUI, s)
+}
+
+// RecordDeviceClassTimeout registers the timeout of the confirmed downlink
+// with the given frame-counter. It does nothing when this was not a
+// Class-B / Class-C downlink. When the device becomes misclassified, a
+// class_health event is sent to the service-profile webhook.
+func RecordDeviceClassTimeout(ctx context.Context, db sqlx.Queryer, p RedisClient, devEUI lorawan.EUI64, fCnt uint32) error {
+	s, err := GetDeviceClassStats(ctx, p, devEUI)
+	if err != nil {
+		return err
+	}
+
+	if s.PendingFCnt == nil || *s.PendingFCnt != fCnt {
+		return nil
+	}
+
+	s.Timeouts++
+	s.ConsecutiveTimeouts++
+	s.PendingFCnt = nil
+
+	if err := saveDeviceClassStats(p, devEUI, s); err != nil {
+		return err
+	}
+
+	if s.ConsecutiveTimeouts == DeviceClassMisclassifiedTimeouts {
+		log.WithFields(log.Fields{
+			"dev_eui":              devEUI,
+			"mode":                 s.Mode,
+			"consecutive_timeouts": s.ConsecutiveTimeouts,
+			"ctx_id":               ctx.Value(logging.ContextIDKey),
+		}).Warning("device does not acknowledge downlinks of its configured device-class")
+
+		sendClassHealthWebhook(ctx, db, devEUI, s)
+	}
+
+	return nil
+}
+
+func saveDeviceClassStats(p RedisClient, devEUI lorawan.EUI64, s DeviceClassStats) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
+	if _, err := c.Do("PSETEX", fmt.Sprintf(deviceClassStatsKeyTempl, devEUI), exp, buf.Bytes()); err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+// sendClassHealthWebhook sends the device-class health status to the
+// webhook of the service-profile of the device (if configured).
+func sendClassHealthWebhook(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, s DeviceClassStats) {
+	d, err := GetDevice(ctx, db, devEUI)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("get device for webhook error")
+		return
+	}
+
+	sp, err := GetAndCacheServiceProfile(ctx, db, RedisPool(), d.ServiceProfileID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("get service-profile for webhook error")
+		return
+	}
+
+	webhook.Send(ctx, sp.WebhookEndpoint(), webhook.EventClassHealth, webhook.ClassHealthEvent{
+		DevEUI:              devEUI,
+		Class:               string(s.Mode),
+		Status:              string(s.Status()),
+		ConfirmedDownlinks:  s.ConfirmedDownlinks,
+		Acknowledged:        s.Acknowledged,
+		ConsecutiveTimeouts: s.ConsecutiveTimeouts,
+	})
+}
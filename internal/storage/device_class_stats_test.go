@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceClassStats() {
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	ts.T().Run("Get empty", func(t *testing.T) {
+		assert := require.New(t)
+
+		s, err := GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(DeviceClassStats{}, s)
+		assert.Equal(DeviceClassHealthUnknown, s.Status())
+	})
+
+	ts.T().Run("Acknowledged", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(RecordDeviceClassDownlink(context.Background(), ts.RedisPool(), devEUI, DeviceModeC, false, 0))
+		assert.NoError(RecordDeviceClassDownlink(context.Background(), ts.RedisPool(), devEUI, DeviceModeC, true, 10))
+
+		// ack of a class-a downlink
+		assert.NoError(RecordDeviceClassACK(context.Background(), ts.RedisPool(), devEUI, 9))
+		s, err := GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(0, s.Acknowledged)
+
+		assert.NoError(RecordDeviceClassACK(context.Background(), ts.RedisPool(), devEUI, 10))
+		s, err = GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(DeviceModeC, s.Mode)
+		assert.Equal(2, s.Downlinks)
+		assert.Equal(1, s.ConfirmedDownlinks)
+		assert.Equal(1, s.Acknowledged)
+		assert.Nil(s.PendingFCnt)
+		assert.NotNil(s.LastAcknowledgedAt)
+		assert.Equal(DeviceClassHealthOK, s.Status())
+	})
+
+	ts.T().Run("Misclassified", func(t *testing.T) {
+		assert := require.New(t)
+
+		for i := 0; i < DeviceClassMisclassifiedTimeouts; i++ {
+			fCnt := uint32(11 + i)
+			assert.NoError(RecordDeviceClassDownlink(context.Background(), ts.RedisPool(), devEUI, DeviceModeC, true, fCnt))
+			assert.NoError(RecordDeviceClassTimeout(context.Background(), ts.Tx(), ts.RedisPool(), devEUI, fCnt))
+		}
+
+		s, err := GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(1, s.Acknowledged)
+		assert.Equal(DeviceClassMisclassifiedTimeouts, s.Timeouts)
+		assert.Equal(DeviceClassMisclassifiedTimeouts, s.ConsecutiveTimeouts)
+		assert.Equal(DeviceClassHealthMisclassified, s.Status())
+	})
+
+	ts.T().Run("Device-class changed", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(RecordDeviceClassDownlink(context.Background(), ts.RedisPool(), devEUI, DeviceModeB, false, 0))
+
+		s, err := GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(DeviceClassStats{Mode: DeviceModeB, Downlinks: 1}, s)
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeleteDeviceClassStats(context.Background(), ts.RedisPool(), devEUI))
+
+		s, err := GetDeviceClassStats(context.Background(), ts.RedisPool(), devEUI)
+		assert.NoError(err)
+		assert.Equal(DeviceClassStats{}, s)
+	})
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// DeviceNonce defines a used (Dev)Nonce for a JoinEUI / DevEUI combination.
+// Unlike the device-activations, these are not removed when the device is
+// removed, so that a re-provisioned device can not re-use its nonces.
+type DeviceNonce struct {
+	JoinEUI     lorawan.EUI64    `db:"join_eui" json:"joinEUI"`
+	DevEUI      lorawan.EUI64    `db:"dev_eui" json:"devEUI"`
+	JoinReqType lorawan.JoinType `db:"join_req_type" json:"joinReqType"`
+	DevNonce    lorawan.DevNonce `db:"dev_nonce" json:"devNonce"`
+	CreatedAt   time.Time        `db:"created_at" json:"createdAt"`
+}
+
+// CreateDeviceNonce stores the given nonce as used. It is not an error when
+// the nonce was already stored.
+func CreateDeviceNonce(ctx context.Context, db sqlx.Execer, n *DeviceNonce) error {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	_, err := db.Exec(`
+		insert into device_nonce (
+			join_eui,
+			dev_eui,
+			join_req_type,
+			dev_nonce,
+			created_at
+		) values ($1, $2, $3, $4, $5)
+		on conflict do nothing`,
+		n.JoinEUI[:],
+		n.DevEUI[:],
+		n.JoinReqType,
+		n.DevNonce,
+		n.CreatedAt,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	return nil
+}
+
+// GetDeviceNonces returns a slice of used nonces, ordered by JoinEUI, DevEUI,
+// join-request type and nonce.
+func GetDeviceNonces(ctx context.Context, db sqlx.Queryer, limit, offset int) ([]DeviceNonce, error) {
+	var nonces []DeviceNonce
+	err := sqlx.Select(db, &nonces, `
+		select
+			*
+		from
+			device_nonce
+		order by
+			join_eui,
+			dev_eui,
+			join_req_type,
+			dev_nonce
+		limit $1
+		offset $2`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return nonces, nil
+}
+
+// DeleteDeviceNoncesForDevice removes the used nonces for the given DevEUI.
+func DeleteDeviceNoncesForDevice(ctx context.Context, db sqlx.Execer, devEUI lorawan.EUI64) error {
+	_, err := db.Exec(`
+		delete
+		from
+			device_nonce
+		where
+			dev_eui = $1
+	`, devEUI[:])
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": devEUI,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}).Info("device-nonces deleted")
+	return nil
+}
+
+// ValidateDevNonce validates the given dev-nonce for the given
+// DevEUI / JoinEUI combination.
+func ValidateDevNonce(ctx context.Context, db sqlx.Queryer, joinEUI, devEUI lorawan.EUI64, nonce lorawan.DevNonce, joinType lorawan.JoinType) error {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select
+			count(*)
+		from
+			device_nonce
+		where
+			dev_eui = $1
+			and join_eui = $2
+			and dev_nonce = $3
+			and join_req_type = $4`,
+		devEUI,
+		joinEUI,
+		nonce,
+		joinType,
+	)
+	if err != nil {
+		return handlePSQLError(err, "select error")
+	}
+
+	if count != 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
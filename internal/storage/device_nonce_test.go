@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceNonce() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	sp := ServiceProfile{}
+	dp := DeviceProfile{}
+	rp := RoutingProfile{}
+
+	assert.Nil(CreateServiceProfile(context.Background(), ts.Tx(), &sp))
+	assert.Nil(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+	assert.Nil(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	d := Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: sp.ID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.Nil(CreateDevice(context.Background(), ts.Tx(), &d))
+
+	joinEUI := lorawan.EUI64{1, 2, 1, 2, 1, 2, 1, 2}
+	da := DeviceActivation{
+		DevEUI:      d.DevEUI,
+		JoinEUI:     joinEUI,
+		DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+		DevNonce:    258,
+		JoinReqType: lorawan.JoinRequestType,
+	}
+	assert.Nil(CreateDeviceActivation(ctx, ts.Tx(), &da))
+
+	ts.T().Run("Nonce is used after device removal", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Nil(DeleteDevice(ctx, ts.Tx(), d.DevEUI))
+		assert.Equal(ErrAlreadyExists, ValidateDevNonce(ctx, ts.Tx(), joinEUI, d.DevEUI, da.DevNonce, lorawan.JoinRequestType))
+		assert.Nil(ValidateDevNonce(ctx, ts.Tx(), joinEUI, d.DevEUI, da.DevNonce, lorawan.RejoinRequestType0))
+	})
+
+	ts.T().Run("CreateDeviceNonce", func(t *testing.T) {
+		assert := require.New(t)
+
+		n := DeviceNonce{
+			JoinEUI:     joinEUI,
+			DevEUI:      d.DevEUI,
+			JoinReqType: lorawan.JoinRequestType,
+			DevNonce:    513,
+		}
+		assert.Nil(CreateDeviceNonce(ctx, ts.Tx(), &n))
+
+		// creating an existing nonce is not an error
+		assert.Nil(CreateDeviceNonce(ctx, ts.Tx(), &n))
+		assert.Equal(ErrAlreadyExists, ValidateDevNonce(ctx, ts.Tx(), joinEUI, d.DevEUI, n.DevNonce, lorawan.JoinRequestType))
+
+		nonces, err := GetDeviceNonces(ctx, ts.Tx(), 10, 0)
+		assert.NoError(err)
+		assert.Len(nonces, 2)
+		assert.Equal(da.DevNonce, nonces[0].DevNonce)
+		assert.Equal(n.DevNonce, nonces[1].DevNonce)
+	})
+
+	ts.T().Run("DeleteDeviceNoncesForDevice", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.Nil(DeleteDeviceNoncesForDevice(ctx, ts.Tx(), d.DevEUI))
+		assert.Nil(ValidateDevNonce(ctx, ts.Tx(), joinEUI, d.DevEUI, da.DevNonce, lorawan.JoinRequestType))
+	})
+}
@@ -16,6 +16,25 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// FCntValidationMode defines the uplink frame-counter validation mode.
+type FCntValidationMode string
+
+// Available frame-counter validation modes.
+const (
+	// FCntValidationStrict validates the 32 bit frame-counter. This is the
+	// default (empty) mode.
+	FCntValidationStrict FCntValidationMode = "strict"
+
+	// FCntValidationRollover16Bit validates the 16 bit frame-counter, for
+	// devices which roll over from 65535 to 0 (e.g. LoRaWAN 1.0.x devices
+	// not supporting 32 bit frame-counters).
+	FCntValidationRollover16Bit FCntValidationMode = "rollover_16bit"
+
+	// FCntValidationRelaxed accepts frame-counter resets (e.g. after a
+	// power-cycle of an ABP device), as long as the MIC is valid.
+	FCntValidationRelaxed FCntValidationMode = "relaxed"
+)
+
 // Templates used for generating Redis keys
 const (
 	DeviceProfileKeyTempl = "lora:ns:dp:%s"
@@ -23,30 +42,32 @@ const (
 
 // DeviceProfile defines the backend.DeviceProfile with some extra meta-data
 type DeviceProfile struct {
-	CreatedAt           time.Time `db:"created_at"`
-	UpdatedAt           time.Time `db:"updated_at"`
-	ID                  uuid.UUID `db:"device_profile_id"`
-	SupportsClassB      bool      `db:"supports_class_b"`
-	ClassBTimeout       int       `db:"class_b_timeout"` // Unit: seconds
-	PingSlotPeriod      int       `db:"ping_slot_period"`
-	PingSlotDR          int       `db:"ping_slot_dr"`
-	PingSlotFreq        int       `db:"ping_slot_freq"` // in Hz
-	SupportsClassC      bool      `db:"supports_class_c"`
-	ClassCTimeout       int       `db:"class_c_timeout"`     // Unit: seconds
-	MACVersion          string    `db:"mac_version"`         // Example: "1.0.2" [LW102]
-	RegParamsRevision   string    `db:"reg_params_revision"` // Example: "B" [RP102B]
-	RXDelay1            int       `db:"rx_delay_1"`
-	RXDROffset1         int       `db:"rx_dr_offset_1"`
-	RXDataRate2         int       `db:"rx_data_rate_2"`       // Unit: bits-per-second
-	RXFreq2             int       `db:"rx_freq_2"`            // In Hz
-	FactoryPresetFreqs  []int     `db:"factory_preset_freqs"` // In Hz
-	MaxEIRP             int       `db:"max_eirp"`             // In dBm
-	MaxDutyCycle        int       `db:"max_duty_cycle"`       // Example: 10 indicates 10%
-	SupportsJoin        bool      `db:"supports_join"`
-	RFRegion            string    `db:"rf_region"`
-	Supports32bitFCnt   bool      `db:"supports_32bit_fcnt"`
-	GeolocBufferTTL     int       `db:"geoloc_buffer_ttl"`
-	GeolocMinBufferSize int       `db:"geoloc_min_buffer_size"`
+	CreatedAt           time.Time          `db:"created_at"`
+	UpdatedAt           time.Time          `db:"updated_at"`
+	ID                  uuid.UUID          `db:"device_profile_id"`
+	SupportsClassB      bool               `db:"supports_class_b"`
+	ClassBTimeout       int                `db:"class_b_timeout"` // Unit: seconds
+	PingSlotPeriod      int                `db:"ping_slot_period"`
+	PingSlotDR          int                `db:"ping_slot_dr"`
+	PingSlotFreq        int                `db:"ping_slot_freq"` // in Hz
+	SupportsClassC      bool               `db:"supports_class_c"`
+	ClassCTimeout       int                `db:"class_c_timeout"`     // Unit: seconds
+	MACVersion          string             `db:"mac_version"`         // Example: "1.0.2" [LW102]
+	RegParamsRevision   string             `db:"reg_params_revision"` // Example: "B" [RP102B]
+	RXDelay1            int                `db:"rx_delay_1"`
+	RXDROffset1         int                `db:"rx_dr_offset_1"`
+	RXDataRate2         int                `db:"rx_data_rate_2"`       // Unit: bits-per-second
+	RXFreq2             int                `db:"rx_freq_2"`            // In Hz
+	FactoryPresetFreqs  []int              `db:"factory_preset_freqs"` // In Hz
+	MaxEIRP             int                `db:"max_eirp"`             // In dBm
+	MaxDutyCycle        int                `db:"max_duty_cycle"`       // Example: 10 indicates 10%
+	SupportsJoin        bool               `db:"supports_join"`
+	RFRegion            string             `db:"rf_region"`
+	Supports32bitFCnt   bool               `db:"supports_32bit_fcnt"`
+	GeolocBufferTTL     int                `db:"geoloc_buffer_ttl"`
+	GeolocMinBufferSize int                `db:"geoloc_min_buffer_size"`
+	FCntValidationMode  FCntValidationMode `db:"fcnt_validation_mode"`
+	FCntMaxGap          int                `db:"fcnt_max_gap"` // 0 = use the band default
 }
 
 // CreateDeviceProfile creates the given device-profile.
@@ -61,6 +82,10 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		}
 	}
 
+	if dp.FCntValidationMode == "" {
+		dp.FCntValidationMode = FCntValidationStrict
+	}
+
 	dp.CreatedAt = now
 	dp.UpdatedAt = now
 
@@ -90,8 +115,10 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
+			geoloc_min_buffer_size,
+			fcnt_validation_mode,
+			fcnt_max_gap
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.ID,
@@ -116,6 +143,8 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.FCntValidationMode,
+		dp.FCntMaxGap,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -131,7 +160,7 @@ func CreateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 
 // CreateDeviceProfileCache caches the given device-profile in Redis.
 // The TTL of the device-profile is the same as that of the device-sessions.
-func CreateDeviceProfileCache(ctx context.Context, p *redis.Pool, dp DeviceProfile) error {
+func CreateDeviceProfileCache(ctx context.Context, p RedisClient, dp DeviceProfile) error {
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(dp); err != nil {
 		return errors.Wrap(err, "gob encode device-profile error")
@@ -144,6 +173,7 @@ func CreateDeviceProfileCache(ctx context.Context, p *redis.Pool, dp DeviceProfi
 	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
 
 	_, err := c.Do("PSETEX", key, exp, buf.Bytes())
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "set device-profile error")
 	}
@@ -152,14 +182,14 @@ func CreateDeviceProfileCache(ctx context.Context, p *redis.Pool, dp DeviceProfi
 }
 
 // GetDeviceProfileCache returns a cached device-profile.
-func GetDeviceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) (DeviceProfile, error) {
+func GetDeviceProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) (DeviceProfile, error) {
 	var dp DeviceProfile
 	key := fmt.Sprintf(DeviceProfileKeyTempl, id)
 
 	c := p.Get()
 	defer c.Close()
 
-	val, err := redis.Bytes(c.Do("GET", key))
+	val, err := getCached(c, key)
 	if err != nil {
 		if err == redis.ErrNil {
 			return dp, ErrDoesNotExist
@@ -176,12 +206,13 @@ func GetDeviceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) (De
 }
 
 // FlushDeviceProfileCache deletes a cached device-profile.
-func FlushDeviceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) error {
+func FlushDeviceProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) error {
 	key := fmt.Sprintf(DeviceProfileKeyTempl, id)
 	c := p.Get()
 	defer c.Close()
 
 	_, err := c.Do("DEL", key)
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "delete error")
 	}
@@ -191,7 +222,7 @@ func FlushDeviceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) e
 // GetAndCacheDeviceProfile returns the device-profile from cache
 // in case available, else it will be retrieved from the database and then
 // stored in cache.
-func GetAndCacheDeviceProfile(ctx context.Context, db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (DeviceProfile, error) {
+func GetAndCacheDeviceProfile(ctx context.Context, db sqlx.Queryer, p RedisClient, id uuid.UUID) (DeviceProfile, error) {
 	dp, err := GetDeviceProfileCache(ctx, p, id)
 	if err == nil {
 		return dp, nil
@@ -250,7 +281,9 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
             rf_region,
             supports_32bit_fcnt,
 			geoloc_buffer_ttl,
-			geoloc_min_buffer_size
+			geoloc_min_buffer_size,
+			fcnt_validation_mode,
+			fcnt_max_gap
         from device_profile
         where
             device_profile_id = $1
@@ -283,6 +316,8 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
 		&dp.Supports32bitFCnt,
 		&dp.GeolocBufferTTL,
 		&dp.GeolocMinBufferSize,
+		&dp.FCntValidationMode,
+		&dp.FCntMaxGap,
 	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
@@ -297,6 +332,10 @@ func GetDeviceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Devic
 
 // UpdateDeviceProfile updates the given device-profile.
 func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile) error {
+	if dp.FCntValidationMode == "" {
+		dp.FCntValidationMode = FCntValidationStrict
+	}
+
 	dp.UpdatedAt = time.Now()
 
 	res, err := db.Exec(`
@@ -323,7 +362,9 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
             rf_region = $20,
             supports_32bit_fcnt = $21,
 			geoloc_buffer_ttl = $22,
-			geoloc_min_buffer_size = $23
+			geoloc_min_buffer_size = $23,
+			fcnt_validation_mode = $24,
+			fcnt_max_gap = $25
         where
             device_profile_id = $1`,
 		dp.ID,
@@ -349,6 +390,8 @@ func UpdateDeviceProfile(ctx context.Context, db sqlx.Execer, dp *DeviceProfile)
 		dp.Supports32bitFCnt,
 		dp.GeolocBufferTTL,
 		dp.GeolocMinBufferSize,
+		dp.FCntValidationMode,
+		dp.FCntMaxGap,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -45,6 +45,8 @@ func TestDeviceProfile(t *testing.T) {
 				Supports32bitFCnt:   true,
 				GeolocBufferTTL:     10,
 				GeolocMinBufferSize: 3,
+				FCntValidationMode:  FCntValidationRollover16Bit,
+				FCntMaxGap:          100,
 			}
 
 			So(CreateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
@@ -108,6 +110,8 @@ func TestDeviceProfile(t *testing.T) {
 				dp.Supports32bitFCnt = false
 				dp.GeolocBufferTTL = 20
 				dp.GeolocMinBufferSize = 4
+				dp.FCntValidationMode = FCntValidationRelaxed
+				dp.FCntMaxGap = 200
 
 				So(UpdateDeviceProfile(context.Background(), DB(), &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
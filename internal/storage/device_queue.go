@@ -5,13 +5,15 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/as"
-	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 )
@@ -30,6 +32,41 @@ type DeviceQueueItem struct {
 	IsPending               bool            `db:"is_pending"`
 	EmitAtTimeSinceGPSEpoch *time.Duration  `db:"emit_at_time_since_gps_epoch"`
 	TimeoutAfter            *time.Time      `db:"timeout_after"`
+	ExpiresAt               *time.Time      `db:"expires_at"`
+	Priority                int             `db:"priority"`
+	TXCount                 int             `db:"tx_count"`
+	TransmittedAt           *time.Time      `db:"transmitted_at"`
+}
+
+// DeliveryReceipt returns the HandleDownlinkACKRequest for the
+// acknowledgement of the device-queue item, received at the given time.
+func (d DeviceQueueItem) DeliveryReceipt(acknowledgedAt time.Time) (as.HandleDownlinkACKRequest, error) {
+	req := as.HandleDownlinkACKRequest{
+		DevEui:       d.DevEUI[:],
+		FCnt:         d.FCnt,
+		Acknowledged: true,
+		TxCount:      uint32(d.TXCount),
+	}
+
+	var err error
+	req.EnqueuedAt, err = ptypes.TimestampProto(d.CreatedAt)
+	if err != nil {
+		return req, errors.Wrap(err, "timestamp proto error")
+	}
+
+	if d.TransmittedAt != nil {
+		req.TransmittedAt, err = ptypes.TimestampProto(*d.TransmittedAt)
+		if err != nil {
+			return req, errors.Wrap(err, "timestamp proto error")
+		}
+	}
+
+	req.AcknowledgedAt, err = ptypes.TimestampProto(acknowledgedAt)
+	if err != nil {
+		return req, errors.Wrap(err, "timestamp proto error")
+	}
+
+	return req, nil
 }
 
 // Validate validates the DeviceQueueItem.
@@ -46,7 +83,7 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
 		return err
 	}
 
-	now := time.Now()
+	now := clock.Now()
 	qi.CreatedAt = now
 	qi.UpdatedAt = now
 
@@ -62,8 +99,12 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
             confirmed,
             emit_at_time_since_gps_epoch,
             is_pending,
-            timeout_after
-        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+            timeout_after,
+            expires_at,
+            priority,
+            tx_count,
+            transmitted_at
+        ) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
         returning id`,
 		qi.CreatedAt,
 		qi.UpdatedAt,
@@ -76,6 +117,10 @@ func CreateDeviceQueueItem(ctx context.Context, db sqlx.Queryer, qi *DeviceQueue
 		qi.EmitAtTimeSinceGPSEpoch,
 		qi.IsPending,
 		qi.TimeoutAfter,
+		qi.ExpiresAt,
+		qi.Priority,
+		qi.TXCount,
+		qi.TransmittedAt,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -102,7 +147,7 @@ func GetDeviceQueueItem(ctx context.Context, db sqlx.Queryer, id int64) (DeviceQ
 
 // UpdateDeviceQueueItem updates the given device-queue item.
 func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueItem) error {
-	qi.UpdatedAt = time.Now()
+	qi.UpdatedAt = clock.Now()
 
 	res, err := db.Exec(`
         update device_queue
@@ -116,7 +161,11 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
             emit_at_time_since_gps_epoch = $8,
             is_pending = $9,
             timeout_after = $10,
-			dev_addr = $11
+			dev_addr = $11,
+            expires_at = $12,
+            priority = $13,
+            tx_count = $14,
+            transmitted_at = $15
         where
             id = $1`,
 		qi.ID,
@@ -130,6 +179,10 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
 		qi.IsPending,
 		qi.TimeoutAfter,
 		qi.DevAddr[:],
+		qi.ExpiresAt,
+		qi.Priority,
+		qi.TXCount,
+		qi.TransmittedAt,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -148,6 +201,8 @@ func UpdateDeviceQueueItem(ctx context.Context, db sqlx.Execer, qi *DeviceQueueI
 		"is_pending":                   qi.IsPending,
 		"emit_at_time_since_gps_epoch": qi.EmitAtTimeSinceGPSEpoch,
 		"timeout_after":                qi.TimeoutAfter,
+		"expires_at":                   qi.ExpiresAt,
+		"tx_count":                     qi.TXCount,
 		"ctx_id":                       ctx.Value(logging.ContextIDKey),
 	}).Info("device-queue item updated")
 
@@ -213,7 +268,7 @@ func GetNextDeviceQueueItemForDevEUI(ctx context.Context, db sqlx.Queryer, devEU
 
 	// In case the transmission is pending and hasn't timed-out yet, do not
 	// return it.
-	if qi.IsPending && qi.TimeoutAfter != nil && qi.TimeoutAfter.After(time.Now()) {
+	if qi.IsPending && qi.TimeoutAfter != nil && qi.TimeoutAfter.After(clock.Now()) {
 		return DeviceQueueItem{}, ErrDoesNotExist
 	}
 
@@ -269,6 +324,26 @@ func GetDeviceQueueItemsForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI l
 	return items, nil
 }
 
+// GetNextDeviceQueueItemFCnt returns the frame-counter that must be used
+// for the next device-queue item of the given device-session. This takes
+// the device-queue items into consideration.
+func GetNextDeviceQueueItemFCnt(ctx context.Context, db sqlx.Queryer, ds DeviceSession) (uint32, error) {
+	fCnt := ds.AFCntDown
+	if ds.GetMACVersion() == lorawan.LoRaWAN1_0 {
+		fCnt = ds.NFCntDown
+	}
+
+	items, err := GetDeviceQueueItemsForDevEUI(ctx, db, ds.DevEUI)
+	if err != nil {
+		return 0, err
+	}
+	if count := len(items); count != 0 {
+		fCnt = items[count-1].FCnt + 1 // we want the next usable frame-counter
+	}
+
+	return fCnt, nil
+}
+
 // GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt returns the next
 // device-queue for the given DevEUI item respecting:
 // * maxPayloadSize: the maximum payload size
@@ -284,12 +359,22 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 			return DeviceQueueItem{}, errors.Wrap(err, "get next device-queue item error")
 		}
 
-		if qi.FCnt < fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now())) {
+		if qi.ExpiresAt != nil && qi.ExpiresAt.Before(clock.Now()) {
+			// the janitor might not have removed the expired item yet
+			if err := DiscardDeviceQueueItem(ctx, db, qi, routingProfileID, as.ErrorType_DEVICE_QUEUE_ITEM_EXPIRED, "device-queue item expired"); err != nil {
+				return DeviceQueueItem{}, err
+			}
+
+			// try next frame
+			continue
+		}
+
+		if qi.FCnt < fCnt || len(qi.FRMPayload) > maxPayloadSize || (qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(clock.Now())) {
 			rp, err := GetRoutingProfile(ctx, db, routingProfileID)
 			if err != nil {
 				return DeviceQueueItem{}, errors.Wrap(err, "get routing-profile error")
 			}
-			asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+			asClient, err := rp.GetApplicationServerClient()
 			if err != nil {
 				return DeviceQueueItem{}, errors.Wrap(err, "get application-server client error")
 			}
@@ -298,7 +383,7 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 				return DeviceQueueItem{}, errors.Wrap(err, "delete device-queue item error")
 			}
 
-			if qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(time.Now()) {
+			if qi.TimeoutAfter != nil && qi.TimeoutAfter.Before(clock.Now()) {
 				// timeout
 				log.WithFields(log.Fields{
 					"dev_eui":                devEUI,
@@ -314,6 +399,13 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
+
+				if err := RecordDeviceClassTimeout(ctx, db, RedisPool(), devEUI, qi.FCnt); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"dev_eui": devEUI,
+						"ctx_id":  ctx.Value(logging.ContextIDKey),
+					}).Error("record device-class timeout error")
+				}
 			} else if qi.FCnt < fCnt {
 				// handle frame-counter error
 				log.WithFields(log.Fields{
@@ -323,15 +415,19 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 					"ctx_id":                 ctx.Value(logging.ContextIDKey),
 				}).Warning("device-queue item discarded due to invalid fCnt")
 
-				_, err = asClient.HandleError(ctx, &as.HandleErrorRequest{
+				errReq := as.HandleErrorRequest{
 					DevEui: devEUI[:],
 					Type:   as.ErrorType_DEVICE_QUEUE_ITEM_FCNT,
 					FCnt:   qi.FCnt,
 					Error:  "invalid frame-counter",
-				})
+				}
+
+				_, err = asClient.HandleError(ctx, &errReq)
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
+
+				sendErrorWebhook(ctx, db, devEUI, errReq)
 			} else if len(qi.FRMPayload) > maxPayloadSize {
 				// handle max payload size error
 				log.WithFields(log.Fields{
@@ -342,15 +438,19 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 					"ctx_id":                         ctx.Value(logging.ContextIDKey),
 				}).Warning("device-queue item discarded as it exceeds the max payload size")
 
-				_, err = asClient.HandleError(ctx, &as.HandleErrorRequest{
+				errReq := as.HandleErrorRequest{
 					DevEui: devEUI[:],
 					Type:   as.ErrorType_DEVICE_QUEUE_ITEM_SIZE,
 					FCnt:   qi.FCnt,
 					Error:  "payload exceeds max payload size",
-				})
+				}
+
+				_, err = asClient.HandleError(ctx, &errReq)
 				if err != nil {
 					return DeviceQueueItem{}, errors.Wrap(err, "application-server client error")
 				}
+
+				sendErrorWebhook(ctx, db, devEUI, errReq)
 			}
 
 			// try next frame
@@ -361,12 +461,41 @@ func GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(ctx context.Context, d
 	}
 }
 
+// sendErrorWebhook sends the given error to the webhook of the
+// service-profile of the device (if configured).
+func sendErrorWebhook(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, req as.HandleErrorRequest) {
+	d, err := GetDevice(ctx, db, devEUI)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("get device for webhook error")
+		return
+	}
+
+	sp, err := GetAndCacheServiceProfile(ctx, db, RedisPool(), d.ServiceProfileID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"ctx_id":  ctx.Value(logging.ContextIDKey),
+		}).Error("get service-profile for webhook error")
+		return
+	}
+
+	webhook.Send(ctx, sp.WebhookEndpoint(), webhook.EventError, webhook.ErrorEvent{
+		DevEUI: devEUI,
+		Type:   req.Type.String(),
+		Error:  req.Error,
+		FCnt:   req.FCnt,
+	})
+}
+
 // GetDevicesWithClassBOrClassCDeviceQueueItems returns a slice of devices that qualify
 // for downlink Class-C transmission.
 // The device records will be locked for update so that multiple instances can
 // run this query in parallel without the risk of duplicate scheduling.
 func GetDevicesWithClassBOrClassCDeviceQueueItems(ctx context.Context, db sqlx.Ext, count int) ([]Device, error) {
-	gpsEpochScheduleTime := gps.Time(time.Now().Add(schedulerInterval * 2)).TimeSinceGPSEpoch()
+	gpsEpochScheduleTime := gps.Time(clock.Now().Add(schedulerInterval * 2)).TimeSinceGPSEpoch()
 
 	var devices []Device
 	err := sqlx.Select(db, &devices, `
@@ -410,7 +539,7 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(ctx context.Context, db sqlx.E
         for update of d skip locked`,
 		count,
 		gpsEpochScheduleTime,
-		time.Now(),
+		clock.Now(),
 	)
 	if err != nil {
 		return nil, handlePSQLError(err, "select error")
@@ -419,6 +548,239 @@ func GetDevicesWithClassBOrClassCDeviceQueueItems(ctx context.Context, db sqlx.E
 	return devices, nil
 }
 
+// DeleteExpiredPendingDeviceQueueItems deletes max. count pending
+// device-queue items which timed out before the given time and notifies the
+// application-server (negative acknowledgement). Normally the pending item is
+// removed on the next uplink of the device, this removes the items of devices
+// which did not send an uplink since. It returns the number of deleted items.
+func DeleteExpiredPendingDeviceQueueItems(ctx context.Context, db sqlx.Ext, timedOutBefore time.Time, count int) (int, error) {
+	var items []DeviceQueueItem
+	err := sqlx.Select(db, &items, `
+		select
+			*
+		from
+			device_queue
+		where
+			is_pending = true
+			and timeout_after < $1
+		order by
+			id
+		limit $2
+		for update skip locked`,
+		timedOutBefore,
+		count,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	for _, qi := range items {
+		d, err := GetDevice(ctx, db, qi.DevEUI)
+		if err != nil {
+			return 0, errors.Wrap(err, "get device error")
+		}
+
+		rp, err := GetRoutingProfile(ctx, db, d.RoutingProfileID)
+		if err != nil {
+			return 0, errors.Wrap(err, "get routing-profile error")
+		}
+
+		asClient, err := rp.GetApplicationServerClient()
+		if err != nil {
+			return 0, errors.Wrap(err, "get application-server client error")
+		}
+
+		if err := DeleteDeviceQueueItem(ctx, db, qi.ID); err != nil {
+			return 0, errors.Wrap(err, "delete device-queue item error")
+		}
+
+		_, err = asClient.HandleDownlinkACK(ctx, &as.HandleDownlinkACKRequest{
+			DevEui:       qi.DevEUI[:],
+			FCnt:         qi.FCnt,
+			Acknowledged: false,
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "application-server client error")
+		}
+
+		if err := RecordDeviceClassTimeout(ctx, db, RedisPool(), qi.DevEUI, qi.FCnt); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": qi.DevEUI,
+				"ctx_id":  ctx.Value(logging.ContextIDKey),
+			}).Error("record device-class timeout error")
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":                qi.DevEUI,
+			"device_queue_item_fcnt": qi.FCnt,
+			"ctx_id":                 ctx.Value(logging.ContextIDKey),
+		}).Info("expired pending device-queue item deleted")
+	}
+
+	return len(items), nil
+}
+
+// DeleteExpiredDeviceQueueItems deletes max. count device-queue items of
+// which the TTL expired before the given time and sends an expiry error to
+// the application-server. It returns the number of deleted items.
+func DeleteExpiredDeviceQueueItems(ctx context.Context, db sqlx.Ext, expiredBefore time.Time, count int) (int, error) {
+	var items []DeviceQueueItem
+	err := sqlx.Select(db, &items, `
+		select
+			*
+		from
+			device_queue
+		where
+			expires_at < $1
+		order by
+			id
+		limit $2
+		for update skip locked`,
+		expiredBefore,
+		count,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	for _, qi := range items {
+		d, err := GetDevice(ctx, db, qi.DevEUI)
+		if err != nil {
+			return 0, errors.Wrap(err, "get device error")
+		}
+
+		if err := DiscardDeviceQueueItem(ctx, db, qi, d.RoutingProfileID, as.ErrorType_DEVICE_QUEUE_ITEM_EXPIRED, "device-queue item expired"); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(items), nil
+}
+
+// DiscardDeviceQueueItem deletes the given device-queue item and sends the
+// given error to the application-server and the service-profile webhook.
+func DiscardDeviceQueueItem(ctx context.Context, db sqlx.Ext, qi DeviceQueueItem, routingProfileID uuid.UUID, errType as.ErrorType, errStr string) error {
+	rp, err := GetRoutingProfile(ctx, db, routingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := rp.GetApplicationServerClient()
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	if err := DeleteDeviceQueueItem(ctx, db, qi.ID); err != nil {
+		return errors.Wrap(err, "delete device-queue item error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":                qi.DevEUI,
+		"device_queue_item_fcnt": qi.FCnt,
+		"error_type":             errType,
+		"ctx_id":                 ctx.Value(logging.ContextIDKey),
+	}).Warning("device-queue item discarded")
+
+	errReq := as.HandleErrorRequest{
+		DevEui: qi.DevEUI[:],
+		Type:   errType,
+		FCnt:   qi.FCnt,
+		Error:  errStr,
+	}
+
+	if _, err := asClient.HandleError(ctx, &errReq); err != nil {
+		return errors.Wrap(err, "application-server client error")
+	}
+
+	sendErrorWebhook(ctx, db, qi.DevEUI, errReq)
+
+	return nil
+}
+
+// ApplyDeviceQueueOverflowPolicy makes sure that the given item can be added
+// to the device-queue of the given device, respecting the device-queue max.
+// size and overflow policy of the service-profile. Depending the policy,
+// it returns ErrDeviceQueueFull or discards queued items (the
+// application-server is notified with a DEVICE_QUEUE_ITEM_OVERFLOW error).
+// This must be called within the transaction creating the item.
+func ApplyDeviceQueueOverflowPolicy(ctx context.Context, db sqlx.Ext, sp ServiceProfile, d Device, qi DeviceQueueItem) error {
+	if sp.DeviceQueueMaxSize <= 0 {
+		return nil
+	}
+
+	// lock the device to serialize concurrent enqueues
+	var devEUI lorawan.EUI64
+	err := sqlx.Get(db, &devEUI, "select dev_eui from device where dev_eui = $1 for update", d.DevEUI[:])
+	if err != nil {
+		return handlePSQLError(err, "select error")
+	}
+
+	var count int
+	err = sqlx.Get(db, &count, "select count(*) from device_queue where dev_eui = $1", d.DevEUI[:])
+	if err != nil {
+		return handlePSQLError(err, "select error")
+	}
+
+	if count < sp.DeviceQueueMaxSize {
+		return nil
+	}
+
+	var orderBy string
+	switch sp.DeviceQueueOverflowPolicy {
+	case DeviceQueueOverflowDropOldest:
+		orderBy = "id"
+	case DeviceQueueOverflowDropLowestPriority:
+		orderBy = "priority, id"
+	default:
+		log.WithFields(log.Fields{
+			"dev_eui":               d.DevEUI,
+			"device_queue_size":     count,
+			"device_queue_max_size": sp.DeviceQueueMaxSize,
+			"ctx_id":                ctx.Value(logging.ContextIDKey),
+		}).Warning("device-queue full, item rejected")
+		return ErrDeviceQueueFull
+	}
+
+	// the pending item can not be discarded as the device might still
+	// acknowledge it
+	var items []DeviceQueueItem
+	err = sqlx.Select(db, &items, `
+		select
+			*
+		from
+			device_queue
+		where
+			dev_eui = $1
+			and is_pending = false
+		order by
+			`+orderBy+`
+		limit $2`,
+		d.DevEUI[:],
+		count-sp.DeviceQueueMaxSize+1,
+	)
+	if err != nil {
+		return handlePSQLError(err, "select error")
+	}
+
+	if len(items) != count-sp.DeviceQueueMaxSize+1 {
+		return ErrDeviceQueueFull
+	}
+
+	for _, item := range items {
+		if sp.DeviceQueueOverflowPolicy == DeviceQueueOverflowDropLowestPriority && item.Priority > qi.Priority {
+			return ErrDeviceQueueFull
+		}
+	}
+
+	for _, item := range items {
+		if err := DiscardDeviceQueueItem(ctx, db, item, d.RoutingProfileID, as.ErrorType_DEVICE_QUEUE_ITEM_OVERFLOW, "device-queue overflow"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetMaxEmitAtTimeSinceGPSEpochForDevEUI returns the maximum / last GPS
 // epoch scheduling timestamp for the given DevEUI.
 func GetMaxEmitAtTimeSinceGPSEpochForDevEUI(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64) (time.Duration, error) {
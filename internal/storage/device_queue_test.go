@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
@@ -42,6 +43,37 @@ func TestDeviceQueueItemValidate(t *testing.T) {
 	})
 }
 
+func TestDeviceQueueItemDeliveryReceipt(t *testing.T) {
+	Convey("Given a transmitted device-queue item", t, func() {
+		createdAt := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+		transmittedAt := createdAt.Add(time.Hour)
+		acknowledgedAt := transmittedAt.Add(5 * time.Second)
+
+		qi := DeviceQueueItem{
+			CreatedAt:     createdAt,
+			DevEUI:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCnt:          10,
+			TXCount:       2,
+			TransmittedAt: &transmittedAt,
+		}
+
+		Convey("Then DeliveryReceipt returns the expected request", func() {
+			req, err := qi.DeliveryReceipt(acknowledgedAt)
+			So(err, ShouldBeNil)
+
+			So(req, ShouldResemble, as.HandleDownlinkACKRequest{
+				DevEui:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				FCnt:           10,
+				Acknowledged:   true,
+				TxCount:        2,
+				EnqueuedAt:     &timestamp.Timestamp{Seconds: createdAt.Unix()},
+				TransmittedAt:  &timestamp.Timestamp{Seconds: transmittedAt.Unix()},
+				AcknowledgedAt: &timestamp.Timestamp{Seconds: acknowledgedAt.Unix()},
+			})
+		})
+	})
+}
+
 func TestDeviceQueue(t *testing.T) {
 	conf := test.GetConfig()
 	if err := Setup(conf); err != nil {
@@ -180,6 +212,113 @@ func TestDeviceQueue(t *testing.T) {
 				})
 			})
 
+			Convey("Given a queue item with an expired TTL and one with a TTL in the future", func() {
+				oneMinuteAgo := time.Now().Add(-time.Minute)
+				inOneMinute := time.Now().Add(time.Minute)
+
+				items := []DeviceQueueItem{
+					{
+						DevEUI:    d.DevEUI,
+						FCnt:      10,
+						FPort:     1,
+						ExpiresAt: &oneMinuteAgo,
+					},
+					{
+						DevEUI:    d.DevEUI,
+						FCnt:      11,
+						FPort:     1,
+						ExpiresAt: &inOneMinute,
+					},
+				}
+				for i := range items {
+					So(CreateDeviceQueueItem(context.Background(), DB(), &items[i]), ShouldBeNil)
+				}
+
+				Convey("Then DeleteExpiredDeviceQueueItems deletes the expired item and notifies the application-server", func() {
+					count, err := DeleteExpiredDeviceQueueItems(context.Background(), DB(), time.Now(), 10)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+
+					So(<-asClient.HandleErrorChan, ShouldResemble, as.HandleErrorRequest{
+						DevEui: d.DevEUI[:],
+						Type:   as.ErrorType_DEVICE_QUEUE_ITEM_EXPIRED,
+						Error:  "device-queue item expired",
+						FCnt:   10,
+					})
+
+					_, err = GetDeviceQueueItem(context.Background(), DB(), items[0].ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+					_, err = GetDeviceQueueItem(context.Background(), DB(), items[1].ID)
+					So(err, ShouldBeNil)
+				})
+
+				Convey("Then GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt skips the expired item", func() {
+					qi, err := GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt(context.Background(), DB(), d.DevEUI, 10, 10, rp.ID)
+					So(err, ShouldBeNil)
+					So(qi.ID, ShouldEqual, items[1].ID)
+
+					req := <-asClient.HandleErrorChan
+					So(req.Type, ShouldEqual, as.ErrorType_DEVICE_QUEUE_ITEM_EXPIRED)
+					So(req.FCnt, ShouldEqual, 10)
+				})
+			})
+
+			Convey("Given a device-queue reaching its max. size", func() {
+				items := []DeviceQueueItem{
+					{DevEUI: d.DevEUI, FCnt: 10, FPort: 1, Priority: 1},
+					{DevEUI: d.DevEUI, FCnt: 11, FPort: 1, Priority: 0},
+				}
+				for i := range items {
+					So(CreateDeviceQueueItem(context.Background(), DB(), &items[i]), ShouldBeNil)
+				}
+				sp.DeviceQueueMaxSize = 2
+
+				Convey("Then ApplyDeviceQueueOverflowPolicy does nothing when there is room", func() {
+					sp.DeviceQueueMaxSize = 3
+					So(ApplyDeviceQueueOverflowPolicy(context.Background(), DB(), sp, d, DeviceQueueItem{}), ShouldBeNil)
+					So(asClient.HandleErrorChan, ShouldHaveLength, 0)
+				})
+
+				Convey("Then ApplyDeviceQueueOverflowPolicy returns ErrDeviceQueueFull for the reject policy", func() {
+					sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowReject
+					So(ApplyDeviceQueueOverflowPolicy(context.Background(), DB(), sp, d, DeviceQueueItem{}), ShouldEqual, ErrDeviceQueueFull)
+					So(asClient.HandleErrorChan, ShouldHaveLength, 0)
+				})
+
+				Convey("Then ApplyDeviceQueueOverflowPolicy drops the oldest item for the drop oldest policy", func() {
+					sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowDropOldest
+					So(ApplyDeviceQueueOverflowPolicy(context.Background(), DB(), sp, d, DeviceQueueItem{}), ShouldBeNil)
+					So(<-asClient.HandleErrorChan, ShouldResemble, as.HandleErrorRequest{
+						DevEui: d.DevEUI[:],
+						Type:   as.ErrorType_DEVICE_QUEUE_ITEM_OVERFLOW,
+						Error:  "device-queue overflow",
+						FCnt:   10,
+					})
+
+					_, err := GetDeviceQueueItem(context.Background(), DB(), items[0].ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Then ApplyDeviceQueueOverflowPolicy drops the lowest priority item for the drop lowest priority policy", func() {
+					sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowDropLowestPriority
+					So(ApplyDeviceQueueOverflowPolicy(context.Background(), DB(), sp, d, DeviceQueueItem{Priority: 1}), ShouldBeNil)
+					req := <-asClient.HandleErrorChan
+					So(req.FCnt, ShouldEqual, 11)
+
+					_, err := GetDeviceQueueItem(context.Background(), DB(), items[1].ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Then ApplyDeviceQueueOverflowPolicy rejects an item with a lower priority than the queued items", func() {
+					sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowDropLowestPriority
+					items[1].Priority = 2
+					So(UpdateDeviceQueueItem(context.Background(), DB(), &items[1]), ShouldBeNil)
+
+					So(ApplyDeviceQueueOverflowPolicy(context.Background(), DB(), sp, d, DeviceQueueItem{Priority: 0}), ShouldEqual, ErrDeviceQueueFull)
+					So(asClient.HandleErrorChan, ShouldHaveLength, 0)
+				})
+			})
+
 			Convey("When testing GetNextDeviceQueueItemForDevEUIMaxPayloadSizeAndFCnt", func() {
 				oneMinuteAgo := time.Now().Add(-time.Minute)
 
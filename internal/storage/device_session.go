@@ -12,23 +12,29 @@ import (
 	"time"
 
 	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
 	loraband "github.com/brocaar/lorawan/band"
 	"github.com/gofrs/uuid"
 	proto "github.com/golang/protobuf/proto"
 	"github.com/gomodule/redigo/redis"
 	"github.com/mxc-foundation/lpwan-server/api/common"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	devAddrKeyTempl                = "lora:ns:devaddr:%s"     // contains a set of DevEUIs using this DevAddr
-	deviceSessionKeyTempl          = "lora:ns:device:%s"      // contains the session of a DevEUI
-	deviceGatewayRXInfoSetKeyTempl = "lora:ns:device:%s:gwrx" // contains gateway meta-data from the last uplink
+	devAddrKeyTempl                = "lora:ns:devaddr:%s"       // contains a set of DevEUIs using this DevAddr
+	deviceSessionKeyTempl          = "lora:ns:device:{%s}"      // contains the session of a DevEUI
+	deviceGatewayRXInfoSetKeyTempl = "lora:ns:device:{%s}:gwrx" // contains gateway meta-data from the last uplink
 )
 
+// updateDeviceSessionRetries contains the max. number of attempts to update
+// a device-session which is modified concurrently.
+const updateDeviceSessionRetries = 3
+
 // UplinkHistorySize contains the number of frames to store
 const UplinkHistorySize = 20
 
@@ -47,6 +53,10 @@ type DeviceGatewayRXInfoSet struct {
 	DevEUI lorawan.EUI64
 	DR     int
 	Items  []DeviceGatewayRXInfo
+
+	// Region holds the region of the receiving gateways, for the band lookup
+	// of the data-rate. It is not persisted and is set by the caller.
+	Region string
 }
 
 // DeviceGatewayRXInfo holds the meta-data of a gateway receiving the last
@@ -97,9 +107,30 @@ type DeviceSession struct {
 	AFCntDown      uint32
 	ConfFCnt       uint32
 
+	// Wrapped network session-keys. When set, the wrapped key is stored
+	// instead of the plain key and unwrapped when the device-session is
+	// read (see the join_server.kek.store_wrapped_keys setting).
+	FNwkSIntKeyEnvelope *KeyEnvelope
+	SNwkSIntKeyEnvelope *KeyEnvelope
+	NwkSEncKeyEnvelope  *KeyEnvelope
+
+	// AppSKey retained after it has been forwarded to the application-server,
+	// when the network-server handles application layer packages of the
+	// device (e.g. clock synchronization or remote multicast setup).
+	RetainedAppSKeyEnvelope *KeyEnvelope
+
 	// Only used by ABP activation
 	SkipFCntValidation bool
 
+	// CertificationTestMode is set when the LoRaWAN certification test mode
+	// is enabled for the device. CertificationActivated is set once the
+	// certification package of the device has been activated and
+	// CertificationEchoPayload contains the payload of the pending
+	// EchoIncPayloadReq.
+	CertificationTestMode    bool
+	CertificationActivated   bool
+	CertificationEchoPayload []byte
+
 	RXWindow     RXWindow
 	RXDelay      uint8
 	RX1DROffset  uint8
@@ -176,6 +207,13 @@ type DeviceSession struct {
 
 	// Max uplink EIRP limitation.
 	UplinkMaxEIRPIndex uint8
+
+	// Region of the device. When empty, the default band is used.
+	Region string
+
+	// SubBands contains the sub-bands of the gateways receiving the device
+	// (only for regions consisting of sub-bands).
+	SubBands []int
 }
 
 // AppendUplinkHistory appends an UplinkHistory item and makes sure the list
@@ -251,7 +289,7 @@ func (s *DeviceSession) ResetToBootParameters(dp DeviceProfile) {
 	s.RX1DROffset = uint8(dp.RXDROffset1)
 	s.RX2DR = uint8(dp.RXDataRate2)
 	s.RX2Frequency = int(dp.RXFreq2)
-	s.EnabledUplinkChannels = band.Band().GetStandardUplinkChannelIndices() // TODO: replace by ServiceProfile.ChannelMask?
+	s.EnabledUplinkChannels = band.Get(s.Region).GetStandardUplinkChannelIndices() // TODO: replace by ServiceProfile.ChannelMask?
 	s.ChannelFrequencies = channelFrequencies
 	s.PingSlotDR = dp.PingSlotDR
 	s.PingSlotFrequency = int(dp.PingSlotFreq)
@@ -283,17 +321,49 @@ func GetRandomDevAddr(netID lorawan.NetID) (lorawan.DevAddr, error) {
 // After a succesful validation of the FCntUp and the MIC, don't forget
 // to synchronize the Node FCntUp with the packet FCnt.
 func ValidateAndGetFullFCntUp(s DeviceSession, fCntUp uint32) (uint32, bool) {
+	return ValidateAndGetFullFCntUpForDeviceProfile(s, DeviceProfile{}, fCntUp)
+}
+
+// ValidateAndGetFullFCntUpForDeviceProfile validates if the given fCntUp is
+// valid, using the frame-counter validation mode and max. gap of the given
+// device-profile, and returns the full frame-counter.
+func ValidateAndGetFullFCntUpForDeviceProfile(s DeviceSession, dp DeviceProfile, fCntUp uint32) (uint32, bool) {
+	maxGap := band.Get(s.Region).GetDefaults().MaxFCntGap
+	if dp.FCntMaxGap > 0 {
+		maxGap = uint32(dp.FCntMaxGap)
+	}
+
 	// we need to compare the difference of the 16 LSB
 	gap := uint32(uint16(fCntUp) - uint16(s.FCntUp%65536))
-	if gap < band.Band().GetDefaults().MaxFCntGap {
-		return s.FCntUp + gap, true
+	if gap >= maxGap {
+		return 0, false
 	}
-	return 0, false
+
+	fullFCnt := s.FCntUp + gap
+	if dp.FCntValidationMode == FCntValidationRollover16Bit {
+		// the device uses a 16 bit frame-counter for the MIC
+		fullFCnt = fullFCnt % 65536
+	}
+
+	return fullFCnt, true
+}
+
+// IsFCntResetForDeviceProfile returns true when the given fCntUp, which did
+// not pass the validation, must be accepted as a frame-counter reset of the
+// device. In the relaxed validation mode of the device-profile, this is only
+// the case when the fCntUp is below the configured reset threshold, as a
+// device starts counting from 0 after a reset.
+func IsFCntResetForDeviceProfile(s DeviceSession, dp DeviceProfile, fCntUp uint32) bool {
+	if s.SkipFCntValidation || s.CertificationTestMode {
+		return true
+	}
+
+	return dp.FCntValidationMode == FCntValidationRelaxed && fCntUp < fCntResetThreshold
 }
 
 // SaveDeviceSession saves the device-session. In case it doesn't exist yet
 // it will be created.
-func SaveDeviceSession(ctx context.Context, p *redis.Pool, s DeviceSession) error {
+func SaveDeviceSession(ctx context.Context, p RedisClient, s DeviceSession) error {
 	dsPB := deviceSessionToPB(s)
 	b, err := proto.Marshal(&dsPB)
 	if err != nil {
@@ -302,20 +372,19 @@ func SaveDeviceSession(ctx context.Context, p *redis.Pool, s DeviceSession) erro
 
 	c := p.Get()
 	defer c.Close()
-	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
 
 	c.Send("MULTI")
-	c.Send("PSETEX", fmt.Sprintf(deviceSessionKeyTempl, s.DevEUI), exp, b)
-	c.Send("SADD", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), s.DevEUI[:])
-	c.Send("PEXPIRE", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), exp)
-	if s.PendingRejoinDeviceSession != nil {
-		c.Send("SADD", fmt.Sprintf(devAddrKeyTempl, s.PendingRejoinDeviceSession.DevAddr), s.DevEUI[:])
-		c.Send("PEXPIRE", fmt.Sprintf(devAddrKeyTempl, s.PendingRejoinDeviceSession.DevAddr), exp)
-	}
-	if _, err := c.Do("EXEC"); err != nil {
+	sendSaveDeviceSession(c, s, b)
+	_, err = c.Do("EXEC")
+	redisLocalCache.remove(fmt.Sprintf(deviceSessionKeyTempl, s.DevEUI))
+	if err != nil {
 		return errors.Wrap(err, "exec error")
 	}
 
+	if err := saveDevAddrIndex(p, s); err != nil {
+		return err
+	}
+
 	log.WithFields(log.Fields{
 		"dev_eui":  s.DevEUI,
 		"dev_addr": s.DevAddr,
@@ -325,13 +394,106 @@ func SaveDeviceSession(ctx context.Context, p *redis.Pool, s DeviceSession) erro
 	return nil
 }
 
-// GetDeviceSession returns the device-session for the given DevEUI.
-func GetDeviceSession(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) (DeviceSession, error) {
-	var dsPB DeviceSessionPB
+// UpdateDeviceSession updates the device-session for the given DevEUI
+// using the given function. The device-session is only saved when it has not
+// been modified (e.g. by the handling of an uplink) since it was read, else
+// the update is retried. This makes it safe to use while LoRa Server is
+// running. The DevEUI and DevAddr of the device-session can not be updated.
+func UpdateDeviceSession(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, f func(*DeviceSession) error) (DeviceSession, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(deviceSessionKeyTempl, devEUI)
+
+	for i := 0; i < updateDeviceSessionRetries; i++ {
+		if _, err := c.Do("WATCH", key); err != nil {
+			return DeviceSession{}, errors.Wrap(err, "watch error")
+		}
+
+		ds, err := getDeviceSession(c, devEUI)
+		if err != nil {
+			c.Do("UNWATCH")
+			return DeviceSession{}, err
+		}
+
+		if err := unwrapNwkSKeys(ctx, &ds); err != nil {
+			c.Do("UNWATCH")
+			return DeviceSession{}, errors.Wrap(err, "unwrap network session-keys error")
+		}
+
+		devAddr := ds.DevAddr
+		if err := f(&ds); err != nil {
+			c.Do("UNWATCH")
+			return DeviceSession{}, err
+		}
+		if ds.DevEUI != devEUI || ds.DevAddr != devAddr {
+			c.Do("UNWATCH")
+			return DeviceSession{}, errors.New("DevEUI and DevAddr can not be updated")
+		}
+
+		dsPB := deviceSessionToPB(ds)
+		b, err := proto.Marshal(&dsPB)
+		if err != nil {
+			c.Do("UNWATCH")
+			return DeviceSession{}, errors.Wrap(err, "protobuf encode error")
+		}
+
+		c.Send("MULTI")
+		sendSaveDeviceSession(c, ds, b)
+		reply, err := c.Do("EXEC")
+		redisLocalCache.remove(key)
+		if err != nil {
+			return DeviceSession{}, errors.Wrap(err, "exec error")
+		}
+		if reply == nil {
+			// the device-session was modified after it was read
+			continue
+		}
+
+		if err := saveDevAddrIndex(p, ds); err != nil {
+			return DeviceSession{}, err
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":  ds.DevEUI,
+			"dev_addr": ds.DevAddr,
+			"ctx_id":   ctx.Value(logging.ContextIDKey),
+		}).Info("device-session updated")
 
+		return ds, nil
+	}
+
+	return DeviceSession{}, ErrDeviceSessionChanged
+}
+
+// GetDeviceSession returns the device-session for the given DevEUI.
+func GetDeviceSession(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) (DeviceSession, error) {
 	c := p.Get()
 	defer c.Close()
 
+	val, err := getCached(c, fmt.Sprintf(deviceSessionKeyTempl, devEUI))
+	if err != nil {
+		if err == redis.ErrNil {
+			return DeviceSession{}, ErrDoesNotExist
+		}
+		return DeviceSession{}, errors.Wrap(err, "get error")
+	}
+
+	ds, err := decodeDeviceSession(val)
+	if err != nil {
+		return ds, err
+	}
+
+	if err := unwrapNwkSKeys(ctx, &ds); err != nil {
+		return DeviceSession{}, errors.Wrap(err, "unwrap network session-keys error")
+	}
+
+	return ds, nil
+}
+
+// getDeviceSession returns the device-session for the given DevEUI. It does
+// not use the local cache, as it is used within WATCH / MULTI / EXEC blocks.
+func getDeviceSession(c redis.Conn, devEUI lorawan.EUI64) (DeviceSession, error) {
 	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(deviceSessionKeyTempl, devEUI)))
 	if err != nil {
 		if err == redis.ErrNil {
@@ -340,7 +502,13 @@ func GetDeviceSession(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64)
 		return DeviceSession{}, errors.Wrap(err, "get error")
 	}
 
-	err = proto.Unmarshal(val, &dsPB)
+	return decodeDeviceSession(val)
+}
+
+func decodeDeviceSession(val []byte) (DeviceSession, error) {
+	var dsPB DeviceSessionPB
+
+	err := proto.Unmarshal(val, &dsPB)
 	if err != nil {
 		// fallback on old gob encoding
 		var dsOld DeviceSessionOld
@@ -355,12 +523,52 @@ func GetDeviceSession(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64)
 	return deviceSessionFromPB(dsPB), nil
 }
 
+// sendSaveDeviceSession sends the commands for saving the given (encoded)
+// device-session. This must be called within a MULTI / EXEC block.
+func sendSaveDeviceSession(c redis.Conn, s DeviceSession, b []byte) {
+	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
+
+	c.Send("PSETEX", fmt.Sprintf(deviceSessionKeyTempl, s.DevEUI), exp, b)
+}
+
+// saveDevAddrIndex adds the DevEUI of the given device-session to the DevAddr
+// set(s). As these sets are not stored in the same (Redis Cluster) slot as
+// the device-session, this is done after the device-session has been saved.
+// This order makes sure that DeleteStaleDevAddrMembers never removes the
+// DevEUI of a saved device-session permanently.
+func saveDevAddrIndex(p RedisClient, s DeviceSession) error {
+	c := p.Get()
+	defer c.Close()
+
+	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
+
+	devAddrs := []lorawan.DevAddr{s.DevAddr}
+	if s.PendingRejoinDeviceSession != nil {
+		devAddrs = append(devAddrs, s.PendingRejoinDeviceSession.DevAddr)
+	}
+
+	for _, devAddr := range devAddrs {
+		key := fmt.Sprintf(devAddrKeyTempl, devAddr)
+
+		c.Send("MULTI")
+		c.Send("SADD", key, s.DevEUI[:])
+		c.Send("PEXPIRE", key, exp)
+		if _, err := c.Do("EXEC"); err != nil {
+			return errors.Wrap(err, "save devaddr index error")
+		}
+	}
+
+	return nil
+}
+
 // DeleteDeviceSession deletes the device-session matching the given DevEUI.
-func DeleteDeviceSession(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) error {
+func DeleteDeviceSession(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) error {
 	c := p.Get()
 	defer c.Close()
 
-	val, err := redis.Int(c.Do("DEL", fmt.Sprintf(deviceSessionKeyTempl, devEUI)))
+	key := fmt.Sprintf(deviceSessionKeyTempl, devEUI)
+	val, err := redis.Int(c.Do("DEL", key))
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "delete error")
 	}
@@ -377,7 +585,7 @@ func DeleteDeviceSession(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI6
 // GetDeviceSessionsForDevAddr returns a slice of device-sessions using the
 // given DevAddr. When no device-session is using the given DevAddr, this returns
 // an empty slice.
-func GetDeviceSessionsForDevAddr(ctx context.Context, p *redis.Pool, devAddr lorawan.DevAddr) ([]DeviceSession, error) {
+func GetDeviceSessionsForDevAddr(ctx context.Context, p RedisClient, devAddr lorawan.DevAddr) ([]DeviceSession, error) {
 	var items []DeviceSession
 
 	c := p.Get()
@@ -422,34 +630,130 @@ func GetDeviceSessionsForDevAddr(ctx context.Context, p *redis.Pool, devAddr lor
 	return items, nil
 }
 
+// DeleteStaleDevAddrMembers removes the DevEUIs from the DevAddr sets for
+// which the device-session does not exist anymore, or is no longer using
+// the DevAddr (e.g. after a re-join). As the expiration of these sets is
+// extended each time a device-session is saved, stale members would
+// otherwise remain as long as the DevAddr is in use. It returns the number
+// of removed members.
+func DeleteStaleDevAddrMembers(ctx context.Context, p RedisClient) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	keys, err := ScanKeys(p, fmt.Sprintf(devAddrKeyTempl, "*"))
+	if err != nil {
+		return 0, errors.Wrap(err, "scan devaddr keys error")
+	}
+
+	var removed int
+	for _, key := range keys {
+		var devAddr lorawan.DevAddr
+		if err := devAddr.UnmarshalText([]byte(strings.TrimPrefix(key, fmt.Sprintf(devAddrKeyTempl, "")))); err != nil {
+			log.WithError(err).WithField("key", key).Warning("storage: decode devaddr key error")
+			continue
+		}
+
+		members, err := redis.ByteSlices(c.Do("SMEMBERS", key))
+		if err != nil {
+			return removed, errors.Wrap(err, "get members error")
+		}
+
+		for _, b := range members {
+			var devEUI lorawan.EUI64
+			copy(devEUI[:], b)
+
+			s, err := getDeviceSession(c, devEUI)
+			if err != nil && err != ErrDoesNotExist {
+				return removed, errors.Wrap(err, "get device-session error")
+			}
+			if err == nil && deviceSessionUsesDevAddr(s, devAddr) {
+				continue
+			}
+
+			if _, err := c.Do("SREM", key, b); err != nil {
+				return removed, errors.Wrap(err, "remove member error")
+			}
+
+			// the device-session and DevAddr set are not stored in the same
+			// (Redis Cluster) slot, thus these can not be watched within a
+			// single transaction. The device-session is read again, to restore
+			// the member in case it was saved concurrently.
+			s, err = getDeviceSession(c, devEUI)
+			if err != nil && err != ErrDoesNotExist {
+				return removed, errors.Wrap(err, "get device-session error")
+			}
+			if err == nil && deviceSessionUsesDevAddr(s, devAddr) {
+				if err := saveDevAddrIndex(p, s); err != nil {
+					return removed, err
+				}
+				continue
+			}
+			removed++
+
+			log.WithFields(log.Fields{
+				"dev_addr": devAddr,
+				"dev_eui":  devEUI,
+				"ctx_id":   ctx.Value(logging.ContextIDKey),
+			}).Debug("storage: stale devaddr member removed")
+		}
+	}
+
+	return removed, nil
+}
+
+// deviceSessionUsesDevAddr returns true when the given device-session (or its
+// pending rejoin device-session) uses the given DevAddr.
+func deviceSessionUsesDevAddr(s DeviceSession, devAddr lorawan.DevAddr) bool {
+	return s.DevAddr == devAddr || (s.PendingRejoinDeviceSession != nil && s.PendingRejoinDeviceSession.DevAddr == devAddr)
+}
+
+// FCntReset holds the uplink frame-counter reset of a device-session.
+type FCntReset struct {
+	FCnt         uint32
+	PreviousFCnt uint32
+}
+
 // GetDeviceSessionForPHYPayload returns the device-session matching the given
 // PHYPayload. This will fetch all device-sessions associated with the used
 // DevAddr and based on FCnt and MIC decide which one to use.
-func GetDeviceSessionForPHYPayload(ctx context.Context, p *redis.Pool, phy lorawan.PHYPayload, txDR, txCh int) (DeviceSession, error) {
+// When the uplink frame-counter of the returned device-session has been
+// reset, the reset is returned so that the caller can notify about it.
+func GetDeviceSessionForPHYPayload(ctx context.Context, p RedisClient, phy lorawan.PHYPayload, txDR, txCh int) (DeviceSession, *FCntReset, error) {
 	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
 	if !ok {
-		return DeviceSession{}, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+		return DeviceSession{}, nil, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
 	}
 	originalFCnt := macPL.FHDR.FCnt
 
 	sessions, err := GetDeviceSessionsForDevAddr(ctx, p, macPL.FHDR.DevAddr)
 	if err != nil {
-		return DeviceSession{}, err
+		return DeviceSession{}, nil, err
 	}
 
 	for _, s := range sessions {
+		dp, err := getDeviceProfileForFCntValidation(ctx, p, s)
+		if err != nil {
+			return DeviceSession{}, nil, err
+		}
+
 		// reset to the original FCnt
 		macPL.FHDR.FCnt = originalFCnt
 		// get full FCnt
-		fullFCnt, ok := ValidateAndGetFullFCntUp(s, macPL.FHDR.FCnt)
+		fullFCnt, ok := ValidateAndGetFullFCntUpForDeviceProfile(s, dp, macPL.FHDR.FCnt)
 		if !ok {
-			// If RelaxFCnt is turned on, just trust the uplink FCnt
-			// this is insecure, but has been requested by many people for
-			// debugging purposes.
+			// If RelaxFCnt is turned on, just trust the uplink FCnt. When the
+			// device-profile uses the relaxed validation mode, only trust the
+			// uplink FCnt when it looks like a reset.
+			// This is insecure, but has been requested by many people for
+			// debugging purposes and for devices which reset their
+			// frame-counter on a power-cycle.
 			// Note that we do not reset the FCntDown as this would reset the
 			// downlink frame-counter on a re-transmit, which is not what we
 			// want.
-			if s.SkipFCntValidation {
+			// In certification test mode, the device resets its frame-counter
+			// on a DutResetReq.
+			if IsFCntResetForDeviceProfile(s, dp, macPL.FHDR.FCnt) {
+				previousFCnt := s.FCntUp
 				fullFCnt = macPL.FHDR.FCnt
 				s.FCntUp = macPL.FHDR.FCnt
 				s.UplinkHistory = []UplinkHistory{}
@@ -459,20 +763,25 @@ func GetDeviceSessionForPHYPayload(ctx context.Context, p *redis.Pool, phy loraw
 				// function will only use it when the ACK bit is set
 				micOK, err := phy.ValidateUplinkDataMIC(s.GetMACVersion(), s.ConfFCnt, uint8(txDR), uint8(txCh), s.FNwkSIntKey, s.SNwkSIntKey)
 				if err != nil {
-					return DeviceSession{}, errors.Wrap(err, "validate mic error")
+					return DeviceSession{}, nil, errors.Wrap(err, "validate mic error")
 				}
 
 				if micOK {
 					// we need to update the NodeSession
 					if err := SaveDeviceSession(ctx, p, s); err != nil {
-						return DeviceSession{}, err
+						return DeviceSession{}, nil, err
 					}
 					log.WithFields(log.Fields{
-						"dev_addr": macPL.FHDR.DevAddr,
-						"dev_eui":  s.DevEUI,
-						"ctx_id":   ctx.Value(logging.ContextIDKey),
+						"dev_addr":      macPL.FHDR.DevAddr,
+						"dev_eui":       s.DevEUI,
+						"fcnt":          fullFCnt,
+						"previous_fcnt": previousFCnt,
+						"ctx_id":        ctx.Value(logging.ContextIDKey),
 					}).Warning("frame counters reset")
-					return s, nil
+					return s, &FCntReset{
+						FCnt:         fullFCnt,
+						PreviousFCnt: previousFCnt,
+					}, nil
 				}
 			}
 			// try the next node-session
@@ -483,18 +792,33 @@ func GetDeviceSessionForPHYPayload(ctx context.Context, p *redis.Pool, phy loraw
 		macPL.FHDR.FCnt = fullFCnt
 		micOK, err := phy.ValidateUplinkDataMIC(s.GetMACVersion(), s.ConfFCnt, uint8(txDR), uint8(txCh), s.FNwkSIntKey, s.SNwkSIntKey)
 		if err != nil {
-			return DeviceSession{}, errors.Wrap(err, "validate mic error")
+			return DeviceSession{}, nil, errors.Wrap(err, "validate mic error")
 		}
 		if micOK {
-			return s, nil
+			return s, nil, nil
 		}
 	}
 
-	return DeviceSession{}, ErrDoesNotExistOrFCntOrMICInvalid
+	return DeviceSession{}, nil, ErrDoesNotExistOrFCntOrMICInvalid
+}
+
+// getDeviceProfileForFCntValidation returns the device-profile of the given
+// device-session. When the device-profile does not exist, an empty
+// device-profile is returned, which results in the strict validation mode.
+func getDeviceProfileForFCntValidation(ctx context.Context, p RedisClient, s DeviceSession) (DeviceProfile, error) {
+	dp, err := GetAndCacheDeviceProfile(ctx, DB(), p, s.DeviceProfileID)
+	if err != nil {
+		if errors.Cause(err) == ErrDoesNotExist {
+			return DeviceProfile{}, nil
+		}
+		return DeviceProfile{}, errors.Wrap(err, "get device-profile error")
+	}
+
+	return dp, nil
 }
 
 // DeviceSessionExists returns a bool indicating if a device session exist.
-func DeviceSessionExists(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
+func DeviceSessionExists(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) (bool, error) {
 	c := p.Get()
 	defer c.Close()
 
@@ -509,7 +833,7 @@ func DeviceSessionExists(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI6
 }
 
 // SaveDeviceGatewayRXInfoSet saves the given DeviceGatewayRXInfoSet.
-func SaveDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, rxInfoSet DeviceGatewayRXInfoSet) error {
+func SaveDeviceGatewayRXInfoSet(ctx context.Context, p RedisClient, rxInfoSet DeviceGatewayRXInfoSet) error {
 	rxInfoSetPB := deviceGatewayRXInfoSetToPB(rxInfoSet)
 	b, err := proto.Marshal(&rxInfoSetPB)
 	if err != nil {
@@ -534,7 +858,7 @@ func SaveDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, rxInfoSet De
 
 // DeleteDeviceGatewayRXInfoSet deletes the device gateway rx-info meta-data
 // for the given Device EUI.
-func DeleteDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) error {
+func DeleteDeviceGatewayRXInfoSet(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) error {
 	c := p.Get()
 	defer c.Close()
 
@@ -554,7 +878,7 @@ func DeleteDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, devEUI lor
 
 // GetDeviceGatewayRXInfoSet returns the DeviceGatewayRXInfoSet for the given
 // Device EUI.
-func GetDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) (DeviceGatewayRXInfoSet, error) {
+func GetDeviceGatewayRXInfoSet(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) (DeviceGatewayRXInfoSet, error) {
 
 	var rxInfoSetPB DeviceGatewayRXInfoSetPB
 
@@ -579,20 +903,17 @@ func GetDeviceGatewayRXInfoSet(ctx context.Context, p *redis.Pool, devEUI lorawa
 
 // GetDeviceGatewayRXInfoSetForDevEUIs returns the DeviceGatewayRXInfoSet
 // objects for the given Device EUIs.
-func GetDeviceGatewayRXInfoSetForDevEUIs(ctx context.Context, p *redis.Pool, devEUIs []lorawan.EUI64) ([]DeviceGatewayRXInfoSet, error) {
+func GetDeviceGatewayRXInfoSetForDevEUIs(ctx context.Context, p RedisClient, devEUIs []lorawan.EUI64) ([]DeviceGatewayRXInfoSet, error) {
 	if len(devEUIs) == 0 {
 		return nil, nil
 	}
 
-	var keys []interface{}
+	var keys []string
 	for _, d := range devEUIs {
 		keys = append(keys, fmt.Sprintf(deviceGatewayRXInfoSetKeyTempl, d))
 	}
 
-	c := p.Get()
-	defer c.Close()
-
-	bs, err := redis.ByteSlices(c.Do("MGET", keys...))
+	bs, err := redis.ByteSlices(mget(p, keys))
 	if err != nil {
 		return nil, errors.Wrap(err, "get byte slices error")
 	}
@@ -638,6 +959,10 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		ConfFCnt:      d.ConfFCnt,
 		SkipFCntCheck: d.SkipFCntValidation,
 
+		CertificationTestMode:    d.CertificationTestMode,
+		CertificationActivated:   d.CertificationActivated,
+		CertificationEchoPayload: d.CertificationEchoPayload,
+
 		RxDelay:      uint32(d.RXDelay),
 		Rx1DrOffset:  uint32(d.RX1DROffset),
 		Rx2Dr:        uint32(d.RX2DR),
@@ -670,6 +995,12 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		UplinkDwellTime_400Ms:   d.UplinkDwellTime400ms,
 		DownlinkDwellTime_400Ms: d.DownlinkDwellTime400ms,
 		UplinkMaxEirpIndex:      uint32(d.UplinkMaxEIRPIndex),
+
+		Region: d.Region,
+	}
+
+	for _, subBand := range d.SubBands {
+		out.SubBands = append(out.SubBands, uint32(subBand))
 	}
 
 	if d.AppSKeyEvelope != nil {
@@ -679,6 +1010,23 @@ func deviceSessionToPB(d DeviceSession) DeviceSessionPB {
 		}
 	}
 
+	// the plain network session-keys are not stored when wrapped
+	if d.FNwkSIntKeyEnvelope != nil {
+		out.FNwkSIntKey = nil
+		out.FNwkSIntKeyEnvelope = keyEnvelopeToPB(d.FNwkSIntKeyEnvelope)
+	}
+	if d.SNwkSIntKeyEnvelope != nil {
+		out.SNwkSIntKey = nil
+		out.SNwkSIntKeyEnvelope = keyEnvelopeToPB(d.SNwkSIntKeyEnvelope)
+	}
+	if d.NwkSEncKeyEnvelope != nil {
+		out.NwkSEncKey = nil
+		out.NwkSEncKeyEnvelope = keyEnvelopeToPB(d.NwkSEncKeyEnvelope)
+	}
+	if d.RetainedAppSKeyEnvelope != nil {
+		out.RetainedAppSKeyEnvelope = keyEnvelopeToPB(d.RetainedAppSKeyEnvelope)
+	}
+
 	for _, c := range d.EnabledUplinkChannels {
 		out.EnabledUplinkChannels = append(out.EnabledUplinkChannels, uint32(c))
 	}
@@ -735,6 +1083,10 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		ConfFCnt:           d.ConfFCnt,
 		SkipFCntValidation: d.SkipFCntCheck,
 
+		CertificationTestMode:    d.CertificationTestMode,
+		CertificationActivated:   d.CertificationActivated,
+		CertificationEchoPayload: d.CertificationEchoPayload,
+
 		RXDelay:      uint8(d.RxDelay),
 		RX1DROffset:  uint8(d.Rx1DrOffset),
 		RX2DR:        uint8(d.Rx2Dr),
@@ -764,6 +1116,12 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		UplinkDwellTime400ms:   d.UplinkDwellTime_400Ms,
 		DownlinkDwellTime400ms: d.DownlinkDwellTime_400Ms,
 		UplinkMaxEIRPIndex:     uint8(d.UplinkMaxEirpIndex),
+
+		Region: d.Region,
+	}
+
+	for _, subBand := range d.SubBands {
+		out.SubBands = append(out.SubBands, int(subBand))
 	}
 
 	if d.LastDeviceStatusRequestTimeUnixNs > 0 {
@@ -788,6 +1146,11 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 		}
 	}
 
+	out.FNwkSIntKeyEnvelope = keyEnvelopeFromPB(d.FNwkSIntKeyEnvelope)
+	out.SNwkSIntKeyEnvelope = keyEnvelopeFromPB(d.SNwkSIntKeyEnvelope)
+	out.NwkSEncKeyEnvelope = keyEnvelopeFromPB(d.NwkSEncKeyEnvelope)
+	out.RetainedAppSKeyEnvelope = keyEnvelopeFromPB(d.RetainedAppSKeyEnvelope)
+
 	for _, c := range d.EnabledUplinkChannels {
 		out.EnabledUplinkChannels = append(out.EnabledUplinkChannels, int(c))
 	}
@@ -826,6 +1189,75 @@ func deviceSessionFromPB(d DeviceSessionPB) DeviceSession {
 	return out
 }
 
+func keyEnvelopeToPB(ke *KeyEnvelope) *common.KeyEnvelope {
+	return &common.KeyEnvelope{
+		KekLabel: ke.KEKLabel,
+		AesKey:   ke.AESKey,
+	}
+}
+
+func keyEnvelopeFromPB(ke *common.KeyEnvelope) *KeyEnvelope {
+	if ke == nil {
+		return nil
+	}
+
+	return &KeyEnvelope{
+		KEKLabel: ke.KekLabel,
+		AESKey:   ke.AesKey,
+	}
+}
+
+// GetAppSKey returns the (unwrapped) AppSKey of the given device-session.
+// This is either the AppSKey which has not yet been forwarded to the
+// application-server, or the retained AppSKey. It returns ErrDoesNotExist
+// when the AppSKey is not available to the network-server.
+func GetAppSKey(ctx context.Context, ds DeviceSession) (lorawan.AES128Key, error) {
+	ke := ds.AppSKeyEvelope
+	if ke == nil {
+		ke = ds.RetainedAppSKeyEnvelope
+	}
+	if ke == nil {
+		return lorawan.AES128Key{}, ErrDoesNotExist
+	}
+
+	return kek.Unwrap(ctx, ds.DevEUI, ds.JoinEUI, &backend.KeyEnvelope{
+		KEKLabel: ke.KEKLabel,
+		AESKey:   ke.AESKey,
+	})
+}
+
+// unwrapNwkSKeys unwraps the wrapped network session-keys of the given
+// device-session (and its pending rejoin device-session).
+func unwrapNwkSKeys(ctx context.Context, ds *DeviceSession) error {
+	for _, k := range []struct {
+		envelope *KeyEnvelope
+		key      *lorawan.AES128Key
+	}{
+		{ds.FNwkSIntKeyEnvelope, &ds.FNwkSIntKey},
+		{ds.SNwkSIntKeyEnvelope, &ds.SNwkSIntKey},
+		{ds.NwkSEncKeyEnvelope, &ds.NwkSEncKey},
+	} {
+		if k.envelope == nil {
+			continue
+		}
+
+		key, err := kek.Unwrap(ctx, ds.DevEUI, ds.JoinEUI, &backend.KeyEnvelope{
+			KEKLabel: k.envelope.KEKLabel,
+			AESKey:   k.envelope.AESKey,
+		})
+		if err != nil {
+			return err
+		}
+		*k.key = key
+	}
+
+	if ds.PendingRejoinDeviceSession != nil {
+		return unwrapNwkSKeys(ctx, ds.PendingRejoinDeviceSession)
+	}
+
+	return nil
+}
+
 func deviceGatewayRXInfoSetToPB(d DeviceGatewayRXInfoSet) DeviceGatewayRXInfoSetPB {
 	out := DeviceGatewayRXInfoSetPB{
 		DevEui: d.DevEUI[:],
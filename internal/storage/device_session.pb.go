@@ -250,10 +250,29 @@ type DeviceSessionPB struct {
 	// DownlinkDwellTime.
 	DownlinkDwellTime_400Ms bool `protobuf:"varint,48,opt,name=downlink_dwell_time_400ms,json=downlinkDwellTime400ms,proto3" json:"downlink_dwell_time_400ms,omitempty"`
 	// Uplink max. EIRP index.
-	UplinkMaxEirpIndex   uint32   `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	UplinkMaxEirpIndex uint32 `protobuf:"varint,49,opt,name=uplink_max_eirp_index,json=uplinkMaxEirpIndex,proto3" json:"uplink_max_eirp_index,omitempty"`
+	// Region (empty for the default region).
+	Region string `protobuf:"bytes,50,opt,name=region,proto3" json:"region,omitempty"`
+	// Sub-bands of the gateways receiving the device.
+	SubBands []uint32 `protobuf:"varint,51,rep,packed,name=sub_bands,json=subBands,proto3" json:"sub_bands,omitempty"`
+	// Wrapped FNwkSIntKey (when set, f_nwk_s_int_key is not stored).
+	FNwkSIntKeyEnvelope *common.KeyEnvelope `protobuf:"bytes,52,opt,name=f_nwk_s_int_key_envelope,json=fNwkSIntKeyEnvelope,proto3" json:"f_nwk_s_int_key_envelope,omitempty"`
+	// Wrapped SNwkSIntKey (when set, s_nwk_s_int_key is not stored).
+	SNwkSIntKeyEnvelope *common.KeyEnvelope `protobuf:"bytes,53,opt,name=s_nwk_s_int_key_envelope,json=sNwkSIntKeyEnvelope,proto3" json:"s_nwk_s_int_key_envelope,omitempty"`
+	// Wrapped NwkSEncKey (when set, nwk_s_enc_key is not stored).
+	NwkSEncKeyEnvelope *common.KeyEnvelope `protobuf:"bytes,54,opt,name=nwk_s_enc_key_envelope,json=nwkSEncKeyEnvelope,proto3" json:"nwk_s_enc_key_envelope,omitempty"`
+	// AppSKey retained by the network-server for handling the application
+	// layer packages of the device (e.g. clock synchronization).
+	RetainedAppSKeyEnvelope *common.KeyEnvelope `protobuf:"bytes,55,opt,name=retained_app_s_key_envelope,json=retainedAppSKeyEnvelope,proto3" json:"retained_app_s_key_envelope,omitempty"`
+	// LoRaWAN certification test mode is enabled for the device.
+	CertificationTestMode bool `protobuf:"varint,56,opt,name=certification_test_mode,json=certificationTestMode,proto3" json:"certification_test_mode,omitempty"`
+	// Certification package of the device has been activated.
+	CertificationActivated bool `protobuf:"varint,57,opt,name=certification_activated,json=certificationActivated,proto3" json:"certification_activated,omitempty"`
+	// Payload of the pending EchoIncPayloadReq.
+	CertificationEchoPayload []byte   `protobuf:"bytes,58,opt,name=certification_echo_payload,json=certificationEchoPayload,proto3" json:"certification_echo_payload,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
 }
 
 func (m *DeviceSessionPB) Reset()         { *m = DeviceSessionPB{} }
@@ -596,6 +615,69 @@ func (m *DeviceSessionPB) GetUplinkMaxEirpIndex() uint32 {
 	return 0
 }
 
+func (m *DeviceSessionPB) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *DeviceSessionPB) GetSubBands() []uint32 {
+	if m != nil {
+		return m.SubBands
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetFNwkSIntKeyEnvelope() *common.KeyEnvelope {
+	if m != nil {
+		return m.FNwkSIntKeyEnvelope
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetSNwkSIntKeyEnvelope() *common.KeyEnvelope {
+	if m != nil {
+		return m.SNwkSIntKeyEnvelope
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetNwkSEncKeyEnvelope() *common.KeyEnvelope {
+	if m != nil {
+		return m.NwkSEncKeyEnvelope
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetRetainedAppSKeyEnvelope() *common.KeyEnvelope {
+	if m != nil {
+		return m.RetainedAppSKeyEnvelope
+	}
+	return nil
+}
+
+func (m *DeviceSessionPB) GetCertificationTestMode() bool {
+	if m != nil {
+		return m.CertificationTestMode
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetCertificationActivated() bool {
+	if m != nil {
+		return m.CertificationActivated
+	}
+	return false
+}
+
+func (m *DeviceSessionPB) GetCertificationEchoPayload() []byte {
+	if m != nil {
+		return m.CertificationEchoPayload
+	}
+	return nil
+}
+
 type DeviceGatewayRXInfoSetPB struct {
 	// Device EUI.
 	DevEui []byte `protobuf:"bytes,1,opt,name=dev_eui,json=devEui,proto3" json:"dev_eui,omitempty"`
@@ -751,88 +833,99 @@ func init() {
 func init() { proto.RegisterFile("device_session.proto", fileDescriptor_958563bbc6ebadf7) }
 
 var fileDescriptor_958563bbc6ebadf7 = []byte{
-	// 1326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x56, 0xed, 0x56, 0x1b, 0x37,
-	0x13, 0x3e, 0xc6, 0xe1, 0x6b, 0xc0, 0x01, 0xc4, 0x97, 0xe0, 0x0d, 0x2f, 0x8e, 0x93, 0xf7, 0x8d,
-	0x9b, 0x26, 0x04, 0x68, 0xd2, 0x93, 0xe6, 0x47, 0x4f, 0x09, 0x26, 0x2d, 0x27, 0x0d, 0xe5, 0x2c,
-	0x24, 0xa7, 0xff, 0x74, 0xe4, 0x95, 0x4c, 0x54, 0xaf, 0xb5, 0x5b, 0xad, 0x8c, 0xd7, 0xb7, 0xd2,
-	0x9b, 0xe8, 0x95, 0xf5, 0x1e, 0x7a, 0x34, 0x92, 0x6d, 0x6c, 0xe0, 0x97, 0xad, 0xe7, 0x79, 0x66,
-	0xa4, 0x1d, 0xcd, 0x8c, 0x06, 0xd6, 0x84, 0xbc, 0x56, 0xb1, 0x64, 0xb9, 0xcc, 0x73, 0x95, 0xea,
-	0xbd, 0xcc, 0xa4, 0x36, 0x25, 0xb3, 0xb9, 0x4d, 0x0d, 0xbf, 0x92, 0xdb, 0x9b, 0x3c, 0x53, 0xaf,
-	0xe2, 0xb4, 0xd3, 0x49, 0x75, 0xf8, 0xf1, 0x8a, 0x9a, 0x80, 0x8d, 0x06, 0x5a, 0x5e, 0x78, 0xc3,
-	0xf3, 0xf7, 0xc7, 0x5f, 0xb9, 0xd6, 0x32, 0x21, 0x8f, 0x60, 0xbe, 0x65, 0xe4, 0x9f, 0x5d, 0xa9,
-	0xe3, 0x3e, 0x2d, 0x55, 0x4b, 0xf5, 0x4a, 0x34, 0x02, 0xc8, 0x3a, 0xcc, 0x74, 0x94, 0x66, 0xc2,
-	0xd0, 0x29, 0xa4, 0xa6, 0x3b, 0x4a, 0x37, 0x0c, 0xc2, 0xbc, 0x70, 0x70, 0x39, 0xc0, 0xbc, 0x68,
-	0x98, 0xda, 0x5f, 0x25, 0xd8, 0x9d, 0xd8, 0xe6, 0x73, 0x96, 0x28, 0xdd, 0x3e, 0x6a, 0x44, 0xbf,
-	0x28, 0x77, 0xc8, 0x3e, 0x59, 0x85, 0xe9, 0x16, 0x8b, 0xb5, 0x0d, 0x7b, 0x3d, 0x68, 0x1d, 0x6b,
-	0x4b, 0x36, 0x61, 0xd6, 0xf9, 0xcb, 0xb5, 0xdf, 0x67, 0x2a, 0x72, 0xee, 0x2f, 0xb4, 0x21, 0x4f,
-	0xe1, 0xa1, 0x2d, 0x58, 0x96, 0xf6, 0xa4, 0x61, 0x4a, 0x0b, 0x59, 0x84, 0x0d, 0x17, 0x6d, 0x71,
-	0xee, 0xc0, 0x53, 0x87, 0x91, 0x27, 0x50, 0xb9, 0xe2, 0x56, 0xf6, 0x78, 0x9f, 0xc5, 0x69, 0x57,
-	0x5b, 0xfa, 0xc0, 0x8b, 0x02, 0x78, 0xec, 0xb0, 0xda, 0x3f, 0xcb, 0xb0, 0x34, 0x71, 0x38, 0xf2,
-	0x1c, 0x56, 0x42, 0x40, 0x33, 0x93, 0xb6, 0x54, 0x22, 0x99, 0x12, 0x78, 0xb0, 0xf9, 0x68, 0xc9,
-	0x13, 0xe7, 0x1e, 0x3f, 0x15, 0xe4, 0x05, 0x90, 0x5c, 0x9a, 0x49, 0xf1, 0x14, 0x8a, 0x97, 0x03,
-	0x33, 0xa6, 0x36, 0x69, 0xd7, 0x2a, 0x7d, 0x75, 0x53, 0x5d, 0xf6, 0xea, 0xc0, 0x8c, 0xd4, 0x5b,
-	0x30, 0x27, 0xe4, 0x35, 0xe3, 0x42, 0x18, 0x3c, 0xfb, 0x62, 0x34, 0x2b, 0xe4, 0xf5, 0x91, 0x10,
-	0xc6, 0x85, 0xc6, 0x51, 0xb2, 0xab, 0xe8, 0x34, 0x32, 0x33, 0x42, 0x5e, 0x9f, 0x74, 0x95, 0xb3,
-	0xf9, 0x23, 0x55, 0x1a, 0x99, 0x19, 0x6f, 0xe3, 0xd6, 0x8e, 0x7a, 0x0a, 0x4b, 0x2d, 0xa6, 0x7b,
-	0x6d, 0x96, 0x33, 0xa5, 0x2d, 0x6b, 0xcb, 0x3e, 0x9d, 0x45, 0xc5, 0x42, 0xeb, 0xac, 0xd7, 0xbe,
-	0x38, 0xd5, 0xf6, 0xa3, 0xec, 0x3b, 0x55, 0x3e, 0xa1, 0x9a, 0xf3, 0xaa, 0xfc, 0x86, 0xea, 0x31,
-	0x54, 0xbc, 0x46, 0xea, 0x18, 0x35, 0xf3, 0xa8, 0x01, 0xdd, 0x6b, 0x5f, 0x9c, 0xe8, 0xd8, 0x49,
-	0x7e, 0x02, 0xc2, 0xb3, 0x8c, 0xe5, 0x8e, 0x66, 0x52, 0x5f, 0xcb, 0x24, 0xcd, 0x24, 0x7d, 0x59,
-	0x2d, 0xd5, 0x17, 0x0e, 0x57, 0xf7, 0x42, 0x1e, 0x7e, 0x94, 0xfd, 0x93, 0x40, 0x45, 0x4b, 0x3c,
-	0xcb, 0x2e, 0x6e, 0x00, 0x84, 0xc2, 0x1c, 0x26, 0x05, 0xeb, 0x66, 0x14, 0xf0, 0xee, 0x66, 0x5c,
-	0x5e, 0x7c, 0xce, 0xc8, 0x2e, 0x2c, 0x6a, 0xe6, 0x39, 0x91, 0xf6, 0x34, 0x5d, 0xf0, 0x19, 0xaa,
-	0x3f, 0x1c, 0x6b, 0xdb, 0x48, 0x7b, 0xda, 0x09, 0xf8, 0x4d, 0xc1, 0xa2, 0x17, 0xf0, 0xa1, 0xe0,
-	0x11, 0x40, 0x9c, 0xea, 0x96, 0xd7, 0xd0, 0x67, 0x48, 0xcf, 0x39, 0xc4, 0x29, 0xc8, 0x33, 0x58,
-	0xce, 0xdb, 0x2a, 0x0b, 0x1e, 0xe2, 0xaf, 0x32, 0x6e, 0xd3, 0x4a, 0xb5, 0x54, 0x9f, 0x8b, 0x2a,
-	0x0e, 0x77, 0x9a, 0x63, 0x07, 0xba, 0x70, 0x9b, 0x82, 0x09, 0x99, 0xf0, 0x3e, 0x7d, 0x88, 0x4e,
-	0x66, 0x4d, 0xd1, 0x70, 0x4b, 0x52, 0x83, 0x8a, 0x29, 0x0e, 0x98, 0x30, 0x2c, 0x6d, 0xb5, 0x72,
-	0x69, 0xe9, 0x12, 0xf2, 0x0b, 0xa6, 0x38, 0x68, 0x98, 0xdf, 0x10, 0x72, 0x15, 0x63, 0x8a, 0x43,
-	0x57, 0x31, 0xcb, 0xbe, 0x62, 0x4c, 0x71, 0xd8, 0x30, 0x2e, 0x73, 0x1d, 0x3c, 0xaa, 0xc0, 0x15,
-	0x9f, 0xb9, 0xa6, 0x38, 0xfc, 0x30, 0x2c, 0xc2, 0xdb, 0x45, 0x40, 0xee, 0x28, 0x82, 0x87, 0x30,
-	0x25, 0x0c, 0x5d, 0x45, 0x66, 0x4a, 0x18, 0xb2, 0x0c, 0x65, 0x2e, 0x0c, 0x5d, 0xc3, 0x8f, 0x71,
-	0x7f, 0xc9, 0x8f, 0xf0, 0x08, 0xab, 0xac, 0x9b, 0x65, 0xa9, 0xb1, 0x52, 0xb0, 0x09, 0xaf, 0xeb,
-	0x68, 0x4b, 0x5d, 0xe9, 0x0d, 0x24, 0x97, 0x37, 0x77, 0xd8, 0x82, 0x39, 0xdd, 0x64, 0xd6, 0x70,
-	0x9d, 0xd3, 0x4d, 0x1f, 0x02, 0xdd, 0xbc, 0x74, 0x4b, 0xf2, 0x3d, 0x6c, 0x4a, 0xcd, 0x9b, 0x89,
-	0x14, 0xac, 0x8b, 0x15, 0xcf, 0x62, 0xdf, 0x5f, 0x72, 0x4a, 0xab, 0xe5, 0x7a, 0x25, 0x5a, 0x0f,
-	0xb4, 0xef, 0x07, 0xa1, 0xf9, 0xe4, 0x44, 0xc2, 0xba, 0x2c, 0xac, 0xe1, 0xb7, 0xac, 0xb6, 0xaa,
-	0xe5, 0xfa, 0xc2, 0xe1, 0xc1, 0x5e, 0xe8, 0x6c, 0x7b, 0x13, 0x95, 0xbb, 0x77, 0xe2, 0xac, 0xc6,
-	0x9d, 0x9d, 0x68, 0x6b, 0xfa, 0xd1, 0xaa, 0xbc, 0xcd, 0x90, 0x57, 0xb0, 0x1a, 0x3c, 0x0f, 0x43,
-	0xad, 0x64, 0x4e, 0xb7, 0xf1, 0x68, 0x24, 0x50, 0x1f, 0x46, 0x0c, 0xf9, 0x02, 0x24, 0x9c, 0x88,
-	0x0b, 0xc3, 0xbe, 0xfa, 0xde, 0x45, 0xff, 0x83, 0x87, 0xaa, 0xdf, 0x77, 0xa8, 0xc9, 0x5e, 0x17,
-	0x2d, 0x7b, 0x1f, 0x47, 0xc2, 0x0c, 0xba, 0x5f, 0x04, 0xcf, 0x12, 0x9e, 0x5b, 0x36, 0x68, 0xe3,
-	0x96, 0xdb, 0x6e, 0xce, 0x70, 0xe3, 0xdc, 0x32, 0xab, 0x3a, 0x92, 0x75, 0xb5, 0x2a, 0x98, 0xce,
-	0xe9, 0x4e, 0xb5, 0x54, 0x2f, 0x47, 0x8f, 0x9d, 0x3c, 0xec, 0x83, 0xe2, 0xc8, 0x6b, 0x2f, 0x55,
-	0x47, 0x7e, 0xd6, 0xaa, 0x38, 0xcb, 0xc9, 0x29, 0xd4, 0xbc, 0xcf, 0xb4, 0xa7, 0xf1, 0xc8, 0xb6,
-	0x40, 0x4f, 0xb9, 0xe5, 0x9d, 0x6c, 0xe8, 0xae, 0x8a, 0xee, 0x76, 0xd0, 0x5d, 0x10, 0x5e, 0x16,
-	0x97, 0x03, 0x59, 0x70, 0xf5, 0x04, 0x2a, 0x4d, 0xc9, 0xe3, 0x54, 0xb3, 0x24, 0x8d, 0xdb, 0x52,
-	0xd0, 0xc7, 0x98, 0x3d, 0x8b, 0x1e, 0xfc, 0x15, 0x31, 0x52, 0x85, 0xc5, 0xcc, 0xf5, 0xb5, 0x3c,
-	0x49, 0x2d, 0xd3, 0x4d, 0x5a, 0xc3, 0x54, 0x00, 0x87, 0x5d, 0x24, 0xa9, 0x3d, 0x6b, 0x8e, 0x2b,
-	0x84, 0xa1, 0x4f, 0xc6, 0x15, 0x0d, 0x43, 0xf6, 0x60, 0x75, 0xa4, 0x18, 0x65, 0xff, 0x53, 0x14,
-	0xae, 0x0c, 0x84, 0xa3, 0x12, 0xd8, 0x85, 0x85, 0x0e, 0x8f, 0xd9, 0xb5, 0x34, 0x2e, 0xd4, 0xf4,
-	0x7f, 0xd8, 0x47, 0xa1, 0xc3, 0xe3, 0x2f, 0x1e, 0xc1, 0xdc, 0x56, 0xfa, 0xfe, 0xdc, 0xfe, 0x7f,
-	0xc8, 0x6d, 0xa5, 0xef, 0xce, 0xed, 0xd7, 0xb0, 0x61, 0x24, 0xf6, 0xd3, 0xc1, 0x65, 0x84, 0x84,
-	0xa5, 0x2f, 0x30, 0x04, 0x6b, 0x9e, 0x0d, 0xd1, 0x3f, 0xf1, 0x1c, 0x79, 0x07, 0xdb, 0x13, 0x56,
-	0xae, 0xc0, 0xf0, 0x0d, 0x62, 0x9a, 0xd6, 0x71, 0xcf, 0x8d, 0x31, 0xcb, 0x4f, 0xbc, 0xc0, 0xe7,
-	0xe8, 0x8c, 0xbc, 0x85, 0xad, 0x3b, 0x6c, 0x31, 0x05, 0x34, 0xfd, 0x06, 0x4d, 0xd7, 0x27, 0x4d,
-	0xdd, 0x7d, 0x9d, 0xb9, 0x7e, 0x10, 0x2c, 0xfd, 0x4e, 0xfb, 0xf4, 0x79, 0xe8, 0x1a, 0x88, 0xa2,
-	0xff, 0x7d, 0x72, 0x04, 0x3b, 0x99, 0xd4, 0xc2, 0x45, 0x39, 0xa8, 0xc7, 0x67, 0x07, 0xfa, 0x2d,
-	0x36, 0xf2, 0xed, 0x20, 0x8a, 0x50, 0x33, 0x96, 0xd1, 0xe4, 0x25, 0x10, 0x23, 0x5b, 0xd2, 0x48,
-	0x1d, 0x4b, 0xc6, 0x13, 0xab, 0x6c, 0x57, 0x48, 0xba, 0x57, 0x2d, 0xd5, 0x4b, 0xd1, 0xca, 0x90,
-	0x39, 0x0a, 0x04, 0x79, 0x03, 0x9b, 0xa1, 0x68, 0x44, 0x4f, 0x26, 0x89, 0xff, 0x96, 0xd7, 0xfb,
-	0xfb, 0x9d, 0x9c, 0xbe, 0xf2, 0x41, 0xf4, 0x74, 0xc3, 0xb1, 0xee, 0x53, 0x90, 0x23, 0x3f, 0xc0,
-	0xd6, 0x30, 0x75, 0x6f, 0x19, 0xee, 0xa3, 0xe1, 0xc6, 0x40, 0x30, 0x61, 0x7a, 0x00, 0xeb, 0x61,
-	0x47, 0x17, 0x3b, 0xa9, 0x4c, 0x16, 0xae, 0xfb, 0x00, 0x03, 0x12, 0x6a, 0xf8, 0x13, 0x2f, 0x4e,
-	0x94, 0xc9, 0xf0, 0xa2, 0xb7, 0xaf, 0x80, 0xde, 0xd7, 0x3b, 0x5c, 0xcb, 0x74, 0x2f, 0x9c, 0x9f,
-	0x4c, 0xdc, 0x5f, 0xf2, 0x06, 0xa6, 0xaf, 0x79, 0xd2, 0x95, 0xf8, 0xce, 0x2f, 0x1c, 0xee, 0xde,
-	0x57, 0xfa, 0xc1, 0x4f, 0xe4, 0xd5, 0xef, 0xa6, 0xde, 0x96, 0x6a, 0x7d, 0xa0, 0x5e, 0xf4, 0xb3,
-	0x9f, 0x42, 0xa2, 0xdf, 0x4f, 0x75, 0x2b, 0xbd, 0x90, 0xf6, 0xfc, 0xfd, 0xcd, 0x47, 0xbd, 0x34,
-	0xf6, 0xa8, 0xfb, 0x26, 0x3e, 0x35, 0x6c, 0xe2, 0xaf, 0x61, 0x5a, 0x59, 0xd9, 0xc9, 0x69, 0x19,
-	0x5b, 0xcf, 0x7f, 0x27, 0xf6, 0x1f, 0x73, 0x7d, 0xfe, 0x3e, 0xf2, 0xe2, 0xda, 0xdf, 0x25, 0x58,
-	0xbf, 0x53, 0x40, 0x76, 0x00, 0x06, 0x93, 0x52, 0x98, 0x74, 0x16, 0xa3, 0xf9, 0x80, 0x9c, 0x0a,
-	0x42, 0xe0, 0x81, 0xc9, 0x73, 0x85, 0x07, 0x98, 0x8e, 0xf0, 0xbf, 0xeb, 0xfa, 0x49, 0x6a, 0x38,
-	0x0e, 0x67, 0x65, 0xbc, 0xfa, 0x59, 0xb7, 0x76, 0xd3, 0xd9, 0x1a, 0x4c, 0x37, 0x53, 0x6e, 0x44,
-	0x98, 0xb7, 0xfc, 0x82, 0x50, 0x98, 0xe5, 0xda, 0x4a, 0xad, 0x39, 0x4e, 0x2c, 0x95, 0x68, 0xb0,
-	0x74, 0x4c, 0x9c, 0x6a, 0x2b, 0x0b, 0x3b, 0x98, 0x58, 0xc2, 0xb2, 0x39, 0x83, 0x63, 0xea, 0x77,
-	0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x4d, 0x3f, 0x46, 0xb2, 0xe0, 0x0a, 0x00, 0x00,
+	// 1496 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x57, 0xed, 0x52, 0x5b, 0xb9,
+	0x19, 0x1e, 0xe3, 0xf0, 0xf5, 0x82, 0x03, 0x11, 0x31, 0x08, 0x92, 0x34, 0x8e, 0x93, 0x36, 0xee,
+	0x76, 0x97, 0x00, 0x9b, 0x64, 0xb3, 0x99, 0x4e, 0xa7, 0x80, 0x9d, 0x2d, 0xdd, 0x2e, 0xa5, 0x07,
+	0x92, 0xe9, 0x3f, 0x8d, 0x7c, 0x24, 0x83, 0xea, 0x63, 0xe9, 0x54, 0x92, 0xed, 0xe3, 0x0b, 0xe8,
+	0x4d, 0xf4, 0x26, 0x7a, 0x8b, 0x1d, 0x7d, 0x18, 0x63, 0x07, 0xef, 0x2f, 0xac, 0xf7, 0x79, 0xde,
+	0x47, 0x3a, 0xd2, 0xfb, 0x05, 0x3c, 0x66, 0x7c, 0x20, 0x52, 0x4e, 0x0c, 0x37, 0x46, 0x28, 0xb9,
+	0x9f, 0x6b, 0x65, 0x15, 0x5a, 0x36, 0x56, 0x69, 0x7a, 0xcd, 0xf7, 0x76, 0x68, 0x2e, 0xde, 0xa4,
+	0xaa, 0xd7, 0x53, 0x32, 0xfe, 0x09, 0x8c, 0x3a, 0x83, 0xed, 0xa6, 0xf7, 0xbc, 0x0c, 0x8e, 0x17,
+	0x27, 0xa7, 0x37, 0x54, 0x4a, 0x9e, 0xa1, 0xa7, 0xb0, 0xda, 0xd1, 0xfc, 0xdf, 0x7d, 0x2e, 0xd3,
+	0x11, 0x2e, 0xd5, 0x4a, 0x8d, 0x4a, 0x32, 0x31, 0xa0, 0x2a, 0x2c, 0xf5, 0x84, 0x24, 0x4c, 0xe3,
+	0x05, 0x0f, 0x2d, 0xf6, 0x84, 0x6c, 0x6a, 0x6f, 0xa6, 0x85, 0x33, 0x97, 0xa3, 0x99, 0x16, 0x4d,
+	0x5d, 0xff, 0x6f, 0x09, 0x9e, 0xcf, 0x6c, 0xf3, 0x39, 0xcf, 0x84, 0xec, 0x1e, 0x37, 0x93, 0xbf,
+	0x08, 0x77, 0xc8, 0x11, 0xda, 0x82, 0xc5, 0x0e, 0x49, 0xa5, 0x8d, 0x7b, 0x3d, 0xe8, 0x9c, 0x4a,
+	0x8b, 0x76, 0x60, 0xd9, 0xe9, 0x19, 0x19, 0xf6, 0x59, 0x48, 0x9c, 0xfc, 0xa5, 0xd4, 0xe8, 0x15,
+	0x3c, 0xb4, 0x05, 0xc9, 0xd5, 0x90, 0x6b, 0x22, 0x24, 0xe3, 0x45, 0xdc, 0x70, 0xdd, 0x16, 0x17,
+	0xce, 0x78, 0xe6, 0x6c, 0xe8, 0x25, 0x54, 0xae, 0xa9, 0xe5, 0x43, 0x3a, 0x22, 0xa9, 0xea, 0x4b,
+	0x8b, 0x1f, 0x04, 0x52, 0x34, 0x9e, 0x3a, 0x5b, 0xfd, 0x3f, 0x55, 0xd8, 0x98, 0x39, 0x1c, 0xfa,
+	0x06, 0x1e, 0xc5, 0x0b, 0xcd, 0xb5, 0xea, 0x88, 0x8c, 0x13, 0xc1, 0xfc, 0xc1, 0x56, 0x93, 0x8d,
+	0x00, 0x5c, 0x04, 0xfb, 0x19, 0x43, 0xdf, 0x02, 0x32, 0x5c, 0xcf, 0x92, 0x17, 0x3c, 0x79, 0x33,
+	0x22, 0x53, 0x6c, 0xad, 0xfa, 0x56, 0xc8, 0xeb, 0xbb, 0xec, 0x72, 0x60, 0x47, 0x64, 0xc2, 0xde,
+	0x85, 0x15, 0xc6, 0x07, 0x84, 0x32, 0xa6, 0xfd, 0xd9, 0xd7, 0x93, 0x65, 0xc6, 0x07, 0xc7, 0x8c,
+	0x69, 0x77, 0x35, 0x0e, 0xe2, 0x7d, 0x81, 0x17, 0x3d, 0xb2, 0xc4, 0xf8, 0xa0, 0xd5, 0x17, 0xce,
+	0xe7, 0x5f, 0x4a, 0x48, 0x8f, 0x2c, 0x05, 0x1f, 0xb7, 0x76, 0xd0, 0x2b, 0xd8, 0xe8, 0x10, 0x39,
+	0xec, 0x12, 0x43, 0x84, 0xb4, 0xa4, 0xcb, 0x47, 0x78, 0xd9, 0x33, 0xd6, 0x3a, 0xe7, 0xc3, 0xee,
+	0xe5, 0x99, 0xb4, 0x3f, 0xf3, 0x91, 0x63, 0x99, 0x19, 0xd6, 0x4a, 0x60, 0x99, 0x3b, 0xac, 0x17,
+	0x50, 0x09, 0x1c, 0x2e, 0x53, 0xcf, 0x59, 0xf5, 0x1c, 0x90, 0xc3, 0xee, 0x65, 0x4b, 0xa6, 0x8e,
+	0xf2, 0x67, 0x40, 0x34, 0xcf, 0x89, 0x71, 0x30, 0xe1, 0x72, 0xc0, 0x33, 0x95, 0x73, 0xfc, 0x5d,
+	0xad, 0xd4, 0x58, 0x3b, 0xda, 0xda, 0x8f, 0x71, 0xf8, 0x33, 0x1f, 0xb5, 0x22, 0x94, 0x6c, 0xd0,
+	0x3c, 0xbf, 0xbc, 0x63, 0x40, 0x18, 0x56, 0x7c, 0x50, 0x90, 0x7e, 0x8e, 0xc1, 0xbf, 0xdd, 0x92,
+	0x8b, 0x8b, 0xcf, 0x39, 0x7a, 0x0e, 0xeb, 0x92, 0x04, 0x8c, 0xa9, 0xa1, 0xc4, 0x6b, 0x21, 0x42,
+	0xe5, 0xa7, 0x53, 0x69, 0x9b, 0x6a, 0x28, 0x1d, 0x81, 0xde, 0x25, 0xac, 0x07, 0x02, 0xbd, 0x25,
+	0x3c, 0x05, 0x48, 0x95, 0xec, 0x04, 0x0e, 0x7e, 0xed, 0xe1, 0x15, 0x67, 0x71, 0x0c, 0xf4, 0x1a,
+	0x36, 0x4d, 0x57, 0xe4, 0x51, 0x21, 0xbd, 0xe1, 0x69, 0x17, 0x57, 0x6a, 0xa5, 0xc6, 0x4a, 0x52,
+	0x71, 0x76, 0xc7, 0x39, 0x75, 0x46, 0x77, 0xdd, 0xba, 0x20, 0x8c, 0x67, 0x74, 0x84, 0x1f, 0x7a,
+	0x91, 0x65, 0x5d, 0x34, 0xdd, 0x12, 0xd5, 0xa1, 0xa2, 0x8b, 0x43, 0xc2, 0x34, 0x51, 0x9d, 0x8e,
+	0xe1, 0x16, 0x6f, 0x78, 0x7c, 0x4d, 0x17, 0x87, 0x4d, 0xfd, 0x77, 0x6f, 0x72, 0x19, 0xa3, 0x8b,
+	0x23, 0x97, 0x31, 0x9b, 0x21, 0x63, 0x74, 0x71, 0xd4, 0xd4, 0x2e, 0x72, 0x9d, 0x79, 0x92, 0x81,
+	0x8f, 0x42, 0xe4, 0xea, 0xe2, 0xe8, 0xd3, 0xd8, 0x76, 0x4f, 0x12, 0xa0, 0x7b, 0x92, 0xe0, 0x21,
+	0x2c, 0x30, 0x8d, 0xb7, 0x3c, 0xb2, 0xc0, 0x34, 0xda, 0x84, 0x32, 0x65, 0x1a, 0x3f, 0xf6, 0x1f,
+	0xe3, 0x7e, 0xa2, 0x3f, 0xc1, 0x53, 0x9f, 0x65, 0xfd, 0x3c, 0x57, 0xda, 0x72, 0x46, 0x66, 0x54,
+	0xab, 0xde, 0x17, 0xbb, 0xd4, 0x1b, 0x53, 0xae, 0xee, 0xee, 0xb0, 0x0b, 0x2b, 0xb2, 0x4d, 0xac,
+	0xa6, 0xd2, 0xe0, 0x9d, 0x70, 0x05, 0xb2, 0x7d, 0xe5, 0x96, 0xe8, 0x3d, 0xec, 0x70, 0x49, 0xdb,
+	0x19, 0x67, 0xa4, 0xef, 0x33, 0x9e, 0xa4, 0xa1, 0xbe, 0x18, 0x8c, 0x6b, 0xe5, 0x46, 0x25, 0xa9,
+	0x46, 0x38, 0xd4, 0x83, 0x58, 0x7c, 0x0c, 0xe2, 0x50, 0xe5, 0x85, 0xd5, 0xf4, 0x2b, 0xaf, 0xdd,
+	0x5a, 0xb9, 0xb1, 0x76, 0x74, 0xb8, 0x1f, 0x2b, 0xdb, 0xfe, 0x4c, 0xe6, 0xee, 0xb7, 0x9c, 0xd7,
+	0xb4, 0x58, 0x4b, 0x5a, 0x3d, 0x4a, 0xb6, 0xf8, 0xd7, 0x08, 0x7a, 0x03, 0x5b, 0x51, 0xf9, 0xf6,
+	0xaa, 0x05, 0x37, 0x78, 0xcf, 0x1f, 0x0d, 0x45, 0xe8, 0xd3, 0x04, 0x41, 0x5f, 0x00, 0xc5, 0x13,
+	0x51, 0xa6, 0xc9, 0x4d, 0xa8, 0x5d, 0xf8, 0x89, 0x3f, 0x54, 0x63, 0xde, 0xa1, 0x66, 0x6b, 0x5d,
+	0xb2, 0x19, 0x34, 0x8e, 0x99, 0x8e, 0x16, 0x94, 0xc0, 0xeb, 0x8c, 0x1a, 0x4b, 0xc6, 0x65, 0xdc,
+	0x52, 0xdb, 0x37, 0xc4, 0x6f, 0x6c, 0x2c, 0xb1, 0xa2, 0xc7, 0x49, 0x5f, 0x8a, 0x82, 0x48, 0x83,
+	0x9f, 0xd5, 0x4a, 0x8d, 0x72, 0xf2, 0xc2, 0xd1, 0xe3, 0x3e, 0x9e, 0x9c, 0x04, 0xee, 0x95, 0xe8,
+	0xf1, 0xcf, 0x52, 0x14, 0xe7, 0x06, 0x9d, 0x41, 0x3d, 0x68, 0xaa, 0xa1, 0xf4, 0x47, 0xb6, 0x85,
+	0x57, 0x32, 0x96, 0xf6, 0xf2, 0x5b, 0xb9, 0x9a, 0x97, 0x7b, 0xe6, 0xe5, 0x22, 0xf1, 0xaa, 0xb8,
+	0x1a, 0xd3, 0xa2, 0xd4, 0x4b, 0xa8, 0xb4, 0x39, 0x4d, 0x95, 0x24, 0x99, 0x4a, 0xbb, 0x9c, 0xe1,
+	0x17, 0x3e, 0x7a, 0xd6, 0x83, 0xf1, 0x6f, 0xde, 0x86, 0x6a, 0xb0, 0x9e, 0xbb, 0xba, 0x66, 0x32,
+	0x65, 0x89, 0x6c, 0xe3, 0xba, 0x0f, 0x05, 0x70, 0xb6, 0xcb, 0x4c, 0xd9, 0xf3, 0xf6, 0x34, 0x83,
+	0x69, 0xfc, 0x72, 0x9a, 0xd1, 0xd4, 0x68, 0x1f, 0xb6, 0x26, 0x8c, 0x49, 0xf4, 0xbf, 0xf2, 0xc4,
+	0x47, 0x63, 0xe2, 0x24, 0x05, 0x9e, 0xc3, 0x5a, 0x8f, 0xa6, 0x64, 0xc0, 0xb5, 0xbb, 0x6a, 0xfc,
+	0x5b, 0x5f, 0x47, 0xa1, 0x47, 0xd3, 0x2f, 0xc1, 0xe2, 0x63, 0x5b, 0xc8, 0xf9, 0xb1, 0xfd, 0xbb,
+	0x18, 0xdb, 0x42, 0xde, 0x1f, 0xdb, 0x6f, 0x61, 0x5b, 0x73, 0x5f, 0x4f, 0xc7, 0x8f, 0x11, 0x03,
+	0x16, 0x7f, 0xeb, 0xaf, 0xe0, 0x71, 0x40, 0xe3, 0xed, 0xb7, 0x02, 0x86, 0x3e, 0xc2, 0xde, 0x8c,
+	0x97, 0x4b, 0x30, 0xdf, 0x83, 0x88, 0xc4, 0x0d, 0xbf, 0xe7, 0xf6, 0x94, 0xe7, 0x2f, 0xb4, 0xf0,
+	0xed, 0xe8, 0x1c, 0x7d, 0x80, 0xdd, 0x7b, 0x7c, 0x7d, 0x08, 0x48, 0xfc, 0x7b, 0xef, 0x5a, 0x9d,
+	0x75, 0x75, 0xef, 0x75, 0xee, 0xea, 0x41, 0xf4, 0x0c, 0x3b, 0x1d, 0xe0, 0x6f, 0x62, 0xd5, 0xf0,
+	0x56, 0xaf, 0x7f, 0x80, 0x8e, 0xe1, 0x59, 0xce, 0x25, 0x73, 0xb7, 0x1c, 0xd9, 0xd3, 0xb3, 0x03,
+	0xfe, 0x83, 0x2f, 0xe4, 0x7b, 0x91, 0x94, 0x78, 0xce, 0x54, 0x44, 0xa3, 0xef, 0x00, 0x69, 0xde,
+	0xe1, 0x9a, 0xcb, 0x94, 0x13, 0x9a, 0x59, 0x61, 0xfb, 0x8c, 0xe3, 0xfd, 0x5a, 0xa9, 0x51, 0x4a,
+	0x1e, 0xdd, 0x22, 0xc7, 0x11, 0x40, 0xef, 0x60, 0x27, 0x26, 0x0d, 0x1b, 0xf2, 0x2c, 0x0b, 0xdf,
+	0xf2, 0xf6, 0xe0, 0xa0, 0x67, 0xf0, 0x9b, 0x70, 0x89, 0x01, 0x6e, 0x3a, 0xd4, 0x7d, 0x8a, 0xc7,
+	0xd0, 0x8f, 0xb0, 0x7b, 0x1b, 0xba, 0x5f, 0x39, 0x1e, 0x78, 0xc7, 0xed, 0x31, 0x61, 0xc6, 0xf5,
+	0x10, 0xaa, 0x71, 0x47, 0x77, 0x77, 0x5c, 0xe8, 0x3c, 0x3e, 0xf7, 0xa1, 0xbf, 0x90, 0x98, 0xc3,
+	0xbf, 0xd0, 0xa2, 0x25, 0x74, 0x1e, 0x1e, 0x7a, 0x1b, 0x96, 0x34, 0xbf, 0x76, 0xdf, 0x7f, 0xe4,
+	0x83, 0x28, 0xae, 0xd0, 0x13, 0x58, 0x35, 0xfd, 0x36, 0x69, 0x53, 0xc9, 0x0c, 0xfe, 0xde, 0x17,
+	0x86, 0x15, 0xd3, 0x6f, 0x9f, 0xb8, 0x35, 0xfa, 0x2b, 0xe0, 0x99, 0x86, 0x3a, 0xe9, 0x73, 0x6f,
+	0xe7, 0xf7, 0xb9, 0xad, 0x3b, 0xed, 0x76, 0x6c, 0x74, 0x5a, 0x66, 0x9e, 0xd6, 0xbb, 0x5f, 0xd1,
+	0x32, 0xf7, 0x68, 0xfd, 0x04, 0xdb, 0x53, 0xcd, 0x79, 0xa2, 0xf4, 0x7e, 0xbe, 0x12, 0x9a, 0xb4,
+	0xee, 0x5b, 0xa1, 0x7f, 0xc0, 0x13, 0xcd, 0x2d, 0x15, 0x92, 0x33, 0x72, 0x4f, 0x2f, 0xff, 0x61,
+	0xbe, 0xda, 0xce, 0xd8, 0xef, 0x78, 0xa6, 0xa7, 0xbf, 0x87, 0x9d, 0x94, 0x6b, 0x2b, 0x3a, 0x22,
+	0xa5, 0x56, 0x28, 0x49, 0xac, 0x8f, 0x71, 0xc5, 0x38, 0xfe, 0xe0, 0x1f, 0xb5, 0x3a, 0x05, 0x5f,
+	0xb9, 0x08, 0x57, 0x8c, 0xa3, 0x1f, 0x66, 0xfd, 0x68, 0x6a, 0xc5, 0x80, 0x5a, 0xce, 0xf0, 0x8f,
+	0x21, 0x18, 0xa6, 0xe0, 0xe3, 0x31, 0x8a, 0xfe, 0x08, 0x7b, 0xd3, 0x8e, 0x3c, 0xbd, 0x51, 0x24,
+	0xa7, 0xa3, 0x4c, 0x51, 0x86, 0x3f, 0xfa, 0x68, 0xc7, 0x53, 0x8c, 0x56, 0x7a, 0xa3, 0x2e, 0x02,
+	0xbe, 0x77, 0x0d, 0x78, 0x5e, 0x4f, 0x71, 0xad, 0xd4, 0x4d, 0x3e, 0x61, 0x62, 0x75, 0x3f, 0xd1,
+	0x3b, 0x58, 0x1c, 0xd0, 0xac, 0xcf, 0xfd, 0xfc, 0xb7, 0x76, 0xf4, 0x7c, 0x5e, 0x4b, 0x88, 0x3a,
+	0x49, 0x60, 0x7f, 0x5c, 0xf8, 0x50, 0xaa, 0x8f, 0x00, 0x07, 0xd2, 0x4f, 0x61, 0x3a, 0x4d, 0xfe,
+	0x79, 0x26, 0x3b, 0xea, 0x92, 0xdb, 0x8b, 0x93, 0xbb, 0xc3, 0x5e, 0x69, 0x6a, 0xd8, 0x0b, 0xcd,
+	0x7d, 0xe1, 0xb6, 0xb9, 0xbf, 0x85, 0x45, 0x61, 0x79, 0xcf, 0xe0, 0xb2, 0x6f, 0x49, 0xbf, 0x99,
+	0xd9, 0x7f, 0x4a, 0xfa, 0xe2, 0x24, 0x09, 0xe4, 0xfa, 0xff, 0x4a, 0x50, 0xbd, 0x97, 0x80, 0x9e,
+	0x01, 0x8c, 0x27, 0xe8, 0x38, 0x01, 0xaf, 0x27, 0xab, 0xd1, 0x72, 0xc6, 0x10, 0x82, 0x07, 0xda,
+	0x18, 0xe1, 0x0f, 0xb0, 0x98, 0xf8, 0xdf, 0x6e, 0x1a, 0xc8, 0x94, 0xa6, 0x7e, 0x68, 0x2f, 0xfb,
+	0x92, 0xb0, 0xec, 0xd6, 0x6e, 0x6a, 0x7f, 0x0c, 0x8b, 0x6d, 0x45, 0x35, 0x8b, 0x73, 0x78, 0x58,
+	0x20, 0x0c, 0xcb, 0x54, 0x5a, 0x2e, 0x25, 0xf5, 0x93, 0x6c, 0x25, 0x19, 0x2f, 0x1d, 0x92, 0x2a,
+	0x69, 0x79, 0x61, 0xc7, 0x93, 0x6c, 0x5c, 0xb6, 0x97, 0xfc, 0xbf, 0x2f, 0xdf, 0xff, 0x7f, 0x00,
+	0xd2, 0x7f, 0xc5, 0xdd, 0xf8, 0x0c, 0x00, 0x00,
 }
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// DeviceSessionExport contains the device-session together with the state
+// that must be migrated with it when moving a device to an other
+// network-server instance.
+type DeviceSessionExport struct {
+	DeviceSession      DeviceSession
+	PendingMACCommands []MACCommandBlock
+	DeviceQueueItems   []DeviceQueueItem
+	MulticastGroupIDs  []uuid.UUID
+}
+
+// ExportDeviceSession returns the device-session, pending mac-commands,
+// device-queue items and multicast-group memberships of the given device.
+func ExportDeviceSession(ctx context.Context, db sqlx.Queryer, p RedisClient, devEUI lorawan.EUI64) (DeviceSessionExport, error) {
+	var out DeviceSessionExport
+	var err error
+
+	out.DeviceSession, err = GetDeviceSession(ctx, p, devEUI)
+	if err != nil {
+		return out, errors.Wrap(err, "get device-session error")
+	}
+
+	out.PendingMACCommands, err = GetPendingMACCommands(ctx, p, devEUI)
+	if err != nil {
+		return out, errors.Wrap(err, "get pending mac-commands error")
+	}
+
+	out.DeviceQueueItems, err = GetDeviceQueueItemsForDevEUI(ctx, db, devEUI)
+	if err != nil {
+		return out, errors.Wrap(err, "get device-queue items error")
+	}
+
+	out.MulticastGroupIDs, err = GetMulticastGroupsForDevEUI(ctx, db, devEUI)
+	if err != nil {
+		return out, errors.Wrap(err, "get multicast-groups error")
+	}
+
+	return out, nil
+}
+
+// ImportDeviceSession restores the given device-session export. The device
+// must already exist. Its device-queue is replaced by the exported items
+// and it is added to the exported multicast-groups (which must exist).
+// The db argument is expected to be a transaction, so that the device-queue
+// is not modified when the import fails.
+func ImportDeviceSession(ctx context.Context, db sqlx.Ext, p RedisClient, exp DeviceSessionExport) error {
+	devEUI := exp.DeviceSession.DevEUI
+
+	if _, err := GetDevice(ctx, db, devEUI); err != nil {
+		return errors.Wrap(err, "get device error")
+	}
+
+	if err := FlushDeviceQueueForDevEUI(ctx, db, devEUI); err != nil {
+		return errors.Wrap(err, "flush device-queue error")
+	}
+
+	for i := range exp.DeviceQueueItems {
+		qi := exp.DeviceQueueItems[i]
+		if qi.DevEUI != devEUI {
+			return errors.Errorf("device-queue item %d belongs to DevEUI %s", qi.ID, qi.DevEUI)
+		}
+
+		if err := CreateDeviceQueueItem(ctx, db, &qi); err != nil {
+			return errors.Wrap(err, "create device-queue item error")
+		}
+	}
+
+	current, err := GetMulticastGroupsForDevEUI(ctx, db, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get multicast-groups error")
+	}
+	currentSet := make(map[uuid.UUID]struct{})
+	for _, id := range current {
+		currentSet[id] = struct{}{}
+	}
+
+	for _, id := range exp.MulticastGroupIDs {
+		if _, ok := currentSet[id]; ok {
+			continue
+		}
+
+		if err := AddDeviceToMulticastGroup(ctx, db, devEUI, id); err != nil {
+			return errors.Wrapf(err, "add device to multicast-group %s error", id)
+		}
+	}
+
+	if err := SaveDeviceSession(ctx, p, exp.DeviceSession); err != nil {
+		return errors.Wrap(err, "save device-session error")
+	}
+
+	pending, err := GetPendingMACCommands(ctx, p, devEUI)
+	if err != nil {
+		return errors.Wrap(err, "get pending mac-commands error")
+	}
+	for _, block := range pending {
+		if err := DeletePendingMACCommand(ctx, p, devEUI, block.CID); err != nil {
+			return errors.Wrap(err, "delete pending mac-command error")
+		}
+	}
+
+	for _, block := range exp.PendingMACCommands {
+		if err := SetPendingMACCommand(ctx, p, devEUI, block); err != nil {
+			return errors.Wrap(err, "set pending mac-command error")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"device_queue_items": len(exp.DeviceQueueItems),
+		"multicast_groups":   len(exp.MulticastGroupIDs),
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("device-session imported")
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestDeviceSessionExport() {
+	assert := require.New(ts.T())
+
+	var sp ServiceProfile
+	var rp RoutingProfile
+	var dp DeviceProfile
+
+	assert.NoError(CreateServiceProfile(context.Background(), ts.Tx(), &sp))
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+	assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+	mg := MulticastGroup{
+		GroupType:        MulticastGroupC,
+		ServiceProfileID: sp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg))
+
+	d := Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: sp.ID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(CreateDevice(context.Background(), ts.Tx(), &d))
+	assert.NoError(AddDeviceToMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg.ID))
+
+	ds := DeviceSession{
+		DevEUI:    d.DevEUI,
+		DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+		FCntUp:    10,
+		NFCntDown: 5,
+	}
+	assert.NoError(SaveDeviceSession(context.Background(), ts.RedisPool(), ds))
+
+	block := MACCommandBlock{
+		CID: lorawan.LinkADRReq,
+		MACCommands: MACCommands{
+			{CID: lorawan.LinkADRReq, Payload: &lorawan.LinkADRReqPayload{DataRate: 3}},
+		},
+	}
+	assert.NoError(SetPendingMACCommand(context.Background(), ts.RedisPool(), d.DevEUI, block))
+
+	qi := DeviceQueueItem{
+		DevAddr:    ds.DevAddr,
+		DevEUI:     d.DevEUI,
+		FRMPayload: []byte{1, 2, 3},
+		FCnt:       5,
+		FPort:      10,
+		Confirmed:  true,
+	}
+	assert.NoError(CreateDeviceQueueItem(context.Background(), ts.Tx(), &qi))
+
+	ts.T().Run("Export", func(t *testing.T) {
+		assert := require.New(t)
+
+		exp, err := ExportDeviceSession(context.Background(), ts.Tx(), ts.RedisPool(), d.DevEUI)
+		assert.NoError(err)
+		assert.Equal(ds.DevAddr, exp.DeviceSession.DevAddr)
+		assert.Len(exp.PendingMACCommands, 1)
+		assert.Len(exp.DeviceQueueItems, 1)
+		assert.Equal(qi.FRMPayload, exp.DeviceQueueItems[0].FRMPayload)
+		assert.Equal([]uuid.UUID{mg.ID}, exp.MulticastGroupIDs)
+
+		t.Run("Import", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(FlushDeviceQueueForDevEUI(context.Background(), ts.Tx(), d.DevEUI))
+			assert.NoError(RemoveDeviceFromMulticastGroup(context.Background(), ts.Tx(), d.DevEUI, mg.ID))
+			assert.NoError(DeleteDeviceSession(context.Background(), ts.RedisPool(), d.DevEUI))
+			assert.NoError(DeletePendingMACCommand(context.Background(), ts.RedisPool(), d.DevEUI, lorawan.LinkADRReq))
+
+			assert.NoError(ImportDeviceSession(context.Background(), ts.Tx(), ts.RedisPool(), exp))
+
+			imp, err := ExportDeviceSession(context.Background(), ts.Tx(), ts.RedisPool(), d.DevEUI)
+			assert.NoError(err)
+			assert.Equal(exp.DeviceSession.FCntUp, imp.DeviceSession.FCntUp)
+			assert.Len(imp.PendingMACCommands, 1)
+			assert.Len(imp.DeviceQueueItems, 1)
+			assert.Equal(qi.FCnt, imp.DeviceQueueItems[0].FCnt)
+			assert.Equal([]uuid.UUID{mg.ID}, imp.MulticastGroupIDs)
+		})
+
+		t.Run("Import unknown multicast-group", func(t *testing.T) {
+			assert := require.New(t)
+
+			exp.MulticastGroupIDs = append(exp.MulticastGroupIDs, uuid.Must(uuid.NewV4()))
+			assert.Error(ImportDeviceSession(context.Background(), ts.Tx(), ts.RedisPool(), exp))
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+)
+
+// DeviceSessionIssue defines an impossible device-session state.
+type DeviceSessionIssue string
+
+// Device-session issues.
+const (
+	// DeviceSessionIssueTXPowerIndex indicates a TXPowerIndex which is not
+	// defined by the band.
+	DeviceSessionIssueTXPowerIndex DeviceSessionIssue = "tx_power_index"
+
+	// DeviceSessionIssueEnabledUplinkChannels indicates enabled uplink
+	// channels which are not defined by the band, nor by the extra uplink
+	// channels of the device-session.
+	DeviceSessionIssueEnabledUplinkChannels DeviceSessionIssue = "enabled_uplink_channels"
+
+	// DeviceSessionIssueDR indicates a data-rate which is not defined by the
+	// band.
+	DeviceSessionIssueDR DeviceSessionIssue = "dr"
+
+	// DeviceSessionIssueRX2DR indicates an RX2 data-rate which is not defined
+	// by the band.
+	DeviceSessionIssueRX2DR DeviceSessionIssue = "rx2_dr"
+)
+
+// ValidateDeviceSession returns the impossible states of the given
+// device-session, validated against the band of its region.
+func ValidateDeviceSession(s DeviceSession) []DeviceSessionIssue {
+	var issues []DeviceSessionIssue
+	b := band.Get(s.Region)
+
+	if _, err := b.GetTXPowerOffset(s.TXPowerIndex); err != nil || s.TXPowerIndex < 0 {
+		issues = append(issues, DeviceSessionIssueTXPowerIndex)
+	}
+
+	if len(validUplinkChannels(s)) != len(s.EnabledUplinkChannels) {
+		issues = append(issues, DeviceSessionIssueEnabledUplinkChannels)
+	}
+
+	if _, err := b.GetDataRate(s.DR); err != nil || s.DR < 0 {
+		issues = append(issues, DeviceSessionIssueDR)
+	}
+
+	if _, err := b.GetDataRate(int(s.RX2DR)); err != nil {
+		issues = append(issues, DeviceSessionIssueRX2DR)
+	}
+
+	return issues
+}
+
+// RepairDeviceSession repairs the given issues of the device-session by
+// resetting the invalid values to safe defaults, which will be corrected by
+// the ADR engine when needed. The TXPowerIndex and DR are reset to 0, the
+// RX2 data-rate to the default of the band and the invalid uplink channels
+// are removed (when none remain, the standard uplink channels are enabled).
+func RepairDeviceSession(s *DeviceSession, issues []DeviceSessionIssue) {
+	b := band.Get(s.Region)
+
+	for _, issue := range issues {
+		switch issue {
+		case DeviceSessionIssueTXPowerIndex:
+			s.TXPowerIndex = 0
+		case DeviceSessionIssueEnabledUplinkChannels:
+			s.EnabledUplinkChannels = validUplinkChannels(*s)
+			if len(s.EnabledUplinkChannels) == 0 {
+				s.EnabledUplinkChannels = b.GetStandardUplinkChannelIndices()
+			}
+		case DeviceSessionIssueDR:
+			s.DR = 0
+		case DeviceSessionIssueRX2DR:
+			s.RX2DR = uint8(b.GetDefaults().RX2DataRate)
+		}
+	}
+}
+
+// GetDeviceSessionDevEUIs returns the DevEUIs of all device-sessions, using
+// SCAN so that Redis is not blocked.
+func GetDeviceSessionDevEUIs(ctx context.Context, p RedisClient) ([]lorawan.EUI64, error) {
+	keys, err := ScanKeys(p, fmt.Sprintf(deviceSessionKeyTempl, "*"))
+	if err != nil {
+		return nil, errors.Wrap(err, "scan device-session keys error")
+	}
+
+	// prefix and suffix surrounding the DevEUI (hash tag)
+	affix := strings.SplitN(deviceSessionKeyTempl, "%s", 2)
+
+	var out []lorawan.EUI64
+	for _, key := range keys {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(strings.TrimSuffix(strings.TrimPrefix(key, affix[0]), affix[1]))); err != nil {
+			continue
+		}
+		out = append(out, devEUI)
+	}
+
+	return out, nil
+}
+
+// validUplinkChannels returns the enabled uplink channels of the
+// device-session which are defined by the band or by the extra uplink
+// channels of the device-session.
+func validUplinkChannels(s DeviceSession) []int {
+	b := band.Get(s.Region)
+
+	var out []int
+	for _, c := range s.EnabledUplinkChannels {
+		if _, ok := s.ExtraUplinkChannels[c]; ok {
+			out = append(out, c)
+			continue
+		}
+		if _, err := b.GetUplinkChannel(c); err == nil {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+)
+
+func TestValidateAndRepairDeviceSession(t *testing.T) {
+	defaults := band.Band().GetDefaults()
+	standardChannels := band.Band().GetStandardUplinkChannelIndices()
+
+	valid := DeviceSession{
+		TXPowerIndex:          1,
+		DR:                    5,
+		RX2DR:                 uint8(defaults.RX2DataRate),
+		EnabledUplinkChannels: standardChannels,
+	}
+
+	tests := []struct {
+		Name           string
+		DeviceSession  DeviceSession
+		ExpectedIssues []DeviceSessionIssue
+		Repaired       DeviceSession
+	}{
+		{
+			Name:          "valid",
+			DeviceSession: valid,
+			Repaired:      valid,
+		},
+		{
+			Name: "invalid tx power index",
+			DeviceSession: DeviceSession{
+				TXPowerIndex:          100,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: standardChannels,
+			},
+			ExpectedIssues: []DeviceSessionIssue{DeviceSessionIssueTXPowerIndex},
+			Repaired: DeviceSession{
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: standardChannels,
+			},
+		},
+		{
+			Name: "invalid enabled uplink channel",
+			DeviceSession: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: []int{0, 1, 2, 50},
+			},
+			ExpectedIssues: []DeviceSessionIssue{DeviceSessionIssueEnabledUplinkChannels},
+			Repaired: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: []int{0, 1, 2},
+			},
+		},
+		{
+			Name: "extra uplink channel",
+			DeviceSession: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: []int{0, 1, 2, 50},
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					50: {Frequency: 867100000, MaxDR: 5},
+				},
+			},
+			Repaired: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: []int{0, 1, 2, 50},
+				ExtraUplinkChannels: map[int]loraband.Channel{
+					50: {Frequency: 867100000, MaxDR: 5},
+				},
+			},
+		},
+		{
+			Name: "no valid enabled uplink channels",
+			DeviceSession: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: []int{50, 51},
+			},
+			ExpectedIssues: []DeviceSessionIssue{DeviceSessionIssueEnabledUplinkChannels},
+			Repaired: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    5,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: standardChannels,
+			},
+		},
+		{
+			Name: "invalid data-rates",
+			DeviceSession: DeviceSession{
+				TXPowerIndex:          1,
+				DR:                    20,
+				RX2DR:                 20,
+				EnabledUplinkChannels: standardChannels,
+			},
+			ExpectedIssues: []DeviceSessionIssue{DeviceSessionIssueDR, DeviceSessionIssueRX2DR},
+			Repaired: DeviceSession{
+				TXPowerIndex:          1,
+				RX2DR:                 uint8(defaults.RX2DataRate),
+				EnabledUplinkChannels: standardChannels,
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			issues := ValidateDeviceSession(tst.DeviceSession)
+			assert.Equal(tst.ExpectedIssues, issues)
+
+			ds := tst.DeviceSession
+			RepairDeviceSession(&ds, issues)
+			assert.Equal(tst.Repaired, ds)
+			assert.Len(ValidateDeviceSession(ds), 0)
+		})
+	}
+}
+
+func (ts *StorageTestSuite) TestGetDeviceSessionDevEUIs() {
+	assert := require.New(ts.T())
+
+	ds := DeviceSession{
+		DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+	}
+	assert.NoError(SaveDeviceSession(context.Background(), ts.RedisPool(), ds))
+	assert.NoError(SaveDeviceGatewayRXInfoSet(context.Background(), ts.RedisPool(), DeviceGatewayRXInfoSet{
+		DevEUI: ds.DevEUI,
+	}))
+
+	devEUIs, err := GetDeviceSessionDevEUIs(context.Background(), ts.RedisPool())
+	assert.NoError(err)
+	assert.Equal([]lorawan.EUI64{ds.DevEUI}, devEUIs)
+}
@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"testing"
 
+	proto "github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/require"
 
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
 
@@ -133,11 +136,82 @@ func TestDeviceSession(t *testing.T) {
 					So(s2, ShouldResemble, s)
 				})
 
+				Convey("Then UpdateDeviceSession updates the device-session", func() {
+					ds, err := UpdateDeviceSession(context.Background(), RedisPool(), s.DevEUI, func(ds *DeviceSession) error {
+						ds.FCntUp = 10
+						return nil
+					})
+					So(err, ShouldBeNil)
+					So(ds.FCntUp, ShouldEqual, 10)
+
+					s2, err := GetDeviceSession(context.Background(), RedisPool(), s.DevEUI)
+					So(err, ShouldBeNil)
+					So(s2.FCntUp, ShouldEqual, 10)
+				})
+
+				Convey("Then UpdateDeviceSession does not update the DevAddr", func() {
+					_, err := UpdateDeviceSession(context.Background(), RedisPool(), s.DevEUI, func(ds *DeviceSession) error {
+						ds.DevAddr = lorawan.DevAddr{4, 3, 2, 1}
+						return nil
+					})
+					So(err, ShouldNotBeNil)
+				})
+
+				Convey("Then UpdateDeviceSession retries on concurrent modification", func() {
+					var calls int
+					ds, err := UpdateDeviceSession(context.Background(), RedisPool(), s.DevEUI, func(ds *DeviceSession) error {
+						calls++
+						if calls == 1 {
+							s2 := s
+							s2.NFCntDown = 5
+							So(SaveDeviceSession(context.Background(), RedisPool(), s2), ShouldBeNil)
+						}
+						ds.FCntUp = 10
+						return nil
+					})
+					So(err, ShouldBeNil)
+					So(calls, ShouldEqual, 2)
+					So(ds.FCntUp, ShouldEqual, 10)
+					So(ds.NFCntDown, ShouldEqual, 5)
+				})
+
 				Convey("Then DeleteDeviceSession deletes the device-session", func() {
 					So(DeleteDeviceSession(context.Background(), RedisPool(), s.DevEUI), ShouldBeNil)
 					So(DeleteDeviceSession(context.Background(), RedisPool(), s.DevEUI), ShouldEqual, ErrDoesNotExist)
 
 				})
+
+				Convey("Then DeleteStaleDevAddrMembers keeps the DevEUI", func() {
+					removed, err := DeleteStaleDevAddrMembers(context.Background(), RedisPool())
+					So(err, ShouldBeNil)
+					So(removed, ShouldEqual, 0)
+
+					sessions, err := GetDeviceSessionsForDevAddr(context.Background(), RedisPool(), s.DevAddr)
+					So(err, ShouldBeNil)
+					So(sessions, ShouldHaveLength, 1)
+				})
+
+				Convey("When the device-session is saved with a different DevAddr", func() {
+					s2 := s
+					s2.DevAddr = lorawan.DevAddr{4, 3, 2, 1}
+					So(SaveDeviceSession(ctx, RedisPool(), s2), ShouldBeNil)
+
+					Convey("Then DeleteStaleDevAddrMembers removes the DevEUI from the old DevAddr set", func() {
+						removed, err := DeleteStaleDevAddrMembers(context.Background(), RedisPool())
+						So(err, ShouldBeNil)
+						So(removed, ShouldEqual, 1)
+
+						c := RedisPool().Get()
+						defer c.Close()
+						n, err := redis.Int(c.Do("SCARD", fmt.Sprintf(devAddrKeyTempl, s.DevAddr)))
+						So(err, ShouldBeNil)
+						So(n, ShouldEqual, 0)
+
+						sessions, err := GetDeviceSessionsForDevAddr(context.Background(), RedisPool(), s2.DevAddr)
+						So(err, ShouldBeNil)
+						So(sessions, ShouldHaveLength, 1)
+					})
+				})
 			})
 
 			Convey("When calling validateAndGetFullFCntUp", func() {
@@ -170,22 +244,162 @@ func TestDeviceSession(t *testing.T) {
 					})
 				}
 			})
+
+			Convey("When calling ValidateAndGetFullFCntUpForDeviceProfile", func() {
+				testTable := []struct {
+					Name       string
+					Mode       FCntValidationMode
+					MaxGap     int
+					ServerFCnt uint32
+					NodeFCnt   uint32
+					FullFCnt   uint32
+					Valid      bool
+				}{
+					{"strict roll-over", FCntValidationStrict, 0, 65535, 0, 65536, true},
+					{"16 bit roll-over", FCntValidationRollover16Bit, 0, 65536, 0, 0, true},
+					{"16 bit roll-over with lost packet", FCntValidationRollover16Bit, 0, 65535, 1, 1, true},
+					{"16 bit old packet", FCntValidationRollover16Bit, 0, 10, 9, 0, false},
+					{"max gap", FCntValidationStrict, 10, 0, 9, 9, true},
+					{"max gap exceeded", FCntValidationStrict, 10, 0, 10, 0, false},
+					{"relaxed reset", FCntValidationRelaxed, 0, 10, 1, 0, false},
+				}
+
+				for _, test := range testTable {
+					Convey(fmt.Sprintf("Then %s: FCntUp=%d, NodeFCnt=%d should return (%d, %t)", test.Name, test.ServerFCnt, test.NodeFCnt, test.FullFCnt, test.Valid), func() {
+						s.FCntUp = test.ServerFCnt
+						dp := DeviceProfile{
+							FCntValidationMode: test.Mode,
+							FCntMaxGap:         test.MaxGap,
+						}
+						fullFCntUp, ok := ValidateAndGetFullFCntUpForDeviceProfile(s, dp, test.NodeFCnt)
+						So(ok, ShouldEqual, test.Valid)
+						So(fullFCntUp, ShouldEqual, test.FullFCnt)
+					})
+				}
+			})
 		})
 	})
 }
 
+func TestDeviceSessionWrappedKeys(t *testing.T) {
+	assert := require.New(t)
+
+	conf := test.GetConfig()
+	conf.JoinServer.KEK.Set = []struct {
+		Label string
+		KEK   string `mapstructure:"kek"`
+	}{
+		{
+			Label: "010203",
+			KEK:   "00000000000000000000000000000000",
+		},
+	}
+	assert.NoError(Setup(conf))
+	assert.NoError(kek.Setup(conf))
+	test.MustFlushRedis(RedisPool())
+
+	sNwkSIntKey := lorawan.AES128Key{88, 148, 152, 153, 48, 146, 207, 219, 95, 210, 224, 42, 199, 81, 11, 241}
+	ds := DeviceSession{
+		DevAddr:             lorawan.DevAddr{1, 2, 3, 4},
+		DevEUI:              lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		JoinEUI:             lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		ExtraUplinkChannels: map[int]loraband.Channel{},
+		SNwkSIntKeyEnvelope: &KeyEnvelope{
+			KEKLabel: "010203",
+			AESKey:   []byte{246, 176, 184, 31, 61, 48, 41, 18, 85, 145, 192, 176, 184, 141, 118, 201, 59, 72, 172, 164, 4, 22, 133, 211},
+		},
+	}
+	assert.NoError(SaveDeviceSession(context.Background(), RedisPool(), ds))
+
+	t.Run("Plain key is not stored", func(t *testing.T) {
+		assert := require.New(t)
+
+		c := RedisPool().Get()
+		defer c.Close()
+
+		b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(deviceSessionKeyTempl, ds.DevEUI)))
+		assert.NoError(err)
+
+		var dsPB DeviceSessionPB
+		assert.NoError(proto.Unmarshal(b, &dsPB))
+		assert.Len(dsPB.SNwkSIntKey, 0)
+		assert.Equal("010203", dsPB.SNwkSIntKeyEnvelope.KekLabel)
+	})
+
+	t.Run("Key is unwrapped on get", func(t *testing.T) {
+		assert := require.New(t)
+
+		dsGet, err := GetDeviceSession(context.Background(), RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sNwkSIntKey, dsGet.SNwkSIntKey)
+		assert.Equal(ds.SNwkSIntKeyEnvelope, dsGet.SNwkSIntKeyEnvelope)
+
+		// saving the read device-session does not store the plain key
+		assert.NoError(SaveDeviceSession(context.Background(), RedisPool(), dsGet))
+		dsGet, err = GetDeviceSession(context.Background(), RedisPool(), ds.DevEUI)
+		assert.NoError(err)
+		assert.Equal(sNwkSIntKey, dsGet.SNwkSIntKey)
+	})
+
+	t.Run("Unknown KEK label", func(t *testing.T) {
+		assert := require.New(t)
+
+		conf.JoinServer.KEK.Set = nil
+		assert.NoError(kek.Setup(conf))
+		defer kek.Setup(test.GetConfig())
+
+		_, err := GetDeviceSession(context.Background(), RedisPool(), ds.DevEUI)
+		assert.Error(err)
+	})
+}
+
+func TestIsFCntResetForDeviceProfile(t *testing.T) {
+	fCntResetThreshold = 16
+	defer func() { fCntResetThreshold = 0 }()
+
+	tests := []struct {
+		Name          string
+		DeviceSession DeviceSession
+		Mode          FCntValidationMode
+		FCnt          uint32
+		Reset         bool
+	}{
+		{"strict", DeviceSession{FCntUp: 100}, FCntValidationStrict, 0, false},
+		{"relaxed below threshold", DeviceSession{FCntUp: 100}, FCntValidationRelaxed, 15, true},
+		{"relaxed at threshold", DeviceSession{FCntUp: 100}, FCntValidationRelaxed, 16, false},
+		{"relaxed old frame-counter", DeviceSession{FCntUp: 100}, FCntValidationRelaxed, 90, false},
+		{"skip fcnt validation", DeviceSession{FCntUp: 100, SkipFCntValidation: true}, FCntValidationStrict, 90, true},
+		{"certification test mode", DeviceSession{FCntUp: 100, CertificationTestMode: true}, FCntValidationStrict, 90, true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+			dp := DeviceProfile{FCntValidationMode: tst.Mode}
+			assert.Equal(tst.Reset, IsFCntResetForDeviceProfile(tst.DeviceSession, dp, tst.FCnt))
+		})
+	}
+}
+
 func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.FCntResetThreshold = 16
 	if err := Setup(conf); err != nil {
 		t.Fatal(err)
 	}
 	ctx := context.Background()
 
-	Convey("Given a clean Redis database with a set of device-sessions for the same DevAddr", t, func() {
+	Convey("Given a clean database with a set of device-sessions for the same DevAddr", t, func() {
+		test.MustResetDB(DB().DB)
 		test.MustFlushRedis(RedisPool())
 
 		devAddr := lorawan.DevAddr{1, 2, 3, 4}
 
+		dp := DeviceProfile{
+			FCntValidationMode: FCntValidationRelaxed,
+		}
+		So(CreateDeviceProfile(ctx, DB(), &dp), ShouldBeNil)
+
 		deviceSessions := []DeviceSession{
 			{
 				DevAddr:            devAddr,
@@ -222,6 +436,15 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					FCntUp:      0,
 				},
 			},
+			{
+				DeviceProfileID: dp.ID,
+				DevAddr:         devAddr,
+				DevEUI:          lorawan.EUI64{5, 5, 5, 5, 5, 5, 5, 5},
+				SNwkSIntKey:     lorawan.AES128Key{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5},
+				FNwkSIntKey:     lorawan.AES128Key{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5},
+				NwkSEncKey:      lorawan.AES128Key{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5},
+				FCntUp:          500,
+			},
 		}
 		for _, s := range deviceSessions {
 			So(SaveDeviceSession(ctx, RedisPool(), s), ShouldBeNil)
@@ -229,14 +452,15 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 
 		Convey("Given a set of tests", func() {
 			testTable := []struct {
-				Name           string
-				DevAddr        lorawan.DevAddr
-				SNwkSIntKey    lorawan.AES128Key
-				FNwkSIntKey    lorawan.AES128Key
-				FCnt           uint32
-				ExpectedDevEUI lorawan.EUI64
-				ExpectedFCntUp uint32
-				ExpectedError  error
+				Name              string
+				DevAddr           lorawan.DevAddr
+				SNwkSIntKey       lorawan.AES128Key
+				FNwkSIntKey       lorawan.AES128Key
+				FCnt              uint32
+				ExpectedDevEUI    lorawan.EUI64
+				ExpectedFCntUp    uint32
+				ExpectedFCntReset *FCntReset
+				ExpectedError     error
 			}{
 				{
 					Name:           "matching DevEUI 0101010101010101",
@@ -264,6 +488,40 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					FCnt:           0,
 					ExpectedFCntUp: 0, // has been reset
 					ExpectedDevEUI: deviceSessions[0].DevEUI,
+					ExpectedFCntReset: &FCntReset{
+						FCnt:         0,
+						PreviousFCnt: deviceSessions[0].FCntUp,
+					},
+				},
+				{
+					Name:           "matching DevEUI 0505050505050505 with valid frame counter (relaxed)",
+					DevAddr:        devAddr,
+					FNwkSIntKey:    deviceSessions[3].FNwkSIntKey,
+					SNwkSIntKey:    deviceSessions[3].SNwkSIntKey,
+					FCnt:           deviceSessions[3].FCntUp,
+					ExpectedFCntUp: deviceSessions[3].FCntUp,
+					ExpectedDevEUI: deviceSessions[3].DevEUI,
+				},
+				{
+					Name:           "matching DevEUI 0505050505050505 with frame counter reset (relaxed)",
+					DevAddr:        devAddr,
+					FNwkSIntKey:    deviceSessions[3].FNwkSIntKey,
+					SNwkSIntKey:    deviceSessions[3].SNwkSIntKey,
+					FCnt:           2,
+					ExpectedFCntUp: 2,
+					ExpectedDevEUI: deviceSessions[3].DevEUI,
+					ExpectedFCntReset: &FCntReset{
+						FCnt:         2,
+						PreviousFCnt: deviceSessions[3].FCntUp,
+					},
+				},
+				{
+					Name:          "matching DevEUI 0505050505050505 with old frame counter above the reset threshold (relaxed)",
+					DevAddr:       devAddr,
+					FNwkSIntKey:   deviceSessions[3].FNwkSIntKey,
+					SNwkSIntKey:   deviceSessions[3].SNwkSIntKey,
+					FCnt:          400,
+					ExpectedError: ErrDoesNotExistOrFCntOrMICInvalid,
 				},
 				{
 					Name:          "matching DevEUI 0202020202020202 with invalid frame counter",
@@ -317,7 +575,7 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					}
 					So(phy.SetUplinkDataMIC(lorawan.LoRaWAN1_0, 0, 0, 0, test.FNwkSIntKey, test.SNwkSIntKey), ShouldBeNil)
 
-					s, err := GetDeviceSessionForPHYPayload(ctx, RedisPool(), phy, 0, 0)
+					s, fCntReset, err := GetDeviceSessionForPHYPayload(ctx, RedisPool(), phy, 0, 0)
 					if test.ExpectedError != nil {
 						So(err, ShouldNotBeNil)
 						So(err.Error(), ShouldEqual, test.ExpectedError.Error())
@@ -326,6 +584,7 @@ func TestGetDeviceSessionForPHYPayload(t *testing.T) {
 					So(err, ShouldBeNil)
 					So(s.DevEUI, ShouldResemble, test.ExpectedDevEUI)
 					So(s.FCntUp, ShouldEqual, test.ExpectedFCntUp)
+					So(fCntReset, ShouldResemble, test.ExpectedFCntReset)
 				})
 			}
 		})
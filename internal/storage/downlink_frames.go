@@ -16,12 +16,12 @@ import (
 )
 
 const downlinkFramesTTL = time.Second * 10
-const downlinkFramesKeyTempl = "lora:ns:frames:%d"
-const downlinkFramesDevEUIKeyTempl = "lora:ns:frames:deveui:%d"
+const downlinkFramesKeyTempl = "lora:ns:frames:{%d}"
+const downlinkFramesDevEUIKeyTempl = "lora:ns:frames:deveui:{%d}"
 
 // SaveDownlinkFrames saves the given downlink-frames. The downlink-frames
 // must share the same token!
-func SaveDownlinkFrames(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, frames []gw.DownlinkFrame) error {
+func SaveDownlinkFrames(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, frames []gw.DownlinkFrame) error {
 	if len(frames) == 0 {
 		return nil
 	}
@@ -69,7 +69,7 @@ func SaveDownlinkFrames(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64
 }
 
 // PopDownlinkFrame returns the first downlink-frame for the given token.
-func PopDownlinkFrame(ctx context.Context, p *redis.Pool, token uint32) (lorawan.EUI64, gw.DownlinkFrame, error) {
+func PopDownlinkFrame(ctx context.Context, p RedisClient, token uint32) (lorawan.EUI64, gw.DownlinkFrame, error) {
 	var out gw.DownlinkFrame
 	var devEUI lorawan.EUI64
 
@@ -15,6 +15,12 @@ var (
 	ErrInvalidAggregationInterval     = errors.New("invalid aggregation interval")
 	ErrInvalidName                    = errors.New("invalid gateway name")
 	ErrInvalidFPort                   = errors.New("invalid fPort (must be > 0)")
+	ErrInvalidGeofence                = errors.New("invalid geofence (must be either a radius or a polygon of at least 3 points)")
+	ErrInvalidMulticastGroupSetup     = errors.New("invalid multicast-group setup (mc_group_id must be 0-3, mc_key_encrypted 16 bytes, min_mc_f_cnt <= max_mc_f_cnt and session_time_out 0-15)")
+	ErrFrequencyPlanInUse             = errors.New("frequency-plan is assigned to one or more gateway-profiles")
+	ErrDeviceSessionChanged           = errors.New("device-session was modified concurrently, retry the update")
+	ErrDeviceQueueFull                = errors.New("device-queue is full")
+	ErrDownlinkDisabled               = errors.New("downlinks are disabled by the service-profile")
 )
 
 func handlePSQLError(err error, description string) error {
@@ -0,0 +1,366 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// FrequencyPlan defines a named frequency-plan template. Each update of the
+// frequency-plan results in a new version, so that gateway-profiles can be
+// pinned to a version and rolled back.
+type FrequencyPlan struct {
+	ID        uuid.UUID `db:"frequency_plan_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Name      string    `db:"name"`
+
+	// Version of the frequency-plan settings below. When retrieving the
+	// frequency-plan without a version, this holds the latest version.
+	Version int `db:"version"`
+
+	Channels          []int64        `db:"channels"`
+	ExtraChannels     []ExtraChannel `db:"-"`
+	RX2Frequency      int            `db:"rx2_frequency"`
+	RX2DR             int            `db:"rx2_dr"`
+	BeaconFrequency   int            `db:"beacon_frequency"`
+	BeaconDR          int            `db:"beacon_dr"`
+	PingSlotFrequency int            `db:"ping_slot_frequency"`
+	PingSlotDR        int            `db:"ping_slot_dr"`
+}
+
+// FrequencyPlanVersion contains the meta-data of a frequency-plan version.
+type FrequencyPlanVersion struct {
+	Version   int       `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// CreateFrequencyPlan creates the given frequency-plan as version 1.
+// As this will execute multiple SQL statements, it is recommended to perform
+// this within a transaction.
+func CreateFrequencyPlan(ctx context.Context, db sqlx.Execer, fp *FrequencyPlan) error {
+	now := time.Now()
+	fp.CreatedAt = now
+	fp.UpdatedAt = now
+	fp.Version = 1
+
+	if fp.ID == uuid.Nil {
+		var err error
+		fp.ID, err = uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid v4 error")
+		}
+	}
+
+	_, err := db.Exec(`
+		insert into frequency_plan (
+			frequency_plan_id,
+			created_at,
+			updated_at,
+			name,
+			version
+		) values ($1, $2, $3, $4, $5)`,
+		fp.ID,
+		fp.CreatedAt,
+		fp.UpdatedAt,
+		fp.Name,
+		fp.Version,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	if err := createFrequencyPlanVersion(db, fp); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"id":      fp.ID,
+		"name":    fp.Name,
+		"version": fp.Version,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}).Info("frequency-plan created")
+
+	return nil
+}
+
+// GetFrequencyPlan returns the latest version of the frequency-plan matching
+// the given ID.
+func GetFrequencyPlan(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (FrequencyPlan, error) {
+	return GetFrequencyPlanVersion(ctx, db, id, 0)
+}
+
+// GetFrequencyPlanVersion returns the given version of the frequency-plan
+// matching the given ID. When version is 0, the latest version is returned.
+func GetFrequencyPlanVersion(ctx context.Context, db sqlx.Queryer, id uuid.UUID, version int) (FrequencyPlan, error) {
+	var fp FrequencyPlan
+	err := db.QueryRowx(`
+		select
+			fp.frequency_plan_id,
+			fp.created_at,
+			fp.updated_at,
+			fp.name,
+			fpv.version,
+			fpv.channels,
+			fpv.rx2_frequency,
+			fpv.rx2_dr,
+			fpv.beacon_frequency,
+			fpv.beacon_dr,
+			fpv.ping_slot_frequency,
+			fpv.ping_slot_dr
+		from frequency_plan fp
+		inner join frequency_plan_version fpv
+			on fpv.frequency_plan_id = fp.frequency_plan_id
+		where
+			fp.frequency_plan_id = $1
+			and fpv.version = (case when $2::integer = 0 then fp.version else $2::integer end)`,
+		id,
+		version,
+	).Scan(
+		&fp.ID,
+		&fp.CreatedAt,
+		&fp.UpdatedAt,
+		&fp.Name,
+		&fp.Version,
+		pq.Array(&fp.Channels),
+		&fp.RX2Frequency,
+		&fp.RX2DR,
+		&fp.BeaconFrequency,
+		&fp.BeaconDR,
+		&fp.PingSlotFrequency,
+		&fp.PingSlotDR,
+	)
+	if err != nil {
+		return fp, handlePSQLError(err, "select error")
+	}
+
+	rows, err := db.Query(`
+		select
+			modulation,
+			frequency,
+			bandwidth,
+			bitrate,
+			spreading_factors
+		from frequency_plan_extra_channel
+		where
+			frequency_plan_id = $1
+			and version = $2
+		order by id`,
+		fp.ID,
+		fp.Version,
+	)
+	if err != nil {
+		return fp, handlePSQLError(err, "select error")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ec ExtraChannel
+		err := rows.Scan(
+			&ec.Modulation,
+			&ec.Frequency,
+			&ec.Bandwidth,
+			&ec.Bitrate,
+			pq.Array(&ec.SpreadingFactors),
+		)
+		if err != nil {
+			return fp, handlePSQLError(err, "select error")
+		}
+		fp.ExtraChannels = append(fp.ExtraChannels, ec)
+	}
+
+	return fp, nil
+}
+
+// GetFrequencyPlanVersions returns the versions of the given frequency-plan,
+// newest first.
+func GetFrequencyPlanVersions(ctx context.Context, db sqlx.Queryer, id uuid.UUID) ([]FrequencyPlanVersion, error) {
+	var versions []FrequencyPlanVersion
+	err := sqlx.Select(db, &versions, `
+		select
+			version,
+			created_at
+		from frequency_plan_version
+		where
+			frequency_plan_id = $1
+		order by version desc`,
+		id,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return versions, nil
+}
+
+// UpdateFrequencyPlan updates the given frequency-plan. The previous versions
+// are kept and the given frequency-plan is stored as a new version.
+// As this will execute multiple SQL statements, it is recommended to perform
+// this within a transaction.
+func UpdateFrequencyPlan(ctx context.Context, db sqlx.Ext, fp *FrequencyPlan) error {
+	fp.UpdatedAt = time.Now()
+
+	err := sqlx.Get(db, &fp.Version, `
+		update frequency_plan
+		set
+			updated_at = $2,
+			name = $3,
+			version = version + 1
+		where
+			frequency_plan_id = $1
+		returning version`,
+		fp.ID,
+		fp.UpdatedAt,
+		fp.Name,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+
+	if err := createFrequencyPlanVersion(db, fp); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"id":      fp.ID,
+		"version": fp.Version,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}).Info("frequency-plan updated")
+
+	return nil
+}
+
+// DeleteFrequencyPlan deletes the frequency-plan matching the given ID,
+// including all its versions. This fails when the frequency-plan is still
+// assigned to a gateway-profile.
+func DeleteFrequencyPlan(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
+	res, err := db.Exec(`
+		delete from frequency_plan
+		where
+			frequency_plan_id = $1`,
+		id,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return ErrFrequencyPlanInUse
+		}
+		return handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":     id,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("frequency-plan deleted")
+
+	return nil
+}
+
+// GetFrequencyPlanForGatewayProfile returns the frequency-plan assigned to
+// the given gateway-profile, using the version the gateway-profile is pinned
+// to. ErrDoesNotExist is returned when no frequency-plan is assigned.
+func GetFrequencyPlanForGatewayProfile(ctx context.Context, db sqlx.Queryer, gp GatewayProfile) (FrequencyPlan, error) {
+	if gp.FrequencyPlanID == nil {
+		return FrequencyPlan{}, ErrDoesNotExist
+	}
+
+	return GetFrequencyPlanVersion(ctx, db, *gp.FrequencyPlanID, gp.FrequencyPlanVersion)
+}
+
+// GetFrequencyPlanForGateway returns the frequency-plan assigned to the
+// gateway-profile of the given gateway. ErrDoesNotExist is returned when the
+// gateway has no gateway-profile or when no frequency-plan is assigned.
+func GetFrequencyPlanForGateway(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (FrequencyPlan, error) {
+	var fpID *uuid.UUID
+	var version int
+	err := db.QueryRowx(`
+		select
+			gp.frequency_plan_id,
+			gp.frequency_plan_version
+		from gateway g
+		inner join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id
+		where
+			g.gateway_id = $1`,
+		gatewayID[:],
+	).Scan(&fpID, &version)
+	if err != nil {
+		return FrequencyPlan{}, handlePSQLError(err, "select error")
+	}
+
+	if fpID == nil {
+		return FrequencyPlan{}, ErrDoesNotExist
+	}
+
+	return GetFrequencyPlanVersion(ctx, db, *fpID, version)
+}
+
+func createFrequencyPlanVersion(db sqlx.Execer, fp *FrequencyPlan) error {
+	_, err := db.Exec(`
+		insert into frequency_plan_version (
+			frequency_plan_id,
+			version,
+			created_at,
+			channels,
+			rx2_frequency,
+			rx2_dr,
+			beacon_frequency,
+			beacon_dr,
+			ping_slot_frequency,
+			ping_slot_dr
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		fp.ID,
+		fp.Version,
+		fp.UpdatedAt,
+		pq.Array(fp.Channels),
+		fp.RX2Frequency,
+		fp.RX2DR,
+		fp.BeaconFrequency,
+		fp.BeaconDR,
+		fp.PingSlotFrequency,
+		fp.PingSlotDR,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	for _, ec := range fp.ExtraChannels {
+		_, err := db.Exec(`
+			insert into frequency_plan_extra_channel (
+				frequency_plan_id,
+				version,
+				modulation,
+				frequency,
+				bandwidth,
+				bitrate,
+				spreading_factors
+			) values ($1, $2, $3, $4, $5, $6, $7)`,
+			fp.ID,
+			fp.Version,
+			ec.Modulation,
+			ec.Frequency,
+			ec.Bandwidth,
+			ec.Bitrate,
+			pq.Array(ec.SpreadingFactors),
+		)
+		if err != nil {
+			return handlePSQLError(err, "insert error")
+		}
+	}
+
+	return nil
+}
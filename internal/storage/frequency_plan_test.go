@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (ts *StorageTestSuite) TestFrequencyPlan() {
+	assert := require.New(ts.T())
+
+	fp := FrequencyPlan{
+		Name:     "eu868-default",
+		Channels: []int64{0, 1, 2},
+		ExtraChannels: []ExtraChannel{
+			{
+				Modulation:       ModulationLoRa,
+				Frequency:        867100000,
+				Bandwidth:        125,
+				SpreadingFactors: []int64{7, 8, 9, 10, 11, 12},
+			},
+		},
+		RX2Frequency: 869525000,
+		RX2DR:        0,
+	}
+	assert.NoError(CreateFrequencyPlan(context.Background(), ts.Tx(), &fp))
+	assert.Equal(1, fp.Version)
+
+	fp.CreatedAt = fp.CreatedAt.Round(time.Millisecond).UTC()
+	fp.UpdatedAt = fp.UpdatedAt.Round(time.Millisecond).UTC()
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		fpGet, err := GetFrequencyPlan(context.Background(), ts.Tx(), fp.ID)
+		assert.NoError(err)
+
+		fpGet.CreatedAt = fpGet.CreatedAt.Round(time.Millisecond).UTC()
+		fpGet.UpdatedAt = fpGet.UpdatedAt.Round(time.Millisecond).UTC()
+		assert.Equal(fp, fpGet)
+	})
+
+	ts.T().Run("Update creates new version", func(t *testing.T) {
+		assert := require.New(t)
+
+		fpUpdated := fp
+		fpUpdated.Channels = []int64{0, 1}
+		fpUpdated.ExtraChannels = nil
+		fpUpdated.RX2Frequency = 869525000
+		fpUpdated.RX2DR = 3
+		assert.NoError(UpdateFrequencyPlan(context.Background(), ts.Tx(), &fpUpdated))
+		assert.Equal(2, fpUpdated.Version)
+
+		fpGet, err := GetFrequencyPlan(context.Background(), ts.Tx(), fp.ID)
+		assert.NoError(err)
+		assert.Equal(2, fpGet.Version)
+		assert.Equal([]int64{0, 1}, fpGet.Channels)
+		assert.Equal(3, fpGet.RX2DR)
+		assert.Len(fpGet.ExtraChannels, 0)
+
+		fpGet, err = GetFrequencyPlanVersion(context.Background(), ts.Tx(), fp.ID, 1)
+		assert.NoError(err)
+		assert.Equal(1, fpGet.Version)
+		assert.Equal(fp.Channels, fpGet.Channels)
+		assert.Equal(fp.ExtraChannels, fpGet.ExtraChannels)
+
+		versions, err := GetFrequencyPlanVersions(context.Background(), ts.Tx(), fp.ID)
+		assert.NoError(err)
+		assert.Len(versions, 2)
+		assert.Equal(2, versions[0].Version)
+		assert.Equal(1, versions[1].Version)
+
+		_, err = GetFrequencyPlanVersion(context.Background(), ts.Tx(), fp.ID, 3)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Assign to gateway-profile", func(t *testing.T) {
+		assert := require.New(t)
+
+		gp := GatewayProfile{
+			FrequencyPlanID:      &fp.ID,
+			FrequencyPlanVersion: 1,
+		}
+		assert.NoError(CreateGatewayProfile(context.Background(), ts.Tx(), &gp))
+
+		fpGet, err := GetFrequencyPlanForGatewayProfile(context.Background(), ts.Tx(), gp)
+		assert.NoError(err)
+		assert.Equal(1, fpGet.Version)
+
+		assert.Equal(ErrFrequencyPlanInUse, DeleteFrequencyPlan(context.Background(), ts.Tx(), fp.ID))
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"database/sql/driver"
 	"encoding/gob"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"time"
 
@@ -58,13 +60,58 @@ type Gateway struct {
 	Location         GPSPoint       `db:"location"`
 	Altitude         float64        `db:"altitude"`
 	GatewayProfileID *uuid.UUID     `db:"gateway_profile_id"`
+	SubBand          int            `db:"sub_band"`
+	AntennaGain      float64        `db:"antenna_gain"`
+	CableLoss        float64        `db:"cable_loss"`
 	Boards           []GatewayBoard `db:"-"`
 }
 
+// GetTXPowerForMaxEIRP returns the (conducted) TX power, so that the
+// radiated power does not exceed the given max. EIRP, taking the antenna
+// gain and cable loss of the gateway into account.
+func (gw Gateway) GetTXPowerForMaxEIRP(maxEIRP int) int {
+	return int(math.Floor(float64(maxEIRP) - gw.AntennaGain + gw.CableLoss))
+}
+
+// GetAntenna returns the antenna for the given board and antenna index.
+// It returns nil when the antenna has not been configured.
+func (gw Gateway) GetAntenna(board, antenna uint32) *GatewayAntenna {
+	if int(board) >= len(gw.Boards) || int(antenna) >= len(gw.Boards[board].Antennas) {
+		return nil
+	}
+	return &gw.Boards[board].Antennas[antenna]
+}
+
+// GetAntennaTXPowerForMaxEIRP returns the (conducted) TX power for the given
+// board and antenna, so that the radiated power does not exceed the given
+// max. EIRP. When the antenna has not been configured, the antenna gain and
+// cable loss of the gateway are used (see GetTXPowerForMaxEIRP).
+func (gw Gateway) GetAntennaTXPowerForMaxEIRP(board, antenna uint32, maxEIRP int) int {
+	a := gw.GetAntenna(board, antenna)
+	if a == nil {
+		return gw.GetTXPowerForMaxEIRP(maxEIRP)
+	}
+	return int(math.Floor(float64(maxEIRP) - a.Gain + a.CableLoss))
+}
+
 // GatewayBoard holds the gateway board configuration.
 type GatewayBoard struct {
 	FPGAID           *lorawan.EUI64     `db:"fpga_id"`
 	FineTimestampKey *lorawan.AES128Key `db:"fine_timestamp_key"`
+	Antennas         []GatewayAntenna   `db:"-"`
+}
+
+// GatewayAntenna holds the configuration of an antenna connected to a
+// gateway board. Sectorized antennas are defined by their azimuth and
+// (horizontal) beamwidth, a beamwidth of 0 defines an omni-directional
+// antenna.
+type GatewayAntenna struct {
+	Board     uint32  `db:"board_id"`
+	Antenna   uint32  `db:"id"`
+	Gain      float64 `db:"gain"`       // dBi
+	CableLoss float64 `db:"cable_loss"` // dB
+	Azimuth   float64 `db:"azimuth"`    // degrees, clockwise from north
+	Beamwidth float64 `db:"beamwidth"`  // degrees
 }
 
 // CreateGateway creates the given gateway.
@@ -83,8 +130,11 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			location,
 			altitude,
 			gateway_profile_id,
-			routing_profile_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			routing_profile_id,
+			sub_band,
+			antenna_gain,
+			cable_loss
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
 		gw.GatewayID[:],
 		gw.CreatedAt,
 		gw.UpdatedAt,
@@ -94,11 +144,27 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.Altitude,
 		gw.GatewayProfileID,
 		gw.RoutingProfileID,
+		gw.SubBand,
+		gw.AntennaGain,
+		gw.CableLoss,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
 	}
 
+	if err := createGatewayBoards(db, gw); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": gw.GatewayID,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("gateway created")
+	return nil
+}
+
+// createGatewayBoards creates the boards and antennas of the given gateway.
+func createGatewayBoards(db sqlx.Execer, gw *Gateway) error {
 	for i, board := range gw.Boards {
 		_, err := db.Exec(`
 			insert into gateway_board (
@@ -115,18 +181,41 @@ func CreateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		if err != nil {
 			return handlePSQLError(err, "insert error")
 		}
+
+		for j := range board.Antennas {
+			board.Antennas[j].Board = uint32(i)
+			board.Antennas[j].Antenna = uint32(j)
+
+			_, err := db.Exec(`
+				insert into gateway_antenna (
+					id,
+					gateway_id,
+					board_id,
+					gain,
+					cable_loss,
+					azimuth,
+					beamwidth
+				) values ($1, $2, $3, $4, $5, $6, $7)`,
+				j,
+				gw.GatewayID,
+				i,
+				board.Antennas[j].Gain,
+				board.Antennas[j].CableLoss,
+				board.Antennas[j].Azimuth,
+				board.Antennas[j].Beamwidth,
+			)
+			if err != nil {
+				return handlePSQLError(err, "insert error")
+			}
+		}
 	}
 
-	log.WithFields(log.Fields{
-		"gateway_id": gw.GatewayID,
-		"ctx_id":     ctx.Value(logging.ContextIDKey),
-	}).Info("gateway created")
 	return nil
 }
 
 // CreateGatewayCache caches the given gateway in Redis.
 // The TTL of the gateway is the same as that of the device-sessions.
-func CreateGatewayCache(ctx context.Context, p *redis.Pool, gw Gateway) error {
+func CreateGatewayCache(ctx context.Context, p RedisClient, gw Gateway) error {
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(gw); err != nil {
 		return errors.Wrap(err, "gob encode gateway error")
@@ -139,6 +228,7 @@ func CreateGatewayCache(ctx context.Context, p *redis.Pool, gw Gateway) error {
 	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
 
 	_, err := c.Do("PSETEX", key, exp, buf.Bytes())
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "set gateway error")
 	}
@@ -147,14 +237,14 @@ func CreateGatewayCache(ctx context.Context, p *redis.Pool, gw Gateway) error {
 }
 
 // GetGatewayCache returns a cached gateway.
-func GetGatewayCache(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64) (Gateway, error) {
+func GetGatewayCache(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) (Gateway, error) {
 	var gw Gateway
 	key := fmt.Sprintf(gatewayKeyTempl, gatewayID)
 
 	c := p.Get()
 	defer c.Close()
 
-	val, err := redis.Bytes(c.Do("GET", key))
+	val, err := getCached(c, key)
 	if err != nil {
 		if err == redis.ErrNil {
 			return gw, ErrDoesNotExist
@@ -171,12 +261,13 @@ func GetGatewayCache(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64
 }
 
 // FlushGatewayCache deletes a cached gateway.
-func FlushGatewayCache(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI64) error {
+func FlushGatewayCache(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) error {
 	key := fmt.Sprintf(gatewayKeyTempl, gatewayID)
 	c := p.Get()
 	defer c.Close()
 
 	_, err := c.Do("DEL", key)
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "delete error")
 	}
@@ -187,7 +278,7 @@ func FlushGatewayCache(ctx context.Context, p *redis.Pool, gatewayID lorawan.EUI
 // GetAndCacheGateway returns a gateway from the cache in case it is available.
 // In case the gateway is not cached, it will be retrieved from the database
 // and then cached.
-func GetAndCacheGateway(ctx context.Context, db sqlx.Queryer, p *redis.Pool, gatewayID lorawan.EUI64) (Gateway, error) {
+func GetAndCacheGateway(ctx context.Context, db sqlx.Queryer, p RedisClient, gatewayID lorawan.EUI64) (Gateway, error) {
 	gw, err := GetGatewayCache(ctx, p, gatewayID)
 	if err == nil {
 		return gw, nil
@@ -242,6 +333,35 @@ func GetGateway(ctx context.Context, db sqlx.Queryer, id lorawan.EUI64) (Gateway
 		return gw, handlePSQLError(err, "select error")
 	}
 
+	var antennas []GatewayAntenna
+	err = sqlx.Select(db, &antennas, `
+		select
+			board_id,
+			id,
+			gain,
+			cable_loss,
+			azimuth,
+			beamwidth
+		from
+			gateway_antenna
+		where
+			gateway_id = $1
+		order by
+			board_id,
+			id
+		`,
+		id,
+	)
+	if err != nil {
+		return gw, handlePSQLError(err, "select error")
+	}
+
+	for _, a := range antennas {
+		if int(a.Board) < len(gw.Boards) {
+			gw.Boards[a.Board].Antennas = append(gw.Boards[a.Board].Antennas, a)
+		}
+	}
+
 	return gw, nil
 }
 
@@ -258,7 +378,10 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 			location = $5,
 			altitude = $6,
 			gateway_profile_id = $7,
-			routing_profile_id = $8
+			routing_profile_id = $8,
+			sub_band = $9,
+			antenna_gain = $10,
+			cable_loss = $11
 		where gateway_id = $1`,
 		gw.GatewayID[:],
 		gw.UpdatedAt,
@@ -268,6 +391,9 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		gw.Altitude,
 		gw.GatewayProfileID,
 		gw.RoutingProfileID,
+		gw.SubBand,
+		gw.AntennaGain,
+		gw.CableLoss,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -288,22 +414,8 @@ func UpdateGateway(ctx context.Context, db sqlx.Execer, gw *Gateway) error {
 		return handlePSQLError(err, "delete error")
 	}
 
-	for i, board := range gw.Boards {
-		_, err := db.Exec(`
-			insert into gateway_board (
-				id,
-				gateway_id,
-				fpga_id,
-				fine_timestamp_key
-			) values ($1, $2, $3, $4)`,
-			i,
-			gw.GatewayID,
-			board.FPGAID,
-			board.FineTimestampKey,
-		)
-		if err != nil {
-			return handlePSQLError(err, "insert error")
-		}
+	if err := createGatewayBoards(db, gw); err != nil {
+		return err
 	}
 
 	log.WithFields(log.Fields{
@@ -357,3 +469,47 @@ func GetGatewaysForIDs(ctx context.Context, db sqlx.Queryer, ids []lorawan.EUI64
 
 	return out, nil
 }
+
+// GetSubBandsForGatewayIDs returns the (sorted) distinct sub-bands of the
+// given gateways. Gateways without sub-band or unknown gateways are ignored.
+func GetSubBandsForGatewayIDs(ctx context.Context, db sqlx.Queryer, p RedisClient, ids []lorawan.EUI64) ([]int, error) {
+	subBands := make(map[int]struct{})
+	for _, id := range ids {
+		gw, err := GetAndCacheGateway(ctx, db, p, id)
+		if err != nil {
+			if errors.Cause(err) == ErrDoesNotExist {
+				continue
+			}
+			return nil, errors.Wrap(err, "get gateway error")
+		}
+
+		if gw.SubBand != 0 {
+			subBands[gw.SubBand] = struct{}{}
+		}
+	}
+
+	var out []int
+	for subBand := range subBands {
+		out = append(out, subBand)
+	}
+	sort.Ints(out)
+
+	return out, nil
+}
+
+// GetDownlinkTXPowerForGateway returns the downlink TX power for the given
+// gateway, board, antenna and max. EIRP (see
+// Gateway.GetAntennaTXPowerForMaxEIRP). In case the gateway could not be
+// retrieved, the max. EIRP is returned.
+func GetDownlinkTXPowerForGateway(ctx context.Context, db sqlx.Queryer, p RedisClient, gatewayID lorawan.EUI64, board, antenna uint32, maxEIRP int) int {
+	gw, err := GetAndCacheGateway(ctx, db, p, gatewayID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+		}).Error("get gateway for downlink tx-power error")
+		return maxEIRP
+	}
+
+	return gw.GetAntennaTXPowerForMaxEIRP(board, antenna, maxEIRP)
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+const gatewayCommandExecResponseKeyTempl = "lora:ns:gw:exec:%s"
+
+// gatewayCommandExecResponseTTL defines the duration for which a received
+// gateway command execution response can be retrieved.
+const gatewayCommandExecResponseTTL = 10 * time.Minute
+
+// SaveGatewayCommandExecResponse stores the given gateway command execution
+// response, so that it can be retrieved by its execution ID by any LoRa
+// Server instance.
+func SaveGatewayCommandExecResponse(ctx context.Context, p RedisClient, resp gw.GatewayCommandExecResponse) error {
+	execID, err := uuid.FromBytes(resp.ExecId)
+	if err != nil {
+		return errors.Wrap(err, "uuid from bytes error")
+	}
+
+	b, err := proto.Marshal(&resp)
+	if err != nil {
+		return errors.Wrap(err, "protobuf marshal error")
+	}
+
+	key := fmt.Sprintf(gatewayCommandExecResponseKeyTempl, execID)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("DEL", key)
+	c.Send("RPUSH", key, b)
+	c.Send("PEXPIRE", key, int64(gatewayCommandExecResponseTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// GetGatewayCommandExecResponse returns the gateway command execution
+// response for the given execution ID. When timeout is greater than zero,
+// it blocks (rounded up to whole seconds) until the response has been
+// received. It returns ErrDoesNotExist when no response is available.
+func GetGatewayCommandExecResponse(ctx context.Context, p RedisClient, execID uuid.UUID, timeout time.Duration) (gw.GatewayCommandExecResponse, error) {
+	var resp gw.GatewayCommandExecResponse
+	key := fmt.Sprintf(gatewayCommandExecResponseKeyTempl, execID)
+
+	c := p.Get()
+	defer c.Close()
+
+	var b []byte
+	var err error
+	if timeout > 0 {
+		// BRPOPLPUSH on the same key blocks until the response is available
+		// and keeps it in the list, so that it can be retrieved again.
+		b, err = redis.Bytes(c.Do("BRPOPLPUSH", key, key, int64(math.Ceil(timeout.Seconds()))))
+	} else {
+		b, err = redis.Bytes(c.Do("LINDEX", key, -1))
+	}
+	if err != nil {
+		if err == redis.ErrNil {
+			return resp, ErrDoesNotExist
+		}
+		return resp, errors.Wrap(err, "get response error")
+	}
+
+	if err := proto.Unmarshal(b, &resp); err != nil {
+		return resp, errors.Wrap(err, "protobuf unmarshal error")
+	}
+
+	return resp, nil
+}
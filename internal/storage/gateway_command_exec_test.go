@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func (ts *StorageTestSuite) TestGatewayCommandExecResponse() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	execID, err := uuid.NewV4()
+	assert.NoError(err)
+
+	_, err = GetGatewayCommandExecResponse(context.Background(), ts.RedisPool(), execID, 0)
+	assert.Equal(ErrDoesNotExist, err)
+
+	_, err = GetGatewayCommandExecResponse(context.Background(), ts.RedisPool(), execID, time.Second)
+	assert.Equal(ErrDoesNotExist, err)
+
+	resp := gw.GatewayCommandExecResponse{
+		GatewayId: gatewayID[:],
+		ExecId:    execID.Bytes(),
+		Stdout:    []byte("hello"),
+	}
+	assert.NoError(SaveGatewayCommandExecResponse(context.Background(), ts.RedisPool(), resp))
+
+	// the response can be retrieved more than once
+	for _, timeout := range []time.Duration{time.Second, 0, time.Second} {
+		r, err := GetGatewayCommandExecResponse(context.Background(), ts.RedisPool(), execID, timeout)
+		assert.NoError(err)
+		assert.Equal(resp.Stdout, r.Stdout)
+		assert.Equal(resp.GatewayId, r.GatewayId)
+	}
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// GatewayConfigurationState defines the rollout state of a gateway
+// configuration.
+type GatewayConfigurationState string
+
+// Gateway configuration states.
+const (
+	GatewayConfigurationUnknown   GatewayConfigurationState = ""
+	GatewayConfigurationScheduled GatewayConfigurationState = "SCHEDULED"
+	GatewayConfigurationSent      GatewayConfigurationState = "SENT"
+	GatewayConfigurationApplied   GatewayConfigurationState = "APPLIED"
+)
+
+// GatewayConfigurationStatus contains the rollout status of the
+// configuration of a gateway.
+type GatewayConfigurationStatus struct {
+	GatewayID   lorawan.EUI64             `db:"gateway_id"`
+	CreatedAt   time.Time                 `db:"created_at"`
+	UpdatedAt   time.Time                 `db:"updated_at"`
+	Version     string                    `db:"version"`
+	State       GatewayConfigurationState `db:"state"`
+	ScheduledAt *time.Time                `db:"scheduled_at"`
+	SentAt      *time.Time                `db:"sent_at"`
+	AppliedAt   *time.Time                `db:"applied_at"`
+}
+
+// SaveGatewayConfigurationStatus creates or updates the given gateway
+// configuration status.
+func SaveGatewayConfigurationStatus(ctx context.Context, db sqlx.Execer, s *GatewayConfigurationStatus) error {
+	now := time.Now()
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+
+	_, err := db.Exec(`
+		insert into gateway_configuration_status (
+			gateway_id,
+			created_at,
+			updated_at,
+			version,
+			state,
+			scheduled_at,
+			sent_at,
+			applied_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		on conflict (gateway_id) do update
+		set
+			updated_at = $3,
+			version = $4,
+			state = $5,
+			scheduled_at = $6,
+			sent_at = $7,
+			applied_at = $8`,
+		s.GatewayID[:],
+		s.CreatedAt,
+		s.UpdatedAt,
+		s.Version,
+		s.State,
+		s.ScheduledAt,
+		s.SentAt,
+		s.AppliedAt,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": s.GatewayID,
+		"version":    s.Version,
+		"state":      s.State,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Info("gateway configuration status saved")
+
+	return nil
+}
+
+// SetGatewayConfigurationApplied marks the configuration status of the given
+// gateway as applied, when the stored version matches the given version and
+// the status has not been marked as applied yet.
+func SetGatewayConfigurationApplied(ctx context.Context, db sqlx.Execer, gatewayID lorawan.EUI64, version string) error {
+	now := time.Now()
+
+	res, err := db.Exec(`
+		update gateway_configuration_status
+		set
+			updated_at = $3,
+			state = $4,
+			scheduled_at = null,
+			applied_at = $3
+		where
+			gateway_id = $1
+			and version = $2
+			and state <> $4`,
+		gatewayID[:],
+		version,
+		now,
+		GatewayConfigurationApplied,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+
+	if ra != 0 {
+		log.WithFields(log.Fields{
+			"gateway_id": gatewayID,
+			"version":    version,
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+		}).Info("gateway configuration applied")
+	}
+
+	return nil
+}
+
+// GetGatewayConfigurationStatus returns the configuration status of the
+// given gateway. It returns ErrDoesNotExist when no configuration has been
+// rolled out to the gateway yet.
+func GetGatewayConfigurationStatus(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (GatewayConfigurationStatus, error) {
+	var s GatewayConfigurationStatus
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			gateway_configuration_status
+		where
+			gateway_id = $1`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetGatewayConfigurationStatusForGatewayProfile returns the configuration
+// status of the gateways using the given gateway-profile, ordered by gateway
+// ID. Gateways to which no configuration has been rolled out yet are
+// returned with an unknown state.
+func GetGatewayConfigurationStatusForGatewayProfile(ctx context.Context, db sqlx.Queryer, gatewayProfileID uuid.UUID) ([]GatewayConfigurationStatus, error) {
+	var out []GatewayConfigurationStatus
+	err := sqlx.Select(db, &out, `
+		select
+			g.gateway_id,
+			coalesce(s.created_at, g.created_at) as created_at,
+			coalesce(s.updated_at, g.updated_at) as updated_at,
+			coalesce(s.version, '') as version,
+			coalesce(s.state, '') as state,
+			s.scheduled_at,
+			s.sent_at,
+			s.applied_at
+		from
+			gateway g
+		left join gateway_configuration_status s
+			on s.gateway_id = g.gateway_id
+		where
+			g.gateway_profile_id = $1
+		order by
+			g.gateway_id`,
+		gatewayProfileID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayConfigurationStatus() {
+	assert := require.New(ts.T())
+
+	gp := GatewayProfile{}
+	assert.NoError(CreateGatewayProfile(context.Background(), ts.Tx(), &gp))
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateways := []Gateway{
+		{
+			GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			RoutingProfileID: rp.ID,
+			GatewayProfileID: &gp.ID,
+		},
+		{
+			GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			RoutingProfileID: rp.ID,
+			GatewayProfileID: &gp.ID,
+		},
+	}
+	for i := range gateways {
+		assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateways[i]))
+	}
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetGatewayConfigurationStatus(context.Background(), ts.Tx(), gateways[0].GatewayID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+		s := GatewayConfigurationStatus{
+			GatewayID:   gateways[0].GatewayID,
+			Version:     "v1",
+			State:       GatewayConfigurationScheduled,
+			ScheduledAt: &scheduledAt,
+		}
+		assert.NoError(SaveGatewayConfigurationStatus(context.Background(), ts.Tx(), &s))
+
+		sGet, err := GetGatewayConfigurationStatus(context.Background(), ts.Tx(), s.GatewayID)
+		assert.NoError(err)
+		assert.Equal("v1", sGet.Version)
+		assert.Equal(GatewayConfigurationScheduled, sGet.State)
+		assert.True(scheduledAt.Equal(*sGet.ScheduledAt))
+		assert.Nil(sGet.SentAt)
+		assert.Nil(sGet.AppliedAt)
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			sentAt := time.Now().UTC().Truncate(time.Millisecond)
+			s.State = GatewayConfigurationSent
+			s.ScheduledAt = nil
+			s.SentAt = &sentAt
+			assert.NoError(SaveGatewayConfigurationStatus(context.Background(), ts.Tx(), &s))
+
+			sGet, err := GetGatewayConfigurationStatus(context.Background(), ts.Tx(), s.GatewayID)
+			assert.NoError(err)
+			assert.Equal(GatewayConfigurationSent, sGet.State)
+			assert.Nil(sGet.ScheduledAt)
+			assert.True(sentAt.Equal(*sGet.SentAt))
+		})
+
+		t.Run("Set applied for other version", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SetGatewayConfigurationApplied(context.Background(), ts.Tx(), s.GatewayID, "v0"))
+
+			sGet, err := GetGatewayConfigurationStatus(context.Background(), ts.Tx(), s.GatewayID)
+			assert.NoError(err)
+			assert.Equal(GatewayConfigurationSent, sGet.State)
+			assert.Nil(sGet.AppliedAt)
+		})
+
+		t.Run("Set applied", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SetGatewayConfigurationApplied(context.Background(), ts.Tx(), s.GatewayID, "v1"))
+
+			sGet, err := GetGatewayConfigurationStatus(context.Background(), ts.Tx(), s.GatewayID)
+			assert.NoError(err)
+			assert.Equal(GatewayConfigurationApplied, sGet.State)
+			assert.NotNil(sGet.SentAt)
+			assert.NotNil(sGet.AppliedAt)
+		})
+	})
+
+	ts.T().Run("Get for gateway-profile", func(t *testing.T) {
+		assert := require.New(t)
+
+		statuses, err := GetGatewayConfigurationStatusForGatewayProfile(context.Background(), ts.Tx(), gp.ID)
+		assert.NoError(err)
+		assert.Len(statuses, 2)
+
+		assert.Equal(gateways[0].GatewayID, statuses[0].GatewayID)
+		assert.Equal("v1", statuses[0].Version)
+		assert.Equal(GatewayConfigurationApplied, statuses[0].State)
+
+		assert.Equal(gateways[1].GatewayID, statuses[1].GatewayID)
+		assert.Equal("", statuses[1].Version)
+		assert.Equal(GatewayConfigurationUnknown, statuses[1].State)
+	})
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// GatewayConnectionState contains the connection state of a gateway, as
+// reported by the gateway backend (e.g. the LoRa Gateway Bridge last-will
+// message).
+type GatewayConnectionState struct {
+	GatewayID lorawan.EUI64 `db:"gateway_id"`
+	UpdatedAt time.Time     `db:"updated_at"`
+	Online    bool          `db:"online"`
+}
+
+// SaveGatewayConnectionState creates or updates the given gateway
+// connection state. An existing state is only updated (including its
+// updated_at timestamp) when the state has changed, so that updated_at
+// contains the time of the last state change. It returns ErrDoesNotExist
+// when the gateway does not exist.
+func SaveGatewayConnectionState(ctx context.Context, db sqlx.Execer, s *GatewayConnectionState) error {
+	s.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		insert into gateway_connection_state (
+			gateway_id,
+			updated_at,
+			online
+		) values ($1, $2, $3)
+		on conflict (gateway_id) do update
+		set
+			updated_at = $2,
+			online = $3
+		where
+			gateway_connection_state.online <> $3`,
+		s.GatewayID[:],
+		s.UpdatedAt,
+		s.Online,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+
+	if ra != 0 {
+		log.WithFields(log.Fields{
+			"gateway_id": s.GatewayID,
+			"online":     s.Online,
+			"ctx_id":     ctx.Value(logging.ContextIDKey),
+		}).Info("gateway connection state saved")
+	}
+
+	return nil
+}
+
+// GetGatewayConnectionState returns the connection state of the given
+// gateway. It returns ErrDoesNotExist when no connection state has been
+// reported for the gateway.
+func GetGatewayConnectionState(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (GatewayConnectionState, error) {
+	var s GatewayConnectionState
+	err := sqlx.Get(db, &s, `
+		select
+			*
+		from
+			gateway_connection_state
+		where
+			gateway_id = $1`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// GetGatewayConnectionStateCount returns the number of gateway connection
+// states. When offlineOnly is set, only the offline gateways are counted.
+func GetGatewayConnectionStateCount(ctx context.Context, db sqlx.Queryer, offlineOnly bool) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select
+			count(*)
+		from
+			gateway_connection_state
+		where
+			$1 = false or online = false`,
+		offlineOnly,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetGatewayConnectionStates returns a slice of gateway connection states,
+// ordered by gateway ID. When offlineOnly is set, only the states of the
+// offline gateways are returned.
+func GetGatewayConnectionStates(ctx context.Context, db sqlx.Queryer, limit, offset int, offlineOnly bool) ([]GatewayConnectionState, error) {
+	var out []GatewayConnectionState
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			gateway_connection_state
+		where
+			$1 = false or online = false
+		order by
+			gateway_id
+		limit $2
+		offset $3`,
+		offlineOnly,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayConnectionState() {
+	assert := require.New(ts.T())
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateways := []Gateway{
+		{
+			GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			RoutingProfileID: rp.ID,
+		},
+		{
+			GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			RoutingProfileID: rp.ID,
+		},
+	}
+	for i := range gateways {
+		assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateways[i]))
+	}
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetGatewayConnectionState(context.Background(), ts.Tx(), gateways[0].GatewayID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		states := []GatewayConnectionState{
+			{GatewayID: gateways[0].GatewayID, Online: true},
+			{GatewayID: gateways[1].GatewayID, Online: false},
+		}
+		for i := range states {
+			assert.NoError(SaveGatewayConnectionState(context.Background(), ts.Tx(), &states[i]))
+		}
+
+		s, err := GetGatewayConnectionState(context.Background(), ts.Tx(), gateways[0].GatewayID)
+		assert.NoError(err)
+		assert.True(s.Online)
+		updatedAt := s.UpdatedAt
+
+		t.Run("Unchanged state keeps updated at", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SaveGatewayConnectionState(context.Background(), ts.Tx(), &GatewayConnectionState{
+				GatewayID: gateways[0].GatewayID,
+				Online:    true,
+			}))
+
+			s, err := GetGatewayConnectionState(context.Background(), ts.Tx(), gateways[0].GatewayID)
+			assert.NoError(err)
+			assert.True(updatedAt.Equal(s.UpdatedAt))
+		})
+
+		t.Run("List", func(t *testing.T) {
+			assert := require.New(t)
+
+			count, err := GetGatewayConnectionStateCount(context.Background(), ts.Tx(), false)
+			assert.NoError(err)
+			assert.Equal(2, count)
+
+			items, err := GetGatewayConnectionStates(context.Background(), ts.Tx(), 10, 0, false)
+			assert.NoError(err)
+			assert.Len(items, 2)
+			assert.Equal(gateways[0].GatewayID, items[0].GatewayID)
+
+			count, err = GetGatewayConnectionStateCount(context.Background(), ts.Tx(), true)
+			assert.NoError(err)
+			assert.Equal(1, count)
+
+			items, err = GetGatewayConnectionStates(context.Background(), ts.Tx(), 10, 0, true)
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(gateways[1].GatewayID, items[0].GatewayID)
+		})
+
+		t.Run("Offline", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SaveGatewayConnectionState(context.Background(), ts.Tx(), &GatewayConnectionState{
+				GatewayID: gateways[0].GatewayID,
+				Online:    false,
+			}))
+
+			s, err := GetGatewayConnectionState(context.Background(), ts.Tx(), gateways[0].GatewayID)
+			assert.NoError(err)
+			assert.False(s.Online)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayDrainKeyTempl = "lora:ns:gw:%s:drain"
+
+// StartGatewayDrain puts the given gateway in drain mode. A draining gateway
+// is no longer selected for new downlinks (including multicast), so that it
+// can be powered off for maintenance once the outstanding downlinks have
+// been transmitted. When the gateway is already draining, the original
+// start time is kept.
+func StartGatewayDrain(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("SET", fmt.Sprintf(gatewayDrainKeyTempl, gatewayID), time.Now().UnixNano(), "NX")
+	if err != nil {
+		return errors.Wrap(err, "set error")
+	}
+
+	return nil
+}
+
+// StopGatewayDrain takes the given gateway out of drain mode.
+func StopGatewayDrain(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(gatewayDrainKeyTempl, gatewayID)); err != nil {
+		return errors.Wrap(err, "del error")
+	}
+
+	return nil
+}
+
+// GetGatewayDrainStartedAt returns the time at which the given gateway was
+// put in drain mode. It returns nil when the gateway is not draining.
+func GetGatewayDrainStartedAt(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) (*time.Time, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ts, err := redis.Int64(c.Do("GET", fmt.Sprintf(gatewayDrainKeyTempl, gatewayID)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get error")
+	}
+
+	t := time.Unix(0, ts)
+	return &t, nil
+}
+
+// GetDrainingGateways returns the gateways of the given IDs which are in
+// drain mode.
+func GetDrainingGateways(ctx context.Context, p RedisClient, ids []lorawan.EUI64) (map[lorawan.EUI64]bool, error) {
+	out := make(map[lorawan.EUI64]bool)
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	var keys []string
+	for _, id := range ids {
+		keys = append(keys, fmt.Sprintf(gatewayDrainKeyTempl, id))
+	}
+
+	values, err := mget(p, keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "mget error")
+	}
+
+	for i, id := range ids {
+		if values[i] != nil {
+			out[id] = true
+		}
+	}
+
+	return out, nil
+}
+
+// FilterDrainingGateways returns the items of the given slice of which the
+// gateway is not in drain mode.
+func FilterDrainingGateways(ctx context.Context, p RedisClient, items []DeviceGatewayRXInfo) ([]DeviceGatewayRXInfo, error) {
+	var ids []lorawan.EUI64
+	for _, item := range items {
+		ids = append(ids, item.GatewayID)
+	}
+
+	draining, err := GetDrainingGateways(ctx, p, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []DeviceGatewayRXInfo
+	for _, item := range items {
+		if !draining[item.GatewayID] {
+			out = append(out, item)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayDrain() {
+	gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+	gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+	items := []DeviceGatewayRXInfo{
+		{GatewayID: gw1},
+		{GatewayID: gw2},
+	}
+
+	ts.T().Run("Not draining", func(t *testing.T) {
+		assert := require.New(t)
+
+		startedAt, err := GetGatewayDrainStartedAt(context.Background(), ts.RedisPool(), gw1)
+		assert.NoError(err)
+		assert.Nil(startedAt)
+
+		out, err := FilterDrainingGateways(context.Background(), ts.RedisPool(), items)
+		assert.NoError(err)
+		assert.Equal(items, out)
+	})
+
+	ts.T().Run("Start drain", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(StartGatewayDrain(context.Background(), ts.RedisPool(), gw1))
+		startedAt, err := GetGatewayDrainStartedAt(context.Background(), ts.RedisPool(), gw1)
+		assert.NoError(err)
+		assert.NotNil(startedAt)
+
+		t.Run("Start again keeps start time", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(StartGatewayDrain(context.Background(), ts.RedisPool(), gw1))
+			startedAt2, err := GetGatewayDrainStartedAt(context.Background(), ts.RedisPool(), gw1)
+			assert.NoError(err)
+			assert.True(startedAt.Equal(*startedAt2))
+		})
+
+		t.Run("Filter", func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := FilterDrainingGateways(context.Background(), ts.RedisPool(), items)
+			assert.NoError(err)
+			assert.Equal([]DeviceGatewayRXInfo{{GatewayID: gw2}}, out)
+
+			draining, err := GetDrainingGateways(context.Background(), ts.RedisPool(), []lorawan.EUI64{gw1, gw2})
+			assert.NoError(err)
+			assert.Equal(map[lorawan.EUI64]bool{gw1: true}, draining)
+		})
+
+		t.Run("Stop drain", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(StopGatewayDrain(context.Background(), ts.RedisPool(), gw1))
+			startedAt, err := GetGatewayDrainStartedAt(context.Background(), ts.RedisPool(), gw1)
+			assert.NoError(err)
+			assert.Nil(startedAt)
+
+			out, err := FilterDrainingGateways(context.Background(), ts.RedisPool(), items)
+			assert.NoError(err)
+			assert.Equal(items, out)
+		})
+	})
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// gatewayInventoryMetaDataKeys defines per inventory field the gateway stats
+// meta-data keys, in order of preference. Besides the generic keys, the keys
+// reported by the LoRa Gateway Bridge and the Basic Station backend are
+// supported.
+var gatewayInventoryMetaDataKeys = struct {
+	Model               []string
+	FirmwareVersion     []string
+	BridgeVersion       []string
+	ConcentratorVersion []string
+}{
+	Model:               []string{"model"},
+	FirmwareVersion:     []string{"firmware_version", "firmware"},
+	BridgeVersion:       []string{"bridge_version", "gateway_bridge_version", "station"},
+	ConcentratorVersion: []string{"concentrator_version", "hal_version"},
+}
+
+// GatewayInventory contains the model and the firmware, bridge and
+// concentrator versions of a gateway, as reported by its stats.
+type GatewayInventory struct {
+	GatewayID           lorawan.EUI64 `db:"gateway_id"`
+	CreatedAt           time.Time     `db:"created_at"`
+	UpdatedAt           time.Time     `db:"updated_at"`
+	Model               string        `db:"model"`
+	FirmwareVersion     string        `db:"firmware_version"`
+	BridgeVersion       string        `db:"bridge_version"`
+	ConcentratorVersion string        `db:"concentrator_version"`
+}
+
+// ParseGatewayInventory parses the gateway inventory from the given gateway
+// stats meta-data. It returns false when the meta-data does not contain any
+// inventory information.
+func ParseGatewayInventory(gatewayID lorawan.EUI64, metaData map[string]string) (GatewayInventory, bool) {
+	inv := GatewayInventory{
+		GatewayID:           gatewayID,
+		Model:               getMetaDataValue(metaData, gatewayInventoryMetaDataKeys.Model),
+		FirmwareVersion:     getMetaDataValue(metaData, gatewayInventoryMetaDataKeys.FirmwareVersion),
+		BridgeVersion:       getMetaDataValue(metaData, gatewayInventoryMetaDataKeys.BridgeVersion),
+		ConcentratorVersion: getMetaDataValue(metaData, gatewayInventoryMetaDataKeys.ConcentratorVersion),
+	}
+
+	ok := inv.Model != "" || inv.FirmwareVersion != "" || inv.BridgeVersion != "" || inv.ConcentratorVersion != ""
+	return inv, ok
+}
+
+// SaveGatewayInventory creates or updates the given gateway inventory. An
+// existing inventory is only updated (including its updated_at timestamp)
+// when one of the values has changed.
+func SaveGatewayInventory(ctx context.Context, db sqlx.Execer, inv *GatewayInventory) error {
+	now := time.Now()
+	if inv.CreatedAt.IsZero() {
+		inv.CreatedAt = now
+	}
+	inv.UpdatedAt = now
+
+	res, err := db.Exec(`
+		insert into gateway_inventory (
+			gateway_id,
+			created_at,
+			updated_at,
+			model,
+			firmware_version,
+			bridge_version,
+			concentrator_version
+		) values ($1, $2, $3, $4, $5, $6, $7)
+		on conflict (gateway_id) do update
+		set
+			updated_at = $3,
+			model = $4,
+			firmware_version = $5,
+			bridge_version = $6,
+			concentrator_version = $7
+		where
+			(gateway_inventory.model, gateway_inventory.firmware_version, gateway_inventory.bridge_version, gateway_inventory.concentrator_version)
+			is distinct from ($4, $5, $6, $7)`,
+		inv.GatewayID[:],
+		inv.CreatedAt,
+		inv.UpdatedAt,
+		inv.Model,
+		inv.FirmwareVersion,
+		inv.BridgeVersion,
+		inv.ConcentratorVersion,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+
+	if ra != 0 {
+		log.WithFields(log.Fields{
+			"gateway_id":           inv.GatewayID,
+			"model":                inv.Model,
+			"firmware_version":     inv.FirmwareVersion,
+			"bridge_version":       inv.BridgeVersion,
+			"concentrator_version": inv.ConcentratorVersion,
+			"ctx_id":               ctx.Value(logging.ContextIDKey),
+		}).Info("gateway inventory saved")
+	}
+
+	return nil
+}
+
+// GetGatewayInventory returns the inventory of the given gateway. It returns
+// ErrDoesNotExist when the gateway has not reported any inventory
+// information yet.
+func GetGatewayInventory(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (GatewayInventory, error) {
+	var inv GatewayInventory
+	err := sqlx.Get(db, &inv, `
+		select
+			*
+		from
+			gateway_inventory
+		where
+			gateway_id = $1`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return inv, handlePSQLError(err, "select error")
+	}
+
+	return inv, nil
+}
+
+// GetGatewayInventoryCount returns the number of gateway inventories. When
+// model is not empty, only the inventories of the given model are counted.
+func GetGatewayInventoryCount(ctx context.Context, db sqlx.Queryer, model string) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select
+			count(*)
+		from
+			gateway_inventory
+		where
+			$1 = '' or model = $1`,
+		model,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetGatewayInventories returns a slice of gateway inventories, ordered by
+// gateway ID. When model is not empty, only the inventories of the given
+// model are returned.
+func GetGatewayInventories(ctx context.Context, db sqlx.Queryer, limit, offset int, model string) ([]GatewayInventory, error) {
+	var out []GatewayInventory
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			gateway_inventory
+		where
+			$1 = '' or model = $1
+		order by
+			gateway_id
+		limit $2
+		offset $3`,
+		model,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
+
+// GetGatewayModels returns the model of the given gateways. Gateways without
+// inventory are omitted from the returned map.
+func GetGatewayModels(ctx context.Context, db sqlx.Queryer, ids []lorawan.EUI64) (map[lorawan.EUI64]string, error) {
+	out := make(map[lorawan.EUI64]string)
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	var idsB [][]byte
+	for i := range ids {
+		idsB = append(idsB, ids[i][:])
+	}
+
+	var items []struct {
+		GatewayID lorawan.EUI64 `db:"gateway_id"`
+		Model     string        `db:"model"`
+	}
+	err := sqlx.Select(db, &items, `
+		select
+			gateway_id,
+			model
+		from
+			gateway_inventory
+		where
+			gateway_id = any($1)`,
+		pq.ByteaArray(idsB),
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	for _, item := range items {
+		out[item.GatewayID] = item.Model
+	}
+
+	return out, nil
+}
+
+func getMetaDataValue(metaData map[string]string, keys []string) string {
+	for _, k := range keys {
+		if v := metaData[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestParseGatewayInventory(t *testing.T) {
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	tests := []struct {
+		Name       string
+		MetaData   map[string]string
+		Expected   GatewayInventory
+		ExpectedOK bool
+	}{
+		{
+			Name:     "no meta-data",
+			Expected: GatewayInventory{GatewayID: gatewayID},
+		},
+		{
+			Name: "no inventory meta-data",
+			MetaData: map[string]string{
+				"foo": "bar",
+			},
+			Expected: GatewayInventory{GatewayID: gatewayID},
+		},
+		{
+			Name: "generic keys",
+			MetaData: map[string]string{
+				"model":                "model-x",
+				"firmware_version":     "1.2.3",
+				"bridge_version":       "3.0.0",
+				"concentrator_version": "5.0.1",
+			},
+			Expected: GatewayInventory{
+				GatewayID:           gatewayID,
+				Model:               "model-x",
+				FirmwareVersion:     "1.2.3",
+				BridgeVersion:       "3.0.0",
+				ConcentratorVersion: "5.0.1",
+			},
+			ExpectedOK: true,
+		},
+		{
+			Name: "basic station keys",
+			MetaData: map[string]string{
+				"station":  "2.0.3(rpi/std)",
+				"firmware": "1.0.0",
+				"package":  "1.0.0",
+				"model":    "rpi",
+			},
+			Expected: GatewayInventory{
+				GatewayID:       gatewayID,
+				Model:           "rpi",
+				FirmwareVersion: "1.0.0",
+				BridgeVersion:   "2.0.3(rpi/std)",
+			},
+			ExpectedOK: true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			inv, ok := ParseGatewayInventory(gatewayID, tst.MetaData)
+			assert.Equal(tst.ExpectedOK, ok)
+			assert.Equal(tst.Expected, inv)
+		})
+	}
+}
+
+func (ts *StorageTestSuite) TestGatewayInventory() {
+	assert := require.New(ts.T())
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateways := []Gateway{
+		{
+			GatewayID:        lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			RoutingProfileID: rp.ID,
+		},
+		{
+			GatewayID:        lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+			RoutingProfileID: rp.ID,
+		},
+	}
+	for i := range gateways {
+		assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateways[i]))
+	}
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetGatewayInventory(context.Background(), ts.Tx(), gateways[0].GatewayID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		invs := []GatewayInventory{
+			{
+				GatewayID:       gateways[0].GatewayID,
+				Model:           "model-x",
+				FirmwareVersion: "1.0.0",
+			},
+			{
+				GatewayID:     gateways[1].GatewayID,
+				Model:         "model-y",
+				BridgeVersion: "3.0.0",
+			},
+		}
+		for i := range invs {
+			assert.NoError(SaveGatewayInventory(context.Background(), ts.Tx(), &invs[i]))
+		}
+
+		invGet, err := GetGatewayInventory(context.Background(), ts.Tx(), gateways[0].GatewayID)
+		assert.NoError(err)
+		assert.Equal("model-x", invGet.Model)
+		assert.Equal("1.0.0", invGet.FirmwareVersion)
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			inv := GatewayInventory{
+				GatewayID:       gateways[0].GatewayID,
+				Model:           "model-x",
+				FirmwareVersion: "1.1.0",
+			}
+			assert.NoError(SaveGatewayInventory(context.Background(), ts.Tx(), &inv))
+
+			invGet, err := GetGatewayInventory(context.Background(), ts.Tx(), gateways[0].GatewayID)
+			assert.NoError(err)
+			assert.Equal("1.1.0", invGet.FirmwareVersion)
+		})
+
+		t.Run("List", func(t *testing.T) {
+			assert := require.New(t)
+
+			count, err := GetGatewayInventoryCount(context.Background(), ts.Tx(), "")
+			assert.NoError(err)
+			assert.Equal(2, count)
+
+			items, err := GetGatewayInventories(context.Background(), ts.Tx(), 10, 0, "")
+			assert.NoError(err)
+			assert.Len(items, 2)
+			assert.Equal(gateways[0].GatewayID, items[0].GatewayID)
+
+			count, err = GetGatewayInventoryCount(context.Background(), ts.Tx(), "model-y")
+			assert.NoError(err)
+			assert.Equal(1, count)
+
+			items, err = GetGatewayInventories(context.Background(), ts.Tx(), 10, 0, "model-y")
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(gateways[1].GatewayID, items[0].GatewayID)
+		})
+
+		t.Run("GetGatewayModels", func(t *testing.T) {
+			assert := require.New(t)
+
+			models, err := GetGatewayModels(context.Background(), ts.Tx(), []lorawan.EUI64{
+				gateways[0].GatewayID,
+				gateways[1].GatewayID,
+				{3, 3, 3, 3, 3, 3, 3, 3},
+			})
+			assert.NoError(err)
+			assert.Equal(map[lorawan.EUI64]string{
+				gateways[0].GatewayID: "model-x",
+				gateways[1].GatewayID: "model-y",
+			}, models)
+		})
+	})
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const gatewayMarshalerKeyTempl = "lora:ns:gw:%s:marshaler"
+
+// gatewayMarshalerTTL defines the duration after which the stored marshaler
+// of a gateway from which no events are received anymore expires.
+const gatewayMarshalerTTL = 7 * 24 * time.Hour
+
+// SetGatewayMarshaler stores the marshaler (payload encoding) last used by
+// the given gateway, so that it is known by all LoRa Server instances.
+func SetGatewayMarshaler(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64, marshaler string) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(gatewayMarshalerKeyTempl, gatewayID), int64(gatewayMarshalerTTL/time.Millisecond), marshaler)
+	if err != nil {
+		return errors.Wrap(err, "psetex error")
+	}
+
+	return nil
+}
+
+// GetGatewayMarshaler returns the marshaler last used by the given gateway.
+// It returns ErrDoesNotExist when the marshaler of the gateway is unknown.
+func GetGatewayMarshaler(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) (string, error) {
+	c := p.Get()
+	defer c.Close()
+
+	marshaler, err := redis.String(c.Do("GET", fmt.Sprintf(gatewayMarshalerKeyTempl, gatewayID)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", ErrDoesNotExist
+		}
+		return "", errors.Wrap(err, "get error")
+	}
+
+	return marshaler, nil
+}
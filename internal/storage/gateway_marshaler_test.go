@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayMarshaler() {
+	assert := require.New(ts.T())
+	gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+	_, err := GetGatewayMarshaler(context.Background(), ts.RedisPool(), gatewayID)
+	assert.Equal(ErrDoesNotExist, err)
+
+	assert.NoError(SetGatewayMarshaler(context.Background(), ts.RedisPool(), gatewayID, "json"))
+	m, err := GetGatewayMarshaler(context.Background(), ts.RedisPool(), gatewayID)
+	assert.NoError(err)
+	assert.Equal("json", m)
+
+	assert.NoError(SetGatewayMarshaler(context.Background(), ts.RedisPool(), gatewayID, "protobuf"))
+	m, err = GetGatewayMarshaler(context.Background(), ts.RedisPool(), gatewayID)
+	assert.NoError(err)
+	assert.Equal("protobuf", m)
+}
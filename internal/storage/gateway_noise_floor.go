@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// RSSIHistogram contains the number of spectral-scan samples by RSSI (dBm).
+type RSSIHistogram map[int32]uint32
+
+// Value implements the driver.Valuer interface.
+func (h RSSIHistogram) Value() (driver.Value, error) {
+	if h == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (h *RSSIHistogram) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	return json.Unmarshal(b, h)
+}
+
+// GatewayNoiseFloor contains the last reported noise-floor and channel
+// utilization of a gateway channel.
+type GatewayNoiseFloor struct {
+	GatewayID          lorawan.EUI64 `db:"gateway_id"`
+	Frequency          uint32        `db:"frequency"`
+	UpdatedAt          time.Time     `db:"updated_at"`
+	Bandwidth          uint32        `db:"bandwidth"`
+	NoiseFloor         float64       `db:"noise_floor"`
+	ChannelUtilization float64       `db:"channel_utilization"`
+	RSSIHistogram      RSSIHistogram `db:"rssi_histogram"`
+}
+
+// SaveGatewayNoiseFloor creates or updates the noise-floor of the given
+// gateway channel. It returns ErrDoesNotExist when the gateway does not
+// exist.
+func SaveGatewayNoiseFloor(ctx context.Context, db sqlx.Execer, nf *GatewayNoiseFloor) error {
+	nf.UpdatedAt = time.Now()
+
+	_, err := db.Exec(`
+		insert into gateway_noise_floor (
+			gateway_id,
+			frequency,
+			updated_at,
+			bandwidth,
+			noise_floor,
+			channel_utilization,
+			rssi_histogram
+		) values ($1, $2, $3, $4, $5, $6, $7)
+		on conflict (gateway_id, frequency) do update
+		set
+			updated_at = $3,
+			bandwidth = $4,
+			noise_floor = $5,
+			channel_utilization = $6,
+			rssi_histogram = $7`,
+		nf.GatewayID[:],
+		nf.Frequency,
+		nf.UpdatedAt,
+		nf.Bandwidth,
+		nf.NoiseFloor,
+		nf.ChannelUtilization,
+		nf.RSSIHistogram,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id":  nf.GatewayID,
+		"frequency":   nf.Frequency,
+		"noise_floor": nf.NoiseFloor,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Debug("gateway noise-floor saved")
+
+	return nil
+}
+
+// GetGatewayNoiseFloors returns the noise-floor of each reported channel of
+// the given gateway, ordered by frequency.
+func GetGatewayNoiseFloors(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) ([]GatewayNoiseFloor, error) {
+	var out []GatewayNoiseFloor
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			gateway_noise_floor
+		where
+			gateway_id = $1
+		order by
+			frequency`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayNoiseFloor() {
+	assert := require.New(ts.T())
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateway := Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateway))
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		for _, nf := range []GatewayNoiseFloor{
+			{
+				GatewayID:          gateway.GatewayID,
+				Frequency:          868300000,
+				Bandwidth:          125000,
+				NoiseFloor:         -110.5,
+				ChannelUtilization: 0.5,
+			},
+			{
+				GatewayID:          gateway.GatewayID,
+				Frequency:          868100000,
+				Bandwidth:          125000,
+				NoiseFloor:         -118.5,
+				ChannelUtilization: 0.25,
+				RSSIHistogram: RSSIHistogram{
+					-120: 10,
+					-100: 2,
+				},
+			},
+		} {
+			assert.NoError(SaveGatewayNoiseFloor(context.Background(), ts.Tx(), &nf))
+		}
+
+		nfs, err := GetGatewayNoiseFloors(context.Background(), ts.Tx(), gateway.GatewayID)
+		assert.NoError(err)
+		assert.Len(nfs, 2)
+		assert.EqualValues(868100000, nfs[0].Frequency)
+		assert.Equal(-118.5, nfs[0].NoiseFloor)
+		assert.Equal(0.25, nfs[0].ChannelUtilization)
+		assert.Equal(RSSIHistogram{-120: 10, -100: 2}, nfs[0].RSSIHistogram)
+		assert.EqualValues(868300000, nfs[1].Frequency)
+		assert.Equal(RSSIHistogram{}, nfs[1].RSSIHistogram)
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SaveGatewayNoiseFloor(context.Background(), ts.Tx(), &GatewayNoiseFloor{
+				GatewayID:          gateway.GatewayID,
+				Frequency:          868300000,
+				Bandwidth:          125000,
+				NoiseFloor:         -95,
+				ChannelUtilization: 0.75,
+			}))
+
+			nfs, err := GetGatewayNoiseFloors(context.Background(), ts.Tx(), gateway.GatewayID)
+			assert.NoError(err)
+			assert.Len(nfs, 2)
+			assert.Equal(-95.0, nfs[1].NoiseFloor)
+			assert.Equal(0.75, nfs[1].ChannelUtilization)
+		})
+	})
+
+	ts.T().Run("Unknown gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		err := SaveGatewayNoiseFloor(context.Background(), ts.Tx(), &GatewayNoiseFloor{
+			GatewayID: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			Frequency: 868100000,
+		})
+		assert.Equal(ErrDoesNotExist, errors.Cause(err))
+	})
+}
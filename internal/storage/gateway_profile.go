@@ -4,9 +4,11 @@ import (
 	"context"
 	"time"
 
+	"github.com/brocaar/lorawan"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -34,6 +36,24 @@ type GatewayProfile struct {
 	UpdatedAt     time.Time      `db:"updated_at"`
 	Channels      []int64        `db:"channels"`
 	ExtraChannels []ExtraChannel `db:"-"`
+	Region        string         `db:"region"`
+	ChannelGroup  string         `db:"channel_group"`
+
+	// Listen-before-talk configuration.
+	LBTEnabled     bool    `db:"lbt_enabled"`
+	LBTRSSITarget  int     `db:"lbt_rssi_target"`
+	LBTScanTime    int     `db:"lbt_scan_time"` // us
+	LBTFrequencies []int64 `db:"lbt_frequencies"`
+
+	// Frequency-plan assigned to this gateway-profile. When the version is
+	// 0, the latest version of the frequency-plan is used.
+	FrequencyPlanID      *uuid.UUID `db:"frequency_plan_id"`
+	FrequencyPlanVersion int        `db:"frequency_plan_version"`
+
+	// Reconfiguration window (UTC). When the duration is 0, configuration
+	// updates are sent to the gateways immediately.
+	ReconfigurationWindowStart    int `db:"reconfiguration_window_start"`    // minutes after midnight
+	ReconfigurationWindowDuration int `db:"reconfiguration_window_duration"` // minutes
 }
 
 // GetVersion returns the gateway-profile version.
@@ -41,6 +61,44 @@ func (p GatewayProfile) GetVersion() string {
 	return p.UpdatedAt.UTC().Format(time.RFC3339Nano)
 }
 
+// InReconfigurationWindow returns true when the given time is within the
+// reconfiguration window, or when no reconfiguration window is configured.
+func (p GatewayProfile) InReconfigurationWindow(t time.Time) bool {
+	if p.ReconfigurationWindowDuration <= 0 {
+		return true
+	}
+
+	start := p.reconfigurationWindowStart(t)
+	if start.After(t) {
+		start = start.AddDate(0, 0, -1)
+	}
+
+	return t.Before(start.Add(time.Duration(p.ReconfigurationWindowDuration) * time.Minute))
+}
+
+// NextReconfigurationWindow returns the start of the next reconfiguration
+// window after the given time. When the given time is within a window or
+// when no reconfiguration window is configured, the given time is returned.
+func (p GatewayProfile) NextReconfigurationWindow(t time.Time) time.Time {
+	if p.InReconfigurationWindow(t) {
+		return t
+	}
+
+	start := p.reconfigurationWindowStart(t)
+	if start.Before(t) {
+		start = start.AddDate(0, 0, 1)
+	}
+
+	return start
+}
+
+// reconfigurationWindowStart returns the start of the reconfiguration window
+// on the (UTC) day of the given time.
+func (p GatewayProfile) reconfigurationWindowStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Add(time.Duration(p.ReconfigurationWindowStart) * time.Minute)
+}
+
 // CreateGatewayProfile creates the given gateway-profile.
 // As this will execute multiple SQL statements, it is recommended to perform
 // this within a transaction.
@@ -62,12 +120,32 @@ func CreateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
-		) values ($1, $2, $3, $4)`,
+			channels,
+			region,
+			lbt_enabled,
+			lbt_rssi_target,
+			lbt_scan_time,
+			lbt_frequencies,
+			frequency_plan_id,
+			frequency_plan_version,
+			channel_group,
+			reconfiguration_window_start,
+			reconfiguration_window_duration
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
 		c.ID,
 		c.CreatedAt,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.Region,
+		c.LBTEnabled,
+		c.LBTRSSITarget,
+		c.LBTScanTime,
+		pq.Array(c.LBTFrequencies),
+		c.FrequencyPlanID,
+		c.FrequencyPlanVersion,
+		c.ChannelGroup,
+		c.ReconfigurationWindowStart,
+		c.ReconfigurationWindowDuration,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -112,7 +190,17 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 			gateway_profile_id,
 			created_at,
 			updated_at,
-			channels
+			channels,
+			region,
+			lbt_enabled,
+			lbt_rssi_target,
+			lbt_scan_time,
+			lbt_frequencies,
+			frequency_plan_id,
+			frequency_plan_version,
+			channel_group,
+			reconfiguration_window_start,
+			reconfiguration_window_duration
 		from gateway_profile
 		where
 			gateway_profile_id = $1`,
@@ -122,6 +210,16 @@ func GetGatewayProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Gate
 		&c.CreatedAt,
 		&c.UpdatedAt,
 		pq.Array(&c.Channels),
+		&c.Region,
+		&c.LBTEnabled,
+		&c.LBTRSSITarget,
+		&c.LBTScanTime,
+		pq.Array(&c.LBTFrequencies),
+		&c.FrequencyPlanID,
+		&c.FrequencyPlanVersion,
+		&c.ChannelGroup,
+		&c.ReconfigurationWindowStart,
+		&c.ReconfigurationWindowDuration,
 	)
 	if err != nil {
 		return c, handlePSQLError(err, "select error")
@@ -172,12 +270,32 @@ func UpdateGatewayProfile(ctx context.Context, db sqlx.Execer, c *GatewayProfile
 		update gateway_profile
 		set
 			updated_at = $2,
-			channels = $3
+			channels = $3,
+			region = $4,
+			lbt_enabled = $5,
+			lbt_rssi_target = $6,
+			lbt_scan_time = $7,
+			lbt_frequencies = $8,
+			frequency_plan_id = $9,
+			frequency_plan_version = $10,
+			channel_group = $11,
+			reconfiguration_window_start = $12,
+			reconfiguration_window_duration = $13
 		where
 			gateway_profile_id = $1`,
 		c.ID,
 		c.UpdatedAt,
 		pq.Array(c.Channels),
+		c.Region,
+		c.LBTEnabled,
+		c.LBTRSSITarget,
+		c.LBTScanTime,
+		pq.Array(c.LBTFrequencies),
+		c.FrequencyPlanID,
+		c.FrequencyPlanVersion,
+		c.ChannelGroup,
+		c.ReconfigurationWindowStart,
+		c.ReconfigurationWindowDuration,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -262,3 +380,74 @@ func DeleteGatewayProfile(ctx context.Context, db sqlx.Execer, id uuid.UUID) err
 
 	return nil
 }
+
+// GetRegionForGateway returns the region of the gateway-profile assigned to
+// the given gateway. An empty string is returned when the gateway has no
+// gateway-profile or when the gateway-profile has no region. When the
+// gateway-profile has a channel-group, the region of the channel-group is
+// returned (see band.RegionWithChannelGroup).
+func GetRegionForGateway(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (string, error) {
+	var region, channelGroup string
+	err := db.QueryRowx(`
+		select
+			coalesce(gp.region, ''),
+			coalesce(gp.channel_group, '')
+		from gateway g
+		left join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id
+		where
+			g.gateway_id = $1`,
+		gatewayID[:],
+	).Scan(&region, &channelGroup)
+	if err != nil {
+		return "", handlePSQLError(err, "select error")
+	}
+
+	return band.RegionWithChannelGroup(region, channelGroup), nil
+}
+
+// GetGatewayRegion returns the region of the given gateway, to be used for
+// the band lookups of the frames received or transmitted by this gateway.
+// When no additional regions are configured, the database is not queried.
+// The default region (empty string) is returned when the region could not be
+// retrieved.
+func GetGatewayRegion(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) string {
+	if !band.HasRegions() {
+		return ""
+	}
+
+	region, err := GetRegionForGateway(ctx, db, gatewayID)
+	if err != nil {
+		if err != ErrDoesNotExist {
+			log.WithError(err).WithFields(log.Fields{
+				"gateway_id": gatewayID,
+				"ctx_id":     ctx.Value(logging.ContextIDKey),
+			}).Error("storage: get region for gateway error")
+		}
+		return ""
+	}
+
+	return region
+}
+
+// GetLBTScanTimeForGateway returns the listen-before-talk scan time of the
+// gateway-profile assigned to the given gateway. It returns 0 when the
+// gateway has no gateway-profile or when listen-before-talk is disabled.
+func GetLBTScanTimeForGateway(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64) (time.Duration, error) {
+	var scanTime int
+	err := sqlx.Get(db, &scanTime, `
+		select
+			coalesce(gp.lbt_scan_time, 0)
+		from gateway g
+		left join gateway_profile gp
+			on gp.gateway_profile_id = g.gateway_profile_id and gp.lbt_enabled = true
+		where
+			g.gateway_id = $1`,
+		gatewayID[:],
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	return time.Duration(scanTime) * time.Microsecond, nil
+}
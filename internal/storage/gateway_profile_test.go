@@ -2,11 +2,16 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/brocaar/lorawan"
+	loraband "github.com/brocaar/lorawan/band"
+	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
 
@@ -58,6 +63,14 @@ func TestGatewayProfile(t *testing.T) {
 
 			Convey("Then it can be updated", func() {
 				gc.Channels = []int64{0, 1}
+				gc.Region = "in865"
+				gc.ChannelGroup = "type-a-1"
+				gc.LBTEnabled = true
+				gc.LBTRSSITarget = -80
+				gc.LBTScanTime = 5000
+				gc.LBTFrequencies = []int64{920900000, 921100000}
+				gc.ReconfigurationWindowStart = 120
+				gc.ReconfigurationWindowDuration = 60
 				gc.ExtraChannels = []ExtraChannel{
 					{
 						Modulation: ModulationLoRa,
@@ -81,6 +94,164 @@ func TestGatewayProfile(t *testing.T) {
 				gc2.UpdatedAt = gc2.UpdatedAt.UTC().Truncate(time.Millisecond)
 				So(gc2, ShouldResemble, gc)
 			})
+
+			Convey("Then the region and lbt scan-time can be retrieved for a gateway using this profile", func() {
+				gc.Region = "in865"
+				So(UpdateGatewayProfile(context.Background(), DB(), &gc), ShouldBeNil)
+
+				rp := RoutingProfile{}
+				So(CreateRoutingProfile(context.Background(), DB(), &rp), ShouldBeNil)
+
+				gw := Gateway{
+					GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					RoutingProfileID: rp.ID,
+					GatewayProfileID: &gc.ID,
+				}
+				So(CreateGateway(context.Background(), DB(), &gw), ShouldBeNil)
+
+				region, err := GetRegionForGateway(context.Background(), DB(), gw.GatewayID)
+				So(err, ShouldBeNil)
+				So(region, ShouldEqual, "in865")
+
+				scanTime, err := GetLBTScanTimeForGateway(context.Background(), DB(), gw.GatewayID)
+				So(err, ShouldBeNil)
+				So(scanTime, ShouldEqual, 0)
+
+				gc.LBTEnabled = true
+				gc.LBTScanTime = 5000
+				So(UpdateGatewayProfile(context.Background(), DB(), &gc), ShouldBeNil)
+
+				scanTime, err = GetLBTScanTimeForGateway(context.Background(), DB(), gw.GatewayID)
+				So(err, ShouldBeNil)
+				So(scanTime, ShouldEqual, 5*time.Millisecond)
+			})
+
+			Convey("Then the region is resolved per gateway, for gateways in two regions", func() {
+				bandConf := test.GetConfig()
+				bandConf.NetworkServer.Regions = []config.Region{
+					{Name: "us915", Band: loraband.US_902_928},
+				}
+				So(band.Setup(bandConf), ShouldBeNil)
+				defer band.Setup(test.GetConfig())
+
+				gc.Region = "us915"
+				So(UpdateGatewayProfile(context.Background(), DB(), &gc), ShouldBeNil)
+
+				rp := RoutingProfile{}
+				So(CreateRoutingProfile(context.Background(), DB(), &rp), ShouldBeNil)
+
+				usGW := Gateway{
+					GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					RoutingProfileID: rp.ID,
+					GatewayProfileID: &gc.ID,
+				}
+				So(CreateGateway(context.Background(), DB(), &usGW), ShouldBeNil)
+
+				euGW := Gateway{
+					GatewayID:        lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					RoutingProfileID: rp.ID,
+				}
+				So(CreateGateway(context.Background(), DB(), &euGW), ShouldBeNil)
+
+				So(GetGatewayRegion(context.Background(), DB(), usGW.GatewayID), ShouldEqual, "us915")
+				So(GetGatewayRegion(context.Background(), DB(), euGW.GatewayID), ShouldEqual, "")
+				So(GetGatewayRegion(context.Background(), DB(), lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}), ShouldEqual, "")
+
+				So(band.Get(GetGatewayRegion(context.Background(), DB(), usGW.GatewayID)).GetDefaults().RX2Frequency, ShouldEqual, 923300000)
+				So(band.Get(GetGatewayRegion(context.Background(), DB(), euGW.GatewayID)).GetDefaults().RX2Frequency, ShouldEqual, 869525000)
+
+				Convey("Without additional regions the default region is returned", func() {
+					So(band.Setup(test.GetConfig()), ShouldBeNil)
+					So(GetGatewayRegion(context.Background(), DB(), usGW.GatewayID), ShouldEqual, "")
+				})
+			})
 		})
 	})
 }
+
+func TestGatewayProfileReconfigurationWindow(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		day := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			Name       string
+			Start      int
+			Duration   int
+			Time       time.Time
+			InWindow   bool
+			NextWindow time.Time
+		}{
+			{
+				Name:       "no window",
+				Time:       day.Add(13 * time.Hour),
+				InWindow:   true,
+				NextWindow: day.Add(13 * time.Hour),
+			},
+			{
+				Name:       "before window",
+				Start:      120,
+				Duration:   60,
+				Time:       day.Add(time.Hour),
+				NextWindow: day.Add(2 * time.Hour),
+			},
+			{
+				Name:       "start of window",
+				Start:      120,
+				Duration:   60,
+				Time:       day.Add(2 * time.Hour),
+				InWindow:   true,
+				NextWindow: day.Add(2 * time.Hour),
+			},
+			{
+				Name:       "end of window",
+				Start:      120,
+				Duration:   60,
+				Time:       day.Add(3 * time.Hour),
+				NextWindow: day.Add(26 * time.Hour),
+			},
+			{
+				Name:       "window crossing midnight, before midnight",
+				Start:      23 * 60,
+				Duration:   180,
+				Time:       day.Add(23*time.Hour + 30*time.Minute),
+				InWindow:   true,
+				NextWindow: day.Add(23*time.Hour + 30*time.Minute),
+			},
+			{
+				Name:       "window crossing midnight, after midnight",
+				Start:      23 * 60,
+				Duration:   180,
+				Time:       day.Add(time.Hour),
+				InWindow:   true,
+				NextWindow: day.Add(time.Hour),
+			},
+			{
+				Name:       "window crossing midnight, outside window",
+				Start:      23 * 60,
+				Duration:   180,
+				Time:       day.Add(12 * time.Hour),
+				NextWindow: day.Add(23 * time.Hour),
+			},
+			{
+				Name:       "non-UTC time",
+				Start:      120,
+				Duration:   60,
+				Time:       day.Add(2*time.Hour + 30*time.Minute).In(time.FixedZone("UTC+5", 5*60*60)),
+				InWindow:   true,
+				NextWindow: day.Add(2*time.Hour + 30*time.Minute),
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				gp := GatewayProfile{
+					ReconfigurationWindowStart:    test.Start,
+					ReconfigurationWindowDuration: test.Duration,
+				}
+
+				So(gp.InReconfigurationWindow(test.Time), ShouldEqual, test.InWindow)
+				So(gp.NextReconfigurationWindow(test.Time).Equal(test.NextWindow), ShouldBeTrue)
+			})
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// GatewayStatsCounts contains packet counts by key, e.g. by frequency or by
+// TX acknowledgement status.
+type GatewayStatsCounts map[string]uint32
+
+// Add adds the given counts.
+func (c GatewayStatsCounts) Add(counts GatewayStatsCounts) {
+	for k, v := range counts {
+		c[k] += v
+	}
+}
+
+// Value implements the driver.Valuer interface.
+func (c GatewayStatsCounts) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (c *GatewayStatsCounts) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	return json.Unmarshal(b, c)
+}
+
+// GatewayStatsAggregate contains the gateway stats aggregated over the
+// interval starting at the given timestamp.
+type GatewayStatsAggregate struct {
+	GatewayID             lorawan.EUI64      `db:"gateway_id"`
+	Interval              time.Duration      `db:"interval"`
+	Timestamp             time.Time          `db:"timestamp"`
+	RXPacketsReceived     uint32             `db:"rx_packets_received"`
+	RXPacketsReceivedOK   uint32             `db:"rx_packets_received_ok"`
+	TXPacketsReceived     uint32             `db:"tx_packets_received"`
+	TXPacketsEmitted      uint32             `db:"tx_packets_emitted"`
+	RXPacketsPerFrequency GatewayStatsCounts `db:"rx_packets_per_frequency"`
+	TXPacketsPerFrequency GatewayStatsCounts `db:"tx_packets_per_frequency"`
+	TXPacketsPerStatus    GatewayStatsCounts `db:"tx_packets_per_status"`
+}
+
+// SaveGatewayStatsAggregate adds the given aggregate to the stored aggregate
+// of the same gateway, interval and timestamp, or creates it when it does
+// not exist yet. This makes it possible to flush partial aggregates, e.g.
+// by multiple LoRa Server instances.
+func SaveGatewayStatsAggregate(ctx context.Context, db sqlx.Execer, agg GatewayStatsAggregate) error {
+	_, err := db.Exec(`
+		insert into gateway_stats_aggregate (
+			gateway_id,
+			"interval",
+			"timestamp",
+			rx_packets_received,
+			rx_packets_received_ok,
+			tx_packets_received,
+			tx_packets_emitted,
+			rx_packets_per_frequency,
+			tx_packets_per_frequency,
+			tx_packets_per_status
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		on conflict (gateway_id, "interval", "timestamp") do update
+		set
+			rx_packets_received = gateway_stats_aggregate.rx_packets_received + excluded.rx_packets_received,
+			rx_packets_received_ok = gateway_stats_aggregate.rx_packets_received_ok + excluded.rx_packets_received_ok,
+			tx_packets_received = gateway_stats_aggregate.tx_packets_received + excluded.tx_packets_received,
+			tx_packets_emitted = gateway_stats_aggregate.tx_packets_emitted + excluded.tx_packets_emitted,
+			rx_packets_per_frequency = gateway_stats_sum_counts(gateway_stats_aggregate.rx_packets_per_frequency, excluded.rx_packets_per_frequency),
+			tx_packets_per_frequency = gateway_stats_sum_counts(gateway_stats_aggregate.tx_packets_per_frequency, excluded.tx_packets_per_frequency),
+			tx_packets_per_status = gateway_stats_sum_counts(gateway_stats_aggregate.tx_packets_per_status, excluded.tx_packets_per_status)`,
+		agg.GatewayID[:],
+		agg.Interval,
+		agg.Timestamp,
+		agg.RXPacketsReceived,
+		agg.RXPacketsReceivedOK,
+		agg.TXPacketsReceived,
+		agg.TXPacketsEmitted,
+		agg.RXPacketsPerFrequency,
+		agg.TXPacketsPerFrequency,
+		agg.TXPacketsPerStatus,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"gateway_id": agg.GatewayID,
+		"interval":   agg.Interval,
+		"timestamp":  agg.Timestamp,
+		"ctx_id":     ctx.Value(logging.ContextIDKey),
+	}).Debug("gateway stats aggregate saved")
+
+	return nil
+}
+
+// GetGatewayStatsAggregates returns the aggregates of the given gateway and
+// interval of which the timestamp is within the given range (inclusive),
+// ordered by timestamp.
+func GetGatewayStatsAggregates(ctx context.Context, db sqlx.Queryer, gatewayID lorawan.EUI64, interval time.Duration, start, end time.Time) ([]GatewayStatsAggregate, error) {
+	var out []GatewayStatsAggregate
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			gateway_stats_aggregate
+		where
+			gateway_id = $1
+			and "interval" = $2
+			and "timestamp" >= $3
+			and "timestamp" <= $4
+		order by
+			"timestamp"`,
+		gatewayID[:],
+		interval,
+		start,
+		end,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
+
+// DeleteGatewayStatsAggregatesBefore deletes the aggregates of the given
+// interval with a timestamp before the given time. It returns the number of
+// deleted aggregates.
+func DeleteGatewayStatsAggregatesBefore(ctx context.Context, db sqlx.Execer, interval time.Duration, before time.Time) (int, error) {
+	res, err := db.Exec(`
+		delete from
+			gateway_stats_aggregate
+		where
+			"interval" = $1
+			and "timestamp" < $2`,
+		interval,
+		before,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, handlePSQLError(err, "get rows affected error")
+	}
+
+	return int(ra), nil
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestGatewayStatsAggregate() {
+	assert := require.New(ts.T())
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateway := Gateway{
+		GatewayID:        lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		RoutingProfileID: rp.ID,
+	}
+	assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateway))
+
+	now := time.Now().Truncate(time.Minute).UTC()
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(SaveGatewayStatsAggregate(context.Background(), ts.Tx(), GatewayStatsAggregate{
+			GatewayID:           gateway.GatewayID,
+			Interval:            time.Minute,
+			Timestamp:           now,
+			RXPacketsReceived:   10,
+			RXPacketsReceivedOK: 8,
+			TXPacketsReceived:   2,
+			TXPacketsEmitted:    1,
+			RXPacketsPerFrequency: GatewayStatsCounts{
+				"868100000": 6,
+				"868300000": 4,
+			},
+			TXPacketsPerFrequency: GatewayStatsCounts{
+				"868100000": 1,
+			},
+			TXPacketsPerStatus: GatewayStatsCounts{
+				"OK":       1,
+				"TOO_LATE": 1,
+			},
+		}))
+
+		t.Run("Add to existing", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(SaveGatewayStatsAggregate(context.Background(), ts.Tx(), GatewayStatsAggregate{
+				GatewayID:         gateway.GatewayID,
+				Interval:          time.Minute,
+				Timestamp:         now,
+				RXPacketsReceived: 5,
+				RXPacketsPerFrequency: GatewayStatsCounts{
+					"868100000": 3,
+					"868500000": 2,
+				},
+			}))
+
+			aggs, err := GetGatewayStatsAggregates(context.Background(), ts.Tx(), gateway.GatewayID, time.Minute, now, now)
+			assert.NoError(err)
+			assert.Len(aggs, 1)
+			assert.True(now.Equal(aggs[0].Timestamp))
+			assert.EqualValues(15, aggs[0].RXPacketsReceived)
+			assert.EqualValues(8, aggs[0].RXPacketsReceivedOK)
+			assert.EqualValues(2, aggs[0].TXPacketsReceived)
+			assert.EqualValues(1, aggs[0].TXPacketsEmitted)
+			assert.Equal(GatewayStatsCounts{
+				"868100000": 9,
+				"868300000": 4,
+				"868500000": 2,
+			}, aggs[0].RXPacketsPerFrequency)
+			assert.Equal(GatewayStatsCounts{"868100000": 1}, aggs[0].TXPacketsPerFrequency)
+			assert.Equal(GatewayStatsCounts{"OK": 1, "TOO_LATE": 1}, aggs[0].TXPacketsPerStatus)
+		})
+
+		t.Run("Get other interval", func(t *testing.T) {
+			assert := require.New(t)
+
+			aggs, err := GetGatewayStatsAggregates(context.Background(), ts.Tx(), gateway.GatewayID, time.Hour, now.Add(-time.Hour), now)
+			assert.NoError(err)
+			assert.Len(aggs, 0)
+		})
+
+		t.Run("Delete before", func(t *testing.T) {
+			assert := require.New(t)
+
+			count, err := DeleteGatewayStatsAggregatesBefore(context.Background(), ts.Tx(), time.Minute, now)
+			assert.NoError(err)
+			assert.Equal(0, count)
+
+			count, err = DeleteGatewayStatsAggregatesBefore(context.Background(), ts.Tx(), time.Minute, now.Add(time.Minute))
+			assert.NoError(err)
+			assert.Equal(1, count)
+
+			aggs, err := GetGatewayStatsAggregates(context.Background(), ts.Tx(), gateway.GatewayID, time.Minute, now, now)
+			assert.NoError(err)
+			assert.Len(aggs, 0)
+		})
+	})
+}
@@ -36,6 +36,10 @@ func (ts *StorageTestSuite) TestGateway() {
 				},
 				{
 					FineTimestampKey: &aesKey,
+					Antennas: []GatewayAntenna{
+						{Gain: 6, CableLoss: 1, Azimuth: 0, Beamwidth: 120},
+						{Gain: 8, CableLoss: 0.5, Azimuth: 120, Beamwidth: 120},
+					},
 				},
 			},
 		}
@@ -56,6 +60,22 @@ func (ts *StorageTestSuite) TestGateway() {
 			assert.Equal(gw, gwGet)
 		})
 
+		t.Run("Antenna TX power", func(t *testing.T) {
+			assert := require.New(t)
+
+			gwGet, err := GetGateway(context.Background(), ts.Tx(), gw.GatewayID)
+			assert.NoError(err)
+
+			assert.Nil(gwGet.GetAntenna(0, 0))
+			assert.Equal(uint32(1), gwGet.GetAntenna(1, 1).Board)
+			assert.Equal(uint32(1), gwGet.GetAntenna(1, 1).Antenna)
+
+			assert.Equal(14, gwGet.GetAntennaTXPowerForMaxEIRP(0, 0, 14))
+			assert.Equal(9, gwGet.GetAntennaTXPowerForMaxEIRP(1, 0, 14))
+			assert.Equal(6, gwGet.GetAntennaTXPowerForMaxEIRP(1, 1, 14))
+			assert.Equal(14, gwGet.GetAntennaTXPowerForMaxEIRP(1, 2, 14))
+		})
+
 		t.Run("Test cache", func(t *testing.T) {
 			gwGet, err := GetAndCacheGateway(context.Background(), ts.Tx(), ts.RedisPool(), gw.GatewayID)
 			assert.NoError(err)
@@ -87,6 +107,8 @@ func (ts *StorageTestSuite) TestGateway() {
 				Longitude: 3.123,
 			}
 			gw.Altitude = 100.5
+			gw.AntennaGain = 3
+			gw.CableLoss = 1.5
 			gw.Boards = []GatewayBoard{
 				{
 					FineTimestampKey: &aesKey,
@@ -111,6 +133,7 @@ func (ts *StorageTestSuite) TestGateway() {
 			gwGet.LastSeenAt = &now
 
 			assert.Equal(gw, gwGet)
+			assert.Equal(12, gwGet.GetTXPowerForMaxEIRP(14))
 		})
 
 		t.Run("Delete", func(t *testing.T) {
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// earthRadius is the mean radius of the earth (meters).
+const earthRadius = 6371000
+
+// Geofence defines a geographical area, either as a circle (center and
+// radius in meters) or as a polygon.
+type Geofence struct {
+	Center  GPSPoint   `json:"center"`
+	Radius  float64    `json:"radius"`
+	Polygon []GPSPoint `json:"polygon"`
+}
+
+// Validate validates the geofence.
+func (g Geofence) Validate() error {
+	if (g.Radius > 0) == (len(g.Polygon) != 0) {
+		return ErrInvalidGeofence
+	}
+
+	if g.Radius < 0 || (len(g.Polygon) != 0 && len(g.Polygon) < 3) {
+		return ErrInvalidGeofence
+	}
+
+	return nil
+}
+
+// Contains returns true when the given point is within the geofence.
+func (g Geofence) Contains(p GPSPoint) bool {
+	if g.Radius > 0 {
+		return distance(g.Center, p) <= g.Radius
+	}
+
+	// ray-casting, treating the latitude and longitude as planar
+	// coordinates, which is accurate enough for regional areas
+	var inside bool
+	for i, j := 0, len(g.Polygon)-1; i < len(g.Polygon); j, i = i, i+1 {
+		a, b := g.Polygon[i], g.Polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// Value implements the driver.Valuer interface.
+func (g Geofence) Value() (driver.Value, error) {
+	b, err := json.Marshal(g)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json error")
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (g *Geofence) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	return json.Unmarshal(b, g)
+}
+
+// distance returns the great-circle distance (meters) between the given
+// points, using the haversine formula.
+func distance(a, b GPSPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeofence(t *testing.T) {
+	amsterdam := GPSPoint{Latitude: 52.3676, Longitude: 4.9041}
+	utrecht := GPSPoint{Latitude: 52.0907, Longitude: 5.1214}
+	paris := GPSPoint{Latitude: 48.8566, Longitude: 2.3522}
+
+	t.Run("Validate", func(t *testing.T) {
+		tests := []struct {
+			Name     string
+			Geofence Geofence
+			Error    error
+		}{
+			{
+				Name:     "radius",
+				Geofence: Geofence{Center: amsterdam, Radius: 1000},
+			},
+			{
+				Name:     "polygon",
+				Geofence: Geofence{Polygon: []GPSPoint{amsterdam, utrecht, paris}},
+			},
+			{
+				Name:  "empty",
+				Error: ErrInvalidGeofence,
+			},
+			{
+				Name:     "radius and polygon",
+				Geofence: Geofence{Radius: 1000, Polygon: []GPSPoint{amsterdam, utrecht, paris}},
+				Error:    ErrInvalidGeofence,
+			},
+			{
+				Name:     "polygon with two points",
+				Geofence: Geofence{Polygon: []GPSPoint{amsterdam, utrecht}},
+				Error:    ErrInvalidGeofence,
+			},
+		}
+
+		for _, tst := range tests {
+			t.Run(tst.Name, func(t *testing.T) {
+				assert := require.New(t)
+				assert.Equal(tst.Error, tst.Geofence.Validate())
+			})
+		}
+	})
+
+	t.Run("Contains radius", func(t *testing.T) {
+		assert := require.New(t)
+
+		// the distance between Amsterdam and Utrecht is roughly 34km
+		g := Geofence{Center: amsterdam, Radius: 40000}
+		assert.True(g.Contains(amsterdam))
+		assert.True(g.Contains(utrecht))
+		assert.False(g.Contains(paris))
+
+		g.Radius = 30000
+		assert.False(g.Contains(utrecht))
+	})
+
+	t.Run("Contains polygon", func(t *testing.T) {
+		assert := require.New(t)
+
+		// roughly the Netherlands
+		g := Geofence{
+			Polygon: []GPSPoint{
+				{Latitude: 53.5, Longitude: 3.3},
+				{Latitude: 53.5, Longitude: 7.2},
+				{Latitude: 50.7, Longitude: 7.2},
+				{Latitude: 50.7, Longitude: 3.3},
+			},
+		}
+		assert.True(g.Contains(amsterdam))
+		assert.True(g.Contains(utrecht))
+		assert.False(g.Contains(paris))
+	})
+}
@@ -12,16 +12,17 @@ import (
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/geo"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 )
 
 const (
-	geolocBufferKeyTempl = "lora:ns:device:%s:geoloc:buffer"
+	geolocBufferKeyTempl = "lora:ns:device:{%s}:geoloc:buffer"
 )
 
 // SaveGeolocBuffer saves the given items in the geolocation buffer.
 // It overwrites the previous buffer to make sure that expired items do not
 // stay in the buffer as the TTL is set on the key, not on the items.
-func SaveGeolocBuffer(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, items []*geo.FrameRXInfo, ttl time.Duration) error {
+func SaveGeolocBuffer(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, items []*geo.FrameRXInfo, ttl time.Duration) error {
 	// nothing to do
 	if ttl == 0 || len(items) == 0 {
 		return nil
@@ -54,7 +55,7 @@ func SaveGeolocBuffer(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64,
 
 // GetGeolocBuffer returns the geolocation buffer. Items that exceed the
 // given TTL are not returned.
-func GetGeolocBuffer(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, ttl time.Duration) ([]*geo.FrameRXInfo, error) {
+func GetGeolocBuffer(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, ttl time.Duration) ([]*geo.FrameRXInfo, error) {
 	// nothing to do
 	if ttl == 0 {
 		return nil, nil
@@ -95,7 +96,7 @@ func GetGeolocBuffer(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, t
 
 			// Ignore items before TTL as the TTL is set on the key of the buffer,
 			// not on the item.
-			if time.Now().Sub(ts) > ttl {
+			if clock.Now().Sub(ts) > ttl {
 				add = false
 			}
 		}
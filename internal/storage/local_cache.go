@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"container/list"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+// localCacheKeyPatterns contains the patterns of the Redis keys which are
+// cached in-process. Note that the device-session pattern also matches the
+// gateway rx-info and geolocation buffer keys, these are never cached but
+// their notifications are received.
+var localCacheKeyPatterns = []string{
+	"lora:ns:device:*",
+	"lora:ns:dp:*",
+	"lora:ns:sp:*",
+	"lora:ns:rp:*",
+	"lora:ns:gw:*",
+}
+
+// localCacheKeyspaceEvents contains the keyspace notification classes
+// required for invalidating the local cache: keyspace events (K), generic
+// commands (g), string commands ($), expired (x) and evicted (e) events.
+const localCacheKeyspaceEvents = "Kg$xe"
+
+// localCacheGenerations defines the number of generation buckets, used to
+// detect an invalidation between reading a value from Redis and storing it
+// in the local cache.
+const localCacheGenerations = 256
+
+// localCachePingInterval defines the interval in which the invalidation
+// subscription is pinged, this must be less than the Redis read timeout.
+const localCachePingInterval = 30 * time.Second
+
+// localCacheRetryInterval defines the interval after which the invalidation
+// subscription is retried after an error.
+const localCacheRetryInterval = time.Second
+
+// localCache implements an in-process LRU cache for Redis values. Entries are
+// removed on write by this instance, on keyspace notifications (for writes
+// by other instances, expiration and eviction) and after the configured TTL.
+// The cache is only used while the invalidation subscription is active.
+type localCache struct {
+	mu          sync.Mutex
+	enabled     bool
+	size        int
+	ttl         time.Duration
+	ll          *list.List
+	items       map[string]*list.Element
+	generations [localCacheGenerations]uint64
+}
+
+type localCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// redisLocalCache holds the local cache for Redis values.
+var redisLocalCache = newLocalCache(0, 0)
+
+func newLocalCache(size int, ttl time.Duration) *localCache {
+	return &localCache{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached value for the given key. On a miss, it returns the
+// generation which must be passed to put.
+func (lc *localCache) get(key string) ([]byte, uint64, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	gen := lc.generations[localCacheBucket(key)]
+
+	if !lc.enabled {
+		return nil, gen, false
+	}
+
+	el, ok := lc.items[key]
+	if !ok {
+		return nil, gen, false
+	}
+
+	entry := el.Value.(*localCacheEntry)
+	if lc.ttl != 0 && !clock.Now().Before(entry.expiresAt) {
+		lc.removeElement(el)
+		return nil, gen, false
+	}
+
+	lc.ll.MoveToFront(el)
+	return entry.value, gen, true
+}
+
+// put stores the given value, unless the key has been invalidated since
+// the given generation was returned by get.
+func (lc *localCache) put(key string, value []byte, gen uint64) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if !lc.enabled || lc.size == 0 || lc.generations[localCacheBucket(key)] != gen {
+		return
+	}
+
+	entry := localCacheEntry{
+		key:       key,
+		value:     value,
+		expiresAt: clock.Now().Add(lc.ttl),
+	}
+
+	if el, ok := lc.items[key]; ok {
+		el.Value = &entry
+		lc.ll.MoveToFront(el)
+		return
+	}
+
+	lc.items[key] = lc.ll.PushFront(&entry)
+	for lc.ll.Len() > lc.size {
+		lc.removeElement(lc.ll.Back())
+	}
+}
+
+// remove invalidates the given key.
+func (lc *localCache) remove(key string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.generations[localCacheBucket(key)]++
+
+	if el, ok := lc.items[key]; ok {
+		lc.removeElement(el)
+	}
+}
+
+// setEnabled enables or disables the cache. In both cases, all entries are
+// removed as invalidations might have been missed.
+func (lc *localCache) setEnabled(enabled bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.enabled = enabled
+	lc.ll.Init()
+	lc.items = make(map[string]*list.Element)
+	for i := range lc.generations {
+		lc.generations[i]++
+	}
+}
+
+func (lc *localCache) len() int {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.ll.Len()
+}
+
+func (lc *localCache) removeElement(el *list.Element) {
+	lc.ll.Remove(el)
+	delete(lc.items, el.Value.(*localCacheEntry).key)
+}
+
+func localCacheBucket(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % localCacheGenerations
+}
+
+// getCached returns the value of the given key, from the local cache when
+// available. The errors are returned as-is (e.g. redis.ErrNil).
+// This must not be used within a WATCH / MULTI / EXEC block.
+func getCached(c redis.Conn, key string) ([]byte, error) {
+	val, gen, ok := redisLocalCache.get(key)
+	if ok {
+		return val, nil
+	}
+
+	val, err := redis.Bytes(c.Do("GET", key))
+	if err != nil {
+		return nil, err
+	}
+
+	redisLocalCache.put(key, val, gen)
+	return val, nil
+}
+
+// setupLocalCache configures the local cache and starts the keyspace
+// notification subscription used for invalidation.
+func setupLocalCache(c config.Config) error {
+	if !c.Redis.LocalCache.Enabled {
+		redisLocalCache = newLocalCache(0, 0)
+		return nil
+	}
+
+	if c.Redis.Cluster.Enabled {
+		return errors.New("storage: local cache is not supported in combination with Redis Cluster")
+	}
+
+	// The keyspace notifications are configured on the primary at startup,
+	// this configuration is not applied to the promoted primary on failover.
+	if c.Redis.Sentinel.MasterName != "" {
+		return errors.New("storage: local cache is not supported in combination with Redis Sentinel")
+	}
+
+	log.WithFields(log.Fields{
+		"size": c.Redis.LocalCache.Size,
+		"ttl":  c.Redis.LocalCache.TTL,
+	}).Info("storage: setting up local cache")
+
+	if err := configureKeyspaceEvents(redisPool, c.Redis.LocalCache.ConfigureKeyspaceEvents); err != nil {
+		return errors.Wrap(err, "storage: configure keyspace events error")
+	}
+
+	redisLocalCache = newLocalCache(c.Redis.LocalCache.Size, c.Redis.LocalCache.TTL)
+	go runLocalCacheInvalidation(redisPool, redisLocalCache)
+
+	return nil
+}
+
+// configureKeyspaceEvents validates that the keyspace notifications required
+// for the local cache are enabled. When configure is set, the missing
+// notification classes are enabled.
+func configureKeyspaceEvents(p RedisClient, configure bool) error {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("CONFIG", "GET", "notify-keyspace-events"))
+	if err != nil || len(values) != 2 {
+		// e.g. the CONFIG command is disabled by the Redis provider
+		log.WithError(err).Warningf("storage: unable to verify notify-keyspace-events, make sure it contains '%s'", localCacheKeyspaceEvents)
+		return nil
+	}
+
+	missing := missingKeyspaceEvents(values[1])
+	if missing == "" {
+		return nil
+	}
+
+	if !configure {
+		return errors.Errorf("notify-keyspace-events does not contain '%s'", missing)
+	}
+
+	if _, err := c.Do("CONFIG", "SET", "notify-keyspace-events", values[1]+missing); err != nil {
+		return errors.Wrap(err, "set notify-keyspace-events error")
+	}
+
+	log.WithField("notify_keyspace_events", values[1]+missing).Info("storage: notify-keyspace-events configured")
+
+	return nil
+}
+
+// missingKeyspaceEvents returns the notification classes required by the
+// local cache which are not contained by the given notify-keyspace-events
+// value. Note that A is an alias for all classes except K, E, m and n.
+func missingKeyspaceEvents(current string) string {
+	var out string
+	for _, r := range localCacheKeyspaceEvents {
+		if strings.ContainsRune(current, r) || (r != 'K' && strings.ContainsRune(current, 'A')) {
+			continue
+		}
+		out += string(r)
+	}
+	return out
+}
+
+func runLocalCacheInvalidation(p RedisClient, lc *localCache) {
+	for {
+		err := subscribeLocalCacheInvalidation(p, lc)
+		lc.setEnabled(false)
+
+		log.WithError(err).Error("storage: local cache invalidation subscription error, local cache disabled")
+		clock.Sleep(localCacheRetryInterval)
+	}
+}
+
+// subscribeLocalCacheInvalidation subscribes to the keyspace notifications
+// of the cached keys and invalidates the local cache on each notification.
+// The local cache is enabled once subscribed. This blocks until an error
+// occurs.
+func subscribeLocalCacheInvalidation(p RedisClient, lc *localCache) error {
+	c := p.Get()
+	defer c.Close()
+
+	var patterns []interface{}
+	for _, pattern := range localCacheKeyPatterns {
+		patterns = append(patterns, "__keyspace@*__:"+pattern)
+	}
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.PSubscribe(patterns...); err != nil {
+		return errors.Wrap(err, "psubscribe error")
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				if i := strings.Index(v.Channel, "__:"); i != -1 {
+					lc.remove(v.Channel[i+3:])
+				}
+			case redis.Subscription:
+				if v.Count == len(patterns) {
+					lc.setEnabled(true)
+					log.Info("storage: local cache invalidation subscribed, local cache enabled")
+				}
+			case error:
+				done <- v
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(localCachePingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := psc.Ping(""); err != nil {
+				return errors.Wrap(err, "ping error")
+			}
+		case err := <-done:
+			return err
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+)
+
+func TestLocalCache(t *testing.T) {
+	m := clock.NewMock(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	clock.Set(m)
+	defer clock.Set(nil)
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+
+		_, gen, ok := lc.get("a")
+		assert.False(ok)
+		lc.put("a", []byte{1}, gen)
+		assert.Equal(0, lc.len())
+	})
+
+	t.Run("Get and put", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		_, gen, ok := lc.get("a")
+		assert.False(ok)
+		lc.put("a", []byte{1}, gen)
+
+		val, _, ok := lc.get("a")
+		assert.True(ok)
+		assert.Equal([]byte{1}, val)
+	})
+
+	t.Run("Least recently used is evicted", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		for _, key := range []string{"a", "b"} {
+			_, gen, _ := lc.get(key)
+			lc.put(key, []byte(key), gen)
+		}
+
+		// a becomes the most recently used entry
+		_, _, ok := lc.get("a")
+		assert.True(ok)
+
+		_, gen, _ := lc.get("c")
+		lc.put("c", []byte("c"), gen)
+		assert.Equal(2, lc.len())
+
+		_, _, ok = lc.get("b")
+		assert.False(ok)
+		_, _, ok = lc.get("a")
+		assert.True(ok)
+		_, _, ok = lc.get("c")
+		assert.True(ok)
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		_, gen, _ := lc.get("a")
+		lc.put("a", []byte{1}, gen)
+
+		m.Add(59 * time.Second)
+		_, _, ok := lc.get("a")
+		assert.True(ok)
+
+		m.Add(time.Second)
+		_, _, ok = lc.get("a")
+		assert.False(ok)
+		assert.Equal(0, lc.len())
+	})
+
+	t.Run("Invalidated before put", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		_, gen, _ := lc.get("a")
+		lc.remove("a")
+		lc.put("a", []byte{1}, gen)
+
+		_, _, ok := lc.get("a")
+		assert.False(ok)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		_, gen, _ := lc.get("a")
+		lc.put("a", []byte{1}, gen)
+		lc.remove("a")
+
+		_, _, ok := lc.get("a")
+		assert.False(ok)
+	})
+
+	t.Run("Disable flushes", func(t *testing.T) {
+		assert := require.New(t)
+		lc := newLocalCache(2, time.Minute)
+		lc.setEnabled(true)
+
+		_, gen, _ := lc.get("a")
+		lc.put("a", []byte{1}, gen)
+		lc.setEnabled(false)
+		lc.setEnabled(true)
+
+		assert.Equal(0, lc.len())
+	})
+}
+
+func TestMissingKeyspaceEvents(t *testing.T) {
+	tests := []struct {
+		Current  string
+		Expected string
+	}{
+		{"", "Kg$xe"},
+		{"Ex", "Kg$e"},
+		{"KA", ""},
+		{"A", "K"},
+		{"Kg$xe", ""},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Current, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, missingKeyspaceEvents(tst.Current))
+		})
+	}
+}
+
+func TestSetupLocalCacheUnsupported(t *testing.T) {
+	defer func(prev *localCache) { redisLocalCache = prev }(redisLocalCache)
+
+	t.Run("Redis Cluster", func(t *testing.T) {
+		assert := require.New(t)
+		var conf config.Config
+		conf.Redis.LocalCache.Enabled = true
+		conf.Redis.Cluster.Enabled = true
+		assert.Error(setupLocalCache(conf))
+	})
+
+	t.Run("Redis Sentinel", func(t *testing.T) {
+		assert := require.New(t)
+		var conf config.Config
+		conf.Redis.LocalCache.Enabled = true
+		conf.Redis.Sentinel.MasterName = "mymaster"
+		assert.Error(setupLocalCache(conf))
+	})
+}
+
+func (ts *StorageTestSuite) TestLocalCacheInvalidation() {
+	assert := require.New(ts.T())
+
+	assert.NoError(configureKeyspaceEvents(ts.RedisPool(), true))
+
+	lc := newLocalCache(10, time.Minute)
+	defer func(prev *localCache) { redisLocalCache = prev }(redisLocalCache)
+	redisLocalCache = lc
+
+	go subscribeLocalCacheInvalidation(ts.RedisPool(), lc)
+	assert.True(waitFor(func() bool {
+		lc.mu.Lock()
+		defer lc.mu.Unlock()
+		return lc.enabled
+	}))
+
+	c := ts.RedisPool().Get()
+	defer c.Close()
+
+	key := "lora:ns:dp:test"
+	_, err := c.Do("SET", key, "a")
+	assert.NoError(err)
+
+	val, err := getCached(c, key)
+	assert.NoError(err)
+	assert.Equal([]byte("a"), val)
+	assert.Equal(1, lc.len())
+
+	ts.T().Run("Updated by other instance", func(t *testing.T) {
+		assert := require.New(t)
+
+		// bypass the local cache, as an other instance would do
+		_, err := c.Do("SET", key, "b")
+		assert.NoError(err)
+
+		assert.True(waitFor(func() bool {
+			val, err := getCached(c, key)
+			return err == nil && string(val) == "b"
+		}))
+	})
+
+	ts.T().Run("Deleted by other instance", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := c.Do("DEL", key)
+		assert.NoError(err)
+
+		assert.True(waitFor(func() bool {
+			_, err := getCached(c, key)
+			return err == redis.ErrNil
+		}))
+	})
+}
+
+// waitFor returns true once the given condition is true, or false after
+// one second.
+func waitFor(f func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if f() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/gob"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -16,8 +17,8 @@ import (
 )
 
 const (
-	macCommandQueueTempl   = "lora:ns:device:%s:mac:queue"
-	macCommandPendingTempl = "lora:ns:device:%s:mac:pending:%d"
+	macCommandQueueTempl   = "lora:ns:device:{%s}:mac:queue"
+	macCommandPendingTempl = "lora:ns:device:{%s}:mac:pending:%d"
 )
 
 // MACCommandBlock defines a block of MAC commands that must be handled
@@ -83,7 +84,7 @@ func (m *MACCommands) UnmarshalBinary(data []byte) error {
 }
 
 // FlushMACCommandQueue flushes the mac-command queue for the given DevEUI.
-func FlushMACCommandQueue(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) error {
+func FlushMACCommandQueue(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) error {
 	c := p.Get()
 	defer c.Close()
 
@@ -96,7 +97,7 @@ func FlushMACCommandQueue(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI
 }
 
 // CreateMACCommandQueueItem creates a new mac-command queue item.
-func CreateMACCommandQueueItem(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, block MACCommandBlock) error {
+func CreateMACCommandQueueItem(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, block MACCommandBlock) error {
 	var buf bytes.Buffer
 	err := gob.NewEncoder(&buf).Encode(block)
 	if err != nil {
@@ -128,7 +129,7 @@ func CreateMACCommandQueueItem(ctx context.Context, p *redis.Pool, devEUI lorawa
 
 // GetMACCommandQueueItems returns the mac-command queue items for the
 // given DevEUI.
-func GetMACCommandQueueItems(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64) ([]MACCommandBlock, error) {
+func GetMACCommandQueueItems(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) ([]MACCommandBlock, error) {
 	var out []MACCommandBlock
 
 	c := p.Get()
@@ -159,7 +160,7 @@ func GetMACCommandQueueItems(ctx context.Context, p *redis.Pool, devEUI lorawan.
 }
 
 // DeleteMACCommandQueueItem deletes the given mac-command from the queue.
-func DeleteMACCommandQueueItem(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, block MACCommandBlock) error {
+func DeleteMACCommandQueueItem(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, block MACCommandBlock) error {
 	var buf bytes.Buffer
 	err := gob.NewEncoder(&buf).Encode(block)
 	if err != nil {
@@ -191,7 +192,7 @@ func DeleteMACCommandQueueItem(ctx context.Context, p *redis.Pool, devEUI lorawa
 // SetPendingMACCommand sets a mac-command to the pending buffer.
 // In case an other mac-command with the same CID has been set to pending,
 // it will be overwritten.
-func SetPendingMACCommand(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, block MACCommandBlock) error {
+func SetPendingMACCommand(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, block MACCommandBlock) error {
 	var buf bytes.Buffer
 	err := gob.NewEncoder(&buf).Encode(block)
 	if err != nil {
@@ -221,7 +222,7 @@ func SetPendingMACCommand(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI
 
 // GetPendingMACCommand returns the pending mac-command for the given CID.
 // In case no items are pending, nil is returned.
-func GetPendingMACCommand(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) (*MACCommandBlock, error) {
+func GetPendingMACCommand(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, cid lorawan.CID) (*MACCommandBlock, error) {
 	var block MACCommandBlock
 
 	c := p.Get()
@@ -244,8 +245,43 @@ func GetPendingMACCommand(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI
 	return &block, nil
 }
 
+// GetPendingMACCommands returns all the pending mac-commands (sorted by CID)
+// for the given DevEUI.
+func GetPendingMACCommands(ctx context.Context, p RedisClient, devEUI lorawan.EUI64) ([]MACCommandBlock, error) {
+	// the pending keys of all CIDs are stored in the same slot (hash tag),
+	// thus these can be retrieved by a single MGET
+	var keys []string
+	for cid := 0; cid < 256; cid++ {
+		keys = append(keys, fmt.Sprintf(macCommandPendingTempl, devEUI, cid))
+	}
+
+	vals, err := redis.ByteSlices(mget(p, keys))
+	if err != nil {
+		return nil, errors.Wrap(err, "get pending mac-commands error")
+	}
+
+	var out []MACCommandBlock
+	for _, val := range vals {
+		if val == nil {
+			continue
+		}
+
+		var block MACCommandBlock
+		if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&block); err != nil {
+			return nil, errors.Wrap(err, "gob decode error")
+		}
+		out = append(out, block)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CID < out[j].CID
+	})
+
+	return out, nil
+}
+
 // DeletePendingMACCommand removes the pending mac-command for the given CID.
-func DeletePendingMACCommand(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) error {
+func DeletePendingMACCommand(ctx context.Context, p RedisClient, devEUI lorawan.EUI64, cid lorawan.CID) error {
 	c := p.Get()
 	defer c.Close()
 
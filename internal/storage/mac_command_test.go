@@ -83,6 +83,12 @@ func TestMACCommand(t *testing.T) {
 				So(*block, ShouldResemble, macCommands[0])
 			})
 
+			Convey("Then GetPendingMACCommands returns the pending mac-command", func() {
+				blocks, err := GetPendingMACCommands(context.Background(), RedisPool(), devEUI)
+				So(err, ShouldBeNil)
+				So(blocks, ShouldResemble, []MACCommandBlock{macCommands[0]})
+			})
+
 			Convey("When deleting a pending mac-command", func() {
 				So(DeletePendingMACCommand(context.Background(), RedisPool(), devEUI, macCommands[0].CID), ShouldBeNil)
 
@@ -24,7 +24,7 @@ const (
 )
 
 const (
-	metricsKeyTempl = "lora:ns:metrics:%s:%s:%d" // metrics key (identifier | aggregation | timestamp)
+	metricsKeyTempl = "lora:ns:metrics:{%s:%s}:%d" // metrics key (identifier | aggregation | timestamp)
 
 )
 
@@ -68,7 +68,7 @@ func SetMetricsTTL(minute, hour, day, month time.Duration) {
 }
 
 // SaveMetrics stores the given metrics into Redis.
-func SaveMetrics(ctx context.Context, p *redis.Pool, name string, metrics MetricsRecord) error {
+func SaveMetrics(ctx context.Context, p RedisClient, name string, metrics MetricsRecord) error {
 	for _, agg := range aggregationIntervals {
 		if err := SaveMetricsForInterval(ctx, p, agg, name, metrics); err != nil {
 			return errors.Wrap(err, "save metrics for interval error")
@@ -85,7 +85,7 @@ func SaveMetrics(ctx context.Context, p *redis.Pool, name string, metrics Metric
 }
 
 // SaveMetricsForInterval aggregates and stores the given metrics.
-func SaveMetricsForInterval(ctx context.Context, p *redis.Pool, agg AggregationInterval, name string, metrics MetricsRecord) error {
+func SaveMetricsForInterval(ctx context.Context, p RedisClient, agg AggregationInterval, name string, metrics MetricsRecord) error {
 	if len(metrics.Metrics) == 0 {
 		return nil
 	}
@@ -139,7 +139,7 @@ func SaveMetricsForInterval(ctx context.Context, p *redis.Pool, agg AggregationI
 }
 
 // GetMetrics returns the metrics for the requested aggregation interval.
-func GetMetrics(ctx context.Context, p *redis.Pool, agg AggregationInterval, name string, start, end time.Time) ([]MetricsRecord, error) {
+func GetMetrics(ctx context.Context, p RedisClient, agg AggregationInterval, name string, start, end time.Time) ([]MetricsRecord, error) {
 	c := p.Get()
 	defer c.Close()
 
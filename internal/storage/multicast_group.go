@@ -36,6 +36,11 @@ type MulticastGroup struct {
 	PingSlotPeriod   int                `db:"ping_slot_period"`
 	RoutingProfileID uuid.UUID          `db:"routing_profile_id"` // there is no downlink data, but it can be used for future error reporting
 	ServiceProfileID uuid.UUID          `db:"service_profile_id"`
+
+	// MaxFramesPerMinute and MaxAirtimePercentage limit the downlink
+	// frames per gateway. A value of 0 disables the limit.
+	MaxFramesPerMinute   int     `db:"max_frames_per_minute"`
+	MaxAirtimePercentage float64 `db:"max_airtime_percentage"`
 }
 
 // MulticastQueueItem defines a multicast queue-item.
@@ -49,6 +54,7 @@ type MulticastQueueItem struct {
 	FCnt                    uint32         `db:"f_cnt"`
 	FPort                   uint8          `db:"f_port"`
 	FRMPayload              []byte         `db:"frm_payload"`
+	Geofence                *Geofence      `db:"geofence"`
 }
 
 // Validate validates the MulticastQueueItem.
@@ -56,6 +62,11 @@ func (m MulticastQueueItem) Validate() error {
 	if m.FPort == 0 {
 		return ErrInvalidFPort
 	}
+	if m.Geofence != nil {
+		if err := m.Geofence.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -86,8 +97,10 @@ func CreateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 			frequency,
 			ping_slot_period,
 			service_profile_id,
-			routing_profile_id
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			routing_profile_id,
+			max_frames_per_minute,
+			max_airtime_percentage
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
 		mg.ID,
 		mg.CreatedAt,
 		mg.UpdatedAt,
@@ -100,6 +113,8 @@ func CreateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 		mg.PingSlotPeriod,
 		mg.ServiceProfileID,
 		mg.RoutingProfileID,
+		mg.MaxFramesPerMinute,
+		mg.MaxAirtimePercentage,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -154,7 +169,9 @@ func UpdateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 			frequency = $8,
 			ping_slot_period = $9,
 			service_profile_id = $10,
-			routing_profile_id = $11
+			routing_profile_id = $11,
+			max_frames_per_minute = $12,
+			max_airtime_percentage = $13
 		where
 			id = $1`,
 		mg.ID,
@@ -168,6 +185,8 @@ func UpdateMulticastGroup(ctx context.Context, db sqlx.Execer, mg *MulticastGrou
 		mg.PingSlotPeriod,
 		mg.ServiceProfileID,
 		mg.RoutingProfileID,
+		mg.MaxFramesPerMinute,
+		mg.MaxAirtimePercentage,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -234,8 +253,9 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 			gateway_id,
 			f_cnt,
 			f_port,
-			frm_payload
-		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+			frm_payload,
+			geofence
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		returning
 			id
 		`,
@@ -247,6 +267,7 @@ func CreateMulticastQueueItem(ctx context.Context, db sqlx.Queryer, qi *Multicas
 		qi.FCnt,
 		qi.FPort,
 		qi.FRMPayload,
+		qi.Geofence,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -290,6 +311,41 @@ func DeleteMulticastQueueItem(ctx context.Context, db sqlx.Execer, id int64) err
 	return nil
 }
 
+// UpdateMulticastQueueItemSchedule updates the scheduling timestamps of the
+// given queue-item.
+func UpdateMulticastQueueItemSchedule(ctx context.Context, db sqlx.Execer, qi MulticastQueueItem) error {
+	res, err := db.Exec(`
+		update
+			multicast_queue
+		set
+			schedule_at = $2,
+			emit_at_time_since_gps_epoch = $3
+		where
+			id = $1`,
+		qi.ID,
+		qi.ScheduleAt,
+		qi.EmitAtTimeSinceGPSEpoch,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":          qi.ID,
+		"schedule_at": qi.ScheduleAt,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Info("multicast queue-item schedule updated")
+
+	return nil
+}
+
 // FlushMulticastQueueForMulticastGroup flushes the multicast-queue given
 // a multicast-group id.
 func FlushMulticastQueueForMulticastGroup(ctx context.Context, db sqlx.Execer, multicastGroupID uuid.UUID) error {
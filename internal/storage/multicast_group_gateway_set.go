@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// MulticastGroupGatewaySet defines the maintained gateway-set of a
+// multicast-group. PendingGatewayIDs holds a candidate gateway-set which
+// has been selected PendingCount times in a row, but which has not been
+// adopted yet.
+type MulticastGroupGatewaySet struct {
+	MulticastGroupID  uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	GatewayIDs        []lorawan.EUI64
+	PendingGatewayIDs []lorawan.EUI64
+	PendingCount      int
+}
+
+// SaveMulticastGroupGatewaySet creates or updates the given gateway-set.
+func SaveMulticastGroupGatewaySet(ctx context.Context, db sqlx.Execer, s *MulticastGroupGatewaySet) error {
+	now := time.Now()
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+
+	_, err := db.Exec(`
+		insert into multicast_group_gateway_set (
+			multicast_group_id,
+			created_at,
+			updated_at,
+			gateway_ids,
+			pending_gateway_ids,
+			pending_count
+		) values ($1, $2, $3, $4, $5, $6)
+		on conflict (multicast_group_id) do update
+		set
+			updated_at = $3,
+			gateway_ids = $4,
+			pending_gateway_ids = $5,
+			pending_count = $6`,
+		s.MulticastGroupID,
+		s.CreatedAt,
+		s.UpdatedAt,
+		eui64sToByteaArray(s.GatewayIDs),
+		eui64sToByteaArray(s.PendingGatewayIDs),
+		s.PendingCount,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": s.MulticastGroupID,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Debug("multicast-group gateway-set saved")
+
+	return nil
+}
+
+// GetMulticastGroupGatewaySet returns the gateway-set of the given
+// multicast-group. It returns ErrDoesNotExist when no gateway-set has been
+// stored yet.
+func GetMulticastGroupGatewaySet(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) (MulticastGroupGatewaySet, error) {
+	var s MulticastGroupGatewaySet
+	var gatewayIDs, pendingGatewayIDs pq.ByteaArray
+
+	err := db.QueryRowx(`
+		select
+			multicast_group_id,
+			created_at,
+			updated_at,
+			gateway_ids,
+			pending_gateway_ids,
+			pending_count
+		from
+			multicast_group_gateway_set
+		where
+			multicast_group_id = $1`,
+		multicastGroupID,
+	).Scan(
+		&s.MulticastGroupID,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&gatewayIDs,
+		&pendingGatewayIDs,
+		&s.PendingCount,
+	)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	s.GatewayIDs = byteaArrayToEUI64s(gatewayIDs)
+	s.PendingGatewayIDs = byteaArrayToEUI64s(pendingGatewayIDs)
+
+	return s, nil
+}
+
+// GetMulticastGroupIDs returns the IDs of all multicast-groups.
+func GetMulticastGroupIDs(ctx context.Context, db sqlx.Queryer) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := sqlx.Select(db, &ids, `
+		select
+			id
+		from
+			multicast_group
+		order by
+			id`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return ids, nil
+}
+
+func eui64sToByteaArray(ids []lorawan.EUI64) pq.ByteaArray {
+	out := make(pq.ByteaArray, 0, len(ids))
+	for i := range ids {
+		out = append(out, ids[i][:])
+	}
+	return out
+}
+
+func byteaArrayToEUI64s(b pq.ByteaArray) []lorawan.EUI64 {
+	var out []lorawan.EUI64
+	for _, v := range b {
+		var id lorawan.EUI64
+		copy(id[:], v)
+		out = append(out, id)
+	}
+	return out
+}
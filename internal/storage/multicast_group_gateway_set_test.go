@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestMulticastGroupGatewaySet() {
+	assert := require.New(ts.T())
+
+	mg := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg))
+
+	ts.T().Run("Get does not exist", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := GetMulticastGroupGatewaySet(context.Background(), ts.Tx(), mg.ID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("Save", func(t *testing.T) {
+		assert := require.New(t)
+
+		s := MulticastGroupGatewaySet{
+			MulticastGroupID: mg.ID,
+			GatewayIDs:       []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+		}
+		assert.NoError(SaveMulticastGroupGatewaySet(context.Background(), ts.Tx(), &s))
+
+		sGet, err := GetMulticastGroupGatewaySet(context.Background(), ts.Tx(), mg.ID)
+		assert.NoError(err)
+		assert.Equal(s.GatewayIDs, sGet.GatewayIDs)
+		assert.Len(sGet.PendingGatewayIDs, 0)
+		assert.Equal(0, sGet.PendingCount)
+
+		t.Run("Update", func(t *testing.T) {
+			assert := require.New(t)
+
+			s.PendingGatewayIDs = []lorawan.EUI64{{2, 2, 2, 2, 2, 2, 2, 2}}
+			s.PendingCount = 1
+			assert.NoError(SaveMulticastGroupGatewaySet(context.Background(), ts.Tx(), &s))
+
+			sGet, err := GetMulticastGroupGatewaySet(context.Background(), ts.Tx(), mg.ID)
+			assert.NoError(err)
+			assert.Equal(s.GatewayIDs, sGet.GatewayIDs)
+			assert.Equal(s.PendingGatewayIDs, sGet.PendingGatewayIDs)
+			assert.Equal(1, sGet.PendingCount)
+		})
+	})
+
+	ts.T().Run("GetMulticastGroupIDs", func(t *testing.T) {
+		assert := require.New(t)
+
+		ids, err := GetMulticastGroupIDs(context.Background(), ts.Tx())
+		assert.NoError(err)
+		assert.Contains(ids, mg.ID)
+	})
+}
@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// MulticastSetupState defines the remote multicast setup state of a device.
+type MulticastSetupState string
+
+// Available remote multicast setup states.
+const (
+	MulticastSetupGroupPending   MulticastSetupState = "GROUP_SETUP_PENDING"
+	MulticastSetupSessionPending MulticastSetupState = "SESSION_PENDING"
+	MulticastSetupCompleted      MulticastSetupState = "COMPLETED"
+	MulticastSetupFailed         MulticastSetupState = "FAILED"
+)
+
+// MulticastGroupSetup defines the remote multicast setup of a
+// multicast-group.
+type MulticastGroupSetup struct {
+	MulticastGroupID uuid.UUID `db:"multicast_group_id"`
+	CreatedAt        time.Time `db:"created_at"`
+	McGroupID        int       `db:"mc_group_id"`
+	McKeyEncrypted   []byte    `db:"mc_key_encrypted"`
+	MinMcFCnt        uint32    `db:"min_mc_f_cnt"`
+	MaxMcFCnt        uint32    `db:"max_mc_f_cnt"`
+	SessionTime      time.Time `db:"session_time"`
+	SessionTimeOut   int       `db:"session_time_out"`
+}
+
+// Validate validates the multicast-group setup.
+func (s MulticastGroupSetup) Validate() error {
+	if s.McGroupID < 0 || s.McGroupID > 3 || len(s.McKeyEncrypted) != 16 || s.MinMcFCnt > s.MaxMcFCnt || s.SessionTimeOut < 0 || s.SessionTimeOut > 15 {
+		return ErrInvalidMulticastGroupSetup
+	}
+
+	return nil
+}
+
+// MulticastGroupSetupDevice defines the remote multicast setup status of a
+// device.
+type MulticastGroupSetupDevice struct {
+	MulticastGroupID uuid.UUID           `db:"multicast_group_id"`
+	DevEUI           lorawan.EUI64       `db:"dev_eui"`
+	CreatedAt        time.Time           `db:"created_at"`
+	UpdatedAt        time.Time           `db:"updated_at"`
+	State            MulticastSetupState `db:"state"`
+	Error            string              `db:"error"`
+}
+
+// CreateMulticastGroupSetup creates the given multicast-group setup. An
+// existing setup of the multicast-group (including the status of its
+// devices) is replaced.
+func CreateMulticastGroupSetup(ctx context.Context, db sqlx.Execer, s *MulticastGroupSetup) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	s.CreatedAt = time.Now()
+
+	_, err := db.Exec("delete from multicast_group_setup where multicast_group_id = $1", s.MulticastGroupID)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	_, err = db.Exec(`
+		insert into multicast_group_setup (
+			multicast_group_id,
+			created_at,
+			mc_group_id,
+			mc_key_encrypted,
+			min_mc_f_cnt,
+			max_mc_f_cnt,
+			session_time,
+			session_time_out
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		s.MulticastGroupID,
+		s.CreatedAt,
+		s.McGroupID,
+		s.McKeyEncrypted,
+		s.MinMcFCnt,
+		s.MaxMcFCnt,
+		s.SessionTime,
+		s.SessionTimeOut,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": s.MulticastGroupID,
+		"mc_group_id":        s.McGroupID,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast-group setup created")
+
+	return nil
+}
+
+// GetMulticastGroupSetup returns the setup of the given multicast-group.
+func GetMulticastGroupSetup(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) (MulticastGroupSetup, error) {
+	var s MulticastGroupSetup
+	err := sqlx.Get(db, &s, "select * from multicast_group_setup where multicast_group_id = $1", multicastGroupID)
+	if err != nil {
+		return s, handlePSQLError(err, "select error")
+	}
+
+	return s, nil
+}
+
+// SaveMulticastGroupSetupDevice creates or updates the given device setup
+// status.
+func SaveMulticastGroupSetupDevice(ctx context.Context, db sqlx.Execer, d *MulticastGroupSetupDevice) error {
+	now := time.Now()
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = now
+	}
+	d.UpdatedAt = now
+
+	_, err := db.Exec(`
+		insert into multicast_group_setup_device (
+			multicast_group_id,
+			dev_eui,
+			created_at,
+			updated_at,
+			state,
+			error
+		) values ($1, $2, $3, $4, $5, $6)
+		on conflict (multicast_group_id, dev_eui) do update
+		set
+			updated_at = $4,
+			state = $5,
+			error = $6`,
+		d.MulticastGroupID,
+		d.DevEUI[:],
+		d.CreatedAt,
+		d.UpdatedAt,
+		d.State,
+		d.Error,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": d.MulticastGroupID,
+		"dev_eui":            d.DevEUI,
+		"state":              d.State,
+		"ctx_id":             ctx.Value(logging.ContextIDKey),
+	}).Info("multicast-group setup device saved")
+
+	return nil
+}
+
+// GetMulticastGroupSetupDevices returns the setup status of the devices of
+// the given multicast-group.
+func GetMulticastGroupSetupDevices(ctx context.Context, db sqlx.Queryer, multicastGroupID uuid.UUID) ([]MulticastGroupSetupDevice, error) {
+	var out []MulticastGroupSetupDevice
+	err := sqlx.Select(db, &out, `
+		select
+			*
+		from
+			multicast_group_setup_device
+		where
+			multicast_group_id = $1
+		order by
+			dev_eui`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
+
+// GetMulticastGroupSetupDeviceForMcGroupID returns the setup status and the
+// multicast-group setup for the given device and McGroupID (as used by the
+// device for the multicast-group). It returns ErrDoesNotExist when no setup
+// is in progress for this McGroupID.
+func GetMulticastGroupSetupDeviceForMcGroupID(ctx context.Context, db sqlx.Queryer, devEUI lorawan.EUI64, mcGroupID int) (MulticastGroupSetupDevice, MulticastGroupSetup, error) {
+	var d MulticastGroupSetupDevice
+	var s MulticastGroupSetup
+
+	err := db.QueryRowx(`
+		select
+			d.multicast_group_id,
+			d.dev_eui,
+			d.created_at,
+			d.updated_at,
+			d.state,
+			d.error,
+			s.created_at,
+			s.mc_group_id,
+			s.mc_key_encrypted,
+			s.min_mc_f_cnt,
+			s.max_mc_f_cnt,
+			s.session_time,
+			s.session_time_out
+		from
+			multicast_group_setup_device d
+		inner join
+			multicast_group_setup s
+			on s.multicast_group_id = d.multicast_group_id
+		where
+			d.dev_eui = $1
+			and s.mc_group_id = $2
+			and d.state in ($3, $4)
+		order by
+			s.created_at desc
+		limit 1`,
+		devEUI[:],
+		mcGroupID,
+		MulticastSetupGroupPending,
+		MulticastSetupSessionPending,
+	).Scan(
+		&d.MulticastGroupID,
+		&d.DevEUI,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.State,
+		&d.Error,
+		&s.CreatedAt,
+		&s.McGroupID,
+		&s.McKeyEncrypted,
+		&s.MinMcFCnt,
+		&s.MaxMcFCnt,
+		&s.SessionTime,
+		&s.SessionTimeOut,
+	)
+	if err != nil {
+		return d, s, handlePSQLError(err, "select error")
+	}
+	s.MulticastGroupID = d.MulticastGroupID
+
+	return d, s, nil
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestMulticastGroupSetup() {
+	assert := require.New(ts.T())
+
+	mg := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg))
+
+	var dp DeviceProfile
+	assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+	d := Device{
+		DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		ServiceProfileID: mg.ServiceProfileID,
+		DeviceProfileID:  dp.ID,
+		RoutingProfileID: mg.RoutingProfileID,
+	}
+	assert.NoError(CreateDevice(context.Background(), ts.Tx(), &d))
+
+	s := MulticastGroupSetup{
+		MulticastGroupID: mg.ID,
+		McGroupID:        2,
+		McKeyEncrypted:   []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		MinMcFCnt:        10,
+		MaxMcFCnt:        20,
+		SessionTime:      time.Now().UTC().Truncate(time.Millisecond),
+		SessionTimeOut:   10,
+	}
+
+	ts.T().Run("Create invalid", func(t *testing.T) {
+		assert := require.New(t)
+
+		invalid := s
+		invalid.McGroupID = 4
+		assert.Equal(ErrInvalidMulticastGroupSetup, CreateMulticastGroupSetup(context.Background(), ts.Tx(), &invalid))
+	})
+
+	ts.T().Run("Create", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(CreateMulticastGroupSetup(context.Background(), ts.Tx(), &s))
+
+		sGet, err := GetMulticastGroupSetup(context.Background(), ts.Tx(), mg.ID)
+		assert.NoError(err)
+		sGet.CreatedAt = s.CreatedAt
+		sGet.SessionTime = sGet.SessionTime.UTC()
+		assert.Equal(s, sGet)
+	})
+
+	ts.T().Run("Save device", func(t *testing.T) {
+		assert := require.New(t)
+
+		sd := MulticastGroupSetupDevice{
+			MulticastGroupID: mg.ID,
+			DevEUI:           d.DevEUI,
+			State:            MulticastSetupGroupPending,
+		}
+		assert.NoError(SaveMulticastGroupSetupDevice(context.Background(), ts.Tx(), &sd))
+
+		devices, err := GetMulticastGroupSetupDevices(context.Background(), ts.Tx(), mg.ID)
+		assert.NoError(err)
+		assert.Len(devices, 1)
+		assert.Equal(MulticastSetupGroupPending, devices[0].State)
+
+		t.Run("Get for McGroupID", func(t *testing.T) {
+			assert := require.New(t)
+
+			sdGet, sGet, err := GetMulticastGroupSetupDeviceForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 2)
+			assert.NoError(err)
+			assert.Equal(mg.ID, sdGet.MulticastGroupID)
+			assert.Equal(mg.ID, sGet.MulticastGroupID)
+			assert.Equal(s.McKeyEncrypted, sGet.McKeyEncrypted)
+
+			_, _, err = GetMulticastGroupSetupDeviceForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 1)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+
+		t.Run("Completed", func(t *testing.T) {
+			assert := require.New(t)
+
+			sd.State = MulticastSetupCompleted
+			assert.NoError(SaveMulticastGroupSetupDevice(context.Background(), ts.Tx(), &sd))
+
+			// the setup is no longer in progress
+			_, _, err := GetMulticastGroupSetupDeviceForMcGroupID(context.Background(), ts.Tx(), d.DevEUI, 2)
+			assert.Equal(ErrDoesNotExist, err)
+		})
+	})
+
+	ts.T().Run("Create replaces existing", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(CreateMulticastGroupSetup(context.Background(), ts.Tx(), &s))
+
+		devices, err := GetMulticastGroupSetupDevices(context.Background(), ts.Tx(), mg.ID)
+		assert.NoError(err)
+		assert.Len(devices, 0)
+	})
+}
@@ -64,6 +64,8 @@ func (ts *StorageTestSuite) TestMulticastGroup() {
 			mc.GroupType = MulticastGroupC
 			mc.Frequency = 868100000
 			mc.PingSlotPeriod = 32
+			mc.MaxFramesPerMinute = 10
+			mc.MaxAirtimePercentage = 5
 
 			assert.Nil(UpdateMulticastGroup(context.Background(), ts.Tx(), &mc))
 
@@ -120,6 +122,10 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 			FPort:                   20,
 			FRMPayload:              []byte{1, 2, 3, 4},
 			EmitAtTimeSinceGPSEpoch: &gps1,
+			Geofence: &Geofence{
+				Center: GPSPoint{Latitude: 52.37, Longitude: 4.89},
+				Radius: 10000,
+			},
 		}
 
 		qi2 := MulticastQueueItem{
@@ -143,6 +149,8 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 
 			assert.EqualValues(items[0].FCnt, 10)
 			assert.EqualValues(items[1].FCnt, 11)
+			assert.Equal(qi1.Geofence, items[0].Geofence)
+			assert.Nil(items[1].Geofence)
 		})
 
 		t.Run("Schedulable multicast queue-items", func(t *testing.T) {
@@ -163,6 +171,24 @@ func (ts *StorageTestSuite) TestMulticastQueue() {
 			assert.Equal(gps2, d)
 		})
 
+		t.Run("Update schedule", func(t *testing.T) {
+			assert := require.New(t)
+
+			gps3 := 120 * time.Second
+			qi2.ScheduleAt = time.Now().Add(time.Hour)
+			qi2.EmitAtTimeSinceGPSEpoch = &gps3
+			assert.NoError(UpdateMulticastQueueItemSchedule(context.Background(), ts.Tx(), qi2))
+
+			items, err := GetSchedulableMulticastQueueItems(context.Background(), ts.Tx(), 2)
+			assert.NoError(err)
+			assert.Len(items, 1)
+			assert.Equal(qi1.ID, items[0].ID)
+
+			d, err := GetMaxEmitAtTimeSinceGPSEpochForMulticastGroup(context.Background(), ts.Tx(), mg.ID)
+			assert.NoError(err)
+			assert.Equal(gps3, d)
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			assert := require.New(t)
 
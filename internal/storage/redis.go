@@ -0,0 +1,595 @@
+package storage
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FZambia/sentinel"
+	"github.com/gomodule/redigo/redis"
+	"github.com/mna/redisc"
+	"github.com/pkg/errors"
+
+	"github.com/mxc-foundation/lpwan-server/internal/config"
+	"github.com/mxc-foundation/lpwan-server/internal/faultinjection"
+)
+
+const (
+	redisClusterRetries    = 3
+	redisClusterRetryDelay = 100 * time.Millisecond
+
+	redisSentinelTimeout = time.Second
+)
+
+// RedisClient defines the interface of the Redis client. This is either a
+// connection pool to a single Redis instance or a Redis Cluster client.
+type RedisClient interface {
+	// Get returns a connection. The caller must close the connection.
+	Get() redis.Conn
+}
+
+// newRedisPool returns a connection pool using the given dial function.
+func newRedisPool(c config.Config, dial func() (redis.Conn, error)) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     c.Redis.MaxIdle,
+		MaxActive:   c.Redis.MaxActive,
+		IdleTimeout: c.Redis.IdleTimeout,
+		Wait:        true,
+		Dial: func() (redis.Conn, error) {
+			c, err := dial()
+			if err != nil {
+				return nil, fmt.Errorf("redis connection error: %s", err)
+			}
+			return faultinjection.WrapRedisConn(c), nil
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Now().Sub(t) < onBorrowPingInterval {
+				return nil
+			}
+
+			_, err := c.Do("PING")
+			if err != nil {
+				return fmt.Errorf("ping redis error: %s", err)
+			}
+			return nil
+		},
+	}
+}
+
+// newRedisSentinelPool returns a connection pool to the Redis primary, of
+// which the address is retrieved from the configured Sentinels. The password
+// and database of the Redis url are used for connecting to the primary.
+// On a failover, the connections to the former primary are closed (or fail
+// the role check on borrow), after which new connections are made to the
+// promoted primary.
+func newRedisSentinelPool(c config.Config) (*redis.Pool, error) {
+	opts, err := redisURLDialOptions(c.Redis.URL)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts,
+		redis.DialReadTimeout(redisDialReadTimeout),
+		redis.DialWriteTimeout(redisDialWriteTimeout),
+	)
+
+	sntnl := &sentinel.Sentinel{
+		Addrs:      c.Redis.Sentinel.Addresses,
+		MasterName: c.Redis.Sentinel.MasterName,
+		Dial: func(addr string) (redis.Conn, error) {
+			c, err := redis.Dial("tcp", addr,
+				redis.DialConnectTimeout(redisSentinelTimeout),
+				redis.DialReadTimeout(redisSentinelTimeout),
+				redis.DialWriteTimeout(redisSentinelTimeout),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return faultinjection.WrapRedisConn(c), nil
+		},
+	}
+
+	// the connections to the primary are wrapped by newRedisPool
+	pool := newRedisPool(c, func() (redis.Conn, error) {
+		addr, err := sntnl.MasterAddr()
+		if err != nil {
+			return nil, errors.Wrap(err, "get master address from sentinel error")
+		}
+		return redis.Dial("tcp", addr, opts...)
+	})
+	pool.TestOnBorrow = sentinelTestOnBorrow
+
+	return pool, nil
+}
+
+// sentinelTestOnBorrow checks that the borrowed connection is (still) to the
+// primary. Like the PING of the default pool, this check is only performed
+// for connections which have been idle for onBorrowPingInterval. On a
+// failover, the Sentinels kill the client connections of the demoted
+// primary, so these are closed without waiting for the role check.
+func sentinelTestOnBorrow(c redis.Conn, t time.Time) error {
+	if time.Now().Sub(t) < onBorrowPingInterval {
+		return nil
+	}
+
+	if !sentinel.TestRole(c, "master") {
+		return errors.New("redis: role check failed, connection is not to the primary")
+	}
+	return nil
+}
+
+// redisURLDialOptions returns the password and database dial options of the
+// given Redis url.
+func redisURLDialOptions(redisURL string) ([]redis.DialOption, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse redis url error")
+	}
+
+	var opts []redis.DialOption
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			opts = append(opts, redis.DialPassword(password))
+		}
+	}
+
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid redis database")
+		}
+		opts = append(opts, redis.DialDatabase(n))
+	}
+
+	return opts, nil
+}
+
+// redisCluster implements the RedisClient interface for Redis Cluster.
+type redisCluster struct {
+	cluster     *redisc.Cluster
+	dialOptions []redis.DialOption
+}
+
+// newRedisCluster returns a Redis Cluster client. The cluster layout is
+// retrieved from the configured nodes, a connection pool is created for each
+// node.
+func newRedisCluster(c config.Config) (*redisCluster, error) {
+	opts := []redis.DialOption{
+		redis.DialReadTimeout(redisDialReadTimeout),
+		redis.DialWriteTimeout(redisDialWriteTimeout),
+	}
+	if c.Redis.Cluster.Password != "" {
+		opts = append(opts, redis.DialPassword(c.Redis.Cluster.Password))
+	}
+
+	cluster := &redisc.Cluster{
+		StartupNodes: c.Redis.Cluster.Nodes,
+		DialOptions:  opts,
+		CreatePool: func(address string, options ...redis.DialOption) (*redis.Pool, error) {
+			return newRedisPool(c, func() (redis.Conn, error) {
+				return redis.Dial("tcp", address, options...)
+			}), nil
+		},
+	}
+
+	if err := cluster.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refresh cluster layout error")
+	}
+
+	return &redisCluster{
+		cluster:     cluster,
+		dialOptions: opts,
+	}, nil
+}
+
+// Get returns a cluster connection.
+func (rc *redisCluster) Get() redis.Conn {
+	return &clusterConn{cluster: rc.cluster}
+}
+
+// masterAddresses returns the addresses of the master nodes.
+func (rc *redisCluster) masterAddresses() ([]string, error) {
+	c := rc.cluster.Get()
+	defer c.Close()
+
+	slots, err := redis.Values(c.Do("CLUSTER", "SLOTS"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get cluster slots error")
+	}
+
+	var out []string
+	seen := make(map[string]bool)
+	for _, slot := range slots {
+		// start, end, master, replicas...
+		values, err := redis.Values(slot, nil)
+		if err != nil || len(values) < 3 {
+			return nil, errors.New("invalid cluster slots reply")
+		}
+		master, err := redis.Values(values[2], nil)
+		if err != nil || len(master) < 2 {
+			return nil, errors.New("invalid cluster slots reply")
+		}
+		host, err := redis.String(master[0], nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "read master host error")
+		}
+		port, err := redis.Int(master[1], nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "read master port error")
+		}
+
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		if !seen[addr] {
+			seen[addr] = true
+			out = append(out, addr)
+		}
+	}
+
+	return out, nil
+}
+
+// redisCommand holds a command of which sending is deferred.
+type redisCommand struct {
+	name string
+	args []interface{}
+}
+
+// clusterConn implements a Redis Cluster connection.
+//
+// Commands executed by Do (outside a pipeline or transaction) are routed by
+// their key, each command uses its own node connection and is retried on a
+// MOVED or ASK redirection.
+//
+// Pipelined commands (Send), transactions (WATCH, MULTI) and subscriptions
+// are sent over a connection which is bound to the slot of the first key. A
+// MULTI which is sent before the first key is deferred until the connection
+// is bound. As all the keys of a pipeline or transaction must be in the same
+// slot, keys which are used together must share the same hash tag.
+type clusterConn struct {
+	cluster *redisc.Cluster
+	conn    redis.Conn
+	pending []redisCommand
+	replies int
+
+	multi      bool
+	watch      bool
+	subscribed bool
+}
+
+// Do sends the command and returns the reply.
+func (c *clusterConn) Do(name string, args ...interface{}) (interface{}, error) {
+	if c.conn == nil && len(c.pending) == 0 {
+		switch strings.ToUpper(name) {
+		case "":
+			return nil, nil
+		case "WATCH", "MULTI", "SUBSCRIBE", "PSUBSCRIBE":
+		default:
+			return c.do(name, args...)
+		}
+	}
+
+	if c.conn == nil {
+		if err := c.bind(redisCommandKey(name, args)); err != nil {
+			return nil, err
+		}
+	}
+
+	c.track(name)
+	reply, err := c.conn.Do(name, args...)
+	c.replies = 0
+	c.release()
+
+	return reply, err
+}
+
+// Send writes the command to the (bound) connection.
+func (c *clusterConn) Send(name string, args ...interface{}) error {
+	if c.conn == nil {
+		key := redisCommandKey(name, args)
+		if key == "" {
+			c.pending = append(c.pending, redisCommand{name: name, args: args})
+			return nil
+		}
+
+		if err := c.bind(key); err != nil {
+			return err
+		}
+	}
+
+	c.track(name)
+	c.replies++
+	return c.conn.Send(name, args...)
+}
+
+// Flush flushes the (bound) connection.
+func (c *clusterConn) Flush() error {
+	if c.conn == nil {
+		if len(c.pending) == 0 {
+			return nil
+		}
+		if err := c.bind(""); err != nil {
+			return err
+		}
+	}
+
+	return c.conn.Flush()
+}
+
+// Receive receives a single reply from the (bound) connection.
+func (c *clusterConn) Receive() (interface{}, error) {
+	if c.conn == nil {
+		return nil, errors.New("redis: no pending replies")
+	}
+
+	reply, err := c.conn.Receive()
+	if c.replies > 0 {
+		c.replies--
+	}
+	c.release()
+
+	return reply, err
+}
+
+// Err returns the error of the bound connection.
+func (c *clusterConn) Err() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Err()
+}
+
+// Close closes the connection.
+func (c *clusterConn) Close() error {
+	c.pending = nil
+	c.replies = 0
+	c.multi, c.watch, c.subscribed = false, false, false
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// do executes a single command using its own connection.
+func (c *clusterConn) do(name string, args ...interface{}) (interface{}, error) {
+	conn := c.cluster.Get()
+	defer conn.Close()
+
+	if key := redisCommandKey(name, args); key != "" {
+		if err := redisc.BindConn(conn, key); err != nil {
+			return nil, errors.Wrap(err, "bind connection error")
+		}
+	}
+
+	rc, err := redisc.RetryConn(conn, redisClusterRetries, redisClusterRetryDelay)
+	if err != nil {
+		return nil, errors.Wrap(err, "retry connection error")
+	}
+
+	return rc.Do(name, args...)
+}
+
+// bind binds the connection to the slot of the given key (or to a random
+// node when empty) and sends the deferred commands.
+func (c *clusterConn) bind(key string) error {
+	conn := c.cluster.Get()
+	if key != "" {
+		if err := redisc.BindConn(conn, key); err != nil {
+			conn.Close()
+			return errors.Wrap(err, "bind connection error")
+		}
+	}
+	c.conn = conn
+
+	pending := c.pending
+	c.pending = nil
+	for _, cmd := range pending {
+		c.track(cmd.name)
+		c.replies++
+		if err := c.conn.Send(cmd.name, cmd.args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// track keeps track of the transaction and subscription state.
+func (c *clusterConn) track(name string) {
+	switch strings.ToUpper(name) {
+	case "MULTI":
+		c.multi = true
+	case "WATCH":
+		c.watch = true
+	case "UNWATCH":
+		c.watch = false
+	case "EXEC", "DISCARD":
+		c.multi = false
+		c.watch = false
+	case "SUBSCRIBE", "PSUBSCRIBE":
+		c.subscribed = true
+	}
+}
+
+// release releases the bound connection when there are no pending replies
+// and no transaction or subscription is in progress.
+func (c *clusterConn) release() {
+	if c.conn == nil || c.replies != 0 || c.multi || c.watch || c.subscribed {
+		return
+	}
+
+	c.conn.Close()
+	c.conn = nil
+}
+
+// redisCommandKey returns the key used for routing the given command, or an
+// empty string when the command does not have a key.
+func redisCommandKey(name string, args []interface{}) string {
+	var arg interface{}
+
+	switch strings.ToUpper(name) {
+	case "", "MULTI", "EXEC", "DISCARD", "UNWATCH", "PING", "ECHO", "INFO", "CONFIG", "SCAN", "DBSIZE", "FLUSHALL", "FLUSHDB", "CLUSTER", "SCRIPT", "TIME":
+		return ""
+	case "EVAL", "EVALSHA":
+		// script, numkeys, key...
+		if len(args) < 3 {
+			return ""
+		}
+		if n, ok := args[1].(int); !ok || n == 0 {
+			return ""
+		}
+		arg = args[2]
+	case "MEMORY":
+		// MEMORY USAGE key
+		if len(args) < 2 {
+			return ""
+		}
+		arg = args[1]
+	default:
+		if len(args) == 0 {
+			return ""
+		}
+		arg = args[0]
+	}
+
+	switch v := arg.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isRedisCluster returns true when the given client is a Redis Cluster
+// client.
+func isRedisCluster(p RedisClient) bool {
+	_, ok := p.(*redisCluster)
+	return ok
+}
+
+// ForEachRedisNode calls the given function with a connection to each Redis
+// node. In case of a single Redis instance, this is called once. In case of
+// Redis Cluster, this is called for each master node, e.g. to scan the keys
+// stored by each node.
+func ForEachRedisNode(p RedisClient, f func(c redis.Conn) error) error {
+	rc, ok := p.(*redisCluster)
+	if !ok {
+		c := p.Get()
+		defer c.Close()
+		return f(c)
+	}
+
+	addrs, err := rc.masterAddresses()
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		c, err := redis.Dial("tcp", addr, rc.dialOptions...)
+		if err != nil {
+			return errors.Wrap(err, "dial redis node error")
+		}
+
+		err = f(faultinjection.WrapRedisConn(c))
+		c.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScanKeys returns the keys matching the given pattern, using SCAN so that
+// Redis is not blocked. In case of Redis Cluster, the keys of all the master
+// nodes are returned.
+func ScanKeys(p RedisClient, pattern string) ([]string, error) {
+	var keys []string
+	err := ForEachRedisNode(p, func(c redis.Conn) error {
+		nodeKeys, err := scanKeys(c, pattern)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, nodeKeys...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// scanKeys returns the keys matching the given pattern, stored by the node
+// to which the given connection is connected.
+func scanKeys(c redis.Conn, pattern string) ([]string, error) {
+	var keys []string
+	cursor := 0
+	for {
+		values, err := redis.Values(c.Do("SCAN", cursor, "MATCH", pattern, "COUNT", 1000))
+		if err != nil {
+			return nil, errors.Wrap(err, "scan error")
+		}
+
+		var batch []string
+		if _, err := redis.Scan(values, &cursor, &batch); err != nil {
+			return nil, errors.Wrap(err, "scan reply error")
+		}
+		keys = append(keys, batch...)
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return keys, nil
+}
+
+// mget returns the values of the given keys (nil when a key does not exist).
+// In case of Redis Cluster, the keys are requested per slot.
+func mget(p RedisClient, keys []string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	groups := [][]string{keys}
+	if isRedisCluster(p) {
+		groups = redisc.SplitBySlot(keys...)
+	}
+
+	values := make(map[string]interface{}, len(keys))
+	for _, group := range groups {
+		args := make([]interface{}, len(group))
+		for i := range group {
+			args[i] = group[i]
+		}
+
+		vals, err := redis.Values(c.Do("MGET", args...))
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) != len(group) {
+			return nil, errors.New("unexpected mget reply length")
+		}
+
+		for i := range group {
+			values[group[i]] = vals[i]
+		}
+	}
+
+	out := make([]interface{}, len(keys))
+	for i := range keys {
+		out[i] = values[keys[i]]
+	}
+
+	return out, nil
+}
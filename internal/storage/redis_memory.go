@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// RedisKeyClass defines a class of Redis keys.
+type RedisKeyClass string
+
+// Redis key classes.
+const (
+	RedisKeyClassSessions      RedisKeyClass = "sessions"
+	RedisKeyClassDeduplication RedisKeyClass = "deduplication"
+	RedisKeyClassFrameLog      RedisKeyClass = "framelog"
+	RedisKeyClassQueues        RedisKeyClass = "queues"
+	RedisKeyClassMetrics       RedisKeyClass = "metrics"
+	RedisKeyClassOther         RedisKeyClass = "other"
+)
+
+// RedisKeyClasses contains all the Redis key classes.
+var RedisKeyClasses = []RedisKeyClass{
+	RedisKeyClassSessions,
+	RedisKeyClassDeduplication,
+	RedisKeyClassFrameLog,
+	RedisKeyClassQueues,
+	RedisKeyClassMetrics,
+	RedisKeyClassOther,
+}
+
+const (
+	redisMemoryUsageKey       = "lora:ns:redis:memory"
+	redisMemoryScanPattern    = "lora:ns:*"
+	redisMemoryScanCount      = 1000
+	redisMemoryDefaultSamples = 100
+)
+
+// RedisKeyClassUsage contains the (estimated) memory usage of a key class.
+type RedisKeyClassUsage struct {
+	Class    RedisKeyClass `json:"class"`
+	Keys     int64         `json:"keys"`
+	Bytes    int64         `json:"bytes"`
+	CapBytes int64         `json:"capBytes"`
+}
+
+// RedisMemoryUsage contains a sample of the Redis memory usage.
+type RedisMemoryUsage struct {
+	SampledAt  time.Time            `json:"sampledAt"`
+	UsedMemory int64                `json:"usedMemory"`
+	Classes    []RedisKeyClassUsage `json:"classes"`
+}
+
+// ClassifyRedisKey returns the key class of the given Redis key.
+func ClassifyRedisKey(key string) RedisKeyClass {
+	switch {
+	case strings.HasPrefix(key, "lora:ns:rx:collect:"):
+		return RedisKeyClassDeduplication
+	case strings.HasPrefix(key, "lora:ns:framelog:"):
+		return RedisKeyClassFrameLog
+	case strings.HasPrefix(key, "lora:ns:metrics:"):
+		return RedisKeyClassMetrics
+	case strings.HasPrefix(key, "lora:ns:frames:"),
+		strings.HasPrefix(key, "lora:ns:mg:tx:"),
+		strings.HasPrefix(key, "lora:ns:txack:"),
+		strings.HasPrefix(key, "lora:ns:device:") && strings.Contains(key, ":mac:"):
+		// downlink frames, pending multicast tx, pending tx acknowledgements
+		// and mac-command queues
+		return RedisKeyClassQueues
+	case strings.HasPrefix(key, "lora:ns:device:"),
+		strings.HasPrefix(key, "lora:ns:devaddr:"):
+		// device-sessions, gateway rx-info sets and DevAddr lookup sets
+		return RedisKeyClassSessions
+	default:
+		return RedisKeyClassOther
+	}
+}
+
+// SampleRedisMemoryUsage returns the memory usage of the network-server
+// Redis keys, per key class. For each class, the memory usage of up to
+// samplesPerClass keys is retrieved (using MEMORY USAGE) and extrapolated
+// to the total number of keys of the class. The returned caps are not set.
+func SampleRedisMemoryUsage(ctx context.Context, p RedisClient, samplesPerClass int) (RedisMemoryUsage, error) {
+	if samplesPerClass < 1 {
+		samplesPerClass = redisMemoryDefaultSamples
+	}
+
+	out := RedisMemoryUsage{
+		SampledAt: clock.Now(),
+	}
+
+	// in case of Redis Cluster, each master node is sampled and the usage of
+	// the nodes is summed
+	usage := make(map[RedisKeyClass]RedisKeyClassUsage)
+	err := ForEachRedisNode(p, func(c redis.Conn) error {
+		nodeUsage, usedMemory, err := sampleRedisNodeMemoryUsage(c, samplesPerClass)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range nodeUsage {
+			cu := usage[u.Class]
+			cu.Keys += u.Keys
+			cu.Bytes += u.Bytes
+			usage[u.Class] = cu
+		}
+		out.UsedMemory += usedMemory
+
+		return nil
+	})
+	if err != nil {
+		return out, err
+	}
+
+	for _, class := range RedisKeyClasses {
+		u := usage[class]
+		u.Class = class
+		out.Classes = append(out.Classes, u)
+	}
+
+	log.WithFields(log.Fields{
+		"used_memory": out.UsedMemory,
+		"ctx_id":      ctx.Value(logging.ContextIDKey),
+	}).Debug("storage: redis memory usage sampled")
+
+	return out, nil
+}
+
+// sampleRedisNodeMemoryUsage returns the memory usage per key class and the
+// used memory of the Redis node of the given connection.
+func sampleRedisNodeMemoryUsage(c redis.Conn, samplesPerClass int) ([]RedisKeyClassUsage, int64, error) {
+	keys := make(map[RedisKeyClass]int64)
+	samples := make(map[RedisKeyClass][]string)
+
+	cursor := 0
+	for {
+		values, err := redis.Values(c.Do("SCAN", cursor, "MATCH", redisMemoryScanPattern, "COUNT", redisMemoryScanCount))
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "scan error")
+		}
+
+		var batch []string
+		if _, err := redis.Scan(values, &cursor, &batch); err != nil {
+			return nil, 0, errors.Wrap(err, "scan reply error")
+		}
+
+		for _, key := range batch {
+			class := ClassifyRedisKey(key)
+			keys[class]++
+			if len(samples[class]) < samplesPerClass {
+				samples[class] = append(samples[class], key)
+			}
+		}
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	var out []RedisKeyClassUsage
+	for _, class := range RedisKeyClasses {
+		usage := RedisKeyClassUsage{
+			Class: class,
+			Keys:  keys[class],
+		}
+
+		sampled, err := redisKeysMemoryUsage(c, samples[class])
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "get memory usage error")
+		}
+
+		if n := len(samples[class]); n != 0 {
+			usage.Bytes = sampled * usage.Keys / int64(n)
+		}
+
+		out = append(out, usage)
+	}
+
+	usedMemory, err := redisUsedMemory(c)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "get used memory error")
+	}
+
+	return out, usedMemory, nil
+}
+
+// redisKeysMemoryUsage returns the sum of the memory usage of the given keys.
+// Keys which have been removed since the scan are ignored.
+func redisKeysMemoryUsage(c redis.Conn, keys []string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	for _, key := range keys {
+		if err := c.Send("MEMORY", "USAGE", key); err != nil {
+			return 0, errors.Wrap(err, "send memory usage error")
+		}
+	}
+	if err := c.Flush(); err != nil {
+		return 0, errors.Wrap(err, "flush error")
+	}
+
+	var out int64
+	for range keys {
+		bytes, err := redis.Int64(c.Receive())
+		if err != nil {
+			if err == redis.ErrNil {
+				continue
+			}
+			return 0, errors.Wrap(err, "receive memory usage error")
+		}
+		out += bytes
+	}
+
+	return out, nil
+}
+
+// redisUsedMemory returns the used_memory value of the INFO memory section.
+func redisUsedMemory(c redis.Conn) (int64, error) {
+	info, err := redis.String(c.Do("INFO", "memory"))
+	if err != nil {
+		return 0, errors.Wrap(err, "info error")
+	}
+
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "used_memory:") {
+			continue
+		}
+
+		usedMemory, err := strconv.ParseInt(strings.TrimPrefix(line, "used_memory:"), 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "parse used_memory error")
+		}
+		return usedMemory, nil
+	}
+
+	return 0, errors.New("used_memory not found in info reply")
+}
+
+// SaveRedisMemoryUsage stores the given Redis memory usage sample.
+func SaveRedisMemoryUsage(ctx context.Context, p RedisClient, usage RedisMemoryUsage) error {
+	b, err := json.Marshal(usage)
+	if err != nil {
+		return errors.Wrap(err, "marshal redis memory usage error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("SET", redisMemoryUsageKey, b); err != nil {
+		return errors.Wrap(err, "set redis memory usage error")
+	}
+
+	return nil
+}
+
+// GetRedisMemoryUsage returns the last stored Redis memory usage sample. When
+// no sample has been stored yet, an empty sample is returned.
+func GetRedisMemoryUsage(ctx context.Context, p RedisClient) (RedisMemoryUsage, error) {
+	var usage RedisMemoryUsage
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", redisMemoryUsageKey))
+	if err != nil {
+		if err == redis.ErrNil {
+			return usage, nil
+		}
+		return usage, errors.Wrap(err, "get redis memory usage error")
+	}
+
+	if err := json.Unmarshal(b, &usage); err != nil {
+		return usage, errors.Wrap(err, "unmarshal redis memory usage error")
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyRedisKey(t *testing.T) {
+	tests := []struct {
+		Key      string
+		Expected RedisKeyClass
+	}{
+		{"lora:ns:device:{0102030405060708}", RedisKeyClassSessions},
+		{"lora:ns:device:{0102030405060708}:gwrx", RedisKeyClassSessions},
+		{"lora:ns:devaddr:01020304", RedisKeyClassSessions},
+		{"lora:ns:rx:collect:abcd", RedisKeyClassDeduplication},
+		{"lora:ns:rx:collect:abcd:lock", RedisKeyClassDeduplication},
+		{"lora:ns:framelog:capture:abcd:frames", RedisKeyClassFrameLog},
+		{"lora:ns:device:{0102030405060708}:mac:queue", RedisKeyClassQueues},
+		{"lora:ns:device:{0102030405060708}:mac:pending:3", RedisKeyClassQueues},
+		{"lora:ns:frames:{12345}", RedisKeyClassQueues},
+		{"lora:ns:mg:tx:12345", RedisKeyClassQueues},
+		{"lora:ns:txack:pending:gw:{0102030405060708}", RedisKeyClassQueues},
+		{"lora:ns:metrics:{gw:HOUR}:12345", RedisKeyClassMetrics},
+		{"lora:ns:dp:0102", RedisKeyClassOther},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Key, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tst.Expected, ClassifyRedisKey(tst.Key))
+		})
+	}
+}
+
+func (ts *StorageTestSuite) TestRedisMemoryUsage() {
+	assert := require.New(ts.T())
+
+	ts.T().Run("No sample", func(t *testing.T) {
+		assert := require.New(t)
+
+		usage, err := GetRedisMemoryUsage(context.Background(), ts.RedisPool())
+		assert.NoError(err)
+		assert.True(usage.SampledAt.IsZero())
+	})
+
+	c := ts.RedisPool().Get()
+	defer c.Close()
+
+	for _, key := range []string{"lora:ns:device:{0102030405060708}", "lora:ns:devaddr:01020304", "lora:ns:rx:collect:abcd"} {
+		_, err := c.Do("SET", key, "test")
+		assert.NoError(err)
+	}
+
+	usage, err := SampleRedisMemoryUsage(context.Background(), ts.RedisPool(), 1)
+	assert.NoError(err)
+	assert.NotEqual(0, usage.UsedMemory)
+	assert.Len(usage.Classes, len(RedisKeyClasses))
+
+	classes := make(map[RedisKeyClass]RedisKeyClassUsage)
+	for _, class := range usage.Classes {
+		classes[class.Class] = class
+	}
+	assert.EqualValues(2, classes[RedisKeyClassSessions].Keys)
+	assert.EqualValues(1, classes[RedisKeyClassDeduplication].Keys)
+	assert.EqualValues(0, classes[RedisKeyClassQueues].Keys)
+	assert.NotEqual(int64(0), classes[RedisKeyClassSessions].Bytes)
+	assert.Equal(int64(0), classes[RedisKeyClassQueues].Bytes)
+
+	ts.T().Run("Save and get", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(SaveRedisMemoryUsage(context.Background(), ts.RedisPool(), usage))
+		stored, err := GetRedisMemoryUsage(context.Background(), ts.RedisPool())
+		assert.NoError(err)
+		assert.True(usage.SampledAt.Equal(stored.SampledAt))
+		assert.Equal(usage.Classes, stored.Classes)
+	})
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisCommandKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		args     []interface{}
+		expected string
+	}{
+		{"GET", "GET", []interface{}{"lora:ns:device:{0102030405060708}"}, "lora:ns:device:{0102030405060708}"},
+		{"byte slice key", "SADD", []interface{}{[]byte("lora:ns:devaddr:01020304"), []byte{1}}, "lora:ns:devaddr:01020304"},
+		{"lowercase", "psetex", []interface{}{"key", 1000, "value"}, "key"},
+		{"MULTI", "MULTI", nil, ""},
+		{"EXEC", "EXEC", nil, ""},
+		{"SCAN", "SCAN", []interface{}{0, "MATCH", "lora:ns:*"}, ""},
+		{"no arguments", "DEL", nil, ""},
+		{"EVALSHA", "EVALSHA", []interface{}{"sha", 1, "lora:ns:leader", "id"}, "lora:ns:leader"},
+		{"EVAL without keys", "EVAL", []interface{}{"return 1", 0, "arg"}, ""},
+		{"MEMORY USAGE", "MEMORY", []interface{}{"USAGE", "key"}, "key"},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			require.Equal(t, tst.expected, redisCommandKey(tst.command, tst.args))
+		})
+	}
+}
+
+func TestRedisURLDialOptions(t *testing.T) {
+	tests := []struct {
+		url     string
+		options int
+		err     bool
+	}{
+		{"redis://localhost:6379", 0, false},
+		{"redis://localhost:6379/1", 1, false},
+		{"redis://:secret@localhost:6379/1", 2, false},
+		{"redis://localhost:6379/db", 0, true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.url, func(t *testing.T) {
+			assert := require.New(t)
+
+			opts, err := redisURLDialOptions(tst.url)
+			if tst.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Len(opts, tst.options)
+		})
+	}
+}
+
+// roleConn implements redis.Conn, replying to the ROLE command with the
+// given role.
+type roleConn struct {
+	redis.Conn
+
+	role     string
+	commands []string
+}
+
+func (c *roleConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	c.commands = append(c.commands, commandName)
+	return []interface{}{[]byte(c.role)}, nil
+}
+
+func TestSentinelTestOnBorrow(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		idle     time.Duration
+		commands int
+		err      bool
+	}{
+		{"recently used", "slave", time.Second, 0, false},
+		{"idle primary", "master", 2 * onBorrowPingInterval, 1, false},
+		{"idle replica", "slave", 2 * onBorrowPingInterval, 1, true},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			assert := require.New(t)
+
+			c := roleConn{role: tst.role}
+			err := sentinelTestOnBorrow(&c, time.Now().Add(-tst.idle))
+			if tst.err {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Len(c.commands, tst.commands)
+		})
+	}
+}
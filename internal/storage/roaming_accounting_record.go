@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// RoamingRole defines the role of LoRa Server for a roamed frame.
+type RoamingRole string
+
+// Roaming roles.
+const (
+	// RoamingRoleFNS is the forwarding network-server role, the frame was
+	// received or transmitted by the gateways of this network.
+	RoamingRoleFNS RoamingRole = "fns"
+
+	// RoamingRoleSNS is the serving network-server role, the frame was
+	// received or transmitted by the gateways of the partner network.
+	RoamingRoleSNS RoamingRole = "sns"
+)
+
+// RoamingAccountingRecord defines the accounting record of a roamed uplink
+// or downlink frame, used for the settlement with the partner network.
+type RoamingAccountingRecord struct {
+	ID          int64               `db:"id" json:"id"`
+	CreatedAt   time.Time           `db:"created_at" json:"createdAt"`
+	Role        RoamingRole         `db:"role" json:"role"`
+	Direction   AccountingDirection `db:"direction" json:"direction"`
+	NetID       lorawan.NetID       `db:"net_id" json:"netID"`
+	DevAddr     lorawan.DevAddr     `db:"dev_addr" json:"devAddr"`
+	GatewayIDs  []lorawan.EUI64     `db:"-" json:"gatewayIDs"`
+	GatewayTime *time.Time          `db:"gateway_time" json:"gatewayTime"`
+	PayloadSize int                 `db:"payload_size" json:"payloadSize"`
+	Airtime     time.Duration       `db:"airtime" json:"airtime"`
+	Frequency   int                 `db:"frequency" json:"frequency"`
+	DR          int                 `db:"dr" json:"dr"`
+	RSSI        *int                `db:"rssi" json:"rssi"`
+	SNR         *float64            `db:"snr" json:"snr"`
+}
+
+// RoamingAccountingRecordFilter defines the filter for retrieving roaming
+// accounting records.
+type RoamingAccountingRecordFilter struct {
+	// NetID of the partner network (optional).
+	NetID *lorawan.NetID
+
+	// Start and End define the (created at) time range [start, end).
+	// Zero values are not filtered.
+	Start time.Time
+	End   time.Time
+
+	// AfterID only returns the records with an ID greater than the given ID,
+	// for paginating through the records.
+	AfterID int64
+
+	// Limit defines the max. number of records to return.
+	Limit int
+}
+
+// CreateRoamingAccountingRecord creates the given roaming accounting record.
+func CreateRoamingAccountingRecord(ctx context.Context, db sqlx.Queryer, r *RoamingAccountingRecord) error {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+
+	gatewayIDs := make(pq.ByteaArray, 0, len(r.GatewayIDs))
+	for i := range r.GatewayIDs {
+		gatewayIDs = append(gatewayIDs, r.GatewayIDs[i][:])
+	}
+
+	err := sqlx.Get(db, &r.ID, `
+		insert into roaming_accounting_record (
+			created_at,
+			role,
+			direction,
+			net_id,
+			dev_addr,
+			gateway_ids,
+			gateway_time,
+			payload_size,
+			airtime,
+			frequency,
+			dr,
+			rssi,
+			snr
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		returning id`,
+		r.CreatedAt,
+		r.Role,
+		r.Direction,
+		r.NetID[:],
+		r.DevAddr[:],
+		gatewayIDs,
+		r.GatewayTime,
+		r.PayloadSize,
+		r.Airtime,
+		r.Frequency,
+		r.DR,
+		r.RSSI,
+		r.SNR,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":        r.ID,
+		"role":      r.Role,
+		"direction": r.Direction,
+		"net_id":    r.NetID,
+		"dev_addr":  r.DevAddr,
+		"ctx_id":    ctx.Value(logging.ContextIDKey),
+	}).Debug("roaming accounting record created")
+
+	return nil
+}
+
+// GetRoamingAccountingRecords returns the roaming accounting records matching
+// the given filter, ordered by id.
+func GetRoamingAccountingRecords(ctx context.Context, db sqlx.Queryer, filter RoamingAccountingRecordFilter) ([]RoamingAccountingRecord, error) {
+	var netID []byte
+	if filter.NetID != nil {
+		netID = filter.NetID[:]
+	}
+
+	var start, end *time.Time
+	if !filter.Start.IsZero() {
+		start = &filter.Start
+	}
+	if !filter.End.IsZero() {
+		end = &filter.End
+	}
+
+	rows, err := db.Queryx(`
+		select
+			id,
+			created_at,
+			role,
+			direction,
+			net_id,
+			dev_addr,
+			gateway_ids,
+			gateway_time,
+			payload_size,
+			airtime,
+			frequency,
+			dr,
+			rssi,
+			snr
+		from
+			roaming_accounting_record
+		where
+			id > $1
+			and ($2::bytea is null or net_id = $2)
+			and ($3::timestamp with time zone is null or created_at >= $3)
+			and ($4::timestamp with time zone is null or created_at < $4)
+		order by
+			id
+		limit $5`,
+		filter.AfterID,
+		netID,
+		start,
+		end,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	defer rows.Close()
+
+	var out []RoamingAccountingRecord
+	for rows.Next() {
+		var r RoamingAccountingRecord
+		var netID, devAddr []byte
+		var gatewayIDs pq.ByteaArray
+
+		err := rows.Scan(
+			&r.ID,
+			&r.CreatedAt,
+			&r.Role,
+			&r.Direction,
+			&netID,
+			&devAddr,
+			&gatewayIDs,
+			&r.GatewayTime,
+			&r.PayloadSize,
+			&r.Airtime,
+			&r.Frequency,
+			&r.DR,
+			&r.RSSI,
+			&r.SNR,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan error")
+		}
+
+		copy(r.NetID[:], netID)
+		copy(r.DevAddr[:], devAddr)
+		for _, b := range gatewayIDs {
+			var id lorawan.EUI64
+			copy(id[:], b)
+			r.GatewayIDs = append(r.GatewayIDs, id)
+		}
+
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+
+	return out, nil
+}
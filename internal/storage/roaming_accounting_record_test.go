@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestRoamingAccountingRecord() {
+	assert := require.New(ts.T())
+	ctx := context.Background()
+
+	rssi := -50
+	snr := 5.5
+	gwTime := time.Now().Add(-time.Second).Round(time.Millisecond).UTC()
+
+	records := []RoamingAccountingRecord{
+		{
+			CreatedAt:   time.Now().Add(-time.Hour),
+			Role:        RoamingRoleFNS,
+			Direction:   AccountingUplink,
+			NetID:       lorawan.NetID{1, 2, 3},
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			GatewayIDs:  []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+			GatewayTime: &gwTime,
+			PayloadSize: 23,
+			Airtime:     61696 * time.Microsecond,
+			Frequency:   868100000,
+			DR:          5,
+			RSSI:        &rssi,
+			SNR:         &snr,
+		},
+		{
+			Role:        RoamingRoleFNS,
+			Direction:   AccountingDownlink,
+			NetID:       lorawan.NetID{1, 2, 3},
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			GatewayIDs:  []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+			PayloadSize: 12,
+			Airtime:     46336 * time.Microsecond,
+			Frequency:   868100000,
+			DR:          5,
+		},
+		{
+			Role:        RoamingRoleSNS,
+			Direction:   AccountingUplink,
+			NetID:       lorawan.NetID{3, 2, 1},
+			DevAddr:     lorawan.DevAddr{4, 3, 2, 1},
+			PayloadSize: 23,
+			Frequency:   868300000,
+			DR:          3,
+		},
+	}
+
+	for i := range records {
+		assert.NoError(CreateRoamingAccountingRecord(ctx, ts.Tx(), &records[i]))
+		assert.NotEqual(0, records[i].ID)
+	}
+
+	ts.T().Run("All", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := GetRoamingAccountingRecords(ctx, ts.Tx(), RoamingAccountingRecordFilter{Limit: 10})
+		assert.NoError(err)
+		assert.Len(out, 3)
+
+		for i := range out {
+			assert.Equal(records[i].ID, out[i].ID)
+			assert.True(out[i].CreatedAt.Round(time.Second).Equal(records[i].CreatedAt.Round(time.Second)))
+			assert.Equal(records[i].Role, out[i].Role)
+			assert.Equal(records[i].Direction, out[i].Direction)
+			assert.Equal(records[i].NetID, out[i].NetID)
+			assert.Equal(records[i].DevAddr, out[i].DevAddr)
+			assert.Equal(records[i].GatewayIDs, out[i].GatewayIDs)
+			assert.Equal(records[i].PayloadSize, out[i].PayloadSize)
+			assert.Equal(records[i].Airtime, out[i].Airtime)
+			assert.Equal(records[i].Frequency, out[i].Frequency)
+			assert.Equal(records[i].DR, out[i].DR)
+			assert.Equal(records[i].RSSI, out[i].RSSI)
+			assert.Equal(records[i].SNR, out[i].SNR)
+		}
+
+		assert.True(gwTime.Equal(*out[0].GatewayTime))
+		assert.Nil(out[1].GatewayTime)
+	})
+
+	ts.T().Run("NetID", func(t *testing.T) {
+		assert := require.New(t)
+
+		netID := lorawan.NetID{1, 2, 3}
+		out, err := GetRoamingAccountingRecords(ctx, ts.Tx(), RoamingAccountingRecordFilter{NetID: &netID, Limit: 10})
+		assert.NoError(err)
+		assert.Len(out, 2)
+	})
+
+	ts.T().Run("Time range and pagination", func(t *testing.T) {
+		assert := require.New(t)
+
+		out, err := GetRoamingAccountingRecords(ctx, ts.Tx(), RoamingAccountingRecordFilter{
+			Start: time.Now().Add(-time.Minute),
+			End:   time.Now().Add(time.Minute),
+			Limit: 1,
+		})
+		assert.NoError(err)
+		assert.Len(out, 1)
+		assert.Equal(records[1].ID, out[0].ID)
+
+		out, err = GetRoamingAccountingRecords(ctx, ts.Tx(), RoamingAccountingRecordFilter{
+			AfterID: out[0].ID,
+			Limit:   10,
+		})
+		assert.NoError(err)
+		assert.Len(out, 1)
+		assert.Equal(records[2].ID, out[0].ID)
+	})
+}
@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+)
+
+// RoamingAgreement defines the roaming agreement with a partner network,
+// identified by its NetID.
+type RoamingAgreement struct {
+	ID        uuid.UUID     `db:"roaming_agreement_id"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+	NetID     lorawan.NetID `db:"net_id"`
+
+	// Server defines the Backend Interfaces endpoint of the partner network.
+	Server string `db:"server"`
+
+	// Allowed roaming services.
+	PassiveRoaming  bool `db:"passive_roaming"`
+	HandoverRoaming bool `db:"handover_roaming"`
+
+	// PassiveRoamingLifetime defines the lifetime of a passive-roaming
+	// session.
+	PassiveRoamingLifetime time.Duration `db:"passive_roaming_lifetime"`
+
+	// PassiveRoamingStateless enables the stateless passive-roaming mode.
+	// In this mode no passive-roaming session is kept and each uplink is
+	// forwarded to the partner network using a PRStartReq, for partners
+	// that do not support stateful sessions.
+	PassiveRoamingStateless bool `db:"passive_roaming_stateless"`
+
+	// BillingReference is an opaque reference to the (commercial) agreement
+	// with the partner network.
+	BillingReference string `db:"billing_reference"`
+}
+
+// CreateRoamingAgreement creates the given roaming agreement.
+func CreateRoamingAgreement(ctx context.Context, db sqlx.Execer, ra *RoamingAgreement) error {
+	now := time.Now()
+	ra.CreatedAt = now
+	ra.UpdatedAt = now
+
+	if ra.ID == uuid.Nil {
+		var err error
+		ra.ID, err = uuid.NewV4()
+		if err != nil {
+			return errors.Wrap(err, "new uuid v4 error")
+		}
+	}
+
+	_, err := db.Exec(`
+		insert into roaming_agreement (
+			roaming_agreement_id,
+			created_at,
+			updated_at,
+			net_id,
+			server,
+			passive_roaming,
+			handover_roaming,
+			passive_roaming_lifetime,
+			passive_roaming_stateless,
+			billing_reference
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		ra.ID,
+		ra.CreatedAt,
+		ra.UpdatedAt,
+		ra.NetID[:],
+		ra.Server,
+		ra.PassiveRoaming,
+		ra.HandoverRoaming,
+		ra.PassiveRoamingLifetime,
+		ra.PassiveRoamingStateless,
+		ra.BillingReference,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":     ra.ID,
+		"net_id": ra.NetID,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("roaming agreement created")
+
+	return nil
+}
+
+// GetRoamingAgreement returns the roaming agreement matching the given ID.
+func GetRoamingAgreement(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (RoamingAgreement, error) {
+	return getRoamingAgreement(db, "roaming_agreement_id = $1", id)
+}
+
+// GetRoamingAgreementForNetID returns the roaming agreement for the given
+// NetID.
+func GetRoamingAgreementForNetID(ctx context.Context, db sqlx.Queryer, netID lorawan.NetID) (RoamingAgreement, error) {
+	return getRoamingAgreement(db, "net_id = $1", netID[:])
+}
+
+// GetRoamingAgreementCount returns the total number of roaming agreements.
+func GetRoamingAgreementCount(ctx context.Context, db sqlx.Queryer) (int, error) {
+	var count int
+	err := sqlx.Get(db, &count, `
+		select
+			count(*)
+		from roaming_agreement`,
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "select error")
+	}
+
+	return count, nil
+}
+
+// GetRoamingAgreements returns a slice of roaming agreements, ordered by
+// NetID.
+func GetRoamingAgreements(ctx context.Context, db sqlx.Queryer, limit, offset int) ([]RoamingAgreement, error) {
+	rows, err := db.Queryx(roamingAgreementSelect+`
+		order by net_id
+		limit $1
+		offset $2`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	defer rows.Close()
+
+	var out []RoamingAgreement
+	for rows.Next() {
+		ra, err := scanRoamingAgreement(rows)
+		if err != nil {
+			return nil, handlePSQLError(err, "scan error")
+		}
+		out = append(out, ra)
+	}
+
+	return out, nil
+}
+
+// GetPassiveRoamingAgreements returns all roaming agreements for which
+// passive roaming is allowed.
+func GetPassiveRoamingAgreements(ctx context.Context, db sqlx.Queryer) ([]RoamingAgreement, error) {
+	rows, err := db.Queryx(roamingAgreementSelect + `
+		where
+			passive_roaming = true
+		order by net_id`,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+	defer rows.Close()
+
+	var out []RoamingAgreement
+	for rows.Next() {
+		ra, err := scanRoamingAgreement(rows)
+		if err != nil {
+			return nil, handlePSQLError(err, "scan error")
+		}
+		out = append(out, ra)
+	}
+
+	return out, nil
+}
+
+// UpdateRoamingAgreement updates the given roaming agreement.
+func UpdateRoamingAgreement(ctx context.Context, db sqlx.Execer, ra *RoamingAgreement) error {
+	ra.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		update roaming_agreement
+		set
+			updated_at = $2,
+			net_id = $3,
+			server = $4,
+			passive_roaming = $5,
+			handover_roaming = $6,
+			passive_roaming_lifetime = $7,
+			passive_roaming_stateless = $8,
+			billing_reference = $9
+		where
+			roaming_agreement_id = $1`,
+		ra.ID,
+		ra.UpdatedAt,
+		ra.NetID[:],
+		ra.Server,
+		ra.PassiveRoaming,
+		ra.HandoverRoaming,
+		ra.PassiveRoamingLifetime,
+		ra.PassiveRoamingStateless,
+		ra.BillingReference,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if rowsAffected == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":     ra.ID,
+		"net_id": ra.NetID,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("roaming agreement updated")
+
+	return nil
+}
+
+// DeleteRoamingAgreement deletes the roaming agreement matching the given ID.
+func DeleteRoamingAgreement(ctx context.Context, db sqlx.Execer, id uuid.UUID) error {
+	res, err := db.Exec(`
+		delete from roaming_agreement
+		where
+			roaming_agreement_id = $1`,
+		id,
+	)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if rowsAffected == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":     id,
+		"ctx_id": ctx.Value(logging.ContextIDKey),
+	}).Info("roaming agreement deleted")
+
+	return nil
+}
+
+const roamingAgreementSelect = `
+	select
+		roaming_agreement_id,
+		created_at,
+		updated_at,
+		net_id,
+		server,
+		passive_roaming,
+		handover_roaming,
+		passive_roaming_lifetime,
+		passive_roaming_stateless,
+		billing_reference
+	from roaming_agreement`
+
+func getRoamingAgreement(db sqlx.Queryer, where string, arg interface{}) (RoamingAgreement, error) {
+	ra, err := scanRoamingAgreement(db.QueryRowx(roamingAgreementSelect+`
+		where
+			`+where,
+		arg,
+	))
+	if err != nil {
+		return ra, handlePSQLError(err, "select error")
+	}
+
+	return ra, nil
+}
+
+type roamingAgreementScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRoamingAgreement(row roamingAgreementScanner) (RoamingAgreement, error) {
+	var ra RoamingAgreement
+	var netID []byte
+
+	err := row.Scan(
+		&ra.ID,
+		&ra.CreatedAt,
+		&ra.UpdatedAt,
+		&netID,
+		&ra.Server,
+		&ra.PassiveRoaming,
+		&ra.HandoverRoaming,
+		&ra.PassiveRoamingLifetime,
+		&ra.PassiveRoamingStateless,
+		&ra.BillingReference,
+	)
+	if err != nil {
+		return ra, err
+	}
+
+	copy(ra.NetID[:], netID)
+
+	return ra, nil
+}
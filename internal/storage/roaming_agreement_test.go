@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+)
+
+func (ts *StorageTestSuite) TestRoamingAgreement() {
+	assert := require.New(ts.T())
+
+	ra := RoamingAgreement{
+		NetID:                  lorawan.NetID{1, 2, 3},
+		Server:                 "https://ns.example.com:8080",
+		PassiveRoaming:         true,
+		PassiveRoamingLifetime: time.Hour,
+		BillingReference:       "contract-123",
+	}
+	assert.NoError(CreateRoamingAgreement(context.Background(), ts.Tx(), &ra))
+
+	ra.CreatedAt = ra.CreatedAt.Round(time.Millisecond).UTC()
+	ra.UpdatedAt = ra.UpdatedAt.Round(time.Millisecond).UTC()
+
+	ts.T().Run("Get", func(t *testing.T) {
+		assert := require.New(t)
+
+		raGet, err := GetRoamingAgreement(context.Background(), ts.Tx(), ra.ID)
+		assert.NoError(err)
+		raGet.CreatedAt = raGet.CreatedAt.Round(time.Millisecond).UTC()
+		raGet.UpdatedAt = raGet.UpdatedAt.Round(time.Millisecond).UTC()
+		assert.Equal(ra, raGet)
+
+		raGet, err = GetRoamingAgreementForNetID(context.Background(), ts.Tx(), ra.NetID)
+		assert.NoError(err)
+		assert.Equal(ra.ID, raGet.ID)
+
+		_, err = GetRoamingAgreementForNetID(context.Background(), ts.Tx(), lorawan.NetID{3, 2, 1})
+		assert.Equal(ErrDoesNotExist, err)
+	})
+
+	ts.T().Run("List", func(t *testing.T) {
+		assert := require.New(t)
+
+		count, err := GetRoamingAgreementCount(context.Background(), ts.Tx())
+		assert.NoError(err)
+		assert.Equal(1, count)
+
+		items, err := GetRoamingAgreements(context.Background(), ts.Tx(), 10, 0)
+		assert.NoError(err)
+		assert.Len(items, 1)
+		assert.Equal(ra.ID, items[0].ID)
+
+		items, err = GetPassiveRoamingAgreements(context.Background(), ts.Tx())
+		assert.NoError(err)
+		assert.Len(items, 1)
+		assert.Equal(ra.ID, items[0].ID)
+	})
+
+	ts.T().Run("Update", func(t *testing.T) {
+		assert := require.New(t)
+
+		ra.Server = "https://ns2.example.com:8080"
+		ra.PassiveRoaming = false
+		ra.HandoverRoaming = true
+		ra.PassiveRoamingLifetime = 0
+		ra.PassiveRoamingStateless = true
+		ra.BillingReference = "contract-456"
+		assert.NoError(UpdateRoamingAgreement(context.Background(), ts.Tx(), &ra))
+
+		raGet, err := GetRoamingAgreement(context.Background(), ts.Tx(), ra.ID)
+		assert.NoError(err)
+		assert.Equal(ra.Server, raGet.Server)
+		assert.False(raGet.PassiveRoaming)
+		assert.True(raGet.HandoverRoaming)
+		assert.Equal(time.Duration(0), raGet.PassiveRoamingLifetime)
+		assert.True(raGet.PassiveRoamingStateless)
+		assert.Equal(ra.BillingReference, raGet.BillingReference)
+
+		raNotExist := RoamingAgreement{ID: uuid.Must(uuid.NewV4())}
+		assert.Equal(ErrDoesNotExist, UpdateRoamingAgreement(context.Background(), ts.Tx(), &raNotExist))
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeleteRoamingAgreement(context.Background(), ts.Tx(), ra.ID))
+		assert.Equal(ErrDoesNotExist, DeleteRoamingAgreement(context.Background(), ts.Tx(), ra.ID))
+
+		_, err := GetRoamingAgreement(context.Background(), ts.Tx(), ra.ID)
+		assert.Equal(ErrDoesNotExist, err)
+	})
+}
@@ -1,18 +1,29 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/mxc-foundation/lpwan-server/api/as"
+	"github.com/mxc-foundation/lpwan-server/internal/api/client/asclient"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
+// Templates used for generating Redis keys
+const (
+	RoutingProfileKeyTempl = "lora:ns:rp:%s"
+)
+
 // RoutingProfile defines the backend.RoutingProfile with some extra meta-data.
 type RoutingProfile struct {
 	ID        uuid.UUID `json:"RoutingProfileID" db:"routing_profile_id"`
@@ -22,10 +33,35 @@ type RoutingProfile struct {
 	CACert    string    `db:"ca_cert"`
 	TLSCert   string    `db:"tls_cert"`
 	TLSKey    string    `db:"tls_key"`
+
+	// FailoverASIDs contains the application-servers to use when ASID is
+	// unavailable (in the given order).
+	FailoverASIDs pq.StringArray `db:"failover_as_ids"`
+
+	// RoundRobin distributes the requests over all the application-servers.
+	RoundRobin bool `db:"round_robin"`
+
+	// DownlinkHookURL defines the hook which is invoked before a
+	// device-queue item is sent (optional).
+	DownlinkHookURL    string `db:"downlink_hook_url"`
+	DownlinkHookSecret string `db:"downlink_hook_secret"`
 }
 
 // GetApplicationServerClient returns the application-server client.
+// In case failover application-servers are configured, the returned client
+// will fail over to these (and optionally load-balance).
 func (rp RoutingProfile) GetApplicationServerClient() (as.ApplicationServerServiceClient, error) {
+	if len(rp.FailoverASIDs) != 0 {
+		return asclient.NewFailoverClient(
+			applicationserver.Pool(),
+			append([]string{rp.ASID}, rp.FailoverASIDs...),
+			[]byte(rp.CACert),
+			[]byte(rp.TLSCert),
+			[]byte(rp.TLSKey),
+			rp.RoundRobin,
+		), nil
+	}
+
 	asClient, err := applicationserver.Pool().Get(
 		rp.ASID,
 		[]byte(rp.CACert),
@@ -63,8 +99,12 @@ func CreateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 			as_id,
 			ca_cert,
 			tls_cert,
-			tls_key
-		) values ($1, $2, $3, $4, $5, $6, $7)`,
+			tls_key,
+			failover_as_ids,
+			round_robin,
+			downlink_hook_url,
+			downlink_hook_secret
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
 		rp.CreatedAt,
 		rp.UpdatedAt,
 		rp.ID,
@@ -72,6 +112,10 @@ func CreateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 		rp.CACert,
 		rp.TLSCert,
 		rp.TLSKey,
+		rp.FailoverASIDs,
+		rp.RoundRobin,
+		rp.DownlinkHookURL,
+		rp.DownlinkHookSecret,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -96,6 +140,100 @@ func GetRoutingProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Rout
 	return rp, nil
 }
 
+// CreateRoutingProfileCache caches the given routing-profile in Redis.
+// The TTL of the routing-profile is the same as that of the device-sessions.
+func CreateRoutingProfileCache(ctx context.Context, p RedisClient, rp RoutingProfile) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(rp); err != nil {
+		return errors.Wrap(err, "gob encode routing-profile error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(RoutingProfileKeyTempl, rp.ID)
+	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
+
+	_, err := c.Do("PSETEX", key, exp, buf.Bytes())
+	redisLocalCache.remove(key)
+	if err != nil {
+		return errors.Wrap(err, "set routing-profile error")
+	}
+
+	return nil
+}
+
+// GetRoutingProfileCache returns a cached routing-profile.
+func GetRoutingProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) (RoutingProfile, error) {
+	var rp RoutingProfile
+	key := fmt.Sprintf(RoutingProfileKeyTempl, id)
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := getCached(c, key)
+	if err != nil {
+		if err == redis.ErrNil {
+			return rp, ErrDoesNotExist
+		}
+		return rp, errors.Wrap(err, "get error")
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(val)).Decode(&rp)
+	if err != nil {
+		return rp, errors.Wrap(err, "gob decode error")
+	}
+
+	return rp, nil
+}
+
+// FlushRoutingProfileCache deletes a cached routing-profile.
+func FlushRoutingProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) error {
+	key := fmt.Sprintf(RoutingProfileKeyTempl, id)
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", key)
+	redisLocalCache.remove(key)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	return nil
+}
+
+// GetAndCacheRoutingProfile returns the routing-profile from cache in case
+// available, else it will be retrieved from the database and then stored
+// in cache.
+func GetAndCacheRoutingProfile(ctx context.Context, db sqlx.Queryer, p RedisClient, id uuid.UUID) (RoutingProfile, error) {
+	rp, err := GetRoutingProfileCache(ctx, p, id)
+	if err == nil {
+		return rp, nil
+	}
+
+	if err != ErrDoesNotExist {
+		log.WithFields(log.Fields{
+			"id":     id,
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).WithError(err).Error("get routing-profile cache error")
+		// we don't return as we can fall-back onto db retrieval
+	}
+
+	rp, err = GetRoutingProfile(ctx, db, id)
+	if err != nil {
+		return RoutingProfile{}, errors.Wrap(err, "get routing-profile error")
+	}
+
+	err = CreateRoutingProfileCache(ctx, p, rp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"id":     id,
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).WithError(err).Error("create routing-profile cache error")
+	}
+
+	return rp, nil
+}
+
 // UpdateRoutingProfile updates the given routing-profile.
 func UpdateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfile) error {
 	rp.UpdatedAt = time.Now()
@@ -105,7 +243,11 @@ func UpdateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 			as_id = $3,
 			ca_cert = $4,
 			tls_cert = $5,
-			tls_key = $6
+			tls_key = $6,
+			failover_as_ids = $7,
+			round_robin = $8,
+			downlink_hook_url = $9,
+			downlink_hook_secret = $10
 		where
 			routing_profile_id = $1`,
 		rp.ID,
@@ -114,6 +256,10 @@ func UpdateRoutingProfile(ctx context.Context, db sqlx.Execer, rp *RoutingProfil
 		rp.CACert,
 		rp.TLSCert,
 		rp.TLSKey,
+		rp.FailoverASIDs,
+		rp.RoundRobin,
+		rp.DownlinkHookURL,
+		rp.DownlinkHookSecret,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -56,6 +56,10 @@ func TestRoutingProfile(t *testing.T) {
 				rp.CACert = "CACERT2"
 				rp.TLSCert = "TLSCERT2"
 				rp.TLSKey = "TLSKEY2"
+				rp.FailoverASIDs = []string{"application-server-2:1234", "application-server-3:1234"}
+				rp.RoundRobin = true
+				rp.DownlinkHookURL = "https://hook.example.com/downlink"
+				rp.DownlinkHookSecret = "secret"
 				So(UpdateRoutingProfile(context.Background(), DB(), &rp), ShouldBeNil)
 				rp.UpdatedAt = rp.UpdatedAt.UTC().Truncate(time.Millisecond)
 
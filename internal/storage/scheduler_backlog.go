@@ -0,0 +1,485 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+)
+
+const (
+	txAckPendingKey           = "lora:ns:txack:pending"
+	txAckPendingGatewayKeyTpl = "lora:ns:txack:pending:gw:{%s}"
+	txAckPendingFramesKeyTpl  = "lora:ns:txack:pending:gw:{%s}:frames"
+	txAckTimeoutKey           = "lora:ns:txack:timeout"
+
+	// txAckPendingTTL defines the duration after which a downlink for which
+	// no TX acknowledgement was received is no longer considered pending.
+	txAckPendingTTL = time.Minute
+
+	// beaconPeriod defines the Class-B beacon period.
+	beaconPeriod = 128 * time.Second
+)
+
+// DeviceQueueBacklog contains the Class-B and Class-C device-queue backlog.
+type DeviceQueueBacklog struct {
+	ClassCItems   int
+	ClassCDevices int
+	ClassBPeriods []BeaconPeriodBacklog
+}
+
+// BeaconPeriodBacklog contains the number of Class-B device-queue items
+// scheduled within the beacon period starting at the given time since GPS
+// epoch.
+type BeaconPeriodBacklog struct {
+	TimeSinceGPSEpoch time.Duration
+	Items             int
+}
+
+// MulticastQueueBacklog contains the multicast-queue backlog.
+type MulticastQueueBacklog struct {
+	DueItems       int `db:"due_items"`
+	ScheduledItems int `db:"scheduled_items"`
+}
+
+// DownlinkTXPending contains a downlink (sent to a gateway) for which no TX
+// acknowledgement has been received yet.
+type DownlinkTXPending struct {
+	Token     uint16
+	CreatedAt time.Time
+	// DownlinkFrame contains the downlink-frame meta-data, the PHYPayload is
+	// not stored.
+	DownlinkFrame gw.DownlinkFrame
+}
+
+// GatewayDownlinkQueueDepth contains the number of downlinks pending a TX
+// acknowledgement of a gateway.
+type GatewayDownlinkQueueDepth struct {
+	GatewayID  lorawan.EUI64
+	QueueDepth int
+}
+
+// SetDownlinkTXAckPending marks the given downlink-frame as pending a TX
+// acknowledgement. The downlink-frame meta-data (excluding the PHYPayload)
+// is stored, so that it can be retrieved using GetDownlinkTXPending.
+func SetDownlinkTXAckPending(ctx context.Context, p RedisClient, frame gw.DownlinkFrame) error {
+	now := clock.Now()
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], frame.GetTxInfo().GetGatewayId())
+	token := uint16(frame.Token)
+
+	frame.PhyPayload = nil
+	b, err := proto.Marshal(&frame)
+	if err != nil {
+		return errors.Wrap(err, "marshal proto error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	gwKey := fmt.Sprintf(txAckPendingGatewayKeyTpl, gatewayID)
+	framesKey := fmt.Sprintf(txAckPendingFramesKeyTpl, gatewayID)
+	exp := int64(txAckPendingTTL / time.Millisecond)
+
+	c.Send("MULTI")
+	c.Send("ZADD", gwKey, now.UnixNano(), token)
+	c.Send("PEXPIRE", gwKey, exp)
+	c.Send("HSET", framesKey, token, b)
+	c.Send("PEXPIRE", framesKey, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	// the (global) pending key is not stored in the same Redis Cluster slot
+	// as the gateway keys
+	c.Send("MULTI")
+	c.Send("ZADD", txAckPendingKey, now.UnixNano(), fmt.Sprintf("%s:%d", gatewayID, token))
+	c.Send("PEXPIRE", txAckPendingKey, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// DeleteDownlinkTXAckPending removes the pending TX acknowledgement state
+// of the downlink with the given token, sent to the given gateway.
+func DeleteDownlinkTXAckPending(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64, token uint16) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZREM", fmt.Sprintf(txAckPendingGatewayKeyTpl, gatewayID), token)
+	c.Send("HDEL", fmt.Sprintf(txAckPendingFramesKeyTpl, gatewayID), token)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	if _, err := c.Do("ZREM", txAckPendingKey, fmt.Sprintf("%s:%d", gatewayID, token)); err != nil {
+		return errors.Wrap(err, "zrem error")
+	}
+
+	return nil
+}
+
+// GetDownlinkTXPending returns the downlinks sent to the given gateway which
+// are pending a TX acknowledgement, ordered by the time these were sent.
+// Expired items are removed, including their meta-data.
+func GetDownlinkTXPending(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64) ([]DownlinkTXPending, error) {
+	gwKey := fmt.Sprintf(txAckPendingGatewayKeyTpl, gatewayID)
+	framesKey := fmt.Sprintf(txAckPendingFramesKeyTpl, gatewayID)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZREMRANGEBYSCORE", gwKey, "-inf", clock.Now().Add(-txAckPendingTTL).UnixNano())
+	c.Send("ZRANGE", gwKey, 0, -1, "WITHSCORES")
+	c.Send("HKEYS", framesKey)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, errors.Wrap(err, "exec error")
+	}
+
+	pending, err := redis.Strings(values[1], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "zrange error")
+	}
+	fields, err := redis.Strings(values[2], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "hkeys error")
+	}
+
+	var out []DownlinkTXPending
+	tokens := make(map[string]struct{})
+	for i := 0; i < len(pending); i += 2 {
+		token, err := strconv.ParseUint(pending[i], 10, 16)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse token error")
+		}
+		// the score contains the time in nanoseconds, stored as float
+		score, err := strconv.ParseFloat(pending[i+1], 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse score error")
+		}
+
+		out = append(out, DownlinkTXPending{
+			Token:     uint16(token),
+			CreatedAt: time.Unix(0, int64(score)),
+		})
+		tokens[pending[i]] = struct{}{}
+	}
+
+	// remove the meta-data of the expired items
+	expired := []interface{}{framesKey}
+	for _, field := range fields {
+		if _, ok := tokens[field]; !ok {
+			expired = append(expired, field)
+		}
+	}
+	if len(expired) > 1 {
+		if _, err := c.Do("HDEL", expired...); err != nil {
+			return nil, errors.Wrap(err, "hdel error")
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{framesKey}
+	for _, item := range out {
+		args = append(args, item.Token)
+	}
+	frames, err := redis.ByteSlices(c.Do("HMGET", args...))
+	if err != nil {
+		return nil, errors.Wrap(err, "hmget error")
+	}
+
+	for i := range out {
+		// the meta-data is nil for items stored before it was added
+		if frames[i] == nil {
+			continue
+		}
+		if err := proto.Unmarshal(frames[i], &out[i].DownlinkFrame); err != nil {
+			return nil, errors.Wrap(err, "unmarshal proto error")
+		}
+	}
+
+	return out, nil
+}
+
+// GetGatewayDownlinkQueueDepths returns the number of downlinks pending a TX
+// acknowledgement for each gateway with pending downlinks, ordered by queue
+// depth (descending) and gateway ID.
+func GetGatewayDownlinkQueueDepths(ctx context.Context, p RedisClient) ([]GatewayDownlinkQueueDepth, error) {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZREMRANGEBYSCORE", txAckPendingKey, "-inf", clock.Now().Add(-txAckPendingTTL).UnixNano())
+	c.Send("ZRANGE", txAckPendingKey, 0, -1)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, errors.Wrap(err, "exec error")
+	}
+
+	members, err := redis.Strings(values[1], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "zrange error")
+	}
+
+	depths := make(map[lorawan.EUI64]int)
+	for _, member := range members {
+		parts := strings.SplitN(member, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid tx ack pending: %s", member)
+		}
+		var gatewayID lorawan.EUI64
+		if err := gatewayID.UnmarshalText([]byte(parts[0])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal gateway id error")
+		}
+		depths[gatewayID]++
+	}
+
+	var out []GatewayDownlinkQueueDepth
+	for gatewayID, depth := range depths {
+		out = append(out, GatewayDownlinkQueueDepth{
+			GatewayID:  gatewayID,
+			QueueDepth: depth,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].QueueDepth != out[j].QueueDepth {
+			return out[i].QueueDepth > out[j].QueueDepth
+		}
+		return bytes.Compare(out[i].GatewayID[:], out[j].GatewayID[:]) < 0
+	})
+
+	return out, nil
+}
+
+// DownlinkTXAckTimeout identifies a downlink (by gateway ID and token) for
+// which the TX acknowledgement timeout has expired.
+type DownlinkTXAckTimeout struct {
+	GatewayID lorawan.EUI64
+	Token     uint16
+}
+
+// SetDownlinkTXAckTimeout registers the downlink with the given token, sent
+// to the given gateway, so that it is returned by
+// GetExpiredDownlinkTXAckTimeouts when no TX acknowledgement has been
+// received before the given deadline.
+func SetDownlinkTXAckTimeout(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64, token uint16, deadline time.Time) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZADD", txAckTimeoutKey, deadline.UnixNano(), fmt.Sprintf("%s:%d", gatewayID, token))
+	c.Send("PEXPIRE", txAckTimeoutKey, int64(deadline.Add(txAckPendingTTL).Sub(clock.Now())/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "exec error")
+	}
+
+	return nil
+}
+
+// DeleteDownlinkTXAckTimeout removes the TX acknowledgement timeout of the
+// downlink with the given token, sent to the given gateway. It returns false
+// when the timeout was not registered or has already been removed (e.g. by
+// an other instance handling the expired timeout).
+func DeleteDownlinkTXAckTimeout(ctx context.Context, p RedisClient, gatewayID lorawan.EUI64, token uint16) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("ZREM", txAckTimeoutKey, fmt.Sprintf("%s:%d", gatewayID, token)))
+	if err != nil {
+		return false, errors.Wrap(err, "zrem error")
+	}
+
+	return n == 1, nil
+}
+
+// GetExpiredDownlinkTXAckTimeouts returns the downlinks of which the TX
+// acknowledgement timeout has expired. The timeouts are not removed, use
+// DeleteDownlinkTXAckTimeout to claim the handling of a timeout.
+func GetExpiredDownlinkTXAckTimeouts(ctx context.Context, p RedisClient) ([]DownlinkTXAckTimeout, error) {
+	c := p.Get()
+	defer c.Close()
+
+	members, err := redis.Strings(c.Do("ZRANGEBYSCORE", txAckTimeoutKey, "-inf", clock.Now().UnixNano()))
+	if err != nil {
+		return nil, errors.Wrap(err, "zrangebyscore error")
+	}
+
+	var out []DownlinkTXAckTimeout
+	for _, member := range members {
+		var t DownlinkTXAckTimeout
+		parts := strings.SplitN(member, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid tx ack timeout: %s", member)
+		}
+		if err := t.GatewayID.UnmarshalText([]byte(parts[0])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal gateway id error")
+		}
+		token, err := strconv.ParseUint(parts[1], 10, 16)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse token error")
+		}
+		t.Token = uint16(token)
+		out = append(out, t)
+	}
+
+	return out, nil
+}
+
+// GetDownlinkTXAckPendingCount returns the number of downlinks pending a TX
+// acknowledgement. When the gateway ID is nil, the count over all gateways
+// is returned.
+func GetDownlinkTXAckPendingCount(ctx context.Context, p RedisClient, gatewayID *lorawan.EUI64) (int, error) {
+	key := txAckPendingKey
+	if gatewayID != nil {
+		key = fmt.Sprintf(txAckPendingGatewayKeyTpl, gatewayID)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZREMRANGEBYSCORE", key, "-inf", clock.Now().Add(-txAckPendingTTL).UnixNano())
+	c.Send("ZCARD", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return 0, errors.Wrap(err, "exec error")
+	}
+
+	count, err := redis.Int(values[1], nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "zcard error")
+	}
+
+	return count, nil
+}
+
+// GetDeviceQueueBacklog returns the Class-B and Class-C device-queue backlog.
+// Pending items (awaiting a device acknowledgement) are not included. When
+// the filter function is not nil, only the items of the devices for which
+// it returns true are included.
+func GetDeviceQueueBacklog(ctx context.Context, db sqlx.Queryer, filter func(devEUI lorawan.EUI64) (bool, error)) (DeviceQueueBacklog, error) {
+	var out DeviceQueueBacklog
+	var rows []struct {
+		DevEUI       lorawan.EUI64 `db:"dev_eui"`
+		Mode         DeviceMode    `db:"mode"`
+		BeaconPeriod int64         `db:"beacon_period"`
+		Items        int           `db:"items"`
+	}
+
+	err := sqlx.Select(db, &rows, `
+		select
+			d.dev_eui,
+			d.mode,
+			coalesce(dq.emit_at_time_since_gps_epoch / $1, 0) as beacon_period,
+			count(*) as items
+		from
+			device d
+		inner join device_queue dq
+			on dq.dev_eui = d.dev_eui
+		where
+			d.mode in ('B', 'C')
+			and dq.is_pending = false
+		group by
+			d.dev_eui,
+			d.mode,
+			beacon_period
+		order by
+			d.dev_eui`,
+		int64(beaconPeriod),
+	)
+	if err != nil {
+		return out, handlePSQLError(err, "select error")
+	}
+
+	periods := make(map[int64]int)
+	included := make(map[lorawan.EUI64]bool)
+
+	for _, row := range rows {
+		ok, found := included[row.DevEUI]
+		if !found {
+			ok = true
+			if filter != nil {
+				if ok, err = filter(row.DevEUI); err != nil {
+					return out, errors.Wrap(err, "filter error")
+				}
+			}
+			included[row.DevEUI] = ok
+
+			if ok && row.Mode == DeviceModeC {
+				out.ClassCDevices++
+			}
+		}
+
+		if !ok {
+			continue
+		}
+
+		switch row.Mode {
+		case DeviceModeB:
+			periods[row.BeaconPeriod] += row.Items
+		case DeviceModeC:
+			out.ClassCItems += row.Items
+		}
+	}
+
+	for period, items := range periods {
+		out.ClassBPeriods = append(out.ClassBPeriods, BeaconPeriodBacklog{
+			TimeSinceGPSEpoch: time.Duration(period) * beaconPeriod,
+			Items:             items,
+		})
+	}
+	sort.Slice(out.ClassBPeriods, func(i, j int) bool {
+		return out.ClassBPeriods[i].TimeSinceGPSEpoch < out.ClassBPeriods[j].TimeSinceGPSEpoch
+	})
+
+	return out, nil
+}
+
+// GetMulticastQueueBacklog returns the multicast-queue backlog. Due items
+// are items of which the schedule timestamp has passed. When the gateway ID
+// is not nil, only the items for the given gateway are included.
+func GetMulticastQueueBacklog(ctx context.Context, db sqlx.Queryer, gatewayID *lorawan.EUI64) (MulticastQueueBacklog, error) {
+	var out MulticastQueueBacklog
+	var gwID []byte
+	if gatewayID != nil {
+		gwID = gatewayID[:]
+	}
+
+	err := sqlx.Get(db, &out, `
+		select
+			count(*) filter (where schedule_at <= $1) as due_items,
+			count(*) filter (where schedule_at > $1) as scheduled_items
+		from
+			multicast_queue
+		where
+			$2::bytea is null or gateway_id = $2`,
+		clock.Now(),
+		gwID,
+	)
+	if err != nil {
+		return out, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
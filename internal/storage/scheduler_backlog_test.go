@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func (ts *StorageTestSuite) TestDeviceQueueBacklog() {
+	assert := require.New(ts.T())
+
+	sp := ServiceProfile{}
+	assert.NoError(CreateServiceProfile(context.Background(), ts.Tx(), &sp))
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+	dp := DeviceProfile{}
+	assert.NoError(CreateDeviceProfile(context.Background(), ts.Tx(), &dp))
+
+	devices := []Device{
+		{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Mode: DeviceModeA},
+		{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Mode: DeviceModeB},
+		{DevEUI: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, Mode: DeviceModeC},
+		{DevEUI: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}, Mode: DeviceModeC},
+	}
+	for i := range devices {
+		devices[i].ServiceProfileID = sp.ID
+		devices[i].RoutingProfileID = rp.ID
+		devices[i].DeviceProfileID = dp.ID
+		assert.NoError(CreateDevice(context.Background(), ts.Tx(), &devices[i]))
+	}
+
+	emitAt := []time.Duration{
+		10*beaconPeriod + time.Second,
+		10*beaconPeriod + 2*time.Second,
+		12*beaconPeriod + time.Second,
+	}
+	timeoutAfter := time.Now().Add(time.Minute)
+
+	items := []DeviceQueueItem{
+		{DevEUI: devices[0].DevEUI, FCnt: 1},
+		{DevEUI: devices[1].DevEUI, FCnt: 1, EmitAtTimeSinceGPSEpoch: &emitAt[0]},
+		{DevEUI: devices[1].DevEUI, FCnt: 2, EmitAtTimeSinceGPSEpoch: &emitAt[1]},
+		{DevEUI: devices[1].DevEUI, FCnt: 3, EmitAtTimeSinceGPSEpoch: &emitAt[2]},
+		{DevEUI: devices[2].DevEUI, FCnt: 1, IsPending: true, TimeoutAfter: &timeoutAfter},
+		{DevEUI: devices[2].DevEUI, FCnt: 2},
+		{DevEUI: devices[3].DevEUI, FCnt: 1},
+		{DevEUI: devices[3].DevEUI, FCnt: 2},
+	}
+	for i := range items {
+		items[i].FPort = 10
+		assert.NoError(CreateDeviceQueueItem(context.Background(), ts.Tx(), &items[i]))
+	}
+
+	ts.T().Run("All devices", func(t *testing.T) {
+		assert := require.New(t)
+
+		backlog, err := GetDeviceQueueBacklog(context.Background(), ts.Tx(), nil)
+		assert.NoError(err)
+		assert.Equal(DeviceQueueBacklog{
+			ClassCItems:   3,
+			ClassCDevices: 2,
+			ClassBPeriods: []BeaconPeriodBacklog{
+				{TimeSinceGPSEpoch: 10 * beaconPeriod, Items: 2},
+				{TimeSinceGPSEpoch: 12 * beaconPeriod, Items: 1},
+			},
+		}, backlog)
+	})
+
+	ts.T().Run("Filtered", func(t *testing.T) {
+		assert := require.New(t)
+
+		backlog, err := GetDeviceQueueBacklog(context.Background(), ts.Tx(), func(devEUI lorawan.EUI64) (bool, error) {
+			return devEUI == devices[3].DevEUI, nil
+		})
+		assert.NoError(err)
+		assert.Equal(DeviceQueueBacklog{
+			ClassCItems:   2,
+			ClassCDevices: 1,
+		}, backlog)
+	})
+}
+
+func (ts *StorageTestSuite) TestMulticastQueueBacklog() {
+	assert := require.New(ts.T())
+
+	mg := ts.GetMulticastGroup()
+	assert.NoError(CreateMulticastGroup(context.Background(), ts.Tx(), &mg))
+
+	rp := RoutingProfile{}
+	assert.NoError(CreateRoutingProfile(context.Background(), ts.Tx(), &rp))
+
+	gateways := []Gateway{
+		{GatewayID: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RoutingProfileID: rp.ID},
+		{GatewayID: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RoutingProfileID: rp.ID},
+	}
+	for i := range gateways {
+		assert.NoError(CreateGateway(context.Background(), ts.Tx(), &gateways[i]))
+	}
+
+	items := []MulticastQueueItem{
+		{GatewayID: gateways[0].GatewayID, FCnt: 1, ScheduleAt: time.Now().Add(-time.Second)},
+		{GatewayID: gateways[0].GatewayID, FCnt: 2, ScheduleAt: time.Now().Add(time.Minute)},
+		{GatewayID: gateways[1].GatewayID, FCnt: 1, ScheduleAt: time.Now().Add(-time.Second)},
+	}
+	for i := range items {
+		items[i].MulticastGroupID = mg.ID
+		items[i].FPort = 10
+		assert.NoError(CreateMulticastQueueItem(context.Background(), ts.Tx(), &items[i]))
+	}
+
+	ts.T().Run("All gateways", func(t *testing.T) {
+		assert := require.New(t)
+
+		backlog, err := GetMulticastQueueBacklog(context.Background(), ts.Tx(), nil)
+		assert.NoError(err)
+		assert.Equal(MulticastQueueBacklog{DueItems: 2, ScheduledItems: 1}, backlog)
+	})
+
+	ts.T().Run("Single gateway", func(t *testing.T) {
+		assert := require.New(t)
+
+		backlog, err := GetMulticastQueueBacklog(context.Background(), ts.Tx(), &gateways[1].GatewayID)
+		assert.NoError(err)
+		assert.Equal(MulticastQueueBacklog{DueItems: 1}, backlog)
+	})
+}
+
+func (ts *StorageTestSuite) TestDownlinkTXAckPending() {
+	assert := require.New(ts.T())
+
+	gatewayIDs := []lorawan.EUI64{
+		{1, 1, 1, 1, 1, 1, 1, 1},
+		{2, 2, 2, 2, 2, 2, 2, 2},
+	}
+
+	assert.NoError(SetDownlinkTXAckPending(context.Background(), ts.RedisPool(), gw.DownlinkFrame{
+		Token:      123,
+		PhyPayload: []byte{1, 2, 3},
+		TxInfo:     &gw.DownlinkTXInfo{GatewayId: gatewayIDs[0][:]},
+	}))
+	assert.NoError(SetDownlinkTXAckPending(context.Background(), ts.RedisPool(), gw.DownlinkFrame{
+		Token:  124,
+		TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayIDs[0][:]},
+	}))
+	assert.NoError(SetDownlinkTXAckPending(context.Background(), ts.RedisPool(), gw.DownlinkFrame{
+		Token:  123,
+		TxInfo: &gw.DownlinkTXInfo{GatewayId: gatewayIDs[1][:]},
+	}))
+
+	count, err := GetDownlinkTXAckPendingCount(context.Background(), ts.RedisPool(), nil)
+	assert.NoError(err)
+	assert.Equal(3, count)
+
+	count, err = GetDownlinkTXAckPendingCount(context.Background(), ts.RedisPool(), &gatewayIDs[0])
+	assert.NoError(err)
+	assert.Equal(2, count)
+
+	ts.T().Run("Get pending", func(t *testing.T) {
+		assert := require.New(t)
+
+		pending, err := GetDownlinkTXPending(context.Background(), ts.RedisPool(), gatewayIDs[0])
+		assert.NoError(err)
+		assert.Len(pending, 2)
+		assert.EqualValues(123, pending[0].Token)
+		assert.EqualValues(124, pending[1].Token)
+		assert.False(pending[0].CreatedAt.After(pending[1].CreatedAt))
+		assert.Equal(gatewayIDs[0][:], pending[0].DownlinkFrame.TxInfo.GatewayId)
+		assert.Nil(pending[0].DownlinkFrame.PhyPayload)
+
+		pending, err = GetDownlinkTXPending(context.Background(), ts.RedisPool(), lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3})
+		assert.NoError(err)
+		assert.Len(pending, 0)
+	})
+
+	ts.T().Run("Get queue depths", func(t *testing.T) {
+		assert := require.New(t)
+
+		depths, err := GetGatewayDownlinkQueueDepths(context.Background(), ts.RedisPool())
+		assert.NoError(err)
+		assert.Equal([]GatewayDownlinkQueueDepth{
+			{GatewayID: gatewayIDs[0], QueueDepth: 2},
+			{GatewayID: gatewayIDs[1], QueueDepth: 1},
+		}, depths)
+	})
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(DeleteDownlinkTXAckPending(context.Background(), ts.RedisPool(), gatewayIDs[0], 123))
+
+		count, err := GetDownlinkTXAckPendingCount(context.Background(), ts.RedisPool(), nil)
+		assert.NoError(err)
+		assert.Equal(2, count)
+
+		count, err = GetDownlinkTXAckPendingCount(context.Background(), ts.RedisPool(), &gatewayIDs[0])
+		assert.NoError(err)
+		assert.Equal(1, count)
+
+		count, err = GetDownlinkTXAckPendingCount(context.Background(), ts.RedisPool(), &gatewayIDs[1])
+		assert.NoError(err)
+		assert.Equal(1, count)
+
+		pending, err := GetDownlinkTXPending(context.Background(), ts.RedisPool(), gatewayIDs[0])
+		assert.NoError(err)
+		assert.Len(pending, 1)
+		assert.EqualValues(124, pending[0].Token)
+	})
+}
+
+func (ts *StorageTestSuite) TestDownlinkTXAckTimeout() {
+	assert := require.New(ts.T())
+
+	gatewayIDs := []lorawan.EUI64{
+		{1, 1, 1, 1, 1, 1, 1, 1},
+		{2, 2, 2, 2, 2, 2, 2, 2},
+	}
+
+	assert.NoError(SetDownlinkTXAckTimeout(context.Background(), ts.RedisPool(), gatewayIDs[0], 123, time.Now().Add(-time.Second)))
+	assert.NoError(SetDownlinkTXAckTimeout(context.Background(), ts.RedisPool(), gatewayIDs[1], 124, time.Now().Add(time.Minute)))
+
+	timeouts, err := GetExpiredDownlinkTXAckTimeouts(context.Background(), ts.RedisPool())
+	assert.NoError(err)
+	assert.Equal([]DownlinkTXAckTimeout{
+		{GatewayID: gatewayIDs[0], Token: 123},
+	}, timeouts)
+
+	ts.T().Run("Delete", func(t *testing.T) {
+		assert := require.New(t)
+
+		deleted, err := DeleteDownlinkTXAckTimeout(context.Background(), ts.RedisPool(), gatewayIDs[0], 123)
+		assert.NoError(err)
+		assert.True(deleted)
+
+		deleted, err = DeleteDownlinkTXAckTimeout(context.Background(), ts.RedisPool(), gatewayIDs[0], 123)
+		assert.NoError(err)
+		assert.False(deleted)
+
+		timeouts, err := GetExpiredDownlinkTXAckTimeouts(context.Background(), ts.RedisPool())
+		assert.NoError(err)
+		assert.Len(timeouts, 0)
+	})
+}
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/brocaar/lorawan"
 	"github.com/gofrs/uuid"
 	"github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -29,6 +32,40 @@ const (
 	Mark RatePolicy = "Mark"
 )
 
+// DeviceQueueOverflowPolicy defines the behavior when the device-queue of a
+// device reached its max. size.
+type DeviceQueueOverflowPolicy string
+
+// Available device-queue overflow policies.
+const (
+	// DeviceQueueOverflowReject rejects the new item. This is the default
+	// (empty) policy.
+	DeviceQueueOverflowReject DeviceQueueOverflowPolicy = "reject"
+
+	// DeviceQueueOverflowDropOldest removes the oldest (not pending) item
+	// from the queue to make room for the new item.
+	DeviceQueueOverflowDropOldest DeviceQueueOverflowPolicy = "drop_oldest"
+
+	// DeviceQueueOverflowDropLowestPriority removes the (oldest) item with the
+	// lowest priority from the queue to make room for the new item. When the
+	// new item has a lower priority than all queued items, it is rejected.
+	DeviceQueueOverflowDropLowestPriority DeviceQueueOverflowPolicy = "drop_lowest_priority"
+)
+
+// FingerprintSensitivity defines the sensitivity of the uplink fingerprint
+// check, used for detecting cloned devices.
+type FingerprintSensitivity string
+
+// Available fingerprint sensitivities.
+const (
+	// FingerprintSensitivityDisabled disables the fingerprint check. This is
+	// the default (empty) sensitivity.
+	FingerprintSensitivityDisabled FingerprintSensitivity = "disabled"
+	FingerprintSensitivityLow      FingerprintSensitivity = "low"
+	FingerprintSensitivityMedium   FingerprintSensitivity = "medium"
+	FingerprintSensitivityHigh     FingerprintSensitivity = "high"
+)
+
 // ServiceProfile defines the backend.ServiceProfile with some extra meta-data.
 type ServiceProfile struct {
 	CreatedAt              time.Time  `db:"created_at"`
@@ -53,6 +90,61 @@ type ServiceProfile struct {
 	NwkGeoLoc              bool       `db:"nwk_geo_loc"`
 	TargetPER              int        `db:"target_per"` // Example: 10 indicates 10%
 	MinGWDiversity         int        `db:"min_gw_diversity"`
+
+	WebhookURL    string         `db:"webhook_url"`
+	WebhookSecret string         `db:"webhook_secret"`
+	WebhookEvents pq.StringArray `db:"webhook_events"` // empty = all events
+
+	GatewayIsolation  bool          `db:"gateway_isolation"`
+	AllowedGatewayIDs pq.ByteaArray `db:"allowed_gateway_ids"`
+
+	ClockSyncEnabled      bool `db:"clock_sync_enabled"`
+	MulticastSetupEnabled bool `db:"multicast_setup_enabled"`
+
+	DeviceQueueMaxSize        int                       `db:"device_queue_max_size"` // 0 = unlimited
+	DeviceQueueOverflowPolicy DeviceQueueOverflowPolicy `db:"device_queue_overflow_policy"`
+
+	FrameLogRedactPayload  bool `db:"frame_log_redact_payload"`
+	FrameLogTruncateDevEUI bool `db:"frame_log_truncate_dev_eui"`
+
+	DownlinkDisabled bool `db:"downlink_disabled"`
+	DLMaxTXPower     int  `db:"dl_max_tx_power"` // Unit: dBm, 0 = no cap
+
+	UplinkFingerprintSensitivity FingerprintSensitivity `db:"uplink_fingerprint_sensitivity"`
+}
+
+// CapDownlinkTXPower returns the given downlink TX power, limited to the
+// max. downlink TX power of the service-profile (if set).
+func (sp ServiceProfile) CapDownlinkTXPower(txPower int) int {
+	if sp.DLMaxTXPower != 0 && txPower > sp.DLMaxTXPower {
+		return sp.DLMaxTXPower
+	}
+	return txPower
+}
+
+// IsGatewayAllowed returns true when the given gateway may be used for the
+// traffic of the devices using this service-profile.
+func (sp ServiceProfile) IsGatewayAllowed(gatewayID lorawan.EUI64) bool {
+	if !sp.GatewayIsolation {
+		return true
+	}
+
+	for _, id := range sp.AllowedGatewayIDs {
+		if bytes.Equal(id, gatewayID[:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookEndpoint returns the webhook endpoint of the service-profile.
+func (sp ServiceProfile) WebhookEndpoint() webhook.Endpoint {
+	return webhook.Endpoint{
+		URL:    sp.WebhookURL,
+		Secret: sp.WebhookSecret,
+		Events: sp.WebhookEvents,
+	}
 }
 
 // CreateServiceProfile creates the given service-profile.
@@ -67,6 +159,14 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 		}
 	}
 
+	if sp.DeviceQueueOverflowPolicy == "" {
+		sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowReject
+	}
+
+	if sp.UplinkFingerprintSensitivity == "" {
+		sp.UplinkFingerprintSensitivity = FingerprintSensitivityDisabled
+	}
+
 	sp.CreatedAt = now
 	sp.UpdatedAt = now
 
@@ -94,8 +194,22 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 			ra_allowed,
 			nwk_geo_loc,
 			target_per,
-			min_gw_diversity
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			min_gw_diversity,
+			webhook_url,
+			webhook_secret,
+			webhook_events,
+			gateway_isolation,
+			allowed_gateway_ids,
+			clock_sync_enabled,
+			multicast_setup_enabled,
+			device_queue_max_size,
+			device_queue_overflow_policy,
+			frame_log_redact_payload,
+			frame_log_truncate_dev_eui,
+			downlink_disabled,
+			dl_max_tx_power,
+			uplink_fingerprint_sensitivity
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36)`,
 		sp.CreatedAt,
 		sp.UpdatedAt,
 		sp.ID,
@@ -118,6 +232,20 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.WebhookURL,
+		sp.WebhookSecret,
+		sp.WebhookEvents,
+		sp.GatewayIsolation,
+		sp.AllowedGatewayIDs,
+		sp.ClockSyncEnabled,
+		sp.MulticastSetupEnabled,
+		sp.DeviceQueueMaxSize,
+		sp.DeviceQueueOverflowPolicy,
+		sp.FrameLogRedactPayload,
+		sp.FrameLogTruncateDevEUI,
+		sp.DownlinkDisabled,
+		sp.DLMaxTXPower,
+		sp.UplinkFingerprintSensitivity,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -136,7 +264,7 @@ func CreateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 // only want to store the service-profile of a roaming device for a finite
 // duration.
 // The TTL of the service-profile is the same as that of the device-sessions.
-func CreateServiceProfileCache(ctx context.Context, p *redis.Pool, sp ServiceProfile) error {
+func CreateServiceProfileCache(ctx context.Context, p RedisClient, sp ServiceProfile) error {
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(sp); err != nil {
 		return errors.Wrap(err, "gob encode service-profile error")
@@ -149,6 +277,7 @@ func CreateServiceProfileCache(ctx context.Context, p *redis.Pool, sp ServicePro
 	exp := int64(deviceSessionTTL) / int64(time.Millisecond)
 
 	_, err := c.Do("PSETEX", key, exp, buf.Bytes())
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "set service-profile error")
 	}
@@ -157,14 +286,14 @@ func CreateServiceProfileCache(ctx context.Context, p *redis.Pool, sp ServicePro
 }
 
 // GetServiceProfileCache returns a cached service-profile.
-func GetServiceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) (ServiceProfile, error) {
+func GetServiceProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) (ServiceProfile, error) {
 	var sp ServiceProfile
 	key := fmt.Sprintf(ServiceProfileKeyTempl, id)
 
 	c := p.Get()
 	defer c.Close()
 
-	val, err := redis.Bytes(c.Do("GET", key))
+	val, err := getCached(c, key)
 	if err != nil {
 		if err == redis.ErrNil {
 			return sp, ErrDoesNotExist
@@ -181,12 +310,13 @@ func GetServiceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) (S
 }
 
 // FlushServiceProfileCache deletes a cached service-profile.
-func FlushServiceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID) error {
+func FlushServiceProfileCache(ctx context.Context, p RedisClient, id uuid.UUID) error {
 	key := fmt.Sprintf(ServiceProfileKeyTempl, id)
 	c := p.Get()
 	defer c.Close()
 
 	_, err := c.Do("DEL", key)
+	redisLocalCache.remove(key)
 	if err != nil {
 		return errors.Wrap(err, "delete error")
 	}
@@ -196,7 +326,7 @@ func FlushServiceProfileCache(ctx context.Context, p *redis.Pool, id uuid.UUID)
 // GetAndCacheServiceProfile returns the service-profile from cache in case
 // available, else it will be retrieved from the database and then stored
 // in cache.
-func GetAndCacheServiceProfile(ctx context.Context, db sqlx.Queryer, p *redis.Pool, id uuid.UUID) (ServiceProfile, error) {
+func GetAndCacheServiceProfile(ctx context.Context, db sqlx.Queryer, p RedisClient, id uuid.UUID) (ServiceProfile, error) {
 	sp, err := GetServiceProfileCache(ctx, p, id)
 	if err == nil {
 		return sp, nil
@@ -239,6 +369,14 @@ func GetServiceProfile(ctx context.Context, db sqlx.Queryer, id uuid.UUID) (Serv
 
 // UpdateServiceProfile updates the given service-profile.
 func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfile) error {
+	if sp.DeviceQueueOverflowPolicy == "" {
+		sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowReject
+	}
+
+	if sp.UplinkFingerprintSensitivity == "" {
+		sp.UplinkFingerprintSensitivity = FingerprintSensitivityDisabled
+	}
+
 	sp.UpdatedAt = time.Now()
 
 	res, err := db.Exec(`
@@ -263,7 +401,21 @@ func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 			ra_allowed = $18,
 			nwk_geo_loc = $19,
 			target_per = $20,
-			min_gw_diversity = $21
+			min_gw_diversity = $21,
+			webhook_url = $22,
+			webhook_secret = $23,
+			webhook_events = $24,
+			gateway_isolation = $25,
+			allowed_gateway_ids = $26,
+			clock_sync_enabled = $27,
+			multicast_setup_enabled = $28,
+			device_queue_max_size = $29,
+			device_queue_overflow_policy = $30,
+			frame_log_redact_payload = $31,
+			frame_log_truncate_dev_eui = $32,
+			downlink_disabled = $33,
+			dl_max_tx_power = $34,
+			uplink_fingerprint_sensitivity = $35
 		where
 			service_profile_id = $1`,
 		sp.ID,
@@ -287,6 +439,20 @@ func UpdateServiceProfile(ctx context.Context, db sqlx.Execer, sp *ServiceProfil
 		sp.NwkGeoLoc,
 		sp.TargetPER,
 		sp.MinGWDiversity,
+		sp.WebhookURL,
+		sp.WebhookSecret,
+		sp.WebhookEvents,
+		sp.GatewayIsolation,
+		sp.AllowedGatewayIDs,
+		sp.ClockSyncEnabled,
+		sp.MulticastSetupEnabled,
+		sp.DeviceQueueMaxSize,
+		sp.DeviceQueueOverflowPolicy,
+		sp.FrameLogRedactPayload,
+		sp.FrameLogTruncateDevEUI,
+		sp.DownlinkDisabled,
+		sp.DLMaxTXPower,
+		sp.UplinkFingerprintSensitivity,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
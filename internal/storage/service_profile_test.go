@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
 
@@ -78,6 +80,17 @@ func TestServiceProfile(t *testing.T) {
 				sp.NwkGeoLoc = false
 				sp.TargetPER = 2
 				sp.MinGWDiversity = 9
+				sp.GatewayIsolation = true
+				sp.AllowedGatewayIDs = pq.ByteaArray{{1, 2, 3, 4, 5, 6, 7, 8}}
+				sp.ClockSyncEnabled = true
+				sp.MulticastSetupEnabled = true
+				sp.DeviceQueueMaxSize = 10
+				sp.DeviceQueueOverflowPolicy = DeviceQueueOverflowDropOldest
+				sp.FrameLogRedactPayload = true
+				sp.FrameLogTruncateDevEUI = true
+				sp.DownlinkDisabled = true
+				sp.DLMaxTXPower = 14
+				sp.UplinkFingerprintSensitivity = FingerprintSensitivityMedium
 
 				So(UpdateServiceProfile(context.Background(), DB(), &sp), ShouldBeNil)
 				sp.UpdatedAt = sp.UpdatedAt.UTC().Truncate(time.Millisecond)
@@ -90,6 +103,26 @@ func TestServiceProfile(t *testing.T) {
 				So(spGet, ShouldResemble, sp)
 			})
 
+			Convey("Then IsGatewayAllowed returns the expected value", func() {
+				gatewayID := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+				So(sp.IsGatewayAllowed(gatewayID), ShouldBeTrue)
+
+				sp.GatewayIsolation = true
+				So(sp.IsGatewayAllowed(gatewayID), ShouldBeFalse)
+
+				sp.AllowedGatewayIDs = pq.ByteaArray{gatewayID[:]}
+				So(sp.IsGatewayAllowed(gatewayID), ShouldBeTrue)
+				So(sp.IsGatewayAllowed(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}), ShouldBeFalse)
+			})
+
+			Convey("Then CapDownlinkTXPower returns the expected value", func() {
+				So(sp.CapDownlinkTXPower(27), ShouldEqual, 27)
+
+				sp.DLMaxTXPower = 14
+				So(sp.CapDownlinkTXPower(27), ShouldEqual, 14)
+				So(sp.CapDownlinkTXPower(10), ShouldEqual, 10)
+			})
+
 			Convey("Then DeleteServiceProfile deletes the service-profile", func() {
 				So(DeleteServiceProfile(context.Background(), DB(), sp.ID), ShouldBeNil)
 				So(DeleteServiceProfile(context.Background(), DB(), sp.ID), ShouldEqual, ErrDoesNotExist)
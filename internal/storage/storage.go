@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -21,40 +20,47 @@ var deviceSessionTTL time.Duration
 // scheduler runs.
 var schedulerInterval time.Duration
 
+// fCntResetThreshold holds the threshold below which an unexpected uplink
+// frame-counter is accepted as frame-counter reset (relaxed validation mode).
+var fCntResetThreshold uint32
+
 // Setup configures the storage backend.
 func Setup(c config.Config) error {
 	log.Info("storage: setting up storage module")
 
 	deviceSessionTTL = c.NetworkServer.DeviceSessionTTL
 	schedulerInterval = c.NetworkServer.Scheduler.SchedulerInterval
+	fCntResetThreshold = uint32(c.NetworkServer.NetworkSettings.FCntResetThreshold)
 
-	log.Info("storage: setting up Redis connection pool")
-	redisPool = &redis.Pool{
-		MaxIdle:     c.Redis.MaxIdle,
-		MaxActive:   c.Redis.MaxActive,
-		IdleTimeout: c.Redis.IdleTimeout,
-		Wait:        true,
-		Dial: func() (redis.Conn, error) {
-			c, err := redis.DialURL(c.Redis.URL,
+	if c.Redis.Cluster.Enabled {
+		log.WithField("nodes", c.Redis.Cluster.Nodes).Info("storage: setting up Redis Cluster client")
+		rc, err := newRedisCluster(c)
+		if err != nil {
+			return errors.Wrap(err, "storage: setup Redis Cluster client error")
+		}
+		redisPool = rc
+	} else if c.Redis.Sentinel.MasterName != "" {
+		log.WithFields(log.Fields{
+			"master_name": c.Redis.Sentinel.MasterName,
+			"addresses":   c.Redis.Sentinel.Addresses,
+		}).Info("storage: setting up Redis connection pool using Sentinel")
+		p, err := newRedisSentinelPool(c)
+		if err != nil {
+			return errors.Wrap(err, "storage: setup Redis Sentinel connection pool error")
+		}
+		redisPool = p
+	} else {
+		log.Info("storage: setting up Redis connection pool")
+		redisPool = newRedisPool(c, func() (redis.Conn, error) {
+			return redis.DialURL(c.Redis.URL,
 				redis.DialReadTimeout(redisDialReadTimeout),
 				redis.DialWriteTimeout(redisDialWriteTimeout),
 			)
-			if err != nil {
-				return nil, fmt.Errorf("redis connection error: %s", err)
-			}
-			return c, err
-		},
-		TestOnBorrow: func(c redis.Conn, t time.Time) error {
-			if time.Now().Sub(t) < onBorrowPingInterval {
-				return nil
-			}
-
-			_, err := c.Do("PING")
-			if err != nil {
-				return fmt.Errorf("ping redis error: %s", err)
-			}
-			return nil
-		},
+		})
+	}
+
+	if err := setupLocalCache(c); err != nil {
+		return err
 	}
 
 	log.Info("storage: connecting to PostgreSQL")
@@ -48,7 +48,7 @@ func (b *StorageTestSuite) DB() *DBLogger {
 	return DB()
 }
 
-func (b *StorageTestSuite) RedisPool() *redis.Pool {
+func (b *StorageTestSuite) RedisPool() RedisClient {
 	return RedisPool()
 }
 
@@ -12,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	context "golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/lorawan"
 	loraband "github.com/brocaar/lorawan/band"
@@ -89,8 +90,14 @@ func GetConfig() config.Config {
 	return c
 }
 
+// redisPool defines the interface of the Redis connection pool (or Redis
+// Cluster client).
+type redisPool interface {
+	Get() redis.Conn
+}
+
 // MustFlushRedis flushes the Redis storage.
-func MustFlushRedis(p *redis.Pool) {
+func MustFlushRedis(p redisPool) {
 	c := p.Get()
 	defer c.Close()
 	if _, err := c.Do("FLUSHALL"); err != nil {
@@ -99,7 +106,7 @@ func MustFlushRedis(p *redis.Pool) {
 }
 
 // MustPrefillRedisPool pre-fills the pool with count connections.
-func MustPrefillRedisPool(p *redis.Pool, count int) {
+func MustPrefillRedisPool(p redisPool, count int) {
 	conns := []redis.Conn{}
 
 	for i := 0; i < count; i++ {
@@ -133,6 +140,9 @@ type GatewayBackend struct {
 	GatewayConfigPacketChan chan gw.GatewayConfiguration
 	statsPacketChan         chan gw.GatewayStats
 	downlinkTXAckChan       chan gw.DownlinkTXAck
+	CommandExecRequestChan  chan gw.GatewayCommandExecRequest
+	commandExecResponseChan chan gw.GatewayCommandExecResponse
+	noiseFloorReportChan    chan gw.NoiseFloorReport
 }
 
 // NewGatewayBackend returns a new GatewayBackend.
@@ -142,6 +152,9 @@ func NewGatewayBackend() *GatewayBackend {
 		TXPacketChan:            make(chan gw.DownlinkFrame, 100),
 		GatewayConfigPacketChan: make(chan gw.GatewayConfiguration, 100),
 		downlinkTXAckChan:       make(chan gw.DownlinkTXAck, 100),
+		CommandExecRequestChan:  make(chan gw.GatewayCommandExecRequest, 100),
+		commandExecResponseChan: make(chan gw.GatewayCommandExecResponse, 100),
+		noiseFloorReportChan:    make(chan gw.NoiseFloorReport, 100),
 	}
 }
 
@@ -172,6 +185,22 @@ func (b *GatewayBackend) DownlinkTXAckChan() chan gw.DownlinkTXAck {
 	return b.downlinkTXAckChan
 }
 
+// SendGatewayCommandExecRequest method.
+func (b *GatewayBackend) SendGatewayCommandExecRequest(req gw.GatewayCommandExecRequest) error {
+	b.CommandExecRequestChan <- req
+	return nil
+}
+
+// GatewayCommandExecResponseChan method.
+func (b *GatewayBackend) GatewayCommandExecResponseChan() chan gw.GatewayCommandExecResponse {
+	return b.commandExecResponseChan
+}
+
+// NoiseFloorReportChan method.
+func (b *GatewayBackend) NoiseFloorReportChan() chan gw.NoiseFloorReport {
+	return b.noiseFloorReportChan
+}
+
 // Close method.
 func (b *GatewayBackend) Close() error {
 	if b.rxPacketChan != nil {
@@ -247,6 +276,17 @@ func (t *ApplicationClient) HandleUplinkData(ctx context.Context, in *as.HandleU
 	return &t.HandleDataUpResponse, nil
 }
 
+// HandleUplinkDataBatch method.
+func (t *ApplicationClient) HandleUplinkDataBatch(ctx context.Context, in *as.HandleUplinkDataBatchRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if t.HandleDataUpErr != nil {
+		return nil, t.HandleDataUpErr
+	}
+	for _, item := range in.Items {
+		t.HandleDataUpChan <- *item
+	}
+	return &t.HandleDataUpResponse, nil
+}
+
 // HandleProprietaryUplink method.
 func (t *ApplicationClient) HandleProprietaryUplink(ctx context.Context, in *as.HandleProprietaryUplinkRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	if t.HandleProprietaryUpErr != nil {
@@ -288,18 +328,25 @@ func (t *ApplicationClient) SetDeviceLocation(ctx context.Context, in *as.SetDev
 
 // NetworkControllerClient is a network-controller client for testing.
 type NetworkControllerClient struct {
-	HandleRXInfoChan           chan nc.HandleUplinkMetaDataRequest
-	HandleDataUpMACCommandChan chan nc.HandleUplinkMACCommandRequest
+	HandleRXInfoChan              chan nc.HandleUplinkMetaDataRequest
+	HandleDataUpMACCommandChan    chan nc.HandleUplinkMACCommandRequest
+	HandleDownlinkMACCommandsChan chan nc.HandleDownlinkMACCommandsRequest
 
 	HandleRXInfoResponse           empty.Empty
 	HandleDataUpMACCommandResponse empty.Empty
+
+	// HandleDownlinkMACCommandsFunc returns the response for the given
+	// downlink mac-command request. When it returns nil, the request is
+	// left unanswered. When it is not set, opening the stream fails.
+	HandleDownlinkMACCommandsFunc func(nc.HandleDownlinkMACCommandsRequest) *nc.HandleDownlinkMACCommandsResponse
 }
 
 // NewNetworkControllerClient returns a new NetworkControllerClient.
 func NewNetworkControllerClient() *NetworkControllerClient {
 	return &NetworkControllerClient{
-		HandleRXInfoChan:           make(chan nc.HandleUplinkMetaDataRequest, 100),
-		HandleDataUpMACCommandChan: make(chan nc.HandleUplinkMACCommandRequest, 100),
+		HandleRXInfoChan:              make(chan nc.HandleUplinkMetaDataRequest, 100),
+		HandleDataUpMACCommandChan:    make(chan nc.HandleUplinkMACCommandRequest, 100),
+		HandleDownlinkMACCommandsChan: make(chan nc.HandleDownlinkMACCommandsRequest, 100),
 	}
 }
 
@@ -315,6 +362,66 @@ func (t *NetworkControllerClient) HandleUplinkMACCommand(ctx context.Context, in
 	return &empty.Empty{}, nil
 }
 
+// HandleDownlinkMACCommands method.
+func (t *NetworkControllerClient) HandleDownlinkMACCommands(ctx context.Context, opts ...grpc.CallOption) (nc.NetworkControllerService_HandleDownlinkMACCommandsClient, error) {
+	if t.HandleDownlinkMACCommandsFunc == nil {
+		return nil, grpc.Errorf(codes.Unimplemented, "not implemented")
+	}
+
+	return &downlinkMACCommandsStream{
+		ctx:      ctx,
+		client:   t,
+		respChan: make(chan *nc.HandleDownlinkMACCommandsResponse, 100),
+	}, nil
+}
+
+// downlinkMACCommandsStream implements the client-side of the downlink
+// mac-command stream for testing. The embedded ClientStream is nil, only
+// the methods used by the controller package are implemented.
+type downlinkMACCommandsStream struct {
+	grpc.ClientStream
+
+	ctx      context.Context
+	client   *NetworkControllerClient
+	respChan chan *nc.HandleDownlinkMACCommandsResponse
+}
+
+// Send method.
+func (s *downlinkMACCommandsStream) Send(req *nc.HandleDownlinkMACCommandsRequest) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	s.client.HandleDownlinkMACCommandsChan <- *req
+
+	if resp := s.client.HandleDownlinkMACCommandsFunc(*req); resp != nil {
+		resp.Id = req.Id
+		s.respChan <- resp
+	}
+
+	return nil
+}
+
+// Recv method.
+func (s *downlinkMACCommandsStream) Recv() (*nc.HandleDownlinkMACCommandsResponse, error) {
+	select {
+	case resp := <-s.respChan:
+		return resp, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+// Context method.
+func (s *downlinkMACCommandsStream) Context() context.Context {
+	return s.ctx
+}
+
+// CloseSend method.
+func (s *downlinkMACCommandsStream) CloseSend() error {
+	return nil
+}
+
 // GeolocationClient is a geolocation client for testing.
 type GeolocationClient struct {
 	ResolveTDOAChan               chan geo.ResolveTDOARequest
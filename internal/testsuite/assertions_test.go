@@ -227,10 +227,19 @@ func AssertASHandleUplinkDataRequest(req as.HandleUplinkDataRequest) Assertion {
 	}
 }
 
-// AssertASHandleDownlinkACKRequest asserts the given ack request.
+// AssertASHandleDownlinkACKRequest asserts the given ack request. As the
+// delivery receipt timestamps depend on the time of the test run, these are
+// only asserted to be set for acknowledged frames.
 func AssertASHandleDownlinkACKRequest(req as.HandleDownlinkACKRequest) Assertion {
 	return func(assert *require.Assertions, ts *IntegrationTestSuite) {
 		r := <-ts.ASClient.HandleDownlinkACKChan
+		if r.Acknowledged {
+			assert.NotNil(r.EnqueuedAt)
+			assert.NotNil(r.AcknowledgedAt)
+			r.EnqueuedAt = nil
+			r.TransmittedAt = nil
+			r.AcknowledgedAt = nil
+		}
 		if !proto.Equal(&r, &req) {
 			assert.Equal(req, r)
 		}
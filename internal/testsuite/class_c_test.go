@@ -2,15 +2,23 @@ package testsuite
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/as"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/downlinkhook"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
@@ -249,6 +257,262 @@ func (ts *ClassCTestSuite) TestClassC() {
 	}
 }
 
+func (ts *ClassCTestSuite) TestDownlinkHook() {
+	assert := require.New(ts.T())
+
+	deviceGatewayRXInfoSet := storage.DeviceGatewayRXInfoSet{
+		DevEUI: ts.Device.DevEUI,
+		Items: []storage.DeviceGatewayRXInfo{
+			{GatewayID: lorawan.EUI64{1, 2, 1, 2, 1, 2, 1, 2}},
+		},
+	}
+	assert.NoError(storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), deviceGatewayRXInfoSet))
+
+	var hookStatusCode int
+	var hookResponse string
+	var hookRequest downlinkhook.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&hookRequest)
+		w.WriteHeader(hookStatusCode)
+		w.Write([]byte(hookResponse))
+	}))
+	defer server.Close()
+
+	ts.RoutingProfile.DownlinkHookURL = server.URL
+	assert.NoError(storage.UpdateRoutingProfile(context.Background(), storage.DB(), ts.RoutingProfile))
+	assert.NoError(storage.FlushRoutingProfileCache(context.Background(), storage.RedisPool(), ts.RoutingProfile.ID))
+
+	hookReturns := func(statusCode int, resp string) func(*DownlinkTest) error {
+		return func(*DownlinkTest) error {
+			hookStatusCode = statusCode
+			hookResponse = resp
+			return nil
+		}
+	}
+
+	assertFRMPayload := func(b []byte) Assertion {
+		return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+			downlinkFrame := <-ts.GWBackend.TXPacketChan
+
+			var phy lorawan.PHYPayload
+			assert.NoError(phy.UnmarshalBinary(downlinkFrame.PhyPayload))
+			macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+			assert.True(ok)
+			assert.Len(macPL.FRMPayload, 1)
+			assert.Equal(&lorawan.DataPayload{Bytes: b}, macPL.FRMPayload[0])
+		}
+	}
+
+	assertHookRequest := func(req downlinkhook.Request) Assertion {
+		return func(assert *require.Assertions, ts *IntegrationTestSuite) {
+			req.MaxPayloadSize = hookRequest.MaxPayloadSize
+			assert.Equal(req, hookRequest)
+		}
+	}
+
+	tests := []DownlinkTest{
+		{
+			Name:          "payload unmodified",
+			BeforeFunc:    hookReturns(http.StatusNoContent, ""),
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				assertHookRequest(downlinkhook.Request{
+					DevEUI:     ts.DeviceSession.DevEUI,
+					DevAddr:    ts.DeviceSession.DevAddr,
+					FCnt:       5,
+					FPort:      10,
+					FRMPayload: []byte{1, 2, 3},
+				}),
+				AssertNFCntDown(6),
+				assertFRMPayload([]byte{1, 2, 3}),
+			},
+		},
+		{
+			Name:          "payload modified",
+			BeforeFunc:    hookReturns(http.StatusOK, `{"frmPayload": "AwIBAA=="}`),
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				AssertNFCntDown(6),
+				assertFRMPayload([]byte{3, 2, 1, 0}),
+			},
+		},
+		{
+			Name:          "payload vetoed",
+			BeforeFunc:    hookReturns(http.StatusOK, `{"veto": true, "reason": "policy"}`),
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				AssertNFCntDown(5),
+				AssertNoDownlinkFrame,
+				AssertDeviceQueueItems(nil),
+				AssertASHandleErrorRequest(as.HandleErrorRequest{
+					DevEui: ts.DeviceSession.DevEUI[:],
+					Type:   as.ErrorType_DEVICE_QUEUE_ITEM_VETOED,
+					Error:  "vetoed by downlink hook: policy",
+					FCnt:   5,
+				}),
+			},
+		},
+		{
+			Name:          "hook error (fail closed)",
+			BeforeFunc:    hookReturns(http.StatusInternalServerError, ""),
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				AssertNFCntDown(5),
+				AssertNoDownlinkFrame,
+				AssertDeviceQueueItems([]storage.DeviceQueueItem{
+					{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+				}),
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertDownlinkTest(t, tst)
+		})
+	}
+}
+
+func (ts *ClassCTestSuite) TestGatewayDrain() {
+	assert := require.New(ts.T())
+
+	gatewayID := lorawan.EUI64{1, 2, 1, 2, 1, 2, 1, 2}
+
+	deviceGatewayRXInfoSet := storage.DeviceGatewayRXInfoSet{
+		DevEUI: ts.Device.DevEUI,
+		Items: []storage.DeviceGatewayRXInfo{
+			{GatewayID: gatewayID},
+		},
+	}
+	assert.NoError(storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), deviceGatewayRXInfoSet))
+
+	tests := []DownlinkTest{
+		{
+			Name: "gateway draining",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.StartGatewayDrain(context.Background(), storage.RedisPool(), gatewayID)
+			},
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				AssertNFCntDown(5),
+				AssertNoDownlinkFrame,
+				AssertDeviceQueueItems([]storage.DeviceQueueItem{
+					{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+				}),
+			},
+		},
+		{
+			Name: "gateway drain stopped",
+			BeforeFunc: func(*DownlinkTest) error {
+				return storage.StopGatewayDrain(context.Background(), storage.RedisPool(), gatewayID)
+			},
+			DeviceSession: *ts.DeviceSession,
+			DeviceQueueItems: []storage.DeviceQueueItem{
+				{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+			},
+			Assert: []Assertion{
+				AssertNFCntDown(6),
+				func(assert *require.Assertions, ts *IntegrationTestSuite) {
+					downlinkFrame := <-ts.GWBackend.TXPacketChan
+					assert.Equal(gatewayID[:], downlinkFrame.TxInfo.GatewayId)
+				},
+			},
+		},
+	}
+
+	for _, tst := range tests {
+		ts.T().Run(tst.Name, func(t *testing.T) {
+			ts.AssertDownlinkTest(t, tst)
+		})
+	}
+}
+
+// TestTXAckTimeoutRetry tests that a Class-C downlink, for which no TX
+// acknowledgement is received, is retried using the next-best gateway once
+// the TX acknowledgement timeout has expired.
+func (ts *ClassCTestSuite) TestTXAckTimeoutRetry() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.NetworkSettings.RX2DR = 5
+	conf.NetworkServer.Scheduler.TXAck.Timeout = 2 * time.Second
+	conf.NetworkServer.Scheduler.TXAck.RetryGateways = 1
+	assert.NoError(downlink.Setup(conf))
+
+	mock := clock.NewMock(time.Now())
+	clock.Set(mock)
+	defer clock.Set(nil)
+
+	gatewayIDs := []lorawan.EUI64{
+		{1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 2, 2, 2, 2, 2, 2, 2},
+	}
+
+	deviceGatewayRXInfoSet := storage.DeviceGatewayRXInfoSet{
+		DevEUI: ts.Device.DevEUI,
+		Items: []storage.DeviceGatewayRXInfo{
+			{GatewayID: gatewayIDs[0], LoRaSNR: 5},
+			{GatewayID: gatewayIDs[1], LoRaSNR: -5},
+		},
+	}
+	assert.NoError(storage.SaveDeviceGatewayRXInfoSet(context.Background(), storage.RedisPool(), deviceGatewayRXInfoSet))
+
+	var sent gw.DownlinkFrame
+	ts.AssertDownlinkTest(ts.T(), DownlinkTest{
+		DeviceSession: *ts.DeviceSession,
+		DeviceQueueItems: []storage.DeviceQueueItem{
+			{DevEUI: ts.DeviceSession.DevEUI, FPort: 10, FCnt: 5, FRMPayload: []byte{1, 2, 3}},
+		},
+		Assert: []Assertion{
+			func(assert *require.Assertions, ts *IntegrationTestSuite) {
+				sent = <-ts.GWBackend.TXPacketChan
+				assert.Equal(gatewayIDs[0][:], sent.TxInfo.GatewayId)
+			},
+		},
+	})
+
+	ts.T().Run("Timeout not expired", func(t *testing.T) {
+		assert := require.New(t)
+
+		mock.Add(time.Second)
+		assert.NoError(ack.HandleTXAckTimeouts(context.Background()))
+		AssertNoDownlinkFrame(assert, &ts.IntegrationTestSuite)
+	})
+
+	ts.T().Run("Timeout expired", func(t *testing.T) {
+		assert := require.New(t)
+
+		mock.Add(2 * time.Second)
+		assert.NoError(ack.HandleTXAckTimeouts(context.Background()))
+
+		retry := <-ts.GWBackend.TXPacketChan
+		assert.Equal(gatewayIDs[1][:], retry.TxInfo.GatewayId)
+		assert.Equal(sent.Token, retry.Token)
+		assert.Equal(sent.PhyPayload, retry.PhyPayload)
+
+		// the retried downlink has its own timeout, but no gateway is left
+		mock.Add(3 * time.Second)
+		assert.NoError(ack.HandleTXAckTimeouts(context.Background()))
+		AssertNoDownlinkFrame(assert, &ts.IntegrationTestSuite)
+	})
+}
+
 func TestClassC(t *testing.T) {
 	suite.Run(t, new(ClassCTestSuite))
 }
@@ -1,13 +1,19 @@
 package testsuite
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
 
 type DownlinkTXAckTestSuite struct {
@@ -110,6 +116,104 @@ func (ts *DownlinkTXAckTestSuite) TestDownlinkTXAck() {
 	}
 }
 
+func (ts *DownlinkTXAckTestSuite) TestTXAckTimeout() {
+	assert := require.New(ts.T())
+
+	conf := test.GetConfig()
+	conf.NetworkServer.Scheduler.TXAck.Timeout = time.Millisecond
+	assert.NoError(downlink.Setup(conf))
+	defer ts.initConfig()
+
+	test.MustFlushRedis(storage.RedisPool())
+
+	devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+	gatewayIDs := []lorawan.EUI64{
+		{8, 7, 6, 5, 4, 3, 2, 1},
+		{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+
+	var fPortOne uint8 = 1
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:    7,
+			},
+			FPort: &fPortOne,
+		},
+		MIC: lorawan.MIC{48, 94, 26, 239},
+	}
+	phyB, err := phy.MarshalBinary()
+	assert.NoError(err)
+
+	assert.NoError(storage.SaveDownlinkFrames(context.Background(), storage.RedisPool(), devEUI, []gw.DownlinkFrame{
+		{
+			Token: 12345,
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayIDs[1][:],
+			},
+			PhyPayload: phyB,
+		},
+		{
+			Token: 12345,
+			TxInfo: &gw.DownlinkTXInfo{
+				GatewayId: gatewayIDs[0][:],
+			},
+			PhyPayload: phyB,
+		},
+	}))
+	assert.NoError(ack.SetTXAckTimeout(context.Background(), gatewayIDs[0], 12345))
+
+	ts.T().Run("Not expired", func(t *testing.T) {
+		assert := require.New(t)
+
+		assert.NoError(storage.SetDownlinkTXAckTimeout(context.Background(), storage.RedisPool(), gatewayIDs[0], 12345, time.Now().Add(time.Minute)))
+		assert.NoError(ack.HandleTXAckTimeouts(context.Background()))
+		AssertNoDownlinkFrame(assert, &ts.IntegrationTestSuite)
+
+		assert.NoError(storage.SetDownlinkTXAckTimeout(context.Background(), storage.RedisPool(), gatewayIDs[0], 12345, time.Now()))
+	})
+
+	ts.T().Run("Expired", func(t *testing.T) {
+		assert := require.New(t)
+
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(ack.HandleTXAckTimeouts(context.Background()))
+		AssertDownlinkFrame(gw.DownlinkTXInfo{
+			GatewayId: gatewayIDs[1][:],
+		}, phy)(assert, &ts.IntegrationTestSuite)
+
+		t.Run("Late negative ack", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(ack.HandleDownlinkTXAck(context.Background(), gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: gatewayIDs[0][:],
+				Error:     "TOO_LATE",
+			}))
+			AssertNoDownlinkFrame(assert, &ts.IntegrationTestSuite)
+		})
+
+		t.Run("Negative ack retry", func(t *testing.T) {
+			assert := require.New(t)
+
+			assert.NoError(ack.HandleDownlinkTXAck(context.Background(), gw.DownlinkTXAck{
+				Token:     12345,
+				GatewayId: gatewayIDs[1][:],
+				Error:     "TOO_LATE",
+			}))
+			AssertDownlinkFrame(gw.DownlinkTXInfo{
+				GatewayId: gatewayIDs[0][:],
+			}, phy)(assert, &ts.IntegrationTestSuite)
+			AssertNoDownlinkFrameSaved(assert, &ts.IntegrationTestSuite)
+		})
+	})
+}
+
 func TestDownlinkTXAck(t *testing.T) {
 	suite.Run(t, new(DownlinkTXAckTestSuite))
 }
@@ -478,6 +478,7 @@ func (ts *IntegrationTestSuite) AssertOTAATest(t *testing.T, tst OTAATest) {
 
 	// create device-activations
 	assert.NoError(storage.DeleteDeviceActivationsForDevice(context.Background(), storage.DB(), ts.Device.DevEUI))
+	assert.NoError(storage.DeleteDeviceNoncesForDevice(context.Background(), storage.DB(), ts.Device.DevEUI))
 	for _, da := range tst.DeviceActivations {
 		assert.NoError(storage.CreateDeviceActivation(context.Background(), storage.DB(), &da))
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 	"github.com/mxc-foundation/lpwan-server/internal/uplink"
@@ -671,6 +672,9 @@ func (ts *OTAATestSuite) TestLW11() {
 						KEK:   "00000000000000000000000000000000",
 					},
 				}
+				if err := kek.Setup(conf); err != nil {
+					return err
+				}
 				return uplink.Setup(conf)
 			},
 			TXInfo:     txInfo,
@@ -1,10 +1,15 @@
 package testsuite
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/as"
@@ -12,6 +17,7 @@ import (
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/api/ns"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 )
@@ -117,6 +123,93 @@ func (ts *ProprietaryTestCase) TestDownlink() {
 	}
 }
 
+func (ts *ProprietaryTestCase) TestDownlinkGPSEpoch() {
+	emitAt := gps.Time(time.Now().Add(time.Minute)).TimeSinceGPSEpoch()
+
+	ts.T().Run("emit at gps time", func(t *testing.T) {
+		ts.AssertDownlinkProprietaryTest(t, DownlinkProprietaryTest{
+			SendProprietaryPayloadRequest: ns.SendProprietaryPayloadRequest{
+				MacPayload:            []byte{1, 2, 3, 4},
+				Mic:                   []byte{5, 6, 7, 8},
+				GatewayMacs:           [][]byte{{8, 7, 6, 5, 4, 3, 2, 1}, {1, 2, 3, 4, 5, 6, 7, 8}},
+				PolarizationInversion: false,
+				Frequency:             868100000,
+				Dr:                    5,
+				TimeSinceGpsEpoch:     ptypes.DurationProto(emitAt),
+			},
+
+			Assert: []Assertion{
+				AssertDownlinkFrame(gw.DownlinkTXInfo{
+					GatewayId:  []byte{8, 7, 6, 5, 4, 3, 2, 1},
+					Frequency:  868100000,
+					Power:      14,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       125,
+							SpreadingFactor: 7,
+							CodeRate:        "4/5",
+						},
+					},
+					Timing: gw.DownlinkTiming_GPS_EPOCH,
+					TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+						GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+							TimeSinceGpsEpoch: ptypes.DurationProto(emitAt),
+						},
+					},
+				}, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						Major: lorawan.LoRaWANR1,
+						MType: lorawan.Proprietary,
+					},
+					MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+					MIC:        lorawan.MIC{5, 6, 7, 8},
+				}),
+				AssertDownlinkFrame(gw.DownlinkTXInfo{
+					GatewayId:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					Frequency:  868100000,
+					Power:      14,
+					Modulation: common.Modulation_LORA,
+					ModulationInfo: &gw.DownlinkTXInfo_LoraModulationInfo{
+						LoraModulationInfo: &gw.LoRaModulationInfo{
+							Bandwidth:       125,
+							SpreadingFactor: 7,
+							CodeRate:        "4/5",
+						},
+					},
+					Timing: gw.DownlinkTiming_GPS_EPOCH,
+					TimingInfo: &gw.DownlinkTXInfo_GpsEpochTimingInfo{
+						GpsEpochTimingInfo: &gw.GPSEpochTimingInfo{
+							TimeSinceGpsEpoch: ptypes.DurationProto(emitAt),
+						},
+					},
+				}, lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						Major: lorawan.LoRaWANR1,
+						MType: lorawan.Proprietary,
+					},
+					MACPayload: &lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}},
+					MIC:        lorawan.MIC{5, 6, 7, 8},
+				}),
+			},
+		})
+	})
+
+	ts.T().Run("emit time in the past", func(t *testing.T) {
+		assert := require.New(t)
+
+		_, err := ts.NSAPI.SendProprietaryPayload(context.Background(), &ns.SendProprietaryPayloadRequest{
+			MacPayload:        []byte{1, 2, 3, 4},
+			Mic:               []byte{5, 6, 7, 8},
+			GatewayMacs:       [][]byte{{8, 7, 6, 5, 4, 3, 2, 1}},
+			Frequency:         868100000,
+			Dr:                5,
+			TimeSinceGpsEpoch: ptypes.DurationProto(gps.Time(time.Now().Add(-time.Second)).TimeSinceGPSEpoch()),
+		})
+		assert.Equal(codes.InvalidArgument, grpc.Code(err))
+	})
+}
+
 func (ts *ProprietaryTestCase) TestUplink() {
 	// the routing profile is needed as the ns will send the proprietary
 	// frame to all application-servers.
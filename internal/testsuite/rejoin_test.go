@@ -17,6 +17,7 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/test"
 	"github.com/mxc-foundation/lpwan-server/internal/uplink"
@@ -92,6 +93,7 @@ func (ts *RejoinTestSuite) SetupTest() {
 		},
 	}
 
+	assert.NoError(kek.Setup(conf))
 	assert.NoError(uplink.Setup(conf))
 	assert.NoError(downlink.Setup(conf))
 
@@ -1,9 +1,9 @@
 package tls
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
 	"io/ioutil"
 
 	"github.com/pkg/errors"
@@ -12,21 +12,21 @@ import (
 
 // GetTransportCredentials returns the gRPC transport credentials.
 func GetTransportCredentials(caCert, tlsCert, tlsKey string, verifyClientCert bool) (credentials.TransportCredentials, error) {
-	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+	cert, err := LoadX509KeyPair(tlsCert, tlsKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "load tls key-pair error")
 	}
 
 	var caCertPool *x509.CertPool
 	if caCert != "" {
-		rawCaCert, err := ioutil.ReadFile(caCert)
+		rawCaCert, err := ReadPEM(caCert)
 		if err != nil {
 			return nil, errors.Wrap(err, "load ca certificate error")
 		}
 
 		caCertPool = x509.NewCertPool()
 		if !caCertPool.AppendCertsFromPEM(rawCaCert) {
-			return nil, fmt.Errorf("append ca certificate error: %s", caCert)
+			return nil, errors.New("append ca certificate error")
 		}
 	}
 
@@ -43,3 +43,35 @@ func GetTransportCredentials(caCert, tlsCert, tlsKey string, verifyClientCert bo
 		RootCAs:      caCertPool,
 	}), nil
 }
+
+// IsPEM returns true when the given value contains PEM encoded data (instead
+// of a file path).
+func IsPEM(value string) bool {
+	return bytes.HasPrefix(bytes.TrimSpace([]byte(value)), []byte("-----BEGIN"))
+}
+
+// ReadPEM returns the PEM encoded data of the given value. The value is
+// either the PEM encoded data itself (e.g. when retrieved from a secret
+// store), or the path of the file containing it.
+func ReadPEM(value string) ([]byte, error) {
+	if IsPEM(value) {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
+
+// LoadX509KeyPair loads the key-pair from the given files or PEM encoded
+// values (see ReadPEM).
+func LoadX509KeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := ReadPEM(certFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "read certificate error")
+	}
+
+	keyPEM, err := ReadPEM(keyFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "read key error")
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
@@ -1,8 +1,10 @@
 package uplink
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"hash/fnv"
 	"sort"
 	"time"
 
@@ -11,19 +13,38 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
-// Templates used for generating Redis keys
+// Templates used for generating Redis keys. The PHYPayload is used as hash
+// tag, so that the keys of a frame are stored in the same Redis Cluster slot.
 const (
-	CollectKeyTempl     = "lora:ns:rx:collect:%s"
-	CollectLockKeyTempl = "lora:ns:rx:collect:%s:lock"
+	CollectKeyTempl     = "lora:ns:rx:collect:{%s}"
+	CollectLockKeyTempl = "lora:ns:rx:collect:{%s}:lock"
 )
 
+// Templates used for generating the sharded Redis keys. The shard is derived
+// from a hash of the PHYPayload, so that all receptions of a frame are
+// collected in the same set. The PHYPayload is still used as hash tag.
+const (
+	CollectShardKeyTempl     = "lora:ns:rx:collect:%d:{%s}"
+	CollectShardLockKeyTempl = "lora:ns:rx:collect:%d:{%s}:lock"
+)
+
+// collectKeyPattern matches all the de-duplication keys.
+const collectKeyPattern = "lora:ns:rx:collect:*"
+
+// collectShardTTLMargin defines how long a sharded set is kept after the
+// de-duplication delay. The set is only read once, by the instance holding
+// the lock, after the de-duplication delay.
+const collectShardTTLMargin = 100 * time.Millisecond
+
 // collectAndCallOnce collects the package, sleeps the configured duraction and
 // calls the callback only once with a slice of packets, sorted by signal
 // strength (strongest at index 0). This method exists since multiple gateways
@@ -32,9 +53,13 @@ const (
 // It is safe to collect the same packet received by the same gateway twice.
 // Since the underlying storage type is a set, the result will always be a
 // unique set per gateway MAC and packet MIC.
-func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(packet models.RXPacket) error) error {
-	b, err := proto.Marshal(&rxPacket)
-	if err != nil {
+func collectAndCallOnce(ctx context.Context, p storage.RedisClient, rxPacket gw.UplinkFrame, callback func(packet models.RXPacket) error) error {
+	// the buffer can be returned to the pool after the SADD as the command
+	// arguments are written to the connection buffer by Send
+	buf := helpers.GetProtoBuffer()
+	defer helpers.PutProtoBuffer(buf)
+
+	if err := buf.Marshal(&rxPacket); err != nil {
 		return errors.Wrap(err, "marshal uplink frame error")
 	}
 
@@ -46,41 +71,66 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(pa
 	// each packet.
 	// The text representation of the PHYPayload is used as key.
 	phyKey := hex.EncodeToString(rxPacket.PhyPayload)
-	key := fmt.Sprintf(CollectKeyTempl, phyKey)
-	lockKey := fmt.Sprintf(CollectLockKeyTempl, phyKey)
 
 	// this way we can set a really low DeduplicationDelay for testing, without
 	// the risk that the set already expired in redis on read
+	deduplicationDelay := getDeduplicationDelay()
 	deduplicationTTL := deduplicationDelay * 2
 	if deduplicationTTL < time.Millisecond*200 {
 		deduplicationTTL = time.Millisecond * 200
 	}
 
+	// while migrating from the legacy (un-sharded) keys, both the legacy and
+	// the sharded keys are written and locked so that instances using either
+	// layout see all the receptions and process the frame only once
+	var keys, lockKeys []string
+	var ttls []time.Duration
+	shards := deduplicationShards
+	if shards == 0 || deduplicationMigrateLegacyKeys {
+		keys = append(keys, fmt.Sprintf(CollectKeyTempl, phyKey))
+		lockKeys = append(lockKeys, fmt.Sprintf(CollectLockKeyTempl, phyKey))
+		ttls = append(ttls, deduplicationTTL)
+	}
+	if shards > 0 {
+		shard := collectShard(rxPacket.PhyPayload, shards)
+		keys = append(keys, fmt.Sprintf(CollectShardKeyTempl, shard, phyKey))
+		lockKeys = append(lockKeys, fmt.Sprintf(CollectShardLockKeyTempl, shard, phyKey))
+		ttls = append(ttls, deduplicationDelay+collectShardTTLMargin)
+	}
+
 	c.Send("MULTI")
-	c.Send("SADD", key, b)
-	c.Send("PEXPIRE", key, int64(deduplicationTTL)/int64(time.Millisecond))
-	_, err = c.Do("EXEC")
+	for i, key := range keys {
+		c.Send("SADD", key, buf.Bytes())
+		c.Send("PEXPIRE", key, int64(ttls[i])/int64(time.Millisecond))
+	}
+	_, err := c.Do("EXEC")
 	if err != nil {
 		return errors.Wrap(err, "add uplink frame to set error")
 	}
 
 	// acquire a lock on processing this packet
-	_, err = redis.String(c.Do("SET", lockKey, "lock", "PX", int64(deduplicationTTL)/int64(time.Millisecond), "NX"))
-	if err != nil {
-		if err == redis.ErrNil {
-			// the packet processing is already locked by an other process
-			// so there is nothing to do anymore :-)
-			return nil
+	for _, lockKey := range lockKeys {
+		_, err = redis.String(c.Do("SET", lockKey, "lock", "PX", int64(deduplicationTTL)/int64(time.Millisecond), "NX"))
+		if err != nil {
+			if err == redis.ErrNil {
+				// the packet processing is already locked by an other process
+				// so there is nothing to do anymore :-)
+				return nil
+			}
+			return errors.Wrap(err, "acquire deduplication lock error")
 		}
-		return errors.Wrap(err, "acquire deduplication lock error")
 	}
 
 	// wait the configured amount of time, more packets might be received
 	// from other gateways
-	time.Sleep(deduplicationDelay)
+	clock.Sleep(deduplicationDelay)
 
-	// collect all packets from the set
-	payloads, err := redis.ByteSlices(c.Do("SMEMBERS", key))
+	// collect all packets from the set(s), all keys share the same hash tag
+	args := make([]interface{}, len(keys))
+	for i := range keys {
+		args[i] = keys[i]
+	}
+	payloads, err := redis.ByteSlices(c.Do("SUNION", args...))
 	if err != nil {
 		return errors.Wrap(err, "get deduplication set members error")
 	}
@@ -88,11 +138,36 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(pa
 		return errors.New("zero items in collect set")
 	}
 
-	var out models.RXPacket
+	out, err := decodeCollectedFrames(ctx, payloads)
+	if err != nil {
+		return err
+	}
+
+	return callback(out)
+}
+
+// collectShard returns the de-duplication shard of the given PHYPayload.
+func collectShard(phyPayload []byte, shards int) int {
+	h := fnv.New32a()
+	h.Write(phyPayload)
+	return int(h.Sum32() % uint32(shards))
+}
+
+// decodeCollectedFrames decodes the collected uplink frames into a single
+// RXPacket, with the RXInfoSet sorted by signal strength. The frames are
+// decoded into a single slice to avoid an allocation per frame, the
+// PHYPayload is only decoded once. The data-rate is resolved using the band
+// of the region of the gateway which received the first frame.
+func decodeCollectedFrames(ctx context.Context, payloads [][]byte) (models.RXPacket, error) {
+	out := models.RXPacket{
+		RXInfoSet: make([]*gw.UplinkRXInfo, 0, len(payloads)),
+	}
+	uplinkFrames := make([]gw.UplinkFrame, len(payloads))
+
 	for i, b := range payloads {
-		var uplinkFrame gw.UplinkFrame
-		if err := proto.Unmarshal(b, &uplinkFrame); err != nil {
-			return errors.Wrap(err, "unmarshal uplink frame error")
+		uplinkFrame := &uplinkFrames[i]
+		if err := proto.Unmarshal(b, uplinkFrame); err != nil {
+			return out, errors.Wrap(err, "unmarshal uplink frame error")
 		}
 
 		if uplinkFrame.TxInfo == nil {
@@ -106,16 +181,14 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(pa
 		}
 
 		if i == 0 {
-			var phy lorawan.PHYPayload
-			if err := phy.UnmarshalBinary(uplinkFrame.PhyPayload); err != nil {
-				return errors.Wrap(err, "unmarshal phypayload error")
+			if err := out.PHYPayload.UnmarshalBinary(uplinkFrame.PhyPayload); err != nil {
+				return out, errors.Wrap(err, "unmarshal phypayload error")
 			}
 
-			out.PHYPayload = phy
-
-			dr, err := helpers.GetDataRateIndex(true, uplinkFrame.TxInfo, band.Band())
+			region := storage.GetGatewayRegion(ctx, storage.DB(), helpers.GetGatewayID(uplinkFrame.RxInfo))
+			dr, err := helpers.GetDataRateIndex(true, uplinkFrame.TxInfo, band.Get(region))
 			if err != nil {
-				return errors.Wrap(err, "get data-rate index error")
+				return out, errors.Wrap(err, "get data-rate index error")
 			}
 			out.DR = dr
 		}
@@ -125,5 +198,45 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.UplinkFrame, callback func(pa
 	}
 
 	sort.Sort(models.BySignalStrength(out.RXInfoSet))
-	return callback(out)
+	return out, nil
+}
+
+// SweepDeduplicationKeys deletes the de-duplication keys without expiration.
+// These keys are created with an expiration of twice the de-duplication
+// delay, keys without expiration (e.g. left behind by an interrupted
+// MULTI / EXEC) would otherwise never be removed. It returns the number of
+// deleted keys.
+func SweepDeduplicationKeys(ctx context.Context, p storage.RedisClient) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	keys, err := storage.ScanKeys(p, collectKeyPattern)
+	if err != nil {
+		return 0, errors.Wrap(err, "scan de-duplication keys error")
+	}
+
+	var deleted int
+	for _, key := range keys {
+		ttl, err := redis.Int64(c.Do("PTTL", key))
+		if err != nil {
+			return deleted, errors.Wrap(err, "get ttl error")
+		}
+
+		// -1 = no expiration, -2 = expired since the scan
+		if ttl != -1 {
+			continue
+		}
+
+		if _, err := c.Do("DEL", key); err != nil {
+			return deleted, errors.Wrap(err, "delete key error")
+		}
+		deleted++
+	}
+
+	log.WithFields(log.Fields{
+		"deleted": deleted,
+		"ctx_id":  ctx.Value(logging.ContextIDKey),
+	}).Debug("uplink: de-duplication keys swept")
+
+	return deleted, nil
 }
@@ -1,10 +1,15 @@
 package uplink
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -85,49 +90,298 @@ func (ts *CollectTestSuite) TestDeduplication() {
 		},
 	}
 
-	for _, tst := range testTable {
+	configs := []struct {
+		Name              string
+		Shards            int
+		MigrateLegacyKeys bool
+	}{
+		{"legacy keys", 0, false},
+		{"sharded keys", 4, false},
+		{"migrate legacy keys", 4, true},
+	}
+
+	for _, conf := range configs {
+		ts.T().Run(conf.Name, func(t *testing.T) {
+			deduplicationShards = conf.Shards
+			deduplicationMigrateLegacyKeys = conf.MigrateLegacyKeys
+			defer func() {
+				deduplicationShards = 0
+				deduplicationMigrateLegacyKeys = false
+			}()
+
+			for _, tst := range testTable {
+				t.Run(tst.Name, func(t *testing.T) {
+					assert := require.New(t)
+					test.MustFlushRedis(storage.RedisPool())
+
+					phyB, err := tst.PHYPayload.MarshalBinary()
+					assert.NoError(err)
+
+					var received int
+					var called int
+
+					cb := func(packet models.RXPacket) error {
+						called = called + 1
+						received = len(packet.RXInfoSet)
+
+						// all receptions are collected in a single set per
+						// key layout
+						phyKey := hex.EncodeToString(phyB)
+						shardKey := fmt.Sprintf(CollectShardKeyTempl, collectShard(phyB, 4), phyKey)
+						legacyKey := fmt.Sprintf(CollectKeyTempl, phyKey)
+						assert.Equal(conf.Shards > 0, ts.setCard(shardKey) == tst.Count)
+						assert.Equal(conf.Shards == 0 || conf.MigrateLegacyKeys, ts.setCard(legacyKey) == tst.Count)
+						return nil
+					}
+
+					var wg sync.WaitGroup
+					for i := range tst.Gateways {
+						g := tst.Gateways[i]
+
+						wg.Add(1)
+						packet := gw.UplinkFrame{
+							RxInfo: &gw.UplinkRXInfo{
+								GatewayId: g[:],
+							},
+							TxInfo:     &gw.UplinkTXInfo{},
+							PhyPayload: phyB,
+						}
+						assert.NoError(helpers.SetUplinkTXInfoDataRate(packet.TxInfo, 0, band.Band()))
+
+						go func(packet gw.UplinkFrame) {
+							assert.NoError(collectAndCallOnce(context.Background(), storage.RedisPool(), packet, cb))
+							wg.Done()
+						}(packet)
+					}
+					wg.Wait()
+
+					assert.Equal(1, called)
+					assert.Equal(tst.Count, received)
+				})
+			}
+		})
+	}
+}
+
+// TestMigrateLegacyKeys tests the interaction with instances using a single
+// key layout, while migrating between the legacy and the sharded keys.
+func (ts *CollectTestSuite) TestMigrateLegacyKeys() {
+	deduplicationShards = 4
+	deduplicationMigrateLegacyKeys = true
+	defer func() {
+		deduplicationShards = 0
+		deduplicationMigrateLegacyKeys = false
+	}()
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MIC:        [4]byte{4, 2, 3, 4},
+		MACPayload: &lorawan.MACPayload{},
+	}
+	phyB, err := phy.MarshalBinary()
+	ts.Require().NoError(err)
+	phyKey := hex.EncodeToString(phyB)
+
+	legacyKey := fmt.Sprintf(CollectKeyTempl, phyKey)
+	legacyLockKey := fmt.Sprintf(CollectLockKeyTempl, phyKey)
+	shardKey := fmt.Sprintf(CollectShardKeyTempl, collectShard(phyB, 4), phyKey)
+	shardLockKey := fmt.Sprintf(CollectShardLockKeyTempl, collectShard(phyB, 4), phyKey)
+
+	newFrame := func(gatewayID byte) gw.UplinkFrame {
+		frame := gw.UplinkFrame{
+			RxInfo: &gw.UplinkRXInfo{
+				GatewayId: []byte{gatewayID, 1, 1, 1, 1, 1, 1, 1},
+			},
+			TxInfo:     &gw.UplinkTXInfo{},
+			PhyPayload: phyB,
+		}
+		ts.Require().NoError(helpers.SetUplinkTXInfoDataRate(frame.TxInfo, 0, band.Band()))
+		return frame
+	}
+
+	tests := []struct {
+		Name     string
+		Key      string
+		LockKey  string
+		Called   bool
+		Received int
+	}{
+		{
+			Name:     "reception collected by a legacy instance",
+			Key:      legacyKey,
+			Called:   true,
+			Received: 2,
+		},
+		{
+			Name:     "reception collected by a sharded instance",
+			Key:      shardKey,
+			Called:   true,
+			Received: 2,
+		},
+		{
+			Name:    "locked by a legacy instance",
+			Key:     legacyKey,
+			LockKey: legacyLockKey,
+		},
+		{
+			Name:    "locked by a sharded instance",
+			Key:     shardKey,
+			LockKey: shardLockKey,
+		},
+	}
+
+	for _, tst := range tests {
 		ts.T().Run(tst.Name, func(t *testing.T) {
 			assert := require.New(t)
 			test.MustFlushRedis(storage.RedisPool())
 
-			var received int
-			var called int
+			// the reception (and lock) of the other instance
+			other := newFrame(1)
+			b, err := proto.Marshal(&other)
+			assert.NoError(err)
+
+			c := storage.RedisPool().Get()
+			_, err = c.Do("SADD", tst.Key, b)
+			assert.NoError(err)
+			if tst.LockKey != "" {
+				_, err = c.Do("SET", tst.LockKey, "lock", "PX", 1000)
+				assert.NoError(err)
+			}
+			c.Close()
 
-			cb := func(packet models.RXPacket) error {
-				called = called + 1
+			var called bool
+			var received int
+			assert.NoError(collectAndCallOnce(context.Background(), storage.RedisPool(), newFrame(2), func(packet models.RXPacket) error {
+				called = true
 				received = len(packet.RXInfoSet)
 				return nil
-			}
+			}))
 
-			var wg sync.WaitGroup
-			for i := range tst.Gateways {
-				g := tst.Gateways[i]
-				phyB, err := tst.PHYPayload.MarshalBinary()
-				assert.NoError(err)
+			assert.Equal(tst.Called, called)
+			assert.Equal(tst.Received, received)
 
-				wg.Add(1)
-				packet := gw.UplinkFrame{
-					RxInfo: &gw.UplinkRXInfo{
-						GatewayId: g[:],
-					},
-					TxInfo:     &gw.UplinkTXInfo{},
-					PhyPayload: phyB,
+			// the reception is visible to the instances using either layout
+			for _, key := range []string{legacyKey, shardKey} {
+				count := 1
+				if key == tst.Key {
+					count = 2
 				}
-				assert.NoError(helpers.SetUplinkTXInfoDataRate(packet.TxInfo, 0, band.Band()))
-
-				go func(packet gw.UplinkFrame) {
-					assert.NoError(collectAndCallOnce(storage.RedisPool(), packet, cb))
-					wg.Done()
-				}(packet)
+				assert.Equal(count, ts.setCard(key))
 			}
-			wg.Wait()
-
-			assert.Equal(1, called)
-			assert.Equal(tst.Count, received)
 		})
 	}
 }
 
+func (ts *CollectTestSuite) setCard(key string) int {
+	c := storage.RedisPool().Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("SCARD", key))
+	ts.Require().NoError(err)
+	return n
+}
+
+func TestCollectShard(t *testing.T) {
+	assert := require.New(t)
+
+	seen := make(map[int]bool)
+	for i := 0; i < 256; i++ {
+		phy := []byte{0x40, 1, 2, 3, 4, 0, byte(i), 0}
+		shard := collectShard(phy, 4)
+		assert.True(shard >= 0 && shard < 4)
+		assert.Equal(shard, collectShard(phy, 4))
+		seen[shard] = true
+	}
+
+	// the frames are spread over all the shards
+	assert.Len(seen, 4)
+}
+
 func TestCollect(t *testing.T) {
 	suite.Run(t, new(CollectTestSuite))
 }
+
+func TestDecodeCollectedFrames(t *testing.T) {
+	assert := require.New(t)
+	assert.NoError(band.Setup(test.GetConfig()))
+
+	payloads := testCollectedFrames(t, 3)
+
+	out, err := decodeCollectedFrames(context.Background(), payloads)
+	assert.NoError(err)
+	assert.Equal(lorawan.UnconfirmedDataUp, out.PHYPayload.MHDR.MType)
+	assert.Equal(0, out.DR)
+	assert.Len(out.RXInfoSet, 3)
+
+	// sorted by signal strength
+	assert.EqualValues(3, out.RXInfoSet[0].LoraSnr)
+	assert.EqualValues(1, out.RXInfoSet[2].LoraSnr)
+}
+
+func BenchmarkDecodeCollectedFrames(b *testing.B) {
+	if err := band.Setup(test.GetConfig()); err != nil {
+		b.Fatal(err)
+	}
+
+	payloads := testCollectedFrames(b, 3)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeCollectedFrames(context.Background(), payloads); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// testCollectedFrames returns the given number of marshaled uplink frames,
+// as stored in the de-duplication set.
+func testCollectedFrames(tb testing.TB, count int) [][]byte {
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MIC: [4]byte{1, 2, 3, 4},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:    10,
+			},
+		},
+	}
+	phyB, err := phy.MarshalBinary()
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var out [][]byte
+	for i := 0; i < count; i++ {
+		uf := gw.UplinkFrame{
+			PhyPayload: phyB,
+			TxInfo: &gw.UplinkTXInfo{
+				Frequency: 868100000,
+			},
+			RxInfo: &gw.UplinkRXInfo{
+				GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, byte(i)},
+				LoraSnr:   float64(i + 1),
+				Rssi:      -50,
+			},
+		}
+		if err := helpers.SetUplinkTXInfoDataRate(uf.TxInfo, 0, band.Band()); err != nil {
+			tb.Fatal(err)
+		}
+
+		b, err := proto.Marshal(&uf)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		out = append(out, b)
+	}
+
+	return out
+}
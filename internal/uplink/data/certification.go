@@ -0,0 +1,67 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/certification"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// handleCertification handles the LoRaWAN Certification Protocol when the
+// certification test mode is enabled for the device. The first uplink
+// activates the certification package of the device, the frames on the
+// certification FPort are never forwarded to the application-server.
+func handleCertification(ctx *dataContext) error {
+	if !ctx.DeviceSession.CertificationTestMode {
+		return nil
+	}
+
+	if ctx.MACPayload.FPort == nil || *ctx.MACPayload.FPort != certification.FPort || len(ctx.MACPayload.FRMPayload) != 1 {
+		if ctx.DeviceSession.CertificationActivated {
+			return nil
+		}
+
+		if err := certification.Activate(ctx.ctx, storage.DB(), &ctx.DeviceSession); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("certification: activate certification package error")
+		}
+		return nil
+	}
+
+	ctx.FRMPayloadHandled = true
+
+	dataPL, ok := ctx.MACPayload.FRMPayload[0].(*lorawan.DataPayload)
+	if !ok {
+		return fmt.Errorf("expected type *lorawan.DataPayload, got %T", ctx.MACPayload.FRMPayload[0])
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx.ctx, ctx.DeviceSession)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("certification: get AppSKey error")
+		return nil
+	}
+
+	b, err := lorawan.EncryptFRMPayload(appSKey, true, ctx.DeviceSession.DevAddr, ctx.MACPayload.FHDR.FCnt, dataPL.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "decrypt FRMPayload error")
+	}
+
+	if err := certification.HandleUplink(ctx.ctx, &ctx.DeviceSession, b); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("certification: handle answers error")
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package data
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
+	"github.com/mxc-foundation/lpwan-server/internal/gps"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// clockSyncFPort is the FPort of the LoRaWAN Application Layer Clock
+// Synchronization package.
+const clockSyncFPort = 202
+
+// Clock Synchronization package commands.
+const (
+	clockSyncPackageVersion = 0x00
+	clockSyncAppTime        = 0x01
+)
+
+// Clock Synchronization package identifier and version.
+const (
+	clockSyncPackageIdentifier = 1
+	clockSyncVersion           = 1
+)
+
+// appTimeReq defines the AppTimeReq payload.
+type appTimeReq struct {
+	DeviceTime  uint32
+	TokenReq    uint8
+	AnsRequired bool
+}
+
+// handleClockSync answers the requests of the Application Layer Clock
+// Synchronization package when this is enabled in the service-profile.
+// As the time-correction is based on the GPS time of the receiving gateway,
+// this avoids the latency of the round-trip through the application-server.
+// The frame is not forwarded to the application-server when handled.
+func handleClockSync(ctx *dataContext) error {
+	if !ctx.ServiceProfile.ClockSyncEnabled || ctx.MACPayload.FPort == nil || *ctx.MACPayload.FPort != clockSyncFPort || len(ctx.MACPayload.FRMPayload) != 1 {
+		return nil
+	}
+
+	dataPL, ok := ctx.MACPayload.FRMPayload[0].(*lorawan.DataPayload)
+	if !ok {
+		return fmt.Errorf("expected type *lorawan.DataPayload, got %T", ctx.MACPayload.FRMPayload[0])
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx.ctx, ctx.DeviceSession)
+	if err != nil {
+		// the AppSKey is not delegated to the network-server
+		if err != storage.ErrDoesNotExist {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("clock-sync: get AppSKey error")
+		}
+		return nil
+	}
+
+	b, err := lorawan.EncryptFRMPayload(appSKey, true, ctx.DeviceSession.DevAddr, ctx.MACPayload.FHDR.FCnt, dataPL.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "decrypt FRMPayload error")
+	}
+
+	// on error, the frame is forwarded to the application-server
+	ans, err := handleClockSyncCommands(b, getNetworkGPSTime(ctx.RXPacket.RXInfoSet))
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("clock-sync: handle commands error")
+		return nil
+	}
+
+	ctx.FRMPayloadHandled = true
+
+	if len(ans) == 0 {
+		return nil
+	}
+
+	fCnt, err := storage.GetNextDeviceQueueItemFCnt(ctx.ctx, storage.DB(), ctx.DeviceSession)
+	if err != nil {
+		return errors.Wrap(err, "get next device-queue item fcnt error")
+	}
+
+	frmPayload, err := lorawan.EncryptFRMPayload(appSKey, false, ctx.DeviceSession.DevAddr, fCnt, ans)
+	if err != nil {
+		return errors.Wrap(err, "encrypt FRMPayload error")
+	}
+
+	qi := storage.DeviceQueueItem{
+		DevAddr:    ctx.DeviceSession.DevAddr,
+		DevEUI:     ctx.DeviceSession.DevEUI,
+		FRMPayload: frmPayload,
+		FCnt:       fCnt,
+		FPort:      clockSyncFPort,
+	}
+	if err := storage.CreateDeviceQueueItem(ctx.ctx, storage.DB(), &qi); err != nil {
+		return errors.Wrap(err, "create device-queue item error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": ctx.DeviceSession.DevEUI,
+		"f_cnt":   fCnt,
+		"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+	}).Info("clock-sync: answer enqueued")
+
+	return nil
+}
+
+// handleClockSyncCommands handles the given (decrypted) Clock
+// Synchronization package commands and returns the answer commands. As the
+// length of an unknown command is not known, the handling stops at the
+// first unknown command.
+func handleClockSyncCommands(b []byte, networkTime time.Duration) ([]byte, error) {
+	var out []byte
+
+	for len(b) != 0 {
+		switch b[0] {
+		case clockSyncPackageVersion:
+			out = append(out, clockSyncPackageVersion, clockSyncPackageIdentifier, clockSyncVersion)
+			b = b[1:]
+		case clockSyncAppTime:
+			if len(b) < 6 {
+				return out, errors.New("AppTimeReq payload must be 5 bytes")
+			}
+
+			var req appTimeReq
+			req.DeviceTime = binary.LittleEndian.Uint32(b[1:5])
+			req.TokenReq = b[5] & 0x0f
+			req.AnsRequired = b[5]&0x10 != 0
+			b = b[6:]
+
+			if ans := getAppTimeAns(req, networkTime); ans != nil {
+				out = append(out, ans...)
+			}
+		default:
+			return out, fmt.Errorf("unknown command: %d", b[0])
+		}
+	}
+
+	return out, nil
+}
+
+// getAppTimeAns returns the AppTimeAns for the given AppTimeReq. It returns
+// nil when the device clock is in sync and no answer is required.
+func getAppTimeAns(req appTimeReq, networkTime time.Duration) []byte {
+	correction := int32(uint32(networkTime/time.Second) - req.DeviceTime)
+	if correction == 0 && !req.AnsRequired {
+		return nil
+	}
+
+	out := make([]byte, 6)
+	out[0] = clockSyncAppTime
+	binary.LittleEndian.PutUint32(out[1:5], uint32(correction))
+	out[5] = req.TokenReq
+
+	return out
+}
+
+// getNetworkGPSTime returns the time since GPS epoch at which the uplink
+// was received. It uses the GPS time of the receiving gateways when
+// available, else the current time.
+func getNetworkGPSTime(rxInfoSet []*gw.UplinkRXInfo) time.Duration {
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.TimeSinceGpsEpoch == nil {
+			continue
+		}
+
+		d, err := ptypes.Duration(rxInfo.TimeSinceGpsEpoch)
+		if err != nil {
+			continue
+		}
+		return d
+	}
+
+	return gps.Time(clock.Now()).TimeSinceGPSEpoch()
+}
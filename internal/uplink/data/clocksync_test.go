@@ -0,0 +1,73 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleClockSyncCommands(t *testing.T) {
+	networkTime := 1000 * time.Second
+
+	tests := []struct {
+		Name     string
+		Commands []byte
+		Expected []byte
+		Error    bool
+	}{
+		{
+			Name:     "package version",
+			Commands: []byte{0x00},
+			Expected: []byte{0x00, 0x01, 0x01},
+		},
+		{
+			Name:     "device clock behind",
+			Commands: []byte{0x01, 0xde, 0x03, 0x00, 0x00, 0x03},
+			Expected: []byte{0x01, 0x0a, 0x00, 0x00, 0x00, 0x03},
+		},
+		{
+			Name:     "device clock ahead",
+			Commands: []byte{0x01, 0xf2, 0x03, 0x00, 0x00, 0x05},
+			Expected: []byte{0x01, 0xf6, 0xff, 0xff, 0xff, 0x05},
+		},
+		{
+			Name:     "device clock in sync",
+			Commands: []byte{0x01, 0xe8, 0x03, 0x00, 0x00, 0x01},
+		},
+		{
+			Name:     "device clock in sync, answer required",
+			Commands: []byte{0x01, 0xe8, 0x03, 0x00, 0x00, 0x11},
+			Expected: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			Name:     "package version and device time",
+			Commands: []byte{0x00, 0x01, 0xde, 0x03, 0x00, 0x00, 0x03},
+			Expected: []byte{0x00, 0x01, 0x01, 0x01, 0x0a, 0x00, 0x00, 0x00, 0x03},
+		},
+		{
+			Name:     "invalid payload size",
+			Commands: []byte{0x01, 0xde, 0x03},
+			Error:    true,
+		},
+		{
+			Name:     "unknown command",
+			Commands: []byte{0x03},
+			Error:    true,
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.Name, func(t *testing.T) {
+			assert := require.New(t)
+
+			out, err := handleClockSyncCommands(tst.Commands, networkTime)
+			if tst.Error {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tst.Expected, out)
+		})
+	}
+}
@@ -14,18 +14,23 @@ import (
 	"github.com/mxc-foundation/lpwan-server/api/geo"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	"github.com/mxc-foundation/lpwan-server/api/nc"
-	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/accounting"
+	"github.com/mxc-foundation/lpwan-server/internal/attestation"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/controller"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/geolocationserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	datadown "github.com/mxc-foundation/lpwan-server/internal/downlink/data"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/data/classb"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/maccommand"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/security"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
@@ -36,9 +41,12 @@ var tasks = []func(*dataContext) error{
 	getDeviceSessionForPHYPayload,
 	decryptFOptsMACCommands,
 	decryptFRMPayloadMACCommands,
-	logUplinkFrame,
 	getDeviceProfile,
 	getServiceProfile,
+	sendFCntResetWebhook,
+	logUplinkFrame,
+	checkUplinkFingerprint,
+	filterRXInfoSetForServiceProfile,
 	getApplicationServerClientForDataUp,
 	resolveDeviceLocation,
 	setADR,
@@ -48,10 +56,16 @@ var tasks = []func(*dataContext) error{
 	handleFOptsMACCommands,
 	handleFRMPayloadMACCommands,
 	storeDeviceGatewayRXInfoSet,
+	setSubBands,
 	appendMetaDataToUplinkHistory,
+	handleClockSync,
+	handleMulticastSetup,
+	handleCertification,
+	signUplink,
 	sendFRMPayloadToApplicationServer,
 	syncUplinkFCnt,
 	saveDeviceSession,
+	createAccountingRecord,
 	handleUplinkACK,
 	handleDownlink,
 }
@@ -75,11 +89,14 @@ type dataContext struct {
 	RXPacket                models.RXPacket
 	MACPayload              *lorawan.MACPayload
 	DeviceSession           storage.DeviceSession
+	FCntReset               *storage.FCntReset
 	DeviceProfile           storage.DeviceProfile
 	ServiceProfile          storage.ServiceProfile
 	ApplicationServerClient as.ApplicationServerServiceClient
 	MACCommandResponses     []storage.MACCommandBlock
 	MustSendDownlink        bool
+	FRMPayloadHandled       bool
+	Attestation             *as.UplinkAttestation
 }
 
 // Handle handles an uplink data frame
@@ -108,19 +125,33 @@ func setContextFromDataPHYPayload(ctx *dataContext) error {
 }
 
 func getDeviceSessionForPHYPayload(ctx *dataContext) error {
-	txDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
+	// The device-session is not yet known, use the region of the receiving
+	// gateway for resolving the data-rate and channel.
+	var region string
+	if band.HasRegions() && len(ctx.RXPacket.RXInfoSet) != 0 {
+		var gatewayID lorawan.EUI64
+		copy(gatewayID[:], ctx.RXPacket.RXInfoSet[0].GatewayId)
+
+		var err error
+		region, err = storage.GetRegionForGateway(ctx.ctx, storage.DB(), gatewayID)
+		if err != nil && err != storage.ErrDoesNotExist {
+			return errors.Wrap(err, "get region for gateway error")
+		}
+	}
+
+	txDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Get(region))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate index error")
 	}
 
 	var txCh int
 	for _, defaultChannel := range []bool{true, false} {
-		i, err := band.Band().GetUplinkChannelIndex(int(ctx.RXPacket.TXInfo.Frequency), defaultChannel)
+		i, err := band.Get(region).GetUplinkChannelIndex(int(ctx.RXPacket.TXInfo.Frequency), defaultChannel)
 		if err != nil {
 			continue
 		}
 
-		c, err := band.Band().GetUplinkChannel(i)
+		c, err := band.Get(region).GetUplinkChannel(i)
 		if err != nil {
 			return errors.Wrap(err, "get channel error")
 		}
@@ -134,21 +165,49 @@ func getDeviceSessionForPHYPayload(ctx *dataContext) error {
 		}
 	}
 
-	ds, err := storage.GetDeviceSessionForPHYPayload(ctx.ctx, storage.RedisPool(), ctx.RXPacket.PHYPayload, txDR, txCh)
+	ds, fCntReset, err := storage.GetDeviceSessionForPHYPayload(ctx.ctx, storage.RedisPool(), ctx.RXPacket.PHYPayload, txDR, txCh)
 	if err != nil {
+		if err == storage.ErrDoesNotExistOrFCntOrMICInvalid {
+			security.MICFailure(ctx.ctx, storage.RedisPool(), ctx.MACPayload.FHDR.DevAddr, security.GatewaySource(ctx.RXPacket))
+		}
 		return errors.Wrap(err, "get device-session error")
 	}
 	ctx.DeviceSession = ds
+	ctx.FCntReset = fCntReset
+
+	return nil
+}
+
+// sendFCntResetWebhook sends the fcnt_reset webhook event when the uplink
+// frame-counter of the device-session has been reset.
+func sendFCntResetWebhook(ctx *dataContext) error {
+	if ctx.FCntReset == nil {
+		return nil
+	}
+
+	webhook.Send(ctx.ctx, ctx.ServiceProfile.WebhookEndpoint(), webhook.EventFCntReset, webhook.FCntResetEvent{
+		DevEUI:       ctx.DeviceSession.DevEUI,
+		FCnt:         ctx.FCntReset.FCnt,
+		PreviousFCnt: ctx.FCntReset.PreviousFCnt,
+	})
 
 	return nil
 }
 
 func logUplinkFrame(ctx *dataContext) error {
+	redaction := framelog.GetRedaction(ctx.ServiceProfile)
+	framelog.SetRedaction(ctx.ctx, redaction)
+
 	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
 	if err != nil {
 		return errors.Wrap(err, "create uplink frame-log error")
 	}
 
+	uplinkFrameSet, err = redaction.RedactUplinkFrameSet(uplinkFrameSet)
+	if err != nil {
+		return errors.Wrap(err, "redact uplink frame-log error")
+	}
+
 	if err := framelog.LogUplinkFrameForDevEUI(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, uplinkFrameSet); err != nil {
 		log.WithError(err).Error("log uplink frame for device error")
 	}
@@ -176,13 +235,33 @@ func getServiceProfile(ctx *dataContext) error {
 	return nil
 }
 
+// checkUplinkFingerprint checks the uplink against the RF fingerprint of the
+// device. This must be called before filtering the rx-info set, so that the
+// fingerprint contains all the receiving gateways.
+func checkUplinkFingerprint(ctx *dataContext) error {
+	security.UplinkFingerprint(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, ctx.ServiceProfile.UplinkFingerprintSensitivity, ctx.RXPacket)
+	return nil
+}
+
+// filterRXInfoSetForServiceProfile removes the receptions of the gateways
+// which are not allowed by the service-profile.
+func filterRXInfoSetForServiceProfile(ctx *dataContext) error {
+	rxInfoSet, err := isolation.FilterRXInfoSet(ctx.ctx, ctx.ServiceProfile, ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "filter rx-info set error")
+	}
+	ctx.RXPacket.RXInfoSet = rxInfoSet
+
+	return nil
+}
+
 func setADR(ctx *dataContext) error {
 	ctx.DeviceSession.ADR = ctx.MACPayload.FHDR.FCtrl.ADR
 	return nil
 }
 
 func setUplinkDataRate(ctx *dataContext) error {
-	currentDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
+	currentDR, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate error")
 	}
@@ -224,8 +303,29 @@ func appendMetaDataToUplinkHistory(ctx *dataContext) error {
 	return nil
 }
 
+// setSubBands updates the sub-bands of the gateways receiving the device,
+// which are used for the channel-mask (re)configuration.
+func setSubBands(ctx *dataContext) error {
+	if !band.SupportsSubBands(ctx.DeviceSession.Region) {
+		return nil
+	}
+
+	var ids []lorawan.EUI64
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		ids = append(ids, helpers.GetGatewayID(rxInfo))
+	}
+
+	subBands, err := storage.GetSubBandsForGatewayIDs(ctx.ctx, storage.DB(), storage.RedisPool(), ids)
+	if err != nil {
+		return errors.Wrap(err, "get sub-bands for gateways error")
+	}
+	ctx.DeviceSession.SubBands = subBands
+
+	return nil
+}
+
 func storeDeviceGatewayRXInfoSet(ctx *dataContext) error {
-	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
+	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate error")
 	}
@@ -255,12 +355,12 @@ func storeDeviceGatewayRXInfoSet(ctx *dataContext) error {
 }
 
 func getApplicationServerClientForDataUp(ctx *dataContext) error {
-	rp, err := storage.GetRoutingProfile(ctx.ctx, storage.DB(), ctx.DeviceSession.RoutingProfileID)
+	rp, err := storage.GetAndCacheRoutingProfile(ctx.ctx, storage.DB(), storage.RedisPool(), ctx.DeviceSession.RoutingProfileID)
 	if err != nil {
 		return errors.Wrap(err, "get routing-profile error")
 	}
 
-	asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+	asClient, err := rp.GetApplicationServerClient()
 	if err != nil {
 		return errors.Wrap(err, "get application-server client error")
 	}
@@ -530,15 +630,21 @@ func handleFRMPayloadMACCommands(ctx *dataContext) error {
 }
 
 func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
+	// the FRMPayload has been handled by the network-server (e.g. clock-sync)
+	if ctx.FRMPayloadHandled {
+		return nil
+	}
+
 	publishDataUpReq := as.HandleUplinkDataRequest{
-		DevEui:  ctx.DeviceSession.DevEUI[:],
-		JoinEui: ctx.DeviceSession.JoinEUI[:],
-		FCnt:    ctx.MACPayload.FHDR.FCnt,
-		Adr:     ctx.MACPayload.FHDR.FCtrl.ADR,
-		TxInfo:  ctx.RXPacket.TXInfo,
+		DevEui:      ctx.DeviceSession.DevEUI[:],
+		JoinEui:     ctx.DeviceSession.JoinEUI[:],
+		FCnt:        ctx.MACPayload.FHDR.FCnt,
+		Adr:         ctx.MACPayload.FHDR.FCtrl.ADR,
+		TxInfo:      ctx.RXPacket.TXInfo,
+		Attestation: ctx.Attestation,
 	}
 
-	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Band())
+	dr, err := helpers.GetDataRateIndex(true, ctx.RXPacket.TXInfo, band.Get(ctx.DeviceSession.Region))
 	if err != nil {
 		return errors.Wrap(err, "get data-rate error")
 	}
@@ -553,6 +659,12 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 			},
 		}
 
+		// retain the AppSKey for the application layer packages handled by
+		// the network-server
+		if ctx.ServiceProfile.ClockSyncEnabled || ctx.ServiceProfile.MulticastSetupEnabled || ctx.DeviceSession.CertificationTestMode {
+			ctx.DeviceSession.RetainedAppSKeyEnvelope = ctx.DeviceSession.AppSKeyEvelope
+		}
+
 		ctx.DeviceSession.AppSKeyEvelope = nil
 	}
 
@@ -587,6 +699,19 @@ func sendFRMPayloadToApplicationServer(ctx *dataContext) error {
 	return nil
 }
 
+// signUplink signs the uplink when uplink attestation is enabled. The
+// attestation is included in the application-server request and in the
+// accounting record.
+func signUplink(ctx *dataContext) error {
+	att, err := attestation.SignUplink(ctx.DeviceSession.DevEUI, ctx.MACPayload.FHDR.FCnt, ctx.RXPacket, clock.Now())
+	if err != nil {
+		return errors.Wrap(err, "sign uplink error")
+	}
+	ctx.Attestation = att
+
+	return nil
+}
+
 func syncUplinkFCnt(ctx *dataContext) error {
 	// sync counter with that of the device + 1
 	ctx.DeviceSession.FCntUp = ctx.MACPayload.FHDR.FCnt + 1
@@ -598,6 +723,19 @@ func saveDeviceSession(ctx *dataContext) error {
 	return storage.SaveDeviceSession(ctx.ctx, storage.RedisPool(), ctx.DeviceSession)
 }
 
+// createAccountingRecord creates the accounting record for the uplink. As
+// the uplink has been processed at this point, errors are logged only.
+func createAccountingRecord(ctx *dataContext) error {
+	if err := accounting.Uplink(ctx.ctx, ctx.DeviceSession.DevEUI, ctx.MACPayload.FHDR.FCnt, ctx.RXPacket, ctx.Attestation); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("create accounting record error")
+	}
+
+	return nil
+}
+
 func handleUplinkACK(ctx *dataContext) error {
 	if !ctx.MACPayload.FHDR.FCtrl.ACK {
 		return nil
@@ -625,11 +763,19 @@ func handleUplinkACK(ctx *dataContext) error {
 		return errors.Wrap(err, "delete device-queue item error")
 	}
 
-	_, err = ctx.ApplicationServerClient.HandleDownlinkACK(ctx.ctx, &as.HandleDownlinkACKRequest{
-		DevEui:       ctx.DeviceSession.DevEUI[:],
-		FCnt:         qi.FCnt,
-		Acknowledged: true,
-	})
+	if err := storage.RecordDeviceClassACK(ctx.ctx, storage.RedisPool(), ctx.DeviceSession.DevEUI, qi.FCnt); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Error("record device-class ack error")
+	}
+
+	receipt, err := qi.DeliveryReceipt(clock.Now())
+	if err != nil {
+		return errors.Wrap(err, "get delivery receipt error")
+	}
+
+	_, err = ctx.ApplicationServerClient.HandleDownlinkACK(ctx.ctx, &receipt)
 	if err != nil {
 		return errors.Wrap(err, "application-server client error")
 	}
@@ -639,7 +785,7 @@ func handleUplinkACK(ctx *dataContext) error {
 
 func handleDownlink(ctx *dataContext) error {
 	// handle downlink (ACK)
-	time.Sleep(getDownlinkDataDelay)
+	clock.Sleep(getDownlinkDataDelay)
 	if err := datadown.HandleResponse(
 		ctx.ctx,
 		ctx.RXPacket,
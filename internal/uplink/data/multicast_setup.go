@@ -0,0 +1,62 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/multicast"
+	"github.com/mxc-foundation/lpwan-server/internal/logging"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
+)
+
+// handleMulticastSetup handles the answers of the Remote Multicast Setup
+// package when this is enabled in the service-profile, to progress the
+// multicast setup started through the API (see multicast.StartSetup).
+// The frame is not forwarded to the application-server when handled.
+func handleMulticastSetup(ctx *dataContext) error {
+	if !ctx.ServiceProfile.MulticastSetupEnabled || ctx.MACPayload.FPort == nil || *ctx.MACPayload.FPort != multicast.SetupFPort || len(ctx.MACPayload.FRMPayload) != 1 {
+		return nil
+	}
+
+	dataPL, ok := ctx.MACPayload.FRMPayload[0].(*lorawan.DataPayload)
+	if !ok {
+		return fmt.Errorf("expected type *lorawan.DataPayload, got %T", ctx.MACPayload.FRMPayload[0])
+	}
+
+	appSKey, err := storage.GetAppSKey(ctx.ctx, ctx.DeviceSession)
+	if err != nil {
+		// the AppSKey is not delegated to the network-server
+		if err != storage.ErrDoesNotExist {
+			log.WithError(err).WithFields(log.Fields{
+				"dev_eui": ctx.DeviceSession.DevEUI,
+				"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+			}).Warning("multicast-setup: get AppSKey error")
+		}
+		return nil
+	}
+
+	b, err := lorawan.EncryptFRMPayload(appSKey, true, ctx.DeviceSession.DevAddr, ctx.MACPayload.FHDR.FCnt, dataPL.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "decrypt FRMPayload error")
+	}
+
+	// on error, the frame is forwarded to the application-server
+	err = storage.Transaction(func(tx sqlx.Ext) error {
+		return multicast.HandleSetupAnswers(ctx.ctx, storage.RedisPool(), tx, ctx.DeviceSession.DevEUI, b)
+	})
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"dev_eui": ctx.DeviceSession.DevEUI,
+			"ctx_id":  ctx.ctx.Value(logging.ContextIDKey),
+		}).Warning("multicast-setup: handle answers error")
+		return nil
+	}
+
+	ctx.FRMPayloadHandled = true
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -15,19 +14,28 @@ import (
 	"github.com/brocaar/lorawan/backend"
 	loraband "github.com/brocaar/lorawan/band"
 	"github.com/mxc-foundation/lpwan-server/internal/backend/joinserver"
+	"github.com/mxc-foundation/lpwan-server/internal/backend/webhook"
 	"github.com/mxc-foundation/lpwan-server/internal/band"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	joindown "github.com/mxc-foundation/lpwan-server/internal/downlink/join"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
+	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/security"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
 var tasks = []func(*joinContext) error{
 	setContextFromJoinRequestPHYPayload,
-	logJoinRequestFramesCollected,
+	countJoinRequest,
 	getDeviceAndDeviceProfile,
+	logJoinRequestFramesCollected,
+	filterRXInfoSetForServiceProfile,
+	validateDownlinkEnabled,
 	validateNonce,
+	getRegion,
+	getSubBands,
 	getRandomDevAddr,
 	getJoinAcceptFromAS,
 	flushDeviceQueue,
@@ -35,6 +43,7 @@ var tasks = []func(*joinContext) error{
 	createDeviceActivation,
 	setDeviceMode,
 	sendJoinAcceptDownlink,
+	sendJoinWebhook,
 }
 
 type joinContext struct {
@@ -45,6 +54,8 @@ type joinContext struct {
 	Device             storage.Device
 	ServiceProfile     storage.ServiceProfile
 	DeviceProfile      storage.DeviceProfile
+	Region             string
+	SubBands           []int
 	DevAddr            lorawan.DevAddr
 	CFList             []uint32
 	JoinAnsPayload     backend.JoinAnsPayload
@@ -56,27 +67,15 @@ var (
 	rx2DR       int
 	rx1DROffset int
 	rx1Delay    int
-	keks        map[string][]byte
 )
 
 // Setup configures the package.
 func Setup(conf config.Config) error {
-	keks = make(map[string][]byte)
-
 	netID = conf.NetworkServer.NetID
 	rx2DR = conf.NetworkServer.NetworkSettings.RX2DR
 	rx1DROffset = conf.NetworkServer.NetworkSettings.RX1DROffset
 	rx1Delay = conf.NetworkServer.NetworkSettings.RX1Delay
 
-	for _, k := range conf.JoinServer.KEK.Set {
-		kek, err := hex.DecodeString(k.KEK)
-		if err != nil {
-			return errors.Wrap(err, "decode kek error")
-		}
-
-		keks[k.Label] = kek
-	}
-
 	return nil
 }
 
@@ -106,12 +105,25 @@ func setContextFromJoinRequestPHYPayload(ctx *joinContext) error {
 	return nil
 }
 
+func countJoinRequest(ctx *joinContext) error {
+	security.JoinRequest(ctx.ctx, storage.RedisPool(), ctx.JoinRequestPayload.DevEUI, security.GatewaySource(ctx.RXPacket))
+	return nil
+}
+
 func logJoinRequestFramesCollected(ctx *joinContext) error {
+	redaction := framelog.GetRedaction(ctx.ServiceProfile)
+	framelog.SetRedaction(ctx.ctx, redaction)
+
 	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
 	if err != nil {
 		return errors.Wrap(err, "create uplink frame-set error")
 	}
 
+	uplinkFrameSet, err = redaction.RedactUplinkFrameSet(uplinkFrameSet)
+	if err != nil {
+		return errors.Wrap(err, "redact uplink frame-set error")
+	}
+
 	if err := framelog.LogUplinkFrameForDevEUI(ctx.ctx, storage.RedisPool(), ctx.JoinRequestPayload.DevEUI, uplinkFrameSet); err != nil {
 		log.WithFields(log.Fields{
 			"ctx_id": ctx.ctx.Value("join_ctx"),
@@ -146,6 +158,27 @@ func getDeviceAndDeviceProfile(ctx *joinContext) error {
 	return nil
 }
 
+// filterRXInfoSetForServiceProfile removes the receptions of the gateways
+// which are not allowed by the service-profile.
+func filterRXInfoSetForServiceProfile(ctx *joinContext) error {
+	rxInfoSet, err := isolation.FilterRXInfoSet(ctx.ctx, ctx.ServiceProfile, ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "filter rx-info set error")
+	}
+	ctx.RXPacket.RXInfoSet = rxInfoSet
+
+	return nil
+}
+
+// validateDownlinkEnabled validates that downlinks are enabled by the
+// service-profile, as the join-accept could not be sent otherwise.
+func validateDownlinkEnabled(ctx *joinContext) error {
+	if ctx.ServiceProfile.DownlinkDisabled {
+		return storage.ErrDownlinkDisabled
+	}
+	return nil
+}
+
 func validateNonce(ctx *joinContext) error {
 	// validate that the nonce has not been used yet
 	err := storage.ValidateDevNonce(
@@ -157,6 +190,16 @@ func validateNonce(ctx *joinContext) error {
 		lorawan.JoinRequestType,
 	)
 	if err != nil {
+		if err == storage.ErrAlreadyExists {
+			security.DevNonceReuse(
+				ctx.ctx,
+				storage.RedisPool(),
+				ctx.JoinRequestPayload.JoinEUI,
+				ctx.JoinRequestPayload.DevEUI,
+				ctx.JoinRequestPayload.DevNonce,
+				security.GatewaySource(ctx.RXPacket),
+			)
+		}
 		return errors.Wrap(err, "validate dev-nonce error")
 	}
 
@@ -173,6 +216,60 @@ func getRandomDevAddr(ctx *joinContext) error {
 	return nil
 }
 
+// getRegion resolves the region of the device from the gateway-profile of
+// the gateway which received the join-request with the best signal.
+func getRegion(ctx *joinContext) error {
+	if len(ctx.RXPacket.RXInfoSet) == 0 {
+		return nil
+	}
+
+	var gatewayID lorawan.EUI64
+	copy(gatewayID[:], ctx.RXPacket.RXInfoSet[0].GatewayId)
+
+	region, err := storage.GetRegionForGateway(ctx.ctx, storage.DB(), gatewayID)
+	if err != nil && err != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get region for gateway error")
+	}
+
+	if !band.IsRegion(region) {
+		return fmt.Errorf("gateway %s has unknown region: %s", gatewayID, region)
+	}
+	ctx.Region = region
+
+	return nil
+}
+
+// getSubBands resolves the sub-bands of the gateways which received the
+// join-request, so that the CFList channel-mask only enables the channels
+// on which these gateways are listening.
+func getSubBands(ctx *joinContext) error {
+	if !band.SupportsSubBands(ctx.Region) {
+		return nil
+	}
+
+	var ids []lorawan.EUI64
+	for _, rxInfo := range ctx.RXPacket.RXInfoSet {
+		ids = append(ids, helpers.GetGatewayID(rxInfo))
+	}
+
+	subBands, err := storage.GetSubBandsForGatewayIDs(ctx.ctx, storage.DB(), storage.RedisPool(), ids)
+	if err != nil {
+		return errors.Wrap(err, "get sub-bands for gateways error")
+	}
+	ctx.SubBands = subBands
+
+	return nil
+}
+
+// getRX2DR returns the RX2 data-rate. For the default region this is the
+// configured RX2 data-rate, for other regions the band default is used.
+func getRX2DR(region string) int {
+	if region == "" {
+		return rx2DR
+	}
+	return band.Get(region).GetDefaults().RX2DataRate
+}
+
 func getJoinAcceptFromAS(ctx *joinContext) error {
 	b, err := ctx.RXPacket.PHYPayload.MarshalBinary()
 	if err != nil {
@@ -187,7 +284,7 @@ func getJoinAcceptFromAS(ctx *joinContext) error {
 	transactionID := binary.LittleEndian.Uint32(randomBytes)
 
 	var cFListB []byte
-	cFList := band.Band().GetCFList(ctx.DeviceProfile.MACVersion)
+	cFList := band.GetForSubBands(ctx.Region, ctx.SubBands).GetCFList(ctx.DeviceProfile.MACVersion)
 	if cFList != nil {
 		cFListB, err = cFList.MarshalBinary()
 		if err != nil {
@@ -214,7 +311,7 @@ func getJoinAcceptFromAS(ctx *joinContext) error {
 		DevAddr:    ctx.DevAddr,
 		DLSettings: lorawan.DLSettings{
 			OptNeg:      !strings.HasPrefix(ctx.DeviceProfile.MACVersion, "1.0"), // must be set to true for != "1.0" devices
-			RX2DataRate: uint8(rx2DR),
+			RX2DataRate: uint8(getRX2DR(ctx.Region)),
 			RX1DROffset: uint8(rx1DROffset),
 		},
 		RxDelay: rx1Delay,
@@ -254,15 +351,18 @@ func createDeviceSession(ctx *joinContext) error {
 		RXWindow:              storage.RX1,
 		RXDelay:               uint8(rx1Delay),
 		RX1DROffset:           uint8(rx1DROffset),
-		RX2DR:                 uint8(rx2DR),
-		RX2Frequency:          band.Band().GetDefaults().RX2Frequency,
-		EnabledUplinkChannels: band.Band().GetStandardUplinkChannelIndices(),
+		RX2DR:                 uint8(getRX2DR(ctx.Region)),
+		RX2Frequency:          band.Get(ctx.Region).GetDefaults().RX2Frequency,
+		EnabledUplinkChannels: band.Get(ctx.Region).GetStandardUplinkChannelIndices(),
 		ExtraUplinkChannels:   make(map[int]loraband.Channel),
 		SkipFCntValidation:    ctx.Device.SkipFCntCheck,
+		CertificationTestMode: ctx.Device.CertificationTestMode,
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
 		NbTrans:               1,
 		ReferenceAltitude:     ctx.Device.ReferenceAltitude,
+		Region:                ctx.Region,
+		SubBands:              ctx.SubBands,
 	}
 
 	if ctx.JoinAnsPayload.AppSKey != nil {
@@ -273,7 +373,7 @@ func createDeviceSession(ctx *joinContext) error {
 	}
 
 	if ctx.JoinAnsPayload.NwkSKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.JoinAnsPayload.NwkSKey)
+		key, ke, err := getNSKey(ctx.ctx, ds.DevEUI, ds.JoinEUI, ctx.JoinAnsPayload.NwkSKey)
 		if err != nil {
 			return err
 		}
@@ -281,36 +381,42 @@ func createDeviceSession(ctx *joinContext) error {
 		ds.SNwkSIntKey = key
 		ds.FNwkSIntKey = key
 		ds.NwkSEncKey = key
+		ds.SNwkSIntKeyEnvelope = ke
+		ds.FNwkSIntKeyEnvelope = ke
+		ds.NwkSEncKeyEnvelope = ke
 	}
 
 	if ctx.JoinAnsPayload.SNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.JoinAnsPayload.SNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, ds.DevEUI, ds.JoinEUI, ctx.JoinAnsPayload.SNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		ds.SNwkSIntKey = key
+		ds.SNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.JoinAnsPayload.FNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.JoinAnsPayload.FNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, ds.DevEUI, ds.JoinEUI, ctx.JoinAnsPayload.FNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		ds.FNwkSIntKey = key
+		ds.FNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.JoinAnsPayload.NwkSEncKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.JoinAnsPayload.NwkSEncKey)
+		key, ke, err := getNSKey(ctx.ctx, ds.DevEUI, ds.JoinEUI, ctx.JoinAnsPayload.NwkSEncKey)
 		if err != nil {
 			return err
 		}
 
 		ds.NwkSEncKey = key
+		ds.NwkSEncKeyEnvelope = ke
 	}
 
-	if cfList := band.Band().GetCFList(ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
+	if cfList := band.GetForSubBands(ctx.Region, ctx.SubBands).GetCFList(ctx.DeviceProfile.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
 			return fmt.Errorf("expected *lorawan.CFListChannelPayload, got %T", cfList.Payload)
@@ -321,7 +427,7 @@ func createDeviceSession(ctx *joinContext) error {
 				continue
 			}
 
-			i, err := band.Band().GetUplinkChannelIndex(int(f), false)
+			i, err := band.Get(ctx.Region).GetUplinkChannelIndex(int(f), false)
 			if err != nil {
 				// if this happens, something is really wrong
 				log.WithError(err).WithFields(log.Fields{
@@ -335,7 +441,7 @@ func createDeviceSession(ctx *joinContext) error {
 
 			// add extra channel to extra uplink channels, so that we can
 			// keep track on frequency and data-rate changes
-			c, err := band.Band().GetUplinkChannel(i)
+			c, err := band.Get(ctx.Region).GetUplinkChannel(i)
 			if err != nil {
 				return errors.Wrap(err, "get uplink channel error")
 			}
@@ -405,3 +511,13 @@ func sendJoinAcceptDownlink(ctx *joinContext) error {
 
 	return nil
 }
+
+func sendJoinWebhook(ctx *joinContext) error {
+	webhook.Send(ctx.ctx, ctx.ServiceProfile.WebhookEndpoint(), webhook.EventJoin, webhook.JoinEvent{
+		DevEUI:  ctx.DeviceSession.DevEUI,
+		JoinEUI: ctx.DeviceSession.JoinEUI,
+		DevAddr: ctx.DeviceSession.DevAddr,
+	})
+
+	return nil
+}
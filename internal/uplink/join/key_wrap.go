@@ -1,40 +1,29 @@
 package join
 
 import (
-	"crypto/aes"
-	"fmt"
-
-	keywrap "github.com/NickBall/go-aes-key-wrap"
-	"github.com/pkg/errors"
+	"context"
 
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
+	"github.com/mxc-foundation/lpwan-server/internal/kek"
+	"github.com/mxc-foundation/lpwan-server/internal/storage"
 )
 
-// unwrapNSKeyEnveope returns the decrypted key from the given KeyEnvelope.
-func unwrapNSKeyEnvelope(ke *backend.KeyEnvelope) (lorawan.AES128Key, error) {
-	var key lorawan.AES128Key
-
-	if ke.KEKLabel == "" {
-		copy(key[:], ke.AESKey[:])
-		return key, nil
-	}
-
-	kek, ok := keks[ke.KEKLabel]
-	if !ok {
-		return key, fmt.Errorf("unknown kek label: %s", ke.KEKLabel)
-	}
-
-	block, err := aes.NewCipher(kek)
-	if err != nil {
-		return key, errors.Wrap(err, "new cipher error")
+// getNSKey returns the network session-key from the given KeyEnvelope. When
+// wrapped keys must be stored, the key is not unwrapped and its key-envelope
+// is returned instead.
+func getNSKey(ctx context.Context, devEUI, joinEUI lorawan.EUI64, ke *backend.KeyEnvelope) (lorawan.AES128Key, *storage.KeyEnvelope, error) {
+	if ke.KEKLabel == "" || !kek.StoreWrappedKeys() {
+		key, err := kek.Unwrap(ctx, devEUI, joinEUI, ke)
+		return key, nil, err
 	}
 
-	b, err := keywrap.Unwrap(block, ke.AESKey[:])
-	if err != nil {
-		return key, errors.Wrap(err, "unwrap key error")
+	if err := kek.Check(joinEUI, ke); err != nil {
+		return lorawan.AES128Key{}, nil, err
 	}
 
-	copy(key[:], b)
-	return key, nil
+	return lorawan.AES128Key{}, &storage.KeyEnvelope{
+		KEKLabel: ke.KEKLabel,
+		AESKey:   ke.AESKey,
+	}, nil
 }
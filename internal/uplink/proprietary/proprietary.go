@@ -10,7 +10,6 @@ import (
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/as"
 	"github.com/mxc-foundation/lpwan-server/api/common"
-	"github.com/mxc-foundation/lpwan-server/internal/backend/applicationserver"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
@@ -101,7 +100,7 @@ func sendProprietaryPayloadToApplicationServer(ctx *proprietaryContext) error {
 
 	for _, rp := range rps {
 		go func(ctx context.Context, rp storage.RoutingProfile, handleReq as.HandleProprietaryUplinkRequest) {
-			asClient, err := applicationserver.Pool().Get(rp.ASID, []byte(rp.CACert), []byte(rp.TLSCert), []byte(rp.TLSKey))
+			asClient, err := rp.GetApplicationServerClient()
 			if err != nil {
 				log.WithError(err).Error("get application-server client error")
 				return
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	joindown "github.com/mxc-foundation/lpwan-server/internal/downlink/join"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/isolation"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
@@ -26,8 +26,10 @@ import (
 
 var tasks = []func(*rejoinContext) error{
 	setContextFromRejoinRequestPHY,
-	logRejoinRequestFramesCollected,
 	getDeviceAndProfiles,
+	logRejoinRequestFramesCollected,
+	filterRXInfoSetForServiceProfile,
+	validateDownlinkEnabled,
 	forRejoinType([]lorawan.JoinType{lorawan.RejoinRequestType0, lorawan.RejoinRequestType2},
 		getDeviceSession,
 		validateRejoinCounter0,
@@ -73,28 +75,16 @@ var (
 	rx2DR       int
 	rx1DROffset int
 	rx1Delay    int
-	keks        map[string][]byte
 	netID       lorawan.NetID
 )
 
 // Setup configures the package.
 func Setup(conf config.Config) error {
-	keks = make(map[string][]byte)
-
 	netID = conf.NetworkServer.NetID
 	rx2DR = conf.NetworkServer.NetworkSettings.RX2DR
 	rx1DROffset = conf.NetworkServer.NetworkSettings.RX1DROffset
 	rx1Delay = conf.NetworkServer.NetworkSettings.RX1Delay
 
-	for _, k := range conf.JoinServer.KEK.Set {
-		kek, err := hex.DecodeString(k.KEK)
-		if err != nil {
-			return errors.Wrap(err, "decode kek error")
-		}
-
-		keks[k.Label] = kek
-	}
-
 	return nil
 }
 
@@ -155,11 +145,19 @@ func logRejoinRequestFramesCollected(ctx *rejoinContext) error {
 		gatewayIDs = append(gatewayIDs, helpers.GetGatewayID(p).String())
 	}
 
+	redaction := framelog.GetRedaction(ctx.ServiceProfile)
+	framelog.SetRedaction(ctx.ctx, redaction)
+
 	uplinkFrameSet, err := framelog.CreateUplinkFrameSet(ctx.RXPacket)
 	if err != nil {
 		return errors.Wrap(err, "create uplink frame-set error")
 	}
 
+	uplinkFrameSet, err = redaction.RedactUplinkFrameSet(uplinkFrameSet)
+	if err != nil {
+		return errors.Wrap(err, "redact uplink frame-set error")
+	}
+
 	if err := framelog.LogUplinkFrameForDevEUI(ctx.ctx, storage.RedisPool(), ctx.DevEUI, uplinkFrameSet); err != nil {
 		log.WithError(err).Error("log uplink frame for device error")
 	}
@@ -200,6 +198,27 @@ func getDeviceAndProfiles(ctx *rejoinContext) error {
 	return nil
 }
 
+// filterRXInfoSetForServiceProfile removes the receptions of the gateways
+// which are not allowed by the service-profile.
+func filterRXInfoSetForServiceProfile(ctx *rejoinContext) error {
+	rxInfoSet, err := isolation.FilterRXInfoSet(ctx.ctx, ctx.ServiceProfile, ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "filter rx-info set error")
+	}
+	ctx.RXPacket.RXInfoSet = rxInfoSet
+
+	return nil
+}
+
+// validateDownlinkEnabled validates that downlinks are enabled by the
+// service-profile, as the join-accept could not be sent otherwise.
+func validateDownlinkEnabled(ctx *rejoinContext) error {
+	if ctx.ServiceProfile.DownlinkDisabled {
+		return storage.ErrDownlinkDisabled
+	}
+	return nil
+}
+
 func getDeviceSession(ctx *rejoinContext) error {
 	var err error
 	ctx.DeviceSession, err = storage.GetDeviceSession(ctx.ctx, storage.RedisPool(), ctx.DevEUI)
@@ -274,7 +293,7 @@ func getRejoinAcceptFromJS(ctx *rejoinContext) error {
 		DevAddr:    ctx.DevAddr,
 		DLSettings: lorawan.DLSettings{
 			OptNeg:      !strings.HasPrefix(ctx.DeviceProfile.MACVersion, "1.0"),
-			RX2DataRate: uint8(rx2DR),
+			RX2DataRate: uint8(getRX2DR(ctx.DeviceSession.Region)),
 			RX1DROffset: uint8(rx1DROffset),
 		},
 		RxDelay: rx1Delay,
@@ -287,7 +306,7 @@ func getRejoinAcceptFromJS(ctx *rejoinContext) error {
 	// 2: Used to rekey a device or change its DevAddr (DevAddr, session keys,
 	//    frame counters). Radio parameters are kept unchanged.
 	if ctx.RejoinType == lorawan.RejoinRequestType0 || ctx.RejoinType == lorawan.RejoinRequestType1 {
-		cFList := band.Band().GetCFList(ctx.DeviceSession.MACVersion)
+		cFList := band.GetForSubBands(ctx.DeviceSession.Region, ctx.DeviceSession.SubBands).GetCFList(ctx.DeviceSession.MACVersion)
 		if cFList != nil {
 			cFListB, err := cFList.MarshalBinary()
 			if err != nil {
@@ -330,14 +349,17 @@ func setRejoin0PendingDeviceSession(ctx *rejoinContext) error {
 		RXWindow:              storage.RX1,
 		RXDelay:               uint8(rx1Delay),
 		RX1DROffset:           uint8(rx1DROffset),
-		RX2DR:                 uint8(rx2DR),
-		RX2Frequency:          band.Band().GetDefaults().RX2Frequency,
-		EnabledUplinkChannels: band.Band().GetStandardUplinkChannelIndices(),
+		RX2DR:                 uint8(getRX2DR(ctx.DeviceSession.Region)),
+		RX2Frequency:          band.Get(ctx.DeviceSession.Region).GetDefaults().RX2Frequency,
+		EnabledUplinkChannels: band.Get(ctx.DeviceSession.Region).GetStandardUplinkChannelIndices(),
 		ExtraUplinkChannels:   make(map[int]loraband.Channel),
 		SkipFCntValidation:    ctx.Device.SkipFCntCheck,
+		CertificationTestMode: ctx.Device.CertificationTestMode,
 		PingSlotDR:            ctx.DeviceProfile.PingSlotDR,
 		PingSlotFrequency:     int(ctx.DeviceProfile.PingSlotFreq),
 		NbTrans:               1,
+		Region:                ctx.DeviceSession.Region,
+		SubBands:              ctx.DeviceSession.SubBands,
 	}
 
 	if ctx.RejoinAnsPayload.AppSKey != nil {
@@ -348,7 +370,7 @@ func setRejoin0PendingDeviceSession(ctx *rejoinContext) error {
 	}
 
 	if ctx.RejoinAnsPayload.NwkSKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.NwkSKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.NwkSKey)
 		if err != nil {
 			return err
 		}
@@ -356,36 +378,42 @@ func setRejoin0PendingDeviceSession(ctx *rejoinContext) error {
 		pendingDS.SNwkSIntKey = key
 		pendingDS.FNwkSIntKey = key
 		pendingDS.NwkSEncKey = key
+		pendingDS.SNwkSIntKeyEnvelope = ke
+		pendingDS.FNwkSIntKeyEnvelope = ke
+		pendingDS.NwkSEncKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.SNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.SNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.SNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.SNwkSIntKey = key
+		pendingDS.SNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.FNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.FNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.FNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.FNwkSIntKey = key
+		pendingDS.FNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.NwkSEncKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.NwkSEncKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.NwkSEncKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.NwkSEncKey = key
+		pendingDS.NwkSEncKeyEnvelope = ke
 	}
 
-	if cfList := band.Band().GetCFList(ctx.DeviceSession.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
+	if cfList := band.GetForSubBands(ctx.DeviceSession.Region, ctx.DeviceSession.SubBands).GetCFList(ctx.DeviceSession.MACVersion); cfList != nil && cfList.CFListType == lorawan.CFListChannel {
 		channelPL, ok := cfList.Payload.(*lorawan.CFListChannelPayload)
 		if !ok {
 			return fmt.Errorf("expected *lorawan.CFListChannelPayload, got %T", cfList.Payload)
@@ -396,7 +424,7 @@ func setRejoin0PendingDeviceSession(ctx *rejoinContext) error {
 				continue
 			}
 
-			i, err := band.Band().GetUplinkChannelIndex(int(f), false)
+			i, err := band.Get(ctx.DeviceSession.Region).GetUplinkChannelIndex(int(f), false)
 			if err != nil {
 				// if this happens, something is really wrong
 				log.WithError(err).WithFields(log.Fields{
@@ -410,7 +438,7 @@ func setRejoin0PendingDeviceSession(ctx *rejoinContext) error {
 
 			// add extra channel to extra uplink channels, so that we can
 			// keep track on frequency and data-rate changes
-			c, err := band.Band().GetUplinkChannel(i)
+			c, err := band.Get(ctx.DeviceSession.Region).GetUplinkChannel(i)
 			if err != nil {
 				return errors.Wrap(err, "get uplink channel error")
 			}
@@ -447,7 +475,7 @@ func setRejoin2PendingDeviceSession(ctx *rejoinContext) error {
 	}
 
 	if ctx.RejoinAnsPayload.NwkSKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.NwkSKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.NwkSKey)
 		if err != nil {
 			return err
 		}
@@ -455,33 +483,39 @@ func setRejoin2PendingDeviceSession(ctx *rejoinContext) error {
 		pendingDS.SNwkSIntKey = key
 		pendingDS.FNwkSIntKey = key
 		pendingDS.NwkSEncKey = key
+		pendingDS.SNwkSIntKeyEnvelope = ke
+		pendingDS.FNwkSIntKeyEnvelope = ke
+		pendingDS.NwkSEncKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.SNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.SNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.SNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.SNwkSIntKey = key
+		pendingDS.SNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.FNwkSIntKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.FNwkSIntKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.FNwkSIntKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.FNwkSIntKey = key
+		pendingDS.FNwkSIntKeyEnvelope = ke
 	}
 
 	if ctx.RejoinAnsPayload.NwkSEncKey != nil {
-		key, err := unwrapNSKeyEnvelope(ctx.RejoinAnsPayload.NwkSEncKey)
+		key, ke, err := getNSKey(ctx.ctx, pendingDS.DevEUI, pendingDS.JoinEUI, ctx.RejoinAnsPayload.NwkSEncKey)
 		if err != nil {
 			return err
 		}
 
 		pendingDS.NwkSEncKey = key
+		pendingDS.NwkSEncKeyEnvelope = ke
 	}
 
 	ctx.DeviceSession.PendingRejoinDeviceSession = &pendingDS
@@ -532,3 +566,12 @@ func sendJoinAcceptDownlink(ctx *rejoinContext) error {
 func errNotSupported(ctx *rejoinContext) error {
 	return fmt.Errorf("rejoin not implemented for type: %s", ctx.RejoinType)
 }
+
+// getRX2DR returns the RX2 data-rate. For the default region this is the
+// configured RX2 data-rate, for other regions the band default is used.
+func getRX2DR(region string) int {
+	if region == "" {
+		return rx2DR
+	}
+	return band.Get(region).GetDefaults().RX2DataRate
+}
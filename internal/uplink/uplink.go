@@ -5,22 +5,29 @@ import (
 	"encoding/hex"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/brocaar/lorawan"
 	"github.com/mxc-foundation/lpwan-server/api/gw"
 	gwbackend "github.com/mxc-foundation/lpwan-server/internal/backend/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/clock"
 	"github.com/mxc-foundation/lpwan-server/internal/config"
 	"github.com/mxc-foundation/lpwan-server/internal/downlink/ack"
+	"github.com/mxc-foundation/lpwan-server/internal/downlink/latency"
 	"github.com/mxc-foundation/lpwan-server/internal/framelog"
 	"github.com/mxc-foundation/lpwan-server/internal/gateway"
+	"github.com/mxc-foundation/lpwan-server/internal/gateway/contribution"
 	"github.com/mxc-foundation/lpwan-server/internal/helpers"
 	"github.com/mxc-foundation/lpwan-server/internal/logging"
 	"github.com/mxc-foundation/lpwan-server/internal/models"
+	"github.com/mxc-foundation/lpwan-server/internal/partition"
+	"github.com/mxc-foundation/lpwan-server/internal/roaming"
 	"github.com/mxc-foundation/lpwan-server/internal/storage"
 	"github.com/mxc-foundation/lpwan-server/internal/uplink/data"
 	"github.com/mxc-foundation/lpwan-server/internal/uplink/join"
@@ -28,8 +35,27 @@ import (
 	"github.com/mxc-foundation/lpwan-server/internal/uplink/rejoin"
 )
 
+// Gateway meta-data update modes.
+const (
+	metaDataUpdateSync     = "sync"
+	metaDataUpdateAsync    = "async"
+	metaDataUpdateDisabled = "disabled"
+)
+
 var (
-	deduplicationDelay time.Duration
+	// deduplicationDelay is accessed atomically as it can be changed at
+	// runtime.
+	deduplicationDelay int64
+
+	// deduplicationShards defines the number of de-duplication shards
+	// (0 = the legacy, un-sharded keys).
+	deduplicationShards int
+
+	// deduplicationMigrateLegacyKeys writes and locks both the legacy and the
+	// sharded keys, for migrating between both key layouts.
+	deduplicationMigrateLegacyKeys bool
+
+	metaDataUpdate = metaDataUpdateSync
 )
 
 // Setup configures the package.
@@ -46,11 +72,36 @@ func Setup(conf config.Config) error {
 		return errors.Wrap(err, "configure uplink/rejoin error")
 	}
 
-	deduplicationDelay = conf.NetworkServer.DeduplicationDelay
+	SetDeduplicationDelay(conf.NetworkServer.DeduplicationDelay)
+
+	if conf.NetworkServer.Deduplication.Shards < 0 {
+		return fmt.Errorf("invalid deduplication shards: %d", conf.NetworkServer.Deduplication.Shards)
+	}
+	deduplicationShards = conf.NetworkServer.Deduplication.Shards
+	deduplicationMigrateLegacyKeys = conf.NetworkServer.Deduplication.MigrateLegacyKeys
+
+	switch conf.NetworkServer.Gateway.MetaDataUpdate {
+	case "":
+		metaDataUpdate = metaDataUpdateSync
+	case metaDataUpdateSync, metaDataUpdateAsync, metaDataUpdateDisabled:
+		metaDataUpdate = conf.NetworkServer.Gateway.MetaDataUpdate
+	default:
+		return fmt.Errorf("invalid gateway meta_data_update: %s", conf.NetworkServer.Gateway.MetaDataUpdate)
+	}
 
 	return nil
 }
 
+// SetDeduplicationDelay sets the uplink de-duplication delay. This is safe to
+// call while the server is handling uplinks.
+func SetDeduplicationDelay(d time.Duration) {
+	atomic.StoreInt64(&deduplicationDelay, int64(d))
+}
+
+func getDeduplicationDelay() time.Duration {
+	return time.Duration(atomic.LoadInt64(&deduplicationDelay))
+}
+
 // Server represents a server listening for uplink packets.
 type Server struct {
 	wg sync.WaitGroup
@@ -106,6 +157,7 @@ func HandleUplinkFrames(wg *sync.WaitGroup) {
 
 			ctx := context.Background()
 			ctx = context.WithValue(ctx, logging.ContextIDKey, ctxID)
+			ctx = latency.ContextWithUplinkReceivedAt(ctx, clock.Now())
 
 			if err := HandleUplinkFrame(ctx, uplinkFrame); err != nil {
 				log.WithFields(log.Fields{
@@ -116,8 +168,16 @@ func HandleUplinkFrames(wg *sync.WaitGroup) {
 	}
 }
 
-// HandleUplinkFrame handles a single uplink frame.
+// HandleUplinkFrame handles a single uplink frame. Frames belonging to a
+// partition owned by an other instance are ignored.
 func HandleUplinkFrame(ctx context.Context, uplinkFrame gw.UplinkFrame) error {
+	if !partition.Owns(uplinkFrame.PhyPayload) {
+		log.WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).Debug("uplink: frame belongs to other partition, ignoring")
+		return nil
+	}
+
 	return collectUplinkFrames(ctx, uplinkFrame)
 }
 
@@ -153,7 +213,7 @@ func HandleDownlinkTXAcks(wg *sync.WaitGroup) {
 }
 
 func collectUplinkFrames(ctx context.Context, uplinkFrame gw.UplinkFrame) error {
-	return collectAndCallOnce(storage.RedisPool(), uplinkFrame, func(rxPacket models.RXPacket) error {
+	return collectAndCallOnce(ctx, storage.RedisPool(), uplinkFrame, func(rxPacket models.RXPacket) error {
 		var uplinkIDs []uuid.UUID
 		for _, p := range rxPacket.RXInfoSet {
 			uplinkIDs = append(uplinkIDs, helpers.GetUplinkID(p))
@@ -165,34 +225,121 @@ func collectUplinkFrames(ctx context.Context, uplinkFrame gw.UplinkFrame) error
 			"ctx_id":     ctx.Value(logging.ContextIDKey),
 		}).Info("uplink: frame(s) collected")
 
-		// update the gateway meta-data
-		if err := gateway.UpdateMetaDataInRxInfoSet(ctx, storage.DB(), storage.RedisPool(), rxPacket.RXInfoSet); err != nil {
-			log.WithError(err).Error("uplink: update gateway meta-data in rx-info set error")
-		}
+		// update the gateway meta-data, in async mode this is done on a
+		// copy of the rx-info set, which is only used for the frame-logs
+		logRXPacket := rxPacket
+		var metaDataDone chan struct{}
+
+		switch metaDataUpdate {
+		case metaDataUpdateSync:
+			updateMetaData(ctx, rxPacket.RXInfoSet)
+		case metaDataUpdateAsync:
+			logRXPacket.RXInfoSet = cloneRXInfoSet(rxPacket.RXInfoSet)
+			metaDataDone = make(chan struct{})
 
-		// log the frame for each receiving gatewa
-		if err := framelog.LogUplinkFrameForGateways(ctx, storage.RedisPool(), gw.UplinkFrameSet{
-			PhyPayload: uplinkFrame.PhyPayload,
-			TxInfo:     rxPacket.TXInfo,
-			RxInfo:     rxPacket.RXInfoSet,
-		}); err != nil {
-			log.WithFields(log.Fields{
-				"ctx_id": ctx.Value(logging.ContextIDKey),
-			}).WithError(err).Error("uplink: log uplink frames for gateways error")
+			go func(ctx context.Context, rxInfoSet []*gw.UplinkRXInfo) {
+				defer close(metaDataDone)
+				updateMetaData(ctx, rxInfoSet)
+			}(ctx, logRXPacket.RXInfoSet)
 		}
 
+		// the handlers set the redaction of the service-profile of the
+		// device, which is applied to the gateway frame-logs
+		ctx = framelog.ContextWithRedaction(ctx)
+
 		// handle the frame based on message-type
+		var err error
+		var validated bool
 		switch rxPacket.PHYPayload.MHDR.MType {
 		case lorawan.JoinRequest:
-			return join.Handle(ctx, rxPacket)
+			err = join.Handle(ctx, rxPacket)
+			validated = true
 		case lorawan.RejoinRequest:
-			return rejoin.Handle(ctx, rxPacket)
+			err = rejoin.Handle(ctx, rxPacket)
+			validated = true
 		case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
-			return data.Handle(ctx, rxPacket)
+			validated, err = handleDataUp(ctx, rxPacket)
 		case lorawan.Proprietary:
-			return proprietary.Handle(ctx, rxPacket)
-		default:
-			return nil
+			err = proprietary.Handle(ctx, rxPacket)
+		}
+
+		// the frame-logs and the gateway contribution are independent,
+		// these are handled concurrently
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if metaDataDone != nil {
+				<-metaDataDone
+			}
+
+			// log the frame for each receiving gateway
+			if err := logUplinkFramesForGateways(ctx, uplinkFrame.PhyPayload, logRXPacket); err != nil {
+				log.WithFields(log.Fields{
+					"ctx_id": ctx.Value(logging.ContextIDKey),
+				}).WithError(err).Error("uplink: log uplink frames for gateways error")
+			}
+		}()
+
+		// only frames which have been validated (e.g. MIC) count as gateway
+		// contribution, the lock-holding frame is the first received frame
+		if err == nil && validated {
+			if err := contribution.HandleUplink(ctx, storage.RedisPool(), helpers.GetGatewayID(uplinkFrame.RxInfo), rxPacket.RXInfoSet); err != nil {
+				log.WithFields(log.Fields{
+					"ctx_id": ctx.Value(logging.ContextIDKey),
+				}).WithError(err).Error("uplink: handle gateway contribution error")
+			}
 		}
+
+		wg.Wait()
+
+		return err
 	})
 }
+
+func updateMetaData(ctx context.Context, rxInfoSet []*gw.UplinkRXInfo) {
+	if err := gateway.UpdateMetaDataInRxInfoSet(ctx, storage.DB(), storage.RedisPool(), rxInfoSet); err != nil {
+		log.WithFields(log.Fields{
+			"ctx_id": ctx.Value(logging.ContextIDKey),
+		}).WithError(err).Error("uplink: update gateway meta-data in rx-info set error")
+	}
+}
+
+// cloneRXInfoSet returns a deep copy of the given rx-info set.
+func cloneRXInfoSet(rxInfoSet []*gw.UplinkRXInfo) []*gw.UplinkRXInfo {
+	out := make([]*gw.UplinkRXInfo, len(rxInfoSet))
+	for i := range rxInfoSet {
+		out[i] = proto.Clone(rxInfoSet[i]).(*gw.UplinkRXInfo)
+	}
+	return out
+}
+
+// handleDataUp handles a data uplink. Uplinks of devices of a partner
+// network are forwarded to the partner network (passive roaming). As these
+// are validated by the partner network, false is returned for validated.
+func handleDataUp(ctx context.Context, rxPacket models.RXPacket) (bool, error) {
+	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if ok && roaming.IsRoamingDevAddr(macPL.FHDR.DevAddr) {
+		err := roaming.HandlePassiveRoamingUplink(ctx, rxPacket)
+		if err != roaming.ErrNoAgreement {
+			return false, err
+		}
+	}
+
+	return true, data.Handle(ctx, rxPacket)
+}
+
+func logUplinkFramesForGateways(ctx context.Context, phyPayload []byte, rxPacket models.RXPacket) error {
+	uplinkFrameSet, err := framelog.GetRedactionFromContext(ctx).RedactUplinkFrameSet(gw.UplinkFrameSet{
+		PhyPayload: phyPayload,
+		TxInfo:     rxPacket.TXInfo,
+		RxInfo:     rxPacket.RXInfoSet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "redact uplink frame-set error")
+	}
+
+	return framelog.LogUplinkFrameForGateways(ctx, storage.RedisPool(), uplinkFrameSet)
+}
@@ -0,0 +1,33 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mxc-foundation/lpwan-server/api/common"
+	"github.com/mxc-foundation/lpwan-server/api/gw"
+)
+
+func TestCloneRXInfoSet(t *testing.T) {
+	assert := require.New(t)
+
+	rxInfoSet := []*gw.UplinkRXInfo{
+		{GatewayId: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Rssi: -60},
+		{GatewayId: []byte{8, 7, 6, 5, 4, 3, 2, 1}, Rssi: -80},
+	}
+
+	out := cloneRXInfoSet(rxInfoSet)
+	assert.Len(out, 2)
+	for i := range out {
+		assert.True(rxInfoSet[i] != out[i])
+		assert.Equal(rxInfoSet[i].GatewayId, out[i].GatewayId)
+		assert.Equal(rxInfoSet[i].Rssi, out[i].Rssi)
+	}
+
+	// updating the copy does not affect the original rx-info set
+	out[0].Location = &common.Location{Latitude: 1.123}
+	out[0].GatewayId[0] = 0
+	assert.Nil(rxInfoSet[0].Location)
+	assert.Equal([]byte{1, 2, 3, 4, 5, 6, 7, 8}, rxInfoSet[0].GatewayId)
+}
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table routing_profile
+    add column failover_as_ids varchar(255)[],
+    add column round_robin boolean not null default false;
+
+-- +migrate Down
+alter table routing_profile
+    drop column round_robin,
+    drop column failover_as_ids;
@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table service_profile
+    add column webhook_url text not null default '',
+    add column webhook_secret text not null default '',
+    add column webhook_events varchar(20)[];
+
+-- +migrate Down
+alter table service_profile
+    drop column webhook_events,
+    drop column webhook_secret,
+    drop column webhook_url;
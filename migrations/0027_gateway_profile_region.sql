@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway_profile
+    add column region varchar(100) not null default '';
+
+-- +migrate Down
+alter table gateway_profile
+    drop column region;
@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table gateway_profile
+    add column lbt_enabled boolean not null default false,
+    add column lbt_rssi_target smallint not null default 0,
+    add column lbt_scan_time integer not null default 0,
+    add column lbt_frequencies bigint[];
+
+-- +migrate Down
+alter table gateway_profile
+    drop column lbt_frequencies,
+    drop column lbt_scan_time,
+    drop column lbt_rssi_target,
+    drop column lbt_enabled;
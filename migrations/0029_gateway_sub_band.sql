@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table gateway
+    add column sub_band smallint not null default 0;
+
+-- +migrate Down
+alter table gateway
+    drop column sub_band;
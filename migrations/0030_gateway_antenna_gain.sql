@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table gateway
+    add column antenna_gain double precision not null default 0,
+    add column cable_loss double precision not null default 0;
+
+-- +migrate Down
+alter table gateway
+    drop column cable_loss,
+    drop column antenna_gain;
@@ -0,0 +1,59 @@
+-- +migrate Up
+create table frequency_plan (
+    frequency_plan_id uuid primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    name varchar(100) not null,
+    version integer not null
+);
+
+create unique index idx_frequency_plan_name on frequency_plan(name);
+
+create table frequency_plan_version (
+    frequency_plan_id uuid not null references frequency_plan on delete cascade,
+    version integer not null,
+    created_at timestamp with time zone not null,
+    channels smallint[],
+    rx2_frequency bigint not null default 0,
+    rx2_dr smallint not null default 0,
+    beacon_frequency bigint not null default 0,
+    beacon_dr smallint not null default 0,
+    ping_slot_frequency bigint not null default 0,
+    ping_slot_dr smallint not null default 0,
+
+    primary key (frequency_plan_id, version)
+);
+
+create table frequency_plan_extra_channel (
+    id bigserial primary key,
+    frequency_plan_id uuid not null,
+    version integer not null,
+    modulation varchar(10) not null,
+    frequency integer not null,
+    bandwidth integer not null,
+    bitrate integer not null,
+    spreading_factors smallint[],
+
+    foreign key (frequency_plan_id, version) references frequency_plan_version on delete cascade
+);
+
+create index idx_frequency_plan_extra_channel_version on frequency_plan_extra_channel(frequency_plan_id, version);
+
+alter table gateway_profile
+    add column frequency_plan_id uuid references frequency_plan on delete restrict,
+    add column frequency_plan_version integer not null default 0;
+
+create index idx_gateway_profile_frequency_plan_id on gateway_profile(frequency_plan_id);
+
+-- +migrate Down
+drop index idx_gateway_profile_frequency_plan_id;
+
+alter table gateway_profile
+    drop column frequency_plan_version,
+    drop column frequency_plan_id;
+
+drop index idx_frequency_plan_extra_channel_version;
+drop table frequency_plan_extra_channel;
+drop table frequency_plan_version;
+drop index idx_frequency_plan_name;
+drop table frequency_plan;
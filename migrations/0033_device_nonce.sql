@@ -0,0 +1,38 @@
+-- +migrate Up
+create table device_nonce (
+    join_eui bytea not null,
+    dev_eui bytea not null,
+    join_req_type smallint not null,
+    dev_nonce integer not null,
+    created_at timestamp with time zone not null,
+
+    primary key (join_eui, dev_eui, join_req_type, dev_nonce)
+);
+
+insert into device_nonce (
+    join_eui,
+    dev_eui,
+    join_req_type,
+    dev_nonce,
+    created_at
+)
+select
+    join_eui,
+    dev_eui,
+    join_req_type,
+    dev_nonce,
+    min(created_at)
+from
+    device_activation
+group by
+    join_eui,
+    dev_eui,
+    join_req_type,
+    dev_nonce;
+
+drop index idx_device_activation_nonce_lookup;
+
+-- +migrate Down
+create index idx_device_activation_nonce_lookup on device_activation (join_eui, dev_eui, join_req_type, dev_nonce);
+
+drop table device_nonce;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_profile
+    add column fcnt_validation_mode varchar(20) not null default 'strict',
+    add column fcnt_max_gap integer not null default 0;
+
+-- +migrate Down
+alter table device_profile
+    drop column fcnt_max_gap,
+    drop column fcnt_validation_mode;
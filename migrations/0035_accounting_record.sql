@@ -0,0 +1,21 @@
+-- +migrate Up
+create table accounting_record (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    direction varchar(8) not null,
+    dev_eui bytea not null,
+    gateway_ids bytea[] not null,
+    airtime bigint not null,
+    dr smallint not null,
+    payload_size integer not null,
+    f_cnt bigint not null,
+    frequency bigint not null
+);
+
+create index idx_accounting_record_created_at on accounting_record(created_at);
+create index idx_accounting_record_dev_eui on accounting_record(dev_eui);
+
+-- +migrate Down
+drop index idx_accounting_record_dev_eui;
+drop index idx_accounting_record_created_at;
+drop table accounting_record;
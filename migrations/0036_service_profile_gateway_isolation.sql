@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table service_profile
+    add column gateway_isolation boolean not null default false,
+    add column allowed_gateway_ids bytea[];
+
+-- +migrate Down
+alter table service_profile
+    drop column allowed_gateway_ids,
+    drop column gateway_isolation;
@@ -0,0 +1,12 @@
+-- +migrate Up
+create table multicast_group_gateway_set (
+    multicast_group_id uuid primary key references multicast_group on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    gateway_ids bytea[] not null,
+    pending_gateway_ids bytea[] not null,
+    pending_count integer not null default 0
+);
+
+-- +migrate Down
+drop table multicast_group_gateway_set;
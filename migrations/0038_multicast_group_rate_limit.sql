@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table multicast_group
+    add column max_frames_per_minute integer not null default 0,
+    add column max_airtime_percentage double precision not null default 0;
+
+-- +migrate Down
+alter table multicast_group
+    drop column max_airtime_percentage,
+    drop column max_frames_per_minute;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table multicast_queue
+    add column geofence jsonb;
+
+-- +migrate Down
+alter table multicast_queue
+    drop column geofence;
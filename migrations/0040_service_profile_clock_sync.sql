@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table service_profile
+    add column clock_sync_enabled boolean not null default false;
+
+-- +migrate Down
+alter table service_profile
+    drop column clock_sync_enabled;
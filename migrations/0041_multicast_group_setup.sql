@@ -0,0 +1,35 @@
+-- +migrate Up
+alter table service_profile
+    add column multicast_setup_enabled boolean not null default false;
+
+create table multicast_group_setup (
+    multicast_group_id uuid primary key references multicast_group on delete cascade,
+    created_at timestamp with time zone not null,
+    mc_group_id smallint not null,
+    mc_key_encrypted bytea not null,
+    min_mc_f_cnt bigint not null,
+    max_mc_f_cnt bigint not null,
+    session_time timestamp with time zone not null,
+    session_time_out smallint not null
+);
+
+create table multicast_group_setup_device (
+    multicast_group_id uuid not null references multicast_group_setup on delete cascade,
+    dev_eui bytea not null references device on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    state varchar(20) not null,
+    error text not null,
+
+    primary key(multicast_group_id, dev_eui)
+);
+
+create index idx_multicast_group_setup_device_dev_eui on multicast_group_setup_device(dev_eui);
+
+-- +migrate Down
+drop index idx_multicast_group_setup_device_dev_eui;
+drop table multicast_group_setup_device;
+drop table multicast_group_setup;
+
+alter table service_profile
+    drop column multicast_setup_enabled;
@@ -0,0 +1,15 @@
+-- +migrate Up
+alter table service_profile
+    add column device_queue_max_size integer not null default 0,
+    add column device_queue_overflow_policy varchar(20) not null default 'reject';
+
+alter table device_queue
+    add column priority integer not null default 0;
+
+-- +migrate Down
+alter table device_queue
+    drop column priority;
+
+alter table service_profile
+    drop column device_queue_overflow_policy,
+    drop column device_queue_max_size;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table device_queue
+    add column tx_count integer not null default 0,
+    add column transmitted_at timestamp with time zone null;
+
+-- +migrate Down
+alter table device_queue
+    drop column transmitted_at,
+    drop column tx_count;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table service_profile
+    add column frame_log_redact_payload boolean not null default false,
+    add column frame_log_truncate_dev_eui boolean not null default false;
+
+-- +migrate Down
+alter table service_profile
+    drop column frame_log_truncate_dev_eui,
+    drop column frame_log_redact_payload;
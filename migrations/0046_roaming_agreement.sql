@@ -0,0 +1,18 @@
+-- +migrate Up
+create table roaming_agreement (
+    roaming_agreement_id uuid primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    net_id bytea not null,
+    server varchar(255) not null,
+    passive_roaming boolean not null default false,
+    handover_roaming boolean not null default false,
+    passive_roaming_lifetime bigint not null default 0,
+    billing_reference varchar(255) not null default ''
+);
+
+create unique index idx_roaming_agreement_net_id on roaming_agreement(net_id);
+
+-- +migrate Down
+drop index idx_roaming_agreement_net_id;
+drop table roaming_agreement;
@@ -0,0 +1,25 @@
+-- +migrate Up
+create table roaming_accounting_record (
+    id bigserial primary key,
+    created_at timestamp with time zone not null,
+    role varchar(3) not null,
+    direction varchar(8) not null,
+    net_id bytea not null,
+    dev_addr bytea not null,
+    gateway_ids bytea[] not null,
+    gateway_time timestamp with time zone,
+    payload_size integer not null,
+    airtime bigint not null,
+    frequency bigint not null,
+    dr smallint not null,
+    rssi smallint,
+    snr double precision
+);
+
+create index idx_roaming_accounting_record_created_at on roaming_accounting_record(created_at);
+create index idx_roaming_accounting_record_net_id on roaming_accounting_record(net_id);
+
+-- +migrate Down
+drop index idx_roaming_accounting_record_net_id;
+drop index idx_roaming_accounting_record_created_at;
+drop table roaming_accounting_record;
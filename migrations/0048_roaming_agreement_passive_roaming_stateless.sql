@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table roaming_agreement
+    add column passive_roaming_stateless boolean not null default false;
+
+-- +migrate Down
+alter table roaming_agreement
+    drop column passive_roaming_stateless;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table device
+    add column certification_test_mode boolean not null default false;
+
+-- +migrate Down
+alter table device
+    drop column certification_test_mode;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table service_profile
+    add column downlink_disabled boolean not null default false,
+    add column dl_max_tx_power integer not null default 0;
+
+-- +migrate Down
+alter table service_profile
+    drop column dl_max_tx_power,
+    drop column downlink_disabled;
@@ -0,0 +1,22 @@
+-- +migrate Up
+alter table gateway_profile
+    add column reconfiguration_window_start integer not null default 0,
+    add column reconfiguration_window_duration integer not null default 0;
+
+create table gateway_configuration_status (
+    gateway_id bytea primary key references gateway on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    version varchar(100) not null,
+    state varchar(20) not null,
+    scheduled_at timestamp with time zone null,
+    sent_at timestamp with time zone null,
+    applied_at timestamp with time zone null
+);
+
+-- +migrate Down
+drop table gateway_configuration_status;
+
+alter table gateway_profile
+    drop column reconfiguration_window_duration,
+    drop column reconfiguration_window_start;
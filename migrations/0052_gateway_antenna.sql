@@ -0,0 +1,15 @@
+-- +migrate Up
+create table gateway_antenna (
+    id smallint not null,
+    gateway_id bytea not null,
+    board_id smallint not null,
+    gain double precision not null default 0,
+    cable_loss double precision not null default 0,
+    azimuth double precision not null default 0,
+    beamwidth double precision not null default 0,
+    primary key(gateway_id, board_id, id),
+    foreign key(gateway_id, board_id) references gateway_board(gateway_id, id) on delete cascade
+);
+
+-- +migrate Down
+drop table gateway_antenna;
@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table service_profile
+    add column uplink_fingerprint_sensitivity varchar(10) not null default 'disabled';
+
+-- +migrate Down
+alter table service_profile
+    drop column uplink_fingerprint_sensitivity;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table routing_profile
+    add column downlink_hook_url text not null default '',
+    add column downlink_hook_secret text not null default '';
+
+-- +migrate Down
+alter table routing_profile
+    drop column downlink_hook_secret,
+    drop column downlink_hook_url;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table accounting_record
+    add column attestation_key_id text not null default '',
+    add column attestation_signature bytea;
+
+-- +migrate Down
+alter table accounting_record
+    drop column attestation_signature,
+    drop column attestation_key_id;
@@ -0,0 +1,16 @@
+-- +migrate Up
+create table gateway_inventory (
+    gateway_id bytea primary key references gateway on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    model varchar(100) not null,
+    firmware_version varchar(100) not null,
+    bridge_version varchar(100) not null,
+    concentrator_version varchar(100) not null
+);
+
+create index idx_gateway_inventory_model on gateway_inventory(model);
+
+-- +migrate Down
+drop index idx_gateway_inventory_model;
+drop table gateway_inventory;
@@ -0,0 +1,12 @@
+-- +migrate Up
+create table gateway_connection_state (
+    gateway_id bytea primary key references gateway on delete cascade,
+    updated_at timestamp with time zone not null,
+    online boolean not null
+);
+
+create index idx_gateway_connection_state_online on gateway_connection_state(online);
+
+-- +migrate Down
+drop index idx_gateway_connection_state_online;
+drop table gateway_connection_state;
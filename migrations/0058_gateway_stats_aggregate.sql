@@ -0,0 +1,41 @@
+-- +migrate Up
+create table gateway_stats_aggregate (
+    gateway_id bytea not null references gateway on delete cascade,
+    "interval" bigint not null,
+    "timestamp" timestamp with time zone not null,
+    rx_packets_received bigint not null,
+    rx_packets_received_ok bigint not null,
+    tx_packets_received bigint not null,
+    tx_packets_emitted bigint not null,
+    rx_packets_per_frequency jsonb not null,
+    tx_packets_per_frequency jsonb not null,
+    tx_packets_per_status jsonb not null,
+
+    primary key (gateway_id, "interval", "timestamp")
+);
+
+create index idx_gateway_stats_aggregate_interval_timestamp on gateway_stats_aggregate("interval", "timestamp");
+
+-- +migrate StatementBegin
+create function gateway_stats_sum_counts(a jsonb, b jsonb) returns jsonb as $$
+    select
+        coalesce(jsonb_object_agg(key, total), '{}'::jsonb)
+    from (
+        select
+            key,
+            sum(value::bigint) as total
+        from (
+            select * from jsonb_each_text(a)
+            union all
+            select * from jsonb_each_text(b)
+        ) c
+        group by
+            key
+    ) s
+$$ language sql immutable;
+-- +migrate StatementEnd
+
+-- +migrate Down
+drop function gateway_stats_sum_counts(jsonb, jsonb);
+drop index idx_gateway_stats_aggregate_interval_timestamp;
+drop table gateway_stats_aggregate;
@@ -0,0 +1,15 @@
+-- +migrate Up
+create table gateway_noise_floor (
+    gateway_id bytea not null references gateway on delete cascade,
+    frequency bigint not null,
+    updated_at timestamp with time zone not null,
+    bandwidth bigint not null,
+    noise_floor double precision not null,
+    channel_utilization double precision not null,
+    rssi_histogram jsonb not null,
+
+    primary key (gateway_id, frequency)
+);
+
+-- +migrate Down
+drop table gateway_noise_floor;